@@ -0,0 +1,36 @@
+// Package hydrav1 is the generated Go client for the Hydra gRPC API
+// (proto/hydra/v1/hydra.proto in the composable-me repository).
+//
+// Start the web backend with HYDRA_GRPC_PORT set, then:
+//
+//	conn, err := grpc.NewClient("localhost:50051",
+//		grpc.WithTransportCredentials(insecure.NewCredentials()))
+//	if err != nil {
+//		return err
+//	}
+//	defer conn.Close()
+//	client := hydrav1.NewHydraClient(conn)
+//
+//	created, err := client.CreateWorkflow(ctx, &hydrav1.CreateWorkflowRequest{
+//		JobDescription: jd,
+//		Resume:         resume,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	events, err := client.StreamEvents(ctx, &hydrav1.StreamEventsRequest{
+//		WorkflowId: created.GetWorkflowId(),
+//	})
+//	...
+//
+// A workflow pauses twice for a person: at STATE_GAP_ANALYSIS_REVIEW until
+// SubmitGreenlight, and at STATE_INTERROGATION_REVIEW until SubmitInterviewAnswers.
+// PauseWorkflow, ResumeWorkflow and CancelWorkflow steer a run at any other time.
+//
+// A server with API keys (HYDRA_API_KEYS) wants the caller's token on every call:
+//
+//	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+//
+// Regenerate hydra.pb.go and hydra_grpc.pb.go with proto/generate.sh; doc.go is
+// the only hand-written file here.
+package hydrav1
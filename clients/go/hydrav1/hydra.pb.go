@@ -0,0 +1,1268 @@
+// Hydra gRPC API: the job-application workflow for services that embed Hydra.
+//
+// The same jobs as the REST API (/api/v1/jobs): a workflow created over gRPC can be
+// watched in the web UI and vice versa. Served by the web backend when
+// HYDRA_GRPC_PORT is set. Regenerate the stubs with proto/generate.sh.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: hydra/v1/hydra.proto
+
+package hydrav1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type State int32
+
+const (
+	State_STATE_UNSPECIFIED          State = 0
+	State_STATE_INITIALIZED          State = 1
+	State_STATE_GAP_ANALYSIS         State = 2
+	State_STATE_GAP_ANALYSIS_REVIEW  State = 3 // paused for the greenlight
+	State_STATE_INTERROGATION        State = 4
+	State_STATE_INTERROGATION_REVIEW State = 5 // paused for interview answers
+	State_STATE_DIFFERENTIATION      State = 6
+	State_STATE_TAILORING            State = 7
+	State_STATE_ATS_OPTIMIZATION     State = 8
+	State_STATE_AUDITING             State = 9
+	State_STATE_EXECUTIVE_SYNTHESIS  State = 10
+	State_STATE_COMPLETED            State = 11
+	State_STATE_FAILED               State = 12
+	State_STATE_INTERRUPTED          State = 13 // stopped by a server drain; resumed when the server restarts
+	State_STATE_PAUSED               State = 14 // paused by PauseWorkflow; resumed only by ResumeWorkflow
+	State_STATE_CANCELLED            State = 15 // stopped by CancelWorkflow
+)
+
+// Enum value maps for State.
+var (
+	State_name = map[int32]string{
+		0:  "STATE_UNSPECIFIED",
+		1:  "STATE_INITIALIZED",
+		2:  "STATE_GAP_ANALYSIS",
+		3:  "STATE_GAP_ANALYSIS_REVIEW",
+		4:  "STATE_INTERROGATION",
+		5:  "STATE_INTERROGATION_REVIEW",
+		6:  "STATE_DIFFERENTIATION",
+		7:  "STATE_TAILORING",
+		8:  "STATE_ATS_OPTIMIZATION",
+		9:  "STATE_AUDITING",
+		10: "STATE_EXECUTIVE_SYNTHESIS",
+		11: "STATE_COMPLETED",
+		12: "STATE_FAILED",
+		13: "STATE_INTERRUPTED",
+		14: "STATE_PAUSED",
+		15: "STATE_CANCELLED",
+	}
+	State_value = map[string]int32{
+		"STATE_UNSPECIFIED":          0,
+		"STATE_INITIALIZED":          1,
+		"STATE_GAP_ANALYSIS":         2,
+		"STATE_GAP_ANALYSIS_REVIEW":  3,
+		"STATE_INTERROGATION":        4,
+		"STATE_INTERROGATION_REVIEW": 5,
+		"STATE_DIFFERENTIATION":      6,
+		"STATE_TAILORING":            7,
+		"STATE_ATS_OPTIMIZATION":     8,
+		"STATE_AUDITING":             9,
+		"STATE_EXECUTIVE_SYNTHESIS":  10,
+		"STATE_COMPLETED":            11,
+		"STATE_FAILED":               12,
+		"STATE_INTERRUPTED":          13,
+		"STATE_PAUSED":               14,
+		"STATE_CANCELLED":            15,
+	}
+)
+
+func (x State) Enum() *State {
+	p := new(State)
+	*p = x
+	return p
+}
+
+func (x State) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (State) Descriptor() protoreflect.EnumDescriptor {
+	return file_hydra_v1_hydra_proto_enumTypes[0].Descriptor()
+}
+
+func (State) Type() protoreflect.EnumType {
+	return &file_hydra_v1_hydra_proto_enumTypes[0]
+}
+
+func (x State) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use State.Descriptor instead.
+func (State) EnumDescriptor() ([]byte, []int) {
+	return file_hydra_v1_hydra_proto_rawDescGZIP(), []int{0}
+}
+
+// Human input a paused workflow is waiting for.
+type AwaitingInput int32
+
+const (
+	AwaitingInput_AWAITING_INPUT_UNSPECIFIED       AwaitingInput = 0 // not paused
+	AwaitingInput_AWAITING_INPUT_GREENLIGHT        AwaitingInput = 1
+	AwaitingInput_AWAITING_INPUT_INTERVIEW_ANSWERS AwaitingInput = 2
+)
+
+// Enum value maps for AwaitingInput.
+var (
+	AwaitingInput_name = map[int32]string{
+		0: "AWAITING_INPUT_UNSPECIFIED",
+		1: "AWAITING_INPUT_GREENLIGHT",
+		2: "AWAITING_INPUT_INTERVIEW_ANSWERS",
+	}
+	AwaitingInput_value = map[string]int32{
+		"AWAITING_INPUT_UNSPECIFIED":       0,
+		"AWAITING_INPUT_GREENLIGHT":        1,
+		"AWAITING_INPUT_INTERVIEW_ANSWERS": 2,
+	}
+)
+
+func (x AwaitingInput) Enum() *AwaitingInput {
+	p := new(AwaitingInput)
+	*p = x
+	return p
+}
+
+func (x AwaitingInput) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AwaitingInput) Descriptor() protoreflect.EnumDescriptor {
+	return file_hydra_v1_hydra_proto_enumTypes[1].Descriptor()
+}
+
+func (AwaitingInput) Type() protoreflect.EnumType {
+	return &file_hydra_v1_hydra_proto_enumTypes[1]
+}
+
+func (x AwaitingInput) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AwaitingInput.Descriptor instead.
+func (AwaitingInput) EnumDescriptor() ([]byte, []int) {
+	return file_hydra_v1_hydra_proto_rawDescGZIP(), []int{1}
+}
+
+type CreateWorkflowRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	JobDescription  string                 `protobuf:"bytes,1,opt,name=job_description,json=jobDescription,proto3" json:"job_description,omitempty"` // at least 10 characters
+	Resume          string                 `protobuf:"bytes,2,opt,name=resume,proto3" json:"resume,omitempty"`                                       // at least 10 characters
+	SourceDocuments string                 `protobuf:"bytes,3,opt,name=source_documents,json=sourceDocuments,proto3" json:"source_documents,omitempty"`
+	Company         string                 `protobuf:"bytes,4,opt,name=company,proto3" json:"company,omitempty"`
+	RoleTitle       string                 `protobuf:"bytes,5,opt,name=role_title,json=roleTitle,proto3" json:"role_title,omitempty"`
+	Source          string                 `protobuf:"bytes,6,opt,name=source,proto3" json:"source,omitempty"`
+	Url             string                 `protobuf:"bytes,7,opt,name=url,proto3" json:"url,omitempty"`
+	Model           string                 `protobuf:"bytes,8,opt,name=model,proto3" json:"model,omitempty"`                                                     // LLM model override
+	MaxAuditRetries *int32                 `protobuf:"varint,9,opt,name=max_audit_retries,json=maxAuditRetries,proto3,oneof" json:"max_audit_retries,omitempty"` // 0-5, default 2
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateWorkflowRequest) Reset() {
+	*x = CreateWorkflowRequest{}
+	mi := &file_hydra_v1_hydra_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWorkflowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWorkflowRequest) ProtoMessage() {}
+
+func (x *CreateWorkflowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydra_v1_hydra_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWorkflowRequest.ProtoReflect.Descriptor instead.
+func (*CreateWorkflowRequest) Descriptor() ([]byte, []int) {
+	return file_hydra_v1_hydra_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateWorkflowRequest) GetJobDescription() string {
+	if x != nil {
+		return x.JobDescription
+	}
+	return ""
+}
+
+func (x *CreateWorkflowRequest) GetResume() string {
+	if x != nil {
+		return x.Resume
+	}
+	return ""
+}
+
+func (x *CreateWorkflowRequest) GetSourceDocuments() string {
+	if x != nil {
+		return x.SourceDocuments
+	}
+	return ""
+}
+
+func (x *CreateWorkflowRequest) GetCompany() string {
+	if x != nil {
+		return x.Company
+	}
+	return ""
+}
+
+func (x *CreateWorkflowRequest) GetRoleTitle() string {
+	if x != nil {
+		return x.RoleTitle
+	}
+	return ""
+}
+
+func (x *CreateWorkflowRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *CreateWorkflowRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CreateWorkflowRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *CreateWorkflowRequest) GetMaxAuditRetries() int32 {
+	if x != nil && x.MaxAuditRetries != nil {
+		return *x.MaxAuditRetries
+	}
+	return 0
+}
+
+type CreateWorkflowResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkflowId    string                 `protobuf:"bytes,1,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`                        // "queued"
+	CreatedAt     string                 `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // RFC 3339
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWorkflowResponse) Reset() {
+	*x = CreateWorkflowResponse{}
+	mi := &file_hydra_v1_hydra_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWorkflowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWorkflowResponse) ProtoMessage() {}
+
+func (x *CreateWorkflowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydra_v1_hydra_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWorkflowResponse.ProtoReflect.Descriptor instead.
+func (*CreateWorkflowResponse) Descriptor() ([]byte, []int) {
+	return file_hydra_v1_hydra_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateWorkflowResponse) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+func (x *CreateWorkflowResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CreateWorkflowResponse) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type GetStateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkflowId    string                 `protobuf:"bytes,1,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStateRequest) Reset() {
+	*x = GetStateRequest{}
+	mi := &file_hydra_v1_hydra_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStateRequest) ProtoMessage() {}
+
+func (x *GetStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydra_v1_hydra_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStateRequest.ProtoReflect.Descriptor instead.
+func (*GetStateRequest) Descriptor() ([]byte, []int) {
+	return file_hydra_v1_hydra_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetStateRequest) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+type Documents struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Resume        string                 `protobuf:"bytes,1,opt,name=resume,proto3" json:"resume,omitempty"`
+	CoverLetter   string                 `protobuf:"bytes,2,opt,name=cover_letter,json=coverLetter,proto3" json:"cover_letter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Documents) Reset() {
+	*x = Documents{}
+	mi := &file_hydra_v1_hydra_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Documents) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Documents) ProtoMessage() {}
+
+func (x *Documents) ProtoReflect() protoreflect.Message {
+	mi := &file_hydra_v1_hydra_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Documents.ProtoReflect.Descriptor instead.
+func (*Documents) Descriptor() ([]byte, []int) {
+	return file_hydra_v1_hydra_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Documents) GetResume() string {
+	if x != nil {
+		return x.Resume
+	}
+	return ""
+}
+
+func (x *Documents) GetCoverLetter() string {
+	if x != nil {
+		return x.CoverLetter
+	}
+	return ""
+}
+
+type Workflow struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	WorkflowId      string                 `protobuf:"bytes,1,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	State           State                  `protobuf:"varint,2,opt,name=state,proto3,enum=hydra.v1.State" json:"state,omitempty"`
+	Success         bool                   `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
+	ProgressPercent int32                  `protobuf:"varint,4,opt,name=progress_percent,json=progressPercent,proto3" json:"progress_percent,omitempty"`
+	AwaitingInput   AwaitingInput          `protobuf:"varint,5,opt,name=awaiting_input,json=awaitingInput,proto3,enum=hydra.v1.AwaitingInput" json:"awaiting_input,omitempty"`
+	CreatedAt       string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // RFC 3339; started_at/completed_at are empty until then
+	StartedAt       string                 `protobuf:"bytes,7,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	CompletedAt     string                 `protobuf:"bytes,8,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	FinalDocuments  *Documents             `protobuf:"bytes,9,opt,name=final_documents,json=finalDocuments,proto3" json:"final_documents,omitempty"`
+	AuditStatus     string                 `protobuf:"bytes,10,opt,name=audit_status,json=auditStatus,proto3" json:"audit_status,omitempty"` // APPROVED, REJECTED or AUDIT_ERROR once audited
+	AuditFailed     bool                   `protobuf:"varint,11,opt,name=audit_failed,json=auditFailed,proto3" json:"audit_failed,omitempty"`
+	AuditError      string                 `protobuf:"bytes,12,opt,name=audit_error,json=auditError,proto3" json:"audit_error,omitempty"`
+	ErrorMessage    string                 `protobuf:"bytes,13,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	AgentModels     map[string]string      `protobuf:"bytes,14,rep,name=agent_models,json=agentModels,proto3" json:"agent_models,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Stage outputs (the gap analysis, interview questions, ...) and the executive
+	// brief, as JSON objects: their shape is model output and varies by stage.
+	IntermediateResultsJson string `protobuf:"bytes,15,opt,name=intermediate_results_json,json=intermediateResultsJson,proto3" json:"intermediate_results_json,omitempty"`
+	ExecutiveBriefJson      string `protobuf:"bytes,16,opt,name=executive_brief_json,json=executiveBriefJson,proto3" json:"executive_brief_json,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *Workflow) Reset() {
+	*x = Workflow{}
+	mi := &file_hydra_v1_hydra_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Workflow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Workflow) ProtoMessage() {}
+
+func (x *Workflow) ProtoReflect() protoreflect.Message {
+	mi := &file_hydra_v1_hydra_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Workflow.ProtoReflect.Descriptor instead.
+func (*Workflow) Descriptor() ([]byte, []int) {
+	return file_hydra_v1_hydra_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Workflow) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+func (x *Workflow) GetState() State {
+	if x != nil {
+		return x.State
+	}
+	return State_STATE_UNSPECIFIED
+}
+
+func (x *Workflow) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *Workflow) GetProgressPercent() int32 {
+	if x != nil {
+		return x.ProgressPercent
+	}
+	return 0
+}
+
+func (x *Workflow) GetAwaitingInput() AwaitingInput {
+	if x != nil {
+		return x.AwaitingInput
+	}
+	return AwaitingInput_AWAITING_INPUT_UNSPECIFIED
+}
+
+func (x *Workflow) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Workflow) GetStartedAt() string {
+	if x != nil {
+		return x.StartedAt
+	}
+	return ""
+}
+
+func (x *Workflow) GetCompletedAt() string {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return ""
+}
+
+func (x *Workflow) GetFinalDocuments() *Documents {
+	if x != nil {
+		return x.FinalDocuments
+	}
+	return nil
+}
+
+func (x *Workflow) GetAuditStatus() string {
+	if x != nil {
+		return x.AuditStatus
+	}
+	return ""
+}
+
+func (x *Workflow) GetAuditFailed() bool {
+	if x != nil {
+		return x.AuditFailed
+	}
+	return false
+}
+
+func (x *Workflow) GetAuditError() string {
+	if x != nil {
+		return x.AuditError
+	}
+	return ""
+}
+
+func (x *Workflow) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *Workflow) GetAgentModels() map[string]string {
+	if x != nil {
+		return x.AgentModels
+	}
+	return nil
+}
+
+func (x *Workflow) GetIntermediateResultsJson() string {
+	if x != nil {
+		return x.IntermediateResultsJson
+	}
+	return ""
+}
+
+func (x *Workflow) GetExecutiveBriefJson() string {
+	if x != nil {
+		return x.ExecutiveBriefJson
+	}
+	return ""
+}
+
+type SubmitGreenlightRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkflowId    string                 `protobuf:"bytes,1,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	Approve       bool                   `protobuf:"varint,2,opt,name=approve,proto3" json:"approve,omitempty"`
+	Notes         string                 `protobuf:"bytes,3,opt,name=notes,proto3" json:"notes,omitempty"` // guidance passed to later stages
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitGreenlightRequest) Reset() {
+	*x = SubmitGreenlightRequest{}
+	mi := &file_hydra_v1_hydra_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitGreenlightRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitGreenlightRequest) ProtoMessage() {}
+
+func (x *SubmitGreenlightRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydra_v1_hydra_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitGreenlightRequest.ProtoReflect.Descriptor instead.
+func (*SubmitGreenlightRequest) Descriptor() ([]byte, []int) {
+	return file_hydra_v1_hydra_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SubmitGreenlightRequest) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+func (x *SubmitGreenlightRequest) GetApprove() bool {
+	if x != nil {
+		return x.Approve
+	}
+	return false
+}
+
+func (x *SubmitGreenlightRequest) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+type InterviewAnswer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	QuestionId    string                 `protobuf:"bytes,1,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"`
+	Question      string                 `protobuf:"bytes,2,opt,name=question,proto3" json:"question,omitempty"`
+	Answer        string                 `protobuf:"bytes,3,opt,name=answer,proto3" json:"answer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InterviewAnswer) Reset() {
+	*x = InterviewAnswer{}
+	mi := &file_hydra_v1_hydra_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InterviewAnswer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InterviewAnswer) ProtoMessage() {}
+
+func (x *InterviewAnswer) ProtoReflect() protoreflect.Message {
+	mi := &file_hydra_v1_hydra_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InterviewAnswer.ProtoReflect.Descriptor instead.
+func (*InterviewAnswer) Descriptor() ([]byte, []int) {
+	return file_hydra_v1_hydra_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *InterviewAnswer) GetQuestionId() string {
+	if x != nil {
+		return x.QuestionId
+	}
+	return ""
+}
+
+func (x *InterviewAnswer) GetQuestion() string {
+	if x != nil {
+		return x.Question
+	}
+	return ""
+}
+
+func (x *InterviewAnswer) GetAnswer() string {
+	if x != nil {
+		return x.Answer
+	}
+	return ""
+}
+
+type SubmitInterviewAnswersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkflowId    string                 `protobuf:"bytes,1,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	Answers       []*InterviewAnswer     `protobuf:"bytes,2,rep,name=answers,proto3" json:"answers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitInterviewAnswersRequest) Reset() {
+	*x = SubmitInterviewAnswersRequest{}
+	mi := &file_hydra_v1_hydra_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitInterviewAnswersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitInterviewAnswersRequest) ProtoMessage() {}
+
+func (x *SubmitInterviewAnswersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydra_v1_hydra_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitInterviewAnswersRequest.ProtoReflect.Descriptor instead.
+func (*SubmitInterviewAnswersRequest) Descriptor() ([]byte, []int) {
+	return file_hydra_v1_hydra_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SubmitInterviewAnswersRequest) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+func (x *SubmitInterviewAnswersRequest) GetAnswers() []*InterviewAnswer {
+	if x != nil {
+		return x.Answers
+	}
+	return nil
+}
+
+type PauseWorkflowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkflowId    string                 `protobuf:"bytes,1,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseWorkflowRequest) Reset() {
+	*x = PauseWorkflowRequest{}
+	mi := &file_hydra_v1_hydra_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseWorkflowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseWorkflowRequest) ProtoMessage() {}
+
+func (x *PauseWorkflowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydra_v1_hydra_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseWorkflowRequest.ProtoReflect.Descriptor instead.
+func (*PauseWorkflowRequest) Descriptor() ([]byte, []int) {
+	return file_hydra_v1_hydra_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PauseWorkflowRequest) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+type ResumeWorkflowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkflowId    string                 `protobuf:"bytes,1,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeWorkflowRequest) Reset() {
+	*x = ResumeWorkflowRequest{}
+	mi := &file_hydra_v1_hydra_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeWorkflowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeWorkflowRequest) ProtoMessage() {}
+
+func (x *ResumeWorkflowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydra_v1_hydra_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeWorkflowRequest.ProtoReflect.Descriptor instead.
+func (*ResumeWorkflowRequest) Descriptor() ([]byte, []int) {
+	return file_hydra_v1_hydra_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ResumeWorkflowRequest) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+type CancelWorkflowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkflowId    string                 `protobuf:"bytes,1,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelWorkflowRequest) Reset() {
+	*x = CancelWorkflowRequest{}
+	mi := &file_hydra_v1_hydra_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelWorkflowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelWorkflowRequest) ProtoMessage() {}
+
+func (x *CancelWorkflowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydra_v1_hydra_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelWorkflowRequest.ProtoReflect.Descriptor instead.
+func (*CancelWorkflowRequest) Descriptor() ([]byte, []int) {
+	return file_hydra_v1_hydra_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CancelWorkflowRequest) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+type SubmitResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	WorkflowId string                 `protobuf:"bytes,1,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	// "approved", "declined" or "submitted"; "noop" if the workflow had already moved on.
+	// For pause/resume/cancel: "pausing" or "cancelling" while a live run winds down,
+	// else "paused", "resumed" or "cancelled".
+	Status        string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Message       string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitResponse) Reset() {
+	*x = SubmitResponse{}
+	mi := &file_hydra_v1_hydra_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitResponse) ProtoMessage() {}
+
+func (x *SubmitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydra_v1_hydra_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitResponse.ProtoReflect.Descriptor instead.
+func (*SubmitResponse) Descriptor() ([]byte, []int) {
+	return file_hydra_v1_hydra_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SubmitResponse) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+func (x *SubmitResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SubmitResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type StreamEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkflowId    string                 `protobuf:"bytes,1,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamEventsRequest) Reset() {
+	*x = StreamEventsRequest{}
+	mi := &file_hydra_v1_hydra_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamEventsRequest) ProtoMessage() {}
+
+func (x *StreamEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydra_v1_hydra_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamEventsRequest.ProtoReflect.Descriptor instead.
+func (*StreamEventsRequest) Descriptor() ([]byte, []int) {
+	return file_hydra_v1_hydra_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *StreamEventsRequest) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+type Event struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// connected, progress, log, stage_complete, complete or error (as the REST SSE stream)
+	Type          string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	DataJson      string `protobuf:"bytes,2,opt,name=data_json,json=dataJson,proto3" json:"data_json,omitempty"` // the event payload as a JSON object
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_hydra_v1_hydra_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_hydra_v1_hydra_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_hydra_v1_hydra_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Event) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Event) GetDataJson() string {
+	if x != nil {
+		return x.DataJson
+	}
+	return ""
+}
+
+var File_hydra_v1_hydra_proto protoreflect.FileDescriptor
+
+const file_hydra_v1_hydra_proto_rawDesc = "" +
+	"\n" +
+	"\x14hydra/v1/hydra.proto\x12\bhydra.v1\"\xc3\x02\n" +
+	"\x15CreateWorkflowRequest\x12'\n" +
+	"\x0fjob_description\x18\x01 \x01(\tR\x0ejobDescription\x12\x16\n" +
+	"\x06resume\x18\x02 \x01(\tR\x06resume\x12)\n" +
+	"\x10source_documents\x18\x03 \x01(\tR\x0fsourceDocuments\x12\x18\n" +
+	"\acompany\x18\x04 \x01(\tR\acompany\x12\x1d\n" +
+	"\n" +
+	"role_title\x18\x05 \x01(\tR\troleTitle\x12\x16\n" +
+	"\x06source\x18\x06 \x01(\tR\x06source\x12\x10\n" +
+	"\x03url\x18\a \x01(\tR\x03url\x12\x14\n" +
+	"\x05model\x18\b \x01(\tR\x05model\x12/\n" +
+	"\x11max_audit_retries\x18\t \x01(\x05H\x00R\x0fmaxAuditRetries\x88\x01\x01B\x14\n" +
+	"\x12_max_audit_retries\"p\n" +
+	"\x16CreateWorkflowResponse\x12\x1f\n" +
+	"\vworkflow_id\x18\x01 \x01(\tR\n" +
+	"workflowId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\tR\tcreatedAt\"2\n" +
+	"\x0fGetStateRequest\x12\x1f\n" +
+	"\vworkflow_id\x18\x01 \x01(\tR\n" +
+	"workflowId\"F\n" +
+	"\tDocuments\x12\x16\n" +
+	"\x06resume\x18\x01 \x01(\tR\x06resume\x12!\n" +
+	"\fcover_letter\x18\x02 \x01(\tR\vcoverLetter\"\xf8\x05\n" +
+	"\bWorkflow\x12\x1f\n" +
+	"\vworkflow_id\x18\x01 \x01(\tR\n" +
+	"workflowId\x12%\n" +
+	"\x05state\x18\x02 \x01(\x0e2\x0f.hydra.v1.StateR\x05state\x12\x18\n" +
+	"\asuccess\x18\x03 \x01(\bR\asuccess\x12)\n" +
+	"\x10progress_percent\x18\x04 \x01(\x05R\x0fprogressPercent\x12>\n" +
+	"\x0eawaiting_input\x18\x05 \x01(\x0e2\x17.hydra.v1.AwaitingInputR\rawaitingInput\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\a \x01(\tR\tstartedAt\x12!\n" +
+	"\fcompleted_at\x18\b \x01(\tR\vcompletedAt\x12<\n" +
+	"\x0ffinal_documents\x18\t \x01(\v2\x13.hydra.v1.DocumentsR\x0efinalDocuments\x12!\n" +
+	"\faudit_status\x18\n" +
+	" \x01(\tR\vauditStatus\x12!\n" +
+	"\faudit_failed\x18\v \x01(\bR\vauditFailed\x12\x1f\n" +
+	"\vaudit_error\x18\f \x01(\tR\n" +
+	"auditError\x12#\n" +
+	"\rerror_message\x18\r \x01(\tR\ferrorMessage\x12F\n" +
+	"\fagent_models\x18\x0e \x03(\v2#.hydra.v1.Workflow.AgentModelsEntryR\vagentModels\x12:\n" +
+	"\x19intermediate_results_json\x18\x0f \x01(\tR\x17intermediateResultsJson\x120\n" +
+	"\x14executive_brief_json\x18\x10 \x01(\tR\x12executiveBriefJson\x1a>\n" +
+	"\x10AgentModelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"j\n" +
+	"\x17SubmitGreenlightRequest\x12\x1f\n" +
+	"\vworkflow_id\x18\x01 \x01(\tR\n" +
+	"workflowId\x12\x18\n" +
+	"\aapprove\x18\x02 \x01(\bR\aapprove\x12\x14\n" +
+	"\x05notes\x18\x03 \x01(\tR\x05notes\"f\n" +
+	"\x0fInterviewAnswer\x12\x1f\n" +
+	"\vquestion_id\x18\x01 \x01(\tR\n" +
+	"questionId\x12\x1a\n" +
+	"\bquestion\x18\x02 \x01(\tR\bquestion\x12\x16\n" +
+	"\x06answer\x18\x03 \x01(\tR\x06answer\"u\n" +
+	"\x1dSubmitInterviewAnswersRequest\x12\x1f\n" +
+	"\vworkflow_id\x18\x01 \x01(\tR\n" +
+	"workflowId\x123\n" +
+	"\aanswers\x18\x02 \x03(\v2\x19.hydra.v1.InterviewAnswerR\aanswers\"7\n" +
+	"\x14PauseWorkflowRequest\x12\x1f\n" +
+	"\vworkflow_id\x18\x01 \x01(\tR\n" +
+	"workflowId\"8\n" +
+	"\x15ResumeWorkflowRequest\x12\x1f\n" +
+	"\vworkflow_id\x18\x01 \x01(\tR\n" +
+	"workflowId\"8\n" +
+	"\x15CancelWorkflowRequest\x12\x1f\n" +
+	"\vworkflow_id\x18\x01 \x01(\tR\n" +
+	"workflowId\"c\n" +
+	"\x0eSubmitResponse\x12\x1f\n" +
+	"\vworkflow_id\x18\x01 \x01(\tR\n" +
+	"workflowId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"6\n" +
+	"\x13StreamEventsRequest\x12\x1f\n" +
+	"\vworkflow_id\x18\x01 \x01(\tR\n" +
+	"workflowId\"8\n" +
+	"\x05Event\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x1b\n" +
+	"\tdata_json\x18\x02 \x01(\tR\bdataJson*\x89\x03\n" +
+	"\x05State\x12\x15\n" +
+	"\x11STATE_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11STATE_INITIALIZED\x10\x01\x12\x16\n" +
+	"\x12STATE_GAP_ANALYSIS\x10\x02\x12\x1d\n" +
+	"\x19STATE_GAP_ANALYSIS_REVIEW\x10\x03\x12\x17\n" +
+	"\x13STATE_INTERROGATION\x10\x04\x12\x1e\n" +
+	"\x1aSTATE_INTERROGATION_REVIEW\x10\x05\x12\x19\n" +
+	"\x15STATE_DIFFERENTIATION\x10\x06\x12\x13\n" +
+	"\x0fSTATE_TAILORING\x10\a\x12\x1a\n" +
+	"\x16STATE_ATS_OPTIMIZATION\x10\b\x12\x12\n" +
+	"\x0eSTATE_AUDITING\x10\t\x12\x1d\n" +
+	"\x19STATE_EXECUTIVE_SYNTHESIS\x10\n" +
+	"\x12\x13\n" +
+	"\x0fSTATE_COMPLETED\x10\v\x12\x10\n" +
+	"\fSTATE_FAILED\x10\f\x12\x15\n" +
+	"\x11STATE_INTERRUPTED\x10\r\x12\x10\n" +
+	"\fSTATE_PAUSED\x10\x0e\x12\x13\n" +
+	"\x0fSTATE_CANCELLED\x10\x0f*t\n" +
+	"\rAwaitingInput\x12\x1e\n" +
+	"\x1aAWAITING_INPUT_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19AWAITING_INPUT_GREENLIGHT\x10\x01\x12$\n" +
+	" AWAITING_INPUT_INTERVIEW_ANSWERS\x10\x022\xec\x04\n" +
+	"\x05Hydra\x12S\n" +
+	"\x0eCreateWorkflow\x12\x1f.hydra.v1.CreateWorkflowRequest\x1a .hydra.v1.CreateWorkflowResponse\x129\n" +
+	"\bGetState\x12\x19.hydra.v1.GetStateRequest\x1a\x12.hydra.v1.Workflow\x12O\n" +
+	"\x10SubmitGreenlight\x12!.hydra.v1.SubmitGreenlightRequest\x1a\x18.hydra.v1.SubmitResponse\x12[\n" +
+	"\x16SubmitInterviewAnswers\x12'.hydra.v1.SubmitInterviewAnswersRequest\x1a\x18.hydra.v1.SubmitResponse\x12I\n" +
+	"\rPauseWorkflow\x12\x1e.hydra.v1.PauseWorkflowRequest\x1a\x18.hydra.v1.SubmitResponse\x12K\n" +
+	"\x0eResumeWorkflow\x12\x1f.hydra.v1.ResumeWorkflowRequest\x1a\x18.hydra.v1.SubmitResponse\x12K\n" +
+	"\x0eCancelWorkflow\x12\x1f.hydra.v1.CancelWorkflowRequest\x1a\x18.hydra.v1.SubmitResponse\x12@\n" +
+	"\fStreamEvents\x12\x1d.hydra.v1.StreamEventsRequest\x1a\x0f.hydra.v1.Event0\x01B<Z:github.com/ask-23/composable-me/clients/go/hydrav1;hydrav1b\x06proto3"
+
+var (
+	file_hydra_v1_hydra_proto_rawDescOnce sync.Once
+	file_hydra_v1_hydra_proto_rawDescData []byte
+)
+
+func file_hydra_v1_hydra_proto_rawDescGZIP() []byte {
+	file_hydra_v1_hydra_proto_rawDescOnce.Do(func() {
+		file_hydra_v1_hydra_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_hydra_v1_hydra_proto_rawDesc), len(file_hydra_v1_hydra_proto_rawDesc)))
+	})
+	return file_hydra_v1_hydra_proto_rawDescData
+}
+
+var file_hydra_v1_hydra_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_hydra_v1_hydra_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_hydra_v1_hydra_proto_goTypes = []any{
+	(State)(0),                            // 0: hydra.v1.State
+	(AwaitingInput)(0),                    // 1: hydra.v1.AwaitingInput
+	(*CreateWorkflowRequest)(nil),         // 2: hydra.v1.CreateWorkflowRequest
+	(*CreateWorkflowResponse)(nil),        // 3: hydra.v1.CreateWorkflowResponse
+	(*GetStateRequest)(nil),               // 4: hydra.v1.GetStateRequest
+	(*Documents)(nil),                     // 5: hydra.v1.Documents
+	(*Workflow)(nil),                      // 6: hydra.v1.Workflow
+	(*SubmitGreenlightRequest)(nil),       // 7: hydra.v1.SubmitGreenlightRequest
+	(*InterviewAnswer)(nil),               // 8: hydra.v1.InterviewAnswer
+	(*SubmitInterviewAnswersRequest)(nil), // 9: hydra.v1.SubmitInterviewAnswersRequest
+	(*PauseWorkflowRequest)(nil),          // 10: hydra.v1.PauseWorkflowRequest
+	(*ResumeWorkflowRequest)(nil),         // 11: hydra.v1.ResumeWorkflowRequest
+	(*CancelWorkflowRequest)(nil),         // 12: hydra.v1.CancelWorkflowRequest
+	(*SubmitResponse)(nil),                // 13: hydra.v1.SubmitResponse
+	(*StreamEventsRequest)(nil),           // 14: hydra.v1.StreamEventsRequest
+	(*Event)(nil),                         // 15: hydra.v1.Event
+	nil,                                   // 16: hydra.v1.Workflow.AgentModelsEntry
+}
+var file_hydra_v1_hydra_proto_depIdxs = []int32{
+	0,  // 0: hydra.v1.Workflow.state:type_name -> hydra.v1.State
+	1,  // 1: hydra.v1.Workflow.awaiting_input:type_name -> hydra.v1.AwaitingInput
+	5,  // 2: hydra.v1.Workflow.final_documents:type_name -> hydra.v1.Documents
+	16, // 3: hydra.v1.Workflow.agent_models:type_name -> hydra.v1.Workflow.AgentModelsEntry
+	8,  // 4: hydra.v1.SubmitInterviewAnswersRequest.answers:type_name -> hydra.v1.InterviewAnswer
+	2,  // 5: hydra.v1.Hydra.CreateWorkflow:input_type -> hydra.v1.CreateWorkflowRequest
+	4,  // 6: hydra.v1.Hydra.GetState:input_type -> hydra.v1.GetStateRequest
+	7,  // 7: hydra.v1.Hydra.SubmitGreenlight:input_type -> hydra.v1.SubmitGreenlightRequest
+	9,  // 8: hydra.v1.Hydra.SubmitInterviewAnswers:input_type -> hydra.v1.SubmitInterviewAnswersRequest
+	10, // 9: hydra.v1.Hydra.PauseWorkflow:input_type -> hydra.v1.PauseWorkflowRequest
+	11, // 10: hydra.v1.Hydra.ResumeWorkflow:input_type -> hydra.v1.ResumeWorkflowRequest
+	12, // 11: hydra.v1.Hydra.CancelWorkflow:input_type -> hydra.v1.CancelWorkflowRequest
+	14, // 12: hydra.v1.Hydra.StreamEvents:input_type -> hydra.v1.StreamEventsRequest
+	3,  // 13: hydra.v1.Hydra.CreateWorkflow:output_type -> hydra.v1.CreateWorkflowResponse
+	6,  // 14: hydra.v1.Hydra.GetState:output_type -> hydra.v1.Workflow
+	13, // 15: hydra.v1.Hydra.SubmitGreenlight:output_type -> hydra.v1.SubmitResponse
+	13, // 16: hydra.v1.Hydra.SubmitInterviewAnswers:output_type -> hydra.v1.SubmitResponse
+	13, // 17: hydra.v1.Hydra.PauseWorkflow:output_type -> hydra.v1.SubmitResponse
+	13, // 18: hydra.v1.Hydra.ResumeWorkflow:output_type -> hydra.v1.SubmitResponse
+	13, // 19: hydra.v1.Hydra.CancelWorkflow:output_type -> hydra.v1.SubmitResponse
+	15, // 20: hydra.v1.Hydra.StreamEvents:output_type -> hydra.v1.Event
+	13, // [13:21] is the sub-list for method output_type
+	5,  // [5:13] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_hydra_v1_hydra_proto_init() }
+func file_hydra_v1_hydra_proto_init() {
+	if File_hydra_v1_hydra_proto != nil {
+		return
+	}
+	file_hydra_v1_hydra_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_hydra_v1_hydra_proto_rawDesc), len(file_hydra_v1_hydra_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_hydra_v1_hydra_proto_goTypes,
+		DependencyIndexes: file_hydra_v1_hydra_proto_depIdxs,
+		EnumInfos:         file_hydra_v1_hydra_proto_enumTypes,
+		MessageInfos:      file_hydra_v1_hydra_proto_msgTypes,
+	}.Build()
+	File_hydra_v1_hydra_proto = out.File
+	file_hydra_v1_hydra_proto_goTypes = nil
+	file_hydra_v1_hydra_proto_depIdxs = nil
+}
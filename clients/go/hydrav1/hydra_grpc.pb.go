@@ -0,0 +1,419 @@
+// Hydra gRPC API: the job-application workflow for services that embed Hydra.
+//
+// The same jobs as the REST API (/api/v1/jobs): a workflow created over gRPC can be
+// watched in the web UI and vice versa. Served by the web backend when
+// HYDRA_GRPC_PORT is set. Regenerate the stubs with proto/generate.sh.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: hydra/v1/hydra.proto
+
+package hydrav1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Hydra_CreateWorkflow_FullMethodName         = "/hydra.v1.Hydra/CreateWorkflow"
+	Hydra_GetState_FullMethodName               = "/hydra.v1.Hydra/GetState"
+	Hydra_SubmitGreenlight_FullMethodName       = "/hydra.v1.Hydra/SubmitGreenlight"
+	Hydra_SubmitInterviewAnswers_FullMethodName = "/hydra.v1.Hydra/SubmitInterviewAnswers"
+	Hydra_PauseWorkflow_FullMethodName          = "/hydra.v1.Hydra/PauseWorkflow"
+	Hydra_ResumeWorkflow_FullMethodName         = "/hydra.v1.Hydra/ResumeWorkflow"
+	Hydra_CancelWorkflow_FullMethodName         = "/hydra.v1.Hydra/CancelWorkflow"
+	Hydra_StreamEvents_FullMethodName           = "/hydra.v1.Hydra/StreamEvents"
+)
+
+// HydraClient is the client API for Hydra service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// The Hydra workflow: create it, steer it at the human gates, and watch it run.
+type HydraClient interface {
+	// Start a workflow. Returns at once; follow it with GetState or StreamEvents.
+	CreateWorkflow(ctx context.Context, in *CreateWorkflowRequest, opts ...grpc.CallOption) (*CreateWorkflowResponse, error)
+	// Current state of a workflow, with its documents once it has finished.
+	GetState(ctx context.Context, in *GetStateRequest, opts ...grpc.CallOption) (*Workflow, error)
+	// Go/no-go on the gap analysis. Approving resumes the workflow; declining ends it.
+	SubmitGreenlight(ctx context.Context, in *SubmitGreenlightRequest, opts ...grpc.CallOption) (*SubmitResponse, error)
+	// Answers to the interview questions; resumes the workflow.
+	SubmitInterviewAnswers(ctx context.Context, in *SubmitInterviewAnswersRequest, opts ...grpc.CallOption) (*SubmitResponse, error)
+	// Pause at the next stage boundary; a workflow with no live run pauses at once.
+	PauseWorkflow(ctx context.Context, in *PauseWorkflowRequest, opts ...grpc.CallOption) (*SubmitResponse, error)
+	// Restart a paused or interrupted workflow from its last completed stage.
+	ResumeWorkflow(ctx context.Context, in *ResumeWorkflowRequest, opts ...grpc.CallOption) (*SubmitResponse, error)
+	// Stop a workflow for good, abandoning the stage under way.
+	CancelWorkflow(ctx context.Context, in *CancelWorkflowRequest, opts ...grpc.CallOption) (*SubmitResponse, error)
+	// Progress events until the workflow completes or fails. The first event is
+	// "connected" with the current state; a finished workflow sends "complete" at once.
+	StreamEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error)
+}
+
+type hydraClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHydraClient(cc grpc.ClientConnInterface) HydraClient {
+	return &hydraClient{cc}
+}
+
+func (c *hydraClient) CreateWorkflow(ctx context.Context, in *CreateWorkflowRequest, opts ...grpc.CallOption) (*CreateWorkflowResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateWorkflowResponse)
+	err := c.cc.Invoke(ctx, Hydra_CreateWorkflow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hydraClient) GetState(ctx context.Context, in *GetStateRequest, opts ...grpc.CallOption) (*Workflow, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Workflow)
+	err := c.cc.Invoke(ctx, Hydra_GetState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hydraClient) SubmitGreenlight(ctx context.Context, in *SubmitGreenlightRequest, opts ...grpc.CallOption) (*SubmitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitResponse)
+	err := c.cc.Invoke(ctx, Hydra_SubmitGreenlight_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hydraClient) SubmitInterviewAnswers(ctx context.Context, in *SubmitInterviewAnswersRequest, opts ...grpc.CallOption) (*SubmitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitResponse)
+	err := c.cc.Invoke(ctx, Hydra_SubmitInterviewAnswers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hydraClient) PauseWorkflow(ctx context.Context, in *PauseWorkflowRequest, opts ...grpc.CallOption) (*SubmitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitResponse)
+	err := c.cc.Invoke(ctx, Hydra_PauseWorkflow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hydraClient) ResumeWorkflow(ctx context.Context, in *ResumeWorkflowRequest, opts ...grpc.CallOption) (*SubmitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitResponse)
+	err := c.cc.Invoke(ctx, Hydra_ResumeWorkflow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hydraClient) CancelWorkflow(ctx context.Context, in *CancelWorkflowRequest, opts ...grpc.CallOption) (*SubmitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitResponse)
+	err := c.cc.Invoke(ctx, Hydra_CancelWorkflow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hydraClient) StreamEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Hydra_ServiceDesc.Streams[0], Hydra_StreamEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamEventsRequest, Event]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Hydra_StreamEventsClient = grpc.ServerStreamingClient[Event]
+
+// HydraServer is the server API for Hydra service.
+// All implementations must embed UnimplementedHydraServer
+// for forward compatibility.
+//
+// The Hydra workflow: create it, steer it at the human gates, and watch it run.
+type HydraServer interface {
+	// Start a workflow. Returns at once; follow it with GetState or StreamEvents.
+	CreateWorkflow(context.Context, *CreateWorkflowRequest) (*CreateWorkflowResponse, error)
+	// Current state of a workflow, with its documents once it has finished.
+	GetState(context.Context, *GetStateRequest) (*Workflow, error)
+	// Go/no-go on the gap analysis. Approving resumes the workflow; declining ends it.
+	SubmitGreenlight(context.Context, *SubmitGreenlightRequest) (*SubmitResponse, error)
+	// Answers to the interview questions; resumes the workflow.
+	SubmitInterviewAnswers(context.Context, *SubmitInterviewAnswersRequest) (*SubmitResponse, error)
+	// Pause at the next stage boundary; a workflow with no live run pauses at once.
+	PauseWorkflow(context.Context, *PauseWorkflowRequest) (*SubmitResponse, error)
+	// Restart a paused or interrupted workflow from its last completed stage.
+	ResumeWorkflow(context.Context, *ResumeWorkflowRequest) (*SubmitResponse, error)
+	// Stop a workflow for good, abandoning the stage under way.
+	CancelWorkflow(context.Context, *CancelWorkflowRequest) (*SubmitResponse, error)
+	// Progress events until the workflow completes or fails. The first event is
+	// "connected" with the current state; a finished workflow sends "complete" at once.
+	StreamEvents(*StreamEventsRequest, grpc.ServerStreamingServer[Event]) error
+	mustEmbedUnimplementedHydraServer()
+}
+
+// UnimplementedHydraServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedHydraServer struct{}
+
+func (UnimplementedHydraServer) CreateWorkflow(context.Context, *CreateWorkflowRequest) (*CreateWorkflowResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateWorkflow not implemented")
+}
+func (UnimplementedHydraServer) GetState(context.Context, *GetStateRequest) (*Workflow, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetState not implemented")
+}
+func (UnimplementedHydraServer) SubmitGreenlight(context.Context, *SubmitGreenlightRequest) (*SubmitResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SubmitGreenlight not implemented")
+}
+func (UnimplementedHydraServer) SubmitInterviewAnswers(context.Context, *SubmitInterviewAnswersRequest) (*SubmitResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SubmitInterviewAnswers not implemented")
+}
+func (UnimplementedHydraServer) PauseWorkflow(context.Context, *PauseWorkflowRequest) (*SubmitResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PauseWorkflow not implemented")
+}
+func (UnimplementedHydraServer) ResumeWorkflow(context.Context, *ResumeWorkflowRequest) (*SubmitResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ResumeWorkflow not implemented")
+}
+func (UnimplementedHydraServer) CancelWorkflow(context.Context, *CancelWorkflowRequest) (*SubmitResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelWorkflow not implemented")
+}
+func (UnimplementedHydraServer) StreamEvents(*StreamEventsRequest, grpc.ServerStreamingServer[Event]) error {
+	return status.Error(codes.Unimplemented, "method StreamEvents not implemented")
+}
+func (UnimplementedHydraServer) mustEmbedUnimplementedHydraServer() {}
+func (UnimplementedHydraServer) testEmbeddedByValue()               {}
+
+// UnsafeHydraServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to HydraServer will
+// result in compilation errors.
+type UnsafeHydraServer interface {
+	mustEmbedUnimplementedHydraServer()
+}
+
+func RegisterHydraServer(s grpc.ServiceRegistrar, srv HydraServer) {
+	// If the following call panics, it indicates UnimplementedHydraServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Hydra_ServiceDesc, srv)
+}
+
+func _Hydra_CreateWorkflow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWorkflowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraServer).CreateWorkflow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Hydra_CreateWorkflow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraServer).CreateWorkflow(ctx, req.(*CreateWorkflowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Hydra_GetState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraServer).GetState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Hydra_GetState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraServer).GetState(ctx, req.(*GetStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Hydra_SubmitGreenlight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitGreenlightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraServer).SubmitGreenlight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Hydra_SubmitGreenlight_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraServer).SubmitGreenlight(ctx, req.(*SubmitGreenlightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Hydra_SubmitInterviewAnswers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitInterviewAnswersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraServer).SubmitInterviewAnswers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Hydra_SubmitInterviewAnswers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraServer).SubmitInterviewAnswers(ctx, req.(*SubmitInterviewAnswersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Hydra_PauseWorkflow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseWorkflowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraServer).PauseWorkflow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Hydra_PauseWorkflow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraServer).PauseWorkflow(ctx, req.(*PauseWorkflowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Hydra_ResumeWorkflow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeWorkflowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraServer).ResumeWorkflow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Hydra_ResumeWorkflow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraServer).ResumeWorkflow(ctx, req.(*ResumeWorkflowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Hydra_CancelWorkflow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelWorkflowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HydraServer).CancelWorkflow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Hydra_CancelWorkflow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HydraServer).CancelWorkflow(ctx, req.(*CancelWorkflowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Hydra_StreamEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HydraServer).StreamEvents(m, &grpc.GenericServerStream[StreamEventsRequest, Event]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Hydra_StreamEventsServer = grpc.ServerStreamingServer[Event]
+
+// Hydra_ServiceDesc is the grpc.ServiceDesc for Hydra service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Hydra_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hydra.v1.Hydra",
+	HandlerType: (*HydraServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateWorkflow",
+			Handler:    _Hydra_CreateWorkflow_Handler,
+		},
+		{
+			MethodName: "GetState",
+			Handler:    _Hydra_GetState_Handler,
+		},
+		{
+			MethodName: "SubmitGreenlight",
+			Handler:    _Hydra_SubmitGreenlight_Handler,
+		},
+		{
+			MethodName: "SubmitInterviewAnswers",
+			Handler:    _Hydra_SubmitInterviewAnswers_Handler,
+		},
+		{
+			MethodName: "PauseWorkflow",
+			Handler:    _Hydra_PauseWorkflow_Handler,
+		},
+		{
+			MethodName: "ResumeWorkflow",
+			Handler:    _Hydra_ResumeWorkflow_Handler,
+		},
+		{
+			MethodName: "CancelWorkflow",
+			Handler:    _Hydra_CancelWorkflow_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamEvents",
+			Handler:       _Hydra_StreamEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "hydra/v1/hydra.proto",
+}
@@ -0,0 +1,19 @@
+#!/bin/bash
+# Regenerate the gRPC stubs from proto/hydra/v1/hydra.proto.
+# Needs grpcio-tools (pip) and protoc with protoc-gen-go + protoc-gen-go-grpc on PATH.
+set -euo pipefail
+cd "$(dirname "$0")/.." || exit 1
+
+# Python server stubs. The proto is mapped into web/backend/grpc_api so the generated
+# modules import each other as part of the web.backend package.
+python -m grpc_tools.protoc \
+    -Iweb/backend/grpc_api=proto/hydra/v1 \
+    --python_out=. --grpc_python_out=. \
+    web/backend/grpc_api/hydra.proto
+
+# Go client package (clients/go/hydrav1).
+GO_MODULE=github.com/ask-23/composable-me/clients/go
+protoc -Iproto \
+    --go_out=clients/go --go_opt=module="$GO_MODULE" \
+    --go-grpc_out=clients/go --go-grpc_opt=module="$GO_MODULE" \
+    hydra/v1/hydra.proto
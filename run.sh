@@ -19,6 +19,12 @@ set -e
 SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
 cd "$SCRIPT_DIR"
 
+# `mcp` speaks JSON-RPC on stdout, so this script's own output goes to stderr.
+exec 3>&1
+if [ "${1:-}" = "mcp" ]; then
+    exec 1>&2
+fi
+
 # Load environment variables from .env or a.env if present
 ENV_FILES=()
 if [ -f "$SCRIPT_DIR/.env" ]; then
@@ -81,4 +87,4 @@ echo "Model: ${MODEL}"
 echo "============================================================"
 echo ""
 
-python -m runtime.crewai.cli "$@"
+python -m runtime.crewai.cli "$@" >&3
@@ -13,6 +13,7 @@ from .contracts import (
 from .llm_client import (
     LLMClientError,
     LLMRetryHandler,
+    complete_batch,
     get_available_models,
     get_llm_client,
     test_llm_connection,
@@ -28,6 +29,7 @@ __all__ = [
     "validate_model_name",
     "LLMClientError",
     "LLMRetryHandler",
+    "complete_batch",
     "cli",
     "TailoredDocuments",
     "ATSResult",
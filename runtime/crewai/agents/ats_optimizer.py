@@ -11,6 +11,7 @@ from typing import Any, Dict
 from crewai import LLM
 
 from runtime.crewai.base_agent import BaseHydraAgent, ValidationError
+from runtime.crewai.localization import language_instruction
 
 
 class ATSOptimizerAgent(BaseHydraAgent):
@@ -40,6 +41,8 @@ class ATSOptimizerAgent(BaseHydraAgent):
                 - tailored_resume: The tailored resume from Tailoring Agent
                 - job_description: The original job description
                 - source_documents: User source documents for verification
+                - output_language: Optional ISO 639-1 code the resume is written in;
+                  the optimized version keeps it (see localization)
 
         Returns:
             Dictionary with ATS analysis and optimized document
@@ -72,6 +75,9 @@ class ATSOptimizerAgent(BaseHydraAgent):
         
         Ensure all additions are truthful and verifiable against source documents.
         """
+        task_description += language_instruction(
+            context.get("output_language"), "the optimized resume"
+        )
 
         # Create task and execute with retry logic
         task = self.create_task(task_description)
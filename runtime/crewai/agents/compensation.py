@@ -0,0 +1,112 @@
+"""
+Compensation Analyst Implementation
+
+This optional agent turns the job description's posted pay (if any), the cited
+company research and the candidate's own target numbers into a negotiation brief:
+market ranges per pay component, a recommended ask, talking points, and questions
+about equity and bonus. Software checks the citations and places the target against
+the range (see runtime.crewai.compensation); the model never sees the résumé.
+"""
+
+from typing import Any, Dict
+
+from crewai import LLM
+
+from runtime.crewai.base_agent import BaseHydraAgent, ValidationError
+from runtime.crewai.compensation import CompensationTargets, assess_target
+from runtime.crewai.contracts import CompensationBrief
+
+PROMPT_PATH = "agents/compensation-analyst/prompt.md"
+
+
+class CompensationAgent(BaseHydraAgent):
+    """Compensation Analyst that prepares a cited negotiation brief"""
+
+    role = "Compensation Analyst"
+    goal = "Estimate the role's pay from cited evidence and prepare the candidate to negotiate"
+    expected_output = "JSON negotiation brief: cited market ranges, talking points, questions"
+
+    def __init__(self, llm: LLM):
+        super().__init__(llm, prompt_path=PROMPT_PATH)
+
+    def execute(self, context: Dict[str, Any]) -> Dict[str, Any]:
+        """
+        Execute the compensation analysis
+
+        Args:
+            context: Dictionary containing:
+                - job_description: The job description text
+                - research: Optional research brief with numbered sources
+                - compensation_targets: Optional CompensationTargets dict
+                - differentiation: Optional differentiation output (leverage)
+                - executive_brief: Optional executive brief (fit rationale)
+
+        Returns:
+            Dictionary with the checked brief, the target assessment and the sources
+        """
+        if "job_description" not in context:
+            raise ValidationError("Missing required context key: job_description")
+
+        targets = CompensationTargets.from_dict(context.get("compensation_targets"))
+        research = context.get("research") or {}
+        sources = research.get("sources") or []
+        task = self.create_task(self._describe(context, targets, research, sources))
+        output = self.execute_with_retry(task)
+
+        known = {source["id"] for source in sources if "id" in source}
+        brief = CompensationBrief.from_raw(output).checked(known).model_dump()
+        return {
+            "agent": output.get("agent", self.role),
+            "timestamp": output.get("timestamp"),
+            "confidence": output.get("confidence"),
+            **brief,
+            "targets": targets.to_dict(),
+            "target_assessment": assess_target(brief, targets),
+            "sources": sources,
+        }
+
+    @staticmethod
+    def _describe(
+        context: Dict[str, Any],
+        targets: CompensationTargets,
+        research: Dict[str, Any],
+        sources: list,
+    ) -> str:
+        numbered = "\n".join(
+            f"[{s['id']}] {s.get('title', '')} {s.get('url', '')}\n    {s.get('snippet', '')}"
+            for s in sources
+            if "id" in s
+        )
+        money = {
+            key: f"{targets.currency} {value:,.0f}" if value is not None else "not given"
+            for key, value in targets.to_dict().items()
+            if key != "currency"
+        }
+        strengths = context.get("differentiation") or "Not available"
+        rationale = (context.get("executive_brief") or {}).get("decision") or "Not available"
+        return f"""
+        Prepare a negotiation brief for this job application (see your output schema).
+
+        Job Description:
+        {context['job_description']}
+
+        Company research summary:
+        {research.get('funding') or 'No funding findings.'}
+
+        Numbered research sources:
+        {numbered or 'None. Cite "jd" where the job description posts pay; otherwise estimate.'}
+
+        Candidate's numbers ({targets.currency}):
+        - Target base salary: {money['target_base']}
+        - Walk-away base salary: {money['minimum_base']}
+
+        Candidate's leverage (differentiators):
+        {strengths}
+
+        Fit rationale:
+        {rationale}
+
+        Give market ranges per component (base, bonus, equity), a recommended ask,
+        talking points grounded in the leverage above, and questions to ask about
+        equity, bonus and the rest of the package.
+        """
@@ -8,7 +8,7 @@ This agent maps job requirements to candidate experience and classifies each as:
 - blocker: Cannot be addressed through framing or transfer
 """
 
-from typing import Any, Dict
+from typing import Any, Dict, List, Optional
 
 from crewai import LLM
 
@@ -40,6 +40,10 @@ class GapAnalyzerAgent(BaseHydraAgent):
                 - job_description: The job description text
                 - resume: The candidate's resume text
                 - research_data: Optional research data
+                - skill_synonyms: Optional JD skills the résumé names differently
+                  ({"skill", "jd_term", "resume_term"}; see skill_taxonomy)
+                - profiles: Optional baseline résumés to recommend among
+                  (ProfileFit.for_prompt(); see profiles), with profile: the one in use
             
         Returns:
             Dictionary with requirements analysis and fit scoring
@@ -62,6 +66,8 @@ class GapAnalyzerAgent(BaseHydraAgent):
         
         Research Data:
         {context.get('research_data', 'Not provided')}
+        {self._describe_synonyms(context.get('skill_synonyms'))}
+        {self._describe_profiles(context.get('profiles'), context.get('profile'))}
         
         Extract all requirements (explicit and implicit) from the job description.
         Map each requirement to the candidate's experience from the resume.
@@ -75,6 +81,41 @@ class GapAnalyzerAgent(BaseHydraAgent):
         # Execute with retry logic
         return self.execute_with_retry(task)
     
+    @staticmethod
+    def _describe_synonyms(synonyms: Optional[List[Dict[str, str]]]) -> str:
+        """The taxonomy's synonym matches, so abbreviations are not classified as gaps."""
+        if not synonyms:
+            return ""
+        lines = "\n".join(
+            f'        - JD "{s["jd_term"]}" = résumé "{s["resume_term"]}" ({s["skill"]})'
+            for s in synonyms
+        )
+        return (
+            "\n        Same skill, different name (matched by the skill taxonomy; "
+            f"treat these as the same skill, not as gaps):\n{lines}\n"
+        )
+
+    @staticmethod
+    def _describe_profiles(
+        profiles: Optional[List[Dict[str, Any]]], current: Optional[str]
+    ) -> str:
+        """The candidate's other baseline résumés, to recommend the best fit among."""
+        if not profiles:
+            return ""
+        lines = "\n".join(
+            f'        - {p["name"]}: {p["description"]} (JD skills covered '
+            f'{p["jd_skills_covered"]}; missing: {", ".join(p["missing"]) or "none"})'
+            for p in profiles
+        )
+        in_use = f' The résumé above is "{current}".' if current else ""
+        return (
+            f"\n        The candidate keeps several baseline résumés (profiles).{in_use}\n"
+            f"{lines}\n"
+            '        Also return "profile_recommendation": {"profile": "<name>", '
+            '"reason": "<one sentence>"} naming the profile whose résumé fits this job '
+            "best.\n"
+        )
+
     def _validate_schema(self, data: Dict[str, Any]) -> None:
         """
         Validate that the output conforms to the required schema
@@ -0,0 +1,104 @@
+"""
+Impact Rewriter Implementation
+
+This agent runs with ``--impact`` (or ``impact`` in a workflow template), after the
+gap analysis. Software has already picked the résumé's weak bullets (see
+runtime.crewai.impact); the agent rewrites each as an impact statement and, where
+a metric is missing, asks the candidate for it rather than inventing one. Rewrites
+of bullets it was not given are dropped, and so is any rewrite with a number found
+in neither the résumé nor the sources; its questions are kept.
+"""
+
+from typing import Any, Dict
+
+from crewai import LLM
+
+from runtime.crewai.base_agent import BaseHydraAgent, ValidationError
+from runtime.crewai.contracts import ImpactRewrites
+from runtime.crewai.impact import MAX_QUESTIONS_PER_BULLET, invented_numbers
+
+PROMPT_PATH = "agents/impact-rewriter/prompt.md"
+
+
+class ImpactRewriterAgent(BaseHydraAgent):
+    """Impact Rewriter that turns weak bullets into impact statements"""
+
+    role = "Impact Rewriter"
+    goal = "Rewrite weak bullets as impact statements and ask for the metrics they lack"
+    expected_output = "JSON rewrites: per bullet id, a rewrite and targeted metric questions"
+
+    def __init__(self, llm: LLM):
+        super().__init__(llm, prompt_path=PROMPT_PATH)
+
+    def execute(self, context: Dict[str, Any]) -> Dict[str, Any]:
+        """
+        Execute the impact rewriter
+
+        Args:
+            context: Dictionary containing:
+                - job_description: The job description text
+                - resume: The baseline résumé
+                - bullets: The weak bullets, each with ``id``, ``text`` and ``reasons``
+                - source_documents: Optional source material (evidence for numbers)
+                - gap_analysis: Optional gap analysis output
+
+        Returns:
+            Dictionary with the bullets, each with its rewrite and questions, and the
+            rewrites dropped for numbers the evidence does not contain
+        """
+        for key in ("job_description", "resume", "bullets"):
+            if key not in context:
+                raise ValidationError(f"Missing required context key: {key}")
+
+        task = self.create_task(self._describe(context))
+        output = self.execute_with_retry(task)
+
+        rewrites = {item["bullet"]: item for item in ImpactRewrites.from_raw(output).rewrites}
+        if not rewrites:
+            raise ValidationError("Impact rewriter returned no rewrites")
+        evidence = f"{context['resume']}\n{context.get('source_documents') or ''}"
+        bullets, invented = [], []
+        for bullet in context["bullets"]:
+            item = rewrites.get(bullet["id"], {})
+            rewrite = item.get("rewrite", "")
+            numbers = invented_numbers(rewrite, evidence)
+            if numbers:
+                invented.append({"bullet": bullet["id"], "numbers": numbers})
+                rewrite = ""
+            if rewrite == bullet["text"]:
+                rewrite = ""
+            questions = item.get("questions", [])[:MAX_QUESTIONS_PER_BULLET]
+            bullets.append({**bullet, "rewrite": rewrite, "questions": questions})
+        return {
+            "agent": output.get("agent", self.role),
+            "timestamp": output.get("timestamp"),
+            "confidence": output.get("confidence"),
+            "bullets": bullets,
+            "invented": invented,
+        }
+
+    @staticmethod
+    def _describe(context: Dict[str, Any]) -> str:
+        bullets = "\n".join(
+            f"[{b['id']}] {b['text']} ({', '.join(b['reasons'])})" for b in context["bullets"]
+        )
+        return f"""
+        Rewrite each weak bullet below as an impact statement for this role, and ask
+        the candidate for every metric a rewrite needs but the evidence lacks. Answer
+        by bullet id. Never write a number the résumé or sources do not contain.
+
+        Weak bullets (id, text, why weak):
+        {bullets}
+
+        Job Description:
+        {context['job_description']}
+
+        Gap Analysis:
+        {context.get('gap_analysis') or 'Not available'}
+
+        Candidate Resume:
+        {context['resume']}
+
+        Source Material:
+        {context.get('source_documents') or 'Not available'}
+        """
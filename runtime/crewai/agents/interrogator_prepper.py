@@ -10,6 +10,8 @@ from typing import Any, Dict
 from crewai import LLM
 
 from runtime.crewai.base_agent import BaseHydraAgent, ValidationError
+from runtime.crewai.debrief import render_debriefs
+from runtime.crewai.knowledge_base import render_facts
 
 
 class InterrogatorPrepperAgent(BaseHydraAgent):
@@ -40,6 +42,9 @@ class InterrogatorPrepperAgent(BaseHydraAgent):
                 - resume: The candidate's resume text
                 - gaps: List of gaps from Gap Analyzer
                 - gap_analysis: Full gap analysis output
+                - past_debriefs: Optional debriefs of earlier interviews at this company
+                - knowledge_facts: Optional verified facts from earlier runs (see
+                  knowledge_base)
 
         Returns:
             Dictionary with targeted questions and interview processing framework
@@ -71,6 +76,20 @@ class InterrogatorPrepperAgent(BaseHydraAgent):
         Focus on extracting truthful, specific details that can strengthen the application.
         Provide framework for processing interview answers and verifying claims.
         """
+        if context.get("past_debriefs"):
+            task_description += f"""
+        Earlier interviews at this company (the candidate's own debriefs):
+        {render_debriefs(context["past_debriefs"])}
+
+        Prepare the candidate for the kinds of questions this company has asked before,
+        and target what the candidate said they would do differently.
+        """
+        if context.get("knowledge_facts"):
+            task_description += f"""
+        Already known about the candidate from earlier runs (verified; do not ask for
+        these again, ask only what they leave open):
+        {render_facts(context["knowledge_facts"])}
+        """
 
         task = self.create_task(task_description)
 
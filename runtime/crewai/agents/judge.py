@@ -0,0 +1,76 @@
+"""
+Judge Implementation
+
+This agent runs with ``--judge`` (or in an eval case that sets a minimum score),
+after the audit. It scores the audited résumé from 1 to 10 on each rubric in
+runtime.crewai.judge, with a rationale per score. Scores outside 1-10 are clamped;
+rubrics it did not score are left out, and an answer with none is rejected.
+"""
+
+from typing import Any, Dict
+
+from crewai import LLM
+
+from runtime.crewai.base_agent import BaseHydraAgent, ValidationError
+from runtime.crewai.contracts import JudgeScores
+
+PROMPT_PATH = "agents/judge/prompt.md"
+
+
+class JudgeAgent(BaseHydraAgent):
+    """Judge that scores the final résumé against the quality rubrics"""
+
+    role = "Judge"
+    goal = "Score the tailored résumé from 1 to 10 on four quality rubrics, with reasons"
+    expected_output = "JSON scores: per rubric, a whole score from 1 to 10 and a rationale"
+
+    def __init__(self, llm: LLM):
+        super().__init__(llm, prompt_path=PROMPT_PATH)
+
+    def execute(self, context: Dict[str, Any]) -> Dict[str, Any]:
+        """
+        Execute the judge
+
+        Args:
+            context: Dictionary containing:
+                - job_description: The job description text
+                - tailored_resume: The audited résumé to score
+                - source_documents: The candidate's résumé and sources (evidence)
+
+        Returns:
+            Dictionary with ``scores``: per rubric, its score and rationale
+        """
+        for key in ("job_description", "tailored_resume", "source_documents"):
+            if key not in context:
+                raise ValidationError(f"Missing required context key: {key}")
+
+        task = self.create_task(self._describe(context))
+        output = self.execute_with_retry(task)
+
+        scores = JudgeScores.from_raw(output).scores
+        if not scores:
+            raise ValidationError("Judge returned no rubric scores")
+        return {
+            "agent": output.get("agent", self.role),
+            "timestamp": output.get("timestamp"),
+            "confidence": output.get("confidence"),
+            "scores": scores,
+        }
+
+    @staticmethod
+    def _describe(context: Dict[str, Any]) -> str:
+        return f"""
+        Score the tailored résumé below from 1 to 10 on each rubric: relevance,
+        truthfulness, readability and ats_friendliness. Give a one- or two-sentence
+        rationale per score that points at the résumé. Judge truthfulness against the
+        sources only.
+
+        Job Description:
+        {context['job_description']}
+
+        Tailored Résumé:
+        {context['tailored_resume']}
+
+        Sources (the candidate's résumé and source material):
+        {context['source_documents']}
+        """
@@ -0,0 +1,133 @@
+"""
+Outreach Writer Implementation
+
+This agent runs in the ``referral`` workflow template (see
+runtime.crewai.workflow_templates) or with ``--outreach``, after the audit. It writes
+short personal messages to the hiring manager or a recruiter — a LinkedIn connection
+request, an InMail and an email — from the candidate's differentiators, the cited
+company research and the audited résumé, with three ranked subject lines and openers
+to choose from. Software holds each message to its channel's limits and checks the
+citations (see runtime.crewai.outreach).
+"""
+
+from typing import Any, Dict
+
+from crewai import LLM
+
+from runtime.crewai.base_agent import BaseHydraAgent, ValidationError
+from runtime.crewai.contracts import OutreachMessages
+from runtime.crewai.outreach import (
+    ANGLES,
+    CHANNELS,
+    HIRING_MANAGER,
+    OPENER,
+    OPTIONS_PER_KIND,
+    SUBJECT_LINE,
+    check_options,
+    check_variants,
+)
+
+PROMPT_PATH = "agents/outreach-writer/prompt.md"
+
+
+class OutreachAgent(BaseHydraAgent):
+    """Outreach Writer that drafts the messages to the hiring manager or recruiter"""
+
+    role = "Outreach Writer"
+    goal = "Write short, specific messages that get the reader to open the application"
+    expected_output = "JSON outreach: connection request, InMail and email variants"
+
+    def __init__(self, llm: LLM):
+        super().__init__(llm, prompt_path=PROMPT_PATH)
+
+    def execute(self, context: Dict[str, Any]) -> Dict[str, Any]:
+        """
+        Execute the outreach writer
+
+        Args:
+            context: Dictionary containing:
+                - job_description: The job description text
+                - tailored_resume: The audited résumé
+                - recipient: Optional "hiring manager" (default) or "recruiter"
+                - differentiation: Optional differentiation output
+                - research: Optional research brief with numbered sources
+                - company / target_role: Optional names given by the candidate
+
+        Returns:
+            Dictionary with the recipient, the checked variants, the ranked subject
+            lines and openers, and the sources
+        """
+        for key in ("job_description", "tailored_resume"):
+            if key not in context:
+                raise ValidationError(f"Missing required context key: {key}")
+
+        recipient = context.get("recipient") or HIRING_MANAGER
+        research = context.get("research") or {}
+        sources = research.get("sources") or []
+        task = self.create_task(self._describe(context, recipient, sources))
+        output = self.execute_with_retry(task)
+
+        messages = OutreachMessages.from_raw(output)
+        if not messages.variants:
+            raise ValidationError("Outreach returned no messages")
+        known = {source["id"] for source in sources if "id" in source}
+        checked = check_variants(messages.variants, known, messages.research_used)
+        return {
+            "agent": output.get("agent", self.role),
+            "timestamp": output.get("timestamp"),
+            "confidence": output.get("confidence"),
+            "recipient": recipient,
+            **checked,
+            "differentiators_used": messages.differentiators_used,
+            "subject_lines": check_options(SUBJECT_LINE, messages.subject_lines),
+            "openers": check_options(OPENER, messages.openers),
+            "sources": sources,
+        }
+
+    def _validate_schema(self, data: Dict[str, Any]) -> None:
+        """Base fields, and a full set of ranked options of each kind: fewer is retried."""
+        super()._validate_schema(data)
+        messages = OutreachMessages.from_raw(data)
+        for kind, options in ((SUBJECT_LINE, messages.subject_lines), (OPENER, messages.openers)):
+            offered = len(check_options(kind, options))
+            if offered < OPTIONS_PER_KIND:
+                label = kind.replace("_", " ")
+                raise ValidationError(f"Outreach offered {offered} of {OPTIONS_PER_KIND} {label}s")
+
+    @staticmethod
+    def _describe(context: Dict[str, Any], recipient: str, sources: list) -> str:
+        numbered = "\n".join(
+            f"[{s['id']}] {s.get('title', '')}: {s.get('snippet', '')}"
+            for s in sources
+            if "id" in s
+        )
+        differentiation = context.get("differentiation") or {}
+        strengths = differentiation.get("differentiators") or differentiation or "Not available"
+        limits = "\n".join(
+            f"- {channel.name}: at most {channel.max_chars} characters" for channel in CHANNELS
+        )
+        return f"""
+        Write the candidate's outreach to the {recipient} for this role, as a LinkedIn
+        connection request, an InMail and an email (see your output schema).
+
+        Company: {context.get('company') or 'see the job description'}
+        Role: {context.get('target_role') or 'see the job description'}
+
+        Message limits:
+        {limits}
+
+        Also offer {OPTIONS_PER_KIND} subject lines and {OPTIONS_PER_KIND} openers, best
+        first, each with a one-sentence rationale and one angle of: {', '.join(ANGLES)}.
+
+        Job Description:
+        {context['job_description']}
+
+        Candidate's differentiators:
+        {strengths}
+
+        Numbered company research:
+        {numbered or 'None. Say nothing about the company the job description does not.'}
+
+        Tailored résumé (audited; the only source of claims about the candidate):
+        {context['tailored_resume']}
+        """
@@ -0,0 +1,103 @@
+"""
+Referral Finder Implementation
+
+This agent runs when the candidate gives their contacts (``--contacts``), after the
+audit. Software has already matched the contacts against the target company and
+suggested a referral path for each (see runtime.crewai.referrals); the agent drafts
+the ask message per contact from the differentiators and the audited résumé. Asks
+for contacts that were not given are dropped, and each ask is held to a length.
+"""
+
+from typing import Any, Dict
+
+from crewai import LLM
+
+from runtime.crewai.base_agent import BaseHydraAgent, ValidationError
+from runtime.crewai.contracts import ReferralAsks
+from runtime.crewai.outreach import fit_to_limit
+from runtime.crewai.referrals import PATHS
+
+PROMPT_PATH = "agents/referral-finder/prompt.md"
+# A message to someone the candidate knows, not a cover letter.
+ASK_MAX_CHARS = 1200
+
+
+class ReferralFinderAgent(BaseHydraAgent):
+    """Referral Finder that drafts an ask for each contact at the target company"""
+
+    role = "Referral Finder"
+    goal = "Draft a short, personal ask for each contact who can help with the application"
+    expected_output = "JSON asks: one message per numbered contact, with its path"
+
+    def __init__(self, llm: LLM):
+        super().__init__(llm, prompt_path=PROMPT_PATH)
+
+    def execute(self, context: Dict[str, Any]) -> Dict[str, Any]:
+        """
+        Execute the referral finder
+
+        Args:
+            context: Dictionary containing:
+                - job_description: The job description text
+                - contacts: The matched contacts, numbered (``id``), with a ``path``
+                - tailored_resume: The audited résumé
+                - differentiation: Optional differentiation output
+                - company / target_role: The target company and role
+
+        Returns:
+            Dictionary with the contacts, each with its path and drafted ask
+        """
+        for key in ("job_description", "contacts", "tailored_resume"):
+            if key not in context:
+                raise ValidationError(f"Missing required context key: {key}")
+
+        contacts = context["contacts"]
+        task = self.create_task(self._describe(context))
+        output = self.execute_with_retry(task)
+
+        asks = {ask["contact"]: ask for ask in ReferralAsks.from_raw(output).asks}
+        if not asks:
+            raise ValidationError("Referral finder returned no asks")
+        drafted = []
+        for contact in contacts:
+            ask = asks.get(contact["id"])
+            if ask is None:
+                drafted.append({**contact, "ask": ""})
+                continue
+            path = ask["path"] if ask["path"] in PATHS else contact["path"]
+            message = fit_to_limit(ask["ask"], ASK_MAX_CHARS)
+            drafted.append({**contact, "path": path, "ask": message})
+        return {
+            "agent": output.get("agent", self.role),
+            "timestamp": output.get("timestamp"),
+            "confidence": output.get("confidence"),
+            "contacts": drafted,
+        }
+
+    @staticmethod
+    def _describe(context: Dict[str, Any]) -> str:
+        contacts = "\n".join(
+            f"[{c['id']}] {c['name']} — {c.get('title') or 'title unknown'}; "
+            f"relationship: {c.get('relationship') or 'not given'}; "
+            f"suggested path: {c['path']}" + (f"; notes: {c['notes']}" if c.get("notes") else "")
+            for c in context["contacts"]
+        )
+        differentiation = context.get("differentiation") or {}
+        strengths = differentiation.get("differentiators") or differentiation or "Not available"
+        return f"""
+        Draft the candidate's ask to each contact below, who all work at
+        {context.get('company') or 'the target company'}, about this role:
+        {context.get('target_role') or 'see the job description'}.
+
+        Contacts:
+        {contacts}
+
+        Job Description:
+        {context['job_description']}
+
+        Candidate's differentiators:
+        {strengths}
+
+        Tailored résumé (audited; the only source of claims about the candidate):
+        {context['tailored_resume']}
+        """
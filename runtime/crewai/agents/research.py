@@ -0,0 +1,160 @@
+"""
+Research Agent Implementation
+
+This agent gathers current, cited context about the hiring company — recent news,
+funding, and tech stack — by invoking a live web search tool in bounded rounds.
+Each round the model either asks for searches or returns its brief; software runs
+the searches, numbers the results, and drops any finding that does not cite one.
+The agent never sees the candidate's résumé.
+"""
+
+from typing import Any, Dict, List, Optional
+
+from crewai import LLM
+
+from runtime.crewai.base_agent import BaseHydraAgent, ValidationError
+from runtime.crewai.contracts import ResearchBrief
+from runtime.crewai.web_search import SearchError, SearchProvider, SearchResult
+
+MAX_SEARCH_ROUNDS = 2
+MAX_QUERIES_PER_ROUND = 3
+RESULTS_PER_QUERY = 5
+
+
+class ResearchAgent(BaseHydraAgent):
+    """Research Agent that builds a cited company brief from live web search"""
+
+    role = "Researcher"
+    goal = "Gather recent, cited facts about the hiring company: news, funding, and tech stack"
+    expected_output = "JSON with either search queries to run or a cited company research brief"
+
+    def __init__(self, llm: LLM, search_provider: Optional[SearchProvider] = None):
+        """
+        Initialize the Research Agent
+
+        Args:
+            llm: The LLM instance to use
+            search_provider: Web search tool (see runtime.crewai.web_search); without
+                one the agent can only report that no research was possible.
+        """
+        super().__init__(llm, "agents/researcher/prompt.md")
+        self.search_provider = search_provider
+
+    def execute(self, context: Dict[str, Any]) -> Dict[str, Any]:
+        """
+        Execute the research loop
+
+        Args:
+            context: Dictionary containing:
+                - job_description: The job description text
+                - company: Optional company name (inferred from the JD otherwise)
+                - target_role: Optional role being applied for
+
+        Returns:
+            Dictionary with the cited brief, the numbered sources, and a transcript
+            of every search that was run
+        """
+        if "job_description" not in context:
+            raise ValidationError("Missing required context key: job_description")
+
+        results: List[SearchResult] = []
+        searches: List[Dict[str, Any]] = []
+        output: Dict[str, Any] = {}
+        for round_number in range(1, MAX_SEARCH_ROUNDS + 2):
+            final = round_number > MAX_SEARCH_ROUNDS or self.search_provider is None
+            task = self.create_task(self._describe(context, results, searches, final))
+            output = self.execute_with_retry(task)
+            queries = [] if final else self._queries(output)
+            if not queries:
+                break
+            for query in queries:
+                searches.append(self._search(query, results, round_number))
+        return self._finalise(output, results, searches)
+
+    def _describe(
+        self,
+        context: Dict[str, Any],
+        results: List[SearchResult],
+        searches: List[Dict[str, Any]],
+        final: bool,
+    ) -> str:
+        numbered = "\n".join(
+            f"[{i}] {r.title} ({r.published or 'undated'}) {r.url}\n    {r.snippet}"
+            for i, r in enumerate(results, start=1)
+        )
+        asked = ", ".join(f'"{s["query"]}"' for s in searches) or "none yet"
+        if final:
+            instruction = (
+                "This is the final round: return the research brief now. "
+                "Cite only the numbered results above; use empty lists where they say nothing."
+            )
+        else:
+            instruction = (
+                f'Either return {{"searches": [...]}} with up to {MAX_QUERIES_PER_ROUND} new '
+                "queries, or, if the results already cover news, funding and tech stack, "
+                "return the research brief."
+            )
+        return f"""
+        Research the hiring company for this job application.
+
+        Job Description:
+        {context['job_description']}
+
+        Company: {context.get('company') or 'Infer from the job description'}
+        Target role: {context.get('target_role') or 'See job description'}
+
+        Searches already run: {asked}
+
+        Numbered search results:
+        {numbered or 'None yet.'}
+
+        {instruction}
+        """
+
+    @staticmethod
+    def _queries(output: Dict[str, Any]) -> List[str]:
+        raw = output.get("searches") or output.get("queries") or []
+        if isinstance(raw, str):
+            raw = [raw]
+        queries = [str(q).strip() for q in raw if str(q).strip()]
+        return queries[:MAX_QUERIES_PER_ROUND]
+
+    def _search(
+        self, query: str, results: List[SearchResult], round_number: int
+    ) -> Dict[str, Any]:
+        """Run one query, appending new (by URL) results; returns its transcript entry."""
+        entry: Dict[str, Any] = {"round": round_number, "query": query, "result_ids": []}
+        try:
+            hits = self.search_provider.search(query, max_results=RESULTS_PER_QUERY)
+        except SearchError as e:
+            entry["error"] = str(e)
+            return entry
+        seen = {r.url: i for i, r in enumerate(results, start=1)}
+        for hit in hits:
+            if hit.url not in seen:
+                results.append(hit)
+                seen[hit.url] = len(results)
+            entry["result_ids"].append(seen[hit.url])
+        return entry
+
+    def _finalise(
+        self,
+        output: Dict[str, Any],
+        results: List[SearchResult],
+        searches: List[Dict[str, Any]],
+    ) -> Dict[str, Any]:
+        """Keep only findings citing a real result; attach sources and transcript."""
+        known = set(range(1, len(results) + 1))
+        brief, dropped = ResearchBrief.from_raw(output).cited_only(known)
+        return {
+            "agent": output.get("agent", self.role),
+            "timestamp": output.get("timestamp"),
+            "confidence": output.get("confidence"),
+            **brief.model_dump(),
+            "sources": [
+                {"id": i, **result.to_dict()} for i, result in enumerate(results, start=1)
+            ],
+            "searches": searches,
+            "uncited_dropped": dropped,
+            "search_provider": getattr(self.search_provider, "name", None),
+        }
@@ -0,0 +1,101 @@
+"""
+Seniority Calibrator Implementation
+
+This agent runs with ``--seniority`` (or ``seniority`` in a workflow template), after
+the gap analysis, when software has found the résumé reads above or below the role's
+level (see runtime.crewai.seniority). The verdict and its reasons are given; the agent
+names the bullets that carry the mismatch and how to frame each, and adds
+instructions for the Tailoring Agent. Notes on bullets it was not given are dropped.
+"""
+
+from typing import Any, Dict
+
+from crewai import LLM
+
+from runtime.crewai.base_agent import BaseHydraAgent, ValidationError
+from runtime.crewai.contracts import SeniorityNotes
+
+PROMPT_PATH = "agents/seniority-calibrator/prompt.md"
+
+# Notes on more bullets than this stop being a calibration and become a rewrite.
+MAX_FINDINGS = 8
+
+
+class SeniorityCalibratorAgent(BaseHydraAgent):
+    """Seniority Calibrator that checks the résumé's framing against the role's level"""
+
+    role = "Seniority Calibrator"
+    goal = "Find the bullets that make the résumé read above or below the role's level"
+    expected_output = "JSON notes: per bullet id, the issue and how to frame it; instructions"
+
+    def __init__(self, llm: LLM):
+        super().__init__(llm, prompt_path=PROMPT_PATH)
+
+    def execute(self, context: Dict[str, Any]) -> Dict[str, Any]:
+        """
+        Execute the seniority calibrator
+
+        Args:
+            context: Dictionary containing:
+                - job_description: The job description text
+                - resume: The baseline résumé
+                - calibration: Software's calibration: role_level, resume_level,
+                  verdict, issues, signals and the bullets with their ids
+                - source_documents: Optional source material (evidence for scope)
+                - gap_analysis: Optional gap analysis output
+
+        Returns:
+            Dictionary with the findings (bullet, issue, suggestion) and instructions
+        """
+        for key in ("job_description", "resume", "calibration"):
+            if key not in context:
+                raise ValidationError(f"Missing required context key: {key}")
+
+        task = self.create_task(self._describe(context))
+        output = self.execute_with_retry(task)
+
+        notes = SeniorityNotes.from_raw(output)
+        known = {bullet["id"] for bullet in context["calibration"].get("bullets") or []}
+        findings = [item for item in notes.findings if item["bullet"] in known]
+        if not findings and not notes.instructions:
+            raise ValidationError("Seniority calibrator returned no findings or instructions")
+        return {
+            "agent": output.get("agent", self.role),
+            "timestamp": output.get("timestamp"),
+            "confidence": output.get("confidence"),
+            "findings": findings[:MAX_FINDINGS],
+            "instructions": notes.instructions,
+        }
+
+    @staticmethod
+    def _describe(context: Dict[str, Any]) -> str:
+        calibration = context["calibration"]
+        issues = "\n".join(f"- {issue}" for issue in calibration.get("issues") or [])
+        bullets = "\n".join(
+            f"[{b['id']}] {b['text']}" for b in calibration.get("bullets") or []
+        )
+        return f"""
+        The role is {calibration['role_level']}; the resume reads
+        {calibration['resume_level']} and is {calibration['verdict']}:
+        {issues}
+
+        Name the bullets that carry this mismatch and how to frame each at the role's
+        level, by bullet id, and add instructions for the tailoring agent. Change the
+        framing, never the facts: no title, team size or scope the evidence does not
+        state.
+
+        Experience bullets (id, text):
+        {bullets}
+
+        Job Description:
+        {context['job_description']}
+
+        Gap Analysis:
+        {context.get('gap_analysis') or 'Not available'}
+
+        Candidate Resume:
+        {context['resume']}
+
+        Source Material:
+        {context.get('source_documents') or 'Not available'}
+        """
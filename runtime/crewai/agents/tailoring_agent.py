@@ -8,11 +8,16 @@ This agent generates tailored resumes and cover letters that:
 - Comply strictly with truth laws from AGENTS.MD
 """
 
+import json
 from typing import Any, Dict
 
 from crewai import LLM
 
 from runtime.crewai.base_agent import BaseHydraAgent, ValidationError
+from runtime.crewai.interview import render_experience
+from runtime.crewai.knowledge_base import render_facts
+from runtime.crewai.localization import language_instruction
+from runtime.crewai.resume_model import apply_patches, parse_resume, propose_patches
 
 
 class TailoringAgent(BaseHydraAgent):
@@ -42,6 +47,23 @@ class TailoringAgent(BaseHydraAgent):
                 - interview_notes: Notes from Interrogator-Prepper
                 - differentiators: Output from Differentiator
                 - gap_analysis: Output from Gap Analyzer
+                - templated_paragraphs: Optional cover letter paragraphs that repeat
+                  letters sent for other roles and must be rewritten
+                - resume_patches: Optional; if True, edit the baseline resume with
+                  per-bullet patches instead of rewriting it (see resume_model)
+                - impact_rewrites: Optional impact rewrites of weak bullets, checked
+                  to add no numbers (see impact)
+                - seniority_calibration: Optional; the role's level, how the resume
+                  misses it, and framing instructions (see seniority)
+                - length_limits: Optional; a previous tailored resume that ran over its
+                  page, bullet or reading-grade limits, and what to trim (see
+                  length_limits)
+                - interview_experience: Optional interview answers as structured
+                  experience: theme, gap, metrics and tools each (see interview)
+                - knowledge_facts: Optional verified facts about the candidate from
+                  earlier runs' interviews and sources (see knowledge_base)
+                - output_language: Optional ISO 639-1 code the resume and cover letter
+                  are written in (see localization)
             
         Returns:
             Dictionary with tailored resume, cover letter, and source mapping
@@ -71,17 +93,128 @@ class TailoringAgent(BaseHydraAgent):
         Gap Analysis:
         {context['gap_analysis']}
         
-        Create a tailored resume in Markdown format that emphasizes relevant experience.
+        {self._resume_instructions(context)}
         Generate a cover letter (250-400 words) that incorporates differentiators naturally.
         Use anti-AI detection patterns from the STYLE_GUIDE.
         Ensure all claims trace to verified source material.
         Provide complete source mapping for every claim made.
         """
+        task_description += language_instruction(
+            context.get("output_language"), "the resume and the cover letter"
+        )
+        if context.get("templated_paragraphs"):
+            repeated = "\n\n".join(context["templated_paragraphs"])
+            task_description += f"""
+        These cover letter paragraphs nearly repeat letters the candidate is sending for
+        other roles. Rewrite them around this company and role so the letters do not
+        read as a template; do not reuse their sentences:
+        {repeated}
+        """
+        if context.get("interview_experience"):
+            task_description += f"""
+        Structured experience from the interview (the candidate's own answers; their
+        metrics and tools are verified evidence, cite them as "user interview"):
+        {render_experience(context["interview_experience"])}
+        """
+        if context.get("knowledge_facts"):
+            task_description += f"""
+        Verified facts about the candidate from earlier runs (their interview answers
+        and source documents). Use those relevant to this role as evidence, cite them as
+        "knowledge base":
+        {render_facts(context["knowledge_facts"])}
+        """
+        if context.get("impact_rewrites"):
+            rewrites = "\n".join(
+                f"- {item['original']}\n  -> {item['rewrite']}"
+                for item in context["impact_rewrites"]
+            )
+            task_description += f"""
+        Impact rewrites of weak resume bullets. Use them where they serve this role, and
+        add a metric to one only when the interview notes give it:
+        {rewrites}
+        """
+        if context.get("seniority_calibration"):
+            calibration = context["seniority_calibration"]
+            notes = "\n".join(
+                [f"- {item}" for item in calibration.get("issues") or []]
+                + [f"- Do: {item}" for item in calibration.get("instructions") or []]
+                + [
+                    f"- [{item['bullet']}] {item['suggestion']}"
+                    for item in calibration.get("findings") or []
+                ]
+            )
+            task_description += f"""
+        Seniority calibration: the role is {calibration['role_level']} and the resume
+        reads {calibration['verdict']}. Frame it at the role's level; change the framing,
+        never the facts (titles as held, no team size or scope the evidence lacks):
+        {notes}
+        """
+        if context.get("length_limits"):
+            trim = context["length_limits"]
+            limits = "\n".join(f"- {item}" for item in trim["instructions"])
+            task_description += f"""
+        Your previous tailored resume, below, ran over its length limits. Trim it to
+        them and return the complete documents again; cut rather than rewrite, and add
+        no claim that was not in it:
+        {limits}
+
+        Previous tailored resume:
+        {trim["resume"]}
+        """
+        
+        if "json_resume" in context:
+            baseline = context["json_resume"]
+            baseline = json.dumps(baseline, indent=2) if baseline else ""
+            task_description += f"""
+        Also return the tailored resume as "tailored_json_resume": a JSON Resume document
+        (jsonresume.org schema v1.0.0) with the same content as the Markdown resume. Dates
+        are YYYY, YYYY-MM or YYYY-MM-DD. Keep every field of the baseline document below
+        that you do not tailor:
+        {baseline}
+        """
         
         task = self.create_task(task_description)
         
         # Execute with retry logic
-        return self.execute_with_retry(task)
+        output = self.execute_with_retry(task)
+        if context.get("resume_patches"):
+            output = self._apply_patches(context["resume"], output)
+        return output
+
+    @staticmethod
+    def _resume_instructions(context: Dict[str, Any]) -> str:
+        if not context.get("resume_patches"):
+            return (
+                "Create a tailored resume in Markdown format that emphasizes relevant "
+                "experience."
+            )
+        outline = parse_resume(context["resume"]).outline()
+        return f"""Do not rewrite the resume. Edit it with "resume_patches": a list of
+        {{"op": "replace" | "insert" | "delete", "target": "<bullet id>", "text": "...",
+        "reason": "..."}}. "replace" rewrites the target bullet, "insert" adds a bullet
+        after it, "delete" removes it. Leave bullets that already serve the role alone.
+        The resume with its bullet ids:
+        {outline}
+        """
+
+    @staticmethod
+    def _apply_patches(baseline: str, output: Dict[str, Any]) -> Dict[str, Any]:
+        """The tailored resume as the baseline with the checked patches applied."""
+        raw = output.get("resume_patches", output.get("patches"))
+        if not isinstance(raw, list):
+            raise ValidationError("Tailoring returned no resume_patches list")
+        document = parse_resume(baseline)
+        patches, problems = propose_patches(document, raw)
+        tailored = apply_patches(document, patches).render()
+        nested = output.get("tailored_output")
+        if isinstance(nested, dict):
+            nested["resume"] = tailored
+        return {
+            **output,
+            "tailored_resume": tailored,
+            "resume_patches": [patch.to_dict() for patch in patches],
+            "patch_problems": problems,
+        }
     
     def _validate_schema(self, data: Dict[str, Any]) -> None:
         """
@@ -0,0 +1,323 @@
+"""Application emails: draft after a run, send on approval, follow up later.
+
+A finished run can draft the email that goes with the application: a short note
+naming the role, from the candidate named in the résumé's header, with the rendered
+résumé (``resume.pdf`` when a theme produced one, else ``resume.md``) and the cover
+letter attached. The draft is saved in the run directory as ``application_email.eml``,
+which any mail client opens, and nothing is sent until ``hydra email RUN --send``.
+
+Sending goes through SMTP (``HYDRA_SMTP_HOST``, ``HYDRA_SMTP_PORT``,
+``HYDRA_SMTP_USER``, ``HYDRA_SMTP_PASSWORD``) or the Gmail API
+(``GMAIL_ACCESS_TOKEN``, an OAuth token with the ``gmail.send`` scope). A sent
+application records a follow-up date in the run's ``application.json``; as with
+debriefs, the run directory is the application record, and ``hydra followups`` lists
+the applications whose follow-up is due.
+"""
+
+from __future__ import annotations
+
+import base64
+import json
+import os
+import smtplib
+import urllib.error
+import urllib.request
+from dataclasses import asdict, dataclass
+from datetime import date, datetime, timedelta
+from email import message_from_bytes, policy
+from email.message import EmailMessage
+from email.utils import formataddr, make_msgid
+from pathlib import Path
+from typing import Any, Callable, Dict, List, Mapping, Optional, Tuple
+
+from runtime.crewai.artifacts import COVER_LETTER_FILE, MANIFEST_FILE, RESUME_FILE
+from runtime.crewai.ats_parse_check import parse_resume
+from runtime.crewai.encryption import read_bytes, read_text, write_bytes, write_text
+from runtime.crewai.resume_themes import PDF_FILE
+
+EMAIL_DRAFT_FILE = "application_email.eml"
+APPLICATION_FILE = "application.json"
+DEFAULT_FOLLOW_UP_DAYS = 7
+
+SMTP = "smtp"
+GMAIL = "gmail"
+TRANSPORTS = (SMTP, GMAIL)
+SMTP_HOST_ENV = "HYDRA_SMTP_HOST"
+SMTP_PORT_ENV = "HYDRA_SMTP_PORT"
+SMTP_USER_ENV = "HYDRA_SMTP_USER"
+SMTP_PASSWORD_ENV = "HYDRA_SMTP_PASSWORD"
+GMAIL_TOKEN_ENV = "GMAIL_ACCESS_TOKEN"
+GMAIL_SEND_URL = "https://gmail.googleapis.com/gmail/v1/users/me/messages/send"
+SEND_TIMEOUT_SECONDS = 30
+
+DRAFTED = "drafted"
+SENT = "sent"
+
+_CONTENT_TYPES = {".pdf": ("application", "pdf"), ".md": ("text", "markdown")}
+
+Sender = Callable[[EmailMessage], None]
+
+
+class EmailError(ValueError):
+    """Raised when an application email cannot be drafted or sent."""
+
+    pass
+
+
+@dataclass
+class Application:
+    """Where an application stands, kept in the run's ``application.json``."""
+
+    run_id: str
+    to: str
+    subject: str
+    company: Optional[str] = None
+    role: Optional[str] = None
+    status: str = DRAFTED
+    attachments: Tuple[str, ...] = ()
+    drafted_at: str = ""
+    sent_at: Optional[str] = None
+    via: Optional[str] = None
+    follow_up_on: Optional[str] = None
+    followed_up_at: Optional[str] = None
+
+    def to_dict(self) -> Dict[str, Any]:
+        data = asdict(self)
+        data["attachments"] = list(self.attachments)
+        return data
+
+    @classmethod
+    def from_dict(cls, raw: Dict[str, Any]) -> "Application":
+        known = {key: raw[key] for key in cls.__dataclass_fields__ if key in raw}
+        known["attachments"] = tuple(known.get("attachments") or ())
+        return cls(**known)
+
+    def to_manifest(self) -> Dict[str, Any]:
+        """Status and dates only — the address and the message stay out of run.json."""
+        return {
+            "status": self.status,
+            "sent_at": self.sent_at,
+            "via": self.via,
+            "follow_up_on": self.follow_up_on,
+            "followed_up_at": self.followed_up_at,
+        }
+
+
+def attachment_paths(run_dir: Path) -> List[Path]:
+    """The rendered résumé (PDF before Markdown) and the cover letter, as written."""
+    run_dir = Path(run_dir)
+    resume = next(
+        (run_dir / name for name in (PDF_FILE, RESUME_FILE) if (run_dir / name).is_file()), None
+    )
+    if resume is None:
+        raise EmailError(f"No résumé in {run_dir}: nothing to send")
+    paths = [resume]
+    if (run_dir / COVER_LETTER_FILE).is_file():
+        paths.append(run_dir / COVER_LETTER_FILE)
+    return paths
+
+
+def _attachment_name(path: Path, candidate: Optional[str]) -> str:
+    """``Jane Doe - resume.pdf`` rather than the run's file name, when the name is known."""
+    return f"{candidate} - {path.name}" if candidate else path.name
+
+
+def email_body(candidate: Optional[str], company: Optional[str], role: Optional[str]) -> str:
+    position = f"the {role} position" if role else "the open position"
+    at = f" at {company}" if company else ""
+    greeting = f"Dear {company} hiring team," if company else "Dear hiring team,"
+    return (
+        f"{greeting}\n\n"
+        f"Please find attached my résumé and cover letter for {position}{at}.\n\n"
+        "I would welcome the chance to discuss how I can contribute, and I look forward "
+        "to hearing from you.\n\n"
+        f"Best regards,\n{candidate or ''}\n"
+    )
+
+
+def draft_email(
+    run_dir: Path,
+    to: str,
+    sender: Optional[str] = None,
+    subject: Optional[str] = None,
+    now: Optional[datetime] = None,
+) -> Tuple[EmailMessage, Application]:
+    """The application email for the run in ``run_dir``, and its record.
+
+    The sender defaults to the name and address in the résumé's header.
+    """
+    run_dir = Path(run_dir)
+    if "@" not in to:
+        raise EmailError(f"Not an email address: {to!r}")
+    manifest = json.loads((run_dir / MANIFEST_FILE).read_text())
+    inputs = manifest.get("inputs") or {}
+    company, role = inputs.get("company"), inputs.get("role")
+    paths = attachment_paths(run_dir)
+    contact = parse_resume(read_text(run_dir / RESUME_FILE)).contact
+    candidate = contact.get("name")
+    sender = sender or contact.get("email")
+    if not sender:
+        raise EmailError("No sender: the résumé has no email address; pass --from")
+    subject = subject or " – ".join(
+        part for part in (f"Application: {role}" if role else "Application", candidate) if part
+    )
+
+    message = EmailMessage()
+    message["From"] = formataddr((candidate or "", sender)) if candidate else sender
+    message["To"] = to
+    message["Subject"] = subject
+    message["Message-ID"] = make_msgid()
+    message.set_content(email_body(candidate, company, role))
+    for path in paths:
+        maintype, subtype = _CONTENT_TYPES.get(path.suffix, ("application", "octet-stream"))
+        message.add_attachment(
+            read_bytes(path),
+            maintype=maintype,
+            subtype=subtype,
+            filename=_attachment_name(path, candidate),
+        )
+    application = Application(
+        run_id=manifest.get("run_id", run_dir.name),
+        to=to,
+        subject=subject,
+        company=company,
+        role=role,
+        attachments=tuple(path.name for path in paths),
+        drafted_at=(now or datetime.now()).isoformat(timespec="seconds"),
+    )
+    return message, application
+
+
+def save_draft(run_dir: Path, message: EmailMessage, application: Application) -> Path:
+    """Write the draft and its record into ``run_dir``; the draft's path."""
+    run_dir = Path(run_dir)
+    path = run_dir / EMAIL_DRAFT_FILE
+    write_bytes(path, message.as_bytes(policy=policy.SMTP))
+    save_application(run_dir, application)
+    return path
+
+
+def load_draft(run_dir: Path) -> EmailMessage:
+    path = Path(run_dir) / EMAIL_DRAFT_FILE
+    if not path.is_file():
+        raise EmailError(f"No email drafted for {run_dir}: run `hydra email` with --to first")
+    return message_from_bytes(read_bytes(path), policy=policy.default)
+
+
+def load_application(run_dir: Path) -> Optional[Application]:
+    path = Path(run_dir) / APPLICATION_FILE
+    if not path.is_file():
+        return None
+    return Application.from_dict(json.loads(read_text(path)))
+
+
+def save_application(run_dir: Path, application: Application) -> None:
+    write_text(Path(run_dir) / APPLICATION_FILE, json.dumps(application.to_dict(), indent=2))
+
+
+def smtp_sender(env: Mapping[str, str] = os.environ) -> Sender:
+    """Send through the SMTP server in ``HYDRA_SMTP_*`` (STARTTLS unless port 465)."""
+    host = env.get(SMTP_HOST_ENV)
+    if not host:
+        raise EmailError(f"{SMTP_HOST_ENV} not set")
+    port = int(env.get(SMTP_PORT_ENV) or 587)
+    user, password = env.get(SMTP_USER_ENV), env.get(SMTP_PASSWORD_ENV)
+
+    def send(message: EmailMessage) -> None:
+        connect = smtplib.SMTP_SSL if port == 465 else smtplib.SMTP
+        try:
+            with connect(host, port, timeout=SEND_TIMEOUT_SECONDS) as server:
+                if port != 465:
+                    server.starttls()
+                if user:
+                    server.login(user, password or "")
+                server.send_message(message)
+        except (smtplib.SMTPException, OSError) as e:
+            raise EmailError(f"SMTP {host}:{port}: {e}") from e
+
+    return send
+
+
+def gmail_sender(env: Mapping[str, str] = os.environ, post: Any = None) -> Sender:
+    """Send through the Gmail API with the OAuth token in ``GMAIL_ACCESS_TOKEN``.
+
+    ``post(url, body, headers)`` is the HTTP call; urllib by default.
+    """
+    token = env.get(GMAIL_TOKEN_ENV)
+    if not token:
+        raise EmailError(f"{GMAIL_TOKEN_ENV} not set")
+    post = post or _post
+
+    def send(message: EmailMessage) -> None:
+        raw = base64.urlsafe_b64encode(message.as_bytes(policy=policy.SMTP)).decode()
+        headers = {"Authorization": f"Bearer {token}", "Content-Type": "application/json"}
+        post(GMAIL_SEND_URL, json.dumps({"raw": raw}).encode(), headers)
+
+    return send
+
+
+def _post(url: str, body: bytes, headers: Dict[str, str]) -> None:
+    request = urllib.request.Request(url, data=body, headers=headers, method="POST")
+    try:
+        with urllib.request.urlopen(request, timeout=SEND_TIMEOUT_SECONDS):
+            pass
+    except urllib.error.HTTPError as e:
+        raise EmailError(f"Gmail API: HTTP {e.code} {e.reason}") from e
+    except (urllib.error.URLError, TimeoutError) as e:
+        raise EmailError(f"Gmail API: {getattr(e, 'reason', e)}") from e
+
+
+def transport_sender(via: str, env: Mapping[str, str] = os.environ) -> Sender:
+    if via == SMTP:
+        return smtp_sender(env)
+    if via == GMAIL:
+        return gmail_sender(env)
+    raise EmailError(f"Unknown transport '{via}' (one of: {', '.join(TRANSPORTS)})")
+
+
+def send_application(
+    run_dir: Path,
+    send: Sender,
+    via: str,
+    follow_up_days: int = DEFAULT_FOLLOW_UP_DAYS,
+    now: Optional[datetime] = None,
+) -> Application:
+    """Send the run's draft, then record it as sent with a follow-up date."""
+    application = load_application(run_dir)
+    message = load_draft(run_dir)
+    if application is None:
+        raise EmailError(f"No {APPLICATION_FILE} next to the draft in {run_dir}")
+    if application.status == SENT:
+        raise EmailError(f"Already sent to {application.to} on {application.sent_at}")
+    send(message)
+    now = now or datetime.now()
+    application.status = SENT
+    application.sent_at = now.isoformat(timespec="seconds")
+    application.via = via
+    application.follow_up_on = (now.date() + timedelta(days=follow_up_days)).isoformat()
+    save_application(run_dir, application)
+    return application
+
+
+def mark_followed_up(run_dir: Path, now: Optional[datetime] = None) -> Application:
+    application = load_application(run_dir)
+    if application is None or application.status != SENT:
+        raise EmailError(f"No sent application in {run_dir}")
+    application.followed_up_at = (now or datetime.now()).isoformat(timespec="seconds")
+    save_application(run_dir, application)
+    return application
+
+
+def follow_ups(
+    out_dir: Path, today: Optional[date] = None, include_upcoming: bool = False
+) -> List[Tuple[Path, Application]]:
+    """Sent applications in ``out_dir`` not yet followed up, due ones (or all) first."""
+    today = today or date.today()
+    found = []
+    for run_dir in sorted(Path(out_dir).glob(f"*/{APPLICATION_FILE}")):
+        application = load_application(run_dir.parent)
+        if application is None or application.status != SENT or application.followed_up_at:
+            continue
+        if include_upcoming or (application.follow_up_on or "") <= today.isoformat():
+            found.append((run_dir.parent, application))
+    return sorted(found, key=lambda item: item[1].follow_up_on or "")
@@ -0,0 +1,172 @@
+"""Earlier applications to the same company: warn before applying twice.
+
+The run directories in ``--out`` are the application tracker: each run records the
+company and role it was for in ``run.json``, a sent application its
+``application.json`` (see application_email), and ``hydra outcome RUN rejected``
+(or ``offer``, ``withdrawn``) what came of it, under ``outcome`` in ``run.json``.
+
+Before a run starts, the earlier runs for the same company are looked up. Two
+kinds are flagged:
+
+- a run for the company within the last ``--dedup-days`` days (default 30) — applying
+  again so soon is usually a mistake, or at least worth knowing about;
+- a run for the same role at the company that was rejected, however long ago.
+
+Company names match whatever their case, punctuation or legal suffix ("Acme, Inc."
+is Acme); roles match when most of their words do. The warning names each earlier
+run, how it stands, and the files in it worth reusing — its résumé and cover letter,
+its research, and its interview answers (``--reuse-interview``). With
+``--interactive`` the run asks whether to go ahead; otherwise it warns and goes on.
+``run.json`` lists the earlier runs found, by id and reason.
+"""
+
+from __future__ import annotations
+
+import json
+import re
+from dataclasses import dataclass, field
+from datetime import date, datetime
+from pathlib import Path
+from typing import Any, Dict, List, Optional
+
+from runtime.crewai.application_email import load_application
+from runtime.crewai.artifacts import (
+    COVER_LETTER_FILE,
+    MANIFEST_FILE,
+    RESEARCH_FILE,
+    RESUME_FILE,
+    record_artifacts,
+)
+from runtime.crewai.interview import TRANSCRIPT_FILE
+from runtime.crewai.retro_audit import run_date
+
+DEFAULT_DEDUP_DAYS = 30
+
+REJECTED = "rejected"
+OFFER = "offer"
+WITHDRAWN = "withdrawn"
+OUTCOMES = (REJECTED, OFFER, WITHDRAWN)
+
+# Why an earlier run is flagged.
+RECENT = "recent"
+REJECTED_ROLE = "rejected role"
+
+# What a new application may reuse from an earlier one, in this order.
+REUSABLE_FILES = (RESUME_FILE, COVER_LETTER_FILE, RESEARCH_FILE, TRANSCRIPT_FILE)
+# Share of role words two roles must have in common to count as the same role.
+SAME_ROLE_OVERLAP = 0.6
+
+_LEGAL_SUFFIXES = frozenset(
+    {"inc", "incorporated", "llc", "ltd", "limited", "gmbh", "ag", "sa", "plc", "corp", "co"}
+)
+
+
+def normalize_company(name: Optional[str]) -> str:
+    """``"Acme, Inc."`` -> ``"acme"``: case, punctuation and legal suffix dropped."""
+    words = re.findall(r"[a-z0-9]+", (name or "").lower())
+    while len(words) > 1 and words[-1] in _LEGAL_SUFFIXES:
+        words.pop()
+    return " ".join(words)
+
+
+def same_role(a: Optional[str], b: Optional[str]) -> bool:
+    """Whether two job titles name the same role: most of their words are shared."""
+    words_a = set(re.findall(r"[a-z0-9]+", (a or "").lower()))
+    words_b = set(re.findall(r"[a-z0-9]+", (b or "").lower()))
+    if not words_a or not words_b:
+        return False
+    return len(words_a & words_b) / len(words_a | words_b) >= SAME_ROLE_OVERLAP
+
+
+@dataclass
+class PriorApplication:
+    """An earlier run for the same company, why it is flagged, and what it left."""
+
+    run_id: str
+    run_dir: Path
+    reasons: List[str]
+    role: Optional[str] = None
+    started: Optional[date] = None
+    status: Optional[str] = None  # the run's status
+    application: Optional[str] = None  # drafted | sent, see application_email
+    outcome: Optional[str] = None
+    reusable: List[str] = field(default_factory=list)
+
+    def describe(self) -> str:
+        """``"Platform Engineer, 2026-10-01: sent, rejected"``."""
+        standing = [s for s in (self.application, self.outcome) if s] or [self.status or "run"]
+        when = self.started.isoformat() if self.started else "undated"
+        return f"{self.role or 'role unknown'}, {when}: {', '.join(standing)}"
+
+    def to_manifest(self) -> Dict[str, Any]:
+        return {"run_id": self.run_id, "reasons": self.reasons}
+
+
+def _manifest(run_dir: Path) -> Dict[str, Any]:
+    try:
+        data = json.loads((run_dir / MANIFEST_FILE).read_text())
+    except (OSError, ValueError):
+        return {}
+    return data if isinstance(data, dict) else {}
+
+
+def prior_applications(
+    out_dir: Path,
+    company: Optional[str],
+    role: Optional[str] = None,
+    days: int = DEFAULT_DEDUP_DAYS,
+    today: Optional[date] = None,
+) -> List[PriorApplication]:
+    """Earlier runs in ``out_dir`` for ``company`` that were recent or are a rejected
+    run for ``role``, newest first. ``days`` of 0 checks rejections only."""
+    wanted = normalize_company(company)
+    if not wanted or not Path(out_dir).is_dir():
+        return []
+    today = today or date.today()
+    found = []
+    for run_dir in Path(out_dir).iterdir():
+        manifest = _manifest(run_dir) if run_dir.is_dir() else {}
+        inputs = manifest.get("inputs") or {}
+        if normalize_company(inputs.get("company")) != wanted:
+            continue
+        started = run_date(run_dir.name)
+        outcome = (manifest.get("outcome") or {}).get("status")
+        reasons = []
+        if days > 0 and started is not None and (today - started).days <= days:
+            reasons.append(RECENT)
+        if outcome == REJECTED and same_role(role, inputs.get("role")):
+            reasons.append(REJECTED_ROLE)
+        if not reasons:
+            continue
+        try:
+            application = load_application(run_dir)
+        except (OSError, ValueError, TypeError):
+            application = None
+        found.append(
+            PriorApplication(
+                run_id=run_dir.name,
+                run_dir=run_dir,
+                reasons=reasons,
+                role=inputs.get("role"),
+                started=started,
+                status=manifest.get("status"),
+                application=application.status if application else None,
+                outcome=outcome,
+                reusable=[name for name in REUSABLE_FILES if (run_dir / name).is_file()],
+            )
+        )
+    return sorted(found, key=lambda prior: (prior.started or date.min, prior.run_id), reverse=True)
+
+
+def record_outcome(run_dir: Path, outcome: str, now: Optional[datetime] = None) -> Dict[str, str]:
+    """Record what came of an application in its ``run.json``; the outcome recorded."""
+    if outcome not in OUTCOMES:
+        raise ValueError(f"Unknown outcome {outcome!r} (expected one of {', '.join(OUTCOMES)})")
+    if not (Path(run_dir) / MANIFEST_FILE).is_file():
+        raise ValueError(f"No run in {run_dir}")
+    recorded = {
+        "status": outcome,
+        "recorded_at": (now or datetime.now()).isoformat(timespec="seconds"),
+    }
+    record_artifacts(run_dir, [], outcome=recorded)
+    return recorded
@@ -3,9 +3,14 @@
 A multi-agent run should leave an inspectable trail. This module centralizes the
 artifact filenames (previously string literals inside the CLI) and writes each run
 into its own ``output/<run_id>/`` directory so consecutive runs no longer clobber
-each other. Every run also emits a ``run.json`` manifest summarizing what happened —
-status, per-stage models, the executive decision, and the produced files — so a run
-can be understood without re-reading the whole log.
+each other; the run id leads with the company and role when they are known
+(``acme-platform-engineer-20260117-101500-1a2b3c4d``). Every run also emits a
+``run.json`` manifest summarizing what happened — status, per-stage models, the
+executive decision, and the produced files — so a run can be understood without
+re-reading the whole log. ``report.html`` renders the same manifest for a browser
+(see ``html_report``), including per-stage context-window usage. ``manifest.json``
+indexes every file in the run directory with its size and SHA-256, so a copied or
+archived run can be checked for missing or altered files.
 
 The manifest deliberately records input *sizes*, not input *content*: no résumé or
 job-description text is written to it.
@@ -13,21 +18,73 @@ job-description text is written to it.
 
 from __future__ import annotations
 
+import hashlib
 import json
+import re
+import unicodedata
 import uuid
 from dataclasses import dataclass
 from datetime import datetime
 from pathlib import Path
-from typing import Any, Iterable, Optional
+from typing import Any, Dict, Iterable, List, Optional, Tuple
 
 import yaml
 
+from runtime.crewai.ats_lint import manifest_summary as ats_lint_summary
+from runtime.crewai.ats_parse_check import ATS_PARSE_FILE
+from runtime.crewai.audit_log import AUDIT_LOG_FILE, append_events
+from runtime.crewai.chronology import manifest_summary as chronology_summary
+from runtime.crewai.compensation import NEGOTIATION_BRIEF_FILE, CompensationTargets, render_brief
+from runtime.crewai.compensation import manifest_summary as compensation_summary
+from runtime.crewai.contact_header import manifest_summary as contact_header_summary
+from runtime.crewai.contracts import GapReview
+from runtime.crewai.encryption import encryption_enabled, read_text, write_text
+from runtime.crewai.fit_score import FitAssessment
+from runtime.crewai.html_report import REPORT_HTML_FILE, render_report
+from runtime.crewai.impact import manifest_summary as impact_summary
+from runtime.crewai.interview import TRANSCRIPT_FILE, interview_summary, transcript
+from runtime.crewai.json_resume import JSON_RESUME_FILE
+from runtime.crewai.judge import manifest_summary as judge_summary
+from runtime.crewai.length_limits import manifest_summary as length_summary
+from runtime.crewai.output_codec import canonical, to_yaml
+from runtime.crewai.outreach import (
+    OUTREACH_FILE,
+    OUTREACH_VARIANTS_FILE,
+    option_lists,
+    render_outreach,
+    save_variants,
+    variants_document,
+)
+from runtime.crewai.outreach import manifest_summary as outreach_summary
+from runtime.crewai.prompt_transcript import PROMPT_TRANSCRIPT_FILE
+from runtime.crewai.referrals import REFERRALS_FILE, render_referrals
+from runtime.crewai.referrals import manifest_summary as referrals_summary
+from runtime.crewai.resume_diff import (
+    RESUME_DIFF_FILE,
+    RESUME_DIFF_HTML_FILE,
+    html_diff,
+    summarize,
+    unified_diff,
+)
+from runtime.crewai.resume_model import (
+    RESUME_PATCHES_FILE,
+    ResumePatch,
+    patch_counts,
+    save_patches,
+)
+from runtime.crewai.seniority import manifest_summary as seniority_summary
+from runtime.crewai.state_schema import STATE_VERSION
+from runtime.crewai.tools import TOOL_TRANSCRIPT_FILE
+
 RESUME_FILE = "resume.md"
 COVER_LETTER_FILE = "cover_letter.md"
 AUDIT_REPORT_FILE = "audit_report.yaml"
 EXECUTION_LOG_FILE = "execution_log.txt"
 MANIFEST_FILE = "run.json"
+ARTIFACT_INDEX_FILE = "manifest.json"
+RESEARCH_FILE = "research.json"
 INTERMEDIATE_DIR = "intermediate"
+VARIANTS_DIR = "variants"
 
 
 @dataclass
@@ -40,12 +97,188 @@ class RunInputs:
     jd_path: Optional[str] = None
     resume_path: Optional[str] = None
     sources_path: Optional[str] = None
+    company: Optional[str] = None  # the employer, not the candidate
+    role: Optional[str] = None  # the job title applied for
+    profile: Optional[str] = None  # the stored baseline résumé used (see profiles)
+    # With --jd-url: the posting and the board it was read from (see job_boards);
+    # jd_path is then the Markdown it was saved as.
+    jd_url: Optional[str] = None
+    jd_board: Optional[str] = None
+    # Stage plugins the run used, and the --plugin-dir directories (see plugins).
+    plugins: Optional[List[str]] = None
+    plugin_dirs: Optional[List[str]] = None
+    # With outreach in the template: who the messages are for (see outreach).
+    outreach_to: Optional[str] = None
+    # With --patches: tailoring edited the baseline bullet by bullet (see resume_model).
+    patches: bool = False
+    # With --contacts: the contacts file, so a resumed run reads it again.
+    contacts_path: Optional[str] = None
+    # With --reuse-interview: the earlier run whose interview answers were reused.
+    reuse_interview: Optional[str] = None
+    # With --constraints: the constraints file, so a resumed run checks them again.
+    constraints_path: Optional[str] = None
+    # With --lang: the language the documents were written in (see localization).
+    lang: Optional[str] = None
+    # With --cv-format: the regional CV format, and the --personal file (see cv_formats).
+    cv_format: Optional[str] = None
+    personal_path: Optional[str] = None
+    # With --deterministic: the seed every call was made with, at temperature 0.
+    seed: Optional[int] = None
+    # With --prompt-dir: the prompts that replaced the agents' own (see replay).
+    prompt_dir: Optional[str] = None
+    # A replay: the run whose stages before ``replay_from`` were reused.
+    replay_of: Optional[str] = None
+    replay_from: Optional[str] = None
+    # With --judge: the run ends by scoring the final résumé (see judge).
+    judge: bool = False
+    # With --prompt-pack NAME: the pack asked for, rather than detected (see prompt_packs).
+    prompt_pack: Optional[str] = None
+
+
+def translated_filename(filename: str, language: str) -> str:
+    """``resume.md`` + ``de`` -> ``resume.de.md``."""
+    stem, dot, suffix = filename.rpartition(".")
+    return f"{stem}.{language}.{suffix}" if dot else f"{filename}.{language}"
+
+
+# Longest company or role slug in a run id, so directory names stay readable.
+_SLUG_MAX = 40
+
+
+def slugify(text: Optional[str], max_length: int = _SLUG_MAX) -> str:
+    """``"Société Générale"`` -> ``"societe-generale"``; empty for no usable text."""
+    ascii_text = unicodedata.normalize("NFKD", text or "").encode("ascii", "ignore").decode()
+    slug = re.sub(r"[^a-z0-9]+", "-", ascii_text.lower()).strip("-")
+    if len(slug) > max_length:
+        slug = slug[:max_length].rsplit("-", 1)[0] or slug[:max_length]
+    return slug
+
 
+_LABEL_RE = re.compile(
+    r"^\W*(company|employer|organi[sz]ation|role|title|position|job title)[*_\s]*:[*_\s]*"
+    r"(.+?)[*_\s]*$",
+    re.IGNORECASE,
+)
+_HEADING_RE = re.compile(r"^#+\s+(.+?)\s*$")
+_LABEL_LINES = 15  # labels live in a job description's header, not its body
 
-def generate_run_id(now: Optional[datetime] = None) -> str:
-    """Return a sortable, unique run id: ``YYYYmmdd-HHMMSS-<8 hex>``."""
+
+def job_labels(job_description: str) -> tuple[Optional[str], Optional[str]]:
+    """Best-effort ``(company, role)`` from a job description's first lines.
+
+    Reads ``Company:``/``Role:``-style lines, falling back to the first Markdown
+    heading for the role. Only names the run directory; None when not found.
+    """
+    company = role = heading = None
+    for line in job_description.splitlines()[:_LABEL_LINES]:
+        label = _LABEL_RE.match(line)
+        if label:
+            key, value = label.group(1).lower(), label.group(2)
+            if key in ("company", "employer", "organisation", "organization"):
+                company = company or value
+            else:
+                role = role or value
+        elif heading is None and _HEADING_RE.match(line):
+            heading = _HEADING_RE.match(line).group(1)
+    return company, role or heading
+
+
+def generate_run_id(
+    now: Optional[datetime] = None, company: Optional[str] = None, role: Optional[str] = None
+) -> str:
+    """Return a unique run id: ``[<company>-][<role>-]YYYYmmdd-HHMMSS-<8 hex>``.
+
+    The company and role lead so a listing of ``output/`` reads as the applications;
+    the timestamp and random suffix keep every run's directory its own.
+    """
     stamp = (now or datetime.now()).strftime("%Y%m%d-%H%M%S")
-    return f"{stamp}-{uuid.uuid4().hex[:8]}"
+    labels = [slug for slug in (slugify(company), slugify(role)) if slug]
+    return "-".join([*labels, stamp, uuid.uuid4().hex[:8]])
+
+
+def _artifact_kind(name: str) -> str:
+    """What a file in a run directory is, for the artifact index."""
+    if name.startswith(f"{INTERMEDIATE_DIR}/"):
+        return "stage_output"
+    if name.startswith(f"{VARIANTS_DIR}/"):
+        return "variant"
+    if name in (EXECUTION_LOG_FILE, TOOL_TRANSCRIPT_FILE, PROMPT_TRANSCRIPT_FILE, AUDIT_LOG_FILE):
+        return "log"
+    if name == MANIFEST_FILE:
+        return "manifest"
+    if name.startswith(("resume.", "cover_letter.")) or name in (
+        NEGOTIATION_BRIEF_FILE,
+        OUTREACH_FILE,
+        REFERRALS_FILE,
+    ):
+        return "document"
+    return "report"
+
+
+def _sha256(path: Path) -> str:
+    digest = hashlib.sha256()
+    with open(path, "rb") as f:
+        for chunk in iter(lambda: f.read(1 << 16), b""):
+            digest.update(chunk)
+    return digest.hexdigest()
+
+
+def write_stage_output(run_dir: Path, stage: str, output: Any) -> Path:
+    """Write one stage's output to ``intermediate/<stage>.yaml``; its path."""
+    intermediate_dir = Path(run_dir) / INTERMEDIATE_DIR
+    intermediate_dir.mkdir(parents=True, exist_ok=True)
+    path = intermediate_dir / f"{stage}.yaml"
+    write_text(path, to_yaml(output))
+    return path
+
+
+def write_artifact_index(run_dir: Path) -> Path:
+    """(Re)write ``manifest.json``: every file in ``run_dir`` with its kind, size and hash.
+
+    Called whenever a run's files change — after the run, and by anything that adds
+    to or edits it later (a review, a debrief, a rendered theme, a status change).
+    """
+    run_dir = Path(run_dir)
+    files = []
+    for path in sorted(p for p in run_dir.rglob("*") if p.is_file()):
+        name = path.relative_to(run_dir).as_posix()
+        if name == ARTIFACT_INDEX_FILE:
+            continue
+        files.append(
+            {
+                "path": name,
+                "kind": _artifact_kind(name),
+                "bytes": path.stat().st_size,
+                "sha256": _sha256(path),
+            }
+        )
+    index = {
+        "run_id": run_dir.name,
+        "indexed_at": datetime.now().isoformat(timespec="seconds"),
+        "files": files,
+    }
+    path = run_dir / ARTIFACT_INDEX_FILE
+    path.write_text(json.dumps(index, indent=2))
+    return path
+
+
+def verify_artifact_index(run_dir: Path) -> Dict[str, list]:
+    """Files ``missing`` from, ``changed`` in or ``added`` to ``run_dir`` since indexing."""
+    run_dir = Path(run_dir)
+    index = json.loads((run_dir / ARTIFACT_INDEX_FILE).read_text())
+    indexed = {entry["path"]: entry["sha256"] for entry in index.get("files", [])}
+    present = {
+        path.relative_to(run_dir).as_posix(): path
+        for path in run_dir.rglob("*")
+        if path.is_file() and path.name != ARTIFACT_INDEX_FILE
+    }
+    return {
+        "missing": sorted(set(indexed) - set(present)),
+        "changed": sorted(
+            name for name in indexed if name in present and _sha256(present[name]) != indexed[name]
+        ),
+        "added": sorted(set(present) - set(indexed)),
+    }
 
 
 def _sanitize_warning(message: Any) -> str:
@@ -86,6 +319,9 @@ def build_manifest(run_id: str, result: Any, inputs: Optional[RunInputs] = None)
     # False, or a failed run with no audit would falsely claim the audit passed.
     final_status = audit_report.get("final_status")
     audit_passed = (final_status == "APPROVED") if final_status else None
+    verification = audit_report.get("claim_verification") or {}
+    lint = audit_report.get("ats_lint") or {}
+    chronology = audit_report.get("chronology") or {}
 
     manifest = {
         "run_id": run_id,
@@ -94,15 +330,63 @@ def build_manifest(run_id: str, result: Any, inputs: Optional[RunInputs] = None)
         "audit": {
             "final_status": final_status,
             "passed": audit_passed,
+            # Counts only; the claims themselves live in audit_report.yaml.
+            "claim_verification": {
+                "status": verification.get("status"),
+                "checked_claims": verification.get("checked_claims", 0),
+                "unverified_claims": len(verification.get("unverified_claims") or []),
+            }
+            if verification
+            else None,
+            # Rules broken and how often; the violations live in audit_report.yaml.
+            "ats_lint": ats_lint_summary(lint) if lint else None,
+            # Date discrepancies by kind; each, with its line, is in audit_report.yaml.
+            "chronology": chronology_summary(chronology) if chronology else None,
         },
         "decision": {
             "recommendation": decision.get("recommendation"),
             "fit_score": decision.get("fit_score"),
         },
         "models": getattr(result, "agent_models", None) or {},
+        # Sizes and key names only (see context_window.measure_usage) — no content.
+        "context_usage": getattr(result, "context_usage", None) or {},
+        # Token counts incl. provider prompt-cache reads and the estimated saving.
+        "usage": getattr(result, "usage", None) or {},
         "log_lines": len(list(log_lines)) if isinstance(log_lines, Iterable) else 0,
         "warnings": warnings,
     }
+    stage_providers = getattr(result, "stage_providers", None)
+    if stage_providers:
+        # Which provider actually served each stage, incl. failovers (see failover).
+        manifest["providers"] = stage_providers
+    stage_confidence = getattr(result, "stage_confidence", None)
+    if stage_confidence:
+        # What each stage reported, its threshold and any re-prompts (see confidence).
+        manifest["confidence"] = stage_confidence
+    reflection = getattr(result, "reflection", None)
+    if reflection:
+        # Critique-and-revise iterations per stage (see reflection).
+        manifest["reflection"] = reflection
+    cost_budget = getattr(result, "cost_budget", None)
+    if cost_budget:
+        # The spend budget, the estimated spend, and each stage's routing decision.
+        manifest["budget"] = cost_budget
+    template = getattr(result, "template", None)
+    if template:
+        # The workflow template and the stages it ran (see workflow_templates).
+        manifest["template"] = template
+    prompt_pack = getattr(result, "prompt_pack", None)
+    if prompt_pack:
+        # The profession's prompts the agents ran with, and why it was chosen.
+        manifest["prompt_pack"] = prompt_pack
+    constraints = getattr(result, "constraints", None)
+    if constraints:
+        # Constraint names only: the values (a salary floor) are personal.
+        manifest["constraints"] = constraints
+    pii_redaction = getattr(result, "pii_redaction", None)
+    if pii_redaction is not None:
+        # Counts only: how many contact details the providers saw as placeholders.
+        manifest["pii_redaction"] = pii_redaction
     if inputs is not None:
         manifest["inputs"] = {
             "job_description_chars": inputs.job_description_chars,
@@ -111,7 +395,33 @@ def build_manifest(run_id: str, result: Any, inputs: Optional[RunInputs] = None)
             "jd_path": inputs.jd_path,
             "resume_path": inputs.resume_path,
             "sources_path": inputs.sources_path,
+            "company": inputs.company,
+            "role": inputs.role,
+            "profile": inputs.profile,
+            "jd_url": inputs.jd_url,
+            "jd_board": inputs.jd_board,
+            "outreach_to": inputs.outreach_to,
+            "contacts_path": inputs.contacts_path,
+            "patches": inputs.patches,
+            "reuse_interview": inputs.reuse_interview,
+            "constraints_path": inputs.constraints_path,
+            "lang": inputs.lang,
+            "cv_format": inputs.cv_format,
+            "personal_path": inputs.personal_path,
+            "seed": inputs.seed,
+            "prompt_dir": inputs.prompt_dir,
+            "replay_of": inputs.replay_of,
+            "replay_from": inputs.replay_from,
+            "judge": inputs.judge,
         }
+    gap_analysis = (getattr(result, "intermediate_results", None) or {}).get("gap_analysis")
+    recommended = GapReview.from_raw(gap_analysis).recommended_profile
+    if recommended:
+        # The profile name only; the reason stays with the gap analysis.
+        manifest["recommended_profile"] = recommended
+    fit = gap_analysis.get("fit") if isinstance(gap_analysis, dict) else None
+    if isinstance(fit, dict) and fit.get("score") is not None:
+        manifest["fit"] = FitAssessment.from_dict(fit).to_manifest()
     return manifest
 
 
@@ -121,11 +431,30 @@ def write_run_artifacts(
     run_id: Optional[str] = None,
     inputs: Optional[RunInputs] = None,
     include_intermediate: bool = False,
+    translation: Any = None,
+    locale_policy: Any = None,
+    baseline_resume: Optional[str] = None,
+    source_documents: str = "",
 ) -> Path:
     """Write all artifacts for a run into ``base_dir/<run_id>/`` and return that dir.
 
     Always writes the manifest; writes documents/audit/log when present. Returns the
     run directory so callers can report exactly where the output landed.
+    ``translation`` (a ``translation.TranslationResult``) adds second-language copies
+    as ``resume.<lang>.md`` / ``cover_letter.<lang>.md``. ``locale_policy`` (a
+    ``locale_policy.PolicyReport``) is summarized in the manifest. Every candidate of a
+    parallel tailoring comparison is kept under ``variants/``. With ``baseline_resume``
+    the run also gets ``resume.diff`` / ``resume_diff.html`` against the final résumé;
+    ``source_documents`` lets the diff summary tell sourced numbers from new ones.
+    Cited company research, when the run did any, is written to ``research.json``; the
+    simulated ATS parse of the final résumé to ``ats_parse.json``; agents' tool calls
+    to ``tool_transcript.json``; every prompt and raw model answer to
+    ``prompt_transcript.json``; decisions and provider calls to ``audit_log.jsonl``
+    (appended); a tailored JSON Resume document to ``resume.json``;
+    the optional negotiation brief to ``negotiation_brief.md``; the optional outreach
+    messages to ``outreach.md`` and their ranked subject lines and openers to
+    ``outreach_variants.yaml``; the optional referral asks to ``referrals.md``; the
+    interview's answers to ``interview_transcript.json``.
     """
     run_id = run_id or generate_run_id()
     run_dir = Path(base_dir) / run_id
@@ -135,32 +464,278 @@ def write_run_artifacts(
     artifacts: list[str] = []
 
     if final_docs.get("resume") is not None:
-        (run_dir / RESUME_FILE).write_text(final_docs.get("resume", ""))
+        write_text(run_dir / RESUME_FILE, final_docs.get("resume", ""))
         artifacts.append(RESUME_FILE)
     if final_docs.get("cover_letter") is not None:
-        (run_dir / COVER_LETTER_FILE).write_text(final_docs.get("cover_letter", ""))
+        write_text(run_dir / COVER_LETTER_FILE, final_docs.get("cover_letter", ""))
         artifacts.append(COVER_LETTER_FILE)
 
+    if translation is not None:
+        for name, base in (("resume", RESUME_FILE), ("cover_letter", COVER_LETTER_FILE)):
+            if name in translation.documents:
+                filename = translated_filename(base, translation.language)
+                write_text(run_dir / filename, translation.documents[name])
+                artifacts.append(filename)
+
+    variants = getattr(result, "tailoring_variants", None) or []
+    for index, candidate in enumerate(variants, start=1):
+        docs = candidate.documents
+        prefix = f"{index:02d}-{candidate.slug}"
+        for suffix, text in (("resume", docs.resume), ("cover_letter", docs.cover_letter)):
+            if text:
+                filename = f"{VARIANTS_DIR}/{prefix}.{suffix}.md"
+                (run_dir / VARIANTS_DIR).mkdir(exist_ok=True)
+                write_text(run_dir / filename, text)
+                artifacts.append(filename)
+
+    diff_summary = None
+    if baseline_resume is not None and final_docs.get("resume"):
+        tailored = final_docs["resume"]
+        write_text(
+            run_dir / RESUME_DIFF_FILE,
+            unified_diff(baseline_resume, tailored, fromfile="baseline", tofile=RESUME_FILE),
+        )
+        write_text(run_dir / RESUME_DIFF_HTML_FILE, html_diff(baseline_resume, tailored))
+        artifacts.extend([RESUME_DIFF_FILE, RESUME_DIFF_HTML_FILE])
+        diff_summary = summarize(baseline_resume, tailored, source_documents)
+
+    ats_parse = getattr(result, "ats_parse", None)
+    if ats_parse:
+        write_text(run_dir / ATS_PARSE_FILE, json.dumps(ats_parse, indent=2, ensure_ascii=False))
+        artifacts.append(ATS_PARSE_FILE)
+
+    json_resume = getattr(result, "json_resume", None)
+    if json_resume:
+        write_text(
+            run_dir / JSON_RESUME_FILE, json.dumps(json_resume, indent=2, ensure_ascii=False)
+        )
+        artifacts.append(JSON_RESUME_FILE)
+
+    # With --patches: the tailoring edits, each to accept or reject (see resume_model).
+    tailoring = (getattr(result, "intermediate_results", None) or {}).get("tailoring") or {}
+    patches = tailoring.get("resume_patches")
+    if isinstance(patches, list):
+        patches = [ResumePatch.from_dict(patch) for patch in patches]
+        save_patches(run_dir, patches)
+        artifacts.append(RESUME_PATCHES_FILE)
+
+    # Company research is public information, kept with its sources and search log.
+    research = (getattr(result, "intermediate_results", None) or {}).get("research")
+    if research:
+        write_text(run_dir / RESEARCH_FILE, json.dumps(research, indent=2, ensure_ascii=False))
+        artifacts.append(RESEARCH_FILE)
+
+    # The negotiation brief holds the candidate's own numbers: the file, not run.json.
+    compensation = getattr(result, "compensation_brief", None)
+    if compensation:
+        targets = CompensationTargets.from_dict(compensation.get("targets"))
+        write_text(run_dir / NEGOTIATION_BRIEF_FILE, render_brief(compensation, targets))
+        artifacts.append(NEGOTIATION_BRIEF_FILE)
+
+    # Outreach messages and the ranked options, ready to copy; run.json keeps their sizes
+    # and the ranks chosen only.
+    outreach = getattr(result, "outreach", None)
+    if outreach:
+        write_text(run_dir / OUTREACH_FILE, render_outreach(outreach))
+        artifacts.append(OUTREACH_FILE)
+        if option_lists(outreach):
+            save_variants(run_dir, variants_document(outreach, run_id))
+            artifacts.append(OUTREACH_VARIANTS_FILE)
+
+    # The interview's answers, for --reuse-interview in a later run.
+    interrogation = (getattr(result, "intermediate_results", None) or {}).get(
+        "interrogation"
+    ) or {}
+    experience = interrogation.get("experience") if isinstance(interrogation, dict) else None
+    if experience:
+        write_text(
+            run_dir / TRANSCRIPT_FILE,
+            json.dumps(transcript(experience, run_id), indent=2, ensure_ascii=False),
+        )
+        artifacts.append(TRANSCRIPT_FILE)
+
+    # Referral asks name the candidate's contacts: the file, not run.json.
+    referrals = getattr(result, "referrals", None)
+    if referrals:
+        write_text(run_dir / REFERRALS_FILE, render_referrals(referrals))
+        artifacts.append(REFERRALS_FILE)
+
+    # Every tool call agents made, with arguments and results, per stage.
+    tool_transcripts = getattr(result, "tool_transcripts", None)
+    if tool_transcripts:
+        write_text(
+            run_dir / TOOL_TRANSCRIPT_FILE,
+            json.dumps(tool_transcripts, indent=2, ensure_ascii=False, default=str),
+        )
+        artifacts.append(TOOL_TRANSCRIPT_FILE)
+
+    # Every model call: the prompts sent and the raw answers, per stage.
+    prompt_transcripts = getattr(result, "prompt_transcripts", None)
+    if prompt_transcripts:
+        write_text(
+            run_dir / PROMPT_TRANSCRIPT_FILE,
+            json.dumps(prompt_transcripts, indent=2, ensure_ascii=False, default=str),
+        )
+        artifacts.append(PROMPT_TRANSCRIPT_FILE)
+
+    # Decisions and provider calls, appended to what earlier runs of it logged.
+    if append_events(run_dir / AUDIT_LOG_FILE, getattr(result, "audit_events", None) or []):
+        artifacts.append(AUDIT_LOG_FILE)
+
     audit_report = getattr(result, "audit_report", None)
     if audit_report is not None:
-        (run_dir / AUDIT_REPORT_FILE).write_text(yaml.safe_dump(audit_report, sort_keys=False))
+        write_text(run_dir / AUDIT_REPORT_FILE, to_yaml(audit_report))
         artifacts.append(AUDIT_REPORT_FILE)
 
     log_lines = getattr(result, "execution_log", None) or []
     if isinstance(log_lines, Iterable):
-        (run_dir / EXECUTION_LOG_FILE).write_text("\n".join(log_lines))
+        write_text(run_dir / EXECUTION_LOG_FILE, "\n".join(log_lines))
         artifacts.append(EXECUTION_LOG_FILE)
 
-    if include_intermediate and getattr(result, "intermediate_results", None):
-        intermediate_dir = run_dir / INTERMEDIATE_DIR
-        intermediate_dir.mkdir(parents=True, exist_ok=True)
+    checkpoint = include_intermediate and bool(getattr(result, "intermediate_results", None))
+    if checkpoint:
         for stage_name, stage_result in result.intermediate_results.items():
-            (intermediate_dir / f"{stage_name}.yaml").write_text(
-                yaml.safe_dump(stage_result, sort_keys=False, default_flow_style=False)
-            )
+            write_stage_output(run_dir, stage_name, stage_result)
 
     manifest = build_manifest(run_id, result, inputs)
+    if checkpoint:
+        # What --resume-run needs to upgrade the stage outputs (see state_schema).
+        manifest["state_version"] = STATE_VERSION
+    if encryption_enabled():
+        # So a resumed run keeps encrypting; the manifest itself stays readable.
+        manifest["encrypted"] = True
+    if translation is not None:
+        manifest["translation"] = {
+            "language": translation.language,
+            "provider": translation.provider,
+            "glossary_terms": translation.glossary_terms,
+            "glossary_issues": len(translation.glossary_issues),
+        }
+    if isinstance(patches, list):
+        manifest["resume_patches"] = {
+            **patch_counts(patches),
+            "dropped": len(tailoring.get("patch_problems") or []),
+        }
+    if ats_parse:
+        # Field names and counts only; the extracted values are personal data.
+        manifest["ats_parse"] = {
+            "score": ats_parse.get("score"),
+            "missing_fields": ats_parse.get("missing_fields", []),
+            "sections": ats_parse.get("sections", []),
+            "hazards": len(ats_parse.get("hazards") or []),
+        }
+        if ats_parse.get("jd_skills"):
+            jd_skills = ats_parse["jd_skills"]
+            manifest["ats_parse"]["jd_skills"] = {key: len(jd_skills[key]) for key in jd_skills}
+    if research:
+        manifest["research"] = {
+            "provider": research.get("search_provider"),
+            "searches": len(research.get("searches") or []),
+            "sources": len(research.get("sources") or []),
+            "uncited_dropped": research.get("uncited_dropped", 0),
+        }
+    if compensation:
+        manifest["compensation"] = compensation_summary(compensation)
+    if isinstance(interrogation, dict) and (
+        interrogation.get("questions") or interrogation.get("reused_answers")
+    ):
+        manifest["interview"] = interview_summary(interrogation)
+    seniority = getattr(result, "seniority", None)
+    if seniority:
+        manifest["seniority"] = seniority_summary(seniority)
+    impact = getattr(result, "impact", None)
+    if impact:
+        manifest["impact"] = impact_summary(impact, interrogation.get("interview_notes"))
+    if outreach:
+        manifest["outreach"] = outreach_summary(outreach)
+    if referrals:
+        manifest["referrals"] = referrals_summary(referrals)
+    judgement = getattr(result, "judge", None)
+    if judgement:
+        manifest["judge"] = judge_summary(judgement)
+    if tool_transcripts:
+        # Tool names and counts only; arguments and results can hold résumé text.
+        manifest["tool_calls"] = {
+            stage: {
+                "calls": len(calls),
+                "errors": sum(1 for call in calls if "error" in call),
+                "tools": sorted({call["tool"] for call in calls}),
+            }
+            for stage, calls in tool_transcripts.items()
+        }
+    latency_budget = getattr(result, "latency_budget", None)
+    if latency_budget:
+        # Stage names and seconds only.
+        manifest["latency_budget"] = latency_budget
+    errors = getattr(result, "errors", None)
+    if errors:
+        # Stage, scope and limit only (see hydra_workflow.WorkflowError).
+        manifest["errors"] = errors
+    retention = getattr(result, "retention", None)
+    if retention:
+        # Stage names and counts only.
+        manifest["retention"] = retention
+    overlap = getattr(result, "cover_letter_overlap", None)
+    if overlap:
+        manifest["cover_letter_overlap"] = {
+            "compared_letters": overlap.get("compared_letters", 0),
+            "initial_overlaps": overlap.get("initial_overlaps", 0),
+            "rewrites": overlap.get("rewrites", 0),
+            "remaining_overlaps": len(overlap.get("overlaps") or []),
+        }
+    length = getattr(result, "length", None)
+    if length:
+        manifest["length"] = length_summary(length)
+    contact_header = getattr(result, "contact_header", None)
+    if contact_header:
+        manifest["contact_header"] = contact_header_summary(contact_header)
+    if diff_summary is not None:
+        manifest["resume_diff"] = diff_summary.to_manifest()
+    if variants:
+        manifest["tailoring_variants"] = [candidate.summary() for candidate in variants]
+    if locale_policy is not None:
+        manifest["locale_policy"] = {
+            "country": locale_policy.country,
+            "removed": list(locale_policy.removed),
+            "warnings": len(locale_policy.warnings),
+        }
+    artifacts.append(REPORT_HTML_FILE)
     manifest["artifacts"] = artifacts
     (run_dir / MANIFEST_FILE).write_text(json.dumps(manifest, indent=2, default=str))
+    (run_dir / REPORT_HTML_FILE).write_text(render_report(manifest))
+    write_artifact_index(run_dir)
 
     return run_dir
+
+
+def record_artifacts(run_dir: Path, files: Iterable[str], **sections: Any) -> None:
+    """Add files written after the run (a rendered theme, say) to its manifest and report.
+
+    ``sections`` are merged into the manifest as top-level keys.
+    """
+    run_dir = Path(run_dir)
+    manifest = json.loads((run_dir / MANIFEST_FILE).read_text())
+    artifacts = manifest.setdefault("artifacts", [])
+    artifacts += [name for name in files if name not in artifacts]
+    manifest.update(sections)
+    (run_dir / MANIFEST_FILE).write_text(json.dumps(manifest, indent=2, default=str))
+    (run_dir / REPORT_HTML_FILE).write_text(render_report(manifest))
+    write_artifact_index(run_dir)
+
+
+def load_checkpoint(run_dir: Path) -> Tuple[Dict[str, Any], Optional[int]]:
+    """Stage outputs kept in ``run_dir/intermediate/`` and the state version they are at.
+
+    The version is None for runs written before the manifest recorded it.
+    """
+    run_dir = Path(run_dir)
+    results: Dict[str, Any] = {}
+    intermediate_dir = run_dir / INTERMEDIATE_DIR
+    if intermediate_dir.is_dir():
+        for path in sorted(intermediate_dir.glob("*.yaml")):
+            results[path.stem] = canonical(yaml.safe_load(read_text(path)))
+    try:
+        manifest = json.loads((run_dir / MANIFEST_FILE).read_text())
+    except (OSError, ValueError):
+        manifest = {}
+    return results, manifest.get("state_version")
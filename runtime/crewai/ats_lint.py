@@ -0,0 +1,226 @@
+"""ATS lint: the tricks and layouts that get a résumé rejected, caught before it ships.
+
+The ATS Optimizer is asked not to game the tracking system; this check makes sure it
+did not. After the audit and the claim check, the final (ATS-optimized) documents are
+read line by line against fixed rules:
+
+- **hidden text** — white or zero-size text, ``display: none``, HTML comments and
+  zero-width characters: invisible to the recruiter, read by the ATS, and treated as
+  fraud by the ones that detect it;
+- **keyword stuffing** — one skill named more than ``MAX_MENTIONS`` times in a
+  document, or ``MAX_MENTIONS_PER_LINE`` times on one line, under any of its names
+  (see skill_taxonomy);
+- **layout** — tables, column-aligned text and images, which parsers scramble or drop
+  (detected as in ats_parse_check);
+- **section headers** — résumé sections under a heading an ATS does not map to one of
+  its own ("Where I've Made an Impact" instead of "Experience").
+
+Each violation is listed with its document and line in ``audit_report.yaml`` under
+``ats_lint``, and any violation fails the audit: the run ends as "completed with
+audit concerns", with the documents written for review. Unlike the claim check
+there is no override; the fix is in the documents.
+"""
+
+from __future__ import annotations
+
+import re
+from dataclasses import asdict, dataclass, field
+from typing import Any, Dict, List, Optional
+
+from runtime.crewai.ats_parse_check import parse_resume, section_for
+from runtime.crewai.skill_taxonomy import SkillTaxonomy, default_taxonomy
+
+PASSED = "PASSED"
+FAILED = "FAILED"
+
+HIDDEN_TEXT = "hidden_text"
+KEYWORD_STUFFING = "keyword_stuffing"
+LAYOUT = "layout"
+SECTION_HEADER = "section_header"
+
+# More mentions of one skill than this read as stuffing, not as experience.
+MAX_MENTIONS = 8
+MAX_MENTIONS_PER_LINE = 2
+# Hazards from the ATS parse check that break parsing outright.
+LAYOUT_HAZARDS = ("table", "columns", "image")
+# Conventional headings an ATS files under "additional information" rather than
+# dropping; the ones it maps to its own sections are in ats_parse_check.
+OTHER_SECTIONS = (
+    "awards",
+    "honors",
+    "publications",
+    "patents",
+    "volunteer",
+    "volunteering",
+    "languages",
+    "interests",
+    "leadership",
+    "affiliations",
+    "memberships",
+    "training",
+    "courses",
+    "references",
+    "contact",
+    "additional information",
+)
+
+_HIDDEN_STYLE_RE = re.compile(
+    r"(?<![\w-])color\s*[:=]\s*[\"']?\s*"  # not background-color
+    r"(?:white\b|#f{3}(?:f{3})?\b|rgba?\(\s*255\s*,\s*255\s*,\s*255)"
+    r"|font-size\s*:\s*0*(?:\.\d+|[01])(?:px|pt|em|rem)?\b"
+    r"|display\s*:\s*none|visibility\s*:\s*hidden|opacity\s*:\s*0(?:\.0+)?\b"
+    r"|\\(?:text)?color\{white\}",
+    re.IGNORECASE,
+)
+_COMMENT_RE = re.compile(r"<!--.*?(?:-->|$)")
+_ZERO_WIDTH_RE = re.compile("[\u200b\u200c\u200d\u2060\ufeff]")
+# Section headings: Markdown levels 1-2 (role headings below them are entries).
+_SECTION_HEADING_RE = re.compile(r"^\s*#{1,2}\s+(?P<title>.+?)\s*#*\s*$")
+
+
+@dataclass
+class Violation:
+    """One rule broken, and where."""
+
+    rule: str
+    document: str
+    line: int
+    detail: str
+
+    @property
+    def location(self) -> str:
+        return f"{self.document}, line {self.line}"
+
+
+@dataclass
+class LintReport:
+    """Every rule the final documents break; any violation fails the audit."""
+
+    violations: List[Violation] = field(default_factory=list)
+
+    @property
+    def status(self) -> str:
+        return FAILED if self.violations else PASSED
+
+    @property
+    def blocking(self) -> bool:
+        return bool(self.violations)
+
+    def to_dict(self) -> Dict[str, Any]:
+        return {
+            "status": self.status,
+            "violations": [
+                {**asdict(violation), "location": violation.location}
+                for violation in self.violations
+            ],
+        }
+
+
+def _hidden_text(name: str, number: int, line: str) -> List[Violation]:
+    found: List[Violation] = []
+    style = _HIDDEN_STYLE_RE.search(line)
+    if style:
+        found.append(
+            Violation(HIDDEN_TEXT, name, number, f"'{style.group(0)}' hides text from readers")
+        )
+    if _COMMENT_RE.search(line):
+        found.append(
+            Violation(HIDDEN_TEXT, name, number, "an HTML comment is invisible but parsed")
+        )
+    if _ZERO_WIDTH_RE.search(line):
+        found.append(
+            Violation(HIDDEN_TEXT, name, number, "zero-width characters hide or split words")
+        )
+    return found
+
+
+def _stuffing(name: str, text: str, taxonomy: SkillTaxonomy) -> List[Violation]:
+    found: List[Violation] = []
+    flagged = set()
+    for number, line in enumerate(text.splitlines(), start=1):
+        for skill, count in taxonomy.counts(line).items():
+            if count > MAX_MENTIONS_PER_LINE:
+                flagged.add(skill)
+                found.append(
+                    Violation(KEYWORD_STUFFING, name, number, f"'{skill}' {count} times")
+                )
+    for skill, count in taxonomy.counts(text).items():
+        if count > MAX_MENTIONS and skill not in flagged:
+            first = next(
+                number
+                for number, line in enumerate(text.splitlines(), start=1)
+                if skill in taxonomy.counts(line)
+            )
+            found.append(
+                Violation(
+                    KEYWORD_STUFFING,
+                    name,
+                    first,
+                    f"'{skill}' {count} times in the document (at most {MAX_MENTIONS})",
+                )
+            )
+    return found
+
+
+def _section_headers(name: str, text: str) -> List[Violation]:
+    found: List[Violation] = []
+    lines = enumerate(text.splitlines(), start=1)
+    body = [(number, line) for number, line in lines if line.strip()][1:]  # not the name
+    for number, line in body:
+        heading = _SECTION_HEADING_RE.match(line)
+        if not heading:
+            continue
+        title = re.sub(r"[*_`]+", "", heading.group("title")).strip().strip(":")
+        key = title.lower()
+        if section_for(title) is None and not any(
+            key == other or key.startswith(other + " ") for other in OTHER_SECTIONS
+        ):
+            found.append(
+                Violation(
+                    SECTION_HEADER,
+                    name,
+                    number,
+                    f"'{title}' is not a section an ATS recognises",
+                )
+            )
+    return found
+
+
+def lint_document(
+    text: str, name: str = "resume", taxonomy: Optional[SkillTaxonomy] = None
+) -> List[Violation]:
+    """The rules ``text`` breaks; section headers are checked for the résumé only."""
+    text = text or ""
+    taxonomy = taxonomy or default_taxonomy()
+    violations: List[Violation] = []
+    for number, line in enumerate(text.splitlines(), start=1):
+        violations += _hidden_text(name, number, line)
+    violations += _stuffing(name, text, taxonomy)
+    violations += [
+        Violation(LAYOUT, name, hazard.line, f"{hazard.kind}: {hazard.detail}")
+        for hazard in parse_resume(text).hazards
+        if hazard.kind in LAYOUT_HAZARDS
+    ]
+    if name == "resume":
+        violations += _section_headers(name, text)
+    return sorted(violations, key=lambda violation: violation.line)
+
+
+def lint_documents(
+    documents: Dict[str, str], taxonomy: Optional[SkillTaxonomy] = None
+) -> LintReport:
+    """Lint every document by name (``resume``, ``cover_letter``)."""
+    taxonomy = taxonomy or default_taxonomy()
+    report = LintReport()
+    for name, text in documents.items():
+        if isinstance(text, str) and text:
+            report.violations += lint_document(text, name, taxonomy)
+    return report
+
+
+def manifest_summary(report: Dict[str, Any]) -> Dict[str, Any]:
+    """What ``run.json`` keeps of a report dict: the status and the rules broken, counted."""
+    rules: Dict[str, int] = {}
+    for violation in report.get("violations") or []:
+        rules[violation["rule"]] = rules.get(violation["rule"], 0) + 1
+    return {"status": report.get("status"), "violations": rules}
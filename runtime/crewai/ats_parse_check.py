@@ -0,0 +1,252 @@
+"""ATS simulation: parse the final résumé the way an applicant-tracking system would.
+
+The ATS Optimizer *writes for* an ATS; this stage *reads like* one. The résumé is
+flattened to plain text (as an ATS does on upload) and run through a deliberately
+naive, rule-based parser modelled on common ATS behaviour:
+
+- contact fields — name (first line), email, phone, LinkedIn URL;
+- sections — recognised only under conventional headings (Experience, Education,
+  Skills, ...); a creative heading like "Where I've Made an Impact" is not a section;
+- experience entries — a role line with a parseable date range;
+- skills — comma/pipe separated items under a skills heading.
+
+It also flags layout choices that commonly destroy parseability: tables and
+multi-column text, images, HTML, contact details only present inside a link, icon
+glyphs used as labels, and dates an ATS cannot read. The report says what an ATS
+would actually extract, so missing fields are visible before a recruiter's system
+silently drops them. It is advisory: it never changes the documents or the run status.
+
+Given the job description, it also matches the JD's skills against the résumé via the
+skill taxonomy (see skill_taxonomy): skills found as written, skills the résumé only
+has under another name (an ATS matching keywords literally may miss "Kubernetes" when
+the posting says "K8s"), and skills not found at all.
+"""
+
+from __future__ import annotations
+
+import re
+from dataclasses import asdict, dataclass, field
+from typing import Any, Dict, List, Optional
+
+from runtime.crewai.skill_taxonomy import SkillTaxonomy, default_taxonomy, skill_coverage
+
+ATS_PARSE_FILE = "ats_parse.json"
+
+CONTACT_FIELDS = ("name", "email", "phone", "linkedin")
+# Conventional headings an ATS maps to its sections (lower-case, prefix match).
+SECTION_ALIASES: Dict[str, tuple] = {
+    "summary": ("summary", "professional summary", "profile", "objective", "about"),
+    "experience": (
+        "experience",
+        "work experience",
+        "professional experience",
+        "employment",
+        "work history",
+        "career history",
+    ),
+    "education": ("education", "academic"),
+    "skills": ("skills", "technical skills", "competencies", "core competencies", "technologies"),
+    "certifications": ("certifications", "certificates", "licenses"),
+    "projects": ("projects",),
+}
+REQUIRED_SECTIONS = ("experience", "education", "skills")
+
+_EMAIL_RE = re.compile(r"[\w.+-]+@[\w-]+(?:\.[\w-]+)+")
+_PHONE_RE = re.compile(
+    r"(?:\+\d{1,3}[\s.-]?)?(?:\(\d{2,4}\)[\s.-]?)?\d{2,4}[\s.-]\d{3,4}[\s.-]?\d{0,4}"
+)
+_LINKEDIN_RE = re.compile(r"(?:https?://)?(?:[a-z]{2,3}\.)?linkedin\.com/in/[\w-]+", re.I)
+_MONTHS = r"(?:jan|feb|mar|apr|may|jun|jul|aug|sep|sept|oct|nov|dec)[a-z]*\.?"
+_DATE = rf"(?:{_MONTHS}\s+\d{{4}}|\d{{1,2}}/\d{{4}}|\d{{4}}-\d{{2}}|\d{{4}})"
+_DATE_RANGE_RE = re.compile(rf"{_DATE}\s*(?:-|–|—|to)\s*(?:{_DATE}|present|current|now)", re.I)
+# Dates an ATS commonly fails on: apostrophe years and season names.
+_BAD_DATE_RE = re.compile(
+    r"['’]\d{2}\b|\b(?:spring|summer|fall|autumn|winter)\s+\d{4}\b", re.I
+)
+_MD_LINK_RE = re.compile(r"\[([^\]]*)\]\(([^)]*)\)")
+_MD_IMAGE_RE = re.compile(r"!\[[^\]]*\]\([^)]*\)")
+_HTML_RE = re.compile(r"</?[a-zA-Z][^>]*>")
+_HEADING_RE = re.compile(r"^\s*(?:#{1,6}\s+(?P<md>.+?)|\*\*(?P<bold>[^*]+)\*\*\s*:?)\s*$")
+_ICON_RE = re.compile("[☀-➿\U0001f300-\U0001faff]")
+_TABLE_RE = re.compile(r"^\s*\|.*\|\s*$")
+
+
+@dataclass
+class Hazard:
+    """A layout choice likely to break ATS parsing, with where it occurs."""
+
+    kind: str
+    line: int
+    detail: str
+
+
+@dataclass
+class ATSParseReport:
+    """What a naive ATS would extract from the résumé, and what got in its way."""
+
+    contact: Dict[str, Optional[str]] = field(default_factory=dict)
+    sections: List[str] = field(default_factory=list)
+    unrecognised_headings: List[str] = field(default_factory=list)
+    experience_entries: List[Dict[str, Any]] = field(default_factory=list)
+    skills: List[str] = field(default_factory=list)
+    hazards: List[Hazard] = field(default_factory=list)
+    # The JD's skills against the résumé's, when parsed with a job description.
+    jd_skills: Optional[Dict[str, Any]] = None
+
+    @property
+    def missing_fields(self) -> List[str]:
+        missing = [name for name in CONTACT_FIELDS if not self.contact.get(name)]
+        missing += [f"section:{name}" for name in REQUIRED_SECTIONS if name not in self.sections]
+        if "experience" in self.sections and not self.experience_entries:
+            missing.append("experience:dated_entries")
+        return missing
+
+    @property
+    def score(self) -> int:
+        """0-100: share of expected fields extracted, less 5 per hazard."""
+        expected = len(CONTACT_FIELDS) + len(REQUIRED_SECTIONS) + 1
+        found = expected - len(self.missing_fields)
+        return max(0, round(100 * found / expected) - 5 * len(self.hazards))
+
+    def to_dict(self) -> Dict[str, Any]:
+        report = {
+            "score": self.score,
+            "missing_fields": self.missing_fields,
+            "contact": self.contact,
+            "sections": self.sections,
+            "unrecognised_headings": self.unrecognised_headings,
+            "experience_entries": self.experience_entries,
+            "skills": self.skills,
+            "hazards": [asdict(h) for h in self.hazards],
+        }
+        if self.jd_skills is not None:
+            report["jd_skills"] = self.jd_skills
+        return report
+
+    def to_manifest(self) -> Dict[str, Any]:
+        """Field names and counts only — extracted values are personal data."""
+        return {
+            "score": self.score,
+            "missing_fields": self.missing_fields,
+            "sections": self.sections,
+            "experience_entries": len(self.experience_entries),
+            "skills": len(self.skills),
+            "hazards": len(self.hazards),
+            **(
+                {"jd_skills": {key: len(items) for key, items in self.jd_skills.items()}}
+                if self.jd_skills is not None
+                else {}
+            ),
+        }
+
+
+def section_for(heading: str) -> Optional[str]:
+    """The ATS section a heading maps to, or None for a heading an ATS does not know."""
+    key = heading.strip().strip(":").lower()
+    for section, aliases in SECTION_ALIASES.items():
+        if any(key == alias or key.startswith(alias + " ") for alias in aliases):
+            return section
+    return None
+
+
+def to_plain_text(line: str) -> str:
+    """What survives an ATS's text extraction: link *text* stays, targets are lost."""
+    line = _MD_IMAGE_RE.sub("", line)
+    line = _MD_LINK_RE.sub(lambda m: m.group(1), line)
+    line = _HTML_RE.sub("", line)
+    line = re.sub(r"[*_`#>]+", "", line)
+    return line.strip()
+
+
+def _hazards(number: int, raw: str, plain: str, previous: str) -> List[Hazard]:
+    found: List[Hazard] = []
+    if _TABLE_RE.match(raw) and not _TABLE_RE.match(previous):  # once per table
+        found.append(Hazard("table", number, "tables are often read cell-by-cell or dropped"))
+    if _MD_IMAGE_RE.search(raw):
+        found.append(Hazard("image", number, "images carry no extractable text"))
+    if _HTML_RE.search(raw):
+        found.append(Hazard("html", number, "raw HTML is stripped or shown literally"))
+    if "\t" in raw or re.search(r"\S {4,}\S", raw):
+        found.append(Hazard("columns", number, "column-aligned text merges across columns"))
+    if _ICON_RE.search(raw):
+        found.append(Hazard("icon", number, "icon glyphs used as labels are not read"))
+    for match in _MD_LINK_RE.finditer(raw):
+        text, target = match.group(1), match.group(2)
+        hidden = (_EMAIL_RE.search(target) and not _EMAIL_RE.search(text)) or (
+            _LINKEDIN_RE.search(target) and not _LINKEDIN_RE.search(text)
+        )
+        if hidden:
+            found.append(Hazard("hidden_contact", number, f"'{text}' hides its link target"))
+    if _BAD_DATE_RE.search(plain):
+        found.append(Hazard("date_format", number, "use 'Mon YYYY' or 'YYYY' dates"))
+    return found
+
+
+def parse_resume(
+    text: str,
+    job_description: Optional[str] = None,
+    taxonomy: Optional[SkillTaxonomy] = None,
+) -> ATSParseReport:
+    """Simulate an ATS parse of a Markdown/plain-text résumé.
+
+    With ``job_description`` the report also matches the JD's skills (``jd_skills``),
+    normalized by ``taxonomy`` (default: built-in plus the user's skills.yaml).
+    """
+    report = ATSParseReport(contact={name: None for name in CONTACT_FIELDS})
+    section: Optional[str] = None
+    previous = ""
+    for number, raw in enumerate((text or "").splitlines(), start=1):
+        plain = to_plain_text(raw)
+        report.hazards.extend(_hazards(number, raw, plain, previous))
+        previous = raw
+        if not plain:
+            continue
+        if report.contact["name"] is None:
+            report.contact["name"] = plain
+            continue
+
+        heading = _HEADING_RE.match(raw)
+        if heading:
+            title = to_plain_text(heading.group("md") or heading.group("bold"))
+            mapped = section_for(title)
+            if mapped:
+                section = mapped
+                if mapped not in report.sections:
+                    report.sections.append(mapped)
+            elif section is None or not _DATE_RANGE_RE.search(title):
+                # A dated bold line inside Experience is a role line, not a heading.
+                report.unrecognised_headings.append(title)
+                section = None
+                continue
+
+        for name, pattern in (("email", _EMAIL_RE), ("linkedin", _LINKEDIN_RE)):
+            if report.contact[name] is None and pattern.search(plain):
+                report.contact[name] = pattern.search(plain).group(0)
+        if report.contact["phone"] is None and section is None:
+            phone = _PHONE_RE.search(_DATE_RANGE_RE.sub("", plain))
+            if phone and len(re.sub(r"\D", "", phone.group(0))) >= 7:
+                report.contact["phone"] = phone.group(0).strip()
+
+        if section == "experience":
+            dates = _DATE_RANGE_RE.search(plain)
+            if dates:
+                role = plain[: dates.start()].strip(" |,–—-()")
+                report.experience_entries.append(
+                    {"line": number, "role": role, "dates": dates.group(0)}
+                )
+        elif section == "skills" and not heading:
+            items = plain.split(":", 1)[-1] if ":" in plain else plain.lstrip("-• ")
+            report.skills.extend(
+                item.strip(" .")
+                for item in re.split(r"[,|;•·]", items)
+                if re.search(r"\w", item)
+            )
+    if job_description:
+        coverage = skill_coverage(
+            job_description,
+            text or "",
+            taxonomy or default_taxonomy(),
+            resume_skills=report.skills,
+        )
+        report.jd_skills = coverage.to_dict()
+    return report
@@ -0,0 +1,117 @@
+"""Audit log: who decided what in a run, who read its documents, and which model
+provider was sent which of its data.
+
+Offering Hydra as a service means answering for a résumé's whereabouts. Every run
+appends events to an append-only log, one JSON object per line (JSONL):
+
+- ``greenlight``: the gap analysis was approved or declined (``approved``), and by
+  whom; ``auto_approved`` when ``--yes`` let the run through without asking;
+- ``interview_answers``: how many interview answers were given;
+- ``provider_call``: a model call (``stage``, ``agent``, ``provider``, ``model``),
+  with the input sections its prompt held (``data``: ``resume``,
+  ``job_description``, a prior stage's output...) and whether contact details were
+  redacted (``redacted``). Calls answered from the stage cache reach no provider and
+  are not logged;
+- ``artifacts_accessed``: a run's documents were read — exported, decrypted, served
+  by ``hydra serve`` or, on the web backend, downloaded over the API.
+
+Each event has ``at`` (ISO time), ``actor`` (the OS user for a local run, the API
+user on the server; None when nobody is known) and ``action``. Events hold names,
+counts and ids, never résumé or job-description text, so the log can be kept after
+the documents are gone (``hydra gc``). A local run keeps it as ``audit_log.jsonl`` in
+the run directory; the web backend keeps it in Postgres, where it can only be
+appended to, and exports it as JSONL (web/backend/services/audit_log.py).
+"""
+
+from __future__ import annotations
+
+import getpass
+import json
+from datetime import datetime
+from pathlib import Path
+from typing import Any, Dict, Iterable, List, Mapping, Optional
+
+AUDIT_LOG_FILE = "audit_log.jsonl"
+
+GREENLIGHT = "greenlight"
+INTERVIEW_ANSWERS = "interview_answers"
+PROVIDER_CALL = "provider_call"
+ARTIFACTS_ACCESSED = "artifacts_accessed"
+
+# Sections of a measured prompt that are the agent's own, not the run's data.
+_PROMPT_SECTIONS = frozenset({"system_prompt", "task_overhead"})
+
+
+def local_actor() -> Optional[str]:
+    """The OS user running this process, or None if it cannot be told."""
+    try:
+        return getpass.getuser()
+    except (KeyError, OSError):
+        return None
+
+
+def audit_event(action: str, actor: Optional[str] = None, **details: Any) -> Dict[str, Any]:
+    """One event as the log keeps it."""
+    return {
+        "at": datetime.now().isoformat(timespec="seconds"),
+        "actor": actor,
+        "action": action,
+        **details,
+    }
+
+
+def provider_calls(
+    stage: str,
+    calls: Iterable[Mapping[str, Any]],
+    sections: Iterable[str] = (),
+    redacted: bool = False,
+) -> List[Dict[str, Any]]:
+    """A ``provider_call`` event for each transcript entry of ``stage`` that reached a
+    provider; ``sections`` are the input sections of the stage's prompt."""
+    data = sorted(name for name in sections if name not in _PROMPT_SECTIONS)
+    events = []
+    for call in calls:
+        if call.get("path") == "cache":
+            continue
+        events.append(
+            audit_event(
+                PROVIDER_CALL,
+                stage=stage,
+                agent=call.get("agent"),
+                provider=call.get("provider"),
+                model=call.get("model"),
+                data=data,
+                redacted=redacted,
+                failed="error" in call,
+            )
+        )
+    return events
+
+
+def append_events(path: Path, events: Iterable[Mapping[str, Any]]) -> int:
+    """Append ``events`` to the log at ``path`` (created if missing); how many."""
+    lines = [json.dumps(dict(event), ensure_ascii=False, default=str) for event in events]
+    if not lines:
+        return 0
+    path = Path(path)
+    path.parent.mkdir(parents=True, exist_ok=True)
+    with path.open("a", encoding="utf-8") as log:
+        log.write("\n".join(lines) + "\n")
+    return len(lines)
+
+
+def record_access(run_dir: Path, how: str, actor: Optional[str] = None, **details: Any) -> None:
+    """Log that the documents in ``run_dir`` were read, and ``how``; never fails."""
+    event = audit_event(ARTIFACTS_ACCESSED, actor=actor, how=how, **details)
+    try:
+        append_events(Path(run_dir) / AUDIT_LOG_FILE, [event])
+    except OSError:
+        pass
+
+
+def load_events(path: Path) -> List[Dict[str, Any]]:
+    """The events in the log at ``path``, oldest first; empty without one."""
+    path = Path(path)
+    if not path.is_file():
+        return []
+    return [json.loads(line) for line in path.read_text(encoding="utf-8").splitlines() if line]
@@ -8,17 +8,52 @@ Provides common functionality for all agents including:
 - Truth rules enforcement
 """
 
-import json
 import os
-import re
 from abc import ABC, abstractmethod
 from datetime import datetime
 from pathlib import Path
-from typing import Any, Dict, List, Optional
+from typing import Any, Callable, Dict, List, Optional
 
 from crewai import LLM, Agent, Crew, Process, Task
 
+from runtime.crewai.cancellation import CancelToken
+from runtime.crewai.circuit_breaker import (
+    CircuitOpen,
+    CircuitPolicy,
+    is_provider_failure,
+    shared_breaker,
+)
+from runtime.crewai.context_window import estimate_tokens
+from runtime.crewai.determinism import pin
+from runtime.crewai.gateway import GatewayLLM
+from runtime.crewai.json_repair import (
+    JSONRepairError,
+    extract_object,
+    json_mode_params,
+    parse_repaired,
+    repair_instruction,
+    strip_fences,
+)
+from runtime.crewai.output_codec import canonical, decode_yaml, to_json
+from runtime.crewai.pii_redaction import PiiRedactor
+from runtime.crewai.prompt_cache import (
+    apply_cache_control,
+    split_shared,
+    usage_from_crew,
+    usage_from_litellm,
+)
+from runtime.crewai.prompt_transcript import call_entry
+from runtime.crewai.rate_limit import Grant, provider_of, shared_limiter
+from runtime.crewai.stage_cache import cache_key
 from runtime.crewai.telemetry import record_agent_error, record_agent_result, trace_agent_execution
+from runtime.crewai.timeouts import SCOPE_LLM_CALL, TimeoutExceeded, run_with_timeout
+from runtime.crewai.tools import (
+    DEFAULT_TOOL_ROUNDS,
+    Tool,
+    parse_tool_calls,
+    render_tool_prompt,
+    run_tool_call,
+)
 
 # Constants
 DEFAULT_CONFIDENCE = 0.8
@@ -51,6 +86,12 @@ class ValidationError(Exception):
     pass
 
 
+class OutputParseError(ValidationError):
+    """Raised when agent output holds no JSON object; the model is asked again."""
+
+    pass
+
+
 class BaseHydraAgent(ABC):
     """Base class for all Hydra agents"""
 
@@ -74,6 +115,47 @@ class BaseHydraAgent(ABC):
         self.truth_rules = self._load_truth_rules()
         self.style_guide = self._load_style_guide()
         self.use_json_mode = use_json_mode
+        # Set by HydraWorkflow in --dry-run mode: prompts are recorded, never sent.
+        self.dry_run_recorder = None
+        # Optional stage_cache.StageCache: validated outputs keyed by prompt+model.
+        self.stage_cache = None
+        # Optional prompt_cache.UsageLedger: per-call tokens, incl. provider cache hits.
+        self.usage_ledger = None
+        # The run's texts every stage embeds (baseline résumé, sources), sent as a
+        # shared leading system message the provider can cache (see prompt_cache).
+        self.shared_context: Dict[str, str] = {}
+        # Optional cancellation.CancelToken: model calls are abandoned once it trips.
+        self.cancel_token: Optional[CancelToken] = None
+        # Which run this agent's calls queue under in the provider rate limiter, so
+        # concurrent runs take turns (see runtime.crewai.rate_limit).
+        self.rate_limit_owner: Optional[str] = None
+        # Tools the model may call during a stage (see runtime.crewai.tools), and the
+        # transcript of the calls made by the most recent execute_with_retry.
+        self.tools: List[Tool] = []
+        self.max_tool_rounds = DEFAULT_TOOL_ROUNDS
+        self.tool_transcript: List[Dict[str, Any]] = []
+        # Every model call since the workflow last collected them: the messages sent,
+        # the raw response and why it was rejected (see prompt_transcript).
+        self.prompt_transcript: List[Dict[str, Any]] = []
+        # Seconds one model call may take before it is abandoned and retried (None: no
+        # limit; see runtime.crewai.timeouts), and the calls that ran out of time.
+        self.call_timeout: Optional[float] = None
+        self.timed_out: List[TimeoutExceeded] = []
+        # Optional circuit_breaker.CircuitPolicy: calls to a provider that keeps failing
+        # stop at once instead of waiting out timeouts and retries (None: no breaker).
+        self.circuit: Optional[CircuitPolicy] = None
+        # Set by --deterministic: every call at temperature 0 with this seed (see
+        # runtime.crewai.determinism); None keeps the model's own sampling.
+        self.seed: Optional[int] = None
+        # Optional pii_redaction.PiiRedactor: contact details in prompts are swapped for
+        # placeholders before a call and restored in its output.
+        self.redactor: Optional[PiiRedactor] = None
+        # Set by HydraWorkflow while a low-confidence stage is re-run: a critique of the
+        # previous answer, appended to the task (see runtime.crewai.confidence).
+        self.critique: Optional[str] = None
+        # Called with the model's answer as it arrives, to show a run's progress live:
+        # token by token on the direct path, which streams, else whole once it is in.
+        self.on_output: Optional[Callable[[str], None]] = None
 
     def _get_project_root(self) -> Path:
         """Get project root directory"""
@@ -158,6 +240,8 @@ class BaseHydraAgent(ABC):
 
     def create_task(self, description: str, context: Optional[List[Task]] = None) -> Task:
         """Create CrewAI task for this agent"""
+        if self.critique:
+            description = f"{description}\n\n{self.critique}"
         # Add required base fields to task description
         enhanced_description = f"""{description}
 
@@ -178,71 +262,47 @@ Return ONLY valid JSON. Do not include any text before or after the JSON object.
 
     def validate_output(self, output: str) -> Dict[str, Any]:
         """
-        Validate and parse agent output as JSON.
+        Validate and parse agent output as JSON, or as YAML when it holds no JSON.
 
         Args:
             output: Raw output string from agent
 
         Returns:
-            Parsed and validated output dictionary
+            Parsed and validated output dictionary, as canonical JSON data
 
         Raises:
-            ValidationError: If output is invalid JSON
+            ValidationError: If output is neither valid JSON nor a YAML mapping
         """
         cleaned_output = self._clean_output(output)
-        parsed = self._parse_json(cleaned_output)
+        try:
+            parsed = self._parse_json(cleaned_output)
+        except OutputParseError:
+            parsed = decode_yaml(output)
+            if parsed is None:
+                raise  # keep the JSON error: it is what the re-prompt shows the model
+        parsed = canonical(parsed)
         self._promote_nested_base_fields(parsed)
         self._validate_schema(parsed)
         return parsed
 
     def _clean_output(self, output: str) -> str:
-        """Clean and extract JSON from raw output."""
-        cleaned = output.strip()
-
-        # Remove markdown code fences
-        if cleaned.startswith("```json"):
-            cleaned = re.sub(r"^```json\s*\n?", "", cleaned)
-            cleaned = re.sub(r"\n?```\s*$", "", cleaned)
-        elif cleaned.startswith("```"):
-            cleaned = re.sub(r"^```\s*\n?", "", cleaned)
-            cleaned = re.sub(r"\n?```\s*$", "", cleaned)
-
-        cleaned = cleaned.strip()
-
-        # Extract JSON from surrounding text
-        first_brace = cleaned.find("{")
-        last_brace = cleaned.rfind("}")
-
-        if first_brace != -1 and last_brace != -1 and last_brace > first_brace:
-            cleaned = cleaned[first_brace : last_brace + 1]
-
-        return cleaned
+        """Extract the JSON object from raw output: out of code fences and prose."""
+        return extract_object(strip_fences(output))
 
     def _parse_json(self, json_str: str) -> Dict[str, Any]:
-        """Parse JSON string with limited, safe error recovery.
-
-        The only automatic repair is stripping trailing commas — a common,
-        unambiguous LLM mistake. We deliberately do NOT blanket-replace single
-        quotes with double quotes: that corrupts legitimate apostrophes inside
-        string values (e.g. "the candidate's experience").
+        """Parse JSON string with limited, safe error recovery (see json_repair).
+
+        The only automatic repairs are dropping trailing commas and accepting raw
+        control characters in strings — common, unambiguous LLM mistakes. We
+        deliberately do NOT blanket-replace single quotes with double quotes: that
+        corrupts legitimate apostrophes inside string values (e.g. "the candidate's
+        experience"). What still fails raises OutputParseError, and the next attempt
+        shows the model its answer and the error.
         """
         try:
-            parsed = json.loads(json_str)
-        except json.JSONDecodeError:
-            try:
-                # Remove trailing commas before a closing } or ].
-                fixed = re.sub(r",(\s*[}\]])", r"\1", json_str)
-                parsed = json.loads(fixed)
-            except json.JSONDecodeError as e2:
-                raise ValidationError(
-                    f"Invalid JSON output: {e2}\n\nOutput was:\n{json_str[:500]}"
-                ) from e2
-
-        # Validate that result is a dictionary
-        if not isinstance(parsed, dict):
-            raise ValidationError("Output must be a JSON object (dictionary)")
-
-        return parsed
+            return parse_repaired(json_str)
+        except JSONRepairError as e:
+            raise OutputParseError(f"{e}\n\nOutput was:\n{json_str[:500]}") from e
 
     def _promote_nested_base_fields(self, parsed: Dict[str, Any]) -> None:
         """Promote base fields from nested structure if needed."""
@@ -299,40 +359,233 @@ Return ONLY valid JSON. Do not include any text before or after the JSON object.
         Built from the same pieces the CrewAI path uses: the agent backstory
         (role + goal + prompt + injected truth/style rules) as the system message,
         and the task description (which already carries the JSON-output instruction)
-        plus the expected-output contract as the user message.
+        plus the expected-output contract as the user message. The shared texts the
+        task embeds come first, as a system message every agent sends the same.
         """
         system = f"You are {self.role}. {self.goal}\n\n{self._build_backstory()}".strip()
-        user = task.description
+        shared, user = split_shared(task.description, self._shared_texts())
         if self.expected_output:
             user = f"{user}\n\nExpected output: {self.expected_output}"
-        return [
+        messages = [{"role": "system", "content": shared}] if shared else []
+        return messages + [
             {"role": "system", "content": system},
             {"role": "user", "content": user},
         ]
 
+    def _shared_texts(self) -> Dict[str, str]:
+        """``shared_context`` as a sent task embeds it: redacted with --redact-pii."""
+        if self.redactor is None:
+            return self.shared_context
+        return {name: self.redactor.redact(text) for name, text in self.shared_context.items()}
+
     def _execute_direct(self, task: Task) -> str:
         """Run one agent call directly through LiteLLM, bypassing CrewAI.
 
         Opt-in via HYDRA_DIRECT_LLM. Reuses the model/credentials from the CrewAI
-        LLM object so provider routing is unchanged.
+        LLM object so provider routing is unchanged. The static system prefix is
+        marked cache-eligible where the provider needs it (see prompt_cache), and JSON
+        mode is asked for where the provider has it (see json_repair). With ``on_output``
+        set the answer is streamed to it.
         """
         import litellm
 
         llm = self.llm
-        response = litellm.completion(
-            model=getattr(llm, "model", None),
-            messages=self._build_messages(task),
+        model = getattr(llm, "model", None)
+        messages = apply_cache_control(self._build_messages(task), model)
+        params = dict(
+            model=model,
+            messages=messages,
             temperature=getattr(llm, "temperature", None),
             api_key=getattr(llm, "api_key", None),
             base_url=getattr(llm, "base_url", None),
+            **({"seed": llm.seed} if getattr(llm, "seed", None) is not None else {}),
+            **(json_mode_params(model) if self.use_json_mode else {}),
         )
+        if self.on_output is None:
+            response = litellm.completion(**params)
+        else:
+            # Streamed so the text can be shown as it arrives; the chunks are then put
+            # back together into the one response (usage included) the rest expects.
+            chunks = []
+            stream = litellm.completion(
+                stream=True, stream_options={"include_usage": True}, **params
+            )
+            for chunk in stream:
+                chunks.append(chunk)
+                choices = getattr(chunk, "choices", None) or [None]
+                text = getattr(getattr(choices[0], "delta", None), "content", None)
+                if text:
+                    self.on_output(text)
+            response = litellm.stream_chunk_builder(chunks, messages=messages)
+        if self.usage_ledger is not None:
+            self.usage_ledger.record(usage_from_litellm(self.role, str(model), response))
         return response["choices"][0]["message"]["content"]
 
+    def _execute_gateway(self, task: Task) -> str:
+        """Run one agent call through the org's LLM gateway (see runtime.crewai.gateway).
+
+        Always direct: CrewAI cannot speak a gateway's envelope. The same system+user
+        messages as the direct path, without cache markers the gateway may reject.
+        """
+        response = self.llm.complete(self._build_messages(task))
+        if self.usage_ledger is not None:
+            self.usage_ledger.record(usage_from_litellm(self.role, self.llm.model, response))
+        return response["choices"][0]["message"]["content"]
+
+    def _admit(self, task: Task) -> tuple[Optional[Grant], int]:
+        """Wait for room under the provider's rate limit; the grant and prompt estimate."""
+        limiter = shared_limiter()
+        if not limiter.limits:
+            return None, 0
+        model = getattr(self.llm, "model", None)
+        prompt_tokens = sum(
+            estimate_tokens(m["content"], model) for m in self._build_messages(task)
+        )
+        grant = limiter.acquire(
+            provider_of(self.llm),
+            self.rate_limit_owner,
+            prompt_tokens,
+            self.cancel_token,
+            self.role,
+        )
+        return grant, prompt_tokens
+
+    def _check_circuit(self, span: Any) -> None:
+        """Raise ``CircuitOpen`` if the provider's circuit is open (see circuit_breaker)."""
+        if self.circuit is None or self.llm is None:
+            return
+        provider = provider_of(self.llm)
+        span.set_attribute("llm.provider", provider)
+        span.set_attribute("llm.circuit", shared_breaker().state(provider))
+        shared_breaker().allow(provider, self.circuit)
+
+    def _record_circuit(self, error: Optional[Exception]) -> None:
+        """Count a call's outcome: a provider failure towards opening its circuit, any
+        answer (even an error one) as the provider being up."""
+        if self.circuit is None or self.llm is None:
+            return
+        provider = provider_of(self.llm)
+        if error is None or not is_provider_failure(error):
+            shared_breaker().record_success(provider)
+        elif shared_breaker().record_failure(provider, self.circuit):
+            print(
+                f"Circuit opened for {provider} after repeated failures: no calls to it "
+                f"for {self.circuit.cooldown:g}s"
+            )
+
+    def _call_path(self) -> str:
+        """How ``_invoke_llm`` reaches the model: gateway, direct or crewai."""
+        if isinstance(self.llm, GatewayLLM):
+            return "gateway"
+        return "direct" if os.environ.get(DIRECT_LLM_ENV) else "crewai"
+
+    def _log_call(
+        self, task: Task, attempt: int, response: Optional[str], error: Optional[Exception]
+    ) -> None:
+        """Add one model call to ``prompt_transcript``, as it was sent and answered."""
+        self.prompt_transcript.append(
+            call_entry(
+                self.role,
+                getattr(self.llm, "model", None),
+                self._call_path(),
+                attempt,
+                self._build_messages(task),
+                response,
+                error,
+                provider=provider_of(self.llm) if self.llm is not None else None,
+            )
+        )
+
+    def _invoke_llm(self, task: Task) -> str:
+        """Run a single model call for ``task`` and return the raw text output.
+
+        Default: execute via a minimal one-task Crew. Opt-in: call LiteLLM directly
+        (no Crew) when HYDRA_DIRECT_LLM is set. Gateway LLMs are always called directly.
+        """
+        if isinstance(self.llm, GatewayLLM):
+            return self._relay(self._execute_gateway(task))
+        if os.environ.get(DIRECT_LLM_ENV):
+            return str(self._execute_direct(task))
+        # Task.execute is not available in newer CrewAI, so wrap in a Crew.
+        crew = Crew(
+            agents=[task.agent],
+            tasks=[task],
+            process=Process.sequential,
+            verbose=False,
+        )
+        output = crew.kickoff()
+        if self.usage_ledger is not None:
+            model = str(getattr(self.llm, "model", None))
+            usage = usage_from_crew(self.role, model, getattr(output, "token_usage", None))
+            self.usage_ledger.record(usage)
+        return self._relay(str(output))
+
+    def _relay(self, text: str) -> str:
+        """Hand a whole answer from a path that does not stream to ``on_output``."""
+        if self.on_output is not None and text:
+            self.on_output(text)
+        return text
+
+    def _redacted(self, task: Task) -> Task:
+        """``task`` as it may be sent to a provider: with placeholders for contact details."""
+        if self.redactor is None:
+            return task
+        return Task(
+            description=self.redactor.redact(task.description),
+            expected_output=task.expected_output,
+            agent=task.agent,
+            context=task.context,
+        )
+
     def execute_with_retry(
         self, task: Task, max_retries: int = DEFAULT_MAX_RETRIES
     ) -> Dict[str, Any]:
         """
-        Execute task with retry logic.
+        Execute task with retry logic, running the tool loop when the agent has tools.
+
+        Args:
+            task: The task to execute
+            max_retries: Maximum number of retries on failure (per model call)
+
+        Returns:
+            Validated output dictionary
+
+        Raises:
+            ValidationError: If all retries fail
+        """
+        self.tool_transcript = []
+        if not self.tools or self.dry_run_recorder is not None:
+            return self._complete(task, max_retries)
+        return self._execute_with_tools(task, max_retries)
+
+    def _execute_with_tools(self, task: Task, max_retries: int) -> Dict[str, Any]:
+        """Bounded tool loop: run the calls the model asks for, then ask again.
+
+        Each round re-sends the task with the tool catalogue and every result so far;
+        after ``max_tool_rounds`` rounds the model must answer without tools.
+        """
+        transcript = self.tool_transcript
+        for round_number in range(1, self.max_tool_rounds + 2):
+            final = round_number > self.max_tool_rounds
+            round_task = Task(
+                description=task.description
+                + render_tool_prompt(self.tools, transcript, final),
+                expected_output=task.expected_output,
+                agent=task.agent,
+                context=task.context,
+            )
+            output = self._complete(round_task, max_retries)
+            calls = [] if final else parse_tool_calls(output)
+            if not calls:
+                output.pop("tool_calls", None)
+                return output
+            for call in calls:
+                transcript.append(run_tool_call(self.tools, call, round_number))
+        return output
+
+    def _complete(self, task: Task, max_retries: int = DEFAULT_MAX_RETRIES) -> Dict[str, Any]:
+        """
+        Execute one model call with retry logic.
 
         Args:
             task: The task to execute
@@ -344,43 +597,105 @@ Return ONLY valid JSON. Do not include any text before or after the JSON object.
         Raises:
             ValidationError: If all retries fail
         """
+        # What is sent: with --redact-pii, contact details are placeholders. The cache
+        # is keyed by the real prompt and holds the restored output.
+        sent = base = self._redacted(task)
+        if self.seed is not None and self.llm is not None:
+            pin(self.llm, self.seed)
+
+        # Dry run: record the fully rendered prompt and return a placeholder output
+        # instead of calling the model.
+        if self.dry_run_recorder is not None:
+            return self.dry_run_recorder.record(self.role, self._build_messages(sent))
+
+        key = None
+        if self.stage_cache is not None:
+            key = cache_key(
+                self._build_messages(task),
+                getattr(self.llm, "model", None),
+                getattr(self.llm, "temperature", None),
+            )
+            cached = self.stage_cache.get(key, self.role)
+            if cached is not None:
+                self.prompt_transcript.append(
+                    call_entry(
+                        self.role,
+                        getattr(self.llm, "model", None),
+                        "cache",
+                        1,
+                        self._build_messages(sent),
+                        to_json(cached),
+                    )
+                )
+                return cached
+
         last_error = None
 
         with trace_agent_execution(self.role, {"max_retries": max_retries}) as span:
             for attempt in range(max_retries + 1):
+                result = None
                 try:
                     span.set_attribute("agent.attempt", attempt + 1)
 
-                    # Default: execute via a minimal one-task Crew. Opt-in: call
-                    # LiteLLM directly (no Crew) when HYDRA_DIRECT_LLM is set.
-                    if os.environ.get(DIRECT_LLM_ENV):
-                        result = self._execute_direct(task)
-                    else:
-                        # Task.execute is not available in newer CrewAI, so wrap in a Crew.
-                        crew = Crew(
-                            agents=[task.agent],
-                            tasks=[task],
-                            process=Process.sequential,
-                            verbose=False,
+                    self._check_circuit(span)
+                    grant, prompt_tokens = self._admit(sent)
+
+                    def _call() -> str:
+                        return run_with_timeout(
+                            self.role,
+                            self.call_timeout,
+                            lambda: self._invoke_llm(sent),
+                            SCOPE_LLM_CALL,
                         )
-                        result = crew.kickoff()
+
+                    if self.cancel_token is not None:
+                        result = self.cancel_token.run(self.role, _call)
+                    else:
+                        result = _call()
+                    self._record_circuit(None)
+                    if grant is not None:
+                        model = getattr(self.llm, "model", None)
+                        grant.settle(prompt_tokens + estimate_tokens(str(result), model))
 
                     # Validate output
-                    validated = self.validate_output(str(result))
+                    validated = self.validate_output(result)
+                    if self.redactor is not None:
+                        validated = self.redactor.restore(validated)
 
                     # Record success
                     record_agent_result(span, validated, self.role)
                     span.set_attribute("agent.retries_used", attempt)
+                    if key is not None:
+                        self.stage_cache.put(key, validated, self.role)
+                    self._log_call(sent, attempt + 1, result, None)
 
                     return validated
 
+                except CircuitOpen as e:
+                    # No retries against an open circuit: the workflow fails over.
+                    record_agent_error(span, e, self.role)
+                    raise
                 except Exception as e:
                     last_error = e
+                    if result is None:
+                        self._record_circuit(e)
+                    self._log_call(sent, attempt + 1, result, e)
                     span.add_event(f"retry.{attempt + 1}", {"error": str(e)})
+                    if isinstance(e, TimeoutExceeded):
+                        self.timed_out.append(e)
 
                     if attempt < max_retries:
                         # Log retry attempt
                         print(f"Retry {attempt + 1}/{max_retries} for {self.role}: {e}")
+                        if isinstance(e, OutputParseError):
+                            # Show the model what it answered and why it did not parse.
+                            sent = Task(
+                                description=base.description
+                                + repair_instruction(str(e).split("\n\n")[0], result),
+                                expected_output=base.expected_output,
+                                agent=base.agent,
+                                context=base.context,
+                            )
                         continue
                     else:
                         # Max retries reached - record error
@@ -0,0 +1,234 @@
+"""Budget-aware routing: cheaper models for the stages that matter least once money runs low.
+
+A run can be given a spend budget in USD. Before each stage the workflow adds up what
+the run's model calls have cost so far (``UsageLedger`` tokens at ``MODEL_PRICING``)
+and asks the policy whether the stage keeps its model or drops to a cheap one. Each
+stage has an importance weight from 0 to 1, and it is downgraded once the spent
+fraction of the budget reaches its threshold::
+
+    threshold = downgrade_at + (1 - downgrade_at) * weight
+
+With the default ``downgrade_at`` of 0.8, research (weight 0) drops to the cheap model
+once 80% of the budget is spent, tailoring (0.8) at 96%, and audit (weight 1) never:
+the quality gate stays on the strong model whatever it costs. A stage is only moved
+to a model that is cheaper than the one it has, and never while the budget is unset.
+
+The policy is the ``budget`` section of the pipeline config (see
+runtime.crewai.pipeline_config)::
+
+    budget:
+      usd: 2.00               # the run's budget; none by default
+      downgrade_at: 0.8       # spent fraction at which weight-0 stages downgrade
+      cheap_model: together:meta-llama/Llama-4-Maverick-17B-128E-Instruct-FP8
+      cheap_models:           # per-stage downgrade targets
+        tailoring: openai:gpt-4o-mini
+      weights:                # stage importance, 0-1
+        research: 0
+        audit: 1
+
+Every decision — kept or downgraded, with the spend that led to it — is logged and
+recorded in the manifest under ``budget``. Costs are estimates: calls to models
+without pricing count as free, and provider cache discounts are not subtracted.
+"""
+
+from __future__ import annotations
+
+from dataclasses import asdict, dataclass, field
+from typing import Any, Dict, Iterable, List, Mapping, Optional
+
+from runtime.crewai.model_config import LLMClientError, estimate_cost, parse_model_spec
+from runtime.crewai.prompt_cache import CallUsage
+from runtime.crewai.timeouts import STAGES, config_stage
+
+DEFAULT_DOWNGRADE_AT = 0.8
+DEFAULT_CHEAP_MODEL = "together:meta-llama/Llama-4-Maverick-17B-128E-Instruct-FP8"
+# How much each stage's output is worth protecting: 1 is never downgraded.
+DEFAULT_WEIGHTS: Dict[str, float] = {
+    "research": 0.0,
+    "compensation": 0.2,
+    "outreach": 0.2,
+    "referrals": 0.2,
+    "judge": 0.2,
+    "impact": 0.3,
+    "seniority": 0.3,
+    "interrogation": 0.3,
+    "ats_optimization": 0.4,
+    "gap_analysis": 0.5,
+    "differentiation": 0.5,
+    "executive_synthesis": 0.6,
+    "tailoring": 0.8,
+    "audit": 1.0,
+}
+
+KEEP = "keep"
+DOWNGRADE = "downgrade"
+
+
+def spent_usd(calls: Iterable[CallUsage]) -> float:
+    """Estimated USD cost of ``calls``; calls to unpriced models count as free."""
+    total = 0.0
+    for call in calls:
+        cost = estimate_cost(call.model, call.prompt_tokens, call.completion_tokens)
+        total += cost or 0.0
+    return round(total, 6)
+
+
+def _price(model: Optional[str]) -> Optional[float]:
+    """A model's cost for a million tokens in and a million out, for comparisons."""
+    return estimate_cost(model or "", 1_000_000, 1_000_000)
+
+
+@dataclass
+class RoutingDecision:
+    """What budget routing did before one stage, and why."""
+
+    stage: str
+    action: str
+    model: Optional[str]
+    spent_usd: float
+    budget_usd: float
+    threshold: float
+    reason: str
+    to_model: Optional[str] = None
+
+    def to_dict(self) -> Dict[str, Any]:
+        return {key: value for key, value in asdict(self).items() if value is not None}
+
+
+def _fraction(value: Any, name: str) -> float:
+    if isinstance(value, bool) or not isinstance(value, (int, float)) or not 0 <= value <= 1:
+        raise ValueError(f"{name} must be a number from 0 to 1")
+    return float(value)
+
+
+def _spec(value: Any, name: str) -> str:
+    if not isinstance(value, str):
+        raise ValueError(f"{name} must be a provider:model spec")
+    try:
+        parse_model_spec(value)
+    except LLMClientError as e:
+        raise ValueError(f"{name}: {e}") from e
+    return value
+
+
+def _stages(data: Mapping[str, Any], name: str) -> None:
+    unknown = set(data) - set(STAGES)
+    if unknown:
+        raise ValueError(
+            f"unknown stage(s) in {name}: {', '.join(sorted(unknown))} "
+            f"(expected: {', '.join(STAGES)})"
+        )
+
+
+@dataclass(frozen=True)
+class BudgetPolicy:
+    """The run's spend budget, and which stages give up their model first."""
+
+    usd: Optional[float] = None
+    downgrade_at: float = DEFAULT_DOWNGRADE_AT
+    cheap_model: str = DEFAULT_CHEAP_MODEL
+    cheap_models: Mapping[str, str] = field(default_factory=dict)
+    weights: Mapping[str, float] = field(default_factory=lambda: dict(DEFAULT_WEIGHTS))
+
+    @classmethod
+    def from_dict(cls, data: Mapping[str, Any]) -> "BudgetPolicy":
+        """``{"usd": 2, "weights": {"research": 0}}``; omitted keys keep their defaults.
+        Raises ValueError on unknown keys or bad values."""
+        unknown = set(data) - {"usd", "downgrade_at", "cheap_model", "cheap_models", "weights"}
+        if unknown:
+            raise ValueError(f"unknown budget setting(s): {', '.join(sorted(unknown))}")
+        default = cls()
+        usd = data.get("usd")
+        if usd is not None and (
+            isinstance(usd, bool) or not isinstance(usd, (int, float)) or usd <= 0
+        ):
+            raise ValueError("budget.usd must be a positive amount")
+        downgrade_at = _fraction(
+            data.get("downgrade_at", default.downgrade_at), "budget.downgrade_at"
+        )
+        cheap_model = _spec(data.get("cheap_model", default.cheap_model), "budget.cheap_model")
+        cheap_models = data.get("cheap_models") or {}
+        weights = data.get("weights") or {}
+        for name, section in (("cheap_models", cheap_models), ("weights", weights)):
+            if not isinstance(section, dict):
+                raise ValueError(f"budget.{name} must map stages to values")
+            _stages(section, f"budget.{name}")
+        return cls(
+            usd=None if usd is None else float(usd),
+            downgrade_at=downgrade_at,
+            cheap_model=cheap_model,
+            cheap_models={
+                stage: _spec(spec, f"budget.cheap_models.{stage}")
+                for stage, spec in cheap_models.items()
+            },
+            weights={
+                **default.weights,
+                **{
+                    stage: _fraction(weight, f"budget.weights.{stage}")
+                    for stage, weight in weights.items()
+                },
+            },
+        )
+
+    def to_dict(self) -> Dict[str, Any]:
+        return {
+            "usd": self.usd,
+            "downgrade_at": self.downgrade_at,
+            "cheap_model": self.cheap_model,
+            "cheap_models": dict(self.cheap_models),
+            "weights": dict(self.weights),
+        }
+
+    @property
+    def enabled(self) -> bool:
+        return self.usd is not None
+
+    def threshold(self, stage: str) -> float:
+        """The spent fraction of the budget at which ``stage`` is downgraded."""
+        weight = self.weights.get(config_stage(stage), 0.5)
+        return round(self.downgrade_at + (1 - self.downgrade_at) * weight, 6)
+
+    def decide(self, stage: str, model: Optional[str], spent: float) -> RoutingDecision:
+        """Whether ``stage``, about to run on ``model`` with ``spent`` USD gone, keeps
+        it or drops to its cheap model. Only meaningful when ``enabled``."""
+        budget = float(self.usd or 0)
+        threshold = self.threshold(stage)
+        decision = RoutingDecision(
+            stage=stage,
+            action=KEEP,
+            model=model,
+            spent_usd=spent,
+            budget_usd=budget,
+            threshold=threshold,
+            reason="",
+        )
+        fraction = spent / budget if budget else 0.0
+        if threshold >= 1:
+            decision.reason = f"{fraction:.0%} spent; never downgraded (weight 1)"
+            return decision
+        if fraction < threshold:
+            decision.reason = f"{fraction:.0%} spent, below {threshold:.0%}"
+            return decision
+        target = self.cheap_models.get(config_stage(stage), self.cheap_model)
+        current, cheaper = _price(model), _price(parse_model_spec(target)[1])
+        if cheaper is None or (current is not None and cheaper >= current):
+            decision.reason = f"{fraction:.0%} spent, but {target} is not cheaper than {model}"
+            return decision
+        decision.action = DOWNGRADE
+        decision.to_model = target
+        decision.reason = f"{fraction:.0%} of ${budget:g} spent, at or above {threshold:.0%}"
+        return decision
+
+
+def budget_summary(
+    policy: BudgetPolicy, spent: float, decisions: List[RoutingDecision]
+) -> Optional[Dict[str, Any]]:
+    """The manifest's ``budget`` section: the budget, the spend and every decision."""
+    if not policy.enabled:
+        return None
+    return {
+        "usd": policy.usd,
+        "spent_usd": spent,
+        "downgraded": [d.stage for d in decisions if d.action == DOWNGRADE],
+        "decisions": [d.to_dict() for d in decisions],
+    }
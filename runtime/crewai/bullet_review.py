@@ -0,0 +1,339 @@
+"""Keyboard-driven review of changed résumé bullets.
+
+The audit and the claim check judge the output; this module lets the candidate
+decide. Every bullet the run rewrote, added or removed is shown old-vs-new, one at a
+time, and a single key accepts (keep the new text), rejects (keep the baseline) or
+edits it. Decisions are applied to the final résumé, followed by a consolidation pass:
+
+- deterministic clean-up — duplicate bullets (e.g. a rejected rewrite restoring a line
+  the run had also kept elsewhere) are dropped and bullet markers are normalised;
+- the claim check (see ``claim_verification``) is re-run, since an edit can introduce
+  a number or skill the evidence does not support.
+
+Every decision is written to ``provenance.json`` next to the résumé: for each bullet,
+the baseline text, the run's text, what the candidate decided and the text that was
+kept. The pre-review résumé is preserved as ``resume.pre_review.md``. ``run.json``
+only gains decision counts, keeping the manifest content-free.
+"""
+
+from __future__ import annotations
+
+import difflib
+import json
+import re
+import sys
+from collections import Counter
+from dataclasses import dataclass
+from datetime import datetime
+from pathlib import Path
+from typing import Callable, Dict, Iterable, List, Optional, TextIO
+
+from runtime.crewai.artifacts import MANIFEST_FILE, RESUME_FILE, write_artifact_index
+from runtime.crewai.claim_verification import VerificationReport, verify_claims
+from runtime.crewai.encryption import read_text, write_text
+from runtime.crewai.html_report import REPORT_HTML_FILE, render_report
+from runtime.crewai.resume_diff import (
+    RESUME_DIFF_FILE,
+    RESUME_DIFF_HTML_FILE,
+    html_diff,
+    unified_diff,
+)
+
+PROVENANCE_FILE = "provenance.json"
+PRE_REVIEW_RESUME_FILE = "resume.pre_review.md"
+
+ACCEPT = "accept"
+REJECT = "reject"
+EDIT = "edit"
+
+ADDED = "added"
+REMOVED = "removed"
+REWRITTEN = "rewritten"
+
+_BULLET_RE = re.compile(r"^(?P<marker>\s*(?:[-*•]|\d+[.)])\s+)(?P<text>.*)$")
+
+_RED = "\033[31m"
+_GREEN = "\033[32m"
+_BOLD = "\033[1m"
+_RESET = "\033[0m"
+_CLEAR = "\033[2J\033[H"
+
+HELP = "[a]ccept  [r]eject  [e]dit  [b]ack  [A]ccept rest  [q]uit"
+
+
+def _bullet(line: Optional[str]) -> Optional[re.Match]:
+    return _BULLET_RE.match(line) if line is not None else None
+
+
+def bullet_text(line: Optional[str]) -> Optional[str]:
+    """The bullet's text without its marker (``None`` stays ``None``)."""
+    if line is None:
+        return None
+    match = _bullet(line)
+    return match.group("text").strip() if match else line.strip()
+
+
+@dataclass
+class BulletChange:
+    """One changed bullet: ``old`` is None when added, ``new`` is None when removed."""
+
+    index: int
+    old: Optional[str]
+    new: Optional[str]
+    # 0-based line in the tailored résumé (for removals: where the line would return).
+    position: int
+    decision: Optional[str] = None
+    edited: Optional[str] = None
+
+    @property
+    def kind(self) -> str:
+        if self.old is None:
+            return ADDED
+        if self.new is None:
+            return REMOVED
+        return REWRITTEN
+
+    @property
+    def final(self) -> Optional[str]:
+        """The full line kept after the decision (None: no line)."""
+        if self.decision == EDIT and self.edited is not None:
+            if _bullet(self.edited):
+                return self.edited
+            marker = _bullet(self.new or self.old).group("marker")
+            return f"{marker}{self.edited.strip()}"
+        if self.decision == REJECT:
+            return self.old
+        return self.new  # accepted or undecided: the run's version stands
+
+    def to_dict(self) -> Dict[str, object]:
+        return {
+            "index": self.index,
+            "kind": self.kind,
+            "line": self.position + 1,
+            "old": bullet_text(self.old),
+            "new": bullet_text(self.new),
+            "decision": self.decision or ACCEPT,
+            "final": bullet_text(self.final),
+        }
+
+
+def changed_bullets(baseline: str, tailored: str) -> List[BulletChange]:
+    """Bullets the run rewrote, added or removed, paired in document order."""
+    old_lines, new_lines = baseline.splitlines(), tailored.splitlines()
+    changes: List[BulletChange] = []
+    matcher = difflib.SequenceMatcher(a=old_lines, b=new_lines, autojunk=False)
+    for tag, i1, i2, j1, j2 in matcher.get_opcodes():
+        if tag == "equal":
+            continue
+        olds = [i for i in range(i1, i2) if _bullet(old_lines[i])]
+        news = [j for j in range(j1, j2) if _bullet(new_lines[j])]
+        for k in range(max(len(olds), len(news))):
+            old = old_lines[olds[k]] if k < len(olds) else None
+            new_at = news[k] if k < len(news) else None
+            position = new_at if new_at is not None else j2
+            new = new_lines[new_at] if new_at is not None else None
+            changes.append(BulletChange(len(changes) + 1, old, new, position))
+    return changes
+
+
+def apply_decisions(tailored: str, changes: Iterable[BulletChange]) -> str:
+    """The tailored résumé with every decision applied (undecided = accepted)."""
+    lines = tailored.splitlines()
+    replaced: Dict[int, BulletChange] = {}
+    restored: Dict[int, List[BulletChange]] = {}
+    for change in changes:
+        if change.new is None:
+            restored.setdefault(change.position, []).append(change)
+        else:
+            replaced[change.position] = change
+
+    out: List[str] = []
+    for position in range(len(lines) + 1):
+        for change in restored.get(position, []):
+            if change.final is not None:
+                out.append(change.final)
+        if position == len(lines):
+            break
+        change = replaced.get(position)
+        line = lines[position] if change is None else change.final
+        if line is not None:
+            out.append(line)
+    return "\n".join(out) + ("\n" if tailored.endswith("\n") else "")
+
+
+def consolidate(text: str) -> str:
+    """Drop duplicate bullets, normalise markers, collapse runs of blank lines."""
+    markers = Counter(
+        match.group("marker").strip()
+        for match in map(_bullet, text.splitlines())
+        if match and not match.group("marker").strip()[0].isdigit()
+    )
+    dominant = markers.most_common(1)[0][0] if markers else None
+    seen: set[str] = set()
+    out: List[str] = []
+    for line in text.splitlines():
+        match = _bullet(line)
+        if match:
+            key = match.group("text").strip().lower()
+            if key in seen:
+                continue
+            seen.add(key)
+            marker = match.group("marker")
+            if dominant and not marker.strip()[0].isdigit():
+                indent = marker[: len(marker) - len(marker.lstrip())]
+                line = f"{indent}{dominant} {match.group('text')}"
+        if not line.strip() and out and not out[-1].strip():
+            continue
+        out.append(line)
+    return "\n".join(out) + ("\n" if text.endswith("\n") else "")
+
+
+def read_key() -> str:
+    """One keypress from the terminal (a line of input when stdin is not a TTY)."""
+    if not sys.stdin.isatty():
+        line = sys.stdin.readline()
+        return line[:1] if line else "q"
+    import termios
+    import tty
+
+    fd = sys.stdin.fileno()
+    saved = termios.tcgetattr(fd)
+    try:
+        tty.setraw(fd)
+        key = sys.stdin.read(1)
+    finally:
+        termios.tcsetattr(fd, termios.TCSADRAIN, saved)
+    return "q" if key in ("\x03", "\x04") else key  # Ctrl-C / Ctrl-D quit
+
+
+def _render(change: BulletChange, total: int, color: bool) -> str:
+    red, green, bold, reset = (_RED, _GREEN, _BOLD, _RESET) if color else ("", "", "", "")
+    lines = [
+        f"{bold}Bullet {change.index}/{total} · {change.kind} · line {change.position + 1}"
+        f"{' · ' + change.decision if change.decision else ''}{reset}",
+        "",
+    ]
+    if change.old is not None:
+        lines.append(f"{red}- {bullet_text(change.old)}{reset}")
+    if change.new is not None:
+        lines.append(f"{green}+ {bullet_text(change.new)}{reset}")
+    if change.decision == EDIT:
+        lines.append(f"  edited: {bullet_text(change.final)}")
+    lines += ["", HELP]
+    return "\n".join(lines)
+
+
+def review_bullets(
+    changes: List[BulletChange],
+    key_fn: Callable[[], str] = read_key,
+    input_fn: Callable[[str], str] = input,
+    out: TextIO = sys.stdout,
+    color: bool = False,
+) -> List[BulletChange]:
+    """Walk ``changes`` one screen at a time, recording a decision per bullet.
+
+    Quitting leaves the remaining bullets undecided, which keeps the run's version.
+    """
+    i = 0
+    while 0 <= i < len(changes):
+        change = changes[i]
+        if color:
+            out.write(_CLEAR)
+        out.write(_render(change, len(changes), color) + "\n")
+        out.flush()
+        key = key_fn()
+        if key == "a":
+            change.decision = ACCEPT
+        elif key == "r":
+            change.decision = REJECT
+        elif key == "e":
+            current = bullet_text(change.final) or ""
+            text = input_fn(f"New text (blank keeps “{current}”): ").strip()
+            change.decision, change.edited = EDIT, text or current
+        elif key == "b":
+            i = max(0, i - 1)
+            continue
+        elif key == "A":
+            for rest in changes[i:]:
+                rest.decision = rest.decision or ACCEPT
+            break
+        elif key == "q":
+            break
+        else:
+            continue  # unknown key: redraw
+        i += 1
+    return changes
+
+
+def decision_counts(changes: Iterable[BulletChange]) -> Dict[str, int]:
+    counts = Counter(change.decision or ACCEPT for change in changes)
+    return {ACCEPT: counts[ACCEPT], REJECT: counts[REJECT], EDIT: counts[EDIT]}
+
+
+def save_review(
+    run_dir: Path,
+    baseline: str,
+    original: str,
+    reviewed: str,
+    changes: List[BulletChange],
+    verification: Optional[VerificationReport] = None,
+) -> Path:
+    """Write the reviewed résumé, refreshed diffs, ``provenance.json`` and counts."""
+    run_dir = Path(run_dir)
+    pre_review = run_dir / PRE_REVIEW_RESUME_FILE
+    if not pre_review.exists():  # keep the run's own output from the first review
+        write_text(pre_review, original)
+    write_text(run_dir / RESUME_FILE, reviewed)
+    write_text(
+        run_dir / RESUME_DIFF_FILE,
+        unified_diff(baseline, reviewed, fromfile="baseline", tofile=RESUME_FILE),
+    )
+    write_text(run_dir / RESUME_DIFF_HTML_FILE, html_diff(baseline, reviewed))
+
+    counts = decision_counts(changes)
+    provenance = {
+        "reviewed_at": datetime.now().isoformat(timespec="seconds"),
+        "decisions": counts,
+        "bullets": [change.to_dict() for change in changes],
+        "claim_verification": verification.to_dict() if verification else None,
+    }
+    path = run_dir / PROVENANCE_FILE
+    write_text(path, json.dumps(provenance, indent=2, ensure_ascii=False))
+
+    manifest_path = run_dir / MANIFEST_FILE
+    if manifest_path.exists():
+        manifest = json.loads(manifest_path.read_text())
+        manifest["review"] = {
+            **counts,
+            "claim_verification": verification.status if verification else None,
+        }
+        artifacts = manifest.setdefault("artifacts", [])
+        for name in (PROVENANCE_FILE, PRE_REVIEW_RESUME_FILE):
+            if name not in artifacts:
+                artifacts.append(name)
+        manifest_path.write_text(json.dumps(manifest, indent=2, default=str))
+        (run_dir / REPORT_HTML_FILE).write_text(render_report(manifest))
+        write_artifact_index(run_dir)
+    return path
+
+
+def run_review(
+    run_dir: Path,
+    baseline: str,
+    evidence: Iterable[str] = (),
+    key_fn: Callable[[], str] = read_key,
+    input_fn: Callable[[str], str] = input,
+    out: TextIO = sys.stdout,
+    color: bool = False,
+) -> Optional[VerificationReport]:
+    """Review ``run_dir``'s résumé against ``baseline``; None if nothing changed."""
+    original = read_text(Path(run_dir) / RESUME_FILE)
+    changes = changed_bullets(baseline, original)
+    if not changes:
+        out.write("No changed bullets to review.\n")
+        return None
+    review_bullets(changes, key_fn=key_fn, input_fn=input_fn, out=out, color=color)
+    reviewed = consolidate(apply_decisions(original, changes))
+    verification = verify_claims({"resume": reviewed}, [baseline, *evidence])
+    save_review(run_dir, baseline, original, reviewed, changes, verification)
+    return verification
@@ -0,0 +1,203 @@
+"""Run bundles: a whole run in one file, to move it between machines or share it.
+
+``hydra export <id> --bundle run.tar.gz`` packs the run directory — ``run.json``, the
+stage outputs under ``intermediate/`` (the state a resume or replay starts from), the
+prompt and tool transcripts, the execution log and every document — into a gzipped
+tarball with the run id as its top directory. ``hydra import run.tar.gz`` unpacks it
+into ``output/<id>/``, where every command that takes a run id finds it.
+
+Files are packed as they are stored: an encrypted run stays encrypted, and whoever
+imports it needs the passphrase to read it. With ``--redact-pii`` the bundle is
+meant for someone else, a coach say: every text file is decrypted and each email,
+phone number and street address in it is swapped for a placeholder (see
+pii_redaction), the same value getting the same placeholder across files. Names and
+employers are kept — the résumé is about them. Files that are not text (a PDF, a
+DOCX) cannot be redacted and are left out; ``run.json``, ``report.html`` and
+``manifest.json`` hold no résumé content and are packed as they are.
+
+Either way the bundle's ``run.json`` records the export under ``bundle`` (the
+redaction counts, or None unredacted), and the bundle carries a fresh
+``manifest.json`` indexing exactly what it holds. An import checks every file against
+it: a bundle altered or cut short on the way is refused and nothing is written.
+Exporting leaves the run as it is; the imported run records where it came from in
+``run.json`` under ``imported``.
+"""
+
+from __future__ import annotations
+
+import json
+import shutil
+import tarfile
+import tempfile
+from dataclasses import dataclass, field
+from datetime import datetime
+from pathlib import Path, PurePosixPath
+from typing import Dict, List, Optional
+
+from runtime.crewai.artifacts import (
+    ARTIFACT_INDEX_FILE,
+    MANIFEST_FILE,
+    record_artifacts,
+    verify_artifact_index,
+    write_artifact_index,
+)
+from runtime.crewai.encryption import EncryptionError, read_bytes
+from runtime.crewai.html_report import REPORT_HTML_FILE
+from runtime.crewai.pii_redaction import PiiRedactor
+from runtime.crewai.run_control import CONTROL_FILE, LIVE_FILE, is_live
+
+BUNDLE_SUFFIX = ".tar.gz"
+
+# Files that belong to the process running the run, not to the run.
+_LOCAL_FILES = (LIVE_FILE, CONTROL_FILE)
+# Files that hold no résumé content (see artifacts), packed unredacted.
+_CONTENT_FREE = (MANIFEST_FILE, ARTIFACT_INDEX_FILE, REPORT_HTML_FILE)
+
+
+class BundleError(Exception):
+    """The run cannot be exported, or the file is not an intact run bundle."""
+
+
+@dataclass
+class BundleSummary:
+    """What an export or import did, for the CLI to report."""
+
+    run_id: str
+    path: Path
+    files: int
+    redacted: Optional[Dict[str, int]] = None  # counts per kind, with --redact-pii
+    left_out: List[str] = field(default_factory=list)
+
+
+def _run_files(run_dir: Path) -> List[Path]:
+    return sorted(
+        path
+        for path in run_dir.rglob("*")
+        if path.is_file() and path.relative_to(run_dir).as_posix() not in _LOCAL_FILES
+    )
+
+
+def _copy(run_dir: Path, staging: Path, redact: bool) -> BundleSummary:
+    """Copy ``run_dir`` into ``staging`` (contact details redacted with ``redact``) and
+    index the copy, so the bundle's ``manifest.json`` matches what it carries."""
+    redactor = PiiRedactor() if redact else None
+    left_out = []
+    files = _run_files(run_dir)
+    for path in files:
+        name = path.relative_to(run_dir).as_posix()
+        target = staging / name
+        target.parent.mkdir(parents=True, exist_ok=True)
+        if redactor is None or name in _CONTENT_FREE:
+            shutil.copyfile(path, target)
+            continue
+        try:
+            text = read_bytes(path).decode("utf-8")
+        except EncryptionError as err:
+            raise BundleError(f"cannot redact {name}: {err}") from err
+        except UnicodeDecodeError:
+            left_out.append(name)
+            continue
+        target.write_text(redactor.redact(text), encoding="utf-8")
+    summary = BundleSummary(run_dir.name, staging, len(files) - len(left_out))
+    if redactor is not None:
+        summary.redacted, summary.left_out = redactor.summary(), left_out
+    # Recorded on every export, so an earlier redacted export's record is not carried.
+    manifest = json.loads((staging / MANIFEST_FILE).read_text())
+    manifest["bundle"] = {
+        "exported_at": datetime.now().isoformat(timespec="seconds"),
+        "redacted": summary.redacted,
+        "left_out": left_out,
+    }
+    (staging / MANIFEST_FILE).write_text(json.dumps(manifest, indent=2, default=str))
+    write_artifact_index(staging)
+    return summary
+
+
+def export_bundle(run_dir: Path, bundle_path: Path, redact: bool = False) -> BundleSummary:
+    """Pack the run in ``run_dir`` into ``bundle_path``; with ``redact``, without the
+    contact details. BundleError for a run that is executing or cannot be redacted."""
+    run_dir = Path(run_dir)
+    if is_live(run_dir):
+        raise BundleError(f"run {run_dir.name} is still executing; export it when it stops")
+    bundle_path = Path(bundle_path)
+    with tempfile.TemporaryDirectory() as tmp:
+        summary = _copy(run_dir, Path(tmp) / run_dir.name, redact)
+        bundle_path.parent.mkdir(parents=True, exist_ok=True)
+        with tarfile.open(bundle_path, "w:gz") as archive:
+            archive.add(summary.path, arcname=run_dir.name)
+    summary.path = bundle_path
+    return summary
+
+
+def _members(archive: tarfile.TarFile) -> Dict[str, tarfile.TarInfo]:
+    """The bundle's files by path under its one top directory; BundleError for anything
+    else (links, devices, absolute or escaping paths, several top directories)."""
+    tops = set()
+    files = {}
+    for member in archive.getmembers():
+        path = PurePosixPath(member.name)
+        if path.is_absolute() or ".." in path.parts or not path.parts:
+            raise BundleError(f"unsafe path in bundle: {member.name}")
+        if member.isdir():
+            tops.add(path.parts[0])
+            continue
+        if not member.isfile():
+            raise BundleError(f"not a regular file in bundle: {member.name}")
+        if len(path.parts) < 2:
+            raise BundleError(f"file outside the run directory in bundle: {member.name}")
+        tops.add(path.parts[0])
+        files[PurePosixPath(*path.parts[1:]).as_posix()] = member
+    if len(tops) != 1:
+        raise BundleError("a bundle holds exactly one run directory")
+    if MANIFEST_FILE not in files or ARTIFACT_INDEX_FILE not in files:
+        raise BundleError(f"not a run bundle: no {MANIFEST_FILE} or {ARTIFACT_INDEX_FILE}")
+    return files
+
+
+def import_bundle(bundle_path: Path, out_dir: Path) -> BundleSummary:
+    """Unpack the bundle at ``bundle_path`` into ``out_dir/<run id>``. BundleError if it
+    is not a run bundle, fails its checks, or the run is already there."""
+    bundle_path = Path(bundle_path)
+    try:
+        with tarfile.open(bundle_path, "r:gz") as archive:
+            members = _members(archive)
+            run_id = PurePosixPath(next(iter(members.values())).name).parts[0]
+            run_dir = Path(out_dir) / run_id
+            if run_dir.exists():
+                raise BundleError(f"{run_dir} already exists")
+            staging = Path(out_dir) / f".{run_id}.importing"
+            shutil.rmtree(staging, ignore_errors=True)
+            try:
+                for name, member in members.items():
+                    target = staging / name
+                    target.parent.mkdir(parents=True, exist_ok=True)
+                    with archive.extractfile(member) as source:
+                        target.write_bytes(source.read())
+                problems = verify_artifact_index(staging)
+                damaged = problems["missing"] + problems["changed"]
+                if damaged:
+                    raise BundleError(
+                        f"files do not match {ARTIFACT_INDEX_FILE}: {', '.join(damaged)}"
+                    )
+                staging.rename(run_dir)
+            finally:
+                shutil.rmtree(staging, ignore_errors=True)
+    except (tarfile.TarError, EOFError, OSError) as err:
+        raise BundleError(f"{bundle_path}: not a readable bundle: {err}") from err
+    manifest = json.loads((run_dir / MANIFEST_FILE).read_text())
+    redacted = (manifest.get("bundle") or {}).get("redacted")
+    record_artifacts(
+        run_dir,
+        [],
+        imported={
+            "from": bundle_path.name,
+            "at": datetime.now().isoformat(timespec="seconds"),
+            "redacted": redacted is not None,
+        },
+    )
+    return BundleSummary(run_id, run_dir, len(members), redacted=redacted)
+
+
+def bundle_name(run_id: str) -> str:
+    """The default file name of a run's bundle."""
+    return f"{run_id}{BUNDLE_SUFFIX}"
@@ -0,0 +1,109 @@
+"""Cancelling a run mid-stage without losing the stages it already finished.
+
+A run can take minutes, most of it waiting on model calls. ``HydraWorkflow.cancel``
+(the CLI calls it on Ctrl-C) trips the run's ``CancelToken``:
+
+- the model call in flight is abandoned — its thread finishes in the background and
+  its result is discarded, since a blocking HTTP call cannot be interrupted — and
+- no further stage starts.
+
+``HydraWorkflow.stop_after_stage`` (the web server calls it while draining for a
+deploy) is the gentle form: the stage in flight runs to completion and its output
+is kept, and the run stops at the next stage boundary.
+
+Either way ``RunCancelled`` unwinds the run, and the workflow returns an
+``interrupted`` result carrying every stage output completed so far: a checkpoint
+the CLI writes to the run directory and ``--resume-run`` picks up from, and the
+server persists with the job so it can be resumed after the restart.
+"""
+
+from __future__ import annotations
+
+import threading
+from typing import Any, Callable, Dict, Optional
+
+# How often a waiting call checks whether the run was cancelled.
+POLL_SECONDS = 0.1
+
+
+class RunCancelled(BaseException):
+    """Raised inside a run once it has been cancelled.
+
+    A ``BaseException``, like ``KeyboardInterrupt``, so the per-stage ``except
+    Exception`` handlers (fallback models, optional stages, audit retries) let it
+    through instead of treating the cancellation as a stage failure.
+    """
+
+    def __init__(self, stage: str, reason: str):
+        super().__init__(f"{reason} during {stage}")
+        self.stage = stage
+        self.reason = reason
+
+
+class CancelToken:
+    """Cancellation flag shared by a run's stages and agents."""
+
+    def __init__(self):
+        self._event = threading.Event()
+        self._boundary = threading.Event()
+        self.reason: Optional[str] = None
+
+    def __deepcopy__(self, memo: Dict[int, Any]) -> "CancelToken":
+        # A run has one flag; a copied agent or state still answers to it.
+        return self
+
+    @property
+    def cancelled(self) -> bool:
+        return self._event.is_set()
+
+    @property
+    def stopping(self) -> bool:
+        """True once the run has been asked to stop, now or at the next stage boundary."""
+        return self._boundary.is_set()
+
+    def cancel(self, reason: str = "cancelled") -> None:
+        """Cancel the run; the first reason given is the one reported."""
+        self._stop(reason)
+        self._event.set()
+
+    def stop_at_boundary(self, reason: str = "stopped") -> None:
+        """Let the stage in flight finish, then stop before the next one starts."""
+        self._stop(reason)
+
+    def _stop(self, reason: str) -> None:
+        if not self._boundary.is_set():
+            self.reason = reason
+            self._boundary.set()
+
+    def check(self, stage: str) -> None:
+        """Raise ``RunCancelled`` if the run has been stopped; called between stages."""
+        if self._boundary.is_set():
+            raise RunCancelled(stage, self.reason or "cancelled")
+
+    def _check_cancelled(self, stage: str) -> None:
+        if self._event.is_set():
+            raise RunCancelled(stage, self.reason or "cancelled")
+
+    def run(self, stage: str, fn: Callable[[], Any]) -> Any:
+        """Run ``fn`` on a worker thread, abandoning it if the run is cancelled meanwhile.
+
+        A stop at the boundary does not abandon the call: the stage gets its answer.
+        """
+        self._check_cancelled(stage)
+        outcome: Dict[str, Any] = {}
+        done = threading.Event()
+
+        def _target() -> None:
+            try:
+                outcome["result"] = fn()
+            except BaseException as e:  # re-raised on the caller's thread
+                outcome["error"] = e
+            finally:
+                done.set()
+
+        threading.Thread(target=_target, daemon=True).start()
+        while not done.wait(POLL_SECONDS):
+            self._check_cancelled(stage)
+        if "error" in outcome:
+            raise outcome["error"]
+        return outcome["result"]
@@ -0,0 +1,294 @@
+"""Chronology check: the tailored résumé's dates against themselves and the baseline.
+
+Tailoring rewrites roles, and a model that rewrites a role can rewrite its dates:
+stretch a contract over the gap after it, start a job before the last one ended, or
+turn "Mar 2019 – Jan 2020" into "2019 – 2020" so the gap behind it no longer shows.
+After the ATS lint, software reads the date range of every role in the experience
+sections of the final résumé and of the baseline, and reports:
+
+- **impossible ranges** — a role that ends before it starts, or starts or ends after
+  today;
+- **overlaps** — two roles held at once for more than ``OVERLAP_GRACE_MONTHS``,
+  unless the baseline has the same two ranges (concurrent roles are real);
+- **changed dates** — a range the baseline does not have, compared at the tailored
+  range's precision (year-only dates match a baseline range with the same years);
+- **hidden gaps** — a gap of ``MIN_GAP_MONTHS`` or more between the baseline's roles
+  that the tailored résumé's ranges cover, in part or in full.
+
+A role is a line of an experience section with a date range (``Jan 2020 – Present``,
+``01/2020 - 2022``, ``2019 to 2021``); its label is the rest of the line, or the line
+above it when the range stands alone. Discrepancies are listed in
+``audit_report.yaml`` under ``chronology`` and fail the audit like an unverified
+claim, with the same override (``--allow-unverified-claims``). A baseline without
+dates has nothing to compare against; only impossible ranges and overlaps are checked.
+"""
+
+from __future__ import annotations
+
+import re
+from dataclasses import asdict, dataclass, field
+from datetime import date
+from typing import Any, Dict, List, Optional, Tuple
+
+from runtime.crewai.ats_parse_check import section_for, to_plain_text
+from runtime.crewai.claim_verification import BLOCKED, OVERRIDDEN, PASSED
+
+IMPOSSIBLE = "impossible_range"
+OVERLAP = "overlap"
+CHANGED_DATES = "changed_dates"
+HIDDEN_GAP = "hidden_gap"
+
+# A gap this long between roles is one a reader would ask about.
+MIN_GAP_MONTHS = 3
+# Roles sharing their boundary month are a handover, not an overlap.
+OVERLAP_GRACE_MONTHS = 1
+
+_MONTH_NAMES = ("jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec")
+# Whole month names only: "Head of Marketing 2018" holds no "Mar 2018".
+_MONTH = (
+    r"\b(?:jan(?:uary)?|feb(?:ruary)?|mar(?:ch)?|apr(?:il)?|may|june?|july?|aug(?:ust)?"
+    r"|sep(?:t(?:ember)?)?|oct(?:ober)?|nov(?:ember)?|dec(?:ember)?)\b\.?"
+)
+_DATE = rf"(?:{_MONTH}\s+\d{{4}}|\d{{1,2}}/\d{{4}}|\d{{4}}-\d{{2}}|\d{{4}})"
+_RANGE_RE = re.compile(
+    rf"(?P<start>{_DATE})\s*(?:-|–|—|to)\s*(?P<end>{_DATE}|present|current|now|today)",
+    re.IGNORECASE,
+)
+_SECTION_HEADING_RE = re.compile(
+    r"^\s*(?:#{1,2}\s+(?P<md>[^#].*?)\s*#*|\*\*(?P<bold>[^*]+)\*\*:?)\s*$"
+)
+_BULLET_RE = re.compile(r"^\s*(?:[-*•]|\d+[.)])\s+")
+_SEPARATORS = " |,;:·—–-()*_"
+
+
+def _month(text: str, end: bool) -> Tuple[int, bool]:
+    """``text`` as a month number (year * 12 + month - 1), and whether it was year-only.
+
+    A year alone starts in January and ends in December."""
+    text = text.strip().lower()
+    named = re.match(rf"(?P<month>{_MONTH})\s+(?P<year>\d{{4}})", text)
+    if named:
+        month = _MONTH_NAMES.index(named.group("month")[:3]) + 1
+        return int(named.group("year")) * 12 + month - 1, False
+    numeric = re.match(
+        r"(?P<month>\d{1,2})/(?P<year>\d{4})|(?P<iso_year>\d{4})-(?P<iso>\d{2})", text
+    )
+    if numeric:
+        year = int(numeric.group("year") or numeric.group("iso_year"))
+        month = int(numeric.group("month") or numeric.group("iso"))
+        return year * 12 + min(max(month, 1), 12) - 1, False
+    return int(text) * 12 + (11 if end else 0), True
+
+
+def _label(month: int) -> str:
+    return f"{_MONTH_NAMES[month % 12].capitalize()} {month // 12}"
+
+
+@dataclass
+class Role:
+    """A dated role of the experience sections."""
+
+    label: str
+    line: int
+    dates: str
+    start: int
+    end: int
+    present: bool = False
+    year_only: bool = False
+
+    def held(self) -> Tuple[int, int]:
+        """The months the role was certainly held: a year-only range is only sure of
+        December of its first year to January of its last."""
+        if self.year_only and not self.present:
+            return self.start + 11, self.end - 11
+        return self.start, self.end
+
+    def overlap(self, other: "Role") -> int:
+        """Months both roles were certainly held."""
+        (start, end), (other_start, other_end) = self.held(), other.held()
+        return max(0, min(end, other_end) - max(start, other_start) + 1)
+
+    def same_range(self, other: "Role") -> bool:
+        """The same range at this role's precision."""
+        if self.year_only:
+            return (self.start // 12, self.end // 12, self.present) == (
+                other.start // 12,
+                other.end // 12,
+                other.present,
+            )
+        return (self.start, self.end, self.present) == (other.start, other.end, other.present)
+
+
+@dataclass
+class Discrepancy:
+    """One way the tailored résumé's chronology does not hold up, and where."""
+
+    kind: str
+    line: int
+    detail: str
+    document: str = "resume"
+
+    @property
+    def location(self) -> str:
+        return f"{self.document}, line {self.line}"
+
+
+@dataclass
+class ChronologyReport:
+    """The tailored résumé's roles checked; any discrepancy fails the audit."""
+
+    roles: int = 0
+    # The baseline's gaps between roles, as "Mar 2020 – Aug 2020".
+    baseline_gaps: List[str] = field(default_factory=list)
+    discrepancies: List[Discrepancy] = field(default_factory=list)
+    status: str = PASSED
+
+    @property
+    def blocking(self) -> bool:
+        return self.status == BLOCKED
+
+    def to_dict(self) -> Dict[str, Any]:
+        return {
+            "status": self.status,
+            "roles": self.roles,
+            "baseline_gaps": self.baseline_gaps,
+            "discrepancies": [
+                {**asdict(item), "location": item.location} for item in self.discrepancies
+            ],
+        }
+
+
+def parse_roles(text: str, today: Optional[date] = None) -> List[Role]:
+    """The dated roles of the experience sections of the résumé ``text``, in order."""
+    today = today or date.today()
+    now = today.year * 12 + today.month - 1
+    roles: List[Role] = []
+    in_experience = False
+    previous = ""
+    for number, line in enumerate((text or "").splitlines(), start=1):
+        heading = _SECTION_HEADING_RE.match(line)
+        # Any Markdown section heading ends the section; a bold line only if it names one.
+        title = heading and (heading.group("md") or heading.group("bold"))
+        section = section_for(to_plain_text(title)) if title else None
+        if heading and (heading.group("md") or section):
+            in_experience = section == "experience"
+            previous = ""
+            continue
+        if not in_experience or not line.strip() or _BULLET_RE.match(line):
+            continue
+        found = _RANGE_RE.search(line)
+        if found is None:
+            previous = to_plain_text(line).strip(_SEPARATORS)
+            continue
+        start, year_only = _month(found.group("start"), end=False)
+        present = found.group("end").lower() in ("present", "current", "now", "today")
+        if present:
+            end, end_year_only = now, year_only
+        else:
+            end, end_year_only = _month(found.group("end"), end=True)
+            if end_year_only and end // 12 == today.year:
+                end = min(end, now)  # "2019 – 2026" in 2026 has not run to December
+        rest = to_plain_text(line[: found.start()] + line[found.end() :]).strip(_SEPARATORS)
+        roles.append(
+            Role(
+                label=rest or previous or f"line {number}",
+                line=number,
+                dates=found.group(0),
+                start=start,
+                end=end,
+                present=present,
+                year_only=year_only and end_year_only,
+            )
+        )
+        previous = ""
+    return roles
+
+
+def gaps(roles: List[Role]) -> List[Tuple[int, int]]:
+    """(first, last) month of each gap of ``MIN_GAP_MONTHS`` or more between ``roles``."""
+    held = sorted((role.start, role.end) for role in roles if role.end >= role.start)
+    found: List[Tuple[int, int]] = []
+    covered = None
+    for start, end in held:
+        if covered is not None and start - covered - 1 >= MIN_GAP_MONTHS:
+            found.append((covered + 1, start - 1))
+        covered = end if covered is None else max(covered, end)
+    return found
+
+
+def check_chronology(
+    resume: str,
+    baseline: str,
+    override: bool = False,
+    today: Optional[date] = None,
+) -> ChronologyReport:
+    """Check the dates of the tailored ``resume`` against themselves and ``baseline``."""
+    today = today or date.today()
+    now = today.year * 12 + today.month - 1
+    roles = parse_roles(resume, today)
+    base = parse_roles(baseline, today)
+    report = ChronologyReport(roles=len(roles))
+    found = report.discrepancies
+
+    for role in roles:
+        if role.end < role.start:
+            found.append(
+                Discrepancy(IMPOSSIBLE, role.line, f"'{role.label}' ends before it starts")
+            )
+        elif role.start > now or (role.end > now and not role.present):
+            found.append(
+                Discrepancy(
+                    IMPOSSIBLE, role.line, f"'{role.label}' ({role.dates}) is in the future"
+                )
+            )
+
+    for index, role in enumerate(roles):
+        for other in roles[index + 1 :]:
+            months = role.overlap(other)
+            in_baseline = any(role.same_range(b) for b in base) and any(
+                other.same_range(b) for b in base
+            )
+            if months > OVERLAP_GRACE_MONTHS and not in_baseline:
+                found.append(
+                    Discrepancy(
+                        OVERLAP,
+                        other.line,
+                        f"'{other.label}' overlaps '{role.label}' by {months} months",
+                    )
+                )
+
+    if base:
+        for role in roles:
+            if not any(role.same_range(b) for b in base):
+                found.append(
+                    Discrepancy(
+                        CHANGED_DATES,
+                        role.line,
+                        f"'{role.label}' ({role.dates}) matches no dates in the baseline",
+                    )
+                )
+        for first, last in gaps(base):
+            gap = f"{_label(first)} – {_label(last)}"
+            report.baseline_gaps.append(gap)
+            covering = [role for role in roles if role.start <= last and role.end >= first]
+            for role in covering:
+                found.append(
+                    Discrepancy(
+                        HIDDEN_GAP,
+                        role.line,
+                        f"'{role.label}' ({role.dates}) covers the baseline's gap {gap}",
+                    )
+                )
+
+    found.sort(key=lambda item: item.line)
+    if found:
+        report.status = OVERRIDDEN if override else BLOCKED
+    return report
+
+
+def manifest_summary(report: Dict[str, Any]) -> Dict[str, Any]:
+    """What ``run.json`` keeps of a report dict: the status and the kinds found, counted."""
+    kinds: Dict[str, int] = {}
+    for item in report.get("discrepancies") or []:
+        kinds[item["kind"]] = kinds.get(item["kind"], 0) + 1
+    return {"status": report.get("status"), "roles": report.get("roles"), "discrepancies": kinds}
@@ -0,0 +1,226 @@
+"""Circuit breaker per provider: stop calling a provider that keeps failing.
+
+Without one, every stage finds out on its own that a provider is down: each call
+waits for its timeout or 5xx, retries, and only then fails over. With several stages
+(and runs) on the same provider, that is minutes of waiting on an answer already known.
+
+The breaker counts consecutive provider failures — 5xx answers, timeouts, refused
+connections — across every run in the process. After ``failures`` in a row the
+provider's circuit opens: calls to it fail at once with ``CircuitOpen``, so the agent
+stops retrying and the workflow fails the stage over as if a health check had found
+the provider down (see failover). After ``cooldown`` seconds the circuit is half
+open: one trial call goes through, and its outcome closes the circuit or opens it for
+another cooldown. Any answer from the provider — even a 429, a rejected request or
+one that does not parse — shows it is up, and closes the circuit.
+
+The settings are the ``circuit`` part of the failover section of the pipeline config::
+
+    failover:
+      circuit:
+        failures: 3     # consecutive failures that open a circuit; 0 disables it
+        cooldown: 60    # seconds a circuit stays open before a trial call
+
+Each call's span carries the provider and its circuit state, and the web backend's
+``/health`` lists every provider's circuit (see ``CircuitBreaker.snapshot``).
+"""
+
+from __future__ import annotations
+
+import socket
+import threading
+import time
+import urllib.error
+from dataclasses import dataclass
+from typing import Any, Callable, Dict, Mapping, Optional
+
+from runtime.crewai.timeouts import TimeoutExceeded
+
+CLOSED = "closed"
+OPEN = "open"
+HALF_OPEN = "half_open"
+
+DEFAULT_FAILURES = 3
+DEFAULT_COOLDOWN = 60.0
+
+# Exception names (LiteLLM's, the OpenAI SDK's) that mean the provider did not answer.
+_UNREACHABLE = {
+    "APIConnectionError",
+    "APITimeoutError",
+    "BadGatewayError",
+    "InternalServerError",
+    "ServiceUnavailableError",
+    "Timeout",
+}
+
+
+class CircuitOpen(Exception):
+    """Raised instead of calling a provider whose circuit is open."""
+
+    def __init__(self, provider: str, retry_in: float):
+        super().__init__(
+            f"{provider} circuit open after repeated failures; next trial in {retry_in:.0f}s"
+        )
+        self.provider = provider
+        self.retry_in = retry_in
+
+
+@dataclass(frozen=True)
+class CircuitPolicy:
+    """How many failures in a row open a circuit, and for how long."""
+
+    failures: int = DEFAULT_FAILURES
+    cooldown: float = DEFAULT_COOLDOWN
+
+    @property
+    def enabled(self) -> bool:
+        return self.failures > 0
+
+    @classmethod
+    def from_dict(cls, data: Mapping[str, Any]) -> "CircuitPolicy":
+        """``{"failures": 3, "cooldown": 60}``; omitted keys keep their defaults.
+        Raises ValueError on unknown keys or bad values."""
+        unknown = set(data) - {"failures", "cooldown"}
+        if unknown:
+            raise ValueError(f"unknown circuit setting(s): {', '.join(sorted(unknown))}")
+        failures = data.get("failures", DEFAULT_FAILURES)
+        if isinstance(failures, bool) or not isinstance(failures, int) or failures < 0:
+            raise ValueError("failover.circuit.failures must be a whole number (0 to disable)")
+        cooldown = data.get("cooldown", DEFAULT_COOLDOWN)
+        if isinstance(cooldown, bool) or not isinstance(cooldown, (int, float)) or cooldown < 0:
+            raise ValueError("failover.circuit.cooldown must be a number of seconds")
+        return cls(failures, float(cooldown))
+
+    def to_dict(self) -> Dict[str, Any]:
+        return {"failures": self.failures, "cooldown": self.cooldown}
+
+
+def is_provider_failure(error: BaseException) -> bool:
+    """Whether ``error`` (or an error it was raised from) means the provider is failing:
+    a 5xx, a timeout or no connection — not a 429, a 4xx or an unparseable answer."""
+    seen = set()
+    while error is not None and id(error) not in seen:
+        seen.add(id(error))
+        status = getattr(error, "status_code", None) or getattr(error, "code", None)
+        if isinstance(status, int) and not isinstance(status, bool) and 100 <= status < 600:
+            return status >= 500
+        if isinstance(error, TimeoutExceeded):
+            return True
+        if isinstance(
+            error, (urllib.error.URLError, socket.timeout, TimeoutError, ConnectionError)
+        ):
+            return True
+        if type(error).__name__ in _UNREACHABLE:
+            return True
+        error = error.__cause__ or error.__context__
+    return False
+
+
+@dataclass
+class _Circuit:
+    failures: int = 0
+    opened_at: Optional[float] = None
+    cooldown: float = DEFAULT_COOLDOWN
+    # When the half-open trial call went out; a trial that never reports back (its
+    # run was cancelled) expires after a cooldown, and the next caller gets one.
+    trial_at: Optional[float] = None
+    opens: int = 0
+
+
+class CircuitBreaker:
+    """Per-provider circuits shared by every run in the process; safe across threads."""
+
+    def __init__(self, clock: Callable[[], float] = time.monotonic):
+        self._clock = clock
+        self._lock = threading.Lock()
+        self._circuits: Dict[str, _Circuit] = {}
+
+    def state(self, provider: str) -> str:
+        """CLOSED, OPEN, or HALF_OPEN once the cooldown is over (or a trial is out)."""
+        with self._lock:
+            return self._state(self._circuits.get(provider))
+
+    def _state(self, circuit: Optional[_Circuit]) -> str:
+        if circuit is None or circuit.opened_at is None:
+            return CLOSED
+        if circuit.trial_at is not None or self._clock() - circuit.opened_at >= circuit.cooldown:
+            return HALF_OPEN
+        return OPEN
+
+    def allow(self, provider: str, policy: CircuitPolicy) -> None:
+        """Return if a call to ``provider`` may go out; raise ``CircuitOpen`` if not.
+
+        While half open, the first caller gets the trial call and the rest wait it out.
+        """
+        if not policy.enabled:
+            return
+        with self._lock:
+            circuit = self._circuits.get(provider)
+            state = self._state(circuit)
+            if state == CLOSED:
+                return
+            now = self._clock()
+            if state == HALF_OPEN and (
+                circuit.trial_at is None or now - circuit.trial_at >= circuit.cooldown
+            ):
+                circuit.trial_at = now
+                return
+            since = circuit.opened_at if circuit.trial_at is None else circuit.trial_at
+            raise CircuitOpen(provider, max(circuit.cooldown - (now - since), 0.0))
+
+    def record_success(self, provider: str) -> None:
+        """The provider answered: its circuit closes."""
+        with self._lock:
+            circuit = self._circuits.get(provider)
+            if circuit is not None:
+                circuit.failures = 0
+                circuit.opened_at = None
+                circuit.trial_at = None
+
+    def record_failure(self, provider: str, policy: CircuitPolicy) -> bool:
+        """The provider failed a call; True if that opened (or reopened) its circuit."""
+        if not policy.enabled:
+            return False
+        with self._lock:
+            circuit = self._circuits.setdefault(provider, _Circuit())
+            circuit.failures += 1
+            # A failed trial reopens at once; a closed circuit opens at the threshold.
+            trial = circuit.trial_at is not None
+            closed = circuit.opened_at is None
+            if not trial and not (closed and circuit.failures >= policy.failures):
+                return False
+            circuit.opened_at = self._clock()
+            circuit.cooldown = policy.cooldown
+            circuit.trial_at = None
+            circuit.opens += 1
+            return True
+
+    def snapshot(self) -> Dict[str, Dict[str, Any]]:
+        """Every provider's circuit: state, failures in a row, times opened, seconds
+        until the next trial call (for metrics and ``/health``)."""
+        with self._lock:
+            snapshot = {}
+            for provider, circuit in sorted(self._circuits.items()):
+                state = self._state(circuit)
+                retry_in = 0.0
+                if state == OPEN:
+                    retry_in = circuit.cooldown - (self._clock() - circuit.opened_at)
+                snapshot[provider] = {
+                    "state": state,
+                    "failures": circuit.failures,
+                    "opens": circuit.opens,
+                    "retry_in": round(retry_in, 1),
+                }
+            return snapshot
+
+
+_shared: Optional[CircuitBreaker] = None
+_shared_lock = threading.Lock()
+
+
+def shared_breaker() -> CircuitBreaker:
+    """The process-wide breaker, so every run stops calling a failing provider at once."""
+    global _shared
+    with _shared_lock:
+        if _shared is None:
+            _shared = CircuitBreaker()
+        return _shared
@@ -0,0 +1,168 @@
+"""Fabrication guard: verify quantified claims and skills against the evidence.
+
+The auditor is a model, and a model can miss an invented "cut costs 35%". This stage
+is the deterministic backstop: every number and every named skill in the final
+documents must appear somewhere in the evidence — the candidate's baseline résumé,
+the sources directory, or their own interview answers. Anything that does not is an
+*unverifiable claim*, reported with its location.
+
+A cover letter also talks about the employer: its name, its product, the team the
+posting describes. For every document but the résumé, the job description and the
+company research count as evidence too; the résumé's claims are about the candidate
+alone, so they do not.
+
+Unverifiable claims block completion: the run ends as "completed with audit
+concerns" (documents are still written for review) unless the user explicitly
+overrides — ``--allow-unverified-claims`` on the CLI, a yes at the interactive
+prompt, or ``claim_override`` in the workflow context.
+
+What counts as a claim:
+
+- **metrics** — numbers, percentages, amounts and multipliers (``40%``, ``$1.2M``,
+  ``3x``, ``15,000``), matched as normalised tokens; digits inside a word (``B2B``,
+  ``Q3``, ``EC2``) are not metrics;
+- **skills** — every item of a "Skills"/"Technologies" section, plus tool-like tokens
+  anywhere (acronyms such as ``AWS``, mixed-case names such as ``PostgreSQL``, and
+  names with digits such as ``EC2``), matched case-insensitively.
+
+Plain prose is not parsed into claims; that remains the auditor's job.
+"""
+
+from __future__ import annotations
+
+import re
+from dataclasses import asdict, dataclass, field
+from typing import Any, Dict, Iterable, List
+
+from runtime.crewai.resume_diff import numbers_in
+
+RESUME_DOCUMENT = "resume"  # checked against the candidate's evidence only
+
+PASSED = "PASSED"
+BLOCKED = "BLOCKED"
+OVERRIDDEN = "OVERRIDDEN"
+
+_HEADING_RE = re.compile(r"^\s*(#{1,6}\s+|\*\*)(?P<title>[^*#]+?)\**\s*:?\s*$")
+_SKILL_HEADINGS = ("skill", "technolog", "tools", "tech stack", "competenc")
+_LABELLED_SKILLS_RE = re.compile(r"^\s*[-*]?\s*\**(?:[A-Za-z /&]+)\**\s*:\s*(?P<items>.+)$")
+_IN_WORD_DIGITS_RE = re.compile(r"\b[A-Za-z]+\d[A-Za-z\d]*\b")
+_TOOL_TOKEN_RE = re.compile(
+    r"\b(?:[A-Z]{2,6}s?|[A-Z][a-z]+[A-Z][A-Za-z]*|[A-Za-z]+\d+[A-Za-z\d]*)\b"
+)
+# Acronyms that are résumé vocabulary rather than claims.
+_COMMON_ACRONYMS = frozenset(
+    {
+        *("CEO", "CTO", "CFO", "VP", "HR", "PM", "AM", "USA", "UK", "EU", "US"),
+        *("II", "III", "IV", "ATS", "API", "APIs", "KPI", "KPIs", "ROI", "OKR", "OKRs", "YoY"),
+    }
+)
+# Quarters, halves and fiscal years ("Q3", "H1", "FY24") are dates, not tools.
+_CALENDAR_RE = re.compile(r"(?:Q[1-4]|H[12]|FY\d{2,4})$")
+
+
+@dataclass
+class Claim:
+    """One checkable statement and where it appears."""
+
+    kind: str  # "metric" | "skill"
+    text: str
+    document: str
+    line: int
+    section: str = ""
+
+    @property
+    def location(self) -> str:
+        where = f"{self.document}, line {self.line}"
+        return f"{where} ({self.section})" if self.section else where
+
+
+@dataclass
+class VerificationReport:
+    """Outcome of checking every claim in the final documents."""
+
+    checked: int = 0
+    unverified: List[Claim] = field(default_factory=list)
+    status: str = PASSED
+
+    @property
+    def blocking(self) -> bool:
+        return self.status == BLOCKED
+
+    def to_dict(self) -> Dict[str, Any]:
+        return {
+            "status": self.status,
+            "checked_claims": self.checked,
+            "unverified_claims": [
+                {**asdict(claim), "location": claim.location} for claim in self.unverified
+            ],
+        }
+
+
+def _split_items(text: str) -> List[str]:
+    items = re.split(r"[,;|•·]|\s/\s", text)
+    return [item.strip(" .*`_") for item in items if item.strip(" .*`_")]
+
+
+def extract_claims(document: str, name: str = "resume") -> List[Claim]:
+    """Metrics and skills in ``document`` with 1-based line numbers and section."""
+    claims: List[Claim] = []
+    section = ""
+    in_skills = False
+    for number, line in enumerate(document.splitlines(), start=1):
+        heading = _HEADING_RE.match(line)
+        if heading:
+            section = heading.group("title").strip()
+            in_skills = any(key in section.lower() for key in _SKILL_HEADINGS)
+            continue
+        for metric in sorted(numbers_in(_IN_WORD_DIGITS_RE.sub(" ", line))):
+            claims.append(Claim("metric", metric, name, number, section))
+
+        skills: List[str] = []
+        if in_skills and line.strip():
+            labelled = _LABELLED_SKILLS_RE.match(line)
+            skills = _split_items(labelled.group("items") if labelled else line.lstrip("-* "))
+        for token in _TOOL_TOKEN_RE.findall(line):
+            if token not in _COMMON_ACRONYMS and not _CALENDAR_RE.match(token):
+                skills.append(token)
+        seen = set()
+        for skill in skills:
+            key = skill.lower()
+            if key in seen or numbers_in(skill) == {skill}:
+                continue  # duplicates, and bare numbers (already metrics)
+            seen.add(key)
+            claims.append(Claim("skill", skill, name, number, section))
+    return claims
+
+
+def _supported(claim: Claim, evidence: str, evidence_numbers: set[str]) -> bool:
+    if claim.kind == "metric":
+        return claim.text in evidence_numbers
+    return claim.text.lower() in evidence
+
+
+def verify_claims(
+    documents: Dict[str, str],
+    evidence: Iterable[str],
+    override: bool = False,
+    employer: Iterable[str] = (),
+) -> VerificationReport:
+    """Check every claim in ``documents`` against the concatenated ``evidence``; the
+    documents other than the résumé may also cite ``employer`` (the job description,
+    the company research)."""
+    corpus = "\n".join(text for text in evidence if text)
+    wider = "\n".join([corpus, *(text for text in employer if text)])
+    corpora = {
+        RESUME_DOCUMENT: (corpus.lower(), numbers_in(corpus)),
+        None: (wider.lower(), numbers_in(wider)),
+    }
+
+    report = VerificationReport()
+    for name, text in documents.items():
+        corpus_lower, corpus_numbers = corpora.get(name) or corpora[None]
+        for claim in extract_claims(text or "", name):
+            report.checked += 1
+            if not _supported(claim, corpus_lower, corpus_numbers):
+                report.unverified.append(claim)
+    if report.unverified:
+        report.status = OVERRIDDEN if override else BLOCKED
+    return report
@@ -0,0 +1,212 @@
+"""Retention for local runs: ``hydra gc`` deletes what has been kept long enough.
+
+A run directory holds the résumé, cover letter, interview answers and prompt
+transcripts of an application: personal data that should not live forever. A
+``CleanupPolicy`` sets two periods, counted from the day a run started (its run id):
+
+- ``artifact_days``: after it, the run's documents go — everything in the directory
+  but its ``run.json`` manifest and ``audit_log.jsonl`` (which hold no résumé
+  content), the ``application.json`` of a sent application, and the ``report.html``
+  and ``manifest.json`` rendered from them. The run stays listed, its outcome and
+  application history still apply, and ``run.json`` records when the documents were
+  deleted under ``cleanup``. Stage-cache entries (derived from a résumé) last no
+  longer than the documents either;
+- ``state_days``: after it, the whole run directory goes.
+
+Either may be unset (keep forever). The defaults come from ``HYDRA_ARTIFACT_EXPIRE_DAYS``
+and ``HYDRA_STATE_EXPIRE_DAYS``, the variables the web backend's own cleanup reads.
+Only directories with a ``run.json`` are touched, so gc never deletes anything in
+``--out`` that is not a run. A git-backed ``--out`` (``--git``) keeps the deleted
+files in its history; that is the repository's to rewrite.
+"""
+
+from __future__ import annotations
+
+import json
+import os
+import shutil
+from dataclasses import dataclass, field
+from datetime import date, datetime
+from pathlib import Path
+from typing import List, Optional
+
+from runtime.crewai.application_email import APPLICATION_FILE
+from runtime.crewai.artifacts import ARTIFACT_INDEX_FILE, MANIFEST_FILE, record_artifacts
+from runtime.crewai.audit_log import AUDIT_LOG_FILE
+from runtime.crewai.html_report import REPORT_HTML_FILE
+from runtime.crewai.retro_audit import run_date
+from runtime.crewai.stage_cache import CACHE_SUBDIR, hydra_home
+
+ARTIFACT_DAYS_ENV = "HYDRA_ARTIFACT_EXPIRE_DAYS"
+STATE_DAYS_ENV = "HYDRA_STATE_EXPIRE_DAYS"
+
+# What a run keeps once its documents are deleted.
+KEPT_FILES = frozenset(
+    {MANIFEST_FILE, APPLICATION_FILE, AUDIT_LOG_FILE, REPORT_HTML_FILE, ARTIFACT_INDEX_FILE}
+)
+
+
+@dataclass(frozen=True)
+class CleanupPolicy:
+    """How many days runs keep their documents, and themselves; None keeps them."""
+
+    artifact_days: Optional[int] = None
+    state_days: Optional[int] = None
+
+    def __post_init__(self) -> None:
+        for name in ("artifact_days", "state_days"):
+            days = getattr(self, name)
+            if days is not None and days < 1:
+                raise ValueError(f"{name} must be at least 1, got {days}")
+        if self.artifact_days and self.state_days and self.state_days < self.artifact_days:
+            raise ValueError(
+                f"Runs cannot be deleted ({self.state_days} days) before their documents "
+                f"({self.artifact_days} days)"
+            )
+
+    @property
+    def empty(self) -> bool:
+        return self.artifact_days is None and self.state_days is None
+
+
+def _days(name: str) -> Optional[int]:
+    raw = os.environ.get(name, "").strip()
+    if not raw:
+        return None
+    try:
+        return int(raw)
+    except ValueError as err:
+        raise ValueError(f"{name} must be a whole number of days, got {raw!r}") from err
+
+
+def policy_from_env(
+    artifact_days: Optional[int] = None, state_days: Optional[int] = None
+) -> CleanupPolicy:
+    """The policy given, each period defaulting to its environment variable."""
+    return CleanupPolicy(
+        artifact_days=artifact_days if artifact_days is not None else _days(ARTIFACT_DAYS_ENV),
+        state_days=state_days if state_days is not None else _days(STATE_DAYS_ENV),
+    )
+
+
+@dataclass
+class CleanupReport:
+    """What a gc deleted (or, with ``dry_run``, would delete)."""
+
+    dry_run: bool = False
+    # Run ids whose documents were deleted, and runs deleted whole.
+    purged: List[str] = field(default_factory=list)
+    deleted: List[str] = field(default_factory=list)
+    cache_entries: int = 0
+    bytes_freed: int = 0
+
+
+def _size(path: Path) -> int:
+    if path.is_file():
+        return path.stat().st_size
+    return sum(p.stat().st_size for p in path.rglob("*") if p.is_file())
+
+
+def _remove(path: Path) -> None:
+    if path.is_dir() and not path.is_symlink():
+        shutil.rmtree(path)
+    else:
+        path.unlink()
+
+
+def _manifest(run_dir: Path) -> dict:
+    try:
+        manifest = json.loads((run_dir / MANIFEST_FILE).read_text())
+    except (OSError, ValueError):
+        return {}
+    return manifest if isinstance(manifest, dict) else {}
+
+
+def _started(run_dir: Path) -> date:
+    """The day a run started: from its id, else when its manifest was written."""
+    started = run_date(run_dir.name)
+    if started is None:
+        started = date.fromtimestamp((run_dir / MANIFEST_FILE).stat().st_mtime)
+    return started
+
+
+def _expired(days: Optional[int], age: int) -> bool:
+    return days is not None and age > days
+
+
+def clean_runs(
+    out_dir: Path,
+    policy: CleanupPolicy,
+    report: CleanupReport,
+    today: Optional[date] = None,
+) -> None:
+    """Apply ``policy`` to the runs in ``out_dir``, adding what it deleted to ``report``."""
+    out_dir = Path(out_dir)
+    if not out_dir.is_dir():
+        return
+    today = today or date.today()
+    for run_dir in sorted(out_dir.iterdir()):
+        if not (run_dir / MANIFEST_FILE).is_file():
+            continue
+        age = (today - _started(run_dir)).days
+        if _expired(policy.state_days, age):
+            report.bytes_freed += _size(run_dir)
+            report.deleted.append(run_dir.name)
+            if not report.dry_run:
+                shutil.rmtree(run_dir)
+            continue
+        manifest = _manifest(run_dir)
+        if not _expired(policy.artifact_days, age) or manifest.get("cleanup"):
+            continue
+        doomed = [path for path in run_dir.iterdir() if path.name not in KEPT_FILES]
+        report.bytes_freed += sum(_size(path) for path in doomed)
+        report.purged.append(run_dir.name)
+        if report.dry_run:
+            continue
+        for path in doomed:
+            _remove(path)
+        # The manifest lists only the files that are left.
+        record_artifacts(
+            run_dir,
+            [],
+            artifacts=[name for name in manifest.get("artifacts") or [] if name in KEPT_FILES],
+            cleanup={
+                "artifacts_deleted_at": datetime.now().isoformat(timespec="seconds"),
+                "files_deleted": len(doomed),
+            },
+        )
+
+
+def clean_stage_cache(
+    policy: CleanupPolicy, report: CleanupReport, root: Optional[Path] = None
+) -> None:
+    """Delete stage-cache entries last written more than ``artifact_days`` ago."""
+    if policy.artifact_days is None:
+        return
+    root = Path(root) if root is not None else hydra_home() / CACHE_SUBDIR
+    if not root.is_dir():
+        return
+    cutoff = datetime.now().timestamp() - policy.artifact_days * 86400
+    for path in root.glob("*/*.json"):
+        stat = path.stat()
+        if stat.st_mtime >= cutoff:
+            continue
+        report.cache_entries += 1
+        report.bytes_freed += stat.st_size
+        if not report.dry_run:
+            path.unlink()
+
+
+def collect(
+    out_dir: Path,
+    policy: CleanupPolicy,
+    dry_run: bool = False,
+    cache: bool = True,
+    today: Optional[date] = None,
+) -> CleanupReport:
+    """Apply ``policy`` to the runs in ``out_dir`` and, with ``cache``, the stage cache."""
+    report = CleanupReport(dry_run=dry_run)
+    clean_runs(out_dir, policy, report, today=today)
+    if cache:
+        clean_stage_cache(policy, report)
+    return report
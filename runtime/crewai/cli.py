@@ -4,16 +4,342 @@ Command-line interface for running the Hydra workflow end-to-end.
 Usage:
     python -m runtime.crewai.cli --jd path/to/jd.md --resume path/to/resume.md \
         --sources sources/ --out output/
+
+Add --dry-run to render every agent prompt (plus token/cost estimates) into the
+output directory without calling any model.
+
+Subcommands:
+    python -m runtime.crewai.cli audit-all --since 2024-01 [--out output/]
+        Re-audit the résumés of past runs with today's auditor rules.
+    python -m runtime.crewai.cli diff output/<run_id> [--style side-by-side]
+        Show what changed between the baseline résumé and a run's final résumé.
+    python -m runtime.crewai.cli routing [--out output/]
+        Show each model's audit record per agent and any automatic routing changes.
+    python -m runtime.crewai.cli profiles [add NAME FILE | remove NAME | recommend --jd FILE]
+        Manage the stored baseline résumés that --profile picks from.
+    python -m runtime.crewai.cli providers
+        Check which model providers are up and where their stages fail over to.
+    python -m runtime.crewai.cli report output/<run_id> [--no-pdf]
+        Write a run's report: decision, company, gaps, ATS before/after, audit, next steps.
+    python -m runtime.crewai.cli watch --feed hn --resume resume.md --sources sources/
+        Score new postings from job feeds against your résumé; queue the best for approval.
+    python -m runtime.crewai.cli email output/<run_id> [--to ADDR] [--send --via smtp|gmail]
+        Draft a run's application email with the résumé and cover letter; send on approval.
+    python -m runtime.crewai.cli followups [--out output/] [--done <run_id>]
+        List sent applications whose follow-up is due.
+    python -m runtime.crewai.cli outcome <run_id> rejected|offer|withdrawn
+        Record what came of an application, so later runs for the role are warned.
+    python -m runtime.crewai.cli gc [--artifacts-days 90] [--state-days 365] [--dry-run]
+        Delete the documents of old runs, and old runs altogether, under a retention policy.
+    python -m runtime.crewai.cli interview <run_id> --at "2026-10-20 14:00" [--tz ZONE]
+        Record an interview and write its calendar event (.ics, or --google) and prep pack.
+    python -m runtime.crewai.cli knowledge [--jd FILE | forget ID]
+        List the verified facts runs have gathered about you, or forget one.
+    python -m runtime.crewai.cli constraints [--jd FILE]
+        Show the conditions every job must meet, or check a job description against them.
+    python -m runtime.crewai.cli plugins [check NAME] [--plugin-dir DIR]
+        List the stage plugins runs would load, or try one on a sample request.
+    python -m runtime.crewai.cli pause|resume|cancel <run_id> [--server URL]
+        Pause, resume or cancel a run, live or saved (or a server's job).
 """
 
 import argparse
+import contextlib
+import json
 import os
+import secrets
+import signal
+import socket
 import sys
+import tempfile
+import time
+from dataclasses import replace
+from datetime import date
+from functools import partial
 from pathlib import Path
 
-from runtime.crewai.artifacts import RunInputs, generate_run_id, write_run_artifacts
+from runtime.crewai.agents.auditor import AuditorSuiteAgent
+from runtime.crewai.application_email import (
+    APPLICATION_FILE,
+    DEFAULT_FOLLOW_UP_DAYS,
+    EMAIL_DRAFT_FILE,
+    SENT,
+    TRANSPORTS,
+    EmailError,
+    draft_email,
+    follow_ups,
+    load_application,
+    load_draft,
+    mark_followed_up,
+    save_draft,
+    send_application,
+    transport_sender,
+)
+from runtime.crewai.application_history import (
+    DEFAULT_DEDUP_DAYS,
+    OUTCOMES,
+    RECENT,
+    prior_applications,
+    record_outcome,
+)
+from runtime.crewai.artifacts import (
+    MANIFEST_FILE,
+    RESUME_FILE,
+    RunInputs,
+    generate_run_id,
+    job_labels,
+    load_checkpoint,
+    record_artifacts,
+    write_run_artifacts,
+)
+from runtime.crewai.ats_lint import lint_documents
+from runtime.crewai.ats_parse_check import ATS_PARSE_FILE, parse_resume
+from runtime.crewai.audit_log import local_actor, record_access
+from runtime.crewai.bullet_review import ACCEPT, EDIT, PROVENANCE_FILE, REJECT, run_review
+from runtime.crewai.bundle import BundleError, bundle_name, export_bundle, import_bundle
+from runtime.crewai.cleanup import collect, policy_from_env
+from runtime.crewai.compensation import (
+    DEFAULT_CURRENCY,
+    NEGOTIATION_BRIEF_FILE,
+    CompensationTargets,
+    parse_amount,
+)
+from runtime.crewai.constraints import (
+    CONSTRAINTS_FILE,
+    ConstraintsError,
+    check_constraints,
+    load_constraints,
+)
+from runtime.crewai.contracts import GapReview
+from runtime.crewai.cover_letter_overlap import RECENT_DAYS, recent_cover_letters
+from runtime.crewai.cv_formats import (
+    CV_FORMATS,
+    CVFormat,
+    PersonalData,
+    PersonalDataError,
+    get_cv_format,
+    load_personal,
+    write_cv,
+)
+from runtime.crewai.dashboard import Dashboard
+from runtime.crewai.debrief import capture_debrief, company_debriefs, save_debrief
+from runtime.crewai.determinism import DEFAULT_SEED
+from runtime.crewai.dry_run import write_dry_run_artifacts
+from runtime.crewai.encryption import (
+    ENCRYPT_ENV,
+    EncryptionError,
+    encryption_enabled,
+    read_bytes,
+    read_text,
+    state_cipher,
+    write_text,
+)
+from runtime.crewai.evals import ASSERTIONS, EVALS_DIR, EvalError, pass_rates, run_case
+from runtime.crewai.evals import discover as discover_evals
+from runtime.crewai.evals import load_case as load_eval_case
+from runtime.crewai.experiments import (
+    BASELINE,
+    EXPERIMENT_STAGES,
+    REPORT_FILE,
+    SCORE_ATS,
+    SCORE_JUDGE,
+    SCORERS,
+    Case,
+    Trial,
+    Variant,
+    ats_score,
+    find_runs,
+    judge_score,
+    load_case,
+    parse_variant,
+    resume_of,
+    write_experiment,
+)
+from runtime.crewai.experiments import summarize as summarize_experiment
+from runtime.crewai.failover import HEALTH_URLS, shared_health
+from runtime.crewai.git_versioning import GitVersioning, GitVersioningError
 from runtime.crewai.hydra_workflow import HydraWorkflow, RunStatus
-from runtime.crewai.llm_client import LLMClientError, get_llm_client
+from runtime.crewai.impact import manifest_summary as impact_summary
+from runtime.crewai.interview import TRANSCRIPT_FILE, load_transcript
+from runtime.crewai.interview_calendar import (
+    DEFAULT_DURATION_MINUTES,
+    INTERVIEWS_FILE,
+    CalendarError,
+    load_interviews,
+    parse_start,
+    push_to_google,
+    save_interview,
+    schedule_interview,
+    write_ics,
+    write_prep_pack,
+)
+from runtime.crewai.job_boards import JobBoardError, fetch_posting, save_posting
+from runtime.crewai.job_watch import (
+    APPROVED,
+    DEFAULT_INTERVAL_MINUTES,
+    DEFAULT_MIN_COVERAGE,
+    DEFAULT_MIN_FIT,
+    PENDING,
+    REJECTED,
+    WATCH_MODEL,
+    JobQueue,
+    PollReport,
+    WatchError,
+    configured_feeds,
+    gap_scorer,
+    poll,
+)
+from runtime.crewai.json_resume import (
+    JsonResumeError,
+    looks_like_json_resume,
+    parse_json_resume,
+    to_markdown,
+)
+from runtime.crewai.judge import LABELS as JUDGE_LABELS
+from runtime.crewai.judge import overall
+from runtime.crewai.knowledge_base import (
+    KnowledgeBase,
+    facts_from_interview,
+    facts_from_sources,
+)
+from runtime.crewai.linkedin_import import (
+    LinkedInImportError,
+    missing_sections,
+    parse_export,
+    render_json,
+    render_markdown,
+)
+from runtime.crewai.llm_client import LLMClientError, complete_batch, get_llm_client
+from runtime.crewai.locale_policy import (
+    POLICIES,
+    LocalePolicy,
+    apply_locale_policy,
+    get_policy,
+)
+from runtime.crewai.localization import LANGUAGES, Language, get_language
+from runtime.crewai.mcp_server import HydraRuns, McpServer, serve
+from runtime.crewai.model_config import LLMClientError as AgentModelError
+from runtime.crewai.model_config import (
+    get_llm_for_agent,
+    get_llm_for_spec,
+    parse_model_spec,
+    resolve_api_key,
+)
+from runtime.crewai.model_routing import ModelRouting, collect_stats, configured_spec
+from runtime.crewai.outreach import (
+    CHANNELS,
+    HIRING_MANAGER,
+    OPENER,
+    OUTREACH_FILE,
+    OUTREACH_PREFERENCES_FILE,
+    OUTREACH_VARIANTS_FILE,
+    RECIPIENTS,
+    SUBJECT_LINE,
+    load_variants,
+    option_lists,
+    record_choice,
+    save_variants,
+)
+from runtime.crewai.pii_redaction import redaction_enabled
+from runtime.crewai.pipeline_config import PipelineConfigError, load_pipeline_config
+from runtime.crewai.plugins import (
+    ANCHOR_STAGES,
+    PluginError,
+    StagePlugin,
+    discover_plugins,
+    plugin_dirs,
+    run_plugin,
+)
+from runtime.crewai.profiles import AUTO as AUTO_PROFILE
+from runtime.crewai.profiles import Profile, ProfileError, ProfileStore, rank_profiles
+from runtime.crewai.prompt_packs import AUTO as AUTO_PACK
+from runtime.crewai.prompt_packs import (
+    PromptPackError,
+    available_packs,
+    detect_pack,
+    load_pack,
+    load_packs,
+    pack_dirs,
+    score_pack,
+)
+from runtime.crewai.prompt_transcript import (
+    PROMPT_TRANSCRIPT_FILE,
+    TRANSCRIPT_MARKDOWN_FILE,
+    load_transcript,
+    render_transcript,
+)
+from runtime.crewai.quick_apply import QUICK_APPLY_BUDGET_SECONDS, LatencyBudget
+from runtime.crewai.referrals import REFERRALS_FILE, ContactsError, load_contacts
+from runtime.crewai.replay import REPLAY_STAGES, load_prompt_overrides, upstream
+from runtime.crewai.resume_diff import html_diff, side_by_side, summarize, unified_diff
+from runtime.crewai.resume_model import RESUME_PATCHES_FILE
+from runtime.crewai.resume_themes import (
+    DEFAULT_THEME,
+    Theme,
+    ThemeError,
+    available_themes,
+    load_theme,
+    theme_dirs,
+    write_theme,
+)
+from runtime.crewai.retention import LEAN, VERBATIM, RetentionPolicy
+from runtime.crewai.retro_audit import REPORT_FILE as RETRO_AUDIT_FILE
+from runtime.crewai.retro_audit import audit_all, parse_since
+from runtime.crewai.review_server import (
+    DEFAULT_HOST,
+    DEFAULT_PORT,
+    SERVE_TOKEN_ENV,
+    RemoteGreenlight,
+    serve_reviews,
+)
+from runtime.crewai.run_control import (
+    CANCEL,
+    CANCELLED,
+    PAUSE,
+    PAUSED,
+    RESUMED,
+    SERVER_URL_ENV,
+    RunControl,
+    RunControlError,
+    control_run,
+    control_server,
+    replay_arguments,
+    resume_arguments,
+    run_status,
+    set_run_status,
+)
+from runtime.crewai.run_report import (
+    RUN_REPORT_MARKDOWN_FILE,
+    RUN_REPORT_PDF_FILE,
+    RunReport,
+    build_run_report,
+    report_for_run,
+    write_run_report,
+)
+from runtime.crewai.scenarios import ScenarioError, discover, load_scenario, run_scenario
+from runtime.crewai.seniority import MATCHED as SENIORITY_MATCHED
+from runtime.crewai.skill_taxonomy import (
+    TaxonomyError,
+    default_taxonomy,
+    env_embedder,
+    load_taxonomy,
+)
+from runtime.crewai.stage_cache import StageCache, hydra_home
+from runtime.crewai.tailoring_variants import PICK_ASK, PICK_AUDIT, PICK_MODES, VariantPreferences
+from runtime.crewai.tools import TOOL_TRANSCRIPT_FILE, default_toolsets
+from runtime.crewai.translation import PROVIDERS as TRANSLATION_PROVIDERS
+from runtime.crewai.translation import (
+    TranslationError,
+    get_translation_provider,
+    load_glossary,
+    translate_documents,
+)
+from runtime.crewai.wasm_plugins import WasmHost
+from runtime.crewai.web_search import PROVIDERS as SEARCH_PROVIDERS
+from runtime.crewai.web_search import SearchError, get_search_provider
+from runtime.crewai.workflow_templates import DEFAULT_TEMPLATE
+
+DIFF_STYLES = ("unified", "side-by-side")
 
 # Map an explicit run status to a process exit code.
 EXIT_CODES = {
@@ -22,6 +348,7 @@ EXIT_CODES = {
     RunStatus.AUDIT_ERROR: 1,
     RunStatus.PAUSED: 1,
     RunStatus.FAILED: 2,
+    RunStatus.INTERRUPTED: 130,  # the shell's code for a Ctrl-C'd command
 }
 
 
@@ -80,8 +407,41 @@ def build_parser() -> argparse.ArgumentParser:
         description="Composable Crew - Hydra Workflow Runner",
         formatter_class=argparse.ArgumentDefaultsHelpFormatter,
     )
-    parser.add_argument("--jd", required=True, help="Path to job description file")
-    parser.add_argument("--resume", required=True, help="Path to resume file")
+    parser.add_argument("--jd", help="Path to job description file")
+    parser.add_argument(
+        "--jd-url",
+        metavar="URL",
+        help="Fetch the job description from a posting instead of --jd. Greenhouse, Lever "
+        "and Workday postings are read through the board's API and split into "
+        "responsibilities, must-haves and nice-to-haves; other pages through their "
+        "JobPosting data or text. Saved under ~/.hydra/job_postings/",
+    )
+    parser.add_argument(
+        "--resume", help="Path to resume file (Markdown, text or JSON Resume)"
+    )
+    parser.add_argument(
+        "--profile",
+        help="Use a stored baseline résumé instead of --resume (see `hydra profiles`); "
+        f"'{AUTO_PROFILE}' picks the one covering most of the JD's skills",
+    )
+    parser.add_argument(
+        "--recommend-profile",
+        action="store_true",
+        help="Have the gap analyzer also recommend the stored profile that fits the job best",
+    )
+    parser.add_argument(
+        "--json-resume",
+        action="store_true",
+        help="Also write the tailored résumé as a JSON Resume document (resume.json); "
+        "automatic when --resume is one",
+    )
+    parser.add_argument(
+        "--patches",
+        action="store_true",
+        help="Tailor by editing the baseline résumé bullet by bullet instead of rewriting "
+        "it; each patch can be accepted or rejected later in hydra serve "
+        f"({RESUME_PATCHES_FILE})",
+    )
     parser.add_argument(
         "--sources",
         help="Path to directory containing source documents for truth verification (defaults to same directory as --jd file)",
@@ -91,6 +451,25 @@ def build_parser() -> argparse.ArgumentParser:
         default="output/",
         help="Directory where outputs will be written",
     )
+    parser.add_argument(
+        "--git",
+        action="store_true",
+        help="Keep --out as a git repository (initialized if it is not one) and commit "
+        "the run after each stage: stage, models and token usage in the message",
+    )
+    parser.add_argument(
+        "--encrypt",
+        action="store_true",
+        help="Encrypt the run's documents, stage outputs and cache entries (AES-GCM) with "
+        "$HYDRA_STATE_PASSPHRASE or the OS keychain passphrase; also $HYDRA_ENCRYPT_STATE=1",
+    )
+    parser.add_argument(
+        "--redact-pii",
+        action="store_true",
+        help="Send emails, phone numbers and street addresses to model providers as "
+        "placeholders, restored in the outputs (with --dry-run: see what would be sent); "
+        "also $HYDRA_REDACT_PII=1",
+    )
     parser.add_argument(
         "--model",
         help="Override model name (defaults to OPENROUTER_MODEL or anthropic/claude-sonnet-4.5)",
@@ -101,6 +480,17 @@ def build_parser() -> argparse.ArgumentParser:
         default=2,
         help="Maximum number of audit retry attempts",
     )
+    parser.add_argument(
+        "--deterministic",
+        action="store_true",
+        help="Call every model at temperature 0 with a fixed seed (where the provider takes "
+        "one), so a run on the same inputs can be reproduced; parameters go in run.json",
+    )
+    parser.add_argument(
+        "--seed",
+        type=int,
+        help=f"Seed for --deterministic (default: {DEFAULT_SEED})",
+    )
     parser.add_argument(
         "--verbose",
         action="store_true",
@@ -111,14 +501,431 @@ def build_parser() -> argparse.ArgumentParser:
         action="store_true",
         help="Enable interactive mode (Human-in-the-Loop) for interviews and approvals",
     )
+    parser.add_argument(
+        "--remote-greenlight",
+        action="store_true",
+        help="Wait for the gap-analysis greenlight from `hydra serve` (e.g. on your phone) "
+        "instead of the terminal; other prompts take their non-interactive defaults",
+    )
+    parser.add_argument(
+        "--tui",
+        action="store_true",
+        help="Show a live dashboard (stage progress, token spend, log) and answer the "
+        "greenlight inline with the gap analysis shown; implies --interactive",
+    )
+    parser.add_argument(
+        "--dry-run",
+        action="store_true",
+        help="Render every agent prompt and estimate tokens/cost without calling any model",
+    )
+    parser.add_argument(
+        "--resume-run",
+        metavar="RUN_ID",
+        help="Continue an interrupted or failed run in --out from its saved stage outputs "
+        "(pass the same --jd/--resume/--sources); the result is written as a new run",
+    )
+    parser.add_argument(
+        "--replay-run",
+        metavar="RUN_ID",
+        help="Reuse the stage outputs of this run in --out from before --replay-from and run "
+        "the rest again (see `hydra replay`); the result is written as a new run",
+    )
+    parser.add_argument(
+        "--replay-from",
+        choices=REPLAY_STAGES,
+        help="First stage a --replay-run runs again",
+    )
+    parser.add_argument(
+        "--prompt-dir",
+        metavar="DIR",
+        help="Prompts that replace the agents' own, laid out like agents/: "
+        "<agent>/prompt.md or <agent>.md",
+    )
+    parser.add_argument(
+        "--prompt-pack",
+        default=AUTO_PACK,
+        metavar="NAME",
+        help="The profession the gap analysis and tailoring are tuned for: engineering, "
+        "product, design, sales or your own (see `hydra prompt-packs`); default: "
+        "detected from the job description",
+    )
+    parser.add_argument(
+        "--no-cache",
+        action="store_true",
+        help="Do not reuse or store cached stage outputs (cache lives in $HYDRA_HOME, "
+        "default ~/.hydra)",
+    )
+    parser.add_argument(
+        "--lang",
+        metavar="LANG",
+        help="Write the résumé and cover letter in this language, with its date "
+        f"conventions in themed output (ISO 639-1). Known: {', '.join(LANGUAGES)}",
+    )
+    parser.add_argument(
+        "--translate-to",
+        metavar="LANG",
+        help="Also produce the final documents in this language (ISO 639-1, e.g. de, fr)",
+    )
+    parser.add_argument(
+        "--translator",
+        choices=TRANSLATION_PROVIDERS,
+        default="llm",
+        help="Translation provider for --translate-to (deepl requires DEEPL_API_KEY)",
+    )
+    parser.add_argument(
+        "--glossary",
+        help="YAML glossary (source term -> target term) enforced during translation",
+    )
+    parser.add_argument(
+        "--tailoring-models",
+        metavar="SPECS",
+        help="Comma-separated provider:model specs for the tailoring stage. One pins the "
+        "model; two or more tailor in parallel and pick a winner (see --pick)",
+    )
+    parser.add_argument(
+        "--pick",
+        choices=PICK_MODES,
+        help="How to choose among tailoring variants: audit scores them, ask shows diffs "
+        "and prompts (default: ask with --interactive, otherwise audit)",
+    )
+    parser.add_argument(
+        "--research",
+        action="store_true",
+        help="Research the company (news, funding, tech stack) with live web search "
+        "before gap analysis; findings are cited in research.json",
+    )
+    parser.add_argument(
+        "--search-provider",
+        choices=SEARCH_PROVIDERS,
+        help="Web search provider for --research (default: the first with an API key: "
+        "TAVILY_API_KEY, SERPAPI_API_KEY, BRAVE_SEARCH_API_KEY)",
+    )
+    parser.add_argument(
+        "--company",
+        help="Company name for --research and the run directory's name "
+        "(inferred from the job description otherwise)",
+    )
+    parser.add_argument(
+        "--role",
+        help="Job title for the run directory's name (inferred from the job description "
+        "otherwise)",
+    )
+    parser.add_argument(
+        "--compensation",
+        action="store_true",
+        help="Finish with a negotiation brief: market pay ranges (cited from the job "
+        f"description or --research), talking points and equity/bonus questions, "
+        f"in {NEGOTIATION_BRIEF_FILE}",
+    )
+    parser.add_argument(
+        "--target-salary",
+        metavar="AMOUNT",
+        help="Base salary you are aiming for, e.g. 185000 or 185k (with --compensation)",
+    )
+    parser.add_argument(
+        "--walk-away",
+        metavar="AMOUNT",
+        help="Lowest base salary you would accept (with --compensation)",
+    )
+    parser.add_argument(
+        "--dedup-days",
+        type=int,
+        default=DEFAULT_DEDUP_DAYS,
+        metavar="N",
+        help="Warn when a run in --out applied to the same company within N days "
+        f"(default: {DEFAULT_DEDUP_DAYS}; 0 warns only about rejected roles)",
+    )
+    parser.add_argument(
+        "--no-knowledge-base",
+        action="store_true",
+        help="Neither use nor add to the verified facts earlier runs gathered about you "
+        "(kept in $HYDRA_HOME, see `hydra knowledge`)",
+    )
+    parser.add_argument(
+        "--constraints",
+        metavar="FILE",
+        help="Conditions every job must meet (work authorization, sponsorship, remote "
+        "only, salary floor, clearance): a job that breaks one fails the run before "
+        f"tailoring (default: $HYDRA_HOME/{CONSTRAINTS_FILE} when it exists)",
+    )
+    parser.add_argument(
+        "--no-constraints",
+        action="store_true",
+        help="Check no constraints, not even those in $HYDRA_HOME",
+    )
+    parser.add_argument(
+        "--reuse-interview",
+        metavar="RUN_ID",
+        help="Reuse the interview answers of an earlier run in --out: questions like "
+        "its questions are not asked again, and its other answers are kept",
+    )
+    parser.add_argument(
+        "--contacts",
+        metavar="CSV",
+        help="Your contacts (name, company, relationship columns): finish with referral "
+        f"paths and a drafted ask for each one at the target company, in {REFERRALS_FILE}",
+    )
+    parser.add_argument(
+        "--currency",
+        default=DEFAULT_CURRENCY,
+        help=f"Currency of --target-salary and --walk-away (default: {DEFAULT_CURRENCY})",
+    )
+    parser.add_argument(
+        "--tools",
+        action="store_true",
+        help="Let agents call tools during their stage (read source files, ATS score, "
+        f"calculator); every call is recorded in {TOOL_TRANSCRIPT_FILE}",
+    )
+    parser.add_argument(
+        "--quick-apply",
+        action="store_true",
+        help="Finish within --budget seconds: fast models, overlapped stages, and no "
+        "research, interview or optional stage that would not fit",
+    )
+    parser.add_argument(
+        "--budget",
+        type=float,
+        default=QUICK_APPLY_BUDGET_SECONDS,
+        metavar="SECONDS",
+        help=f"Wall-clock budget for --quick-apply (default: {QUICK_APPLY_BUDGET_SECONDS})",
+    )
+    parser.add_argument(
+        "--retention",
+        default=VERBATIM,
+        metavar="POLICY",
+        help=f"Stage outputs to keep only a summary of: comma-separated stages, {LEAN} "
+        f"(research and the executive brief) or {VERBATIM} (default: keep everything)",
+    )
+    parser.add_argument(
+        "--no-auto-routing",
+        action="store_true",
+        help="Use the models in model_config as configured: do not re-route agents from "
+        "the audit record of past runs in --out (see `hydra routing`)",
+    )
+    parser.add_argument(
+        "--overlap-days",
+        type=int,
+        default=RECENT_DAYS,
+        metavar="DAYS",
+        help="Compare the cover letter with letters from other runs in --out written in the "
+        f"last DAYS days and rewrite near-duplicate paragraphs (default: {RECENT_DAYS}; "
+        "0 disables)",
+    )
+    parser.add_argument(
+        "--allow-unverified-claims",
+        action="store_true",
+        help="Report metrics/skills not found in the résumé, sources or interview answers, "
+        "and résumé dates that do not match the baseline, without blocking completion",
+    )
+    parser.add_argument(
+        "--show-diff",
+        choices=DIFF_STYLES,
+        help="Print the baseline-vs-final résumé diff to the terminal "
+        "(resume.diff and resume_diff.html are always written)",
+    )
+    parser.add_argument(
+        "--review",
+        action="store_true",
+        help="After the run, accept/reject/edit each changed résumé bullet from the "
+        "keyboard (decisions are recorded in provenance.json)",
+    )
+    parser.add_argument(
+        "--target-country",
+        metavar="CC",
+        help="Enforce this country's résumé conventions (photo, date of birth, marital "
+        f"status) on export. Known: {', '.join(sorted(POLICIES))}",
+    )
+    parser.add_argument(
+        "--theme",
+        help="Also render the final résumé with this theme: resume.themed.md, resume.tex "
+        "and resume.pdf when a LaTeX engine is installed (see `hydra themes`)",
+    )
+    parser.add_argument(
+        "--theme-dir",
+        action="append",
+        default=[],
+        metavar="DIR",
+        help="Directory of your own themes, searched before the built-in ones (repeatable)",
+    )
+    parser.add_argument(
+        "--cv-format",
+        choices=sorted(CV_FORMATS),
+        help="Render the final résumé in a regional CV format: its country's conventions, "
+        "theme, photo and personal data section (europass also writes europass.xml)",
+    )
+    parser.add_argument(
+        "--personal",
+        metavar="FILE",
+        help="YAML personal data (photo, date of birth, nationality, ...) for --cv-format "
+        "(default: $HYDRA_HOME/personal.yaml)",
+    )
+    parser.add_argument(
+        "--report",
+        action="store_true",
+        help="Also write run_report.md/.html (and run_report.pdf when a LaTeX engine is "
+        "installed): company snapshot, gaps, differentiators, ATS score before/after, "
+        "audit findings and next steps (see `hydra report`)",
+    )
+    parser.add_argument(
+        "--email-to",
+        metavar="ADDR",
+        help="Also draft the application email to ADDR, résumé and cover letter attached; "
+        "nothing is sent until `hydra email RUN --send`",
+    )
+    parser.add_argument(
+        "--email-from",
+        metavar="ADDR",
+        help="Sender of the drafted email (default: the address in the résumé's header)",
+    )
+    parser.add_argument(
+        "--skill-taxonomy",
+        action="append",
+        default=[],
+        metavar="FILE",
+        help="Extra skill aliases (same layout as taxonomy/skills.yaml) used to match JD "
+        "skills to the résumé's, after ~/.hydra/skills.yaml (repeatable)",
+    )
+    parser.add_argument(
+        "--pipeline-config",
+        metavar="FILE",
+        help="Pipeline config (timeouts per stage and per LLM call) instead of "
+        "$HYDRA_PIPELINE_CONFIG or ~/.hydra/pipeline.yaml",
+    )
+    parser.add_argument(
+        "--max-cost",
+        type=float,
+        metavar="USD",
+        help="Spend budget for the run's model calls: as it runs out, the less important "
+        "stages move to a cheap model (overrides budget.usd in the pipeline config)",
+    )
+    parser.add_argument(
+        "--template",
+        default=DEFAULT_TEMPLATE,
+        metavar="NAME",
+        help="Workflow template: which stages run. quick (research, tailoring, ATS), "
+        "thorough (everything; the default), referral (adds outreach messages) or one "
+        "from the pipeline config's templates section (see `hydra templates`)",
+    )
+    parser.add_argument(
+        "--outreach",
+        action="store_true",
+        help="Finish with outreach messages (LinkedIn connection request, InMail, email) "
+        f"in {OUTREACH_FILE}, whatever the template; the referral template always does",
+    )
+    parser.add_argument(
+        "--outreach-to",
+        choices=[recipient.replace(" ", "-") for recipient in RECIPIENTS],
+        default=HIRING_MANAGER.replace(" ", "-"),
+        help="Who the outreach messages are for (default: hiring-manager)",
+    )
+    parser.add_argument(
+        "--seniority",
+        action="store_true",
+        help="Check the résumé's framing (scope, leading verbs, team sizes) against the "
+        "level the job is hired at, and have tailoring correct under- or over-leveling",
+    )
+    parser.add_argument(
+        "--impact",
+        action="store_true",
+        help="Rewrite the résumé's weak bullets as impact statements before tailoring, "
+        "asking in the interview for the metrics they lack (with --interactive)",
+    )
+    parser.add_argument(
+        "--judge",
+        action="store_true",
+        help="Finish by scoring the final résumé 1-10 on relevance, truthfulness, "
+        f"readability and ATS-friendliness, with reasons in {RUN_REPORT_MARKDOWN_FILE}",
+    )
+    parser.add_argument(
+        "--plugin-dir",
+        action="append",
+        default=[],
+        metavar="DIR",
+        help="Also load stage plugins from DIR, after ~/.hydra/plugins (repeatable; see "
+        "`hydra plugins`)",
+    )
+    parser.add_argument("--no-plugins", action="store_true", help="Run no stage plugins")
     return parser
 
 
+def _report_unverified_claims(audit_report: dict | None) -> None:
+    """List claims the evidence does not support, with their locations."""
+    verification = (audit_report or {}).get("claim_verification") or {}
+    claims = verification.get("unverified_claims") or []
+    if not claims:
+        return
+    overridden = verification.get("status") == "OVERRIDDEN"
+    print(
+        f"🔎 {len(claims)} claim(s) not found in your résumé, sources or interview answers"
+        + (" (kept by override):" if overridden else " — blocking completion:")
+    )
+    for claim in claims:
+        print(f"   - [{claim['kind']}] {claim['text']} — {claim['location']}")
+    if not overridden:
+        print("   Add evidence to --sources, or re-run with --allow-unverified-claims.")
+
+
+def _report_chronology(audit_report: dict | None) -> None:
+    """List the résumé dates that do not hold up against themselves or the baseline."""
+    chronology = (audit_report or {}).get("chronology") or {}
+    discrepancies = chronology.get("discrepancies") or []
+    if not discrepancies:
+        return
+    overridden = chronology.get("status") == "OVERRIDDEN"
+    print(
+        f"📅 {len(discrepancies)} date discrepancy(ies) in the résumé"
+        + (" (kept by override):" if overridden else " — blocking completion:")
+    )
+    for item in discrepancies:
+        print(f"   - [{item['kind']}] {item['detail']} — {item['location']}")
+    if not overridden:
+        print("   Fix the dates, or re-run with --allow-unverified-claims to keep them.")
+
+
+def _report_ats_lint(audit_report: dict | None) -> None:
+    """List the ATS anti-patterns in the final documents, each failing the audit."""
+    violations = ((audit_report or {}).get("ats_lint") or {}).get("violations") or []
+    if not violations:
+        return
+    print(f"🚫 {len(violations)} ATS lint violation(s) — failing the audit:")
+    for violation in violations:
+        print(f"   - [{violation['rule']}] {violation['detail']} — {violation['location']}")
+
+
+def _pick_profile(store: ProfileStore, name: str, job_description: str, taxonomy) -> Profile:
+    """The ``--profile``: by name, or for ``auto`` the best skill coverage of the JD."""
+    if name != AUTO_PROFILE:
+        return store.get(name)
+    fits = rank_profiles(job_description, store.list(), taxonomy)
+    if not fits:
+        raise ProfileError("no profiles stored (see `hydra profiles add`)")
+    best = fits[0]
+    print(
+        f"ℹ️  Using profile {best.profile.name}: covers {len(best.covered)} of "
+        f"{len(best.covered) + len(best.missing)} skills in the job description"
+    )
+    return best.profile
+
+
+def _report_profile_recommendation(result, profile: Profile | None) -> None:
+    """The gap analyzer's pick among the stored profiles, when it differs."""
+    gap = (getattr(result, "intermediate_results", None) or {}).get("gap_analysis")
+    review = GapReview.from_raw(gap)
+    if not review.recommended_profile:
+        return
+    if profile is not None and review.recommended_profile == profile.name:
+        print(f"🎯 The gap analyzer agrees profile {profile.name} fits this job best")
+        return
+    reason = f": {review.profile_reason}" if review.profile_reason else ""
+    print(f"💡 The gap analyzer recommends profile {review.recommended_profile}{reason}")
+    print(f"   Re-run with --profile {review.recommended_profile} to tailor from it.")
+
+
 def _read_file(path: Path) -> str:
     """Read a text file, raising a helpful error if missing."""
     if not path.is_file():
         raise FileNotFoundError(f"Input file not found: {path}")
-    return path.read_text()
+    return read_text(path)
 
 
 def _read_sources(directory: Path) -> str:
@@ -142,94 +949,3159 @@ def _read_sources(directory: Path) -> str:
     return "\n".join(parts)
 
 
-def main(argv: list[str] | None = None) -> int:
-    """CLI entrypoint. Returns an exit code instead of exiting for testability."""
-    parser = build_parser()
-    args = parser.parse_args(argv)
-
-    # Detect and change to repository root directory
+def _translate(args: argparse.Namespace, result, fallback_llm, workflow=None):
+    """Translate the final documents; a translation failure never fails the run."""
     try:
-        repo_root = _get_repo_root()
-        os.chdir(repo_root)
-        _validate_repo_structure(repo_root)
-    except FileNotFoundError as err:
-        parser.error(str(err))
+        glossary = load_glossary(Path(args.glossary), args.translate_to) if args.glossary else {}
+        llm = None
+        if args.translator == "llm":
+            # Translation is candidate-facing writing: use the tailoring agent's model.
+            try:
+                llm = get_llm_for_agent("tailoring_agent")
+            except AgentModelError:
+                llm = fallback_llm
+        redactor = workflow.redactor if workflow is not None else None
+        provider = get_translation_provider(args.translator, llm=llm, redactor=redactor)
+        translation = translate_documents(
+            result.final_documents, provider, args.translate_to, glossary
+        )
+    except TranslationError as err:
+        print(f"⚠️  Translation to '{args.translate_to}' skipped: {err}")
+        return None
 
-    # Resolve paths relative to repo root
-    jd_path = Path(args.jd)
-    resume_path = Path(args.resume)
+    for issue in translation.glossary_issues:
+        print(f"⚠️  Glossary: {issue}")
+    return translation
 
-    # Default sources to same directory as JD file if not specified
-    if args.sources:
-        sources_dir = Path(args.sources)
-    else:
-        sources_dir = jd_path.parent
-        print(f"ℹ️  No --sources specified, defaulting to: {sources_dir}")
 
-    out_dir = Path(args.out)
+def _report_resume_diff(baseline: str, tailored: str, sources: str, style: str | None) -> None:
+    """Print the requested diff plus the new-number / dropped-line hints."""
+    color = sys.stdout.isatty()
+    if style == "unified":
+        print(unified_diff(baseline, tailored, color=color, tofile="final"))
+    elif style == "side-by-side":
+        print(side_by_side(baseline, tailored, color=color))
+    summary = summarize(baseline, tailored, sources)
+    if summary.new_numbers:
+        print(
+            "⚠️  Numbers in the final résumé found in neither your résumé nor your "
+            f"sources: {', '.join(summary.new_numbers)} — check they are not fabricated."
+        )
+    if summary.dropped_lines:
+        print(
+            f"ℹ️  {len(summary.dropped_lines)} baseline lines have no counterpart in the final "
+            "résumé — see resume.diff to confirm nothing important was lost."
+        )
 
-    # Validate that all input paths exist
-    if not jd_path.exists():
-        parser.error(f"Job description file not found: {jd_path}")
-    if not resume_path.exists():
-        parser.error(f"Resume file not found: {resume_path}")
-    if not sources_dir.exists():
-        parser.error(f"Sources directory not found: {sources_dir}")
-    if not sources_dir.is_dir():
-        parser.error(f"Sources path must be a directory: {sources_dir}")
 
-    try:
-        jd_text = _read_file(jd_path)
-        resume_text = _read_file(resume_path)
-        sources_text = _read_sources(sources_dir)
-    except (FileNotFoundError, ValueError) as err:
-        parser.error(str(err))
+def _report_ats_parse(report: dict | None, verbose: bool = False) -> None:
+    """Print what a simulated ATS extracted, and the layout hazards that got in its way."""
+    if not report:
+        return
+    missing = report.get("missing_fields") or []
+    print(
+        f"🤖 ATS parse check: score {report['score']}/100"
+        + (f", not extracted: {', '.join(missing)}" if missing else ", all key fields extracted")
+    )
+    hazards = report.get("hazards") or []
+    for hazard in hazards if verbose else hazards[:5]:
+        print(f"   - line {hazard['line']}: {hazard['kind']} — {hazard['detail']}")
+    if len(hazards) > 5 and not verbose:
+        print(f"   … {len(hazards) - 5} more in {ATS_PARSE_FILE}")
+    for heading in report.get("unrecognised_headings") or []:
+        print(f"   - heading '{heading}' is not a section an ATS recognises")
+    jd_skills = report.get("jd_skills")
+    if jd_skills:
+        print(
+            f"🔑 JD skills: {len(jd_skills['matched'])} found as written, "
+            f"{len(jd_skills['synonyms'])} under another name, {len(jd_skills['missing'])} missing"
+        )
+        for synonym in jd_skills["synonyms"]:
+            print(
+                f"   - JD says '{synonym['jd_term']}', your résumé '{synonym['resume_term']}': "
+                "a keyword-matching ATS may miss it"
+            )
+        if jd_skills["missing"]:
+            print(f"   - not found: {', '.join(jd_skills['missing'])}")
 
-    try:
-        llm = get_llm_client(model=args.model)
-    except LLMClientError as err:
-        print(f"❌ LLM configuration error: {err}", file=sys.stderr)
-        return 1
 
-    workflow = HydraWorkflow(
-        llm,
-        max_audit_retries=args.max_audit_retries,
-        interactive=args.interactive,
-        # A non-interactive CLI run has no way to resume a pause, so it proceeds
-        # past the human gates automatically. `--interactive` uses the real prompts.
-        auto_approve=not args.interactive,
+def _report_latency_budget(budget: dict | None) -> None:
+    """Print how a quick-apply run spent its budget."""
+    if not budget:
+        return
+    print(
+        f"⏱️  Quick apply: {budget['elapsed_seconds']:g}s of {budget['budget_seconds']:g}s"
+        + (f"; skipped {', '.join(budget['skipped'])}" if budget["skipped"] else "")
     )
+    if budget.get("exceeded"):
+        print(f"⚠️  Budget ran out during {budget['exceeded']}")
 
-    print("Starting Hydra workflow...\n")
-    print(f"Job description: {jd_path}")
-    print(f"Resume: {resume_path}")
-    print(f"Sources: {sources_dir}")
-    print(f"Output directory: {out_dir}\n")
 
-    context = {
-        "job_description": jd_text,
-        "resume": resume_text,
-        "source_documents": sources_text,
-    }
+def _report_timeouts(errors: list | None) -> None:
+    """Print the model calls and stages that ran out of time (see timeouts)."""
+    timeouts = [error for error in errors or [] if error.get("kind") == "timeout"]
+    if not timeouts:
+        return
+    print(f"⏱️  {len(timeouts)} timeout(s); retried or failed over as configured:")
+    for error in timeouts:
+        what = "LLM call" if error.get("scope") == "llm_call" else "whole stage"
+        print(f"   - {error['stage']}: {what} after {error['seconds']:g}s")
 
-    result = workflow.execute(context)
 
-    # Run-scoped output directory + PII-free manifest.
-    run_id = generate_run_id()
-    inputs = RunInputs(
-        job_description_chars=len(jd_text),
-        resume_chars=len(resume_text),
-        sources_chars=len(sources_text),
-        jd_path=str(jd_path),
-        resume_path=str(resume_path),
-        sources_path=str(sources_dir),
+def _report_cost_budget(budget: dict | None) -> None:
+    """Print the run's spend against its budget, and the stages moved to a cheap model."""
+    if not budget:
+        return
+    print(f"💵 Spent ~${budget['spent_usd']:.2f} of ${budget['usd']:g} budget")
+    for decision in budget["decisions"]:
+        if decision["action"] == "downgrade":
+            print(f"   - {decision['stage']} → {decision['to_model']}: {decision['reason']}")
+
+
+def _report_contact_header(report: dict | None) -> None:
+    """Print the résumé header fields restored from the baseline."""
+    changes = (report or {}).get("changes") or []
+    if not changes:
+        return
+    fields = ", ".join(f"{change['field']} {change['change']}" for change in changes)
+    print(f"📇 Restored the résumé's name and contacts from your baseline ({fields})")
+
+
+def _report_length(report: dict | None) -> None:
+    """Print how the résumé was trimmed to its length limits, and what is still over."""
+    if not report or not report["before"]["violations"]:
+        return
+    before, after = report["before"], report["after"]
+    done = [f"{report['trims']} trim(s)"] if report["trims"] else []
+    done += [f"{report['bullets_cut']} bullet(s) cut"] if report["bullets_cut"] else []
+    print(
+        f"📏 Résumé over its length limits: about {before['pages']:g} → {after['pages']:g} "
+        f"pages" + (f" ({', '.join(done)})" if done else "")
     )
-    status = result.status
-    # Preserve intermediate stage outputs whenever the run didn't cleanly complete.
-    include_intermediate = status is not RunStatus.COMPLETED
-    run_dir = write_run_artifacts(
-        out_dir, result, run_id=run_id, inputs=inputs, include_intermediate=include_intermediate
+    for violation in after["violations"]:
+        print(f"   - still {violation}")
+
+
+def _report_cover_letter_overlap(report: dict | None) -> None:
+    """Print how the cover letter compared with other recent applications' letters."""
+    if not report or not report.get("initial_overlaps"):
+        return
+    remaining = report.get("overlaps") or []
+    rewrites = report.get("rewrites", 0)
+    if not remaining:
+        print(
+            f"✍️  Cover letter rewritten {rewrites}× to differentiate "
+            f"{report['initial_overlaps']} paragraph(s) repeated from other applications"
+        )
+        return
+    print(f"⚠️  Cover letter still repeats {len(remaining)} paragraph(s) of other letters:")
+    for overlap in remaining:
+        print(
+            f"   - paragraph {overlap['paragraph']} ≈ {overlap['other_run']} paragraph "
+            f"{overlap['other_paragraph']} ({overlap['similarity']:.0%} similar)"
+        )
+
+
+def _report_seniority(result, requested: bool) -> None:
+    """Print the role's level, the résumé's, and what tailoring was asked to fix."""
+    calibration = getattr(result, "seniority", None)
+    if not calibration:
+        if requested:
+            print("⚠️  Seniority could not be calibrated (see execution.log)")
+        return
+    levels = f"role {calibration['role_level']}, résumé {calibration['resume_level']}"
+    if calibration["verdict"] == SENIORITY_MATCHED:
+        print(f"🎚️  Seniority: matched ({levels})")
+        return
+    print(f"🎚️  Seniority: {calibration['verdict']} ({levels}); tailoring reframes it:")
+    for issue in calibration.get("issues") or []:
+        print(f"   - {issue}")
+
+
+def _report_impact(result, requested: bool) -> None:
+    """Print how many weak bullets were rewritten and how many metric questions were
+    answered."""
+    impact = getattr(result, "impact", None)
+    if not impact:
+        if requested:
+            print("⚠️  Weak bullets could not be rewritten (see execution.log)")
+        return
+    interrogation = (getattr(result, "intermediate_results", None) or {}).get(
+        "interrogation"
+    ) or {}
+    summary = impact_summary(impact, interrogation.get("interview_notes"))
+    print(
+        f"💪 Impact: {summary['rewritten']} of {summary['weak_bullets']} weak bullet(s) "
+        f"rewritten; {summary['questions']} metric question(s), {summary['answered']} answered"
+    )
+    if summary["dropped_for_invented_numbers"]:
+        print(
+            f"   {summary['dropped_for_invented_numbers']} rewrite(s) dropped for numbers "
+            "the résumé and sources do not contain"
+        )
+    if summary["questions"] and not summary["answered"]:
+        print("   Run with --interactive to answer the metric questions")
+
+
+def _report_compensation(brief: dict | None, requested: bool) -> None:
+    """Print where the negotiation brief landed and where the target sits in the range."""
+    if not brief:
+        if requested:
+            print("⚠️  Negotiation brief could not be prepared (see execution.log)")
+        return
+    ranges = brief.get("market_ranges") or []
+    estimated = sum(1 for item in ranges if item.get("basis") == "model estimate")
+    print(
+        f"💰 Negotiation brief: {len(ranges)} market range(s), {estimated} model-estimated "
+        f"→ {NEGOTIATION_BRIEF_FILE}"
     )
+    assessment = brief.get("target_assessment") or {}
+    if assessment.get("position") in ("below", "within", "above"):
+        print(f"   Your target is {assessment['position']} the base range ({assessment['basis']})")
+
+
+def _report_outreach(outreach: dict | None, requested: bool) -> None:
+    """Print the outreach variants' sizes and the connection request, ready to paste."""
+    if not outreach:
+        if requested:
+            print("⚠️  Outreach messages could not be written (see execution.log)")
+        return
+    variants = outreach.get("variants") or {}
+    print(f"✉️  Outreach to the {outreach.get('recipient')} → {OUTREACH_FILE}")
+    for channel in CHANNELS:
+        variant = variants.get(channel.name)
+        if variant:
+            trimmed = " (trimmed to fit)" if variant.get("trimmed") else ""
+            print(f"   {channel.label}: {variant['chars']}/{variant['limit']} chars{trimmed}")
+    connection = variants.get("connection_request")
+    if connection:
+        print(f"   > {connection['message']}")
+    options = option_lists(outreach)
+    if options:
+        chosen = {c["variant_kind"]: c["rank_chosen"] for c in outreach.get("choices") or []}
+        print(f"   Subject lines and openers → {OUTREACH_VARIANTS_FILE}")
+        for kind, ranked in options.items():
+            rank = chosen.get(kind, 1)
+            text = next((o["text"] for o in ranked if o["rank"] == rank), ranked[0]["text"])
+            picked = "picked" if kind in chosen else "top"
+            print(f"   {picked} {kind.replace('_', ' ')}: {text}")
+        if len(chosen) < len(options):
+            print("   Pick with: hydra outreach-pick <run_id> --subject-line N --opener N")
+
+
+def _report_referrals(referrals: dict | None, requested: bool) -> None:
+    """Print who the candidate knows at the company and the path suggested for each."""
+    if not referrals:
+        if requested:
+            print("⚠️  Referral paths could not be found (see execution.log)")
+        return
+    matches = referrals.get("contacts") or []
+    print(
+        f"🤝 Referrals: {len(matches)} of {referrals.get('contacts_checked', 0)} contact(s) "
+        f"at {referrals.get('company')} → {REFERRALS_FILE}"
+    )
+    for match in matches:
+        drafted = "" if match.get("ask") else " (no ask drafted)"
+        print(f"   {match['name']}: {match['path']}{drafted}")
+
+
+def _report_prompt_pack(pack: dict | None) -> None:
+    """The prompt pack the run was tuned with, and why (see prompt_packs)."""
+    if not pack:
+        return
+    how = f"detected: {pack['reason']}" if pack["detected"] else pack["reason"]
+    print(f"🎒 Prompt pack {pack['name']} ({how})")
+
+
+def _report_judge(judgement: dict | None, requested: bool) -> None:
+    """Print the final résumé's rubric scores."""
+    if not judgement:
+        if requested:
+            print("⚠️  The résumé could not be judged (see execution.log)")
+        return
+    scores = ", ".join(
+        f"{JUDGE_LABELS[criterion]} {item['score']}"
+        for criterion, item in judgement["scores"].items()
+    )
+    print(f"⚖️  Judge: {overall(judgement)}/10 ({scores}) → {RUN_REPORT_MARKDOWN_FILE}")
+
+
+def _skill_taxonomy(files: list[str]):
+    """The run's skill taxonomy: default_taxonomy, checked strictly when files are given."""
+    if not files:
+        return default_taxonomy()
+    return load_taxonomy([Path(path) for path in files], embedder=env_embedder())
+
+
+def build_ats_check_parser() -> argparse.ArgumentParser:
+    """Argument parser for the ``ats-check`` subcommand."""
+    parser = argparse.ArgumentParser(
+        prog="hydra ats-check",
+        description="Parse a résumé as a naive ATS would, report what it extracts, and "
+        "lint it for ATS anti-patterns",
+        formatter_class=argparse.ArgumentDefaultsHelpFormatter,
+    )
+    parser.add_argument("resume", help="Résumé file (Markdown or plain text)")
+    parser.add_argument(
+        "--jd", help="Job description: also report which of its skills the résumé has"
+    )
+    parser.add_argument(
+        "--skill-taxonomy",
+        action="append",
+        default=[],
+        metavar="FILE",
+        help="Extra skill aliases for --jd (same layout as taxonomy/skills.yaml)",
+    )
+    parser.add_argument("--json", action="store_true", help="Print the full report as JSON")
+    return parser
+
+
+def _ats_check(argv: list[str]) -> int:
+    """``ats-check``: simulated ATS parse and lint; exits 1 when key fields are not
+    extracted or a lint rule is broken."""
+    parser = build_ats_check_parser()
+    args = parser.parse_args(argv)
+    try:
+        job_description = _read_file(Path(args.jd)) if args.jd else None
+        taxonomy = _skill_taxonomy(args.skill_taxonomy)
+        resume = _read_file(Path(args.resume))
+        report = parse_resume(resume, job_description, taxonomy).to_dict()
+        lint = lint_documents({"resume": resume}, taxonomy).to_dict()
+    except (FileNotFoundError, TaxonomyError) as err:
+        parser.error(str(err))
+    if args.json:
+        print(json.dumps({**report, "lint": lint}, indent=2, ensure_ascii=False))
+    else:
+        _report_ats_parse(report, verbose=True)
+        _report_ats_lint({"ats_lint": lint})
+    return 1 if report["missing_fields"] or lint["violations"] else 0
+
+
+def build_diff_parser() -> argparse.ArgumentParser:
+    """Argument parser for the ``diff`` subcommand."""
+    parser = argparse.ArgumentParser(
+        prog="hydra diff",
+        description="Show what changed between the baseline résumé and a run's final résumé",
+        formatter_class=argparse.ArgumentDefaultsHelpFormatter,
+    )
+    parser.add_argument("run_dir", help="Run directory (output/<run_id>)")
+    parser.add_argument(
+        "--baseline",
+        help="Baseline résumé (defaults to the --resume path recorded in run.json)",
+    )
+    parser.add_argument("--style", choices=DIFF_STYLES, default="unified")
+    parser.add_argument("--html", metavar="PATH", help="Also write a side-by-side HTML diff")
+    parser.add_argument("--no-color", action="store_true", help="Disable ANSI colours")
+    return parser
+
+
+def _load_baseline(parser: argparse.ArgumentParser, run_dir: Path, baseline_path) -> str:
+    """The run's baseline résumé: ``baseline_path``, else the path recorded in run.json."""
+    if baseline_path is None:
+        baseline_path = _recorded_input(parser, run_dir, "resume_path", "--baseline")
+    return _read_file(Path(baseline_path))
+
+
+def _recorded_input(parser: argparse.ArgumentParser, run_dir: Path, key: str, flag: str) -> str:
+    """An input path from the run's manifest (resolving it like the original run did)."""
+    manifest = json.loads(_read_file(run_dir / MANIFEST_FILE))
+    path = (manifest.get("inputs") or {}).get(key)
+    if path is None:
+        parser.error(f"run.json records no {key.split('_')[0]} path; pass {flag}")
+    # Manifest paths are relative to the repo root the run was made from.
+    if not Path(path).is_absolute():
+        os.chdir(_get_repo_root())
+    return path
+
+
+def _diff(argv: list[str]) -> int:
+    """``diff``: print a run's baseline-vs-final résumé diff."""
+    parser = build_diff_parser()
+    args = parser.parse_args(argv)
+    run_dir = Path(args.run_dir).resolve()
+    try:
+        tailored = _read_file(run_dir / RESUME_FILE)
+        baseline = _load_baseline(parser, run_dir, args.baseline)
+    except (FileNotFoundError, ValueError) as err:
+        parser.error(str(err))
+
+    color = sys.stdout.isatty() and not args.no_color
+    if args.style == "side-by-side":
+        print(side_by_side(baseline, tailored, color=color))
+    else:
+        print(unified_diff(baseline, tailored, color=color, tofile="final"))
+    if args.html:
+        Path(args.html).write_text(html_diff(baseline, tailored))
+        print(f"HTML diff → {args.html}")
+    return 0
+
+
+def build_decrypt_parser() -> argparse.ArgumentParser:
+    """Argument parser for the ``decrypt`` subcommand."""
+    parser = argparse.ArgumentParser(
+        prog="hydra decrypt",
+        description="Print a file of an encrypted run (--encrypt) in the clear",
+        formatter_class=argparse.ArgumentDefaultsHelpFormatter,
+    )
+    parser.add_argument("file", help="A file from an encrypted run, e.g. output/<run_id>/resume.md")
+    parser.add_argument("--out", metavar="PATH", help="Write the plaintext here instead")
+    return parser
+
+
+def _decrypt(argv: list[str]) -> int:
+    """``decrypt``: print (or write out) an encrypted run file; plain files pass through."""
+    parser = build_decrypt_parser()
+    args = parser.parse_args(argv)
+    path = Path(args.file)
+    if not path.is_file():
+        parser.error(f"File not found: {path}")
+    try:
+        data = read_bytes(path)
+    except EncryptionError as err:
+        parser.error(str(err))
+    if (path.parent / MANIFEST_FILE).is_file():
+        record_access(path.parent, "decrypt", actor=local_actor(), file=path.name)
+    if args.out:
+        Path(args.out).write_bytes(data)
+        print(f"🔓 {path} → {args.out}")
+    else:
+        sys.stdout.buffer.write(data)
+        sys.stdout.flush()
+    return 0
+
+
+def build_outreach_pick_parser() -> argparse.ArgumentParser:
+    """Argument parser for the ``outreach-pick`` subcommand."""
+    parser = argparse.ArgumentParser(
+        prog="hydra outreach-pick",
+        description="Show a run's ranked outreach subject lines and openers, or record the "
+        "ones you used; later runs rank the angles you pick first",
+        formatter_class=argparse.ArgumentDefaultsHelpFormatter,
+    )
+    parser.add_argument("run", help="Run id in --out, or a run directory")
+    parser.add_argument("--out", default="output/", help="Directory holding the runs")
+    parser.add_argument("--subject-line", type=int, metavar="RANK", help="Subject line used")
+    parser.add_argument("--opener", type=int, metavar="RANK", help="Opener used")
+    return parser
+
+
+def _outreach_pick(argv: list[str]) -> int:
+    """``outreach-pick``: list a run's outreach options, or record the candidate's pick."""
+    parser = build_outreach_pick_parser()
+    args = parser.parse_args(argv)
+    run_dir = _run_dir(parser, args.run, args.out)
+    document = load_variants(run_dir)
+    if not document or not option_lists(document):
+        parser.error(f"No {OUTREACH_VARIANTS_FILE} in {run_dir} (run with --outreach)")
+    options = option_lists(document)
+    picks = {
+        kind: rank
+        for kind, rank in ((SUBJECT_LINE, args.subject_line), (OPENER, args.opener))
+        if rank is not None
+    }
+    if not picks:
+        for kind, ranked in options.items():
+            print(f"{kind.replace('_', ' ').capitalize()}s:")
+            for option in ranked:
+                print(f"  [{option['rank']}] {option['text']}")
+                if option.get("rationale"):
+                    print(f"      {option['angle']}: {option['rationale']}")
+        return 0
+
+    for kind, rank in picks.items():
+        if not any(option["rank"] == rank for option in options.get(kind) or []):
+            parser.error(f"No {kind.replace('_', ' ')} ranked {rank}")
+    preferences = VariantPreferences(hydra_home() / OUTREACH_PREFERENCES_FILE)
+    for kind, rank in picks.items():
+        record_choice(document, kind, rank, run_id=run_dir.name, preferences=preferences)
+        print(f"✅ {kind.replace('_', ' ').capitalize()} {rank} recorded")
+    save_variants(run_dir, document)
+    manifest = json.loads(_read_file(run_dir / MANIFEST_FILE))
+    chosen = {c["variant_kind"]: c["rank_chosen"] for c in document.get("choices") or []}
+    record_artifacts(run_dir, [], outreach={**(manifest.get("outreach") or {}), "chosen": chosen})
+    return 0
+
+
+def build_review_parser() -> argparse.ArgumentParser:
+    """Argument parser for the ``review`` subcommand."""
+    parser = argparse.ArgumentParser(
+        prog="hydra review",
+        description="Accept, reject or edit each changed résumé bullet of a run",
+        formatter_class=argparse.ArgumentDefaultsHelpFormatter,
+    )
+    parser.add_argument("run_dir", help="Run directory (output/<run_id>)")
+    parser.add_argument(
+        "--baseline",
+        help="Baseline résumé (defaults to the --resume path recorded in run.json)",
+    )
+    parser.add_argument(
+        "--sources",
+        help="Sources directory for the post-review claim check (defaults to the one "
+        "recorded in run.json)",
+    )
+    parser.add_argument("--no-color", action="store_true", help="Disable ANSI colours")
+    return parser
+
+
+def _review_run(run_dir: Path, baseline: str, sources: str, color: bool) -> int:
+    """Run the bullet review on ``run_dir`` and report the post-review claim check."""
+    verification = run_review(run_dir, baseline, [sources], color=color)
+    if verification is None:
+        return 0
+    counts = json.loads(read_text(run_dir / PROVENANCE_FILE))["decisions"]
+    print(
+        f"\n📝 Review saved: {counts[ACCEPT]} accepted, {counts[REJECT]} rejected, "
+        f"{counts[EDIT]} edited → {run_dir / PROVENANCE_FILE}"
+    )
+    _report_unverified_claims({"claim_verification": verification.to_dict()})
+    return 1 if verification.blocking else 0
+
+
+def _review(argv: list[str]) -> int:
+    """``review``: keyboard-driven accept/reject/edit of a run's changed bullets."""
+    parser = build_review_parser()
+    args = parser.parse_args(argv)
+    run_dir = Path(args.run_dir).resolve()
+    try:
+        _read_file(run_dir / RESUME_FILE)
+        baseline = _load_baseline(parser, run_dir, args.baseline)
+        sources_path = args.sources or _recorded_input(
+            parser, run_dir, "sources_path", "--sources"
+        )
+        sources = _read_sources(Path(sources_path))
+    except (FileNotFoundError, ValueError) as err:
+        parser.error(str(err))
+    return _review_run(run_dir, baseline, sources, sys.stdout.isatty() and not args.no_color)
+
+
+def _run_dry(
+    context: dict,
+    out_dir: Path,
+    max_audit_retries: int,
+    redact_pii: bool = False,
+    prompt_overrides: dict | None = None,
+    prompt_pack: str | None = None,
+) -> int:
+    """Walk the pipeline recording prompts instead of calling models (--dry-run)."""
+    # No LLM client is needed: nothing is sent, so a dry run works without API keys.
+    workflow = HydraWorkflow(
+        None,
+        max_audit_retries=max_audit_retries,
+        dry_run=True,
+        redact_pii=redact_pii,
+        prompt_overrides=prompt_overrides,
+        prompt_pack=prompt_pack,
+    )
+    result = workflow.execute(context)
+    _report_prompt_pack(getattr(result, "prompt_pack", None))
+
+    run_id = generate_run_id()
+    run_dir = write_dry_run_artifacts(out_dir, workflow.dry_run_recorder, run_id)
+
+    print(f"{'#':>2}  {'stage':<20} {'model':<52} {'in tok':>8} {'est. $':>9}")
+    for record in workflow.dry_run_recorder.records:
+        cost = f"{record.cost_usd:.4f}" if record.cost_usd is not None else "?"
+        print(
+            f"{record.index:>2}  {record.stage:<20} {record.model:<52} "
+            f"{record.input_tokens:>8} {cost:>9}"
+        )
+    totals = workflow.dry_run_recorder.totals()
+    print(
+        f"\n🧪 Dry run: {totals['calls']} model calls planned, "
+        f"~{totals['input_tokens']} input + ~{totals['output_tokens']} output tokens, "
+        f"~${totals['cost_usd']:.4f}. Prompts → {run_dir}"
+    )
+    if totals["cache_read_tokens"]:
+        print(
+            f"💾 ~{totals['cache_read_tokens']} input tokens are repeated cache-eligible "
+            f"prefixes; provider prompt caching would save ~${totals['cache_savings_usd']:.4f}"
+        )
+
+    if result.status is RunStatus.FAILED:
+        print(f"❌ Dry run stopped early: {result.error_message}", file=sys.stderr)
+        return EXIT_CODES[RunStatus.FAILED]
+    return 0
+
+
+def build_audit_all_parser() -> argparse.ArgumentParser:
+    """Argument parser for the ``audit-all`` subcommand."""
+    parser = argparse.ArgumentParser(
+        prog="hydra audit-all",
+        description="Re-run today's audit over the résumés of past runs and report any "
+        "that the current rules would flag",
+        formatter_class=argparse.ArgumentDefaultsHelpFormatter,
+    )
+    parser.add_argument(
+        "--since",
+        required=True,
+        help="Only runs on/after this date (YYYY, YYYY-MM or YYYY-MM-DD)",
+    )
+    parser.add_argument("--out", default="output/", help="Directory holding the past runs")
+    parser.add_argument(
+        "--sources",
+        help="Sources directory to verify against when a run's recorded sources have moved",
+    )
+    parser.add_argument(
+        "--model",
+        help="Fallback model when no per-agent auditor model is configured",
+    )
+    return parser
+
+
+def _audit_all(argv: list[str]) -> int:
+    """``audit-all``: exit 0 when no run is flagged, 1 when any is, 2 on setup errors."""
+    parser = build_audit_all_parser()
+    args = parser.parse_args(argv)
+    try:
+        parse_since(args.since)
+    except ValueError as err:
+        parser.error(str(err))
+
+    # Manifests record input paths relative to the repo root, as the main command does.
+    try:
+        os.chdir(_get_repo_root())
+    except FileNotFoundError as err:
+        parser.error(str(err))
+
+    sources_override = None
+    if args.sources:
+        try:
+            sources_override = _read_sources(Path(args.sources))
+        except (FileNotFoundError, ValueError) as err:
+            parser.error(str(err))
+
+    try:
+        llm = get_llm_for_agent("auditor_suite")
+    except AgentModelError:
+        try:
+            llm = get_llm_client(model=args.model)
+        except LLMClientError as err:
+            print(f"❌ LLM configuration error: {err}", file=sys.stderr)
+            return 2
+
+    out_dir = Path(args.out)
+    report = audit_all(
+        out_dir,
+        args.since,
+        AuditorSuiteAgent(llm),
+        _read_file,
+        _read_sources,
+        sources_override=sources_override,
+    )
+
+    if not report.entries:
+        print(f"No runs found in {out_dir} since {args.since}.")
+        return 0
+    for entry in report.entries:
+        outcome = f"{entry.outcome} (passed originally)" if entry.newly_flagged else entry.outcome
+        print(f"{entry.run_id:<28} {outcome:<28} {entry.reason}".rstrip())
+        for issue in entry.blocking:
+            print(f"    - {issue}")
+    counts = report.to_dict()["counts"]
+    print(
+        f"\n🔎 Re-audited {len(report.entries)} runs: {counts['flagged']} flagged, "
+        f"{counts['clean']} clean, {counts['skipped']} skipped, {counts['error']} errors. "
+        f"Report → {out_dir / RETRO_AUDIT_FILE}"
+    )
+    return 1 if report.flagged else 0
+
+
+def build_mcp_parser() -> argparse.ArgumentParser:
+    """Argument parser for the ``mcp`` subcommand."""
+    parser = argparse.ArgumentParser(
+        prog="hydra mcp",
+        description="Serve Hydra runs to an MCP client (e.g. Claude Desktop) over stdio",
+        formatter_class=argparse.ArgumentDefaultsHelpFormatter,
+    )
+    parser.add_argument("--out", default="output/", help="Directory where runs are written")
+    parser.add_argument("--model", help="Fallback model (same as the main command's --model)")
+    parser.add_argument("--max-audit-retries", type=int, default=2)
+    return parser
+
+
+def _mcp(argv: list[str]) -> int:
+    """``mcp``: stdio MCP server; runs pause for the greenlight and interview answers."""
+    args = build_mcp_parser().parse_args(argv)
+
+    def _workflow() -> HydraWorkflow:
+        # Resolved per run, so a missing key fails that run with a readable error
+        # instead of taking the server down.
+        return HydraWorkflow(
+            get_llm_client(model=args.model),
+            max_audit_retries=args.max_audit_retries,
+            prompt_pack=AUTO_PACK,
+        )
+
+    print(f"Hydra MCP server on stdio; runs → {args.out}", file=sys.stderr)
+    serve(McpServer(HydraRuns(Path(args.out), _workflow)))
+    return 0
+
+
+def build_serve_parser() -> argparse.ArgumentParser:
+    """Argument parser for the ``serve`` subcommand."""
+    parser = argparse.ArgumentParser(
+        prog="hydra serve",
+        description="Review runs from a browser or phone: gap analysis, greenlight, the "
+        "tailored résumé with its changes highlighted, and downloads",
+        formatter_class=argparse.ArgumentDefaultsHelpFormatter,
+    )
+    parser.add_argument("--out", default="output/", help="Directory holding the runs")
+    parser.add_argument(
+        "--host",
+        default=DEFAULT_HOST,
+        help="Address to listen on (0.0.0.0 to reach it from your phone on the same network)",
+    )
+    parser.add_argument("--port", type=int, default=DEFAULT_PORT)
+    parser.add_argument(
+        "--token",
+        default=os.environ.get(SERVE_TOKEN_ENV),
+        help=f"Access token (default: ${SERVE_TOKEN_ENV}, else a new one per start)",
+    )
+    return parser
+
+
+def _serve(argv: list[str]) -> int:
+    """``serve``: review pages for the runs in --out; Ctrl-C stops it."""
+    args = build_serve_parser().parse_args(argv)
+    token = args.token or secrets.token_urlsafe(16)
+    host = socket.gethostname() if args.host in ("0.0.0.0", "::") else args.host
+    print(f"📱 Reviewing runs in {args.out} at http://{host}:{args.port}/?token={token}")
+    print("   Start runs with --remote-greenlight to greenlight them here. Ctrl-C stops.")
+    try:
+        serve_reviews(Path(args.out), token, args.host, args.port)
+    except OSError as err:
+        print(f"❌ Cannot listen on {args.host}:{args.port}: {err}", file=sys.stderr)
+        return 1
+    except KeyboardInterrupt:
+        pass
+    return 0
+
+
+def build_debrief_parser() -> argparse.ArgumentParser:
+    """Argument parser for the ``debrief`` subcommand."""
+    parser = argparse.ArgumentParser(
+        prog="hydra debrief",
+        description="Record how an interview went for an application; later runs for the "
+        "same company use it in interview prep",
+        formatter_class=argparse.ArgumentDefaultsHelpFormatter,
+    )
+    parser.add_argument("run", help="Run id in --out, or a run directory")
+    parser.add_argument("--out", default="output/", help="Directory holding the runs")
+    parser.add_argument("--company", help="Company (defaults to the one recorded in run.json)")
+    return parser
+
+
+def _debrief(argv: list[str]) -> int:
+    """``debrief``: guided Q&A after an interview, appended to the run's debrief.json."""
+    parser = build_debrief_parser()
+    args = parser.parse_args(argv)
+    run_dir = Path(args.run)
+    if not run_dir.is_dir():
+        run_dir = Path(args.out) / args.run
+    if not (run_dir / MANIFEST_FILE).is_file():
+        parser.error(f"No run found: {args.run}")
+    manifest = json.loads(_read_file(run_dir / MANIFEST_FILE))
+    company = args.company or (manifest.get("inputs") or {}).get("company")
+
+    print(f"Debrief for {manifest.get('run_id', run_dir.name)} (Ctrl-C to abandon)")
+    try:
+        debrief = capture_debrief(company, input_fn=input, out=sys.stdout)
+    except (KeyboardInterrupt, EOFError):
+        print("\nDebrief abandoned; nothing saved.")
+        return 1
+    debrief.run_id = manifest.get("run_id", run_dir.name)
+    path = save_debrief(run_dir, debrief)
+    print(f"📝 Debrief saved → {path}")
+    if debrief.follow_up_by:
+        print(f"⏰ Follow up with {debrief.company} by {debrief.follow_up_by}")
+    return 0
+
+
+def _draft_application_email(
+    run_dir: Path, to: str, sender: str | None = None, subject: str | None = None
+) -> int:
+    """Draft the run's application email into ``run_dir`` and report it."""
+    try:
+        message, application = draft_email(run_dir, to, sender=sender, subject=subject)
+    except EmailError as err:
+        print(f"⚠️  No application email drafted: {err}")
+        return 1
+    path = save_draft(run_dir, message, application)
+    record_artifacts(
+        run_dir, [EMAIL_DRAFT_FILE, APPLICATION_FILE], application=application.to_manifest()
+    )
+    print(f"✉️  Application email to {to} drafted → {path}")
+    print(f"   Attached: {', '.join(application.attachments)}")
+    print(f"   Send it with: hydra email {run_dir} --send")
+    return 0
+
+
+def _run_dir(parser: argparse.ArgumentParser, run: str, out: str) -> Path:
+    """``run`` as a run directory, or as a run id in ``out``."""
+    run_dir = Path(run)
+    if not run_dir.is_dir():
+        run_dir = Path(out) / run
+    if not (run_dir / MANIFEST_FILE).is_file():
+        parser.error(f"No run found: {run}")
+    return run_dir
+
+
+def build_email_parser() -> argparse.ArgumentParser:
+    """Argument parser for the ``email`` subcommand."""
+    parser = argparse.ArgumentParser(
+        prog="hydra email",
+        description="Draft a run's application email with the rendered résumé and cover "
+        "letter attached and, once you approve it, send it and schedule a follow-up",
+        formatter_class=argparse.ArgumentDefaultsHelpFormatter,
+    )
+    parser.add_argument("run", help="Run id in --out, or a run directory")
+    parser.add_argument("--out", default="output/", help="Directory holding the runs")
+    parser.add_argument("--to", help="Recipient; (re)drafts the email")
+    parser.add_argument(
+        "--from",
+        dest="sender",
+        help="Sender (default: the address in the résumé's header)",
+    )
+    parser.add_argument("--subject", help="Subject (default: Application: ROLE – NAME)")
+    parser.add_argument("--send", action="store_true", help="Send the draft")
+    parser.add_argument("--via", choices=TRANSPORTS, default=TRANSPORTS[0], help="How to send")
+    parser.add_argument("--yes", action="store_true", help="Send without asking to confirm")
+    parser.add_argument(
+        "--force", action="store_true", help="Send even though the run's audit did not pass"
+    )
+    parser.add_argument(
+        "--follow-up-days",
+        type=int,
+        default=DEFAULT_FOLLOW_UP_DAYS,
+        help="Days after sending to follow up (see `hydra followups`)",
+    )
+    return parser
+
+
+def _email(argv: list[str]) -> int:
+    """``email``: draft, show and (on approval) send a run's application email."""
+    parser = build_email_parser()
+    args = parser.parse_args(argv)
+    run_dir = _run_dir(parser, args.run, args.out)
+    if args.to:
+        if _draft_application_email(run_dir, args.to, args.sender, args.subject):
+            return 1
+    elif args.sender or args.subject:
+        parser.error("--from and --subject apply to a new draft: pass --to as well")
+    try:
+        message = load_draft(run_dir)
+    except EmailError as err:
+        parser.error(str(err))
+    application = load_application(run_dir)
+    if application is not None and application.status == SENT:
+        print(f"📨 Sent to {application.to} on {application.sent_at}")
+        print(f"   Follow up on {application.follow_up_on}")
+        return 0
+    if not args.send:
+        if not args.to:
+            print(f"From: {message['From']}\nTo: {message['To']}\nSubject: {message['Subject']}")
+            print(f"\n{message.get_body(('plain',)).get_content()}")
+        return 0
+
+    status = json.loads(_read_file(run_dir / MANIFEST_FILE)).get("status")
+    if status != RunStatus.COMPLETED.value and not args.force:
+        parser.error(f"The run is {status}, not completed: review it, or pass --force")
+    try:
+        send = transport_sender(args.via)
+    except EmailError as err:
+        parser.error(str(err))
+    if not args.yes:
+        attached = ", ".join(part.get_filename() for part in message.iter_attachments())
+        print(f"To: {message['To']}\nSubject: {message['Subject']}\nAttached: {attached}")
+        try:
+            answer = input(f"Send via {args.via}? [y/N] ").strip().lower()
+        except EOFError:
+            answer = ""
+        if answer not in ("y", "yes"):
+            print("Not sent; the draft is kept.")
+            return 1
+    try:
+        application = send_application(
+            run_dir, send, args.via, follow_up_days=args.follow_up_days
+        )
+    except EmailError as err:
+        print(f"❌ Not sent: {err}")
+        return 1
+    record_artifacts(run_dir, [], application=application.to_manifest())
+    print(f"📨 Sent to {application.to} via {args.via}")
+    print(f"⏰ Follow up on {application.follow_up_on} (hydra followups)")
+    return 0
+
+
+def build_followups_parser() -> argparse.ArgumentParser:
+    """Argument parser for the ``followups`` subcommand."""
+    parser = argparse.ArgumentParser(
+        prog="hydra followups",
+        description="List sent applications whose follow-up is due",
+        formatter_class=argparse.ArgumentDefaultsHelpFormatter,
+    )
+    parser.add_argument("--out", default="output/", help="Directory holding the runs")
+    parser.add_argument("--all", action="store_true", help="Also follow-ups not yet due")
+    parser.add_argument("--done", metavar="RUN", help="Mark RUN's follow-up as done")
+    return parser
+
+
+def _followups(argv: list[str]) -> int:
+    """``followups``: due follow-ups of sent applications, or mark one done."""
+    parser = build_followups_parser()
+    args = parser.parse_args(argv)
+    if args.done:
+        run_dir = _run_dir(parser, args.done, args.out)
+        try:
+            application = mark_followed_up(run_dir)
+        except EmailError as err:
+            parser.error(str(err))
+        record_artifacts(run_dir, [], application=application.to_manifest())
+        print(f"✅ Followed up on {application.run_id}")
+        return 0
+    due = follow_ups(Path(args.out), include_upcoming=args.all)
+    if not due:
+        print("No follow-ups due.")
+        return 0
+    today = date.today().isoformat()
+    for run_dir, application in due:
+        marker = "⏰" if (application.follow_up_on or "") <= today else "  "
+        label = " — ".join(part for part in (application.company, application.role) if part)
+        print(f"{marker} {application.follow_up_on}  {label or application.run_id}")
+        print(f"   sent to {application.to} on {application.sent_at[:10]} · {run_dir}")
+    return 0
+
+
+def build_gc_parser() -> argparse.ArgumentParser:
+    """Argument parser for the ``gc`` subcommand."""
+    parser = argparse.ArgumentParser(
+        prog="hydra gc",
+        description="Delete the documents of old runs, and old runs altogether, under a "
+        "retention policy (see runtime/crewai/cleanup.py)",
+        formatter_class=argparse.ArgumentDefaultsHelpFormatter,
+    )
+    parser.add_argument("--out", default="output/", help="Directory holding the runs")
+    parser.add_argument(
+        "--artifacts-days",
+        type=int,
+        metavar="DAYS",
+        help="Delete a run's documents, transcripts and cached stage outputs after DAYS "
+        "(default: $HYDRA_ARTIFACT_EXPIRE_DAYS)",
+    )
+    parser.add_argument(
+        "--state-days",
+        type=int,
+        metavar="DAYS",
+        help="Delete the whole run, manifest included, after DAYS "
+        "(default: $HYDRA_STATE_EXPIRE_DAYS)",
+    )
+    parser.add_argument("--dry-run", action="store_true", help="Only list what would be deleted")
+    parser.add_argument("--no-cache", action="store_true", help="Leave the stage cache alone")
+    return parser
+
+
+def _gc(argv: list[str]) -> int:
+    """``gc``: apply the retention policy to the runs in --out and the stage cache."""
+    parser = build_gc_parser()
+    args = parser.parse_args(argv)
+    try:
+        policy = policy_from_env(args.artifacts_days, args.state_days)
+    except ValueError as err:
+        parser.error(str(err))
+    if policy.empty:
+        parser.error(
+            "No retention period: pass --artifacts-days or --state-days, or set "
+            "HYDRA_ARTIFACT_EXPIRE_DAYS or HYDRA_STATE_EXPIRE_DAYS"
+        )
+    report = collect(Path(args.out), policy, dry_run=args.dry_run, cache=not args.no_cache)
+    verb = "Would delete" if args.dry_run else "Deleted"
+    for run_id in report.purged:
+        print(f"🗑  {verb} the documents of {run_id}")
+    for run_id in report.deleted:
+        print(f"🗑  {verb} {run_id}")
+    if report.cache_entries:
+        print(f"🗑  {verb} {report.cache_entries} stage-cache entries")
+    if not (report.purged or report.deleted or report.cache_entries):
+        print("Nothing to delete.")
+        return 0
+    print(f"{verb} {report.bytes_freed / 1e6:.1f} MB")
+    if (Path(args.out) / ".git").exists() and not args.dry_run:
+        print(f"⚠️  The git history in {args.out} still holds the deleted files")
+    return 0
+
+
+def build_outcome_parser() -> argparse.ArgumentParser:
+    """Argument parser for the ``outcome`` subcommand."""
+    parser = argparse.ArgumentParser(
+        prog="hydra outcome",
+        description="Record what came of an application; a later run for a rejected role "
+        "at the same company is warned",
+        formatter_class=argparse.ArgumentDefaultsHelpFormatter,
+    )
+    parser.add_argument("run", help="Run id in --out, or a run directory")
+    parser.add_argument("outcome", choices=OUTCOMES)
+    parser.add_argument("--out", default="output/", help="Directory holding the runs")
+    return parser
+
+
+def _outcome(argv: list[str]) -> int:
+    """``outcome``: record that an application was rejected, got an offer or was withdrawn."""
+    parser = build_outcome_parser()
+    args = parser.parse_args(argv)
+    run_dir = _run_dir(parser, args.run, args.out)
+    record_outcome(run_dir, args.outcome)
+    print(f"✅ Recorded {run_dir.name} as {args.outcome}")
+    return 0
+
+
+def build_interview_parser() -> argparse.ArgumentParser:
+    """Argument parser for the ``interview`` subcommand."""
+    parser = argparse.ArgumentParser(
+        prog="hydra interview",
+        description="Record an interview for an application and put it in your calendar: "
+        "an .ics file (or a Google Calendar event) with reminders and a link to the prep pack",
+        formatter_class=argparse.ArgumentDefaultsHelpFormatter,
+    )
+    parser.add_argument("run", help="Run id in --out, or a run directory")
+    parser.add_argument("--out", default="output/", help="Directory holding the runs")
+    parser.add_argument(
+        "--at", metavar="WHEN", help='Start, "YYYY-MM-DD HH:MM" (without it: list interviews)'
+    )
+    parser.add_argument(
+        "--tz", metavar="ZONE", help="Time zone of --at, e.g. America/New_York (default: local)"
+    )
+    parser.add_argument("--round", default="Interview", help="Round, e.g. 'Phone screen'")
+    parser.add_argument(
+        "--duration", type=int, default=DEFAULT_DURATION_MINUTES, help="Length in minutes"
+    )
+    parser.add_argument("--location", default="", help="Address or video link")
+    parser.add_argument(
+        "--remind",
+        type=int,
+        action="append",
+        metavar="MINUTES",
+        help="Reminder this many minutes before (repeatable; default: a day and an hour)",
+    )
+    parser.add_argument(
+        "--prep-url", help="Link to the prep pack instead of the local interview_prep.md"
+    )
+    parser.add_argument(
+        "--google",
+        action="store_true",
+        help="Also add the event to Google Calendar (OAuth token in GOOGLE_CALENDAR_TOKEN)",
+    )
+    return parser
+
+
+def _interview(argv: list[str]) -> int:
+    """``interview``: schedule an interview round, or list the scheduled ones."""
+    parser = build_interview_parser()
+    args = parser.parse_args(argv)
+    run_dir = _run_dir(parser, args.run, args.out)
+    if not args.at:
+        interviews = load_interviews(run_dir)
+        if not interviews:
+            print("No interviews recorded.")
+        for number, interview in enumerate(interviews, 1):
+            print(f"{number}. {interview.start} {interview.timezone}  {interview.title}")
+        return 0
+
+    try:
+        start = parse_start(args.at, args.tz)
+        interview = schedule_interview(
+            run_dir,
+            start,
+            args.round,
+            duration_minutes=args.duration,
+            location=args.location,
+            reminders=args.remind,
+            tz_name=args.tz,
+        )
+    except CalendarError as err:
+        parser.error(str(err))
+    prep_pack = write_prep_pack(run_dir, interview, out_dir=Path(args.out))
+    prep_link = args.prep_url or prep_pack.resolve().as_uri()
+    if args.google:
+        try:
+            interview.google_event_id = push_to_google(interview, prep_link)
+        except CalendarError as err:
+            print(f"⚠️  Not added to Google Calendar: {err}")
+        else:
+            print(f"📆 Added to Google Calendar: {interview.title}")
+    number = save_interview(run_dir, interview)
+    ics = write_ics(run_dir, interview, number, prep_link)
+    interviews = load_interviews(run_dir)
+    record_artifacts(
+        run_dir,
+        [INTERVIEWS_FILE, prep_pack.name, ics.name],
+        interviews={"count": len(interviews), "rounds": [i.round for i in interviews]},
+    )
+    print(f"🗓️  {interview.title}: {interview.start} {interview.timezone} → {ics}")
+    print(f"📋 Prep pack → {prep_pack}")
+    return 0
+
+
+def build_routing_parser() -> argparse.ArgumentParser:
+    """Argument parser for the ``routing`` subcommand."""
+    parser = argparse.ArgumentParser(
+        prog="hydra routing",
+        description="Show how each model has done per agent in past runs, which models "
+        "agents are routed to, and why routing changed",
+        formatter_class=argparse.ArgumentDefaultsHelpFormatter,
+    )
+    parser.add_argument("--out", default="output/", help="Directory holding the runs")
+    parser.add_argument(
+        "--reset",
+        action="store_true",
+        help="Route every agent back to its configured model (the history is kept)",
+    )
+    return parser
+
+
+def _routing(argv: list[str]) -> int:
+    """``routing``: per-agent model records, current routing and the change history."""
+    args = build_routing_parser().parse_args(argv)
+    routing = ModelRouting()
+    if args.reset:
+        routing.reset()
+        print("Model routing reset; every agent uses its configured model.")
+        return 0
+
+    overrides = routing.overrides()
+    stats = collect_stats(Path(args.out))
+    if not stats and not overrides:
+        print(f"No audited runs in {args.out} yet; every agent uses its configured model.")
+    for agent in sorted(set(stats) | set(overrides)):
+        current = overrides.get(agent) or configured_spec(agent)
+        note = "routed" if agent in overrides else "configured"
+        print(f"{agent}: {current} ({note})")
+        records = stats.get(agent, {})
+        for spec, record in sorted(records.items(), key=lambda item: -item[1].score):
+            marker = "*" if spec == current else " "
+            print(f"  {marker} {spec:<60} {record.describe()}")
+
+    history = routing.load()["history"]
+    if history:
+        print("\nRouting changes:")
+        for entry in history:
+            print(
+                f"  {entry.get('at')}  {entry.get('agent')}: {entry.get('old')} → "
+                f"{entry.get('new')} ({entry.get('kind')}d; {entry.get('reason')})"
+            )
+    return 0
+
+
+def build_providers_parser() -> argparse.ArgumentParser:
+    """Argument parser for the ``providers`` subcommand."""
+    parser = argparse.ArgumentParser(
+        prog="hydra providers",
+        description="Check which model providers are up, and where each one's stages "
+        "fail over to while it is down",
+        formatter_class=argparse.ArgumentDefaultsHelpFormatter,
+    )
+    parser.add_argument(
+        "--pipeline-config",
+        default=None,
+        help="Pipeline config whose failover section to show (default: "
+        "$HYDRA_PIPELINE_CONFIG or ~/.hydra/pipeline.yaml)",
+    )
+    return parser
+
+
+def _providers(argv: list[str]) -> int:
+    """``providers``: a health check of every provider with a key; 1 if one is down."""
+    parser = build_providers_parser()
+    args = parser.parse_args(argv)
+    try:
+        policy = load_pipeline_config(
+            Path(args.pipeline_config) if args.pipeline_config else None
+        ).failover
+    except PipelineConfigError as err:
+        parser.error(str(err))
+
+    configured = [provider for provider in HEALTH_URLS if resolve_api_key(provider)]
+    if not configured:
+        print("No provider API keys are set (see .env.example).")
+        return 1
+    health = shared_health()
+    down = 0
+    for provider in configured:
+        status = health.check(provider, 0)
+        down += not status.healthy
+        icon = "✅" if status.healthy else "❌"
+        order = ", ".join(policy.providers.get(provider, ())) or "none"
+        print(
+            f"{icon} {provider:<11} {status.detail:<32} {status.latency_ms:>6} ms  "
+            f"fails over to: {order}"
+        )
+    if not policy.enabled:
+        print("ℹ️  Failover is disabled in the pipeline config.")
+    return 1 if down else 0
+
+
+def build_import_linkedin_parser() -> argparse.ArgumentParser:
+    """Argument parser for the ``import-linkedin`` subcommand."""
+    parser = argparse.ArgumentParser(
+        prog="hydra import-linkedin",
+        description="Convert a LinkedIn data export (the ZIP from 'Get a copy of your data') "
+        "into a baseline résumé to pass as --resume",
+        formatter_class=argparse.ArgumentDefaultsHelpFormatter,
+    )
+    parser.add_argument("export", help="LinkedIn export ZIP, or the unzipped directory")
+    parser.add_argument("-o", "--output", default="resume.md", help="Résumé file to write")
+    parser.add_argument("--format", choices=("markdown", "json"), default="markdown")
+    parser.add_argument("--force", action="store_true", help="Overwrite an existing --output")
+    return parser
+
+
+def _import_linkedin(argv: list[str]) -> int:
+    """``import-linkedin``: LinkedIn export → Markdown (or JSON) baseline résumé."""
+    parser = build_import_linkedin_parser()
+    args = parser.parse_args(argv)
+    output = Path(args.output)
+    if output.exists() and not args.force:
+        parser.error(f"{output} exists; pass --force to overwrite it")
+    try:
+        profile = parse_export(Path(args.export))
+    except LinkedInImportError as err:
+        parser.error(str(err))
+
+    render = render_json if args.format == "json" else render_markdown
+    output.write_text(render(profile), encoding="utf-8")
+    print(
+        f"📥 Imported {profile.name}: {len(profile.positions)} position(s), "
+        f"{len(profile.skills)} skill(s) → {output}"
+    )
+    missing = missing_sections(profile)
+    if missing:
+        print(f"⚠️  Nothing in the export for: {', '.join(missing)}; fill these in by hand")
+    return 0
+
+
+def build_scenario_parser() -> argparse.ArgumentParser:
+    """Argument parser for the ``scenario`` subcommand."""
+    parser = argparse.ArgumentParser(
+        prog="hydra scenario",
+        description="Run declarative end-to-end scenarios (YAML: inputs, canned model "
+        "responses, expected stages and artifacts) against the real pipeline",
+    )
+    parser.add_argument("paths", nargs="+", help="Scenario files, or directories of them")
+    parser.add_argument("--out", help="Keep each scenario's run directory here")
+    return parser
+
+
+def _scenario(argv: list[str]) -> int:
+    """``scenario``: run each scenario; exits 1 if any fails."""
+    args = build_scenario_parser().parse_args(argv)
+    failed = 0
+    with tempfile.TemporaryDirectory() as scratch:
+        for path in discover(args.paths):
+            try:
+                result = run_scenario(load_scenario(path), Path(args.out or scratch))
+            except ScenarioError as err:
+                print(f"❌ {path.stem}: {err}")
+                failed += 1
+                continue
+            print(f"{'✅' if result.passed else '❌'} {result.scenario.name}")
+            for failure in result.failures:
+                print(f"   - {failure}")
+            failed += not result.passed
+    return 1 if failed else 0
+
+
+def _write_theme(
+    out_dir: Path,
+    resume_text: str,
+    theme: Theme,
+    is_run: bool,
+    language: Language | None = None,
+) -> int:
+    """Render ``resume_text`` with ``theme`` into ``out_dir`` and report the files."""
+    try:
+        output = write_theme(out_dir, resume_text, theme, language=language)
+    except ThemeError as err:
+        print(f"⚠️  Not rendered with the {theme.name} theme: {err}")
+        return 1
+    if is_run:
+        theme_summary = {"name": theme.name, "pdf": output.pdf_error is None}
+        if language is not None:
+            theme_summary["lang"] = language.code
+        record_artifacts(out_dir, output.files, theme=theme_summary)
+    print(f"🎨 {theme.name} theme → {', '.join(str(out_dir / f) for f in output.files)}")
+    if output.pdf_error:
+        print(f"⚠️  No PDF: {output.pdf_error}")
+    return 0
+
+
+def _write_cv(
+    out_dir: Path,
+    resume_text: str,
+    cv_format: CVFormat,
+    theme: Theme,
+    personal: PersonalData | None,
+    policy: LocalePolicy | None,
+    language: Language | None,
+    is_run: bool,
+) -> int:
+    """Render ``resume_text`` as a ``cv_format`` CV into ``out_dir`` and report the files."""
+    try:
+        output = write_cv(out_dir, resume_text, cv_format, theme, personal, policy, language)
+    except ThemeError as err:
+        print(f"⚠️  Not rendered as a {cv_format.name} CV: {err}")
+        return 1
+    if is_run:
+        theme_summary = {"name": theme.name, "pdf": output.pdf_error is None}
+        if language is not None:
+            theme_summary["lang"] = language.code
+        record_artifacts(
+            out_dir, output.files, theme=theme_summary, cv_format=output.to_manifest()
+        )
+    files = ", ".join(str(out_dir / f) for f in output.files)
+    print(f"🪪 {cv_format.name} CV ({theme.name} theme) → {files}")
+    if output.fields or output.photo:
+        shown = [*output.fields, *(["photo"] if output.photo else [])]
+        print(f"   Personal data shown: {', '.join(shown)}")
+    for warning in output.warnings:
+        print(f"⚠️  {cv_format.name} CV: {warning}")
+    if output.pdf_error:
+        print(f"⚠️  No PDF: {output.pdf_error}")
+    return 0
+
+
+def _write_run_report(run_dir: Path, report: RunReport, pdf: bool = True) -> int:
+    """Write ``report`` into ``run_dir``, add it to the manifest and report the files."""
+    output = write_run_report(run_dir, report, pdf=pdf)
+    pdf_written = RUN_REPORT_PDF_FILE in output.files
+    record_artifacts(run_dir, output.files, run_report={"pdf": pdf_written})
+    print(f"📝 Run report → {', '.join(str(run_dir / f) for f in output.files)}")
+    if output.pdf_error:
+        print(f"⚠️  No PDF: {output.pdf_error}")
+    return 0
+
+
+def build_report_parser() -> argparse.ArgumentParser:
+    """Argument parser for the ``report`` subcommand."""
+    parser = argparse.ArgumentParser(
+        prog="hydra report",
+        description="Write a run's report: decision, company snapshot, gap summary, "
+        "differentiators, ATS score before/after, audit findings and next steps, "
+        "as Markdown, HTML and PDF",
+    )
+    parser.add_argument("run_dir", help="Run directory (output/<run_id>)")
+    parser.add_argument("--no-pdf", action="store_true", help="Markdown and HTML only")
+    parser.add_argument(
+        "--skill-taxonomy",
+        action="append",
+        default=[],
+        metavar="FILE",
+        help="Extra skill aliases for the JD skill counts (same layout as "
+        "taxonomy/skills.yaml)",
+    )
+    return parser
+
+
+def _report(argv: list[str]) -> int:
+    """``report``: (re)write the run report of a finished run."""
+    parser = build_report_parser()
+    args = parser.parse_args(argv)
+    run_dir = Path(args.run_dir)
+    if not (run_dir / MANIFEST_FILE).is_file():
+        parser.error(f"Not a run directory (no {MANIFEST_FILE}): {run_dir}")
+    try:
+        report = report_for_run(run_dir, _skill_taxonomy(args.skill_taxonomy))
+    except (TaxonomyError, ValueError) as err:
+        parser.error(str(err))
+    return _write_run_report(run_dir, report, pdf=not args.no_pdf)
+
+
+def build_export_transcript_parser() -> argparse.ArgumentParser:
+    """Argument parser for the ``export-transcript`` subcommand."""
+    parser = argparse.ArgumentParser(
+        prog="hydra export-transcript",
+        description="Write every system prompt, user prompt and raw model response of a "
+        "run, stage by stage, as a Markdown transcript",
+        formatter_class=argparse.ArgumentDefaultsHelpFormatter,
+    )
+    parser.add_argument("run", help="Run id in --out, or a run directory")
+    parser.add_argument("--out", default="output/", help="Directory holding the runs")
+    parser.add_argument(
+        "--stage",
+        action="append",
+        default=[],
+        metavar="STAGE",
+        help="Only this stage (repeatable), e.g. gap_analysis or tailoring",
+    )
+    parser.add_argument(
+        "-o",
+        "--output",
+        metavar="FILE",
+        help=f"Where to write it ('-' for stdout); default <run>/{TRANSCRIPT_MARKDOWN_FILE}",
+    )
+    return parser
+
+
+def _export_transcript(argv: list[str]) -> int:
+    """``export-transcript``: a run's prompt transcript as Markdown."""
+    parser = build_export_transcript_parser()
+    args = parser.parse_args(argv)
+    run_dir = _run_dir(parser, args.run, args.out)
+    transcripts = load_transcript(run_dir)
+    if not transcripts:
+        parser.error(f"{run_dir} has no {PROMPT_TRANSCRIPT_FILE} (a dry run, or an older run)")
+    unknown = [stage for stage in args.stage if stage not in transcripts]
+    if unknown:
+        parser.error(
+            f"No model calls for stage(s) {', '.join(unknown)}; "
+            f"the run has {', '.join(transcripts)}"
+        )
+    manifest = json.loads(_read_file(run_dir / MANIFEST_FILE))
+    markdown = render_transcript(transcripts, manifest.get("run_id", run_dir.name), args.stage)
+    if args.output == "-":
+        sys.stdout.write(markdown)
+        return 0
+    if args.output:
+        path = Path(args.output)
+        path.write_text(markdown)
+    else:
+        path = run_dir / TRANSCRIPT_MARKDOWN_FILE
+        write_text(path, markdown)
+        record_artifacts(run_dir, [TRANSCRIPT_MARKDOWN_FILE])
+    calls = sum(len(transcripts[stage]) for stage in args.stage or transcripts)
+    print(f"📜 Transcript of {calls} model call(s) → {path}")
+    return 0
+
+
+def build_export_parser() -> argparse.ArgumentParser:
+    """Argument parser for the ``export`` subcommand."""
+    parser = argparse.ArgumentParser(
+        prog="hydra export",
+        description="Pack a run (state, transcripts and artifacts) into one bundle, to move "
+        "it to another machine or share it; `hydra import` unpacks it",
+        formatter_class=argparse.ArgumentDefaultsHelpFormatter,
+    )
+    parser.add_argument("run", help="Run id in --out, or a run directory")
+    parser.add_argument("--out", default="output/", help="Directory holding the runs")
+    parser.add_argument(
+        "--bundle", metavar="FILE", help="Write the bundle here (default: <run_id>.tar.gz)"
+    )
+    parser.add_argument(
+        "--redact-pii",
+        action="store_true",
+        help="Replace emails, phone numbers and street addresses with placeholders, "
+        "decrypting an encrypted run; files that are not text are left out",
+    )
+    return parser
+
+
+def _export(argv: list[str]) -> int:
+    """``export``: a run as a ``.tar.gz`` bundle."""
+    parser = build_export_parser()
+    args = parser.parse_args(argv)
+    run_dir = _run_dir(parser, args.run, args.out)
+    try:
+        summary = export_bundle(
+            run_dir, Path(args.bundle or bundle_name(run_dir.name)), redact=args.redact_pii
+        )
+    except BundleError as err:
+        parser.error(str(err))
+    record_access(
+        run_dir,
+        "export",
+        actor=local_actor(),
+        bundle=str(summary.path),
+        redacted=summary.redacted is not None,
+    )
+    print(f"📦 Run {summary.run_id}: {summary.files} file(s) → {summary.path}")
+    if summary.redacted is not None:
+        counts = ", ".join(f"{count} {kind}" for kind, count in summary.redacted.items())
+        print(f"   Redacted: {counts}")
+        if summary.left_out:
+            print(f"   Left out (not text, cannot be redacted): {', '.join(summary.left_out)}")
+    return 0
+
+
+def build_import_parser() -> argparse.ArgumentParser:
+    """Argument parser for the ``import`` subcommand."""
+    parser = argparse.ArgumentParser(
+        prog="hydra import",
+        description="Unpack a run bundle written by `hydra export` into the runs directory, "
+        "checking every file against its manifest",
+        formatter_class=argparse.ArgumentDefaultsHelpFormatter,
+    )
+    parser.add_argument("bundle", help="The .tar.gz bundle")
+    parser.add_argument("--out", default="output/", help="Directory holding the runs")
+    return parser
+
+
+def _import(argv: list[str]) -> int:
+    """``import``: a bundled run back into a run directory."""
+    parser = build_import_parser()
+    args = parser.parse_args(argv)
+    if not Path(args.bundle).is_file():
+        parser.error(f"Bundle not found: {args.bundle}")
+    try:
+        summary = import_bundle(Path(args.bundle), Path(args.out))
+    except BundleError as err:
+        parser.error(str(err))
+    redacted = " (contact details redacted)" if summary.redacted is not None else ""
+    print(f"📦 Run {summary.run_id}: {summary.files} file(s) → {summary.path}{redacted}")
+    return 0
+
+
+def build_themes_parser() -> argparse.ArgumentParser:
+    """Argument parser for the ``themes`` subcommand."""
+    parser = argparse.ArgumentParser(
+        prog="hydra themes",
+        description="List the résumé themes available to --theme",
+    )
+    parser.add_argument(
+        "--theme-dir", action="append", default=[], metavar="DIR", help="Also search DIR"
+    )
+    return parser
+
+
+def _themes(argv: list[str]) -> int:
+    """``themes``: the theme gallery, with where each theme comes from."""
+    args = build_themes_parser().parse_args(argv)
+    dirs = theme_dirs(args.theme_dir)
+    for name in available_themes(dirs):
+        try:
+            theme = load_theme(name, dirs)
+        except ThemeError as err:
+            print(f"❌ {name}: {err}")
+            continue
+        default = " (default)" if name == DEFAULT_THEME else ""
+        print(f"{name:<12} {theme.description}{default}")
+        print(f"{'':<12} {theme.path}")
+    return 0
+
+
+def build_prompt_packs_parser() -> argparse.ArgumentParser:
+    """Argument parser for the ``prompt-packs`` subcommand."""
+    parser = argparse.ArgumentParser(
+        prog="hydra prompt-packs",
+        description="List the prompt packs available to --prompt-pack",
+    )
+    parser.add_argument(
+        "--jd", help="Score each pack against this job description, as --prompt-pack auto does"
+    )
+    return parser
+
+
+def _prompt_packs(argv: list[str]) -> int:
+    """``prompt-packs``: the packs, the agents each tunes, and the one a JD would get."""
+    parser = build_prompt_packs_parser()
+    args = parser.parse_args(argv)
+    jd_text = None
+    if args.jd:
+        try:
+            jd_text = _read_file(Path(args.jd))
+        except FileNotFoundError:
+            parser.error(f"Job description file not found: {args.jd}")
+    dirs = pack_dirs()
+    for name in available_packs(dirs):
+        try:
+            pack = load_pack(name, dirs)
+        except PromptPackError as err:
+            print(f"❌ {name}: {err}")
+            continue
+        tunes = ", ".join(sorted(pack.prompts)) or "the agents' own prompts"
+        score = f"  score {score_pack(pack, jd_text)[0]}" if jd_text is not None else ""
+        print(f"{name:<12} {pack.description}{score}")
+        print(f"{'':<12} {tunes} — {pack.path}")
+    if jd_text is not None:
+        try:
+            choice = detect_pack(jd_text, load_packs(dirs))
+        except PromptPackError as err:
+            print(f"❌ {err}", file=sys.stderr)
+            return 1
+        print(f"\n🎒 {args.jd}: {choice.pack.name} ({choice.reason})")
+    return 0
+
+
+def build_knowledge_parser() -> argparse.ArgumentParser:
+    """Argument parser for the ``knowledge`` subcommand."""
+    parser = argparse.ArgumentParser(
+        prog="hydra knowledge",
+        description="The verified facts earlier runs gathered about you, which runs reuse",
+    )
+    parser.add_argument(
+        "--jd", help="Rank the facts a run for this job description would be given"
+    )
+    actions = parser.add_subparsers(dest="action")
+    actions.add_parser("list", help="List every fact (the default)")
+    forget = actions.add_parser("forget", help="Delete a fact so no run uses it again")
+    forget.add_argument("id", help="The fact's id, as listed")
+    return parser
+
+
+def _knowledge(argv: list[str]) -> int:
+    """``knowledge``: list, rank or forget the candidate knowledge base's facts."""
+    parser = build_knowledge_parser()
+    args = parser.parse_args(argv)
+    knowledge = KnowledgeBase()
+    if args.action == "forget":
+        if not knowledge.forget(args.id):
+            parser.error(f"No fact {args.id} in {knowledge.path}")
+        print(f"🗑️  Forgot fact {args.id}")
+        return 0
+    if args.jd:
+        try:
+            facts = knowledge.relevant(_read_file(Path(args.jd)))
+        except FileNotFoundError as err:
+            parser.error(str(err))
+    else:
+        facts = knowledge.facts()
+    if not facts:
+        print("No facts yet: runs add your interview answers and sourced statements.")
+        return 0
+    for fact in facts:
+        about = f" [{fact.project}]" if fact.project else ""
+        print(f"{fact.id}  {fact.origin:<9} {len(fact.runs)} run(s){about}")
+        print(f"            {fact.text}")
+    return 0
+
+
+def build_constraints_parser() -> argparse.ArgumentParser:
+    """Argument parser for the ``constraints`` subcommand."""
+    parser = argparse.ArgumentParser(
+        prog="hydra constraints",
+        description="Show the conditions every job must meet, or check a job description "
+        "against them without a run",
+    )
+    parser.add_argument(
+        "--constraints",
+        metavar="FILE",
+        help=f"Read this file (default: $HYDRA_HOME/{CONSTRAINTS_FILE})",
+    )
+    parser.add_argument("--jd", help="Check this job description against the constraints")
+    return parser
+
+
+def _constraints(argv: list[str]) -> int:
+    """``constraints``: list the hard constraints, or check a posting against them."""
+    parser = build_constraints_parser()
+    args = parser.parse_args(argv)
+    try:
+        constraints = load_constraints(Path(args.constraints) if args.constraints else None)
+    except ConstraintsError as err:
+        parser.error(str(err))
+    if constraints is None:
+        print(f"No constraints declared: write them to {hydra_home() / CONSTRAINTS_FILE}.")
+        return 0
+    for line in constraints.describe():
+        print(f"   {line}")
+    if not args.jd:
+        return 0
+    try:
+        violations = check_constraints(constraints, _read_file(Path(args.jd)))
+    except FileNotFoundError as err:
+        parser.error(str(err))
+    if not violations:
+        print(f"✅ {args.jd} meets every constraint")
+        return 0
+    for violation in violations:
+        print(f"🚧 {violation.describe()}")
+    return 1
+
+
+def build_templates_parser() -> argparse.ArgumentParser:
+    """Argument parser for the ``templates`` subcommand."""
+    parser = argparse.ArgumentParser(
+        prog="hydra templates",
+        description="List the workflow templates available to --template: the built-in "
+        "ones and those in the pipeline config",
+    )
+    parser.add_argument("--pipeline-config", metavar="FILE", help="Read this config file")
+    return parser
+
+
+def _templates(argv: list[str]) -> int:
+    """``templates``: every workflow template and the stages it runs."""
+    parser = build_templates_parser()
+    args = parser.parse_args(argv)
+    try:
+        policy = load_pipeline_config(
+            Path(args.pipeline_config) if args.pipeline_config else None
+        ).templates
+    except PipelineConfigError as err:
+        parser.error(str(err))
+    for name, template in policy.available().items():
+        default = " (default)" if name == DEFAULT_TEMPLATE else ""
+        own = " [pipeline config]" if name in policy.templates else ""
+        print(f"{name:<12} {template.description}{default}{own}")
+        print(f"{'':<12} {' → '.join(template.stages)}")
+    return 0
+
+
+def build_plugins_parser() -> argparse.ArgumentParser:
+    """Argument parser for the ``plugins`` subcommand."""
+    parser = argparse.ArgumentParser(
+        prog="hydra plugins",
+        description="List the stage plugins a run would load (from ~/.hydra/plugins and "
+        "--plugin-dir), or check one against a sample request",
+    )
+    parser.add_argument(
+        "--plugin-dir", action="append", default=[], metavar="DIR", help="Also this directory"
+    )
+    actions = parser.add_subparsers(dest="action")
+    check = actions.add_parser("check", help="Run a plugin on a sample request")
+    check.add_argument("name")
+    return parser
+
+
+_PLUGIN_CHECK_CONTEXT = {
+    "job_description": "Company: Example Corp\nRole: Platform Engineer\n\nMust have: Python.",
+    "resume": "Jane Doe\n\n## Experience\n- Ran Python services",
+    "source_documents": "",
+    "target_role": "Platform Engineer",
+}
+
+
+def _plugins(argv: list[str]) -> int:
+    """``plugins``: the discovered stage plugins, or one plugin's answer to a sample."""
+    parser = build_plugins_parser()
+    args = parser.parse_args(argv)
+    try:
+        plugins = discover_plugins(plugin_dirs(args.plugin_dir))
+    except PluginError as err:
+        parser.error(str(err))
+    if args.action == "check":
+        plugin = next((p for p in plugins if p.name == args.name), None)
+        if plugin is None:
+            parser.error(f"No plugin named {args.name}")
+        return _check_plugin(plugin)
+
+    if not plugins:
+        print(f"No plugins in {', '.join(str(d) for d in plugin_dirs(args.plugin_dir))}")
+        return 0
+    for plugin in plugins:
+        flags = " (required)" if plugin.required else ""
+        print(f"{plugin.name:<24} after {plugin.after:<20} {plugin.describe()}{flags}")
+        if plugin.description:
+            print(f"{'':<24} {plugin.description}")
+    return 0
+
+
+def _check_plugin(plugin: StagePlugin) -> int:
+    """Send ``plugin`` a sample request with placeholder outputs for the stages before it."""
+    earlier = ANCHOR_STAGES[: ANCHOR_STAGES.index(plugin.after) + 1]
+    results = {stage: {} for stage in earlier}
+    try:
+        output, log = run_plugin(plugin, _PLUGIN_CHECK_CONTEXT, results)
+    except PluginError as err:
+        print(f"❌ {err}")
+        return 1
+    for line in log:
+        print(f"   [{plugin.name}] {line}")
+    print(f"✅ {plugin.name} answered with output keys: {', '.join(sorted(output)) or '(none)'}")
+    return 0
+
+
+def build_profiles_parser() -> argparse.ArgumentParser:
+    """Argument parser for the ``profiles`` subcommand."""
+    parser = argparse.ArgumentParser(
+        prog="hydra profiles",
+        description="Manage stored baseline résumés (profiles) for --profile",
+    )
+    actions = parser.add_subparsers(dest="action")
+    actions.add_parser("list", help="List the stored profiles (the default)")
+    add = actions.add_parser("add", help="Store a résumé as a profile")
+    add.add_argument("name", help="Profile name, e.g. ic-backend or management")
+    add.add_argument("resume", help="Résumé file (Markdown, text or JSON Resume)")
+    add.add_argument("--description", default="", help="What the profile is for")
+    add.add_argument("--replace", action="store_true", help="Replace an existing profile")
+    remove = actions.add_parser("remove", help="Delete a profile")
+    remove.add_argument("name")
+    recommend = actions.add_parser(
+        "recommend", help="Rank the profiles by how much of a JD's skills they cover"
+    )
+    recommend.add_argument("--jd", required=True, help="Path to job description file")
+    recommend.add_argument(
+        "--skill-taxonomy",
+        action="append",
+        default=[],
+        metavar="FILE",
+        help="Extra skill aliases for --jd (same layout as taxonomy/skills.yaml)",
+    )
+    return parser
+
+
+def _profiles(argv: list[str]) -> int:
+    """``profiles``: list, add, remove or rank the stored baseline résumés."""
+    parser = build_profiles_parser()
+    args = parser.parse_args(argv)
+    store = ProfileStore()
+    try:
+        if args.action == "add":
+            profile = store.add(args.name, Path(args.resume), args.description, args.replace)
+            print(f"✅ Stored profile {profile.name} → {profile.resume_path}")
+            return 0
+        if args.action == "remove":
+            store.remove(args.name)
+            print(f"🗑️  Removed profile {args.name}")
+            return 0
+    except ProfileError as err:
+        parser.error(str(err))
+
+    stored = store.list()
+    if not stored:
+        print("No profiles yet. Add one with: hydra profiles add NAME resume.md")
+        return 0
+    if args.action == "recommend":
+        try:
+            jd_text = _read_file(Path(args.jd))
+            taxonomy = _skill_taxonomy(args.skill_taxonomy)
+        except (FileNotFoundError, TaxonomyError) as err:
+            parser.error(str(err))
+        for rank, fit in enumerate(rank_profiles(jd_text, stored, taxonomy), 1):
+            total = len(fit.covered) + len(fit.missing)
+            print(f"{rank}. {fit.profile.name:<16} {len(fit.covered)}/{total} JD skills")
+            if fit.missing:
+                print(f"   {'':<16} missing: {', '.join(fit.missing)}")
+        return 0
+    for profile in stored:
+        print(f"{profile.name:<16} {profile.description or '—'}")
+        print(f"{'':<16} {profile.resume_path}")
+    return 0
+
+
+def build_render_parser() -> argparse.ArgumentParser:
+    """Argument parser for the ``render`` subcommand."""
+    parser = argparse.ArgumentParser(
+        prog="hydra render",
+        description="Render a run's résumé (or any Markdown résumé) with a theme, "
+        "to Markdown, LaTeX and PDF",
+        formatter_class=argparse.ArgumentDefaultsHelpFormatter,
+    )
+    parser.add_argument("target", help="Run directory (output/<run_id>) or a résumé file")
+    parser.add_argument(
+        "--theme",
+        help=f"Theme name (see `hydra themes`; default: {DEFAULT_THEME}, or the CV format's)",
+    )
+    parser.add_argument(
+        "--theme-dir", action="append", default=[], metavar="DIR", help="Also search DIR"
+    )
+    parser.add_argument(
+        "--lang",
+        metavar="LANG",
+        help="Write dates the way this language does (default: the run's --lang, if any)",
+    )
+    parser.add_argument(
+        "--cv-format",
+        choices=sorted(CV_FORMATS),
+        help="Render in this regional CV format (default: the run's --cv-format, if any)",
+    )
+    parser.add_argument(
+        "--personal",
+        metavar="FILE",
+        help="YAML personal data for --cv-format (default: $HYDRA_HOME/personal.yaml)",
+    )
+    parser.add_argument(
+        "--out", help="Where to write the files (default: the run directory, or next to "
+        "the résumé file)"
+    )
+    return parser
+
+
+def _render(argv: list[str]) -> int:
+    """``render``: re-render a finished run, or a résumé file, with a theme."""
+    parser = build_render_parser()
+    args = parser.parse_args(argv)
+    target = Path(args.target)
+    is_run = target.is_dir()
+    resume_path = target / RESUME_FILE if is_run else target
+    recorded = {}
+    if is_run and (target / MANIFEST_FILE).is_file():
+        recorded = json.loads(_read_file(target / MANIFEST_FILE)).get("inputs") or {}
+    lang = args.lang or recorded.get("lang")
+    language = get_language(lang) if lang else None
+    if lang and language is None:
+        parser.error(f"--lang: unknown language '{lang}' (known: {', '.join(LANGUAGES)})")
+    cv_name = args.cv_format or recorded.get("cv_format")
+    cv_format = get_cv_format(cv_name) if cv_name else None
+    if args.personal and cv_format is None:
+        parser.error("--personal requires --cv-format")
+    try:
+        theme_name = args.theme or (cv_format.theme if cv_format else DEFAULT_THEME)
+        theme = load_theme(theme_name, theme_dirs(args.theme_dir))
+        resume_text = _read_file(resume_path)
+        personal = None
+        if cv_format is not None:
+            personal_path = args.personal or recorded.get("personal_path")
+            personal = load_personal(Path(personal_path) if personal_path else None)
+    except (ThemeError, FileNotFoundError, ValueError) as err:
+        parser.error(str(err))
+    out_dir = Path(args.out) if args.out else (target if is_run else target.parent)
+    out_dir.mkdir(parents=True, exist_ok=True)
+    if cv_format is not None:
+        policy = get_policy(cv_format.country) if cv_format.country else None
+        return _write_cv(
+            out_dir,
+            resume_text,
+            cv_format,
+            theme,
+            personal,
+            policy,
+            language,
+            is_run and not args.out,
+        )
+    return _write_theme(out_dir, resume_text, theme, is_run and not args.out, language)
+
+
+def build_control_parser(action: str) -> argparse.ArgumentParser:
+    """Argument parser for the ``pause``, ``resume`` and ``cancel`` subcommands."""
+    descriptions = {
+        PAUSE: "Pause a run at its next stage boundary (a saved interrupted run at once)",
+        "resume": "Resume a paused or interrupted run from its completed stages",
+        CANCEL: "Cancel a run: a live one stops now, and none can be resumed afterwards",
+    }
+    parser = argparse.ArgumentParser(
+        prog=f"hydra {action}",
+        description=descriptions[action],
+        formatter_class=argparse.ArgumentDefaultsHelpFormatter,
+    )
+    parser.add_argument("run", help="Run id in --out, or a run directory (a job id with --server)")
+    parser.add_argument("--out", default="output/", help="Directory holding the runs")
+    parser.add_argument(
+        "--server",
+        default=os.environ.get(SERVER_URL_ENV),
+        help=f"Web backend URL: act on its job instead of a local run (${SERVER_URL_ENV})",
+    )
+    return parser
+
+
+def _control(action: str, argv: list[str]) -> int:
+    """``pause``/``resume``/``cancel``: steer a local run, or a server job with --server."""
+    parser = build_control_parser(action)
+    args, run_args = parser.parse_known_args(argv)
+    if run_args and (action != "resume" or args.server):
+        parser.error(f"unrecognized arguments: {' '.join(run_args)}")
+
+    if args.server:
+        try:
+            reply = control_server(args.server, args.run, action)
+        except RunControlError as err:
+            print(f"❌ {err}", file=sys.stderr)
+            return 1
+        print(f"Job {args.run}: {reply.get('status')} — {reply.get('message')}")
+        return 0
+
+    run_dir = Path(args.run)
+    if not run_dir.is_dir():
+        run_dir = Path(args.out) / args.run
+    try:
+        if action == "resume":
+            resume_args = resume_arguments(run_dir)
+        else:
+            outcome = control_run(run_dir, action)
+    except RunControlError as err:
+        parser.error(str(err))
+
+    if action == "resume":
+        # Further run flags (--model, --quick-apply, ...) are passed through as given.
+        return main(resume_args + run_args)
+    icon = "⏸ " if action == PAUSE else "🛑"
+    print(f"{icon} Run {outcome.run_id} {outcome.message}")
+    return 0
+
+
+def _pause(argv: list[str]) -> int:
+    return _control(PAUSE, argv)
+
+
+def _resume(argv: list[str]) -> int:
+    return _control("resume", argv)
+
+
+def _cancel(argv: list[str]) -> int:
+    return _control(CANCEL, argv)
+
+
+def build_eval_parser() -> argparse.ArgumentParser:
+    """Argument parser for the ``eval`` subcommand."""
+    parser = argparse.ArgumentParser(
+        prog="hydra eval",
+        description="Run golden eval cases (JD, résumé, expected properties) through the "
+        "pipeline and report the pass rate of each assertion",
+        formatter_class=argparse.ArgumentDefaultsHelpFormatter,
+    )
+    parser.add_argument(
+        "paths",
+        nargs="*",
+        help=f"Case directories, or directories of them (default: {EVALS_DIR})",
+    )
+    parser.add_argument(
+        "--live",
+        action="store_true",
+        help="Call the model instead of answering from each case's recorded responses",
+    )
+    parser.add_argument("--model", help="Fallback model for --live, as for a run")
+    parser.add_argument(
+        "--prompt-dir",
+        metavar="DIR",
+        help="With --live: prompts that replace the agents' own, laid out like agents/",
+    )
+    parser.add_argument(
+        "--deterministic",
+        action="store_true",
+        help=f"With --live: call every model at temperature 0 with seed {DEFAULT_SEED}",
+    )
+    parser.add_argument(
+        "--min-pass-rate",
+        type=float,
+        default=100.0,
+        metavar="PERCENT",
+        help="Exit 1 when fewer cases pass than this",
+    )
+    return parser
+
+
+def _eval(argv: list[str]) -> int:
+    """``eval``: run each case and report pass rates; exits 1 below --min-pass-rate."""
+    parser = build_eval_parser()
+    args = parser.parse_args(argv)
+    if not args.live and (args.model or args.prompt_dir or args.deterministic):
+        parser.error("--model, --prompt-dir and --deterministic require --live")
+    # Resolved before moving to the repository root, which the default path is under.
+    paths = [Path(path).resolve() for path in args.paths]
+    prompt_dir = Path(args.prompt_dir).resolve() if args.prompt_dir else None
+    try:
+        repo_root = _get_repo_root()
+        os.chdir(repo_root)
+    except FileNotFoundError as err:
+        parser.error(str(err))
+    paths = paths or [repo_root / EVALS_DIR]
+    directories = discover_evals(paths)
+    if not directories:
+        parser.error(f"no eval cases in {', '.join(map(str, paths))}")
+
+    llm, options = None, {}
+    if args.live:
+        if prompt_dir is not None:
+            try:
+                options["prompt_overrides"] = load_prompt_overrides(prompt_dir, repo_root)
+            except ValueError as err:
+                parser.error(f"--prompt-dir: {err}")
+        if args.deterministic:
+            options["seed"] = DEFAULT_SEED
+        try:
+            llm = get_llm_client(model=args.model)
+        except LLMClientError as err:
+            print(f"❌ LLM configuration error: {err}", file=sys.stderr)
+            return 1
+    print(
+        f"🧪 {len(directories)} eval case(s), "
+        f"{'live model' if args.live else 'recorded responses'}"
+    )
+    results = []
+    for directory in directories:
+        try:
+            result = run_case(load_eval_case(directory), llm, **options)
+        except EvalError as err:
+            print(f"❌ {directory.name}: {err}")
+            results.append(None)
+            continue
+        results.append(result)
+        print(f"{'✅' if result.passed else '❌'} {result.case.name} ({result.status})")
+        for check in result.checks:
+            if not check.passed:
+                print(f"   - {check.assertion}: {check.detail}")
+        if result.judge:
+            scores = ", ".join(
+                f"{JUDGE_LABELS[criterion]} {item['score']}"
+                for criterion, item in result.judge["scores"].items()
+            )
+            print(f"   judge: {scores}")
+
+    checked = [result for result in results if result is not None]
+    rates = pass_rates(checked)
+    rates["cases"] = (rates["cases"][0], len(results))
+    print("Pass rates:")
+    for name in (*ASSERTIONS, "cases"):
+        if name in rates:
+            passed, total = rates[name]
+            print(f"   {name:<24} {passed}/{total}  {100 * passed / total:.0f}%")
+    passed, total = rates["cases"]
+    return 0 if 100 * passed / total >= args.min_pass_rate else 1
+
+
+def build_experiment_parser() -> argparse.ArgumentParser:
+    """Argument parser for the ``experiment`` subcommand."""
+    parser = argparse.ArgumentParser(
+        prog="hydra experiment",
+        description="Run prompt variants for one stage over saved runs, score each "
+        "output résumé and write a comparison report",
+        formatter_class=argparse.ArgumentDefaultsHelpFormatter,
+    )
+    parser.add_argument(
+        "runs",
+        nargs="*",
+        help="Saved run ids in --out, or run directories (default: every run in --out "
+        "that stored what the stage needs)",
+    )
+    parser.add_argument("--stage", required=True, choices=tuple(EXPERIMENT_STAGES))
+    parser.add_argument(
+        "--variant",
+        action="append",
+        default=[],
+        metavar="NAME=DIR",
+        help="Prompts laid out like agents/ (<agent>/prompt.md or <agent>.md); repeatable",
+    )
+    parser.add_argument(
+        "--no-baseline", action="store_true", help="Leave out the repository's own prompts"
+    )
+    parser.add_argument(
+        "--score",
+        choices=SCORERS,
+        default=SCORE_ATS,
+        help="ats: ATS parse score and JD skill coverage, offline; judge: the auditor's review",
+    )
+    parser.add_argument("--limit", type=int, help="Use at most this many saved runs")
+    parser.add_argument("--model", help="Fallback model, as for a run")
+    parser.add_argument(
+        "--deterministic",
+        action="store_true",
+        help=f"Call every model at temperature 0 with seed {DEFAULT_SEED}, so the "
+        "variants differ by their prompts only",
+    )
+    parser.add_argument("--out", default="output/", help="Directory holding the runs")
+    return parser
+
+
+def _experiment(argv: list[str]) -> int:
+    parser = build_experiment_parser()
+    args = parser.parse_args(argv)
+    # Resolved before moving to the repository root, where runs record their inputs from.
+    out_dir = Path(args.out).resolve()
+    run_dirs = [Path(run) if Path(run).is_dir() else out_dir / run for run in args.runs]
+    run_dirs = [run_dir.resolve() for run_dir in run_dirs]
+    specs = []
+    for spec in args.variant:
+        try:
+            name, directory = parse_variant(spec)
+        except ValueError as err:
+            parser.error(f"--variant: {err}")
+        specs.append((name, str(Path(directory).resolve())))
+    if len({name for name, _ in specs}) != len(specs):
+        parser.error("--variant names must differ")
+    try:
+        repo_root = _get_repo_root()
+        os.chdir(repo_root)
+    except FileNotFoundError as err:
+        parser.error(str(err))
+
+    variants = [] if args.no_baseline else [Variant(BASELINE)]
+    for name, directory in specs:
+        try:
+            overrides = load_prompt_overrides(Path(directory), repo_root)
+        except ValueError as err:
+            parser.error(f"--variant {name}: {err}")
+        variants.append(Variant(name, directory, overrides))
+    if len(variants) < 2:
+        parser.error("an experiment compares two or more variants (the baseline is one)")
+    run_dirs = run_dirs or find_runs(out_dir, args.stage)
+    if args.limit is not None:
+        run_dirs = run_dirs[: max(args.limit, 0)]
+    cases = []
+    for run_dir in run_dirs:
+        try:
+            cases.append(load_case(run_dir, args.stage, _read_sources))
+        except ValueError as err:
+            print(f"⚠️  Skipping {err}")
+    if not cases:
+        parser.error(f"no saved runs to experiment on in {out_dir}")
+    try:
+        llm = get_llm_client(model=args.model)
+    except LLMClientError as err:
+        print(f"❌ LLM configuration error: {err}", file=sys.stderr)
+        return 1
+    seed = DEFAULT_SEED if args.deterministic else None
+
+    def _trial(case: Case, variant: Variant) -> Trial:
+        trial = Trial(case.run_id, variant.name)
+        workflow = HydraWorkflow(
+            llm, auto_approve=True, prompt_overrides=variant.overrides, seed=seed
+        )
+        trial.resume = resume_of(args.stage, workflow.execute_stage(args.stage, case.context))
+        if not trial.resume:
+            trial.error = "the stage returned no résumé"
+        elif args.score == SCORE_JUDGE:
+            report = workflow.audit_document(case.context, trial.resume)
+            trial.score, trial.detail = judge_score(report)
+        else:
+            trial.score, trial.detail = ats_score(trial.resume, case.context["job_description"])
+        return trial
+
+    print(
+        f"🧪 {len(variants)} variants × {len(cases)} run(s) on {args.stage}, "
+        f"scored by {args.score}"
+    )
+    pairs = [(case, variant) for case in cases for variant in variants]
+    trials = []
+    for (case, variant), result in zip(
+        pairs, complete_batch([partial(_trial, case, variant) for case, variant in pairs])
+    ):
+        if result.ok:
+            trials.append(result.value)
+        else:
+            error = str(result.error).splitlines()[0][:200] if str(result.error) else ""
+            trials.append(Trial(case.run_id, variant.name, error=error or "failed"))
+
+    directory = write_experiment(
+        out_dir, generate_run_id(), args.stage, args.score, variants, trials
+    )
+    for row in summarize_experiment(trials, [variant.name for variant in variants]):
+        mean = "–" if row["mean"] is None else row["mean"]
+        print(
+            f"   {row['variant']:<20} mean {mean:>5}  wins {row['wins']}/{row['cases']}"
+            + (f"  failed {row['failed']}" if row["failed"] else "")
+        )
+    print(f"📄 Report → {directory / REPORT_FILE}")
+    return 0
+
+
+def build_replay_parser() -> argparse.ArgumentParser:
+    """Argument parser for the ``replay`` subcommand."""
+    parser = argparse.ArgumentParser(
+        prog="hydra replay",
+        description="Run a saved run again from one stage, reusing its earlier stage "
+        "outputs, e.g. to try new prompts; the result is written as a new run",
+        formatter_class=argparse.ArgumentDefaultsHelpFormatter,
+    )
+    parser.add_argument("run", help="Run id in --out, or a run directory")
+    parser.add_argument(
+        "--from",
+        dest="stage",
+        required=True,
+        choices=REPLAY_STAGES,
+        help="First stage to run again; the stages before it are reused",
+    )
+    parser.add_argument(
+        "--prompt-dir",
+        metavar="DIR",
+        help="Prompts that replace the agents' own, laid out like agents/: "
+        "<agent>/prompt.md or <agent>.md",
+    )
+    parser.add_argument("--out", default="output/", help="Directory holding the runs")
+    return parser
+
+
+def _replay(argv: list[str]) -> int:
+    parser = build_replay_parser()
+    args, run_args = parser.parse_known_args(argv)
+    run_dir = Path(args.run)
+    if not run_dir.is_dir():
+        run_dir = Path(args.out) / args.run
+    try:
+        replay_args = replay_arguments(run_dir, args.stage)
+    except RunControlError as err:
+        parser.error(str(err))
+    if args.prompt_dir:
+        # Resolved here: the run itself resolves paths from the repository root.
+        replay_args += ["--prompt-dir", str(Path(args.prompt_dir).resolve())]
+    # Further run flags (--model, --interactive, ...) are passed through as given.
+    return main(replay_args + run_args)
+
+
+def build_watch_parser() -> argparse.ArgumentParser:
+    """Argument parser for the ``watch`` subcommand."""
+    parser = argparse.ArgumentParser(
+        prog="hydra watch",
+        description="Poll job feeds, score new postings against your résumé with the gap "
+        "analyzer on a cheap model, and queue the high-fit ones for a full run you approve",
+        formatter_class=argparse.ArgumentDefaultsHelpFormatter,
+    )
+    parser.add_argument(
+        "--feed",
+        action="append",
+        default=[],
+        metavar="SPEC",
+        help="hn, remoteok[:TAG], greenhouse:BOARD, lever:COMPANY or rss:URL (repeatable; "
+        "default: the feeds list of ~/.hydra/watch.yaml)",
+    )
+    parser.add_argument("--resume", help="Baseline résumé to score against")
+    parser.add_argument("--profile", help="Stored profile to score against (see `hydra profiles`)")
+    parser.add_argument("--sources", help="Sources directory for the approved runs")
+    parser.add_argument(
+        "--interval", type=float, default=DEFAULT_INTERVAL_MINUTES, help="Minutes between polls"
+    )
+    parser.add_argument("--once", action="store_true", help="Poll every feed once and exit")
+    parser.add_argument(
+        "--min-fit", type=float, default=DEFAULT_MIN_FIT, help="Fit score (0-100) that queues"
+    )
+    parser.add_argument(
+        "--min-coverage",
+        type=float,
+        default=DEFAULT_MIN_COVERAGE,
+        help="Share of a posting's skills the résumé must have before a model scores it",
+    )
+    parser.add_argument("--model", help=f"Scoring model as provider:model (default {WATCH_MODEL})")
+    parser.add_argument(
+        "--skill-taxonomy",
+        action="append",
+        default=[],
+        metavar="FILE",
+        help="Extra skill aliases (same layout as taxonomy/skills.yaml)",
+    )
+    actions = parser.add_subparsers(dest="action")
+    queue = actions.add_parser("queue", help="List the queued postings")
+    queue.add_argument("--all", action="store_true", help="Also approved and rejected ones")
+    approve = actions.add_parser(
+        "approve", help="Start a full run on a queued posting (further run flags pass through)"
+    )
+    approve.add_argument("id")
+    approve.add_argument("--out", default="output/", help="Output directory for the run")
+    reject = actions.add_parser("reject", help="Drop a queued posting")
+    reject.add_argument("id")
+    return parser
+
+
+def _print_poll(report: PollReport) -> None:
+    if report.error and not report.fetched:
+        print(f"❌ {report.feed}: {report.error}")
+        return
+    print(
+        f"🔎 {report.feed}: {report.fetched} postings, {report.new} new, "
+        f"{report.below_coverage} below skill coverage, {report.scored} scored, "
+        f"{len(report.queued)} queued"
+    )
+    if report.error:
+        print(f"⚠️  {report.error}")
+    for job in report.queued:
+        print(f"   ⭐ {job.fit_score:.0f} {job.company} — {job.title} [{job.id}] {job.url}")
+
+
+def _watch_scorer(parser: argparse.ArgumentParser, model: str | None):
+    """The gap analyzer on --model, else WATCH_MODEL (else its usual model, keyless)."""
+    try:
+        return gap_scorer(get_llm_for_spec(model or WATCH_MODEL, "gap_analyzer"))
+    except AgentModelError as err:
+        if model:
+            parser.error(f"--model: {err}")
+    try:
+        return gap_scorer(get_llm_for_agent("gap_analyzer"))
+    except AgentModelError as err:
+        parser.error(str(err))
+
+
+def _watch(argv: list[str]) -> int:
+    """``watch``: poll job feeds and queue high-fit postings, or manage the queue."""
+    parser = build_watch_parser()
+    args, run_args = parser.parse_known_args(argv)
+    if run_args and args.action != "approve":
+        parser.error(f"unrecognized arguments: {' '.join(run_args)}")
+    queue = JobQueue()
+
+    if args.action == "queue":
+        jobs = queue.jobs(None if args.all else PENDING)
+        if not jobs:
+            print("Nothing queued." if args.all else "Nothing waiting for approval.")
+        for job in sorted(jobs, key=lambda job: job.fit_score, reverse=True):
+            print(
+                f"{job.id:<28} {job.fit_score:>3.0f}  {job.status:<9} {job.company} — {job.title}"
+            )
+            print(f"{'':<28} {job.url}")
+        return 0
+    if args.action in ("approve", "reject"):
+        try:
+            job = queue.get(args.id)
+        except WatchError as err:
+            parser.error(str(err))
+        if job.status != PENDING:
+            parser.error(f"Job {job.id} is already {job.status}")
+        if args.action == "reject":
+            job.status = REJECTED
+            queue.put(job)
+            print(f"🗑️  Rejected {job.company} — {job.title}")
+            return 0
+        baseline = ["--resume", job.resume_path] if job.resume_path else ["--profile", job.profile]
+        job.status = APPROVED
+        queue.put(job)
+        print(f"▶️  Running {job.company} — {job.title} (fit {job.fit_score:.0f})")
+        job.exit_code = main(
+            ["--jd", job.jd_path, *baseline, "--sources", job.sources_path, "--out", args.out]
+            + run_args
+        )
+        queue.put(job)
+        return job.exit_code
+
+    if bool(args.resume) == bool(args.profile):
+        parser.error("give exactly one of --resume and --profile")
+    if not args.sources:
+        parser.error("--sources is required: approved runs use it")
+    try:
+        feeds = configured_feeds(args.feed)
+        taxonomy = _skill_taxonomy(args.skill_taxonomy)
+        if args.profile:
+            resume_text = ProfileStore().get(args.profile).read()
+        else:
+            resume_text = _read_file(Path(args.resume))
+    except (WatchError, TaxonomyError, ProfileError, FileNotFoundError) as err:
+        parser.error(str(err))
+    if not feeds:
+        parser.error("no feeds: pass --feed, or list them under 'feeds' in ~/.hydra/watch.yaml")
+    score = _watch_scorer(parser, args.model)
+    run_inputs = {
+        "resume_path": str(Path(args.resume).resolve()) if args.resume else None,
+        "profile": args.profile,
+        "sources_path": str(Path(args.sources).resolve()),
+    }
+    try:
+        while True:
+            for feed in feeds:
+                report = poll(
+                    feed,
+                    resume_text,
+                    score,
+                    queue,
+                    taxonomy,
+                    min_fit=args.min_fit,
+                    min_coverage=args.min_coverage,
+                    run_inputs=run_inputs,
+                )
+                _print_poll(report)
+            waiting = len(queue.jobs(PENDING))
+            if waiting:
+                print(f"📬 {waiting} posting(s) waiting: hydra watch queue")
+            if args.once:
+                return 0
+            time.sleep(args.interval * 60)
+    except KeyboardInterrupt:
+        print("\n👋 Watch stopped")
+        return 0
+
+
+# `hydra <command>` subcommands; anything else is the classic flag-style run.
+SUBCOMMANDS = {
+    "ats-check": _ats_check,
+    "audit-all": _audit_all,
+    "cancel": _cancel,
+    "constraints": _constraints,
+    "debrief": _debrief,
+    "decrypt": _decrypt,
+    "diff": _diff,
+    "email": _email,
+    "eval": _eval,
+    "experiment": _experiment,
+    "export": _export,
+    "export-transcript": _export_transcript,
+    "followups": _followups,
+    "gc": _gc,
+    "import": _import,
+    "import-linkedin": _import_linkedin,
+    "interview": _interview,
+    "knowledge": _knowledge,
+    "mcp": _mcp,
+    "outcome": _outcome,
+    "outreach-pick": _outreach_pick,
+    "pause": _pause,
+    "plugins": _plugins,
+    "profiles": _profiles,
+    "prompt-packs": _prompt_packs,
+    "providers": _providers,
+    "render": _render,
+    "replay": _replay,
+    "report": _report,
+    "resume": _resume,
+    "review": _review,
+    "routing": _routing,
+    "scenario": _scenario,
+    "serve": _serve,
+    "templates": _templates,
+    "themes": _themes,
+    "watch": _watch,
+}
+
+
+def _warn_prior_applications(out_dir: Path, company, role, args) -> list | None:
+    """Warn about earlier runs for the same company (see application_history); None
+    if an interactive user chose not to apply again."""
+    priors = prior_applications(out_dir, company, role, days=max(args.dedup_days, 0))
+    if not priors:
+        return priors
+    print(f"⚠️  You have applied to {company} before:")
+    for prior in priors:
+        why = " and ".join(
+            f"within {args.dedup_days} days" if reason == RECENT else reason
+            for reason in prior.reasons
+        )
+        print(f"   {prior.run_id} — {prior.describe()} ({why})")
+        if prior.reusable:
+            print(f"      reusable: {', '.join(prior.reusable)} in {prior.run_dir}")
+        if TRANSCRIPT_FILE in prior.reusable:
+            print(f"      reuse its interview answers with --reuse-interview {prior.run_id}")
+    if not args.interactive:
+        return priors
+    try:
+        answer = input("Apply again anyway? [y/N] ").strip().lower()
+    except EOFError:
+        answer = ""
+    return priors if answer in ("y", "yes") else None
+
+
+def _grow_knowledge_base(
+    knowledge: KnowledgeBase, run_dir: Path, result, sources_text: str, context: dict
+) -> None:
+    """Add the run's interview answers and sourced statements to the knowledge base."""
+    interrogation = (result.intermediate_results or {}).get("interrogation") or {}
+    experience = interrogation.get("experience") if isinstance(interrogation, dict) else None
+    facts = [
+        *facts_from_interview(experience or [], run_dir.name),
+        *facts_from_sources(sources_text, run_dir.name),
+    ]
+    try:
+        added = knowledge.add(facts)
+    except OSError as err:
+        print(f"⚠️  Could not update the knowledge base: {err}")
+        return
+    record_artifacts(
+        run_dir,
+        [],
+        knowledge_base={
+            "facts_used": len(context.get("knowledge_facts") or []),
+            "facts_added": added,
+        },
+    )
+    if added:
+        print(f"🧠 Knowledge base: {added} new fact(s) → {knowledge.path}")
+
+
+def _execute_interruptibly(workflow: HydraWorkflow, context: dict):
+    """Run the workflow; Ctrl-C cancels it gracefully, a second Ctrl-C quits at once."""
+
+    def _on_interrupt(signum, frame):
+        signal.signal(signal.SIGINT, signal.default_int_handler)
+        print(
+            "\n⏹  Interrupting: saving the completed stages (Ctrl-C again to quit now)",
+            file=sys.stderr,
+        )
+        workflow.cancel("interrupted by Ctrl-C")
+
+    try:
+        previous = signal.signal(signal.SIGINT, _on_interrupt)
+    except ValueError:  # not the main thread: leave Ctrl-C to whoever owns it
+        return workflow.execute(context)
+    try:
+        return workflow.execute(context)
+    finally:
+        if previous is not None:
+            signal.signal(signal.SIGINT, previous)
+
+
+def main(argv: list[str] | None = None) -> int:
+    """CLI entrypoint. Returns an exit code instead of exiting for testability."""
+    argv = sys.argv[1:] if argv is None else argv
+    if argv and argv[0] in SUBCOMMANDS:
+        return SUBCOMMANDS[argv[0]](argv[1:])
+
+    parser = build_parser()
+    args = parser.parse_args(argv)
+
+    # Detect and change to repository root directory
+    try:
+        repo_root = _get_repo_root()
+        os.chdir(repo_root)
+        _validate_repo_structure(repo_root)
+    except FileNotFoundError as err:
+        parser.error(str(err))
+
+    if bool(args.resume) == bool(args.profile):
+        parser.error("give exactly one of --resume and --profile")
+    if bool(args.jd) == bool(args.jd_url):
+        parser.error("give exactly one of --jd and --jd-url")
+    if args.jd_url and not args.sources:
+        parser.error("--sources is required with --jd-url")
+
+    posting = None
+    if args.jd_url:
+        try:
+            posting = fetch_posting(args.jd_url)
+        except JobBoardError as err:
+            parser.error(f"--jd-url: {err}")
+        jd_path = save_posting(posting)
+        print(
+            f"🌐 {posting.board} posting: {len(posting.responsibilities)} responsibilities, "
+            f"{len(posting.must_haves)} must-haves, {len(posting.nice_to_haves)} "
+            f"nice-to-haves → {jd_path}"
+        )
+    else:
+        # Resolve paths relative to repo root
+        jd_path = Path(args.jd)
+    resume_path = Path(args.resume) if args.resume else None
+
+    # Default sources to same directory as JD file if not specified
+    if args.sources:
+        sources_dir = Path(args.sources)
+    else:
+        sources_dir = jd_path.parent
+        print(f"ℹ️  No --sources specified, defaulting to: {sources_dir}")
+
+    out_dir = Path(args.out)
+
+    # Validate that all input paths exist
+    if not jd_path.exists():
+        parser.error(f"Job description file not found: {jd_path}")
+    if resume_path is not None and not resume_path.exists():
+        parser.error(f"Resume file not found: {resume_path}")
+    if not sources_dir.exists():
+        parser.error(f"Sources directory not found: {sources_dir}")
+    if not sources_dir.is_dir():
+        parser.error(f"Sources path must be a directory: {sources_dir}")
+
+    try:
+        skill_taxonomy = _skill_taxonomy(args.skill_taxonomy)
+    except TaxonomyError as err:
+        parser.error(f"--skill-taxonomy: {err}")
+
+    try:
+        jd_text = _read_file(jd_path)
+        sources_text = _read_sources(sources_dir)
+    except (FileNotFoundError, ValueError) as err:
+        parser.error(str(err))
+    profiles = ProfileStore()
+    profile = None
+    if args.profile:
+        try:
+            profile = _pick_profile(profiles, args.profile, jd_text, skill_taxonomy)
+        except ProfileError as err:
+            parser.error(f"--profile: {err}")
+        resume_path = profile.resume_path
+    try:
+        resume_text = _read_file(resume_path)
+    except (FileNotFoundError, ValueError) as err:
+        parser.error(str(err))
+
+    json_resume = None
+    if looks_like_json_resume(resume_text):
+        try:
+            json_resume = parse_json_resume(resume_text)
+        except JsonResumeError as err:
+            parser.error(f"--resume: {err}")
+        # Agents work on text; the document is kept to tailor into resume.json.
+        resume_text = to_markdown(json_resume)
+        print("ℹ️  Baseline is a JSON Resume document; the run also writes resume.json")
+
+    policy = get_policy(args.target_country) if args.target_country else None
+    if args.target_country and policy is None:
+        parser.error(f"No résumé conventions on file for country: {args.target_country}")
+
+    cv_format = get_cv_format(args.cv_format) if args.cv_format else None
+    personal = None
+    if args.personal and cv_format is None:
+        parser.error("--personal requires --cv-format")
+    if cv_format is not None:
+        try:
+            personal = load_personal(Path(args.personal) if args.personal else None)
+        except PersonalDataError as err:
+            parser.error(f"--personal: {err}")
+        if policy is None and cv_format.country:
+            policy = get_policy(cv_format.country)
+
+    theme = None
+    if args.theme or cv_format is not None:
+        try:
+            theme = load_theme(args.theme or cv_format.theme, theme_dirs(args.theme_dir))
+        except ThemeError as err:
+            parser.error(f"--theme: {err}")
+
+    try:
+        pipeline_config = load_pipeline_config(
+            Path(args.pipeline_config) if args.pipeline_config else None
+        )
+    except PipelineConfigError as err:
+        parser.error(f"--pipeline-config: {err}")
+    if args.max_cost is not None:
+        if args.max_cost <= 0:
+            parser.error("--max-cost must be positive")
+        budget = replace(pipeline_config.budget, usd=args.max_cost)
+        pipeline_config = replace(pipeline_config, budget=budget)
+    try:
+        template = pipeline_config.templates.get(args.template)
+    except ValueError as err:
+        parser.error(f"--template: {err}")
+    if args.outreach:
+        template = template.including("outreach")
+    if args.seniority:
+        template = template.including("seniority")
+    if args.impact:
+        template = template.including("impact")
+    try:
+        plugins = [] if args.no_plugins else discover_plugins(plugin_dirs(args.plugin_dir))
+    except PluginError as err:
+        parser.error(f"plugins: {err}")
+
+    if args.glossary and not args.translate_to:
+        parser.error("--glossary requires --translate-to")
+    language = get_language(args.lang) if args.lang else None
+    if args.lang and language is None:
+        parser.error(f"--lang: unknown language '{args.lang}' (known: {', '.join(LANGUAGES)})")
+    if language is not None and args.translate_to:
+        if args.translate_to.strip().lower() == language.code:
+            parser.error("--translate-to is the --lang language; the documents are already in it")
+    if args.glossary and not Path(args.glossary).is_file():
+        parser.error(f"Glossary file not found: {args.glossary}")
+    if args.seed is not None and not args.deterministic:
+        parser.error("--seed requires --deterministic")
+    seed = None
+    if args.deterministic:
+        seed = DEFAULT_SEED if args.seed is None else args.seed
+    if bool(args.replay_run) != bool(args.replay_from):
+        parser.error("--replay-run and --replay-from go together (see `hydra replay`)")
+    if args.replay_run and args.resume_run:
+        parser.error("--replay-run cannot be combined with --resume-run")
+    prompt_overrides = None
+    if args.prompt_dir:
+        try:
+            prompt_overrides = load_prompt_overrides(Path(args.prompt_dir), repo_root)
+        except ValueError as err:
+            parser.error(f"--prompt-dir: {err}")
+        replaced = sorted(Path(path).parent.name for path in prompt_overrides)
+        print(f"📝 Prompts from {args.prompt_dir}: {', '.join(replaced)}")
+    if args.prompt_pack != AUTO_PACK:
+        try:
+            load_pack(args.prompt_pack, pack_dirs(), repo_root)
+        except PromptPackError as err:
+            parser.error(f"--prompt-pack: {err}")
+
+    if args.quick_apply:
+        for flag, given in (
+            ("--interactive", args.interactive),
+            ("--tui", args.tui),
+            ("--remote-greenlight", args.remote_greenlight),
+            ("--research", args.research),
+            ("--compensation", args.compensation),
+            ("--contacts", args.contacts),
+            ("--seniority", args.seniority),
+            ("--impact", args.impact),
+            ("--judge", args.judge),
+            ("--reuse-interview", args.reuse_interview),
+            ("--tailoring-models", args.tailoring_models),
+        ):
+            if given:
+                parser.error(f"--quick-apply cannot be combined with {flag}")
+        if args.budget <= 0:
+            parser.error("--budget must be positive")
+    if args.remote_greenlight and args.tui:
+        parser.error("--remote-greenlight cannot be combined with --tui")
+    if args.remote_greenlight and args.interactive:
+        parser.error("--remote-greenlight cannot be combined with --interactive")
+    if args.tui and not sys.stdout.isatty():
+        print("ℹ️  --tui needs a terminal; running without the dashboard")
+        args.tui = False
+    if args.tui or args.remote_greenlight:
+        # The dashboard, or hydra serve, is where the gates are answered.
+        args.interactive = True
+    if args.encrypt:
+        os.environ[ENCRYPT_ENV] = "1"
+    if encryption_enabled():
+        try:
+            state_cipher().encrypt(b"")  # fail now, not after the first stage
+        except EncryptionError as err:
+            parser.error(f"--encrypt: {err}")
+
+    targets = None
+    if args.compensation:
+        amounts = {}
+        given = (("--target-salary", args.target_salary), ("--walk-away", args.walk_away))
+        for flag, value in given:
+            try:
+                amounts[flag] = parse_amount(value) if value is not None else None
+            except ValueError as err:
+                parser.error(f"{flag}: {err}")
+        target, floor = amounts["--target-salary"], amounts["--walk-away"]
+        if target is not None and floor is not None and floor > target:
+            parser.error("--walk-away cannot be above --target-salary")
+        targets = CompensationTargets(target, floor, args.currency.strip().upper())
+    elif args.target_salary or args.walk_away:
+        parser.error("--target-salary and --walk-away require --compensation")
+
+    contacts = None
+    if args.contacts:
+        try:
+            contacts = load_contacts(Path(args.contacts))
+        except ContactsError as err:
+            parser.error(f"--contacts: {err}")
+
+    constraints = None
+    if args.constraints and args.no_constraints:
+        parser.error("--constraints cannot be combined with --no-constraints")
+    if not args.no_constraints:
+        try:
+            constraints = load_constraints(Path(args.constraints) if args.constraints else None)
+        except ConstraintsError as err:
+            parser.error(f"--constraints: {err}")
+    if constraints is not None:
+        print(f"🚧 Constraints: {', '.join(constraints.declared()) or 'none declared'}")
+
+    try:
+        retention = RetentionPolicy.parse(args.retention)
+    except ValueError as err:
+        parser.error(f"--retention: {err}")
+
+    tailoring_models = [spec.strip() for spec in (args.tailoring_models or "").split(",")]
+    tailoring_models = [spec for spec in tailoring_models if spec]
+    for spec in tailoring_models:
+        try:
+            parse_model_spec(spec)
+        except AgentModelError as err:
+            parser.error(str(err))
+    preferences = VariantPreferences()
+    if not tailoring_models and not args.dry_run and not args.quick_apply:
+        # Default to the model that keeps winning comparisons, once it has a record.
+        preferred = preferences.preferred()
+        if preferred:
+            tailoring_models = [preferred]
+            print(
+                f"ℹ️  Tailoring with {preferred} (past comparison winner; "
+                "override with --tailoring-models)"
+            )
+
+    model_routing = None
+    if not args.no_auto_routing and not args.dry_run:
+        # Past runs' audit record may move an agent to a model that passes more often.
+        routing = ModelRouting()
+        for change in routing.update(collect_stats(out_dir)):
+            print(f"🔀 Model routing: {change.describe()}")
+        model_routing = routing.overrides()
+
+    context = {
+        "job_description": jd_text,
+        "resume": resume_text,
+        "source_documents": sources_text,
+    }
+    if args.patches:
+        context["resume_patches"] = True
+    if language is not None:
+        context["output_language"] = language.code
+        print(f"🌐 Writing the documents in {language.name}")
+    if seed is not None:
+        print(f"🎯 Deterministic: temperature 0, seed {seed}")
+    if json_resume is not None or args.json_resume:
+        context["json_resume"] = json_resume or {}
+    if template.runs("outreach"):
+        context["outreach_recipient"] = args.outreach_to.replace("-", " ")
+    if args.company:
+        context["company"] = args.company
+        debriefs = company_debriefs(out_dir, args.company)
+        if debriefs:
+            # Earlier interviews at this company shape the interview prep.
+            context["past_debriefs"] = [debrief.to_dict() for debrief in debriefs]
+            print(f"ℹ️  Using {len(debriefs)} earlier interview debrief(s) for {args.company}")
+    knowledge = None if args.no_knowledge_base else KnowledgeBase()
+    if knowledge is not None:
+        facts = knowledge.relevant(jd_text)
+        if facts:
+            context["knowledge_facts"] = [fact.to_dict() for fact in facts]
+            print(f"🧠 Using {len(facts)} known fact(s) about you from earlier runs")
+    if targets is not None:
+        context["compensation_targets"] = targets.to_dict()
+    if args.recommend_profile:
+        stored = profiles.list()
+        if len(stored) < 2:
+            print("ℹ️  --recommend-profile needs two or more profiles (see `hydra profiles`)")
+        else:
+            fits = rank_profiles(jd_text, stored, skill_taxonomy)
+            context["profiles"] = [fit.for_prompt() for fit in fits]
+            if profile is not None:
+                context["profile"] = profile.name
+
+    if args.resume_run:
+        if args.dry_run:
+            parser.error("--resume-run cannot be combined with --dry-run")
+        checkpoint_dir = out_dir / args.resume_run
+        if not (checkpoint_dir / MANIFEST_FILE).is_file():
+            parser.error(f"No run to resume in {out_dir}: {args.resume_run}")
+        if run_status(checkpoint_dir) == CANCELLED:
+            parser.error(f"Run {args.resume_run} was cancelled and cannot be resumed")
+        previous_results, state_version = load_checkpoint(checkpoint_dir)
+        if not previous_results:
+            parser.error(f"Run {args.resume_run} saved no completed stages to resume from")
+        context["previous_results"] = previous_results
+        context["state_version"] = state_version
+        print(f"ℹ️  Resuming {args.resume_run}; already done: {', '.join(previous_results)}")
+
+    if args.replay_run:
+        if args.dry_run:
+            parser.error("--replay-run cannot be combined with --dry-run")
+        replayed_dir = out_dir / args.replay_run
+        if not (replayed_dir / MANIFEST_FILE).is_file():
+            parser.error(f"No run to replay in {out_dir}: {args.replay_run}")
+        stored, state_version = load_checkpoint(replayed_dir)
+        reused = upstream(stored, args.replay_from, plugins)
+        context["previous_results"] = reused
+        context["state_version"] = state_version
+        context["replay_from"] = args.replay_from
+        print(
+            f"ℹ️  Replaying {args.replay_run} from {args.replay_from}; reusing: "
+            f"{', '.join(reused) or 'nothing'}"
+        )
+
+    if args.reuse_interview:
+        try:
+            entries = load_transcript(out_dir / args.reuse_interview)
+        except ValueError as err:
+            parser.error(f"--reuse-interview: {err}")
+        context["reused_interview"] = {"run_id": args.reuse_interview, "entries": entries}
+        print(f"ℹ️  Reusing {len(entries)} interview answer(s) from {args.reuse_interview}")
+
+    if args.dry_run:
+        redact_pii = args.redact_pii or redaction_enabled()
+        return _run_dry(
+            context,
+            out_dir,
+            args.max_audit_retries,
+            redact_pii,
+            prompt_overrides,
+            args.prompt_pack,
+        )
+
+    try:
+        llm = get_llm_client(model=args.model)
+    except LLMClientError as err:
+        print(f"❌ LLM configuration error: {err}", file=sys.stderr)
+        return 1
+
+    search_provider = None
+    if args.research:
+        try:
+            search_provider = get_search_provider(args.search_provider)
+        except SearchError as err:
+            print(f"❌ Research configuration error: {err}", file=sys.stderr)
+            return 1
+
+    try:
+        workflow = HydraWorkflow(
+            llm,
+            max_audit_retries=args.max_audit_retries,
+            interactive=args.interactive,
+            # A non-interactive CLI run has no way to resume a pause, so it proceeds
+            # past the human gates automatically. `--interactive` uses the real prompts.
+            auto_approve=not args.interactive,
+            stage_cache=None if args.no_cache else StageCache(),
+            tailoring_models=tailoring_models,
+            variant_pick=args.pick or (PICK_ASK if args.interactive else PICK_AUDIT),
+            variant_preferences=preferences,
+            outreach_preferences=(
+                None
+                if args.dry_run
+                else VariantPreferences(hydra_home() / OUTREACH_PREFERENCES_FILE)
+            ),
+            allow_unverified_claims=args.allow_unverified_claims,
+            search_provider=search_provider,
+            other_cover_letters=recent_cover_letters(
+                out_dir, exclude_jd_path=str(jd_path), days=args.overlap_days
+            ),
+            agent_tools=default_toolsets(sources_dir) if args.tools else None,
+            latency_budget=LatencyBudget(args.budget) if args.quick_apply else None,
+            retention=retention,
+            model_routing=model_routing,
+            compensation=args.compensation,
+            skill_taxonomy=skill_taxonomy,
+            pipeline_config=pipeline_config,
+            redact_pii=args.redact_pii or redaction_enabled(),
+            plugins=plugins,
+            plugin_host=WasmHost(llm, sources_dir),
+            template=template,
+            contacts=contacts,
+            constraints=constraints,
+            seed=seed,
+            prompt_overrides=prompt_overrides,
+            judge=args.judge,
+            prompt_pack=args.prompt_pack,
+        )
+    except AgentModelError as err:
+        print(f"❌ Tailoring model configuration error: {err}", file=sys.stderr)
+        return 1
+    workflow.actor = local_actor()
+    if plugins:
+        print(f"🔌 Plugins: {', '.join(f'{p.name} (after {p.after})' for p in plugins)}")
+    if template.name != DEFAULT_TEMPLATE:
+        print(f"🧭 Template {template.name}: {' → '.join(template.stages)}")
+
+    # Taken up front so `hydra pause|cancel <run_id>` can reach the run while it executes.
+    inferred_company, inferred_role = job_labels(jd_text)
+    company, role = args.company or inferred_company, args.role or inferred_role
+    if contacts and company:
+        context.setdefault("company", company)  # what the contacts are matched against
+    run_id = generate_run_id(company=company, role=role)
+    versioning = None
+    if args.git:
+        try:
+            versioning = GitVersioning(out_dir, run_id, workflow).open()
+        except GitVersioningError as err:
+            print(f"❌ {err}", file=sys.stderr)
+            return 1
+        workflow.stage_listeners.append(versioning.commit_stage)
+    priors = [] if args.resume_run else _warn_prior_applications(out_dir, company, role, args)
+    if priors is None:
+        print("Not started.")
+        return 0
+    if args.remote_greenlight:
+        workflow.user_interaction = RemoteGreenlight(out_dir / run_id, workflow)
+    print("Starting Hydra workflow...\n")
+    print(f"Run id: {run_id}")
+    print(f"Job description: {jd_path}")
+    print(f"Resume: {resume_path}" + (f" (profile {profile.name})" if profile else ""))
+    print(f"Sources: {sources_dir}")
+    print(f"Output directory: {out_dir}\n")
+
+    dashboard = Dashboard(workflow, run_id) if args.tui else contextlib.nullcontext()
+    with RunControl(out_dir / run_id, workflow) as control, dashboard:
+        result = _execute_interruptibly(workflow, context)
+
+    for candidate in getattr(result, "tailoring_variants", None) or []:
+        verdict = {True: "approved", False: "rejected", None: "unjudged"}[candidate.approved]
+        outcome = candidate.error or f"audit {verdict}, {len(candidate.blocking)} blocking"
+        print(f"{'🏆' if candidate.winner else '  '} {candidate.spec}: {outcome}")
+
+    tool_transcripts = getattr(result, "tool_transcripts", None) or {}
+    if tool_transcripts:
+        calls = ", ".join(f"{stage} {len(entries)}" for stage, entries in tool_transcripts.items())
+        print(f"🛠️  Tool calls: {calls}")
+
+    _report_prompt_pack(getattr(result, "prompt_pack", None))
+    _report_latency_budget(getattr(result, "latency_budget", None))
+    _report_timeouts(getattr(result, "errors", None))
+    _report_cost_budget(getattr(result, "cost_budget", None))
+
+    cache = getattr(workflow, "stage_cache", None)
+    if cache is not None and cache.hits:
+        print(f"♻️  Served from cache (unchanged prompt + inputs): {', '.join(cache.hits)}")
+    usage = getattr(result, "usage", None) or {}
+    if usage.get("cache_read_tokens"):
+        saved = usage.get("cache_savings_usd")
+        share = usage["cache_read_tokens"] / max(usage["prompt_tokens"], 1)
+        print(
+            f"💾 Provider prompt cache: {usage.get('cache_hit_calls', 0)} of "
+            f"{usage['calls']} calls hit, {usage['cache_read_tokens']} of "
+            f"{usage['prompt_tokens']} prompt tokens read from cache ({share:.0%}), "
+            f"{usage.get('cache_write_tokens', 0)} written"
+            + (f", ~${saved:.4f} saved" if saved is not None else "")
+        )
+
+    # Run-scoped output directory + PII-free manifest.
+    inputs = RunInputs(
+        job_description_chars=len(jd_text),
+        resume_chars=len(resume_text),
+        sources_chars=len(sources_text),
+        jd_path=str(jd_path),
+        resume_path=str(resume_path),
+        sources_path=str(sources_dir),
+        company=company,
+        role=role,
+        profile=profile.name if profile is not None else None,
+        jd_url=args.jd_url,
+        jd_board=posting.board if posting is not None else None,
+        plugins=[plugin.name for plugin in plugins] or None,
+        plugin_dirs=[str(Path(d).resolve()) for d in args.plugin_dir] or None,
+        outreach_to=context.get("outreach_recipient"),
+        contacts_path=str(Path(args.contacts).resolve()) if args.contacts else None,
+        patches=args.patches,
+        reuse_interview=args.reuse_interview,
+        constraints_path=str(Path(args.constraints).resolve()) if args.constraints else None,
+        lang=language.code if language is not None else None,
+        cv_format=cv_format.name if cv_format is not None else None,
+        personal_path=str(Path(args.personal).resolve()) if args.personal else None,
+        seed=seed,
+        prompt_dir=str(Path(args.prompt_dir).resolve()) if args.prompt_dir else None,
+        replay_of=args.replay_run,
+        replay_from=args.replay_from,
+        judge=args.judge,
+        prompt_pack=args.prompt_pack if args.prompt_pack != AUTO_PACK else None,
+    )
+    status = result.status
+    # Locale policy first, so a translation is made from the compliant documents.
+    policy_report = None
+    if policy is not None and result.final_documents:
+        policy_report = apply_locale_policy(result.final_documents, policy)
+        result.final_documents = policy_report.documents
+        for warning in policy_report.warnings:
+            print(f"⚠️  {policy.country} conventions: {warning}")
+    translation = None
+    if args.translate_to and result.final_documents:
+        translation = _translate(args, result, llm, workflow)
+    run_dir = write_run_artifacts(
+        out_dir,
+        result,
+        run_id=run_id,
+        inputs=inputs,
+        # Every stage's output is kept, so a run can be inspected stage by stage.
+        include_intermediate=True,
+        translation=translation,
+        locale_policy=policy_report,
+        baseline_resume=resume_text,
+        source_documents=sources_text,
+    )
+    if priors:
+        record_artifacts(
+            run_dir, [], prior_applications=[prior.to_manifest() for prior in priors]
+        )
+    if knowledge is not None:
+        _grow_knowledge_base(knowledge, run_dir, result, sources_text, context)
+    stopped_as = control.status if status is RunStatus.INTERRUPTED else None
+    if stopped_as is not None:
+        set_run_status(run_dir, stopped_as)
+    if args.resume_run:
+        set_run_status(out_dir / args.resume_run, RESUMED, resumed_as=run_id)
+
+    tailored_resume = (result.final_documents or {}).get("resume")
+    if tailored_resume:
+        _report_resume_diff(resume_text, tailored_resume, sources_text, args.show_diff)
+
+    _report_profile_recommendation(result, profile)
+    _report_unverified_claims(result.audit_report)
+    _report_ats_lint(result.audit_report)
+    _report_chronology(result.audit_report)
+    _report_ats_parse(getattr(result, "ats_parse", None), verbose=args.verbose)
+    _report_cover_letter_overlap(getattr(result, "cover_letter_overlap", None))
+    _report_length(getattr(result, "length", None))
+    _report_contact_header(getattr(result, "contact_header", None))
+    _report_seniority(result, template.runs("seniority"))
+    _report_impact(result, template.runs("impact"))
+    _report_compensation(getattr(result, "compensation_brief", None), args.compensation)
+    _report_outreach(getattr(result, "outreach", None), template.runs("outreach"))
+    _report_referrals(getattr(result, "referrals", None), contacts is not None)
+    _report_judge(getattr(result, "judge", None), args.judge)
+    if args.review and tailored_resume:
+        blocked = _review_run(run_dir, resume_text, sources_text, sys.stdout.isatty())
+        if blocked and status is RunStatus.COMPLETED:
+            # An edit introduced a claim the evidence does not support.
+            status = RunStatus.COMPLETED_WITH_AUDIT_CONCERNS
+            result.audit_error = "reviewed résumé has claims not supported by the sources"
+    if theme is not None and tailored_resume:
+        # After the review, which may have edited resume.md.
+        themed = _read_file(run_dir / RESUME_FILE)
+        if cv_format is not None:
+            _write_cv(run_dir, themed, cv_format, theme, personal, policy, language, is_run=True)
+        else:
+            _write_theme(run_dir, themed, theme, is_run=True, language=language)
+    if args.report and not args.dry_run and result.intermediate_results:
+        # Also after the review: the "after" ATS score is of the résumé as sent.
+        final_resume = _read_file(run_dir / RESUME_FILE) if tailored_resume else None
+        report = build_run_report(
+            result.intermediate_results,
+            result.audit_report,
+            ats_before=parse_resume(resume_text, jd_text, skill_taxonomy).to_dict(),
+            ats_after=(
+                parse_resume(final_resume, jd_text, skill_taxonomy).to_dict()
+                if final_resume
+                else None
+            ),
+            company=company,
+            role=role,
+        )
+        _write_run_report(run_dir, report)
+    if args.email_to and not args.dry_run and tailored_resume:
+        # Last, so a rendered PDF is attached rather than the Markdown.
+        _draft_application_email(run_dir, args.email_to, args.email_from)
+
+    if versioning is not None:
+        try:
+            versioning.commit_run(stopped_as or status.value)
+            print(f"🗂️  Committed {len(versioning.commits)} revision(s) to git in {out_dir}")
+        except GitVersioningError as err:
+            print(f"⚠️  Could not commit the run to git: {err}")
 
     exit_code = EXIT_CODES.get(status, 2)
     final_status = result.audit_report.get("final_status") if result.audit_report else None
@@ -245,6 +4117,16 @@ def main(argv: list[str] | None = None) -> int:
     elif status is RunStatus.PAUSED:
         print(f"⏸  Run paused awaiting input: {result.error_message}")
         print("   Re-run with --interactive to answer inline. Partial results →", run_dir)
+    elif stopped_as == PAUSED:
+        exit_code = EXIT_CODES[RunStatus.PAUSED]
+        print(f"⏸  Run paused at a stage boundary. Completed stages saved → {run_dir}")
+        print(f"   Resume with: hydra resume {run_dir.name}")
+    elif stopped_as == CANCELLED:
+        print(f"🛑 Run cancelled. Completed stages saved → {run_dir}")
+    elif status is RunStatus.INTERRUPTED:
+        print(f"⏹  {result.error_message}. Completed stages saved → {run_dir}")
+        print(f"   Resume with: hydra resume {run_dir.name} (or the same arguments plus")
+        print(f"   --resume-run {run_dir.name})")
     else:  # FAILED
         print(f"❌ Workflow failed: {result.error_message}", file=sys.stderr)
         print(f"   Partial results → {run_dir}", file=sys.stderr)
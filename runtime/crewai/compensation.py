@@ -0,0 +1,169 @@
+"""Compensation: the optional negotiation brief, and the checks on its numbers.
+
+Not everyone wants salary advice with an application, so the stage only runs with
+``--compensation``. It comes after executive synthesis: the Compensation Analyst
+reads the job description (which often posts a pay range), the company research
+and the candidate's own numbers (``--target-salary``, ``--walk-away``), and returns
+a negotiation brief: market ranges per pay component, a recommended ask, talking
+points, and questions to ask about equity, bonus and the rest of the package.
+
+Software owns the numbers, as with research:
+
+- Each market range cites the job description (``"jd"``) or numbered research
+  sources. Citations to sources that do not exist are dropped, and a range left
+  with none is kept but labelled a model estimate.
+- Where the target base salary falls against the base range (below, within, above)
+  is computed here, from the best-backed range, not asked of the model.
+
+The targets are personal. They reach the model, the stage output and
+``negotiation_brief.md``, but not ``run.json``, which records counts only. A failed
+stage never fails the run.
+"""
+
+from __future__ import annotations
+
+import re
+from dataclasses import dataclass
+from typing import Any, Dict, List, Optional
+
+NEGOTIATION_BRIEF_FILE = "negotiation_brief.md"
+DEFAULT_CURRENCY = "USD"
+
+# Ranges backed by the posting beat researched ones, which beat the model's guess.
+_BASIS_RANK = {"job description": 0, "research": 1, "model estimate": 2}
+
+
+@dataclass
+class CompensationTargets:
+    """The candidate's numbers: the base salary aimed for and the walk-away floor."""
+
+    target_base: Optional[float] = None
+    minimum_base: Optional[float] = None
+    currency: str = DEFAULT_CURRENCY
+
+    def to_dict(self) -> Dict[str, Any]:
+        return {
+            "target_base": self.target_base,
+            "minimum_base": self.minimum_base,
+            "currency": self.currency,
+        }
+
+    @classmethod
+    def from_dict(cls, data: Optional[Dict[str, Any]]) -> "CompensationTargets":
+        data = data or {}
+        return cls(
+            target_base=data.get("target_base"),
+            minimum_base=data.get("minimum_base"),
+            currency=data.get("currency") or DEFAULT_CURRENCY,
+        )
+
+
+def parse_amount(text: str) -> float:
+    """``"185000"``, ``"185,000"`` or ``"185k"`` -> 185000.0; ValueError otherwise."""
+    match = re.fullmatch(r"\s*([0-9][0-9,]*(?:\.[0-9]+)?)\s*([kK])?\s*", str(text))
+    if not match:
+        raise ValueError(f"Not an amount: {text!r} (e.g. 185000 or 185k)")
+    value = float(match.group(1).replace(",", ""))
+    return value * 1000 if match.group(2) else value
+
+
+def base_range(brief: Dict[str, Any]) -> Optional[Dict[str, Any]]:
+    """The best-backed base-salary range in a checked brief, if it has one."""
+    ranges = [r for r in brief.get("market_ranges") or [] if r.get("component") == "base"]
+    if not ranges:
+        return None
+    return min(ranges, key=lambda r: _BASIS_RANK.get(r.get("basis"), len(_BASIS_RANK)))
+
+
+def assess_target(
+    brief: Dict[str, Any], targets: CompensationTargets
+) -> Optional[Dict[str, Any]]:
+    """Where the target base sits against the base range: below, within or above."""
+    market = base_range(brief)
+    if market is None or targets.target_base is None:
+        return None
+    if market.get("currency") and market["currency"] != targets.currency:
+        return {"position": "unknown", "reason": f"range is in {market['currency']}"}
+    low, high = market.get("low"), market.get("high")
+    if low is not None and targets.target_base < low:
+        position = "below"
+    elif high is not None and targets.target_base > high:
+        position = "above"
+    else:
+        position = "within"
+    assessment = {"position": position, "basis": market.get("basis")}
+    if targets.minimum_base is not None and high is not None and targets.minimum_base > high:
+        assessment["walk_away_above_range"] = True
+    return assessment
+
+
+def _money(value: Optional[float], currency: str) -> str:
+    return f"{currency} {value:,.0f}".strip() if value is not None else "?"
+
+
+def render_brief(brief: Dict[str, Any], targets: CompensationTargets) -> str:
+    """The negotiation brief as Markdown, for ``negotiation_brief.md``."""
+    lines = ["# Negotiation brief", ""]
+    if targets.target_base is not None or targets.minimum_base is not None:
+        lines += [
+            f"Your target base: {_money(targets.target_base, targets.currency)}; "
+            f"walk-away: {_money(targets.minimum_base, targets.currency)}",
+            "",
+        ]
+    assessment = brief.get("target_assessment")
+    if assessment and assessment.get("position") != "unknown":
+        lines += [
+            f"Your target is **{assessment['position']}** the base range "
+            f"({assessment.get('basis')}).",
+            "",
+        ]
+        if assessment.get("walk_away_above_range"):
+            lines += ["⚠️ Your walk-away is above the top of the range.", ""]
+
+    lines += ["## Market ranges", ""]
+    sources = {s.get("id"): s for s in brief.get("sources") or []}
+    for item in brief.get("market_ranges") or []:
+        currency = item.get("currency") or ""
+        span = f"{_money(item.get('low'), currency)} – {_money(item.get('high'), currency)}"
+        cited = "".join(f"[{i}]" for i in item.get("citations") or [])
+        note = f" — {item['note']}" if item.get("note") else ""
+        lines.append(f"- **{item['component']}**: {span} ({item.get('basis')}{cited}){note}")
+    if not brief.get("market_ranges"):
+        lines.append("- No market data found.")
+
+    ask = brief.get("recommended_ask") or {}
+    if ask:
+        lines += ["", "## Recommended ask", ""]
+        if ask.get("base") is not None:
+            lines.append(f"Base: {_money(ask['base'], brief.get('currency') or '')}")
+        if ask.get("note"):
+            lines.append(ask["note"])
+
+    for title, key in (
+        ("Talking points", "talking_points"),
+        ("Questions to ask", "questions"),
+        ("Risks", "risks"),
+    ):
+        if brief.get(key):
+            lines += ["", f"## {title}", ""]
+            lines += [f"- {text}" for text in brief[key]]
+
+    cited_ids = sorted({i for r in brief.get("market_ranges") or [] for i in r["citations"]})
+    if cited_ids:
+        lines += ["", "## Sources", ""]
+        for source_id in cited_ids:
+            source = sources.get(source_id, {})
+            title, url = source.get("title", ""), source.get("url", "")
+            lines.append(f"[{source_id}] {title} {url}".rstrip())
+    return "\n".join(lines) + "\n"
+
+
+def manifest_summary(brief: Dict[str, Any]) -> Dict[str, Any]:
+    """Counts only for run.json: the amounts and targets stay in the brief."""
+    ranges: List[Dict[str, Any]] = brief.get("market_ranges") or []
+    return {
+        "market_ranges": len(ranges),
+        "estimated_ranges": sum(1 for r in ranges if r.get("basis") == "model estimate"),
+        "talking_points": len(brief.get("talking_points") or []),
+        "questions": len(brief.get("questions") or []),
+    }
@@ -0,0 +1,111 @@
+"""Confidence gating: a stage that is unsure of its output gets another look.
+
+Every agent reports a ``confidence`` between 0 and 1 with its output (see
+base_agent). A stage whose output reports less than its threshold is run again with a
+critique prompt: its previous answer, and the instruction to find what is weakly
+supported, guessed or missing before answering again. The more confident of the two
+answers is kept. If the output is still below the threshold after ``reprompts``
+re-runs, the stage is escalated: an interactive run asks whether to continue with it,
+any other run logs a warning and flags the stage in the manifest for review.
+
+The policy is the ``confidence`` section of the pipeline config (see
+runtime.crewai.pipeline_config)::
+
+    confidence:
+      threshold: 0.5         # below this a stage is re-prompted; 0 turns gating off
+      stages:                # per-stage thresholds
+        gap_analysis: 0.7
+      reprompts: 1           # re-runs with a critique before escalating
+      escalate: true         # then ask (interactive) or flag the stage for review
+
+Each stage's confidence, threshold and re-prompt count are recorded in the manifest
+under ``confidence``. A dry run never calls a model, so nothing is gated.
+"""
+
+from __future__ import annotations
+
+import json
+from dataclasses import dataclass, field
+from typing import Any, Dict, Mapping, Optional
+
+DEFAULT_THRESHOLD = 0.5
+DEFAULT_REPROMPTS = 1
+
+# Bookkeeping fields left out of the previous answer quoted in a critique prompt.
+_QUOTED_NOISE = ("agent", "timestamp", "confidence", "tool_calls")
+
+
+def _threshold(value: Any, name: str) -> float:
+    if isinstance(value, bool) or not isinstance(value, (int, float)) or not 0 <= value <= 1:
+        raise ValueError(f"{name} must be a number between 0 and 1")
+    return float(value)
+
+
+@dataclass(frozen=True)
+class ConfidencePolicy:
+    """The confidence each stage needs, and what happens when it falls short."""
+
+    threshold: float = DEFAULT_THRESHOLD
+    stages: Mapping[str, float] = field(default_factory=dict)
+    reprompts: int = DEFAULT_REPROMPTS
+    escalate: bool = True
+
+    @classmethod
+    def from_dict(cls, data: Mapping[str, Any]) -> "ConfidencePolicy":
+        """``{"threshold": 0.5, "stages": {...}, "reprompts": 1, "escalate": true}``;
+        omitted keys keep their defaults. Raises ValueError on unknown keys or bad values."""
+        unknown = set(data) - {"threshold", "stages", "reprompts", "escalate"}
+        if unknown:
+            raise ValueError(f"unknown confidence setting(s): {', '.join(sorted(unknown))}")
+        default = cls()
+        threshold = _threshold(data.get("threshold", default.threshold), "confidence.threshold")
+        stages = data.get("stages") or {}
+        if not isinstance(stages, dict):
+            raise ValueError("confidence.stages must map stage names to thresholds")
+        stages = {
+            str(stage): _threshold(value, f"confidence.stages.{stage}")
+            for stage, value in stages.items()
+        }
+        reprompts = data.get("reprompts", default.reprompts)
+        if isinstance(reprompts, bool) or not isinstance(reprompts, int) or reprompts < 0:
+            raise ValueError("confidence.reprompts must be a whole number, 0 or more")
+        escalate = data.get("escalate", default.escalate)
+        if not isinstance(escalate, bool):
+            raise ValueError("confidence.escalate must be true or false")
+        return cls(threshold, stages, reprompts, escalate)
+
+    def to_dict(self) -> Dict[str, Any]:
+        return {
+            "threshold": self.threshold,
+            "stages": dict(self.stages),
+            "reprompts": self.reprompts,
+            "escalate": self.escalate,
+        }
+
+    def for_stage(self, stage: str) -> float:
+        return self.stages.get(stage, self.threshold)
+
+
+def confidence_of(result: Any) -> Optional[float]:
+    """The confidence a stage output reports, or None if it reports none."""
+    if not isinstance(result, dict):
+        return None
+    value = result.get("confidence")
+    if isinstance(value, bool) or not isinstance(value, (int, float)):
+        return None
+    return float(value)
+
+
+def critique_prompt(result: Dict[str, Any], confidence: float, threshold: float) -> str:
+    """What a re-prompted stage is told about its previous answer."""
+    previous = {key: value for key, value in result.items() if key not in _QUOTED_NOISE}
+    quoted = json.dumps(previous, indent=2, ensure_ascii=False, default=str)
+    return f"""REVIEW OF YOUR PREVIOUS ANSWER: it reported confidence {confidence:.2f}, below the
+{threshold:.2f} this step requires. Your previous answer was:
+
+{quoted}
+
+First critique it: find the claims the inputs above only weakly support, the parts of
+the task it skipped, and anything it guessed. Then answer the task again, fixing those
+weaknesses, and set "confidence" to how sure you are of the new answer. If the inputs
+do not support more certainty, say what is missing instead of raising the number."""
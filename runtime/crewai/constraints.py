@@ -0,0 +1,321 @@
+"""Hard constraints: conditions a job must meet before a run spends tokens on it.
+
+Declared once in ``~/.hydra/constraints.yaml`` (or ``--constraints FILE``):
+
+```yaml
+work_authorization: [US, CA]   # where you may work without sponsorship
+needs_sponsorship: false       # true: a posting that will not sponsor is out
+remote_only: true              # an on-site or hybrid role is out
+salary_floor: 150k             # a posted range topping out below this is out
+currency: USD                  # of salary_floor (default USD)
+clearance: false               # false: a role that needs a security clearance is out
+```
+
+Leave out what does not matter to you. The job description is checked before the
+first stage. Company research, when the run does it, is checked as soon as it is in.
+The requirements the Gap Analyzer lists are checked before the greenlight. The checks
+are text matches, as for the fit score's flags (see fit_score), so nothing is asked
+of a model. A posting that breaks a constraint fails the run there, before tailoring,
+with the constraint and the sentence that broke it. A posting that says nothing about
+a constraint passes it: a posting without a pay range is not below the floor.
+``--no-constraints`` runs without them. ``hydra constraints --jd FILE`` checks a
+posting without a run. ``run.json`` records which constraints were declared and which
+were broken, never their values.
+"""
+
+from __future__ import annotations
+
+import re
+from dataclasses import dataclass, field
+from pathlib import Path
+from typing import Any, Dict, Iterable, List, Optional, Tuple
+
+import yaml
+
+from runtime.crewai.compensation import DEFAULT_CURRENCY, parse_amount
+from runtime.crewai.fit_score import (
+    CLEARANCE,
+    MUST_LIVE_NEARBY,
+    NO_SPONSORSHIP,
+    ON_SITE,
+    location_flags,
+    sentence_at,
+)
+from runtime.crewai.stage_cache import hydra_home
+
+CONSTRAINTS_FILE = "constraints.yaml"  # in hydra_home()
+
+WORK_AUTHORIZATION = "work_authorization"
+NEEDS_SPONSORSHIP = "needs_sponsorship"
+REMOTE_ONLY = "remote_only"
+SALARY_FLOOR = "salary_floor"
+SECURITY_CLEARANCE = "clearance"
+CONSTRAINTS = (
+    WORK_AUTHORIZATION,
+    NEEDS_SPONSORSHIP,
+    REMOTE_ONLY,
+    SALARY_FLOOR,
+    SECURITY_CLEARANCE,
+)
+
+# Places a posting may restrict work authorization to, by their common names.
+_PLACES = {
+    "US": ("us", "u.s", "u.s.", "usa", "u.s.a", "u.s.a.", "united states", "america"),
+    "UK": ("uk", "u.k", "u.k.", "united kingdom", "great britain", "britain"),
+    "EU": ("eu", "european union", "eea"),
+    "CA": ("ca", "canada"),
+}
+_AUTHORIZED_RE = re.compile(
+    r"(?:authori[sz]ed to work|right to work|work authori[sz]ation|citizenship|citizens?)"
+    r"\s+(?:in|of)\s+(?:the\s+)?(?P<place>[A-Z][\w.]*(?:\s+[A-Z][\w.]*)?)"
+)
+_REMOTE_RE = re.compile(r"\bremote\b", re.IGNORECASE)
+# "Not remote" said of the role or its location ("this role is not remote", "Location:
+# Berlin (not remote)"), not of the company or a team around it.
+_NOT_REMOTE_RE = re.compile(
+    r"\b(?:role|position|job|this|it|location)\b[^,.;\n]{0,30}?"
+    r"\b(?:not|no|non)[- ](?:\w+\s+){0,2}remote\b"
+    r"|(?:^|[.!?:(])\s*(?:not|no|non)[- ](?:\w+\s+){0,2}remote\b"
+    r"|\bnon[- ]remote\s+(?:role|position|job)\b"
+    r"|\bremote\s+(?:is\s+)?not\b",
+    re.IGNORECASE | re.MULTILINE,
+)
+_SYMBOLS = {"$": "USD", "€": "EUR", "£": "GBP"}
+_AMOUNT = r"\d[\d,]*(?:\.\d+)?"
+_PAY_RE = re.compile(
+    rf"(?P<symbol>[$€£])\s?(?P<low>{_AMOUNT})\s*(?P<low_k>[kK])?"
+    rf"(?:\s*(?:-|–|—|to)\s*[$€£]?\s?(?P<high>{_AMOUNT})\s*(?P<high_k>[kK])?)?"
+    rf"|(?P<plain_low>{_AMOUNT})\s*(?P<plain_low_k>[kK])?\s*(?:-|–|—|to)\s*"
+    rf"(?P<plain_high>{_AMOUNT})\s*(?P<plain_high_k>[kK])?\s*(?P<code>USD|EUR|GBP|CAD|AUD)\b"
+)
+# Posted amounts below this are hourly or daily rates, not salaries.
+MIN_SALARY = 1000
+# A single amount is pay only in a sentence about pay; else it is a stipend or a budget.
+_PAY_CONTEXT_RE = re.compile(
+    r"\b(?:salary|base|compensation|pay|OTE|per\s+(?:year|annum)|a\s+year|annual(?:ly)?)\b"
+    r"|/\s?(?:yr|year|annum)\b",
+    re.IGNORECASE,
+)
+
+
+class ConstraintsError(ValueError):
+    """A constraints file that cannot be read as constraints."""
+
+
+@dataclass
+class Violation:
+    """A constraint a posting breaks, why, and the sentence that says so."""
+
+    constraint: str
+    reason: str
+    evidence: str = ""
+
+    def describe(self) -> str:
+        quote = f" — “{self.evidence}”" if self.evidence else ""
+        return f"{self.constraint.replace('_', ' ')}: {self.reason}{quote}"
+
+    def to_dict(self) -> Dict[str, str]:
+        return {"constraint": self.constraint, "reason": self.reason, "evidence": self.evidence}
+
+
+class ConstraintViolation(Exception):
+    """Raised when a run's job breaks a hard constraint; ends the run before tailoring."""
+
+    def __init__(self, where: str, violations: List[Violation]):
+        self.where = where
+        self.violations = violations
+        broken = "; ".join(violation.describe() for violation in violations)
+        super().__init__(f"Hard constraint not met in the {where}: {broken}")
+
+
+@dataclass
+class Constraints:
+    """The conditions every job must meet; None (or empty) is not declared."""
+
+    work_authorization: List[str] = field(default_factory=list)
+    needs_sponsorship: Optional[bool] = None
+    remote_only: Optional[bool] = None
+    salary_floor: Optional[float] = None
+    currency: str = DEFAULT_CURRENCY
+    clearance: Optional[bool] = None
+
+    @classmethod
+    def from_dict(cls, data: Any, source: str = "constraints") -> "Constraints":
+        if not isinstance(data, dict):
+            raise ConstraintsError(f"{source}: expected a mapping of constraints")
+        unknown = sorted(set(data) - {*CONSTRAINTS, "currency"})
+        if unknown:
+            raise ConstraintsError(f"{source}: unknown constraint(s) {', '.join(unknown)}")
+        places = data.get(WORK_AUTHORIZATION) or []
+        places = [places] if isinstance(places, str) else places
+        if not isinstance(places, list):
+            raise ConstraintsError(f"{source}: {WORK_AUTHORIZATION} must be a list of places")
+        for name in (NEEDS_SPONSORSHIP, REMOTE_ONLY, SECURITY_CLEARANCE):
+            if data.get(name) is not None and not isinstance(data[name], bool):
+                raise ConstraintsError(f"{source}: {name} must be true or false")
+        floor = data.get(SALARY_FLOOR)
+        try:
+            floor = parse_amount(str(floor)) if floor is not None else None
+        except ValueError as err:
+            raise ConstraintsError(f"{source}: {SALARY_FLOOR}: {err}") from err
+        return cls(
+            work_authorization=[place_code(str(place)) for place in places],
+            needs_sponsorship=data.get(NEEDS_SPONSORSHIP),
+            remote_only=data.get(REMOTE_ONLY),
+            salary_floor=floor,
+            currency=str(data.get("currency") or DEFAULT_CURRENCY).upper(),
+            clearance=data.get(SECURITY_CLEARANCE),
+        )
+
+    def declared(self) -> List[str]:
+        """The names of the constraints that are set, in ``CONSTRAINTS`` order."""
+        values = {
+            WORK_AUTHORIZATION: self.work_authorization or None,
+            NEEDS_SPONSORSHIP: self.needs_sponsorship,
+            REMOTE_ONLY: self.remote_only,
+            SALARY_FLOOR: self.salary_floor,
+            SECURITY_CLEARANCE: self.clearance,
+        }
+        return [name for name in CONSTRAINTS if values[name] is not None]
+
+    def describe(self) -> List[str]:
+        """One line per declared constraint, for ``hydra constraints``."""
+        lines = []
+        if self.work_authorization:
+            lines.append(f"Authorized to work in: {', '.join(self.work_authorization)}")
+        if self.needs_sponsorship is not None:
+            needs = "needed" if self.needs_sponsorship else "not needed"
+            lines.append(f"Visa sponsorship: {needs}")
+        if self.remote_only is not None:
+            lines.append(f"Remote only: {'yes' if self.remote_only else 'no'}")
+        if self.salary_floor is not None:
+            lines.append(f"Salary floor: {self.salary_floor:,.0f} {self.currency}")
+        if self.clearance is not None:
+            lines.append(f"Security clearance: {'held' if self.clearance else 'none'}")
+        return lines
+
+
+def load_constraints(path: Optional[Path] = None) -> Optional[Constraints]:
+    """The constraints in ``path``, else ``~/.hydra/constraints.yaml``; None without
+    either. ``path`` must exist; the default may not."""
+    if path is None:
+        path = hydra_home() / CONSTRAINTS_FILE
+        if not path.is_file():
+            return None
+    try:
+        data = yaml.safe_load(Path(path).read_text()) or {}
+    except OSError as err:
+        raise ConstraintsError(f"Cannot read {path}: {err}") from err
+    except yaml.YAMLError as err:
+        raise ConstraintsError(f"{path}: not YAML: {err}") from err
+    return Constraints.from_dict(data, str(path))
+
+
+def place_code(name: str) -> str:
+    """``"United States"`` -> ``"US"``; a place not listed keeps its name, upper-cased."""
+    wanted = " ".join(name.lower().split()).rstrip(".")
+    for code, names in _PLACES.items():
+        if wanted in (known.rstrip(".") for known in names):
+            return code
+    return name.strip().upper()
+
+
+def _amount(number: str, k: Optional[str], other_k: Optional[str]) -> float:
+    """One end of a range; "150-180k" puts the k on both ends."""
+    value = float(number.replace(",", ""))
+    return value * 1000 if k or (other_k and value < MIN_SALARY) else value
+
+
+def posted_pay(text: str) -> List[Tuple[float, str, int]]:
+    """(top of each posted salary range, its currency, where it is) in ``text``. A single
+    amount counts only in a sentence about pay: a "$2,500 home office stipend" is not."""
+    found = []
+    for match in _PAY_RE.finditer(text or ""):
+        if match.group("symbol"):
+            low, low_k, high, high_k = match.group("low", "low_k", "high", "high_k")
+            currency = _SYMBOLS[match.group("symbol")]
+        else:
+            low, low_k, high, high_k = match.group(
+                "plain_low", "plain_low_k", "plain_high", "plain_high_k"
+            )
+            currency = match.group("code")
+        value = _amount(high, high_k, low_k) if high else _amount(low, low_k, None)
+        if not high and not _PAY_CONTEXT_RE.search(sentence_at(text, match.start())):
+            continue
+        if value >= MIN_SALARY:
+            found.append((value, currency, match.start()))
+    return found
+
+
+def check_constraints(constraints: Constraints, text: str) -> List[Violation]:
+    """The constraints ``text`` (a posting, research, requirements) breaks."""
+    text = text or ""
+    flags = dict(location_flags(text))
+    violations = []
+    if constraints.needs_sponsorship and NO_SPONSORSHIP in flags:
+        violations.append(
+            Violation(NEEDS_SPONSORSHIP, "you need sponsorship", flags[NO_SPONSORSHIP])
+        )
+    if constraints.clearance is False and CLEARANCE in flags:
+        violations.append(
+            Violation(SECURITY_CLEARANCE, "the role needs a clearance", flags[CLEARANCE])
+        )
+    if constraints.remote_only:
+        not_remote = _NOT_REMOTE_RE.search(text)
+        if not_remote:
+            evidence = sentence_at(text, not_remote.start())
+            violations.append(Violation(REMOTE_ONLY, "the role is not remote", evidence))
+        elif not _REMOTE_RE.search(text):
+            for flag in (ON_SITE, MUST_LIVE_NEARBY):
+                if flag in flags:
+                    violations.append(Violation(REMOTE_ONLY, f"the role is {flag}", flags[flag]))
+                    break
+    if constraints.work_authorization:
+        for match in _AUTHORIZED_RE.finditer(text):
+            place = place_code(match.group("place"))
+            if place in _PLACES and place not in constraints.work_authorization:
+                evidence = sentence_at(text, match.start())
+                reason = f"the role needs authorization to work in {place}"
+                violations.append(Violation(WORK_AUTHORIZATION, reason, evidence))
+                break
+    if constraints.salary_floor is not None:
+        pay = [p for p in posted_pay(text) if p[1] == constraints.currency]
+        if pay and max(value for value, _, _ in pay) < constraints.salary_floor:
+            top, currency, where = max(pay)
+            reason = (
+                f"the posted pay tops out at {top:,.0f} {currency}, below your floor of "
+                f"{constraints.salary_floor:,.0f}"
+            )
+            violations.append(Violation(SALARY_FLOOR, reason, sentence_at(text, where)))
+    return violations
+
+
+def text_of(value: Any) -> str:
+    """Every string in a stage output, one per line, for checking."""
+    if isinstance(value, str):
+        return value
+    if isinstance(value, dict):
+        return "\n".join(text_of(item) for item in value.values())
+    if isinstance(value, (list, tuple)):
+        return "\n".join(text_of(item) for item in value)
+    return ""
+
+
+def requirements_text(gap_result: Any) -> str:
+    """The requirements a gap analysis lists, one per line; not the résumé evidence."""
+    analysis = gap_result.get("gap_analysis") if isinstance(gap_result, dict) else None
+    analysis = analysis if isinstance(analysis, dict) else gap_result
+    requirements = analysis.get("requirements") if isinstance(analysis, dict) else None
+    return "\n".join(
+        text_of(req.get("text") or req.get("requirement"))
+        for req in requirements or []
+        if isinstance(req, dict)
+    )
+
+
+def summary(constraints: Constraints, violations: Iterable[Violation]) -> Dict[str, Any]:
+    """For run.json: the constraints declared and broken, by name."""
+    return {
+        "declared": constraints.declared(),
+        "violated": sorted({violation.constraint for violation in violations}),
+    }
@@ -0,0 +1,286 @@
+"""Contact header guarantee: the tailored résumé keeps the baseline's name and contacts.
+
+Models retype the header along with everything else, and a retyped header is where a
+digit of a phone number goes missing, ``jane.doe@`` becomes ``janedoe@``, or a
+LinkedIn URL gains a slug that does not exist. A recruiter cannot reach a candidate
+through any of those, and nothing downstream would notice. So before the audit,
+software parses the header of the baseline résumé (the lines above its first ``##``
+section, or above a later ``#`` heading such as ``# Experience``) for:
+
+- the **name** — the ``#`` heading or, without one, a first line that reads as a name
+  and has a contact line right below it (a lone "Senior Platform Engineer" is a
+  headline, not a name);
+- every **email**, **phone** number (7 to 15 digits, not a year range such as
+  ``2015-2024``) and **link** (a URL with a path: ``linkedin.com/in/…``,
+  ``github.com/…``);
+
+and looks for each, character for character, in the header of the final résumé. A
+value that is not there is restored: in place of a value of the same kind the
+baseline does not have (it was altered), or appended to the contact line (it was
+dropped). A contact the baseline does not have and nothing replaced (it was added) is
+removed, with its Markdown link text if it has any. Everything else in the header,
+such as a tailored headline, is left alone. A baseline with more than one ``#``
+heading that has a contact line below it is several résumés pasted together; which
+header is the candidate's is not known, so nothing is restored.
+
+The changes, by field and never with the values, are listed under ``contact_header``
+in ``run.json``.
+"""
+
+from __future__ import annotations
+
+import re
+from dataclasses import asdict, dataclass, field
+from typing import Any, Dict, List, Optional, Tuple
+
+NAME = "name"
+EMAIL = "email"
+PHONE = "phone"
+LINK = "link"
+
+ALTERED = "altered"
+DROPPED = "dropped"
+ADDED = "added"
+
+PHONE_DIGITS = (7, 15)
+# Without a "##" section, the header is the first block of at most this many lines.
+MAX_HEADER_LINES = 6
+
+_SECTION_RE = re.compile(r"^\s*##\s")
+_NAME_RE = re.compile(r"^(?P<prefix>\s*#\s+)(?P<name>.+?)\s*#*\s*$")
+_EMAIL_RE = re.compile(
+    r"(?<![\w.+-])[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}"
+)
+_PHONE_RE = re.compile(
+    r"(?<![\w+/.])(?!(?:19|20)\d\d\s?[-–]\s?(?:19|20)\d\d(?!\d))"  # not a year range
+    r"(?:\+\d{1,3}[ .-]?)?(?:\(\d{1,4}\)[ .-]?)?"
+    r"\d{2,4}(?:[ .-]?\d{2,4}){1,4}(?![\w/])"
+)
+_LINK_RE = re.compile(
+    r"(?<![\w@.-])(?:https?://)?(?:www\.)?[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}"
+    r"/[^\s)\]|,<>]*[^\s)\]|,<>.;:]"
+)
+# "Jane Doe", "Mary-Ann O'Neil": two to four capitalised words, nothing else.
+_PLAIN_NAME_RE = re.compile(r"^\s*[A-Z][\w'.-]*(?:\s+[A-Z][\w'.-]*){1,3}\s*$")
+_SEPARATOR_RE = re.compile(r"\s*([|·•])\s*(?:[|·•]\s*)+")
+_SEPARATORS = " |·•,;"
+
+
+def _phones(line: str) -> List[str]:
+    found = []
+    for match in _PHONE_RE.finditer(_LINK_RE.sub("", line)):
+        digits = sum(ch.isdigit() for ch in match.group(0))
+        if PHONE_DIGITS[0] <= digits <= PHONE_DIGITS[1]:
+            found.append(match.group(0).strip())
+    return found
+
+
+def _values(line: str, kind: str) -> List[str]:
+    if kind == EMAIL:
+        return _EMAIL_RE.findall(line)
+    if kind == LINK:
+        return [match.group(0) for match in _LINK_RE.finditer(line)]
+    return _phones(_EMAIL_RE.sub("", line))
+
+
+def _header_end(lines: List[str]) -> int:
+    """Index of the first line after the header: its first section, which is a ``##``
+    heading or a ``#`` heading after the first."""
+    headings = 0
+    for index, line in enumerate(lines):
+        if _SECTION_RE.match(line):
+            return index
+        if _NAME_RE.match(line):
+            headings += 1
+            if headings > 1:
+                return index
+    started = False
+    for index, line in enumerate(lines[:MAX_HEADER_LINES]):
+        if line.strip():
+            started = True
+        elif started:
+            return index
+    return min(len(lines), MAX_HEADER_LINES)
+
+
+def _name_line(lines: List[str]) -> Optional[int]:
+    """Index of the header's name line: its ``#`` heading, or its first line if that
+    reads as a name and the next line holds a contact."""
+    for index, line in enumerate(lines):
+        if _NAME_RE.match(line):
+            return index
+    filled = [index for index, line in enumerate(lines) if line.strip()]
+    if len(filled) < 2 or not _PLAIN_NAME_RE.match(lines[filled[0]]):
+        return None
+    if not _has_contact(lines[filled[1]]):
+        return None
+    return filled[0]
+
+
+def _has_contact(line: str) -> bool:
+    return any(_values(line, kind) for kind in (EMAIL, PHONE, LINK))
+
+
+def _headers(lines: List[str]) -> int:
+    """How many ``#`` headings in ``lines`` have a contact below them, before the next
+    heading: a name's, where a section such as ``# Experience`` has none."""
+    count = 0
+    for index, line in enumerate(lines):
+        if not _NAME_RE.match(line):
+            continue
+        for below in lines[index + 1 : index + MAX_HEADER_LINES]:
+            if below.lstrip().startswith("#"):
+                break
+            if _has_contact(below):
+                count += 1
+                break
+    return count
+
+
+def _name(line: str) -> str:
+    heading = _NAME_RE.match(line)
+    return (heading.group("name") if heading else line).strip()
+
+
+@dataclass
+class ContactHeader:
+    """The name and contacts of a résumé's header."""
+
+    name: Optional[str] = None
+    email: List[str] = field(default_factory=list)
+    phone: List[str] = field(default_factory=list)
+    link: List[str] = field(default_factory=list)
+
+    def count(self) -> int:
+        return (self.name is not None) + len(self.email) + len(self.phone) + len(self.link)
+
+
+def parse_header(text: str) -> ContactHeader:
+    """The name and contacts in the header of the résumé ``text``."""
+    lines = (text or "").splitlines()
+    header = lines[: _header_end(lines)]
+    name = _name_line(header)
+    parsed = ContactHeader(name=_name(header[name]) if name is not None else None)
+    for line in header:
+        for kind in (EMAIL, PHONE, LINK):
+            for value in _values(line, kind):
+                if value not in getattr(parsed, kind):
+                    getattr(parsed, kind).append(value)
+    return parsed
+
+
+@dataclass
+class Change:
+    """A header field the final résumé did not keep, and how it was put right."""
+
+    field: str
+    change: str
+
+
+@dataclass
+class HeaderCheck:
+    """The final résumé's header against the baseline's, and the résumé restored."""
+
+    resume: str
+    checked: int = 0
+    changes: List[Change] = field(default_factory=list)
+
+    @property
+    def restored(self) -> bool:
+        return bool(self.changes)
+
+    def to_dict(self) -> Dict[str, Any]:
+        return {"checked": self.checked, "changes": [asdict(change) for change in self.changes]}
+
+
+def _tidy(line: str) -> str:
+    """``line`` without the separators a removed value left behind."""
+    line = _SEPARATOR_RE.sub(lambda m: f" {m.group(1)} ", line)
+    return line.strip(_SEPARATORS)
+
+
+def _remove(line: str, value: str) -> str:
+    """``line`` without ``value``, or without the whole Markdown link around it."""
+    link = re.compile(rf"\[[^\]]*\]\(\s*{re.escape(value)}\s*\)")
+    if link.search(line):
+        return _tidy(link.sub("", line, count=1))
+    return _tidy(line.replace(value, "", 1))
+
+
+def _replace(lines: List[str], end: int, old: str, new: str) -> None:
+    for index in range(end):
+        if old in lines[index]:
+            lines[index] = lines[index].replace(old, new, 1)
+            return
+
+
+def _contact_line(lines: List[str], end: int, name: Optional[int]) -> Optional[int]:
+    for index in range(end):
+        if index != name and _has_contact(lines[index]):
+            return index
+    return None
+
+
+def preserve_header(resume: str, baseline: str) -> HeaderCheck:
+    """``resume`` with the baseline's name and contacts restored in its header."""
+    if _headers((baseline or "").splitlines()) > 1:
+        return HeaderCheck(resume=resume)
+    expected = parse_header(baseline)
+    check = HeaderCheck(resume=resume, checked=expected.count())
+    if not check.checked or not resume:
+        return check
+
+    lines = resume.splitlines()
+    end = _header_end(lines)
+    name = _name_line(lines[:end])
+    if expected.name is not None:
+        if name is None:
+            lines.insert(0, f"# {expected.name}")
+            end, name = end + 1, 0
+            check.changes.append(Change(NAME, DROPPED))
+        elif _name(lines[name]) != expected.name:
+            heading = _NAME_RE.match(lines[name])
+            prefix = heading.group("prefix") if heading else ""
+            lines[name] = f"{prefix}{expected.name}"
+            check.changes.append(Change(NAME, ALTERED))
+
+    actual = parse_header("\n".join(lines[:end]))
+    for kind in (EMAIL, PHONE, LINK):
+        wanted: List[str] = getattr(expected, kind)
+        found: List[str] = getattr(actual, kind)
+        missing = [value for value in wanted if value not in found]
+        extra = [value for value in found if value not in wanted]
+        pairs: List[Tuple[str, str]] = list(zip(extra, missing))
+        for old, new in pairs:
+            _replace(lines, end, old, new)
+            check.changes.append(Change(kind, ALTERED))
+        for value in missing[len(pairs) :]:
+            contact = _contact_line(lines, end, name)
+            if contact is None:
+                at = 0 if name is None else name + 1
+                lines.insert(at, value)
+                end += 1
+            else:
+                lines[contact] = f"{lines[contact].rstrip()} | {value}"
+            check.changes.append(Change(kind, DROPPED))
+        for value in extra[len(pairs) :]:
+            for index in range(end):
+                if value in lines[index]:
+                    lines[index] = _remove(lines[index], value)
+                    break
+            check.changes.append(Change(kind, ADDED))
+
+    if check.changes:
+        if 0 < end < len(lines) and lines[end - 1].strip():
+            lines.insert(end, "")  # a header restored from nothing, above a section
+        check.resume = "\n".join(lines) + ("\n" if resume.endswith("\n") else "")
+    return check
+
+
+def manifest_summary(report: Dict[str, Any]) -> Dict[str, Any]:
+    """What ``run.json`` keeps of a report dict: the fields checked and restored, counted."""
+    restored: Dict[str, int] = {}
+    for change in report.get("changes") or []:
+        key = f"{change['field']} {change['change']}"
+        restored[key] = restored.get(key, 0) + 1
+    return {"checked": report.get("checked", 0), "restored": restored}
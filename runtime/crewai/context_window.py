@@ -0,0 +1,214 @@
+"""Context-window management: keep each agent's prompt inside its model's limit.
+
+Every stage forwards the outputs of the stages before it, so the context handed to
+later agents (the Executive Synthesizer sees all of them) grows with each stage. On a
+long résumé that can exceed the context window of the model assigned to the stage —
+and a smaller fallback model makes it worse.
+
+Before each agent call the workflow measures the rendered context against the
+model's budget (see ``model_config.get_context_window``) and, if it does not fit,
+compacts it deterministically:
+
+1. drop irrelevant bookkeeping fields (agent name, timestamps, confidence) from
+   prior-stage outputs;
+2. truncate prior-stage outputs, oldest stage first, each to what still fits.
+
+The primary inputs (job description, résumé, source documents, and the document
+under review) are never touched: the truth gates depend on them verbatim.
+
+Tokens are counted with the model's tokenizer (see runtime.crewai.tokenizer): exactly
+for OpenAI models when tiktoken is installed, estimated from length otherwise.
+
+Every call is also measured (``measure_usage``): how much of the window each input
+section takes, after compaction. The workflow keeps the peak per stage and the run
+manifest records it, so a stage that routinely runs near its limit — a candidate for
+a larger model or a leaner prompt — is visible without reading logs.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass, field
+from typing import Any, Dict, List, Optional, Tuple
+
+from runtime.crewai.tokenizer import count_tokens, truncate_to_tokens
+
+# The estimate used when the model is not known.
+CHARS_PER_TOKEN = 4
+
+# Room kept free for the model's answer.
+DEFAULT_OUTPUT_RESERVE_TOKENS = 4096
+# Task boilerplate around the context (JSON instructions, headings).
+TASK_OVERHEAD_TOKENS = 500
+# A truncated output keeps at least this much, so the agent still sees its gist.
+MIN_KEEP_CHARS = 400
+
+# Never compacted: the truth gates need these verbatim.
+PROTECTED_KEYS = frozenset(
+    {
+        "job_description",
+        "resume",
+        "source_documents",
+        "document",
+        "document_type",
+        "tailored_resume",
+        "tailored_cover_letter",
+        "target_role",
+    }
+)
+
+# Prior-stage outputs in pipeline order — the oldest is compacted first.
+COMPACTION_ORDER = (
+    "research_data",
+    "gap_analysis",
+    "gaps",
+    "interrogation_prep",
+    "interview_notes",
+    "differentiation",
+    "differentiators",
+    "ats_optimization",
+    "audit_report",
+)
+
+# Bookkeeping fields agents add to every output; no downstream prompt needs them.
+NOISE_FIELDS = frozenset({"agent", "timestamp", "confidence", "metadata"})
+
+TRUNCATION_MARKER = "… [truncated {dropped} chars to fit the context window]"
+
+
+def estimate_tokens(text: str, model: Optional[str] = None) -> int:
+    """Tokens of ``text`` for ``model``; without a model, ceil of chars / CHARS_PER_TOKEN."""
+    return count_tokens(text, model)
+
+
+def context_tokens(context: Dict[str, Any], model: Optional[str] = None) -> int:
+    """Tokens of a context as agents render it (``str()`` of each value)."""
+    return sum(estimate_tokens(str(value), model) for value in context.values())
+
+
+def prompt_budget(
+    context_window: int,
+    overhead_tokens: int = 0,
+    output_reserve: int = DEFAULT_OUTPUT_RESERVE_TOKENS,
+) -> int:
+    """Tokens available to the context after the system prompt and the answer."""
+    return max(0, context_window - output_reserve - TASK_OVERHEAD_TOKENS - overhead_tokens)
+
+
+@dataclass
+class CompactionReport:
+    """What compaction did to one agent call's context."""
+
+    budget_tokens: int
+    tokens_before: int
+    tokens_after: int = 0
+    stripped_keys: List[str] = field(default_factory=list)
+    truncated_keys: List[str] = field(default_factory=list)
+
+    @property
+    def over_budget(self) -> bool:
+        """True when even a fully compacted context does not fit."""
+        return self.tokens_after > self.budget_tokens
+
+
+def _strip_noise(value: Any) -> Any:
+    if isinstance(value, dict):
+        return {k: _strip_noise(v) for k, v in value.items() if k not in NOISE_FIELDS}
+    if isinstance(value, list):
+        return [_strip_noise(v) for v in value]
+    return value
+
+
+def _truncate(text: str, keep_tokens: int, model: Optional[str]) -> str:
+    kept = truncate_to_tokens(text, keep_tokens, model)
+    return kept + TRUNCATION_MARKER.format(dropped=len(text) - len(kept))
+
+
+def compact_context(
+    context: Dict[str, Any], budget_tokens: int, model: Optional[str] = None
+) -> Tuple[Dict[str, Any], Optional[CompactionReport]]:
+    """Return ``(context, report)`` fitted to ``budget_tokens`` of ``model``'s tokens.
+
+    The input is not mutated. ``report`` is None when the context already fits.
+    """
+    before = context_tokens(context, model)
+    if before <= budget_tokens:
+        return context, None
+
+    report = CompactionReport(budget_tokens=budget_tokens, tokens_before=before)
+    compacted = dict(context)
+    candidates = [k for k in COMPACTION_ORDER if k in compacted and k not in PROTECTED_KEYS]
+
+    # 1. Drop bookkeeping fields from structured prior outputs.
+    for key in candidates:
+        value = compacted[key]
+        if isinstance(value, (dict, list)):
+            stripped = _strip_noise(value)
+            if stripped != value:
+                compacted[key] = stripped
+                report.stripped_keys.append(key)
+
+    # 2. Truncate prior outputs, oldest first, until the overflow is absorbed.
+    overflow = context_tokens(compacted, model) - budget_tokens
+    for key in candidates:
+        if overflow <= 0:
+            break
+        text = str(compacted[key])
+        tokens = estimate_tokens(text, model)
+        marker_tokens = estimate_tokens(TRUNCATION_MARKER.format(dropped=len(text)), model)
+        floor = estimate_tokens(text[:MIN_KEEP_CHARS], model)
+        keep = max(floor, tokens - overflow - marker_tokens)
+        if keep < tokens:
+            truncated = _truncate(text, keep, model)
+            if len(truncated) < len(text):
+                compacted[key] = truncated
+                report.truncated_keys.append(key)
+                overflow -= tokens - estimate_tokens(truncated, model)
+
+    report.tokens_after = context_tokens(compacted, model)
+    return compacted, report
+
+
+@dataclass
+class StageUsage:
+    """Share of a model's context window one agent call used, by input section."""
+
+    model: Optional[str]
+    context_window: int
+    sections: Dict[str, int] = field(default_factory=dict)
+    compacted: bool = False
+
+    @property
+    def used_tokens(self) -> int:
+        return sum(self.sections.values())
+
+    @property
+    def fraction(self) -> float:
+        return self.used_tokens / self.context_window if self.context_window else 0.0
+
+    def to_dict(self) -> Dict[str, Any]:
+        return {
+            "model": self.model,
+            "context_window": self.context_window,
+            "used_tokens": self.used_tokens,
+            "fraction": round(self.fraction, 4),
+            "compacted": self.compacted,
+            "sections": dict(self.sections),
+        }
+
+
+def measure_usage(
+    context: Dict[str, Any],
+    system_tokens: int,
+    context_window: int,
+    model: Optional[str] = None,
+    compacted: bool = False,
+) -> StageUsage:
+    """Count the tokens of each section of one call, with ``model``'s tokenizer: system
+    prompt, task boilerplate, and every context key. The output reserve is not counted
+    as used."""
+    sections = {"system_prompt": system_tokens, "task_overhead": TASK_OVERHEAD_TOKENS}
+    for key, value in context.items():
+        sections[key] = estimate_tokens(str(value), model)
+    return StageUsage(
+        model=model, context_window=context_window, sections=sections, compacted=compacted
+    )
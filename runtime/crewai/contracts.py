@@ -12,6 +12,7 @@ spelunking that previously lived inside `hydra_workflow.py`.
 
 from __future__ import annotations
 
+import re
 from typing import Any
 
 from pydantic import BaseModel, Field
@@ -177,6 +178,102 @@ class GapAnalysis(BaseModel):
         return cls()
 
 
+_REVIEW_CLASSES = {
+    "direct_match": "matches",
+    "adjacent_experience": "adjacent",
+    "adjacent": "adjacent",
+    "gap": "gaps",
+    "blocker": "gaps",
+}
+
+
+def _requirement_text(item: Any) -> str:
+    if isinstance(item, dict):
+        text = item.get("requirement") or item.get("text") or item.get("skill")
+        if text:
+            return coerce_text(text)
+    return coerce_text(item)
+
+
+class GapReview(BaseModel):
+    """What a person greenlighting the gap analysis is shown: fit and requirements by class.
+
+    Mirrors the web review (GapAnalysisReview.svelte): flat ``matches``/``gaps``/
+    ``adjacent_skills`` lists when the model gave them, otherwise the requirements
+    sorted by classification; flat or nested under ``gap_analysis``.
+    """
+
+    fit_score: float | None = None
+    matches: list[str] = Field(default_factory=list)
+    adjacent: list[str] = Field(default_factory=list)
+    gaps: list[str] = Field(default_factory=list)
+    # With --recommend-profile: the baseline résumé the analyzer would send, and why.
+    recommended_profile: str | None = None
+    profile_reason: str | None = None
+    # The score Python computed from the findings and its go / no-go call (see fit_score).
+    recommendation: str | None = None
+    decision: str | None = None
+    rationale: list[str] = Field(default_factory=list)
+    flags: list[str] = Field(default_factory=list)
+
+    @classmethod
+    def from_raw(cls, raw: Any) -> "GapReview":
+        if not isinstance(raw, dict):
+            return cls()
+        recommendation = raw.get("profile_recommendation")
+        recommendation = recommendation if isinstance(recommendation, dict) else {}
+        profile = {
+            "recommended_profile": coerce_text(recommendation.get("profile")) or None,
+            "profile_reason": coerce_text(recommendation.get("reason")) or None,
+        }
+        fit = raw.get("fit")
+        fit = fit if isinstance(fit, dict) else {}
+        profile.update(
+            recommendation=fit.get("recommendation"),
+            decision=fit.get("decision"),
+            rationale=[coerce_text(line) for line in fit.get("rationale") or []],
+            flags=[coerce_text(flag) for flag in fit.get("flags") or []],
+        )
+        analysis = raw.get("gap_analysis")
+        if not isinstance(analysis, dict):
+            analysis = raw
+        summary = analysis.get("summary")
+        summary = summary if isinstance(summary, dict) else {}
+        score = None
+        candidates = (fit.get("score"), analysis.get("fit_score"), summary.get("fit_score"))
+        for value in (*candidates, raw.get("fit_score")):
+            try:
+                score = float(value)
+                break
+            except (TypeError, ValueError):
+                continue
+
+        flat = {
+            "matches": analysis.get("matches"),
+            "adjacent": analysis.get("adjacent_skills"),
+            "gaps": analysis.get("gaps"),
+        }
+        review: dict[str, list[str]] = {field: [] for field in flat}
+        if any(isinstance(items, list) and items for items in flat.values()):
+            for field, items in flat.items():
+                if isinstance(items, list):
+                    review[field] = [t for t in map(_requirement_text, items) if t]
+            return cls(fit_score=score, **review, **profile)
+
+        requirements = analysis.get("requirements")
+        if not isinstance(requirements, list):
+            nested = analysis.get("requirements_analysis")
+            requirements = nested.get("explicit_required") if isinstance(nested, dict) else None
+        for req in requirements if isinstance(requirements, list) else []:
+            if not isinstance(req, dict):
+                continue
+            field = _REVIEW_CLASSES.get(req.get("classification"))
+            text = _requirement_text(req) if field else ""
+            if text:
+                review[field].append(text)
+        return cls(fit_score=score, **review, **profile)
+
+
 # Recommendation is derived deterministically from fit_score; the model supplies the
 # score and rationale, Python owns the gate. Thresholds mirror the Executive
 # Synthesizer's DECISION_THRESHOLDS and are the single source of truth for the CLI.
@@ -243,3 +340,346 @@ def recommendation_for_fit_score(fit_score: float) -> str:
     if fit_score >= 50:
         return "PROCEED_WITH_CAUTION"
     return "PASS"
+
+
+RESEARCH_CATEGORIES = ("recent_news", "funding", "tech_stack")
+
+
+def _citation_ids(value: Any) -> list[int]:
+    """Citation ids from ``[1, "2", "[3]"]``-ish shapes; anything else is dropped."""
+    if not isinstance(value, list):
+        value = [value] if value is not None else []
+    ids: list[int] = []
+    for item in value:
+        try:
+            ids.append(int(str(item).strip().strip("[]")))
+        except ValueError:
+            continue
+    return ids
+
+
+class ResearchBrief(BaseModel):
+    """Canonical company research: findings per category, each citing source ids."""
+
+    company: str = ""
+    recent_news: list[dict] = Field(default_factory=list)
+    funding: list[dict] = Field(default_factory=list)
+    tech_stack: list[dict] = Field(default_factory=list)
+
+    @classmethod
+    def from_raw(cls, raw: Any) -> "ResearchBrief":
+        data = _first_dict(raw, "research", "company_research")
+        findings: dict[str, list[dict]] = {}
+        for category in RESEARCH_CATEGORIES:
+            items = data.get(category) or []
+            findings[category] = []
+            for item in items if isinstance(items, list) else []:
+                if isinstance(item, str):
+                    item = {"summary": item}
+                if not isinstance(item, dict):
+                    continue
+                text = coerce_text(item.get("summary", item.get("name", item.get("text"))))
+                if text:
+                    findings[category].append(
+                        {
+                            "summary": text,
+                            "date": item.get("date"),
+                            "citations": _citation_ids(
+                                item.get("citations", item.get("sources"))
+                            ),
+                        }
+                    )
+        return cls(company=coerce_text(data.get("company")), **findings)
+
+    def cited_only(self, known_ids: set[int]) -> tuple["ResearchBrief", int]:
+        """Drop citations to unknown sources, then findings left with none.
+
+        Returns the filtered brief and how many findings were dropped.
+        """
+        dropped = 0
+        kept: dict[str, list[dict]] = {}
+        for category in RESEARCH_CATEGORIES:
+            kept[category] = []
+            for finding in getattr(self, category):
+                citations = [i for i in finding["citations"] if i in known_ids]
+                if citations:
+                    kept[category].append({**finding, "citations": citations})
+                else:
+                    dropped += 1
+        return ResearchBrief(company=self.company, **kept), dropped
+
+
+def _amount(value: Any) -> float | None:
+    """A pay figure from ``185000``, ``"185,000"``, ``"$185k"``-ish shapes, else None."""
+    if isinstance(value, bool):
+        return None
+    if isinstance(value, (int, float)):
+        return float(value)
+    if not isinstance(value, str):
+        return None
+    text = value.strip().lower().replace(",", "").lstrip("$€£ ")
+    scale = 1000.0 if text.endswith("k") else 1.0
+    try:
+        return float(text.rstrip("k")) * scale
+    except ValueError:
+        return None
+
+
+def _strings(value: Any) -> list[str]:
+    items = value if isinstance(value, list) else [value] if value else []
+    texts = [
+        coerce_text(item.get("text", item) if isinstance(item, dict) else item) for item in items
+    ]
+    return [text.strip() for text in texts if text.strip()]
+
+
+class CompensationBrief(BaseModel):
+    """Canonical negotiation brief: cited market ranges, talking points, questions."""
+
+    currency: str = ""
+    market_ranges: list[dict] = Field(default_factory=list)
+    recommended_ask: dict = Field(default_factory=dict)
+    talking_points: list[str] = Field(default_factory=list)
+    questions: list[str] = Field(default_factory=list)
+    risks: list[str] = Field(default_factory=list)
+
+    @classmethod
+    def from_raw(cls, raw: Any) -> "CompensationBrief":
+        data = _first_dict(raw, "negotiation_brief", "compensation")
+        currency = coerce_text(data.get("currency")).upper()
+        ranges = []
+        items = data.get("market_ranges") or data.get("ranges") or []
+        for item in items if isinstance(items, list) else []:
+            if not isinstance(item, dict):
+                continue
+            low, high = _amount(item.get("low")), _amount(item.get("high"))
+            if low is None and high is None:
+                continue
+            if low is not None and high is not None and low > high:
+                low, high = high, low
+            citations = item.get("citations", item.get("sources"))
+            cited = citations if isinstance(citations, list) else [citations]
+            ranges.append(
+                {
+                    "component": coerce_text(item.get("component")).lower() or "base",
+                    "low": low,
+                    "high": high,
+                    "currency": coerce_text(item.get("currency")).upper() or currency,
+                    "note": coerce_text(item.get("note", item.get("summary"))),
+                    "from_jd": any(str(c).strip().lower() == "jd" for c in cited),
+                    "citations": _citation_ids([c for c in cited if str(c).lower() != "jd"]),
+                }
+            )
+        ask = data.get("recommended_ask")
+        ask = ask if isinstance(ask, dict) else {"note": coerce_text(ask)} if ask else {}
+        if ask:
+            ask = {
+                "base": _amount(ask.get("base")),
+                "note": coerce_text(ask.get("note", ask.get("rationale"))),
+            }
+        return cls(
+            currency=currency,
+            market_ranges=ranges,
+            recommended_ask=ask,
+            talking_points=_strings(data.get("talking_points")),
+            questions=_strings(data.get("questions")),
+            risks=_strings(data.get("risks")),
+        )
+
+    def checked(self, known_ids: set[int]) -> "CompensationBrief":
+        """Drop citations to unknown sources and label each range by what backs it."""
+        ranges = []
+        for item in self.market_ranges:
+            citations = [i for i in item["citations"] if i in known_ids]
+            if item["from_jd"]:
+                basis = "job description"
+            elif citations:
+                basis = "research"
+            else:
+                basis = "model estimate"
+            ranges.append({**item, "citations": citations, "basis": basis})
+        return self.model_copy(update={"market_ranges": ranges})
+
+
+OUTREACH_CHANNELS = ("connection_request", "inmail", "email")
+
+
+def _options(value: Any, text_key: str) -> list[dict[str, str]]:
+    """Ranked outreach options as ``{text, rationale, angle}``, best first (by their
+    ``rank`` where given, else in order); a bare string is an option without a
+    rationale."""
+    found = []
+    for position, item in enumerate(value if isinstance(value, list) else []):
+        item = item if isinstance(item, dict) else {"text": item}
+        text = coerce_text(item.get("text", item.get(text_key))).strip()
+        if not text:
+            continue
+        rank = str(item.get("rank", "")).strip()
+        option = {
+            "text": text,
+            "rationale": coerce_text(item.get("rationale", item.get("why"))).strip(),
+            "angle": coerce_text(item.get("angle")).strip(),
+        }
+        found.append((int(rank) if rank.isdigit() else position + 1, position, option))
+    return [option for _, _, option in sorted(found, key=lambda entry: entry[:2])]
+
+
+class OutreachMessages(BaseModel):
+    """Canonical outreach: one pitch per channel, with what it was built on."""
+
+    recipient: str = ""
+    variants: dict[str, dict[str, str]] = Field(default_factory=dict)
+    differentiators_used: list[str] = Field(default_factory=list)
+    research_used: list[int] = Field(default_factory=list)
+    # Ranked alternatives for the subject line and the opening paragraph.
+    subject_lines: list[dict[str, str]] = Field(default_factory=list)
+    openers: list[dict[str, str]] = Field(default_factory=list)
+
+    @classmethod
+    def from_raw(cls, raw: Any) -> "OutreachMessages":
+        data = _first_dict(raw, "outreach")
+        found = data.get("variants") or data.get("messages")
+        found = found if isinstance(found, dict) else {}
+        if not found and data.get("message"):
+            found = {"email": data}  # a single message is the email
+        variants = {}
+        for channel in OUTREACH_CHANNELS:
+            item = found.get(channel)
+            if not isinstance(item, dict):
+                item = {"message": item}
+            message = coerce_text(item.get("message", item.get("body"))).strip()
+            if message:
+                variants[channel] = {
+                    "subject": coerce_text(item.get("subject")).strip(),
+                    "message": message,
+                }
+        return cls(
+            recipient=coerce_text(data.get("recipient")).strip().lower(),
+            variants=variants,
+            differentiators_used=_strings(data.get("differentiators_used")),
+            research_used=_citation_ids(data.get("research_used")),
+            subject_lines=_options(data.get("subject_lines"), "subject"),
+            openers=_options(data.get("openers"), "opener"),
+        )
+
+
+class ReferralAsks(BaseModel):
+    """Canonical referral asks: a drafted message per numbered contact."""
+
+    asks: list[dict[str, Any]] = Field(default_factory=list)
+
+    @classmethod
+    def from_raw(cls, raw: Any) -> "ReferralAsks":
+        data = _first_dict(raw, "referrals")
+        items = data.get("asks") or data.get("referrals") or data.get("contacts") or []
+        asks = []
+        for item in items if isinstance(items, list) else []:
+            if not isinstance(item, dict):
+                continue
+            ids = _citation_ids(item.get("contact", item.get("id")))
+            ask = coerce_text(item.get("ask", item.get("message"))).strip()
+            if ids and ask:
+                asks.append(
+                    {
+                        "contact": ids[0],
+                        "path": coerce_text(item.get("path")).strip().lower(),
+                        "ask": ask,
+                    }
+                )
+        return cls(asks=asks)
+
+
+class ImpactRewrites(BaseModel):
+    """Canonical impact rewrites: per weak bullet, a rewrite and the metric questions."""
+
+    rewrites: list[dict[str, Any]] = Field(default_factory=list)
+
+    @classmethod
+    def from_raw(cls, raw: Any) -> "ImpactRewrites":
+        data = _first_dict(raw, "impact")
+        items = data.get("rewrites") or data.get("bullets") or []
+        rewrites = []
+        for item in items if isinstance(items, list) else []:
+            if not isinstance(item, dict):
+                continue
+            bullet = coerce_text(item.get("bullet", item.get("id"))).strip().strip("[]")
+            questions = item.get("questions") or []
+            if isinstance(questions, str):
+                questions = [questions]
+            if bullet:
+                rewrites.append(
+                    {
+                        "bullet": bullet,
+                        "rewrite": coerce_text(item.get("rewrite")).strip(),
+                        "questions": [
+                            coerce_text(q).strip() for q in questions if coerce_text(q).strip()
+                        ],
+                    }
+                )
+        return cls(rewrites=rewrites)
+
+
+
+class SeniorityNotes(BaseModel):
+    """Canonical seniority calibration notes: per bullet id, what misleads about the
+    level and how to frame it, plus instructions for the whole résumé."""
+
+    findings: list[dict[str, str]] = Field(default_factory=list)
+    instructions: list[str] = Field(default_factory=list)
+
+    @classmethod
+    def from_raw(cls, raw: Any) -> "SeniorityNotes":
+        data = _first_dict(raw, "seniority")
+        items = data.get("findings") or data.get("bullets") or []
+        findings = []
+        for item in items if isinstance(items, list) else []:
+            if not isinstance(item, dict):
+                continue
+            bullet = coerce_text(item.get("bullet", item.get("id"))).strip().strip("[]")
+            suggestion = coerce_text(item.get("suggestion", item.get("framing"))).strip()
+            if bullet and suggestion:
+                findings.append(
+                    {
+                        "bullet": bullet,
+                        "issue": coerce_text(item.get("issue")).strip(),
+                        "suggestion": suggestion,
+                    }
+                )
+        instructions = data.get("instructions") or []
+        if isinstance(instructions, str):
+            instructions = [instructions]
+        return cls(
+            findings=findings,
+            instructions=[
+                coerce_text(i).strip() for i in instructions if coerce_text(i).strip()
+            ],
+        )
+
+JUDGE_CRITERIA = ("relevance", "truthfulness", "readability", "ats_friendliness")
+
+
+class JudgeScores(BaseModel):
+    """Canonical judge output: per rubric, a whole score from 1 to 10 and why."""
+
+    scores: dict[str, dict[str, Any]] = Field(default_factory=dict)
+
+    @classmethod
+    def from_raw(cls, raw: Any) -> "JudgeScores":
+        data = _first_dict(raw, "judge", "judgement")
+        found = data.get("scores") if isinstance(data.get("scores"), dict) else data
+        # "ATS friendliness" and "ats-friendliness" name the same rubric.
+        named = {re.sub(r"[\s-]+", "_", str(k).strip().lower()): v for k, v in found.items()}
+        scores = {}
+        for criterion in JUDGE_CRITERIA:
+            item = named.get(criterion)
+            if not isinstance(item, dict):
+                item = {"score": item}
+            try:
+                score = round(float(str(item.get("score")).split("/")[0].strip()))
+            except ValueError:
+                continue
+            scores[criterion] = {
+                "score": max(1, min(10, score)),
+                "rationale": coerce_text(item.get("rationale", item.get("reason"))).strip(),
+            }
+        return cls(scores=scores)
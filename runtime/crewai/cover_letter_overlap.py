@@ -0,0 +1,128 @@
+"""Near-duplicate detection between cover letters for concurrent applications.
+
+Someone applying to several similar roles at once tends to get several similar cover
+letters, and a hiring manager who sees two of them side by side (recruiters talk, and
+agencies forward) spots the template immediately. This module compares the new
+letter's paragraphs with the letters of other recent runs in the same output
+directory, skipping runs for the same job description, which are iterations and are
+*meant* to be similar.
+
+Similarity is the word-level ``difflib`` ratio between normalised paragraphs; short
+paragraphs (salutations, sign-offs) are ignored because every letter shares them.
+The workflow uses the overlaps to send the tailoring agent back with the offending
+paragraphs to rewrite (see ``HydraWorkflow._differentiate_cover_letter``).
+"""
+
+from __future__ import annotations
+
+import json
+import re
+import time
+from dataclasses import dataclass
+from difflib import SequenceMatcher
+from pathlib import Path
+from typing import Any, Dict, List, Optional
+
+from runtime.crewai.artifacts import COVER_LETTER_FILE, MANIFEST_FILE
+from runtime.crewai.encryption import read_text
+
+SIMILARITY_THRESHOLD = 0.8
+MIN_PARAGRAPH_WORDS = 12
+RECENT_DAYS = 30
+MAX_LETTERS = 20
+
+
+@dataclass
+class Overlap:
+    """A paragraph of the new letter that nearly repeats one from another run."""
+
+    paragraph: int  # 1-based, among the letter's comparable paragraphs
+    text: str
+    other_run: str
+    other_paragraph: int
+    similarity: float
+
+    def to_dict(self) -> Dict[str, Any]:
+        return {
+            "paragraph": self.paragraph,
+            "other_run": self.other_run,
+            "other_paragraph": self.other_paragraph,
+            "similarity": round(self.similarity, 2),
+        }
+
+
+def _words(text: str) -> List[str]:
+    return re.findall(r"[a-z0-9']+", text.lower())
+
+
+def paragraphs(text: str) -> List[str]:
+    """Comparable paragraphs: blank-line separated, headings and short lines dropped."""
+    blocks = re.split(r"\n\s*\n", text or "")
+    kept = []
+    for block in blocks:
+        block = " ".join(line.strip() for line in block.strip().splitlines())
+        if block.startswith("#") or len(_words(block)) < MIN_PARAGRAPH_WORDS:
+            continue
+        kept.append(block)
+    return kept
+
+
+def similarity(a: str, b: str) -> float:
+    """Word-level similarity of two paragraphs, 0.0-1.0."""
+    return SequenceMatcher(None, _words(a), _words(b), autojunk=False).ratio()
+
+
+def find_overlaps(
+    letter: str,
+    others: Dict[str, str],
+    threshold: float = SIMILARITY_THRESHOLD,
+) -> List[Overlap]:
+    """Paragraphs of ``letter`` at or above ``threshold`` against any letter in
+    ``others`` (run id -> text). Each paragraph is reported once, at its closest match."""
+    other_paragraphs = {run: paragraphs(text) for run, text in others.items()}
+    found: List[Overlap] = []
+    for index, paragraph in enumerate(paragraphs(letter), start=1):
+        best: Optional[Overlap] = None
+        for run, candidates in other_paragraphs.items():
+            for other_index, candidate in enumerate(candidates, start=1):
+                score = similarity(paragraph, candidate)
+                if score >= threshold and (best is None or score > best.similarity):
+                    best = Overlap(index, paragraph, run, other_index, score)
+        if best is not None:
+            found.append(best)
+    return found
+
+
+def recent_cover_letters(
+    base_dir: Path,
+    exclude_jd_path: Optional[str] = None,
+    days: int = RECENT_DAYS,
+    now: Optional[float] = None,
+) -> Dict[str, str]:
+    """Cover letters of runs under ``base_dir`` written in the last ``days`` days.
+
+    Runs whose manifest records ``exclude_jd_path`` as their job description are
+    skipped. At most ``MAX_LETTERS`` of the newest letters are returned.
+    """
+    base_dir = Path(base_dir)
+    if days <= 0 or not base_dir.is_dir():
+        return {}
+    cutoff = (now if now is not None else time.time()) - days * 86400
+    letters = []
+    for path in base_dir.glob(f"*/{COVER_LETTER_FILE}"):
+        modified = path.stat().st_mtime
+        if modified < cutoff:
+            continue
+        if exclude_jd_path and _jd_path(path.parent) == exclude_jd_path:
+            continue
+        letters.append((modified, path))
+    letters.sort(reverse=True)
+    return {path.parent.name: read_text(path) for _, path in letters[:MAX_LETTERS]}
+
+
+def _jd_path(run_dir: Path) -> Optional[str]:
+    try:
+        manifest = json.loads((run_dir / MANIFEST_FILE).read_text())
+    except (OSError, ValueError):
+        return None
+    return (manifest.get("inputs") or {}).get("jd_path")
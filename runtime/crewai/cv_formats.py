@@ -0,0 +1,579 @@
+"""CV formats: the regional shape of the final résumé, chosen per run.
+
+A US résumé has no photo and no personal data; a German Lebenslauf leads with both,
+and a Europass CV is a fixed EU layout that many public bodies and employers accept as
+XML as well as PDF. A US-style résumé sent to those markets reads as incomplete, so
+``--cv-format`` picks the conventions for a run:
+
+    us          no photo, no personal data (classic theme)
+    uk          the same, as a CV (classic theme)
+    europass    personal information and photo, europass theme, plus europass.xml
+    lebenslauf  photo and personal data first, dates in a left column (DE, AT)
+    ch          the Lebenslauf with a work permit line (Switzerland)
+    fr          photo optional, date of birth and nationality (modern theme)
+
+Personal data never comes from a model. It is read from ``~/.hydra/personal.yaml``
+(or ``--personal FILE``), and a format shows only the fields it calls for that you
+filled in:
+
+    photo: ~/Pictures/portrait.jpg
+    date_of_birth: 1990-05-12
+    nationality: German
+    marital_status: married
+    work_permit: C permit
+    address: Musterstraße 1, 10115 Berlin
+
+The format's country conventions (see locale_policy) still apply: a field the target
+country forbids is left out, with a warning. The personal data is written into the
+themed files and ``europass.xml`` only; ``run.json`` records which fields were shown,
+never their values. ``europass.xml`` follows the Europass CV XML schema (v3):
+identification, headline, work experience, education and skills are read from the
+résumé's ``##`` sections.
+"""
+
+from __future__ import annotations
+
+import base64
+import re
+import xml.etree.ElementTree as ET
+from dataclasses import dataclass, field
+from datetime import date, datetime
+from pathlib import Path
+from typing import Any, Dict, List, Optional, Tuple
+
+import yaml
+
+from runtime.crewai.locale_policy import FIELD_LABELS, FORBIDDEN, LocalePolicy, detect_fields
+from runtime.crewai.localization import Language, date_points
+from runtime.crewai.resume_themes import (
+    ParsedResume,
+    Theme,
+    ThemeError,
+    parse_resume,
+    write_theme,
+)
+from runtime.crewai.stage_cache import hydra_home
+
+PERSONAL_FILE = "personal.yaml"
+EUROPASS_XML_FILE = "europass.xml"
+EUROPASS_NAMESPACE = "http://europass.cedefop.europa.eu/Europass"
+EUROPASS_XSD_VERSION = "V3.4"
+
+DATE_OF_BIRTH = "date_of_birth"
+NATIONALITY = "nationality"
+MARITAL_STATUS = "marital_status"
+WORK_PERMIT = "work_permit"
+ADDRESS = "address"
+PERSONAL_FIELDS = (DATE_OF_BIRTH, NATIONALITY, MARITAL_STATUS, WORK_PERMIT, ADDRESS)
+
+# The personal section's heading and labels; other languages use the English ones.
+PERSONAL_LABELS: Dict[str, Dict[str, str]] = {
+    "en": {
+        "title": "Personal information",
+        DATE_OF_BIRTH: "Date of birth",
+        NATIONALITY: "Nationality",
+        MARITAL_STATUS: "Marital status",
+        WORK_PERMIT: "Work permit",
+        ADDRESS: "Address",
+    },
+    "de": {
+        "title": "Persönliche Daten",
+        DATE_OF_BIRTH: "Geburtsdatum",
+        NATIONALITY: "Staatsangehörigkeit",
+        MARITAL_STATUS: "Familienstand",
+        WORK_PERMIT: "Arbeitsbewilligung",
+        ADDRESS: "Anschrift",
+    },
+    "fr": {
+        "title": "Informations personnelles",
+        DATE_OF_BIRTH: "Date de naissance",
+        NATIONALITY: "Nationalité",
+        MARITAL_STATUS: "Situation de famille",
+        WORK_PERMIT: "Permis de travail",
+        ADDRESS: "Adresse",
+    },
+}
+_BIRTH_DATE_FORMATS = {
+    "de": "{d:02d}.{m:02d}.{y}",
+    "fr": "{d:02d}/{m:02d}/{y}",
+    "en": "{d} {month} {y}",
+}
+
+
+class PersonalDataError(ValueError):
+    """Raised for a personal data file that cannot be read or has unknown fields."""
+
+    pass
+
+
+@dataclass(frozen=True)
+class CVFormat:
+    name: str
+    description: str
+    theme: str
+    # The country whose conventions apply unless --target-country names another.
+    country: Optional[str]
+    photo: bool = False
+    personal: Tuple[str, ...] = ()
+    europass_xml: bool = False
+
+
+CV_FORMATS: Dict[str, CVFormat] = {
+    cv_format.name: cv_format
+    for cv_format in (
+        CVFormat("us", "US résumé: no photo, no personal data", "classic", "US"),
+        CVFormat("uk", "UK CV: no photo, no personal data", "classic", "GB"),
+        CVFormat(
+            "europass",
+            "Europass CV: personal information and photo; also europass.xml",
+            "europass",
+            None,
+            photo=True,
+            personal=(DATE_OF_BIRTH, NATIONALITY, ADDRESS),
+            europass_xml=True,
+        ),
+        CVFormat(
+            "lebenslauf",
+            "German Lebenslauf: photo and personal data first, dates in a left column",
+            "lebenslauf",
+            "DE",
+            photo=True,
+            personal=(DATE_OF_BIRTH, NATIONALITY, MARITAL_STATUS, ADDRESS),
+        ),
+        CVFormat(
+            "ch",
+            "Swiss CV: the Lebenslauf with a photo and work permit",
+            "lebenslauf",
+            "CH",
+            photo=True,
+            personal=(DATE_OF_BIRTH, NATIONALITY, WORK_PERMIT, ADDRESS),
+        ),
+        CVFormat(
+            "fr",
+            "French CV: optional photo, date of birth and nationality",
+            "modern",
+            "FR",
+            photo=True,
+            personal=(DATE_OF_BIRTH, NATIONALITY),
+        ),
+    )
+}
+
+
+@dataclass
+class PersonalData:
+    photo: Optional[Path] = None
+    values: Dict[str, str] = field(default_factory=dict)
+
+    @classmethod
+    def from_dict(cls, data: Any, source: str = "personal data") -> "PersonalData":
+        if not isinstance(data, dict):
+            raise PersonalDataError(f"{source}: expected a mapping of personal data")
+        unknown = sorted(set(data) - {*PERSONAL_FIELDS, "photo"})
+        if unknown:
+            raise PersonalDataError(f"{source}: unknown field(s) {', '.join(unknown)}")
+        photo = Path(str(data["photo"])).expanduser() if data.get("photo") else None
+        values = {}
+        for name in PERSONAL_FIELDS:
+            value = data.get(name)
+            if isinstance(value, (date, datetime)):
+                value = value.isoformat()[:10]  # YAML reads 1990-05-12 as a date
+            if value not in (None, ""):
+                values[name] = str(value).strip()
+        return cls(photo=photo, values=values)
+
+
+def get_cv_format(name: str) -> Optional[CVFormat]:
+    return CV_FORMATS.get(name.strip().lower())
+
+
+def load_personal(path: Optional[Path] = None) -> Optional[PersonalData]:
+    """The personal data in ``path``, else ``~/.hydra/personal.yaml``; None without
+    either. ``path`` must exist; the default may not. A relative photo path is read
+    from the file's directory."""
+    if path is None:
+        path = hydra_home() / PERSONAL_FILE
+        if not path.is_file():
+            return None
+    try:
+        data = yaml.safe_load(Path(path).read_text()) or {}
+    except OSError as err:
+        raise PersonalDataError(f"Cannot read {path}: {err}") from err
+    except yaml.YAMLError as err:
+        raise PersonalDataError(f"{path}: not YAML: {err}") from err
+    personal = PersonalData.from_dict(data, str(path))
+    if personal.photo is not None and not personal.photo.is_absolute():
+        personal.photo = Path(path).parent / personal.photo
+    return personal
+
+
+def _on_resume(resume_markdown: str) -> set:
+    """The personal fields the résumé already shows, as labelled lines."""
+    found = {name for name, lines in detect_fields(resume_markdown).items() if lines}
+    for name in PERSONAL_FIELDS:
+        names = "|".join(re.escape(labels[name]) for labels in PERSONAL_LABELS.values())
+        if re.search(rf"^\W*({names})\W*\s*:", resume_markdown, re.IGNORECASE | re.MULTILINE):
+            found.add(name)
+    return found
+
+
+def _labels(language: Optional[Language]) -> Dict[str, str]:
+    return PERSONAL_LABELS.get(language.code if language else "en", PERSONAL_LABELS["en"])
+
+
+def _birth_date(value: str, language: Optional[Language]) -> str:
+    try:
+        born = date.fromisoformat(value)
+    except ValueError:
+        return value  # written the candidate's own way
+    code = language.code if language else "en"
+    pattern = _BIRTH_DATE_FORMATS.get(code, "{y}-{m:02d}-{d:02d}")
+    return pattern.format(d=born.day, m=born.month, y=born.year, month=born.strftime("%B"))
+
+
+@dataclass
+class PersonalSection:
+    """What a CV format adds to the résumé from the personal data."""
+
+    markdown: str = ""
+    fields: List[str] = field(default_factory=list)
+    photo: Optional[Path] = None
+    warnings: List[str] = field(default_factory=list)
+
+
+def personal_section(
+    cv_format: CVFormat,
+    personal: Optional[PersonalData],
+    resume_markdown: str,
+    policy: Optional[LocalePolicy] = None,
+    language: Optional[Language] = None,
+) -> PersonalSection:
+    """The personal information ``cv_format`` shows: fields filled in ``personal``,
+    not already on the résumé and not forbidden by ``policy``."""
+    section = PersonalSection()
+    if personal is None:
+        return section
+    on_resume = _on_resume(resume_markdown)
+    labels = _labels(language)
+    lines = []
+    for name in cv_format.personal:
+        value = personal.values.get(name)
+        if value is None or name in on_resume:
+            continue
+        if policy is not None and name in FIELD_LABELS and policy.rule(name) == FORBIDDEN:
+            section.warnings.append(
+                f"{FIELD_LABELS[name]} left out: not expected on applications in "
+                f"{policy.country}"
+            )
+            continue
+        shown = _birth_date(value, language) if name == DATE_OF_BIRTH else value
+        lines.append(f"{labels[name]}: {shown}")
+        section.fields.append(name)
+    if lines:
+        section.markdown = f"## {labels['title']}\n\n" + "\n".join(lines) + "\n"
+    if cv_format.photo and personal.photo is not None:
+        if policy is not None and policy.rule("photo") == FORBIDDEN:
+            section.warnings.append(
+                f"photo left out: not expected on applications in {policy.country}"
+            )
+        elif not personal.photo.is_file():
+            section.warnings.append(f"photo not found: {personal.photo}")
+        else:
+            section.photo = personal.photo
+    return section
+
+
+def with_personal_section(resume_markdown: str, section: str) -> str:
+    """``resume_markdown`` with ``section`` as its first ``##`` section."""
+    if not section:
+        return resume_markdown
+    match = re.search(r"^## ", resume_markdown, re.MULTILINE)
+    if match is None:
+        return resume_markdown.rstrip("\n") + "\n\n" + section
+    start = match.start()
+    return resume_markdown[:start] + section + "\n" + resume_markdown[start:]
+
+
+_EXPERIENCE = re.compile(
+    r"experience|employment|work|career|berufserfahrung|erfahrung|expérience|experiencia|"
+    r"esperienza|werkervaring",
+    re.IGNORECASE,
+)
+_EDUCATION = re.compile(
+    r"education|training|studies|ausbildung|bildung|studium|formation|educación|formazione|"
+    r"opleiding",
+    re.IGNORECASE,
+)
+_SKILLS = re.compile(
+    r"skill|kenntnisse|fähigkeiten|compétences|competencias|competenze|vaardigheden",
+    re.IGNORECASE,
+)
+_TITLE_WORDS = re.compile(
+    r"\b(engineer|developer|manager|lead|director|analyst|designer|consultant|architect|"
+    r"scientist|specialist|intern|head|officer|administrator|coordinator|researcher|"
+    r"associate|ingenieur|entwickler|leiter|berater|student|bachelor|master|b\.?sc|m\.?sc|"
+    r"ph\.?d|diploma|degree)\b",
+    re.IGNORECASE,
+)
+_HEADING_SPLIT = re.compile(r"\s+[—–|@]\s+|\s+-\s+|\s+at\s+|,\s+")
+_PARENTHESIS = re.compile(r"\s*\(([^)]*)\)\s*$")
+_LINK = re.compile(r"\[([^\]]+)\]\(([^)\s]+)\)")
+_MARKUP = re.compile(r"\[([^\]]+)\]\([^)]*\)|\*\*|\*|__|`")
+_EMAIL = re.compile(r"[\w.+-]+@[\w-]+\.[\w.-]+")
+_PHONE = re.compile(r"^\+?[\d\s().-]{7,}$")
+
+
+def _plain(text: str) -> str:
+    return _MARKUP.sub(lambda m: m.group(1) or "", text).strip()
+
+
+def _split_heading(heading: str) -> Tuple[str, str, str]:
+    """An entry heading as (title, organisation, dates in parentheses)."""
+    heading = _plain(heading)
+    dates = ""
+    match = _PARENTHESIS.search(heading)
+    if match and date_points(match.group(1)):
+        dates, heading = match.group(1), heading[: match.start()]
+    parts = _HEADING_SPLIT.split(heading, maxsplit=1)
+    if len(parts) == 1:
+        return parts[0], "", dates
+    first, second = parts
+    if _TITLE_WORDS.search(second) and not _TITLE_WORDS.search(first):
+        return second, first, dates
+    return first, second, dates
+
+
+def employers(resume_markdown: str) -> List[str]:
+    """The organisations named in the headings of a résumé's experience entries."""
+    names = []
+    for section in parse_resume(resume_markdown).sections:
+        if _EXPERIENCE.search(section.title):
+            for entry in section.entries:
+                organisation = _split_heading(entry.heading)[1] if entry.heading else ""
+                if organisation and organisation not in names:
+                    names.append(organisation)
+    return names
+
+
+def _entry_text(content: List[tuple]) -> str:
+    lines = []
+    for kind, text in content:
+        if kind == "item":
+            lines.append(f"- {_plain(text)}")
+        else:
+            lines.extend(_plain(line) for line in text)
+    return "\n".join(lines)
+
+
+def _text(parent: ET.Element, tag: str, text: str) -> ET.Element:
+    element = ET.SubElement(parent, tag)
+    element.text = text
+    return element
+
+
+def _period(parent: ET.Element, dates: str) -> None:
+    points = date_points(dates)
+    if not points:
+        return
+    period = ET.SubElement(parent, "Period")
+    for tag, point in zip(("From", "To"), points[:2]):
+        if point is None:
+            _text(period, "Current", "true")
+            continue
+        year, month = point
+        attributes = {"year": str(year)}
+        if month is not None:
+            attributes["month"] = f"--{month:02d}"
+        ET.SubElement(period, tag, attributes)
+
+
+def _identification(
+    learner: ET.Element,
+    resume: ParsedResume,
+    personal: Optional[PersonalData],
+    shown: Tuple[str, ...],
+) -> None:
+    identification = ET.SubElement(learner, "Identification")
+    name = ET.SubElement(identification, "PersonName")
+    *first, surname = resume.name.split() or [""]
+    _text(name, "FirstName", " ".join(first))
+    _text(name, "Surname", surname)
+    contact = ET.SubElement(identification, "ContactInfo")
+    values = personal.values if personal is not None else {}
+    if ADDRESS in shown:
+        address = ET.SubElement(ET.SubElement(contact, "Address"), "Contact")
+        _text(address, "AddressLine", values[ADDRESS])
+    items = [_plain(item) for item in resume.contact]
+    email = next((item for item in items if _EMAIL.fullmatch(item)), None)
+    if email:
+        _text(ET.SubElement(contact, "Email"), "Contact", email)
+    phones = [item for item in items if _PHONE.match(item)]
+    if phones:
+        telephones = ET.SubElement(contact, "TelephoneList")
+        for phone in phones:
+            _text(ET.SubElement(telephones, "Telephone"), "Contact", phone)
+    sites = [m.group(2) for m in _LINK.finditer(" ".join(resume.contact))]
+    sites += [item for item in items if "://" in item]
+    if sites:
+        websites = ET.SubElement(contact, "WebsiteList")
+        for site in sites:
+            _text(ET.SubElement(websites, "Website"), "Contact", site)
+    if DATE_OF_BIRTH in shown or NATIONALITY in shown:
+        demographics = ET.SubElement(identification, "Demographics")
+        if DATE_OF_BIRTH in shown:
+            try:
+                born = date.fromisoformat(values[DATE_OF_BIRTH])
+                ET.SubElement(
+                    demographics,
+                    "Birthdate",
+                    {
+                        "year": str(born.year),
+                        "month": f"--{born.month:02d}",
+                        "day": f"---{born.day:02d}",
+                    },
+                )
+            except ValueError:
+                pass  # not a date the schema can hold
+        if NATIONALITY in shown:
+            nationalities = ET.SubElement(demographics, "NationalityList")
+            _text(ET.SubElement(nationalities, "Nationality"), "Label", values[NATIONALITY])
+
+
+def europass_xml(
+    resume_markdown: str,
+    personal: Optional[PersonalData] = None,
+    shown: Tuple[str, ...] = (),
+    photo: Optional[Path] = None,
+    language: Optional[Language] = None,
+    now: Optional[datetime] = None,
+) -> str:
+    """The résumé as a Europass CV XML document; ``shown`` are the personal fields to
+    include (see ``personal_section``) and ``photo`` is embedded when given."""
+    resume = parse_resume(resume_markdown)
+    root = ET.Element(
+        "SkillsPassport",
+        {"xmlns": EUROPASS_NAMESPACE, "locale": language.code if language else "en"},
+    )
+    info = ET.SubElement(root, "DocumentInfo")
+    _text(info, "DocumentType", "ECV")
+    created = (now or datetime.now()).replace(microsecond=0)
+    _text(info, "CreationDate", created.isoformat())
+    _text(info, "XSDVersion", EUROPASS_XSD_VERSION)
+    _text(info, "Generator", "hydra")
+    learner = ET.SubElement(root, "LearnerInfo")
+    _identification(learner, resume, personal, shown)
+    if photo is not None:
+        identification = learner.find("Identification")
+        picture = ET.SubElement(identification, "Photo")
+        suffix = photo.suffix.lower().lstrip(".")
+        _text(picture, "MimeType", f"image/{'jpeg' if suffix == 'jpg' else suffix}")
+        _text(picture, "Data", base64.b64encode(photo.read_bytes()).decode("ascii"))
+    if resume.headline:
+        headline = ET.SubElement(learner, "Headline")
+        _text(ET.SubElement(headline, "Type"), "Code", "position")
+        _text(ET.SubElement(headline, "Description"), "Label", _plain(resume.headline))
+
+    work, education, skills, achievements = [], [], [], []
+    for section in resume.sections:
+        if _EXPERIENCE.search(section.title):
+            work += [entry for entry in section.entries if entry.heading]
+        elif _EDUCATION.search(section.title):
+            education += [entry for entry in section.entries if entry.heading]
+        elif _SKILLS.search(section.title):
+            skills += [_entry_text(entry.content) for entry in section.entries]
+        else:
+            achievements.append(section)
+    if work:
+        work_list = ET.SubElement(learner, "WorkExperienceList")
+        for entry in work:
+            title, employer, dates = _split_heading(entry.heading)
+            experience = ET.SubElement(work_list, "WorkExperience")
+            _period(experience, entry.details or dates)
+            _text(ET.SubElement(experience, "Position"), "Label", title)
+            _text(experience, "Activities", _entry_text(entry.content))
+            if employer:
+                _text(ET.SubElement(experience, "Employer"), "Name", employer)
+    if education:
+        education_list = ET.SubElement(learner, "EducationList")
+        for entry in education:
+            title, organisation, dates = _split_heading(entry.heading)
+            studies = ET.SubElement(education_list, "Education")
+            _period(studies, entry.details or dates)
+            _text(studies, "Title", title)
+            if entry.content:
+                _text(studies, "Activities", _entry_text(entry.content))
+            if organisation:
+                _text(ET.SubElement(studies, "Organisation"), "Name", organisation)
+    if any(skills):
+        other = ET.SubElement(ET.SubElement(learner, "Skills"), "Other")
+        _text(other, "Description", "\n".join(text for text in skills if text))
+    if achievements:
+        achievement_list = ET.SubElement(learner, "AchievementList")
+        for section in achievements:
+            text = "\n".join(
+                line
+                for entry in section.entries
+                for line in (_plain(entry.heading or ""), _entry_text(entry.content))
+                if line
+            )
+            achievement = ET.SubElement(achievement_list, "Achievement")
+            _text(ET.SubElement(achievement, "Title"), "Label", _plain(section.title))
+            _text(achievement, "Description", text)
+    ET.indent(root)
+    declaration = '<?xml version="1.0" encoding="UTF-8"?>\n'
+    return declaration + ET.tostring(root, encoding="unicode") + "\n"
+
+
+@dataclass
+class CVOutput:
+    cv_format: str
+    theme: str
+    files: List[str]
+    fields: List[str] = field(default_factory=list)
+    photo: bool = False
+    pdf_error: Optional[str] = None
+    warnings: List[str] = field(default_factory=list)
+
+    def to_manifest(self) -> Dict[str, Any]:
+        """Field names only: the values are personal."""
+        return {"name": self.cv_format, "personal": list(self.fields), "photo": self.photo}
+
+
+def write_cv(
+    out_dir: Path,
+    resume_markdown: str,
+    cv_format: CVFormat,
+    theme: Theme,
+    personal: Optional[PersonalData] = None,
+    policy: Optional[LocalePolicy] = None,
+    language: Optional[Language] = None,
+    pdf: bool = True,
+) -> CVOutput:
+    """Write the résumé in ``cv_format`` into ``out_dir``: the themed files with the
+    personal section and photo, and ``europass.xml`` for Europass."""
+    out_dir = Path(out_dir)
+    section = personal_section(cv_format, personal, resume_markdown, policy, language)
+    markdown = with_personal_section(resume_markdown, section.markdown)
+    themed = write_theme(
+        out_dir, markdown, theme, pdf=pdf, language=language, photo=section.photo
+    )
+    output = CVOutput(
+        cv_format=cv_format.name,
+        theme=themed.theme,
+        files=list(themed.files),
+        fields=section.fields,
+        photo=section.photo is not None,
+        pdf_error=themed.pdf_error,
+        warnings=section.warnings,
+    )
+    if cv_format.europass_xml:
+        try:
+            xml = europass_xml(
+                resume_markdown, personal, tuple(section.fields), section.photo, language
+            )
+        except ThemeError as err:
+            output.warnings.append(f"{EUROPASS_XML_FILE} not written: {err}")
+            return output
+        (out_dir / EUROPASS_XML_FILE).write_text(xml, encoding="utf-8")
+        output.files.append(EUROPASS_XML_FILE)
+    return output
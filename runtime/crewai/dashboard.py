@@ -0,0 +1,269 @@
+"""Live terminal dashboard for a CLI run (``--tui``).
+
+A run takes minutes; without this the terminal sits silent between the start banner
+and the summary. The dashboard redraws a few times a second from the workflow's own
+state — nothing is sent to it, so the workflow does not know it is being watched:
+
+- every stage of the pipeline: pending, running (with its elapsed time) or done;
+- token spend: prompt and completion tokens, model calls and the estimated cost so
+  far, in total and per stage;
+- the execution log as it is written, plus anything the run prints.
+
+The human gates are answered inline. The dashboard swaps the workflow's
+``user_interaction`` for one that stops the redraw, asks, and resumes it — for the
+greenlight it renders the gap analysis first (fit score, matches, adjacent
+experience, gaps) instead of asking blind.
+
+Token usage is read from the workflow's usage ledger and attributed to the stage that
+was running when the dashboard first saw the call, so stages running in parallel
+(quick apply) may trade a call between them.
+"""
+
+from __future__ import annotations
+
+import threading
+import time
+from contextlib import contextmanager
+from dataclasses import dataclass
+from typing import Any, Dict, Iterator, List, Optional
+
+from rich.console import Console, Group
+from rich.live import Live
+from rich.panel import Panel
+from rich.table import Table
+from rich.text import Text
+
+from runtime.crewai.contracts import GapReview
+from runtime.crewai.fit_score import DECISION_MARKS, greenlight_question
+from runtime.crewai.hydra_workflow import UserInteraction, WorkflowState
+from runtime.crewai.model_config import estimate_cost
+
+REFRESH_PER_SECOND = 4
+LOG_LINES = 8
+
+# Pipeline order; research and compensation only show when the run has them.
+STAGES = (
+    WorkflowState.RESEARCH,
+    WorkflowState.GAP_ANALYSIS,
+    WorkflowState.INTERROGATION,
+    WorkflowState.DIFFERENTIATION,
+    WorkflowState.TAILORING,
+    WorkflowState.ATS_OPTIMIZATION,
+    WorkflowState.AUDITING,
+    WorkflowState.CLAIM_VERIFICATION,
+    WorkflowState.ATS_LINT,
+    WorkflowState.CHRONOLOGY_CHECK,
+    WorkflowState.ATS_PARSE_CHECK,
+    WorkflowState.EXECUTIVE_SYNTHESIS,
+    WorkflowState.COMPENSATION,
+)
+# The human gates, shown against the stage they follow.
+_GATES = {
+    WorkflowState.GAP_ANALYSIS_REVIEW: WorkflowState.GAP_ANALYSIS,
+    WorkflowState.INTERROGATION_REVIEW: WorkflowState.INTERROGATION,
+}
+
+
+@dataclass
+class StageProgress:
+    """What the dashboard has seen of one stage."""
+
+    name: str
+    started: Optional[float] = None
+    finished: Optional[float] = None
+    calls: int = 0
+    prompt_tokens: int = 0
+    completion_tokens: int = 0
+    cost_usd: float = 0.0
+
+    @property
+    def status(self) -> str:
+        if self.finished is not None:
+            return "done"
+        return "running" if self.started is not None else "pending"
+
+    def elapsed(self, now: float) -> Optional[float]:
+        if self.started is None:
+            return None
+        return (self.finished or now) - self.started
+
+
+class Dashboard:
+    """Redraws a run's progress while ``workflow.execute`` runs (a context manager)."""
+
+    def __init__(self, workflow: Any, run_id: str = "", console: Optional[Console] = None):
+        self.workflow = workflow
+        self.run_id = run_id
+        self.console = console or Console()
+        stages = [
+            state
+            for state in STAGES
+            if (state is not WorkflowState.RESEARCH or workflow.search_provider is not None)
+            and (state is not WorkflowState.COMPENSATION or workflow.compensation)
+        ]
+        self.stages: Dict[str, StageProgress] = {s.value: StageProgress(s.value) for s in stages}
+        self.waiting_for: Optional[str] = None
+        self._started = time.monotonic()
+        self._current: Optional[str] = None
+        self._seen_calls = 0
+        self._lock = threading.Lock()
+        self._live: Optional[Live] = None
+        self._previous_interaction: Any = None
+
+    def __enter__(self) -> "Dashboard":
+        self._previous_interaction = self.workflow.user_interaction
+        self.workflow.user_interaction = DashboardInteraction(self)
+        self._live = Live(
+            console=self.console,
+            get_renderable=self.render,
+            refresh_per_second=REFRESH_PER_SECOND,
+        )
+        self._live.start()
+        return self
+
+    def __exit__(self, *exc: Any) -> None:
+        self.observe(finished=True)
+        if self._live is not None:
+            self._live.stop()
+        self.workflow.user_interaction = self._previous_interaction
+
+    @contextmanager
+    def paused(self, waiting_for: str) -> Iterator[None]:
+        """Stop redrawing while the user answers a prompt."""
+        self.waiting_for = waiting_for
+        if self._live is not None:
+            self._live.stop()
+        try:
+            yield
+        finally:
+            self.waiting_for = None
+            if self._live is not None:
+                self._live.start()
+
+    def observe(self, finished: bool = False) -> None:
+        """Catch up with the workflow: stage transitions and new model calls."""
+        now = time.monotonic()
+        with self._lock:
+            state = self.workflow.get_current_state()
+            state = _GATES.get(state, state).value
+            if state != self._current:
+                if self._current in self.stages:
+                    self.stages[self._current].finished = now
+                if state in self.stages and self.stages[state].started is None:
+                    self.stages[state].started = now
+                self._current = state
+            if finished and self._current in self.stages:
+                self.stages[self._current].finished = now
+
+            ledger = getattr(self.workflow, "usage_ledger", None)
+            calls = list(getattr(ledger, "calls", None) or [])
+            stage = self.stages.get(self._current)
+            for call in calls[self._seen_calls :]:
+                if stage is None:
+                    continue
+                stage.calls += 1
+                stage.prompt_tokens += call.prompt_tokens
+                stage.completion_tokens += call.completion_tokens
+                stage.cost_usd += (
+                    estimate_cost(call.model, call.prompt_tokens, call.completion_tokens) or 0.0
+                )
+            self._seen_calls = len(calls)
+
+    def render(self) -> Group:
+        """The dashboard as it stands now."""
+        self.observe()
+        now = time.monotonic()
+        elapsed = now - self._started
+        title = f"Hydra run {self.run_id}".strip()
+        status = f"awaiting {self.waiting_for}" if self.waiting_for else self._current
+
+        table = Table(expand=True, box=None, pad_edge=False)
+        table.add_column("")
+        table.add_column("Stage")
+        table.add_column("Time", justify="right")
+        table.add_column("Calls", justify="right")
+        table.add_column("Tokens in/out", justify="right")
+        table.add_column("Est. $", justify="right")
+        for stage in self.stages.values():
+            mark = {"done": "[green]✓[/]", "running": "[yellow]▶[/]", "pending": "[dim]·[/]"}
+            seconds = stage.elapsed(now)
+            table.add_row(
+                mark[stage.status],
+                stage.name if stage.status != "pending" else f"[dim]{stage.name}[/]",
+                f"{seconds:.0f}s" if seconds is not None else "",
+                str(stage.calls or ""),
+                f"{stage.prompt_tokens}/{stage.completion_tokens}" if stage.calls else "",
+                f"{stage.cost_usd:.4f}" if stage.cost_usd else "",
+            )
+        stages = list(self.stages.values())
+        prompt = sum(s.prompt_tokens for s in stages)
+        completion = sum(s.completion_tokens for s in stages)
+        cost = sum(s.cost_usd for s in stages)
+        spend = Text(
+            f"{sum(s.calls for s in stages)} model calls · {prompt} in / {completion} out "
+            f"tokens · ~${cost:.4f}"
+        )
+
+        log = [
+            line.split("] ", 1)[-1] for line in self.workflow.get_execution_log()[-LOG_LINES:]
+        ]
+        return Group(
+            Panel(
+                Group(table, Text(""), spend),
+                title=title,
+                subtitle=f"{status or 'starting'} · {elapsed:.0f}s",
+            ),
+            Panel(Text("\n".join(log) or "…"), title="Log"),
+        )
+
+
+def render_gap_review(result: Dict[str, Any]) -> Panel:
+    """The gap analysis as shown before the greenlight."""
+    review = GapReview.from_raw(result)
+    table = Table(box=None, show_header=False, expand=True)
+    table.add_column(style="bold", no_wrap=True)
+    table.add_column()
+    for label, items, style in (
+        ("Matches", review.matches, "green"),
+        ("Adjacent", review.adjacent, "yellow"),
+        ("Gaps", review.gaps, "red"),
+    ):
+        table.add_row(f"[{style}]{label} ({len(items)})[/]", "\n".join(items) or "—")
+    if review.recommended_profile:
+        reason = f" — {review.profile_reason}" if review.profile_reason else ""
+        table.add_row("[bold]Best profile[/]", f"{review.recommended_profile}{reason}")
+    if review.rationale:
+        table.add_row("[bold]Why[/]", "\n".join(review.rationale))
+    score = f"fit score {review.fit_score:g}" if review.fit_score is not None else "no fit score"
+    if review.decision:
+        score += f" · {DECISION_MARKS.get(review.decision, '')} {review.decision}"
+    return Panel(table, title=f"Gap analysis · {score}")
+
+
+class DashboardInteraction(UserInteraction):
+    """The workflow's prompts, asked with the dashboard paused."""
+
+    def __init__(self, dashboard: Dashboard):
+        self.dashboard = dashboard
+
+    def ask_yes_no(self, question: str) -> bool:
+        with self.dashboard.paused("an answer"):
+            return UserInteraction.ask_yes_no(question)
+
+    def greenlight_gap_analysis(self, result: Dict[str, Any]) -> bool:
+        with self.dashboard.paused("your greenlight"):
+            self.dashboard.console.print(render_gap_review(result))
+            decision = GapReview.from_raw(result).decision
+            return UserInteraction.ask_yes_no(greenlight_question(decision))
+
+    def pick_variant(self, candidates: List[Any]) -> Any:
+        with self.dashboard.paused("a variant pick"):
+            return UserInteraction.pick_variant(candidates)
+
+    def pick_outreach(self, kind: str, options: List[Dict[str, Any]]) -> Optional[int]:
+        with self.dashboard.paused("an outreach pick"):
+            return UserInteraction.pick_outreach(kind, options)
+
+    def conduct_interview(self, questions: List[Dict[str, Any]]) -> List[Dict[str, Any]]:
+        with self.dashboard.paused("interview answers"):
+            return UserInteraction.conduct_interview(questions)
@@ -0,0 +1,186 @@
+"""Interview debriefs: what happened in an interview, kept with the application.
+
+``hydra debrief <run_id>`` asks a few guided questions after an interview — the
+round, the questions asked, a self-assessment, next steps — and appends the answers
+to the run's ``debrief.json``. A run directory is the application record, so its
+debriefs sit next to the documents that were sent. Later runs for the same company
+(``--company``) pass every earlier debrief for that company to the
+Interrogator-Prepper, so its interview prep starts from what the company actually
+asked rather than from the job description alone.
+"""
+
+from __future__ import annotations
+
+import json
+from dataclasses import asdict, dataclass, field
+from datetime import date, datetime
+from pathlib import Path
+from typing import Any, Callable, Dict, List, Optional, TextIO
+
+from runtime.crewai.artifacts import MANIFEST_FILE, write_artifact_index
+from runtime.crewai.encryption import read_text, write_text
+from runtime.crewai.html_report import REPORT_HTML_FILE, render_report
+
+DEBRIEF_FILE = "debrief.json"
+# Debriefs passed to interview prep; the most recent win.
+MAX_PROMPT_DEBRIEFS = 5
+
+
+@dataclass
+class Debrief:
+    """One interview round, as the candidate remembers it."""
+
+    company: str
+    round: str
+    interview_date: str
+    questions_asked: List[str] = field(default_factory=list)
+    self_rating: Optional[int] = None  # 1 (poor) .. 5 (excellent)
+    went_well: str = ""
+    to_improve: str = ""
+    next_steps: str = ""
+    follow_up_by: Optional[str] = None
+    run_id: Optional[str] = None
+    recorded_at: str = ""
+
+    def to_dict(self) -> Dict[str, Any]:
+        return asdict(self)
+
+    @classmethod
+    def from_dict(cls, raw: Dict[str, Any]) -> "Debrief":
+        known = {key: raw[key] for key in cls.__dataclass_fields__ if key in raw}
+        return cls(**known)
+
+
+def _normalize_company(name: str) -> str:
+    return " ".join(name.lower().split())
+
+
+def load_debriefs(run_dir: Path) -> List[Debrief]:
+    path = Path(run_dir) / DEBRIEF_FILE
+    if not path.is_file():
+        return []
+    return [Debrief.from_dict(raw) for raw in json.loads(read_text(path))]
+
+
+def save_debrief(run_dir: Path, debrief: Debrief) -> Path:
+    """Append ``debrief`` to the run's debriefs and note the count in ``run.json``."""
+    run_dir = Path(run_dir)
+    debriefs = [*load_debriefs(run_dir), debrief]
+    path = run_dir / DEBRIEF_FILE
+    write_text(path, json.dumps([d.to_dict() for d in debriefs], indent=2))
+
+    manifest_path = run_dir / MANIFEST_FILE
+    if manifest_path.exists():
+        manifest = json.loads(manifest_path.read_text())
+        # Rounds and dates only; the answers stay in debrief.json.
+        manifest["debriefs"] = {
+            "count": len(debriefs),
+            "rounds": [d.round for d in debriefs],
+            "last_interview": max(d.interview_date for d in debriefs),
+        }
+        artifacts = manifest.setdefault("artifacts", [])
+        if DEBRIEF_FILE not in artifacts:
+            artifacts.append(DEBRIEF_FILE)
+        manifest_path.write_text(json.dumps(manifest, indent=2, default=str))
+        (run_dir / REPORT_HTML_FILE).write_text(render_report(manifest))
+        write_artifact_index(run_dir)
+    return path
+
+
+def company_debriefs(out_dir: Path, company: str) -> List[Debrief]:
+    """Every debrief for ``company`` across the runs in ``out_dir``, oldest first."""
+    wanted = _normalize_company(company)
+    found: List[Debrief] = []
+    if not Path(out_dir).is_dir():
+        return found
+    for run_dir in sorted(Path(out_dir).iterdir()):
+        if not run_dir.is_dir():
+            continue
+        try:
+            debriefs = load_debriefs(run_dir)
+        except (OSError, ValueError, TypeError):
+            continue  # unreadable debrief file: skip the run, not the whole lookup
+        found.extend(d for d in debriefs if _normalize_company(d.company) == wanted)
+    return sorted(found, key=lambda d: (d.interview_date, d.recorded_at))
+
+
+def render_debriefs(debriefs: List[Dict[str, Any]]) -> str:
+    """Debriefs as prompt text for interview prep (most recent last)."""
+    blocks = []
+    for raw in debriefs[-MAX_PROMPT_DEBRIEFS:]:
+        debrief = Debrief.from_dict(raw)
+        lines = [f"- {debrief.interview_date}, {debrief.round}:"]
+        lines += [f"    asked: {question}" for question in debrief.questions_asked]
+        if debrief.self_rating is not None:
+            lines.append(f"    self-rating: {debrief.self_rating}/5")
+        if debrief.went_well:
+            lines.append(f"    went well: {debrief.went_well}")
+        if debrief.to_improve:
+            lines.append(f"    to improve: {debrief.to_improve}")
+        if debrief.next_steps:
+            lines.append(f"    next steps: {debrief.next_steps}")
+        blocks.append("\n".join(lines))
+    return "\n".join(blocks)
+
+
+def capture_debrief(
+    company: Optional[str],
+    input_fn: Callable[[str], str] = input,
+    out: Optional[TextIO] = None,
+    today: Optional[date] = None,
+) -> Debrief:
+    """Guided Q&A for one interview round; ``company`` is offered as the default."""
+
+    def _ask(prompt: str, default: Optional[str] = None) -> str:
+        suffix = f" [{default}]" if default else ""
+        answer = input_fn(f"{prompt}{suffix}: ").strip()
+        return answer or (default or "")
+
+    def _ask_date(prompt: str, default: Optional[str]) -> Optional[str]:
+        while True:
+            answer = _ask(prompt, default)
+            if not answer:
+                return None
+            try:
+                return date.fromisoformat(answer).isoformat()
+            except ValueError:
+                if out is not None:
+                    out.write("  Please use YYYY-MM-DD.\n")
+
+    company = _ask("Company", company)
+    while not company:
+        company = _ask("Company")
+    round_name = _ask("Interview round (e.g. recruiter screen, technical, onsite)") or "interview"
+    interview_date = _ask_date("Date of the interview", (today or date.today()).isoformat())
+
+    if out is not None:
+        out.write("Questions you were asked, one per line (empty line to finish):\n")
+    questions = []
+    while True:
+        question = input_fn("  > ").strip()
+        if not question:
+            break
+        questions.append(question)
+
+    rating = None
+    while rating is None:
+        answer = _ask("How did it go, 1 (poorly) to 5 (very well)? Enter to skip")
+        if not answer:
+            break
+        if answer.isdigit() and 1 <= int(answer) <= 5:
+            rating = int(answer)
+        elif out is not None:
+            out.write("  Please answer 1-5.\n")
+
+    return Debrief(
+        company=company,
+        round=round_name,
+        interview_date=interview_date or (today or date.today()).isoformat(),
+        questions_asked=questions,
+        self_rating=rating,
+        went_well=_ask("What went well"),
+        to_improve=_ask("What would you do differently"),
+        next_steps=_ask("Next steps (e.g. onsite scheduled, waiting to hear back)"),
+        follow_up_by=_ask_date("Follow up by (YYYY-MM-DD, Enter to skip)", None),
+        recorded_at=datetime.now().isoformat(timespec="seconds"),
+    )
@@ -0,0 +1,53 @@
+"""Deterministic runs: temperature 0 and a fixed seed on every model call.
+
+``--deterministic`` pins sampling so that two people running the same inputs on the
+same models get the same documents — or, where a provider cannot promise that, can at
+least see exactly how theirs were produced:
+
+- every call is made at temperature 0, on every provider;
+- the seed (``--seed``, default DEFAULT_SEED) goes to every provider that takes one:
+  OpenAI, Together, Chutes, OpenRouter (which passes it on) and an LLM gateway whose
+  request names a ``seed`` field. Anthropic has no seed; temperature 0 is as close as
+  it gets there.
+
+Providers still do not promise identical answers (batching and hardware vary), so
+every run records, per stage, the model and the sampling parameters it was called
+with (``providers`` in the manifest), and a deterministic run records its seed among
+the run's inputs.
+"""
+
+from __future__ import annotations
+
+from typing import Any, Dict
+
+from runtime.crewai.rate_limit import provider_of
+
+DEFAULT_SEED = 42
+
+# Providers whose API has no seed parameter: LiteLLM would reject the call.
+SEEDLESS_PROVIDERS = ("anthropic",)
+
+# The sampling parameters a run records for each stage, when the model has them set.
+PARAMETERS = ("temperature", "seed", "top_p", "max_tokens")
+
+
+def accepts_seed(llm: Any) -> bool:
+    """Whether ``llm``'s provider takes a ``seed`` parameter."""
+    return provider_of(llm) not in SEEDLESS_PROVIDERS
+
+
+def pin(llm: Any, seed: int) -> None:
+    """Set ``llm`` to temperature 0 and ``seed`` (where its provider takes one)."""
+    llm.temperature = 0.0
+    if accepts_seed(llm):
+        llm.seed = seed
+
+
+def model_parameters(llm: Any) -> Dict[str, Any]:
+    """The sampling parameters set on ``llm``, for the manifest."""
+    values = {name: getattr(llm, name, None) for name in PARAMETERS}
+    return {
+        name: value
+        for name, value in values.items()
+        if isinstance(value, (int, float)) and not isinstance(value, bool)
+    }
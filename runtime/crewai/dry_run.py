@@ -0,0 +1,218 @@
+"""Dry-run support: render every agent prompt without calling a model.
+
+``--dry-run`` walks the real pipeline with the real inputs, but each agent hands its
+fully rendered system+user prompt to a ``DryRunRecorder`` instead of sending it. The
+recorder returns a placeholder output so downstream stages still render their
+prompts, and estimates token counts and cost for the planned model of each stage.
+
+Useful for prompt iteration (read exactly what a model would see) and budgeting
+(what a run would cost) — at zero API spend.
+
+Token counts come from each planned model's tokenizer (see runtime.crewai.tokenizer):
+exact for OpenAI models when tiktoken is installed, a character-based estimate for
+the rest. The summary records which was used per model; either way the output size
+is assumed, so treat the totals as a budget, not an invoice. Repeated
+calls that share a cache-eligible system prefix (see ``prompt_cache``) are assumed to
+hit the provider's prompt cache, and the projected saving is reported separately.
+"""
+
+from __future__ import annotations
+
+import json
+from dataclasses import asdict, dataclass
+from datetime import datetime
+from pathlib import Path
+from typing import Any, Dict, List, Optional
+
+from runtime.crewai.context_window import CHARS_PER_TOKEN, estimate_tokens
+from runtime.crewai.model_config import estimate_cost
+from runtime.crewai.prompt_cache import (
+    MIN_CACHEABLE_TOKENS,
+    CallUsage,
+    cache_provider,
+    cache_savings,
+)
+from runtime.crewai.tokenizer import tokenizer_for
+
+# Assumed completion size per call; agents emit one structured JSON document.
+ASSUMED_OUTPUT_TOKENS = 1500
+
+DRY_RUN_FILE = "dry_run.json"
+PROMPTS_DIR = "prompts"
+
+PLACEHOLDER_TEXT = "[dry run: placeholder, no model was called]"
+
+
+@dataclass
+class PromptRecord:
+    """One planned model call: who, on which model, with which prompt."""
+
+    index: int
+    stage: str
+    agent: str
+    model: str
+    system: str
+    user: str
+    input_tokens: int
+    output_tokens: int
+    cost_usd: Optional[float]
+    # Projected provider prompt-cache traffic for this call's system prefix.
+    cache_read_tokens: int = 0
+    cache_write_tokens: int = 0
+
+    def summary(self) -> Dict[str, Any]:
+        """PII-free view of the record (sizes and estimates, no prompt text)."""
+        data = asdict(self)
+        data.pop("system")
+        data.pop("user")
+        data["prompt_file"] = f"{PROMPTS_DIR}/{self.filename}"
+        return data
+
+    @property
+    def filename(self) -> str:
+        return f"{self.index:02d}-{self.stage}.md"
+
+
+class DryRunRecorder:
+    """Collects rendered prompts in pipeline order in place of model calls."""
+
+    def __init__(self) -> None:
+        self.records: List[PromptRecord] = []
+        self._agents: Dict[str, tuple] = {}
+        # (model, leading system messages) already sent: what the provider has cached.
+        self._prefixes: set = set()
+
+    def register(self, agent: Any, stage: str, model: str) -> None:
+        """Route ``agent``'s model calls to this recorder, labelled with its stage."""
+        agent.dry_run_recorder = self
+        self._agents[agent.role] = (stage, model)
+
+    def model_for(self, role: str) -> str:
+        """Planned model for the agent with ``role`` ("unknown" if unregistered)."""
+        return self._agents.get(role, (None, "unknown"))[1]
+
+    def record(self, role: str, messages: List[Dict[str, str]]) -> Dict[str, Any]:
+        """Record one rendered call and return a placeholder agent output."""
+        stage, model = self._agents.get(role, (role.lower().replace(" ", "_"), "unknown"))
+        systems = [m["content"] for m in messages if m["role"] == "system"]
+        system = "\n\n".join(systems)
+        user = next((m["content"] for m in messages if m["role"] == "user"), "")
+        input_tokens = estimate_tokens(system, model) + estimate_tokens(user, model)
+        record = PromptRecord(
+            index=len(self.records) + 1,
+            stage=stage,
+            agent=role,
+            model=model,
+            system=system,
+            user=user,
+            input_tokens=input_tokens,
+            output_tokens=ASSUMED_OUTPUT_TOKENS,
+            cost_usd=estimate_cost(model, input_tokens, ASSUMED_OUTPUT_TOKENS),
+        )
+        if cache_provider(model):
+            self._project_cache(record, systems)
+        self.records.append(record)
+        return placeholder_output(role)
+
+    def _project_cache(self, record: PromptRecord, systems: List[str]) -> None:
+        """Estimate ``record``'s cache reads and writes from the prefixes sent before.
+
+        Each run of leading system messages is a cacheable prefix (the shared résumé
+        and sources, then the agent's own): the longest one already sent is read, the
+        rest of the system prefix written — when it is long enough to be cached at all.
+        """
+        tokens = [estimate_tokens(system, record.model) for system in systems]
+        if sum(tokens) < MIN_CACHEABLE_TOKENS:
+            return
+        read = 0
+        for k in range(len(systems), 0, -1):
+            if (record.model, tuple(systems[:k])) in self._prefixes:
+                read = sum(tokens[:k])
+                break
+        record.cache_read_tokens = read if read >= MIN_CACHEABLE_TOKENS else 0
+        record.cache_write_tokens = sum(tokens) - record.cache_read_tokens
+        for k in range(1, len(systems) + 1):
+            self._prefixes.add((record.model, tuple(systems[:k])))
+
+    def totals(self) -> Dict[str, Any]:
+        """Aggregate token and cost estimates across all recorded calls."""
+        costs = [r.cost_usd for r in self.records]
+        savings = [
+            cache_savings(
+                CallUsage(
+                    role=r.agent,
+                    model=r.model,
+                    cache_read_tokens=r.cache_read_tokens,
+                    cache_write_tokens=r.cache_write_tokens,
+                )
+            )
+            for r in self.records
+        ]
+        return {
+            "calls": len(self.records),
+            "input_tokens": sum(r.input_tokens for r in self.records),
+            "output_tokens": sum(r.output_tokens for r in self.records),
+            "cost_usd": round(sum(c for c in costs if c is not None), 6),
+            # Calls whose model has no entry in MODEL_PRICING are excluded from cost.
+            "unpriced_calls": sum(1 for c in costs if c is None),
+            "cache_read_tokens": sum(r.cache_read_tokens for r in self.records),
+            "cache_savings_usd": round(sum(s for s in savings if s is not None), 6),
+        }
+
+
+def placeholder_output(role: str) -> Dict[str, Any]:
+    """Stand-in agent output that lets every downstream stage render its prompt."""
+    return {
+        "agent": role,
+        "timestamp": datetime.now().isoformat(),
+        "confidence": 0.0,
+        "dry_run": True,
+        "tailored_resume": PLACEHOLDER_TEXT,
+        "tailored_cover_letter": PLACEHOLDER_TEXT,
+    }
+
+
+def _render_prompt(record: PromptRecord) -> str:
+    cost = f"${record.cost_usd:.4f}" if record.cost_usd is not None else "unknown"
+    return (
+        f"# {record.index:02d} · {record.stage} ({record.agent})\n\n"
+        f"- Model: `{record.model}`\n"
+        f"- Estimated input tokens: {record.input_tokens}\n"
+        f"- Assumed output tokens: {record.output_tokens}\n"
+        f"- Estimated cost: {cost}\n\n"
+        f"## System\n\n{record.system}\n\n"
+        f"## User\n\n{record.user}\n"
+    )
+
+
+def write_dry_run_artifacts(base_dir: Path, recorder: DryRunRecorder, run_id: str) -> Path:
+    """Write ``<base_dir>/<run_id>/`` with one prompt file per call plus a summary.
+
+    ``prompts/NN-<stage>.md`` holds the full rendered prompt (it contains the inputs,
+    by design — that is what is being inspected). ``dry_run.json`` holds only
+    sizes, models, and estimates.
+    """
+    run_dir = Path(base_dir) / run_id
+    prompts_dir = run_dir / PROMPTS_DIR
+    prompts_dir.mkdir(parents=True, exist_ok=True)
+
+    for record in recorder.records:
+        (prompts_dir / record.filename).write_text(_render_prompt(record))
+
+    summary = {
+        "run_id": run_id,
+        "mode": "dry_run",
+        "created_at": datetime.now().isoformat(),
+        "calls": [r.summary() for r in recorder.records],
+        "totals": recorder.totals(),
+        "assumptions": {
+            "chars_per_token": CHARS_PER_TOKEN,
+            "output_tokens_per_call": ASSUMED_OUTPUT_TOKENS,
+            "tokenizers": {
+                model: tokenizer_for(model).name
+                for model in sorted({r.model for r in recorder.records})
+            },
+        },
+    }
+    (run_dir / DRY_RUN_FILE).write_text(json.dumps(summary, indent=2) + "\n")
+    return run_dir
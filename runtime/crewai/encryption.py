@@ -0,0 +1,197 @@
+"""Optional encryption at rest for run state and artifacts (AES-256-GCM).
+
+A run directory holds the full résumé, the interview notes and every stage's output,
+and the stage cache under ``~/.hydra`` holds outputs derived from them. With
+encryption on (``--encrypt`` or ``HYDRA_ENCRYPT_STATE=1``) those files are written
+encrypted; ``run.json``, ``report.html`` and ``manifest.json`` hold no résumé
+content (see artifacts.py) and stay readable, so runs can still be listed, indexed
+and verified without the key.
+
+Reading is transparent: every loader in the runtime goes through ``read_text`` /
+``read_bytes``, which decrypt any file carrying the header below and pass plain files
+through, so encrypted and plain runs mix freely. Nothing else changes — a resumed run,
+the review pages, a retro audit or a debrief read an encrypted run like any other.
+
+The key is derived with scrypt from a passphrase: ``HYDRA_STATE_PASSPHRASE``, or else
+the OS keychain entry ``hydra`` / ``state-passphrase`` (``keyring set hydra
+state-passphrase``; needs ``pip install keyring``). Each file gets a fresh nonce and
+is authenticated, so a wrong passphrase or an altered file is an error, never
+garbage. ``hydra decrypt <file>`` prints one in the clear. Exports meant for sending
+or compiling — rendered themes, PDF/DOCX — and dry-run prompts are not encrypted.
+
+The web backend uses the same switch and key for what it keeps: ``seal_text`` and
+``seal_json`` encrypt the résumé, job description, sources, answers and stage outputs
+of a job row (and the run records beside it) as printable values a database column
+holds, and ``seal_bytes`` the state snapshots and documents in the artifact store.
+
+File format: ``MAGIC || salt (16) || nonce (12) || AES-GCM ciphertext+tag``, with the
+magic header as associated data. A sealed column value is ``TEXT_MAGIC`` and that
+blob in base64; a sealed JSON value is ``{SEALED_KEY: <sealed text of its JSON>}``.
+"""
+
+from __future__ import annotations
+
+import base64
+import functools
+import hashlib
+import json
+import os
+from pathlib import Path
+from typing import Any, Dict, Optional, Union
+
+ENCRYPT_ENV = "HYDRA_ENCRYPT_STATE"
+PASSPHRASE_ENV = "HYDRA_STATE_PASSPHRASE"
+KEYCHAIN_SERVICE = "hydra"
+KEYCHAIN_ACCOUNT = "state-passphrase"
+
+MAGIC = b"HYDRA-AESGCM-1\n"
+TEXT_MAGIC = "hydra-aesgcm-1:"
+SEALED_KEY = "hydra_sealed"
+SALT_BYTES = 16
+NONCE_BYTES = 12
+# scrypt cost: ~50ms and 32 MiB per key derivation, once per salt per process.
+_SCRYPT = {"n": 2**15, "r": 8, "p": 1, "maxmem": 64 * 1024 * 1024, "dklen": 32}
+
+PathLike = Union[str, Path]
+
+
+class EncryptionError(ValueError):
+    """No key is configured, or a file does not decrypt with it."""
+
+
+def is_encrypted(data: bytes) -> bool:
+    return data.startswith(MAGIC)
+
+
+class StateCipher:
+    """AES-256-GCM keyed by a passphrase; one salt for everything this instance writes."""
+
+    def __init__(self, passphrase: str):
+        if not passphrase:
+            raise EncryptionError("Empty passphrase")
+        self._passphrase = passphrase.encode("utf-8")
+        self._salt = os.urandom(SALT_BYTES)
+        self._keys: Dict[bytes, bytes] = {}
+
+    def _aead(self, salt: bytes):
+        try:
+            from cryptography.hazmat.primitives.ciphers.aead import AESGCM
+        except ImportError as e:
+            raise EncryptionError(
+                "Encryption needs the cryptography package: pip install cryptography"
+            ) from e
+        if salt not in self._keys:
+            self._keys[salt] = hashlib.scrypt(self._passphrase, salt=salt, **_SCRYPT)
+        return AESGCM(self._keys[salt])
+
+    def encrypt(self, data: bytes) -> bytes:
+        nonce = os.urandom(NONCE_BYTES)
+        return MAGIC + self._salt + nonce + self._aead(self._salt).encrypt(nonce, data, MAGIC)
+
+    def decrypt(self, blob: bytes) -> bytes:
+        if not is_encrypted(blob):
+            raise EncryptionError("Not an encrypted file")
+        body = blob[len(MAGIC) :]
+        salt, nonce = body[:SALT_BYTES], body[SALT_BYTES : SALT_BYTES + NONCE_BYTES]
+        try:
+            return self._aead(salt).decrypt(nonce, body[SALT_BYTES + NONCE_BYTES :], MAGIC)
+        except EncryptionError:
+            raise
+        except Exception as e:  # cryptography's InvalidTag, or a truncated file
+            raise EncryptionError("Wrong passphrase, or the file was altered") from e
+
+
+def keychain_passphrase() -> Optional[str]:
+    """The passphrase stored in the OS keychain, if keyring is installed and has one."""
+    try:
+        import keyring
+    except ImportError:
+        return None
+    try:
+        return keyring.get_password(KEYCHAIN_SERVICE, KEYCHAIN_ACCOUNT) or None
+    except Exception:  # no usable keychain backend
+        return None
+
+
+def passphrase() -> Optional[str]:
+    return os.environ.get(PASSPHRASE_ENV) or keychain_passphrase()
+
+
+def encryption_enabled() -> bool:
+    return os.environ.get(ENCRYPT_ENV, "").strip().lower() in ("1", "true", "yes", "on")
+
+
+@functools.lru_cache(maxsize=4)
+def _cipher_for(secret: str) -> StateCipher:
+    return StateCipher(secret)
+
+
+def state_cipher() -> StateCipher:
+    """The cipher for the configured passphrase; EncryptionError when there is none."""
+    secret = passphrase()
+    if not secret:
+        raise EncryptionError(
+            f"No passphrase: set {PASSPHRASE_ENV} or store one in the OS keychain "
+            f"(keyring set {KEYCHAIN_SERVICE} {KEYCHAIN_ACCOUNT})"
+        )
+    return _cipher_for(secret)
+
+
+def seal_bytes(data: bytes) -> bytes:
+    """``data`` encrypted when encryption is on, else unchanged."""
+    return state_cipher().encrypt(data) if encryption_enabled() else data
+
+
+def open_bytes(data: bytes) -> bytes:
+    """``data`` decrypted if it was sealed; plain data passes through."""
+    return state_cipher().decrypt(data) if is_encrypted(data) else data
+
+
+def seal_text(text: Optional[str]) -> Optional[str]:
+    """``text`` encrypted as printable text, for a database column, when encryption is
+    on; empty text and None are kept as they are."""
+    if not text or not encryption_enabled():
+        return text
+    blob = state_cipher().encrypt(text.encode("utf-8"))
+    return TEXT_MAGIC + base64.b64encode(blob).decode("ascii")
+
+
+def open_text(value: Optional[str]) -> Optional[str]:
+    """``value`` decrypted if ``seal_text`` sealed it; plain text passes through."""
+    if not isinstance(value, str) or not value.startswith(TEXT_MAGIC):
+        return value
+    return open_bytes(base64.b64decode(value[len(TEXT_MAGIC) :])).decode("utf-8")
+
+
+def seal_json(value: Any) -> Any:
+    """A JSON value encrypted as ``{SEALED_KEY: ...}`` when encryption is on; None is
+    kept as None."""
+    if value is None or not encryption_enabled():
+        return value
+    return {SEALED_KEY: seal_text(json.dumps(value))}
+
+
+def open_json(value: Any) -> Any:
+    """``value`` decrypted if ``seal_json`` sealed it; plain values pass through."""
+    if isinstance(value, dict) and set(value) == {SEALED_KEY}:
+        return json.loads(open_text(value[SEALED_KEY]))
+    return value
+
+
+def write_bytes(path: PathLike, data: bytes) -> None:
+    """Write ``data`` to ``path``, encrypted when encryption is on."""
+    Path(path).write_bytes(seal_bytes(data))
+
+
+def write_text(path: PathLike, text: str) -> None:
+    """Write ``text`` (UTF-8) to ``path``, encrypted when encryption is on."""
+    write_bytes(path, text.encode("utf-8"))
+
+
+def read_bytes(path: PathLike) -> bytes:
+    """The contents of ``path``, decrypted if it was written encrypted."""
+    return open_bytes(Path(path).read_bytes())
+
+
+def read_text(path: PathLike) -> str:
+    return read_bytes(path).decode("utf-8")
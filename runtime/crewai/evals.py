@@ -0,0 +1,297 @@
+"""Evals: golden cases, each with the properties the pipeline's output must have.
+
+An eval case is a directory (the shipped ones are in ``tests/evals/``):
+
+    tests/evals/platform-engineer/
+      jd.md            # the job description
+      resume.md        # the candidate's résumé
+      sources.md       # optional: source material beyond the résumé
+      responses.yaml   # recorded model answers per stage, as in a scenario
+      expected.yaml    # the properties below
+
+    # expected.yaml
+    must_contain: [Kubernetes, Terraform]  # the final résumé names each (any case)
+    must_not_contain: [COBOL]
+    no_fabricated_employers: true          # default: every employer in the final résumé
+                                           # appears in the résumé or sources
+    valid_schema: true                     # default: each stage's output has the shape
+                                           # the next stage reads (see contracts)
+    status: [completed]                    # default: any status with documents
+    min_judge_score: 7                     # the judge scores every rubric at least 7
+
+Each property is one assertion; a case passes when all of them hold. A case with
+``min_judge_score`` also runs the judge (see judge), whose scores are reported. By
+default the model's answers come from ``responses.yaml`` (scenarios.canned_model), so
+a run is deterministic and needs no API key: that is what CI runs. With a live model
+the same assertions measure the prompts themselves, and a pass rate below 100% is
+expected.
+
+``hydra eval`` reports each case, then the pass rate of each assertion and of the
+whole suite.
+"""
+
+from __future__ import annotations
+
+import re
+from dataclasses import dataclass, field
+from pathlib import Path
+from types import SimpleNamespace
+from typing import Any, Callable, Dict, List, Optional
+
+import yaml
+
+from runtime.crewai.contracts import ATSResult, GapReview, TailoredDocuments
+from runtime.crewai.cv_formats import employers
+from runtime.crewai.hydra_workflow import HydraWorkflow, RunStatus, WorkflowResult
+from runtime.crewai.judge import lowest
+from runtime.crewai.scenarios import canned_model
+
+EVALS_DIR = "tests/evals"
+JD_FILE = "jd.md"
+RESUME_FILE = "resume.md"
+SOURCES_FILE = "sources.md"
+RESPONSES_FILE = "responses.yaml"
+EXPECTED_FILE = "expected.yaml"
+
+EVAL_MODEL = "eval/recorded"
+
+MUST_CONTAIN = "must_contain"
+MUST_NOT_CONTAIN = "must_not_contain"
+NO_FABRICATED_EMPLOYERS = "no_fabricated_employers"
+VALID_SCHEMA = "valid_schema"
+STATUS = "status"
+MIN_JUDGE_SCORE = "min_judge_score"
+ASSERTIONS = (
+    STATUS,
+    VALID_SCHEMA,
+    MUST_CONTAIN,
+    MUST_NOT_CONTAIN,
+    NO_FABRICATED_EMPLOYERS,
+    MIN_JUDGE_SCORE,
+)
+
+# Statuses that produced documents: the default for ``status``.
+PRODUCED = (
+    RunStatus.COMPLETED.value,
+    RunStatus.COMPLETED_WITH_AUDIT_CONCERNS.value,
+    RunStatus.AUDIT_ERROR.value,
+)
+
+
+class EvalError(ValueError):
+    """Raised for a malformed eval case."""
+
+
+@dataclass
+class EvalCase:
+    name: str
+    job_description: str
+    resume: str
+    sources: str
+    expected: Dict[str, Any]
+    responses: Optional[Dict[str, Any]] = None
+    path: Optional[Path] = None
+
+    def context(self) -> Dict[str, Any]:
+        return {
+            "job_description": self.job_description,
+            "resume": self.resume,
+            "source_documents": self.sources,
+        }
+
+
+@dataclass
+class Check:
+    """One assertion's outcome on one case."""
+
+    assertion: str
+    passed: bool
+    detail: str = ""
+
+
+@dataclass
+class EvalResult:
+    case: EvalCase
+    status: str
+    checks: List[Check] = field(default_factory=list)
+    # With ``min_judge_score``: the judge's rubric scores (see judge).
+    judge: Optional[Dict[str, Any]] = None
+
+    @property
+    def passed(self) -> bool:
+        return all(check.passed for check in self.checks)
+
+
+def _read(path: Path) -> str:
+    try:
+        return path.read_text(encoding="utf-8")
+    except OSError as err:
+        raise EvalError(f"{path.parent.name}: cannot read {path.name}: {err}") from err
+
+
+def _mapping(path: Path) -> Dict[str, Any]:
+    try:
+        raw = yaml.safe_load(_read(path)) or {}
+    except yaml.YAMLError as err:
+        raise EvalError(f"{path.parent.name}: {path.name} is not valid YAML: {err}") from err
+    if not isinstance(raw, dict):
+        raise EvalError(f"{path.parent.name}: {path.name} must be a mapping")
+    return raw
+
+
+def load_case(directory: Path) -> EvalCase:
+    """The eval case in ``directory``; EvalError if it is incomplete or malformed."""
+    directory = Path(directory)
+    resume = _read(directory / RESUME_FILE)
+    expected = _mapping(directory / EXPECTED_FILE)
+    unknown = sorted(set(expected) - set(ASSERTIONS))
+    if unknown:
+        raise EvalError(
+            f"{directory.name}: unknown assertion(s) {', '.join(unknown)} "
+            f"(one of {', '.join(ASSERTIONS)})"
+        )
+    sources = directory / SOURCES_FILE
+    responses = directory / RESPONSES_FILE
+    return EvalCase(
+        name=directory.name,
+        job_description=_read(directory / JD_FILE),
+        resume=resume,
+        sources=f"{resume}\n\n{_read(sources)}" if sources.is_file() else resume,
+        expected=expected,
+        responses=_mapping(responses) if responses.is_file() else None,
+        path=directory,
+    )
+
+
+def discover(paths: List[Path]) -> List[Path]:
+    """Case directories under ``paths``: each path is a case or a directory of them."""
+    found: List[Path] = []
+    for path in map(Path, paths):
+        if (path / EXPECTED_FILE).is_file():
+            found.append(path)
+        else:
+            found += sorted(p.parent for p in path.glob(f"*/{EXPECTED_FILE}"))
+    return found
+
+
+def _normalize(text: str) -> str:
+    return re.sub(r"\s+", " ", text).strip().lower()
+
+
+def _keywords(case: EvalCase, resume: str, key: str, wanted: bool) -> Check:
+    text = _normalize(resume)
+    wrong = [k for k in case.expected[key] if (_normalize(str(k)) in text) != wanted]
+    if not wrong:
+        return Check(key, True)
+    return Check(key, False, f"{'missing' if wanted else 'present'}: {', '.join(wrong)}")
+
+
+def _employers(case: EvalCase, resume: str) -> Check:
+    known = _normalize(case.sources)
+    invented = [name for name in employers(resume) if _normalize(name) not in known]
+    if not invented:
+        return Check(NO_FABRICATED_EMPLOYERS, True)
+    return Check(NO_FABRICATED_EMPLOYERS, False, f"not in the inputs: {', '.join(invented)}")
+
+
+def _judged(minimum: Any, judgement: Optional[Dict[str, Any]]) -> Check:
+    if lowest(judgement) is None:
+        return Check(MIN_JUDGE_SCORE, False, "the judge gave no scores")
+    below = [
+        f"{criterion} {item['score']}"
+        for criterion, item in judgement["scores"].items()
+        if item["score"] < minimum
+    ]
+    if not below:
+        return Check(MIN_JUDGE_SCORE, True)
+    return Check(MIN_JUDGE_SCORE, False, f"below {minimum}: {', '.join(below)}")
+
+
+def _classified(output: Any) -> bool:
+    review = GapReview.from_raw(output)
+    return bool(review.matches or review.adjacent or review.gaps)
+
+
+# What each stage's output must hold for the stage after it, and how to tell.
+_SHAPES: Dict[str, tuple[str, Callable[[Any], Any]]] = {
+    "gap_analysis": ("classified requirements", _classified),
+    "tailoring": ("a résumé", lambda output: TailoredDocuments.from_raw(output).resume),
+    "ats_optimization": (
+        "an optimized résumé",
+        lambda output: ATSResult.from_raw(output).optimized_resume,
+    ),
+}
+_VERDICT_KEYS = ("approval", "approved", "final_status", "overall_status")
+
+
+def _schema(result: WorkflowResult) -> Check:
+    problems = []
+    for stage, output in (result.intermediate_results or {}).items():
+        if not isinstance(output, dict):
+            problems.append(f"{stage} is not a mapping")
+        elif stage in _SHAPES and not _SHAPES[stage][1](output):
+            problems.append(f"{stage} has no {_SHAPES[stage][0]}")
+    audit = result.audit_report
+    if audit is not None:
+        nested = audit.get("audit_report")
+        report = nested if isinstance(nested, dict) else audit
+        if not any(key in report for key in _VERDICT_KEYS):
+            problems.append("the audit gives no verdict")
+    if not problems:
+        return Check(VALID_SCHEMA, True)
+    return Check(VALID_SCHEMA, False, "; ".join(problems))
+
+
+def check(case: EvalCase, result: WorkflowResult) -> List[Check]:
+    """Every assertion of ``case`` on the run ``result``."""
+    expected = case.expected
+    status = result.status.value
+    allowed = expected.get(STATUS) or PRODUCED
+    allowed = [allowed] if isinstance(allowed, str) else list(allowed)
+    detail = f" ({result.error_message})" if result.error_message else ""
+    checks = [Check(STATUS, status in allowed, "" if status in allowed else status + detail)]
+    if expected.get(VALID_SCHEMA, True):
+        checks.append(_schema(result))
+    resume = (result.final_documents or {}).get("resume") or ""
+    for key, wanted in ((MUST_CONTAIN, True), (MUST_NOT_CONTAIN, False)):
+        if expected.get(key):
+            checks.append(_keywords(case, resume, key, wanted))
+    if expected.get(NO_FABRICATED_EMPLOYERS, True):
+        checks.append(_employers(case, resume))
+    if expected.get(MIN_JUDGE_SCORE) is not None:
+        checks.append(_judged(expected[MIN_JUDGE_SCORE], result.judge))
+    return checks
+
+
+def run_case(case: EvalCase, llm: Any = None, **options: Any) -> EvalResult:
+    """Run ``case`` through the pipeline and check it: with ``llm`` if given, else on
+    its recorded responses. ``options`` (``prompt_overrides``, ``seed``) go to the
+    workflow of a live run."""
+    judge = case.expected.get(MIN_JUDGE_SCORE) is not None
+    if llm is None:
+        if case.responses is None:
+            raise EvalError(f"{case.name}: no {RESPONSES_FILE}; run it against a live model")
+        workflow = HydraWorkflow(
+            SimpleNamespace(model=EVAL_MODEL),
+            use_per_agent_models=False,
+            auto_approve=True,
+            judge=judge,
+        )
+        with canned_model(workflow, case.responses):
+            result = workflow.execute(case.context())
+    else:
+        workflow = HydraWorkflow(llm, auto_approve=True, judge=judge, **options)
+        result = workflow.execute(case.context())
+    return EvalResult(case, result.status.value, check(case, result), result.judge)
+
+
+def pass_rates(results: List[EvalResult]) -> Dict[str, tuple[int, int]]:
+    """(passed, checked) per assertion, in ``ASSERTIONS`` order, and for whole cases
+    under ``"cases"``."""
+    rates: Dict[str, tuple[int, int]] = {}
+    for assertion in ASSERTIONS:
+        outcomes = [c.passed for r in results for c in r.checks if c.assertion == assertion]
+        if outcomes:
+            rates[assertion] = (sum(outcomes), len(outcomes))
+    rates["cases"] = (sum(r.passed for r in results), len(results))
+    return rates
@@ -0,0 +1,270 @@
+"""Prompt experiments: run prompt variants for one stage over saved runs and score them.
+
+``hydra experiment --stage tailoring --variant p2=./experiments/p2 run-a run-b`` takes
+saved runs as the corpus. Each run's recorded inputs (job description, résumé,
+sources) and its stored outputs of the stages before ``--stage`` are one case (see
+replay). Every variant runs the stage once per case. A variant is a prompt directory
+laid out like ``agents/``, and the baseline is the repository's own prompts. Only that
+stage runs: no human gate, no later stage.
+
+Each output résumé is scored 0-100 by one of two scorers:
+
+- ``ats`` — offline: the ATS parse score (what a naive ATS extracts) averaged with the
+  share of the job description's skills the résumé covers (see ats_parse_check);
+- ``judge`` — the auditor reviews the résumé: an approval starts at 100, a rejection
+  at 50, and each blocking finding costs 10.
+
+The report (``report.md`` and ``results.json`` under ``<out>/experiments/<id>/``)
+gives each variant's mean, lowest and highest score, its wins (cases where it
+scored highest) and its failures, then every case's scores. Each output résumé is
+kept next to it, so a score can be checked against the text.
+"""
+
+from __future__ import annotations
+
+import json
+import statistics
+from dataclasses import dataclass, field
+from pathlib import Path
+from typing import Any, Callable, Dict, List, Optional, Tuple
+
+from runtime.crewai.artifacts import INTERMEDIATE_DIR, MANIFEST_FILE, load_checkpoint
+from runtime.crewai.ats_parse_check import parse_resume
+from runtime.crewai.contracts import ATSResult, AuditVerdict, TailoredDocuments
+from runtime.crewai.replay import upstream
+from runtime.crewai.retro_audit import blocking_issues
+from runtime.crewai.skill_taxonomy import SkillTaxonomy
+
+EXPERIMENTS_DIR = "experiments"
+REPORT_FILE = "report.md"
+RESULTS_FILE = "results.json"
+OUTPUTS_DIR = "outputs"
+
+BASELINE = "baseline"
+SCORE_ATS = "ats"
+SCORE_JUDGE = "judge"
+SCORERS = (SCORE_ATS, SCORE_JUDGE)
+
+# The stages an experiment can run, each with the stored output a case must hold.
+EXPERIMENT_STAGES = {"tailoring": "gap_analysis", "ats_optimization": "tailoring"}
+
+
+@dataclass(frozen=True)
+class Variant:
+    """A named set of prompts: ``overrides`` by the prompt path they replace."""
+
+    name: str
+    prompt_dir: Optional[str] = None
+    overrides: Dict[str, str] = field(default_factory=dict)
+
+
+def parse_variant(spec: str) -> Tuple[str, str]:
+    """``NAME=DIR`` (or a bare ``DIR``, named after it) -> (name, directory)."""
+    name, sep, directory = spec.partition("=")
+    if not sep:
+        directory = name
+        name = Path(directory.rstrip("/")).name
+    if not name or not directory:
+        raise ValueError(f"expected NAME=DIR, got {spec!r}")
+    if name == BASELINE:
+        raise ValueError(f"{BASELINE!r} is the repository's own prompts; pick another name")
+    return name, directory
+
+
+@dataclass
+class Case:
+    """One saved run as an experiment input."""
+
+    run_id: str
+    context: Dict[str, Any]
+
+
+def load_case(run_dir: Path, stage: str, read_sources: Callable[[Path], str]) -> Case:
+    """The inputs and stored upstream outputs of the saved run in ``run_dir``, with
+    its sources directory read by ``read_sources``.
+
+    Raises ValueError if the run did not record its input files, they cannot be read,
+    or it holds no output of the stage ``stage`` needs.
+    """
+    run_dir = Path(run_dir)
+    try:
+        manifest = json.loads((run_dir / MANIFEST_FILE).read_text())
+    except (OSError, ValueError):
+        raise ValueError(f"{run_dir.name}: not a saved run") from None
+    inputs = manifest.get("inputs") or {}
+    if not inputs.get("jd_path") or not inputs.get("resume_path"):
+        raise ValueError(f"{run_dir.name}: the run did not record its input files")
+    stored, state_version = load_checkpoint(run_dir)
+    if EXPERIMENT_STAGES[stage] not in stored:
+        raise ValueError(f"{run_dir.name}: no stored {EXPERIMENT_STAGES[stage]} output")
+    try:
+        context = {
+            "job_description": Path(inputs["jd_path"]).read_text(encoding="utf-8"),
+            "resume": Path(inputs["resume_path"]).read_text(encoding="utf-8"),
+            "source_documents": read_sources(Path(inputs.get("sources_path") or ".")),
+        }
+    except (OSError, ValueError) as err:
+        raise ValueError(f"{run_dir.name}: cannot read its inputs: {err}") from err
+    context["previous_results"] = upstream(stored, stage)
+    context["state_version"] = state_version
+    for key in ("company", "role"):
+        if inputs.get(key):
+            context[key] = inputs[key]
+    return Case(run_dir.name, context)
+
+
+def find_runs(out_dir: Path, stage: str) -> List[Path]:
+    """Saved runs in ``out_dir`` that stored the output ``stage`` needs, oldest first."""
+    needed = f"{EXPERIMENT_STAGES[stage]}.yaml"
+    return sorted(
+        path.parent.parent
+        for path in Path(out_dir).glob(f"*/{INTERMEDIATE_DIR}/{needed}")
+        if (path.parent.parent / MANIFEST_FILE).is_file()
+    )
+
+
+def resume_of(stage: str, output: Dict[str, Any]) -> str:
+    """The résumé a stage's output holds."""
+    if stage == "ats_optimization":
+        return ATSResult.from_raw(output).optimized_resume
+    return TailoredDocuments.from_raw(output).resume
+
+
+def ats_score(
+    resume: str, job_description: str, taxonomy: Optional[SkillTaxonomy] = None
+) -> Tuple[float, Dict[str, Any]]:
+    """0-100: the ATS parse score averaged with the JD skill coverage; and its parts."""
+    report = parse_resume(resume, job_description, taxonomy)
+    skills = report.jd_skills or {}
+    found = len(skills.get("matched") or []) + len(skills.get("synonyms") or [])
+    wanted = found + len(skills.get("missing") or [])
+    coverage = 100 * found / wanted if wanted else 100.0
+    detail = {
+        "parse_score": report.score,
+        "skill_coverage": round(coverage, 1),
+        "missing_skills": list(skills.get("missing") or []),
+    }
+    return round((report.score + coverage) / 2, 1), detail
+
+
+def judge_score(audit_report: Dict[str, Any]) -> Tuple[float, Dict[str, Any]]:
+    """0-100 from the auditor's review: 100 (approved) or 50, less 10 per blocking
+    finding; and the verdict and findings."""
+    approved = AuditVerdict.from_raw(audit_report).approved
+    blocking = blocking_issues(audit_report)
+    score = max(0.0, (100.0 if approved else 50.0) - 10 * len(blocking))
+    return score, {"approved": approved, "blocking": blocking}
+
+
+@dataclass
+class Trial:
+    """One variant's run of the stage on one case."""
+
+    run_id: str
+    variant: str
+    score: Optional[float] = None
+    detail: Dict[str, Any] = field(default_factory=dict)
+    resume: str = ""
+    error: Optional[str] = None
+
+    def to_dict(self) -> Dict[str, Any]:
+        return {
+            "run_id": self.run_id,
+            "variant": self.variant,
+            "score": self.score,
+            "detail": self.detail,
+            "error": self.error,
+        }
+
+
+def summarize(trials: List[Trial], variants: List[str]) -> List[Dict[str, Any]]:
+    """Per variant, in ``variants`` order: scores, wins and failures."""
+    best: Dict[str, float] = {}
+    for trial in trials:
+        if trial.score is not None:
+            best[trial.run_id] = max(best.get(trial.run_id, trial.score), trial.score)
+    summary = []
+    for name in variants:
+        mine = [t for t in trials if t.variant == name]
+        scores = [t.score for t in mine if t.score is not None]
+        summary.append(
+            {
+                "variant": name,
+                "cases": len(mine),
+                "mean": round(statistics.fmean(scores), 1) if scores else None,
+                "min": min(scores) if scores else None,
+                "max": max(scores) if scores else None,
+                "wins": sum(1 for t in mine if t.score is not None and t.score == best[t.run_id]),
+                "failed": sum(1 for t in mine if t.score is None),
+            }
+        )
+    return summary
+
+
+def _cell(value: Any) -> str:
+    return "–" if value is None else str(value)
+
+
+def render_report(
+    stage: str, scorer: str, variants: List[Variant], trials: List[Trial]
+) -> str:
+    """The comparison as Markdown: the summary, then every case."""
+    names = [variant.name for variant in variants]
+    lines = [
+        f"# Prompt experiment: {stage}",
+        "",
+        f"Scored by `{scorer}` (0-100) over {len({t.run_id for t in trials})} saved run(s).",
+        "",
+        "| variant | prompts | mean | min | max | wins | failed |",
+        "|---|---|---|---|---|---|---|",
+    ]
+    dirs = {variant.name: variant.prompt_dir or "(repository)" for variant in variants}
+    for row in summarize(trials, names):
+        lines.append(
+            f"| {row['variant']} | {dirs[row['variant']]} | {_cell(row['mean'])} | "
+            f"{_cell(row['min'])} | {_cell(row['max'])} | {row['wins']} | {row['failed']} |"
+        )
+    lines += ["", "## Cases", "", "| run | " + " | ".join(names) + " |"]
+    lines.append("|---|" + "---|" * len(names))
+    scores = {(t.run_id, t.variant): t for t in trials}
+    for run_id in dict.fromkeys(t.run_id for t in trials):
+        cells = []
+        for name in names:
+            trial = scores.get((run_id, name))
+            cells.append("failed" if trial is None or trial.error else _cell(trial.score))
+        lines.append(f"| {run_id} | " + " | ".join(cells) + " |")
+    failures = [t for t in trials if t.error]
+    if failures:
+        lines += ["", "## Failures", ""]
+        lines += [f"- {t.run_id} / {t.variant}: {t.error}" for t in failures]
+    return "\n".join(lines) + "\n"
+
+
+def write_experiment(
+    out_dir: Path,
+    experiment_id: str,
+    stage: str,
+    scorer: str,
+    variants: List[Variant],
+    trials: List[Trial],
+) -> Path:
+    """Write the report, the results and each output résumé; the experiment directory."""
+    directory = Path(out_dir) / EXPERIMENTS_DIR / experiment_id
+    directory.mkdir(parents=True, exist_ok=True)
+    (directory / REPORT_FILE).write_text(render_report(stage, scorer, variants, trials))
+    results = {
+        "experiment_id": experiment_id,
+        "stage": stage,
+        "scorer": scorer,
+        "variants": [{"name": v.name, "prompt_dir": v.prompt_dir} for v in variants],
+        "summary": summarize(trials, [variant.name for variant in variants]),
+        "trials": [trial.to_dict() for trial in trials],
+    }
+    (directory / RESULTS_FILE).write_text(json.dumps(results, indent=2))
+    for trial in trials:
+        if trial.resume:
+            path = directory / OUTPUTS_DIR / trial.variant / f"{trial.run_id}.md"
+            path.parent.mkdir(parents=True, exist_ok=True)
+            path.write_text(trial.resume)
+    return directory
+
@@ -0,0 +1,265 @@
+"""Provider failover: when a provider is down, run its stages somewhere else.
+
+A stage whose model call fails makes the workflow ask the ``HealthChecker`` whether
+the provider is up. The checker probes the provider's model-listing endpoint with
+the configured key: a 5xx, a timeout or a refused connection means the provider is
+down, and so does a rejected key. A 429 means it is up but busy. Results are trusted
+for ``health_ttl`` seconds and shared by every run in the process.
+
+A stage on a provider that is down moves to the first healthy provider in the
+policy's failover order that serves an equivalent model. ``EQUIVALENT_MODELS`` maps
+a model to its id on each provider. A failing stage on a provider that is up, or
+with no equivalent anywhere, takes the workflow's fallback model as before. A stage
+whose provider is already known to be down fails over before its first call, and so
+does one whose provider's circuit is open after repeated failures.
+
+The policy is the ``failover`` section of the pipeline config (see
+runtime.crewai.pipeline_config)::
+
+    failover:
+      enabled: true
+      health_ttl: 60              # seconds a health check result is trusted
+      circuit: {failures: 3, cooldown: 60}   # see runtime.crewai.circuit_breaker
+      providers:                  # where a provider's stages go, in order
+        together: [openrouter]
+      models:                     # more equivalents: model -> {provider: id}
+        meta-llama/Llama-3.3-70B-Instruct-Turbo:
+          openrouter: meta-llama/llama-3.3-70b-instruct
+
+The provider and model that served each stage, and the provider it failed over
+from, are recorded in the manifest under ``providers``.
+"""
+
+from __future__ import annotations
+
+import threading
+import time
+import urllib.error
+import urllib.request
+from dataclasses import dataclass, field
+from typing import Any, Callable, Dict, List, Mapping, Optional, Tuple
+
+from runtime.crewai.circuit_breaker import CircuitPolicy
+from runtime.crewai.model_config import resolve_api_key
+
+DEFAULT_HEALTH_TTL = 60.0
+PROBE_TIMEOUT = 10.0
+
+# Where each provider's stages go when it is down, in order of preference.
+DEFAULT_FAILOVER_ORDER: Dict[str, Tuple[str, ...]] = {
+    "together": ("openrouter",),
+    "chutes": ("together", "openrouter"),
+    "anthropic": ("openrouter",),
+    "openai": ("openrouter",),
+    "openrouter": ("together",),
+}
+
+# The same model on each provider that serves it, keyed by the names AGENT_MODELS uses.
+EQUIVALENT_MODELS: Dict[str, Dict[str, str]] = {
+    "meta-llama/Llama-4-Maverick-17B-128E-Instruct-FP8": {
+        "together": "meta-llama/Llama-4-Maverick-17B-128E-Instruct-FP8",
+        "openrouter": "meta-llama/llama-4-maverick",
+    },
+    "deepseek-ai/DeepSeek-V3": {
+        "chutes": "deepseek-ai/DeepSeek-V3",
+        "together": "deepseek-ai/DeepSeek-V3",
+        "openrouter": "deepseek/deepseek-chat",
+    },
+    "claude-sonnet-4-20250514": {
+        "anthropic": "claude-sonnet-4-20250514",
+        "openrouter": "anthropic/claude-sonnet-4",
+    },
+    "gpt-4o-mini": {"openai": "gpt-4o-mini", "openrouter": "openai/gpt-4o-mini"},
+}
+
+# An authenticated GET that lists models: cheap, and answered only when the API is up.
+HEALTH_URLS: Dict[str, str] = {
+    "together": "https://api.together.xyz/v1/models",
+    "chutes": "https://llm.chutes.ai/v1/models",
+    "anthropic": "https://api.anthropic.com/v1/models",
+    "openai": "https://api.openai.com/v1/models",
+    "openrouter": "https://openrouter.ai/api/v1/models",
+}
+
+Fetch = Callable[[urllib.request.Request, float], int]
+
+
+@dataclass(frozen=True)
+class FailoverPolicy:
+    """Whether stages fail over, where to, and how long a health check holds."""
+
+    enabled: bool = True
+    health_ttl: float = DEFAULT_HEALTH_TTL
+    providers: Mapping[str, Tuple[str, ...]] = field(
+        default_factory=lambda: dict(DEFAULT_FAILOVER_ORDER)
+    )
+    models: Mapping[str, Mapping[str, str]] = field(
+        default_factory=lambda: {name: dict(ids) for name, ids in EQUIVALENT_MODELS.items()}
+    )
+    circuit: CircuitPolicy = CircuitPolicy()
+
+    @classmethod
+    def from_dict(cls, data: Mapping[str, Any]) -> "FailoverPolicy":
+        """``{"enabled": true, "providers": {...}, "models": {...}}``; omitted keys keep
+        their defaults. Raises ValueError on unknown keys or bad values."""
+        unknown = set(data) - {"enabled", "health_ttl", "providers", "models", "circuit"}
+        if unknown:
+            raise ValueError(f"unknown failover setting(s): {', '.join(sorted(unknown))}")
+        default = cls()
+        enabled = data.get("enabled", default.enabled)
+        if not isinstance(enabled, bool):
+            raise ValueError("failover.enabled must be true or false")
+        ttl = data.get("health_ttl", default.health_ttl)
+        if isinstance(ttl, bool) or not isinstance(ttl, (int, float)) or ttl < 0:
+            raise ValueError("failover.health_ttl must be a number of seconds")
+
+        providers = dict(default.providers)
+        for provider, order in (data.get("providers") or {}).items():
+            if isinstance(order, str):
+                order = [order]
+            if not isinstance(order, list) or not all(isinstance(p, str) for p in order):
+                raise ValueError(f"failover.providers.{provider} must be a list of providers")
+            unknown = [p for p in [provider, *order] if p not in HEALTH_URLS]
+            if unknown:
+                raise ValueError(
+                    f"unknown provider(s) in failover.providers: {', '.join(unknown)} "
+                    f"(expected: {', '.join(HEALTH_URLS)})"
+                )
+            providers[provider] = tuple(order)
+
+        models = {name: dict(ids) for name, ids in default.models.items()}
+        for name, ids in (data.get("models") or {}).items():
+            if not isinstance(ids, dict) or not all(
+                p in HEALTH_URLS and isinstance(model, str) for p, model in ids.items()
+            ):
+                raise ValueError(f"failover.models.{name} must map providers to model ids")
+            models.setdefault(str(name), {}).update(ids)
+
+        circuit = data.get("circuit") or {}
+        if not isinstance(circuit, dict):
+            raise ValueError("failover.circuit must be a mapping")
+        return cls(enabled, float(ttl), providers, models, CircuitPolicy.from_dict(circuit))
+
+    def to_dict(self) -> Dict[str, Any]:
+        return {
+            "enabled": self.enabled,
+            "health_ttl": self.health_ttl,
+            "providers": {provider: list(order) for provider, order in self.providers.items()},
+            "models": {name: dict(ids) for name, ids in self.models.items()},
+            "circuit": self.circuit.to_dict(),
+        }
+
+    def equivalent(self, model: str, provider: str) -> Optional[str]:
+        """``model``'s id on ``provider``; ``model`` may carry a LiteLLM route prefix."""
+        for name, ids in self.models.items():
+            names = [name, *ids.values()]
+            if any(model == known or model.endswith(f"/{known}") for known in names):
+                return ids.get(provider)
+        return None
+
+    def candidates(self, provider: str, model: str) -> List[Tuple[str, str]]:
+        """``(provider, model)`` to fail over to from ``provider``, in policy order."""
+        found = []
+        for target in self.providers.get(provider, ()):
+            equivalent = self.equivalent(model, target)
+            if equivalent:
+                found.append((target, equivalent))
+        return found
+
+
+@dataclass(frozen=True)
+class ProviderStatus:
+    """One health check: whether ``provider`` answered, and how."""
+
+    provider: str
+    healthy: bool
+    detail: str
+    latency_ms: Optional[int] = None
+    checked_at: float = 0.0
+
+    def to_dict(self) -> Dict[str, Any]:
+        return {
+            "provider": self.provider,
+            "healthy": self.healthy,
+            "detail": self.detail,
+            "latency_ms": self.latency_ms,
+        }
+
+
+def _fetch(request: urllib.request.Request, timeout: float) -> int:
+    with urllib.request.urlopen(request, timeout=timeout) as response:
+        return response.status
+
+
+def _probe_request(provider: str, api_key: str) -> urllib.request.Request:
+    if provider == "anthropic":
+        headers = {"x-api-key": api_key, "anthropic-version": "2023-06-01"}
+    else:
+        headers = {"Authorization": f"Bearer {api_key}"}
+    return urllib.request.Request(HEALTH_URLS[provider], headers=headers, method="GET")
+
+
+class HealthChecker:
+    """Probes providers and remembers the answers for a while; safe across threads."""
+
+    def __init__(self, fetch: Fetch = _fetch, clock: Callable[[], float] = time.monotonic):
+        self._fetch = fetch
+        self._clock = clock
+        self._lock = threading.Lock()
+        self._statuses: Dict[str, ProviderStatus] = {}
+
+    def known(self, provider: str, max_age: float = DEFAULT_HEALTH_TTL) -> Optional[ProviderStatus]:
+        """The last check of ``provider`` if it is younger than ``max_age``, without probing."""
+        with self._lock:
+            status = self._statuses.get(provider)
+        if status is None or self._clock() - status.checked_at > max_age:
+            return None
+        return status
+
+    def check(self, provider: str, max_age: float = DEFAULT_HEALTH_TTL) -> ProviderStatus:
+        """Whether ``provider`` is up: the remembered answer, or a fresh probe."""
+        status = self.known(provider, max_age)
+        if status is None:
+            status = self._probe(provider)
+            with self._lock:
+                self._statuses[provider] = status
+        return status
+
+    def _probe(self, provider: str) -> ProviderStatus:
+        started = self._clock()
+
+        def _status(healthy: bool, detail: str) -> ProviderStatus:
+            now = self._clock()
+            return ProviderStatus(provider, healthy, detail, int((now - started) * 1000), now)
+
+        if provider not in HEALTH_URLS:
+            return _status(True, "no health check for this provider")
+        api_key = resolve_api_key(provider)
+        if not api_key:
+            return _status(False, "no API key")
+        try:
+            code = self._fetch(_probe_request(provider, api_key), PROBE_TIMEOUT)
+        except urllib.error.HTTPError as e:
+            code = e.code
+        except (urllib.error.URLError, OSError) as e:  # refused, DNS, timeout
+            return _status(False, f"unreachable: {getattr(e, 'reason', e)}")
+        if code == 429:
+            return _status(True, "rate limited")
+        if code in (401, 403):
+            return _status(False, f"API key rejected ({code})")
+        if code >= 500:
+            return _status(False, f"server error ({code})")
+        return _status(True, "ok")
+
+
+_shared: Optional[HealthChecker] = None
+_shared_lock = threading.Lock()
+
+
+def shared_health() -> HealthChecker:
+    """The process-wide checker, so concurrent runs share what they learn."""
+    global _shared
+    with _shared_lock:
+        if _shared is None:
+            _shared = HealthChecker()
+        return _shared
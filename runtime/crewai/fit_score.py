@@ -0,0 +1,371 @@
+"""Fit score: a go / no-go call on a job before the greenlight.
+
+The Gap Analyzer classifies every requirement of the job description against the
+résumé. This module turns that into a score Python computes, not the model (as with
+the executive decision, the model supplies the findings and Python owns the gate, see
+contracts). The score has three parts:
+
+- must-have coverage: the required requirements (``explicit_hard``) the résumé covers.
+  A direct match counts fully and adjacent experience half. Nice-to-haves
+  (``explicit_soft``) count for less;
+- seniority: the level the job title asks for (junior … vice president) and the years
+  of experience the posting asks for, against the résumé's latest title and the years
+  its dates span;
+- location and visa flags: "no visa sponsorship", "must be authorized to work",
+  on-site or hybrid work, relocation, a place to live in, security clearance. They do
+  not lower the score, since only the candidate knows whether they apply. A run with
+  one is at best "go with caution".
+
+A part with nothing to go on is left out and the others weigh more; without any
+classified requirement there is no score, and the analyzer's own is shown. A blocker (a
+requirement the analyzer says framing cannot address) caps the score below the pass
+line. The score maps to the executive decision's recommendation (see
+contracts.recommendation_for_fit_score). The recommendation maps to a call: GO,
+GO WITH CAUTION or NO-GO. The terminal, the dashboard and ``hydra serve`` show the
+call with one line of rationale per part. ``run.json`` records the score, the call and
+the flags under ``fit``.
+"""
+
+from __future__ import annotations
+
+import re
+from dataclasses import asdict, dataclass, field
+from datetime import date
+from typing import Any, Dict, List, Optional, Tuple
+
+from runtime.crewai.contracts import recommendation_for_fit_score
+from runtime.crewai.resume_model import parse_resume
+
+GO = "GO"
+GO_WITH_CAUTION = "GO WITH CAUTION"
+NO_GO = "NO-GO"
+DECISION_MARKS = {GO: "🟢", GO_WITH_CAUTION: "🟡", NO_GO: "🔴"}
+
+# How much each part weighs; parts with nothing to go on are left out.
+MUST_HAVE_WEIGHT = 0.6
+NICE_TO_HAVE_WEIGHT = 0.15
+SENIORITY_WEIGHT = 0.25
+# Credit a requirement gets for each classification.
+CREDIT = {"direct_match": 1.0, "adjacent": 0.5, "adjacent_experience": 0.5}
+# A blocker keeps the score below the PROCEED_WITH_CAUTION line.
+BLOCKER_CAP = 49.0
+# More than this many years asked for is read as a typo or a date.
+MAX_YEARS = 25
+
+# Title words by level; a title without one is mid-level.
+_LEVELS = (
+    (0, ("intern", "internship", "trainee")),
+    (1, ("junior", "jr", "graduate", "entry", "associate")),
+    (3, ("senior", "sr")),
+    (4, ("staff", "lead")),
+    (5, ("principal", "distinguished", "architect")),
+    (6, ("director", "head")),
+    (7, ("vp", "vice", "chief", "cto", "cio")),
+)
+MID_LEVEL = 2
+LEVEL_NAMES = {
+    0: "intern",
+    1: "junior",
+    2: "mid-level",
+    3: "senior",
+    4: "staff/lead",
+    5: "principal",
+    6: "director",
+    7: "executive",
+}
+
+# Location and visa flags.
+NO_SPONSORSHIP = "no visa sponsorship"
+WORK_AUTHORIZATION = "work authorization required"
+CLEARANCE = "security clearance"
+ON_SITE = "on-site or hybrid"
+RELOCATION = "relocation"
+MUST_LIVE_NEARBY = "must live nearby"
+
+_YEARS_RE = re.compile(
+    r"\b(?P<years>\d{1,2})\s*\+?\s*(?:-\s*\d{1,2}\s*)?(?:years?|yrs?)\b", re.IGNORECASE
+)
+_YEAR_RE = re.compile(r"\b(?:19|20)\d{2}\b")
+_PRESENT_RE = re.compile(r"\b(?:present|current|now|today)\b", re.IGNORECASE)
+_EXPERIENCE_RE = re.compile(r"experience|employment|work history|career", re.IGNORECASE)
+_FLAGS = (
+    (
+        NO_SPONSORSHIP,
+        re.compile(
+            r"\b(?:no|not|unable to|cannot|can't|won't|will not|does not|do not)\s+"
+            r"(?:\w+\s+){0,3}sponsor",
+            re.IGNORECASE,
+        ),
+    ),
+    (
+        WORK_AUTHORIZATION,
+        re.compile(r"authori[sz]ed to work|right to work|work authori[sz]ation", re.IGNORECASE),
+    ),
+    (
+        CLEARANCE,
+        re.compile(r"security clearance|clearance required|\bts/sci\b", re.IGNORECASE),
+    ),
+    (ON_SITE, re.compile(r"\b(?:on[- ]?site|in[- ]office|hybrid)\b", re.IGNORECASE)),
+    (RELOCATION, re.compile(r"\brelocat", re.IGNORECASE)),
+    (
+        MUST_LIVE_NEARBY,
+        re.compile(
+            r"\bmust\s+(?:be\s+)?(?:based|live|reside|located|residing)\s+(?:in|within|near)",
+            re.IGNORECASE,
+        ),
+    ),
+)
+_SENTENCE_RE = re.compile(r"[^.!?\n]*")
+MAX_QUOTE = 80
+
+
+@dataclass
+class FitAssessment:
+    """The score, the call and why; each part is 0-1, or None when unknown."""
+
+    score: Optional[float] = None
+    recommendation: Optional[str] = None
+    decision: Optional[str] = None
+    rationale: List[str] = field(default_factory=list)
+    flags: List[str] = field(default_factory=list)
+    must_have: Optional[float] = None
+    nice_to_have: Optional[float] = None
+    seniority: Optional[float] = None
+    blockers: int = 0
+    model_score: Optional[float] = None  # the analyzer's own estimate
+
+    def to_dict(self) -> Dict[str, Any]:
+        return asdict(self)
+
+    @classmethod
+    def from_dict(cls, data: Dict[str, Any]) -> "FitAssessment":
+        names = cls.__dataclass_fields__
+        return cls(**{key: value for key, value in data.items() if key in names})
+
+    def to_manifest(self) -> Dict[str, Any]:
+        """For run.json: no rationale, which quotes the job description."""
+        return {
+            "score": self.score,
+            "recommendation": self.recommendation,
+            "decision": self.decision,
+            "flags": self.flags,
+            "blockers": self.blockers,
+        }
+
+
+def greenlight_question(decision: Optional[str]) -> str:
+    """The greenlight's question; after a NO-GO it asks whether to go on anyway."""
+    if decision == NO_GO:
+        return "The fit is low (NO-GO). Proceed anyway?"
+    return "Proceed with these findings?"
+
+
+def decision_for(recommendation: str, flags: List[str]) -> str:
+    """GO, GO WITH CAUTION or NO-GO; a flag makes a GO one with caution."""
+    if recommendation == "PASS":
+        return NO_GO
+    if recommendation == "PROCEED_WITH_CAUTION" or flags:
+        return GO_WITH_CAUTION
+    return GO
+
+
+def _analysis(gap_result: Any) -> Dict[str, Any]:
+    if not isinstance(gap_result, dict):
+        return {}
+    analysis = gap_result.get("gap_analysis")
+    return analysis if isinstance(analysis, dict) else gap_result
+
+
+def _requirements(analysis: Dict[str, Any]) -> List[Tuple[str, str]]:
+    """(type, classification) of each requirement; flat lists count as must-haves."""
+    requirements = analysis.get("requirements")
+    if isinstance(requirements, list) and requirements:
+        return [
+            (str(req.get("type") or ""), str(req.get("classification") or ""))
+            for req in requirements
+            if isinstance(req, dict)
+        ]
+    found = []
+    for key, classification in (
+        ("matches", "direct_match"),
+        ("adjacent_skills", "adjacent"),
+        ("gaps", "gap"),
+    ):
+        items = analysis.get(key)
+        found += [("explicit_hard", classification)] * len(items if isinstance(items, list) else [])
+    return found
+
+
+def _coverage(requirements: List[Tuple[str, str]]) -> Tuple[Optional[float], str]:
+    if not requirements:
+        return None, ""
+    classes = [classification for _, classification in requirements]
+    covered = sum(CREDIT.get(c, 0.0) for c in classes)
+    direct = classes.count("direct_match")
+    adjacent = len(classes) - direct - sum(c not in CREDIT for c in classes)
+    detail = f"{covered:g} of {len(classes)} covered ({direct} direct, {adjacent} adjacent)"
+    return covered / len(classes), detail
+
+
+def level_of(title: str) -> Optional[int]:
+    """The level a title's words name, or None when it names none."""
+    words = set(re.findall(r"[a-z]+", (title or "").lower()))
+    found = [level for level, names in _LEVELS if words & set(names)]
+    return max(found) if found else None
+
+
+def required_years(job_description: str) -> Optional[int]:
+    """The most years of experience the posting asks for, if it asks."""
+    years = [int(m.group("years")) for m in _YEARS_RE.finditer(job_description or "")]
+    years = [y for y in years if 0 < y <= MAX_YEARS]
+    return max(years) if years else None
+
+
+def _experience_text(resume: str) -> Tuple[str, str]:
+    """The résumé's latest role heading and its experience section's text."""
+    document = parse_resume(resume or "")
+    sections = [s for s in document.sections if _EXPERIENCE_RE.search(s.title)]
+    sections = sections or document.sections
+    headings = [e.heading for s in sections for e in s.entries if e.heading]
+    text = "\n".join(
+        "\n".join([e.heading, *(getattr(line, "text", line) for line in e.lines)])
+        for s in sections
+        for e in s.entries
+    )
+    return (headings[0] if headings else ""), text
+
+
+def resume_years(text: str, today: Optional[date] = None) -> Optional[int]:
+    """Years from the earliest year a résumé names to its latest (or to today)."""
+    years = [int(y) for y in _YEAR_RE.findall(text or "")]
+    if not years:
+        return None
+    latest = max(years)
+    if _PRESENT_RE.search(text):
+        latest = max(latest, (today or date.today()).year)
+    return latest - min(years)
+
+
+def _seniority(
+    role: str, job_description: str, resume: str, today: Optional[date]
+) -> Tuple[Optional[float], str]:
+    wanted_level = level_of(role)
+    wanted_years = required_years(job_description)
+    if wanted_level is None and wanted_years is None:
+        return None, ""
+    heading, text = _experience_text(resume)
+    own_level = level_of(heading)
+    own_years = resume_years(text, today)
+    parts, wanted, own = [], [], []
+    if wanted_level is not None:
+        level = MID_LEVEL if own_level is None else own_level
+        gap = level - wanted_level
+        # One level above is a match; further above is overqualified, worth a look.
+        parts.append(1.0 if gap in (0, 1) else 0.5 if gap == -1 or gap > 1 else 0.0)
+        wanted.append(LEVEL_NAMES[wanted_level])
+        own.append(LEVEL_NAMES[level])
+    if wanted_years is not None:
+        wanted.append(f"{wanted_years}+ years")
+        if own_years is not None:
+            short = wanted_years - own_years
+            parts.append(1.0 if short <= 0 else 0.5 if short <= 2 else 0.0)
+            own.append(f"about {own_years} years")
+    if not parts:
+        return None, ""
+    return sum(parts) / len(parts), f"job asks {', '.join(wanted)}; résumé shows {', '.join(own)}"
+
+
+def location_flags(job_description: str) -> List[Tuple[str, str]]:
+    """(flag, the sentence that raised it) for each location or visa condition."""
+    found = []
+    for label, pattern in _FLAGS:
+        match = pattern.search(job_description or "")
+        if not match:
+            continue
+        found.append((label, sentence_at(job_description, match.start())))
+    return found
+
+
+def sentence_at(text: str, index: int) -> str:
+    """The sentence of ``text`` around ``index``, shortened to ``MAX_QUOTE``."""
+    start = max(text.rfind(end, 0, index) for end in ".!?\n") + 1
+    sentence = _SENTENCE_RE.match(text, start).group(0).strip(" -*•")
+    if len(sentence) > MAX_QUOTE:
+        sentence = sentence[: MAX_QUOTE - 1].rstrip() + "…"
+    return sentence
+
+
+def _model_score(analysis: Dict[str, Any], gap_result: Dict[str, Any]) -> Optional[float]:
+    summary = analysis.get("summary")
+    summary = summary if isinstance(summary, dict) else {}
+    for value in (analysis.get("fit_score"), summary.get("fit_score"), gap_result.get("fit_score")):
+        try:
+            return float(value)
+        except (TypeError, ValueError):
+            continue
+    return None
+
+
+def assess_fit(
+    gap_result: Any,
+    job_description: str,
+    resume: str,
+    role: Optional[str] = None,
+    today: Optional[date] = None,
+) -> FitAssessment:
+    """Score the Gap Analyzer's findings; ``role`` defaults to the one it names, then
+    to the job description's first line."""
+    analysis = _analysis(gap_result)
+    meta = analysis.get("meta") if isinstance(analysis.get("meta"), dict) else {}
+    role = role or meta.get("role") or next(
+        (line.strip("# ") for line in (job_description or "").splitlines() if line.strip()), ""
+    )
+    requirements = _requirements(analysis)
+    blockers = sum(classification == "blocker" for _, classification in requirements)
+    must = [r for r in requirements if r[0] != "explicit_soft"]
+    nice = [r for r in requirements if r[0] == "explicit_soft"]
+    must_have, must_detail = _coverage(must)
+    nice_to_have, nice_detail = _coverage(nice)
+    seniority, seniority_detail = _seniority(str(role), job_description, resume, today)
+    flags = location_flags(job_description)
+
+    assessment = FitAssessment(
+        must_have=must_have,
+        nice_to_have=nice_to_have,
+        seniority=seniority,
+        blockers=blockers,
+        flags=[label for label, _ in flags],
+        model_score=_model_score(analysis, gap_result if isinstance(gap_result, dict) else {}),
+    )
+    weighted = [
+        (weight, part)
+        for weight, part in (
+            (MUST_HAVE_WEIGHT, must_have),
+            (NICE_TO_HAVE_WEIGHT, nice_to_have),
+            (SENIORITY_WEIGHT, seniority),
+        )
+        if part is not None
+    ]
+    if must_have is None and nice_to_have is None:
+        return assessment  # seniority alone says too little
+    score = 100 * sum(w * p for w, p in weighted) / sum(w for w, _ in weighted)
+    if blockers:
+        score = min(score, BLOCKER_CAP)
+    assessment.score = round(score)
+    assessment.recommendation = recommendation_for_fit_score(assessment.score)
+    assessment.decision = decision_for(assessment.recommendation, assessment.flags)
+
+    for label, detail in (
+        ("Must-haves", must_detail),
+        ("Nice-to-haves", nice_detail),
+        ("Seniority", seniority_detail),
+    ):
+        if detail:
+            assessment.rationale.append(f"{label}: {detail}")
+    if blockers:
+        assessment.rationale.append(f"Blockers: {blockers} (score capped at {BLOCKER_CAP:g})")
+    for label, sentence in flags:
+        assessment.rationale.append(f"Flag: {label} — “{sentence}”")
+    if assessment.model_score is not None:
+        assessment.rationale.append(f"Analyzer's own estimate: {assessment.model_score:g}")
+    return assessment
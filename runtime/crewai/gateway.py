@@ -0,0 +1,233 @@
+"""Org-internal LLM gateways: one configurable client for corporate LLM proxies.
+
+Some users can only reach models through their employer's proxy — a custom URL,
+its own auth header, and often its own request and response envelope that neither
+CrewAI nor LiteLLM understands. ``HYDRA_GATEWAY_CONFIG`` points at a small YAML (or
+JSON) mapping that describes the gateway::
+
+    url: https://llm.corp.example/v1/generate
+    model: claude-sonnet-4          # default model; `gateway:<model>` picks another
+    auth:
+      scheme: header                # bearer (default), header, or none
+      header: X-Api-Key             # header name for scheme `header`
+      token_env: CORP_LLM_TOKEN     # env var holding the secret (never the file)
+    headers: {X-Team: hiring}       # extra static headers
+    request:                        # field names in the request body
+      model: model
+      messages: messages
+      temperature: temperature
+      seed: seed                    # sent only by --deterministic runs
+      extra: {stream: false}        # merged into every body
+    response:                       # dotted paths into the response body
+      content: choices.0.message.content
+      prompt_tokens: usage.prompt_tokens
+      completion_tokens: usage.completion_tokens
+      error: error.message
+    timeout: 60
+
+Every key but ``url`` is optional; the defaults are the OpenAI chat shape. The
+gateway is a provider like any other: ``gateway:<model>`` works wherever a
+``provider:model`` spec does, and when configured it is the fallback for every agent
+that has no direct provider key. ``GatewayLLM.complete`` returns the reply in the
+LiteLLM envelope, so callers read it exactly as they read ``litellm.completion``.
+"""
+
+from __future__ import annotations
+
+import json
+import os
+import urllib.error
+import urllib.request
+from dataclasses import dataclass, field
+from pathlib import Path
+from typing import Any, Dict, List, Optional
+
+import yaml
+
+GATEWAY_PROVIDER = "gateway"
+GATEWAY_CONFIG_ENV = "HYDRA_GATEWAY_CONFIG"
+
+AUTH_BEARER = "bearer"
+AUTH_HEADER = "header"
+AUTH_NONE = "none"
+AUTH_SCHEMES = (AUTH_BEARER, AUTH_HEADER, AUTH_NONE)
+
+DEFAULT_REQUEST = {
+    "model": "model",
+    "messages": "messages",
+    "temperature": "temperature",
+    "seed": "seed",
+}
+DEFAULT_RESPONSE = {
+    "content": "choices.0.message.content",
+    "prompt_tokens": "usage.prompt_tokens",
+    "completion_tokens": "usage.completion_tokens",
+    "error": "error.message",
+}
+DEFAULT_TIMEOUT = 60.0
+
+
+class GatewayError(Exception):
+    """Raised when the gateway is misconfigured or a call through it fails."""
+
+    pass
+
+
+def extract(payload: Any, path: Optional[str]) -> Any:
+    """Value at a dotted ``path`` (``choices.0.message.content``), or None."""
+    if not path:
+        return None
+    value = payload
+    for part in path.split("."):
+        if isinstance(value, dict):
+            value = value.get(part)
+        elif isinstance(value, list) and part.isdigit() and int(part) < len(value):
+            value = value[int(part)]
+        else:
+            return None
+    return value
+
+
+@dataclass
+class GatewayConfig:
+    """How to call one gateway; see the module docstring for the file format."""
+
+    url: str
+    model: Optional[str] = None
+    auth_scheme: str = AUTH_BEARER
+    auth_header: str = "Authorization"
+    token_env: Optional[str] = None
+    headers: Dict[str, str] = field(default_factory=dict)
+    request: Dict[str, str] = field(default_factory=lambda: dict(DEFAULT_REQUEST))
+    request_extra: Dict[str, Any] = field(default_factory=dict)
+    response: Dict[str, str] = field(default_factory=lambda: dict(DEFAULT_RESPONSE))
+    timeout: float = DEFAULT_TIMEOUT
+
+    @classmethod
+    def from_dict(cls, data: Dict[str, Any]) -> "GatewayConfig":
+        if not isinstance(data, dict) or not data.get("url"):
+            raise GatewayError("Gateway config needs a `url`")
+        auth = data.get("auth") or {}
+        scheme = str(auth.get("scheme", AUTH_BEARER)).lower()
+        if scheme not in AUTH_SCHEMES:
+            raise GatewayError(
+                f"Unknown gateway auth scheme '{scheme}' (one of {', '.join(AUTH_SCHEMES)})"
+            )
+        if scheme == AUTH_HEADER and not auth.get("header"):
+            raise GatewayError("Gateway auth scheme `header` needs `auth.header`")
+        if scheme != AUTH_NONE and not auth.get("token_env"):
+            raise GatewayError(f"Gateway auth scheme `{scheme}` needs `auth.token_env`")
+        request = dict(data.get("request") or {})
+        return cls(
+            url=str(data["url"]),
+            model=data.get("model"),
+            auth_scheme=scheme,
+            auth_header=auth.get("header") or "Authorization",
+            token_env=auth.get("token_env"),
+            headers={str(k): str(v) for k, v in (data.get("headers") or {}).items()},
+            request={**DEFAULT_REQUEST, **{k: v for k, v in request.items() if k != "extra"}},
+            request_extra=dict(request.get("extra") or {}),
+            response={**DEFAULT_RESPONSE, **(data.get("response") or {})},
+            timeout=float(data.get("timeout", DEFAULT_TIMEOUT)),
+        )
+
+    @classmethod
+    def load(cls, path: Path) -> "GatewayConfig":
+        try:
+            data = yaml.safe_load(Path(path).read_text())
+        except (OSError, yaml.YAMLError) as e:
+            raise GatewayError(f"Cannot read gateway config {path}: {e}") from e
+        return cls.from_dict(data or {})
+
+    def auth_headers(self) -> Dict[str, str]:
+        """The auth header, with the secret read from ``token_env`` at call time."""
+        if self.auth_scheme == AUTH_NONE:
+            return {}
+        token = os.environ.get(self.token_env or "")
+        if not token:
+            raise GatewayError(f"{self.token_env} not set (gateway auth token)")
+        if self.auth_scheme == AUTH_BEARER:
+            return {self.auth_header: f"Bearer {token}"}
+        return {self.auth_header: token}
+
+
+def gateway_config_from_env() -> Optional[GatewayConfig]:
+    """The gateway named by ``HYDRA_GATEWAY_CONFIG``, or None when it is unset."""
+    path = os.environ.get(GATEWAY_CONFIG_ENV)
+    return GatewayConfig.load(Path(path).expanduser()) if path else None
+
+
+class GatewayLLM:
+    """An LLM reached through a gateway; stands in for ``crewai.LLM`` in the agents."""
+
+    def __init__(self, config: GatewayConfig, model: Optional[str], temperature: float = 0.5):
+        model = model or config.model
+        if not model:
+            raise GatewayError("No gateway model: set `model` in the config or pass one")
+        self.config = config
+        self.model = model
+        self.temperature = temperature
+        # Set by --deterministic (see runtime.crewai.determinism); None sends no seed.
+        self.seed: Optional[int] = None
+        self.timeout = config.timeout
+
+    def complete(
+        self, messages: List[Dict[str, Any]], temperature: Optional[float] = None
+    ) -> Dict[str, Any]:
+        """Send ``messages``; the reply in the LiteLLM envelope (choices + usage)."""
+        names = self.config.request
+        body = dict(self.config.request_extra)
+        body[names["model"]] = self.model
+        body[names["messages"]] = messages
+        if names.get("temperature"):
+            body[names["temperature"]] = self.temperature if temperature is None else temperature
+        if names.get("seed") and self.seed is not None:
+            body[names["seed"]] = self.seed
+        request = urllib.request.Request(
+            self.config.url,
+            data=json.dumps(body).encode("utf-8"),
+            headers={
+                "Content-Type": "application/json",
+                **self.config.headers,
+                **self.config.auth_headers(),
+            },
+        )
+        try:
+            with urllib.request.urlopen(request, timeout=self.timeout) as response:
+                payload = json.loads(response.read().decode("utf-8"))
+        except urllib.error.HTTPError as e:
+            raise GatewayError(f"Gateway returned HTTP {e.code}: {_error_detail(e)}") from e
+        except Exception as e:
+            raise GatewayError(f"Gateway request failed: {e}") from e
+
+        mapping = self.config.response
+        content = extract(payload, mapping.get("content"))
+        if not isinstance(content, str):
+            error = extract(payload, mapping.get("error"))
+            raise GatewayError(
+                f"Gateway reply has no text at `{mapping.get('content')}`"
+                + (f": {error}" if error else "")
+            )
+        return {
+            "choices": [{"message": {"role": "assistant", "content": content}}],
+            "usage": {
+                "prompt_tokens": extract(payload, mapping.get("prompt_tokens")) or 0,
+                "completion_tokens": extract(payload, mapping.get("completion_tokens")) or 0,
+            },
+            "model": self.model,
+        }
+
+
+def _error_detail(error: urllib.error.HTTPError) -> str:
+    try:
+        return error.read().decode("utf-8", errors="replace")[:200]
+    except Exception:
+        return str(error.reason or "")
+
+
+def get_gateway_llm(model: Optional[str] = None, temperature: float = 0.5) -> GatewayLLM:
+    """A ``GatewayLLM`` for the configured gateway; GatewayError when there is none."""
+    config = gateway_config_from_env()
+    if config is None:
+        raise GatewayError(f"{GATEWAY_CONFIG_ENV} not set (no LLM gateway configured)")
+    return GatewayLLM(config, model, temperature)
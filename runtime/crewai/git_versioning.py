@@ -0,0 +1,144 @@
+"""Git-backed run history: a commit for every stage, in a repository in the output directory.
+
+With ``--git`` the CLI keeps ``--out`` as a git repository — an existing one when the
+directory is already a repository's root, a new one otherwise — and commits the run's
+directory as the run goes:
+
+- after each stage, its output (``intermediate/<stage>.yaml``), with a message naming
+  the stage, the models its calls went to and the tokens they used;
+- after the run, everything the run wrote, with its final status.
+
+``git log -- <run_id>`` is then the run's history, and ``git diff`` between two
+tailoring or audit revisions — in the same run or in two runs for the same job — shows
+what a revision cycle changed. Commits only ever take the run's own directory, so runs
+sharing the repository do not sweep up each other's files.
+
+Token usage is read from the workflow's usage ledger: the calls made since the
+previous commit. Stages that run in parallel (quick apply) may share a commit's count;
+a stage served from the stage cache made no calls and says so.
+"""
+
+from __future__ import annotations
+
+import logging
+import shutil
+import subprocess
+import threading
+from pathlib import Path
+from typing import Any, Dict, List, Optional
+
+from runtime.crewai.artifacts import write_stage_output
+from runtime.crewai.run_control import CONTROL_FILE, LIVE_FILE
+
+GIT_TIMEOUT_SECONDS = 30
+# Markers of a run in flight (see runtime.crewai.run_control), not part of its history.
+UNVERSIONED = (LIVE_FILE, CONTROL_FILE)
+# Used only when git has no identity configured, so commits never fail for want of one.
+FALLBACK_IDENTITY = ("-c", "user.name=Hydra", "-c", "user.email=hydra@localhost")
+
+logger = logging.getLogger(__name__)
+
+
+class GitVersioningError(Exception):
+    """git is missing, or a git command failed."""
+
+
+class GitVersioning:
+    """Commits a run's directory under ``out_dir`` as its stages complete.
+
+    Register ``commit_stage`` as a workflow stage listener and call ``commit_run``
+    once the run's artifacts are written.
+    """
+
+    def __init__(self, out_dir: Path, run_id: str, workflow: Any = None):
+        self.out_dir = Path(out_dir)
+        self.run_id = run_id
+        self.workflow = workflow
+        self.commits: List[str] = []
+        self._identity: tuple = ()
+        self._seen_calls = 0
+        self._lock = threading.Lock()
+
+    @property
+    def run_dir(self) -> Path:
+        return self.out_dir / self.run_id
+
+    def _git(self, *args: str, check: bool = True) -> subprocess.CompletedProcess:
+        try:
+            completed = subprocess.run(
+                ["git", *self._identity, *args],
+                cwd=self.out_dir,
+                capture_output=True,
+                text=True,
+                timeout=GIT_TIMEOUT_SECONDS,
+            )
+        except subprocess.TimeoutExpired:
+            raise GitVersioningError(
+                f"git {args[0]} timed out after {GIT_TIMEOUT_SECONDS}s"
+            ) from None
+        if check and completed.returncode != 0:
+            detail = (completed.stderr or completed.stdout).strip().splitlines()
+            raise GitVersioningError(f"git {args[0]} failed: {' / '.join(detail[-3:])}")
+        return completed
+
+    def open(self) -> "GitVersioning":
+        """Attach to the repository at ``out_dir``, initializing one if there is none."""
+        if shutil.which("git") is None:
+            raise GitVersioningError("git is not installed (needed for --git)")
+        self.out_dir.mkdir(parents=True, exist_ok=True)
+        if not (self.out_dir / ".git").exists():
+            self._git("init", "--quiet")
+            logger.info("Initialized a git repository in %s", self.out_dir)
+        if not self._git("config", "user.email", check=False).stdout.strip():
+            self._identity = FALLBACK_IDENTITY
+        return self
+
+    def _calls_since_last_commit(self) -> list:
+        ledger = getattr(self.workflow, "usage_ledger", None)
+        calls = list(getattr(ledger, "calls", None) or [])
+        if len(calls) < self._seen_calls:  # a new run started a new ledger
+            self._seen_calls = 0
+        fresh, self._seen_calls = calls[self._seen_calls :], len(calls)
+        return fresh
+
+    def _commit(self, subject: str, body: str = "") -> Optional[str]:
+        """Commit the run's directory; the new commit's hash, or None if nothing changed."""
+        path = self.run_id
+        excluded = [f":(exclude){path}/{name}" for name in UNVERSIONED]
+        self._git("add", "--all", "--", path, *excluded)
+        if self._git("diff", "--cached", "--quiet", "--", path, check=False).returncode == 0:
+            return None
+        message = f"{subject}\n\n{body}" if body else subject
+        self._git("commit", "--quiet", "--message", message, "--", path)
+        commit = self._git("rev-parse", "HEAD").stdout.strip()
+        self.commits.append(commit)
+        return commit
+
+    def commit_stage(self, stage: str, output: Dict[str, Any]) -> Optional[str]:
+        """Write ``output`` into the run directory and commit it (a stage listener)."""
+        with self._lock:
+            write_stage_output(self.run_dir, stage, output)
+            subject, body = stage_message(self.run_id, stage, self._calls_since_last_commit())
+            return self._commit(subject, body)
+
+    def commit_run(self, status: str) -> Optional[str]:
+        """Commit everything the finished run wrote, with its final status."""
+        with self._lock:
+            return self._commit(f"[{self.run_id}] run {status}")
+
+
+def stage_message(run_id: str, stage: str, calls: list) -> tuple[str, str]:
+    """Commit subject and body for a stage: its models and token usage."""
+    if not calls:
+        return f"[{run_id}] {stage}: no model calls (served from the stage cache)", ""
+    models = sorted({call.model for call in calls})
+    prompt = sum(call.prompt_tokens for call in calls)
+    completion = sum(call.completion_tokens for call in calls)
+    cached = sum(call.cache_read_tokens for call in calls)
+    subject = f"[{run_id}] {stage}: {', '.join(models)}"
+    body = [
+        f"Model calls: {len(calls)}",
+        f"Prompt tokens: {prompt}" + (f" ({cached} read from cache)" if cached else ""),
+        f"Completion tokens: {completion}",
+    ]
+    return subject, "\n".join(body)
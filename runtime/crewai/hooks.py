@@ -0,0 +1,206 @@
+"""Stage hooks: your own commands before and after a stage, without changing the engine.
+
+Hooks are set in the pipeline config (see runtime.crewai.pipeline_config)::
+
+    hooks:
+      tailoring:
+        pre: ./check-inputs.sh               # a shell command, or a list of them
+        post:
+          - ./lint-resume.sh
+          - run: ./upload.sh
+            timeout: 30                      # seconds; 60 by default
+            on_failure: warn                 # fail (the default) or warn
+      "*":                                   # every stage
+        post: ./log-stage.sh
+
+A hook gets one JSON object on stdin: ``{"hook": "pre" | "post", "stage": ...,
+"inputs": {...}}`` and, for a post hook, the stage's ``"output"``. It runs through the
+shell from the current directory, with ``HYDRA_HOOK`` and ``HYDRA_STAGE`` set. A pre
+hook validates: a non-zero exit fails the stage. A post hook may also reformat: if it
+prints ``{"output": {...}}`` that replaces the stage's output for the rest of the run;
+printing nothing keeps it. With ``on_failure: warn`` a failing hook is reported and
+the stage goes on. Hooks run each time a stage's agent runs (so once per audit retry),
+and not in a dry run.
+
+From Python, a StageHook can hold a callback instead of a command: it is called with
+the same object, and a dict it returns replaces the output.
+"""
+
+from __future__ import annotations
+
+import json
+import os
+import subprocess
+from dataclasses import dataclass, field
+from typing import Any, Callable, Dict, List, Mapping, Optional, Tuple
+
+from runtime.crewai.timeouts import STAGES, config_stage
+
+PRE = "pre"
+POST = "post"
+EVERY_STAGE = "*"
+FAIL = "fail"
+WARN = "warn"
+DEFAULT_HOOK_SECONDS = 60.0
+
+Runner = Callable[..., "subprocess.CompletedProcess[str]"]
+Callback = Callable[[Dict[str, Any]], Optional[Dict[str, Any]]]
+
+
+class HookError(RuntimeError):
+    """Raised when a hook fails, or answers with something other than its output."""
+
+    pass
+
+
+@dataclass(frozen=True)
+class StageHook:
+    """One command (or Python callback) to run before or after a stage."""
+
+    stage: str  # a pipeline config stage name, or "*"
+    when: str  # PRE or POST
+    command: Optional[str] = None
+    callback: Optional[Callback] = None
+    timeout: float = DEFAULT_HOOK_SECONDS
+    on_failure: str = FAIL
+
+    @property
+    def label(self) -> str:
+        name = self.command or getattr(self.callback, "__name__", "callback")
+        return f"{self.when}-{config_stage(self.stage)} hook {name}"
+
+    def to_dict(self) -> Dict[str, Any]:
+        return {
+            "run": self.command or getattr(self.callback, "__name__", "callback"),
+            "timeout": self.timeout,
+            "on_failure": self.on_failure,
+        }
+
+
+def _hook(stage: str, when: str, value: Any) -> StageHook:
+    where = f"hooks.{stage}.{when}"
+    if isinstance(value, str):
+        value = {"run": value}
+    if not isinstance(value, dict) or not isinstance(value.get("run"), str):
+        raise ValueError(f"{where}: expected a command, or a mapping with 'run'")
+    unknown = set(value) - {"run", "timeout", "on_failure"}
+    if unknown:
+        raise ValueError(f"{where}: unknown key(s): {', '.join(sorted(unknown))}")
+    on_failure = value.get("on_failure", FAIL)
+    if on_failure not in (FAIL, WARN):
+        raise ValueError(f"{where}: on_failure must be '{FAIL}' or '{WARN}'")
+    try:
+        timeout = float(value.get("timeout", DEFAULT_HOOK_SECONDS))
+    except (TypeError, ValueError) as e:
+        raise ValueError(f"{where}: timeout must be a number of seconds") from e
+    if timeout <= 0:
+        raise ValueError(f"{where}: timeout must be positive")
+    return StageHook(stage, when, value["run"], timeout=timeout, on_failure=on_failure)
+
+
+@dataclass(frozen=True)
+class HookPolicy:
+    """The hooks configured for each stage (none by default)."""
+
+    hooks: Tuple[StageHook, ...] = field(default_factory=tuple)
+
+    @classmethod
+    def from_dict(cls, data: Mapping[str, Any]) -> "HookPolicy":
+        """``{"tailoring": {"post": "./lint.sh"}, "*": {...}}``. Raises ValueError on
+        unknown stages or bad hooks."""
+        unknown = set(data) - set(STAGES) - {EVERY_STAGE}
+        if unknown:
+            raise ValueError(
+                f"unknown stage(s) in hooks: {', '.join(sorted(unknown))} "
+                f"(expected: {', '.join(STAGES)} or '{EVERY_STAGE}')"
+            )
+        hooks: List[StageHook] = []
+        for stage, section in data.items():
+            if not isinstance(section, dict) or set(section) - {PRE, POST}:
+                raise ValueError(f"hooks.{stage}: expected 'pre' and/or 'post'")
+            for when in (PRE, POST):
+                entries = section.get(when) or []
+                for value in entries if isinstance(entries, list) else [entries]:
+                    hooks.append(_hook(stage, when, value))
+        return cls(tuple(hooks))
+
+    def to_dict(self) -> Dict[str, Dict[str, List[Dict[str, Any]]]]:
+        out: Dict[str, Dict[str, List[Dict[str, Any]]]] = {}
+        for hook in self.hooks:
+            out.setdefault(hook.stage, {}).setdefault(hook.when, []).append(hook.to_dict())
+        return out
+
+    def for_stage(self, stage: str, when: str) -> List[StageHook]:
+        """The ``when`` hooks of ``stage`` (a workflow stage or agent name), "*" first."""
+        stage = config_stage(stage)
+        matching = [h for h in self.hooks if h.when == when and h.stage in (EVERY_STAGE, stage)]
+        return sorted(matching, key=lambda h: h.stage != EVERY_STAGE)
+
+
+def run_hooks(
+    hooks: List[StageHook],
+    stage: str,
+    inputs: Dict[str, Any],
+    output: Optional[Dict[str, Any]] = None,
+    runner: Runner = subprocess.run,
+) -> Tuple[Optional[Dict[str, Any]], List[str]]:
+    """Run ``hooks`` in order: the (possibly replaced) output and the failures of hooks
+    that only warn. A failing hook with ``on_failure: fail`` raises HookError."""
+    warnings: List[str] = []
+    for hook in hooks:
+        payload = {"hook": hook.when, "stage": config_stage(stage), "inputs": inputs}
+        if hook.when == POST:
+            payload["output"] = output
+        try:
+            replaced = _run_hook(hook, payload, runner)
+        except HookError as e:
+            if hook.on_failure == FAIL:
+                raise
+            warnings.append(str(e))
+            continue
+        if replaced is not None:
+            output = replaced
+    return output, warnings
+
+
+def _run_hook(hook: StageHook, payload: Dict[str, Any], runner: Runner) -> Optional[Dict]:
+    """One hook: the output it replaces, or None to keep it (always for a pre hook)."""
+    if hook.callback is not None:
+        try:
+            answer = hook.callback(payload)
+        except Exception as e:
+            raise HookError(f"{hook.label} failed: {e}") from e
+        if answer is not None and not isinstance(answer, dict):
+            raise HookError(f"{hook.label} returned {type(answer).__name__}, not a dict")
+        return answer if hook.when == POST else None
+
+    env = {**os.environ, "HYDRA_HOOK": hook.when, "HYDRA_STAGE": payload["stage"]}
+    try:
+        completed = runner(
+            hook.command,
+            shell=True,
+            input=json.dumps(payload, default=str),
+            capture_output=True,
+            text=True,
+            timeout=hook.timeout,
+            env=env,
+        )
+    except subprocess.TimeoutExpired as e:
+        raise HookError(f"{hook.label} did not finish within {hook.timeout:g}s") from e
+    except OSError as e:
+        raise HookError(f"{hook.label} could not be started: {e}") from e
+    if completed.returncode != 0:
+        stderr = (completed.stderr or "").strip().splitlines()
+        detail = f": {stderr[-1]}" if stderr else ""
+        raise HookError(f"{hook.label} exited with {completed.returncode}{detail}")
+    stdout = (completed.stdout or "").strip()
+    if hook.when == PRE or not stdout:
+        return None
+    try:
+        answer = json.loads(stdout)
+    except ValueError as e:
+        raise HookError(f"{hook.label} printed something other than JSON") from e
+    output = answer.get("output") if isinstance(answer, dict) else None
+    if not isinstance(output, dict):
+        raise HookError(f"{hook.label} printed JSON without an 'output' object")
+    return output
@@ -0,0 +1,166 @@
+"""Self-contained HTML summary of a run, rendered from its manifest.
+
+``report.html`` is the at-a-glance view of ``run.json``: status, decision, per-stage
+models and confidence, and — the part a JSON file is bad at — a bar per stage showing
+how much of the model's context window the call used and which input sections filled
+it. Use it to spot stages that need a larger model, a leaner prompt, or fewer
+forwarded outputs.
+
+It is rendered from the manifest only, so it inherits the manifest's guarantee: no
+résumé or job-description content, just sizes and names. No scripts, no external
+assets — it opens from disk anywhere.
+"""
+
+from __future__ import annotations
+
+from html import escape
+from typing import Any, Dict, List
+
+REPORT_HTML_FILE = "report.html"
+
+# Usage above these fractions of the window is shown as a warning / near the limit.
+WARN_FRACTION = 0.6
+CRITICAL_FRACTION = 0.85
+
+_PALETTE = (
+    "#4e79a7",
+    "#f28e2b",
+    "#59a14f",
+    "#e15759",
+    "#76b7b2",
+    "#edc948",
+    "#b07aa1",
+    "#ff9da7",
+    "#9c755f",
+    "#bab0ac",
+)
+
+_STYLE = """
+body { font: 14px/1.4 system-ui, sans-serif; margin: 2rem; color: #222; max-width: 960px; }
+h1 { font-size: 1.3rem; } h2 { font-size: 1.1rem; margin-top: 2rem; }
+table { border-collapse: collapse; } td, th { padding: .25rem .75rem; text-align: left; }
+.bar { display: flex; height: 18px; background: #eee; border-radius: 3px; overflow: hidden; }
+.bar span { display: block; height: 100%; }
+.stage { margin: .75rem 0; }
+.meta { color: #666; font-size: 12px; }
+.warn { color: #b8860b; } .critical { color: #c0392b; font-weight: bold; }
+.legend span { display: inline-block; margin-right: 1rem; font-size: 12px; }
+.legend i { display: inline-block; width: 10px; height: 10px; margin-right: 4px; }
+"""
+
+_CONFIDENCE_HEAD = (
+    "<tr><th>Stage</th><th>Confidence</th><th>Threshold</th><th>Re-prompts</th><th></th></tr>"
+)
+
+
+def _section_colors(usage: Dict[str, Dict[str, Any]]) -> Dict[str, str]:
+    names: List[str] = []
+    for stage in usage.values():
+        for name in stage.get("sections", {}):
+            if name not in names:
+                names.append(name)
+    return {name: _PALETTE[i % len(_PALETTE)] for i, name in enumerate(names)}
+
+
+def _usage_html(usage: Dict[str, Dict[str, Any]]) -> str:
+    if not usage:
+        return "<p>No context-usage data for this run.</p>"
+    colors = _section_colors(usage)
+    parts = ['<div class="legend">']
+    for name, color in colors.items():
+        parts.append(f'<span><i style="background:{color}"></i>{escape(name)}</span>')
+    parts.append("</div>")
+
+    for stage, data in usage.items():
+        window = data.get("context_window") or 0
+        fraction = float(data.get("fraction") or 0.0)
+        level = (
+            "critical"
+            if fraction >= CRITICAL_FRACTION
+            else "warn"
+            if fraction >= WARN_FRACTION
+            else ""
+        )
+        compacted = " · compacted" if data.get("compacted") else ""
+        parts.append('<div class="stage">')
+        parts.append(
+            f'<div><strong>{escape(stage)}</strong> <span class="{level}">'
+            f"{fraction:.0%}</span> "
+            f'<span class="meta">{data.get("used_tokens", 0):,} / {window:,} tokens · '
+            f"{escape(str(data.get('model')))}{compacted}</span></div>"
+        )
+        parts.append('<div class="bar">')
+        for name, tokens in data.get("sections", {}).items():
+            width = 100.0 * tokens / window if window else 0.0
+            if width <= 0:
+                continue
+            parts.append(
+                f'<span style="width:{min(width, 100.0):.2f}%;background:{colors[name]}" '
+                f'title="{escape(name)}: ~{tokens:,} tokens"></span>'
+            )
+        parts.append("</div></div>")
+    return "\n".join(parts)
+
+
+def render_report(manifest: Dict[str, Any]) -> str:
+    """Render ``manifest`` (see ``artifacts.build_manifest``) as a standalone page."""
+    decision = manifest.get("decision") or {}
+    audit = manifest.get("audit") or {}
+    usage = manifest.get("usage") or {}
+    saved = usage.get("cache_savings_usd")
+    review = manifest.get("review") or {}
+    ats_parse = manifest.get("ats_parse") or {}
+    rows = [
+        ("Status", manifest.get("status")),
+        ("Audit", audit.get("final_status")),
+        ("Recommendation", decision.get("recommendation")),
+        ("Fit score", decision.get("fit_score")),
+        ("ATS parse score", ats_parse.get("score")),
+        ("Missing for an ATS", ", ".join(ats_parse.get("missing_fields") or []) or None),
+        (
+            "Bullet review",
+            f"{review.get('accept', 0)} accepted, {review.get('reject', 0)} rejected, "
+            f"{review.get('edit', 0)} edited"
+            if review
+            else None,
+        ),
+        ("Prompt tokens", usage.get("prompt_tokens")),
+        ("Read from provider cache", usage.get("cache_read_tokens") or None),
+        ("Cache savings (est.)", f"${saved:.4f}" if saved else None),
+    ]
+    summary = "".join(
+        f"<tr><th>{escape(label)}</th><td>{escape(str(value))}</td></tr>"
+        for label, value in rows
+        if value is not None
+    )
+    models = "".join(
+        f"<tr><td>{escape(str(stage))}</td><td>{escape(str(model))}</td></tr>"
+        for stage, model in (manifest.get("models") or {}).items()
+    )
+    confidence = "".join(
+        f"<tr><td>{escape(str(stage))}</td><td>{entry.get('confidence', 0):.2f}</td>"
+        f"<td>{entry.get('threshold', 0):.2f}</td><td>{entry.get('reprompts', 0)}</td>"
+        f"<td>{'review' if entry.get('below_threshold') else ''}</td></tr>"
+        for stage, entry in (manifest.get("confidence") or {}).items()
+    )
+    warnings = "".join(f"<li>{escape(str(w))}</li>" for w in manifest.get("warnings") or [])
+
+    return f"""<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Run {escape(str(manifest.get("run_id")))}</title>
+<style>{_STYLE}</style>
+</head>
+<body>
+<h1>Run {escape(str(manifest.get("run_id")))}</h1>
+<table>{summary}</table>
+{f"<h2>Warnings</h2><ul>{warnings}</ul>" if warnings else ""}
+<h2>Context window usage by stage</h2>
+{_usage_html(manifest.get("context_usage") or {})}
+<h2>Models</h2>
+<table>{models}</table>
+{f"<h2>Confidence by stage</h2><table>{_CONFIDENCE_HEAD}{confidence}</table>" if confidence else ""}
+</body>
+</html>
+"""
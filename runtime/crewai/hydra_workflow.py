@@ -2,56 +2,195 @@
 HydraWorkflow - Orchestrates the complete Composable Me pipeline
 
 This workflow coordinates all agents in the proper sequence:
+0. Researcher - Optional cited company research via live web search
 1. Gap Analyzer - Maps requirements to experience
+   Seniority Calibrator - Optional check of the résumé's framing against the role's
+   level, which adjusts the tailoring instructions (``--seniority``)
+   Impact Rewriter - Optional impact rewrites of weak bullets, whose metric questions
+   join the interview (``--impact``)
 2. Interrogator-Prepper - Generates STAR+ questions
 3. Differentiator - Identifies unique value propositions
 4. Tailoring Agent - Creates tailored resume and cover letter
 5. ATS Optimizer - Optimizes for automated screening
 6. Auditor Suite - Comprehensive verification (with retry loop)
+7. Claim verification - Deterministic check of metrics/skills against the evidence
+   ATS lint - Hidden text, keyword stuffing, parser-breaking layout and non-standard
+   headers in the final documents; any violation fails the audit
+8. ATS parse check - Simulated ATS extraction of the final résumé (advisory)
+9. Compensation Analyst - Optional negotiation brief (``compensation=True``)
+10. Outreach Writer - Messages to the hiring manager (``referral`` template, or
+    ``--outreach``)
+11. Referral Finder - Asks to the candidate's contacts at the company (``contacts``)
+12. Judge - Optional rubric scores of the final résumé (``judge=True``)
+
+A workflow template (see runtime.crewai.workflow_templates) picks which of the
+optional stages run; tailoring and the audit always do.
 
 Includes state machine transitions, error recovery, and audit retry logic.
 """
 
+import copy
 import logging
+import threading
+import time
+import uuid
 from dataclasses import dataclass
 from datetime import datetime
 from enum import Enum
-from typing import Any, Dict, List, Optional
+from typing import Any, Callable, Dict, List, Optional
 
 from crewai import LLM
 
 from runtime.crewai.agents.ats_optimizer import ATSOptimizerAgent
 from runtime.crewai.agents.auditor import AuditorSuiteAgent
+from runtime.crewai.agents.compensation import CompensationAgent
 from runtime.crewai.agents.differentiator import DifferentiatorAgent
 from runtime.crewai.agents.executive_synthesizer import ExecutiveSynthesizerAgent
 from runtime.crewai.agents.gap_analyzer import GapAnalyzerAgent
+from runtime.crewai.agents.impact_rewriter import ImpactRewriterAgent
 from runtime.crewai.agents.interrogator_prepper import InterrogatorPrepperAgent
+from runtime.crewai.agents.judge import JudgeAgent
+from runtime.crewai.agents.outreach import OutreachAgent
+from runtime.crewai.agents.referrals import ReferralFinderAgent
+from runtime.crewai.agents.research import ResearchAgent
+from runtime.crewai.agents.seniority_calibrator import SeniorityCalibratorAgent
 from runtime.crewai.agents.tailoring_agent import TailoringAgent
+from runtime.crewai.ats_lint import lint_documents
+from runtime.crewai.ats_parse_check import parse_resume
+from runtime.crewai.audit_log import (
+    GREENLIGHT,
+    INTERVIEW_ANSWERS,
+    audit_event,
+    provider_calls,
+)
 from runtime.crewai.base_agent import BaseHydraAgent, ValidationError
+from runtime.crewai.budget_routing import (
+    DOWNGRADE,
+    KEEP,
+    RoutingDecision,
+    budget_summary,
+    spent_usd,
+)
+from runtime.crewai.cancellation import CancelToken, RunCancelled
+from runtime.crewai.chronology import check_chronology
+from runtime.crewai.circuit_breaker import OPEN, shared_breaker
+from runtime.crewai.claim_verification import VerificationReport, verify_claims
+from runtime.crewai.confidence import confidence_of, critique_prompt
+from runtime.crewai.constraints import (
+    Constraints,
+    ConstraintViolation,
+    Violation,
+    check_constraints,
+    requirements_text,
+    text_of,
+)
+from runtime.crewai.constraints import summary as constraints_summary
+from runtime.crewai.contact_header import preserve_header
+from runtime.crewai.context_window import (
+    compact_context,
+    estimate_tokens,
+    measure_usage,
+    prompt_budget,
+)
 from runtime.crewai.contracts import (
     ATSResult,
     AuditVerdict,
     ExecutiveDecision,
     GapAnalysis,
+    GapReview,
     TailoredDocuments,
 )
-from runtime.crewai.model_config import LLMClientError, get_agent_model_info, get_llm_for_agent
+from runtime.crewai.cover_letter_overlap import find_overlaps
+from runtime.crewai.determinism import model_parameters
+from runtime.crewai.dry_run import DryRunRecorder
+from runtime.crewai.failover import ProviderStatus, shared_health
+from runtime.crewai.fit_score import DECISION_MARKS, assess_fit, greenlight_question
+from runtime.crewai.hooks import POST, PRE, run_hooks
+from runtime.crewai.impact import interview_questions, tailoring_suggestions, weak_bullets
+from runtime.crewai.interview import (
+    BACK_COMMANDS,
+    DONE_COMMANDS,
+    InterviewSession,
+    compile_experience,
+    describe,
+    normalize_questions,
+    reuse_answers,
+)
+from runtime.crewai.json_resume import extract_tailored
+from runtime.crewai.json_resume import validate as validate_json_resume
+from runtime.crewai.knowledge_base import render_facts
+from runtime.crewai.length_limits import check_length, cut_bullets
+from runtime.crewai.llm_client import complete_batch
+from runtime.crewai.model_config import (
+    LLMClientError,
+    get_agent_model_info,
+    get_context_window,
+    get_llm_for_agent,
+    get_llm_for_spec,
+)
+from runtime.crewai.outreach import option_lists, pick_option, rank_options, record_choice
+from runtime.crewai.pii_redaction import PiiRedactor
+from runtime.crewai.pipeline_config import PipelineConfig, default_pipeline_config
+from runtime.crewai.plugins import PluginError, StagePlugin, run_plugin
+from runtime.crewai.prompt_cache import UsageLedger
+from runtime.crewai.prompt_packs import PackChoice, choose_pack
+from runtime.crewai.quick_apply import BudgetExceeded, LatencyBudget, fast_llm
+from runtime.crewai.rate_limit import provider_of
+from runtime.crewai.referrals import Contact, referral_paths
+from runtime.crewai.reflection import reflect
+from runtime.crewai.retention import RetentionPolicy
+from runtime.crewai.seniority import MATCHED, calibrate, tailoring_brief
+from runtime.crewai.skill_taxonomy import SkillTaxonomy, default_taxonomy, skill_coverage
+from runtime.crewai.stage_cache import StageCache
+from runtime.crewai.state_schema import upgrade_state
+from runtime.crewai.tailoring_variants import (
+    PICK_ASK,
+    PICK_AUDIT,
+    TailoringCandidate,
+    VariantPreferences,
+    judge_by_audit,
+    pick_by_audit,
+    pick_interactively,
+    run_variants,
+)
 from runtime.crewai.telemetry import trace_workflow_stage
+from runtime.crewai.timeouts import TimeoutExceeded, run_with_timeout
+from runtime.crewai.tools import Tool
+from runtime.crewai.wasm_plugins import WasmHost
+from runtime.crewai.web_search import SearchProvider
+from runtime.crewai.workflow_templates import (
+    BUILTIN_TEMPLATES,
+    DEFAULT_TEMPLATE,
+    WorkflowTemplate,
+)
 
+# Rewrites asked of the tailoring agent when its cover letter repeats other letters.
+MAX_DIFFERENTIATION_REWRITES = 2
 
 class WorkflowState(Enum):
     """Workflow execution states"""
 
     INITIALIZED = "initialized"
+    RESEARCH = "research"
     GAP_ANALYSIS = "gap_analysis"
     GAP_ANALYSIS_REVIEW = "gap_analysis_review"  # Pause state
+    SENIORITY = "seniority"
+    IMPACT = "impact"
     INTERROGATION = "interrogation"
     INTERROGATION_REVIEW = "interrogation_review"  # Pause state
     DIFFERENTIATION = "differentiation"
     TAILORING = "tailoring"
     ATS_OPTIMIZATION = "ats_optimization"
     AUDITING = "auditing"
+    CLAIM_VERIFICATION = "claim_verification"
+    ATS_LINT = "ats_lint"
+    CHRONOLOGY_CHECK = "chronology_check"
+    ATS_PARSE_CHECK = "ats_parse_check"
     EXECUTIVE_SYNTHESIS = "executive_synthesis"
+    COMPENSATION = "compensation"
+    OUTREACH = "outreach"
+    REFERRALS = "referrals"
+    JUDGE = "judge"
     COMPLETED = "completed"
     FAILED = "failed"
 
@@ -68,9 +207,39 @@ class RunStatus(str, Enum):
     COMPLETED_WITH_AUDIT_CONCERNS = "completed_with_audit_concerns"  # produced, audit rejected
     AUDIT_ERROR = "audit_error"  # produced, but the audit stage errored
     PAUSED = "paused"  # waiting for human input (HITL)
+    INTERRUPTED = "interrupted"  # cancelled mid-run; completed stages kept for resuming
     FAILED = "failed"  # a pre-audit stage failed; no documents
 
 
+@dataclass
+class WorkflowError:
+    """A failure the run recovered from or reported, kept apart from the free-text log.
+
+    Most entries are timeouts (``kind`` "timeout"; ``scope`` "llm_call" or "stage",
+    see runtime.crewai.timeouts): one model call or a whole stage that ran past its
+    limit, whether or not a retry or the fallback model then succeeded. An optional
+    plugin that failed is ``kind`` "plugin" (see runtime.crewai.plugins), and a stage
+    hook set to only warn that failed is ``kind`` "hook" (see runtime.crewai.hooks).
+    """
+
+    stage: str
+    kind: str
+    message: str
+    scope: Optional[str] = None
+    seconds: Optional[float] = None
+    at: str = ""
+
+    def to_dict(self) -> Dict[str, Any]:
+        return {
+            "stage": self.stage,
+            "kind": self.kind,
+            "message": self.message,
+            "scope": self.scope,
+            "seconds": self.seconds,
+            "at": self.at,
+        }
+
+
 @dataclass
 class WorkflowResult:
     """Result of workflow execution"""
@@ -87,6 +256,72 @@ class WorkflowResult:
     audit_failed: bool = False
     audit_error: Optional[str] = None
     agent_models: Optional[Dict[str, str]] = None
+    # Peak context-window usage per stage (see context_window.measure_usage).
+    context_usage: Optional[Dict[str, Dict[str, Any]]] = None
+    # Every candidate of a parallel tailoring comparison (winner flagged).
+    tailoring_variants: Optional[List[TailoringCandidate]] = None
+    # Token usage incl. provider prompt-cache reads/writes (see prompt_cache).
+    usage: Optional[Dict[str, Any]] = None
+    # What a simulated ATS extracts from the final résumé (see ats_parse_check).
+    ats_parse: Optional[Dict[str, Any]] = None
+    # Cover-letter paragraphs repeated from other recent applications, and the rewrites
+    # made to differentiate them (see cover_letter_overlap).
+    cover_letter_overlap: Optional[Dict[str, Any]] = None
+    # The résumé's length and reading grade against the limits, before and after the
+    # trims (see length_limits).
+    length: Optional[Dict[str, Any]] = None
+    # Header fields (name, email, phone, links) restored from the baseline résumé
+    # (see contact_header).
+    contact_header: Optional[Dict[str, Any]] = None
+    # Every tool call agents made, per stage (see tools).
+    tool_transcripts: Optional[Dict[str, List[Dict[str, Any]]]] = None
+    # Every model call agents made, per stage: messages, raw response, rejection
+    # (see prompt_transcript).
+    prompt_transcripts: Optional[Dict[str, List[Dict[str, Any]]]] = None
+    # Quick apply: the wall-clock budget, time per stage, and what was skipped.
+    latency_budget: Optional[Dict[str, Any]] = None
+    # Stages whose stored output was summarized, and what was discarded (see retention).
+    retention: Optional[Dict[str, Any]] = None
+    # The tailored résumé as a validated JSON Resume document (see json_resume).
+    json_resume: Optional[Dict[str, Any]] = None
+    # The résumé's level against the role's, and the framing tailoring was asked for
+    # (see seniority).
+    seniority: Optional[Dict[str, Any]] = None
+    # Weak bullets rewritten as impact statements, with their metric questions (see
+    # impact).
+    impact: Optional[Dict[str, Any]] = None
+    # The optional negotiation brief (see compensation).
+    compensation_brief: Optional[Dict[str, Any]] = None
+    # Messages to the hiring manager or recruiter, per channel (see outreach).
+    outreach: Optional[Dict[str, Any]] = None
+    # The candidate's contacts at the company, with a path and an ask each (see
+    # referrals).
+    referrals: Optional[Dict[str, Any]] = None
+    # Rubric scores of the final résumé, 1-10 with a rationale each (see judge).
+    judge: Optional[Dict[str, Any]] = None
+    # The workflow template the run used and the stages it skipped (see
+    # workflow_templates).
+    template: Optional[Dict[str, Any]] = None
+    # Timeouts and other recorded failures, as WorkflowError.to_dict() entries.
+    errors: Optional[List[Dict[str, Any]]] = None
+    # How many contact details were kept from the providers (see pii_redaction).
+    pii_redaction: Optional[Dict[str, int]] = None
+    # The provider and model that served each stage, and any failover (see failover).
+    stage_providers: Optional[Dict[str, Dict[str, Any]]] = None
+    # The confidence each stage reported, its threshold and any re-prompts (see
+    # confidence).
+    stage_confidence: Optional[Dict[str, Dict[str, Any]]] = None
+    # Reflection iterations run per stage (see reflection).
+    reflection: Optional[Dict[str, int]] = None
+    # The spend budget, what the run cost, and each stage's routing decision (see
+    # budget_routing).
+    cost_budget: Optional[Dict[str, Any]] = None
+    # The hard constraints declared and any the job broke (see constraints).
+    constraints: Optional[Dict[str, Any]] = None
+    # Decisions taken and data sent to providers during the run (see audit_log).
+    audit_events: Optional[List[Dict[str, Any]]] = None
+    # The prompt pack the agents' prompts were tuned with (see prompt_packs).
+    prompt_pack: Optional[Dict[str, Any]] = None
 
 
 class UserInteraction:
@@ -105,36 +340,74 @@ class UserInteraction:
         except EOFError:
             return True  # Default to yes in non-interactive environments
 
+    @staticmethod
+    def greenlight_gap_analysis(result: Dict[str, Any]) -> bool:
+        """Show the gap analysis and ask whether to proceed with it"""
+        review = GapReview.from_raw(result)
+        print("\n📊 GAP ANALYSIS COMPLETE")
+        if review.fit_score is not None:
+            mark = DECISION_MARKS.get(review.decision, "")
+            call = f" — {mark} {review.decision}" if review.decision else ""
+            print(f"   Fit score: {review.fit_score:g}{call}")
+        for line in review.rationale:
+            print(f"     · {line}")
+        for label, items in (
+            ("Matches", review.matches),
+            ("Adjacent", review.adjacent),
+            ("Gaps", review.gaps),
+        ):
+            if items:
+                print(f"   {label}: {', '.join(items)}")
+        if review.recommended_profile:
+            reason = f" — {review.profile_reason}" if review.profile_reason else ""
+            print(f"   Best-fitting profile: {review.recommended_profile}{reason}")
+        return UserInteraction.ask_yes_no(greenlight_question(review.decision))
+
+    @staticmethod
+    def pick_variant(candidates: List[TailoringCandidate]) -> Optional[TailoringCandidate]:
+        """Let the user pick among tailoring variants (see tailoring_variants)"""
+        return pick_interactively(candidates)
+
+    @staticmethod
+    def pick_outreach(kind: str, options: List[Dict[str, Any]]) -> Optional[int]:
+        """Let the user pick an outreach subject line or opener (see outreach)"""
+        return pick_option(kind, options)
+
     @staticmethod
     def conduct_interview(questions: List[Dict[str, Any]]) -> List[Dict[str, Any]]:
-        """Conduct an interactive interview based on generated questions"""
+        """Ask the interview questions one at a time (see runtime.crewai.interview)"""
+        session = InterviewSession(normalize_questions(questions))
         print("\n🎤 STARTING INTERVIEW SESSION")
         print("=" * 50)
         print("The agent has identified some gaps or areas needing detail.")
         print("Please answer the following questions to help tailor your resume.")
+        print("Press Enter to skip a question, type 'back' for the previous one")
+        print("or 'done' to finish early.")
         print("=" * 50)
 
-        answers = []
         try:
-            for i, q in enumerate(questions):
-                q_text = q.get("question", "Unknown Question")
-                print(f"\n[{i + 1}/{len(questions)}] {q_text}")
-                answer = input("   Your Answer > ").strip()
-                if answer:
-                    answers.append(
-                        {
-                            "question_id": q.get("id"),
-                            "question_text": q_text,
-                            "answer": answer,
-                            "verified": True,
-                            "source_material": True,
-                        }
-                    )
+            while not session.done:
+                question = session.current
+                print(f"\n[{session.progress}] {question['question']}")
+                if describe(question):
+                    print(f"   ({describe(question)})")
+                previous = session.answers.get(question["id"])
+                if previous:
+                    print(f"   Your earlier answer: {previous}")
+                reply = input("   Your Answer > ").strip()
+                if reply.lower() in BACK_COMMANDS:
+                    session.back()
+                elif reply.lower() in DONE_COMMANDS:
+                    session.finish()
+                else:
+                    session.answer(reply)
         except EOFError:
             print("\n⚠️ Input stream closed, skipping remaining questions.")
 
-        print("\n✅ Interview complete! Proceeding with these details...")
-        return answers
+        notes = session.notes()
+        skipped = len(session.questions) - len(notes)
+        print(f"\n✅ Interview complete: {len(notes)} answered, {skipped} skipped.")
+        return notes
 
 
 class WorkflowPaused(Exception):
@@ -147,7 +420,15 @@ class WorkflowPaused(Exception):
 
 
 class HydraWorkflow:
-    """Orchestrates the complete Composable Me agent pipeline"""
+    """Orchestrates the complete Composable Me agent pipeline
+
+    One instance runs one workflow at a time: ``execute`` starts from fresh run state
+    (log, stage outputs, usage, tool calls) and refuses to start while another call is
+    still running on the same instance, since the agents and their models are shared.
+    Servers and batch runs create a workflow per run. Within a run, stages that run in
+    parallel (quick apply, tailoring variants) and threads polling progress (the web
+    backend) share the run state under ``_state_lock``.
+    """
 
     def __init__(
         self,
@@ -156,6 +437,32 @@ class HydraWorkflow:
         use_per_agent_models: bool = True,
         interactive: bool = False,
         auto_approve: bool = False,
+        dry_run: bool = False,
+        stage_cache: Optional[StageCache] = None,
+        tailoring_models: Optional[List[str]] = None,
+        variant_pick: str = PICK_AUDIT,
+        variant_preferences: Optional[VariantPreferences] = None,
+        outreach_preferences: Optional[VariantPreferences] = None,
+        allow_unverified_claims: bool = False,
+        search_provider: Optional[SearchProvider] = None,
+        other_cover_letters: Optional[Dict[str, str]] = None,
+        agent_tools: Optional[Dict[str, List[Tool]]] = None,
+        latency_budget: Optional[LatencyBudget] = None,
+        retention: Optional[RetentionPolicy] = None,
+        model_routing: Optional[Dict[str, str]] = None,
+        compensation: bool = False,
+        skill_taxonomy: Optional[SkillTaxonomy] = None,
+        pipeline_config: Optional[PipelineConfig] = None,
+        redact_pii: bool = False,
+        plugins: Optional[List[StagePlugin]] = None,
+        plugin_host: Optional[WasmHost] = None,
+        template: Optional[WorkflowTemplate] = None,
+        contacts: Optional[List[Contact]] = None,
+        constraints: Optional[Constraints] = None,
+        seed: Optional[int] = None,
+        prompt_overrides: Optional[Dict[str, str]] = None,
+        judge: bool = False,
+        prompt_pack: Optional[str] = None,
     ):
         """
         Initialize the workflow with all agents
@@ -168,21 +475,137 @@ class HydraWorkflow:
             auto_approve: If True, proceed past the human gates without pausing
                 (used by the non-interactive CLI, which has no way to resume a pause).
                 The async web flow leaves this False so it can pause for real HITL.
+            dry_run: If True, render and record every agent prompt instead of calling
+                a model (see runtime.crewai.dry_run). Implies auto_approve.
+            stage_cache: Optional content-addressed cache of validated stage outputs
+                (see runtime.crewai.stage_cache); None disables caching.
+            tailoring_models: ``provider:model`` specs for the tailoring stage. One spec
+                pins the tailoring model; two or more run in parallel and a winner is
+                picked (see runtime.crewai.tailoring_variants). Ignored in a dry run.
+            variant_pick: How the winner is chosen: "audit" or "ask" (interactive).
+            variant_preferences: Where wins are recorded; None disables recording.
+            outreach_preferences: Where the outreach subject line and opener picks are
+                recorded, and what ranks the options (see runtime.crewai.outreach); None
+                keeps the writer's ranking and records nothing.
+            allow_unverified_claims: If True, claims the evidence does not support are
+                reported but do not block completion (see runtime.crewai.claim_verification),
+                and so are dates that do not hold up (see runtime.crewai.chronology).
+            search_provider: Web search tool for the research stage (see
+                runtime.crewai.web_search); None skips research.
+            other_cover_letters: Cover letters of other recent applications (run id ->
+                text). Paragraphs the new letter nearly repeats are sent back to the
+                tailoring agent to rewrite (see runtime.crewai.cover_letter_overlap).
+            agent_tools: Tools per agent type (e.g. "ats_optimizer") the agent may call
+                during its stage (see runtime.crewai.tools); None gives no agent tools.
+            latency_budget: Quick apply: finish within this wall-clock budget using fast
+                models, overlapped stages and no optional extras that do not fit (see
+                runtime.crewai.quick_apply). Ignored in a dry run.
+            retention: Which stage outputs state keeps only a summary of (see
+                runtime.crewai.retention); None keeps every output verbatim.
+            model_routing: ``provider:model`` spec per agent type that replaces its
+                configured model (see runtime.crewai.model_routing). An agent whose
+                routed model cannot be created falls back to the configured one.
+            compensation: If True, finish with a negotiation brief from the research,
+                the posted pay and the candidate's targets (see
+                runtime.crewai.compensation). Skipped under a latency budget.
+            skill_taxonomy: Skill names and aliases that gap analysis and the ATS parse
+                check match by (see runtime.crewai.skill_taxonomy); None loads the
+                built-in taxonomy extended by the user's skills.yaml.
+            pipeline_config: Run-wide execution settings: the per-stage and per-call
+                timeouts, the provider failover policy, confidence gating and reflection
+                (see runtime.crewai.pipeline_config); None loads the user's pipeline.yaml,
+                or the defaults.
+            redact_pii: If True, emails, phone numbers and street addresses in prompts
+                are replaced by placeholders before any call and restored in the outputs
+                (see runtime.crewai.pii_redaction).
+            plugins: External stages, each run after the built-in stage it names (see
+                runtime.crewai.plugins). Not run in a dry run.
+            plugin_host: The model and sources directory WebAssembly plugins may call
+                into (see runtime.crewai.wasm_plugins); None offers them this
+                workflow's ``llm`` and no sources.
+            template: The stages this run goes through (see
+                runtime.crewai.workflow_templates); None is the full pipeline.
+            contacts: The candidate's contacts. Given, the run finishes with referral
+                paths and a drafted ask for each contact at the target company (see
+                runtime.crewai.referrals). Skipped under a latency budget.
+            constraints: Conditions the job must meet (see runtime.crewai.constraints).
+                A job description, research or gap analysis that breaks one fails the
+                run before tailoring; None checks nothing.
+            seed: Deterministic run: every model call at temperature 0 with this seed
+                where the provider takes one (see runtime.crewai.determinism); None
+                keeps each agent's configured sampling.
+            prompt_overrides: Prompt text by the prompt path it replaces (e.g.
+                "agents/tailoring-agent/prompt.md"), for trying other prompts (see
+                runtime.crewai.replay); None keeps every agent's own.
+            judge: If True, finish by scoring the final résumé on the quality rubrics
+                (see runtime.crewai.judge). Skipped under a latency budget.
+            prompt_pack: The profession's prompt pack to add to the agents' prompts,
+                or "auto" to pick it from each run's job description (see
+                runtime.crewai.prompt_packs); None keeps the prompts as they are.
         """
         self.fallback_llm = llm
         self.max_audit_retries = max_audit_retries
         self.use_per_agent_models = use_per_agent_models
-        self.interactive = interactive
-        self.auto_approve = auto_approve
+        self.dry_run = dry_run
+        self.interactive = interactive and not dry_run
+        self.auto_approve = auto_approve or dry_run
+        self.allow_unverified_claims = allow_unverified_claims
+        self.other_cover_letters = other_cover_letters or {}
+        self.cover_letter_overlap: Optional[Dict[str, Any]] = None
+        self.length_enforcement: Optional[Dict[str, Any]] = None
+        self.contact_header: Optional[Dict[str, Any]] = None
+        self.json_resume: Optional[Dict[str, Any]] = None
         self.logger = logging.getLogger(__name__)
 
+        self.compensation = compensation
+        self.contacts = list(contacts or [])
+        self.judge = judge
+        self.constraints = constraints
+        self.template = template or BUILTIN_TEMPLATES[DEFAULT_TEMPLATE]
+        self.plugins = list(plugins or [])
+        self.plugin_host = plugin_host or WasmHost(llm)
+        self.skill_taxonomy = skill_taxonomy or default_taxonomy()
+        pipeline_config = pipeline_config or default_pipeline_config()
+        self.timeouts = pipeline_config.timeouts
+        # Stages on a provider that is down move to an equivalent model elsewhere.
+        self.failover = pipeline_config.failover
+        self.health = shared_health()
+        self.breaker = shared_breaker()
+        # Stages reporting low confidence are re-prompted with a critique, then escalated.
+        self.confidence = pipeline_config.confidence
+        # Stages that critique and revise their first pass, and how many times.
+        self.reflection = pipeline_config.reflection
+        # User commands run before and after stages.
+        self.hooks = pipeline_config.hooks
+        # Cheaper models for the less important stages as the spend budget runs out.
+        self.cost_budget = pipeline_config.budget
+        # Page, bullet and reading-grade limits the tailored résumé is trimmed to.
+        self.length = pipeline_config.length
+
         # Initialize agents with per-agent model assignments
+        self.model_routing = dict(model_routing or {})
         self.agent_models = {}
+        self.context_usage: Dict[str, Dict[str, Any]] = {}
+
+        # Researcher - Llama 4 (Together); only runs with a search provider
+        self.search_provider = search_provider
+        research_llm = self._get_agent_llm("research_agent") if search_provider else None
+        self.research_agent = ResearchAgent(research_llm, search_provider)
 
         # Gap Analyzer - DeepSeek V3 TEE (Chutes) or fallback
         gap_llm = self._get_agent_llm("gap_analyzer")
         self.gap_analyzer = GapAnalyzerAgent(gap_llm)
 
+        # Seniority Calibrator - Claude Sonnet (Anthropic); only in templates that calibrate
+        seniority = self.template.runs("seniority")
+        seniority_llm = self._get_agent_llm("seniority_calibrator") if seniority else None
+        self.seniority_calibrator = SeniorityCalibratorAgent(seniority_llm)
+
+        # Impact Rewriter - Claude Sonnet (Anthropic); only in templates that rewrite
+        impact = self.template.runs("impact")
+        impact_llm = self._get_agent_llm("impact_rewriter") if impact else None
+        self.impact_rewriter = ImpactRewriterAgent(impact_llm)
+
         # Interrogator - Llama 3.3 (Together)
         interrogator_llm = self._get_agent_llm("interrogator_prepper")
         self.interrogator_prepper = InterrogatorPrepperAgent(interrogator_llm)
@@ -207,10 +630,150 @@ class HydraWorkflow:
         exec_llm = self._get_agent_llm("executive_synthesizer")
         self.executive_synthesizer = ExecutiveSynthesizerAgent(exec_llm)
 
-        # Workflow state
+        # Compensation Analyst - Claude Sonnet (Anthropic); only runs when asked for
+        compensation_llm = self._get_agent_llm("compensation_analyst") if compensation else None
+        self.compensation_agent = CompensationAgent(compensation_llm)
+
+        # Outreach Writer - Claude Sonnet (Anthropic); only in templates that reach out
+        outreach = self.template.runs("outreach")
+        outreach_llm = self._get_agent_llm("outreach_writer") if outreach else None
+        self.outreach_agent = OutreachAgent(outreach_llm)
+
+        # Referral Finder - Claude Sonnet (Anthropic); only runs with contacts
+        referral_llm = self._get_agent_llm("referral_finder") if self.contacts else None
+        self.referral_agent = ReferralFinderAgent(referral_llm)
+
+        # Judge - Claude Sonnet (Anthropic); only runs when asked for
+        judge_llm = self._get_agent_llm("judge") if judge else None
+        self.judge_agent = JudgeAgent(judge_llm)
+
+        # A single tailoring spec pins the model; several are compared per run.
+        specs = [] if dry_run else list(tailoring_models or [])
+        if len(specs) == 1:
+            self.tailoring_agent.llm = get_llm_for_spec(specs[0], "tailoring_agent")
+            self.agent_models["tailoring_agent"] = specs[0]
+        self.variant_preferences = variant_preferences
+        self.tailoring_variants = specs if len(specs) > 1 else []
+        if self.tailoring_variants and variant_preferences is not None:
+            self.tailoring_variants = variant_preferences.ranked(self.tailoring_variants)
+        self.variant_pick = variant_pick
+        self.variant_candidates: List[TailoringCandidate] = []
+        self.outreach_preferences = outreach_preferences
+
+        self.latency_budget = None if dry_run else latency_budget
+        self.retention = retention or RetentionPolicy()
+        self.agent_tools = agent_tools or {}
+        self.tool_transcripts: Dict[str, List[Dict[str, Any]]] = {}
+        self.prompt_transcripts: Dict[str, List[Dict[str, Any]]] = {}
+        agents_by_type = {
+            "gap_analyzer": self.gap_analyzer,
+            "interrogator_prepper": self.interrogator_prepper,
+            "differentiator": self.differentiator,
+            "tailoring_agent": self.tailoring_agent,
+            "ats_optimizer": self.ats_optimizer,
+            "auditor_suite": self.auditor_suite,
+            "executive_synthesizer": self.executive_synthesizer,
+        }
+        if self.latency_budget is not None and self.use_per_agent_models:
+            for agent_type, agent in agents_by_type.items():
+                llm, spec = fast_llm(agent_type)
+                if llm is not None:
+                    agent.llm = llm
+                    self.agent_models[agent_type] = spec
+        for agent_type, tools in self.agent_tools.items():
+            if agent_type not in agents_by_type:
+                raise ValueError(f"No agent to give tools to: {agent_type}")
+            agents_by_type[agent_type].tools = list(tools)
+
+        self.stage_cache = stage_cache
+        self.usage_ledger = UsageLedger()
+        # The run's résumé and sources, shared by every agent's prompts (see prompt_cache).
+        self.shared_context: Dict[str, str] = {}
+        self.redactor = PiiRedactor() if redact_pii else None
+        for agent in self._agents():
+            agent.stage_cache = stage_cache
+            agent.usage_ledger = self.usage_ledger
+            agent.redactor = self.redactor
+            agent.circuit = self.failover.circuit if self.failover.enabled else None
+            agent.seed = seed
+            if agent.prompt_path in (prompt_overrides or {}):
+                agent.prompt = prompt_overrides[agent.prompt_path]
+        # Each run's pack is added to these, not to the previous run's (see _choose_pack).
+        self.prompt_pack = prompt_pack
+        self.pack_choice: Optional[PackChoice] = None
+        self._own_prompts = {id(agent): agent.prompt for agent in self._agents()}
+
+        self.dry_run_recorder: Optional[DryRunRecorder] = None
+        if dry_run:
+            self.dry_run_recorder = DryRunRecorder()
+            for agent, stage, agent_type in (
+                (self.gap_analyzer, "gap_analysis", "gap_analyzer"),
+                (self.interrogator_prepper, "interrogation", "interrogator_prepper"),
+                (self.differentiator, "differentiation", "differentiator"),
+                (self.tailoring_agent, "tailoring", "tailoring_agent"),
+                (self.ats_optimizer, "ats_optimization", "ats_optimizer"),
+                (self.auditor_suite, "auditor_suite", "auditor_suite"),
+                (self.executive_synthesizer, "executive_synthesis", "executive_synthesizer"),
+            ):
+                self.dry_run_recorder.register(agent, stage, self._planned_model(agent_type))
+            if search_provider is not None:
+                self.dry_run_recorder.register(
+                    self.research_agent, "research", self._planned_model("research_agent")
+                )
+            if seniority:
+                self.dry_run_recorder.register(
+                    self.seniority_calibrator,
+                    "seniority",
+                    self._planned_model("seniority_calibrator"),
+                )
+            if impact:
+                self.dry_run_recorder.register(
+                    self.impact_rewriter, "impact", self._planned_model("impact_rewriter")
+                )
+            if compensation:
+                self.dry_run_recorder.register(
+                    self.compensation_agent,
+                    "compensation",
+                    self._planned_model("compensation_analyst"),
+                )
+            if outreach:
+                self.dry_run_recorder.register(
+                    self.outreach_agent, "outreach", self._planned_model("outreach_writer")
+                )
+            if self.contacts:
+                self.dry_run_recorder.register(
+                    self.referral_agent, "referrals", self._planned_model("referral_finder")
+                )
+            if judge:
+                self.dry_run_recorder.register(
+                    self.judge_agent, "judge", self._planned_model("judge")
+                )
+
+        # Workflow state (per run; see _begin_run)
+        self.cancel_token = CancelToken()
+        self.rate_limit_owner = uuid.uuid4().hex
+        self._state_lock = threading.RLock()
+        self._run_lock = threading.Lock()
         self.current_state = WorkflowState.INITIALIZED
         self.execution_log = []
         self.intermediate_results = {}
+        self.errors: List[WorkflowError] = []
+        self.stage_providers: Dict[str, Dict[str, Any]] = {}
+        self.stage_confidence: Dict[str, Dict[str, Any]] = {}
+        self.budget_decisions: List[RoutingDecision] = []
+        self.reflections: Dict[str, int] = {}
+        # How the interactive gates ask (the CLI dashboard swaps in its own).
+        self.user_interaction = UserInteraction()
+        # Who answers those gates, for the audit log (see audit_log); the CLI sets it.
+        self.actor: Optional[str] = None
+        self.audit_events: List[Dict[str, Any]] = []
+        # Called with (stage, output) as each stage completes, e.g. to version it
+        # (see runtime.crewai.git_versioning).
+        self.stage_listeners: List[Callable[[str, Dict[str, Any]], None]] = []
+        # Called with (stage, text) as a model's answer arrives, to show it live (see
+        # BaseHydraAgent.on_output); add them before execute. Text from a retried or
+        # abandoned call is included.
+        self.output_listeners: List[Callable[[str, str], None]] = []
 
     def _get_agent_llm(self, agent_type: str) -> Optional[LLM]:
         """Resolve the LLM for an agent, or None if no provider key is available.
@@ -220,6 +783,16 @@ class HydraWorkflow:
         never reaches a model call. A run that actually invokes an agent with no LLM
         fails at that stage via ``_execute_with_fallback`` and is reported as FAILED.
         """
+        routed = self.model_routing.get(agent_type) if self.use_per_agent_models else None
+        if routed:
+            try:
+                llm = get_llm_for_spec(routed, agent_type)
+                self.agent_models[agent_type] = routed
+                self.logger.info(f"Agent '{agent_type}' using routed model: {routed}")
+                return llm
+            except LLMClientError as e:
+                self.logger.warning(f"Routed model failed for '{agent_type}': {e}")
+
         if self.use_per_agent_models:
             try:
                 llm = get_llm_for_agent(agent_type)
@@ -245,14 +818,112 @@ class HydraWorkflow:
             self.agent_models[agent_type] = "unavailable"
             return None
 
+    def _agents(self) -> List[BaseHydraAgent]:
+        """All pipeline agents, in stage order."""
+        return [
+            self.research_agent,
+            self.gap_analyzer,
+            self.seniority_calibrator,
+            self.impact_rewriter,
+            self.interrogator_prepper,
+            self.differentiator,
+            self.tailoring_agent,
+            self.ats_optimizer,
+            self.auditor_suite,
+            self.executive_synthesizer,
+            self.compensation_agent,
+            self.outreach_agent,
+            self.referral_agent,
+            self.judge_agent,
+        ]
+
+    def _planned_model(self, agent_type: str) -> str:
+        """Model a real run would try first for ``agent_type`` (used by dry runs)."""
+        if self.use_per_agent_models:
+            return get_agent_model_info(agent_type).get("model", "unknown")
+        return getattr(self.fallback_llm, "model", "unknown")
+
+    def _fit_context(
+        self, agent: BaseHydraAgent, context: Dict[str, Any], stage_name: str
+    ) -> Dict[str, Any]:
+        """Compact prior-stage outputs in ``context`` to fit the agent's model window."""
+        model = getattr(agent.llm, "model", None)
+        if model is None and self.dry_run_recorder is not None:
+            model = self.dry_run_recorder.model_for(agent.role)
+        model = str(model) if model is not None else None
+        system_tokens = estimate_tokens(agent._build_backstory(), model)
+        window = get_context_window(model)
+        budget = prompt_budget(window, overhead_tokens=system_tokens)
+        compacted, report = compact_context(context, budget, model)
+        usage = measure_usage(
+            compacted,
+            system_tokens,
+            window,
+            model=model,
+            compacted=report is not None,
+        )
+        # Stages that call their agent more than once (the audit) keep their peak.
+        with self._state_lock:
+            previous = self.context_usage.get(stage_name)
+            if previous is None or usage.fraction >= previous["fraction"]:
+                self.context_usage[stage_name] = usage.to_dict()
+        if report is not None:
+            self._log(
+                f"Compacted context for {stage_name} ({model}): "
+                f"~{report.tokens_before} -> ~{report.tokens_after} tokens "
+                f"(budget {report.budget_tokens}; stripped {report.stripped_keys or 'none'}, "
+                f"truncated {report.truncated_keys or 'none'})"
+            )
+            if report.over_budget:
+                self._log(f"Context for {stage_name} still exceeds the budget after compaction")
+        return compacted
+
     def _execute_with_fallback(
         self, agent: BaseHydraAgent, context: Dict[str, Any], stage_name: str
     ) -> Dict[str, Any]:
-        """Execute agent with automatic fallback to secondary model on failure."""
+        """Execute agent with automatic fallback to secondary model on failure, then
+        give an output that reports low confidence another look."""
+        self._run_stage_hooks(PRE, stage_name, context)
+        result = self._run_with_fallback(agent, context, stage_name)
+        result = self._gate_confidence(agent, context, stage_name, result)
+        return self._run_stage_hooks(POST, stage_name, context, result)
+
+    def _run_stage_hooks(
+        self,
+        when: str,
+        stage_name: str,
+        context: Dict[str, Any],
+        output: Optional[Dict[str, Any]] = None,
+    ) -> Optional[Dict[str, Any]]:
+        """Run the ``when`` hooks of a stage (see runtime.crewai.hooks): the output, as a
+        post hook may have replaced it. Hooks that only warn are reported as errors."""
+        hooks = self.hooks.for_stage(stage_name, when)
+        if not hooks or self.dry_run:
+            return output
+        started = time.monotonic()
+        output, warnings = run_hooks(hooks, stage_name, context, output)
+        for warning in warnings:
+            self._log(f"Hook failed, continuing: {warning}")
+            with self._state_lock:
+                self.errors.append(
+                    WorkflowError(
+                        stage=stage_name,
+                        kind="hook",
+                        message=warning,
+                        scope="stage",
+                        seconds=round(time.monotonic() - started, 3),
+                        at=datetime.now().isoformat(),
+                    )
+                )
+        return output
+
+    def _run_with_fallback(
+        self, agent: BaseHydraAgent, context: Dict[str, Any], stage_name: str
+    ) -> Dict[str, Any]:
         # An agent constructed without a resolvable LLM (no provider key) must not
         # silently run on CrewAI's default OpenAI model: use the fallback if we have
-        # one, otherwise fail loudly.
-        if agent.llm is None:
+        # one, otherwise fail loudly. A dry run never calls the model, so needs no LLM.
+        if agent.llm is None and not self.dry_run:
             if self.fallback_llm is None:
                 raise ValueError(
                     f"No LLM available for stage '{stage_name}': set a provider API key "
@@ -261,35 +932,466 @@ class HydraWorkflow:
             agent.llm = self.fallback_llm
             self.agent_models[stage_name] = getattr(self.fallback_llm, "model", "fallback")
 
+        self.cancel_token.check(stage_name)
+        self._route_for_budget(agent, stage_name)
+        # A provider already known to be down is not tried first.
+        failed_over_from = self._fail_over(agent, stage_name, known_only=True)
         try:
-            return agent.execute(context)
+            result = self._run_agent(agent, context, stage_name)
+            self._record_tool_calls(agent, stage_name)
+            self._record_prompts(agent, stage_name)
+            self._record_provider(agent, stage_name, failed_over_from)
+            return result
+        except BudgetExceeded:
+            raise  # no time left for a fallback attempt
         except Exception as e:
+            self._record_tool_calls(agent, stage_name)
+            self._record_prompts(agent, stage_name)
             self.logger.warning(f"Stage '{stage_name}' failed with primary model: {e}")
             self._log(f"Primary model failed for {stage_name}, attempting fallback...")
 
             try:
-                # Get fallback LLM
-                if self.fallback_llm:
-                    fallback = self.fallback_llm
-                    model_name = getattr(fallback, "model", "fallback")
+                # A provider that is down: the same model on a healthy provider.
+                down = self._fail_over(agent, stage_name)
+                if down is not None:
+                    failed_over_from = down
                 else:
-                    # Create a specific fallback for this agent
-                    fallback = get_llm_for_agent(stage_name, fallback_only=True)
-                    model_name = "fallback"
-
-                # Update agent with fallback LLM
-                agent.llm = fallback
-                self.agent_models[stage_name] = model_name
-                self._log(f"Switched {stage_name} to fallback model: {model_name}")
-
-                # Retry execution
-                return agent.execute(context)
+                    # Get fallback LLM
+                    if self.fallback_llm:
+                        fallback = self.fallback_llm
+                        model_name = getattr(fallback, "model", "fallback")
+                    else:
+                        # Create a specific fallback for this agent
+                        fallback = get_llm_for_agent(stage_name, fallback_only=True)
+                        model_name = "fallback"
+
+                    # Update agent with fallback LLM
+                    agent.llm = fallback
+                    self.agent_models[stage_name] = model_name
+                    self._log(f"Switched {stage_name} to fallback model: {model_name}")
+
+                # Retry execution (re-fitted: the fallback may have a smaller window)
+                result = self._run_agent(agent, context, stage_name)
+                self._record_tool_calls(agent, stage_name)
+                self._record_prompts(agent, stage_name)
+                self._record_provider(agent, stage_name, failed_over_from)
+                return result
 
             except Exception as fallback_error:
                 self.logger.error(f"Fallback failed for {stage_name}: {fallback_error}")
                 # Surface the original error; it is usually the more informative one.
                 raise e from fallback_error
 
+    def _fail_over(
+        self, agent: BaseHydraAgent, stage_name: str, known_only: bool = False
+    ) -> Optional[str]:
+        """Move ``agent`` off its provider if that provider is down; the provider left.
+
+        ``known_only`` trusts a recent health check and never probes (before a stage);
+        otherwise, after a failed call, the provider is probed afresh. A provider whose
+        circuit is open is down without a probe (see circuit_breaker).
+        """
+        if not self.failover.enabled or self.dry_run or agent.llm is None:
+            return None
+        provider = provider_of(agent.llm)
+        ttl = self.failover.health_ttl
+        status = self._circuit_status(provider)
+        if status is None:
+            status = (
+                self.health.known(provider, ttl) if known_only else self.health.check(provider, 0)
+            )
+        if status is None or status.healthy:
+            return None
+        model = str(getattr(agent.llm, "model", None) or "")
+        for target, equivalent in self.failover.candidates(provider, model):
+            if self._circuit_status(target) or not self.health.check(target, ttl).healthy:
+                continue
+            spec = f"{target}:{equivalent}"
+            try:
+                llm = get_llm_for_spec(spec, stage_name, getattr(agent.llm, "temperature", None))
+            except LLMClientError:
+                continue
+            agent.llm = llm
+            self.agent_models[stage_name] = spec
+            self._log(f"{provider} is down ({status.detail}); {stage_name} fails over to {spec}")
+            return provider
+        self._log(f"{provider} is down ({status.detail}); no healthy equivalent for {model}")
+        return None
+
+    def _circuit_status(self, provider: str) -> Optional[ProviderStatus]:
+        """``provider`` as down if its circuit is open; None if it is not."""
+        if self.breaker.state(provider) != OPEN:
+            return None
+        return ProviderStatus(provider, False, "circuit open after repeated failures")
+
+    def _route_for_budget(self, agent: BaseHydraAgent, stage_name: str) -> None:
+        """Drop ``agent`` to its stage's cheap model if the budget says so, and record
+        the decision either way (see runtime.crewai.budget_routing)."""
+        if not self.cost_budget.enabled or self.dry_run or agent.llm is None:
+            return
+        model = getattr(agent.llm, "model", None)
+        model = None if model is None else str(model)
+        decision = self.cost_budget.decide(stage_name, model, spent_usd(self.usage_ledger.calls))
+        if decision.action == DOWNGRADE:
+            try:
+                llm = get_llm_for_spec(
+                    decision.to_model, stage_name, getattr(agent.llm, "temperature", None)
+                )
+            except LLMClientError as e:
+                decision.action = KEEP
+                decision.reason += f"; {decision.to_model} unavailable: {e}"
+            else:
+                agent.llm = llm
+                self.agent_models[stage_name] = decision.to_model
+        with self._state_lock:
+            self.budget_decisions.append(decision)
+        verb = f"downgraded to {decision.to_model}" if decision.action == DOWNGRADE else "kept"
+        self._log(f"Budget routing: {stage_name} {verb} ({decision.reason})")
+
+    def _spend_summary(self) -> Optional[Dict[str, Any]]:
+        with self._state_lock:
+            decisions = list(self.budget_decisions)
+        return budget_summary(self.cost_budget, spent_usd(self.usage_ledger.calls), decisions)
+
+    def _check_constraints(self, where: str, text: str) -> None:
+        """Fail the run if ``text`` breaks a hard constraint (see constraints)."""
+        if self.constraints is None:
+            return
+        violations = check_constraints(self.constraints, text)
+        if violations:
+            self.constraint_violations = violations
+            for violation in violations:
+                self._log(f"Constraint broken in the {where}: {violation.describe()}")
+            raise ConstraintViolation(where, violations)
+
+    def _constraint_summary(self) -> Optional[Dict[str, Any]]:
+        if self.constraints is None:
+            return None
+        return constraints_summary(self.constraints, self.constraint_violations)
+
+    def _record_provider(
+        self, agent: BaseHydraAgent, stage_name: str, failed_over_from: Optional[str]
+    ) -> None:
+        """Which provider and model served ``stage_name``, and the sampling parameters
+        it was called with (nothing in a dry run)."""
+        if agent.llm is None or self.dry_run:
+            return
+        served = {"provider": provider_of(agent.llm), "model": getattr(agent.llm, "model", None)}
+        served.update(model_parameters(agent.llm))
+        if failed_over_from:
+            served["failed_over_from"] = failed_over_from
+        with self._state_lock:
+            self.stage_providers[stage_name] = served
+
+    def _provider_summary(self) -> Optional[Dict[str, Dict[str, Any]]]:
+        with self._state_lock:
+            return dict(self.stage_providers) or None
+
+    def _gate_confidence(
+        self,
+        agent: BaseHydraAgent,
+        context: Dict[str, Any],
+        stage_name: str,
+        result: Dict[str, Any],
+    ) -> Dict[str, Any]:
+        """Re-run a stage whose output is below its confidence threshold with a critique
+        of that output, keeping the more confident answer; escalate if it stays low."""
+        confidence = confidence_of(result)
+        if self.dry_run or confidence is None:
+            return result
+        threshold = self.confidence.for_stage(stage_name)
+        reprompts = 0
+        while confidence < threshold and reprompts < self.confidence.reprompts:
+            self.cancel_token.check(stage_name)
+            reprompts += 1
+            self._log(
+                f"{stage_name} reported confidence {confidence:.2f} (threshold "
+                f"{threshold:.2f}); re-prompting with a critique "
+                f"({reprompts}/{self.confidence.reprompts})"
+            )
+            agent.critique = critique_prompt(result, confidence, threshold)
+            try:
+                retry = self._run_agent(agent, context, stage_name)
+            except BudgetExceeded:
+                raise
+            except Exception as e:  # the first answer stands
+                self._log(f"Re-prompting {stage_name} failed, keeping its earlier output: {e}")
+                break
+            finally:
+                agent.critique = None
+                self._record_tool_calls(agent, stage_name)
+                self._record_prompts(agent, stage_name)
+            retried = confidence_of(retry)
+            if retried is not None and retried >= confidence:
+                result, confidence = retry, retried
+
+        record: Dict[str, Any] = {
+            "confidence": confidence,
+            "threshold": threshold,
+            "reprompts": reprompts,
+        }
+        if confidence < threshold:
+            record["below_threshold"] = True
+            if self.confidence.escalate:
+                record["escalated"] = True
+        with self._state_lock:
+            self.stage_confidence[stage_name] = record
+        if record.get("escalated"):
+            self._escalate_confidence(stage_name, confidence, threshold)
+        return result
+
+    def _escalate_confidence(self, stage_name: str, confidence: float, threshold: float) -> None:
+        """Ask whether to go on with a low-confidence output; without a user, flag it."""
+        message = f"{stage_name} is still unsure of its output ({confidence:.2f} < {threshold:.2f})"
+        if not self.interactive:
+            self._log(f"⚠️ {message}; review it before sending")
+            return
+        if not self.user_interaction.ask_yes_no(f"{message}. Continue with it?"):
+            self._log(f"User aborted after low confidence in {stage_name}")
+            raise Exception("User aborted workflow")
+
+    def _confidence_summary(self) -> Optional[Dict[str, Dict[str, Any]]]:
+        with self._state_lock:
+            return dict(self.stage_confidence) or None
+
+    def _run_agent(
+        self, agent: BaseHydraAgent, context: Dict[str, Any], stage_name: str
+    ) -> Dict[str, Any]:
+        """One agent call within the stage timeout; under a latency budget, also capped
+        at the time remaining."""
+
+        def _run() -> Dict[str, Any]:
+            return self._timed(
+                agent,
+                stage_name,
+                lambda: self._reflected(agent, stage_name)(
+                    self._fit_context(agent, context, stage_name)
+                ),
+            )
+
+        if self.latency_budget is None:
+            return _run()
+        budget = self.latency_budget
+        if agent.llm is not None and hasattr(agent.llm, "timeout"):
+            agent.llm.timeout = max(1, int(budget.remaining()))
+        return budget.run(stage_name, _run)
+
+    def _reflected(
+        self, agent: BaseHydraAgent, stage_name: str
+    ) -> Callable[[Dict[str, Any]], Dict[str, Any]]:
+        """``agent.execute``, with the reflection iterations configured for the stage."""
+        iterations = self.reflection.for_stage(stage_name)
+        if iterations:
+            with self._state_lock:
+                self.reflections[stage_name] = iterations
+        return reflect(agent, iterations, self._log)
+
+    def _reflection_summary(self) -> Optional[Dict[str, int]]:
+        with self._state_lock:
+            return dict(self.reflections) or None
+
+    def _timed(
+        self, agent: BaseHydraAgent, stage_name: str, run: Callable[[], Dict[str, Any]]
+    ) -> Dict[str, Any]:
+        """Run a stage within its timeout, recording every timeout as a WorkflowError."""
+        try:
+            return run_with_timeout(stage_name, self.timeouts.for_stage(stage_name), run)
+        except TimeoutExceeded as e:
+            self._record_error(stage_name, e)
+            raise
+        finally:
+            # Model calls that timed out inside the stage (and were retried).
+            timed_out = getattr(agent, "timed_out", None)
+            if isinstance(timed_out, list) and timed_out:
+                agent.timed_out = []
+                for call in timed_out:
+                    self._record_error(stage_name, call)
+
+    def _record_error(self, stage_name: str, error: TimeoutExceeded) -> None:
+        self._log(f"Timeout in {stage_name}: {error}")
+        with self._state_lock:
+            self.errors.append(
+                WorkflowError(
+                    stage=stage_name,
+                    kind="timeout",
+                    message=str(error),
+                    scope=error.scope,
+                    seconds=error.seconds,
+                    at=datetime.now().isoformat(),
+                )
+            )
+
+    def _error_summary(self) -> Optional[List[Dict[str, Any]]]:
+        with self._state_lock:
+            return [error.to_dict() for error in self.errors] or None
+
+    def _fits_budget(self, stage: str, ahead: tuple = ()) -> bool:
+        """Whether optional ``stage`` runs: always without a budget, else if it fits."""
+        if self.latency_budget is None or self.latency_budget.fits(stage, ahead):
+            return True
+        self._skip_for_budget(stage)
+        return False
+
+    def _skip_for_budget(self, stage: str) -> None:
+        self.latency_budget.skip(stage)
+        self._log(f"Quick apply: skipping {stage} ({self.latency_budget.remaining():.0f}s left)")
+
+    def _budget_summary(self) -> Optional[Dict[str, Any]]:
+        return self.latency_budget.summary() if self.latency_budget is not None else None
+
+    def _in_template(self, stage: str) -> bool:
+        """Whether the run's template includes ``stage``; logs the skip if not."""
+        if self.template.runs(stage):
+            return True
+        self._log(f"Template {self.template.name}: skipping {stage}")
+        return False
+
+    def _execute_gap_and_differentiation(
+        self, context: Dict[str, Any]
+    ) -> tuple[Dict[str, Any], Dict[str, Any]]:
+        """Quick apply: differentiate from the JD and résumé while the gaps are analysed."""
+        if not self._fits_budget("differentiation", ("gap_analysis", "tailoring", "auditing")):
+            return self._execute_gap_analysis(context), {}
+        gap, differentiation = complete_batch(
+            [
+                lambda: self._execute_gap_analysis(context),
+                lambda: self._execute_differentiation(context, {}, {"interview_notes": ""}),
+            ],
+            self.cancel_token,
+        )
+        if not gap.ok:
+            raise gap.error
+        if not differentiation.ok:  # optional stage: tailor without it
+            self._log(f"Differentiation failed, tailoring without it: {differentiation.error}")
+        return gap.value, differentiation.value or {}
+
+    def _replayed(self, context: Dict[str, Any], stage: str) -> Optional[Dict[str, Any]]:
+        """In a replay, the replayed run's output of ``stage`` when the replay starts
+        after it (see runtime.crewai.replay); None if the stage runs."""
+        if not context.get("replay_from") or stage not in self.intermediate_results:
+            return None
+        self._log(f"Skipping {stage} (reused from the replayed run)")
+        return self.intermediate_results[stage]
+
+    def _record(self, stage: str, result: Dict[str, Any]) -> None:
+        """Keep a stage output in state, summarized if the retention policy says so,
+        and hand it to the stage listeners."""
+        retained = self.retention.retain(stage, result)
+        with self._state_lock:
+            self.intermediate_results[stage] = retained
+        for listener in list(self.stage_listeners):
+            try:
+                listener(stage, retained)
+            except Exception as e:  # a listener never fails the run
+                self._log(f"Stage listener failed after {stage}: {e}")
+
+    def _relay_output(self, text: str) -> None:
+        """Hand a piece of model output to the output listeners, under the stage."""
+        stage = self.get_current_state().value
+        for listener in list(self.output_listeners):
+            try:
+                listener(stage, text)
+            except Exception as e:  # a listener never fails the run
+                self._log(f"Output listener failed during {stage}: {e}")
+
+    def _record_tool_calls(self, agent: BaseHydraAgent, stage_name: str) -> None:
+        """Keep the tool calls an agent made for this stage (none without tools)."""
+        transcript = getattr(agent, "tool_transcript", None)
+        if isinstance(transcript, list) and transcript:
+            with self._state_lock:
+                self.tool_transcripts.setdefault(stage_name, []).extend(transcript)
+            agent.tool_transcript = []
+
+    def _record_prompts(self, agent: BaseHydraAgent, stage_name: str) -> None:
+        """Keep the model calls an agent made for this stage, as sent and answered."""
+        transcript = getattr(agent, "prompt_transcript", None)
+        if isinstance(transcript, list) and transcript:
+            with self._state_lock:
+                self.prompt_transcripts.setdefault(stage_name, []).extend(transcript)
+                usage = self.context_usage.get(stage_name) or self.context_usage.get(
+                    stage_name.partition(":")[0]
+                )
+                self.audit_events += provider_calls(
+                    stage_name,
+                    transcript,
+                    sections=(usage or {}).get("sections") or {},
+                    redacted=self.redactor is not None,
+                )
+            agent.prompt_transcript = []
+
+    def _pack_summary(self) -> Optional[Dict[str, Any]]:
+        return self.pack_choice.to_dict() if self.pack_choice is not None else None
+
+    def _choose_pack(self, context: Dict[str, Any]) -> None:
+        """Add the run's prompt pack to the agents' prompts (see prompt_packs)."""
+        self.pack_choice = None
+        if self.prompt_pack is None:
+            return
+        choice = choose_pack(self.prompt_pack, context["job_description"])
+        for agent in self._agents():
+            agent.prompt = choice.pack.apply(agent.prompt_path, self._own_prompts[id(agent)])
+        self.pack_choice = choice
+        how = f"detected: {choice.reason}" if choice.detected else choice.reason
+        self._log(f"Prompt pack {choice.pack.name} ({how})")
+
+    def _audit(self, action: str, **details: Any) -> None:
+        """Add a decision taken during the run to its audit log (see audit_log)."""
+        with self._state_lock:
+            self.audit_events.append(audit_event(action, actor=self.actor, **details))
+
+    def _begin_run(self) -> None:
+        """Fresh run state, so one run's results never leak into the next."""
+        with self._state_lock:
+            self.current_state = WorkflowState.INITIALIZED
+            self.execution_log = []
+            self.intermediate_results = {}
+            self.context_usage = {}
+            self.tool_transcripts = {}
+            self.prompt_transcripts = {}
+            self.audit_events = []
+            self.variant_candidates = []
+            self.cover_letter_overlap = None
+            self.length_enforcement = None
+            self.contact_header = None
+            self.json_resume = None
+            self.usage_ledger = UsageLedger()
+            self.shared_context = {}
+            self.cancel_token = CancelToken()
+            # Each run takes its own turns in the provider rate limiter.
+            self.rate_limit_owner = uuid.uuid4().hex
+            self.errors = []
+            self.stage_providers = {}
+            self.stage_confidence = {}
+            self.budget_decisions = []
+            self.reflections = {}
+            self.constraint_violations: List[Violation] = []
+            for agent in self._agents():
+                agent.usage_ledger = self.usage_ledger
+                agent.shared_context = self.shared_context
+                agent.cancel_token = self.cancel_token
+                agent.rate_limit_owner = self.rate_limit_owner
+                agent.call_timeout = self.timeouts.llm_call
+                agent.timed_out = []
+                # Only streamed when someone is listening.
+                agent.on_output = self._relay_output if self.output_listeners else None
+
+    def cancel(self, reason: str = "cancelled") -> None:
+        """Stop the run in flight (safe to call from any thread or a signal handler).
+
+        The model call under way is abandoned and no further stage starts; ``execute``
+        returns an INTERRUPTED result holding the stages completed so far (see
+        runtime.crewai.cancellation).
+        """
+        self.cancel_token.cancel(reason)
+
+    def stop_after_stage(self, reason: str = "stopped") -> None:
+        """Stop the run at the next stage boundary, keeping the stage in flight.
+
+        Unlike ``cancel`` nothing is abandoned: the current stage finishes and its
+        output is part of the INTERRUPTED result.
+        """
+        self.cancel_token.stop_at_boundary(reason)
+
     def execute(self, context: Dict[str, Any]) -> WorkflowResult:
         """
         Execute the complete workflow pipeline
@@ -302,30 +1404,151 @@ class HydraWorkflow:
                 - target_role: The role being applied for
                 - research_data: Optional research data
                 - previous_results: Optional dict of results from previous run (for resuming)
+                - state_version: Schema version previous_results were saved at; older
+                  states are upgraded on load (see runtime.crewai.state_schema)
                 - resume_stage: Optional string indicating stage to resume from
+                - replay_from: Optional stage a replay runs again from; the outputs
+                  of earlier stages in previous_results are reused, not re-run (see
+                  runtime.crewai.replay)
                 - gap_analysis_approved: Boolean (for resuming after gap analysis)
+                - greenlight_notes: Optional reviewer guidance given with the approval
                 - interview_answers: List (for resuming after interrogation)
+                - reused_interview: Optional ``{"run_id", "entries"}``: an earlier
+                  run's interview answers to reuse (see runtime.crewai.interview)
+                - knowledge_facts: Optional verified facts from earlier runs, for the
+                  prompts and the claim check (see runtime.crewai.knowledge_base)
+                - claim_override: Optional bool; accept unverifiable claims and dates
+                  (web HITL)
 
         Returns:
-            WorkflowResult. If paused, state will reflect the pause point.
+            WorkflowResult. If paused or cancelled, state will reflect where it stopped.
+
+        Raises:
+            RuntimeError: if this workflow is already executing another run.
+        """
+        if not self._run_lock.acquire(blocking=False):
+            raise RuntimeError(
+                "HydraWorkflow is already running; create one workflow per concurrent run"
+            )
+        try:
+            self._begin_run()
+            return self._execute(context)
+        finally:
+            self._run_lock.release()
+
+    def execute_stage(self, stage: str, context: Dict[str, Any]) -> Dict[str, Any]:
+        """Run only ``stage`` ("tailoring" or "ats_optimization") on the earlier stages'
+        outputs in ``context["previous_results"]`` and return its output, for prompt
+        experiments (see runtime.crewai.experiments). No gate, plugin or later stage runs.
         """
+        if not self._run_lock.acquire(blocking=False):
+            raise RuntimeError(
+                "HydraWorkflow is already running; create one workflow per concurrent run"
+            )
+        try:
+            self._begin_run()
+            self._validate_input_context(context)
+            self._share_context(context)
+            self._choose_pack(context)
+            previous = upgrade_state(
+                context.get("previous_results") or {}, context.get("state_version")
+            )
+            with self._state_lock:
+                self.intermediate_results = previous
+            if stage == "tailoring":
+                return self._execute_tailoring(
+                    context,
+                    previous.get("gap_analysis") or {},
+                    previous.get("interrogation") or {"questions": [], "interview_notes": []},
+                    previous.get("differentiation") or {},
+                    previous.get("impact"),
+                    previous.get("seniority"),
+                )
+            if stage == "ats_optimization":
+                return self._execute_ats_optimization(context, previous.get("tailoring") or {})
+            raise ValueError(f"{stage} cannot run on its own")
+        finally:
+            self._run_lock.release()
+
+    def audit_document(
+        self, context: Dict[str, Any], document: str, document_type: str = "resume"
+    ) -> Dict[str, Any]:
+        """The auditor's report on one document, outside a run (experiments judge by it)."""
+        return self._audit_document(context, document, document_type)
+
+    def _execute(self, context: Dict[str, Any]) -> WorkflowResult:
+        """The pipeline itself; run state is fresh and owned by this call."""
         try:
             self._log("Starting HydraWorkflow execution")
             self._validate_input_context(context)
+            self._share_context(context)
+            self._choose_pack(context)
 
             # Load previous results if resuming
             if "previous_results" in context:
-                self.intermediate_results = context["previous_results"]
+                previous = upgrade_state(context["previous_results"], context.get("state_version"))
+                with self._state_lock:
+                    self.intermediate_results = previous
                 self._log("Loaded intermediate results from previous run")
 
+            # Hard constraints fail the run before any model is called.
+            self._check_constraints("job description", context["job_description"])
+
             # Execute pipeline stages
 
-            # 1. GAP ANALYSIS
+            # 0. RESEARCH (optional; user-supplied research_data wins)
+            if "research" in self.intermediate_results:
+                context = {**context, "research_data": self.intermediate_results["research"]}
+            elif (
+                self.search_provider is not None
+                and not context.get("research_data")
+                and self._in_template("research")
+            ):
+                if self.latency_budget is not None:
+                    self._skip_for_budget("research")
+                else:
+                    research = self._execute_research(context)
+                    if research is not None:
+                        self._check_constraints("company research", text_of(research))
+                        context = {**context, "research_data": research}
+            self._run_plugins("research", context)
+
+            # 1. GAP ANALYSIS (under a latency budget, differentiation runs alongside)
+            differentiation_result = None
             if "gap_analysis" in self.intermediate_results:
                 gap_result = self.intermediate_results["gap_analysis"]
                 self._log("Skipping Gap Analysis (already complete)")
+                # Reviewer guidance given at the greenlight travels with the analysis,
+                # so every later stage sees it.
+                if context.get("greenlight_notes"):
+                    gap_result["greenlight_notes"] = context["greenlight_notes"]
+            elif not self._in_template("gap_analysis"):
+                gap_result = {}
+            elif self.latency_budget is not None:
+                gap_result, differentiation_result = self._execute_gap_and_differentiation(
+                    context
+                )
             else:
                 gap_result = self._execute_gap_analysis(context)
+            self._run_plugins("gap_analysis", context)
+
+            # 1b. SENIORITY CALIBRATION (optional; adjusts the tailoring instructions)
+            seniority = self.intermediate_results.get("seniority")
+            if seniority is None and self._in_template("seniority"):
+                if self.latency_budget is not None:
+                    self._skip_for_budget("seniority")
+                else:
+                    seniority = self._execute_seniority(context, gap_result)
+
+            # 1c. IMPACT REWRITES (optional; its metric questions join the interview)
+            impact = self.intermediate_results.get("impact")
+            impact_questions: List[Dict[str, Any]] = []
+            if impact is None and self._in_template("impact"):
+                if self.latency_budget is not None:
+                    self._skip_for_budget("impact")
+                else:
+                    impact = self._execute_impact(context, gap_result)
+                    impact_questions = interview_questions(impact or {})
 
             # 2. INTERROGATION
             if "interrogation" in self.intermediate_results:
@@ -333,38 +1556,143 @@ class HydraWorkflow:
                 self._log("Skipping Interrogation (already complete)")
                 # Check if we have answers now
                 if "interview_answers" in context and context["interview_answers"]:
-                    interrogation_result["interview_notes"] = context["interview_answers"]
+                    notes = [
+                        *(interrogation_result.get("reused_answers") or []),
+                        *context["interview_answers"],
+                    ]
+                    interrogation_result["interview_notes"] = notes
+                    interrogation_result["experience"] = compile_experience(
+                        interrogation_result.get("questions") or [], notes
+                    )
+            elif not self._in_template("interrogation"):
+                interrogation_result = {"questions": [], "interview_notes": []}
+                if impact_questions:
+                    self._log("Impact questions not asked: the template has no interview")
+            elif self.latency_budget is not None:
+                self._skip_for_budget("interrogation")
+                interrogation_result = {"questions": [], "interview_notes": []}
             else:
-                interrogation_result = self._execute_interrogation(context, gap_result)
+                interrogation_result = self._execute_interrogation(
+                    context, gap_result, impact_questions
+                )
+            self._run_plugins("interrogation", context)
 
             # 3. DIFFERENTIATION
-            differentiation_result = self._execute_differentiation(
-                context, gap_result, interrogation_result
-            )
+            if differentiation_result is None:
+                differentiation_result = self._replayed(context, "differentiation")
+            if differentiation_result is None and not self._in_template("differentiation"):
+                differentiation_result = {}
+            elif differentiation_result is None:
+                differentiation_result = self._execute_differentiation(
+                    context, gap_result, interrogation_result
+                )
+            self._run_plugins("differentiation", context)
 
             # 4. TAILORING
-            tailoring_result = self._execute_tailoring(
-                context, gap_result, interrogation_result, differentiation_result
-            )
+            tailoring_result = self._replayed(context, "tailoring")
+            if tailoring_result is None:
+                tailoring_result = self._execute_tailoring(
+                    context,
+                    gap_result,
+                    interrogation_result,
+                    differentiation_result,
+                    impact,
+                    seniority,
+                )
+            self._run_plugins("tailoring", context)
 
             # 5. ATS OPTIMIZATION
-            ats_result = self._execute_ats_optimization(context, tailoring_result)
-
-            # 6. AUDIT
-            # Execute audit with retry loop (no longer throws exceptions)
-            final_result = self._execute_audit(context, ats_result)
-
-            # 7. EXECUTIVE SYNTHESIS
-            # Execute executive synthesis to create strategic brief
-            executive_brief = self._execute_executive_synthesis(
-                context,
-                gap_result,
-                interrogation_result,
-                differentiation_result,
-                tailoring_result,
-                ats_result,
-                final_result,
-            )
+            ats_result = self._replayed(context, "ats_optimization")
+            if ats_result is None and (
+                self._in_template("ats_optimization")
+                and self._fits_budget("ats_optimization", ("auditing",))
+            ):
+                ats_result = self._execute_ats_optimization(context, tailoring_result)
+            elif ats_result is None:
+                ats_result = {}  # the audit falls back to the tailored documents
+            self._run_plugins("ats_optimization", context)
+
+            # 6. AUDIT, then 7. EXECUTIVE SYNTHESIS (overlapped under a latency budget)
+            def _audit() -> Dict[str, Any]:
+                # Execute audit with retry loop (no longer throws exceptions)
+                audit_result = self._execute_audit(context, ats_result)
+                audit_result = self._execute_claim_verification(
+                    context, interrogation_result, audit_result
+                )
+                audit_result = self._execute_ats_lint(audit_result)
+                return self._execute_chronology_check(context, audit_result)
+
+            def _synthesis(audit_result: Dict[str, Any]) -> Optional[Dict[str, Any]]:
+                # Execute executive synthesis to create strategic brief
+                return self._execute_executive_synthesis(
+                    context,
+                    gap_result,
+                    interrogation_result,
+                    differentiation_result,
+                    tailoring_result,
+                    ats_result,
+                    audit_result,
+                )
+
+            if self.latency_budget is None:
+                final_result = _audit()
+                self._run_plugins("audit", context)
+                ats_parse = self._execute_ats_parse_check(
+                    final_result, context["job_description"]
+                )
+                executive_brief = None
+                if self._in_template("executive_synthesis"):
+                    executive_brief = _synthesis(final_result)
+            else:
+                calls = [_audit]
+                if self._in_template("executive_synthesis") and self._fits_budget(
+                    "executive_synthesis"
+                ):
+                    calls.append(lambda: _synthesis({}))
+                results = complete_batch(calls, self.cancel_token)
+                for result in results:
+                    if not result.ok:
+                        raise result.error
+                final_result = results[0].value
+                executive_brief = results[1].value if len(results) > 1 else None
+                self._run_plugins("audit", context)
+                ats_parse = self._execute_ats_parse_check(
+                    final_result, context["job_description"]
+                )
+            self._run_plugins("executive_synthesis", context)
+
+            # 9. COMPENSATION (optional; never fails the run)
+            compensation_brief = self.intermediate_results.get("compensation")
+            if self.compensation and compensation_brief is None:
+                if self.latency_budget is not None:
+                    self._skip_for_budget("compensation")
+                else:
+                    compensation_brief = self._execute_compensation(
+                        context, differentiation_result, executive_brief
+                    )
+
+            # 10. OUTREACH (referral template; never fails the run)
+            outreach = self.intermediate_results.get("outreach")
+            if outreach is None and self._in_template("outreach"):
+                outreach = self._execute_outreach(context, differentiation_result, final_result)
+
+            # 11. REFERRALS (with the candidate's contacts; never fails the run)
+            referrals = self.intermediate_results.get("referrals")
+            if self.contacts and referrals is None:
+                if self.latency_budget is not None:
+                    self._skip_for_budget("referrals")
+                else:
+                    referrals = self._execute_referrals(
+                        context, differentiation_result, final_result
+                    )
+
+            # 12. JUDGE (optional; never fails the run)
+            judgement = self.intermediate_results.get("judge")
+            if self.judge and judgement is None:
+                if self.latency_budget is not None:
+                    self._skip_for_budget("judge")
+                else:
+                    judgement = self._execute_judge(context, final_result)
 
             # Documents were produced; classify the outcome explicitly.
             audit_failed = final_result.get("audit_failed", False)
@@ -386,11 +1714,39 @@ class HydraWorkflow:
                 final_documents=final_result.get("final_documents"),
                 audit_report=final_result.get("audit_report"),
                 executive_brief=executive_brief,
-                execution_log=self.execution_log.copy(),
+                execution_log=self.get_execution_log(),
                 intermediate_results=self.get_intermediate_results(),
                 audit_failed=audit_failed,
                 audit_error=final_result.get("audit_error"),
                 agent_models=self.agent_models,
+                context_usage=self.context_usage,
+                tailoring_variants=self.variant_candidates,
+                usage=self.usage_ledger.summary(),
+                tool_transcripts=self.tool_transcripts,
+                prompt_transcripts=self.prompt_transcripts,
+                latency_budget=self._budget_summary(),
+                retention=self.retention.describe(self.intermediate_results),
+                ats_parse=ats_parse,
+                cover_letter_overlap=self.cover_letter_overlap,
+                length=self.length_enforcement,
+                contact_header=self.contact_header,
+                json_resume=self.json_resume,
+                seniority=seniority,
+                impact=impact,
+                compensation_brief=compensation_brief,
+                outreach=outreach,
+                referrals=referrals,
+                judge=judgement,
+                errors=self._error_summary(),
+                pii_redaction=self.redactor.summary() if self.redactor else None,
+                stage_providers=self._provider_summary(),
+                stage_confidence=self._confidence_summary(),
+                reflection=self._reflection_summary(),
+                cost_budget=self._spend_summary(),
+                template=self.template.to_dict(),
+                audit_events=self.audit_events,
+                prompt_pack=self._pack_summary(),
+                constraints=self._constraint_summary(),
             )
 
         except WorkflowPaused as e:
@@ -400,10 +1756,54 @@ class HydraWorkflow:
                 state=self.current_state,
                 success=True,  # It's a successful "pause"
                 status=RunStatus.PAUSED,
-                execution_log=self.execution_log.copy(),
+                execution_log=self.get_execution_log(),
                 intermediate_results=self.get_intermediate_results(),
                 error_message=e.message,  # Use error message field for pause reason
                 agent_models=self.agent_models,
+                context_usage=self.context_usage,
+                tailoring_variants=self.variant_candidates,
+                usage=self.usage_ledger.summary(),
+                tool_transcripts=self.tool_transcripts,
+                prompt_transcripts=self.prompt_transcripts,
+                latency_budget=self._budget_summary(),
+                retention=self.retention.describe(self.intermediate_results),
+                errors=self._error_summary(),
+                pii_redaction=self.redactor.summary() if self.redactor else None,
+                stage_providers=self._provider_summary(),
+                stage_confidence=self._confidence_summary(),
+                reflection=self._reflection_summary(),
+                cost_budget=self._spend_summary(),
+                template=self.template.to_dict(),
+                audit_events=self.audit_events,
+                prompt_pack=self._pack_summary(),
+            )
+
+        except RunCancelled as e:
+            self._log(f"Workflow INTERRUPTED: {e}; completed stages kept for resuming")
+            return WorkflowResult(
+                state=self.current_state,
+                success=False,
+                status=RunStatus.INTERRUPTED,
+                execution_log=self.get_execution_log(),
+                intermediate_results=self.get_intermediate_results(),
+                error_message=f"Interrupted: {e}",
+                agent_models=self.agent_models,
+                context_usage=self.context_usage,
+                tailoring_variants=self.variant_candidates,
+                usage=self.usage_ledger.summary(),
+                tool_transcripts=self.tool_transcripts,
+                prompt_transcripts=self.prompt_transcripts,
+                latency_budget=self._budget_summary(),
+                retention=self.retention.describe(self.intermediate_results),
+                errors=self._error_summary(),
+                pii_redaction=self.redactor.summary() if self.redactor else None,
+                stage_providers=self._provider_summary(),
+                stage_confidence=self._confidence_summary(),
+                reflection=self._reflection_summary(),
+                cost_budget=self._spend_summary(),
+                template=self.template.to_dict(),
+                audit_events=self.audit_events,
+                prompt_pack=self._pack_summary(),
             )
 
         except Exception as e:
@@ -416,11 +1816,38 @@ class HydraWorkflow:
                 success=False,
                 status=RunStatus.FAILED,
                 error_message=error_msg,
-                execution_log=self.execution_log.copy(),
+                execution_log=self.get_execution_log(),
                 intermediate_results=self.get_intermediate_results(),  # Include partial results on failure
                 agent_models=self.agent_models,
+                context_usage=self.context_usage,
+                tailoring_variants=self.variant_candidates,
+                usage=self.usage_ledger.summary(),
+                tool_transcripts=self.tool_transcripts,
+                prompt_transcripts=self.prompt_transcripts,
+                latency_budget=self._budget_summary(),
+                retention=self.retention.describe(self.intermediate_results),
+                errors=self._error_summary(),
+                pii_redaction=self.redactor.summary() if self.redactor else None,
+                stage_providers=self._provider_summary(),
+                stage_confidence=self._confidence_summary(),
+                reflection=self._reflection_summary(),
+                cost_budget=self._spend_summary(),
+                template=self.template.to_dict(),
+                audit_events=self.audit_events,
+                prompt_pack=self._pack_summary(),
+                constraints=self._constraint_summary(),
             )
 
+    def _share_context(self, context: Dict[str, Any]) -> None:
+        """Give every agent the run's résumé and sources, which it sends as one
+        leading system message the provider caches across stages (see prompt_cache)."""
+        self.shared_context = {
+            "resume": context["resume"] or "",
+            "source_documents": context["source_documents"] or "",
+        }
+        for agent in self._agents():
+            agent.shared_context = self.shared_context
+
     def _validate_input_context(self, context: Dict[str, Any]) -> None:
         """Validate required input context"""
         required_keys = ["job_description", "resume", "source_documents"]
@@ -428,14 +1855,388 @@ class HydraWorkflow:
             if key not in context:
                 raise ValidationError(f"Missing required context key: {key}")
 
+    def _execute_research(self, context: Dict[str, Any]) -> Optional[Dict[str, Any]]:
+        """Gather cited company research; a failure here never fails the run.
+
+        Only the job description, company and role are sent: research is about the
+        employer, so the résumé and sources stay out of the search loop.
+        """
+        self.current_state = WorkflowState.RESEARCH
+        self._log("Executing Company Research")
+
+        with trace_workflow_stage("research") as span:
+            research_context = {
+                "job_description": context["job_description"],
+                "company": context.get("company"),
+                "target_role": context.get("target_role"),
+            }
+            try:
+                result = self._execute_with_fallback(
+                    self.research_agent, research_context, "research_agent"
+                )
+            except Exception as e:
+                self._log(f"Research failed, continuing without it: {e}")
+                span.set_attribute("stage.error", str(e))
+                return None
+            self._record("research", result)
+
+            span.set_attribute("stage.searches", len(result.get("searches", [])))
+            span.set_attribute("stage.sources", len(result.get("sources", [])))
+            span.set_attribute("stage.uncited_dropped", result.get("uncited_dropped", 0))
+            self._log(
+                f"Research: {len(result.get('sources', []))} sources from "
+                f"{len(result.get('searches', []))} searches"
+            )
+        return result
+
+    def _execute_seniority(
+        self, context: Dict[str, Any], gap_result: Dict[str, Any]
+    ) -> Optional[Dict[str, Any]]:
+        """Check the résumé's framing against the role's level and write the tailoring
+        instructions that correct it; a failure never fails the run.
+
+        Software reads the levels and decides the verdict (see seniority); the agent is
+        only called for a résumé that reads above or below the role, and its notes are
+        added to software's instructions, which stand without them.
+        """
+        self.current_state = WorkflowState.SENIORITY
+        self._log("Executing Seniority Calibrator")
+
+        with trace_workflow_stage("seniority") as span:
+            try:
+                result = calibrate(context["job_description"], context["resume"])
+            except Exception as e:
+                self._log(f"Seniority calibration failed, continuing without it: {e}")
+                span.set_attribute("stage.error", str(e))
+                return None
+            span.set_attribute("stage.verdict", result["verdict"])
+            if result["verdict"] != MATCHED:
+                calibrator_context = {
+                    "job_description": context["job_description"],
+                    "resume": context["resume"],
+                    "source_documents": context.get("source_documents"),
+                    "gap_analysis": gap_result,
+                    "calibration": result,
+                }
+                try:
+                    notes = self._execute_with_fallback(
+                        self.seniority_calibrator, calibrator_context, "seniority"
+                    )
+                    result["findings"] = notes.get("findings") or []
+                    result["instructions"] = list(
+                        dict.fromkeys([*result["instructions"], *(notes.get("instructions") or [])])
+                    )
+                except Exception as e:
+                    self._log(f"Seniority calibrator failed, using software's instructions: {e}")
+                    span.set_attribute("stage.error", str(e))
+            self._record("seniority", result)
+            span.set_attribute("stage.findings", len(result["findings"]))
+            self._log(
+                f"Seniority: the role is {result['role_level']}, the résumé reads "
+                f"{result['resume_level']} ({result['verdict']}"
+                + (f", {len(result['findings'])} bullet note(s)" if result["findings"] else "")
+                + ")"
+            )
+        return result
+
+    def _execute_impact(
+        self, context: Dict[str, Any], gap_result: Dict[str, Any]
+    ) -> Optional[Dict[str, Any]]:
+        """Rewrite the résumé's weak bullets as impact statements and collect the
+        metric questions for the interview; a failure never fails the run.
+
+        The weak bullets are picked by software; with none, no model is called.
+        """
+        self.current_state = WorkflowState.IMPACT
+        self._log("Executing Impact Rewriter")
+
+        with trace_workflow_stage("impact") as span:
+            weak = weak_bullets(context["resume"])
+            span.set_attribute("stage.weak_bullets", len(weak))
+            result: Dict[str, Any] = {"bullets": [], "invented": []}
+            if weak:
+                impact_context = {
+                    "job_description": context["job_description"],
+                    "resume": context["resume"],
+                    "source_documents": context.get("source_documents"),
+                    "gap_analysis": gap_result,
+                    "bullets": weak,
+                }
+                try:
+                    result = self._execute_with_fallback(
+                        self.impact_rewriter, impact_context, "impact"
+                    )
+                except Exception as e:
+                    self._log(f"Impact rewriter failed, continuing without it: {e}")
+                    span.set_attribute("stage.error", str(e))
+                    return None
+            self._record("impact", result)
+
+            rewritten = len(tailoring_suggestions(result))
+            questions = len(interview_questions(result))
+            span.set_attribute("stage.rewritten", rewritten)
+            span.set_attribute("stage.questions", questions)
+            dropped = len(result.get("invented") or [])
+            self._log(
+                f"Impact: {rewritten} of {len(weak)} weak bullet(s) rewritten, "
+                f"{questions} metric question(s) for the interview"
+                + (f" ({dropped} rewrite(s) dropped for invented numbers)" if dropped else "")
+            )
+        return result
+
+    def _execute_compensation(
+        self,
+        context: Dict[str, Any],
+        differentiation_result: Dict[str, Any],
+        executive_brief: Optional[Dict[str, Any]],
+    ) -> Optional[Dict[str, Any]]:
+        """Prepare the negotiation brief; a failure here never fails the run.
+
+        The résumé and sources are not sent: the analyst works from the posting, the
+        cited research, the candidate's targets and the differentiators.
+        """
+        self.current_state = WorkflowState.COMPENSATION
+        self._log("Executing Compensation Analysis")
+
+        with trace_workflow_stage("compensation") as span:
+            research = self.intermediate_results.get("research")
+            if research is None and isinstance(context.get("research_data"), dict):
+                research = context["research_data"]
+            compensation_context = {
+                "job_description": context["job_description"],
+                "research": research,
+                "compensation_targets": context.get("compensation_targets"),
+                "differentiation": differentiation_result,
+                "executive_brief": executive_brief,
+            }
+            try:
+                result = self._execute_with_fallback(
+                    self.compensation_agent, compensation_context, "compensation"
+                )
+            except Exception as e:
+                self._log(f"Compensation analysis failed, continuing without it: {e}")
+                span.set_attribute("stage.error", str(e))
+                return None
+            self._record("compensation", result)
+
+            ranges = result.get("market_ranges", [])
+            estimated = sum(1 for r in ranges if r.get("basis") == "model estimate")
+            span.set_attribute("stage.market_ranges", len(ranges))
+            span.set_attribute("stage.estimated_ranges", estimated)
+            self._log(f"Compensation: {len(ranges)} market ranges ({estimated} estimated)")
+        return result
+
+    def _execute_outreach(
+        self,
+        context: Dict[str, Any],
+        differentiation_result: Dict[str, Any],
+        final_result: Dict[str, Any],
+    ) -> Optional[Dict[str, Any]]:
+        """Draft the messages to the hiring manager or recruiter; a failure never fails
+        the run.
+
+        Written from the audited résumé, so it claims nothing the audit did not see.
+        """
+        self.current_state = WorkflowState.OUTREACH
+        self._log("Executing Outreach")
+
+        with trace_workflow_stage("outreach") as span:
+            research = self.intermediate_results.get("research")
+            if research is None and isinstance(context.get("research_data"), dict):
+                research = context["research_data"]
+            documents = final_result.get("final_documents") or {}
+            outreach_context = {
+                "job_description": context["job_description"],
+                "company": context.get("company"),
+                "target_role": context.get("target_role"),
+                "recipient": context.get("outreach_recipient"),
+                "research": research,
+                "differentiation": differentiation_result,
+                "tailored_resume": documents.get("resume", ""),
+            }
+            try:
+                result = self._execute_with_fallback(
+                    self.outreach_agent, outreach_context, "outreach"
+                )
+            except Exception as e:
+                self._log(f"Outreach failed, continuing without it: {e}")
+                span.set_attribute("stage.error", str(e))
+                return None
+            self._pick_outreach_options(result)
+            self._record("outreach", result)
+
+            variants = result.get("variants") or {}
+            trimmed = [name for name, variant in variants.items() if variant.get("trimmed")]
+            span.set_attribute("stage.variants", len(variants))
+            span.set_attribute("stage.trimmed", len(trimmed))
+            self._log(
+                f"Outreach: {len(variants)} message(s) to the {result.get('recipient')}"
+                + (f" (trimmed to fit: {', '.join(trimmed)})" if trimmed else "")
+            )
+        return result
+
+    def _pick_outreach_options(self, result: Dict[str, Any]) -> None:
+        """Rank the subject lines and openers by the angles chosen before and, in an
+        interactive run, let the user pick one of each."""
+        for kind, options in option_lists(result).items():
+            if self.outreach_preferences is not None:
+                result[f"{kind}s"] = options = rank_options(
+                    kind, options, self.outreach_preferences
+                )
+            if not self.interactive:
+                continue
+            rank = self.user_interaction.pick_outreach(kind, options)
+            if rank is not None:
+                record_choice(result, kind, rank, preferences=self.outreach_preferences)
+                self._log(f"Outreach {kind.replace('_', ' ')}: picked option {rank}")
+
+    def _execute_referrals(
+        self,
+        context: Dict[str, Any],
+        differentiation_result: Dict[str, Any],
+        final_result: Dict[str, Any],
+    ) -> Optional[Dict[str, Any]]:
+        """Find the contacts at the target company and draft an ask for each; a
+        failure never fails the run.
+
+        Only the matched contacts reach the model. With none, no model is called.
+        """
+        self.current_state = WorkflowState.REFERRALS
+        self._log("Executing Referral Finder")
+
+        with trace_workflow_stage("referrals") as span:
+            company = context.get("company")
+            if not company:
+                self._log("Referrals skipped: no target company (pass --company)")
+                return None
+            matches = referral_paths(self.contacts, company)
+            span.set_attribute("stage.contacts", len(self.contacts))
+            span.set_attribute("stage.matches", len(matches))
+            result: Dict[str, Any] = {"contacts": matches}
+            if matches:
+                documents = final_result.get("final_documents") or {}
+                referral_context = {
+                    "job_description": context["job_description"],
+                    "company": company,
+                    "target_role": context.get("target_role"),
+                    "contacts": matches,
+                    "differentiation": differentiation_result,
+                    "tailored_resume": documents.get("resume", ""),
+                }
+                try:
+                    result = self._execute_with_fallback(
+                        self.referral_agent, referral_context, "referrals"
+                    )
+                except Exception as e:
+                    self._log(f"Referral finder failed, continuing without it: {e}")
+                    span.set_attribute("stage.error", str(e))
+                    return None
+            result = {**result, "company": company, "contacts_checked": len(self.contacts)}
+            self._record("referrals", result)
+            self._log(
+                f"Referrals: {len(matches)} of {len(self.contacts)} contact(s) at {company}"
+            )
+        return result
+
+    def _execute_judge(
+        self, context: Dict[str, Any], final_result: Dict[str, Any]
+    ) -> Optional[Dict[str, Any]]:
+        """Score the final résumé on the quality rubrics; a failure never fails the run."""
+        self.current_state = WorkflowState.JUDGE
+        self._log("Executing Judge")
+
+        with trace_workflow_stage("judge") as span:
+            documents = final_result.get("final_documents") or {}
+            judge_context = {
+                "job_description": context["job_description"],
+                "tailored_resume": documents.get("resume", ""),
+                "source_documents": context.get("source_documents", ""),
+            }
+            try:
+                result = self._execute_with_fallback(self.judge_agent, judge_context, "judge")
+            except Exception as e:
+                self._log(f"Judge failed, continuing without it: {e}")
+                span.set_attribute("stage.error", str(e))
+                return None
+            self._record("judge", result)
+
+            for criterion, item in result["scores"].items():
+                span.set_attribute(f"stage.{criterion}", item["score"])
+            self._log(
+                "Judge: "
+                + ", ".join(f"{c} {item['score']}" for c, item in result["scores"].items())
+            )
+        return result
+
+    def _run_plugins(self, after: str, context: Dict[str, Any]) -> None:
+        """Run the plugins that follow stage ``after``, keeping each one's output.
+
+        An optional plugin's failure is recorded and the run goes on; a required
+        plugin's fails the run. Plugins with output already (a resumed run) are skipped.
+        """
+        for plugin in self.plugins:
+            if plugin.after != after or plugin.name in self.intermediate_results:
+                continue
+            if self.dry_run:
+                self._log(f"Dry run: not running plugin {plugin.name}")
+                continue
+            self.cancel_token.check(plugin.name)
+            self._log(f"Executing plugin {plugin.name}")
+            started = time.monotonic()
+            with trace_workflow_stage(plugin.name) as span:
+                try:
+                    output, log = self.cancel_token.run(
+                        plugin.name,
+                        lambda plugin=plugin: run_plugin(
+                            plugin,
+                            context,
+                            self.get_intermediate_results(),
+                            host=self.plugin_host,
+                        ),
+                    )
+                except PluginError as e:
+                    span.set_attribute("stage.error", str(e))
+                    if plugin.required:
+                        raise
+                    self._log(f"Plugin failed, continuing without it: {e}")
+                    with self._state_lock:
+                        self.errors.append(
+                            WorkflowError(
+                                stage=plugin.name,
+                                kind="plugin",
+                                message=str(e),
+                                scope="stage",
+                                seconds=round(time.monotonic() - started, 3),
+                                at=datetime.now().isoformat(),
+                            )
+                        )
+                    continue
+            for line in log:
+                self._log(f"[{plugin.name}] {line}")
+            self._record(plugin.name, output)
+
     def _execute_gap_analysis(self, context: Dict[str, Any]) -> Dict[str, Any]:
         """Execute gap analysis stage"""
         self.current_state = WorkflowState.GAP_ANALYSIS
         self._log("Executing Gap Analysis")
 
         with trace_workflow_stage("gap_analysis") as span:
+            # The résumé's skills under the JD's names, so "K8s" is not reported missing
+            # when the résumé says "Kubernetes" (see skill_taxonomy).
+            coverage = skill_coverage(
+                context["job_description"], context["resume"], self.skill_taxonomy
+            )
+            if coverage.synonyms:
+                context = {**context, "skill_synonyms": coverage.synonyms}
             result = self._execute_with_fallback(self.gap_analyzer, context, "gap_analysis")
-            self.intermediate_results["gap_analysis"] = result
+            # Python scores the findings and makes the go / no-go call (see fit_score).
+            fit = assess_fit(result, context["job_description"], context["resume"])
+            if fit.score is not None:
+                result["fit"] = fit.to_dict()
+                span.set_attribute("stage.fit_score", fit.score)
+            self._record("gap_analysis", result)
+            span.set_attribute("stage.skill_synonyms", len(coverage.synonyms))
+            self._check_constraints("gap analysis", requirements_text(result))
 
             # Record metrics
             gaps_count = len(result.get("gaps", []))
@@ -443,12 +2244,14 @@ class HydraWorkflow:
             span.set_attribute("stage.confidence", result.get("confidence", 0))
 
             if self.interactive:
-                print("\n📊 GAP ANALYSIS COMPLETE")
-                # Ideally print specific gaps here, but for now just pause
-                if not UserInteraction.ask_yes_no("Proceed with these findings?"):
+                approved = self.user_interaction.greenlight_gap_analysis(result)
+                self._audit(GREENLIGHT, approved=approved)
+                if not approved:
                     self._log("User aborted after Gap Analysis")
                     raise Exception("User aborted workflow")
-            elif not context.get("gap_analysis_approved", False) and not self.auto_approve:
+            elif self.auto_approve and not context.get("gap_analysis_approved", False):
+                self._audit(GREENLIGHT, approved=True, auto_approved=True)
+            elif not context.get("gap_analysis_approved", False):
                 # Async web mode: pause for real human approval.
                 span.set_attribute("stage.paused", True)
                 raise WorkflowPaused(
@@ -458,9 +2261,13 @@ class HydraWorkflow:
         return result
 
     def _execute_interrogation(
-        self, context: Dict[str, Any], gap_result: Dict[str, Any]
+        self,
+        context: Dict[str, Any],
+        gap_result: Dict[str, Any],
+        impact_questions: Optional[List[Dict[str, Any]]] = None,
     ) -> Dict[str, Any]:
-        """Execute interrogation preparation stage"""
+        """Execute interrogation preparation stage; ``impact_questions`` (see impact)
+        are asked after the prepper's own."""
         self.current_state = WorkflowState.INTERROGATION
         self._log("Executing Interrogation Preparation")
 
@@ -478,33 +2285,52 @@ class HydraWorkflow:
             result = self._execute_with_fallback(
                 self.interrogator_prepper, interrogation_context, "interrogation"
             )
-            self.intermediate_results["interrogation"] = result
+            # One shape for every front end: numbered dicts (see interview).
+            result["questions"] = normalize_questions(
+                [*normalize_questions(result.get("questions")), *(impact_questions or [])]
+            )
+            self._record("interrogation", result)
 
-            questions = result.get("questions", [])
+            questions = result["questions"]
             span.set_attribute("stage.questions_generated", len(questions))
 
-            if not questions:
+            # An earlier run's answers: matched questions are not asked again.
+            reused, to_ask = [], questions
+            earlier = context.get("reused_interview")
+            if earlier:
+                reused, to_ask = reuse_answers(questions, earlier["entries"], earlier["run_id"])
+                result["reused_answers"] = reused
+                self._log(
+                    f"Interview: reused {len(reused)} answer(s) from {earlier['run_id']}; "
+                    f"{len(to_ask)} of {len(questions)} question(s) left to ask"
+                )
+
+            if not questions and not reused:
                 self._log("No interview questions needed (no skill gaps to address)")
                 return result
 
-            if self.interactive:
-                answers = UserInteraction.conduct_interview(questions)
-                # Merge answers into result
-                result["interview_notes"] = answers
-                self._log("User completed interactive interview")
+            if not to_ask:
+                answers = []
+            elif self.interactive:
+                answers = self.user_interaction.conduct_interview(to_ask)
+                self._log(f"Interview: {len(answers)} of {len(to_ask)} question(s) answered")
+                self._audit(INTERVIEW_ANSWERS, answered=len(answers), asked=len(to_ask))
             elif context.get("interview_answers"):
-                result["interview_notes"] = context["interview_answers"]
+                answers = context["interview_answers"]
             elif self.auto_approve:
                 # Non-interactive CLI: the interview is optional gap-filling; proceed
                 # with no additional answers rather than pausing with no way to resume.
                 self._log("Auto-approve: proceeding without interview answers")
-                result["interview_notes"] = []
+                answers = []
             else:
                 # Async web mode: pause for real human answers.
                 span.set_attribute("stage.paused", True)
                 raise WorkflowPaused(
                     WorkflowState.INTERROGATION_REVIEW, "Waiting for interview answers"
                 )
+            result["interview_notes"] = [*reused, *answers]
+            # What tailoring reads: each answer with its theme, gap, metrics and tools.
+            result["experience"] = compile_experience(questions, result["interview_notes"])
 
         return result
 
@@ -529,7 +2355,7 @@ class HydraWorkflow:
             result = self._execute_with_fallback(
                 self.differentiator, differentiation_context, "differentiation"
             )
-            self.intermediate_results["differentiation"] = result
+            self._record("differentiation", result)
 
             # Record metrics
             differentiators_count = len(result.get("differentiators", []))
@@ -544,6 +2370,8 @@ class HydraWorkflow:
         gap_result: Dict[str, Any],
         interrogation_result: Dict[str, Any],
         differentiation_result: Dict[str, Any],
+        impact: Optional[Dict[str, Any]] = None,
+        seniority: Optional[Dict[str, Any]] = None,
     ) -> Dict[str, Any]:
         """Execute tailoring stage"""
         self.current_state = WorkflowState.TAILORING
@@ -555,14 +2383,26 @@ class HydraWorkflow:
                 **context,
                 "gap_analysis": gap_result,
                 "interview_notes": interrogation_result.get("interview_notes", ""),
+                "interview_experience": interrogation_result.get("experience") or [],
                 "interrogation_prep": interrogation_result,
                 "differentiation": differentiation_result,
                 "differentiators": differentiation_result.get("differentiators", []),
             }
-            result = self._execute_with_fallback(
-                self.tailoring_agent, tailoring_context, "tailoring"
-            )
-            self.intermediate_results["tailoring"] = result
+            if impact and tailoring_suggestions(impact):
+                tailoring_context["impact_rewrites"] = tailoring_suggestions(impact)
+            if tailoring_brief(seniority):
+                tailoring_context["seniority_calibration"] = tailoring_brief(seniority)
+            if self.tailoring_variants:
+                result = self._execute_tailoring_variants(context, tailoring_context)
+            else:
+                result = self._execute_with_fallback(
+                    self.tailoring_agent, tailoring_context, "tailoring"
+                )
+            result = self._differentiate_cover_letter(tailoring_context, result)
+            result = self._enforce_length(tailoring_context, result)
+            if "json_resume" in context:
+                self._keep_json_resume(result)
+            self._record("tailoring", result)
 
             docs = TailoredDocuments.from_raw(result)
             span.set_attribute("stage.confidence", result.get("confidence", 0))
@@ -571,6 +2411,186 @@ class HydraWorkflow:
 
         return result
 
+    def _keep_json_resume(self, result: Dict[str, Any]) -> None:
+        """Keep the tailored JSON Resume document if the agent returned a valid one."""
+        document = extract_tailored(result)
+        if document is None:
+            self._log("Tailoring returned no JSON Resume document; resume.json not written")
+            return
+        errors = validate_json_resume(document)
+        if errors:
+            self._log(f"Tailored JSON Resume is invalid ({'; '.join(errors[:3])}); not written")
+            return
+        self.json_resume = document
+
+    def _differentiate_cover_letter(
+        self, tailoring_context: Dict[str, Any], result: Dict[str, Any]
+    ) -> Dict[str, Any]:
+        """Send the tailoring agent back while the cover letter repeats paragraphs from
+        other recent applications; a rewrite is kept only if it repeats less."""
+        if self.dry_run or not self.other_cover_letters:
+            return result
+        if self.latency_budget is not None:
+            self._skip_for_budget("cover_letter_rewrites")
+            return result
+
+        def _overlaps(output: Dict[str, Any]):
+            letter = TailoredDocuments.from_raw(output).cover_letter
+            return find_overlaps(letter, self.other_cover_letters)
+
+        overlaps = _overlaps(result)
+        report = {
+            "compared_letters": len(self.other_cover_letters),
+            "initial_overlaps": len(overlaps),
+            "rewrites": 0,
+        }
+        for _ in range(MAX_DIFFERENTIATION_REWRITES):
+            if not overlaps:
+                break
+            self._log(
+                f"Cover letter repeats {len(overlaps)} paragraph(s) from other applications; "
+                "asking for a rewrite"
+            )
+            rewrite_context = {
+                **tailoring_context,
+                "templated_paragraphs": [overlap.text for overlap in overlaps],
+            }
+            try:
+                candidate = self._execute_with_fallback(
+                    self.tailoring_agent, rewrite_context, "tailoring"
+                )
+            except Exception as e:
+                self._log(f"Cover letter rewrite failed: {e}")
+                break
+            report["rewrites"] += 1
+            remaining = _overlaps(candidate)
+            if len(remaining) <= len(overlaps):
+                result, overlaps = candidate, remaining
+
+        report["overlaps"] = [overlap.to_dict() for overlap in overlaps]
+        self.cover_letter_overlap = report
+        return result
+
+    def _enforce_length(
+        self, tailoring_context: Dict[str, Any], result: Dict[str, Any]
+    ) -> Dict[str, Any]:
+        """Trim a résumé over the length limits: the tailoring agent is sent back with
+        what is over (a trim is kept only if closer to the limits), then bullets still
+        over the per-role limit are cut. Never fails the run."""
+        resume = TailoredDocuments.from_raw(result).resume
+        if self.dry_run or not self.length.enabled or not resume:
+            return result
+
+        report = before = check_length(resume, self.length)
+        enforcement = {"limits": self.length.to_dict(), "trims": 0, "bullets_cut": 0}
+        if report.violations and self.latency_budget is not None:
+            self._skip_for_budget("length_trims")
+        elif report.violations:
+            for _ in range(self.length.trim_passes):
+                if not report.violations:
+                    break
+                self._log(f"Résumé over its limits ({'; '.join(report.violations)}); trimming")
+                trim_context = {
+                    **tailoring_context,
+                    "length_limits": {
+                        "instructions": report.instructions(),
+                        "resume": TailoredDocuments.from_raw(result).resume,
+                    },
+                }
+                try:
+                    candidate = self._execute_with_fallback(
+                        self.tailoring_agent, trim_context, "tailoring"
+                    )
+                except Exception as e:
+                    self._log(f"Résumé trim failed: {e}")
+                    break
+                enforcement["trims"] += 1
+                trimmed = TailoredDocuments.from_raw(candidate).resume
+                candidate_report = check_length(trimmed, self.length) if trimmed else None
+                if candidate_report is not None and candidate_report.excess < report.excess:
+                    result, report = candidate, candidate_report
+
+        if report.long_roles:
+            limit = self.length.max_bullets_per_role
+            enforcement["bullets_cut"] = sum(role["bullets"] - limit for role in report.long_roles)
+            trimmed = cut_bullets(TailoredDocuments.from_raw(result).resume, limit)
+            result = self._with_resume(result, trimmed)
+            report = check_length(trimmed, self.length)
+            self._log(f"Cut {enforcement['bullets_cut']} bullet(s) over the per-role limit")
+
+        if before.violations:
+            self._log(f"Résumé length: {'; '.join(report.violations) or 'within the limits'}")
+        self.length_enforcement = {
+            **enforcement,
+            "before": before.to_dict(),
+            "after": report.to_dict(),
+        }
+        return result
+
+    @staticmethod
+    def _with_resume(result: Dict[str, Any], resume: str) -> Dict[str, Any]:
+        """``result`` with its tailored résumé replaced, in both output shapes."""
+        output = result.get("tailored_output")
+        if isinstance(output, dict) and output.get("resume"):
+            output = {**output, "resume": resume}
+            return {**result, "tailored_output": output, "tailored_resume": resume}
+        return {**result, "tailored_resume": resume}
+
+    def _execute_tailoring_variants(
+        self, context: Dict[str, Any], tailoring_context: Dict[str, Any]
+    ) -> Dict[str, Any]:
+        """Tailor with every spec in parallel, then keep the winner's output."""
+        self._log(f"Tailoring with {len(self.tailoring_variants)} models in parallel")
+
+        def _tailor(spec: str) -> Dict[str, Any]:
+            agent = TailoringAgent(get_llm_for_spec(spec, "tailoring_agent"))
+            agent.stage_cache = self.stage_cache
+            agent.usage_ledger = self.usage_ledger
+            agent.cancel_token = self.cancel_token
+            agent.rate_limit_owner = self.rate_limit_owner
+            agent.call_timeout = self.timeouts.llm_call
+            agent.redactor = self.redactor
+            agent.circuit = self.tailoring_agent.circuit
+            agent.seed = self.tailoring_agent.seed
+            agent.prompt = self.tailoring_agent.prompt
+            agent.shared_context = self.shared_context
+            agent.tools = self.tailoring_agent.tools
+            agent.on_output = self.tailoring_agent.on_output
+            result = self._timed(
+                agent,
+                "tailoring",
+                lambda: self._reflected(agent, "tailoring")(
+                    self._fit_context(agent, tailoring_context, "tailoring")
+                ),
+            )
+            self._record_tool_calls(agent, f"tailoring:{spec}")
+            self._record_prompts(agent, f"tailoring:{spec}")
+            return result
+
+        candidates = run_variants(
+            self.tailoring_variants, _tailor, cancel_token=self.cancel_token
+        )
+        self.variant_candidates = candidates
+        for candidate in candidates:
+            if candidate.error:
+                self._log(f"Tailoring variant {candidate.spec} failed: {candidate.error}")
+        if all(candidate.error for candidate in candidates):
+            raise ValueError("Every tailoring variant failed")
+
+        judge_by_audit(candidates, lambda resume: self._audit_document(context, resume, "resume"))
+        if self.variant_pick == PICK_ASK and self.interactive:
+            winner = self.user_interaction.pick_variant(candidates)
+        else:
+            winner = pick_by_audit(candidates)
+        winner.winner = True
+        self.agent_models["tailoring_agent"] = winner.spec
+        self._log(f"Tailoring variant selected: {winner.spec}")
+        if self.variant_preferences is not None:
+            self.variant_preferences.record(
+                winner.spec, [c.spec for c in candidates if not c.error]
+            )
+        return winner.result
+
     def _execute_ats_optimization(
         self, context: Dict[str, Any], tailoring_result: Dict[str, Any]
     ) -> Dict[str, Any]:
@@ -593,7 +2613,7 @@ class HydraWorkflow:
             result = self._execute_with_fallback(
                 self.ats_optimizer, ats_context, "ats_optimization"
             )
-            self.intermediate_results["ats_optimization"] = result
+            self._record("ats_optimization", result)
 
             # Record ATS score if available
             ats_report = result.get("ats_report", {})
@@ -627,14 +2647,31 @@ class HydraWorkflow:
                 "resume": ats.optimized_resume or tailored.resume,
                 "cover_letter": ats.optimized_cover_letter or tailored.cover_letter,
             }
+            documents["resume"] = self._preserve_contact_header(context, documents["resume"])
 
             try:
-                resume_audit = self._audit_document(context, documents["resume"], "resume")
-                cover_letter_audit = (
-                    self._audit_document(context, documents["cover_letter"], "cover_letter")
-                    if documents["cover_letter"]
-                    else None
-                )
+                if self.latency_budget is not None and documents["cover_letter"]:
+                    # Quick apply: audit both documents at once.
+                    audits = complete_batch(
+                        [
+                            lambda: self._audit_document(context, documents["resume"], "resume"),
+                            lambda: self._audit_document(
+                                context, documents["cover_letter"], "cover_letter"
+                            ),
+                        ],
+                        self.cancel_token,
+                    )
+                    for audit in audits:
+                        if not audit.ok:
+                            raise audit.error
+                    resume_audit, cover_letter_audit = (audit.value for audit in audits)
+                else:
+                    resume_audit = self._audit_document(context, documents["resume"], "resume")
+                    cover_letter_audit = (
+                        self._audit_document(context, documents["cover_letter"], "cover_letter")
+                        if documents["cover_letter"]
+                        else None
+                    )
             except Exception as e:
                 self._log(f"Audit crashed: {e}")
                 span.set_attribute("stage.final_status", "AUDIT_ERROR")
@@ -676,6 +2713,186 @@ class HydraWorkflow:
                 "audit_error": None if approved else "Document did not pass audit",
             }
 
+    def _preserve_contact_header(self, context: Dict[str, Any], resume: str) -> str:
+        """``resume`` with the baseline's name, email, phone and links restored in its
+        header (see runtime.crewai.contact_header), so the audit sees them."""
+        if self.dry_run or not resume:
+            return resume
+        check = preserve_header(resume, context.get("resume", ""))
+        self.contact_header = check.to_dict()
+        if check.restored:
+            fields = ", ".join(f"{c.field} {c.change}" for c in check.changes)
+            self._log(f"Restored the résumé header from the baseline ({fields})")
+        return check.resume
+
+    def _execute_claim_verification(
+        self,
+        context: Dict[str, Any],
+        interrogation_result: Dict[str, Any],
+        audit_result: Dict[str, Any],
+    ) -> Dict[str, Any]:
+        """Block completion on metrics/skills the evidence does not support.
+
+        Evidence is the baseline résumé, the source documents, and the candidate's
+        interview answers; the cover letter may also cite the job description and the
+        company research. Unverifiable claims are added to the audit report with
+        their locations; unless overridden (``allow_unverified_claims``, a yes at the
+        interactive prompt, or ``claim_override`` in the context) they mark the run
+        as audit-failed. Documents are preserved either way.
+        """
+        if self.dry_run:
+            return audit_result  # placeholder documents; nothing real to verify
+
+        self.current_state = WorkflowState.CLAIM_VERIFICATION
+        self._log("Executing Claim Verification")
+
+        with trace_workflow_stage("claim_verification") as span:
+            documents = {
+                name: text
+                for name, text in (audit_result.get("final_documents") or {}).items()
+                if isinstance(text, str) and text
+            }
+            evidence = [
+                context.get("resume", ""),
+                context.get("source_documents", ""),
+                str(interrogation_result.get("interview_notes") or ""),
+                render_facts(context.get("knowledge_facts") or []),
+            ]
+            employer = [
+                context.get("job_description", ""),
+                str(context.get("research_data") or ""),
+            ]
+            override = self.allow_unverified_claims or bool(context.get("claim_override"))
+            report = verify_claims(documents, evidence, override=override, employer=employer)
+            if report.blocking and self.interactive:
+                self._print_unverified(report)
+                if self.user_interaction.ask_yes_no("Keep these unverified claims anyway?"):
+                    report = verify_claims(documents, evidence, override=True, employer=employer)
+
+            span.set_attribute("stage.checked_claims", report.checked)
+            span.set_attribute("stage.unverified_claims", len(report.unverified))
+            span.set_attribute("stage.status", report.status)
+            self._log(
+                f"Claim verification: {report.status} "
+                f"({len(report.unverified)}/{report.checked} unverified)"
+            )
+
+            audit_report = dict(audit_result.get("audit_report") or {})
+            audit_report["claim_verification"] = report.to_dict()
+            result = {**audit_result, "audit_report": audit_report}
+            if report.blocking:
+                message = f"{len(report.unverified)} claim(s) not supported by the sources"
+                if audit_report.get("final_status") == "APPROVED":
+                    audit_report["final_status"] = "REJECTED"
+                    audit_report["rejection_reason"] = message
+                result["audit_failed"] = True
+                result["audit_error"] = (
+                    f"{audit_result['audit_error']}; {message}"
+                    if audit_result.get("audit_error")
+                    else message
+                )
+            return result
+
+    def _execute_ats_lint(self, audit_result: Dict[str, Any]) -> Dict[str, Any]:
+        """Fail the audit on hidden text, keyword stuffing, broken layout or headers.
+
+        The rules are in runtime.crewai.ats_lint; violations are added to the audit
+        report with their locations. There is no override: documents are preserved
+        either way, and the fix is in them.
+        """
+        if self.dry_run:
+            return audit_result  # placeholder documents; nothing real to lint
+
+        self.current_state = WorkflowState.ATS_LINT
+        with trace_workflow_stage("ats_lint") as span:
+            report = lint_documents(audit_result.get("final_documents") or {}, self.skill_taxonomy)
+            span.set_attribute("stage.violations", len(report.violations))
+            span.set_attribute("stage.status", report.status)
+            self._log(f"ATS lint: {report.status} ({len(report.violations)} violation(s))")
+
+            audit_report = dict(audit_result.get("audit_report") or {})
+            audit_report["ats_lint"] = report.to_dict()
+            result = {**audit_result, "audit_report": audit_report}
+            if report.blocking:
+                rules = ", ".join(sorted({violation.rule for violation in report.violations}))
+                message = f"{len(report.violations)} ATS lint violation(s): {rules}"
+                if audit_report.get("final_status") == "APPROVED":
+                    audit_report["final_status"] = "REJECTED"
+                    audit_report["rejection_reason"] = message
+                result["audit_failed"] = True
+                result["audit_error"] = (
+                    f"{audit_result['audit_error']}; {message}"
+                    if audit_result.get("audit_error")
+                    else message
+                )
+            return result
+
+    def _execute_chronology_check(
+        self, context: Dict[str, Any], audit_result: Dict[str, Any]
+    ) -> Dict[str, Any]:
+        """Block completion on résumé dates that do not hold up against the baseline.
+
+        Impossible ranges, overlaps, changed dates and baseline gaps the tailored
+        résumé covers (see runtime.crewai.chronology) are added to the audit report
+        with their locations; they fail the audit unless overridden like unverified
+        claims. Documents are preserved either way.
+        """
+        resume = (audit_result.get("final_documents") or {}).get("resume")
+        if self.dry_run or not resume:
+            return audit_result
+
+        self.current_state = WorkflowState.CHRONOLOGY_CHECK
+        with trace_workflow_stage("chronology_check") as span:
+            override = self.allow_unverified_claims or bool(context.get("claim_override"))
+            report = check_chronology(resume, context.get("resume", ""), override=override)
+            span.set_attribute("stage.roles", report.roles)
+            span.set_attribute("stage.discrepancies", len(report.discrepancies))
+            span.set_attribute("stage.status", report.status)
+            self._log(
+                f"Chronology check: {report.status} "
+                f"({len(report.discrepancies)} discrepancy(ies) in {report.roles} role(s))"
+            )
+
+            audit_report = dict(audit_result.get("audit_report") or {})
+            audit_report["chronology"] = report.to_dict()
+            result = {**audit_result, "audit_report": audit_report}
+            if report.blocking:
+                kinds = ", ".join(sorted({item.kind for item in report.discrepancies}))
+                message = f"{len(report.discrepancies)} date discrepancy(ies): {kinds}"
+                if audit_report.get("final_status") == "APPROVED":
+                    audit_report["final_status"] = "REJECTED"
+                    audit_report["rejection_reason"] = message
+                result["audit_failed"] = True
+                result["audit_error"] = (
+                    f"{audit_result['audit_error']}; {message}"
+                    if audit_result.get("audit_error")
+                    else message
+                )
+            return result
+
+    def _execute_ats_parse_check(
+        self, audit_result: Dict[str, Any], job_description: Optional[str] = None
+    ) -> Optional[Dict[str, Any]]:
+        """Parse the final résumé as a naive ATS would; advisory, never blocks."""
+        resume = (audit_result.get("final_documents") or {}).get("resume")
+        if self.dry_run or not resume:
+            return None
+
+        self.current_state = WorkflowState.ATS_PARSE_CHECK
+        with trace_workflow_stage("ats_parse_check") as span:
+            report = parse_resume(resume, job_description, self.skill_taxonomy)
+            span.set_attribute("stage.parse_score", report.score)
+            span.set_attribute("stage.hazards", len(report.hazards))
+            missing = ", ".join(report.missing_fields) or "none"
+            self._log(f"ATS parse check: score {report.score}, missing: {missing}")
+        return report.to_dict()
+
+    @staticmethod
+    def _print_unverified(report: VerificationReport) -> None:
+        print("\n🔎 Claims not found in your résumé, sources, or interview answers:")
+        for claim in report.unverified:
+            print(f"  - [{claim.kind}] {claim.text} — {claim.location}")
+
     def _audit_document(
         self, context: Dict[str, Any], document: str, document_type: str
     ) -> Dict[str, Any]:
@@ -689,6 +2906,8 @@ class HydraWorkflow:
                     {**context, "document": document, "document_type": document_type},
                     "auditor_suite",
                 )
+            except BudgetExceeded:
+                raise  # out of time: a retry cannot finish either
             except Exception as e:  # transient (e.g. LLM API error) -> retry
                 last_error = e
                 self._log(f"Audit attempt {attempt + 1}/{attempts} for {document_type} failed: {e}")
@@ -738,7 +2957,7 @@ class HydraWorkflow:
                 else:
                     result["decision"] = canonical.model_dump()
 
-                self.intermediate_results["executive_synthesis"] = result
+                self._record("executive_synthesis", result)
 
                 span.set_attribute("stage.recommendation", canonical.recommendation)
                 span.set_attribute("stage.fit_score", canonical.fit_score)
@@ -775,7 +2994,8 @@ class HydraWorkflow:
         timestamp = datetime.now().isoformat()
         log_entry = f"[{timestamp}] {message}"
         self.logger.info(log_entry)
-        self.execution_log.append(log_entry)
+        with self._state_lock:
+            self.execution_log.append(log_entry)
 
     def get_current_state(self) -> WorkflowState:
         """Get current workflow state"""
@@ -783,10 +3003,10 @@ class HydraWorkflow:
 
     def get_execution_log(self) -> List[str]:
         """Get execution log"""
-        return self.execution_log.copy()
+        with self._state_lock:
+            return self.execution_log.copy()
 
     def get_intermediate_results(self) -> Dict[str, Any]:
         """Get intermediate results from all stages"""
-        import copy
-
-        return copy.deepcopy(self.intermediate_results)
+        with self._state_lock:
+            return copy.deepcopy(self.intermediate_results)
@@ -0,0 +1,134 @@
+"""Impact rewrites: weak résumé bullets as impact statements, and the metrics they lack.
+
+With ``--impact`` (or ``impact`` in a workflow template) a focused agent, the Impact
+Rewriter, runs after the gap analysis. Software picks the weak bullets first: those
+with no number in them, or that open with a duty rather than an outcome ("Responsible
+for", "Helped with", "Worked on"). The agent rewrites each as an impact statement —
+an action, what it changed, for whom — and, where a metric is missing, asks the
+candidate a targeted question instead of inventing one ("What was the p99 latency
+before and after?").
+
+Numbers are never made up. A rewrite that contains a number found in neither the
+baseline résumé nor the sources is dropped (its questions are kept), and the
+questions join the interview, so the answers reach tailoring as interview notes and
+the claim check (see claim_verification) accepts the metrics they give. Without an
+interview in the template, or without ``--interactive``, the questions go unasked and
+the rewrites stay unquantified. The checked rewrites are offered to the Tailoring
+Agent as suggestions; the stage never fails the run.
+"""
+
+from __future__ import annotations
+
+import re
+from typing import Any, Dict, List
+
+from runtime.crewai.resume_diff import numbers_in
+from runtime.crewai.resume_model import parse_resume
+
+# Bullets that open like a job description rather than an achievement.
+WEAK_OPENERS = (
+    "responsible for",
+    "in charge of",
+    "tasked with",
+    "duties included",
+    "helped",
+    "assisted",
+    "worked on",
+    "involved in",
+    "participated in",
+    "contributed to",
+    "supported",
+)
+NO_METRIC = "no metric"
+WEAK_OPENER = "weak opener"
+
+# A handful of bullets is what a candidate will answer questions about.
+MAX_BULLETS = 10
+MAX_QUESTIONS_PER_BULLET = 2
+QUESTION_ID_PREFIX = "impact-"
+
+# Sections whose bullets are not achievements.
+_SKIPPED_SECTIONS = ("skill", "education", "certif", "language", "interest", "contact")
+_LEADING_MARKUP_RE = re.compile(r"^[*_\s]*")
+
+
+def weak_reasons(text: str) -> List[str]:
+    """Why a bullet reads weak: it has no number, or opens with a duty."""
+    reasons = []
+    if not numbers_in(text):
+        reasons.append(NO_METRIC)
+    opening = _LEADING_MARKUP_RE.sub("", text).lower()
+    if opening.startswith(WEAK_OPENERS):
+        reasons.append(WEAK_OPENER)
+    return reasons
+
+
+def weak_bullets(resume: str, limit: int = MAX_BULLETS) -> List[Dict[str, Any]]:
+    """The résumé's weakest bullets (both reasons first, then in résumé order), each
+    with its id (see resume_model), section, entry and reasons."""
+    found = []
+    for section, entry, bullet in parse_resume(resume).bullets():
+        if any(word in section.title.lower() for word in _SKIPPED_SECTIONS):
+            continue
+        reasons = weak_reasons(bullet.text)
+        if reasons and bullet.text.strip():
+            found.append(
+                {
+                    "id": bullet.id,
+                    "text": bullet.text.strip(),
+                    "section": section.title,
+                    "entry": entry.heading,
+                    "reasons": reasons,
+                }
+            )
+    ranked = sorted(found, key=lambda bullet: -len(bullet["reasons"]))
+    return ranked[:limit]
+
+
+def invented_numbers(text: str, evidence: str) -> List[str]:
+    """The numbers in ``text`` that ``evidence`` does not contain."""
+    return sorted(numbers_in(text) - numbers_in(evidence))
+
+
+def interview_questions(impact: Dict[str, Any]) -> List[Dict[str, Any]]:
+    """The rewriter's questions in the interview's shape, each naming its bullet."""
+    questions = []
+    for bullet in impact.get("bullets") or []:
+        for question in bullet.get("questions") or []:
+            questions.append(
+                {
+                    "id": f"{QUESTION_ID_PREFIX}{len(questions) + 1}",
+                    "question": f'{question} (about: "{bullet["text"]}")',
+                    "category": "quantification",
+                    "bullet": bullet["id"],
+                }
+            )
+    return questions
+
+
+def tailoring_suggestions(impact: Dict[str, Any]) -> List[Dict[str, str]]:
+    """The checked rewrites, for the Tailoring Agent: original and rewrite."""
+    return [
+        {"original": bullet["text"], "rewrite": bullet["rewrite"]}
+        for bullet in impact.get("bullets") or []
+        if bullet.get("rewrite")
+    ]
+
+
+def manifest_summary(impact: Dict[str, Any], interview_notes: Any = None) -> Dict[str, Any]:
+    """What ``run.json`` keeps of the stage: counts, never the bullets."""
+    bullets = impact.get("bullets") or []
+    notes = interview_notes if isinstance(interview_notes, list) else []
+    answered = [
+        note
+        for note in notes
+        if isinstance(note, dict)
+        and str(note.get("question_id") or "").startswith(QUESTION_ID_PREFIX)
+    ]
+    return {
+        "weak_bullets": len(bullets),
+        "rewritten": sum(1 for bullet in bullets if bullet.get("rewrite")),
+        "questions": len(interview_questions(impact)),
+        "answered": len(answered),
+        "dropped_for_invented_numbers": len(impact.get("invented") or []),
+    }
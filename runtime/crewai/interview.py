@@ -0,0 +1,314 @@
+"""The interview as a question-and-answer session, and what tailoring gets from it.
+
+The Interrogator-Prepper writes gap-filling questions; the interview asks them one at
+a time and collects the answers. Every front end drives the same InterviewSession:
+the terminal (``--interactive``), the dashboard (``--tui``, which pauses for it) and
+the phone pages of ``hydra serve`` (``--remote-greenlight``, through
+``interview.json`` in the run directory). A question can be answered, skipped (an
+empty answer), or gone back to, and the session can be finished early; the
+remaining questions count as skipped.
+
+Questions arrive in whatever shape the model wrote them — strings, dicts with
+``question``/``text``, or lists grouped by theme — and are numbered ``q1``… unless
+they carry an id. Answers are kept in the interview-notes shape the rest of the
+pipeline reads (``question_id``, ``question_text``, ``answer``) and compiled into
+structured experience: per answer its theme and gap, and the metrics and tools it
+names (found as the claim check finds them, see claim_verification). Tailoring gets
+both; the claim check accepts what the answers say.
+
+Every run that was interviewed keeps the transcript, its structured experience, in
+``interview_transcript.json``. A later run for a similar role reuses it with
+``--reuse-interview <run_id>``: each new question takes the answer of the most
+similar earlier question (word-level ``difflib`` similarity, as cover_letter_overlap
+measures paragraphs; a shared gap counts towards it) and is not asked again, and the
+earlier answers no question matched are carried along, so transcripts grow from run
+to run. Reused answers are marked with the run they came from.
+"""
+
+from __future__ import annotations
+
+import json
+import re
+from dataclasses import asdict, dataclass, field
+from datetime import datetime
+from difflib import SequenceMatcher
+from pathlib import Path
+from typing import Any, Dict, List, Optional, Tuple
+
+from runtime.crewai.claim_verification import extract_claims
+from runtime.crewai.encryption import read_text, write_text
+
+INTERVIEW_FILE = "interview.json"
+TRANSCRIPT_FILE = "interview_transcript.json"
+
+PENDING = "pending"
+DONE = "done"
+
+# What the terminal accepts besides an answer.
+BACK_COMMANDS = ("back", "b")
+DONE_COMMANDS = ("done", "quit", "q")
+
+# How alike an earlier question must be to reuse its answer; a shared gap adds
+# GAP_BONUS, so a reworded question about the same gap still matches.
+REUSE_SIMILARITY = 0.6
+GAP_BONUS = 0.2
+CARRIED_ID_PREFIX = "reused-"
+
+
+def _text(item: Dict[str, Any], *keys: str) -> str:
+    for key in keys:
+        value = item.get(key)
+        if isinstance(value, str) and value.strip():
+            return value.strip()
+    return ""
+
+
+def normalize_questions(raw: Any) -> List[Dict[str, Any]]:
+    """The prepper's questions as dicts with an ``id`` and a ``question``; other keys
+    are kept. A mapping of theme -> questions sets each question's ``theme``."""
+    items: List[Dict[str, Any]] = []
+    if isinstance(raw, dict):
+        for theme, grouped in raw.items():
+            for item in grouped if isinstance(grouped, list) else []:
+                item = item if isinstance(item, dict) else {"question": item}
+                items.append({"theme": theme, **item})
+    elif isinstance(raw, list):
+        items = [item if isinstance(item, dict) else {"question": item} for item in raw]
+    questions = []
+    for item in items:
+        text = _text(item, "question", "text", "q")
+        if not text:
+            continue
+        number = len(questions) + 1
+        questions.append({**item, "id": str(item.get("id") or f"q{number}"), "question": text})
+    return questions
+
+
+def describe(question: Dict[str, Any]) -> str:
+    """``"Observability · Gap: SLOs"``: why a question is asked, if it says."""
+    theme = _text(question, "theme", "category")
+    gap = _text(question, "gap", "requirement")
+    return " · ".join(part for part in (theme.capitalize(), f"Gap: {gap}" if gap else "") if part)
+
+
+@dataclass
+class InterviewSession:
+    """Questions asked one at a time; answers by question id."""
+
+    questions: List[Dict[str, Any]]
+    position: int = 0
+    answers: Dict[str, str] = field(default_factory=dict)
+    finished: bool = False
+
+    @property
+    def done(self) -> bool:
+        return self.finished or self.position >= len(self.questions)
+
+    @property
+    def current(self) -> Optional[Dict[str, Any]]:
+        return None if self.done else self.questions[self.position]
+
+    @property
+    def progress(self) -> str:
+        return f"{min(self.position + 1, len(self.questions))}/{len(self.questions)}"
+
+    def answer(self, text: str) -> None:
+        """Answer the current question; an empty answer skips it."""
+        question = self.current
+        if question is None:
+            return
+        if text.strip():
+            self.answers[question["id"]] = text.strip()
+        else:
+            self.answers.pop(question["id"], None)
+        self.position += 1
+
+    def skip(self) -> None:
+        self.answer("")
+
+    def back(self) -> None:
+        self.position = max(0, self.position - 1)
+        self.finished = False
+
+    def finish(self) -> None:
+        self.finished = True
+
+    def notes(self) -> List[Dict[str, Any]]:
+        """The answers in the interview-notes shape, in question order."""
+        return [
+            {
+                "question_id": question["id"],
+                "question_text": question["question"],
+                "answer": self.answers[question["id"]],
+                "verified": True,
+                "source_material": True,
+            }
+            for question in self.questions
+            if question["id"] in self.answers
+        ]
+
+    def to_dict(self) -> Dict[str, Any]:
+        return {"status": DONE if self.done else PENDING, **asdict(self)}
+
+    @classmethod
+    def from_dict(cls, data: Dict[str, Any]) -> "InterviewSession":
+        return cls(
+            questions=data.get("questions") or [],
+            position=int(data.get("position") or 0),
+            answers=dict(data.get("answers") or {}),
+            finished=bool(data.get("finished")),
+        )
+
+
+def save_session(run_dir: Path, session: InterviewSession) -> Path:
+    path = Path(run_dir) / INTERVIEW_FILE
+    path.parent.mkdir(parents=True, exist_ok=True)
+    data = {**session.to_dict(), "updated_at": datetime.now().isoformat(timespec="seconds")}
+    write_text(path, json.dumps(data, indent=2, ensure_ascii=False))
+    return path
+
+
+def load_session(run_dir: Path) -> Optional[InterviewSession]:
+    """The run's interview in ``hydra serve``, or None if it never asked there."""
+    try:
+        return InterviewSession.from_dict(json.loads(read_text(Path(run_dir) / INTERVIEW_FILE)))
+    except (OSError, ValueError):
+        return None
+
+
+def compile_experience(questions: List[Dict[str, Any]], notes: Any) -> List[Dict[str, Any]]:
+    """The answers as structured experience: per answer its question, theme and gap,
+    and the metrics and tools it names."""
+    by_id = {question["id"]: question for question in normalize_questions(questions)}
+    experience = []
+    for note in notes if isinstance(notes, list) else []:
+        if not isinstance(note, dict) or not str(note.get("answer") or "").strip():
+            continue
+        question = by_id.get(str(note.get("question_id")), {})
+        answer = str(note["answer"]).strip()
+        claims = extract_claims(answer, "interview")
+        entry = {
+            "question_id": note.get("question_id"),
+            "question": note.get("question_text") or question.get("question", ""),
+            "theme": _text(question, "theme", "category") or _text(note, "theme"),
+            "gap": _text(question, "gap", "requirement") or _text(note, "gap"),
+            "answer": answer,
+            "metrics": sorted({c.text for c in claims if c.kind == "metric"}),
+            "tools": sorted({c.text for c in claims if c.kind == "skill"}),
+            "source": "user interview",
+        }
+        if note.get("reused_from"):
+            entry["reused_from"] = note["reused_from"]
+        experience.append(entry)
+    return experience
+
+
+def render_experience(experience: List[Dict[str, Any]]) -> str:
+    """Structured experience as prompt text, one answer per entry."""
+    lines = []
+    for entry in experience:
+        about = " · ".join(part for part in (entry.get("theme"), entry.get("gap")) if part)
+        lines.append(f"- {entry['question']}" + (f" ({about})" if about else ""))
+        lines.append(f"  Answer: {entry['answer']}")
+        for label in ("metrics", "tools"):
+            if entry.get(label):
+                lines.append(f"  {label.capitalize()}: {', '.join(entry[label])}")
+    return "\n".join(lines)
+
+
+def interview_summary(interrogation: Dict[str, Any]) -> Dict[str, int]:
+    """What ``run.json`` keeps of the interview: counts, never the answers. Answers
+    reused from an earlier run count as ``reused``, not ``answered``."""
+    ids = {question["id"] for question in normalize_questions(interrogation.get("questions"))}
+    notes = interrogation.get("interview_notes")
+    notes = [n for n in notes if isinstance(n, dict)] if isinstance(notes, list) else []
+    covered = {str(note.get("question_id")) for note in notes} & ids
+    reused = [note for note in notes if note.get("reused_from")]
+    return {
+        "questions": len(ids),
+        "answered": len([n for n in notes if not n.get("reused_from")]),
+        "reused": len(reused),
+        "skipped": len(ids - covered),
+    }
+
+
+def transcript(experience: List[Dict[str, Any]], run_id: str) -> Dict[str, Any]:
+    """What ``interview_transcript.json`` keeps: every answer, with the run it was
+    first given in."""
+    entries = [
+        {
+            "question": entry["question"],
+            "theme": entry.get("theme", ""),
+            "gap": entry.get("gap", ""),
+            "answer": entry["answer"],
+            "from_run": entry.get("reused_from") or run_id,
+        }
+        for entry in experience
+    ]
+    return {"run_id": run_id, "entries": entries}
+
+
+def load_transcript(run_dir: Path) -> List[Dict[str, Any]]:
+    """An earlier run's interview answers; ValueError if it kept none."""
+    path = Path(run_dir) / TRANSCRIPT_FILE
+    try:
+        entries = json.loads(read_text(path)).get("entries") or []
+    except OSError:
+        raise ValueError(f"Run {Path(run_dir).name} has no interview transcript") from None
+    except (ValueError, AttributeError):
+        raise ValueError(f"Unreadable interview transcript: {path}") from None
+    entries = [e for e in entries if isinstance(e, dict) and _text(e, "question", "answer")]
+    if not entries:
+        raise ValueError(f"Run {Path(run_dir).name} has no interview answers to reuse")
+    return entries
+
+
+def _words(text: str) -> List[str]:
+    return re.findall(r"[a-z0-9']+", text.lower())
+
+
+def _match(question: Dict[str, Any], entry: Dict[str, Any]) -> float:
+    score = SequenceMatcher(
+        None, _words(question["question"]), _words(_text(entry, "question")), autojunk=False
+    ).ratio()
+    gap = _text(question, "gap", "requirement").casefold()
+    if gap and gap == _text(entry, "gap").casefold():
+        score += GAP_BONUS
+    return score
+
+
+def reuse_answers(
+    questions: List[Dict[str, Any]], entries: List[Dict[str, Any]], run_id: str
+) -> Tuple[List[Dict[str, Any]], List[Dict[str, Any]]]:
+    """Merge an earlier transcript into this interview: the notes reused (matched
+    questions first, then the earlier answers nothing matched) and the questions
+    still to ask."""
+    unused = list(entries)
+    reused, remaining = [], []
+    for question in questions:
+        scored = [(_match(question, entry), n) for n, entry in enumerate(unused)]
+        score, best = max(scored, default=(0.0, -1))
+        if score < REUSE_SIMILARITY:
+            remaining.append(question)
+            continue
+        entry = unused.pop(best)
+        reused.append(_reused_note(question["id"], question["question"], entry, run_id))
+    for number, entry in enumerate(unused, start=1):
+        note_id = f"{CARRIED_ID_PREFIX}{number}"
+        reused.append(_reused_note(note_id, _text(entry, "question"), entry, run_id))
+    return reused, remaining
+
+
+def _reused_note(
+    question_id: str, question_text: str, entry: Dict[str, Any], run_id: str
+) -> Dict[str, Any]:
+    return {
+        "question_id": question_id,
+        "question_text": question_text,
+        "answer": _text(entry, "answer"),
+        "theme": _text(entry, "theme"),
+        "gap": _text(entry, "gap"),
+        "verified": True,
+        "source_material": True,
+        "reused_from": _text(entry, "from_run") or run_id,
+    }
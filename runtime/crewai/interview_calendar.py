@@ -0,0 +1,332 @@
+"""Interview scheduling: a calendar event for each interview an application gets.
+
+``hydra interview <run_id> --at "2026-10-20 14:00" --tz Europe/Berlin`` records the
+interview in the run's ``interviews.json`` — the run directory is the application
+record, as with debriefs and the application email — and writes
+``interview-<n>.ics`` for any calendar app, or with ``--google`` adds the event to
+Google Calendar (``GOOGLE_CALENDAR_TOKEN``, an OAuth token with the
+``calendar.events`` scope).
+
+The event links the run's prep pack, ``interview_prep.md``: the interview questions
+the run generated, the gaps to have an answer for, the differentiators to lead with
+and what earlier interviews at the company asked. Times are taken in the
+interview's time zone (``--tz``, the local one by default) and written in UTC, so a
+calendar in any zone shows them right; the description gives the interview's own
+local time. Reminders are ``VALARM``s, a day and an hour before unless ``--remind``
+says otherwise.
+"""
+
+from __future__ import annotations
+
+import json
+import os
+import urllib.error
+import urllib.request
+import uuid
+from dataclasses import asdict, dataclass, field
+from datetime import datetime, timedelta, timezone
+from pathlib import Path
+from typing import Any, Dict, List, Mapping, Optional
+from zoneinfo import ZoneInfo, ZoneInfoNotFoundError
+
+from runtime.crewai.artifacts import MANIFEST_FILE, load_checkpoint
+from runtime.crewai.debrief import company_debriefs
+from runtime.crewai.encryption import read_text, write_text
+
+INTERVIEWS_FILE = "interviews.json"
+PREP_PACK_FILE = "interview_prep.md"
+DEFAULT_DURATION_MINUTES = 60
+DEFAULT_REMINDERS = (24 * 60, 60)  # minutes before the interview
+GOOGLE_TOKEN_ENV = "GOOGLE_CALENDAR_TOKEN"
+GOOGLE_EVENTS_URL = "https://www.googleapis.com/calendar/v3/calendars/primary/events"
+SEND_TIMEOUT_SECONDS = 30
+PRODID = "-//Hydra//Interview calendar//EN"
+
+
+class CalendarError(ValueError):
+    """Raised for an unknown time zone or time, or a calendar that cannot be reached."""
+
+    pass
+
+
+@dataclass
+class Interview:
+    """One scheduled interview round."""
+
+    run_id: str
+    round: str
+    start: str  # ISO 8601 with the interview's UTC offset
+    timezone: str
+    duration_minutes: int = DEFAULT_DURATION_MINUTES
+    company: Optional[str] = None
+    role: Optional[str] = None
+    location: str = ""
+    reminders: List[int] = field(default_factory=lambda: list(DEFAULT_REMINDERS))
+    uid: str = ""
+    google_event_id: Optional[str] = None
+
+    def to_dict(self) -> Dict[str, Any]:
+        return asdict(self)
+
+    @classmethod
+    def from_dict(cls, raw: Dict[str, Any]) -> "Interview":
+        known = {key: raw[key] for key in cls.__dataclass_fields__ if key in raw}
+        return cls(**known)
+
+    @property
+    def starts_at(self) -> datetime:
+        return datetime.fromisoformat(self.start)
+
+    @property
+    def ends_at(self) -> datetime:
+        return self.starts_at + timedelta(minutes=self.duration_minutes)
+
+    @property
+    def title(self) -> str:
+        at = f" — {self.company}" if self.company else ""
+        return f"{self.round} interview{at}" + (f" ({self.role})" if self.role else "")
+
+
+def zone(name: Optional[str]) -> Any:
+    """The named IANA time zone, or the local one."""
+    if not name:
+        return datetime.now().astimezone().tzinfo
+    try:
+        return ZoneInfo(name)
+    except (ZoneInfoNotFoundError, ValueError) as e:
+        raise CalendarError(f"Unknown time zone '{name}' (an IANA name like Europe/Berlin)") from e
+
+
+def parse_start(text: str, tz_name: Optional[str] = None) -> datetime:
+    """``YYYY-MM-DD HH:MM`` in ``tz_name`` (or with its own offset) as an aware datetime."""
+    try:
+        start = datetime.fromisoformat(text.strip().replace(" ", "T", 1))
+    except ValueError as e:
+        raise CalendarError(f"Not a date and time: '{text}' (use YYYY-MM-DD HH:MM)") from e
+    if start.tzinfo is None:
+        start = start.replace(tzinfo=zone(tz_name))
+    return start
+
+
+def load_interviews(run_dir: Path) -> List[Interview]:
+    path = Path(run_dir) / INTERVIEWS_FILE
+    if not path.is_file():
+        return []
+    return [Interview.from_dict(raw) for raw in json.loads(read_text(path))]
+
+
+def save_interview(run_dir: Path, interview: Interview) -> int:
+    """Add ``interview`` (or replace the one with its uid); its 1-based number."""
+    interviews = load_interviews(run_dir)
+    numbers = [i for i, known in enumerate(interviews) if known.uid == interview.uid]
+    if numbers:
+        interviews[numbers[0]] = interview
+    else:
+        interviews.append(interview)
+    write_text(
+        Path(run_dir) / INTERVIEWS_FILE,
+        json.dumps([known.to_dict() for known in interviews], indent=2),
+    )
+    return (numbers[0] if numbers else len(interviews) - 1) + 1
+
+
+def schedule_interview(
+    run_dir: Path,
+    start: datetime,
+    round: str,
+    duration_minutes: int = DEFAULT_DURATION_MINUTES,
+    location: str = "",
+    reminders: Optional[List[int]] = None,
+    tz_name: Optional[str] = None,
+) -> Interview:
+    """An interview for the application in ``run_dir`` (not saved yet)."""
+    manifest = json.loads((Path(run_dir) / MANIFEST_FILE).read_text())
+    inputs = manifest.get("inputs") or {}
+    return Interview(
+        run_id=manifest.get("run_id", Path(run_dir).name),
+        round=round,
+        start=start.isoformat(timespec="minutes"),
+        timezone=tz_name or str(start.tzname() or ""),
+        duration_minutes=duration_minutes,
+        company=inputs.get("company"),
+        role=inputs.get("role"),
+        location=location,
+        reminders=list(DEFAULT_REMINDERS if reminders is None else reminders),
+        uid=f"{uuid.uuid4()}@hydra",
+    )
+
+
+def _questions(results: Dict[str, Any]) -> List[str]:
+    """The interrogation stage's questions; their layout varies from model to model."""
+    raw = (results.get("interrogation") or {}).get("questions") or []
+    questions = []
+    for item in raw:
+        if isinstance(item, dict):
+            text = item.get("question") or item.get("text")
+            theme = item.get("theme") or item.get("category")
+            if text:
+                questions.append(f"{text} ({theme})" if theme else str(text))
+        elif item:
+            questions.append(str(item))
+    return questions
+
+
+def _labels(items: Any) -> List[str]:
+    labels = []
+    for item in items or []:
+        if isinstance(item, dict):
+            item = item.get("title") or item.get("skill") or item.get("requirement")
+        if item:
+            labels.append(str(item))
+    return labels
+
+
+def write_prep_pack(run_dir: Path, interview: Interview, out_dir: Optional[Path] = None) -> Path:
+    """Write the run's ``interview_prep.md`` for ``interview``; its path.
+
+    Earlier debriefs for the company are looked up in ``out_dir`` (the run's parent).
+    """
+    run_dir = Path(run_dir)
+    results, _ = load_checkpoint(run_dir)
+    when = interview.starts_at.strftime("%A %d %B %Y, %H:%M")
+    lines = [f"# Interview prep: {interview.title}", "", f"{when} {interview.timezone}"]
+    if interview.location:
+        lines.append(f"Where: {interview.location}")
+    gaps = (results.get("gap_analysis") or {}).get("gaps")
+    differentiators = (results.get("differentiation") or {}).get("differentiators")
+    sections = [
+        ("Questions to prepare", _questions(results)),
+        ("Gaps to have an answer for", _labels(gaps)),
+        ("Lead with", _labels(differentiators)),
+    ]
+    if interview.company:
+        earlier = company_debriefs(out_dir or run_dir.parent, interview.company)
+        asked = [f"{q} ({d.round}, {d.interview_date})" for d in earlier for q in d.questions_asked]
+        sections.append(("Asked in earlier interviews", asked))
+    for heading, items in sections:
+        if items:
+            lines += ["", f"## {heading}", ""] + [f"- {item}" for item in items]
+    path = run_dir / PREP_PACK_FILE
+    write_text(path, "\n".join(lines) + "\n")
+    return path
+
+
+def _ics_text(value: str) -> str:
+    return (
+        value.replace("\\", "\\\\").replace(";", "\\;").replace(",", "\\,").replace("\n", "\\n")
+    )
+
+
+def _fold(line: str) -> List[str]:
+    """RFC 5545 line folding: 75 octets a line, continuations start with a space."""
+    folded, current = [], ""
+    for char in line:
+        if len((current + char).encode("utf-8")) > 75:
+            folded.append(current)
+            current = " "
+        current += char
+    return folded + [current]
+
+
+def _utc(moment: datetime) -> str:
+    return moment.astimezone(timezone.utc).strftime("%Y%m%dT%H%M%SZ")
+
+
+def event_description(interview: Interview, prep_link: Optional[str]) -> str:
+    local = interview.starts_at.strftime("%Y-%m-%d %H:%M")
+    lines = [f"{local} {interview.timezone} ({interview.duration_minutes} min)"]
+    if prep_link:
+        lines.append(f"Prep pack: {prep_link}")
+    return "\n".join(lines)
+
+
+def render_ics(
+    interview: Interview, prep_link: Optional[str] = None, now: Optional[datetime] = None
+) -> str:
+    """``interview`` as an iCalendar file with its reminders."""
+    lines = [
+        "BEGIN:VCALENDAR",
+        "VERSION:2.0",
+        f"PRODID:{PRODID}",
+        "METHOD:PUBLISH",
+        "BEGIN:VEVENT",
+        f"UID:{interview.uid}",
+        f"DTSTAMP:{_utc(now or datetime.now(timezone.utc))}",
+        f"DTSTART:{_utc(interview.starts_at)}",
+        f"DTEND:{_utc(interview.ends_at)}",
+        f"SUMMARY:{_ics_text(interview.title)}",
+        f"DESCRIPTION:{_ics_text(event_description(interview, prep_link))}",
+    ]
+    if interview.location:
+        lines.append(f"LOCATION:{_ics_text(interview.location)}")
+    if prep_link:
+        lines.append(f"URL:{prep_link}")
+    for minutes in interview.reminders:
+        lines += [
+            "BEGIN:VALARM",
+            "ACTION:DISPLAY",
+            f"DESCRIPTION:{_ics_text(interview.title)}",
+            f"TRIGGER:-PT{minutes}M",
+            "END:VALARM",
+        ]
+    lines += ["END:VEVENT", "END:VCALENDAR"]
+    return "\r\n".join(folded for line in lines for folded in _fold(line)) + "\r\n"
+
+
+def write_ics(run_dir: Path, interview: Interview, number: int, prep_link: Optional[str]) -> Path:
+    path = Path(run_dir) / f"interview-{number}.ics"
+    # Plain text even with encryption on: calendar apps open it directly.
+    path.write_text(render_ics(interview, prep_link), newline="")
+    return path
+
+
+def google_event(interview: Interview, prep_link: Optional[str] = None) -> Dict[str, Any]:
+    """The Google Calendar API event for ``interview``."""
+    # Google takes IANA names only; the offset in dateTime is enough otherwise.
+    named = {"timeZone": interview.timezone} if "/" in interview.timezone else {}
+    event: Dict[str, Any] = {
+        "summary": interview.title,
+        "description": event_description(interview, prep_link),
+        "start": {"dateTime": interview.starts_at.isoformat(), **named},
+        "end": {"dateTime": interview.ends_at.isoformat(), **named},
+        "iCalUID": interview.uid,
+        "reminders": {
+            "useDefault": False,
+            "overrides": [{"method": "popup", "minutes": m} for m in interview.reminders],
+        },
+    }
+    if interview.location:
+        event["location"] = interview.location
+    if prep_link:
+        event["source"] = {"title": "Interview prep", "url": prep_link}
+    return event
+
+
+def push_to_google(
+    interview: Interview,
+    prep_link: Optional[str] = None,
+    env: Mapping[str, str] = os.environ,
+    post: Any = None,
+) -> str:
+    """Add ``interview`` to the primary Google Calendar; the new event's id.
+
+    ``post(url, body, headers)`` is the HTTP call, returning the response body.
+    """
+    token = env.get(GOOGLE_TOKEN_ENV)
+    if not token:
+        raise CalendarError(f"{GOOGLE_TOKEN_ENV} not set")
+    headers = {"Authorization": f"Bearer {token}", "Content-Type": "application/json"}
+    body = json.dumps(google_event(interview, prep_link)).encode()
+    response = (post or _post)(GOOGLE_EVENTS_URL, body, headers)
+    return json.loads(response or "{}").get("id", "")
+
+
+def _post(url: str, body: bytes, headers: Dict[str, str]) -> str:
+    request = urllib.request.Request(url, data=body, headers=headers, method="POST")
+    try:
+        with urllib.request.urlopen(request, timeout=SEND_TIMEOUT_SECONDS) as response:
+            return response.read().decode("utf-8")
+    except urllib.error.HTTPError as e:
+        raise CalendarError(f"Google Calendar: HTTP {e.code} {e.reason}") from e
+    except (urllib.error.URLError, TimeoutError) as e:
+        raise CalendarError(f"Google Calendar: {getattr(e, 'reason', e)}") from e
@@ -0,0 +1,415 @@
+"""Job postings from a URL: ``--jd-url`` instead of a saved job description file.
+
+Postings on the big ATS job boards are read through the board's own public JSON
+rather than scraped, so what reaches the agents is the posting — not the page's
+navigation, cookie banner and "similar jobs":
+
+- Greenhouse (``boards.greenhouse.io/<board>/jobs/<id>``, ``job-boards.greenhouse.io``)
+  via the Job Board API;
+- Lever (``jobs.lever.co/<company>/<id>``, ``jobs.eu.lever.co``) via the Postings API;
+- Workday (``<tenant>.wd<N>.myworkdayjobs.com/.../job/...``) via the JSON the career
+  site itself loads.
+
+Any other URL is fetched as HTML: a schema.org ``JobPosting`` in the page's JSON-LD is
+used when there is one (most career sites publish it for search engines), otherwise
+the page text.
+
+Whatever the source, the description is split into structured requirements by its
+headings — responsibilities, must-haves and nice-to-haves — with the title, company,
+team and location alongside. ``JobPosting.to_markdown`` writes that as the job
+description the run reads, with ``Company:``/``Role:`` header lines (which also name
+the run directory; see artifacts.job_labels). The Markdown is saved under
+``~/.hydra/job_postings/`` so the run, ``hydra resume`` and later re-audits read the
+same text even after the posting is taken down.
+"""
+
+from __future__ import annotations
+
+import html
+import json
+import re
+import urllib.error
+import urllib.parse
+import urllib.request
+from dataclasses import dataclass, field
+from html.parser import HTMLParser
+from pathlib import Path
+from typing import Any, Callable, Dict, List, Optional, Tuple
+
+from runtime.crewai.encryption import write_text
+from runtime.crewai.stage_cache import hydra_home
+
+JOB_POSTINGS_DIR = "job_postings"  # in hydra_home()
+
+GREENHOUSE = "greenhouse"
+LEVER = "lever"
+WORKDAY = "workday"
+HTML = "html"
+
+FETCH_TIMEOUT_SECONDS = 20
+USER_AGENT = "hydra-jd-fetch/1.0"
+
+_GREENHOUSE_RE = re.compile(r"^(?:job-)?boards(?:\.eu)?\.greenhouse\.io$", re.IGNORECASE)
+_LEVER_RE = re.compile(r"^jobs(\.eu)?\.lever\.co$", re.IGNORECASE)
+_WORKDAY_RE = re.compile(r"^([a-z0-9-]+)\.wd\d+\.myworkdayjobs\.com$", re.IGNORECASE)
+_LOCALE_RE = re.compile(r"^[a-z]{2}-[A-Z]{2}$")
+
+# Heading keywords, checked in this order: "preferred qualifications" is a nice-to-have
+# and "what you'll do" a responsibility before "qualifications"/"you'll" match.
+_NICE_TO_HAVE = (
+    "nice to have",
+    "nice-to-have",
+    "preferred",
+    "bonus",
+    "plus",
+    "desirable",
+    "desired",
+    "ideally",
+)
+_RESPONSIBILITIES = (
+    "responsibilit",
+    "what you'll do",
+    "what you will do",
+    "what you'll be doing",
+    "you will",
+    "the role",
+    "your role",
+    "in this role",
+    "day to day",
+    "day-to-day",
+    "duties",
+    "your impact",
+)
+_MUST_HAVE = (
+    "requirement",
+    "qualification",
+    "must have",
+    "must-have",
+    "what you bring",
+    "what you'll bring",
+    "looking for",
+    "you have",
+    "you'll have",
+    "about you",
+    "who you are",
+    "skills",
+    "experience",
+)
+# A line this short ending in ":" is a heading even without heading markup.
+_MAX_HEADING_CHARS = 60
+_BULLET_RE = re.compile(r"^\s*(?:[-*•·▪●◦]|\d+[.)])\s+")
+
+
+class JobBoardError(ValueError):
+    """Raised for a posting that cannot be fetched or holds no job description."""
+
+    pass
+
+
+@dataclass
+class JobPosting:
+    """One job posting, split into the parts the agents reason about."""
+
+    url: str
+    board: str
+    title: str = ""
+    company: str = ""
+    team: str = ""
+    location: str = ""
+    responsibilities: List[str] = field(default_factory=list)
+    must_haves: List[str] = field(default_factory=list)
+    nice_to_haves: List[str] = field(default_factory=list)
+    # Text under no recognised heading: the company pitch, benefits, the role intro.
+    about: List[str] = field(default_factory=list)
+
+    def to_markdown(self) -> str:
+        lines = [f"# {self.title or 'Job description'}", ""]
+        for label, value in (
+            ("Company", self.company),
+            ("Role", self.title),
+            ("Team", self.team),
+            ("Location", self.location),
+            ("Posting", self.url),
+        ):
+            if value:
+                lines.append(f"{label}: {value}")
+        for heading, items in (
+            ("Responsibilities", self.responsibilities),
+            ("Must-haves", self.must_haves),
+            ("Nice-to-haves", self.nice_to_haves),
+        ):
+            if items:
+                lines += ["", f"## {heading}", ""] + [f"- {item}" for item in items]
+        if self.about:
+            lines += ["", "## About the role", ""] + _paragraphs(self.about)
+        return "\n".join(lines).rstrip() + "\n"
+
+
+def _paragraphs(blocks: List[str]) -> List[str]:
+    lines: List[str] = []
+    for block in blocks:
+        lines += [block, ""]
+    return lines[:-1]
+
+
+class _Blocks(HTMLParser):
+    """Flatten description HTML into ``(kind, text)`` blocks: heading, item or text."""
+
+    _HEADINGS = {"h1", "h2", "h3", "h4", "h5", "h6"}
+    _BREAKS = {"p", "div", "li", "ul", "ol", "br", "tr", "section", "article"}
+    _SKIPPED = {"script", "style", "noscript", "svg", "nav", "footer", "header", "form"}
+
+    def __init__(self):
+        super().__init__(convert_charrefs=True)
+        self.blocks: List[Tuple[str, str]] = []
+        self._text: List[str] = []
+        self._bold: List[str] = []
+        self._kind = "text"
+        self._bold_depth = 0
+        self._skip_depth = 0
+
+    def handle_starttag(self, tag, attrs):
+        if tag in self._SKIPPED:
+            self._skip_depth += 1
+        elif tag in self._HEADINGS or tag in self._BREAKS:
+            self._flush()
+            if tag in self._HEADINGS:
+                self._kind = "heading"
+            elif tag == "li":
+                self._kind = "item"
+        elif tag in ("strong", "b"):
+            self._bold_depth += 1
+
+    def handle_endtag(self, tag):
+        if tag in self._SKIPPED:
+            self._skip_depth = max(0, self._skip_depth - 1)
+        elif tag in self._HEADINGS or tag in self._BREAKS:
+            self._flush()
+        elif tag in ("strong", "b"):
+            self._bold_depth = max(0, self._bold_depth - 1)
+
+    def handle_data(self, data):
+        if self._skip_depth:
+            return
+        self._text.append(data)
+        if self._bold_depth:
+            self._bold.append(data)
+
+    def close(self):
+        super().close()
+        self._flush()
+
+    def _flush(self):
+        text = " ".join("".join(self._text).split())
+        bold = " ".join("".join(self._bold).split())
+        kind = self._kind
+        if text and kind == "text" and bold == text and len(text) <= _MAX_HEADING_CHARS:
+            kind = "heading"  # <p><strong>Requirements</strong></p>
+        if text:
+            self.blocks.append((kind, text))
+            self._kind = "text"
+        self._text, self._bold = [], []
+
+
+def html_blocks(markup: str) -> List[Tuple[str, str]]:
+    """Description HTML as ``(kind, text)`` blocks, plain-text bullets made items."""
+    parser = _Blocks()
+    parser.feed(markup or "")
+    parser.close()
+    blocks: List[Tuple[str, str]] = []
+    for kind, text in parser.blocks:
+        if kind == "text" and _BULLET_RE.match(text):
+            kind, text = "item", _BULLET_RE.sub("", text)
+        elif kind == "text" and text.endswith(":") and len(text) <= _MAX_HEADING_CHARS:
+            kind = "heading"
+        blocks.append((kind, text))
+    return blocks
+
+
+def classify_heading(heading: str) -> Optional[str]:
+    """``"responsibilities"``, ``"must_haves"``, ``"nice_to_haves"`` or None."""
+    text = heading.lower().replace("’", "'")
+    for name, keywords in (
+        ("nice_to_haves", _NICE_TO_HAVE),
+        ("responsibilities", _RESPONSIBILITIES),
+        ("must_haves", _MUST_HAVE),
+    ):
+        if any(keyword in text for keyword in keywords):
+            return name
+    return None
+
+
+def add_description(posting: JobPosting, markup: str) -> JobPosting:
+    """Sort the blocks of ``markup`` into ``posting``'s sections by their headings."""
+    section: Optional[str] = None
+    for kind, text in html_blocks(markup):
+        if kind == "heading":
+            section = classify_heading(text)
+            if section is None:
+                posting.about.append(f"**{text.rstrip(':')}**")
+        elif section is not None:
+            items = getattr(posting, section)
+            if text not in items:
+                items.append(text)
+        else:
+            posting.about.append(f"- {text}" if kind == "item" else text)
+    return posting
+
+
+def greenhouse_posting(url: str, data: Dict[str, Any], board: str) -> JobPosting:
+    """A posting from a Greenhouse Job Board API job (one job, or one of a board's)."""
+    departments = data.get("departments") or []
+    posting = JobPosting(
+        url=url,
+        board=GREENHOUSE,
+        title=data.get("title") or "",
+        company=data.get("company_name") or board.replace("-", " ").title(),
+        team=departments[0].get("name", "") if departments else "",
+        location=(data.get("location") or {}).get("name", ""),
+    )
+    # The Job Board API returns the description HTML entity-escaped.
+    return add_description(posting, html.unescape(data.get("content") or ""))
+
+
+def lever_posting(url: str, data: Dict[str, Any], company: str) -> JobPosting:
+    """A posting from a Lever Postings API posting."""
+    categories = data.get("categories") or {}
+    posting = JobPosting(
+        url=url,
+        board=LEVER,
+        title=data.get("text") or "",
+        company=company.replace("-", " ").title(),
+        team=categories.get("team") or categories.get("department") or "",
+        location=categories.get("location") or "",
+    )
+    add_description(posting, data.get("description") or "")
+    for entry in data.get("lists") or []:
+        heading = html.escape(entry.get("text") or "")
+        add_description(posting, f"<h3>{heading}</h3><ul>{entry.get('content') or ''}</ul>")
+    return add_description(posting, data.get("additional") or "")
+
+
+def workday_posting(url: str, data: Dict[str, Any], tenant: str) -> JobPosting:
+    """A posting from the JSON a Workday career site loads for a job."""
+    info = data.get("jobPostingInfo") or {}
+    organization = data.get("hiringOrganization") or {}
+    posting = JobPosting(
+        url=url,
+        board=WORKDAY,
+        title=info.get("title") or "",
+        company=organization.get("name") or tenant.title(),
+        location=info.get("location") or "",
+    )
+    return add_description(posting, info.get("jobDescription") or "")
+
+
+def _json_ld_posting(page: str) -> Optional[Dict[str, Any]]:
+    for match in re.finditer(
+        r"<script[^>]*application/ld\+json[^>]*>(.*?)</script>", page, re.DOTALL | re.IGNORECASE
+    ):
+        try:
+            data = json.loads(match.group(1).strip())
+        except ValueError:
+            continue
+        candidates = data if isinstance(data, list) else data.get("@graph", [data])
+        for item in candidates:
+            if isinstance(item, dict) and item.get("@type") == "JobPosting":
+                return item
+    return None
+
+
+def _html(url: str, page: str) -> JobPosting:
+    item = _json_ld_posting(page)
+    if item is not None:
+        organization = item.get("hiringOrganization") or {}
+        places = item.get("jobLocation") or []
+        place = (places[0] if isinstance(places, list) and places else places) or {}
+        address = place.get("address") or {} if isinstance(place, dict) else {}
+        posting = JobPosting(
+            url=url,
+            board=HTML,
+            title=html.unescape(item.get("title") or ""),
+            company=organization.get("name", "") if isinstance(organization, dict) else "",
+            location=address.get("addressLocality", "") if isinstance(address, dict) else "",
+        )
+        return add_description(posting, html.unescape(item.get("description") or ""))
+    title = re.search(r"<title[^>]*>(.*?)</title>", page, re.DOTALL | re.IGNORECASE)
+    title = html.unescape(" ".join(title.group(1).split())) if title else ""
+    posting = JobPosting(url=url, board=HTML, title=title)
+    body = re.search(r"<body[^>]*>(.*)</body>", page, re.DOTALL | re.IGNORECASE)
+    return add_description(posting, body.group(1) if body else page)
+
+
+def board_source(url: str) -> Tuple[str, str]:
+    """``(board, url to fetch)``: the board's JSON API for a known board, else ``url``."""
+    parts = urllib.parse.urlsplit(url)
+    if parts.scheme not in ("http", "https") or not parts.hostname:
+        raise JobBoardError(f"Not an http(s) URL: {url}")
+    host = parts.hostname
+    path = [segment for segment in parts.path.split("/") if segment]
+    if _GREENHOUSE_RE.match(host) and len(path) >= 3 and path[1] == "jobs":
+        return GREENHOUSE, (
+            f"https://boards-api.greenhouse.io/v1/boards/{path[0]}/jobs/{path[2]}"
+        )
+    lever = _LEVER_RE.match(host)
+    if lever and len(path) >= 2:
+        api = "api.eu.lever.co" if lever.group(1) else "api.lever.co"
+        return LEVER, f"https://{api}/v0/postings/{path[0]}/{path[1]}"
+    workday = _WORKDAY_RE.match(host)
+    if workday:
+        if path and _LOCALE_RE.match(path[0]):
+            path = path[1:]
+        if len(path) >= 3 and path[1] in ("job", "details"):
+            site, rest = path[0], "/".join(path[2:])
+            return WORKDAY, f"https://{host}/wday/cxs/{workday.group(1)}/{site}/job/{rest}"
+    return HTML, url
+
+
+def http_get(url: str) -> str:
+    """The body of ``url`` as text; JobBoardError when it cannot be fetched."""
+    request = urllib.request.Request(
+        url, headers={"User-Agent": USER_AGENT, "Accept": "application/json, text/html"}
+    )
+    try:
+        with urllib.request.urlopen(request, timeout=FETCH_TIMEOUT_SECONDS) as response:
+            charset = response.headers.get_content_charset() or "utf-8"
+            return response.read().decode(charset, errors="replace")
+    except urllib.error.HTTPError as e:
+        raise JobBoardError(f"{url}: HTTP {e.code} {e.reason}") from e
+    except (urllib.error.URLError, TimeoutError) as e:
+        raise JobBoardError(f"Cannot fetch {url}: {getattr(e, 'reason', e)}") from e
+
+
+def fetch_posting(url: str, get: Callable[[str], str] = http_get) -> JobPosting:
+    """Fetch and structure the posting at ``url`` (``get`` returns a URL's body)."""
+    board, source = board_source(url)
+    body = get(source)
+    if board == HTML:
+        posting = _html(url, body)
+    else:
+        try:
+            data = json.loads(body)
+        except ValueError as e:
+            raise JobBoardError(f"{board} returned something other than JSON for {url}") from e
+        path = [segment for segment in urllib.parse.urlsplit(url).path.split("/") if segment]
+        host = urllib.parse.urlsplit(url).hostname or ""
+        if board == GREENHOUSE:
+            posting = greenhouse_posting(url, data, path[0])
+        elif board == LEVER:
+            posting = lever_posting(url, data, path[0])
+        else:
+            posting = workday_posting(url, data, _WORKDAY_RE.match(host).group(1))
+    if not (posting.responsibilities or posting.must_haves or posting.about):
+        raise JobBoardError(f"No job description found at {url}")
+    return posting
+
+
+def save_posting(posting: JobPosting, root: Optional[Path] = None) -> Path:
+    """Write ``posting`` as Markdown under ``root`` (``~/.hydra/job_postings``)."""
+    root = Path(root) if root is not None else hydra_home() / JOB_POSTINGS_DIR
+    root.mkdir(parents=True, exist_ok=True)
+    parts = urllib.parse.urlsplit(posting.url)
+    name = re.sub(r"[^a-z0-9]+", "-", f"{parts.hostname}{parts.path}".lower()).strip("-")
+    path = root / f"{name[:120]}.md"
+    write_text(path, posting.to_markdown())
+    return path
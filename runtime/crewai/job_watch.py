@@ -0,0 +1,385 @@
+"""Job watch: pull job feeds, score new postings against the baseline, queue the best.
+
+``hydra watch`` polls the configured feeds every ``--interval`` minutes (``--once``
+for a single pass). Every posting it has not seen before is scored in two steps:
+
+1. for free — the share of the posting's skills the baseline résumé covers (see
+   skill_taxonomy); postings below ``--min-coverage`` stop here;
+2. by the gap analyzer on a cheap model (``WATCH_MODEL`` unless ``--model``), whose
+   fit score decides: at ``--min-fit`` or above the posting is queued.
+
+Nothing runs on its own. A queued posting waits for ``hydra watch approve ID``, which
+starts a full run on it with the résumé and sources the watch used; ``hydra watch
+reject ID`` drops it and ``hydra watch queue`` lists what is waiting.
+
+Feeds are ``KIND`` or ``KIND:TARGET`` specs, from ``--feed`` or the ``feeds`` list of
+``~/.hydra/watch.yaml``:
+
+- ``hn`` — the latest "Ask HN: Who is hiring?" thread, one posting per top comment;
+- ``remoteok`` or ``remoteok:TAG`` — the RemoteOK API;
+- ``greenhouse:BOARD`` and ``lever:COMPANY`` — every open job on that board;
+- ``rss:URL`` — any RSS or Atom feed of postings.
+
+Queued postings are saved as job descriptions like ``--jd-url`` saves them (see
+job_boards). The queue and the ids already seen live under ``~/.hydra/watch/``.
+"""
+
+from __future__ import annotations
+
+import json
+import re
+import xml.etree.ElementTree as ElementTree
+from dataclasses import asdict, dataclass, field
+from datetime import datetime
+from pathlib import Path
+from typing import Any, Callable, Dict, List, Optional
+
+import yaml
+
+from runtime.crewai.agents.gap_analyzer import GapAnalyzerAgent
+from runtime.crewai.contracts import GapReview
+from runtime.crewai.job_boards import (
+    JobBoardError,
+    JobPosting,
+    add_description,
+    greenhouse_posting,
+    http_get,
+    lever_posting,
+    save_posting,
+)
+from runtime.crewai.quick_apply import QUICK_APPLY_MODELS
+from runtime.crewai.skill_taxonomy import SkillTaxonomy, skill_coverage
+from runtime.crewai.stage_cache import hydra_home
+
+WATCH_DIR = "watch"  # in hydra_home()
+WATCH_CONFIG_FILE = "watch.yaml"  # in hydra_home()
+QUEUE_FILE = "queue.json"
+SEEN_FILE = "seen.json"
+
+FEED_KINDS = ("hn", "remoteok", "greenhouse", "lever", "rss")
+# Kinds that name no board or URL.
+_UNTARGETED = ("hn", "remoteok")
+
+# The gap analyzer's model for scoring: fast and cheap, like quick apply's.
+WATCH_MODEL = QUICK_APPLY_MODELS["gap_analyzer"]
+DEFAULT_INTERVAL_MINUTES = 60
+DEFAULT_MIN_FIT = 70.0
+DEFAULT_MIN_COVERAGE = 0.3
+
+PENDING = "pending"
+APPROVED = "approved"
+REJECTED = "rejected"
+
+HN_SEARCH_URL = (
+    "https://hn.algolia.com/api/v1/search_by_date?tags=story,author_whoishiring&hitsPerPage=10"
+)
+HN_ITEM_URL = "https://hn.algolia.com/api/v1/items/{id}"
+REMOTEOK_URL = "https://remoteok.com/api"
+
+_ATOM = "{http://www.w3.org/2005/Atom}"
+
+Fetch = Callable[[str], str]
+# (job description, résumé) -> fit score 0-100, or None when the model gave none.
+Scorer = Callable[[str, str], Optional[float]]
+
+
+class WatchError(ValueError):
+    """Raised for a bad feed spec or watch config, or an unknown queued job."""
+
+    pass
+
+
+@dataclass(frozen=True)
+class Feed:
+    kind: str
+    target: str = ""
+
+    @classmethod
+    def from_spec(cls, spec: str) -> "Feed":
+        """``hn``, ``remoteok[:TAG]``, ``greenhouse:BOARD``, ``lever:COMPANY``,
+        ``rss:URL``."""
+        kind, _, target = str(spec).strip().partition(":")
+        if kind not in FEED_KINDS:
+            raise WatchError(f"Unknown feed '{spec}' (kinds: {', '.join(FEED_KINDS)})")
+        if not target and kind not in _UNTARGETED:
+            raise WatchError(f"Feed '{spec}' needs a target: {kind}:...")
+        return cls(kind, target)
+
+    @property
+    def spec(self) -> str:
+        return f"{self.kind}:{self.target}" if self.target else self.kind
+
+    def fetch(self, get: Fetch = http_get) -> Dict[str, JobPosting]:
+        """The feed's current postings by id (unique across feeds)."""
+        readers = {
+            "hn": _hn,
+            "remoteok": _remoteok,
+            "greenhouse": _greenhouse,
+            "lever": _lever,
+            "rss": _rss,
+        }
+        return {f"{self.kind}:{key}": post for key, post in readers[self.kind](self, get).items()}
+
+
+def _json(get: Fetch, url: str) -> Any:
+    body = get(url)
+    try:
+        return json.loads(body)
+    except ValueError as e:
+        raise JobBoardError(f"{url} returned something other than JSON") from e
+
+
+def _hn(feed: Feed, get: Fetch) -> Dict[str, JobPosting]:
+    hits = _json(get, HN_SEARCH_URL).get("hits") or []
+    thread = next(
+        (hit for hit in hits if str(hit.get("title", "")).startswith("Ask HN: Who is hiring")),
+        None,
+    )
+    if thread is None:
+        return {}
+    postings = {}
+    for comment in _json(get, HN_ITEM_URL.format(id=thread["objectID"])).get("children") or []:
+        text = comment.get("text")
+        if not text:
+            continue
+        # By convention the first line is "Company | Role | Location | ...".
+        first = re.split(r"<p>", text, maxsplit=1)[0]
+        fields = [part.strip() for part in re.sub(r"<[^>]+>", "", first).split("|")]
+        posting = JobPosting(
+            url=f"https://news.ycombinator.com/item?id={comment['id']}",
+            board="hn",
+            company=fields[0] if len(fields) > 1 else "",
+            title=fields[1] if len(fields) > 1 else fields[0][:80],
+            location=fields[2] if len(fields) > 2 else "",
+        )
+        postings[str(comment["id"])] = add_description(posting, text)
+    return postings
+
+
+def _remoteok(feed: Feed, get: Fetch) -> Dict[str, JobPosting]:
+    url = f"{REMOTEOK_URL}?tag={feed.target}" if feed.target else REMOTEOK_URL
+    postings = {}
+    for job in _json(get, url):
+        if not isinstance(job, dict) or not job.get("position"):
+            continue  # the first entry is the API's legal notice
+        posting = JobPosting(
+            url=job.get("url") or "",
+            board="remoteok",
+            title=job["position"],
+            company=job.get("company") or "",
+            location=job.get("location") or "Remote",
+        )
+        postings[str(job.get("id") or job.get("url"))] = add_description(
+            posting, job.get("description") or ""
+        )
+    return postings
+
+
+def _greenhouse(feed: Feed, get: Fetch) -> Dict[str, JobPosting]:
+    url = f"https://boards-api.greenhouse.io/v1/boards/{feed.target}/jobs?content=true"
+    return {
+        str(job["id"]): greenhouse_posting(job.get("absolute_url") or "", job, feed.target)
+        for job in _json(get, url).get("jobs") or []
+    }
+
+
+def _lever(feed: Feed, get: Fetch) -> Dict[str, JobPosting]:
+    url = f"https://api.lever.co/v0/postings/{feed.target}?mode=json"
+    return {
+        str(job["id"]): lever_posting(job.get("hostedUrl") or "", job, feed.target)
+        for job in _json(get, url)
+    }
+
+
+def _rss(feed: Feed, get: Fetch) -> Dict[str, JobPosting]:
+    try:
+        root = ElementTree.fromstring(get(feed.target))
+    except ElementTree.ParseError as e:
+        raise JobBoardError(f"{feed.target} is not an RSS or Atom feed: {e}") from e
+    postings = {}
+    for item in root.iter("item"):
+        link = item.findtext("link") or ""
+        posting = JobPosting(url=link, board="rss", title=item.findtext("title") or "")
+        key = item.findtext("guid") or link
+        postings[key] = add_description(posting, item.findtext("description") or "")
+    for entry in root.iter(f"{_ATOM}entry"):
+        link = entry.find(f"{_ATOM}link")
+        url = link.get("href", "") if link is not None else ""
+        posting = JobPosting(url=url, board="rss", title=entry.findtext(f"{_ATOM}title") or "")
+        body = entry.findtext(f"{_ATOM}content") or entry.findtext(f"{_ATOM}summary") or ""
+        postings[entry.findtext(f"{_ATOM}id") or url] = add_description(posting, body)
+    return postings
+
+
+def configured_feeds(specs: List[str], config_path: Optional[Path] = None) -> List[Feed]:
+    """``specs`` (``--feed``), else the ``feeds`` of ``~/.hydra/watch.yaml``."""
+    if not specs:
+        path = config_path or hydra_home() / WATCH_CONFIG_FILE
+        if path.is_file():
+            config = yaml.safe_load(path.read_text()) or {}
+            if not isinstance(config, dict) or not isinstance(config.get("feeds", []), list):
+                raise WatchError(f"{path}: expected a 'feeds' list")
+            specs = config.get("feeds") or []
+    return [Feed.from_spec(spec) for spec in specs]
+
+
+@dataclass
+class QueuedJob:
+    """A posting that scored high enough, waiting for approval."""
+
+    id: str
+    feed: str
+    url: str
+    title: str
+    company: str
+    fit_score: float
+    coverage: Optional[float]
+    jd_path: str
+    found: str
+    status: str = PENDING
+    # What the full run uses: the watch's baseline and sources.
+    resume_path: Optional[str] = None
+    profile: Optional[str] = None
+    sources_path: Optional[str] = None
+    exit_code: Optional[int] = None
+
+    @classmethod
+    def from_dict(cls, data: Dict[str, Any]) -> "QueuedJob":
+        return cls(**data)
+
+
+class JobQueue:
+    """The queue and the seen ids under ``root`` (``~/.hydra/watch`` by default)."""
+
+    def __init__(self, root: Optional[Path] = None):
+        self.root = Path(root) if root is not None else hydra_home() / WATCH_DIR
+
+    def jobs(self, status: Optional[str] = None) -> List[QueuedJob]:
+        path = self.root / QUEUE_FILE
+        data = json.loads(path.read_text()) if path.is_file() else []
+        jobs = [QueuedJob.from_dict(item) for item in data]
+        return [job for job in jobs if status is None or job.status == status]
+
+    def get(self, job_id: str) -> QueuedJob:
+        for job in self.jobs():
+            if job.id == job_id:
+                return job
+        raise WatchError(f"No queued job '{job_id}' (see `hydra watch queue`)")
+
+    def put(self, job: QueuedJob) -> None:
+        """Add ``job``, or replace the queued job with its id."""
+        jobs = [queued for queued in self.jobs() if queued.id != job.id] + [job]
+        self.root.mkdir(parents=True, exist_ok=True)
+        (self.root / QUEUE_FILE).write_text(json.dumps([asdict(j) for j in jobs], indent=2))
+
+    def seen(self) -> Dict[str, str]:
+        """Every posting id looked at, with when."""
+        path = self.root / SEEN_FILE
+        return json.loads(path.read_text()) if path.is_file() else {}
+
+    def mark_seen(self, ids: List[str]) -> None:
+        seen = self.seen()
+        now = datetime.now().isoformat(timespec="seconds")
+        seen.update({posting_id: seen.get(posting_id, now) for posting_id in ids})
+        self.root.mkdir(parents=True, exist_ok=True)
+        (self.root / SEEN_FILE).write_text(json.dumps(seen, indent=2))
+
+
+@dataclass
+class PollReport:
+    """What one pass over one feed found."""
+
+    feed: str
+    fetched: int = 0
+    new: int = 0
+    below_coverage: int = 0
+    scored: int = 0
+    queued: List[QueuedJob] = field(default_factory=list)
+    error: Optional[str] = None
+
+
+def gap_scorer(llm: Any) -> Scorer:
+    """Score with the gap analyzer on ``llm``: its fit score for the posting."""
+    agent = GapAnalyzerAgent(llm)
+
+    def score(job_description: str, resume: str) -> Optional[float]:
+        result = agent.execute({"job_description": job_description, "resume": resume})
+        return GapReview.from_raw(result).fit_score
+
+    return score
+
+
+def coverage_of(job_description: str, resume: str, taxonomy: SkillTaxonomy) -> Optional[float]:
+    """Share of the posting's skills the résumé has; None when it names none we know."""
+    coverage = skill_coverage(job_description, resume, taxonomy)
+    have = len(coverage.matched) + len(coverage.synonyms)
+    wanted = have + len(coverage.missing)
+    return have / wanted if wanted else None
+
+
+def poll(
+    feed: Feed,
+    resume: str,
+    score: Scorer,
+    queue: JobQueue,
+    taxonomy: SkillTaxonomy,
+    min_fit: float = DEFAULT_MIN_FIT,
+    min_coverage: float = DEFAULT_MIN_COVERAGE,
+    get: Fetch = http_get,
+    run_inputs: Optional[Dict[str, Optional[str]]] = None,
+) -> PollReport:
+    """One pass over ``feed``: score what is new and queue what scores ``min_fit``+.
+
+    ``run_inputs`` (``resume_path``, ``profile``, ``sources_path``) are kept with each
+    queued job for its full run. A posting that fails to score is left unseen, so the
+    next pass tries it again.
+    """
+    report = PollReport(feed.spec)
+    try:
+        postings = feed.fetch(get)
+    except JobBoardError as e:
+        report.error = str(e)
+        return report
+    report.fetched = len(postings)
+    seen = queue.seen()
+    looked_at = []
+    for posting_id, posting in postings.items():
+        if posting_id in seen:
+            continue
+        report.new += 1
+        job_description = posting.to_markdown()
+        coverage = coverage_of(job_description, resume, taxonomy)
+        if coverage is not None and coverage < min_coverage:
+            report.below_coverage += 1
+            looked_at.append(posting_id)
+            continue
+        try:
+            fit = score(job_description, resume)
+        except Exception as e:  # one bad posting (or model reply) must not stop the watch
+            report.error = f"{posting_id}: {e}"
+            continue
+        report.scored += 1
+        looked_at.append(posting_id)
+        if fit is None or fit < min_fit:
+            continue
+        job = QueuedJob(
+            id=_queue_id(posting_id),
+            feed=feed.spec,
+            url=posting.url,
+            title=posting.title,
+            company=posting.company,
+            fit_score=fit,
+            coverage=coverage,
+            jd_path=str(save_posting(posting)),
+            found=datetime.now().isoformat(timespec="seconds"),
+            **(run_inputs or {}),
+        )
+        queue.put(job)
+        report.queued.append(job)
+    queue.mark_seen(looked_at)
+    return report
+
+
+def _queue_id(posting_id: str) -> str:
+    """A short id to type: ``greenhouse:4012345`` -> ``greenhouse-4012345``."""
+    return re.sub(r"[^a-z0-9]+", "-", posting_id.lower()).strip("-")[-48:]
@@ -0,0 +1,139 @@
+"""Getting a JSON object out of a model's answer, and asking again when there is none.
+
+Agents answer in JSON, but models wrap it in code fences or prose, leave trailing
+commas, or put raw line breaks inside strings. ``parse_object`` finds the object and
+makes only repairs that cannot change what the model meant:
+
+- the first fenced block holding an object is used, wherever the fence is;
+- the object is the first balanced ``{...}``, so prose after it (even with braces) is
+  ignored;
+- commas before a closing ``}`` or ``]`` are dropped (outside strings);
+- raw control characters inside strings are accepted.
+
+Quotes are never rewritten (``'`` is an apostrophe far more often than a delimiter)
+and missing commas or brackets are not guessed. What still does not parse is sent
+back to the model with the parse error (``repair_instruction``). Where the provider
+offers it, the model is asked for JSON output in the first place (``json_mode_params``).
+"""
+
+from __future__ import annotations
+
+import json
+import re
+from typing import Any, Dict, Optional
+
+_FENCE = re.compile(r"```[A-Za-z]*[ \t]*\n?(.*?)```", re.DOTALL)
+# How much of an unparseable answer is quoted back to the model.
+MAX_QUOTED_CHARS = 2000
+
+
+class JSONRepairError(ValueError):
+    """Raised when an answer holds no JSON object, even after the safe repairs."""
+
+    pass
+
+
+def strip_fences(text: str) -> str:
+    """The first fenced block that holds an object, or ``text`` without fences."""
+    for match in _FENCE.finditer(text):
+        if "{" in match.group(1):
+            return match.group(1).strip()
+    return text.strip()
+
+
+def extract_object(text: str) -> str:
+    """The first balanced ``{...}`` in ``text``; from its first ``{`` to the last ``}``
+    when the braces do not balance (the parse error then says where)."""
+    start = text.find("{")
+    if start == -1:
+        return text
+    depth = 0
+    in_string = escaped = False
+    for i in range(start, len(text)):
+        char = text[i]
+        if in_string:
+            if escaped:
+                escaped = False
+            elif char == "\\":
+                escaped = True
+            elif char == '"':
+                in_string = False
+        elif char == '"':
+            in_string = True
+        elif char == "{":
+            depth += 1
+        elif char == "}":
+            depth -= 1
+            if depth == 0:
+                return text[start : i + 1]
+    end = text.rfind("}")
+    return text[start : end + 1] if end > start else text[start:]
+
+
+def drop_trailing_commas(text: str) -> str:
+    """``text`` without commas before a closing ``}`` or ``]``, leaving strings alone."""
+    out = []
+    in_string = escaped = False
+    for i, char in enumerate(text):
+        if in_string:
+            if escaped:
+                escaped = False
+            elif char == "\\":
+                escaped = True
+            elif char == '"':
+                in_string = False
+        elif char == '"':
+            in_string = True
+        elif char == ",":
+            rest = text[i + 1 :].lstrip()
+            if rest[:1] in ("}", "]"):
+                continue
+        out.append(char)
+    return "".join(out)
+
+
+def parse_object(text: str) -> Dict[str, Any]:
+    """The JSON object in a model's answer; JSONRepairError if there is none."""
+    candidate = extract_object(strip_fences(text))
+    return parse_repaired(candidate)
+
+
+def parse_repaired(candidate: str) -> Dict[str, Any]:
+    """``candidate`` parsed as an object, with trailing commas dropped if need be."""
+    try:
+        parsed = json.loads(candidate, strict=False)
+    except json.JSONDecodeError:
+        try:
+            parsed = json.loads(drop_trailing_commas(candidate), strict=False)
+        except json.JSONDecodeError as e:
+            raise JSONRepairError(f"Invalid JSON output: {e}") from e
+    if not isinstance(parsed, dict):
+        raise JSONRepairError("Output must be a JSON object (dictionary)")
+    return parsed
+
+
+def repair_instruction(error: str, output: str) -> str:
+    """What to add to a task whose last answer did not parse."""
+    quoted = output if len(output) <= MAX_QUOTED_CHARS else output[:MAX_QUOTED_CHARS] + "…"
+    return (
+        "\n\nJSON REPAIR: your previous answer could not be used "
+        f"({error}). It was:\n\n{quoted}\n\n"
+        "Answer again with the same content as one valid JSON object: double-quoted "
+        "keys and strings, no trailing commas, no comments, no text or code fences "
+        "around it."
+    )
+
+
+def json_mode_params(model: Optional[str]) -> Dict[str, Any]:
+    """``response_format`` for a LiteLLM call, if the model's provider offers JSON mode."""
+    if not model:
+        return {}
+    try:
+        import litellm
+
+        supported = litellm.get_supported_openai_params(model=model) or []
+    except Exception:  # unknown model or provider: ask for nothing special
+        return {}
+    if "response_format" not in supported:
+        return {}
+    return {"response_format": {"type": "json_object"}}
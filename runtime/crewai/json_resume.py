@@ -0,0 +1,305 @@
+"""JSON Resume: the jsonresume.org schema as an input and output format.
+
+A baseline résumé may be a JSON Resume document (``--resume resume.json``). It is
+validated against the schema (v1.0.0) and rendered to Markdown for the agents, which
+work on text; the document itself is kept in the workflow context as ``json_resume``.
+When it is there — or when ``--json-resume`` asks for one from a Markdown baseline —
+the tailoring stage also returns the tailored résumé as a JSON Resume document. The
+workflow validates it and the run writes it to ``resume.json``, ready for any JSON
+Resume theme renderer. A tailored document that fails validation is logged and left
+out rather than written broken.
+
+The schema is mirrored here as pydantic models (as ``contracts`` does for stage
+outputs) rather than fetched: every section and field is optional and unknown fields
+are allowed, as in the upstream schema, but types and ISO 8601 dates are enforced.
+The JSON document is tailored before ATS optimisation, so it carries the tailoring
+stage's wording where ``resume.md`` may have ATS edits on top.
+"""
+
+from __future__ import annotations
+
+import json
+from typing import Annotated, Any, Dict, List, Optional
+
+from pydantic import BaseModel, ConfigDict, StringConstraints
+from pydantic import ValidationError as PydanticValidationError
+
+SCHEMA_URL = "https://raw.githubusercontent.com/jsonresume/resume-schema/v1.0.0/schema.json"
+JSON_RESUME_FILE = "resume.json"
+
+# YYYY, YYYY-MM or YYYY-MM-DD, as the schema's iso8601 definition.
+_ISO8601 = r"^([1-2][0-9]{3}-[0-1][0-9]-[0-3][0-9]|[1-2][0-9]{3}-[0-1][0-9]|[1-2][0-9]{3})$"
+Iso8601 = Annotated[str, StringConstraints(pattern=_ISO8601)]
+
+
+class JsonResumeError(ValueError):
+    """Raised for a document that is not valid JSON Resume."""
+
+    def __init__(self, errors: List[str]):
+        super().__init__("Not a valid JSON Resume document: " + "; ".join(errors))
+        self.errors = errors
+
+
+class _Section(BaseModel):
+    model_config = ConfigDict(extra="allow")
+
+
+class Location(_Section):
+    address: Optional[str] = None
+    postalCode: Optional[str] = None
+    city: Optional[str] = None
+    countryCode: Optional[str] = None
+    region: Optional[str] = None
+
+
+class Profile(_Section):
+    network: Optional[str] = None
+    username: Optional[str] = None
+    url: Optional[str] = None
+
+
+class Basics(_Section):
+    name: Optional[str] = None
+    label: Optional[str] = None
+    image: Optional[str] = None
+    email: Optional[str] = None
+    phone: Optional[str] = None
+    url: Optional[str] = None
+    summary: Optional[str] = None
+    location: Optional[Location] = None
+    profiles: List[Profile] = []
+
+
+class Work(_Section):
+    name: Optional[str] = None
+    location: Optional[str] = None
+    description: Optional[str] = None
+    position: Optional[str] = None
+    url: Optional[str] = None
+    startDate: Optional[Iso8601] = None
+    endDate: Optional[Iso8601] = None
+    summary: Optional[str] = None
+    highlights: List[str] = []
+
+
+class Volunteer(_Section):
+    organization: Optional[str] = None
+    position: Optional[str] = None
+    url: Optional[str] = None
+    startDate: Optional[Iso8601] = None
+    endDate: Optional[Iso8601] = None
+    summary: Optional[str] = None
+    highlights: List[str] = []
+
+
+class Education(_Section):
+    institution: Optional[str] = None
+    url: Optional[str] = None
+    area: Optional[str] = None
+    studyType: Optional[str] = None
+    startDate: Optional[Iso8601] = None
+    endDate: Optional[Iso8601] = None
+    score: Optional[str] = None
+    courses: List[str] = []
+
+
+class Award(_Section):
+    title: Optional[str] = None
+    date: Optional[Iso8601] = None
+    awarder: Optional[str] = None
+    summary: Optional[str] = None
+
+
+class Certificate(_Section):
+    name: Optional[str] = None
+    date: Optional[Iso8601] = None
+    url: Optional[str] = None
+    issuer: Optional[str] = None
+
+
+class Publication(_Section):
+    name: Optional[str] = None
+    publisher: Optional[str] = None
+    releaseDate: Optional[Iso8601] = None
+    url: Optional[str] = None
+    summary: Optional[str] = None
+
+
+class Skill(_Section):
+    name: Optional[str] = None
+    level: Optional[str] = None
+    keywords: List[str] = []
+
+
+class Language(_Section):
+    language: Optional[str] = None
+    fluency: Optional[str] = None
+
+
+class Interest(_Section):
+    name: Optional[str] = None
+    keywords: List[str] = []
+
+
+class Reference(_Section):
+    name: Optional[str] = None
+    reference: Optional[str] = None
+
+
+class Project(_Section):
+    name: Optional[str] = None
+    description: Optional[str] = None
+    highlights: List[str] = []
+    keywords: List[str] = []
+    startDate: Optional[Iso8601] = None
+    endDate: Optional[Iso8601] = None
+    url: Optional[str] = None
+    roles: List[str] = []
+    entity: Optional[str] = None
+    type: Optional[str] = None
+
+
+class Meta(_Section):
+    canonical: Optional[str] = None
+    version: Optional[str] = None
+    lastModified: Optional[str] = None
+
+
+class JsonResume(_Section):
+    basics: Optional[Basics] = None
+    work: List[Work] = []
+    volunteer: List[Volunteer] = []
+    education: List[Education] = []
+    awards: List[Award] = []
+    certificates: List[Certificate] = []
+    publications: List[Publication] = []
+    skills: List[Skill] = []
+    languages: List[Language] = []
+    interests: List[Interest] = []
+    references: List[Reference] = []
+    projects: List[Project] = []
+    meta: Optional[Meta] = None
+
+
+def validate(document: Any) -> List[str]:
+    """Schema violations in ``document``, as ``path: message`` strings; [] when valid."""
+    if not isinstance(document, dict):
+        return ["document: must be a JSON object"]
+    try:
+        JsonResume.model_validate(document)
+    except PydanticValidationError as e:
+        return [
+            f"{'.'.join(str(part) for part in error['loc'])}: {error['msg']}"
+            for error in e.errors()
+        ]
+    return []
+
+
+def looks_like_json_resume(text: str) -> bool:
+    """Whether a résumé file's text is a JSON Resume document rather than prose."""
+    if not text.lstrip().startswith("{"):
+        return False
+    try:
+        document = json.loads(text)
+    except json.JSONDecodeError:
+        return False
+    return isinstance(document, dict) and (
+        "basics" in document or "jsonresume" in str(document.get("$schema", ""))
+    )
+
+
+def parse_json_resume(text: str) -> Dict[str, Any]:
+    """The validated document in ``text``; raises ``JsonResumeError`` otherwise."""
+    try:
+        document = json.loads(text)
+    except json.JSONDecodeError as e:
+        raise JsonResumeError([f"document: not JSON ({e})"]) from e
+    errors = validate(document)
+    if errors:
+        raise JsonResumeError(errors)
+    return document
+
+
+def _dates(entry: Dict[str, Any]) -> str:
+    start, end = entry.get("startDate"), entry.get("endDate")
+    if not start:
+        return end or ""
+    return f"{start} – {end or 'Present'}"
+
+
+def to_markdown(document: Dict[str, Any]) -> str:
+    """A JSON Resume document as a Markdown résumé, in the layout the agents expect."""
+    basics = document.get("basics") or {}
+    location = basics.get("location") or {}
+    lines = [f"# {basics.get('name') or 'Candidate'}"]
+    if basics.get("label"):
+        lines.append(f"**{basics['label']}**")
+    place = ", ".join(p for p in (location.get("city"), location.get("region")) if p)
+    links = [profile.get("url") for profile in basics.get("profiles") or [] if profile.get("url")]
+    contact = [place, basics.get("email"), basics.get("phone"), basics.get("url"), *links]
+    if any(contact):
+        lines += ["", " | ".join(part for part in contact if part)]
+
+    if basics.get("summary"):
+        lines += ["", "---", "", "## Summary", "", basics["summary"].strip()]
+
+    if document.get("work"):
+        lines += ["", "---", "", "## Experience"]
+        for job in document["work"]:
+            lines += ["", f"### {job.get('name', '')} — {job.get('position', '')}"]
+            details = " | ".join(p for p in (_dates(job), job.get("location")) if p)
+            if details:
+                lines.append(f"**{details}**")
+            if job.get("summary"):
+                lines += ["", job["summary"].strip()]
+            if job.get("highlights"):
+                lines.append("")
+                lines += [f"- {highlight}" for highlight in job["highlights"]]
+
+    if document.get("projects"):
+        lines += ["", "---", "", "## Projects"]
+        for project in document["projects"]:
+            lines += ["", f"### {project.get('name', '')}"]
+            if project.get("description"):
+                lines.append(project["description"].strip())
+            lines += [f"- {highlight}" for highlight in project.get("highlights") or []]
+
+    if document.get("skills"):
+        lines += ["", "---", "", "## Skills", ""]
+        for skill in document["skills"]:
+            name, keywords = skill.get("name", ""), ", ".join(skill.get("keywords") or [])
+            lines.append(f"**{name}:** {keywords}" if keywords else name)
+
+    if document.get("education"):
+        lines += ["", "---", "", "## Education", ""]
+        for school in document["education"]:
+            degree = " ".join(p for p in (school.get("studyType"), school.get("area")) if p)
+            head = " — ".join(p for p in (degree, school.get("institution")) if p)
+            dates = _dates(school)
+            lines.append(f"{head}, {dates}" if dates else head)
+
+    if document.get("certificates"):
+        lines += ["", "---", "", "## Certifications", ""]
+        for certificate in document["certificates"]:
+            parts = (certificate.get("name"), certificate.get("issuer"), certificate.get("date"))
+            lines.append("- " + " — ".join(p for p in parts if p))
+
+    if document.get("languages"):
+        lines += ["", "---", "", "## Languages", ""]
+        for language in document["languages"]:
+            fluency = language.get("fluency")
+            name = language.get("language", "")
+            lines.append(f"- {name} ({fluency})" if fluency else f"- {name}")
+
+    return "\n".join(lines) + "\n"
+
+
+def extract_tailored(result: Dict[str, Any]) -> Optional[Any]:
+    """The JSON Resume document in a tailoring result, if the agent returned one."""
+    document = result.get("tailored_json_resume", result.get("json_resume"))
+    if isinstance(document, str):
+        try:
+            document = json.loads(document)
+        except json.JSONDecodeError:
+            return document  # reported as invalid by ``validate``
+    return document
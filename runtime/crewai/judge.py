@@ -0,0 +1,66 @@
+"""The judge: rubric scores for the final résumé, from 1 to 10, each with a rationale.
+
+With ``--judge`` a run ends with one more model call, after the audit. The Judge reads
+the job description, the audited résumé and the sources, and grades the résumé on
+four rubrics (``agents/judge/prompt.md`` anchors each score):
+
+- ``relevance`` — how directly it answers this job description;
+- ``truthfulness`` — whether every claim is backed by the résumé and sources;
+- ``readability`` — whether a recruiter takes it in at a glance;
+- ``ats_friendliness`` — whether an applicant tracking system will parse it.
+
+The audit decides whether the résumé may be sent; the judge decides nothing. Its
+scores put runs, prompts and models on one scale: the run report shows them with
+their rationales, ``run.json`` keeps the scores alone, and an eval case can require
+a minimum (see evals). A rubric the model did not score is left out, not guessed,
+and a failed judge never fails the run.
+"""
+
+from __future__ import annotations
+
+import statistics
+from typing import Any, Dict, List, Optional
+
+from runtime.crewai.contracts import JUDGE_CRITERIA
+
+CRITERIA = JUDGE_CRITERIA
+LABELS = {
+    "relevance": "Relevance",
+    "truthfulness": "Truthfulness",
+    "readability": "Readability",
+    "ats_friendliness": "ATS-friendliness",
+}
+
+
+def overall(judgement: Optional[Dict[str, Any]]) -> Optional[float]:
+    """The mean of the rubric scores, to one decimal; None if nothing was scored."""
+    scores = [item["score"] for item in ((judgement or {}).get("scores") or {}).values()]
+    return round(statistics.fmean(scores), 1) if scores else None
+
+
+def lowest(judgement: Optional[Dict[str, Any]]) -> Optional[int]:
+    """The lowest rubric score; None if nothing was scored."""
+    scores = [item["score"] for item in ((judgement or {}).get("scores") or {}).values()]
+    return min(scores) if scores else None
+
+
+def report_lines(judgement: Dict[str, Any]) -> List[str]:
+    """One line per scored rubric, in rubric order: the score and its rationale."""
+    scores = judgement.get("scores") or {}
+    lines = []
+    for criterion in CRITERIA:
+        if criterion in scores:
+            item = scores[criterion]
+            why = f" — {item['rationale']}" if item.get("rationale") else ""
+            lines.append(f"{LABELS[criterion]}: {item['score']}/10{why}")
+    return lines
+
+
+def manifest_summary(judgement: Dict[str, Any]) -> Dict[str, Any]:
+    """What ``run.json`` keeps of the judgement: the scores, not the rationales (which
+    quote the résumé)."""
+    scores = judgement.get("scores") or {}
+    return {
+        "scores": {criterion: item["score"] for criterion, item in scores.items()},
+        "overall": overall(judgement),
+    }
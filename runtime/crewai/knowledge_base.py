@@ -0,0 +1,232 @@
+"""Candidate knowledge base: verified facts that accumulate across runs.
+
+Every run adds what it learned about the candidate to
+``$HYDRA_HOME/knowledge_base.json`` (encrypted like run state when encryption is on):
+each interview answer, and each line of the sources that states a metric, a tool or a
+date. A fact keeps the project it is about (the interview question's theme or gap,
+or the heading it sits under in the sources), the metrics, tools and dates it names
+(found as the claim check finds them, see claim_verification), and the runs that
+confirmed it. The same statement seen again is merged, not added twice.
+
+Facts are verified by where they come from: the candidate said them, or the
+candidate's own documents do. Nothing a model wrote is ever added; tailored résumés
+are not sources.
+
+A run is given the facts most relevant to its job description — ranked by the tools
+and words they share with it, then by how many runs confirmed them and how recently
+— in the Interrogator-Prepper's prompt (so it does not ask what is already known),
+in the Tailoring Agent's prompt, and as claim-check evidence. ``--no-knowledge-base``
+runs without it, reading and adding nothing; ``hydra knowledge`` lists, ranks and
+forgets facts.
+"""
+
+from __future__ import annotations
+
+import hashlib
+import json
+import re
+import threading
+from dataclasses import asdict, dataclass, field
+from datetime import date
+from pathlib import Path
+from typing import Any, Dict, Iterable, List, Optional
+
+from runtime.crewai.claim_verification import extract_claims
+from runtime.crewai.encryption import read_text, write_text
+from runtime.crewai.stage_cache import hydra_home
+
+KNOWLEDGE_BASE_FILE = "knowledge_base.json"  # in hydra_home()
+
+INTERVIEW = "interview"
+SOURCES = "sources"
+
+# How many facts a prompt gets, and how many one run's sources may add.
+MAX_PROMPT_FACTS = 15
+MAX_SOURCE_FACTS = 200
+# Shorter lines are labels or skill lists, not statements.
+MIN_FACT_WORDS = 4
+# A tool the job description names counts this many shared words.
+TOOL_WEIGHT = 3
+
+_KB_LOCK = threading.Lock()
+_MONTH = r"(?:jan|feb|mar|apr|may|jun|jul|aug|sep|sept|oct|nov|dec)[a-z]*\.?"
+_DATE_RE = re.compile(rf"\b(?:{_MONTH}\s+)?(?:19|20)\d{{2}}\b", re.IGNORECASE)
+_HEADING_RE = re.compile(r"^\s*#{1,6}\s+(?P<title>.+?)\s*#*\s*$")
+_MARKER_RE = re.compile(r"^\s*(?:[-*+•]|\d+[.)])\s+")
+_WORD_RE = re.compile(r"[a-z][a-z0-9+#.]{2,}")
+_STOPWORDS = frozenset(
+    {
+        *("the", "and", "for", "with", "our", "you", "your", "are", "was", "were", "that"),
+        *("this", "from", "have", "has", "will", "who", "what", "into", "their", "they"),
+        *("team", "teams", "work", "role", "years", "experience", "about", "across"),
+    }
+)
+
+
+@dataclass
+class Fact:
+    """One verified statement about the candidate, and where it was confirmed."""
+
+    id: str
+    text: str
+    origin: str  # INTERVIEW | SOURCES
+    project: str = ""
+    metrics: List[str] = field(default_factory=list)
+    tools: List[str] = field(default_factory=list)
+    dates: List[str] = field(default_factory=list)
+    runs: List[str] = field(default_factory=list)
+    first_seen: str = ""
+    last_seen: str = ""
+
+    def to_dict(self) -> Dict[str, Any]:
+        return asdict(self)
+
+    @classmethod
+    def from_dict(cls, data: Dict[str, Any]) -> "Fact":
+        names = cls.__dataclass_fields__
+        return cls(**{key: value for key, value in data.items() if key in names})
+
+
+def fact_id(text: str) -> str:
+    """Stable id of a statement: the same words in any case or punctuation match."""
+    words = " ".join(re.findall(r"[a-z0-9]+", text.lower()))
+    return hashlib.sha256(words.encode()).hexdigest()[:10]
+
+
+def _fact(text: str, origin: str, project: str, run_id: str) -> Fact:
+    claims = extract_claims(text, origin)
+    dates = sorted({match.group(0) for match in _DATE_RE.finditer(text)})
+    years = {re.sub(r"\D", "", found) for found in dates}
+    today = date.today().isoformat()
+    return Fact(
+        id=fact_id(text),
+        text=text,
+        origin=origin,
+        project=project,
+        metrics=sorted({c.text for c in claims if c.kind == "metric"} - years),
+        tools=sorted({c.text for c in claims if c.kind == "skill"}),
+        dates=dates,
+        runs=[run_id],
+        first_seen=today,
+        last_seen=today,
+    )
+
+
+def facts_from_interview(experience: Iterable[Dict[str, Any]], run_id: str) -> List[Fact]:
+    """One fact per interview answer (see interview.compile_experience)."""
+    facts = []
+    for entry in experience:
+        answer = " ".join(str(entry.get("answer") or "").split())
+        if answer:
+            project = entry.get("gap") or entry.get("theme") or ""
+            facts.append(_fact(answer, INTERVIEW, project, entry.get("reused_from") or run_id))
+    return facts
+
+
+def facts_from_sources(sources: str, run_id: str, limit: int = MAX_SOURCE_FACTS) -> List[Fact]:
+    """One fact per line of the sources that states a metric, a tool or a date, with
+    the heading it sits under as its project."""
+    facts, project = [], ""
+    for line in (sources or "").splitlines():
+        heading = _HEADING_RE.match(line)
+        if heading:
+            project = heading.group("title").strip(" *")
+            continue
+        text = " ".join(_MARKER_RE.sub("", line).split()).strip("*_ ")
+        if len(text.split()) < MIN_FACT_WORDS:
+            continue
+        fact = _fact(text, SOURCES, project, run_id)
+        if fact.metrics or fact.tools or fact.dates:
+            facts.append(fact)
+        if len(facts) >= limit:
+            break
+    return facts
+
+
+def _words(text: str) -> set:
+    return {word.strip(".") for word in _WORD_RE.findall(text.lower())} - _STOPWORDS
+
+
+def relevance(fact: Fact, job_description: str) -> int:
+    """Shared words of a fact and a job description; a shared tool counts more."""
+    jd = job_description.lower()
+    tools = [tool for tool in fact.tools if tool.lower() in jd]
+    words = _words(f"{fact.project} {fact.text}") & _words(jd)
+    words -= {tool.lower() for tool in tools}
+    return TOOL_WEIGHT * len(tools) + len(words)
+
+
+def render_facts(facts: Iterable[Any]) -> str:
+    """Facts as prompt text, one per line with its project and how often confirmed."""
+    lines = []
+    for fact in facts:
+        fact = fact if isinstance(fact, Fact) else Fact.from_dict(fact)
+        about = f"[{fact.project}] " if fact.project else ""
+        seen = len(fact.runs)
+        lines.append(f"- {about}{fact.text} ({fact.origin}, {seen} run{'s' * (seen != 1)})")
+    return "\n".join(lines)
+
+
+class KnowledgeBase:
+    """The facts in ``path`` (``~/.hydra/knowledge_base.json`` by default)."""
+
+    def __init__(self, path: Optional[Path] = None):
+        self.path = Path(path) if path is not None else hydra_home() / KNOWLEDGE_BASE_FILE
+
+    def facts(self) -> List[Fact]:
+        try:
+            data = json.loads(read_text(self.path))
+        except (OSError, ValueError):
+            return []
+        items = data.get("facts") if isinstance(data, dict) else None
+        return [Fact.from_dict(item) for item in items or [] if isinstance(item, dict)]
+
+    def _save(self, facts: List[Fact]) -> None:
+        self.path.parent.mkdir(parents=True, exist_ok=True, mode=0o700)
+        data = {"facts": [fact.to_dict() for fact in facts]}
+        write_text(self.path, json.dumps(data, indent=2, ensure_ascii=False))
+
+    def add(self, new: Iterable[Fact]) -> int:
+        """Merge ``new`` into the knowledge base; how many facts were not known yet."""
+        new = list(new)
+        if not new:
+            return 0
+        with _KB_LOCK:
+            facts = self.facts()
+            by_id = {fact.id: fact for fact in facts}
+            added = 0
+            for fact in new:
+                known = by_id.get(fact.id)
+                if known is None:
+                    facts.append(fact)
+                    by_id[fact.id] = fact
+                    added += 1
+                    continue
+                known.runs += [run for run in fact.runs if run not in known.runs]
+                known.last_seen = max(known.last_seen, fact.last_seen)
+                if fact.origin == INTERVIEW:
+                    known.origin = INTERVIEW  # the candidate confirmed it in person
+                known.project = known.project or fact.project
+            self._save(facts)
+            return added
+
+    def forget(self, fact_id: str) -> bool:
+        """Remove one fact; whether it was there."""
+        with _KB_LOCK:
+            facts = self.facts()
+            kept = [fact for fact in facts if fact.id != fact_id]
+            if len(kept) == len(facts):
+                return False
+            self._save(kept)
+            return True
+
+    def relevant(self, job_description: str, limit: int = MAX_PROMPT_FACTS) -> List[Fact]:
+        """The facts that share most with ``job_description``, best first."""
+        scored = [(relevance(fact, job_description), fact) for fact in self.facts()]
+        ranked = sorted(
+            ((score, fact) for score, fact in scored if score > 0),
+            key=lambda item: item[1].last_seen,
+            reverse=True,
+        )
+        ranked.sort(key=lambda item: (-item[0], -len(item[1].runs)))
+        return [fact for _, fact in ranked[:limit]]
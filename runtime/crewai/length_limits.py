@@ -0,0 +1,318 @@
+"""Length and readability limits on the tailored résumé, enforced after tailoring.
+
+Left to itself the Tailoring Agent writes three pages where two were wanted, with
+nine bullets under a role and sentences a recruiter reads twice. The limits are the
+``length`` section of the pipeline config (see runtime.crewai.pipeline_config)::
+
+    length:
+      max_pages: 2              # estimated pages of the résumé; 0 or null for none
+      max_bullets_per_role: 6   # bullets under each ### role of the experience
+      max_grade_level: 12       # Flesch-Kincaid grade of bullets and summary; unset: none
+      trim_passes: 2            # re-prompts of the tailoring agent to trim
+
+Software checks them, so a limit does not depend on the model keeping to it:
+
+- pages are estimated from the rendered lines: each line wraps at
+  ``CHARS_PER_LINE`` characters, a section heading takes a line more, and a page holds
+  ``LINES_PER_PAGE`` lines (a one-column layout at 10-11pt);
+- bullets are counted per ``###`` entry of the experience sections (see resume_model);
+- the grade is the Flesch-Kincaid grade level of the bullets and the summary,
+  syllables counted by vowel groups.
+
+A résumé over a limit goes back to the Tailoring Agent with what is over and by how
+much, up to ``trim_passes`` times; a trim is kept only if it is closer to the limits.
+Bullets still over the per-role limit are then cut, keeping each role's first ones,
+which the agent orders by relevance. Pages and grade are only ever trimmed by the
+model, so a run can end over them; the check never fails the run. ``run.json`` keeps
+the measures before and after under ``length``.
+"""
+
+from __future__ import annotations
+
+import math
+import re
+from dataclasses import dataclass, field
+from typing import Any, Dict, List, Mapping, Optional, Union
+
+from runtime.crewai.resume_model import Bullet, Entry, ResumeDocument, parse_resume
+
+DEFAULT_MAX_PAGES = 2
+DEFAULT_MAX_BULLETS = 6
+DEFAULT_TRIM_PASSES = 2
+MAX_TRIM_PASSES = 5
+
+CHARS_PER_LINE = 95
+LINES_PER_PAGE = 50
+
+_EXPERIENCE_RE = re.compile(r"experience|employment|work history|career", re.IGNORECASE)
+_SUMMARY_RE = re.compile(r"summary|profile|about|objective", re.IGNORECASE)
+_MARKUP_RE = re.compile(r"[*_`#>]+|\[([^\]]*)\]\([^)]*\)")
+_WORD_RE = re.compile(r"[A-Za-z]+(?:'[a-z]+)?")
+_SENTENCE_END_RE = re.compile(r"[.!?]+(?=\s|$)")
+# Summary lines shorter than this are labels, not prose.
+_MIN_PROSE_WORDS = 5
+
+
+def _limit(value: Any, name: str, whole: bool = False) -> Optional[float]:
+    """A positive limit; 0, null or false for none."""
+    if value is None or value is False or value == 0:
+        return None
+    if isinstance(value, bool) or not isinstance(value, (int, float)) or value < 0:
+        raise ValueError(f"{name} must be a positive number, or 0 for no limit")
+    if whole and not float(value).is_integer():
+        raise ValueError(f"{name} must be a whole number")
+    return int(value) if whole else float(value)
+
+
+@dataclass(frozen=True)
+class LengthPolicy:
+    """The résumé's limits, and how many times the model is asked to keep to them."""
+
+    max_pages: Optional[float] = DEFAULT_MAX_PAGES
+    max_bullets_per_role: Optional[int] = DEFAULT_MAX_BULLETS
+    max_grade_level: Optional[float] = None
+    trim_passes: int = DEFAULT_TRIM_PASSES
+
+    @classmethod
+    def from_dict(cls, data: Mapping[str, Any]) -> "LengthPolicy":
+        """``{"max_pages": 2, "max_bullets_per_role": 6, "max_grade_level": 12,
+        "trim_passes": 2}``; omitted keys keep their defaults. Raises ValueError on
+        unknown keys or bad values."""
+        keys = {"max_pages", "max_bullets_per_role", "max_grade_level", "trim_passes"}
+        unknown = set(data) - keys
+        if unknown:
+            raise ValueError(f"unknown length setting(s): {', '.join(sorted(unknown))}")
+        default = cls()
+        passes = data.get("trim_passes", default.trim_passes)
+        if isinstance(passes, bool) or not isinstance(passes, int):
+            raise ValueError("length.trim_passes must be a whole number")
+        if not 0 <= passes <= MAX_TRIM_PASSES:
+            raise ValueError(f"length.trim_passes must be between 0 and {MAX_TRIM_PASSES}")
+        return cls(
+            max_pages=_limit(data.get("max_pages", default.max_pages), "length.max_pages"),
+            max_bullets_per_role=_limit(
+                data.get("max_bullets_per_role", default.max_bullets_per_role),
+                "length.max_bullets_per_role",
+                whole=True,
+            ),
+            max_grade_level=_limit(
+                data.get("max_grade_level", default.max_grade_level), "length.max_grade_level"
+            ),
+            trim_passes=passes,
+        )
+
+    def to_dict(self) -> Dict[str, Any]:
+        return {
+            "max_pages": self.max_pages,
+            "max_bullets_per_role": self.max_bullets_per_role,
+            "max_grade_level": self.max_grade_level,
+            "trim_passes": self.trim_passes,
+        }
+
+    @property
+    def enabled(self) -> bool:
+        return any(
+            limit is not None
+            for limit in (self.max_pages, self.max_bullets_per_role, self.max_grade_level)
+        )
+
+
+@dataclass
+class LengthReport:
+    """The résumé's measures against the policy's limits."""
+
+    pages: float
+    grade_level: Optional[float]
+    # Roles over the bullet limit: {"role", "bullets"}.
+    long_roles: List[Dict[str, Any]] = field(default_factory=list)
+    policy: LengthPolicy = field(default_factory=LengthPolicy)
+
+    @property
+    def over_pages(self) -> bool:
+        return self.policy.max_pages is not None and self.pages > self.policy.max_pages
+
+    @property
+    def over_grade(self) -> bool:
+        limit = self.policy.max_grade_level
+        return limit is not None and self.grade_level is not None and self.grade_level > limit
+
+    @property
+    def violations(self) -> List[str]:
+        """What is over, in words."""
+        found = []
+        if self.over_pages:
+            found.append(f"about {self.pages:g} pages (at most {self.policy.max_pages:g})")
+        for role in self.long_roles:
+            found.append(
+                f"{role['bullets']} bullets under '{role['role']}' "
+                f"(at most {self.policy.max_bullets_per_role})"
+            )
+        if self.over_grade:
+            found.append(
+                f"reading grade {self.grade_level:g} (at most {self.policy.max_grade_level:g})"
+            )
+        return found
+
+    @property
+    def excess(self) -> float:
+        """How far over the limits, each as a fraction of its limit; 0 within them."""
+        policy = self.policy
+        total = 0.0
+        if self.over_pages:
+            total += (self.pages - policy.max_pages) / policy.max_pages
+        for role in self.long_roles:
+            total += (role["bullets"] - policy.max_bullets_per_role) / policy.max_bullets_per_role
+        if self.over_grade:
+            total += (self.grade_level - policy.max_grade_level) / policy.max_grade_level
+        return total
+
+    def instructions(self) -> List[str]:
+        """What the Tailoring Agent is told to trim."""
+        policy = self.policy
+        found = []
+        if self.over_pages:
+            found.append(
+                f"Cut the resume to {policy.max_pages:g} page(s); it runs to about "
+                f"{self.pages:g}. Drop what serves this role least and tighten the rest; "
+                "keep every role, title and date."
+            )
+        if self.long_roles:
+            roles = ", ".join(f"'{role['role']}' has {role['bullets']}" for role in self.long_roles)
+            found.append(
+                f"Keep at most {policy.max_bullets_per_role} bullets per role ({roles}), "
+                "the ones that serve this role best first."
+            )
+        if self.over_grade:
+            found.append(
+                f"Write for a reading grade of {policy.max_grade_level:g} or lower (now "
+                f"{self.grade_level:g}): shorter sentences, plain words, one idea per bullet."
+            )
+        return found
+
+    def to_dict(self) -> Dict[str, Any]:
+        return {
+            "pages": self.pages,
+            "grade_level": self.grade_level,
+            "long_roles": self.long_roles,
+            "violations": self.violations,
+        }
+
+
+def _plain(line: str) -> str:
+    return _MARKUP_RE.sub(lambda m: m.group(1) or "", line).strip()
+
+
+def estimate_pages(text: str) -> float:
+    """Pages ``text`` fills, to a tenth (see the module doc)."""
+    lines = 0
+    for line in (text or "").splitlines():
+        plain = _plain(line)
+        if not plain:
+            continue
+        lines += math.ceil(len(plain) / CHARS_PER_LINE)
+        if line.lstrip().startswith("## "):
+            lines += 1  # the space above a section
+    return round(lines / LINES_PER_PAGE, 1)
+
+
+def syllables(word: str) -> int:
+    """Syllables in ``word``: vowel groups, less a silent final e."""
+    word = word.lower()
+    count = len(re.findall(r"[aeiouy]+", word))
+    if count > 1 and word.endswith("e") and not word.endswith(("le", "ee")):
+        count -= 1
+    return max(1, count)
+
+
+def grade_level(sentences: List[str]) -> Optional[float]:
+    """Flesch-Kincaid grade level of ``sentences`` (each at least one); None if no words."""
+    words = [word for text in sentences for word in _WORD_RE.findall(text)]
+    if not words:
+        return None
+    count = sum(
+        max(1, len([part for part in _SENTENCE_END_RE.split(text) if _WORD_RE.search(part)]))
+        for text in sentences
+        if _WORD_RE.search(text)
+    )
+    total = sum(syllables(word) for word in words)
+    return round(0.39 * len(words) / count + 11.8 * total / len(words) - 15.59, 1)
+
+
+def _prose(text: str) -> List[str]:
+    """The résumé's bullets, and its summary's lines."""
+    found = []
+    for section in parse_resume(text).sections:
+        summary = bool(_SUMMARY_RE.search(section.title))
+        for entry in section.entries:
+            for item in entry.lines:
+                if isinstance(item, Bullet):
+                    found.append(_plain(item.text))
+                elif summary and len(_WORD_RE.findall(item)) >= _MIN_PROSE_WORDS:
+                    found.append(_plain(item))
+    return [line for line in found if line]
+
+
+def _roles(document: ResumeDocument) -> List[Entry]:
+    """The ``###`` entries of the experience sections."""
+    return [
+        entry
+        for section in document.sections
+        if _EXPERIENCE_RE.search(section.title)
+        for entry in section.entries
+        if entry.heading
+    ]
+
+
+def check_length(text: str, policy: LengthPolicy) -> LengthReport:
+    """Measure the résumé ``text`` against ``policy``."""
+    limit = policy.max_bullets_per_role
+    long_roles = [
+        {"role": _plain(entry.heading), "bullets": len(entry.bullets)}
+        for entry in _roles(parse_resume(text))
+        if limit is not None and len(entry.bullets) > limit
+    ]
+    return LengthReport(
+        pages=estimate_pages(text),
+        grade_level=grade_level(_prose(text)),
+        long_roles=long_roles,
+        policy=policy,
+    )
+
+
+def cut_bullets(text: str, max_bullets: int) -> str:
+    """``text`` with each experience role's bullets after the first ``max_bullets`` cut,
+    each with the indented lines it wraps onto."""
+    document = parse_resume(text)
+    for entry in _roles(document):
+        kept = [bullet.id for bullet in entry.bullets[:max_bullets]]
+        lines: List[Union[str, Bullet]] = []
+        cutting = False
+        for item in entry.lines:
+            if isinstance(item, Bullet):
+                cutting = item.id not in kept
+            elif not (item[:1].isspace() and item.strip()):
+                cutting = False  # a blank or unindented line ends the bullet
+            if not cutting:
+                lines.append(item)
+        entry.lines = lines
+    return document.render()
+
+
+def manifest_summary(enforcement: Dict[str, Any]) -> Dict[str, Any]:
+    """What ``run.json`` keeps of the enforcement: limits and measures, never the roles."""
+
+    def _measures(report: Dict[str, Any]) -> Dict[str, Any]:
+        return {
+            "pages": report.get("pages"),
+            "grade_level": report.get("grade_level"),
+            "long_roles": len(report.get("long_roles") or []),
+            "violations": len(report.get("violations") or []),
+        }
+
+    return {
+        "limits": enforcement.get("limits"),
+        "before": _measures(enforcement.get("before") or {}),
+        "after": _measures(enforcement.get("after") or {}),
+        "trims": enforcement.get("trims", 0),
+        "bullets_cut": enforcement.get("bullets_cut", 0),
+    }
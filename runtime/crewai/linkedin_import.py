@@ -0,0 +1,242 @@
+"""LinkedIn import: a baseline résumé from the official LinkedIn data export.
+
+LinkedIn's "Get a copy of your data" download is a ZIP of CSV files. ``hydra
+import-linkedin <export.zip>`` reads the ones that make up a résumé — Profile,
+Positions, Education, Skills, Certifications, Languages and Email Addresses — and
+writes a Markdown résumé in the layout of ``examples/sample_resume.md``, ready to
+pass as ``--resume``. Candidates without an up-to-date résumé file start from their
+LinkedIn history instead of a blank page.
+
+The import only restructures what the export holds; nothing is written or improved
+by a model, so every line of the result is something the candidate already
+published. ``--format json`` writes the structured résumé instead. The export may
+also be an unzipped directory; CSVs missing from it (partial exports leave some
+out) leave their section empty.
+"""
+
+from __future__ import annotations
+
+import csv
+import io
+import json
+import zipfile
+from dataclasses import asdict, dataclass, field
+from pathlib import Path
+from typing import Any, Dict, List
+
+PROFILE_CSV = "Profile.csv"
+POSITIONS_CSV = "Positions.csv"
+EDUCATION_CSV = "Education.csv"
+SKILLS_CSV = "Skills.csv"
+CERTIFICATIONS_CSV = "Certifications.csv"
+LANGUAGES_CSV = "Languages.csv"
+EMAILS_CSV = "Email Addresses.csv"
+
+PRESENT = "Present"
+
+
+class LinkedInImportError(ValueError):
+    """Raised when the path is not a LinkedIn export (or holds no profile)."""
+
+    pass
+
+
+@dataclass
+class Position:
+    company: str
+    title: str
+    started_on: str = ""
+    finished_on: str = ""  # empty while the role is current
+    location: str = ""
+    description: str = ""
+
+    @property
+    def dates(self) -> str:
+        if not self.started_on:
+            return self.finished_on
+        return f"{self.started_on} – {self.finished_on or PRESENT}"
+
+
+@dataclass
+class Education:
+    school: str
+    degree: str = ""
+    start: str = ""
+    end: str = ""
+    notes: str = ""
+
+
+@dataclass
+class LinkedInProfile:
+    """The résumé-relevant part of a LinkedIn export."""
+
+    name: str
+    headline: str = ""
+    summary: str = ""
+    location: str = ""
+    email: str = ""
+    positions: List[Position] = field(default_factory=list)
+    education: List[Education] = field(default_factory=list)
+    skills: List[str] = field(default_factory=list)
+    certifications: List[str] = field(default_factory=list)
+    languages: List[str] = field(default_factory=list)
+
+    def to_dict(self) -> Dict[str, Any]:
+        return asdict(self)
+
+
+def _read_files(path: Path) -> Dict[str, str]:
+    """CSV name -> text, from the export ZIP or an unzipped export directory."""
+    if path.is_dir():
+        return {
+            p.name: p.read_text(encoding="utf-8-sig") for p in path.rglob("*.csv") if p.is_file()
+        }
+    if not zipfile.is_zipfile(path):
+        raise LinkedInImportError(f"{path} is not a LinkedIn export ZIP or directory")
+    with zipfile.ZipFile(path) as archive:
+        return {
+            Path(info.filename).name: archive.read(info).decode("utf-8-sig")
+            for info in archive.infolist()
+            if info.filename.lower().endswith(".csv")
+        }
+
+
+def _rows(files: Dict[str, str], name: str, header: str) -> List[Dict[str, str]]:
+    """Rows of one CSV, skipping the "Notes:" preamble LinkedIn puts above some headers."""
+    text = files.get(name)
+    if not text:
+        return []
+    lines = text.splitlines()
+    start = next((i for i, line in enumerate(lines) if header in line), None)
+    if start is None:
+        return []
+    reader = csv.DictReader(io.StringIO("\n".join(lines[start:])))
+    return [{k.strip(): (v or "").strip() for k, v in row.items() if k} for row in reader]
+
+
+def _paragraphs(text: str) -> List[str]:
+    return [line.strip(" •-*\t") for line in text.splitlines() if line.strip(" •-*\t")]
+
+
+def parse_export(path: Path) -> LinkedInProfile:
+    """Read a LinkedIn data export into a ``LinkedInProfile``."""
+    path = Path(path)
+    if not path.exists():
+        raise LinkedInImportError(f"No such export: {path}")
+    files = _read_files(path)
+    profile_rows = _rows(files, PROFILE_CSV, "First Name")
+    if not profile_rows:
+        raise LinkedInImportError(f"{path} has no {PROFILE_CSV}; is it a LinkedIn data export?")
+    profile = profile_rows[0]
+
+    emails = _rows(files, EMAILS_CSV, "Email Address")
+    primary = next((e for e in emails if e.get("Primary", "").lower() == "yes"), None)
+    email = (primary or (emails[0] if emails else {})).get("Email Address", "")
+
+    positions = [
+        Position(
+            company=row.get("Company Name", ""),
+            title=row.get("Title", ""),
+            started_on=row.get("Started On", ""),
+            finished_on=row.get("Finished On", ""),
+            location=row.get("Location", ""),
+            description=row.get("Description", ""),
+        )
+        for row in _rows(files, POSITIONS_CSV, "Company Name")
+        if row.get("Company Name") or row.get("Title")
+    ]
+    education = [
+        Education(
+            school=row.get("School Name", ""),
+            degree=row.get("Degree Name", ""),
+            start=row.get("Start Date", ""),
+            end=row.get("End Date", ""),
+            notes=row.get("Notes", ""),
+        )
+        for row in _rows(files, EDUCATION_CSV, "School Name")
+        if row.get("School Name")
+    ]
+    certifications = [
+        " — ".join(part for part in (row.get("Name", ""), row.get("Authority", "")) if part)
+        for row in _rows(files, CERTIFICATIONS_CSV, "Authority")
+        if row.get("Name")
+    ]
+    languages = [
+        f"{row['Name']} ({row['Proficiency']})" if row.get("Proficiency") else row["Name"]
+        for row in _rows(files, LANGUAGES_CSV, "Proficiency")
+        if row.get("Name")
+    ]
+
+    return LinkedInProfile(
+        name=" ".join(filter(None, (profile.get("First Name"), profile.get("Last Name")))),
+        headline=profile.get("Headline", ""),
+        summary=profile.get("Summary", ""),
+        location=profile.get("Geo Location", "") or profile.get("Location", ""),
+        email=email,
+        positions=positions,
+        education=education,
+        skills=[row["Name"] for row in _rows(files, SKILLS_CSV, "Name") if row.get("Name")],
+        certifications=certifications,
+        languages=languages,
+    )
+
+
+def render_markdown(profile: LinkedInProfile) -> str:
+    """The profile as a Markdown résumé in the layout of ``examples/sample_resume.md``."""
+    lines = [f"# {profile.name}"]
+    if profile.headline:
+        lines.append(f"**{profile.headline}**")
+    contact = " | ".join(part for part in (profile.location, profile.email) if part)
+    if contact:
+        lines += ["", contact]
+
+    if profile.summary:
+        lines += ["", "---", "", "## Summary", "", profile.summary.strip()]
+
+    if profile.positions:
+        lines += ["", "---", "", "## Experience"]
+        for position in profile.positions:
+            lines += ["", f"### {position.company} — {position.title}"]
+            details = " | ".join(part for part in (position.dates, position.location) if part)
+            if details:
+                lines.append(f"**{details}**")
+            bullets = _paragraphs(position.description)
+            if bullets:
+                lines.append("")
+                lines += [f"- {bullet}" for bullet in bullets]
+
+    if profile.skills:
+        lines += ["", "---", "", "## Skills", "", ", ".join(profile.skills)]
+
+    if profile.education:
+        lines += ["", "---", "", "## Education", ""]
+        for entry in profile.education:
+            years = "–".join(part for part in (entry.start, entry.end) if part)
+            head = " — ".join(part for part in (entry.degree, entry.school) if part)
+            lines.append(f"{head}, {years}" if years else head)
+
+    extras = (("Certifications", profile.certifications), ("Languages", profile.languages))
+    for title, items in extras:
+        if items:
+            lines += ["", "---", "", f"## {title}", ""]
+            lines += [f"- {item}" for item in items]
+
+    return "\n".join(lines) + "\n"
+
+
+def render_json(profile: LinkedInProfile) -> str:
+    return json.dumps(profile.to_dict(), indent=2, ensure_ascii=False) + "\n"
+
+
+def missing_sections(profile: LinkedInProfile) -> List[str]:
+    """Résumé sections the export left empty, worth filling in by hand."""
+    return [
+        name
+        for name, value in (
+            ("summary", profile.summary),
+            ("experience", profile.positions),
+            ("skills", profile.skills),
+            ("education", profile.education),
+        )
+        if not value
+    ]
@@ -4,12 +4,18 @@ LLM client integration for Composable Me Hydra.
 Handles OpenRouter LLM client configuration with error handling and retry logic.
 """
 
+import contextvars
 import os
 import time
-from typing import Optional
+from concurrent.futures import ThreadPoolExecutor
+from dataclasses import dataclass
+from typing import Any, Callable, List, Optional, Sequence
 
 from crewai import LLM
 
+from runtime.crewai.cancellation import CancelToken, RunCancelled
+from runtime.crewai.gateway import GATEWAY_CONFIG_ENV, GatewayError, get_gateway_llm
+
 
 class LLMClientError(Exception):
     """Raised when LLM client initialization or API calls fail"""
@@ -27,6 +33,8 @@ def get_llm_client(
     Configure and return LLM client for CrewAI.
 
     Supports multiple providers:
+    - An org-internal LLM gateway (HYDRA_GATEWAY_CONFIG) - preferred when configured,
+      since a user behind one usually cannot reach providers directly
     - Chutes.ai (CHUTES_API_KEY) - OpenAI-compatible gateway
     - OpenRouter (OPENROUTER_API_KEY) - Multi-model router
 
@@ -42,6 +50,12 @@ def get_llm_client(
     Raises:
         LLMClientError: If API key is missing or configuration fails
     """
+    if os.environ.get(GATEWAY_CONFIG_ENV):
+        try:
+            return get_gateway_llm(model)
+        except GatewayError as e:
+            raise LLMClientError(f"LLM gateway configuration error: {e}") from e
+
     # Check for Together AI first (preferred)
     together_key = api_key or os.environ.get("TOGETHER_API_KEY")
     chutes_key = os.environ.get("CHUTES_API_KEY")
@@ -256,3 +270,67 @@ class LLMRetryHandler:
 
         # All retries failed
         raise LLMClientError(f"Failed after {self.max_retries + 1} attempts: {last_error}")
+
+
+@dataclass
+class BatchResult:
+    """Outcome of one request in ``complete_batch``: its value, or the error it raised."""
+
+    value: Any = None
+    error: Optional[Exception] = None
+
+    @property
+    def ok(self) -> bool:
+        return self.error is None
+
+
+def complete_batch(
+    requests: Sequence[Callable[[], Any]],
+    cancel_token: Optional[CancelToken] = None,
+    max_workers: Optional[int] = None,
+) -> List[BatchResult]:
+    """
+    Run model-calling requests concurrently; one result per request, in order.
+
+    Each request is a callable that makes its model calls through an agent, so every
+    call still queues under the shared per-provider rate limiter (rate_limit): the
+    batch only sets how many may wait at once, the limiter decides when each is sent.
+    Requests run in a copy of the caller's context, so trace spans nest as before.
+
+    A request that raises does not fail the batch: its result carries the error and
+    the others run to the end. Cancellation is not a per-request error — once
+    ``cancel_token`` trips, requests not yet started are skipped and ``RunCancelled``
+    is raised after the running ones return.
+
+    Args:
+        requests: Zero-argument callables, e.g. ``lambda: agent.execute(context)``
+        cancel_token: The run's token; None never cancels
+        max_workers: Most requests in flight at once (default: all of them)
+
+    Returns:
+        One BatchResult per request, in ``requests`` order
+
+    Raises:
+        RunCancelled: If the run was cancelled during the batch
+    """
+    results = [BatchResult() for _ in requests]
+    cancelled: List[RunCancelled] = []
+
+    def _run(index: int) -> None:
+        if cancel_token is not None and cancel_token.cancelled:
+            return
+        try:
+            results[index].value = contextvars.copy_context().run(requests[index])
+        except RunCancelled as err:
+            cancelled.append(err)
+        except Exception as err:  # one request failing must not sink the batch
+            results[index].error = err
+
+    if requests:
+        with ThreadPoolExecutor(max_workers=max_workers or len(requests)) as pool:
+            list(pool.map(_run, range(len(requests))))
+    if cancelled:
+        raise cancelled[0]
+    if cancel_token is not None and cancel_token.cancelled:
+        raise RunCancelled("batch", cancel_token.reason or "cancelled")
+    return results
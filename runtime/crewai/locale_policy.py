@@ -0,0 +1,161 @@
+"""Per-country résumé conventions for personal data, enforced at export time.
+
+What belongs on a résumé differs by market. A photo, a date of birth, or a marital
+status is customary in some countries and an anti-discrimination red flag in others
+(a US or UK recruiter may discard a résumé with a photo unread). The candidate's own
+template usually reflects *their* market, not the target one.
+
+This module owns that gate in software. ``apply_locale_policy`` runs on the final
+documents just before they are written:
+
+- a field the target country **forbids** is removed, with a warning;
+- a field the target country **expects** but the document lacks produces a warning
+  only — the pipeline never invents a photo or a birth date (truth rules).
+
+Detection is line-based and deliberately conservative: it looks for Markdown/HTML
+images and for labelled fields ("Date of birth: ...", "Marital status: ..."), not for
+free-text mentions.
+"""
+
+from __future__ import annotations
+
+import re
+from dataclasses import dataclass, field
+from typing import Dict, List, Optional
+
+EXPECTED = "expected"
+OPTIONAL = "optional"
+FORBIDDEN = "forbidden"
+
+FIELDS = ("photo", "date_of_birth", "marital_status")
+
+FIELD_LABELS = {
+    "photo": "photo",
+    "date_of_birth": "date of birth",
+    "marital_status": "marital status",
+}
+
+_PHOTO_RE = re.compile(r"!\[[^\]]*\]\([^)]*\)|<img\b[^>]*>", re.IGNORECASE)
+_FIELD_RES = {
+    "date_of_birth": re.compile(
+        r"^\W*(date of birth|birth ?date|d\.?o\.?b\.?|born|geburtsdatum|date de naissance)"
+        r"\W*\s*[:\-–]",
+        re.IGNORECASE,
+    ),
+    "marital_status": re.compile(
+        r"^\W*(marital status|civil status|familienstand|[ée]tat civil|situation de famille)"
+        r"\W*\s*[:\-–]",
+        re.IGNORECASE,
+    ),
+}
+
+
+@dataclass(frozen=True)
+class LocalePolicy:
+    """Conventions of one target country for each personal-data field."""
+
+    country: str
+    photo: str = OPTIONAL
+    date_of_birth: str = OPTIONAL
+    marital_status: str = OPTIONAL
+
+    def rule(self, field_name: str) -> str:
+        return getattr(self, field_name)
+
+
+_ANGLO = dict(photo=FORBIDDEN, date_of_birth=FORBIDDEN, marital_status=FORBIDDEN)
+_DACH = dict(photo=OPTIONAL, date_of_birth=OPTIONAL, marital_status=OPTIONAL)
+
+# ISO 3166-1 alpha-2 -> conventions. Countries not listed get no enforcement.
+POLICIES: Dict[str, LocalePolicy] = {
+    "US": LocalePolicy("US", **_ANGLO),
+    "CA": LocalePolicy("CA", **_ANGLO),
+    "GB": LocalePolicy("GB", **_ANGLO),
+    "IE": LocalePolicy("IE", **_ANGLO),
+    "AU": LocalePolicy("AU", **_ANGLO),
+    "NZ": LocalePolicy("NZ", **_ANGLO),
+    "NL": LocalePolicy("NL", photo=OPTIONAL, date_of_birth=OPTIONAL, marital_status=FORBIDDEN),
+    "SE": LocalePolicy("SE", photo=OPTIONAL, date_of_birth=FORBIDDEN, marital_status=FORBIDDEN),
+    "FR": LocalePolicy("FR", photo=OPTIONAL, date_of_birth=OPTIONAL, marital_status=FORBIDDEN),
+    "DE": LocalePolicy("DE", **_DACH),
+    "AT": LocalePolicy("AT", **_DACH),
+    "CH": LocalePolicy("CH", photo=EXPECTED, date_of_birth=OPTIONAL, marital_status=OPTIONAL),
+    "JP": LocalePolicy("JP", photo=EXPECTED, date_of_birth=EXPECTED, marital_status=OPTIONAL),
+    "KR": LocalePolicy("KR", photo=EXPECTED, date_of_birth=EXPECTED, marital_status=OPTIONAL),
+    "AE": LocalePolicy("AE", photo=EXPECTED, date_of_birth=EXPECTED, marital_status=OPTIONAL),
+}
+
+# Common aliases accepted on the command line.
+_ALIASES = {"UK": "GB", "USA": "US", "UAE": "AE"}
+
+
+def get_policy(country: str) -> Optional[LocalePolicy]:
+    """Policy for a country code (case-insensitive, common aliases accepted)."""
+    code = country.strip().upper()
+    return POLICIES.get(_ALIASES.get(code, code))
+
+
+def detect_fields(document: str) -> Dict[str, List[int]]:
+    """Return ``{field: [line indexes]}`` for every personal-data field found."""
+    found: Dict[str, List[int]] = {name: [] for name in FIELDS}
+    for index, line in enumerate(document.splitlines()):
+        if _PHOTO_RE.search(line):
+            found["photo"].append(index)
+        for name, pattern in _FIELD_RES.items():
+            if pattern.search(line):
+                found[name].append(index)
+    return found
+
+
+@dataclass
+class PolicyReport:
+    """Outcome of applying one country's policy to the final documents."""
+
+    country: str
+    documents: Dict[str, str] = field(default_factory=dict)
+    removed: List[str] = field(default_factory=list)
+    warnings: List[str] = field(default_factory=list)
+
+
+def _strip_photo(line: str) -> str:
+    return _PHOTO_RE.sub("", line)
+
+
+def apply_locale_policy(documents: Dict[str, str], policy: LocalePolicy) -> PolicyReport:
+    """Enforce ``policy`` on each document; return cleaned copies plus warnings."""
+    report = PolicyReport(country=policy.country)
+    for name, text in documents.items():
+        if not text:
+            report.documents[name] = text
+            continue
+        found = detect_fields(text)
+        lines = text.splitlines()
+        drop: set[int] = set()
+        for field_name in FIELDS:
+            rule = policy.rule(field_name)
+            label = FIELD_LABELS[field_name]
+            hits = found[field_name]
+            if hits and rule == FORBIDDEN:
+                report.removed.append(f"{name}:{field_name}")
+                report.warnings.append(
+                    f"{name}: removed {label} — not expected on applications in "
+                    f"{policy.country} and may count against you"
+                )
+                if field_name == "photo":
+                    for i in hits:
+                        lines[i] = _strip_photo(lines[i])
+                        if not lines[i].strip():
+                            drop.add(i)
+                else:
+                    drop.update(hits)
+            elif not hits and rule == EXPECTED and name == "resume":
+                report.warnings.append(
+                    f"{name}: {label} is customary in {policy.country} but not present — "
+                    "add it yourself if you want to include it"
+                )
+        kept = [line for i, line in enumerate(lines) if i not in drop]
+        cleaned = "\n".join(kept)
+        if text.endswith("\n"):
+            cleaned += "\n"
+        report.documents[name] = cleaned
+    return report
@@ -0,0 +1,169 @@
+"""Output language: the résumé and cover letter written in a target language.
+
+``--lang de`` has the tailoring agent write the documents in German from the start,
+rather than translating them after the audit (``--translate-to`` does that, for a
+second version alongside the first). The ATS optimizer is told to keep that language.
+
+Dates follow the language's conventions: a German CV writes ``03/2021 – heute``, a
+French one ``mars 2021 – aujourd'hui``. The agents are asked for that form, and the
+theme renderer converts whatever English or numeric dates remain on a dates line
+(``Mar 2021``, ``March 2021``, ``2021-03``, ``03/2021``, ``Present``), so a themed
+résumé is consistent even when the model slipped. Templates also get ``$lang`` (the
+ISO code) and ``$babel`` (the LaTeX babel name) for hyphenation and fixed words.
+"""
+
+from __future__ import annotations
+
+import re
+from dataclasses import dataclass
+from typing import Dict, List, Optional, Tuple
+
+DEFAULT_LANGUAGE = "en"
+
+
+@dataclass(frozen=True)
+class Language:
+    code: str
+    name: str
+    babel: str
+    # Abbreviated month names, January first; None writes months as numbers (MM/YYYY).
+    months: Optional[Tuple[str, ...]]
+    present: str
+
+    def month_year(self, month: int, year: int) -> str:
+        if self.months is None:
+            return f"{month:02d}/{year}"
+        return f"{self.months[month - 1]} {year}"
+
+    def example(self) -> str:
+        """A date range the way this language writes it, for the agents' prompts."""
+        return f"{self.month_year(3, 2021)} – {self.present}"
+
+
+LANGUAGES: Dict[str, Language] = {
+    language.code: language
+    for language in (
+        Language(
+            "en",
+            "English",
+            "english",
+            ("Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"),
+            "Present",
+        ),
+        Language("de", "German", "ngerman", None, "heute"),
+        Language(
+            "fr",
+            "French",
+            "french",
+            ("janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.",
+             "nov.", "déc."),
+            "aujourd'hui",
+        ),
+        Language(
+            "es",
+            "Spanish",
+            "spanish",
+            ("ene.", "feb.", "mar.", "abr.", "may.", "jun.", "jul.", "ago.", "sept.", "oct.",
+             "nov.", "dic."),
+            "actualidad",
+        ),
+        Language(
+            "it",
+            "Italian",
+            "italian",
+            ("gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"),
+            "oggi",
+        ),
+        Language(
+            "nl",
+            "Dutch",
+            "dutch",
+            ("jan", "feb", "mrt", "apr", "mei", "jun", "jul", "aug", "sep", "okt", "nov", "dec"),
+            "heden",
+        ),
+        Language(
+            "pt",
+            "Portuguese",
+            "portuguese",
+            ("jan", "fev", "mar", "abr", "mai", "jun", "jul", "ago", "set", "out", "nov", "dez"),
+            "atual",
+        ),
+        Language("pl", "Polish", "polish", None, "obecnie"),
+        Language("sv", "Swedish", "swedish", None, "nu"),
+    )
+}
+
+LANGUAGES_PRESENT = tuple(language.present for language in LANGUAGES.values())
+_ENGLISH_MONTHS = (
+    "jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"
+)
+_MONTH_NAME = (
+    r"(?P<name>jan(?:uary)?|feb(?:ruary)?|mar(?:ch)?|apr(?:il)?|may|june?|july?|"
+    r"aug(?:ust)?|sept?(?:ember)?|oct(?:ober)?|nov(?:ember)?|dec(?:ember)?)\.?\s+"
+    r"(?P<name_year>(?:19|20)\d{2})"
+)
+_ISO = r"(?P<iso_year>(?:19|20)\d{2})-(?P<iso_month>0[1-9]|1[0-2])(?![\d-])"
+_NUMERIC = r"(?<![\d/])(?P<num_month>0?[1-9]|1[0-2])/(?P<num_year>(?:19|20)\d{2})"
+# "Present" only where it ends a range ("2021 – present"), not "current platform".
+_PRESENT = r"(?<=\d)(?P<until>\s*(?:[-–—]|to)\s*)(?P<present>present|current)\b"
+_DATE = re.compile(rf"\b(?:{_MONTH_NAME}|{_ISO}|{_NUMERIC})\b|{_PRESENT}", re.IGNORECASE)
+# Also bare years, and "Present" in any of the languages, for reading a period.
+_ANY_PRESENT = "|".join(
+    re.escape(word) for word in sorted({"present", "current", "now", *LANGUAGES_PRESENT})
+)
+_POINT = re.compile(
+    rf"\b(?:{_MONTH_NAME}|{_ISO}|{_NUMERIC}|(?P<year>(?:19|20)\d{{2}}))\b"
+    rf"|(?<!\w)(?P<any_present>{_ANY_PRESENT})(?!\w)",
+    re.IGNORECASE,
+)
+
+
+def get_language(code: str) -> Optional[Language]:
+    """The language for an ISO 639-1 ``code`` (case-insensitive), or None if unknown."""
+    return LANGUAGES.get(code.strip().lower())
+
+
+def localize_dates(text: str, language: Language) -> str:
+    """``text`` with month/year dates and "Present" written the ``language`` way."""
+
+    def convert(match: re.Match) -> str:
+        if match.group("present"):
+            return match.group("until") + language.present
+        year, month = _year_month(match)
+        return language.month_year(month, year)
+
+    return _DATE.sub(convert, text)
+
+
+def date_points(text: str) -> List[Optional[Tuple[int, Optional[int]]]]:
+    """The dates in ``text`` in order, as (year, month or None); None for "Present"."""
+    points: List[Optional[Tuple[int, Optional[int]]]] = []
+    for match in _POINT.finditer(text):
+        if match.group("any_present"):
+            points.append(None)
+        elif match.group("year"):
+            points.append((int(match.group("year")), None))
+        else:
+            points.append(_year_month(match))
+    return points
+
+
+def _year_month(match: re.Match) -> Tuple[int, int]:
+    if match.group("name"):
+        month = _ENGLISH_MONTHS.index(match.group("name")[:3].lower()) + 1
+        return int(match.group("name_year")), month
+    if match.group("iso_year"):
+        return int(match.group("iso_year")), int(match.group("iso_month"))
+    return int(match.group("num_year")), int(match.group("num_month"))
+
+
+def language_instruction(code: Optional[str], documents: str) -> str:
+    """Prompt lines asking for ``documents`` in the language ``code``; "" for none."""
+    language = get_language(code) if code else None
+    if language is None:
+        return ""
+    return f"""
+        Write {documents} in {language.name} ({language.code}), as a native {language.name}
+        recruiter would expect to read them. Keep names of people, companies, products,
+        technologies and certifications as they are. Write dates as {language.example()}.
+        """
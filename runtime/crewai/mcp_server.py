@@ -0,0 +1,452 @@
+"""MCP (Model Context Protocol) server exposing Hydra runs as tools and resources.
+
+Run with ``./run.sh mcp`` and register it with an MCP client (e.g. Claude Desktop) as
+a stdio server. The client can then drive an application conversationally:
+
+- ``start_run`` starts the workflow on a background thread and returns a run id;
+- ``get_run`` reports progress, and when the run pauses, what it is waiting for —
+  the gap-analysis greenlight (with the gaps) or interview answers (with the
+  questions);
+- ``answer_greenlight`` / ``answer_interview`` resume a paused run, exactly like the
+  web UI's approve/answer endpoints (the answers go back in as ``previous_results``,
+  ``gap_analysis_approved`` and ``interview_answers``);
+- ``read_output`` returns a finished run's documents, which are also listed as
+  ``hydra://runs/<run_id>/<file>`` resources — for every run under the output
+  directory, including CLI runs.
+
+The transport is newline-delimited JSON-RPC 2.0 on stdin/stdout, implemented here so
+the server needs no SDK. While serving, ``print`` output from the workflow is sent
+to stderr so it cannot corrupt the protocol stream.
+"""
+
+from __future__ import annotations
+
+import contextlib
+import json
+import sys
+import threading
+from dataclasses import dataclass, field
+from pathlib import Path
+from typing import Any, Callable, Dict, List, Optional, TextIO
+
+from runtime.crewai.artifacts import (
+    MANIFEST_FILE,
+    RunInputs,
+    generate_run_id,
+    write_run_artifacts,
+)
+from runtime.crewai.contracts import GapAnalysis
+from runtime.crewai.encryption import read_text
+from runtime.crewai.hydra_workflow import HydraWorkflow, RunStatus, WorkflowState
+from runtime.crewai.state_schema import STATE_VERSION
+from runtime.crewai.tools import Tool, ToolError, validate_arguments
+
+SERVER_NAME = "composable-me-hydra"
+SERVER_VERSION = "0.1.0"
+PROTOCOL_VERSION = "2025-03-26"
+SUPPORTED_PROTOCOL_VERSIONS = ("2024-11-05", "2025-03-26", "2025-06-18")
+RESOURCE_PREFIX = "hydra://runs/"
+MAX_WAIT_SECONDS = 30
+
+# JSON-RPC 2.0 error codes.
+PARSE_ERROR = -32700
+INVALID_REQUEST = -32600
+METHOD_NOT_FOUND = -32601
+INVALID_PARAMS = -32602
+
+RUNNING = "running"
+PAUSED = "paused"
+DECLINED = "declined"
+AWAITING_GREENLIGHT = "greenlight"
+AWAITING_INTERVIEW = "interview_answers"
+
+_MIME_TYPES = {
+    ".md": "text/markdown",
+    ".json": "application/json",
+    ".yaml": "application/yaml",
+    ".html": "text/html",
+}
+
+
+@dataclass
+class McpRun:
+    """A run started over MCP, and everything needed to resume it."""
+
+    run_id: str
+    context: Dict[str, Any]
+    status: str = RUNNING
+    state: Optional[str] = None
+    awaiting: Optional[str] = None
+    answers: Dict[str, Any] = field(default_factory=dict)
+    intermediate_results: Dict[str, Any] = field(default_factory=dict)
+    error: Optional[str] = None
+    run_dir: Optional[Path] = None
+    done: threading.Event = field(default_factory=threading.Event)
+
+    def summary(self) -> Dict[str, Any]:
+        summary: Dict[str, Any] = {
+            "run_id": self.run_id,
+            "status": self.status,
+            "state": self.state,
+            "awaiting": self.awaiting,
+        }
+        if self.error:
+            summary["error"] = self.error
+        if self.awaiting == AWAITING_GREENLIGHT:
+            gaps = GapAnalysis.from_raw(self.intermediate_results.get("gap_analysis")).gaps
+            summary["gaps"] = gaps
+        elif self.awaiting == AWAITING_INTERVIEW:
+            interrogation = self.intermediate_results.get("interrogation") or {}
+            summary["questions"] = interrogation.get("questions", [])
+        if self.run_dir is not None:
+            manifest = json.loads((self.run_dir / MANIFEST_FILE).read_text())
+            summary["audit"] = manifest.get("audit")
+            summary["resources"] = [
+                f"{RESOURCE_PREFIX}{self.run_id}/{name}" for name in manifest.get("artifacts", [])
+            ]
+        return summary
+
+
+class HydraRuns:
+    """Runs started over MCP, each executed on a background thread."""
+
+    def __init__(self, out_dir: Path, workflow_factory: Callable[[], HydraWorkflow]):
+        self.out_dir = Path(out_dir)
+        self.workflow_factory = workflow_factory
+        self.runs: Dict[str, McpRun] = {}
+        self._lock = threading.Lock()
+
+    def start(self, context: Dict[str, Any]) -> McpRun:
+        run = McpRun(run_id=generate_run_id(), context=context)
+        with self._lock:
+            self.runs[run.run_id] = run
+        self._launch(run)
+        return run
+
+    def get(self, run_id: str) -> McpRun:
+        with self._lock:
+            run = self.runs.get(run_id)
+        if run is None:
+            raise ToolError(f"unknown run: {run_id}")
+        return run
+
+    def resume(self, run: McpRun, awaiting: str, **answers: Any) -> McpRun:
+        with self._lock:
+            if run.status != PAUSED or run.awaiting != awaiting:
+                raise ToolError(f"run {run.run_id} is not waiting for {awaiting}")
+            run.answers.update(answers)
+            run.status, run.awaiting = RUNNING, None
+        self._launch(run)
+        return run
+
+    def decline(self, run: McpRun, notes: Optional[str]) -> McpRun:
+        with self._lock:
+            if run.status != PAUSED or run.awaiting != AWAITING_GREENLIGHT:
+                raise ToolError(f"run {run.run_id} is not waiting for {AWAITING_GREENLIGHT}")
+            run.status, run.awaiting, run.error = DECLINED, None, notes
+        return run
+
+    def list(self) -> List[McpRun]:
+        with self._lock:
+            return list(self.runs.values())
+
+    def wait(self, run: McpRun, seconds: float) -> None:
+        if seconds > 0:
+            run.done.wait(min(seconds, MAX_WAIT_SECONDS))
+
+    def _launch(self, run: McpRun) -> None:
+        run.done.clear()
+        threading.Thread(target=self._execute, args=(run,), daemon=True).start()
+
+    def _execute(self, run: McpRun) -> None:
+        try:
+            workflow = self.workflow_factory()
+            context = {
+                **run.context,
+                "previous_results": run.intermediate_results,
+                # Kept in this process, so always in the current shape.
+                "state_version": STATE_VERSION,
+                **run.answers,
+            }
+            result = workflow.execute(context)
+            with self._lock:
+                run.intermediate_results = {
+                    **run.intermediate_results,
+                    **(result.intermediate_results or {}),
+                }
+                run.state = result.state.value
+                if result.status is RunStatus.PAUSED:
+                    run.status = PAUSED
+                    run.awaiting = (
+                        AWAITING_GREENLIGHT
+                        if result.state is WorkflowState.GAP_ANALYSIS_REVIEW
+                        else AWAITING_INTERVIEW
+                    )
+                    return
+            run_dir = write_run_artifacts(
+                self.out_dir,
+                result,
+                run_id=run.run_id,
+                inputs=RunInputs(
+                    job_description_chars=len(run.context["job_description"]),
+                    resume_chars=len(run.context["resume"]),
+                    sources_chars=len(run.context["source_documents"]),
+                ),
+                include_intermediate=result.status is not RunStatus.COMPLETED,
+                baseline_resume=run.context["resume"],
+                source_documents=run.context["source_documents"],
+            )
+            with self._lock:
+                run.run_dir = run_dir
+                run.status = result.status.value
+                run.error = result.error_message or result.audit_error
+        except Exception as e:
+            with self._lock:
+                run.status, run.error = RunStatus.FAILED.value, str(e)
+        finally:
+            run.done.set()
+
+    # Resources: the artifacts of every run under out_dir, MCP-started or not.
+
+    def resources(self) -> List[Dict[str, Any]]:
+        listed = []
+        for manifest_path in sorted(self.out_dir.glob(f"*/{MANIFEST_FILE}")):
+            run_id = manifest_path.parent.name
+            try:
+                artifacts = json.loads(manifest_path.read_text()).get("artifacts", [])
+            except (OSError, ValueError):
+                continue
+            for name in [MANIFEST_FILE, *artifacts]:
+                listed.append(
+                    {
+                        "uri": f"{RESOURCE_PREFIX}{run_id}/{name}",
+                        "name": f"{run_id}/{name}",
+                        "mimeType": _mime_type(name),
+                    }
+                )
+        return listed
+
+    def read(self, run_id: str, name: str) -> str:
+        base = self.out_dir.resolve()
+        path = (base / run_id / name).resolve()
+        if base not in path.parents or not path.is_file():
+            raise ToolError(f"no such output: {run_id}/{name}")
+        return read_text(path)
+
+
+def _mime_type(name: str) -> str:
+    return _MIME_TYPES.get(Path(name).suffix, "text/plain")
+
+
+def _run_id_schema(**extra: Any) -> Dict[str, Any]:
+    return {
+        "type": "object",
+        "properties": {"run_id": {"type": "string"}, **extra},
+        "required": ["run_id"],
+    }
+
+
+class _RunsTool(Tool):
+    def __init__(self, runs: HydraRuns):
+        self.runs = runs
+
+
+class StartRunTool(_RunsTool):
+    name = "start_run"
+    description = (
+        "Start tailoring a résumé and cover letter for a job. Returns a run id at once; "
+        "the run pauses for your greenlight after gap analysis (see get_run)."
+    )
+    parameters = {
+        "type": "object",
+        "properties": {
+            "job_description": {"type": "string"},
+            "resume": {"type": "string", "description": "Résumé text (Markdown)"},
+            "source_documents": {
+                "type": "string",
+                "description": "Supporting material claims can be verified against",
+            },
+            "company": {"type": "string"},
+        },
+        "required": ["job_description", "resume"],
+    }
+
+    def execute(self, arguments: Dict[str, Any]) -> Any:
+        context = {
+            "job_description": arguments["job_description"],
+            "resume": arguments["resume"],
+            "source_documents": arguments.get("source_documents", ""),
+        }
+        if arguments.get("company"):
+            context["company"] = arguments["company"]
+        return self.runs.start(context).summary()
+
+
+class GetRunTool(_RunsTool):
+    name = "get_run"
+    description = (
+        "Status of a run; when paused, the gaps awaiting your greenlight or the interview "
+        "questions. wait_seconds (max 30) blocks until the run next stops."
+    )
+    parameters = _run_id_schema(wait_seconds={"type": "number"})
+
+    def execute(self, arguments: Dict[str, Any]) -> Any:
+        run = self.runs.get(arguments["run_id"])
+        self.runs.wait(run, arguments.get("wait_seconds", 0))
+        return run.summary()
+
+
+class ListRunsTool(_RunsTool):
+    name = "list_runs"
+    description = "Runs started in this session, newest first."
+    parameters = {"type": "object", "properties": {}}
+
+    def execute(self, arguments: Dict[str, Any]) -> Any:
+        return [
+            {"run_id": run.run_id, "status": run.status, "awaiting": run.awaiting}
+            for run in reversed(self.runs.list())
+        ]
+
+
+class AnswerGreenlightTool(_RunsTool):
+    name = "answer_greenlight"
+    description = (
+        "Approve or decline a run paused after gap analysis. Notes given with an approval "
+        "guide every later stage."
+    )
+    parameters = _run_id_schema(approve={"type": "boolean"}, notes={"type": "string"})
+    parameters["required"] = ["run_id", "approve"]
+
+    def execute(self, arguments: Dict[str, Any]) -> Any:
+        run = self.runs.get(arguments["run_id"])
+        if not arguments["approve"]:
+            return self.runs.decline(run, arguments.get("notes")).summary()
+        answers: Dict[str, Any] = {"gap_analysis_approved": True}
+        if arguments.get("notes"):
+            answers["greenlight_notes"] = arguments["notes"]
+        return self.runs.resume(run, AWAITING_GREENLIGHT, **answers).summary()
+
+
+class AnswerInterviewTool(_RunsTool):
+    name = "answer_interview"
+    description = (
+        "Answer the interview questions of a paused run (one entry per question, "
+        'e.g. {"question_id": ..., "answer": ...}) and resume it.'
+    )
+    parameters = _run_id_schema(answers={"type": "array"})
+    parameters["required"] = ["run_id", "answers"]
+
+    def execute(self, arguments: Dict[str, Any]) -> Any:
+        run = self.runs.get(arguments["run_id"])
+        return self.runs.resume(
+            run, AWAITING_INTERVIEW, interview_answers=arguments["answers"]
+        ).summary()
+
+
+class ReadOutputTool(_RunsTool):
+    name = "read_output"
+    description = "Read a file of a finished run, e.g. resume.md or cover_letter.md."
+    parameters = _run_id_schema(file={"type": "string"})
+    parameters["required"] = ["run_id", "file"]
+
+    def execute(self, arguments: Dict[str, Any]) -> Any:
+        return self.runs.read(arguments["run_id"], arguments["file"])
+
+
+class McpServer:
+    """Dispatches MCP JSON-RPC requests to the run tools and resources."""
+
+    def __init__(self, runs: HydraRuns):
+        self.runs = runs
+        self.tools: Dict[str, Tool] = {
+            tool.name: tool
+            for tool in (
+                StartRunTool(runs),
+                GetRunTool(runs),
+                ListRunsTool(runs),
+                AnswerGreenlightTool(runs),
+                AnswerInterviewTool(runs),
+                ReadOutputTool(runs),
+            )
+        }
+
+    def handle(self, message: Any) -> Optional[Dict[str, Any]]:
+        """Handle one JSON-RPC message; returns the response (None for notifications)."""
+        if not isinstance(message, dict) or message.get("jsonrpc") != "2.0":
+            return _error(None, INVALID_REQUEST, "not a JSON-RPC 2.0 message")
+        if "id" not in message:
+            return None  # notification, e.g. notifications/initialized
+        request_id = message["id"]
+        handler = {
+            "initialize": self._initialize,
+            "ping": lambda params: {},
+            "tools/list": self._list_tools,
+            "tools/call": self._call_tool,
+            "resources/list": lambda params: {"resources": self.runs.resources()},
+            "resources/read": self._read_resource,
+        }.get(message.get("method"))
+        if handler is None:
+            return _error(request_id, METHOD_NOT_FOUND, f"unknown method: {message.get('method')}")
+        try:
+            result = handler(message.get("params") or {})
+        except ToolError as e:
+            return _error(request_id, INVALID_PARAMS, str(e))
+        return {"jsonrpc": "2.0", "id": request_id, "result": result}
+
+    def _initialize(self, params: Dict[str, Any]) -> Dict[str, Any]:
+        requested = params.get("protocolVersion")
+        return {
+            "protocolVersion": (
+                requested if requested in SUPPORTED_PROTOCOL_VERSIONS else PROTOCOL_VERSION
+            ),
+            "capabilities": {"tools": {}, "resources": {}},
+            "serverInfo": {"name": SERVER_NAME, "version": SERVER_VERSION},
+        }
+
+    def _list_tools(self, params: Dict[str, Any]) -> Dict[str, Any]:
+        return {
+            "tools": [
+                {"name": t.name, "description": t.description, "inputSchema": t.parameters}
+                for t in self.tools.values()
+            ]
+        }
+
+    def _call_tool(self, params: Dict[str, Any]) -> Dict[str, Any]:
+        tool = self.tools.get(params.get("name"))
+        if tool is None:
+            raise ToolError(f"unknown tool: {params.get('name')}")
+        # Failures of a known tool are results the model can read, not protocol errors.
+        try:
+            arguments = validate_arguments(tool.parameters, params.get("arguments") or {})
+            output = tool.execute(arguments)
+        except ToolError as e:
+            return {"content": [{"type": "text", "text": str(e)}], "isError": True}
+        text = output if isinstance(output, str) else json.dumps(output, indent=2, default=str)
+        return {"content": [{"type": "text", "text": text}], "isError": False}
+
+    def _read_resource(self, params: Dict[str, Any]) -> Dict[str, Any]:
+        uri = str(params.get("uri", ""))
+        run_id, _, name = uri.removeprefix(RESOURCE_PREFIX).partition("/")
+        if not uri.startswith(RESOURCE_PREFIX) or not run_id or not name:
+            raise ToolError(f"unknown resource: {uri}")
+        text = self.runs.read(run_id, name)
+        return {"contents": [{"uri": uri, "mimeType": _mime_type(name), "text": text}]}
+
+
+def _error(request_id: Any, code: int, message: str) -> Dict[str, Any]:
+    return {"jsonrpc": "2.0", "id": request_id, "error": {"code": code, "message": message}}
+
+
+def serve(server: McpServer, stdin: TextIO = sys.stdin, stdout: TextIO = sys.stdout) -> None:
+    """Serve newline-delimited JSON-RPC until stdin closes."""
+    with contextlib.redirect_stdout(sys.stderr):
+        for line in stdin:
+            if not line.strip():
+                continue
+            try:
+                response = server.handle(json.loads(line))
+            except ValueError as e:
+                response = _error(None, PARSE_ERROR, f"invalid JSON: {e}")
+            if response is not None:
+                stdout.write(json.dumps(response, default=str) + "\n")
+                stdout.flush()
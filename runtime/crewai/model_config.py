@@ -15,6 +15,13 @@ from typing import Any, Dict, Optional
 
 from crewai import LLM
 
+from runtime.crewai.gateway import (
+    GATEWAY_CONFIG_ENV,
+    GATEWAY_PROVIDER,
+    GatewayError,
+    get_gateway_llm,
+)
+
 # Single source of truth for provider -> environment variable holding its API key.
 # Both the per-agent matrix below and llm_client's generic selection resolve keys
 # through PROVIDER_ENV_KEYS so there is one place to learn "which env var is which".
@@ -58,6 +65,15 @@ AGENT_MODELS: Dict[str, Dict[str, Any]] = {
             Why Llama 4 Maverick: MoE efficiency, strong instruction following.
         """,
     },
+    "research_agent": {
+        "provider": "together",
+        "model": "meta-llama/Llama-4-Maverick-17B-128E-Instruct-FP8",
+        "temperature": 0.2,
+        "rationale": """
+            Task: Plan web searches, summarize cited results about the employer.
+            Why Llama 4 Maverick: Extraction from search snippets, no résumé PII involved.
+        """,
+    },
     "interrogator_prepper": {
         "provider": "together",
         "model": "meta-llama/Llama-4-Maverick-17B-128E-Instruct-FP8",
@@ -125,7 +141,128 @@ AGENT_MODELS: Dict[str, Dict[str, Any]] = {
             Why frontier: Cross-document reasoning across 6+ agent outputs.
         """,
     },
+    "compensation_analyst": {
+        "provider": "anthropic",
+        "model": "claude-sonnet-4-20250514",
+        "fallback_provider": "together",
+        "fallback_model": "meta-llama/Llama-4-Maverick-17B-128E-Instruct-FP8",
+        "temperature": 0.3,
+        "rationale": """
+            Task: Weigh posted pay and cited research into ranges and a negotiation plan.
+            Why Sonnet: Careful reasoning about evidence; optional, runs once per job.
+        """,
+    },
+    "impact_rewriter": {
+        "provider": "anthropic",
+        "model": "claude-sonnet-4-20250514",
+        "fallback_provider": "together",
+        "fallback_model": "meta-llama/Llama-4-Maverick-17B-128E-Instruct-FP8",
+        "temperature": 0.4,
+        "rationale": """
+            Task: Weak bullets as impact statements, and questions for missing metrics.
+            Why Sonnet: Résumé voice without invented numbers; optional (--impact).
+        """,
+    },
+    "seniority_calibrator": {
+        "provider": "anthropic",
+        "model": "claude-sonnet-4-20250514",
+        "fallback_provider": "together",
+        "fallback_model": "meta-llama/Llama-4-Maverick-17B-128E-Instruct-FP8",
+        "temperature": 0.3,
+        "rationale": """
+            Task: The bullets that make the résumé read above or below the role's level.
+            Why Sonnet: Judgement about framing without adding facts; optional (--seniority).
+        """,
+    },
+    "outreach_writer": {
+        "provider": "anthropic",
+        "model": "claude-sonnet-4-20250514",
+        "fallback_provider": "together",
+        "fallback_model": "meta-llama/Llama-4-Maverick-17B-128E-Instruct-FP8",
+        "temperature": 0.6,
+        "rationale": """
+            Task: A short personal message to the hiring manager (referral template).
+            Why Sonnet: Candidate-facing voice, like tailoring; a few hundred tokens.
+        """,
+    },
+    "referral_finder": {
+        "provider": "anthropic",
+        "model": "claude-sonnet-4-20250514",
+        "fallback_provider": "together",
+        "fallback_model": "meta-llama/Llama-4-Maverick-17B-128E-Instruct-FP8",
+        "temperature": 0.6,
+        "rationale": """
+            Task: A short ask per contact at the target company (with --contacts).
+            Why Sonnet: Personal voice that fits each relationship; runs once per job.
+        """,
+    },
+    "judge": {
+        "provider": "anthropic",
+        "model": "claude-sonnet-4-20250514",
+        "fallback_provider": "together",
+        "fallback_model": "meta-llama/Llama-4-Maverick-17B-128E-Instruct-FP8",
+        "temperature": 0.0,
+        "rationale": """
+            Task: Score the final résumé 1-10 on four rubrics, with a rationale each.
+            Why Sonnet, temperature 0: Careful reading, and scores comparable across runs.
+        """,
+    },
+}
+
+
+# Approximate list prices in USD per 1M tokens (input, output), keyed by the model
+# names used in AGENT_MODELS. Used for cost *estimates* only (e.g. --dry-run);
+# providers change prices, so treat these as a budget guide, not a bill.
+MODEL_PRICING: Dict[str, Dict[str, float]] = {
+    "deepseek-ai/DeepSeek-V3": {"input": 0.27, "output": 1.10},
+    "meta-llama/Llama-4-Maverick-17B-128E-Instruct-FP8": {"input": 0.27, "output": 0.85},
+    "claude-sonnet-4-20250514": {"input": 3.00, "output": 15.00},
+    "gpt-4o-mini": {"input": 0.15, "output": 0.60},
+}
+
+
+# Context window (total tokens) per model. Prompts are compacted to fit (see
+# context_window.py); unknown models get the conservative DEFAULT_CONTEXT_WINDOW.
+MODEL_CONTEXT_WINDOWS: Dict[str, int] = {
+    "deepseek-ai/DeepSeek-V3": 64_000,
+    "meta-llama/Llama-4-Maverick-17B-128E-Instruct-FP8": 128_000,
+    "claude-sonnet-4-20250514": 200_000,
+    "gpt-4o-mini": 128_000,
 }
+DEFAULT_CONTEXT_WINDOW = 32_000
+
+
+def _lookup_model(table: Dict[str, Any], model: str) -> Optional[Any]:
+    """Find a model's entry in ``table``, tolerating LiteLLM provider prefixes."""
+    if not isinstance(model, str):
+        return None
+    if model in table:
+        return table[model]
+    # e.g. "together_ai/meta-llama/..." or "openrouter/anthropic/claude-..."
+    for name, entry in table.items():
+        if model.endswith(f"/{name}"):
+            return entry
+    return None
+
+
+def _pricing_for(model: str) -> Optional[Dict[str, float]]:
+    """Find pricing for a model name, tolerating LiteLLM provider prefixes."""
+    return _lookup_model(MODEL_PRICING, model)
+
+
+def get_context_window(model: Optional[str]) -> int:
+    """Context window in tokens for ``model`` (provider prefix optional)."""
+    window = _lookup_model(MODEL_CONTEXT_WINDOWS, model or "")
+    return window if window is not None else DEFAULT_CONTEXT_WINDOW
+
+
+def estimate_cost(model: str, input_tokens: int, output_tokens: int) -> Optional[float]:
+    """Estimated USD cost of one call, or None if the model has no known pricing."""
+    pricing = _pricing_for(model or "")
+    if pricing is None:
+        return None
+    cost = (input_tokens * pricing["input"] + output_tokens * pricing["output"]) / 1_000_000
+    return round(cost, 6)
 
 
 class LLMClientError(Exception):
@@ -188,12 +325,52 @@ def get_llm_for_agent(agent_type: str, fallback_only: bool = False) -> LLM:
             temperature=temperature,
         )
 
+    # Or the org's LLM gateway, at its default model.
+    if os.environ.get(GATEWAY_CONFIG_ENV):
+        return _create_llm(GATEWAY_PROVIDER, "", temperature, config)
+
     raise LLMClientError(
         f"No valid API key found for agent '{agent_type}'.\n"
-        "Set one of: TOGETHER_API_KEY, ANTHROPIC_API_KEY, CHUTES_API_KEY"
+        "Set one of: TOGETHER_API_KEY, ANTHROPIC_API_KEY, CHUTES_API_KEY "
+        f"(or {GATEWAY_CONFIG_ENV} for an LLM gateway)"
     )
 
 
+def parse_model_spec(spec: str) -> tuple[str, str]:
+    """Split a ``provider:model`` spec (e.g. ``together:meta-llama/...``)."""
+    provider, sep, model = spec.strip().partition(":")
+    providers = [*PROVIDER_ENV_KEYS, GATEWAY_PROVIDER]
+    if not sep or not model or provider not in providers:
+        raise LLMClientError(
+            f"Invalid model spec '{spec}': expected <provider>:<model> with provider one of "
+            f"{', '.join(providers)}"
+        )
+    return provider, model
+
+
+def get_llm_for_spec(spec: str, agent_type: str, temperature: Optional[float] = None) -> LLM:
+    """LLM for an explicit ``provider:model`` spec, at ``agent_type``'s temperature
+    (or ``temperature``).
+
+    Unlike ``get_llm_for_agent`` there is no fallback: the caller asked for this model.
+    """
+    provider, model = parse_model_spec(spec)
+    config = dict(AGENT_MODELS.get(agent_type, {}))
+    if temperature is not None:
+        config["temperature"] = temperature
+    if provider == "openrouter":
+        api_key = resolve_api_key("openrouter")
+        if not api_key:
+            raise LLMClientError("OPENROUTER_API_KEY not set")
+        return LLM(
+            model=f"openrouter/{model}",
+            api_key=api_key,
+            base_url="https://openrouter.ai/api/v1",
+            temperature=config.get("temperature", 0.5),
+        )
+    return _create_llm(provider, model, config.get("temperature", 0.5), config)
+
+
 def _create_llm(provider: str, model: str, temperature: float, config: dict) -> LLM:
     """Create an LLM instance for a specific provider.
 
@@ -236,6 +413,13 @@ def _create_llm(provider: str, model: str, temperature: float, config: dict) ->
             raise LLMClientError("OPENAI_API_KEY not set")
         return LLM(model=f"openai/{model}", api_key=api_key, temperature=temperature)
 
+    if provider == GATEWAY_PROVIDER:
+        # Not a LiteLLM route: the gateway has its own envelope (see runtime.crewai.gateway).
+        try:
+            return get_gateway_llm(model or None, temperature)
+        except GatewayError as e:
+            raise LLMClientError(str(e)) from e
+
     raise LLMClientError(f"Unknown provider: {provider}")
 
 
@@ -0,0 +1,265 @@
+"""Automatic model routing: promote the models that keep passing, demote the ones that don't.
+
+``model_config.AGENT_MODELS`` is a hand-tuned guess at the best model per agent. Past
+runs say how that guess is doing: every ``run.json`` records which model each agent
+used and whether the audit passed, and a run that was scored by an eval suite carries
+an ``eval`` section with a 0-1 ``score``. ``collect_stats`` turns the run directories
+into per-agent, per-model records; ``plan_routing`` compares them:
+
+- **promote** — another model that has served the agent at least ``MIN_RUNS`` times
+  scores ``PROMOTE_MARGIN`` better than the current one; it becomes the agent's model.
+- **demote** — the current model scores below ``DEMOTE_BELOW`` over at least
+  ``MIN_RUNS`` runs and nothing has clearly beaten it; a promoted model goes back to
+  the configured one, a configured model steps down to its configured fallback.
+
+A model's score is its audit pass rate, averaged with its mean eval score when it
+has any. Audit outcomes are per run, so every model in a run shares its verdict;
+models that only ever run together cannot be told apart and are left alone.
+
+Decisions are stored in ``$HYDRA_HOME/model_routing.json`` with the reason for each
+change, which the CLI prints when it makes one and ``hydra routing`` reports.
+"""
+
+from __future__ import annotations
+
+import json
+import os
+import threading
+from dataclasses import asdict, dataclass, field
+from datetime import date, datetime, timezone
+from pathlib import Path
+from typing import Any, Dict, List, Optional
+
+from runtime.crewai.artifacts import MANIFEST_FILE
+from runtime.crewai.model_config import AGENT_MODELS, LLMClientError, parse_model_spec
+from runtime.crewai.retro_audit import find_runs
+from runtime.crewai.stage_cache import hydra_home
+
+ROUTING_FILE = "model_routing.json"
+# Runs a model needs for an agent before its record counts either way.
+MIN_RUNS = 5
+# How much better a challenger must score to replace the current model.
+PROMOTE_MARGIN = 0.10
+# A current model scoring below this is demoted.
+DEMOTE_BELOW = 0.5
+# Values the manifest records when no specific model served the agent.
+_NO_MODEL = {"fallback", "unavailable", "unknown"}
+_ROUTING_LOCK = threading.Lock()
+
+
+@dataclass
+class ModelStats:
+    """How one model did for one agent across past runs."""
+
+    runs: int = 0
+    passed: int = 0
+    eval_scores: List[float] = field(default_factory=list)
+
+    @property
+    def pass_rate(self) -> float:
+        return self.passed / self.runs if self.runs else 0.0
+
+    @property
+    def eval_mean(self) -> Optional[float]:
+        return sum(self.eval_scores) / len(self.eval_scores) if self.eval_scores else None
+
+    @property
+    def score(self) -> float:
+        """Audit pass rate, averaged with the mean eval score when there is one."""
+        if self.eval_mean is None:
+            return self.pass_rate
+        return (self.pass_rate + self.eval_mean) / 2
+
+    def describe(self) -> str:
+        text = f"audit passed {self.passed}/{self.runs}"
+        if self.eval_mean is not None:
+            text += f", eval {self.eval_mean:.2f} over {len(self.eval_scores)}"
+        return f"{text}, score {self.score:.2f}"
+
+
+# agent type -> model spec -> record
+RoutingStats = Dict[str, Dict[str, ModelStats]]
+
+
+@dataclass
+class RoutingChange:
+    """One routing decision and why it was made."""
+
+    agent: str
+    old: str
+    new: str
+    reason: str
+    kind: str = "promote"
+    at: Optional[str] = None
+
+    def describe(self) -> str:
+        return f"{self.agent}: {self.old} → {self.new} ({self.kind}d; {self.reason})"
+
+    def to_dict(self) -> Dict[str, Any]:
+        return asdict(self)
+
+
+def configured_spec(agent: str) -> Optional[str]:
+    """``provider:model`` for the agent's model in AGENT_MODELS."""
+    config = AGENT_MODELS.get(agent) or {}
+    if not config.get("provider") or not config.get("model"):
+        return None
+    return f"{config['provider']}:{config['model']}"
+
+
+def fallback_spec(agent: str) -> Optional[str]:
+    """``provider:model`` for the agent's configured fallback, if it has one."""
+    config = AGENT_MODELS.get(agent) or {}
+    if not config.get("fallback_provider") or not config.get("fallback_model"):
+        return None
+    return f"{config['fallback_provider']}:{config['fallback_model']}"
+
+
+def _spec_for(agent: str, recorded: Any) -> Optional[str]:
+    """The spec behind a manifest ``models`` value, or None when it names no model.
+
+    Default routing records the bare model name; pinned and routed models record
+    the full spec.
+    """
+    if not isinstance(recorded, str) or recorded in _NO_MODEL:
+        return None
+    try:
+        parse_model_spec(recorded)
+        return recorded
+    except LLMClientError:
+        pass
+    config = AGENT_MODELS.get(agent) or {}
+    if recorded == config.get("model"):
+        return configured_spec(agent)
+    if recorded == config.get("fallback_model"):
+        return fallback_spec(agent)
+    return None
+
+
+def collect_stats(out_dir: Path, since: date = date.min) -> RoutingStats:
+    """Per-agent, per-model records from the run manifests under ``out_dir``.
+
+    Runs without an audit verdict (failed before the audit, dry runs) are skipped.
+    """
+    stats: RoutingStats = {}
+    for run_dir in find_runs(Path(out_dir), since):
+        try:
+            manifest = json.loads((run_dir / MANIFEST_FILE).read_text())
+        except (OSError, ValueError):
+            continue
+        passed = (manifest.get("audit") or {}).get("passed")
+        if passed is None:
+            continue
+        score = (manifest.get("eval") or {}).get("score")
+        for agent, recorded in (manifest.get("models") or {}).items():
+            spec = _spec_for(agent, recorded)
+            if spec is None:
+                continue
+            entry = stats.setdefault(agent, {}).setdefault(spec, ModelStats())
+            entry.runs += 1
+            entry.passed += 1 if passed else 0
+            if isinstance(score, (int, float)):
+                entry.eval_scores.append(float(score))
+    return stats
+
+
+def plan_routing(stats: RoutingStats, overrides: Dict[str, str]) -> List[RoutingChange]:
+    """Changes the records call for, given the current ``overrides`` (agent -> spec)."""
+    changes = []
+    for agent in sorted(stats):
+        records = stats[agent]
+        current = overrides.get(agent) or configured_spec(agent)
+        record = records.get(current)
+        if current is None or record is None or record.runs < MIN_RUNS:
+            continue
+        challengers = [
+            (spec, other)
+            for spec, other in records.items()
+            if spec != current and other.runs >= MIN_RUNS
+        ]
+        best = max(challengers, key=lambda item: item[1].score, default=None)
+        if best is not None and best[1].score >= record.score + PROMOTE_MARGIN:
+            changes.append(
+                RoutingChange(
+                    agent,
+                    current,
+                    best[0],
+                    f"{best[1].describe()} vs {record.describe()}",
+                )
+            )
+            continue
+        if record.score >= DEMOTE_BELOW:
+            continue
+        target = configured_spec(agent) if agent in overrides else fallback_spec(agent)
+        target_record = records.get(target)
+        if target is None or target == current:
+            continue
+        if target_record is not None and target_record.runs >= MIN_RUNS:
+            if target_record.score <= record.score:
+                continue  # stepping down would not help
+        changes.append(
+            RoutingChange(
+                agent,
+                current,
+                target,
+                f"{record.describe()}, below {DEMOTE_BELOW:.2f}",
+                kind="demote",
+            )
+        )
+    return changes
+
+
+class ModelRouting:
+    """Routed model per agent and the history of changes, under ``$HYDRA_HOME``."""
+
+    def __init__(self, path: Optional[Path] = None):
+        self.path = Path(path) if path is not None else hydra_home() / ROUTING_FILE
+
+    def load(self) -> Dict[str, Any]:
+        try:
+            data = json.loads(self.path.read_text())
+        except (OSError, ValueError):
+            return {"overrides": {}, "history": []}
+        if not isinstance(data, dict):
+            return {"overrides": {}, "history": []}
+        data.setdefault("overrides", {})
+        data.setdefault("history", [])
+        return data
+
+    def overrides(self) -> Dict[str, str]:
+        """Agent type -> routed ``provider:model`` spec (agents on their default omitted)."""
+        return dict(self.load()["overrides"])
+
+    def update(self, stats: RoutingStats) -> List[RoutingChange]:
+        """Apply the changes ``stats`` call for and record them; returns the changes."""
+        with _ROUTING_LOCK:
+            data = self.load()
+            changes = plan_routing(stats, data["overrides"])
+            if not changes:
+                return []
+            now = datetime.now(timezone.utc).isoformat(timespec="seconds")
+            for change in changes:
+                change.at = now
+                if change.new == configured_spec(change.agent):
+                    data["overrides"].pop(change.agent, None)
+                else:
+                    data["overrides"][change.agent] = change.new
+                data["history"].append(change.to_dict())
+            self._save(data)
+            return changes
+
+    def reset(self) -> None:
+        """Forget every routed model (back to AGENT_MODELS); the history is kept."""
+        with _ROUTING_LOCK:
+            data = self.load()
+            data["overrides"] = {}
+            self._save(data)
+
+    def _save(self, data: Dict[str, Any]) -> None:
+        try:
+            self.path.parent.mkdir(parents=True, exist_ok=True, mode=0o700)
+            tmp = self.path.with_suffix(".tmp")
+            tmp.write_text(json.dumps(data, indent=2, sort_keys=True))
+            os.replace(tmp, self.path)
+        except OSError:
+            pass  # routing is advisory; never fail a run over it
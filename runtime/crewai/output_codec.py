@@ -0,0 +1,87 @@
+"""Agent output as data: YAML or JSON in, canonical JSON-compatible values out.
+
+Several prompts illustrate their schema in YAML, and models sometimes answer in it.
+``decode_yaml`` accepts such an answer when JSON parsing (see json_repair) found no
+object: a fenced ``yaml`` block, or an answer that is a YAML mapping of more than one
+key from its first line. Either way the result goes through ``canonical``, so what is
+kept, cached, checkpointed and handed to the next stage is plain JSON data — string
+keys, lists, strings, numbers, booleans and null — whichever format the model wrote.
+YAML's own types are turned into their JSON forms: dates and times become ISO-8601
+strings, other keys become strings, sets become sorted lists.
+
+``to_yaml`` and ``to_json`` render canonical data back out; run directories keep
+stage outputs as YAML (see artifacts.write_stage_output).
+"""
+
+from __future__ import annotations
+
+import json
+import re
+from datetime import date, datetime, time
+from typing import Any, Dict, Optional
+
+import yaml
+
+_YAML_FENCE = re.compile(r"```(?:yaml|yml)[ \t]*\n(.*?)```", re.DOTALL | re.IGNORECASE)
+# A YAML mapping answer opens with "key:" (a plain or quoted key), not with prose.
+_MAPPING_START = re.compile(r"""^(?:[A-Za-z_][\w-]*|"[^"\n]+"|'[^'\n]+')[ \t]*:(?:\s|$)""")
+
+
+def decode_yaml(text: str) -> Optional[Dict[str, Any]]:
+    """The mapping in a YAML answer, canonical; None if the answer is not YAML."""
+    match = _YAML_FENCE.search(text)
+    if match:
+        source = match.group(1)
+    else:
+        source = text.strip()
+        if not _MAPPING_START.match(source):
+            return None
+    try:
+        data = yaml.safe_load(source)
+    except yaml.YAMLError:
+        return None
+    if not isinstance(data, dict) or not data:
+        return None
+    if not match and len(data) < 2:
+        return None  # "Note: ..." is a sentence, not an answer
+    return canonical(data)
+
+
+def canonical(value: Any) -> Any:
+    """``value`` as JSON data: what ``json.loads(json.dumps(value))`` would give, with
+    dates as ISO strings, every key a string and sets sorted."""
+    if isinstance(value, dict):
+        return {_key(k): canonical(v) for k, v in value.items()}
+    if isinstance(value, (list, tuple)):
+        return [canonical(v) for v in value]
+    if isinstance(value, (set, frozenset)):
+        return sorted((canonical(v) for v in value), key=repr)
+    if isinstance(value, (datetime, date, time)):
+        return value.isoformat()
+    if value is None or isinstance(value, (bool, int, str)):
+        return value
+    if isinstance(value, float):
+        return value if value == value and value not in (float("inf"), float("-inf")) else None
+    return str(value)
+
+
+def _key(key: Any) -> str:
+    if isinstance(key, str):
+        return key
+    if isinstance(key, bool) or key is None:
+        return json.dumps(key)  # true / false / null, as JSON writes them
+    if isinstance(key, (datetime, date, time)):
+        return key.isoformat()
+    return str(key)
+
+
+def to_yaml(value: Any) -> str:
+    """Canonical ``value`` as block YAML, keys in their original order."""
+    return yaml.safe_dump(
+        canonical(value), sort_keys=False, default_flow_style=False, allow_unicode=True
+    )
+
+
+def to_json(value: Any) -> str:
+    """Canonical ``value`` as indented JSON."""
+    return json.dumps(canonical(value), indent=2, ensure_ascii=False)
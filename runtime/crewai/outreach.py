@@ -0,0 +1,293 @@
+"""Outreach: short messages to the hiring manager or recruiter, in three lengths.
+
+The outreach stage runs in the ``referral`` workflow template (see
+runtime.crewai.workflow_templates), or in any run with ``--outreach``. It comes last,
+after the audit: the Outreach Writer reads the candidate's differentiators, the
+cited company research and the audited résumé, and writes the same pitch at three
+lengths, one for each channel:
+
+- ``connection_request``: a LinkedIn connection note, at most 300 characters;
+- ``inmail``: a LinkedIn InMail, a subject and a body of a few short paragraphs;
+- ``email``: a cold email, a subject and a body of at most about 200 words.
+
+Software owns the limits, as with the other stages' checks: a variant over its
+channel's limit is cut back to its last whole sentence (or word) that fits, and
+marked ``trimmed``, so nothing is handed over that the channel would reject.
+Citations to research sources that do not exist are dropped.
+
+The messages are written to ``outreach.md`` in the run directory, ready to copy. A
+failed stage never fails the run.
+
+Next to them the writer offers ``OPTIONS_PER_KIND`` ranked **subject lines** and
+**openers** (first paragraphs), each with the reason it might work and the angle it
+takes (``ANGLES``: a result, the company, the role, a connection, a question). They
+go to ``outreach_variants.yaml``. In an ``--interactive`` run the candidate picks one
+of each, or later with ``hydra outreach-pick``; the pick is recorded as
+``{variant_kind, rank_chosen, run_id}`` and counted per angle in
+``$HYDRA_HOME/outreach_preferences.json`` (a ``VariantPreferences`` store, as for
+tailoring models), and later runs rank the angles that keep winning first.
+"""
+
+from __future__ import annotations
+
+import re
+from dataclasses import dataclass
+from pathlib import Path
+from typing import Any, Callable, Dict, List, Optional
+
+import yaml
+
+from runtime.crewai.encryption import read_text, write_text
+from runtime.crewai.output_codec import to_yaml
+
+OUTREACH_FILE = "outreach.md"
+OUTREACH_VARIANTS_FILE = "outreach_variants.yaml"
+OUTREACH_PREFERENCES_FILE = "outreach_preferences.json"
+
+HIRING_MANAGER = "hiring manager"
+RECRUITER = "recruiter"
+RECIPIENTS = (HIRING_MANAGER, RECRUITER)
+
+
+@dataclass(frozen=True)
+class Channel:
+    """One outreach variant and the limits of the channel it is sent through."""
+
+    name: str
+    label: str
+    max_chars: int
+    subject_chars: Optional[int] = None  # None: the channel has no subject line
+
+
+CHANNELS = (
+    Channel("connection_request", "LinkedIn connection request", 300),
+    Channel("inmail", "LinkedIn InMail", 1900, subject_chars=200),
+    Channel("email", "Email", 1400, subject_chars=120),
+)
+
+SUBJECT_LINE = "subject_line"
+OPENER = "opener"
+OPTION_KINDS = (SUBJECT_LINE, OPENER)
+OPTIONS_PER_KIND = 3
+# The email's subject limit holds for every subject line; an opener is one paragraph.
+OPTION_CHARS = {SUBJECT_LINE: 120, OPENER: 450}
+# The angle an option takes, so the choices can be learned across runs.
+ANGLES = ("result", "company", "role", "connection", "question")
+OTHER_ANGLE = "other"
+
+_SENTENCE_END = re.compile(r"[.!?](?=\s|$)")
+
+
+def fit_to_limit(text: str, max_chars: int) -> str:
+    """``text`` cut back to at most ``max_chars``: to its last whole sentence that
+    fits, else its last whole word with an ellipsis. Text that fits is unchanged."""
+    text = text.strip()
+    if len(text) <= max_chars:
+        return text
+    head = text[:max_chars]
+    ends = [m.end() for m in _SENTENCE_END.finditer(head)]
+    if ends and ends[-1] >= max_chars // 2:
+        return head[: ends[-1]].rstrip()
+    cut = text[: max_chars - 1]
+    if " " in cut and not text[max_chars - 1].isspace():
+        cut = cut.rsplit(" ", 1)[0]  # drop the word the limit falls in
+    return cut.rstrip(" ,;:—-") + "…"
+
+
+def check_variants(
+    variants: Dict[str, Dict[str, str]], known_ids: set[int], cited: List[int]
+) -> Dict[str, Any]:
+    """Every channel's variant held to its limits, and the research citations checked.
+
+    ``variants`` maps channel names to ``{"subject", "message"}``; channels the model
+    left out are missing from the result.
+    """
+    checked: Dict[str, Dict[str, Any]] = {}
+    for channel in CHANNELS:
+        variant = variants.get(channel.name)
+        if not variant or not variant.get("message"):
+            continue
+        message = fit_to_limit(variant["message"], channel.max_chars)
+        entry: Dict[str, Any] = {"message": message}
+        trimmed = message != variant["message"].strip()
+        if channel.subject_chars is not None:
+            subject = variant.get("subject") or ""
+            entry["subject"] = fit_to_limit(subject, channel.subject_chars)
+            trimmed = trimmed or entry["subject"] != subject.strip()
+        entry.update(chars=len(message), limit=channel.max_chars, trimmed=trimmed)
+        checked[channel.name] = entry
+    return {
+        "variants": checked,
+        "research_used": [i for i in cited if i in known_ids],
+    }
+
+
+def option_key(kind: str, angle: str) -> str:
+    """The preference store's key for an option: its kind and angle."""
+    return f"{kind}:{angle}"
+
+
+def check_options(kind: str, options: List[Dict[str, str]]) -> List[Dict[str, Any]]:
+    """The first ``OPTIONS_PER_KIND`` options of ``kind``, held to its limit, their
+    angles normalised and ranked in the order the writer gave them."""
+    checked: List[Dict[str, Any]] = []
+    for option in options:
+        text = fit_to_limit(option.get("text") or "", OPTION_CHARS[kind])
+        if not text or text in (item["text"] for item in checked):
+            continue
+        angle = (option.get("angle") or "").strip().lower()
+        checked.append(
+            {
+                "rank": len(checked) + 1,
+                "text": text,
+                "rationale": (option.get("rationale") or "").strip(),
+                "angle": angle if angle in ANGLES else OTHER_ANGLE,
+            }
+        )
+        if len(checked) == OPTIONS_PER_KIND:
+            break
+    return checked
+
+
+def rank_options(
+    kind: str, options: List[Dict[str, Any]], preferences: Any
+) -> List[Dict[str, Any]]:
+    """``options`` re-ranked by the angles the candidate has chosen most often before
+    (``preferences`` is a ``VariantPreferences``); ties keep the writer's order."""
+    keys = [option_key(kind, option["angle"]) for option in options]
+    order = preferences.ranked(list(dict.fromkeys(keys)))
+    ranked = sorted(options, key=lambda option: order.index(option_key(kind, option["angle"])))
+    return [{**option, "rank": rank} for rank, option in enumerate(ranked, start=1)]
+
+
+def option_lists(outreach: Dict[str, Any]) -> Dict[str, List[Dict[str, Any]]]:
+    """The outreach's ranked options by kind; kinds without any are left out."""
+    found = {kind: outreach.get(f"{kind}s") or [] for kind in OPTION_KINDS}
+    return {kind: options for kind, options in found.items() if options}
+
+
+def record_choice(
+    outreach: Dict[str, Any],
+    kind: str,
+    rank: int,
+    run_id: Optional[str] = None,
+    preferences: Any = None,
+) -> Dict[str, Any]:
+    """Record that the candidate chose the option of ``kind`` ranked ``rank``: in
+    ``outreach["choices"]`` (a later pick of the same kind replaces it) and, with
+    ``preferences``, as a win for its angle over the others offered.
+
+    Raises ValueError for a kind or rank that was not offered.
+    """
+    options = option_lists(outreach).get(kind) or []
+    chosen = next((option for option in options if option["rank"] == rank), None)
+    if chosen is None:
+        raise ValueError(f"No {kind.replace('_', ' ')} ranked {rank} (1-{len(options)})")
+    choice = {"variant_kind": kind, "rank_chosen": rank, "run_id": run_id}
+    choices = [c for c in outreach.get("choices") or [] if c["variant_kind"] != kind]
+    outreach["choices"] = choices + [choice]
+    if preferences is not None:
+        preferences.record(
+            option_key(kind, chosen["angle"]),
+            list(dict.fromkeys(option_key(kind, option["angle"]) for option in options)),
+        )
+    return choice
+
+
+def pick_option(
+    kind: str, options: List[Dict[str, Any]], input_fn: Callable[[str], str] = input
+) -> Optional[int]:
+    """Show the options of ``kind`` and ask which to use; the rank chosen, or None for
+    no choice (Enter or EOF)."""
+    if not options:
+        return None
+    label = kind.replace("_", " ")
+    print(f"\n✉️  Pick the outreach {label}:")
+    for option in options:
+        print(f"  [{option['rank']}] {option['text']}")
+        if option.get("rationale"):
+            print(f"      {option['angle']}: {option['rationale']}")
+    while True:
+        try:
+            answer = input_fn(f"\n❓ {label.capitalize()} 1-{len(options)} (Enter = no pick): ")
+        except EOFError:
+            return None
+        answer = answer.strip()
+        if not answer:
+            return None
+        if answer.isdigit() and 1 <= int(answer) <= len(options):
+            return int(answer)
+
+
+def variants_document(outreach: Dict[str, Any], run_id: Optional[str] = None) -> Dict[str, Any]:
+    """The ranked options and the choices, for ``outreach_variants.yaml``."""
+    return {
+        "subject_lines": outreach.get("subject_lines") or [],
+        "openers": outreach.get("openers") or [],
+        "choices": [
+            {**choice, "run_id": choice.get("run_id") or run_id}
+            for choice in outreach.get("choices") or []
+        ],
+    }
+
+
+def load_variants(run_dir: Path) -> Optional[Dict[str, Any]]:
+    """The run's ``outreach_variants.yaml``, or None if it has none."""
+    path = Path(run_dir) / OUTREACH_VARIANTS_FILE
+    if not path.is_file():
+        return None
+    document = yaml.safe_load(read_text(path))
+    return document if isinstance(document, dict) else None
+
+
+def save_variants(run_dir: Path, document: Dict[str, Any]) -> Path:
+    """Write ``document`` to the run's ``outreach_variants.yaml``; returns its path."""
+    path = Path(run_dir) / OUTREACH_VARIANTS_FILE
+    write_text(path, to_yaml(document))
+    return path
+
+
+def render_outreach(outreach: Dict[str, Any]) -> str:
+    """The outreach messages as Markdown, for ``outreach.md``."""
+    recipient = outreach.get("recipient") or HIRING_MANAGER
+    lines = [f"# Outreach to the {recipient}", ""]
+    variants = outreach.get("variants") or {}
+    for channel in CHANNELS:
+        variant = variants.get(channel.name)
+        if not variant:
+            continue
+        size = f"{variant['chars']}/{variant['limit']} characters"
+        trimmed = ", trimmed to fit" if variant.get("trimmed") else ""
+        lines += [f"## {channel.label}", "", f"_{size}{trimmed}_", ""]
+        if variant.get("subject"):
+            lines += [f"**Subject:** {variant['subject']}", ""]
+        lines += [variant["message"], ""]
+    if not variants:
+        lines += ["No messages were written.", ""]
+
+    if outreach.get("differentiators_used"):
+        lines += ["## Built on", ""]
+        lines += [f"- {item}" for item in outreach["differentiators_used"]]
+        lines.append("")
+    sources = {s.get("id"): s for s in outreach.get("sources") or []}
+    if outreach.get("research_used"):
+        lines += ["## Sources", ""]
+        for source_id in outreach["research_used"]:
+            source = sources.get(source_id) or {}
+            lines.append(f"{source_id}. {source.get('title', '')} {source.get('url', '')}".rstrip())
+        lines.append("")
+    return "\n".join(lines).rstrip() + "\n"
+
+
+def manifest_summary(outreach: Dict[str, Any]) -> Dict[str, Any]:
+    """What ``run.json`` keeps of the outreach: who it is for, each variant's size, how
+    many options of each kind were offered and which rank was chosen."""
+    return {
+        "recipient": outreach.get("recipient"),
+        "variants": {
+            name: {"chars": variant["chars"], "trimmed": variant["trimmed"]}
+            for name, variant in (outreach.get("variants") or {}).items()
+        },
+        "options": {kind: len(options) for kind, options in option_lists(outreach).items()},
+        "chosen": {c["variant_kind"]: c["rank_chosen"] for c in outreach.get("choices") or []},
+    }
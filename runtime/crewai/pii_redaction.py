@@ -0,0 +1,127 @@
+"""PII redaction for model calls: contact details never reach a provider.
+
+With ``--redact-pii`` every prompt is scanned before it is sent, and each email
+address, phone number and street address in it is swapped for a numbered
+placeholder (``[EMAIL_1]``, ``[PHONE_1]``, ``[ADDRESS_1]``). The same value always
+gets the same placeholder within a run, so the model can still tell one contact
+detail from another and copy it into the résumé header where it belongs. Every
+string of the validated output is then restored before anything else sees it: stage
+outputs, the final documents, the stage cache and the artifacts hold the real values,
+and only the provider (and its logs) sees placeholders.
+
+Detection is pattern-based and errs on the side of redacting: a ten-digit order
+number is treated as a phone number, and that costs nothing, because it comes back
+restored. Names, employers and profile URLs are not redacted — the résumé is about
+them. A dry run with ``--redact-pii`` records the prompts exactly as they would be
+sent, which is the way to check what a provider would see. ``HYDRA_REDACT_PII=1``
+turns redaction on for every run, including the web backend's.
+"""
+
+from __future__ import annotations
+
+import os
+import re
+import threading
+from typing import Any, Dict
+
+REDACT_ENV = "HYDRA_REDACT_PII"
+
+EMAIL = "EMAIL"
+PHONE = "PHONE"
+ADDRESS = "ADDRESS"
+KINDS = (EMAIL, PHONE, ADDRESS)
+_SUMMARY_KEYS = {EMAIL: "emails", PHONE: "phones", ADDRESS: "addresses"}
+
+PLACEHOLDER_FORMAT = "[{kind}_{index}]"
+_PLACEHOLDER_RE = re.compile(r"\[(EMAIL|PHONE|ADDRESS)_(\d+)\]")
+
+_EMAIL_RE = re.compile(
+    r"(?<![\w.+-])[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}"
+)
+
+# Optional country code, optional area code in parentheses, then digit groups split by
+# spaces, dots or hyphens (never newlines); 10–15 digits in all, checked separately.
+_PHONE_RE = re.compile(
+    r"(?<![\w+])(?:\+\d{1,3}[ .-]?)?(?:\(\d{1,4}\)[ .-]?)?"
+    r"\d{2,4}(?:[ .-]?\d{2,4}){1,4}(?!\w)"
+)
+PHONE_DIGITS = (10, 15)
+
+_STREET_SUFFIXES = (
+    "Street|St|Avenue|Ave|Road|Rd|Boulevard|Blvd|Lane|Ln|Drive|Dr|Court|Ct|Way|Place|Pl"
+    "|Terrace|Ter|Circle|Cir|Parkway|Pkwy|Highway|Hwy|Square|Sq"
+)
+_WORD = r"[A-Z][A-Za-z0-9'.-]*"
+# "221B Baker Street, Apt 4, London" / "12 Main St, Springfield, IL 62704": a house
+# number, capitalized street words and a suffix, then an optional unit, city, state
+# and ZIP.
+_ADDRESS_RE = re.compile(
+    rf"\b\d{{1,6}}[A-Za-z]?\s+(?:{_WORD}\s+){{1,4}}(?:{_STREET_SUFFIXES})\b\.?"
+    rf"(?:,?\s+(?:Apt|Apartment|Suite|Ste|Unit|Flat|#)\.?\s*[\w-]+)?"
+    rf"(?:,\s*{_WORD}(?: {_WORD}){{0,2}})?"
+    rf"(?:,?\s+[A-Z]{{2}}(?:\s+\d{{5}}(?:-\d{{4}})?)?)?"
+    rf"(?=[\s,;)]|$)"
+)
+
+
+def redaction_enabled() -> bool:
+    return os.environ.get(REDACT_ENV, "").strip().lower() in ("1", "true", "yes", "on")
+
+
+def _is_phone(match: str) -> bool:
+    digits = sum(ch.isdigit() for ch in match)
+    return PHONE_DIGITS[0] <= digits <= PHONE_DIGITS[1]
+
+
+class PiiRedactor:
+    """Swaps contact details for placeholders going out, and back coming in.
+
+    One redactor serves a whole run and is shared by its agents, which may call from
+    several threads at once (parallel tailoring variants, overlapped stages).
+    """
+
+    def __init__(self) -> None:
+        self._lock = threading.Lock()
+        self._placeholders: Dict[str, str] = {}  # value -> placeholder
+        self._values: Dict[str, str] = {}  # placeholder -> value
+        self._counts: Dict[str, int] = {kind: 0 for kind in KINDS}
+
+    def _placeholder(self, kind: str, value: str) -> str:
+        with self._lock:
+            placeholder = self._placeholders.get(value)
+            if placeholder is None:
+                self._counts[kind] += 1
+                placeholder = PLACEHOLDER_FORMAT.format(kind=kind, index=self._counts[kind])
+                self._placeholders[value] = placeholder
+                self._values[placeholder] = value
+            return placeholder
+
+    def redact(self, text: str) -> str:
+        """``text`` with every email, phone number and street address replaced."""
+        if not text:
+            return text
+        # Emails first: their digits must not be mistaken for a phone number.
+        text = _EMAIL_RE.sub(lambda m: self._placeholder(EMAIL, m.group(0)), text)
+        text = _ADDRESS_RE.sub(lambda m: self._placeholder(ADDRESS, m.group(0)), text)
+        return _PHONE_RE.sub(self._phone, text)
+
+    def _phone(self, match: re.Match) -> str:
+        number = match.group(0)
+        return self._placeholder(PHONE, number) if _is_phone(number) else number
+
+    def restore(self, value: Any) -> Any:
+        """``value`` with every placeholder put back: strings, lists and dicts, recursively."""
+        if isinstance(value, str):
+            if "[" not in value:
+                return value
+            return _PLACEHOLDER_RE.sub(lambda m: self._values.get(m.group(0), m.group(0)), value)
+        if isinstance(value, dict):
+            return {key: self.restore(item) for key, item in value.items()}
+        if isinstance(value, list):
+            return [self.restore(item) for item in value]
+        return value
+
+    def summary(self) -> Dict[str, int]:
+        """How many distinct values of each kind were redacted (counts only, never values)."""
+        with self._lock:
+            return {_SUMMARY_KEYS[kind]: count for kind, count in self._counts.items()}
@@ -0,0 +1,149 @@
+"""The pipeline config: how runs execute, as opposed to what they are about.
+
+Settings that hold for every application rather than one — the timeouts (see
+runtime.crewai.timeouts), provider failover (see runtime.crewai.failover),
+confidence gating (see runtime.crewai.confidence), reflection (see
+runtime.crewai.reflection), stage hooks (see runtime.crewai.hooks), the spend
+budget (see runtime.crewai.budget_routing), the user's workflow templates (see
+runtime.crewai.workflow_templates) and the résumé's length limits (see
+runtime.crewai.length_limits) — live in a YAML file::
+
+    timeouts:
+      llm_call: 120        # seconds per model call; 0 or null for none
+      stage: 600           # seconds per stage on one model
+      stages:              # per-stage overrides
+        tailoring: 900
+        audit: 300
+    failover:
+      providers:           # where a provider's stages go when it is down
+        together: [openrouter]
+    confidence:
+      threshold: 0.5       # re-prompt a stage reporting less confidence
+    reflection:
+      tailoring: 1         # critique-and-revise iterations per stage
+    hooks:
+      tailoring:
+        post: ./lint-resume.sh   # gets the stage's output as JSON on stdin
+    budget:
+      usd: 2.00            # cheaper models for unimportant stages as it runs out
+    templates:
+      fast: [tailoring, ats_optimization]   # hydra run --template fast
+    length:
+      max_pages: 2         # trimmed after tailoring when over
+      max_bullets_per_role: 6
+
+The file is ``~/.hydra/pipeline.yaml``, or ``$HYDRA_PIPELINE_CONFIG``, or the CLI's
+``--pipeline-config FILE``. Every key is optional; what is left out keeps its
+default, so no file at all means the defaults.
+"""
+
+from __future__ import annotations
+
+import logging
+import os
+from dataclasses import dataclass, field
+from pathlib import Path
+from typing import Any, Dict, Optional
+
+import yaml
+
+from runtime.crewai.budget_routing import BudgetPolicy
+from runtime.crewai.confidence import ConfidencePolicy
+from runtime.crewai.failover import FailoverPolicy
+from runtime.crewai.hooks import HookPolicy
+from runtime.crewai.length_limits import LengthPolicy
+from runtime.crewai.reflection import ReflectionPolicy
+from runtime.crewai.stage_cache import hydra_home
+from runtime.crewai.timeouts import TimeoutPolicy
+from runtime.crewai.workflow_templates import TemplatePolicy
+
+logger = logging.getLogger(__name__)
+
+PIPELINE_CONFIG_FILE = "pipeline.yaml"  # in hydra_home()
+PIPELINE_CONFIG_ENV = "HYDRA_PIPELINE_CONFIG"
+
+
+# Each section of the file, and the policy that reads it.
+_SECTIONS = {
+    "timeouts": TimeoutPolicy,
+    "failover": FailoverPolicy,
+    "confidence": ConfidencePolicy,
+    "reflection": ReflectionPolicy,
+    "hooks": HookPolicy,
+    "budget": BudgetPolicy,
+    "templates": TemplatePolicy,
+    "length": LengthPolicy,
+}
+
+
+class PipelineConfigError(ValueError):
+    """A pipeline config that cannot be read or holds unknown or bad settings."""
+
+
+@dataclass(frozen=True)
+class PipelineConfig:
+    """Run-wide execution settings."""
+
+    timeouts: TimeoutPolicy = field(default_factory=TimeoutPolicy)
+    failover: FailoverPolicy = field(default_factory=FailoverPolicy)
+    confidence: ConfidencePolicy = field(default_factory=ConfidencePolicy)
+    reflection: ReflectionPolicy = field(default_factory=ReflectionPolicy)
+    hooks: HookPolicy = field(default_factory=HookPolicy)
+    budget: BudgetPolicy = field(default_factory=BudgetPolicy)
+    templates: TemplatePolicy = field(default_factory=TemplatePolicy)
+    length: LengthPolicy = field(default_factory=LengthPolicy)
+
+    @classmethod
+    def from_dict(cls, data: Dict[str, Any], source: str = "pipeline config") -> "PipelineConfig":
+        unknown = set(data) - set(_SECTIONS)
+        if unknown:
+            raise PipelineConfigError(f"{source}: unknown section(s): {', '.join(sorted(unknown))}")
+        sections = {name: data.get(name) or {} for name in _SECTIONS}
+        for name, section in sections.items():
+            if not isinstance(section, dict):
+                raise PipelineConfigError(f"{source}: '{name}' must be a mapping")
+        try:
+            return cls(
+                **{name: policy.from_dict(sections[name]) for name, policy in _SECTIONS.items()}
+            )
+        except ValueError as e:
+            raise PipelineConfigError(f"{source}: {e}") from e
+
+    def to_dict(self) -> Dict[str, Any]:
+        return {name: getattr(self, name).to_dict() for name in _SECTIONS}
+
+
+def pipeline_config_file() -> Optional[Path]:
+    """``$HYDRA_PIPELINE_CONFIG``, else ``~/.hydra/pipeline.yaml`` if it exists."""
+    override = os.environ.get(PIPELINE_CONFIG_ENV)
+    if override:
+        return Path(override).expanduser()
+    path = hydra_home() / PIPELINE_CONFIG_FILE
+    return path if path.is_file() else None
+
+
+def load_pipeline_config(path: Optional[Path] = None) -> PipelineConfig:
+    """The config in ``path`` (default: ``pipeline_config_file()``); defaults if none."""
+    path = path if path is not None else pipeline_config_file()
+    if path is None:
+        return PipelineConfig()
+    try:
+        data = yaml.safe_load(Path(path).read_text(encoding="utf-8")) or {}
+    except (OSError, yaml.YAMLError) as e:
+        raise PipelineConfigError(f"Cannot read pipeline config {path}: {e}") from e
+    if not isinstance(data, dict):
+        raise PipelineConfigError(f"{path}: expected a mapping of sections")
+    return PipelineConfig.from_dict(data, str(path))
+
+
+def default_pipeline_config() -> PipelineConfig:
+    """The config a run uses when none is given; a broken file means the defaults.
+
+    The failure is logged rather than raised so a server or batch run is not stopped
+    by a typo (the CLI checks ``--pipeline-config`` up front with ``load_pipeline_config``).
+    """
+    try:
+        return load_pipeline_config()
+    except PipelineConfigError as e:
+        logger.warning(f"{e}; using the default pipeline config")
+        return PipelineConfig()
@@ -0,0 +1,272 @@
+"""Stage plugins: your own agents as extra pipeline stages, without forking Hydra.
+
+A plugin is any executable — a Go or Rust binary, a script — in its own directory
+under ``~/.hydra/plugins/`` (or a ``--plugin-dir``), described by a ``plugin.yaml``::
+
+    name: salary_sanity          # its stage name: lowercase, digits, underscores
+    command: ./salary-sanity     # or a list: [python3, check.py]; run in the directory
+    after: gap_analysis          # the built-in stage it follows (see ANCHOR_STAGES)
+    description: Flags postings whose pay band is below my floor
+    timeout: 120                 # seconds; 300 by default
+    required: false              # true: the run fails when the plugin does
+    inputs: [gap_analysis]       # earlier stage outputs it is sent; all by default
+
+Protocol version 1 is one JSON object each way. On stdin the plugin gets::
+
+    {"protocol": 1, "stage": "salary_sanity", "after": "gap_analysis",
+     "context": {"job_description": "...", "resume": "...", "source_documents": "...",
+                 "target_role": "..."},
+     "results": {"gap_analysis": {...}}}
+
+and it answers on stdout with ``{"output": {...}}`` — kept as the stage's output,
+written to ``intermediate/<name>.yaml`` and sent to later plugins — or
+``{"error": "why"}``. An optional ``"log": ["..."]`` is added to the run log. A
+non-zero exit, a timeout or anything but that JSON is a failure too: an optional
+plugin's failure is reported in ``run.json``'s errors and the run goes on. Fields
+may be added to the request in later versions of protocol 1; plugins should ignore
+what they do not know. A plugin that has output in a run being resumed is not run
+again, like any completed stage.
+
+A plugin with ``runtime: wasm`` is a WebAssembly module instead of a program, run
+sandboxed with only the host functions it asks for; see runtime.crewai.wasm_plugins.
+"""
+
+from __future__ import annotations
+
+import json
+import re
+import subprocess
+from dataclasses import dataclass
+from pathlib import Path
+from typing import Any, Callable, Dict, Iterable, List, Optional, Tuple
+
+import yaml
+
+from runtime.crewai.stage_cache import hydra_home
+
+PLUGINS_DIR = "plugins"  # in hydra_home()
+PLUGIN_MANIFEST = "plugin.yaml"
+PROTOCOL_VERSION = 1
+DEFAULT_TIMEOUT_SECONDS = 300
+PROCESS = "process"  # an executable, with the user's own access
+WASM = "wasm"  # a sandboxed WebAssembly module
+RUNTIMES = (PROCESS, WASM)
+# Host functions a WebAssembly plugin can be granted (see wasm_plugins).
+WASM_CAPABILITIES = ("llm", "sources")
+DEFAULT_WASM_MEMORY_MB = 64
+
+# The built-in stages a plugin can follow, in pipeline order. "audit" includes claim
+# verification.
+ANCHOR_STAGES = (
+    "research",
+    "gap_analysis",
+    "interrogation",
+    "differentiation",
+    "tailoring",
+    "ats_optimization",
+    "audit",
+    "executive_synthesis",
+)
+# Stage names a plugin may not take: its output would overwrite theirs.
+RESERVED_NAMES = frozenset(ANCHOR_STAGES) | {"auditing", "compensation", "claim_verification"}
+_NAME_RE = re.compile(r"^[a-z][a-z0-9_]{0,47}$")
+
+Runner = Callable[..., "subprocess.CompletedProcess[str]"]
+
+
+class PluginError(ValueError):
+    """Raised for a bad plugin.yaml, or a plugin that failed or broke the protocol."""
+
+    pass
+
+
+@dataclass(frozen=True)
+class StagePlugin:
+    """One plugin, as its ``plugin.yaml`` describes it."""
+
+    name: str
+    command: Tuple[str, ...]
+    after: str
+    directory: Path
+    description: str = ""
+    timeout: float = DEFAULT_TIMEOUT_SECONDS
+    required: bool = False
+    inputs: Optional[Tuple[str, ...]] = None  # None: every earlier output
+    runtime: str = PROCESS
+    module: str = ""  # the .wasm (or .wat) file of a WebAssembly plugin
+    capabilities: Tuple[str, ...] = ()
+    memory_mb: int = DEFAULT_WASM_MEMORY_MB
+
+    @classmethod
+    def from_manifest(cls, path: Path) -> "StagePlugin":
+        path = Path(path)
+        try:
+            data = yaml.safe_load(path.read_text())
+        except (OSError, yaml.YAMLError) as e:
+            raise PluginError(f"{path}: {e}") from e
+        if not isinstance(data, dict):
+            raise PluginError(f"{path}: expected a mapping")
+        name = str(data.get("name") or path.parent.name)
+        if not _NAME_RE.match(name):
+            raise PluginError(f"{path}: name '{name}' must be lowercase letters, digits and _")
+        if name in RESERVED_NAMES:
+            raise PluginError(f"{path}: '{name}' is a built-in stage")
+        after = data.get("after")
+        if after not in ANCHOR_STAGES:
+            raise PluginError(f"{path}: 'after' must be one of: {', '.join(ANCHOR_STAGES)}")
+        runtime = data.get("runtime", PROCESS)
+        if runtime not in RUNTIMES:
+            raise PluginError(f"{path}: 'runtime' must be one of: {', '.join(RUNTIMES)}")
+        command = data.get("command")
+        module = data.get("module")
+        capabilities = data.get("capabilities") or []
+        if runtime == WASM:
+            if not isinstance(module, str) or not module:
+                raise PluginError(f"{path}: a wasm plugin needs 'module', its .wasm file")
+            if not isinstance(capabilities, list) or not all(
+                c in WASM_CAPABILITIES for c in capabilities
+            ):
+                raise PluginError(
+                    f"{path}: 'capabilities' must be a list of: {', '.join(WASM_CAPABILITIES)}"
+                )
+            command = []
+        else:
+            if isinstance(command, str):
+                command = command.split()
+            if not command or not all(isinstance(part, str) for part in command):
+                raise PluginError(f"{path}: 'command' must be a string or a list of strings")
+            if capabilities:
+                raise PluginError(f"{path}: 'capabilities' are for wasm plugins only")
+        inputs = data.get("inputs")
+        if inputs is not None and not (
+            isinstance(inputs, list) and all(isinstance(stage, str) for stage in inputs)
+        ):
+            raise PluginError(f"{path}: 'inputs' must be a list of stage names")
+        try:
+            timeout = float(data.get("timeout") or DEFAULT_TIMEOUT_SECONDS)
+        except (TypeError, ValueError) as e:
+            raise PluginError(f"{path}: 'timeout' must be a number of seconds") from e
+        try:
+            memory_mb = int(data.get("memory_mb") or DEFAULT_WASM_MEMORY_MB)
+        except (TypeError, ValueError) as e:
+            raise PluginError(f"{path}: 'memory_mb' must be a number") from e
+        return cls(
+            name=name,
+            command=tuple(command),
+            after=after,
+            directory=path.parent.resolve(),
+            description=str(data.get("description") or ""),
+            timeout=timeout,
+            required=bool(data.get("required", False)),
+            inputs=tuple(inputs) if inputs is not None else None,
+            runtime=runtime,
+            module=module or "",
+            capabilities=tuple(capabilities),
+            memory_mb=memory_mb,
+        )
+
+    def argv(self) -> List[str]:
+        """The command, its program resolved against the plugin directory if it is there."""
+        program = self.directory / self.command[0]
+        first = str(program) if program.is_file() else self.command[0]
+        return [first, *self.command[1:]]
+
+    def describe(self) -> str:
+        """What runs: the command line, or the WebAssembly module and its capabilities."""
+        if self.runtime == WASM:
+            return f"wasm {self.module} [{', '.join(self.capabilities) or 'no capabilities'}]"
+        return " ".join(self.command)
+
+
+def plugin_dirs(extra: Iterable[str] = ()) -> List[Path]:
+    """``~/.hydra/plugins`` and any ``--plugin-dir``, in that order."""
+    return [hydra_home() / PLUGINS_DIR, *(Path(directory) for directory in extra)]
+
+
+def discover_plugins(directories: Iterable[Path]) -> List[StagePlugin]:
+    """Every plugin in ``directories`` (one sub-directory each), in pipeline order."""
+    plugins: Dict[str, StagePlugin] = {}
+    for directory in directories:
+        if not Path(directory).is_dir():
+            continue
+        for manifest in sorted(Path(directory).glob(f"*/{PLUGIN_MANIFEST}")):
+            plugin = StagePlugin.from_manifest(manifest)
+            if plugin.name in plugins:
+                first = plugins[plugin.name].directory
+                raise PluginError(f"Two plugins named '{plugin.name}': {first} and {manifest}")
+            plugins[plugin.name] = plugin
+    return sorted(plugins.values(), key=lambda p: (ANCHOR_STAGES.index(p.after), p.name))
+
+
+def plugin_request(
+    plugin: StagePlugin, context: Dict[str, Any], results: Dict[str, Any]
+) -> Dict[str, Any]:
+    """The JSON object the plugin reads on stdin."""
+    wanted = results if plugin.inputs is None else {
+        stage: results[stage] for stage in plugin.inputs if stage in results
+    }
+    return {
+        "protocol": PROTOCOL_VERSION,
+        "stage": plugin.name,
+        "after": plugin.after,
+        "context": {
+            key: context.get(key)
+            for key in ("job_description", "resume", "source_documents", "target_role")
+        },
+        "results": wanted,
+    }
+
+
+def run_plugin(
+    plugin: StagePlugin,
+    context: Dict[str, Any],
+    results: Dict[str, Any],
+    runner: Runner = subprocess.run,
+    host: Any = None,
+) -> Tuple[Dict[str, Any], List[str]]:
+    """Run ``plugin`` once: its output and log lines, or PluginError.
+
+    ``host`` is the wasm_plugins.WasmHost a WebAssembly plugin may call into; without
+    one it gets no model and no sources.
+    """
+    request = plugin_request(plugin, context, results)
+    if plugin.runtime == WASM:
+        from runtime.crewai.wasm_plugins import WasmHost, run_wasm_plugin
+
+        response = run_wasm_plugin(plugin, request, host or WasmHost())
+    else:
+        response = _run_process(plugin, request, runner)
+    if not isinstance(response, dict):
+        raise PluginError(f"{plugin.name} did not answer with a JSON object")
+    if response.get("error"):
+        raise PluginError(f"{plugin.name}: {response['error']}")
+    output = response.get("output")
+    if not isinstance(output, dict):
+        raise PluginError(f"{plugin.name} answered without an 'output' object")
+    log = response.get("log") or []
+    return output, [str(line) for line in log] if isinstance(log, list) else [str(log)]
+
+
+def _run_process(plugin: StagePlugin, request: Dict[str, Any], runner: Runner) -> Any:
+    """Run an executable plugin: its decoded stdout."""
+    try:
+        completed = runner(
+            plugin.argv(),
+            input=json.dumps(request, default=str),
+            capture_output=True,
+            text=True,
+            timeout=plugin.timeout,
+            cwd=plugin.directory,
+        )
+    except subprocess.TimeoutExpired as e:
+        raise PluginError(f"{plugin.name} did not answer within {plugin.timeout:g}s") from e
+    except OSError as e:
+        raise PluginError(f"{plugin.name} could not be started: {e}") from e
+    if completed.returncode != 0:
+        stderr = (completed.stderr or "").strip().splitlines()
+        detail = f": {stderr[-1]}" if stderr else ""
+        raise PluginError(f"{plugin.name} exited with {completed.returncode}{detail}")
+    try:
+        return json.loads(completed.stdout)
+    except ValueError as e:
+        raise PluginError(f"{plugin.name} did not answer with a JSON object") from e
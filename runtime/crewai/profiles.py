@@ -0,0 +1,155 @@
+"""Résumé profiles: several baseline résumés, one picked per application.
+
+A candidate who applies for IC and management roles, or in two domains, keeps a
+baseline résumé for each. ``hydra profiles add NAME FILE`` stores one under
+``~/.hydra/profiles/NAME/`` with a short description; ``--profile NAME`` runs with it
+instead of ``--resume``. Stored résumés are encrypted like run state when encryption
+is on (see runtime.crewai.encryption).
+
+Which profile fits a job is ranked without a model by the share of the JD's skills
+each résumé covers, literally or under another name (see skill_taxonomy):
+``hydra profiles recommend --jd FILE`` prints the ranking and ``--profile auto`` runs
+with the top profile. With ``--recommend-profile`` the gap analyzer also sees every
+profile's description and coverage and names the one it would send, with a reason;
+the recommendation is shown at the greenlight and recorded in the manifest.
+"""
+
+from __future__ import annotations
+
+import json
+import re
+import shutil
+from dataclasses import dataclass, field
+from datetime import datetime
+from pathlib import Path
+from typing import Any, Dict, List, Optional
+
+from runtime.crewai.encryption import read_text, write_text
+from runtime.crewai.skill_taxonomy import SkillTaxonomy, skill_coverage
+from runtime.crewai.stage_cache import hydra_home
+
+PROFILES_DIR = "profiles"  # in hydra_home()
+PROFILE_FILE = "profile.json"
+RESUME_STEM = "resume"
+# ``--profile auto``: the profile whose résumé covers most of the JD's skills.
+AUTO = "auto"
+
+_NAME_RE = re.compile(r"^[a-z0-9][a-z0-9_-]{0,39}$")
+
+
+class ProfileError(ValueError):
+    """A profile that does not exist, or cannot be stored as given."""
+
+
+@dataclass
+class Profile:
+    """One stored baseline résumé and what it is for."""
+
+    name: str
+    resume_path: Path
+    description: str = ""
+    added: str = ""
+
+    def read(self) -> str:
+        return read_text(self.resume_path)
+
+    def to_dict(self) -> Dict[str, Any]:
+        return {
+            "name": self.name,
+            "description": self.description,
+            "resume": self.resume_path.name,
+            "added": self.added,
+        }
+
+
+@dataclass
+class ProfileFit:
+    """How much of a JD's skill list one profile's résumé covers."""
+
+    profile: Profile
+    covered: List[str] = field(default_factory=list)
+    missing: List[str] = field(default_factory=list)
+
+    @property
+    def score(self) -> float:
+        wanted = len(self.covered) + len(self.missing)
+        return len(self.covered) / wanted if wanted else 0.0
+
+    def for_prompt(self) -> Dict[str, Any]:
+        """What the gap analyzer is told about this profile (no résumé text)."""
+        return {
+            "name": self.profile.name,
+            "description": self.profile.description or "no description",
+            "jd_skills_covered": f"{len(self.covered)}/{len(self.covered) + len(self.missing)}",
+            "missing": self.missing,
+        }
+
+
+class ProfileStore:
+    """The profiles under ``root`` (``~/.hydra/profiles`` by default)."""
+
+    def __init__(self, root: Optional[Path] = None):
+        self.root = Path(root) if root is not None else hydra_home() / PROFILES_DIR
+
+    def list(self) -> List[Profile]:
+        if not self.root.is_dir():
+            return []
+        return [
+            self._load(path.parent)
+            for path in sorted(self.root.glob(f"*/{PROFILE_FILE}"))
+        ]
+
+    def get(self, name: str) -> Profile:
+        directory = self.root / name
+        if not _NAME_RE.match(name) or not (directory / PROFILE_FILE).is_file():
+            known = ", ".join(p.name for p in self.list()) or "none"
+            raise ProfileError(f"No profile '{name}' (profiles: {known})")
+        return self._load(directory)
+
+    def add(
+        self, name: str, resume_file: Path, description: str = "", replace: bool = False
+    ) -> Profile:
+        """Store a copy of ``resume_file`` as profile ``name``."""
+        if not _NAME_RE.match(name) or name == AUTO:
+            raise ProfileError(
+                f"Bad profile name '{name}': lowercase letters, digits, '-' and '_' "
+                f"(not '{AUTO}')"
+            )
+        resume_file = Path(resume_file)
+        if not resume_file.is_file():
+            raise ProfileError(f"Resume file not found: {resume_file}")
+        directory = self.root / name
+        if (directory / PROFILE_FILE).exists() and not replace:
+            raise ProfileError(f"Profile '{name}' exists (use --replace to update it)")
+        if directory.exists():
+            shutil.rmtree(directory)
+        directory.mkdir(parents=True)
+        resume_path = directory / f"{RESUME_STEM}{resume_file.suffix or '.md'}"
+        write_text(resume_path, read_text(resume_file))
+        profile = Profile(name, resume_path, description, datetime.now().isoformat())
+        (directory / PROFILE_FILE).write_text(json.dumps(profile.to_dict(), indent=2))
+        return profile
+
+    def remove(self, name: str) -> None:
+        shutil.rmtree(self.get(name).resume_path.parent)
+
+    def _load(self, directory: Path) -> Profile:
+        meta = json.loads((directory / PROFILE_FILE).read_text())
+        return Profile(
+            name=directory.name,
+            resume_path=directory / meta.get("resume", f"{RESUME_STEM}.md"),
+            description=meta.get("description", ""),
+            added=meta.get("added", ""),
+        )
+
+
+def rank_profiles(
+    job_description: str, profiles: List[Profile], taxonomy: SkillTaxonomy
+) -> List[ProfileFit]:
+    """``profiles`` by the share of the JD's skills their résumés cover, best first."""
+    fits = []
+    for profile in profiles:
+        coverage = skill_coverage(job_description, profile.read(), taxonomy)
+        covered = coverage.matched + [s["skill"] for s in coverage.synonyms]
+        fits.append(ProfileFit(profile, sorted(covered, key=str.lower), coverage.missing))
+    return sorted(fits, key=lambda fit: fit.score, reverse=True)
@@ -0,0 +1,223 @@
+"""Provider prompt caching: cache-eligible prefixes and a usage ledger.
+
+Every call an agent makes starts with the same system message — role, goal, the
+agent's prompt file, the truth rules and (for writing agents) the style guide —
+followed by the per-run task. That static prefix is thousands of tokens, and both
+large providers discount a repeated prefix:
+
+- **Anthropic** caches only what the request marks: ``cache_control`` on a content
+  block caches everything up to and including it. Reads cost ~10% of the input price,
+  the first write ~125%.
+- **OpenAI** caches automatically once a prompt exceeds ~1024 tokens, as long as the
+  prefix is byte-identical. Reads cost ~50%.
+
+``_build_messages`` therefore keeps all dynamic content out of the system message
+(it is rendered from static files only), and ``apply_cache_control`` marks it for
+Anthropic when it is long enough to be eligible.
+
+The baseline résumé and the source documents are per run, but nearly every stage
+embeds them in its task, so without help they are paid for in full on every call.
+``split_shared`` moves the run's shared texts (``BaseHydraAgent.shared_context``,
+set by the workflow) out of the task into a leading system message that is
+byte-identical for every agent: the provider then reads the résumé and sources from
+its cache from the second stage on, and each agent's own system message is a second
+cached prefix after it. Only text a task embeds verbatim is moved, so an agent that
+gets a trimmed or redacted copy sends exactly what it did before.
+
+Marking and the shared prefix apply to direct LiteLLM calls (``HYDRA_DIRECT_LLM``)
+and the gateway (which gets no markers, but OpenAI-style automatic caching still
+sees the same prefix); the CrewAI path assembles its own prompt, but any cached
+tokens the provider reports are still recorded.
+
+``UsageLedger`` collects per-call token usage — including cache reads and writes —
+so the run manifest and the end-of-run summary can report the hit rate and what
+caching saved.
+"""
+
+from __future__ import annotations
+
+from dataclasses import asdict, dataclass
+from typing import Any, Dict, List, Optional, Tuple
+
+from runtime.crewai.context_window import estimate_tokens
+from runtime.crewai.model_config import estimate_cost
+
+ANTHROPIC = "anthropic"
+OPENAI = "openai"
+
+# Providers skip caching below this prompt length (Anthropic: per model, 1024 for Sonnet).
+MIN_CACHEABLE_TOKENS = 1024
+
+# Shared texts shorter than this are left in the task: not worth a cache entry.
+MIN_SHARED_CHARS = 500
+SHARED_LABELS = {
+    "resume": "The candidate's baseline resume",
+    "source_documents": "The candidate's source documents",
+}
+SHARED_INTRO = "Reference material for this task, the same for every step of this run."
+
+# Price multipliers relative to the normal input price.
+CACHE_PRICING = {
+    ANTHROPIC: {"read": 0.10, "write": 1.25},
+    OPENAI: {"read": 0.50, "write": 1.00},
+}
+
+
+def cache_provider(model: Any) -> Optional[str]:
+    """Which caching scheme applies to ``model`` (a LiteLLM model string), if any."""
+    if not isinstance(model, str):
+        return None
+    name = model.lower()
+    if "claude" in name or name.startswith("anthropic/"):
+        return ANTHROPIC
+    if name.startswith("openai/gpt") or name.startswith("gpt-") or "/gpt-" in name:
+        return OPENAI
+    return None
+
+
+def split_shared(description: str, shared: Dict[str, str]) -> Tuple[str, str]:
+    """Move the ``shared`` texts ``description`` embeds verbatim into a prefix.
+
+    Returns ``(prefix, description)``: the prefix holds each moved text under its
+    label, in ``shared`` order, and the description refers to it instead. Without a
+    match the prefix is "" and the description is unchanged.
+    """
+    parts = []
+    for name, text in shared.items():
+        if len(text) < MIN_SHARED_CHARS or text not in description:
+            continue
+        label = SHARED_LABELS.get(name, name)
+        description = description.replace(text, f"[{label}: given in full above]")
+        parts.append(f"{label}:\n\n{text}")
+    if not parts:
+        return "", description
+    return "\n\n".join([SHARED_INTRO, *parts]), description
+
+
+def apply_cache_control(messages: List[Dict[str, Any]], model: Any) -> List[Dict[str, Any]]:
+    """Mark each system message cache-eligible for Anthropic models; else unchanged.
+
+    Returns a new list; ``messages`` is not mutated. A marker caches everything up
+    to it, so one is set where the prefix so far reaches MIN_CACHEABLE_TOKENS —
+    below that the provider would ignore it.
+    """
+    if cache_provider(model) != ANTHROPIC:
+        return messages
+    marked: List[Dict[str, Any]] = []
+    prefix_tokens = 0
+    for message in messages:
+        content = message.get("content")
+        if message.get("role") == "system" and isinstance(content, str):
+            prefix_tokens += estimate_tokens(content, model)
+        if (
+            message.get("role") == "system"
+            and isinstance(content, str)
+            and prefix_tokens >= MIN_CACHEABLE_TOKENS
+        ):
+            message = {
+                **message,
+                "content": [
+                    {"type": "text", "text": content, "cache_control": {"type": "ephemeral"}}
+                ],
+            }
+        marked.append(message)
+    return marked
+
+
+@dataclass
+class CallUsage:
+    """Token usage of one model call."""
+
+    role: str
+    model: str
+    prompt_tokens: int = 0
+    completion_tokens: int = 0
+    cache_read_tokens: int = 0
+    cache_write_tokens: int = 0
+
+
+def _get(obj: Any, name: str) -> Any:
+    if isinstance(obj, dict):
+        return obj.get(name)
+    return getattr(obj, name, None)
+
+
+def _as_int(value: Any) -> int:
+    return value if isinstance(value, int) and not isinstance(value, bool) else 0
+
+
+def usage_from_litellm(role: str, model: str, response: Any) -> Optional[CallUsage]:
+    """Extract usage (incl. cache reads/writes) from a LiteLLM completion response."""
+    usage = _get(response, "usage")
+    if usage is None:
+        return None
+    details = _get(usage, "prompt_tokens_details")
+    cache_read = _as_int(_get(usage, "cache_read_input_tokens")) or _as_int(
+        _get(details, "cached_tokens") if details is not None else None
+    )
+    return CallUsage(
+        role=role,
+        model=model,
+        prompt_tokens=_as_int(_get(usage, "prompt_tokens")),
+        completion_tokens=_as_int(_get(usage, "completion_tokens")),
+        cache_read_tokens=cache_read,
+        cache_write_tokens=_as_int(_get(usage, "cache_creation_input_tokens")),
+    )
+
+
+def usage_from_crew(role: str, model: str, token_usage: Any) -> Optional[CallUsage]:
+    """Extract usage from a CrewAI ``CrewOutput.token_usage`` (UsageMetrics)."""
+    if token_usage is None:
+        return None
+    return CallUsage(
+        role=role,
+        model=model,
+        prompt_tokens=_as_int(_get(token_usage, "prompt_tokens")),
+        completion_tokens=_as_int(_get(token_usage, "completion_tokens")),
+        cache_read_tokens=_as_int(_get(token_usage, "cached_prompt_tokens")),
+    )
+
+
+def cache_savings(usage: CallUsage) -> Optional[float]:
+    """Estimated USD saved by cache reads, net of the cache-write premium."""
+    provider = cache_provider(usage.model)
+    per_million = estimate_cost(usage.model, 1_000_000, 0)
+    if provider is None or per_million is None:
+        return None
+    multipliers = CACHE_PRICING[provider]
+    per_token = per_million / 1_000_000
+    saved = usage.cache_read_tokens * per_token * (1 - multipliers["read"])
+    premium = usage.cache_write_tokens * per_token * (multipliers["write"] - 1)
+    return saved - premium
+
+
+class UsageLedger:
+    """Record of every model call's usage in one run.
+
+    Parallel stages (tailoring variants) record from worker threads; ``list.append``
+    is atomic, so no lock is needed — and none is held, which keeps the ledger safe
+    to reach from ``copy.deepcopy`` of workflow state.
+    """
+
+    def __init__(self):
+        self.calls: List[CallUsage] = []
+
+    def record(self, usage: Optional[CallUsage]) -> None:
+        if usage is not None:
+            self.calls.append(usage)
+
+    def summary(self) -> Dict[str, Any]:
+        """Totals plus per-call rows for the manifest (token counts only, no content)."""
+        calls = list(self.calls)
+        savings = [cache_savings(call) for call in calls]
+        known = [s for s in savings if s is not None]
+        return {
+            "calls": len(calls),
+            "prompt_tokens": sum(c.prompt_tokens for c in calls),
+            "completion_tokens": sum(c.completion_tokens for c in calls),
+            "cache_read_tokens": sum(c.cache_read_tokens for c in calls),
+            "cache_write_tokens": sum(c.cache_write_tokens for c in calls),
+            "cache_hit_calls": sum(1 for c in calls if c.cache_read_tokens),
+            "cache_savings_usd": round(sum(known), 6) if known else None,
+            "by_call": [asdict(call) for call in calls],
+        }
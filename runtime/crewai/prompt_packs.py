@@ -0,0 +1,216 @@
+"""Prompt packs: the agents' prompts tuned to a profession.
+
+What a gap analysis looks for and what a tailored résumé leads with differ by
+profession: an SRE's requirements are platforms and on-call, a sales executive's are
+quota, territory and deal size. The agents' own prompts are written for engineering
+roles; a pack adds a profession's guidance to them. Four ship with Hydra
+(``--prompt-pack NAME``):
+
+- ``engineering``: the agents' own prompts, unchanged;
+- ``product``: product management — outcomes, discovery, prioritisation;
+- ``design``: product, UX and visual design — portfolio, research, craft;
+- ``sales``: account executives and sales leadership — quota, pipeline, deals.
+
+A pack is a directory holding ``pack.yaml`` and, for each agent it tunes, a Markdown
+file named after the agent's directory under ``agents/`` (``gap-analyzer.md``,
+``tailoring-agent.md``), appended to that agent's prompt::
+
+    description: Account executives and sales leadership
+    titles: [account executive, sales, business development]   # matched in the title
+    keywords: [quota, pipeline, arr, crm]                      # matched in the JD
+
+``--prompt-pack auto`` (the default) picks the pack for the job description: a title
+phrase in the role's title (its ``Role:`` line or heading, else the JD's first lines)
+counts three, each keyword found in the text one, and the best score wins; a JD that
+matches no pack, or two equally, gets ``engineering``.
+The choice and why are recorded in ``run.json``. Directories listed in
+``$HYDRA_PROMPT_PACK_PATH`` or found at ``$HYDRA_HOME/prompt_packs`` are searched
+before the built-in ``prompt_packs/``: a pack there with a built-in's name replaces it.
+Packs go on top of ``--prompt-dir``, so a pack also tunes prompts being experimented
+with.
+"""
+
+from __future__ import annotations
+
+import os
+import re
+from dataclasses import dataclass, field
+from pathlib import Path
+from typing import Any, Dict, List, Optional, Sequence
+
+import yaml
+
+from runtime.crewai.artifacts import job_labels
+from runtime.crewai.replay import agent_prompt_paths
+from runtime.crewai.stage_cache import hydra_home
+
+REPO_ROOT = Path(__file__).resolve().parents[2]
+BUILTIN_PACKS_DIR = REPO_ROOT / "prompt_packs"
+PACK_PATH_ENV = "HYDRA_PROMPT_PACK_PATH"
+PACK_FILE = "pack.yaml"
+# ``--prompt-pack auto``: the pack whose titles and keywords the JD matches best.
+AUTO = "auto"
+DEFAULT_PACK = "engineering"
+TITLE_WEIGHT = 3
+# Without a ``Role:`` line or heading, the title is looked for in this many first lines.
+TITLE_LINES = 3
+
+
+class PromptPackError(ValueError):
+    """An unknown or malformed prompt pack."""
+
+
+@dataclass
+class PromptPack:
+    """A profession's additions to the agents' prompts, and how to recognise its jobs."""
+
+    name: str
+    description: str = ""
+    path: Optional[Path] = None
+    titles: List[str] = field(default_factory=list)
+    keywords: List[str] = field(default_factory=list)
+    # Agent directory name (e.g. "tailoring-agent") -> the text added to its prompt.
+    prompts: Dict[str, str] = field(default_factory=dict)
+
+    def apply(self, prompt_path: Optional[str], prompt: str) -> str:
+        """``prompt`` with this pack's addition for the agent at ``prompt_path``."""
+        addition = self.prompts.get(Path(prompt_path or "").parent.name)
+        return f"{prompt.rstrip()}\n\n{addition.strip()}\n" if addition else prompt
+
+
+@dataclass
+class PackChoice:
+    """The pack a run uses, and whether it was detected from the JD or asked for."""
+
+    pack: PromptPack
+    detected: bool = False
+    reason: str = ""
+
+    def to_dict(self) -> Dict[str, Any]:
+        return {
+            "name": self.pack.name,
+            "detected": self.detected,
+            "reason": self.reason,
+            "agents": sorted(self.pack.prompts),
+        }
+
+
+def pack_dirs(extra: Sequence[Path] = ()) -> List[Path]:
+    """Directories searched for packs, highest precedence first."""
+    dirs = [Path(d).expanduser() for d in extra]
+    env = os.environ.get(PACK_PATH_ENV, "")
+    dirs += [Path(d).expanduser() for d in env.split(os.pathsep) if d]
+    dirs += [hydra_home() / "prompt_packs", BUILTIN_PACKS_DIR]
+    return dirs
+
+
+def available_packs(dirs: Sequence[Path]) -> Dict[str, Path]:
+    """Pack name -> directory, the first directory defining a name winning."""
+    found: Dict[str, Path] = {}
+    for directory in dirs:
+        if not directory.is_dir():
+            continue
+        for pack_file in sorted(directory.glob(f"*/{PACK_FILE}")):
+            found.setdefault(pack_file.parent.name, pack_file.parent)
+    return dict(sorted(found.items()))
+
+
+def _words(raw: Any, name: str, key: str) -> List[str]:
+    if raw is None:
+        return []
+    if not isinstance(raw, list) or not all(isinstance(word, str) for word in raw):
+        raise PromptPackError(f"Prompt pack '{name}': {key} must be a list of strings")
+    return [word.strip().lower() for word in raw if word.strip()]
+
+
+def _load(name: str, path: Path, root: Path) -> PromptPack:
+    try:
+        raw = yaml.safe_load((path / PACK_FILE).read_text(encoding="utf-8")) or {}
+    except yaml.YAMLError as err:
+        raise PromptPackError(f"Prompt pack '{name}': {PACK_FILE} is not valid YAML") from err
+    if not isinstance(raw, dict):
+        raise PromptPackError(f"Prompt pack '{name}': {PACK_FILE} must be a mapping")
+    agents = agent_prompt_paths(root)
+    prompts = {file.stem: file.read_text(encoding="utf-8") for file in sorted(path.glob("*.md"))}
+    unknown = sorted(set(prompts) - set(agents))
+    if unknown:
+        raise PromptPackError(
+            f"Prompt pack '{name}': no agent named {', '.join(unknown)} "
+            f"(expected: {', '.join(agents)})"
+        )
+    return PromptPack(
+        name=name,
+        description=str(raw.get("description") or ""),
+        path=path,
+        titles=_words(raw.get("titles"), name, "titles"),
+        keywords=_words(raw.get("keywords"), name, "keywords"),
+        prompts=prompts,
+    )
+
+
+def load_pack(name: str, dirs: Sequence[Path], root: Path = REPO_ROOT) -> PromptPack:
+    """The pack called ``name`` in ``dirs``; its agent files are checked against the
+    agents of the repository at ``root``."""
+    path = available_packs(dirs).get(name)
+    if path is None:
+        known = ", ".join(available_packs(dirs)) or "none"
+        raise PromptPackError(f"Unknown prompt pack '{name}' (available: {known})")
+    return _load(name, path, root)
+
+
+def load_packs(dirs: Sequence[Path], root: Path = REPO_ROOT) -> List[PromptPack]:
+    """Every pack in ``dirs``, by name."""
+    return [_load(name, path, root) for name, path in available_packs(dirs).items()]
+
+
+def _mentions(text: str, phrase: str) -> bool:
+    return re.search(rf"(?<![\w-]){re.escape(phrase)}(?![\w-])", text) is not None
+
+
+def _title(job_description: str) -> str:
+    role = job_labels(job_description)[1]
+    if role:
+        return role.lower()
+    lines = [line.strip() for line in job_description.splitlines() if line.strip()]
+    return "\n".join(lines[:TITLE_LINES]).lower()
+
+
+def score_pack(pack: PromptPack, job_description: str) -> tuple[int, List[str]]:
+    """How well ``job_description`` matches ``pack``, and the phrases that matched."""
+    title = _title(job_description)
+    text = job_description.lower()
+    in_title = [phrase for phrase in pack.titles if _mentions(title, phrase)]
+    in_text = [word for word in pack.keywords if _mentions(text, word)]
+    return TITLE_WEIGHT * len(in_title) + len(in_text), in_title + in_text
+
+
+def detect_pack(job_description: str, packs: Sequence[PromptPack]) -> PackChoice:
+    """The pack for ``job_description`` (see the module doc)."""
+    by_name = {pack.name: pack for pack in packs}
+    scored = sorted(
+        ((*score_pack(pack, job_description), pack) for pack in packs),
+        key=lambda item: item[0],
+        reverse=True,
+    )
+    default = by_name.get(DEFAULT_PACK) or PromptPack(DEFAULT_PACK)
+    if not scored or scored[0][0] == 0:
+        return PackChoice(default, detected=True, reason="no pack's titles or keywords matched")
+    best_score, matched, best = scored[0]
+    if len(scored) > 1 and scored[1][0] == best_score and best.name != DEFAULT_PACK:
+        tied = " and ".join(sorted(item[2].name for item in scored if item[0] == best_score))
+        return PackChoice(default, detected=True, reason=f"{tied} matched equally")
+    return PackChoice(best, detected=True, reason=f"matched {', '.join(matched[:5])}")
+
+
+def choose_pack(
+    name: str,
+    job_description: str,
+    dirs: Optional[Sequence[Path]] = None,
+    root: Path = REPO_ROOT,
+) -> PackChoice:
+    """The pack ``name`` names, or for ``auto`` the one detected from the JD."""
+    dirs = pack_dirs() if dirs is None else dirs
+    if name != AUTO:
+        return PackChoice(load_pack(name, dirs, root), reason="asked for")
+    return detect_pack(job_description, load_packs(dirs, root))
+
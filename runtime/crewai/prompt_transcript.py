@@ -0,0 +1,108 @@
+"""Every prompt sent to a model during a run, and every raw answer, per stage.
+
+Agents log each model call (``BaseHydraAgent.prompt_transcript``): the system and
+user messages, the raw response, and the error that got it rejected — a parse error,
+a timeout, a provider failure — so retries and JSON repairs show up as the separate
+calls they were. Stage-cache hits are logged too, with the cached output as their
+response. The workflow collects the calls per stage and the run directory keeps them
+in ``prompt_transcript.json``; ``hydra export-transcript`` renders them as Markdown.
+
+What is logged is what crossed the wire: with ``--redact-pii`` the messages and
+responses hold placeholders, not contact details. On the CrewAI path the messages
+are the pieces CrewAI is given (backstory and task); CrewAI wraps them in its own
+framing, which is not captured.
+"""
+
+from __future__ import annotations
+
+import json
+import re
+from datetime import datetime
+from pathlib import Path
+from typing import Any, Dict, Iterable, List, Optional
+
+from runtime.crewai.encryption import read_text
+
+PROMPT_TRANSCRIPT_FILE = "prompt_transcript.json"
+TRANSCRIPT_MARKDOWN_FILE = "prompt_transcript.md"
+
+
+def call_entry(
+    agent: str,
+    model: Any,
+    path: str,
+    attempt: int,
+    messages: List[Dict[str, str]],
+    response: Optional[str],
+    error: Optional[Exception] = None,
+    provider: Optional[str] = None,
+) -> Dict[str, Any]:
+    """One model call as it is kept in the transcript."""
+    entry: Dict[str, Any] = {
+        "agent": agent,
+        "model": None if model is None else str(model),
+        "provider": provider,
+        "path": path,
+        "attempt": attempt,
+        "at": datetime.now().isoformat(timespec="seconds"),
+        "messages": messages,
+        "response": response,
+    }
+    if error is not None:
+        entry["error"] = f"{type(error).__name__}: {error}"
+    return entry
+
+
+def load_transcript(run_dir: Path) -> Dict[str, List[Dict[str, Any]]]:
+    """A run's prompt transcript, per stage; empty for runs that kept none."""
+    path = Path(run_dir) / PROMPT_TRANSCRIPT_FILE
+    if not path.is_file():
+        return {}
+    return json.loads(read_text(path))
+
+
+def render_transcript(
+    transcripts: Dict[str, List[Dict[str, Any]]],
+    run_id: str,
+    stages: Iterable[str] = (),
+) -> str:
+    """The transcript as Markdown: a section per stage, then each call's messages and
+    response verbatim. ``stages`` limits it to those stages."""
+    wanted = set(stages)
+    lines = [f"# Prompt transcript: {run_id}", ""]
+    shown = {s: calls for s, calls in transcripts.items() if not wanted or s in wanted}
+    total = sum(len(calls) for calls in shown.values())
+    lines += [f"{total} model call(s) across {len(shown)} stage(s).", ""]
+    for stage, calls in shown.items():
+        lines += [f"## {stage}", ""]
+        for number, call in enumerate(calls, 1):
+            lines += _render_call(number, call)
+    return "\n".join(lines).rstrip() + "\n"
+
+
+def _render_call(number: int, call: Dict[str, Any]) -> List[str]:
+    details = [call.get("agent") or "agent", call.get("model") or "unknown model"]
+    if call.get("path") == "cache":
+        details.append("stage cache hit")
+    else:
+        details += [f"via {call.get('path')}", f"attempt {call.get('attempt')}"]
+    if call.get("at"):
+        details.append(call["at"])
+    lines = [f"### Call {number}: {' · '.join(details)}", ""]
+    if call.get("error"):
+        lines += [f"**Rejected:** {call['error']}", ""]
+    for message in call.get("messages") or []:
+        role = str(message.get("role", "message"))
+        lines += [f"#### {role.capitalize()} prompt", ""]
+        lines += _fenced(str(message.get("content", "")))
+    lines += ["#### Response", ""]
+    response = call.get("response")
+    lines += _fenced(response) if response is not None else ["_No response._", ""]
+    return lines
+
+
+def _fenced(text: str) -> List[str]:
+    """``text`` in a code fence longer than any backtick run inside it."""
+    longest = max((len(run) for run in re.findall(r"`+", text)), default=0)
+    fence = "`" * max(3, longest + 1)
+    return [fence, text.rstrip("\n"), fence, ""]
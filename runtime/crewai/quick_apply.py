@@ -0,0 +1,142 @@
+"""Quick apply: a latency-optimised preset with a hard wall-clock budget.
+
+For postings that close within hours. With a ``LatencyBudget`` the workflow:
+
+- moves every agent to a fast model (``QUICK_APPLY_MODELS``; an agent whose provider
+  key is missing keeps its normal model);
+- skips the optional steps that cost most time: company research, the interview, and
+  cover-letter rewrites;
+- overlaps stages that do not need each other's output — differentiation runs
+  alongside gap analysis, the executive brief alongside the audit, and the résumé and
+  cover letter are audited in parallel;
+- runs an optional stage (differentiation, ATS optimisation, the executive brief)
+  only if its typical duration still fits after reserving time for the required
+  stages ahead (gap analysis, tailoring, audit);
+- caps every model call at the time remaining, so the run returns within the budget
+  whatever a provider does.
+
+Running out of budget before the documents exist fails the run. Running out during
+the audit returns the documents marked AUDIT_ERROR (manual review required): quick
+never means unaudited-and-silent.
+"""
+
+from __future__ import annotations
+
+import threading
+import time
+from typing import Any, Callable, Dict, Iterable, List, Optional
+
+from crewai import LLM
+
+from runtime.crewai.model_config import LLMClientError, get_llm_for_spec
+
+QUICK_APPLY_BUDGET_SECONDS = 300
+MAVERICK = "together:meta-llama/Llama-4-Maverick-17B-128E-Instruct-FP8"
+QUICK_APPLY_MODELS: Dict[str, str] = {
+    "gap_analyzer": MAVERICK,
+    "differentiator": MAVERICK,
+    "tailoring_agent": MAVERICK,
+    "ats_optimizer": MAVERICK,
+    "auditor_suite": "openai:gpt-4o-mini",
+    "executive_synthesizer": MAVERICK,
+}
+# Typical wall-clock seconds per stage on the quick-apply models.
+STAGE_ESTIMATES: Dict[str, float] = {
+    "gap_analysis": 30,
+    "differentiation": 30,
+    "tailoring": 60,
+    "ats_optimization": 30,
+    "auditing": 40,
+    "executive_synthesis": 30,
+}
+REQUIRED_STAGES = ("gap_analysis", "tailoring", "auditing")
+
+
+class BudgetExceeded(Exception):
+    """Raised when a stage cannot finish inside the latency budget."""
+
+    pass
+
+
+class LatencyBudget:
+    """Wall-clock budget for a whole run, with what it skipped and what it took."""
+
+    def __init__(self, seconds: float, clock: Callable[[], float] = time.monotonic):
+        self.seconds = seconds
+        self.clock = clock
+        self.started = clock()
+        self.skipped: List[str] = []
+        self.stage_seconds: Dict[str, float] = {}
+        self.exceeded: Optional[str] = None
+        self._lock = threading.Lock()
+
+    def elapsed(self) -> float:
+        return self.clock() - self.started
+
+    def remaining(self) -> float:
+        return max(0.0, self.seconds - self.elapsed())
+
+    def fits(self, stage: str, ahead: Iterable[str] = ()) -> bool:
+        """Whether optional ``stage`` fits, keeping time for the required stages ahead."""
+        reserve = sum(STAGE_ESTIMATES.get(s, 0) for s in ahead if s in REQUIRED_STAGES)
+        return self.remaining() >= STAGE_ESTIMATES.get(stage, 0) + reserve
+
+    def skip(self, stage: str) -> None:
+        with self._lock:
+            self.skipped.append(stage)
+
+    def run(self, stage: str, fn: Callable[[], Any]) -> Any:
+        """Run ``fn`` on a worker thread, waiting at most the time remaining.
+
+        A call that overruns is abandoned (its thread finishes in the background,
+        its result discarded) and ``BudgetExceeded`` is raised.
+        """
+        remaining = self.remaining()
+        if remaining <= 0:
+            self._exceed(stage)
+        outcome: Dict[str, Any] = {}
+
+        def _target() -> None:
+            try:
+                outcome["result"] = fn()
+            except BaseException as e:  # re-raised on the caller's thread
+                outcome["error"] = e
+
+        started = self.clock()
+        worker = threading.Thread(target=_target, daemon=True)
+        worker.start()
+        worker.join(remaining)
+        with self._lock:
+            self.stage_seconds[stage] = round(
+                self.stage_seconds.get(stage, 0) + self.clock() - started, 1
+            )
+        if worker.is_alive():
+            self._exceed(stage)
+        if "error" in outcome:
+            raise outcome["error"]
+        return outcome["result"]
+
+    def _exceed(self, stage: str) -> None:
+        with self._lock:
+            self.exceeded = self.exceeded or stage
+        raise BudgetExceeded(f"latency budget of {self.seconds:g}s exhausted during {stage}")
+
+    def summary(self) -> Dict[str, Any]:
+        return {
+            "budget_seconds": self.seconds,
+            "elapsed_seconds": round(self.elapsed(), 1),
+            "skipped": list(self.skipped),
+            "stage_seconds": dict(self.stage_seconds),
+            "exceeded": self.exceeded,
+        }
+
+
+def fast_llm(agent_type: str) -> tuple[Optional[LLM], Optional[str]]:
+    """The quick-apply LLM and spec for ``agent_type``, or (None, None) if unavailable."""
+    spec = QUICK_APPLY_MODELS.get(agent_type)
+    if spec is None:
+        return None, None
+    try:
+        return get_llm_for_spec(spec, agent_type), spec
+    except LLMClientError:
+        return None, None
@@ -0,0 +1,230 @@
+"""Per-provider rate limits, shared by every workflow in the process.
+
+Several runs at once — the web backend's workers, a batch of applications — send
+their model calls to the same few providers, and a provider that sees too many
+requests answers with 429s that burn retries. ``HYDRA_RATE_LIMITS`` caps each
+provider's requests and tokens per minute::
+
+    HYDRA_RATE_LIMITS="chutes=60/200000, openrouter=20, gateway=0/90000"
+
+``provider=RPM[/TPM]``; a 0 or missing figure means "no limit on that axis", and a
+provider that is not listed is not limited at all. A call over the limit waits in
+its provider's queue instead of being sent.
+
+The queue is fair across runs: each run (its agents share a ``rate_limit_owner``)
+gets one call through in turn, so a job with thirty queued calls does not make a
+job with one wait behind all thirty. Within a run calls keep their order.
+
+Token counts come from the model's tokenizer (``runtime.crewai.tokenizer``): the
+prompt is charged when the call is admitted, and the charge is corrected with the
+answer's length once it returns. A single call larger than the whole TPM budget is let
+through on its own once the window is empty rather than queued forever.
+"""
+
+from __future__ import annotations
+
+import os
+import threading
+import time
+from collections import deque
+from dataclasses import dataclass
+from typing import Any, Callable, Deque, Dict, List, Optional
+
+from runtime.crewai.cancellation import CancelToken, RunCancelled
+from runtime.crewai.gateway import GATEWAY_PROVIDER, GatewayLLM
+
+RATE_LIMITS_ENV = "HYDRA_RATE_LIMITS"
+WINDOW_SECONDS = 60.0
+# How often a queued call re-checks its turn and whether its run was cancelled.
+POLL_SECONDS = 0.1
+DEFAULT_OWNER = "default"
+
+# LiteLLM model prefix -> provider name (as in model_config.PROVIDER_ENV_KEYS).
+_PREFIX_PROVIDERS = {
+    "anthropic": "anthropic",
+    "openai": "openai",
+    "openrouter": "openrouter",
+    "together_ai": "together",
+}
+
+
+class RateLimitConfigError(ValueError):
+    """Raised when ``HYDRA_RATE_LIMITS`` cannot be parsed."""
+
+    pass
+
+
+@dataclass(frozen=True)
+class RateLimit:
+    """Requests and tokens per minute for one provider; None means unlimited."""
+
+    rpm: Optional[int] = None
+    tpm: Optional[int] = None
+
+
+def parse_rate_limits(text: str) -> Dict[str, RateLimit]:
+    """``"chutes=60/200000, openrouter=20"`` -> {provider: RateLimit}."""
+    limits: Dict[str, RateLimit] = {}
+    for item in (part.strip() for part in (text or "").split(",")):
+        if not item:
+            continue
+        provider, sep, figures = item.partition("=")
+        rpm, _, tpm = figures.partition("/")
+        try:
+            rpm_value, tpm_value = int(rpm or 0), int(tpm or 0)
+        except ValueError:
+            rpm_value = tpm_value = -1
+        if not sep or not provider.strip() or rpm_value < 0 or tpm_value < 0:
+            raise RateLimitConfigError(
+                f"Bad {RATE_LIMITS_ENV} entry '{item}' (expected provider=RPM[/TPM])"
+            )
+        limits[provider.strip().lower()] = RateLimit(rpm_value or None, tpm_value or None)
+    return limits
+
+
+def provider_of(llm: Any) -> str:
+    """The provider an LLM object's calls go to, for looking up its limit."""
+    if isinstance(llm, GatewayLLM):
+        return GATEWAY_PROVIDER
+    if "chutes" in str(getattr(llm, "base_url", None) or ""):
+        return "chutes"  # routed as openai/<model> with Chutes' base URL
+    prefix = str(getattr(llm, "model", None) or "").partition("/")[0]
+    return _PREFIX_PROVIDERS.get(prefix, prefix or DEFAULT_OWNER)
+
+
+class Grant:
+    """An admitted call's place in its provider's window."""
+
+    def __init__(self, queue: "ProviderQueue", entry: List[float]):
+        self._queue = queue
+        self._entry = entry
+
+    def settle(self, tokens: int) -> None:
+        """Correct the call's token charge once its real size is known."""
+        self._queue.settle(self._entry, tokens)
+
+
+class ProviderQueue:
+    """One provider's sliding one-minute window and its fair queue of waiting calls."""
+
+    def __init__(
+        self,
+        limit: RateLimit,
+        window: float = WINDOW_SECONDS,
+        clock: Callable[[], float] = time.monotonic,
+    ):
+        self.limit = limit
+        self.window = window
+        self._clock = clock
+        self._cond = threading.Condition()
+        self._sent: Deque[List[float]] = deque()  # [admitted at, tokens charged]
+        self._waiting: Dict[str, Deque[object]] = {}
+        self._turns: Deque[str] = deque()  # owners with waiting calls, next first
+
+    def acquire(
+        self,
+        owner: str,
+        tokens: int,
+        cancel_token: Optional[CancelToken] = None,
+        stage: str = "",
+    ) -> Grant:
+        """Wait for ``owner``'s turn and room in the window, then admit the call."""
+        ticket = object()
+        with self._cond:
+            self._waiting.setdefault(owner, deque()).append(ticket)
+            if owner not in self._turns:
+                self._turns.append(owner)
+            try:
+                while True:
+                    if cancel_token is not None and cancel_token.cancelled:
+                        raise RunCancelled(stage, cancel_token.reason or "cancelled")
+                    delay = self._delay(tokens) if self._next() is ticket else POLL_SECONDS
+                    if delay <= 0:
+                        break
+                    self._cond.wait(min(delay, POLL_SECONDS))
+            finally:
+                self._leave(owner, ticket)
+                self._cond.notify_all()
+            entry = [self._clock(), float(tokens)]
+            self._sent.append(entry)
+            return Grant(self, entry)
+
+    def settle(self, entry: List[float], tokens: int) -> None:
+        with self._cond:
+            entry[1] = float(tokens)
+            self._cond.notify_all()
+
+    def _next(self) -> Optional[object]:
+        return self._waiting[self._turns[0]][0] if self._turns else None
+
+    def _leave(self, owner: str, ticket: object) -> None:
+        """Drop ``ticket`` from the queue; an owner served goes to the back of the line."""
+        queue = self._waiting.get(owner)
+        if queue is None or ticket not in queue:
+            return
+        served = queue[0] is ticket and self._turns and self._turns[0] == owner
+        queue.remove(ticket)
+        if not queue:
+            del self._waiting[owner]
+            self._turns.remove(owner)
+        elif served:
+            self._turns.rotate(-1)
+
+    def _delay(self, tokens: int) -> float:
+        """Seconds until a call of ``tokens`` fits the window (0 when it fits now)."""
+        now = self._clock()
+        while self._sent and self._sent[0][0] <= now - self.window:
+            self._sent.popleft()
+        delay = 0.0
+        if self.limit.rpm and len(self._sent) >= self.limit.rpm:
+            oldest = self._sent[len(self._sent) - self.limit.rpm]
+            delay = max(delay, oldest[0] + self.window - now)
+        if self.limit.tpm and self._sent:
+            excess = sum(entry[1] for entry in self._sent) + tokens - self.limit.tpm
+            for admitted, charged in self._sent:
+                if excess <= 0:
+                    break
+                excess -= charged
+                delay = max(delay, admitted + self.window - now)
+        return delay
+
+
+class RateLimiter:
+    """The per-provider queues; providers without a limit pass straight through."""
+
+    def __init__(self, limits: Dict[str, RateLimit], window: float = WINDOW_SECONDS):
+        self.limits = dict(limits)
+        self._queues = {
+            provider: ProviderQueue(limit, window)
+            for provider, limit in self.limits.items()
+            if limit.rpm or limit.tpm
+        }
+
+    def acquire(
+        self,
+        provider: str,
+        owner: Optional[str],
+        tokens: int,
+        cancel_token: Optional[CancelToken] = None,
+        stage: str = "",
+    ) -> Optional[Grant]:
+        """Admit one call to ``provider``, waiting if needed; None when it is unlimited."""
+        queue = self._queues.get(provider)
+        if queue is None:
+            return None
+        return queue.acquire(owner or DEFAULT_OWNER, tokens, cancel_token, stage)
+
+
+_shared: Optional[RateLimiter] = None
+_shared_spec: Optional[str] = None
+_shared_lock = threading.Lock()
+
+
+def shared_limiter() -> RateLimiter:
+    """The process-wide limiter for ``HYDRA_RATE_LIMITS`` (rebuilt if the value changes)."""
+    global _shared, _shared_spec
+    spec = os.environ.get(RATE_LIMITS_ENV, "")
+    with _shared_lock:
+        if _shared is None or spec != _shared_spec:
+            _shared, _shared_spec = RateLimiter(parse_rate_limits(spec)), spec
+        return _shared
@@ -0,0 +1,209 @@
+"""Referrals: who the candidate knows at the target company, and what to ask them.
+
+``--contacts contacts.csv`` points a run at the candidate's own contacts, one per
+row with ``name``, ``company`` and ``relationship`` columns (``title`` and ``notes``
+are read when present; other columns are ignored). Header names are matched
+without regard to case, so a trimmed-down LinkedIn ``Connections.csv`` works once
+its ``First Name``/``Last Name`` are merged into ``name``.
+
+Software does the cross-referencing: a contact is a match when their company is
+the target company once case, punctuation and legal suffixes (Inc, GmbH, …) are
+set aside, or one name is the other plus more words ("Acme" and "Acme Robotics").
+Matches are ranked by how close the relationship is, and each gets a referral
+path — ask for a referral, for an introduction to the hiring team, or for advice
+first — from that closeness. The Referral Finder then drafts the ask message for
+each match from the candidate's differentiators and the audited résumé; it may
+pick a different path for a contact, but cannot add contacts.
+
+Contacts are other people's details. Only the matches reach the model, the stage
+output and ``referrals.md``; ``run.json`` records counts only. The stage runs
+after the audit, needs the target company (``--company``, or a ``Company:`` line
+in the job description), and never fails the run.
+"""
+
+from __future__ import annotations
+
+import csv
+import io
+import re
+from dataclasses import asdict, dataclass
+from pathlib import Path
+from typing import Any, Dict, List, Optional
+
+REFERRALS_FILE = "referrals.md"
+
+# Referral paths, from the biggest ask to the smallest.
+REFERRAL = "referral"
+INTRODUCTION = "introduction"
+ADVICE = "advice"
+PATHS = (REFERRAL, INTRODUCTION, ADVICE)
+
+# Relationship words, closest first; anything else is an ordinary tie.
+_STRONG_TIES = (
+    "friend",
+    "family",
+    "former colleague",
+    "colleague",
+    "ex-colleague",
+    "teammate",
+    "manager",
+    "mentor",
+    "direct report",
+    "classmate",
+)
+_WEAK_TIES = ("acquaintance", "met ", "met at", "recruiter", "follower", "online", "cold")
+_PATH_BY_TIE = {0: REFERRAL, 1: INTRODUCTION, 2: ADVICE}
+
+# Words that do not tell two companies apart.
+_LEGAL_SUFFIXES = {
+    "ag",
+    "bv",
+    "co",
+    "company",
+    "corp",
+    "corporation",
+    "gmbh",
+    "inc",
+    "incorporated",
+    "llc",
+    "llp",
+    "ltd",
+    "limited",
+    "plc",
+    "sa",
+    "sarl",
+    "srl",
+}
+_WORD_RE = re.compile(r"[a-z0-9]+")
+
+
+class ContactsError(ValueError):
+    """Raised when the contacts file is missing, has no usable columns or no contacts."""
+
+    pass
+
+
+@dataclass(frozen=True)
+class Contact:
+    """One row of the candidate's contacts file."""
+
+    name: str
+    company: str
+    relationship: str = ""
+    title: str = ""
+    notes: str = ""
+
+    def to_dict(self) -> Dict[str, str]:
+        return asdict(self)
+
+
+def load_contacts(path: Path) -> List[Contact]:
+    """The contacts in a CSV file with ``name`` and ``company`` columns.
+
+    Rows without a name or a company are skipped. Raises ContactsError when the
+    file is missing, lacks those columns or holds no contact.
+    """
+    path = Path(path)
+    if not path.is_file():
+        raise ContactsError(f"No such contacts file: {path}")
+    reader = csv.DictReader(io.StringIO(path.read_text(encoding="utf-8-sig")))
+    columns = {(name or "").strip().lower(): name for name in reader.fieldnames or []}
+    missing = [column for column in ("name", "company") if column not in columns]
+    if missing:
+        raise ContactsError(
+            f"{path} needs {' and '.join(missing)} column(s) "
+            "(expected: name, company, relationship)"
+        )
+
+    def cell(row: Dict[str, Any], column: str) -> str:
+        return str(row.get(columns.get(column, ""), "") or "").strip()
+
+    contacts = [
+        Contact(
+            name=cell(row, "name"),
+            company=cell(row, "company"),
+            relationship=cell(row, "relationship"),
+            title=cell(row, "title"),
+            notes=cell(row, "notes"),
+        )
+        for row in reader
+        if cell(row, "name") and cell(row, "company")
+    ]
+    if not contacts:
+        raise ContactsError(f"{path} holds no contacts with a name and a company")
+    return contacts
+
+
+def company_words(company: str) -> List[str]:
+    """``"Acme, Inc."`` -> ``["acme"]``: the words that name a company."""
+    words = _WORD_RE.findall(company.lower().replace("&", " and "))
+    return [word for word in words if word not in _LEGAL_SUFFIXES]
+
+
+def same_company(a: str, b: str) -> bool:
+    """Whether two company names name the same employer, as far as names can tell."""
+    first, second = company_words(a), company_words(b)
+    if not first or not second:
+        return False
+    shorter, longer = sorted((first, second), key=len)
+    return longer[: len(shorter)] == shorter
+
+
+def tie_strength(relationship: str) -> int:
+    """0 for a close tie (friend, former colleague), 2 for a loose one, else 1."""
+    text = f" {relationship.lower()} "
+    if any(word in text for word in _STRONG_TIES):
+        return 0
+    if any(word in text for word in _WEAK_TIES):
+        return 2
+    return 1
+
+
+def referral_paths(contacts: List[Contact], company: Optional[str]) -> List[Dict[str, Any]]:
+    """The contacts at ``company``, closest first, each with a suggested path.
+
+    Entries are numbered from 1 (``id``), the numbers the Referral Finder answers by.
+    """
+    if not company:
+        return []
+    matches = [contact for contact in contacts if same_company(contact.company, company)]
+    ranked = sorted(matches, key=lambda contact: tie_strength(contact.relationship))
+    return [
+        {
+            "id": number,
+            **contact.to_dict(),
+            "path": _PATH_BY_TIE[tie_strength(contact.relationship)],
+        }
+        for number, contact in enumerate(ranked, start=1)
+    ]
+
+
+def render_referrals(referrals: Dict[str, Any]) -> str:
+    """The referral paths and drafted asks as Markdown, for ``referrals.md``."""
+    company = referrals.get("company") or "the target company"
+    lines = [f"# Referral paths at {company}", ""]
+    matches = referrals.get("contacts") or []
+    if not matches:
+        lines += ["None of your contacts work there.", ""]
+    for match in matches:
+        about = ", ".join(
+            part for part in (match.get("title"), match.get("relationship")) if part
+        )
+        lines += [f"## {match['name']}" + (f" ({about})" if about else ""), ""]
+        lines += [f"**Path:** {match['path']}", ""]
+        if match.get("ask"):
+            lines += [match["ask"], ""]
+        else:
+            lines += ["_No ask was drafted._", ""]
+    return "\n".join(lines).rstrip() + "\n"
+
+
+def manifest_summary(referrals: Dict[str, Any]) -> Dict[str, Any]:
+    """What ``run.json`` keeps of the referrals: counts, never names."""
+    matches = referrals.get("contacts") or []
+    return {
+        "contacts_checked": referrals.get("contacts_checked", 0),
+        "matches": len(matches),
+        "paths": {path: sum(1 for m in matches if m.get("path") == path) for path in PATHS},
+        "drafted": sum(1 for m in matches if m.get("ask")),
+    }
@@ -0,0 +1,119 @@
+"""Reflection: an agent critiques its own first pass and revises it.
+
+``reflect(agent, iterations)`` wraps ``agent.execute``. After the first pass the
+output goes back to the same model with a reflection prompt: critique the answer
+against the task (facts the inputs do not support, requirements it missed, vague or
+generic wording, format problems), then return the complete revised output. Each
+iteration revises the previous revision. A revision that fails keeps the output
+before it, so reflecting never costs a stage its answer.
+
+Any agent can be wrapped; the workflow does it for the stages the ``reflection``
+section of the pipeline config names (see runtime.crewai.pipeline_config)::
+
+    reflection:
+      tailoring: 2          # iterations after the first pass
+      differentiation: true # one iteration
+      audit: false
+
+Every iteration is another model call of the stage's size, inside the stage's
+timeout. Reflection is off for every stage by default. The iterations each stage
+ran are recorded in the manifest under ``reflection``.
+"""
+
+from __future__ import annotations
+
+import functools
+import json
+from dataclasses import dataclass, field
+from typing import Any, Callable, Dict, Mapping, Optional
+
+from runtime.crewai.timeouts import STAGES, config_stage
+
+MAX_ITERATIONS = 5
+
+# Bookkeeping fields left out of the answer quoted back to the model.
+_QUOTED_NOISE = ("agent", "timestamp", "confidence", "tool_calls")
+
+Execute = Callable[[Dict[str, Any]], Dict[str, Any]]
+
+
+def reflection_prompt(result: Dict[str, Any], iteration: int, iterations: int) -> str:
+    """What the model is told about its previous answer in reflection ``iteration``."""
+    previous = {key: value for key, value in result.items() if key not in _QUOTED_NOISE}
+    quoted = json.dumps(previous, indent=2, ensure_ascii=False, default=str)
+    return f"""REFLECTION ({iteration}/{iterations}): below is your previous answer to this task.
+
+{quoted}
+
+Critique it before you answer again. Check every claim against the inputs above and
+drop or fix anything they do not support; find the requirements of the task or the job
+description it misses or covers weakly; find vague, generic or repetitive wording and
+anything that breaks the required format. Then return the complete revised answer in
+the same JSON format, not a list of changes. Keep what was already right."""
+
+
+def reflect(
+    agent: Any, iterations: int, log: Optional[Callable[[str], None]] = None
+) -> Execute:
+    """``agent.execute`` followed by ``iterations`` rounds of critique and revision.
+
+    The critique reaches the model through ``agent.critique`` (see base_agent), which
+    is restored afterwards.
+    """
+    execute = agent.execute
+    if iterations <= 0:
+        return execute
+
+    @functools.wraps(execute)
+    def reflected(context: Dict[str, Any]) -> Dict[str, Any]:
+        result = execute(context)
+        before = getattr(agent, "critique", None)
+        try:
+            for iteration in range(1, iterations + 1):
+                agent.critique = reflection_prompt(result, iteration, iterations)
+                try:
+                    result = execute(context)
+                except Exception as e:  # the last good answer stands
+                    if log is not None:
+                        log(f"Reflection {iteration}/{iterations} of {agent.role} failed: {e}")
+                    break
+        finally:
+            agent.critique = before
+        return result
+
+    return reflected
+
+
+def _iterations(value: Any, stage: str) -> int:
+    if isinstance(value, bool):
+        return int(value)
+    if not isinstance(value, int) or not 0 <= value <= MAX_ITERATIONS:
+        raise ValueError(
+            f"reflection.{stage} must be true, false or 0-{MAX_ITERATIONS} iterations"
+        )
+    return value
+
+
+@dataclass(frozen=True)
+class ReflectionPolicy:
+    """How many reflection iterations each stage runs (none unless configured)."""
+
+    stages: Mapping[str, int] = field(default_factory=dict)
+
+    @classmethod
+    def from_dict(cls, data: Mapping[str, Any]) -> "ReflectionPolicy":
+        """``{"tailoring": 2, "audit": false}``. Raises ValueError on unknown stages or
+        bad values."""
+        unknown = set(data) - set(STAGES)
+        if unknown:
+            raise ValueError(
+                f"unknown stage(s) in reflection: {', '.join(sorted(unknown))} "
+                f"(expected: {', '.join(STAGES)})"
+            )
+        return cls({stage: _iterations(value, stage) for stage, value in data.items()})
+
+    def to_dict(self) -> Dict[str, int]:
+        return dict(self.stages)
+
+    def for_stage(self, stage: str) -> int:
+        return self.stages.get(config_stage(stage), 0)
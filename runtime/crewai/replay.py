@@ -0,0 +1,89 @@
+"""Replay a finished run from one of its stages, with other prompts.
+
+``hydra replay <id> --from tailoring --prompt-dir ./experiments/p2`` is for prompt
+engineering: it starts a new run on the same inputs, reuses the stored outputs of
+every stage before ``--from`` (research, gap analysis, the interview, ...), and runs
+``--from`` and everything after it again, so a prompt change is tried in the time a
+couple of stages take rather than a whole run, and without answering the interview
+again. The original run is left as it is.
+
+A prompt directory is laid out like ``agents/``: ``<agent>/prompt.md`` (or
+``<agent>.md``) replaces that agent's prompt, e.g. ``tailoring-agent/prompt.md``.
+Agents without a file keep theirs. ``--prompt-dir`` works on any run, not only a
+replay, and the run records it among its inputs.
+"""
+
+from __future__ import annotations
+
+from pathlib import Path
+from typing import Any, Dict, Iterable, Mapping
+
+from runtime.crewai.plugins import ANCHOR_STAGES, StagePlugin
+from runtime.crewai.workflow_templates import TEMPLATE_STAGES
+
+AGENTS_DIR = "agents"
+PROMPT_FILE = "prompt.md"
+
+# The stages a replay can start from, in pipeline order. The audit's output is not
+# kept apart from the run's result, so a replay from it also re-runs the synthesis.
+REPLAY_STAGES = (
+    "gap_analysis",
+    "seniority",
+    "impact",
+    "interrogation",
+    "differentiation",
+    "tailoring",
+    "ats_optimization",
+    "audit",
+)
+
+
+def upstream(
+    results: Mapping[str, Any], stage: str, plugins: Iterable[StagePlugin] = ()
+) -> Dict[str, Any]:
+    """The stored outputs a replay from ``stage`` reuses: the built-in stages before it
+    and the plugins that run after one of those. Raises ValueError for an unknown stage."""
+    if stage not in REPLAY_STAGES:
+        raise ValueError(f"cannot replay from {stage!r} (expected: {', '.join(REPLAY_STAGES)})")
+    before = set(TEMPLATE_STAGES[: TEMPLATE_STAGES.index(stage)])
+    anchors = {plugin.name: plugin.after for plugin in plugins}
+    kept = {}
+    for name, output in results.items():
+        anchor = anchors.get(name)
+        if name in before or (
+            anchor in ANCHOR_STAGES and anchor in before and anchor != stage
+        ):
+            kept[name] = output
+    return kept
+
+
+def agent_prompt_paths(root: Path) -> Dict[str, str]:
+    """Agent name (its directory under ``agents/``) -> the prompt path agents load."""
+    agents = Path(root) / AGENTS_DIR
+    return {
+        path.parent.name: f"{AGENTS_DIR}/{path.parent.name}/{PROMPT_FILE}"
+        for path in sorted(agents.glob(f"*/{PROMPT_FILE}"))
+    }
+
+
+def load_prompt_overrides(prompt_dir: Path, root: Path) -> Dict[str, str]:
+    """The prompts in ``prompt_dir``, keyed by the prompt path they replace.
+
+    Raises ValueError if the directory is missing, holds no prompt, or names an agent
+    the repository at ``root`` does not have (a typo would otherwise go unnoticed).
+    """
+    prompt_dir = Path(prompt_dir)
+    if not prompt_dir.is_dir():
+        raise ValueError(f"not a directory: {prompt_dir}")
+    known = agent_prompt_paths(root)
+    files = {path.parent.name: path for path in prompt_dir.glob(f"*/{PROMPT_FILE}")}
+    for path in prompt_dir.glob("*.md"):
+        files.setdefault(path.stem, path)
+    unknown = sorted(set(files) - set(known))
+    if unknown:
+        raise ValueError(
+            f"no agent named {', '.join(unknown)} (expected: {', '.join(known)})"
+        )
+    if not files:
+        raise ValueError(f"no <agent>/{PROMPT_FILE} or <agent>.md files in {prompt_dir}")
+    return {known[name]: path.read_text(encoding="utf-8") for name, path in files.items()}
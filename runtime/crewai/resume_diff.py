@@ -0,0 +1,188 @@
+"""Diff the candidate's baseline résumé against the tailored/ATS-optimized output.
+
+The audit judges the output against the sources, but the fastest human check is
+still "what changed?". This module renders that:
+
+- ``unified_diff`` — a plain unified diff, optionally ANSI-coloured for a terminal;
+- ``side_by_side`` — two terminal columns aligned by ``difflib.SequenceMatcher``;
+- ``html_diff`` — a standalone side-by-side HTML table (``difflib.HtmlDiff``);
+- ``inline_diff`` — the tailored résumé line by line, marking added lines and placing
+  removed ones where they were, rebuilt from a run's ``resume.diff`` alone.
+
+``summarize`` adds two cheap fabrication/loss hints on top of the raw diff: numbers
+(metrics, years, amounts) that appear in the tailored version but nowhere in the
+baseline or sources, and baseline lines that vanished entirely. They are hints for a
+human reviewer, not verdicts — rephrasing legitimately removes lines.
+"""
+
+from __future__ import annotations
+
+import difflib
+import re
+import shutil
+from dataclasses import dataclass, field
+from typing import List
+
+RESUME_DIFF_FILE = "resume.diff"
+RESUME_DIFF_HTML_FILE = "resume_diff.html"
+
+_RED = "\033[31m"
+_GREEN = "\033[32m"
+_CYAN = "\033[36m"
+_RESET = "\033[0m"
+
+# Metrics and amounts: 40%, $1.2M, 3x, 2019, 15,000 ...
+_NUMBER_RE = re.compile(r"[$€£]?\d[\d,.]*\s?(?:%|[kKmMbB]\b|x\b)?")
+
+
+def _lines(text: str) -> List[str]:
+    return (text or "").splitlines()
+
+
+def unified_diff(
+    baseline: str,
+    tailored: str,
+    color: bool = False,
+    fromfile: str = "baseline",
+    tofile: str = "tailored",
+) -> str:
+    """Unified diff of the two résumés (ANSI-coloured when ``color``)."""
+    diff = list(
+        difflib.unified_diff(
+            _lines(baseline), _lines(tailored), fromfile=fromfile, tofile=tofile, lineterm=""
+        )
+    )
+    if not color:
+        return "\n".join(diff)
+    out = []
+    for line in diff:
+        if line.startswith(("+++", "---")):
+            out.append(line)
+        elif line.startswith("+"):
+            out.append(f"{_GREEN}{line}{_RESET}")
+        elif line.startswith("-"):
+            out.append(f"{_RED}{line}{_RESET}")
+        elif line.startswith("@@"):
+            out.append(f"{_CYAN}{line}{_RESET}")
+        else:
+            out.append(line)
+    return "\n".join(out)
+
+
+def _fit(text: str, width: int) -> str:
+    return text[: width - 1] + "…" if len(text) > width else text.ljust(width)
+
+
+def side_by_side(baseline: str, tailored: str, width: int = 0, color: bool = False) -> str:
+    """Two aligned columns, baseline left; changed rows marked ``|``, ``<`` or ``>``."""
+    width = width or shutil.get_terminal_size((160, 24)).columns
+    column = max(20, (width - 3) // 2)
+    left, right = _lines(baseline), _lines(tailored)
+    rows = []
+    matcher = difflib.SequenceMatcher(a=left, b=right, autojunk=False)
+    for tag, i1, i2, j1, j2 in matcher.get_opcodes():
+        span = max(i2 - i1, j2 - j1)
+        for k in range(span):
+            a = left[i1 + k] if i1 + k < i2 else ""
+            b = right[j1 + k] if j1 + k < j2 else ""
+            if tag == "equal":
+                mark = " "
+            elif not a:
+                mark = ">"
+            elif not b:
+                mark = "<"
+            else:
+                mark = "|"
+            row = f"{_fit(a, column)} {mark} {_fit(b, column)}".rstrip()
+            if color and mark != " ":
+                row = f"{_RED if mark == '<' else _GREEN}{row}{_RESET}"
+            rows.append(row)
+    return "\n".join(rows)
+
+
+def html_diff(baseline: str, tailored: str, title: str = "Résumé changes") -> str:
+    """Standalone side-by-side HTML diff."""
+    return difflib.HtmlDiff(wrapcolumn=80).make_file(
+        _lines(baseline), _lines(tailored), "Baseline", "Tailored", context=False
+    ).replace("<title></title>", f"<title>{title}</title>")
+
+
+_HUNK_RE = re.compile(r"^@@ -\d+(?:,\d+)? \+(\d+)(?:,\d+)? @@")
+
+
+def inline_diff(tailored: str, unified: str) -> List[tuple[str, str]]:
+    """``(kind, line)`` for every line of ``tailored``, with removed lines in place.
+
+    ``kind`` is "added", "removed" or "same"; ``unified`` is the unified diff of the
+    baseline against ``tailored`` (as ``unified_diff`` writes it).
+    """
+    lines = _lines(tailored)
+    added: set[int] = set()
+    removed: dict[int, List[str]] = {}
+    position = 0  # index into the tailored lines
+    for line in _lines(unified):
+        hunk = _HUNK_RE.match(line)
+        if hunk:
+            position = max(int(hunk.group(1)) - 1, 0)
+        elif line.startswith(("+++", "---")):
+            continue
+        elif line.startswith("+"):
+            added.add(position)
+            position += 1
+        elif line.startswith("-"):
+            removed.setdefault(position, []).append(line[1:])
+        elif line.startswith(" "):
+            position += 1
+    rows: List[tuple[str, str]] = []
+    for index in range(len(lines) + 1):
+        rows.extend(("removed", text) for text in removed.get(index, []))
+        if index < len(lines):
+            rows.append(("added" if index in added else "same", lines[index]))
+    return rows
+
+
+def numbers_in(text: str) -> set[str]:
+    """Normalised numeric tokens (metrics, amounts, years) found in ``text``."""
+    return {m.group(0).strip().rstrip(".,") for m in _NUMBER_RE.finditer(text or "")} - {""}
+
+
+@dataclass
+class DiffSummary:
+    """Counts plus reviewer hints; ``to_manifest`` is content-free."""
+
+    added_lines: int = 0
+    removed_lines: int = 0
+    # Numbers in the tailored résumé found in neither the baseline nor the sources.
+    new_numbers: List[str] = field(default_factory=list)
+    # Baseline lines with no close match anywhere in the tailored résumé.
+    dropped_lines: List[str] = field(default_factory=list)
+
+    def to_manifest(self) -> dict:
+        return {
+            "added_lines": self.added_lines,
+            "removed_lines": self.removed_lines,
+            "new_numbers": len(self.new_numbers),
+            "dropped_lines": len(self.dropped_lines),
+        }
+
+
+def summarize(baseline: str, tailored: str, sources: str = "") -> DiffSummary:
+    """Line counts plus new-number and dropped-line hints."""
+    summary = DiffSummary()
+    for line in difflib.unified_diff(_lines(baseline), _lines(tailored), lineterm="", n=0):
+        if line.startswith("+") and not line.startswith("+++"):
+            summary.added_lines += 1
+        elif line.startswith("-") and not line.startswith("---"):
+            summary.removed_lines += 1
+
+    known = numbers_in(baseline) | numbers_in(sources)
+    summary.new_numbers = sorted(numbers_in(tailored) - known)
+
+    tailored_lines = [line.strip() for line in _lines(tailored) if line.strip()]
+    for line in _lines(baseline):
+        stripped = line.strip()
+        if len(stripped) < 12 or stripped.startswith("#"):
+            continue  # headings and fragments are reshuffled, not lost
+        if not difflib.get_close_matches(stripped, tailored_lines, n=1, cutoff=0.6):
+            summary.dropped_lines.append(stripped)
+    return summary
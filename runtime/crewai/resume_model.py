@@ -0,0 +1,382 @@
+"""Structured résumé model and per-bullet patches.
+
+A Markdown résumé in the layout the pipeline writes (see
+``agents/tailoring-agent/prompt.md``) reads as sections (``## Experience``), entries
+within a section (``### Title | Company``) and bullets within an entry. Every bullet
+gets an id from its place in the document: ``experience-2-3`` is the third bullet of
+the second entry under Experience (lines before a section's first entry are entry
+0). Lines that are not bullets — the header, dates, the skills line — are kept
+verbatim, so ``parse_resume(text).render() == text``.
+
+With ``--patches`` the Tailoring Agent edits the baseline instead of rewriting it:
+it is shown the résumé with its bullet ids and answers with patches — replace a
+bullet, insert one after a bullet, delete one — each with a reason. Software checks
+the targets, applies the patches to the baseline, and the rest of the pipeline runs
+on the result as before. The patches are kept in ``resume_patches.json``.
+
+Each patch can then be accepted or rejected on its own (``hydra serve``, or
+``decide_patches``). A decision is applied to the run's current ``resume.md`` by
+text, not by id, because later stages (ATS optimisation, a review) may have moved
+things: rejecting a replacement puts the baseline bullet back where the new one is,
+rejecting an insertion removes it, rejecting a deletion restores the bullet after
+the one it followed. A bullet a later stage rewrote cannot be matched, so that
+decision is reported as a conflict and ``resume.md`` is left as it is.
+"""
+
+from __future__ import annotations
+
+import copy
+import json
+import re
+from dataclasses import asdict, dataclass, field
+from datetime import datetime
+from pathlib import Path
+from typing import Any, Dict, Iterator, List, Optional, Tuple, Union
+
+from runtime.crewai.encryption import read_text, write_text
+
+RESUME_PATCHES_FILE = "resume_patches.json"
+
+REPLACE = "replace"
+INSERT = "insert"
+DELETE = "delete"
+OPS = (REPLACE, INSERT, DELETE)
+
+PROPOSED = "proposed"  # applied, not yet decided on
+ACCEPTED = "accepted"
+REJECTED = "rejected"
+
+_SECTION_RE = re.compile(r"^##\s+(?P<title>.+?)\s*#*\s*$")
+_ENTRY_RE = re.compile(r"^###\s+(?P<title>.+?)\s*#*\s*$")
+_BULLET_RE = re.compile(r"^(?P<marker>\s*(?:[-*•]|\d+[.)])\s+)(?P<text>.*)$")
+_SLUG_RE = re.compile(r"[^a-z0-9]+")
+DEFAULT_MARKER = "- "
+
+
+@dataclass
+class Bullet:
+    id: str
+    marker: str
+    text: str
+
+    def line(self) -> str:
+        return f"{self.marker}{self.text}"
+
+
+@dataclass
+class Entry:
+    """A ``###`` entry (a role, a degree), or a section's lines before its first one."""
+
+    heading: str = ""
+    heading_line: Optional[str] = None
+    lines: List[Union[str, Bullet]] = field(default_factory=list)
+
+    @property
+    def bullets(self) -> List[Bullet]:
+        return [line for line in self.lines if isinstance(line, Bullet)]
+
+
+@dataclass
+class Section:
+    """A ``##`` section; the first section of a document is its header (no title)."""
+
+    title: str = ""
+    heading_line: Optional[str] = None
+    entries: List[Entry] = field(default_factory=list)
+
+
+@dataclass
+class ResumeDocument:
+    sections: List[Section]
+    trailing_newline: bool = True
+
+    def bullets(self) -> Iterator[Tuple[Section, Entry, Bullet]]:
+        for section in self.sections:
+            for entry in section.entries:
+                for bullet in entry.bullets:
+                    yield section, entry, bullet
+
+    def find(self, bullet_id: str) -> Optional[Tuple[Section, Entry, Bullet]]:
+        return next((found for found in self.bullets() if found[2].id == bullet_id), None)
+
+    def render(self) -> str:
+        lines: List[str] = []
+        for section in self.sections:
+            if section.heading_line is not None:
+                lines.append(section.heading_line)
+            for entry in section.entries:
+                if entry.heading_line is not None:
+                    lines.append(entry.heading_line)
+                lines += [_line(item) for item in entry.lines]
+        text = "\n".join(lines)
+        return text + "\n" if self.trailing_newline and lines else text
+
+    def outline(self) -> str:
+        """The résumé with each bullet's id in front, for a model to patch by."""
+        lines: List[str] = []
+        for section in self.sections:
+            if section.heading_line is not None:
+                lines.append(section.heading_line)
+            for entry in section.entries:
+                if entry.heading_line is not None:
+                    lines.append(entry.heading_line)
+                for item in entry.lines:
+                    is_bullet = isinstance(item, Bullet)
+                    lines.append(f"[{item.id}] {item.text}" if is_bullet else item)
+        return "\n".join(lines)
+
+    def to_dict(self) -> Dict[str, Any]:
+        """Sections, entries and bullets (with ids); non-bullet lines are left out."""
+        return {
+            "sections": [
+                {
+                    "title": section.title,
+                    "entries": [
+                        {
+                            "heading": entry.heading,
+                            "bullets": [{"id": b.id, "text": b.text} for b in entry.bullets],
+                        }
+                        for entry in section.entries
+                    ],
+                }
+                for section in self.sections
+            ]
+        }
+
+
+def _line(item: Union[str, Bullet]) -> str:
+    return item.line() if isinstance(item, Bullet) else item
+
+
+def _slug(title: str) -> str:
+    return _SLUG_RE.sub("-", title.lower()).strip("-") or "section"
+
+
+def parse_resume(text: str) -> ResumeDocument:
+    """A Markdown résumé as sections, entries and bullets with ids."""
+    sections = [Section(entries=[Entry()])]
+    slug = "header"
+    for line in text.splitlines():
+        section, entry = sections[-1], sections[-1].entries[-1]
+        entry_match, section_match = _ENTRY_RE.match(line), _SECTION_RE.match(line)
+        if section_match:
+            title = section_match.group("title")
+            slug = _slug(title)
+            # A second section with the same title gets ids of its own.
+            same = sum(1 for s in sections if s.title and _slug(s.title) == slug)
+            slug = f"{slug}{same + 1}" if same else slug
+            sections.append(Section(title=title, heading_line=line, entries=[Entry()]))
+        elif entry_match:
+            heading = entry_match.group("title")
+            section.entries.append(Entry(heading=heading, heading_line=line))
+        elif _BULLET_RE.match(line):
+            bullet = _BULLET_RE.match(line)
+            bullet_id = f"{slug}-{len(section.entries) - 1}-{len(entry.bullets) + 1}"
+            entry.lines.append(Bullet(bullet_id, bullet.group("marker"), bullet.group("text")))
+        else:
+            entry.lines.append(line)
+    return ResumeDocument(sections=sections, trailing_newline=text.endswith("\n"))
+
+
+@dataclass
+class ResumePatch:
+    """One proposed edit to a baseline bullet, and the candidate's decision on it."""
+
+    id: str
+    op: str
+    target: str  # the baseline bullet's id
+    text: str = ""  # the new bullet (replace, insert)
+    reason: str = ""
+    section: str = ""
+    entry: str = ""
+    before: Optional[str] = None  # the baseline bullet (replace, delete)
+    anchor: Optional[str] = None  # the bullet the new or deleted one follows
+    status: str = PROPOSED
+
+    def to_dict(self) -> Dict[str, Any]:
+        return asdict(self)
+
+    @classmethod
+    def from_dict(cls, data: Dict[str, Any]) -> "ResumePatch":
+        return cls(**{key: data[key] for key in cls.__dataclass_fields__ if key in data})
+
+
+def propose_patches(
+    document: ResumeDocument, raw: Any
+) -> Tuple[List[ResumePatch], List[str]]:
+    """The model's patches checked against ``document``: the valid ones, numbered
+    ``p1``…, with the baseline text each needs to be undone; and why others were
+    dropped (unknown op or bullet, no text, a replacement that changes nothing)."""
+    patches: List[ResumePatch] = []
+    problems: List[str] = []
+    for item in raw if isinstance(raw, list) else []:
+        if not isinstance(item, dict):
+            continue
+        op = str(item.get("op", "")).strip().lower()
+        target = str(item.get("target", item.get("bullet", ""))).strip().strip("[]")
+        text = str(item.get("text") or "").strip()
+        found = document.find(target)
+        if op not in OPS:
+            problems.append(f"{target or '?'}: unknown op {op!r}")
+            continue
+        if found is None:
+            problems.append(f"{target or '?'}: no such bullet")
+            continue
+        if op != DELETE and not text:
+            problems.append(f"{target}: {op} without text")
+            continue
+        section, entry, bullet = found
+        if op == REPLACE and text == bullet.text.strip():
+            continue
+        bullets = entry.bullets
+        previous = bullets[bullets.index(bullet) - 1] if bullets.index(bullet) else None
+        patches.append(
+            ResumePatch(
+                id=f"p{len(patches) + 1}",
+                op=op,
+                target=target,
+                text=text,
+                reason=str(item.get("reason") or "").strip(),
+                section=section.title,
+                entry=entry.heading,
+                before=bullet.text if op != INSERT else None,
+                anchor=bullet.text if op == INSERT else previous.text if previous else None,
+            )
+        )
+    return patches, problems
+
+
+def apply_patches(document: ResumeDocument, patches: List[ResumePatch]) -> ResumeDocument:
+    """A copy of the baseline ``document`` with every patch not rejected applied."""
+    patched = copy.deepcopy(document)
+    live = [patch for patch in patches if patch.status != REJECTED]
+    for section in patched.sections:
+        for entry in section.entries:
+            lines: List[Union[str, Bullet]] = []
+            for item in entry.lines:
+                if not isinstance(item, Bullet):
+                    lines.append(item)
+                    continue
+                ops = [patch for patch in live if patch.target == item.id]
+                replaced = [patch for patch in ops if patch.op == REPLACE]
+                if not any(patch.op == DELETE for patch in ops):
+                    text = replaced[-1].text if replaced else item.text
+                    lines.append(Bullet(item.id, item.marker, text))
+                for patch in ops:
+                    if patch.op == INSERT:
+                        lines.append(Bullet(f"{item.id}+{patch.id}", item.marker, patch.text))
+            entry.lines = lines
+    return patched
+
+
+def _locate(
+    document: ResumeDocument, text: Optional[str], patch: ResumePatch
+) -> Optional[Tuple[Entry, Bullet]]:
+    """The bullet reading ``text``, in the patch's entry if it is still there."""
+    if text is None:
+        return None
+    wanted = text.strip()
+    found = [(s, e, b) for s, e, b in document.bullets() if b.text.strip() == wanted]
+    same_entry = [f for f in found if (f[0].title, f[1].heading) == (patch.section, patch.entry)]
+    section, entry, bullet = (same_entry or found or [(None, None, None)])[0]
+    return (entry, bullet) if bullet is not None else None
+
+
+def _entry_of(document: ResumeDocument, patch: ResumePatch) -> Optional[Entry]:
+    for section in document.sections:
+        for entry in section.entries:
+            if (section.title, entry.heading) == (patch.section, patch.entry):
+                return entry
+    return None
+
+
+def _place_after(document: ResumeDocument, patch: ResumePatch, text: str) -> bool:
+    """Insert a bullet reading ``text`` after the patch's anchor (or first in its entry)."""
+    anchor = _locate(document, patch.anchor, patch)
+    if anchor is not None:
+        entry, after = anchor
+        entry.lines.insert(entry.lines.index(after) + 1, Bullet("", after.marker, text))
+        return True
+    entry = _entry_of(document, patch)
+    if entry is None or patch.anchor is not None:
+        return False
+    bullets = entry.bullets
+    marker = bullets[0].marker if bullets else DEFAULT_MARKER
+    position = entry.lines.index(bullets[0]) if bullets else len(entry.lines)
+    entry.lines.insert(position, Bullet("", marker, text))
+    return True
+
+
+def _remove(document: ResumeDocument, patch: ResumePatch, text: Optional[str]) -> bool:
+    found = _locate(document, text, patch)
+    if found is None:
+        return False
+    entry, bullet = found
+    entry.lines.remove(bullet)
+    return True
+
+
+def _set_applied(document: ResumeDocument, patch: ResumePatch, applied: bool) -> bool:
+    """Apply (or undo) ``patch`` on ``document`` by text; False when it cannot be
+    matched, leaving ``document`` as it was."""
+    if patch.op == REPLACE:
+        current, wanted = (patch.before, patch.text) if applied else (patch.text, patch.before)
+        found = _locate(document, current, patch)
+        if found is None:
+            return False
+        found[1].text = wanted
+        return True
+    if (patch.op == INSERT) == applied:
+        text = patch.text if patch.op == INSERT else patch.before
+        return _place_after(document, patch, text)
+    return _remove(document, patch, patch.text if patch.op == INSERT else patch.before)
+
+
+def decide(
+    text: str, patches: List[ResumePatch], decisions: Dict[str, str]
+) -> Tuple[str, List[str]]:
+    """``text`` (the run's current résumé) with ``decisions`` (patch id -> accepted or
+    rejected) applied; the ids of the patches that could not be matched.
+
+    A patch is applied unless rejected, so only a change of mind edits the text.
+    Statuses are updated on ``patches``; a conflicting patch keeps its old one.
+    """
+    document = parse_resume(text)
+    conflicts: List[str] = []
+    for patch in patches:
+        decision = decisions.get(patch.id)
+        if decision not in (ACCEPTED, REJECTED):
+            continue
+        was_applied, applied = patch.status != REJECTED, decision == ACCEPTED
+        if was_applied != applied and not _set_applied(document, patch, applied):
+            conflicts.append(patch.id)
+            continue
+        patch.status = decision
+    return document.render(), conflicts
+
+
+def patch_counts(patches: List[ResumePatch]) -> Dict[str, int]:
+    return {
+        status: sum(1 for patch in patches if patch.status == status)
+        for status in (PROPOSED, ACCEPTED, REJECTED)
+    }
+
+
+def load_patches(run_dir: Path) -> Optional[List[ResumePatch]]:
+    """The run's patches, or None if it was not tailored with ``--patches``."""
+    path = Path(run_dir) / RESUME_PATCHES_FILE
+    if not path.is_file():
+        return None
+    data = json.loads(read_text(path))
+    return [ResumePatch.from_dict(item) for item in data.get("patches") or []]
+
+
+def save_patches(run_dir: Path, patches: List[ResumePatch]) -> Path:
+    path = Path(run_dir) / RESUME_PATCHES_FILE
+    data = {
+        "updated_at": datetime.now().isoformat(timespec="seconds"),
+        "counts": patch_counts(patches),
+        "patches": [patch.to_dict() for patch in patches],
+    }
+    write_text(path, json.dumps(data, indent=2, ensure_ascii=False))
+    return path
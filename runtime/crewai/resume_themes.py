@@ -0,0 +1,506 @@
+"""Résumé themes: render the final résumé as polished Markdown, LaTeX and PDF.
+
+``resume.md`` is whatever Markdown the agents wrote. A theme turns it into a
+typeset document: ``--theme modern`` also writes ``resume.themed.md``,
+``resume.tex`` and, when a LaTeX engine is installed, ``resume.pdf``.
+
+A theme is a directory holding ``theme.yaml``:
+
+    description: One column, serif type, ruled section headings
+    extends: classic        # optional: take every template not defined here from it
+    markdown:               # one set of templates per output format
+      document: "# $name\\n\\n$headline\\n\\n$contact\\n\\n$body\\n"
+      section: "## $title\\n\\n$body"
+      ...
+    latex:
+      ...
+
+The templates use ``string.Template`` placeholders (``$$`` for a literal dollar):
+
+    document    $name $headline $contact $body $lang $babel $photo
+    headline    $text        (rendered only when the résumé has a headline)
+    contact     $items       (joined with contact_separator)
+    section     $title $body
+    entry       $heading $details $body    (a ``###`` heading and what follows it)
+    details     $text        (the dates/location line under a heading, when present)
+    list        $items       (items joined by newlines)
+    item        $text
+    paragraph   $text
+    photo       $path        (optional; the photo's file name, see below)
+
+The résumé is parsed into a name (``#``), a headline and contact line, and ``##``
+sections of entries holding bullets and paragraphs; text is escaped for LaTeX and
+``**bold**``, ``*italic*``, ``[links](...)`` and ``code`` are carried over.
+With an output language (``--lang``, see localization) the dates on a details line
+are written that language's way, and ``$lang``/``$babel`` name it (``en``/``english``
+otherwise). A photo (a CV format that shows one, see cv_formats) is copied next to
+the output as ``photo.<ext>`` and rendered through the ``photo`` template into
+``$photo``; the default templates are a Markdown image and ``\\includegraphics``, so
+a LaTeX document using ``$photo`` loads ``graphicx``.
+
+The built-in gallery lives in ``themes/`` (classic, modern, compact). Directories
+given with ``--theme-dir``, listed in ``$HYDRA_THEME_PATH`` or found at
+``$HYDRA_HOME/themes`` are searched first: a theme there with a built-in's name
+replaces it, and with ``extends`` it can change a single template of one. PDFs are
+built with the first of tectonic, latexmk or pdflatex on PATH; without one the
+``.tex`` is still written and the PDF is reported as skipped.
+"""
+
+from __future__ import annotations
+
+import os
+import re
+import shutil
+import subprocess
+import tempfile
+from dataclasses import dataclass, field
+from pathlib import Path
+from string import Template
+from typing import Callable, Dict, Iterable, List, Optional, Sequence
+
+import yaml
+
+from runtime.crewai.localization import DEFAULT_LANGUAGE, LANGUAGES, Language, localize_dates
+from runtime.crewai.stage_cache import hydra_home
+
+BUILTIN_THEMES_DIR = Path(__file__).resolve().parents[2] / "themes"
+THEME_PATH_ENV = "HYDRA_THEME_PATH"
+THEME_FILE = "theme.yaml"
+DEFAULT_THEME = "classic"
+
+THEMED_MARKDOWN_FILE = "resume.themed.md"
+LATEX_FILE = "resume.tex"
+PDF_FILE = "resume.pdf"
+
+FORMATS = ("markdown", "latex")
+TEMPLATE_KEYS = (
+    "document",
+    "headline",
+    "contact",
+    "contact_separator",
+    "section",
+    "entry",
+    "details",
+    "list",
+    "item",
+    "paragraph",
+)
+# Templates a theme may leave out; these defaults are used then.
+OPTIONAL_TEMPLATES = {
+    "markdown": {"photo": "![Photo]($path)"},
+    "latex": {"photo": "\\includegraphics[width=3cm]{$path}"},
+}
+PHOTO_STEM = "photo"
+
+# Tried in order; each compiles the .tex file (named here as resume.tex) in its own directory.
+PDF_ENGINES = (
+    ("tectonic", ["tectonic", LATEX_FILE]),
+    ("latexmk", ["latexmk", "-pdf", "-interaction=nonstopmode", "-halt-on-error", LATEX_FILE]),
+    ("pdflatex", ["pdflatex", "-interaction=nonstopmode", "-halt-on-error", LATEX_FILE]),
+)
+PDF_TIMEOUT_SECONDS = 120
+
+
+class ThemeError(ValueError):
+    """Raised for an unknown or malformed theme, or a PDF that would not build."""
+
+    pass
+
+
+@dataclass
+class Theme:
+    name: str
+    description: str
+    path: Path
+    templates: Dict[str, Dict[str, str]]
+
+
+@dataclass
+class Entry:
+    """A ``###`` entry (or the untitled text at the top of a section)."""
+
+    heading: Optional[str] = None
+    details: Optional[str] = None
+    # ("item", text) and ("paragraph", [lines]), in résumé order.
+    content: List[tuple] = field(default_factory=list)
+
+
+@dataclass
+class Section:
+    title: str
+    entries: List[Entry] = field(default_factory=list)
+
+
+@dataclass
+class ParsedResume:
+    name: str = ""
+    headline: Optional[str] = None
+    contact: List[str] = field(default_factory=list)
+    sections: List[Section] = field(default_factory=list)
+
+
+@dataclass
+class ThemeOutput:
+    theme: str
+    files: List[str]
+    pdf_error: Optional[str] = None
+
+
+
+
+def theme_dirs(extra: Sequence[Path] = ()) -> List[Path]:
+    """Directories searched for themes, highest precedence first."""
+    dirs = [Path(d).expanduser() for d in extra]
+    env = os.environ.get(THEME_PATH_ENV, "")
+    dirs += [Path(d).expanduser() for d in env.split(os.pathsep) if d]
+    dirs += [hydra_home() / "themes", BUILTIN_THEMES_DIR]
+    return dirs
+
+
+def available_themes(dirs: Sequence[Path]) -> Dict[str, Path]:
+    """Theme name -> directory, the first directory defining a name winning."""
+    found: Dict[str, Path] = {}
+    for directory in dirs:
+        if not directory.is_dir():
+            continue
+        for theme_file in sorted(directory.glob(f"*/{THEME_FILE}")):
+            found.setdefault(theme_file.parent.name, theme_file.parent)
+    return dict(sorted(found.items()))
+
+
+def _find(name: str, dirs: Sequence[Path], exclude: Optional[Path] = None) -> Optional[Path]:
+    for directory in dirs:
+        candidate = directory / name
+        if (candidate / THEME_FILE).is_file() and candidate != exclude:
+            return candidate
+    return None
+
+
+def _read(path: Path) -> dict:
+    try:
+        raw = yaml.safe_load((path / THEME_FILE).read_text(encoding="utf-8")) or {}
+    except yaml.YAMLError as e:
+        raise ThemeError(f"{path / THEME_FILE}: not valid YAML: {e}") from e
+    if not isinstance(raw, dict):
+        raise ThemeError(f"{path / THEME_FILE}: a theme is a mapping")
+    for fmt in FORMATS:
+        if not isinstance(raw.get(fmt, {}), dict):
+            raise ThemeError(f"{path / THEME_FILE}: '{fmt}' must be a mapping of templates")
+    return raw
+
+
+def _load(path: Path, dirs: Sequence[Path], seen: tuple) -> tuple:
+    raw = _read(path)
+    templates: Dict[str, Dict[str, str]] = {fmt: {} for fmt in FORMATS}
+    parent_name = raw.get("extends")
+    if parent_name:
+        if path in seen:
+            raise ThemeError(f"{path / THEME_FILE}: 'extends' loops back to itself")
+        # A user theme may extend the built-in it shadows, so skip its own directory.
+        parent = _find(parent_name, dirs, exclude=path)
+        if parent is None:
+            raise ThemeError(f"{path / THEME_FILE}: extends unknown theme '{parent_name}'")
+        templates, _ = _load(parent, dirs, (*seen, path))
+    for fmt in FORMATS:
+        templates[fmt].update({k: str(v) for k, v in (raw.get(fmt) or {}).items()})
+    return templates, raw.get("description", "")
+
+
+def load_theme(name: str, dirs: Sequence[Path]) -> Theme:
+    """The theme called ``name`` in ``dirs``, with ``extends`` resolved."""
+    path = _find(name, dirs)
+    if path is None:
+        known = ", ".join(available_themes(dirs)) or "none"
+        raise ThemeError(f"Unknown theme '{name}' (available: {known})")
+    templates, description = _load(path, dirs, ())
+    for fmt in FORMATS:
+        missing = [key for key in TEMPLATE_KEYS if key not in templates[fmt]]
+        if missing:
+            raise ThemeError(f"Theme '{name}' has no {fmt} template for: {', '.join(missing)}")
+        for key, default in OPTIONAL_TEMPLATES[fmt].items():
+            templates[fmt].setdefault(key, default)
+    return Theme(name=name, description=description, path=path, templates=templates)
+
+
+
+_BULLET = re.compile(r"^\s*[-*•]\s+(.*)$")
+_WRAPPED = re.compile(r"^(\*\*|\*|_)(.+)\1$")
+_YEAR = re.compile(r"\b(19|20)\d{2}\b|\bPresent\b", re.IGNORECASE)
+_CONTACT_SPLIT = re.compile(r"\s+[|·•]\s+")
+
+
+def _unwrap(text: str) -> str:
+    match = _WRAPPED.match(text.strip())
+    return match.group(2).strip() if match else text.strip()
+
+
+def _is_contact(line: str) -> bool:
+    return bool(_CONTACT_SPLIT.search(line)) or "@" in line or "://" in line
+
+
+def _is_details(line: str) -> bool:
+    """A dates/location line right under an entry heading."""
+    return bool(_WRAPPED.match(line)) or (len(line) <= 120 and bool(_YEAR.search(line)))
+
+
+def parse_resume(markdown: str) -> ParsedResume:
+    """Name, headline, contact line and sections of a Markdown résumé."""
+    resume = ParsedResume()
+    section: Optional[Section] = None
+    entry: Optional[Entry] = None
+    after_heading = False
+    paragraph: List[str] = []
+
+    def flush() -> None:
+        if paragraph and entry is not None:
+            entry.content.append(("paragraph", list(paragraph)))
+        paragraph.clear()
+
+    for raw in markdown.splitlines():
+        line = raw.strip()
+        if line.startswith("# ") and not resume.name:
+            resume.name = line[2:].strip()
+            continue
+        if line.startswith("## "):
+            flush()
+            section = Section(title=line[3:].strip())
+            resume.sections.append(section)
+            entry, after_heading = None, False
+            continue
+        if section is None:
+            # The header block: a headline, then the contact line(s).
+            if not line or set(line) <= {"-", "*", "_"}:
+                continue
+            if _is_contact(line):
+                resume.contact += [_unwrap(p) for p in _CONTACT_SPLIT.split(line) if p.strip()]
+            elif resume.headline is None:
+                resume.headline = _unwrap(line)
+            continue
+        if line.startswith("### "):
+            flush()
+            entry = Entry(heading=line[4:].strip())
+            section.entries.append(entry)
+            after_heading = True
+            continue
+        if not line or set(line) <= {"-", "*", "_"}:
+            flush()
+            continue
+        if entry is None:
+            entry = Entry()
+            section.entries.append(entry)
+        bullet = _BULLET.match(line)
+        if after_heading and not bullet and entry.details is None and _is_details(line):
+            entry.details = _unwrap(line)
+        elif bullet:
+            flush()
+            entry.content.append(("item", bullet.group(1).strip()))
+        else:
+            paragraph.append(line)
+        after_heading = False
+    flush()
+    if not resume.name:
+        raise ThemeError("The résumé has no '# Name' heading to render")
+    return resume
+
+
+
+_INLINE = re.compile(r"\[([^\]]+)\]\(([^)\s]+)\)|\*\*(.+?)\*\*|\*(.+?)\*|`([^`]+)`")
+_LATEX_SPECIAL = {
+    "\\": r"\textbackslash{}",
+    "&": r"\&",
+    "%": r"\%",
+    "$": r"\$",
+    "#": r"\#",
+    "_": r"\_",
+    "{": r"\{",
+    "}": r"\}",
+    "~": r"\textasciitilde{}",
+    "^": r"\textasciicircum{}",
+}
+
+
+def latex_escape(text: str) -> str:
+    return "".join(_LATEX_SPECIAL.get(char, char) for char in text)
+
+
+def latex_inline(text: str) -> str:
+    """Markdown inline text as LaTeX: escaped, with emphasis, code and links kept."""
+    out, position = [], 0
+    for match in _INLINE.finditer(text):
+        out.append(latex_escape(text[position : match.start()]))
+        label, url, bold, italic, code = match.groups()
+        if label is not None:
+            target = url.replace("\\", "/").replace("%", r"\%").replace("#", r"\#")
+            out.append(rf"\href{{{target}}}{{{latex_inline(label)}}}")
+        elif bold is not None:
+            out.append(rf"\textbf{{{latex_inline(bold)}}}")
+        elif italic is not None:
+            out.append(rf"\emph{{{latex_inline(italic)}}}")
+        else:
+            out.append(rf"\texttt{{{latex_escape(code)}}}")
+        position = match.end()
+    out.append(latex_escape(text[position:]))
+    return "".join(out)
+
+
+_INLINE_FORMATTERS: Dict[str, Callable[[str], str]] = {
+    "markdown": lambda text: text,
+    "latex": latex_inline,
+}
+# Résumé paragraphs keep their line breaks (a skills block is one line per group).
+_LINE_BREAKS = {"markdown": "  \n", "latex": "\\\\\n"}
+
+
+def _fill(theme: Theme, fmt: str, key: str, **values: str) -> str:
+    try:
+        return Template(theme.templates[fmt][key]).substitute(values)
+    except KeyError as e:
+        placeholder = e.args[0]
+        raise ThemeError(
+            f"Theme '{theme.name}': {fmt}.{key} has unknown placeholder ${placeholder}"
+        ) from e
+    except ValueError as e:
+        raise ThemeError(
+            f"Theme '{theme.name}': {fmt}.{key}: {e} (write $$ for a dollar)"
+        ) from e
+
+
+def _content(theme: Theme, fmt: str, content: Iterable[tuple]) -> str:
+    inline = _INLINE_FORMATTERS[fmt]
+    blocks: List[str] = []
+    items: List[str] = []
+    for kind, text in [*content, ("end", "")]:
+        if kind == "item":
+            items.append(_fill(theme, fmt, "item", text=inline(text)))
+            continue
+        if items:
+            blocks.append(_fill(theme, fmt, "list", items="\n".join(items)))
+            items = []
+        if kind == "paragraph":
+            lines = _LINE_BREAKS[fmt].join(inline(line) for line in text)
+            blocks.append(_fill(theme, fmt, "paragraph", text=lines))
+    return "\n\n".join(blocks)
+
+
+def _entry(theme: Theme, fmt: str, entry: Entry, language: Optional[Language]) -> str:
+    body = _content(theme, fmt, entry.content)
+    if entry.heading is None:
+        return body
+    inline = _INLINE_FORMATTERS[fmt]
+    details = ""
+    if entry.details:
+        text = localize_dates(entry.details, language) if language else entry.details
+        details = _fill(theme, fmt, "details", text=inline(text))
+    return _fill(theme, fmt, "entry", heading=inline(entry.heading), details=details, body=body)
+
+
+def render(
+    resume: ParsedResume,
+    theme: Theme,
+    fmt: str,
+    language: Optional[Language] = None,
+    photo: Optional[str] = None,
+) -> str:
+    """``resume`` in output format ``fmt`` ("markdown" or "latex") with ``theme``;
+    ``photo`` is the file name of a photo next to the output."""
+    inline = _INLINE_FORMATTERS[fmt]
+    sections = [
+        _fill(
+            theme,
+            fmt,
+            "section",
+            title=inline(section.title),
+            body="\n\n".join(_entry(theme, fmt, entry, language) for entry in section.entries),
+        )
+        for section in resume.sections
+    ]
+    headline = (
+        _fill(theme, fmt, "headline", text=inline(resume.headline)) if resume.headline else ""
+    )
+    separator = theme.templates[fmt]["contact_separator"]
+    contact = (
+        _fill(theme, fmt, "contact", items=separator.join(inline(c) for c in resume.contact))
+        if resume.contact
+        else ""
+    )
+    text = _fill(
+        theme,
+        fmt,
+        "document",
+        name=inline(resume.name),
+        headline=headline,
+        contact=contact,
+        body="\n\n".join(sections),
+        lang=(language or LANGUAGES[DEFAULT_LANGUAGE]).code,
+        babel=(language or LANGUAGES[DEFAULT_LANGUAGE]).babel,
+        photo=_fill(theme, fmt, "photo", path=photo) if photo else "",
+    )
+    # Templates are written loosely; absent parts leave blank runs and dangling spaces.
+    lines = text.split("\n")
+    for i, line in enumerate(lines):
+        if i + 1 == len(lines) or not lines[i + 1].strip():
+            lines[i] = line.rstrip()
+    return re.sub(r"\n{3,}", "\n\n", "\n".join(lines)).strip() + "\n"
+
+
+def compile_pdf(tex_path: Path, assets: Sequence[str] = ()) -> Path:
+    """Build ``tex_path`` into a PDF next to it with the first LaTeX engine on PATH;
+    ``assets`` are files next to it the document includes (a photo)."""
+    for engine, command in PDF_ENGINES:
+        if shutil.which(engine) is None:
+            continue
+        with tempfile.TemporaryDirectory(prefix="hydra-latex-") as work:
+            for name in (tex_path.name, *assets):
+                shutil.copy(tex_path.parent / name, Path(work) / name)
+            command = [tex_path.name if arg == LATEX_FILE else arg for arg in command]
+            try:
+                completed = subprocess.run(
+                    command,
+                    cwd=work,
+                    capture_output=True,
+                    text=True,
+                    timeout=PDF_TIMEOUT_SECONDS,
+                )
+            except subprocess.TimeoutExpired:
+                raise ThemeError(f"{engine} timed out after {PDF_TIMEOUT_SECONDS}s") from None
+            built = Path(work) / tex_path.with_suffix(".pdf").name
+            if completed.returncode != 0 or not built.is_file():
+                tail = (completed.stdout + completed.stderr).strip().splitlines()[-5:]
+                raise ThemeError(f"{engine} failed: " + " / ".join(tail))
+            pdf_path = tex_path.with_suffix(".pdf")
+            shutil.copy(built, pdf_path)
+            return pdf_path
+    engines = ", ".join(engine for engine, _ in PDF_ENGINES)
+    raise ThemeError(f"no LaTeX engine on PATH ({engines}); {tex_path.name} was written")
+
+
+def write_theme(
+    out_dir: Path,
+    resume_markdown: str,
+    theme: Theme,
+    pdf: bool = True,
+    language: Optional[Language] = None,
+    photo: Optional[Path] = None,
+) -> ThemeOutput:
+    """Write the themed Markdown, LaTeX and (when it builds) PDF into ``out_dir``."""
+    out_dir = Path(out_dir)
+    parsed = parse_resume(resume_markdown)
+    assets: List[str] = []
+    if photo is not None:
+        name = PHOTO_STEM + Path(photo).suffix.lower()
+        if Path(photo).resolve() != (out_dir / name).resolve():
+            shutil.copy(photo, out_dir / name)
+        assets.append(name)
+    photo_name = assets[0] if assets else None
+    markdown = render(parsed, theme, "markdown", language, photo_name)
+    latex = render(parsed, theme, "latex", language, photo_name)
+    (out_dir / THEMED_MARKDOWN_FILE).write_text(markdown, encoding="utf-8")
+    (out_dir / LATEX_FILE).write_text(latex, encoding="utf-8")
+    output = ThemeOutput(theme=theme.name, files=[THEMED_MARKDOWN_FILE, LATEX_FILE, *assets])
+    if pdf:
+        try:
+            compile_pdf(out_dir / LATEX_FILE, assets)
+            output.files.append(PDF_FILE)
+        except ThemeError as e:
+            output.pdf_error = str(e)
+    return output
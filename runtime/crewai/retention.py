@@ -0,0 +1,135 @@
+"""Stage-output retention: what the workflow keeps verbatim and what it summarizes.
+
+Every stage output is kept in the workflow state (``intermediate_results``), which the
+web backend persists per job and resumes from, and which lands in the run directory.
+Some outputs are bulky and only needed while the run is in flight — research carries
+the snippet of every page the search returned, the executive brief is already in the
+result on its own. A ``RetentionPolicy`` names the stages whose stored output is
+summarized instead: the stage still hands its full output to the stages after it in
+the same run, but state keeps only the summary, with counts of what was discarded.
+
+The inputs the audit and claim verification re-read, and that a paused run resumes
+from (``AUDIT_INPUTS``), are always kept verbatim; a policy that tries to summarize
+one is rejected.
+
+Policies are written as a comma-separated list of stages (``research``), ``lean``
+(every stage that can be summarized) or ``verbatim`` (the default: keep everything).
+The CLI takes one with ``--retention``; the web backend reads ``HYDRA_RETENTION``.
+"""
+
+from __future__ import annotations
+
+import os
+from dataclasses import dataclass, field
+from typing import Any, Callable, Dict, FrozenSet, Optional
+
+from runtime.crewai.contracts import RESEARCH_CATEGORIES
+
+VERBATIM = "verbatim"
+LEAN = "lean"
+RETENTION_ENV = "HYDRA_RETENTION"
+
+# Stage outputs the audit, claim verification or a resumed run read back in full.
+AUDIT_INPUTS = frozenset(
+    {"gap_analysis", "interrogation", "differentiation", "tailoring", "ats_optimization"}
+)
+
+
+def _summarize_research(result: Dict[str, Any]) -> Dict[str, Any]:
+    """Keep the findings and the sources they cite; drop snippets and uncited pages."""
+    cited = {
+        source_id
+        for category in RESEARCH_CATEGORIES
+        for finding in result.get(category) or []
+        for source_id in finding.get("citations") or []
+    }
+    sources = result.get("sources") or []
+    kept = [
+        {key: value for key, value in source.items() if key != "snippet"}
+        for source in sources
+        if source.get("id") in cited
+    ]
+    summary = {key: value for key, value in result.items() if key != "sources"}
+    summary["sources"] = kept
+    summary["retained"] = {
+        "summarized": True,
+        "discarded": {
+            "sources": len(sources) - len(kept),
+            "snippets": sum(1 for source in sources if source.get("snippet")),
+        },
+    }
+    return summary
+
+
+def _summarize_executive_synthesis(result: Dict[str, Any]) -> Dict[str, Any]:
+    """Keep the decision; the full brief is returned as the run's executive brief."""
+    summary = {
+        key: result[key]
+        for key in ("agent", "timestamp", "confidence", "decision")
+        if key in result
+    }
+    summary["retained"] = {
+        "summarized": True,
+        "discarded": {"fields": len([key for key in result if key not in summary])},
+    }
+    return summary
+
+
+SUMMARIZERS: Dict[str, Callable[[Dict[str, Any]], Dict[str, Any]]] = {
+    "research": _summarize_research,
+    "executive_synthesis": _summarize_executive_synthesis,
+}
+
+
+@dataclass(frozen=True)
+class RetentionPolicy:
+    """The stages whose stored output is summarized; all others are kept verbatim."""
+
+    summarize: FrozenSet[str] = field(default_factory=frozenset)
+
+    def __post_init__(self):
+        protected = sorted(self.summarize & AUDIT_INPUTS)
+        if protected:
+            raise ValueError(
+                f"Always kept verbatim (the audit reads them): {', '.join(protected)}"
+            )
+        unknown = sorted(self.summarize - set(SUMMARIZERS))
+        if unknown:
+            raise ValueError(
+                f"No summary for stage(s): {', '.join(unknown)} "
+                f"(can summarize: {', '.join(sorted(SUMMARIZERS))})"
+            )
+
+    @classmethod
+    def parse(cls, spec: Optional[str]) -> "RetentionPolicy":
+        """``"verbatim"``, ``"lean"`` or a comma-separated list of stages."""
+        spec = (spec or "").strip().lower()
+        if spec in ("", VERBATIM):
+            return cls()
+        if spec == LEAN:
+            return cls(frozenset(SUMMARIZERS))
+        return cls(frozenset(stage.strip() for stage in spec.split(",") if stage.strip()))
+
+    def retain(self, stage: str, result: Any) -> Any:
+        """What state keeps of ``stage``'s ``result``; the original is left untouched."""
+        if stage not in self.summarize or not isinstance(result, dict):
+            return result
+        return SUMMARIZERS[stage](result)
+
+    def describe(self, intermediate_results: Dict[str, Any]) -> Optional[Dict[str, Any]]:
+        """Manifest summary: the policy and what each summarized stage discarded."""
+        if not self.summarize:
+            return None
+        return {
+            "summarize": sorted(self.summarize),
+            "discarded": {
+                stage: result["retained"]["discarded"]
+                for stage, result in intermediate_results.items()
+                if isinstance(result, dict) and isinstance(result.get("retained"), dict)
+            },
+        }
+
+
+def policy_from_env() -> RetentionPolicy:
+    """The policy named by ``HYDRA_RETENTION`` (verbatim when unset)."""
+    return RetentionPolicy.parse(os.environ.get(RETENTION_ENV))
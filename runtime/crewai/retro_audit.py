@@ -0,0 +1,227 @@
+"""Retroactive audit: re-run today's auditor over résumés from past runs.
+
+The audit prompt gets stricter over time. A résumé that passed last year's audit may
+carry a claim today's rules would block — and it may already be in a recruiter's
+inbox. ``audit-all --since 2024-01`` walks the run-scoped output directories, re-audits
+each stored ``resume.md`` against the sources recorded in its ``run.json``, and reports
+which runs the current rules flag, so the user can assess historical exposure.
+
+Nothing in a past run is modified. The re-audit needs the original job description
+and sources; the manifest records their *paths* (never their content), so runs whose
+inputs have since moved are reported as skipped unless ``--sources`` supplies them.
+"""
+
+from __future__ import annotations
+
+import json
+import re
+from dataclasses import asdict, dataclass, field
+from datetime import date
+from pathlib import Path
+from typing import Any, Callable, Dict, Iterator, List, Optional
+
+from runtime.crewai.artifacts import MANIFEST_FILE, RESUME_FILE
+from runtime.crewai.contracts import AuditVerdict
+from runtime.crewai.encryption import read_text, write_text
+
+REPORT_FILE = "retro_audit.json"
+
+CLEAN = "clean"
+FLAGGED = "flagged"
+SKIPPED = "skipped"
+ERROR = "error"
+
+_SINCE_RE = re.compile(r"^(\d{4})(?:-(\d{2}))?(?:-(\d{2}))?$")
+# The stamp in a run id, after any company/role prefix (see artifacts.generate_run_id).
+_RUN_STAMP_RE = re.compile(r"(?:^|-)((\d{4})(\d{2})(\d{2})-\d{6})(?:-|$)")
+
+
+def parse_since(value: str) -> date:
+    """``2024``, ``2024-01`` or ``2024-01-15`` -> the first day it denotes."""
+    match = _SINCE_RE.match(value.strip())
+    if not match:
+        raise ValueError(f"Expected YYYY, YYYY-MM or YYYY-MM-DD, got: {value!r}")
+    year, month, day = match.groups()
+    return date(int(year), int(month or 1), int(day or 1))
+
+
+def run_date(run_id: str) -> Optional[date]:
+    """Date encoded in a ``[<company>-<role>-]YYYYmmdd-HHMMSS-<hex>`` run id, or None."""
+    match = _RUN_STAMP_RE.search(run_id)
+    if not match:
+        return None
+    try:
+        return date(*(int(part) for part in match.groups()[1:]))
+    except ValueError:
+        return None
+
+
+def _run_order(run_dir: Path) -> tuple:
+    """Sort key: when the run started, whatever its company/role prefix."""
+    match = _RUN_STAMP_RE.search(run_dir.name)
+    return (match.group(1) if match else "", run_dir.name)
+
+
+def find_runs(out_dir: Path, since: date) -> Iterator[Path]:
+    """Run directories under ``out_dir`` dated on/after ``since``, oldest first."""
+    if not out_dir.is_dir():
+        return
+    for run_dir in sorted(out_dir.iterdir(), key=_run_order):
+        started = run_date(run_dir.name)
+        if run_dir.is_dir() and started is not None and started >= since:
+            if (run_dir / MANIFEST_FILE).is_file():
+                yield run_dir
+
+
+@dataclass
+class RetroAuditEntry:
+    """Outcome of re-auditing one past run."""
+
+    run_id: str
+    outcome: str
+    original_status: Optional[str] = None
+    reason: str = ""
+    blocking: List[str] = field(default_factory=list)
+
+    @property
+    def newly_flagged(self) -> bool:
+        """Passed when it was produced, flagged under today's rules."""
+        return self.outcome == FLAGGED and self.original_status == "APPROVED"
+
+
+@dataclass
+class RetroAuditReport:
+    """All entries of one ``audit-all`` sweep."""
+
+    since: str
+    entries: List[RetroAuditEntry] = field(default_factory=list)
+
+    @property
+    def flagged(self) -> List[RetroAuditEntry]:
+        return [entry for entry in self.entries if entry.outcome == FLAGGED]
+
+    def to_dict(self) -> Dict[str, Any]:
+        counts = {
+            outcome: sum(1 for e in self.entries if e.outcome == outcome)
+            for outcome in (CLEAN, FLAGGED, SKIPPED, ERROR)
+        }
+        return {
+            "since": self.since,
+            "counts": counts,
+            "newly_flagged": [e.run_id for e in self.entries if e.newly_flagged],
+            "runs": [asdict(entry) for entry in self.entries],
+        }
+
+
+def blocking_issues(audit: Any) -> List[str]:
+    """Blocking findings from an auditor response, as short one-line descriptions.
+
+    Reads ``action_required.blocking`` plus any issue marked ``severity: blocking``
+    in the per-component audits; tolerant of the nested and flat shapes the
+    auditor produces (see ``AuditVerdict.from_raw``).
+    """
+    if not isinstance(audit, dict):
+        return []
+    report = audit.get("audit_report") if isinstance(audit.get("audit_report"), dict) else audit
+    found: List[str] = []
+
+    action = report.get("action_required")
+    if isinstance(action, dict) and isinstance(action.get("blocking"), list):
+        found.extend(str(item) for item in action["blocking"] if item)
+
+    for component in report.values():
+        if not isinstance(component, dict):
+            continue
+        for key in ("issues", "violations"):
+            for issue in component.get(key) or []:
+                severity = issue.get("severity", "") if isinstance(issue, dict) else ""
+                if str(severity).lower() == "blocking":
+                    label = issue.get("id") or issue.get("location") or "issue"
+                    detail = issue.get("claim") or issue.get("pattern") or issue.get("fix") or ""
+                    text = f"{label}: {detail}" if detail else str(label)
+                    if text not in found:
+                        found.append(text)
+    return found
+
+
+def _read_path(value: Optional[str], read: Callable[[Path], str]) -> Optional[str]:
+    if not value:
+        return None
+    path = Path(value)
+    try:
+        return read(path)
+    except (FileNotFoundError, ValueError, OSError):
+        return None
+
+
+def audit_run(
+    run_dir: Path,
+    auditor: Any,
+    read_file: Callable[[Path], str],
+    read_sources: Callable[[Path], str],
+    sources_override: Optional[str] = None,
+) -> RetroAuditEntry:
+    """Re-audit the stored résumé of one run with ``auditor`` (an ``AuditorSuiteAgent``)."""
+    manifest = json.loads((run_dir / MANIFEST_FILE).read_text())
+    run_id = manifest.get("run_id") or run_dir.name
+    original = (manifest.get("audit") or {}).get("final_status")
+    entry = RetroAuditEntry(run_id=run_id, outcome=SKIPPED, original_status=original)
+
+    resume_path = run_dir / RESUME_FILE
+    if not resume_path.is_file():
+        entry.reason = "no stored résumé"
+        return entry
+
+    inputs = manifest.get("inputs") or {}
+    sources = (
+        sources_override
+        if sources_override is not None
+        else _read_path(inputs.get("sources_path"), read_sources)
+    )
+    if sources is None:
+        # Without sources there is nothing to verify claims against; auditing anyway
+        # would flag every claim and drown the real findings.
+        entry.reason = "original sources not found (pass --sources)"
+        return entry
+    job_description = _read_path(inputs.get("jd_path"), read_file) or ""
+
+    try:
+        audit = auditor.execute(
+            {
+                "document": read_text(resume_path),
+                "document_type": "resume",
+                "job_description": job_description,
+                "source_documents": sources,
+            }
+        )
+    except Exception as err:  # one bad run must not abort the sweep
+        entry.outcome = ERROR
+        entry.reason = str(err).splitlines()[0][:200] if str(err) else type(err).__name__
+        return entry
+
+    verdict = AuditVerdict.from_raw(audit)
+    entry.blocking = blocking_issues(audit)
+    entry.outcome = CLEAN if verdict.approved and not entry.blocking else FLAGGED
+    entry.reason = verdict.reason
+    if not job_description:
+        entry.reason = (entry.reason + " (job description unavailable)").strip()
+    return entry
+
+
+def audit_all(
+    out_dir: Path,
+    since: str,
+    auditor: Any,
+    read_file: Callable[[Path], str],
+    read_sources: Callable[[Path], str],
+    sources_override: Optional[str] = None,
+) -> RetroAuditReport:
+    """Re-audit every run under ``out_dir`` since ``since``; write ``retro_audit.json``."""
+    report = RetroAuditReport(since=since)
+    for run_dir in find_runs(out_dir, parse_since(since)):
+        report.entries.append(
+            audit_run(run_dir, auditor, read_file, read_sources, sources_override)
+        )
+    if out_dir.is_dir():
+        write_text(out_dir / REPORT_FILE, json.dumps(report.to_dict(), indent=2))
+    return report
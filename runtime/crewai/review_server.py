@@ -0,0 +1,592 @@
+"""Phone-friendly review pages for the runs in an output directory (``hydra serve``).
+
+``hydra serve`` serves ``--out`` over HTTP with the standard library — plain HTML
+forms, no scripts, sized for a phone — so a run on the desktop can be followed and
+greenlit from elsewhere on the network:
+
+- ``/`` lists the runs, newest first: live, waiting for a greenlight, or finished;
+- ``/runs/<run_id>`` shows the gap analysis (fit score, matches, adjacent experience,
+  gaps), the greenlight buttons while the run waits for them, the tailored résumé
+  with its changes highlighted (from ``resume.diff``) and every file to download,
+  PDFs first. A live run's page reloads itself until it needs an answer.
+- A run tailored with ``--patches`` also lists its résumé patches, each with accept
+  and reject buttons; a decision edits ``resume.md`` right away (see resume_model).
+
+A CLI run started with ``--remote-greenlight`` asks here instead of in the terminal:
+at the gap-analysis gate it writes ``greenlight.json`` (pending, with the gap review)
+into its run directory and waits until the page answers it. The interview is held
+here too, one question per page through ``interview.json`` (see interview): answer,
+skip, go back or finish early. Its other prompts take their non-interactive
+defaults — the audit's variant pick, unverified claims kept blocking — so nothing
+waits on a terminal nobody is watching.
+
+Every request needs the server's token: open the URL ``hydra serve`` prints once
+(``?token=…``) and a cookie carries it from then on.
+"""
+
+from __future__ import annotations
+
+import hmac
+import json
+import mimetypes
+import time
+from datetime import datetime
+from html import escape
+from http import HTTPStatus
+from http.cookies import SimpleCookie
+from http.server import BaseHTTPRequestHandler, ThreadingHTTPServer
+from pathlib import Path
+from typing import Any, Dict, List, Optional, Tuple
+from urllib.parse import parse_qs, quote, unquote, urlsplit
+
+import yaml
+
+from runtime.crewai.artifacts import (
+    INTERMEDIATE_DIR,
+    MANIFEST_FILE,
+    RESUME_FILE,
+    write_artifact_index,
+)
+from runtime.crewai.audit_log import record_access
+from runtime.crewai.contracts import GapReview
+from runtime.crewai.encryption import read_bytes, read_text, write_text
+from runtime.crewai.fit_score import DECISION_MARKS
+from runtime.crewai.html_report import REPORT_HTML_FILE, render_report
+from runtime.crewai.hydra_workflow import UserInteraction
+from runtime.crewai.interview import (
+    INTERVIEW_FILE,
+    InterviewSession,
+    describe,
+    load_session,
+    normalize_questions,
+    save_session,
+)
+from runtime.crewai.resume_diff import (
+    RESUME_DIFF_FILE,
+    RESUME_DIFF_HTML_FILE,
+    html_diff,
+    inline_diff,
+    unified_diff,
+)
+from runtime.crewai.resume_model import (
+    ACCEPTED,
+    REJECTED,
+    ResumePatch,
+    decide,
+    load_patches,
+    patch_counts,
+    save_patches,
+)
+from runtime.crewai.run_control import CONTROL_FILE, LIVE_FILE, is_live, run_status
+from runtime.crewai.tailoring_variants import pick_by_audit
+
+GREENLIGHT_FILE = "greenlight.json"
+DEFAULT_HOST = "127.0.0.1"
+DEFAULT_PORT = 8765
+TOKEN_COOKIE = "hydra_token"
+SERVE_TOKEN_ENV = "HYDRA_SERVE_TOKEN"
+POLL_SECONDS = 1.0
+REFRESH_SECONDS = 10  # how often a live run's page reloads
+
+PENDING = "pending"
+APPROVED = "approved"
+DECLINED = "declined"
+
+# Files that are not the run's output.
+_HIDDEN = (LIVE_FILE, CONTROL_FILE, GREENLIGHT_FILE, INTERVIEW_FILE)
+# What the interview page's buttons do (see InterviewSession).
+INTERVIEW_ACTIONS = ("answer", "skip", "back", "finish")
+# Shown in the browser as text rather than downloaded.
+_TEXT_SUFFIXES = (".md", ".txt", ".yaml", ".json", ".diff", ".tex")
+
+Response = Tuple[int, Dict[str, str], bytes]
+
+
+def _read_json(path: Path) -> Optional[Dict[str, Any]]:
+    try:
+        return json.loads(read_text(path))
+    except (OSError, ValueError):
+        return None
+
+
+def greenlight_state(run_dir: Path) -> Optional[Dict[str, Any]]:
+    """The run's ``greenlight.json``, or None if it never asked for one here."""
+    return _read_json(Path(run_dir) / GREENLIGHT_FILE)
+
+
+def answer_greenlight(run_dir: Path, approve: bool) -> Dict[str, Any]:
+    """Approve or decline a pending greenlight; the updated state."""
+    state = greenlight_state(run_dir)
+    if state is None or state.get("status") != PENDING:
+        raise ValueError(f"Run {Path(run_dir).name} is not waiting for a greenlight")
+    state.update(status=APPROVED if approve else DECLINED, answered_at=datetime.now().isoformat())
+    write_text(Path(run_dir) / GREENLIGHT_FILE, json.dumps(state, indent=2))
+    return state
+
+
+def answer_interview(run_dir: Path, action: str, answer: str = "") -> InterviewSession:
+    """Answer, skip, go back in or finish the run's pending interview; the session."""
+    session = load_session(run_dir)
+    if session is None or session.done:
+        raise ValueError(f"Run {Path(run_dir).name} is not waiting for interview answers")
+    if action == "answer":
+        session.answer(answer)
+    else:
+        getattr(session, action)()
+    save_session(run_dir, session)
+    return session
+
+
+def decide_patches(run_dir: Path, decisions: Dict[str, str]) -> List[str]:
+    """Accept or reject résumé patches (patch id -> accepted/rejected) in the run's
+    ``resume.md``; the ids of those that no longer match it (see resume_model)."""
+    run_dir = Path(run_dir)
+    patches = load_patches(run_dir)
+    if patches is None:
+        raise ValueError(f"Run {run_dir.name} was not tailored with --patches")
+    unknown = set(decisions) - {patch.id for patch in patches}
+    if unknown:
+        raise ValueError(f"No such patch: {', '.join(sorted(unknown))}")
+    text, conflicts = decide(read_text(run_dir / RESUME_FILE), patches, decisions)
+    write_text(run_dir / RESUME_FILE, text)
+    save_patches(run_dir, patches)
+
+    manifest_path = run_dir / MANIFEST_FILE
+    manifest = _read_json(manifest_path)
+    if manifest is not None:
+        manifest["resume_patches"] = {**manifest.get("resume_patches", {}), **patch_counts(patches)}
+        baseline = Path((manifest.get("inputs") or {}).get("resume_path") or "")
+        if baseline.is_file():  # keep the diffs in step with the edited résumé
+            baseline_text = baseline.read_text(encoding="utf-8")
+            write_text(
+                run_dir / RESUME_DIFF_FILE,
+                unified_diff(baseline_text, text, fromfile="baseline", tofile=RESUME_FILE),
+            )
+            write_text(run_dir / RESUME_DIFF_HTML_FILE, html_diff(baseline_text, text))
+        manifest_path.write_text(json.dumps(manifest, indent=2, default=str))
+        (run_dir / REPORT_HTML_FILE).write_text(render_report(manifest))
+        write_artifact_index(run_dir)
+    return conflicts
+
+
+class RemoteGreenlight(UserInteraction):
+    """The workflow's prompts for a run greenlit from ``hydra serve`` (see the module doc)."""
+
+    def __init__(self, run_dir: Path, workflow: Any = None, poll_seconds: float = POLL_SECONDS):
+        self.run_dir = Path(run_dir)
+        self.workflow = workflow
+        self.poll_seconds = poll_seconds
+
+    def greenlight_gap_analysis(self, result: Dict[str, Any]) -> bool:
+        self.run_dir.mkdir(parents=True, exist_ok=True)
+        state = {
+            "status": PENDING,
+            "asked_at": datetime.now().isoformat(),
+            "gap_review": GapReview.from_raw(result).model_dump(),
+        }
+        write_text(self.run_dir / GREENLIGHT_FILE, json.dumps(state, indent=2))
+        print(f"\n📱 Waiting for the greenlight for {self.run_dir.name} in hydra serve")
+        token = getattr(self.workflow, "cancel_token", None)
+        while True:
+            status = (greenlight_state(self.run_dir) or {}).get("status")
+            if status in (APPROVED, DECLINED):
+                print(f"   Greenlight {status}")
+                return status == APPROVED
+            if token is not None and token.stopping:
+                # Paused or cancelled meanwhile: the stage boundary ends the run.
+                return True
+            time.sleep(self.poll_seconds)
+
+    def ask_yes_no(self, question: str) -> bool:
+        return False
+
+    def conduct_interview(self, questions: List[Dict[str, Any]]) -> List[Dict[str, Any]]:
+        session = InterviewSession(normalize_questions(questions))
+        save_session(self.run_dir, session)
+        print(f"\n📱 Waiting for interview answers for {self.run_dir.name} in hydra serve")
+        token = getattr(self.workflow, "cancel_token", None)
+        while True:
+            session = load_session(self.run_dir) or session
+            if session.done or (token is not None and token.stopping):
+                notes = session.notes()
+                print(f"   Interview: {len(notes)} of {len(session.questions)} answered")
+                return notes
+            time.sleep(self.poll_seconds)
+
+    def pick_variant(self, candidates: List[Any]) -> Any:
+        return pick_by_audit(candidates)
+
+    def pick_outreach(self, kind: str, options: List[Dict[str, Any]]) -> Optional[int]:
+        return None  # picked afterwards with `hydra outreach-pick`
+
+
+# Pages
+
+_STYLE = """
+body { font: 16px/1.45 system-ui, sans-serif; margin: 0 auto; padding: 1rem;
+  max-width: 760px; color: #222; }
+h1 { font-size: 1.25rem; } h2 { font-size: 1.05rem; margin-top: 1.5rem; }
+a { color: #2357a5; } .meta { color: #666; font-size: 14px; }
+ul.runs { list-style: none; padding: 0; } ul.runs li { padding: .6rem 0;
+  border-bottom: 1px solid #eee; }
+.badge { display: inline-block; padding: 0 .45rem; border-radius: 4px; font-size: 13px;
+  background: #eee; } .badge.waiting { background: #ffe08a; } .badge.live { background: #cde; }
+.gaps td { vertical-align: top; padding: .2rem .5rem .2rem 0; }
+.fit { background: #f4f4f4; border-radius: 6px; padding: .5rem .75rem; }
+.fit ul { margin: .35rem 0 0; padding-left: 1.2rem; font-size: 14px; }
+form.greenlight { display: flex; gap: .75rem; margin: 1rem 0; }
+form.greenlight button { flex: 1; font-size: 1.1rem; padding: .8rem; border: 0;
+  border-radius: 6px; color: #fff; }
+button.approve { background: #2e7d32; } button.decline { background: #c62828; }
+form.interview textarea { width: 100%; box-sizing: border-box; font: inherit;
+  padding: .5rem; }
+form.interview div { display: flex; gap: .5rem; margin-top: .5rem; }
+form.interview button { flex: 1; padding: .6rem; border: 0; border-radius: 6px;
+  background: #555; color: #fff; }
+.patch { border-top: 1px solid #eee; padding: .5rem 0; }
+.patch del, .patch ins { display: block; text-decoration: none; padding: 0 .25rem; }
+.patch del { background: #fbd6d6; color: #888; } .patch ins { background: #d4f7d4; }
+form.patch-decision { display: flex; gap: .5rem; margin-top: .35rem; }
+form.patch-decision button { flex: 1; padding: .45rem; border: 0; border-radius: 6px;
+  color: #fff; }
+pre.resume { white-space: pre-wrap; font: 14px/1.5 ui-monospace, monospace; }
+pre.resume ins { background: #d4f7d4; text-decoration: none; display: block; }
+pre.resume del { background: #fbd6d6; color: #888; display: block; }
+"""
+
+
+def _page(title: str, body: str, refresh: bool = False) -> bytes:
+    meta = f'<meta http-equiv="refresh" content="{REFRESH_SECONDS}">' if refresh else ""
+    return (
+        "<!doctype html><html><head><meta charset='utf-8'>"
+        "<meta name='viewport' content='width=device-width, initial-scale=1'>"
+        f"{meta}<title>{escape(title)}</title><style>{_STYLE}</style></head>"
+        f"<body>{body}</body></html>"
+    ).encode()
+
+
+def _run_state(run_dir: Path) -> str:
+    greenlight = greenlight_state(run_dir) or {}
+    if greenlight.get("status") == PENDING and is_live(run_dir):
+        return "waiting for greenlight"
+    interview = load_session(run_dir)
+    if interview is not None and not interview.done and is_live(run_dir):
+        return "waiting for interview answers"
+    if is_live(run_dir):
+        return "running"
+    return run_status(run_dir) or "unknown"
+
+
+def _badge(state: str) -> str:
+    kind = {
+        "waiting for greenlight": "waiting",
+        "waiting for interview answers": "waiting",
+        "running": "live",
+    }.get(state, "")
+    return f'<span class="badge {kind}">{escape(state)}</span>'
+
+
+def _gap_review_html(review: GapReview) -> str:
+    score = f"fit score {review.fit_score:g}" if review.fit_score is not None else "no fit score"
+    rows = "".join(
+        f"<tr><th>{label} ({len(items)})</th>"
+        f"<td>{'<br>'.join(escape(item) for item in items) or '—'}</td></tr>"
+        for label, items in (
+            ("Matches", review.matches),
+            ("Adjacent", review.adjacent),
+            ("Gaps", review.gaps),
+        )
+    )
+    if review.recommended_profile:
+        reason = f" — {review.profile_reason}" if review.profile_reason else ""
+        rows += (
+            f"<tr><th>Best profile</th><td>{escape(review.recommended_profile)}"
+            f"{escape(reason)}</td></tr>"
+        )
+    call = ""
+    if review.decision:
+        why = "".join(f"<li>{escape(line)}</li>" for line in review.rationale)
+        call = (
+            f"<div class='fit'><strong>{DECISION_MARKS.get(review.decision, '')} "
+            f"Recommended: {escape(review.decision)}</strong><ul>{why}</ul></div>"
+        )
+    return f"<h2>Gap analysis · {score}</h2>{call}<table class='gaps'>{rows}</table>"
+
+
+def _patches_html(run_name: str, patches: List[ResumePatch]) -> str:
+    counts = patch_counts(patches)
+    items = []
+    for patch in patches:
+        where = " · ".join(part for part in (patch.section, patch.entry) if part)
+        lines = []
+        if patch.before is not None:
+            lines.append(f"<del>{escape(patch.before)}</del>")
+        if patch.op != "delete":
+            lines.append(f"<ins>{escape(patch.text)}</ins>")
+        reason = f"<div class='meta'>{escape(patch.reason)}</div>" if patch.reason else ""
+        items.append(
+            f"<div class='patch'><div class='meta'>{escape(patch.id)} · {escape(patch.op)}"
+            f" · {escape(where)} · {_badge(patch.status)}</div>{''.join(lines)}{reason}"
+            f"<form class='patch-decision' method='post' "
+            f"action='/runs/{quote(run_name)}/patches'>"
+            f"<input type='hidden' name='patch' value='{escape(patch.id)}'>"
+            f"<button class='approve' name='decision' value='{ACCEPTED}'>Accept</button>"
+            f"<button class='decline' name='decision' value='{REJECTED}'>Reject</button>"
+            "</form></div>"
+        )
+    summary = ", ".join(f"{count} {status}" for status, count in counts.items())
+    return f"<h2>Résumé patches</h2><p class='meta'>{summary}</p>{''.join(items)}"
+
+
+def _interview_html(run_name: str, session: InterviewSession) -> str:
+    question = session.current
+    about = describe(question)
+    earlier = session.answers.get(question["id"], "")
+    return (
+        f"<h2>Interview · question {session.progress}</h2>"
+        + (f"<p class='meta'>{escape(about)}</p>" if about else "")
+        + f"<p><strong>{escape(question['question'])}</strong></p>"
+        f"<form class='interview' method='post' action='/runs/{quote(run_name)}/interview'>"
+        f"<textarea name='answer' rows='5'>{escape(earlier)}</textarea><div>"
+        "<button class='approve' name='action' value='answer'>Answer</button>"
+        "<button name='action' value='skip'>Skip</button>"
+        "<button name='action' value='back'>Back</button>"
+        "<button class='decline' name='action' value='finish'>Finish</button>"
+        "</div></form>"
+    )
+
+
+def _run_files(run_dir: Path) -> List[str]:
+    files = [
+        path.relative_to(run_dir).as_posix()
+        for path in run_dir.rglob("*")
+        if path.is_file() and path.name not in _HIDDEN
+    ]
+    # PDFs first, then the documents at the top level, then the rest.
+    return sorted(files, key=lambda name: (not name.endswith(".pdf"), "/" in name, name))
+
+
+class ReviewServer:
+    """Routes requests for the runs under ``out_dir``; ``handle`` is the whole app."""
+
+    def __init__(self, out_dir: Path, token: str):
+        self.out_dir = Path(out_dir)
+        self.token = token
+
+    def _run_dir(self, run_id: str) -> Optional[Path]:
+        run_dir = (self.out_dir / run_id).resolve()
+        if run_dir.parent != self.out_dir.resolve() or not run_dir.is_dir():
+            return None
+        return run_dir
+
+    def runs(self) -> List[Path]:
+        """Run directories, most recently changed first."""
+        if not self.out_dir.is_dir():
+            return []
+        runs = [
+            path
+            for path in self.out_dir.iterdir()
+            if path.is_dir() and any((path / name).exists() for name in (MANIFEST_FILE, LIVE_FILE))
+        ]
+        return sorted(runs, key=lambda path: path.stat().st_mtime, reverse=True)
+
+    def handle(
+        self,
+        method: str,
+        target: str,
+        form: Optional[Dict[str, str]] = None,
+        cookie_token: Optional[str] = None,
+    ) -> Response:
+        """Answer one request: status, headers and body."""
+        url = urlsplit(target)
+        query_token = (parse_qs(url.query).get("token") or [None])[0]
+        given = query_token or cookie_token or ""
+        if not hmac.compare_digest(given.encode(), self.token.encode()):
+            body = _page("Hydra", "<p>Open the link <code>hydra serve</code> printed.</p>")
+            return HTTPStatus.UNAUTHORIZED, {}, body
+        headers = {}
+        if query_token:
+            headers["Set-Cookie"] = (
+                f"{TOKEN_COOKIE}={self.token}; Path=/; HttpOnly; SameSite=Strict"
+            )
+
+        parts = [unquote(part) for part in url.path.strip("/").split("/") if part]
+        if method == "GET" and not parts:
+            return HTTPStatus.OK, headers, self._index()
+        if len(parts) < 2 or parts[0] != "runs":
+            return self._not_found(headers)
+        run_dir = self._run_dir(parts[1])
+        if run_dir is None:
+            return self._not_found(headers)
+        if method == "GET" and len(parts) == 2:
+            return HTTPStatus.OK, headers, self._run_page(run_dir)
+        if method == "GET" and len(parts) > 3 and parts[2] == "files":
+            return self._file(run_dir, "/".join(parts[3:]), headers)
+        if method == "POST" and parts[2:] == ["greenlight"]:
+            decision = (form or {}).get("decision")
+            if decision not in ("approve", "decline"):
+                return HTTPStatus.BAD_REQUEST, headers, _page("Hydra", "<p>No decision.</p>")
+            try:
+                answer_greenlight(run_dir, decision == "approve")
+            except ValueError as err:
+                return HTTPStatus.CONFLICT, headers, _page("Hydra", f"<p>{escape(str(err))}</p>")
+            headers["Location"] = f"/runs/{quote(run_dir.name)}"
+            return HTTPStatus.SEE_OTHER, headers, b""
+        if method == "POST" and parts[2:] == ["interview"]:
+            action = (form or {}).get("action")
+            if action not in INTERVIEW_ACTIONS:
+                return HTTPStatus.BAD_REQUEST, headers, _page("Hydra", "<p>No answer.</p>")
+            try:
+                answer_interview(run_dir, action, (form or {}).get("answer") or "")
+            except ValueError as err:
+                return HTTPStatus.CONFLICT, headers, _page("Hydra", f"<p>{escape(str(err))}</p>")
+            headers["Location"] = f"/runs/{quote(run_dir.name)}"
+            return HTTPStatus.SEE_OTHER, headers, b""
+        if method == "POST" and parts[2:] == ["patches"]:
+            patch, decision = (form or {}).get("patch"), (form or {}).get("decision")
+            if not patch or decision not in (ACCEPTED, REJECTED):
+                return HTTPStatus.BAD_REQUEST, headers, _page("Hydra", "<p>No decision.</p>")
+            try:
+                conflicts = decide_patches(run_dir, {patch: decision})
+            except ValueError as err:
+                return HTTPStatus.CONFLICT, headers, _page("Hydra", f"<p>{escape(str(err))}</p>")
+            if conflicts:
+                message = (
+                    f"<p>Patch {escape(patch)} no longer matches the résumé (a later stage "
+                    f"changed that bullet); nothing was changed.</p>"
+                    f"<p><a href='/runs/{quote(run_dir.name)}'>Back</a></p>"
+                )
+                return HTTPStatus.CONFLICT, headers, _page("Hydra", message)
+            headers["Location"] = f"/runs/{quote(run_dir.name)}"
+            return HTTPStatus.SEE_OTHER, headers, b""
+        return self._not_found(headers)
+
+    @staticmethod
+    def _not_found(headers: Dict[str, str]) -> Response:
+        return HTTPStatus.NOT_FOUND, headers, _page("Hydra", "<p>Not found.</p>")
+
+    def _index(self) -> bytes:
+        items = []
+        for run_dir in self.runs():
+            state = _run_state(run_dir)
+            items.append(
+                f"<li><a href='/runs/{quote(run_dir.name)}'>{escape(run_dir.name)}</a> "
+                f"{_badge(state)}</li>"
+            )
+        listing = f"<ul class='runs'>{''.join(items)}</ul>" if items else "<p>No runs yet.</p>"
+        live = any(is_live(run_dir) for run_dir in self.runs())
+        return _page("Hydra runs", f"<h1>Hydra runs</h1>{listing}", refresh=live)
+
+    def _run_page(self, run_dir: Path) -> bytes:
+        state = _run_state(run_dir)
+        greenlight = greenlight_state(run_dir) or {}
+        sections = [
+            f"<p><a href='/'>← all runs</a></p><h1>{escape(run_dir.name)}</h1>",
+            f"<p>{_badge(state)}</p>",
+        ]
+
+        review = None
+        if greenlight.get("gap_review"):
+            review = GapReview(**greenlight["gap_review"])
+        else:
+            gap_file = run_dir / INTERMEDIATE_DIR / "gap_analysis.yaml"
+            if gap_file.is_file():
+                try:
+                    review = GapReview.from_raw(yaml.safe_load(read_text(gap_file)))
+                except yaml.YAMLError:
+                    review = None
+        if review is not None:
+            sections.append(_gap_review_html(review))
+        if state == "waiting for greenlight":
+            sections.append(
+                f"<form class='greenlight' method='post' "
+                f"action='/runs/{quote(run_dir.name)}/greenlight'>"
+                "<button class='approve' name='decision' value='approve'>Approve</button>"
+                "<button class='decline' name='decision' value='decline'>Decline</button>"
+                "</form>"
+            )
+        elif greenlight.get("status") in (APPROVED, DECLINED):
+            sections.append(f"<p class='meta'>Greenlight {escape(greenlight['status'])}.</p>")
+        if state == "waiting for interview answers":
+            sections.append(_interview_html(run_dir.name, load_session(run_dir)))
+
+        patches = load_patches(run_dir)
+        if patches:
+            sections.append(_patches_html(run_dir.name, patches))
+
+        resume = run_dir / RESUME_FILE
+        if resume.is_file():
+            diff_file = run_dir / RESUME_DIFF_FILE
+            diff = read_text(diff_file) if diff_file.is_file() else ""
+            lines = []
+            for kind, line in inline_diff(read_text(resume), diff):
+                tag = {"added": "ins", "removed": "del"}.get(kind)
+                text = escape(line) or "&nbsp;"
+                lines.append(f"<{tag}>{text}</{tag}>" if tag else f"{text}\n")
+            sections.append(
+                "<h2>Tailored résumé</h2><p class='meta'>Added lines in green, removed in "
+                f"red.</p><pre class='resume'>{''.join(lines)}</pre>"
+            )
+
+        files = _run_files(run_dir)
+        if files:
+            links = "".join(
+                f"<li><a href='/runs/{quote(run_dir.name)}/files/{quote(name)}'>"
+                f"{escape(name)}</a></li>"
+                for name in files
+            )
+            sections.append(f"<h2>Files</h2><ul>{links}</ul>")
+        refresh = state == "running"
+        return _page(run_dir.name, "".join(sections), refresh=refresh)
+
+    def _file(self, run_dir: Path, name: str, headers: Dict[str, str]) -> Response:
+        path = (run_dir / name).resolve()
+        if run_dir not in path.parents or not path.is_file() or path.name in _HIDDEN:
+            return self._not_found(headers)
+        if path.suffix == ".html":  # report.html, resume_diff.html
+            headers["Content-Type"] = "text/html; charset=utf-8"
+        elif path.suffix in _TEXT_SUFFIXES:
+            headers["Content-Type"] = "text/plain; charset=utf-8"
+        else:  # PDFs and anything else: a download
+            guessed = mimetypes.guess_type(path.name)[0]
+            headers["Content-Type"] = guessed or "application/octet-stream"
+            headers["Content-Disposition"] = f'attachment; filename="{path.name}"'
+        record_access(run_dir, "hydra serve", file=path.relative_to(run_dir).as_posix())
+        return HTTPStatus.OK, headers, read_bytes(path)
+
+
+def _handler(app: ReviewServer) -> type:
+    class Handler(BaseHTTPRequestHandler):
+        def _respond(self, method: str, form: Optional[Dict[str, str]] = None) -> None:
+            cookie = SimpleCookie(self.headers.get("Cookie") or "")
+            token = cookie[TOKEN_COOKIE].value if TOKEN_COOKIE in cookie else None
+            status, headers, body = app.handle(method, self.path, form, token)
+            self.send_response(status)
+            headers.setdefault("Content-Type", "text/html; charset=utf-8")
+            for key, value in headers.items():
+                self.send_header(key, value)
+            self.send_header("Content-Length", str(len(body)))
+            self.end_headers()
+            self.wfile.write(body)
+
+        def do_GET(self) -> None:
+            self._respond("GET")
+
+        def do_POST(self) -> None:
+            length = int(self.headers.get("Content-Length") or 0)
+            fields = parse_qs(self.rfile.read(length).decode())
+            self._respond("POST", {key: values[0] for key, values in fields.items()})
+
+        def log_message(self, format: str, *args: Any) -> None:  # quiet by default
+            pass
+
+    return Handler
+
+
+def serve_reviews(out_dir: Path, token: str, host: str = DEFAULT_HOST, port: int = DEFAULT_PORT):
+    """Serve ``out_dir`` until interrupted."""
+    server = ThreadingHTTPServer((host, port), _handler(ReviewServer(out_dir, token)))
+    try:
+        server.serve_forever()
+    finally:
+        server.server_close()
@@ -0,0 +1,295 @@
+"""Pause, resume and cancel CLI runs by run id, live or persisted.
+
+A CLI run takes its run id before it starts and marks its directory with
+``live.json`` (the pid) while it executes. ``hydra pause <id>`` and ``hydra cancel
+<id>`` reach a live run through ``control.json`` in that directory, which the run
+polls:
+
+- pause stops it at the next stage boundary — the stage under way finishes and is
+  kept — and the run is saved with status ``paused``;
+- cancel abandons the stage under way; the run is saved with status ``cancelled``
+  and cannot be resumed.
+
+A run that is not live is changed in its ``run.json`` directly: an interrupted run
+can be paused or cancelled, and ``hydra resume <id>`` re-runs a paused or
+interrupted one from its completed stages (``--resume-run``), after which it is
+``resumed``. ``hydra replay <id>`` starts a new run on a saved run's inputs and
+flags in the same way (see runtime.crewai.replay). Against a server (``--server``
+or ``$HYDRA_SERVER_URL``) the three commands call the job API instead, with
+``$HYDRA_API_TOKEN`` as the bearer token when the server has API keys.
+"""
+
+from __future__ import annotations
+
+import json
+import os
+import threading
+import urllib.error
+import urllib.request
+from dataclasses import dataclass
+from datetime import datetime
+from pathlib import Path
+from typing import Any, Dict, Optional
+
+from runtime.crewai.artifacts import MANIFEST_FILE, write_artifact_index
+
+LIVE_FILE = "live.json"
+CONTROL_FILE = "control.json"
+SERVER_URL_ENV = "HYDRA_SERVER_URL"
+API_TOKEN_ENV = "HYDRA_API_TOKEN"
+
+PAUSE = "pause"
+CANCEL = "cancel"
+ACTIONS = (PAUSE, CANCEL)
+
+# run.json statuses a stopped run is saved with.
+PAUSED = "paused"
+CANCELLED = "cancelled"
+RESUMED = "resumed"  # continued in a later run (``resumed_as`` in its status_history)
+INTERRUPTED = "interrupted"  # RunStatus.INTERRUPTED: Ctrl-C or a stop not asked for here
+RESUMABLE = (PAUSED, INTERRUPTED)
+
+POLL_SECONDS = 0.5
+_REASONS = {PAUSE: "paused by hydra pause", CANCEL: "cancelled by hydra cancel"}
+
+
+class RunControlError(Exception):
+    """The run does not exist or is in a state the action does not apply to."""
+
+
+def _read_json(path: Path) -> Optional[Dict[str, Any]]:
+    try:
+        return json.loads(path.read_text())
+    except (OSError, ValueError):
+        return None
+
+
+def _pid_alive(pid: Any) -> bool:
+    if not isinstance(pid, int) or pid <= 0:
+        return False
+    try:
+        os.kill(pid, 0)
+    except ProcessLookupError:
+        return False
+    except PermissionError:  # someone else's process, but alive
+        return True
+    return True
+
+
+def is_live(run_dir: Path) -> bool:
+    """Whether a CLI process is executing the run in ``run_dir`` right now."""
+    live = _read_json(Path(run_dir) / LIVE_FILE)
+    return bool(live) and _pid_alive(live.get("pid"))
+
+
+def run_status(run_dir: Path) -> Optional[str]:
+    """The status in the run's ``run.json``, or None if it has none."""
+    manifest = _read_json(Path(run_dir) / MANIFEST_FILE)
+    return manifest.get("status") if manifest else None
+
+
+def set_run_status(run_dir: Path, status: str, **details: Any) -> None:
+    """Rewrite the status in ``run.json``, noting when and from what it changed.
+
+    ``details`` (the run that resumed it, say) are kept with the change.
+    """
+    path = Path(run_dir) / MANIFEST_FILE
+    manifest = json.loads(path.read_text())
+    change = {"from": manifest.get("status"), "to": status, "at": datetime.now().isoformat()}
+    manifest.setdefault("status_history", []).append({**change, **details})
+    manifest["status"] = status
+    path.write_text(json.dumps(manifest, indent=2, default=str))
+    write_artifact_index(run_dir)
+
+
+class RunControl:
+    """Marks a run as live and applies the pause or cancel written for it.
+
+    Used as a context manager around ``workflow.execute``; ``requested`` is the
+    action that stopped the run, if any.
+    """
+
+    def __init__(self, run_dir: Path, workflow: Any, poll_seconds: float = POLL_SECONDS):
+        self.run_dir = Path(run_dir)
+        self.workflow = workflow
+        self.poll_seconds = poll_seconds
+        self.requested: Optional[str] = None
+        self._done = threading.Event()
+        self._watcher: Optional[threading.Thread] = None
+
+    def __enter__(self) -> "RunControl":
+        self.run_dir.mkdir(parents=True, exist_ok=True)
+        live = {"pid": os.getpid(), "started_at": datetime.now().isoformat()}
+        (self.run_dir / LIVE_FILE).write_text(json.dumps(live))
+        self._watcher = threading.Thread(target=self._watch, daemon=True)
+        self._watcher.start()
+        return self
+
+    def __exit__(self, *exc: Any) -> None:
+        self._done.set()
+        if self._watcher is not None:
+            self._watcher.join()
+        for name in (LIVE_FILE, CONTROL_FILE):
+            (self.run_dir / name).unlink(missing_ok=True)
+
+    def _watch(self) -> None:
+        while not self._done.wait(self.poll_seconds):
+            control = _read_json(self.run_dir / CONTROL_FILE) or {}
+            action = control.get("action")
+            if action not in ACTIONS or action == self.requested:
+                continue
+            # A cancel after a pause still applies: it stops the stage under way.
+            self.requested = action
+            if action == CANCEL:
+                self.workflow.cancel(_REASONS[CANCEL])
+            else:
+                self.workflow.stop_after_stage(_REASONS[PAUSE])
+
+    @property
+    def status(self) -> Optional[str]:
+        """The run.json status for a run this stopped: ``paused`` or ``cancelled``."""
+        return {PAUSE: PAUSED, CANCEL: CANCELLED}.get(self.requested)
+
+
+@dataclass(frozen=True)
+class ControlOutcome:
+    """What a pause or cancel did: ``requested`` of a live run, or the new status."""
+
+    run_id: str
+    status: str  # "pausing"/"cancelling" for a live run, else the status now saved
+    message: str
+
+
+def control_run(run_dir: Path, action: str) -> ControlOutcome:
+    """Pause or cancel the run in ``run_dir`` (see the module docstring)."""
+    run_dir = Path(run_dir)
+    run_id = run_dir.name
+    if is_live(run_dir):
+        (run_dir / CONTROL_FILE).write_text(json.dumps({"action": action}))
+        if action == PAUSE:
+            return ControlOutcome(run_id, "pausing", "will pause when its current stage finishes")
+        return ControlOutcome(run_id, "cancelling", "is being cancelled")
+
+    status = run_status(run_dir)
+    if status is None:
+        raise RunControlError(f"No run {run_id} in {run_dir.parent}")
+    target = PAUSED if action == PAUSE else CANCELLED
+    if status == target:
+        return ControlOutcome(run_id, status, f"is already {status}")
+    if status not in RESUMABLE:
+        raise RunControlError(
+            f"Run {run_id} is {status}; only a paused or interrupted run can be {target}"
+        )
+    set_run_status(run_dir, target)
+    return ControlOutcome(run_id, target, target)
+
+
+def resume_arguments(run_dir: Path) -> list[str]:
+    """CLI arguments that re-run a paused or interrupted run from its checkpoint."""
+    run_dir = Path(run_dir)
+    manifest = _saved_manifest(run_dir)
+    status = manifest.get("status")
+    if status not in RESUMABLE:
+        raise RunControlError(
+            f"Run {run_dir.name} is {status}; only a paused or interrupted run can resume"
+        )
+    return _run_arguments(run_dir, manifest) + [
+        "--out", str(run_dir.parent), "--resume-run", run_dir.name,
+    ]  # fmt: skip
+
+
+def replay_arguments(run_dir: Path, stage: str) -> list[str]:
+    """CLI arguments for a new run on a saved run's inputs that reuses its stages
+    before ``stage`` (see runtime.crewai.replay)."""
+    run_dir = Path(run_dir)
+    manifest = _saved_manifest(run_dir)
+    return _run_arguments(run_dir, manifest) + [
+        "--out", str(run_dir.parent), "--replay-run", run_dir.name, "--replay-from", stage,
+    ]  # fmt: skip
+
+
+def _saved_manifest(run_dir: Path) -> Dict[str, Any]:
+    if is_live(run_dir):
+        raise RunControlError(f"Run {run_dir.name} is still running")
+    manifest = _read_json(run_dir / MANIFEST_FILE)
+    if manifest is None:
+        raise RunControlError(f"No run {run_dir.name} in {run_dir.parent}")
+    return manifest
+
+
+def _run_arguments(run_dir: Path, manifest: Dict[str, Any]) -> list[str]:
+    """The run flags a saved run was started with, from its manifest."""
+    inputs = manifest.get("inputs") or {}
+    if not inputs.get("jd_path") or not inputs.get("resume_path"):
+        raise RunControlError(f"Run {run_dir.name} did not record its input files")
+    args = ["--jd", inputs["jd_path"], "--resume", inputs["resume_path"]]
+    if inputs.get("sources_path"):
+        args += ["--sources", inputs["sources_path"]]
+    for flag in ("company", "role"):
+        if inputs.get(flag):
+            args += [f"--{flag}", inputs[flag]]
+    for directory in inputs.get("plugin_dirs") or []:
+        args += ["--plugin-dir", directory]
+    if manifest.get("encrypted"):
+        args.append("--encrypt")
+    if manifest.get("pii_redaction") is not None:
+        args.append("--redact-pii")
+    template = (manifest.get("template") or {}).get("name")
+    if template:
+        args += ["--template", template]
+    stages = (manifest.get("template") or {}).get("stages") or []
+    if "seniority" in stages:
+        args.append("--seniority")
+    if "impact" in stages:
+        args.append("--impact")
+    if "outreach" in stages:
+        args.append("--outreach")
+        if inputs.get("outreach_to"):
+            args += ["--outreach-to", inputs["outreach_to"].replace(" ", "-")]
+    if inputs.get("patches"):
+        args.append("--patches")
+    if inputs.get("contacts_path"):
+        args += ["--contacts", inputs["contacts_path"]]
+    if inputs.get("reuse_interview"):
+        args += ["--reuse-interview", inputs["reuse_interview"]]
+    if inputs.get("constraints_path"):
+        args += ["--constraints", inputs["constraints_path"]]
+    if inputs.get("lang"):
+        args += ["--lang", inputs["lang"]]
+    if inputs.get("cv_format"):
+        args += ["--cv-format", inputs["cv_format"]]
+    if inputs.get("personal_path"):
+        args += ["--personal", inputs["personal_path"]]
+    if inputs.get("seed") is not None:
+        args += ["--deterministic", "--seed", str(inputs["seed"])]
+    if inputs.get("prompt_dir"):
+        args += ["--prompt-dir", inputs["prompt_dir"]]
+    if inputs.get("judge"):
+        args.append("--judge")
+    if inputs.get("prompt_pack"):
+        args += ["--prompt-pack", inputs["prompt_pack"]]
+    return args
+
+
+def control_server(server_url: str, job_id: str, action: str) -> Dict[str, Any]:
+    """POST ``action`` ("pause", "resume" or "cancel") for a server job; its reply."""
+    url = f"{server_url.rstrip('/')}/api/v1/jobs/{job_id}/{action}"
+    token = os.environ.get(API_TOKEN_ENV)
+    headers = {"Authorization": f"Bearer {token}"} if token else {}
+    request = urllib.request.Request(url, data=b"", headers=headers, method="POST")
+    try:
+        with urllib.request.urlopen(request, timeout=30) as response:
+            return json.loads(response.read() or b"{}")
+    except urllib.error.HTTPError as e:
+        detail = (_decode(e.read()) or {}).get("detail") or e.reason
+        raise RunControlError(f"{action} {job_id}: {e.code} {detail}") from e
+    except urllib.error.URLError as e:
+        raise RunControlError(f"Cannot reach {server_url}: {e.reason}") from e
+
+
+def _decode(body: bytes) -> Optional[Dict[str, Any]]:
+    try:
+        data = json.loads(body)
+    except ValueError:
+        return None
+    return data if isinstance(data, dict) else None
@@ -0,0 +1,399 @@
+"""The run report: one human-readable summary of what a run found and produced.
+
+The stage outputs of a run are spread over ``intermediate/``, ``audit_report.yaml``
+and ``ats_parse.json``; ``run.json`` and ``report.html`` deliberately hold no content
+at all. The run report is the document a candidate actually reads before sending:
+
+- the decision — recommendation, fit score and the synthesizer's rationale;
+- a company snapshot from the cited research;
+- the gap summary — requirements met, adjacent and missing;
+- the differentiators to lead with;
+- ATS score before and after — the simulated parse (see ats_parse_check) of the
+  baseline résumé against the final one, plus the ATS optimizer's own score;
+- audit findings — the verdict, blocking issues and unverified claims;
+- quality scores — with ``--judge``, the résumé's rubric scores and why (see judge);
+- next steps — the synthesizer's action items, then ones that follow from the audit
+  and the gaps.
+
+It is assembled without a model from what the run already has, and written as
+``run_report.md``, ``run_report.html`` and, when a LaTeX engine is installed (see
+resume_themes), ``run_report.pdf`` from ``run_report.tex``. Unlike run.json it holds
+résumé and job content: the Markdown and HTML are encrypted like the documents when
+encryption is on; the LaTeX and PDF, like a rendered theme's, are not.
+"""
+
+from __future__ import annotations
+
+import json
+from dataclasses import dataclass, field
+from html import escape
+from pathlib import Path
+from typing import Any, Dict, List, Optional, Tuple
+
+import yaml
+
+from runtime.crewai.artifacts import AUDIT_REPORT_FILE, MANIFEST_FILE, load_checkpoint
+from runtime.crewai.ats_parse_check import ATS_PARSE_FILE, parse_resume
+from runtime.crewai.contracts import (
+    ATSResult,
+    AuditVerdict,
+    ExecutiveDecision,
+    GapReview,
+    ResearchBrief,
+    coerce_text,
+)
+from runtime.crewai.encryption import read_text, write_text
+from runtime.crewai.judge import overall, report_lines
+from runtime.crewai.resume_themes import ThemeError, compile_pdf, latex_escape
+from runtime.crewai.retro_audit import blocking_issues
+
+RUN_REPORT_MARKDOWN_FILE = "run_report.md"
+RUN_REPORT_HTML_FILE = "run_report.html"
+RUN_REPORT_LATEX_FILE = "run_report.tex"
+RUN_REPORT_PDF_FILE = "run_report.pdf"
+
+_SNAPSHOT_LABELS = (
+    ("recent_news", "Recent news"),
+    ("funding", "Funding"),
+    ("tech_stack", "Tech stack"),
+)
+# Findings shown per research category and per gap class; the files have the rest.
+MAX_ITEMS = 8
+
+_STYLE = """
+body { font: 15px/1.5 system-ui, sans-serif; margin: 2rem auto; color: #222; max-width: 760px; }
+h1 { font-size: 1.4rem; } h2 { font-size: 1.1rem; margin-top: 1.75rem; }
+"""
+
+
+@dataclass
+class RunReport:
+    """What the report says, section by section, independent of the output format."""
+
+    company: str = ""
+    role: str = ""
+    recommendation: Optional[str] = None
+    fit_score: Optional[float] = None
+    rationale: str = ""
+    snapshot: Dict[str, List[str]] = field(default_factory=dict)
+    matches: List[str] = field(default_factory=list)
+    adjacent: List[str] = field(default_factory=list)
+    gaps: List[str] = field(default_factory=list)
+    differentiators: List[str] = field(default_factory=list)
+    ats_before: Optional[Dict[str, Any]] = None
+    ats_after: Optional[Dict[str, Any]] = None
+    ats_optimizer_score: Optional[float] = None
+    audit_status: Optional[str] = None
+    audit_findings: List[str] = field(default_factory=list)
+    judge: Optional[Dict[str, Any]] = None
+    next_steps: List[str] = field(default_factory=list)
+
+    @property
+    def title(self) -> str:
+        target = " at ".join(part for part in (self.role, self.company) if part)
+        return f"Run report: {target}" if target else "Run report"
+
+    def sections(self) -> List[Tuple[str, List[str], List[str]]]:
+        """``(heading, paragraphs, bullets)`` per section, empty sections left out."""
+        sections = []
+        if self.recommendation:
+            score = f" (fit {self.fit_score:.0f}/100)" if self.fit_score is not None else ""
+            paragraphs = [f"{self.recommendation}{score}"]
+            sections.append(("Decision", paragraphs + _nonempty([self.rationale]), []))
+        snapshot = [
+            f"{label}: {finding}"
+            for key, label in _SNAPSHOT_LABELS
+            for finding in self.snapshot.get(key, [])[:MAX_ITEMS]
+        ]
+        if snapshot:
+            sections.append(("Company snapshot", [], snapshot))
+        gap_summary = [
+            f"{label} ({len(items)}): {', '.join(items[:MAX_ITEMS])}"
+            + (", …" if len(items) > MAX_ITEMS else "")
+            for label, items in (
+                ("Met", self.matches),
+                ("Adjacent", self.adjacent),
+                ("Missing", self.gaps),
+            )
+            if items
+        ]
+        if gap_summary:
+            sections.append(("Gap summary", [], gap_summary))
+        if self.differentiators:
+            sections.append(("Differentiators", [], self.differentiators))
+        ats = self._ats_lines()
+        if ats:
+            sections.append(("ATS score", [], ats))
+        if self.audit_status:
+            verdict = [f"Audit: {self.audit_status}"]
+            sections.append(("Audit findings", verdict, self.audit_findings))
+        if self.judge and report_lines(self.judge):
+            score = [f"Overall: {overall(self.judge)}/10"]
+            sections.append(("Quality scores", score, report_lines(self.judge)))
+        if self.next_steps:
+            sections.append(("Next steps", [], self.next_steps))
+        return sections
+
+    def _ats_lines(self) -> List[str]:
+        lines = []
+        before, after = self.ats_before or {}, self.ats_after or {}
+        if after.get("score") is not None:
+            was = f"{before['score']} → " if before.get("score") is not None else ""
+            lines.append(f"Simulated ATS parse: {was}{after['score']}/100")
+        if before.get("jd_skills") and after.get("jd_skills"):
+            lines.append(f"JD skills found: {_found(before)} → {_found(after)}")
+        missing = after.get("missing_fields") or []
+        if missing:
+            lines.append(f"Not extracted from the final résumé: {', '.join(missing)}")
+        if self.ats_optimizer_score is not None:
+            lines.append(f"ATS optimizer's own score: {self.ats_optimizer_score:.0f}/100")
+        return lines
+
+
+def _nonempty(texts: List[Any]) -> List[str]:
+    return [text for text in (coerce_text(t).strip() for t in texts) if text]
+
+
+def _found(ats_parse: Dict[str, Any]) -> str:
+    skills = ats_parse["jd_skills"]
+    found = len(skills.get("matched") or []) + len(skills.get("synonyms") or [])
+    return f"{found}/{found + len(skills.get('missing') or [])}"
+
+
+def _item_text(item: Any) -> str:
+    if isinstance(item, dict):
+        for key in ("differentiator", "title", "name", "summary", "description", "text"):
+            if item.get(key):
+                text = coerce_text(item[key])
+                detail = coerce_text(item.get("evidence") or item.get("description") or "")
+                return f"{text} — {detail}" if detail and detail != text else text
+        return ""
+    return coerce_text(item)
+
+
+def _differentiators(differentiation: Any) -> List[str]:
+    items = differentiation.get("differentiators") if isinstance(differentiation, dict) else None
+    return _nonempty([_item_text(item) for item in items or []])[:MAX_ITEMS]
+
+
+def _audit_findings(audit_report: Dict[str, Any]) -> List[str]:
+    findings = []
+    for document in ("resume", "cover_letter"):
+        audit = audit_report.get(f"{document}_audit")
+        if not audit:
+            continue
+        label = document.replace("_", " ")
+        verdict = AuditVerdict.from_raw(audit)
+        if not verdict.approved and verdict.reason:
+            findings.append(f"{label.capitalize()} not approved: {verdict.reason}")
+        findings += [
+            f"{label.capitalize()}, blocking — {issue}" for issue in blocking_issues(audit)
+        ]
+    claims = (audit_report.get("claim_verification") or {}).get("unverified_claims") or []
+    findings += [
+        f"Unverified {claim.get('kind', 'claim')} '{claim.get('text')}' ({claim.get('location')})"
+        for claim in claims
+    ]
+    violations = (audit_report.get("ats_lint") or {}).get("violations") or []
+    findings += [
+        f"ATS lint, {violation.get('rule')} — {violation.get('detail')} "
+        f"({violation.get('location')})"
+        for violation in violations
+    ]
+    discrepancies = (audit_report.get("chronology") or {}).get("discrepancies") or []
+    findings += [
+        f"Dates, {item.get('kind')} — {item.get('detail')} ({item.get('location')})"
+        for item in discrepancies
+    ]
+    if audit_report.get("error"):
+        findings.append(f"The audit stage errored: {audit_report['error']}")
+    return findings
+
+
+def _next_steps(
+    executive: Any, audit_report: Dict[str, Any], gaps: List[str]
+) -> List[str]:
+    steps = []
+    actions = executive.get("action_items") if isinstance(executive, dict) else None
+    if isinstance(actions, dict):
+        for items in actions.values():
+            steps += _nonempty(items if isinstance(items, list) else [items])
+    elif isinstance(actions, list):
+        steps += _nonempty(actions)
+    status = audit_report.get("final_status")
+    if status == "REJECTED":
+        steps.append("Fix the audit findings above, or re-run, before sending anything.")
+    elif status == "AUDIT_ERROR":
+        steps.append("Review both documents by hand: the audit did not complete.")
+    for gap in gaps[:3]:
+        steps.append(f"Prepare an honest answer for the missing requirement: {gap}")
+    return list(dict.fromkeys(steps))
+
+
+def build_run_report(
+    intermediate_results: Dict[str, Any],
+    audit_report: Optional[Dict[str, Any]] = None,
+    ats_before: Optional[Dict[str, Any]] = None,
+    ats_after: Optional[Dict[str, Any]] = None,
+    company: Optional[str] = None,
+    role: Optional[str] = None,
+) -> RunReport:
+    """Assemble the report from a run's stage outputs (``intermediate_results``).
+
+    ``ats_before``/``ats_after`` are simulated ATS parses (``ATSParseReport.to_dict``)
+    of the baseline and the final résumé.
+    """
+    results = intermediate_results or {}
+    audit_report = audit_report or {}
+    report = RunReport(role=role or "", ats_before=ats_before, ats_after=ats_after)
+
+    research = results.get("research")
+    if research:
+        brief = ResearchBrief.from_raw(research)
+        report.company = brief.company
+        report.snapshot = {
+            key: [finding["summary"] for finding in getattr(brief, key)]
+            for key, _ in _SNAPSHOT_LABELS
+        }
+    report.company = company or report.company
+
+    gap_review = GapReview.from_raw(results.get("gap_analysis"))
+    report.matches, report.adjacent, report.gaps = (
+        gap_review.matches,
+        gap_review.adjacent,
+        gap_review.gaps,
+    )
+    report.differentiators = _differentiators(results.get("differentiation"))
+    if results.get("ats_optimization"):
+        report.ats_optimizer_score = ATSResult.from_raw(results["ats_optimization"]).ats_score
+
+    executive = results.get("executive_synthesis")
+    if executive:
+        decision = ExecutiveDecision.from_raw(executive)
+        report.recommendation = decision.recommendation
+        report.fit_score = decision.fit_score
+        report.rationale = decision.rationale
+    elif gap_review.fit_score is not None:
+        report.fit_score = gap_review.fit_score
+
+    report.audit_status = audit_report.get("final_status")
+    report.audit_findings = _audit_findings(audit_report)
+    report.judge = results.get("judge")
+    report.next_steps = _next_steps(executive, audit_report, report.gaps)
+    return report
+
+
+def render_markdown(report: RunReport) -> str:
+    lines = [f"# {report.title}", ""]
+    for heading, paragraphs, bullets in report.sections():
+        lines += [f"## {heading}", ""]
+        for paragraph in paragraphs:
+            lines += [paragraph, ""]
+        if bullets:
+            lines += [f"- {bullet}" for bullet in bullets] + [""]
+    return "\n".join(lines)
+
+
+def render_html(report: RunReport) -> str:
+    """A standalone page: no scripts, no external assets."""
+    parts = [f"<h1>{escape(report.title)}</h1>"]
+    for heading, paragraphs, bullets in report.sections():
+        parts.append(f"<h2>{escape(heading)}</h2>")
+        parts += [f"<p>{escape(paragraph)}</p>" for paragraph in paragraphs]
+        if bullets:
+            items = "".join(f"<li>{escape(bullet)}</li>" for bullet in bullets)
+            parts.append(f"<ul>{items}</ul>")
+    return (
+        '<!doctype html><html><head><meta charset="utf-8">'
+        f"<title>{escape(report.title)}</title><style>{_STYLE}</style></head>"
+        f"<body>{''.join(parts)}</body></html>"
+    )
+
+
+def render_latex(report: RunReport) -> str:
+    body = []
+    for heading, paragraphs, bullets in report.sections():
+        body.append(rf"\section*{{{latex_escape(heading)}}}")
+        body += [latex_escape(paragraph) + "\n" for paragraph in paragraphs]
+        if bullets:
+            body.append(r"\begin{itemize}")
+            body += [rf"  \item {latex_escape(bullet)}" for bullet in bullets]
+            body.append(r"\end{itemize}")
+    return "\n".join(
+        [
+            r"\documentclass[11pt]{article}",
+            r"\usepackage[utf8]{inputenc}",
+            r"\usepackage[T1]{fontenc}",
+            r"\usepackage[margin=2cm]{geometry}",
+            r"\usepackage{newunicodechar}",
+            r"\newunicodechar{→}{$\rightarrow$}",
+            r"\newunicodechar{—}{---}",
+            r"\newunicodechar{…}{\ldots}",
+            r"\begin{document}",
+            rf"{{\LARGE {latex_escape(report.title)}}}",
+            "",
+            *body,
+            r"\end{document}",
+            "",
+        ]
+    )
+
+
+@dataclass
+class ReportOutput:
+    files: List[str]
+    pdf_error: Optional[str] = None
+
+
+def write_run_report(run_dir: Path, report: RunReport, pdf: bool = True) -> ReportOutput:
+    """Write the report as Markdown, HTML and (when it builds) PDF into ``run_dir``."""
+    run_dir = Path(run_dir)
+    write_text(run_dir / RUN_REPORT_MARKDOWN_FILE, render_markdown(report))
+    write_text(run_dir / RUN_REPORT_HTML_FILE, render_html(report))
+    output = ReportOutput([RUN_REPORT_MARKDOWN_FILE, RUN_REPORT_HTML_FILE])
+    if pdf:
+        (run_dir / RUN_REPORT_LATEX_FILE).write_text(render_latex(report), encoding="utf-8")
+        output.files.append(RUN_REPORT_LATEX_FILE)
+        try:
+            compile_pdf(run_dir / RUN_REPORT_LATEX_FILE)
+            output.files.append(RUN_REPORT_PDF_FILE)
+        except ThemeError as e:
+            output.pdf_error = str(e)
+    return output
+
+
+def _read(path: Optional[str]) -> Optional[str]:
+    if not path:
+        return None
+    try:
+        return read_text(Path(path))
+    except (OSError, ValueError):
+        return None
+
+
+def report_for_run(run_dir: Path, taxonomy: Any = None) -> RunReport:
+    """The report of a finished run, from what its directory (and its inputs) hold.
+
+    The "before" ATS score needs the baseline résumé; it is read from where the run
+    found it, and left out when that file is gone.
+    """
+    run_dir = Path(run_dir)
+    results, _ = load_checkpoint(run_dir)
+    manifest = json.loads((run_dir / MANIFEST_FILE).read_text())
+    inputs = manifest.get("inputs") or {}
+    audit_path = run_dir / AUDIT_REPORT_FILE
+    audit_report = yaml.safe_load(read_text(audit_path)) if audit_path.is_file() else None
+    ats_path = run_dir / ATS_PARSE_FILE
+    ats_after = json.loads(read_text(ats_path)) if ats_path.is_file() else None
+    baseline = _read(inputs.get("resume_path"))
+    ats_before = None
+    if baseline is not None:
+        ats_before = parse_resume(baseline, _read(inputs.get("jd_path")), taxonomy).to_dict()
+    return build_run_report(
+        results,
+        audit_report,
+        ats_before=ats_before,
+        ats_after=ats_after,
+        company=inputs.get("company"),
+        role=inputs.get("role"),
+    )
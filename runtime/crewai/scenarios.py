@@ -0,0 +1,233 @@
+"""Scenarios: declarative end-to-end checks of a preset, with canned model output.
+
+A scenario is a YAML file describing one run of the real pipeline:
+
+    name: quick apply skips the interview
+    preset: quick-apply            # default | quick-apply
+    inputs:                        # the workflow context
+      job_description: ...
+      resume: ...
+    responses:                     # what "the model" answers, per stage
+      gap_analysis: {...}          # a mapping is sent as JSON, a string verbatim,
+      auditor_suite: [{...}, ...]  # a list answers successive calls (the last repeats)
+    expect:
+      status: completed            # a RunStatus value
+      stages: [[gap_analysis, differentiation], tailoring, auditor_suite]
+      skipped: [interrogation]     # stages the latency budget dropped
+      artifacts:                   # files in the run directory, optionally with text
+        resume.md: ["Jane Doe"]    # each must contain
+        run.json: []
+
+Only the model is faked: every agent builds its real prompt, parses and validates
+the canned answer, and the workflow routes, gates and writes its artifacts exactly
+as in a live run. ``stages`` is the order in which stages called the model; a
+nested list is a group that runs concurrently, in any order. Retries count, so a
+stage that appears twice ran twice.
+
+``run_scenario`` returns the failures instead of raising, so ``hydra scenario``
+and the pytest suite (``tests/scenarios``) report every mismatch at once.
+``canned_model`` is the fake model on its own, for other harnesses (see evals).
+"""
+
+from __future__ import annotations
+
+import json
+import threading
+from contextlib import contextmanager
+from dataclasses import dataclass, field
+from pathlib import Path
+from types import SimpleNamespace
+from typing import Any, Dict, Iterator, List, Optional, Union
+from unittest.mock import patch
+
+import yaml
+
+from runtime.crewai.artifacts import write_run_artifacts
+from runtime.crewai.base_agent import BaseHydraAgent
+from runtime.crewai.hydra_workflow import HydraWorkflow
+from runtime.crewai.quick_apply import QUICK_APPLY_BUDGET_SECONDS, LatencyBudget
+
+DEFAULT_PRESET = "default"
+QUICK_APPLY_PRESET = "quick-apply"
+PRESETS = (DEFAULT_PRESET, QUICK_APPLY_PRESET)
+
+SCENARIO_MODEL = "scenario/canned"
+
+
+class ScenarioError(ValueError):
+    """Raised for a malformed scenario file."""
+
+    pass
+
+
+@dataclass
+class Scenario:
+    name: str
+    inputs: Dict[str, Any]
+    responses: Dict[str, Any]
+    expect: Dict[str, Any]
+    preset: str = DEFAULT_PRESET
+    path: Optional[Path] = None
+
+
+@dataclass
+class ScenarioResult:
+    scenario: Scenario
+    stages: List[str] = field(default_factory=list)
+    failures: List[str] = field(default_factory=list)
+    run_dir: Optional[Path] = None
+
+    @property
+    def passed(self) -> bool:
+        return not self.failures
+
+
+def load_scenario(path: Path) -> Scenario:
+    path = Path(path)
+    try:
+        raw = yaml.safe_load(path.read_text(encoding="utf-8"))
+    except yaml.YAMLError as e:
+        raise ScenarioError(f"{path}: not valid YAML: {e}") from e
+    if not isinstance(raw, dict):
+        raise ScenarioError(f"{path}: a scenario is a mapping")
+    for key in ("inputs", "responses", "expect"):
+        if not isinstance(raw.get(key), dict):
+            raise ScenarioError(f"{path}: '{key}' must be a mapping")
+    preset = raw.get("preset", DEFAULT_PRESET)
+    if preset not in PRESETS:
+        raise ScenarioError(f"{path}: unknown preset '{preset}' (one of {', '.join(PRESETS)})")
+    return Scenario(
+        name=raw.get("name") or path.stem,
+        inputs=raw["inputs"],
+        responses=raw["responses"],
+        expect=raw["expect"],
+        preset=preset,
+        path=path,
+    )
+
+
+def discover(paths: List[Path]) -> List[Path]:
+    """Scenario files under ``paths`` (files, or directories searched for *.yaml)."""
+    found: List[Path] = []
+    for path in map(Path, paths):
+        found += sorted(path.glob("**/*.yaml")) if path.is_dir() else [path]
+    return found
+
+
+class _CannedModel:
+    """Answers each stage's model calls from the scenario's responses, in order."""
+
+    def __init__(self, responses: Dict[str, Any]):
+        self.responses = responses
+        self.calls: Dict[str, int] = {}
+        self.stages: List[str] = []
+        # Agent -> the stage it is running. Keyed by agent rather than thread: calls
+        # under a latency budget or a cancel token run on a thread of their own.
+        self._stage_of: Dict[int, str] = {}
+        self._lock = threading.Lock()
+
+    def enter(self, agent: BaseHydraAgent, stage: str) -> None:
+        with self._lock:
+            self._stage_of[id(agent)] = stage
+
+    def answer(self, agent: BaseHydraAgent) -> str:
+        with self._lock:
+            stage = self._stage_of.get(id(agent), agent.role)
+            self.stages.append(stage)
+            if stage not in self.responses:
+                raise RuntimeError(f"Scenario has no response for stage '{stage}'")
+            canned = self.responses[stage]
+            if isinstance(canned, list):
+                index = self.calls.get(stage, 0)
+                self.calls[stage] = index + 1
+                canned = canned[min(index, len(canned) - 1)]
+        return canned if isinstance(canned, str) else json.dumps(canned)
+
+
+def _workflow(scenario: Scenario) -> HydraWorkflow:
+    quick_apply = scenario.preset == QUICK_APPLY_PRESET
+    budget = LatencyBudget(QUICK_APPLY_BUDGET_SECONDS) if quick_apply else None
+    return HydraWorkflow(
+        SimpleNamespace(model=SCENARIO_MODEL),
+        use_per_agent_models=False,
+        auto_approve=True,
+        latency_budget=budget,
+    )
+
+
+def _stage_failures(expected: List[Union[str, List[str]]], actual: List[str]) -> List[str]:
+    position = 0
+    for step in expected:
+        group = step if isinstance(step, list) else [step]
+        taken = actual[position : position + len(group)]
+        if sorted(taken) != sorted(group):
+            return [f"stages: expected {expected}, got {actual}"]
+        position += len(group)
+    if position != len(actual):
+        return [f"stages: expected {expected}, got {actual}"]
+    return []
+
+
+def _artifact_failures(expected: Any, run_dir: Path) -> List[str]:
+    if isinstance(expected, list):
+        expected = {name: [] for name in expected}
+    failures = []
+    for name, snippets in expected.items():
+        path = run_dir / name
+        if not path.is_file():
+            failures.append(f"artifacts: {name} was not written")
+            continue
+        text = path.read_text(encoding="utf-8")
+        failures += [
+            f"artifacts: {name} does not contain {snippet!r}"
+            for snippet in snippets or []
+            if snippet not in text
+        ]
+    return failures
+
+
+@contextmanager
+def canned_model(workflow: HydraWorkflow, responses: Dict[str, Any]) -> Iterator[_CannedModel]:
+    """Within the block, ``workflow``'s agents get their answers from ``responses``
+    (a stage -> answer mapping, as in a scenario) instead of the model."""
+    model = _CannedModel(responses)
+    execute_stage = workflow._execute_with_fallback
+
+    def _tracked(agent, context, stage_name):
+        model.enter(agent, stage_name)
+        return execute_stage(agent, context, stage_name)
+
+    with (
+        patch.object(workflow, "_execute_with_fallback", _tracked),
+        patch.object(BaseHydraAgent, "_invoke_llm", lambda agent, task: model.answer(agent)),
+    ):
+        yield model
+
+
+def run_scenario(scenario: Scenario, out_dir: Path) -> ScenarioResult:
+    """Run ``scenario`` with canned model output; artifacts go under ``out_dir``."""
+    workflow = _workflow(scenario)
+    with canned_model(workflow, scenario.responses) as model:
+        result = workflow.execute(dict(scenario.inputs))
+    run_dir = write_run_artifacts(
+        Path(out_dir),
+        result,
+        run_id=Path(scenario.path or scenario.name).stem,
+        include_intermediate=True,
+    )
+
+    outcome = ScenarioResult(scenario, stages=model.stages, run_dir=run_dir)
+    expect = scenario.expect
+    status = result.status.value
+    if "status" in expect and expect["status"] != status:
+        detail = f" ({result.error_message})" if result.error_message else ""
+        outcome.failures.append(f"status: expected {expect['status']}, got {status}{detail}")
+    if "stages" in expect:
+        outcome.failures += _stage_failures(expect["stages"], model.stages)
+    if "skipped" in expect:
+        skipped = (result.latency_budget or {}).get("skipped", [])
+        if sorted(skipped) != sorted(expect["skipped"]):
+            outcome.failures.append(f"skipped: expected {expect['skipped']}, got {skipped}")
+    if "artifacts" in expect:
+        outcome.failures += _artifact_failures(expect["artifacts"], run_dir)
+    return outcome
@@ -0,0 +1,273 @@
+"""Seniority calibration: does the résumé read at the level the job is hired at?
+
+A staff role read by someone who sees "helped with" bullets and no team sizes gets
+a mid-level candidate; a hands-on role read by someone who sees "managed 40 people"
+gets a manager who will be bored. With ``--seniority`` (or ``seniority`` in a
+workflow template) a stage runs after the gap analysis that compares the two.
+
+Software reads the levels and the framing, so the verdict does not depend on the
+model:
+
+- the job's level: its title's words (junior … executive, see fit_score), or, when
+  the title names none, the years of experience it asks for; ``manager``, ``head``
+  or ``director`` in the title means the role manages people;
+- the résumé's level: its latest title, or the years its dates span;
+- the framing of its experience bullets: how many open with a leading verb ("Led",
+  "Owned", "Drove") and how many with a supporting one ("Helped", "Assisted"), the
+  team sizes it states ("team of 6", "12 engineers") and the scope it claims beyond
+  the candidate's own team ("cross-functional", "org-wide", "across 4 teams").
+
+The résumé is **under-leveled** when its latest title is below the role's, or when a
+senior role meets mostly supporting verbs, a staff-level one no leading verb or no
+scope, or a managing one no team size; **over-leveled** when its title is two levels
+or more above the role's, or a team of ten or more is managed for a role that is not
+senior. Each finding comes with an instruction for the Tailoring Agent. The Seniority
+Calibrator agent then reads the flagged résumé and adds notes for the bullets that
+carry the mismatch; a matched résumé calls no model. The instructions change the
+framing, never the facts: titles stay as held and no team size or scope is added that
+the evidence does not state. The stage never fails the run.
+"""
+
+from __future__ import annotations
+
+import re
+from typing import Any, Dict, List, Optional, Tuple
+
+from runtime.crewai.fit_score import (
+    LEVEL_NAMES,
+    MID_LEVEL,
+    level_of,
+    required_years,
+    resume_years,
+)
+from runtime.crewai.resume_model import parse_resume
+
+UNDER = "under-leveled"
+OVER = "over-leveled"
+MATCHED = "matched"
+
+# Levels (see fit_score.LEVEL_NAMES) from which a role expects the framing below.
+SENIOR_LEVEL = 3  # more leading than supporting bullets
+STAFF_LEVEL = 4  # a leading verb, and scope beyond the own team
+# A team this large, managed, reads as a manager's résumé.
+LARGE_TEAM = 10
+# Years of experience asked for -> the level a title without level words is hired at.
+_LEVEL_BY_YEARS = ((8, 4), (5, 3), (2, MID_LEVEL), (0, 1))
+
+LEADING_VERBS = (
+    "led",
+    "lead",
+    "owned",
+    "own",
+    "drove",
+    "managed",
+    "manage",
+    "directed",
+    "headed",
+    "mentored",
+    "coached",
+    "hired",
+    "architected",
+    "established",
+    "founded",
+    "launched",
+    "defined",
+    "scaled",
+    "oversaw",
+)
+SUPPORTING_VERBS = (
+    "assisted",
+    "helped",
+    "supported",
+    "participated",
+    "contributed",
+    "shadowed",
+    "learned",
+    "worked on",
+    "involved in",
+    "tasked with",
+)
+_MANAGING_WORDS = {"manager", "management", "head", "director", "vp", "vice", "chief"}
+
+_TEAM_RE = re.compile(
+    r"\b(?:team|group|org|organi[sz]ation|department)\s+of\s+(?P<of>\d{1,4})\b"
+    r"|\b(?P<count>\d{1,4})\s*\+?\s+(?:direct\s+)?(?:reports|engineers|developers|"
+    r"designers|analysts|people|staff|reps|managers|ics|person\s+team|people\s+team)\b",
+    re.IGNORECASE,
+)
+_SCOPE_RE = re.compile(
+    r"\b(?:company|org|organi[sz]ation|department|enterprise|team)[- ]wide\b"
+    r"|\bcross[- ]functional\b|\bmulti[- ]team\b|\bacross\s+(?:\d+|several|multiple)\s+"
+    r"(?:teams|products|business units|regions|orgs)\b|\bglobal(?:ly)?\b|\bp&l\b",
+    re.IGNORECASE,
+)
+# Sections whose bullets are not experience.
+_SKIPPED_SECTIONS = ("skill", "education", "certif", "language", "interest", "contact")
+_EXPERIENCE_RE = re.compile(r"experience|employment|work history|career", re.IGNORECASE)
+_LEADING_MARKUP_RE = re.compile(r"^[*_\s]*")
+
+
+def _opening(text: str) -> str:
+    return _LEADING_MARKUP_RE.sub("", text).lower()
+
+
+def _opens_with(text: str, verbs: Tuple[str, ...]) -> bool:
+    return any(re.match(rf"{re.escape(verb)}\b", _opening(text)) for verb in verbs)
+
+
+def team_sizes(text: str) -> List[int]:
+    """The team sizes ``text`` states."""
+    return [int(m.group("of") or m.group("count")) for m in _TEAM_RE.finditer(text or "")]
+
+
+def role_level(job_description: str, role: Optional[str] = None) -> Tuple[int, bool]:
+    """The level the job is hired at, and whether it manages people; ``role`` defaults
+    to the job description's first line."""
+    role = role or next(
+        (line.strip("# ") for line in (job_description or "").splitlines() if line.strip()), ""
+    )
+    level = level_of(role)
+    if level is None:
+        years = required_years(job_description)
+        level = next(
+            (lvl for least, lvl in _LEVEL_BY_YEARS if years is not None and years >= least),
+            MID_LEVEL,
+        )
+    words = set(re.findall(r"[a-z]+", role.lower()))
+    return level, bool(words & _MANAGING_WORDS)
+
+
+def framing(resume: str) -> Dict[str, Any]:
+    """How the résumé's experience reads: its latest title's level and the signals
+    of its bullets (see the module doc), each bullet with its id."""
+    document = parse_resume(resume or "")
+    headings, bullets = [], []
+    for section in document.sections:
+        title = section.title.lower()
+        if not section.title or any(word in title for word in _SKIPPED_SECTIONS):
+            continue
+        for entry in section.entries:
+            if entry.heading and _EXPERIENCE_RE.search(section.title):
+                headings.append(entry.heading)
+            bullets += [bullet for bullet in entry.bullets if bullet.text.strip()]
+    text = "\n".join([*headings, *(bullet.text for bullet in bullets)])
+    level = level_of(headings[0]) if headings else None
+    if level is None:
+        years = resume_years(text)
+        level = next(
+            (lvl for least, lvl in _LEVEL_BY_YEARS if years is not None and years >= least),
+            MID_LEVEL,
+        )
+    return {
+        "level": level,
+        "title": headings[0] if headings else None,
+        "bullets": [{"id": bullet.id, "text": bullet.text.strip()} for bullet in bullets],
+        "leading": sum(_opens_with(bullet.text, LEADING_VERBS) for bullet in bullets),
+        "supporting": sum(_opens_with(bullet.text, SUPPORTING_VERBS) for bullet in bullets),
+        "team_sizes": team_sizes(text),
+        "scope": sorted({m.group(0).lower() for m in _SCOPE_RE.finditer(text)}),
+    }
+
+
+def calibrate(job_description: str, resume: str, role: Optional[str] = None) -> Dict[str, Any]:
+    """The job's level, the résumé's, the verdict, why, and what tailoring should do."""
+    wanted, managing = role_level(job_description, role)
+    seen = framing(resume)
+    own, total = seen["level"], len(seen["bullets"])
+    wanted_name, own_name = LEVEL_NAMES[wanted], LEVEL_NAMES[own]
+    largest = max(seen["team_sizes"], default=None)
+    under: List[Tuple[str, str]] = []
+    over: List[Tuple[str, str]] = []
+
+    if own < wanted:
+        under.append(
+            (
+                f"the latest title reads {own_name}; the role is {wanted_name}",
+                f"Keep the titles as held, and show {wanted_name}-level work under them; "
+                "the cover letter says why the candidate is ready for the step up",
+            )
+        )
+    if wanted >= SENIOR_LEVEL and total and seen["supporting"] > seen["leading"]:
+        under.append(
+            (
+                f"{seen['supporting']} of {total} bullets open with a supporting verb "
+                "(helped, assisted) and fewer with a leading one",
+                "Open each bullet with what the candidate did themselves: a leading verb "
+                "(led, owned, drove) for work they led, a concrete one for work they did; "
+                "keep 'helped' only where they did not",
+            )
+        )
+    elif wanted >= STAFF_LEVEL and total and not seen["leading"]:
+        under.append(
+            (
+                "no bullet opens with leading or owning work",
+                "Lead each recent role with the work the candidate owned or led, where "
+                "the résumé or sources say so",
+            )
+        )
+    if wanted >= STAFF_LEVEL and total and not seen["scope"]:
+        under.append(
+            (
+                "no scope beyond the candidate's own team",
+                "Make scope explicit: the teams, products, users or revenue the work "
+                "touched, as the résumé and sources state them",
+            )
+        )
+    if managing and largest is None:
+        under.append(
+            (
+                "no team size stated, and the role manages people",
+                "State the team sizes and reporting lines the résumé or sources give; "
+                "never estimate one",
+            )
+        )
+    if own >= wanted + 2:
+        over.append(
+            (
+                f"the latest title reads {own_name}; the role is {wanted_name}",
+                f"Frame the résumé at the {wanted_name} level: lead each role with "
+                "hands-on work in the role's field, and keep the titles as held",
+            )
+        )
+    if not managing and wanted < SENIOR_LEVEL and largest is not None and largest >= LARGE_TEAM:
+        over.append(
+            (
+                f"the résumé manages a team of {largest}; the role is {wanted_name} and "
+                "hands-on",
+                "Compress management and org-wide scope to one line per role; the cover "
+                "letter says briefly why this role is the move the candidate wants",
+            )
+        )
+    found = under or over
+    return {
+        "role_level": wanted_name,
+        "resume_level": own_name,
+        "manages_people": managing,
+        "verdict": UNDER if under else OVER if over else MATCHED,
+        "issues": [issue for issue, _ in found],
+        "instructions": [instruction for _, instruction in found],
+        "signals": {key: seen[key] for key in ("leading", "supporting", "team_sizes", "scope")},
+        "bullets": seen["bullets"],
+        "findings": [],
+    }
+
+
+def tailoring_brief(calibration: Optional[Dict[str, Any]]) -> Optional[Dict[str, Any]]:
+    """What the Tailoring Agent is told: None when the résumé reads at the role's level."""
+    if not calibration or calibration.get("verdict") in (None, MATCHED):
+        return None
+    return {
+        key: calibration.get(key)
+        for key in ("role_level", "verdict", "issues", "instructions", "findings")
+    }
+
+
+def manifest_summary(calibration: Dict[str, Any]) -> Dict[str, Any]:
+    """What ``run.json`` keeps of the stage: the levels and counts, never the bullets."""
+    return {
+        "role_level": calibration.get("role_level"),
+        "resume_level": calibration.get("resume_level"),
+        "verdict": calibration.get("verdict"),
+        "issues": len(calibration.get("issues") or []),
+        "findings": len(calibration.get("findings") or []),
+    }
@@ -0,0 +1,308 @@
+"""Skill taxonomy: one name per skill, so "K8s" in the JD matches "Kubernetes" in the résumé.
+
+Job descriptions and résumés name the same skill differently: abbreviations (K8s,
+GCP, IaC), spellings (NodeJS, Node.js), product names (Postgres, PostgreSQL). Left
+alone, literal matching reports a gap where there is none. This module maps every
+name to a canonical one:
+
+- an alias dictionary: ``taxonomy/skills.yaml`` (built in), extended by the user's
+  ``~/.hydra/skills.yaml`` (or ``$HYDRA_SKILLS_FILE``, or ``--skill-taxonomy FILE``).
+  User aliases are added to the built-in ones and may move an alias to another skill;
+- optional embedding similarity (``$HYDRA_SKILL_EMBEDDING_MODEL``, any LiteLLM
+  embedding model): a listed skill the dictionary does not know is mapped to the
+  nearest canonical skill when the cosine similarity clears the threshold. Only
+  short skill names are embedded, never résumé text, and a failing embedding call
+  just turns the similarity off.
+
+Lookups ignore case, spacing, hyphens and underscores. Scanning free text is
+stricter: names that are also ordinary words (Go, R, Spark) count only with the
+case listed under ``case_sensitive``.
+
+``skill_coverage`` compares the skills a JD mentions with the résumé's. The gap
+analysis is told which JD skills the résumé has under another name, and the ATS
+parse check reports them apart from literal matches: the candidate has the skill,
+but a keyword-matching ATS may not see it.
+"""
+
+from __future__ import annotations
+
+import logging
+import math
+import os
+import re
+from dataclasses import dataclass, field
+from pathlib import Path
+from typing import Any, Callable, Dict, Iterable, List, Optional, Sequence, Set
+
+import yaml
+
+from runtime.crewai.stage_cache import hydra_home
+
+logger = logging.getLogger(__name__)
+
+BUILTIN_TAXONOMY_FILE = Path(__file__).resolve().parents[2] / "taxonomy" / "skills.yaml"
+USER_TAXONOMY_FILE = "skills.yaml"  # in hydra_home()
+TAXONOMY_FILE_ENV = "HYDRA_SKILLS_FILE"
+EMBEDDING_MODEL_ENV = "HYDRA_SKILL_EMBEDDING_MODEL"
+DEFAULT_SIMILARITY = 0.85
+
+# texts -> one vector per text
+Embedder = Callable[[List[str]], List[List[float]]]
+
+
+class TaxonomyError(ValueError):
+    """A taxonomy file that cannot be read, or is not laid out like taxonomy/skills.yaml."""
+
+
+def _key(term: str) -> str:
+    """Lookup key: case, spacing, hyphens and surrounding punctuation do not matter."""
+    text = re.sub(r"[\s_\-]+", " ", str(term).lower())
+    return text.strip(" .,;:()[]'\"")
+
+
+def _pattern(names: Iterable[str]) -> str:
+    parts = []
+    for name in sorted(names, key=len, reverse=True):
+        parts.append(re.sub(r"(?:\\ |\\-|_)+", r"[\\s\\-_]+", re.escape(name)))
+    # Not inside a longer word or name: "Go" is not in "Google", "Node" not in "Node.js".
+    return rf"(?<![\w.&+#])(?:{'|'.join(parts)})(?![\w+#&]|\.\w)"
+
+
+@dataclass
+class SkillTaxonomy:
+    """Canonical skill names, their aliases, and the optional embedding fallback."""
+
+    aliases: Dict[str, str] = field(default_factory=dict)  # _key(name) -> canonical
+    names: Set[str] = field(default_factory=set)  # every name, as written
+    case_sensitive: Set[str] = field(default_factory=set)
+    embedder: Optional[Embedder] = None
+    threshold: float = DEFAULT_SIMILARITY
+    _vectors: Dict[str, List[float]] = field(default_factory=dict, repr=False)
+    _scanners: Optional[List[re.Pattern]] = field(default=None, repr=False)
+
+    @property
+    def skills(self) -> List[str]:
+        return sorted(set(self.aliases.values()), key=str.lower)
+
+    def add(self, canonical: str, aliases: Iterable[str] = ()) -> None:
+        """Add a skill, or more names for one; a name another skill had moves to it."""
+        for name in (canonical, *aliases):
+            if _key(name):
+                self.aliases[_key(name)] = canonical
+                self.names.add(name.strip())
+        self._scanners = None
+
+    def canonical(self, term: str) -> Optional[str]:
+        """The canonical name for ``term``, by alias or else by embedding; None if unknown."""
+        key = _key(term)
+        if not key:
+            return None
+        if key in self.aliases:
+            return self.aliases[key]
+        return self._nearest(str(term).strip()) if self.embedder is not None else None
+
+    def normalize(self, term: str) -> str:
+        """The canonical name for ``term``, or ``term`` itself (trimmed) if unknown."""
+        return self.canonical(term) or str(term).strip()
+
+    def same(self, a: str, b: str) -> bool:
+        """Whether two names are the same skill."""
+        return _key(self.normalize(a)) == _key(self.normalize(b))
+
+    def mentions(self, text: str) -> Dict[str, Set[str]]:
+        """Canonical skill -> the names it is written as in free ``text``."""
+        found: Dict[str, Set[str]] = {}
+        for surface in self._surfaces(text):
+            found.setdefault(self.aliases[_key(surface)], set()).add(surface)
+        return found
+
+    def counts(self, text: str) -> Dict[str, int]:
+        """Canonical skill -> how many times free ``text`` names it, under any name."""
+        found: Dict[str, int] = {}
+        for surface in self._surfaces(text):
+            skill = self.aliases[_key(surface)]
+            found[skill] = found.get(skill, 0) + 1
+        return found
+
+    def _surfaces(self, text: str) -> List[str]:
+        """Every skill name in ``text``, in order; a name inside a longer one is not."""
+        if self._scanners is None:
+            exact = {n for n in self.names if n in self.case_sensitive}
+            loose = self.names - exact
+            self._scanners = [
+                re.compile(_pattern(group), flags)
+                for group, flags in ((exact, 0), (loose, re.I))
+                if group
+            ]
+        spans = [
+            (m.start(), m.end(), m.group(0))
+            for scanner in self._scanners
+            for m in scanner.finditer(text or "")
+        ]
+        surfaces: List[str] = []
+        end = -1
+        for start, stop, surface in sorted(spans, key=lambda span: (span[0], -span[1])):
+            if start < end:
+                continue  # part of a longer name already matched
+            end = stop
+            surfaces.append(surface)
+        return surfaces
+
+    def _nearest(self, term: str) -> Optional[str]:
+        skills = self.skills
+        try:
+            missing = [s for s in dict.fromkeys([term, *skills]) if s not in self._vectors]
+            if missing:
+                self._vectors.update(zip(missing, self.embedder(missing)))
+        except Exception as e:  # an optional aid: lookups go on without it
+            logger.warning(f"Skill embeddings unavailable, using aliases only: {e}")
+            self.embedder = None
+            return None
+        best, score = None, self.threshold
+        for skill in skills:
+            similarity = _cosine(self._vectors[term], self._vectors[skill])
+            if similarity >= score:
+                best, score = skill, similarity
+        return best
+
+
+def _cosine(a: Sequence[float], b: Sequence[float]) -> float:
+    dot = sum(x * y for x, y in zip(a, b))
+    norm = math.sqrt(sum(x * x for x in a)) * math.sqrt(sum(y * y for y in b))
+    return dot / norm if norm else 0.0
+
+
+def _read(path: Path) -> Dict[str, Any]:
+    try:
+        data = yaml.safe_load(Path(path).read_text(encoding="utf-8")) or {}
+    except (OSError, yaml.YAMLError) as e:
+        raise TaxonomyError(f"Cannot read skill taxonomy {path}: {e}") from e
+    skills = data.get("skills") if isinstance(data, dict) else None
+    if not isinstance(skills, dict):
+        raise TaxonomyError(f"{path}: expected 'skills:' mapping each skill to its aliases")
+    for name, aliases in skills.items():
+        if aliases is not None and not isinstance(aliases, list):
+            raise TaxonomyError(f"{path}: aliases of '{name}' must be a list")
+    exact = data.get("case_sensitive") or []
+    if not isinstance(exact, list):
+        raise TaxonomyError(f"{path}: 'case_sensitive' must be a list")
+    return {"skills": skills, "case_sensitive": exact}
+
+
+def user_taxonomy_file() -> Optional[Path]:
+    """``$HYDRA_SKILLS_FILE``, else ``~/.hydra/skills.yaml`` if it exists."""
+    override = os.environ.get(TAXONOMY_FILE_ENV)
+    if override:
+        return Path(override).expanduser()
+    path = hydra_home() / USER_TAXONOMY_FILE
+    return path if path.is_file() else None
+
+
+def load_taxonomy(
+    extra: Sequence[Path] = (),
+    embedder: Optional[Embedder] = None,
+    threshold: float = DEFAULT_SIMILARITY,
+) -> SkillTaxonomy:
+    """The built-in taxonomy extended by the user's file, then by each of ``extra``."""
+    taxonomy = SkillTaxonomy(embedder=embedder, threshold=threshold)
+    user = user_taxonomy_file()
+    for path in [BUILTIN_TAXONOMY_FILE, *([user] if user else []), *extra]:
+        _extend(taxonomy, path)
+    return taxonomy
+
+
+def _extend(taxonomy: SkillTaxonomy, path: Path) -> None:
+    data = _read(path)
+    for name, aliases in data["skills"].items():
+        taxonomy.add(str(name), [str(alias) for alias in aliases or []])
+    taxonomy.case_sensitive.update(str(name) for name in data["case_sensitive"])
+
+
+def litellm_embedder(model: str) -> Embedder:
+    """Embed with any LiteLLM embedding model (same credentials as the agents)."""
+
+    def embed(texts: List[str]) -> List[List[float]]:
+        import litellm
+
+        response = litellm.embedding(model=model, input=texts)
+        return [item["embedding"] for item in response.data]
+
+    return embed
+
+
+def env_embedder() -> Optional[Embedder]:
+    """The embedder ``$HYDRA_SKILL_EMBEDDING_MODEL`` names, if any."""
+    model = os.environ.get(EMBEDDING_MODEL_ENV)
+    return litellm_embedder(model) if model else None
+
+
+def default_taxonomy() -> SkillTaxonomy:
+    """The taxonomy a run uses: built-in + user file, embeddings if configured.
+
+    A broken user file is logged and skipped rather than failing the run (the CLI
+    checks ``--skill-taxonomy`` files up front with ``load_taxonomy``).
+    """
+    embedder = env_embedder()
+    try:
+        return load_taxonomy(embedder=embedder)
+    except TaxonomyError as e:
+        logger.warning(f"{e}; using the built-in skill taxonomy")
+        taxonomy = SkillTaxonomy(embedder=embedder)
+        _extend(taxonomy, BUILTIN_TAXONOMY_FILE)
+        return taxonomy
+
+
+@dataclass
+class SkillCoverage:
+    """The JD's skills against the résumé's: literal matches, synonyms, and missing."""
+
+    matched: List[str] = field(default_factory=list)
+    # The résumé has the skill, named differently: {"skill", "jd_term", "resume_term"}.
+    synonyms: List[Dict[str, str]] = field(default_factory=list)
+    missing: List[str] = field(default_factory=list)
+
+    def to_dict(self) -> Dict[str, Any]:
+        return {"matched": self.matched, "synonyms": self.synonyms, "missing": self.missing}
+
+    def to_manifest(self) -> Dict[str, int]:
+        return {
+            "matched": len(self.matched),
+            "synonyms": len(self.synonyms),
+            "missing": len(self.missing),
+        }
+
+
+def skill_coverage(
+    job_description: str,
+    resume: str,
+    taxonomy: SkillTaxonomy,
+    resume_skills: Iterable[str] = (),
+) -> SkillCoverage:
+    """Which skills the JD names are in the résumé, literally or under another name.
+
+    ``resume_skills`` are the résumé's listed skills (e.g. from its Skills section):
+    unlike free text they are looked up one by one, so embeddings can place them.
+    """
+    wanted = taxonomy.mentions(job_description)
+    have = taxonomy.mentions(resume)
+    for item in resume_skills:
+        skill = taxonomy.canonical(item)
+        if skill is not None:
+            have.setdefault(skill, set()).add(str(item).strip())
+
+    coverage = SkillCoverage()
+    for skill in sorted(wanted, key=str.lower):
+        jd_terms = {_key(term) for term in wanted[skill]}
+        if skill not in have:
+            coverage.missing.append(skill)
+        elif jd_terms & {_key(term) for term in have[skill]}:
+            coverage.matched.append(skill)
+        else:
+            coverage.synonyms.append(
+                {
+                    "skill": skill,
+                    "jd_term": sorted(wanted[skill])[0],
+                    "resume_term": sorted(have[skill])[0],
+                }
+            )
+    return coverage
@@ -0,0 +1,88 @@
+"""Content-addressed cache of validated stage outputs.
+
+Iterating on one prompt should not re-pay for every stage before it. Each agent call
+is keyed by a SHA-256 of exactly what would be sent — the rendered system and user
+messages (which already embed the agent's prompt file, the truth rules, and the
+inputs) plus the model and temperature. Change the tailoring prompt and only the
+tailoring key (and the keys of stages downstream of its output) change; gap analysis
+is served from the cache.
+
+Only *validated* outputs are stored, so a malformed response is never replayed.
+Entries live under ``$HYDRA_HOME/cache/stages`` (default ``~/.hydra``) with
+owner-only permissions, since stage outputs are derived from the résumé, and are
+encrypted along with the run when encryption is on (see encryption.py). The CLI
+enables the cache by default; ``--no-cache`` disables it.
+"""
+
+from __future__ import annotations
+
+import hashlib
+import json
+import os
+from pathlib import Path
+from typing import Any, Dict, List, Optional
+
+from runtime.crewai.encryption import read_text, write_text
+
+HYDRA_HOME_ENV = "HYDRA_HOME"
+CACHE_SUBDIR = Path("cache") / "stages"
+# Bump to invalidate every entry when the stored shape changes.
+CACHE_VERSION = 1
+
+
+def hydra_home() -> Path:
+    """Per-user state directory: ``$HYDRA_HOME`` or ``~/.hydra``."""
+    override = os.environ.get(HYDRA_HOME_ENV)
+    return Path(override).expanduser() if override else Path.home() / ".hydra"
+
+
+def cache_key(messages: List[Dict[str, str]], model: Any, temperature: Any) -> str:
+    """SHA-256 over the rendered messages, model, and temperature."""
+    payload = json.dumps(
+        {
+            "v": CACHE_VERSION,
+            "model": str(model) if model is not None else None,
+            "temperature": temperature if isinstance(temperature, (int, float)) else None,
+            "messages": messages,
+        },
+        sort_keys=True,
+        ensure_ascii=False,
+    )
+    return hashlib.sha256(payload.encode("utf-8")).hexdigest()
+
+
+class StageCache:
+    """Filesystem store of validated agent outputs, one JSON file per key."""
+
+    def __init__(self, root: Optional[Path] = None):
+        self.root = Path(root) if root is not None else hydra_home() / CACHE_SUBDIR
+        # Roles served from the cache during this process, in call order.
+        self.hits: List[str] = []
+
+    def _path(self, key: str) -> Path:
+        return self.root / key[:2] / f"{key}.json"
+
+    def get(self, key: str, role: str = "") -> Optional[Dict[str, Any]]:
+        """Return a fresh copy of the cached output, or None on a miss/corrupt entry."""
+        path = self._path(key)
+        try:
+            entry = json.loads(read_text(path))
+        except (OSError, ValueError):  # EncryptionError included: no key, or a wrong one
+            return None
+        output = entry.get("output") if isinstance(entry, dict) else None
+        if not isinstance(output, dict):
+            return None
+        self.hits.append(role or entry.get("role", ""))
+        return output
+
+    def put(self, key: str, output: Dict[str, Any], role: str = "") -> None:
+        """Store a validated output. Cache failures never fail the run."""
+        path = self._path(key)
+        try:
+            path.parent.mkdir(parents=True, exist_ok=True, mode=0o700)
+            tmp = path.with_suffix(".tmp")
+            write_text(tmp, json.dumps({"role": role, "output": output}, default=str))
+            tmp.chmod(0o600)
+            os.replace(tmp, path)
+        except (OSError, TypeError, ValueError):
+            pass
@@ -0,0 +1,81 @@
+"""Versioned workflow state: upgrade persisted stage outputs before resuming from them.
+
+A paused web job keeps its stage outputs (``intermediate_results``) in Postgres and the
+workflow resumes from them, possibly after a deploy that changed what a stage output
+looks like. Every persisted state now carries ``STATE_VERSION``; states from before
+versioning count as version 1. ``upgrade_state`` runs the registered migrations in
+order, so code only ever sees the current shape.
+
+To change the shape of a stored stage output: bump ``STATE_VERSION`` and register a
+``@migration(<old version>)`` that rewrites an old state into the new shape. Migrations
+must not need the LLM and must leave a state they do not recognize alone.
+"""
+
+from __future__ import annotations
+
+import copy
+from typing import Any, Callable, Dict, Optional
+
+from runtime.crewai.contracts import ExecutiveDecision
+
+STATE_VERSION = 2
+# States persisted before they carried a version.
+UNVERSIONED = 1
+
+Migration = Callable[[Dict[str, Any]], Dict[str, Any]]
+MIGRATIONS: Dict[int, Migration] = {}
+
+
+class StateVersionError(Exception):
+    """Raised for a state written by a newer Hydra than this one."""
+
+    pass
+
+
+def migration(from_version: int) -> Callable[[Migration], Migration]:
+    """Register a migration from ``from_version`` to ``from_version + 1``."""
+
+    def _register(fn: Migration) -> Migration:
+        if from_version in MIGRATIONS:
+            raise ValueError(f"Duplicate state migration from version {from_version}")
+        MIGRATIONS[from_version] = fn
+        return fn
+
+    return _register
+
+
+@migration(1)
+def _python_owned_decision(state: Dict[str, Any]) -> Dict[str, Any]:
+    """Version 2: the recommendation is derived from the fit score, never the model's.
+
+    Version 1 states can hold the model's own verdict, or a 0-1 fit score read as a
+    0-100 one (0.85 -> PASS).
+    """
+    synthesis = state.get("executive_synthesis")
+    if isinstance(synthesis, dict):
+        canonical = ExecutiveDecision.from_raw(synthesis)
+        decision = synthesis.get("decision")
+        if isinstance(decision, dict):
+            decision["fit_score"] = canonical.fit_score
+            decision["recommendation"] = canonical.recommendation
+        else:
+            synthesis["decision"] = canonical.model_dump()
+    return state
+
+
+def upgrade_state(state: Optional[Dict[str, Any]], version: Optional[int]) -> Dict[str, Any]:
+    """``state`` (written at ``version``; None means unversioned) in the current shape.
+
+    Returns a new dict; the input is left untouched.
+    """
+    version = UNVERSIONED if version is None else version
+    if version > STATE_VERSION:
+        raise StateVersionError(
+            f"Workflow state version {version} is newer than this Hydra "
+            f"(supports up to {STATE_VERSION})"
+        )
+    upgraded = copy.deepcopy(state or {})
+    while version < STATE_VERSION:
+        upgraded = MIGRATIONS[version](upgraded)
+        version += 1
+    return upgraded
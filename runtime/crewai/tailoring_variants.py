@@ -0,0 +1,223 @@
+"""Parallel A/B tailoring: several models write the documents, one version wins.
+
+The tailoring stage produces what a hiring manager reads, and models differ most
+there — in voice more than in facts. With ``--tailoring-models a,b`` the workflow
+runs the tailoring agent once per ``provider:model`` spec, concurrently, keeps every
+candidate, and picks a winner either:
+
+- ``audit`` — each candidate résumé goes through the auditor; an approved candidate
+  beats a rejected one, fewer blocking findings beat more, and remaining ties go to
+  the model that has won most often before; or
+- ``ask`` — the candidates are shown as diffs against the first and the user picks.
+
+Only the winner flows on to ATS optimization and the audit; the others are written
+next to the run (``variants/``) for reference. Each win is recorded in
+``$HYDRA_HOME/tailoring_preferences.json`` so future runs can default to the model
+that keeps winning (see ``VariantPreferences.preferred``).
+"""
+
+from __future__ import annotations
+
+import difflib
+import json
+import os
+import re
+import threading
+from dataclasses import dataclass, field
+from pathlib import Path
+from typing import Any, Callable, Dict, List, Optional
+
+from runtime.crewai.cancellation import CancelToken
+from runtime.crewai.contracts import AuditVerdict, TailoredDocuments
+from runtime.crewai.llm_client import complete_batch
+from runtime.crewai.retro_audit import blocking_issues
+from runtime.crewai.stage_cache import hydra_home
+
+PICK_AUDIT = "audit"
+PICK_ASK = "ask"
+PICK_MODES = (PICK_AUDIT, PICK_ASK)
+
+PREFERENCES_FILE = "tailoring_preferences.json"
+# Wins needed before a model is suggested as the default tailoring model.
+MIN_WINS_FOR_DEFAULT = 3
+# Concurrent runs in one process (the web backend) record wins into the same file.
+_PREFERENCES_LOCK = threading.Lock()
+
+
+@dataclass
+class TailoringCandidate:
+    """One model's tailoring output and, once judged, its audit outcome."""
+
+    spec: str
+    result: Optional[Dict[str, Any]] = None
+    error: Optional[str] = None
+    approved: Optional[bool] = None
+    blocking: List[str] = field(default_factory=list)
+    winner: bool = False
+
+    @property
+    def documents(self) -> TailoredDocuments:
+        return TailoredDocuments.from_raw(self.result or {})
+
+    @property
+    def slug(self) -> str:
+        """Filesystem-safe name for the spec (used for ``variants/`` filenames)."""
+        return spec_slug(self.spec)
+
+    def rank(self) -> tuple:
+        """Sort key for audit picking: approved first, then fewest blocking findings."""
+        return (self.approved is True, -len(self.blocking))
+
+    def summary(self) -> Dict[str, Any]:
+        """PII-free description for the manifest."""
+        return {
+            "model": self.spec,
+            "winner": self.winner,
+            "approved": self.approved,
+            "blocking": len(self.blocking),
+            "error": self.error,
+        }
+
+
+def spec_slug(spec: str) -> str:
+    """Filesystem-safe name for a model spec."""
+    return re.sub(r"[^A-Za-z0-9._-]+", "-", spec).strip("-")
+
+
+def run_variants(
+    specs: List[str],
+    execute: Callable[[str], Dict[str, Any]],
+    max_workers: Optional[int] = None,
+    cancel_token: Optional[CancelToken] = None,
+) -> List[TailoringCandidate]:
+    """Run ``execute(spec)`` for every spec concurrently; failures become candidates
+    with ``error`` set rather than aborting the others. Order follows ``specs``."""
+    candidates = [TailoringCandidate(spec=spec) for spec in specs]
+    results = complete_batch(
+        [lambda spec=spec: execute(spec) for spec in specs], cancel_token, max_workers
+    )
+    for candidate, result in zip(candidates, results):
+        candidate.result = result.value
+        if not result.ok:
+            err = result.error
+            candidate.error = str(err).splitlines()[0][:200] if str(err) else type(err).__name__
+    return candidates
+
+
+def judge_by_audit(
+    candidates: List[TailoringCandidate], audit: Callable[[str], Dict[str, Any]]
+) -> None:
+    """Audit each successful candidate's résumé, filling ``approved``/``blocking``.
+
+    An audit call that errors leaves the candidate unjudged (``approved`` None),
+    which ranks below any judged candidate.
+    """
+    for candidate in candidates:
+        if candidate.error or not candidate.documents.resume:
+            continue
+        try:
+            report = audit(candidate.documents.resume)
+        except Exception:
+            continue
+        candidate.approved = AuditVerdict.from_raw(report).approved
+        candidate.blocking = blocking_issues(report)
+
+
+def pick_by_audit(candidates: List[TailoringCandidate]) -> Optional[TailoringCandidate]:
+    """Best-ranked successful candidate; ties keep ``candidates`` order."""
+    usable = [c for c in candidates if not c.error and c.result is not None]
+    if not usable:
+        return None
+    best = usable[0]
+    for candidate in usable[1:]:
+        if candidate.rank() > best.rank():
+            best = candidate
+    return best
+
+
+def render_comparison(candidates: List[TailoringCandidate]) -> str:
+    """Unified diffs of each candidate résumé against the first usable one."""
+    usable = [c for c in candidates if not c.error and c.result is not None]
+    if not usable:
+        return ""
+    base = usable[0]
+    parts = [f"[1] {base.spec} (baseline)"]
+    for index, candidate in enumerate(usable[1:], start=2):
+        diff = difflib.unified_diff(
+            base.documents.resume.splitlines(),
+            candidate.documents.resume.splitlines(),
+            fromfile=f"[1] {base.spec}",
+            tofile=f"[{index}] {candidate.spec}",
+            lineterm="",
+        )
+        parts.append("\n".join(diff) or f"[{index}] {candidate.spec}: identical résumé")
+    return "\n\n".join(parts)
+
+
+def pick_interactively(
+    candidates: List[TailoringCandidate], input_fn: Callable[[str], str] = input
+) -> Optional[TailoringCandidate]:
+    """Show the diffs and let the user choose; EOF/blank keeps the audit pick."""
+    usable = [c for c in candidates if not c.error and c.result is not None]
+    if not usable:
+        return None
+    print("\n" + render_comparison(candidates))
+    for index, candidate in enumerate(usable, start=1):
+        verdict = {True: "approved", False: "rejected", None: "unjudged"}[candidate.approved]
+        blocking = len(candidate.blocking)
+        print(f"  [{index}] {candidate.spec} — audit {verdict}, {blocking} blocking")
+    fallback = pick_by_audit(candidates)
+    while True:
+        try:
+            prompt = f"\n❓ Pick a version 1-{len(usable)} (Enter = audit pick): "
+            answer = input_fn(prompt).strip()
+        except EOFError:
+            return fallback
+        if not answer:
+            return fallback
+        if answer.isdigit() and 1 <= int(answer) <= len(usable):
+            return usable[int(answer) - 1]
+
+
+class VariantPreferences:
+    """Win/comparison counts per model spec, persisted under ``$HYDRA_HOME``."""
+
+    def __init__(self, path: Optional[Path] = None):
+        self.path = Path(path) if path is not None else hydra_home() / PREFERENCES_FILE
+
+    def load(self) -> Dict[str, Dict[str, int]]:
+        try:
+            data = json.loads(self.path.read_text())
+        except (OSError, ValueError):
+            return {}
+        return data if isinstance(data, dict) else {}
+
+    def record(self, winner: str, contenders: List[str]) -> None:
+        """Count one comparison for every contender and a win for ``winner``."""
+        with _PREFERENCES_LOCK:
+            data = self.load()
+            for spec in contenders:
+                entry = data.setdefault(spec, {"wins": 0, "runs": 0})
+                entry["runs"] = entry.get("runs", 0) + 1
+                if spec == winner:
+                    entry["wins"] = entry.get("wins", 0) + 1
+            try:
+                self.path.parent.mkdir(parents=True, exist_ok=True, mode=0o700)
+                tmp = self.path.with_suffix(".tmp")
+                tmp.write_text(json.dumps(data, indent=2, sort_keys=True))
+                os.replace(tmp, self.path)
+            except OSError:
+                pass  # preferences are advisory; never fail a run over them
+
+    def ranked(self, specs: List[str]) -> List[str]:
+        """``specs`` ordered by past wins (stable for ties)."""
+        data = self.load()
+        return sorted(specs, key=lambda s: -data.get(s, {}).get("wins", 0))
+
+    def preferred(self) -> Optional[str]:
+        """Spec with the most wins, once it has at least MIN_WINS_FOR_DEFAULT."""
+        data = self.load()
+        if not data:
+            return None
+        spec, entry = max(data.items(), key=lambda item: item[1].get("wins", 0))
+        return spec if entry.get("wins", 0) >= MIN_WINS_FOR_DEFAULT else None
@@ -0,0 +1,150 @@
+"""Per-stage and per-LLM-call timeouts, so one hung call cannot stall a run forever.
+
+A provider that accepts a request and never answers blocks its stage, and with it
+the run, indefinitely. ``TimeoutPolicy`` bounds both levels:
+
+- ``llm_call``: one model call (one attempt). A call that overruns is abandoned — its
+  thread finishes in the background and its answer is discarded, as with
+  cancellation — and counts as a failed attempt, so the agent's retries apply;
+- ``stage``: one agent's whole stage on one model, retries and tool rounds included.
+  A stage that overruns fails like any other stage error: the workflow's fallback
+  model gets one more try with a fresh timeout, and optional stages are skipped.
+
+Defaults suit the slowest configured models; the pipeline config overrides them,
+per stage if need be (see runtime.crewai.pipeline_config). A value of 0 or null
+turns that timeout off. Every timeout is recorded as a ``WorkflowError`` on the
+result, whether or not a retry then succeeded.
+"""
+
+from __future__ import annotations
+
+import threading
+from dataclasses import dataclass, field
+from typing import Any, Callable, Dict, Mapping, Optional
+
+DEFAULT_LLM_CALL_SECONDS = 180.0
+DEFAULT_STAGE_SECONDS = 600.0
+# Stages that make several calls by design (tool rounds, cover-letter rewrites).
+DEFAULT_STAGE_TIMEOUTS: Dict[str, float] = {"tailoring": 900.0}
+
+SCOPE_LLM_CALL = "llm_call"
+SCOPE_STAGE = "stage"
+
+# Stage names as the pipeline config writes them; the workflow runs some stages
+# under an agent's name.
+STAGES = (
+    "research",
+    "gap_analysis",
+    "seniority",
+    "impact",
+    "interrogation",
+    "differentiation",
+    "tailoring",
+    "ats_optimization",
+    "audit",
+    "executive_synthesis",
+    "compensation",
+    "outreach",
+    "referrals",
+    "judge",
+)
+_STAGE_ALIASES = {"research_agent": "research", "auditor_suite": "audit"}
+
+
+def config_stage(stage: str) -> str:
+    """``stage`` (a workflow stage or agent name) as the pipeline config writes it."""
+    return _STAGE_ALIASES.get(stage, stage)
+
+
+class TimeoutExceeded(Exception):
+    """Raised when a model call or a stage runs past its timeout."""
+
+    def __init__(self, stage: str, seconds: float, scope: str = SCOPE_STAGE):
+        what = "LLM call" if scope == SCOPE_LLM_CALL else "stage"
+        super().__init__(f"{what} for {stage} timed out after {seconds:g}s")
+        self.stage = stage
+        self.seconds = seconds
+        self.scope = scope
+
+
+def _seconds(value: Any, where: str) -> Optional[float]:
+    """A timeout from config: positive seconds, or None for "no timeout"."""
+    if value is None:
+        return None
+    if isinstance(value, bool) or not isinstance(value, (int, float)) or value < 0:
+        raise ValueError(f"{where} must be a number of seconds (0 or null for none)")
+    return float(value) or None
+
+
+@dataclass(frozen=True)
+class TimeoutPolicy:
+    """Timeouts for one model call and for each stage; None means no timeout."""
+
+    llm_call: Optional[float] = DEFAULT_LLM_CALL_SECONDS
+    stage: Optional[float] = DEFAULT_STAGE_SECONDS
+    stages: Mapping[str, Optional[float]] = field(
+        default_factory=lambda: dict(DEFAULT_STAGE_TIMEOUTS)
+    )
+
+    def for_stage(self, stage: str) -> Optional[float]:
+        """The timeout for ``stage`` (a workflow stage or agent name)."""
+        name = config_stage(stage)
+        return self.stages[name] if name in self.stages else self.stage
+
+    @classmethod
+    def from_dict(cls, data: Mapping[str, Any]) -> "TimeoutPolicy":
+        """``{"llm_call": 120, "stage": 600, "stages": {"audit": 300}}``; omitted keys
+        keep their defaults. Raises ValueError on unknown stages or bad values."""
+        unknown = set(data) - {"llm_call", "stage", "stages"}
+        if unknown:
+            raise ValueError(f"unknown timeout setting(s): {', '.join(sorted(unknown))}")
+        stages = data.get("stages") or {}
+        if not isinstance(stages, Mapping):
+            raise ValueError("timeouts.stages must map stage names to seconds")
+        unknown = set(stages) - set(STAGES)
+        if unknown:
+            raise ValueError(
+                f"unknown stage(s) in timeouts.stages: {', '.join(sorted(unknown))} "
+                f"(expected: {', '.join(STAGES)})"
+            )
+        default = cls()
+        llm_call, stage = default.llm_call, default.stage
+        if "llm_call" in data:
+            llm_call = _seconds(data["llm_call"], "timeouts.llm_call")
+        if "stage" in data:
+            stage = _seconds(data["stage"], "timeouts.stage")
+        overrides = {
+            name: _seconds(value, f"timeouts.stages.{name}") for name, value in stages.items()
+        }
+        return cls(llm_call, stage, {**default.stages, **overrides})
+
+    def to_dict(self) -> Dict[str, Any]:
+        return {"llm_call": self.llm_call, "stage": self.stage, "stages": dict(self.stages)}
+
+
+def run_with_timeout(
+    stage: str, seconds: Optional[float], fn: Callable[[], Any], scope: str = SCOPE_STAGE
+) -> Any:
+    """Run ``fn``, waiting at most ``seconds`` (no limit when None).
+
+    A call that overruns is abandoned on its worker thread and ``TimeoutExceeded``
+    is raised.
+    """
+    if seconds is None:
+        return fn()
+    outcome: Dict[str, Any] = {}
+
+    def _target() -> None:
+        try:
+            outcome["result"] = fn()
+        except BaseException as e:  # re-raised on the caller's thread
+            outcome["error"] = e
+
+    worker = threading.Thread(target=_target, daemon=True)
+    worker.start()
+    worker.join(seconds)
+    if worker.is_alive():
+        raise TimeoutExceeded(stage, seconds, scope)
+    if "error" in outcome:
+        raise outcome["error"]
+    return outcome["result"]
@@ -0,0 +1,95 @@
+"""Token counting per model: exact for OpenAI models, a per-provider estimate otherwise.
+
+Everything in a run that reasons about prompt size goes through here: fitting each
+agent's context into its model's window (``context_window``), the ``--dry-run`` cost
+estimate (``dry_run``), and the token side of the per-provider rate limits
+(``rate_limit``, charged from ``base_agent`` and the translation and WebAssembly
+plugin calls). Agents use it too, to cut source material down to a token budget::
+
+    from runtime.crewai.tokenizer import count_tokens, truncate_to_tokens
+
+    if count_tokens(sources, self.llm.model) > 20_000:
+        sources = truncate_to_tokens(sources, 20_000, self.llm.model)
+
+``tokenizer_for(model)`` picks the tokenizer for a LiteLLM model string (provider
+prefixes such as ``openai/`` or ``openrouter/openai/`` are ignored):
+
+- OpenAI models (``gpt-*``, ``chatgpt-*``, the ``o`` series) are counted with their
+  own BPE vocabulary through tiktoken, ``o200k_base`` or ``cl100k_base``. tiktoken is
+  optional (``pip install tiktoken``; LiteLLM normally brings it) and downloads the
+  vocabulary on first use; without it these models fall back to the estimate.
+- Every other model is estimated from its length: characters over a ratio for the
+  model family (``HEURISTIC_RATIOS``; 3.5 for Claude), else 4. Providers do not
+  publish their tokenizers, so this is the best available without an API call.
+- No model at all gets the plain 4-characters-per-token estimate.
+
+Counts are for the text alone; chat framing adds a few tokens per message.
+"""
+
+from __future__ import annotations
+
+import logging
+from functools import lru_cache
+from typing import Optional
+
+from runtime.crewai.tokenizer.base import DEFAULT_CHARS_PER_TOKEN, HeuristicTokenizer, Tokenizer
+from runtime.crewai.tokenizer.bpe import TiktokenTokenizer
+
+logger = logging.getLogger(__name__)
+
+# Characters per token for model families that differ from the default, by a
+# substring of the bare model name; the first match wins.
+HEURISTIC_RATIOS = (("claude", 3.5),)
+
+OPENAI_PREFIXES = ("gpt-", "chatgpt-", "o1", "o3", "o4")
+
+DEFAULT_TOKENIZER = HeuristicTokenizer(DEFAULT_CHARS_PER_TOKEN)
+
+__all__ = [
+    "DEFAULT_CHARS_PER_TOKEN",
+    "DEFAULT_TOKENIZER",
+    "HEURISTIC_RATIOS",
+    "HeuristicTokenizer",
+    "TiktokenTokenizer",
+    "Tokenizer",
+    "count_tokens",
+    "is_openai_model",
+    "tokenizer_for",
+    "truncate_to_tokens",
+]
+
+
+def _bare_name(model: str) -> str:
+    return model.strip().lower().rsplit("/", 1)[-1]
+
+
+def is_openai_model(model: Optional[str]) -> bool:
+    """True for models that use an OpenAI BPE vocabulary."""
+    return isinstance(model, str) and _bare_name(model).startswith(OPENAI_PREFIXES)
+
+
+@lru_cache(maxsize=64)
+def tokenizer_for(model: Optional[str] = None) -> Tokenizer:
+    """The tokenizer for ``model`` (a LiteLLM model string); see the module docs."""
+    if not isinstance(model, str) or not model.strip():
+        return DEFAULT_TOKENIZER
+    name = _bare_name(model)
+    if is_openai_model(model):
+        try:
+            return TiktokenTokenizer(name)
+        except Exception as e:  # not installed, or the vocabulary cannot be fetched
+            logger.debug("tiktoken unavailable for %s (%s); estimating tokens", model, e)
+    for family, ratio in HEURISTIC_RATIOS:
+        if family in name:
+            return HeuristicTokenizer(ratio)
+    return DEFAULT_TOKENIZER
+
+
+def count_tokens(text: str, model: Optional[str] = None) -> int:
+    """Tokens ``model`` would see in ``text``."""
+    return tokenizer_for(model).count(text)
+
+
+def truncate_to_tokens(text: str, max_tokens: int, model: Optional[str] = None) -> str:
+    """``text`` cut to at most ``max_tokens`` of ``model``'s tokens; whole if it fits."""
+    return tokenizer_for(model).truncate(text, max_tokens)
@@ -0,0 +1,56 @@
+"""The tokenizer interface, and the character-ratio tokenizer every model falls back to."""
+
+from __future__ import annotations
+
+from abc import ABC, abstractmethod
+
+DEFAULT_CHARS_PER_TOKEN = 4.0
+
+
+class Tokenizer(ABC):
+    """Counts a model's tokens in text, and cuts text down to a number of them."""
+
+    name: str
+
+    @property
+    def exact(self) -> bool:
+        """True when counts come from the model's own vocabulary, not an estimate."""
+        return False
+
+    @abstractmethod
+    def count(self, text: str) -> int:
+        """Tokens in ``text``."""
+
+    @abstractmethod
+    def truncate(self, text: str, max_tokens: int) -> str:
+        """The longest prefix of ``text`` that is at most ``max_tokens`` tokens."""
+
+
+class HeuristicTokenizer(Tokenizer):
+    """Estimates tokens as characters over a fixed ratio, rounded up.
+
+    Good to about ±15% on English prose for the models it stands in for; code and
+    non-Latin scripts take more tokens per character than it assumes.
+    """
+
+    def __init__(self, chars_per_token: float = DEFAULT_CHARS_PER_TOKEN):
+        if chars_per_token <= 0:
+            raise ValueError("chars_per_token must be positive")
+        self.chars_per_token = chars_per_token
+        self.name = f"heuristic:{chars_per_token:g}"
+
+    def count(self, text: str) -> int:
+        if not text:
+            return 0
+        # Integral ratios keep exact integer arithmetic: ceil(len / ratio).
+        if float(self.chars_per_token).is_integer():
+            return -(-len(text) // int(self.chars_per_token))
+        return -int(-len(text) // self.chars_per_token)
+
+    def truncate(self, text: str, max_tokens: int) -> str:
+        if max_tokens <= 0:
+            return ""
+        return text[: int(max_tokens * self.chars_per_token)]
+
+    def __repr__(self) -> str:
+        return f"HeuristicTokenizer({self.chars_per_token:g})"
@@ -0,0 +1,53 @@
+"""Exact token counts for OpenAI models, from their BPE vocabularies via tiktoken."""
+
+from __future__ import annotations
+
+from typing import Any
+
+from runtime.crewai.tokenizer.base import Tokenizer
+
+# Families newer than the installed tiktoken may know by name.
+O200K_PREFIXES = ("gpt-4o", "gpt-4.1", "gpt-4.5", "gpt-5", "chatgpt-4o", "o1", "o3", "o4")
+
+
+def encoding_name(model: str) -> str:
+    """The tiktoken encoding ``model`` (a bare OpenAI model name) uses."""
+    import tiktoken
+
+    try:
+        return tiktoken.encoding_name_for_model(model)
+    except KeyError:
+        return "o200k_base" if model.startswith(O200K_PREFIXES) else "cl100k_base"
+
+
+class TiktokenTokenizer(Tokenizer):
+    """Counts with tiktoken. Needs the optional ``tiktoken`` package, which loads
+    (and on first use downloads) the encoding; construction raises if it cannot."""
+
+    def __init__(self, model: str):
+        import tiktoken
+
+        self.encoding_name = encoding_name(model)
+        self._encoding: Any = tiktoken.get_encoding(self.encoding_name)
+        self.name = f"tiktoken:{self.encoding_name}"
+
+    @property
+    def exact(self) -> bool:
+        return True
+
+    def count(self, text: str) -> int:
+        if not text:
+            return 0
+        return len(self._encoding.encode(text, disallowed_special=()))
+
+    def truncate(self, text: str, max_tokens: int) -> str:
+        if max_tokens <= 0:
+            return ""
+        tokens = self._encoding.encode(text, disallowed_special=())
+        if len(tokens) <= max_tokens:
+            return text
+        # A cut inside a multi-byte character decodes to U+FFFD; drop it.
+        return self._encoding.decode(tokens[:max_tokens]).rstrip("�")
+
+    def __repr__(self) -> str:
+        return f"TiktokenTokenizer({self.encoding_name!r})"
@@ -0,0 +1,300 @@
+"""Tools agents can call while a stage runs.
+
+A ``Tool`` has a name, a description, a JSON-schema ``parameters`` object and an
+``execute(arguments)`` that returns something JSON-serialisable. An agent given tools
+(``BaseHydraAgent.tools``) runs a bounded loop: each round the model either returns
+``{"tool_calls": [{"tool": ..., "arguments": {...}}]}`` or its normal output;
+software validates and runs the calls, appends the results to the next round's prompt,
+and keeps a transcript of every call. The protocol is plain JSON rather than a
+provider's native function calling, so it works the same for every model in
+``model_config`` — including those served without function-calling support.
+
+Built-in tools: ``read_file`` (sandboxed to one directory), ``web_search`` (wraps a
+``web_search.SearchProvider``), ``ats_score`` (the simulated ATS parse) and
+``calculator`` (arithmetic only, evaluated without ``eval``).
+"""
+
+from __future__ import annotations
+
+import ast
+import json
+import operator
+from abc import ABC, abstractmethod
+from pathlib import Path
+from typing import Any, Dict, List, Optional
+
+from runtime.crewai.ats_parse_check import parse_resume
+from runtime.crewai.web_search import SearchError, SearchProvider
+
+TOOL_TRANSCRIPT_FILE = "tool_transcript.json"
+
+DEFAULT_TOOL_ROUNDS = 3
+MAX_TOOL_CALLS_PER_ROUND = 4
+# A tool result longer than this is truncated before it goes back into the prompt.
+MAX_RESULT_CHARS = 6000
+
+_JSON_TYPES = {
+    "string": str,
+    "integer": int,
+    "number": (int, float),
+    "boolean": bool,
+    "array": list,
+    "object": dict,
+}
+
+
+class ToolError(Exception):
+    """Raised when a tool call is malformed or the tool cannot complete it."""
+
+    pass
+
+
+class Tool(ABC):
+    """A capability an agent can invoke during its stage."""
+
+    name: str = ""
+    description: str = ""
+    # JSON schema of the arguments object.
+    parameters: Dict[str, Any] = {"type": "object", "properties": {}}
+
+    @abstractmethod
+    def execute(self, arguments: Dict[str, Any]) -> Any:
+        """Run the tool; raise ToolError for a call that cannot be served."""
+
+    def spec(self) -> Dict[str, Any]:
+        return {"name": self.name, "description": self.description, "parameters": self.parameters}
+
+
+def validate_arguments(schema: Dict[str, Any], arguments: Any) -> Dict[str, Any]:
+    """Check required keys and top-level types; returns the arguments."""
+    if not isinstance(arguments, dict):
+        raise ToolError("arguments must be a JSON object")
+    for key in schema.get("required", []):
+        if key not in arguments:
+            raise ToolError(f"missing required argument: {key}")
+    for key, value in arguments.items():
+        expected = (schema.get("properties") or {}).get(key, {}).get("type")
+        python_type = _JSON_TYPES.get(expected)
+        if python_type and (
+            not isinstance(value, python_type)
+            or (expected in ("integer", "number") and isinstance(value, bool))
+        ):
+            raise ToolError(f"argument {key} must be {expected}")
+    return arguments
+
+
+def parse_tool_calls(output: Dict[str, Any]) -> List[Dict[str, Any]]:
+    """The tool calls a model output asks for (empty when it is a final answer)."""
+    raw = output.get("tool_calls") or []
+    if isinstance(raw, dict):
+        raw = [raw]
+    calls = []
+    for call in raw if isinstance(raw, list) else []:
+        if isinstance(call, dict) and (call.get("tool") or call.get("name")):
+            calls.append(
+                {
+                    "tool": str(call.get("tool") or call.get("name")),
+                    "arguments": call.get("arguments") or {},
+                }
+            )
+    return calls[:MAX_TOOL_CALLS_PER_ROUND]
+
+
+def run_tool_call(tools: List[Tool], call: Dict[str, Any], round_number: int) -> Dict[str, Any]:
+    """Run one call; returns its transcript entry (``result`` or ``error``)."""
+    entry: Dict[str, Any] = {
+        "round": round_number,
+        "tool": call["tool"],
+        "arguments": call["arguments"],
+    }
+    tool = next((t for t in tools if t.name == call["tool"]), None)
+    try:
+        if tool is None:
+            raise ToolError(f"unknown tool: {call['tool']}")
+        entry["result"] = tool.execute(validate_arguments(tool.parameters, call["arguments"]))
+    except ToolError as e:
+        entry["error"] = str(e)
+    return entry
+
+
+def render_tool_prompt(tools: List[Tool], transcript: List[Dict[str, Any]], final: bool) -> str:
+    """The tool section appended to a stage's task description each round."""
+    calls = []
+    for entry in transcript:
+        if "error" in entry:
+            outcome = f"ERROR: {entry['error']}"
+        else:
+            outcome = json.dumps(entry["result"], ensure_ascii=False, default=str)
+            if len(outcome) > MAX_RESULT_CHARS:
+                outcome = outcome[:MAX_RESULT_CHARS] + " …[truncated]"
+        calls.append(
+            f"[{entry['round']}] {entry['tool']}({json.dumps(entry['arguments'])}) → {outcome}"
+        )
+    history = "\n".join(calls) or "None yet."
+    if final:
+        instruction = "Tool budget used up: return your final output now, without tool_calls."
+    else:
+        catalogue = json.dumps([tool.spec() for tool in tools], indent=2)
+        instruction = (
+            f"You may call these tools before answering:\n{catalogue}\n"
+            'To call tools, return ONLY {"tool_calls": [{"tool": "<name>", "arguments": '
+            f"{{...}}}}]}} with up to {MAX_TOOL_CALLS_PER_ROUND} calls; the results will "
+            "be shown to you. Otherwise return your final output."
+        )
+    return f"""
+TOOLS
+Tool calls so far:
+{history}
+
+{instruction}
+"""
+
+
+class FileReadTool(Tool):
+    """Read a text file (or list a directory) inside one root directory."""
+
+    name = "read_file"
+    description = (
+        "Read a text file from the candidate's source documents, or list a directory. "
+        "Paths are relative to the sources directory."
+    )
+    parameters = {
+        "type": "object",
+        "properties": {"path": {"type": "string", "description": "Relative path"}},
+        "required": ["path"],
+    }
+
+    def __init__(self, root: Path, max_chars: int = 20000):
+        self.root = Path(root).resolve()
+        self.max_chars = max_chars
+
+    def execute(self, arguments: Dict[str, Any]) -> Any:
+        path = (self.root / arguments["path"]).resolve()
+        if path != self.root and self.root not in path.parents:
+            raise ToolError("path is outside the sources directory")
+        if path.is_dir():
+            return sorted(
+                str(p.relative_to(self.root)) + ("/" if p.is_dir() else "")
+                for p in path.iterdir()
+                if not p.name.startswith(".")
+            )
+        try:
+            text = path.read_text(encoding="utf-8")
+        except (OSError, UnicodeDecodeError) as e:
+            raise ToolError(f"cannot read {arguments['path']}: {e}") from e
+        return text[: self.max_chars]
+
+
+class WebSearchTool(Tool):
+    """Live web search through a configured search provider."""
+
+    name = "web_search"
+    description = "Search the web; returns titles, URLs, snippets and publication dates."
+    parameters = {
+        "type": "object",
+        "properties": {
+            "query": {"type": "string"},
+            "max_results": {"type": "integer", "description": "1-10, default 5"},
+        },
+        "required": ["query"],
+    }
+
+    def __init__(self, provider: SearchProvider):
+        self.provider = provider
+
+    def execute(self, arguments: Dict[str, Any]) -> Any:
+        limit = max(1, min(10, arguments.get("max_results", 5)))
+        try:
+            hits = self.provider.search(arguments["query"], max_results=limit)
+        except SearchError as e:
+            raise ToolError(str(e)) from e
+        return [hit.to_dict() for hit in hits]
+
+
+class ATSScoreTool(Tool):
+    """Score a résumé draft with the simulated ATS parse (see ats_parse_check)."""
+
+    name = "ats_score"
+    description = (
+        "Parse a Markdown résumé as a naive ATS would. Returns a 0-100 score, the fields "
+        "and sections it could not extract, unrecognised headings, and layout hazards. "
+        "With the job description, also the JD skills found as written, found only under "
+        "another name (e.g. JD 'K8s', résumé 'Kubernetes'), and missing."
+    )
+    parameters = {
+        "type": "object",
+        "properties": {
+            "resume": {"type": "string", "description": "Résumé Markdown"},
+            "job_description": {"type": "string", "description": "Optional JD text"},
+        },
+        "required": ["resume"],
+    }
+
+    def execute(self, arguments: Dict[str, Any]) -> Any:
+        report = parse_resume(arguments["resume"], arguments.get("job_description")).to_dict()
+        return {
+            key: report[key]
+            for key in ("score", "missing_fields", "unrecognised_headings", "hazards", "jd_skills")
+            if key in report
+        }
+
+
+_OPERATORS = {
+    ast.Add: operator.add,
+    ast.Sub: operator.sub,
+    ast.Mult: operator.mul,
+    ast.Div: operator.truediv,
+    ast.FloorDiv: operator.floordiv,
+    ast.Mod: operator.mod,
+    ast.Pow: operator.pow,
+    ast.USub: operator.neg,
+    ast.UAdd: operator.pos,
+}
+
+
+class CalculatorTool(Tool):
+    """Exact arithmetic, so percentages and totals are computed rather than guessed."""
+
+    name = "calculator"
+    description = "Evaluate an arithmetic expression, e.g. (180 - 120) / 120 * 100."
+    parameters = {
+        "type": "object",
+        "properties": {"expression": {"type": "string"}},
+        "required": ["expression"],
+    }
+
+    def execute(self, arguments: Dict[str, Any]) -> Any:
+        try:
+            value = self._eval(ast.parse(arguments["expression"], mode="eval").body)
+        except (SyntaxError, ZeroDivisionError, OverflowError) as e:
+            raise ToolError(f"cannot evaluate: {e}") from e
+        return round(value, 10) if isinstance(value, float) else value
+
+    def _eval(self, node: ast.AST) -> Any:
+        if isinstance(node, ast.Constant) and isinstance(node.value, (int, float)):
+            return node.value
+        if isinstance(node, ast.BinOp) and type(node.op) in _OPERATORS:
+            left, right = self._eval(node.left), self._eval(node.right)
+            if isinstance(node.op, ast.Pow) and abs(right) > 100:
+                raise ToolError("exponent too large")
+            return _OPERATORS[type(node.op)](left, right)
+        if isinstance(node, ast.UnaryOp) and type(node.op) in _OPERATORS:
+            return _OPERATORS[type(node.op)](self._eval(node.operand))
+        raise ToolError("only numbers and + - * / // % ** are allowed")
+
+
+def default_toolsets(sources_dir: Optional[Path] = None) -> Dict[str, List[Tool]]:
+    """Tools per agent type for ``--tools``.
+
+    ``web_search`` is deliberately absent: these agents hold the résumé, and a query
+    they compose could carry it to a search provider. Only the Researcher searches.
+    """
+    files: List[Tool] = [FileReadTool(sources_dir)] if sources_dir else []
+    calculator = CalculatorTool()
+    return {
+        "gap_analyzer": [*files],
+        "differentiator": [*files, calculator],
+        "tailoring_agent": [*files, calculator],
+        "ats_optimizer": [ATSScoreTool(), calculator],
+        "auditor_suite": [*files, calculator],
+    }
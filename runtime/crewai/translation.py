@@ -0,0 +1,237 @@
+"""Translation stage: a second-language version of the final documents.
+
+Some markets (Switzerland, Belgium, Canada, much of the Gulf) expect an application
+in two languages. This stage runs *after* the audit, on the audited documents, so the
+translation never introduces claims the audit did not see.
+
+Providers are pluggable behind ``TranslationProvider``: DeepL (``DEEPL_API_KEY``) or
+an LLM via LiteLLM. Terminology consistency is enforced by software, not by asking
+nicely: every glossary term is swapped for an opaque placeholder before the text is
+sent, and the placeholder is replaced with the glossary's target term afterwards, so
+"on-call" becomes the same word in the résumé and the cover letter every time. Any
+placeholder the provider dropped or mangled is reported as a glossary issue.
+"""
+
+from __future__ import annotations
+
+import json
+import os
+import re
+import urllib.request
+from abc import ABC, abstractmethod
+from dataclasses import dataclass, field
+from pathlib import Path
+from typing import Any, Dict, List, Optional
+
+import yaml
+
+from runtime.crewai.context_window import estimate_tokens
+from runtime.crewai.gateway import GatewayLLM
+from runtime.crewai.rate_limit import provider_of, shared_limiter
+
+DEEPL_API_KEY_ENV = "DEEPL_API_KEY"
+DEEPL_FREE_URL = "https://api-free.deepl.com/v2/translate"
+DEEPL_PRO_URL = "https://api.deepl.com/v2/translate"
+
+PLACEHOLDER_FORMAT = "[[G{index}]]"
+_PLACEHOLDER_RE = re.compile(r"\[\[G(\d+)\]\]")
+
+PROVIDERS = ("llm", "deepl")
+
+
+class TranslationError(Exception):
+    """Raised when a translation provider is misconfigured or its call fails."""
+
+    pass
+
+
+class TranslationProvider(ABC):
+    """Translates plain/Markdown text into a target language."""
+
+    name: str = ""
+
+    @abstractmethod
+    def translate(self, text: str, target_language: str) -> str:
+        """Return ``text`` translated into ``target_language`` (ISO 639-1 code)."""
+
+
+class DeepLProvider(TranslationProvider):
+    """DeepL REST API. Free-tier keys (suffix ``:fx``) use the free endpoint."""
+
+    name = "deepl"
+
+    def __init__(self, api_key: Optional[str] = None, timeout: float = 60.0):
+        self.api_key = api_key or os.environ.get(DEEPL_API_KEY_ENV)
+        if not self.api_key:
+            raise TranslationError(f"{DEEPL_API_KEY_ENV} not set")
+        self.url = DEEPL_FREE_URL if self.api_key.endswith(":fx") else DEEPL_PRO_URL
+        self.timeout = timeout
+
+    def translate(self, text: str, target_language: str) -> str:
+        payload = json.dumps(
+            {
+                "text": [text],
+                "target_lang": target_language.upper(),
+                "preserve_formatting": True,
+            }
+        ).encode("utf-8")
+        request = urllib.request.Request(
+            self.url,
+            data=payload,
+            headers={
+                "Authorization": f"DeepL-Auth-Key {self.api_key}",
+                "Content-Type": "application/json",
+            },
+        )
+        try:
+            with urllib.request.urlopen(request, timeout=self.timeout) as response:
+                body = json.loads(response.read().decode("utf-8"))
+        except Exception as e:
+            raise TranslationError(f"DeepL request failed: {e}") from e
+        try:
+            return body["translations"][0]["text"]
+        except (KeyError, IndexError, TypeError) as e:
+            raise TranslationError("DeepL returned an unexpected response") from e
+
+
+class LLMProvider(TranslationProvider):
+    """Translation through any LiteLLM-routable model (same credentials as the agents)."""
+
+    name = "llm"
+
+    def __init__(self, llm: Any, redactor: Any = None):
+        if llm is None:
+            raise TranslationError("No LLM available for translation")
+        self.llm = llm
+        # Optional pii_redaction.PiiRedactor: contact details are sent as placeholders.
+        self.redactor = redactor
+
+    def translate(self, text: str, target_language: str) -> str:
+        import litellm
+
+        messages = [
+            {
+                "role": "system",
+                "content": (
+                    "You are a professional translator of job-application documents. "
+                    f"Translate the user's text into the language with ISO code "
+                    f"'{target_language}'. Preserve Markdown structure, line breaks, "
+                    "names, numbers, dates, and URLs exactly. Copy every token of the "
+                    "form [[G0]], [[G1]], ... or [EMAIL_1], [PHONE_1], ... through "
+                    "unchanged. Do not add, remove, "
+                    "or embellish any claim. Return only the translated text."
+                ),
+            },
+            {"role": "user", "content": self.redactor.redact(text) if self.redactor else text},
+        ]
+        model = getattr(self.llm, "model", None)
+        prompt_tokens = sum(estimate_tokens(m["content"], model) for m in messages)
+        grant = shared_limiter().acquire(provider_of(self.llm), None, prompt_tokens)
+        try:
+            if isinstance(self.llm, GatewayLLM):
+                response = self.llm.complete(messages, temperature=0.0)
+            else:
+                response = litellm.completion(
+                    model=model,
+                    messages=messages,
+                    temperature=0.0,
+                    api_key=getattr(self.llm, "api_key", None),
+                    base_url=getattr(self.llm, "base_url", None),
+                )
+        except Exception as e:
+            raise TranslationError(f"LLM translation failed: {e}") from e
+        translated = response["choices"][0]["message"]["content"].strip()
+        if grant is not None:
+            grant.settle(prompt_tokens + estimate_tokens(translated, model))
+        return self.redactor.restore(translated) if self.redactor else translated
+
+
+def load_glossary(path: Path, target_language: str) -> Dict[str, str]:
+    """Load a ``source term -> target term`` glossary from YAML.
+
+    Either a flat mapping, or one mapping per language keyed by ISO code
+    (``{de: {...}, fr: {...}}``), in which case the ``target_language`` section is used.
+    """
+    data = yaml.safe_load(Path(path).read_text()) or {}
+    if not isinstance(data, dict):
+        raise TranslationError(f"Glossary must be a mapping: {path}")
+    section = data.get(target_language.lower(), data.get(target_language.upper()))
+    if isinstance(section, dict):
+        data = section
+    elif any(isinstance(v, dict) for v in data.values()):
+        # Per-language file without an entry for this language.
+        return {}
+    return {str(k): str(v) for k, v in data.items() if k and v is not None}
+
+
+def _protect_terms(text: str, glossary: Dict[str, str]) -> tuple[str, Dict[int, str]]:
+    """Swap glossary terms for numbered placeholders; longest terms first."""
+    used: Dict[int, str] = {}
+    terms = sorted(glossary, key=len, reverse=True)
+    for index, term in enumerate(terms):
+        pattern = re.compile(rf"(?<!\w){re.escape(term)}(?!\w)", re.IGNORECASE)
+        placeholder = PLACEHOLDER_FORMAT.format(index=index)
+        text, count = pattern.subn(placeholder, text)
+        if count:
+            used[index] = term
+    return text, used
+
+
+def _restore_terms(
+    text: str, used: Dict[int, str], glossary: Dict[str, str]
+) -> tuple[str, List[str]]:
+    """Replace placeholders with target terms; report any the provider lost."""
+    issues: List[str] = []
+    for index, term in used.items():
+        if PLACEHOLDER_FORMAT.format(index=index) not in text:
+            issues.append(f"Glossary term '{term}' was lost in translation")
+
+    def _substitute(match: re.Match) -> str:
+        term = used.get(int(match.group(1)))
+        return glossary[term] if term is not None else match.group(0)
+
+    return _PLACEHOLDER_RE.sub(_substitute, text), issues
+
+
+@dataclass
+class TranslationResult:
+    """Translated documents plus what the manifest needs to know about them."""
+
+    language: str
+    provider: str
+    documents: Dict[str, str] = field(default_factory=dict)
+    glossary_terms: int = 0
+    glossary_issues: List[str] = field(default_factory=list)
+
+
+def translate_documents(
+    documents: Dict[str, str],
+    provider: TranslationProvider,
+    target_language: str,
+    glossary: Optional[Dict[str, str]] = None,
+) -> TranslationResult:
+    """Translate each non-empty document, enforcing the glossary across all of them."""
+    glossary = glossary or {}
+    result = TranslationResult(
+        language=target_language.lower(), provider=provider.name, glossary_terms=len(glossary)
+    )
+    for name, text in documents.items():
+        if not text:
+            continue
+        protected, used = _protect_terms(text, glossary)
+        translated = provider.translate(protected, target_language)
+        translated, issues = _restore_terms(translated, used, glossary)
+        result.documents[name] = translated
+        result.glossary_issues.extend(f"{name}: {issue}" for issue in issues)
+    return result
+
+
+def get_translation_provider(
+    name: str, llm: Any = None, redactor: Any = None
+) -> TranslationProvider:
+    """Build the named provider (``llm`` or ``deepl``); ``redactor`` applies to ``llm``."""
+    if name == "deepl":
+        return DeepLProvider()
+    if name == "llm":
+        return LLMProvider(llm, redactor)
+    raise TranslationError(f"Unknown translation provider: {name}")
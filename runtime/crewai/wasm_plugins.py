@@ -0,0 +1,213 @@
+"""WebAssembly stage plugins: community agents that run sandboxed, without host access.
+
+A ``runtime: wasm`` plugin (see runtime.crewai.plugins) is a WebAssembly module run
+in-process by wasmtime (``pip install wasmtime``). Nothing of the host is linked in
+but the functions below — no WASI, so no files, network, clock or environment — and
+each of those beyond ``log`` only with the capability the ``plugin.yaml`` asks for::
+
+    name: bullet_polish
+    runtime: wasm
+    module: bullet_polish.wasm
+    after: tailoring
+    capabilities: [llm]          # llm, sources; none by default
+    memory_mb: 64                # linear memory limit (default 64)
+
+The module exports ``memory``, ``hydra_alloc(size: i32) -> i32`` (room for the host
+to write into) and ``hydra_run(ptr: i32, len: i32) -> i64``, which gets the protocol
+v1 request JSON and returns where its response JSON is, as ``ptr << 32 | len``. The
+request and response are those of subprocess plugins. Host functions, all in the
+``hydra`` import module, take a UTF-8 string by pointer and length:
+
+- ``log(ptr, len)``: a line for the run log.
+- ``llm(ptr, len) -> i64`` (capability ``llm``): ``{"messages": [{"role", "content"}]}``
+  in, ``{"content": "..."}`` or ``{"error": "..."}`` out, through the run's model and
+  rate limits; at most MAX_LLM_CALLS per run.
+- ``read_source(ptr, len) -> i64`` (capability ``sources``): a path relative to the
+  ``--sources`` directory in, ``{"content": text or listing}`` or ``{"error"}`` out.
+
+Returned strings are written through ``hydra_alloc`` and given as ``ptr << 32 | len``.
+A module that imports anything else, or a host function it was not granted, is
+refused before it runs. The plugin's ``timeout`` is enforced with epoch interruption.
+"""
+
+from __future__ import annotations
+
+import json
+import threading
+from pathlib import Path
+from typing import TYPE_CHECKING, Any, Callable, Dict, Iterable, List, Optional, Tuple
+
+from runtime.crewai.context_window import estimate_tokens
+from runtime.crewai.gateway import GatewayLLM
+from runtime.crewai.plugins import WASM_CAPABILITIES, PluginError
+from runtime.crewai.rate_limit import provider_of, shared_limiter
+from runtime.crewai.tools import FileReadTool, ToolError
+
+if TYPE_CHECKING:
+    from runtime.crewai.plugins import StagePlugin
+
+HOST_MODULE = "hydra"
+LLM, SOURCES = WASM_CAPABILITIES
+# Host function name -> the capability it needs (None: always linked).
+HOST_FUNCTIONS: Dict[str, Optional[str]] = {"log": None, "llm": LLM, "read_source": SOURCES}
+MAX_LLM_CALLS = 20
+_ROLES = ("system", "user", "assistant")
+
+
+class WasmHost:
+    """What a WebAssembly plugin may reach: the run's model and its sources directory."""
+
+    def __init__(self, llm: Any = None, sources_dir: Optional[Path] = None):
+        self.llm = llm
+        self.sources = FileReadTool(sources_dir) if sources_dir else None
+
+    def call_llm(self, request: Dict[str, Any]) -> Dict[str, Any]:
+        """One chat completion for ``{"messages": [...]}``; ``{"content"}`` or ``{"error"}``."""
+        messages = request.get("messages") if isinstance(request, dict) else None
+        if not isinstance(messages, list) or not messages or not all(
+            isinstance(m, dict) and m.get("role") in _ROLES and isinstance(m.get("content"), str)
+            for m in messages
+        ):
+            return {"error": "expected {\"messages\": [{\"role\", \"content\"}, ...]}"}
+        if self.llm is None:
+            return {"error": "no model is configured for plugins"}
+        messages = [{"role": m["role"], "content": m["content"]} for m in messages]
+        model = getattr(self.llm, "model", None)
+        prompt_tokens = sum(estimate_tokens(m["content"], model) for m in messages)
+        grant = shared_limiter().acquire(provider_of(self.llm), None, prompt_tokens)
+        try:
+            if isinstance(self.llm, GatewayLLM):
+                response = self.llm.complete(messages)
+            else:
+                import litellm
+
+                response = litellm.completion(
+                    model=model,
+                    messages=messages,
+                    temperature=getattr(self.llm, "temperature", None),
+                    api_key=getattr(self.llm, "api_key", None),
+                    base_url=getattr(self.llm, "base_url", None),
+                )
+        except Exception as e:
+            return {"error": f"model call failed: {e}"}
+        content = response["choices"][0]["message"]["content"]
+        if grant is not None:
+            grant.settle(prompt_tokens + estimate_tokens(content, model))
+        return {"content": content}
+
+    def read_source(self, path: str) -> Dict[str, Any]:
+        """A file (or directory listing) under the sources directory; or ``{"error"}``."""
+        if self.sources is None:
+            return {"error": "no sources directory"}
+        try:
+            return {"content": self.sources.execute({"path": path})}
+        except ToolError as e:
+            return {"error": str(e)}
+
+
+def check_imports(plugin: "StagePlugin", imports: Iterable[Tuple[str, str]]) -> None:
+    """Refuse a module importing anything but the host functions ``plugin`` was granted."""
+    for module, name in imports:
+        if module != HOST_MODULE or name not in HOST_FUNCTIONS:
+            raise PluginError(
+                f"{plugin.name} imports {module}.{name}; only the {HOST_MODULE} "
+                f"functions {', '.join(HOST_FUNCTIONS)} are available"
+            )
+        needed = HOST_FUNCTIONS[name]
+        if needed and needed not in plugin.capabilities:
+            raise PluginError(
+                f"{plugin.name} imports {HOST_MODULE}.{name} without the '{needed}' capability"
+            )
+
+
+def run_wasm_plugin(plugin: "StagePlugin", request: Dict[str, Any], host: WasmHost) -> Any:
+    """Run a WebAssembly plugin once: its response JSON, with ``log`` lines it sent first."""
+    try:
+        import wasmtime
+    except ImportError as e:
+        raise PluginError(
+            f"{plugin.name} is a WebAssembly plugin, which needs wasmtime: pip install wasmtime"
+        ) from e
+
+    config = wasmtime.Config()
+    config.epoch_interruption = True
+    engine = wasmtime.Engine(config)
+    try:
+        module = wasmtime.Module.from_file(engine, str(plugin.directory / plugin.module))
+    except (OSError, wasmtime.WasmtimeError) as e:
+        raise PluginError(f"{plugin.name}: cannot load {plugin.module}: {e}") from e
+    check_imports(plugin, [(imp.module, imp.name) for imp in module.imports])
+
+    store = wasmtime.Store(engine)
+    store.set_limits(memory_size=plugin.memory_mb * 1024 * 1024)
+    store.set_epoch_deadline(1)
+    log: List[str] = []
+    llm_calls = [0]
+    i32, i64 = wasmtime.ValType.i32(), wasmtime.ValType.i64()
+
+    def read(caller, ptr: int, length: int) -> str:
+        return bytes(caller["memory"].read(caller, ptr, ptr + length)).decode("utf-8", "replace")
+
+    def write(caller, value: Dict[str, Any]) -> int:
+        data = json.dumps(value, default=str).encode("utf-8")
+        ptr = caller["hydra_alloc"](caller, len(data))
+        caller["memory"].write(caller, data, ptr)
+        return (ptr << 32) | len(data)
+
+    def host_log(caller, ptr, length):
+        log.append(read(caller, ptr, length))
+
+    def host_llm(caller, ptr, length):
+        llm_calls[0] += 1
+        if llm_calls[0] > MAX_LLM_CALLS:
+            return write(caller, {"error": f"at most {MAX_LLM_CALLS} model calls per run"})
+        try:
+            payload = json.loads(read(caller, ptr, length))
+        except ValueError:
+            payload = None
+        return write(caller, host.call_llm(payload))
+
+    def host_read_source(caller, ptr, length):
+        return write(caller, host.read_source(read(caller, ptr, length)))
+
+    functions: Dict[str, Tuple[Callable[..., Any], List[Any]]] = {
+        "log": (host_log, []),
+        "llm": (host_llm, [i64]),
+        "read_source": (host_read_source, [i64]),
+    }
+    linker = wasmtime.Linker(engine)
+    for name, (func, results) in functions.items():
+        needed = HOST_FUNCTIONS[name]
+        if needed is None or needed in plugin.capabilities:
+            func_type = wasmtime.FuncType([i32, i32], results)
+            linker.define_func(HOST_MODULE, name, func_type, func, access_caller=True)
+
+    timer = threading.Timer(plugin.timeout, engine.increment_epoch)
+    timer.daemon = True
+    timer.start()
+    try:
+        instance = linker.instantiate(store, module)
+        exports = instance.exports(store)
+        try:
+            memory, alloc, run = exports["memory"], exports["hydra_alloc"], exports["hydra_run"]
+        except KeyError as e:
+            raise PluginError(f"{plugin.name} does not export {e.args[0]}") from e
+        data = json.dumps(request, default=str).encode("utf-8")
+        ptr = alloc(store, len(data))
+        memory.write(store, data, ptr)
+        packed = run(store, ptr, len(data))
+        answer = memory.read(store, packed >> 32, (packed >> 32) + (packed & 0xFFFFFFFF))
+    except (wasmtime.WasmtimeError, wasmtime.Trap) as e:
+        if not timer.is_alive():
+            raise PluginError(f"{plugin.name} did not answer within {plugin.timeout:g}s") from e
+        raise PluginError(f"{plugin.name} trapped: {e}") from e
+    finally:
+        timer.cancel()
+    try:
+        response = json.loads(bytes(answer).decode("utf-8"))
+    except ValueError as e:
+        raise PluginError(f"{plugin.name} did not answer with a JSON object") from e
+    if isinstance(response, dict) and log:
+        extra = response.get("log") or []
+        response["log"] = [*log, *(extra if isinstance(extra, list) else [extra])]
+    return response
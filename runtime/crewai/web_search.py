@@ -0,0 +1,179 @@
+"""Live web search for the research stage.
+
+The research agent cannot know this week's funding round or a company's current tech
+stack from its training data. This module gives it a search tool behind a small,
+pluggable interface: ``SearchProvider.search(query) -> [SearchResult]``, implemented
+for Tavily (``TAVILY_API_KEY``), SerpAPI (``SERPAPI_API_KEY``) and Brave Search
+(``BRAVE_SEARCH_API_KEY``). ``get_search_provider`` picks the named provider, or the
+first one with a key configured.
+
+Results are normalised to title / URL / snippet / date so the agent cites them the
+same way whichever provider answered. Calls use the standard library only, like the
+DeepL translation provider.
+"""
+
+from __future__ import annotations
+
+import json
+import os
+import urllib.parse
+import urllib.request
+from abc import ABC, abstractmethod
+from dataclasses import asdict, dataclass
+from typing import Any, Dict, List, Optional
+
+TAVILY = "tavily"
+SERPAPI = "serpapi"
+BRAVE = "brave"
+PROVIDERS = (TAVILY, SERPAPI, BRAVE)
+
+SEARCH_API_KEYS: Dict[str, str] = {
+    TAVILY: "TAVILY_API_KEY",
+    SERPAPI: "SERPAPI_API_KEY",
+    BRAVE: "BRAVE_SEARCH_API_KEY",
+}
+
+TAVILY_URL = "https://api.tavily.com/search"
+SERPAPI_URL = "https://serpapi.com/search.json"
+BRAVE_URL = "https://api.search.brave.com/res/v1/web/search"
+
+DEFAULT_MAX_RESULTS = 5
+
+
+class SearchError(Exception):
+    """Raised when a search provider is misconfigured or its call fails."""
+
+    pass
+
+
+@dataclass
+class SearchResult:
+    """One search hit, normalised across providers."""
+
+    title: str
+    url: str
+    snippet: str = ""
+    published: Optional[str] = None
+
+    def to_dict(self) -> Dict[str, Any]:
+        return asdict(self)
+
+
+class SearchProvider(ABC):
+    """Runs a web search and returns normalised results."""
+
+    name: str = ""
+
+    def __init__(self, api_key: Optional[str] = None, timeout: float = 20.0):
+        self.api_key = api_key or os.environ.get(SEARCH_API_KEYS[self.name])
+        if not self.api_key:
+            raise SearchError(f"{SEARCH_API_KEYS[self.name]} not set")
+        self.timeout = timeout
+
+    @abstractmethod
+    def search(self, query: str, max_results: int = DEFAULT_MAX_RESULTS) -> List[SearchResult]:
+        """Return up to ``max_results`` results for ``query``."""
+
+    def _fetch(self, request: urllib.request.Request) -> Dict[str, Any]:
+        try:
+            with urllib.request.urlopen(request, timeout=self.timeout) as response:
+                body = json.loads(response.read().decode("utf-8"))
+        except Exception as e:
+            raise SearchError(f"{self.name} search failed: {e}") from e
+        if not isinstance(body, dict):
+            raise SearchError(f"{self.name} returned an unexpected response")
+        return body
+
+
+class TavilyProvider(SearchProvider):
+    """Tavily search API (built for LLM agents; returns extracted content)."""
+
+    name = TAVILY
+
+    def search(self, query: str, max_results: int = DEFAULT_MAX_RESULTS) -> List[SearchResult]:
+        payload = json.dumps({"query": query, "max_results": max_results}).encode("utf-8")
+        request = urllib.request.Request(
+            TAVILY_URL,
+            data=payload,
+            headers={
+                "Authorization": f"Bearer {self.api_key}",
+                "Content-Type": "application/json",
+            },
+        )
+        body = self._fetch(request)
+        return [
+            SearchResult(
+                title=str(hit.get("title", "")),
+                url=str(hit.get("url", "")),
+                snippet=str(hit.get("content", "")),
+                published=hit.get("published_date"),
+            )
+            for hit in body.get("results") or []
+            if isinstance(hit, dict) and hit.get("url")
+        ][:max_results]
+
+
+class SerpAPIProvider(SearchProvider):
+    """SerpAPI (Google results)."""
+
+    name = SERPAPI
+
+    def search(self, query: str, max_results: int = DEFAULT_MAX_RESULTS) -> List[SearchResult]:
+        params = urllib.parse.urlencode(
+            {"engine": "google", "q": query, "num": max_results, "api_key": self.api_key}
+        )
+        body = self._fetch(urllib.request.Request(f"{SERPAPI_URL}?{params}"))
+        return [
+            SearchResult(
+                title=str(hit.get("title", "")),
+                url=str(hit.get("link", "")),
+                snippet=str(hit.get("snippet", "")),
+                published=hit.get("date"),
+            )
+            for hit in body.get("organic_results") or []
+            if isinstance(hit, dict) and hit.get("link")
+        ][:max_results]
+
+
+class BraveProvider(SearchProvider):
+    """Brave Search web API."""
+
+    name = BRAVE
+
+    def search(self, query: str, max_results: int = DEFAULT_MAX_RESULTS) -> List[SearchResult]:
+        params = urllib.parse.urlencode({"q": query, "count": max_results})
+        request = urllib.request.Request(
+            f"{BRAVE_URL}?{params}",
+            headers={"Accept": "application/json", "X-Subscription-Token": self.api_key},
+        )
+        body = self._fetch(request)
+        return [
+            SearchResult(
+                title=str(hit.get("title", "")),
+                url=str(hit.get("url", "")),
+                snippet=str(hit.get("description", "")),
+                published=hit.get("page_age") or hit.get("age"),
+            )
+            for hit in (body.get("web") or {}).get("results") or []
+            if isinstance(hit, dict) and hit.get("url")
+        ][:max_results]
+
+
+_PROVIDER_CLASSES = {TAVILY: TavilyProvider, SERPAPI: SerpAPIProvider, BRAVE: BraveProvider}
+
+
+def get_search_provider(name: Optional[str] = None) -> SearchProvider:
+    """The named provider, or the first with an API key configured.
+
+    Raises:
+        SearchError: if the name is unknown or no provider has a key.
+    """
+    if name:
+        if name not in _PROVIDER_CLASSES:
+            raise SearchError(f"Unknown search provider '{name}' (known: {', '.join(PROVIDERS)})")
+        return _PROVIDER_CLASSES[name]()
+    for candidate in PROVIDERS:
+        if os.environ.get(SEARCH_API_KEYS[candidate]):
+            return _PROVIDER_CLASSES[candidate]()
+    keys = ", ".join(SEARCH_API_KEYS[p] for p in PROVIDERS)
+    raise SearchError(f"No search provider configured (set one of {keys})")
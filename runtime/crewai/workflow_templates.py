@@ -0,0 +1,184 @@
+"""Workflow templates: which stages a run goes through, by application strategy.
+
+A template names the stages a run goes through; the rest are skipped. Three ship
+with Hydra (``--template NAME``):
+
+- ``quick``: research, tailoring and ATS optimisation. No gap-analysis greenlight,
+  no interview, no executive brief — for applications that are worth a tailored
+  résumé but not an afternoon.
+- ``thorough``: the full pipeline, research through the executive brief. The
+  default, and what a run without a template does.
+- ``referral``: ``thorough`` plus an outreach message to the hiring manager, for
+  applications that go through a person rather than a portal.
+
+No built-in template rewrites weak bullets (``impact``, see runtime.crewai.impact) or
+calibrates the résumé's seniority (``seniority``, see runtime.crewai.seniority);
+``--impact`` and ``--seniority`` add those stages to any template, as ``--outreach``
+adds outreach.
+
+Tailoring and the audit run in every template, listed or not: no template ships
+documents that were not checked against the sources. Stages run in pipeline order
+whatever order a template lists them in, research runs only with a search provider
+(``--research``), and a stage whose output a resumed run already has is not re-run.
+
+Users define their own in the ``templates`` section of the pipeline config (see
+runtime.crewai.pipeline_config), as a list of stages or with a description; a
+template may extend another, and one named like a built-in replaces it::
+
+    templates:
+      networking:
+        extends: quick
+        stages: [differentiation, outreach]
+        description: Tailor fast, then reach out
+      fast: [tailoring, ats_optimization]
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass, field
+from typing import Any, Dict, Iterable, List, Mapping, Tuple
+
+from runtime.crewai.timeouts import config_stage
+
+# Stages a template can select, in the order the pipeline runs them.
+TEMPLATE_STAGES = (
+    "research",
+    "gap_analysis",
+    "seniority",
+    "impact",
+    "interrogation",
+    "differentiation",
+    "tailoring",
+    "ats_optimization",
+    "audit",
+    "executive_synthesis",
+    "outreach",
+)
+# Run in every template.
+REQUIRED_STAGES = ("tailoring", "audit")
+
+QUICK = "quick"
+THOROUGH = "thorough"
+REFERRAL = "referral"
+DEFAULT_TEMPLATE = THOROUGH
+
+
+def _ordered(stages: Iterable[str], name: str) -> Tuple[str, ...]:
+    """``stages`` plus the required ones, checked and in pipeline order."""
+    wanted = set(stages)
+    unknown = wanted - set(TEMPLATE_STAGES)
+    if unknown:
+        raise ValueError(
+            f"unknown stage(s) in template {name}: {', '.join(sorted(unknown))} "
+            f"(expected: {', '.join(TEMPLATE_STAGES)})"
+        )
+    wanted.update(REQUIRED_STAGES)
+    return tuple(stage for stage in TEMPLATE_STAGES if stage in wanted)
+
+
+@dataclass(frozen=True)
+class WorkflowTemplate:
+    """A named set of stages a run goes through."""
+
+    name: str
+    stages: Tuple[str, ...]
+    description: str = ""
+
+    @classmethod
+    def of(cls, name: str, stages: Iterable[str], description: str = "") -> "WorkflowTemplate":
+        """A template of ``stages`` (plus the required ones); ValueError on unknown stages."""
+        return cls(name=name, stages=_ordered(stages, name), description=description)
+
+    def runs(self, stage: str) -> bool:
+        """Whether ``stage`` (a workflow stage or agent name) is part of this template."""
+        return config_stage(stage) in self.stages
+
+    def including(self, *stages: str) -> "WorkflowTemplate":
+        """This template with ``stages`` added (``--outreach``, ``--impact``, ...)."""
+        return WorkflowTemplate.of(self.name, [*self.stages, *stages], self.description)
+
+    def skipped(self) -> List[str]:
+        """The stages this template leaves out."""
+        return [stage for stage in TEMPLATE_STAGES if stage not in self.stages]
+
+    def to_dict(self) -> Dict[str, Any]:
+        return {"name": self.name, "stages": list(self.stages), "description": self.description}
+
+
+BUILTIN_TEMPLATES: Dict[str, WorkflowTemplate] = {
+    QUICK: WorkflowTemplate.of(
+        QUICK,
+        ["research", "tailoring", "ats_optimization"],
+        "Research, tailor and ATS-optimise; no greenlight, interview or brief",
+    ),
+    THOROUGH: WorkflowTemplate.of(
+        THOROUGH,
+        [stage for stage in TEMPLATE_STAGES if stage not in ("seniority", "impact", "outreach")],
+        "The full pipeline, research through the executive brief",
+    ),
+    REFERRAL: WorkflowTemplate.of(
+        REFERRAL,
+        [stage for stage in TEMPLATE_STAGES if stage not in ("seniority", "impact")],
+        "The full pipeline plus an outreach message to the hiring manager",
+    ),
+}
+
+
+@dataclass(frozen=True)
+class TemplatePolicy:
+    """The user's own templates, on top of the built-in ones."""
+
+    templates: Mapping[str, WorkflowTemplate] = field(default_factory=dict)
+
+    @classmethod
+    def from_dict(cls, data: Mapping[str, Any]) -> "TemplatePolicy":
+        """``{"fast": ["tailoring"], "networking": {"extends": "quick", "stages": [...]}}``.
+        Raises ValueError on unknown stages, bad entries or an unknown ``extends``."""
+        templates: Dict[str, WorkflowTemplate] = {}
+        for name, entry in data.items():
+            if isinstance(entry, list):
+                entry = {"stages": entry}
+            if not isinstance(entry, dict):
+                raise ValueError(f"templates.{name} must be a list of stages or a mapping")
+            unknown = set(entry) - {"stages", "description", "extends"}
+            if unknown:
+                raise ValueError(
+                    f"unknown setting(s) in templates.{name}: {', '.join(sorted(unknown))}"
+                )
+            stages = entry.get("stages") or []
+            if not isinstance(stages, list) or not all(isinstance(s, str) for s in stages):
+                raise ValueError(f"templates.{name}.stages must be a list of stage names")
+            base: Tuple[str, ...] = ()
+            description = entry.get("description", "")
+            if entry.get("extends") is not None:
+                parent = templates.get(entry["extends"]) or BUILTIN_TEMPLATES.get(
+                    entry["extends"]
+                )
+                if parent is None:
+                    raise ValueError(
+                        f"templates.{name} extends unknown template {entry['extends']!r} "
+                        "(a template can extend a built-in one or one defined above it)"
+                    )
+                base = parent.stages
+                description = description or parent.description
+            templates[name] = WorkflowTemplate.of(name, [*base, *stages], str(description))
+        return cls(templates=templates)
+
+    def to_dict(self) -> Dict[str, Any]:
+        return {
+            name: {"stages": list(template.stages), "description": template.description}
+            for name, template in self.templates.items()
+        }
+
+    def available(self) -> Dict[str, WorkflowTemplate]:
+        """Every template a run can use, built-in ones first; the user's win on a clash."""
+        return {**BUILTIN_TEMPLATES, **self.templates}
+
+    def get(self, name: str) -> WorkflowTemplate:
+        """The template called ``name``; ValueError naming the choices if there is none."""
+        available = self.available()
+        if name not in available:
+            raise ValueError(
+                f"unknown workflow template {name!r} (available: {', '.join(available)})"
+            )
+        return available[name]
@@ -1,4 +1,4 @@
-from unittest.mock import MagicMock, patch
+from unittest.mock import MagicMock, Mock, patch
 
 import pytest
 
@@ -7,6 +7,53 @@ import pytest
 # the runtime-only suite in CI, which does not install the web backend's dependencies
 # — to import litestar/psycopg, breaking collection. Keep these imports fixture-local.
 
+# The agent classes patched_workflow() swaps for Mocks while HydraWorkflow is built.
+AGENTS = (
+    "GapAnalyzerAgent",
+    "SeniorityCalibratorAgent",
+    "ImpactRewriterAgent",
+    "InterrogatorPrepperAgent",
+    "DifferentiatorAgent",
+    "TailoringAgent",
+    "ATSOptimizerAgent",
+    "AuditorSuiteAgent",
+    "ExecutiveSynthesizerAgent",
+    "ReferralFinderAgent",
+    "OutreachAgent",
+)
+
+
+def patched_workflow(**kwargs):
+    """A HydraWorkflow whose agents are Mocks, so each test sets what they return.
+
+    ``kwargs`` go to HydraWorkflow as they are; the LLM is a Mock and per-agent
+    models are off.
+    """
+    from runtime.crewai.hydra_workflow import HydraWorkflow
+
+    patches = [patch(f"runtime.crewai.hydra_workflow.{name}") for name in AGENTS]
+    for p in patches:
+        p.start()
+    try:
+        return HydraWorkflow(Mock(), use_per_agent_models=False, **kwargs)
+    finally:
+        for p in patches:
+            p.stop()
+
+
+@pytest.fixture
+def hydra_home(monkeypatch, tmp_path):
+    """Point HYDRA_HOME at a fresh directory and hide the developer's provider keys.
+
+    Modules that read or write under HYDRA_HOME opt in with
+    ``pytestmark = pytest.mark.usefixtures("hydra_home")``.
+    """
+    from runtime.crewai.model_config import PROVIDER_ENV_KEYS
+
+    monkeypatch.setenv("HYDRA_HOME", str(tmp_path / "home"))
+    for env_var in PROVIDER_ENV_KEYS.values():
+        monkeypatch.delenv(env_var, raising=False)
+
 
 @pytest.fixture
 def test_client():
@@ -19,6 +66,13 @@ def test_client():
         yield client
 
 
+@pytest.fixture(autouse=True)
+def no_duplicate_window(monkeypatch):
+    """Tests post the same inputs over and over: each creates its own job unless it
+    turns duplicate detection on (web/backend/services/idempotency.py)."""
+    monkeypatch.setenv("HYDRA_DUPLICATE_WINDOW_SECONDS", "0")
+
+
 @pytest.fixture
 def mock_llm_client():
     """Mock LLM client to prevent actual API calls during tests."""
@@ -155,6 +155,27 @@ class TestInterrogatorPrepperAgent:
         assert result == valid_output
         mock_execute.assert_called_once()
     
+    @patch('runtime.crewai.agents.interrogator_prepper.InterrogatorPrepperAgent.execute_with_retry')
+    def test_past_debriefs_shape_the_prep(self, mock_execute, interrogator_prepper, sample_context):
+        """Earlier interviews at the company are put in front of the model"""
+        debrief = {
+            "company": "Acme",
+            "round": "system design",
+            "interview_date": "2026-09-01",
+            "questions_asked": ["Design a rate limiter"],
+            "to_improve": "Quantify the outage work",
+        }
+
+        interrogator_prepper.execute({**sample_context, "past_debriefs": [debrief]})
+        description = mock_execute.call_args[0][0].description
+
+        assert "Earlier interviews at this company" in description
+        assert "asked: Design a rate limiter" in description
+        assert "to improve: Quantify the outage work" in description
+
+        interrogator_prepper.execute(sample_context)
+        assert "Earlier interviews" not in mock_execute.call_args[0][0].description
+
     def test_validate_schema_valid_output(self, interrogator_prepper, valid_output):
         """Test schema validation with valid output"""
         # Should not raise any exception
@@ -0,0 +1,107 @@
+"""
+Unit tests for the Research Agent's search loop and citation enforcement.
+"""
+
+from unittest.mock import Mock, patch
+
+import pytest
+from crewai import LLM
+
+from runtime.crewai.agents.research import MAX_SEARCH_ROUNDS, ResearchAgent
+from runtime.crewai.base_agent import ValidationError
+from runtime.crewai.web_search import SearchError, SearchResult
+
+BRIEF = {
+    "company": "Acme Robotics",
+    "recent_news": [{"summary": "Launched a picking robot", "date": "2026-03", "citations": [1]}],
+    "funding": [
+        {"summary": "Raised a $40M Series B", "citations": ["2"]},
+        {"summary": "Valued at $1B", "citations": [9]},  # not a real result
+    ],
+    "tech_stack": [{"name": "Rust", "citations": [1, 2]}, "Kubernetes"],  # uncited string
+}
+
+
+class _Provider:
+    name = "fake"
+
+    def __init__(self, hits=None, error=None):
+        self.hits = hits or {}
+        self.error = error
+        self.queries = []
+
+    def search(self, query, max_results=5):
+        self.queries.append(query)
+        if self.error:
+            raise SearchError(self.error)
+        return self.hits.get(query, [])
+
+
+@pytest.fixture
+def make_agent():
+    def _make(provider):
+        with patch("runtime.crewai.base_agent.Path.read_text", return_value="Research prompt"):
+            return ResearchAgent(LLM(model="gpt-4", api_key="test-key"), provider)
+
+    return _make
+
+
+def test_missing_job_description_is_rejected(make_agent):
+    with pytest.raises(ValidationError, match="job_description"):
+        make_agent(_Provider()).execute({})
+
+
+def test_search_round_then_cited_brief(make_agent):
+    provider = _Provider(
+        {
+            "Acme news": [SearchResult("Acme launches robot", "https://a.example/1")],
+            "Acme funding": [
+                SearchResult("Acme raises $40M", "https://a.example/2", published="2025-11"),
+                SearchResult("Acme launches robot", "https://a.example/1"),  # duplicate URL
+            ],
+        }
+    )
+    agent = make_agent(provider)
+    agent.execute_with_retry = Mock(
+        side_effect=[{"searches": ["Acme news", "Acme funding"]}, BRIEF]
+    )
+
+    result = agent.execute({"job_description": "Robotics engineer at Acme", "resume": "secret"})
+
+    assert provider.queries == ["Acme news", "Acme funding"]
+    assert [s["url"] for s in result["sources"]] == ["https://a.example/1", "https://a.example/2"]
+    assert result["searches"][1]["result_ids"] == [2, 1]
+    assert result["funding"] == [
+        {"summary": "Raised a $40M Series B", "date": None, "citations": [2]}
+    ]
+    assert [t["summary"] for t in result["tech_stack"]] == ["Rust"]
+    assert result["uncited_dropped"] == 2
+    # The research prompt is about the employer only.
+    prompt = agent.execute_with_retry.call_args_list[1][0][0].description
+    assert "secret" not in prompt and "[2] Acme raises $40M (2025-11)" in prompt
+
+
+def test_rounds_are_bounded_and_the_last_one_forces_the_brief(make_agent):
+    provider = _Provider()
+    agent = make_agent(provider)
+    agent.execute_with_retry = Mock(return_value={"searches": ["again"]})
+
+    result = agent.execute({"job_description": "JD"})
+
+    assert agent.execute_with_retry.call_count == MAX_SEARCH_ROUNDS + 1
+    assert len(provider.queries) == MAX_SEARCH_ROUNDS
+    final_prompt = agent.execute_with_retry.call_args[0][0].description
+    assert "final round" in final_prompt
+    assert result["sources"] == []
+
+
+def test_search_errors_are_recorded_not_raised(make_agent):
+    agent = make_agent(_Provider(error="quota exceeded"))
+    agent.execute_with_retry = Mock(side_effect=[{"searches": ["Acme"]}, BRIEF])
+
+    result = agent.execute({"job_description": "JD"})
+
+    assert result["searches"] == [
+        {"round": 1, "query": "Acme", "result_ids": [], "error": "quota exceeded"}
+    ]
+    assert result["recent_news"] == [] and result["uncited_dropped"] == 5
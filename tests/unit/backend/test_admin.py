@@ -0,0 +1,190 @@
+"""Admin API: listing every user's runs, force-failing, requeueing and purging."""
+
+import uuid
+from datetime import datetime, timedelta
+from unittest.mock import patch
+
+import pytest
+
+from web.backend.models import JobState
+from web.backend.services import admin
+from web.backend.services.job_control import JobControlError
+from web.backend.services.job_queue import Job, job_queue
+from web.backend.services.storage import EXPIRE_DAYS_ENV, LocalArtifactStore, StorageConfigError
+
+
+class _Queue:
+    """The job_queue calls the admin actions make, over jobs kept in memory."""
+
+    def __init__(self, *jobs):
+        self.jobs = {job.id: job for job in jobs}
+        self.purged = []
+
+    def override_job(self, job_id, **fields):
+        job = self.jobs[job_id]
+        for key, value in fields.items():
+            setattr(job, key, value)
+        return job
+
+    def finished_before(self, cutoff):
+        return [job for job in self.jobs.values() if job.completed_at < cutoff]
+
+    def purge_documents(self, job_id):
+        self.purged.append(job_id)
+
+
+@pytest.fixture
+def started(monkeypatch):
+    started = []
+    monkeypatch.setattr(admin, "start_workflow_background", started.append)
+    return started
+
+
+def _failed_job():
+    job = Job(id="job-1", state=JobState.FAILED, error_message="Provider timed out")
+    job.gap_analysis_approved, job.greenlight_notes = True, "Lead with Terraform"
+    job.interview_answers = [{"question_id": "q1", "answer": "Yes"}]
+    job.intermediate_results = {
+        "gap_analysis": {"fit": "strong"},
+        "interrogation": {"questions": []},
+        "differentiation": {"angles": []},
+        "tailoring": {"tailored_resume": "# Jane"},
+    }
+    return job
+
+
+def test_requeue_resumes_a_failed_job_from_its_checkpoint(monkeypatch, started):
+    job = _failed_job()
+    monkeypatch.setattr(admin, "job_queue", _Queue(job))
+
+    admin.requeue(job)
+
+    assert started == [job] and job.state == JobState.INITIALIZED
+    assert job.error_message is None and "tailoring" in job.intermediate_results
+    assert job.gap_analysis_approved and job.interview_answers
+
+    with pytest.raises(JobControlError, match="Only a failed job"):
+        admin.requeue(job)
+
+
+def test_requeue_from_a_stage_drops_it_and_what_depends_on_it(monkeypatch, started):
+    job = _failed_job()
+    monkeypatch.setattr(admin, "job_queue", _Queue(job))
+
+    admin.requeue(job, "differentiation")
+    assert set(job.intermediate_results) == {"gap_analysis", "interrogation"}
+    assert job.gap_analysis_approved and job.interview_answers
+
+    job.state = JobState.FAILED
+    admin.requeue(job, "gap_analysis")
+    assert job.intermediate_results == {}
+    assert not job.gap_analysis_approved and job.greenlight_notes is None
+    assert job.interview_answers == []
+
+    job.state = JobState.FAILED
+    with pytest.raises(JobControlError, match="cannot replay"):
+        admin.requeue(job, "proofreading")
+    assert len(started) == 2
+
+
+@pytest.mark.asyncio
+async def test_force_fail_stops_the_run_and_revokes_its_lease(monkeypatch):
+    job = Job(id="job-1", state=JobState.TAILORING)
+    monkeypatch.setattr(admin, "job_queue", _Queue(job))
+    events = job.subscribe()
+
+    with (
+        patch.object(admin, "request_stop") as stop,
+        patch.object(admin, "leases") as leases,
+    ):
+        await admin.force_fail(job, "hung on the provider")
+
+    stop.assert_called_once_with("job-1", JobState.CANCELLED)
+    leases.revoke.assert_called_once_with("job-1")
+    assert job.state == JobState.FAILED and job.completed_at is not None
+    assert job.error_message == "Failed by an administrator: hung on the provider"
+    assert events.get_nowait()["event"] == "complete"
+
+    with pytest.raises(JobControlError, match="Cannot fail a failed job"):
+        await admin.force_fail(job)
+
+
+def test_retention_period_defaults_to_the_lifecycle_setting(monkeypatch):
+    monkeypatch.delenv(EXPIRE_DAYS_ENV, raising=False)
+    with pytest.raises(StorageConfigError, match="No retention period"):
+        admin.retention_days()
+    assert admin.retention_days(30) == 30
+
+    monkeypatch.setenv(EXPIRE_DAYS_ENV, "365")
+    assert admin.retention_days() == 365 and admin.retention_days(7) == 7
+
+
+def test_purge_deletes_the_documents_of_old_runs(tmp_path, monkeypatch):
+    store = LocalArtifactStore(tmp_path)
+    path = store.write(
+        owner=None, company="Acme", role_title="SRE", run_id="7", kind="resume", content="#"
+    )
+    old = Job(id="old", completed_at=datetime(2026, 1, 5))
+    old.hydra_run_id = "7"
+    recent = Job(id="recent", completed_at=datetime.now())
+    queue = _Queue(old, recent)
+    monkeypatch.setattr(admin, "job_queue", queue)
+    monkeypatch.setattr(admin, "artifact_store_from_env", lambda: store)
+    monkeypatch.setattr(admin, "state_store_from_env", lambda: None)
+
+    with patch.object(admin, "hydra_db") as db:
+        db.list_artifacts.return_value = [{"metadata": {"path": path}}]
+        db.delete_artifacts.return_value = 1
+
+        dry = admin.purge_artifacts(90, dry_run=True)
+        assert dry["job_ids"] == ["old"] and dry["artifacts"] is None
+        assert not queue.purged and (tmp_path / "Acme").exists()
+
+        purged = admin.purge_artifacts(90)
+
+    assert purged == {"days": 90, "dry_run": False, "job_ids": ["old"], "artifacts": 1}
+    assert queue.purged == ["old"] and not (tmp_path / "Acme").exists()
+    db.delete_artifacts.assert_called_once_with("7")
+
+
+def test_runs_are_searched_and_purged_in_the_job_queue():
+    owner = f"user-{uuid.uuid4().hex[:8]}"
+    job = job_queue.create_job(job_description="Test JD", resume="Test resume", owner=owner)
+    job_queue.update_job(
+        job.id,
+        state=JobState.COMPLETED,
+        completed_at=datetime.now() - timedelta(days=100),
+        final_documents={"resume": "# Jane"},
+    )
+
+    assert [j.id for j in job_queue.search_jobs(owner=owner)] == [job.id]
+    assert job_queue.search_jobs(state=JobState.FAILED, owner=owner) == []
+    assert job.id in [j.id for j in job_queue.finished_before(datetime.now() - timedelta(days=90))]
+
+    purged = job_queue.purge_documents(job.id)
+    assert purged.final_documents is None and purged.resume == ""
+    assert purged.artifacts_purged_at is not None
+    assert job.id not in [j.id for j in job_queue.finished_before(datetime.now())]
+
+
+@pytest.fixture
+def admin_users(monkeypatch):
+    monkeypatch.setenv("HYDRA_API_KEYS", "alice:tok-a,bob:tok-b")
+    monkeypatch.setenv("HYDRA_ADMIN_USERS", "alice")
+
+
+def test_only_admins_see_every_run(test_client, admin_users):
+    runs = [Job(id="job-1", owner="bob", state=JobState.FAILED)]
+    with patch("web.backend.services.job_queue.JobQueue.search_jobs", return_value=runs) as search:
+        denied = test_client.get("/api/v1/admin/runs", headers={"X-API-Key": "tok-b"})
+        listed = test_client.get(
+            "/api/v1/admin/runs?status=failed&user=bob", headers={"X-API-Key": "tok-a"}
+        )
+        unknown = test_client.get("/api/v1/admin/runs?status=stuck", headers={"X-API-Key": "tok-a"})
+
+    assert denied.status_code == 403
+    assert listed.status_code == 200
+    assert [run["job_id"] for run in listed.json()["runs"]] == ["job-1"]
+    assert search.call_args.kwargs["state"] == JobState.FAILED
+    assert search.call_args.kwargs["owner"] == "bob"
+    assert unknown.status_code == 400
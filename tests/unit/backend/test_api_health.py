@@ -7,3 +7,20 @@ def test_health_check(test_client):
         "service": "hydra-api",
         "version": "1.0.0",
     }
+
+
+def test_health_lists_open_circuits(test_client):
+    """Providers whose circuit is open are listed; the service stays healthy"""
+    from unittest.mock import patch
+
+    from runtime.crewai.circuit_breaker import CircuitBreaker, CircuitPolicy
+
+    breaker = CircuitBreaker()
+    breaker.record_failure("together", CircuitPolicy(failures=1))
+    breaker.record_success("openrouter")
+    with patch("web.backend.routes.health.shared_breaker", return_value=breaker):
+        response = test_client.get("/health")
+    assert response.status_code == 200
+    body = response.json()
+    assert body["status"] == "healthy" and list(body["circuits"]) == ["together"]
+    assert body["circuits"]["together"]["state"] == "open"
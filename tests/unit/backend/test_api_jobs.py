@@ -144,3 +144,73 @@ async def test_job_stream_exists(test_client):
     # Should be 404 because job doesn't exist (or 200 if we mock it right, but start simple)
     # The controller checks for job existence first
     assert response.status_code == 404
+
+def _create_paused_job(test_client):
+    payload = {
+        "job_description": "Test Job Description (long enough)",
+        "resume": "Test Resume Content (long enough)",
+        "source_documents": "",
+    }
+    job_id = test_client.post("/api/jobs", json=payload).json()["job_id"]
+    job_queue.update_job(
+        job_id,
+        state=JobState.GAP_ANALYSIS_REVIEW,
+        awaiting_user="greenlight",
+        intermediate_results={"gap_analysis": {"gaps": []}},
+    )
+    return job_id
+
+def test_greenlight_approve_resumes_with_notes(test_client, mock_workflow_runner):
+    job_id = _create_paused_job(test_client)
+    assert test_client.get(f"/api/jobs/{job_id}").json()["awaiting_user"] == "greenlight"
+
+    response = test_client.post(
+        f"/api/jobs/{job_id}/greenlight",
+        json={"approve": True, "notes": "Lead with the platform work"},
+    )
+
+    assert response.status_code == 200
+    assert response.json()["status"] == "approved"
+    assert mock_workflow_runner.call_count == 2
+    resumed = mock_workflow_runner.call_args[0][0]
+    assert resumed.gap_analysis_approved is True
+    assert resumed.greenlight_notes == "Lead with the platform work"
+    assert resumed.awaiting_user is None
+
+def test_greenlight_survives_server_restart(test_client, mock_workflow_runner):
+    job_id = _create_paused_job(test_client)
+    # Simulate a restart: drop the in-memory cache so the job is reloaded from Postgres.
+    job_queue._active_jobs.clear()
+
+    response = test_client.post(f"/api/jobs/{job_id}/greenlight", json={"approve": True})
+
+    assert response.status_code == 200
+    resumed = mock_workflow_runner.call_args[0][0]
+    assert resumed.intermediate_results == {"gap_analysis": {"gaps": []}}
+    assert resumed.gap_analysis_approved is True
+
+def test_greenlight_decline_stops_the_job(test_client, mock_workflow_runner):
+    job_id = _create_paused_job(test_client)
+
+    response = test_client.post(
+        f"/api/jobs/{job_id}/greenlight", json={"approve": False, "notes": "Not a fit"}
+    )
+
+    assert response.status_code == 200
+    assert response.json()["status"] == "declined"
+    assert mock_workflow_runner.call_count == 1
+    data = test_client.get(f"/api/jobs/{job_id}").json()
+    assert data["state"] == "failed"
+    assert data["awaiting_user"] is None
+
+def test_greenlight_rejected_before_review_state(test_client, mock_workflow_runner):
+    payload = {
+        "job_description": "Test Job Description (long enough)",
+        "resume": "Test Resume Content (long enough)",
+        "source_documents": "",
+    }
+    job_id = test_client.post("/api/jobs", json=payload).json()["job_id"]
+
+    response = test_client.post(f"/api/jobs/{job_id}/greenlight", json={"approve": True})
+
+    assert response.status_code == 400
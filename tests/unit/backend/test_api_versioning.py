@@ -0,0 +1,55 @@
+from web.backend.versioning import is_legacy_path, successor_path, version_headers
+
+JOB = {
+    "job_description": "Test Job Description",
+    "resume": "Test Resume",
+    "source_documents": "Test Sources",
+}
+
+
+def test_versioned_path_serves_the_api(test_client, mock_workflow_runner):
+    response = test_client.post("/api/v1/jobs", json=JOB)
+
+    assert response.status_code == 202
+    assert response.headers["api-version"] == "v1"
+    assert "deprecation" not in response.headers
+
+    job_id = response.json()["job_id"]
+    assert test_client.get(f"/api/v1/jobs/{job_id}").json()["job_id"] == job_id
+
+
+def test_legacy_path_still_works_and_is_deprecated(test_client, mock_workflow_runner):
+    job_id = test_client.post("/api/v1/jobs", json=JOB).json()["job_id"]
+
+    response = test_client.get(f"/api/jobs/{job_id}")
+
+    assert response.status_code == 200
+    assert response.json()["job_id"] == job_id
+    assert response.headers["api-version"] == "v1"
+    assert response.headers["deprecation"].startswith("@")
+    assert response.headers["sunset"].endswith("GMT")
+    assert response.headers["link"] == f'</api/v1/jobs/{job_id}>; rel="successor-version"'
+
+
+def test_health_is_not_versioned(test_client):
+    response = test_client.get("/health")
+
+    assert response.status_code == 200
+    assert "api-version" not in response.headers
+
+
+def test_unknown_version_is_not_found(test_client):
+    assert test_client.get("/api/v2/jobs/anything").status_code == 404
+
+
+def test_legacy_paths_map_to_their_successor():
+    assert is_legacy_path("/api/jobs/abc/stream")
+    assert not is_legacy_path("/api/v1/jobs/abc/stream")
+    assert not is_legacy_path("/api/v2")
+    # A resource that starts with a "v" is not a version.
+    assert is_legacy_path("/api/videos/abc")
+    assert is_legacy_path("/api/v1beta/jobs")
+    assert not is_legacy_path("/health")
+    assert successor_path("/api/jobs/abc/stream") == "/api/v1/jobs/abc/stream"
+    assert version_headers("/health") == []
+    assert version_headers("/api/v1/jobs") == [(b"api-version", b"v1")]
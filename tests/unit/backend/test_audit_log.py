@@ -0,0 +1,69 @@
+"""Audit log: decisions, provider calls and document access, kept append-only in Postgres."""
+
+import json
+import uuid
+from datetime import datetime, timedelta
+from types import SimpleNamespace
+from unittest.mock import patch
+
+import psycopg
+import pytest
+
+from runtime.crewai.audit_log import GREENLIGHT, PROVIDER_CALL, audit_event
+from web.backend.db import get_conn
+from web.backend.services import audit_log as audit_module
+from web.backend.services import workflow_runner
+from web.backend.services.audit_log import audit_log, to_jsonl
+from web.backend.services.job_queue import Job
+
+
+def test_events_are_recorded_searched_and_never_changed():
+    job_id = f"job-{uuid.uuid4().hex[:8]}"
+    call = audit_event(PROVIDER_CALL, stage="tailoring", provider="openai", data=["resume"])
+    assert audit_log.record_events(job_id, [call], actor="bob") == 1
+    assert audit_log.record(GREENLIGHT, job_id, "alice", approved=True, via="rest")
+
+    first, second = audit_log.search(job_id=job_id)
+    assert first["actor"] == "bob" and first["data"] == ["resume"]
+    assert second["action"] == GREENLIGHT and second["actor"] == "alice"
+    assert second["approved"] is True and second["via"] == "rest"
+    assert audit_log.search(job_id=job_id, actor="alice") == [second]
+    assert audit_log.search(job_id=job_id, since=datetime.now() + timedelta(days=1)) == []
+
+    with pytest.raises(psycopg.Error, match="append-only"):
+        with get_conn() as conn:
+            conn.execute("DELETE FROM audit_log WHERE job_id = %s", (job_id,))
+    assert len(audit_log.search(job_id=job_id)) == 2
+
+
+def test_a_failed_write_does_not_fail_the_request():
+    with (
+        patch.object(audit_module, "get_conn", side_effect=OSError("database is down")),
+        patch.object(audit_module, "logger") as logger,
+    ):
+        assert audit_log.record(GREENLIGHT, "job-1", "alice", approved=False) is False
+    assert "not recorded" in logger.error.call_args.args[0]
+
+
+def test_a_run_logs_its_provider_calls_for_the_jobs_owner():
+    job = Job(id="job-1", owner="bob")
+    result = SimpleNamespace(audit_events=[audit_event(PROVIDER_CALL, stage="tailoring")])
+    with patch.object(workflow_runner, "audit_log") as log:
+        workflow_runner._record_audit_events(job, result)
+        workflow_runner._record_audit_events(job, SimpleNamespace())
+    log.record_events.assert_called_once_with("job-1", result.audit_events, actor="bob")
+
+
+def test_only_admins_export_the_whole_log(test_client, monkeypatch):
+    monkeypatch.setenv("HYDRA_API_KEYS", "alice:tok-a,bob:tok-b")
+    monkeypatch.setenv("HYDRA_ADMIN_USERS", "alice")
+    events = [{"at": "2026-10-17T09:00:00", "job_id": "job-1", "action": GREENLIGHT}]
+    with patch.object(type(audit_log), "search", return_value=events) as search:
+        denied = test_client.get("/api/v1/admin/audit", headers={"X-API-Key": "tok-b"})
+        exported = test_client.get("/api/v1/admin/audit?user=bob", headers={"X-API-Key": "tok-a"})
+
+    assert denied.status_code == 403
+    assert exported.status_code == 200
+    assert [json.loads(line) for line in exported.text.splitlines()] == events
+    assert search.call_args.kwargs["actor"] == "bob"
+    assert to_jsonl(events) == exported.text
@@ -0,0 +1,109 @@
+"""Tests for API tokens, per-user job isolation and budgets (web/backend/auth.py)."""
+
+from datetime import datetime, timezone
+from unittest.mock import patch
+
+import pytest
+
+from web.backend.auth import (
+    ApiUser,
+    AuthConfigError,
+    authenticate,
+    can_access,
+    is_admin,
+    month_start,
+    over_budget,
+    parse_api_keys,
+    token_from_headers,
+)
+from web.backend.services.job_queue import job_queue
+
+JOB = {
+    "job_description": "Platform engineer, AWS and Terraform",
+    "resume": "Jane Doe, site reliability engineer",
+}
+
+
+def test_keys_parse_with_optional_budgets():
+    keys = parse_api_keys(" alice:tok-a:25, bob:tok-b ,", default_budget=5.0)
+
+    assert [user for _, user in keys] == [ApiUser("alice", 25.0), ApiUser("bob", 5.0)]
+    assert authenticate("tok-b", keys) == ApiUser("bob", 5.0)
+    assert authenticate("tok-c", keys) is None and authenticate("", keys) is None
+
+    for raw in ("alice", "alice:", ":tok", "alice:a,Alice:b", "alice:tok:lots", "../x:tok"):
+        with pytest.raises(AuthConfigError):
+            parse_api_keys(raw)
+
+
+def test_token_comes_from_bearer_or_api_key_header():
+    assert token_from_headers([("Authorization", "Bearer  tok ")]) == "tok"
+    assert token_from_headers([("x-api-key", "tok")]) == "tok"
+    assert token_from_headers([("authorization", "Basic dXNlcg==")]) is None
+    assert token_from_headers([]) is None
+
+
+def test_ownership_and_budget_rules():
+    alice = ApiUser("alice", budget_usd=10.0)
+    assert can_access(None, None) and can_access(None, "alice")  # auth off
+    assert can_access(alice, "alice")
+    assert not can_access(alice, "bob") and not can_access(alice, None)
+
+    assert over_budget(alice, 9.99) is None
+    assert "Monthly budget of $10.00 reached" in over_budget(alice, 10.0)
+    assert over_budget(ApiUser("bob"), 1e6) is None
+
+    start = month_start(datetime(2026, 3, 31, 23, 30, tzinfo=timezone.utc))
+    assert start == datetime(2026, 3, 1, tzinfo=timezone.utc)
+
+
+def test_admins_are_the_listed_users(monkeypatch):
+    assert is_admin(None)  # auth off
+    monkeypatch.delenv("HYDRA_ADMIN_USERS", raising=False)
+    assert not is_admin(ApiUser("alice"))
+
+    monkeypatch.setenv("HYDRA_ADMIN_USERS", " Alice , carol")
+    assert is_admin(ApiUser("alice")) and is_admin(ApiUser("carol"))
+    assert not is_admin(ApiUser("bob"))
+
+
+@pytest.fixture
+def two_users(monkeypatch):
+    monkeypatch.setenv("HYDRA_API_KEYS", "alice:tok-a:10,bob:tok-b")
+
+
+def _as(token):
+    return {"Authorization": f"Bearer {token}"}
+
+
+def test_api_requires_a_token_and_isolates_users(test_client, mock_workflow_runner, two_users):
+    assert test_client.post("/api/v1/jobs", json=JOB).status_code == 401
+    assert test_client.post("/api/v1/jobs", json=JOB, headers=_as("nope")).status_code == 401
+    assert test_client.get("/health").status_code == 200
+
+    created = test_client.post("/api/v1/jobs", json=JOB, headers=_as("tok-a"))
+    assert created.status_code == 202
+    job_id = created.json()["job_id"]
+    assert job_queue.get_job(job_id).owner == "alice"
+
+    assert test_client.get(f"/api/v1/jobs/{job_id}", headers=_as("tok-a")).status_code == 200
+    assert test_client.get(f"/api/v1/jobs/{job_id}", headers=_as("tok-b")).status_code == 404
+    cancel = test_client.post(f"/api/v1/jobs/{job_id}/cancel", headers=_as("tok-b"))
+    assert cancel.status_code == 404
+
+
+def test_a_spent_budget_blocks_new_runs(test_client, mock_workflow_runner, two_users):
+    with patch.object(job_queue, "spent_since", return_value=10.5) as spent:
+        refused = test_client.post("/api/v1/jobs", json=JOB, headers=_as("tok-a"))
+        assert refused.status_code == 402 and "budget" in refused.json()["detail"]
+        # bob has no budget, so his spend is never even looked up.
+        assert test_client.post("/api/v1/jobs", json=JOB, headers=_as("tok-b")).status_code == 202
+    assert spent.call_args.args[0] == "alice"
+    assert mock_workflow_runner.call_count == 1
+
+
+def test_with_no_keys_the_api_stays_open(test_client, mock_workflow_runner, monkeypatch):
+    monkeypatch.delenv("HYDRA_API_KEYS", raising=False)
+    created = test_client.post("/api/v1/jobs", json=JOB)
+    assert created.status_code == 202
+    assert job_queue.get_job(created.json()["job_id"]).owner is None
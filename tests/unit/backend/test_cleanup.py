@@ -0,0 +1,68 @@
+"""Scheduled cleanup: finished jobs lose their documents, then go, under the retention policy."""
+
+from datetime import datetime, timedelta
+from unittest.mock import patch
+
+from runtime.crewai.cleanup import ARTIFACT_DAYS_ENV, STATE_DAYS_ENV, CleanupPolicy
+from web.backend.services import cleanup
+from web.backend.services.cleanup import GC_INTERVAL_ENV, collect, gc_interval_from_env
+from web.backend.services.job_queue import Job
+
+
+class _Queue:
+    """The job_queue calls the cleanup makes, over jobs kept in memory."""
+
+    def __init__(self, *jobs):
+        self.jobs = {job.id: job for job in jobs}
+
+    def finished_before(self, cutoff, purged=False):
+        return [job for job in self.jobs.values() if job.completed_at < cutoff]
+
+    def delete_job(self, job_id):
+        return self.jobs.pop(job_id, None) is not None
+
+
+def test_jobs_past_the_state_period_are_purged_then_deleted(monkeypatch):
+    ancient = Job(id="ancient", owner="bob", completed_at=datetime.now() - timedelta(days=400))
+    ancient.hydra_job_id = "hydra-1"
+    recent = Job(id="recent", completed_at=datetime.now() - timedelta(days=100))
+    queue = _Queue(ancient, recent)
+    monkeypatch.setattr(cleanup, "job_queue", queue)
+    monkeypatch.setattr(cleanup, "state_store_from_env", lambda: None)
+
+    with (
+        patch.object(cleanup.admin, "purge_artifacts") as purge,
+        patch.object(cleanup, "hydra_db") as db,
+        patch.object(cleanup, "audit_log") as log,
+    ):
+        purge.return_value = {"job_ids": ["ancient", "recent"]}
+        result = collect(CleanupPolicy(artifact_days=90, state_days=365))
+
+        assert result == {"purged": ["ancient", "recent"], "deleted": ["ancient"]}
+        purge.assert_called_once_with(90)
+        db.delete_job.assert_called_once_with("hydra-1")
+        assert list(queue.jobs) == ["recent"]
+        # The audit log, which has no résumé text, outlives them.
+        assert [c.args[:2] for c in log.record.call_args_list] == [
+            ("purged", "ancient"),
+            ("purged", "recent"),
+            ("deleted", "ancient"),
+        ]
+
+        # Without a document period, documents go with the job.
+        collect(CleanupPolicy(state_days=365))
+        purge.assert_called_with(365)
+
+
+def test_cleanup_runs_only_with_a_retention_period(monkeypatch):
+    assert gc_interval_from_env() == 86400.0
+    monkeypatch.setenv(GC_INTERVAL_ENV, "0")
+    assert gc_interval_from_env() == 0.0
+
+    monkeypatch.delenv(GC_INTERVAL_ENV)
+    monkeypatch.delenv(ARTIFACT_DAYS_ENV, raising=False)
+    monkeypatch.delenv(STATE_DAYS_ENV, raising=False)
+    assert cleanup.start_cleanup() is None
+
+    monkeypatch.setenv(STATE_DAYS_ENV, "not a number")
+    assert cleanup.start_cleanup() is None
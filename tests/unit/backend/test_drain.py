@@ -0,0 +1,106 @@
+"""Drain mode: stop accepting runs, checkpoint in-flight workflows, resume on restart."""
+
+import asyncio
+from unittest.mock import MagicMock, patch
+
+import pytest
+
+from runtime.crewai.hydra_workflow import RunStatus, WorkflowResult, WorkflowState
+from web.backend.models import JobState
+from web.backend.services.drain import DRAIN_REASON, Drain, drain
+from web.backend.services.job_queue import job_queue
+
+
+@pytest.mark.asyncio
+async def test_drain_stops_live_runs_at_the_boundary_and_waits_for_them():
+    draining = Drain()
+    workflow = MagicMock()
+    draining.register("job-1", workflow)
+    asyncio.get_running_loop().call_later(0.3, draining.unregister, "job-1")
+
+    assert await draining.drain(timeout=5) == []
+
+    assert draining.draining
+    workflow.stop_after_stage.assert_called_with(DRAIN_REASON)
+    workflow.cancel.assert_not_called()
+
+
+@pytest.mark.asyncio
+async def test_drain_abandons_the_stage_in_flight_after_the_timeout():
+    draining = Drain()
+    workflow = MagicMock()
+    workflow.cancel.side_effect = lambda reason: draining.unregister("job-1")
+    draining.register("job-1", workflow)
+
+    assert await draining.drain(timeout=0.3) == []
+    workflow.cancel.assert_called_once_with(DRAIN_REASON)
+
+
+@pytest.mark.asyncio
+async def test_an_interrupted_run_is_persisted_as_resumable():
+    from web.backend.services.workflow_runner import run_workflow_async
+
+    result = WorkflowResult(
+        state=WorkflowState.TAILORING,
+        success=False,
+        status=RunStatus.INTERRUPTED,
+        error_message=f"Interrupted: {DRAIN_REASON} during tailoring",
+        execution_log=["Gap analysis complete"],
+        intermediate_results={"gap_analysis": {"gaps": []}},
+        agent_models={"gap_analyzer": "gpt-4o-mini"},
+    )
+
+    with (
+        patch("web.backend.services.workflow_runner.HydraWorkflow") as workflow_class,
+        patch("web.backend.services.workflow_runner.get_llm_client"),
+    ):
+        workflow = MagicMock()
+        workflow.execute.return_value = result
+        workflow.get_current_state.return_value = WorkflowState.TAILORING
+        workflow.get_execution_log.return_value = result.execution_log
+        workflow.get_intermediate_results.return_value = result.intermediate_results
+        workflow.agent_models = result.agent_models
+        workflow_class.return_value = workflow
+
+        job = job_queue.create_job(job_description="Test JD", resume="Test resume")
+        await run_workflow_async(job)
+
+    events = []
+    while not job._event_queue.empty():
+        events.append((await job._event_queue.get())["event"])
+
+    assert "complete" not in events
+    assert drain.live_jobs() == []
+    stored = job_queue.get_job(job.id)
+    assert stored.state == JobState.INTERRUPTED and stored.completed_at is None
+    assert stored.intermediate_results == {"gap_analysis": {"gaps": []}}
+
+
+def test_resume_interrupted_jobs_restarts_them():
+    from web.backend.services.workflow_runner import resume_interrupted_jobs
+
+    job = job_queue.create_job(job_description="Test JD", resume="Test resume")
+    job_queue.update_job(job.id, state=JobState.INTERRUPTED, error_message="Interrupted")
+
+    with patch("web.backend.services.workflow_runner.start_workflow_background") as start:
+        resumed = resume_interrupted_jobs()
+
+    assert job.id in resumed
+    assert job.id in [call.args[0].id for call in start.call_args_list]
+    stored = job_queue.get_job(job.id)
+    assert stored.state == JobState.INITIALIZED and stored.error_message is None
+
+
+def test_no_new_runs_while_draining(test_client, mock_workflow_runner):
+    drain.begin()
+    try:
+        health = test_client.get("/health")
+        assert health.status_code == 503 and health.json()["status"] == "draining"
+
+        response = test_client.post(
+            "/api/jobs", json={"job_description": "Test Job Description", "resume": "Resume text"}
+        )
+        assert response.status_code == 503
+        mock_workflow_runner.assert_not_called()
+    finally:
+        drain.reset()
@@ -0,0 +1,118 @@
+"""Container execution backends: worker launch, limits, and watching the store."""
+
+from unittest.mock import MagicMock, patch
+
+import pytest
+
+from web.backend.models import JobState
+from web.backend.services import execution
+from web.backend.services.execution import (
+    DockerBackend,
+    ExecutionBackendError,
+    KubernetesBackend,
+    backend_from_env,
+    watch_job,
+    worker_name,
+)
+from web.backend.services.job_queue import job_queue
+
+
+def test_docker_workers_get_limits_and_pass_through_keys(monkeypatch):
+    monkeypatch.setenv("OPENAI_API_KEY", "sk-test")
+    monkeypatch.delenv("CHUTES_API_KEY", raising=False)
+    backend = DockerBackend(image="hydra:1", cpus="2", memory="512Mi")
+
+    command = backend.command("Job_42")
+
+    assert command[:3] == ["docker", "run", "--detach"]
+    assert command[command.index("--name") + 1] == "hydra-job-job-42"
+    assert command[command.index("--cpus") + 1] == "2"
+    assert command[command.index("--memory") + 1] == "512m"
+    assert "OPENAI_API_KEY" in command and "sk-test" not in command
+    assert "CHUTES_API_KEY" not in command
+    assert command[-5:] == ["hydra:1", "python", "-m", "web.backend.worker", "Job_42"]
+
+
+def test_kubernetes_job_has_resources_deadline_and_secret():
+    backend = KubernetesBackend(namespace="hydra", env_secret="keys", timeout=600)
+
+    manifest = backend.manifest("abc")
+
+    assert manifest["metadata"] == {
+        "name": "hydra-job-abc",
+        "namespace": "hydra",
+        "labels": {"app": "hydra-worker", "hydra.job": "hydra-job-abc"},
+    }
+    assert manifest["spec"]["backoffLimit"] == 0
+    assert manifest["spec"]["activeDeadlineSeconds"] == 600
+    container = manifest["spec"]["template"]["spec"]["containers"][0]
+    assert container["args"] == ["python", "-m", "web.backend.worker", "abc"]
+    assert container["envFrom"] == [{"secretRef": {"name": "keys"}}]
+    assert container["resources"]["limits"] == {"cpu": "1", "memory": "2Gi"}
+
+
+def test_kubernetes_job_status_is_read_with_kubectl():
+    backend = KubernetesBackend()
+    with patch.object(execution.subprocess, "run") as run:
+        run.return_value = MagicMock(returncode=0, stdout="/")
+        assert backend.running("abc")
+        run.return_value = MagicMock(returncode=0, stdout="1/")
+        assert not backend.running("abc")
+        run.return_value = MagicMock(returncode=1, stdout="")
+        assert not backend.running("abc")
+
+
+def test_backend_is_chosen_from_the_env(monkeypatch):
+    monkeypatch.delenv("HYDRA_EXECUTION_BACKEND", raising=False)
+    assert backend_from_env() is None
+
+    monkeypatch.setenv("HYDRA_EXECUTION_BACKEND", "kubernetes")
+    monkeypatch.setenv("HYDRA_WORKER_MEMORY", "4Gi")
+    backend = backend_from_env()
+    assert isinstance(backend, KubernetesBackend) and backend.memory == "4Gi"
+
+    monkeypatch.setenv("HYDRA_EXECUTION_BACKEND", "lambda")
+    with pytest.raises(ExecutionBackendError, match="inprocess, docker, kubernetes"):
+        backend_from_env()
+
+
+def test_worker_names_are_valid_kubernetes_names():
+    name = worker_name("A_B" * 40)
+    assert len(name) <= 63 and name == name.lower() and "_" not in name
+
+
+@pytest.mark.asyncio
+async def test_a_worker_gone_without_a_result_fails_the_job(monkeypatch):
+    monkeypatch.setattr(execution, "WATCH_SECONDS", 0)
+    backend = MagicMock(timeout=60)
+    backend.running.return_value = False
+    job = job_queue.create_job(job_description="Test JD", resume="Test resume")
+    job_queue.update_job(job.id, state=JobState.TAILORING, execution_log=["Started"])
+
+    await watch_job(job_queue.get_job(job.id), backend)
+
+    stored = job_queue.get_job(job.id)
+    assert stored.state == JobState.FAILED
+    assert "exited without a result" in stored.error_message
+    events = []
+    while not stored._event_queue.empty():
+        events.append((await stored._event_queue.get())["event"])
+    assert events[-1] == "complete"
+
+
+@pytest.mark.asyncio
+async def test_an_interrupted_worker_is_relaunched_from_its_checkpoint():
+    from web.backend.services.workflow_runner import run_workflow_in_container
+
+    job = job_queue.create_job(job_description="Test JD", resume="Test resume")
+    backend = MagicMock()
+    outcomes = iter([JobState.INTERRUPTED, JobState.COMPLETED])
+
+    async def _worker_ran(watched, _backend):
+        job_queue.update_job(watched.id, state=next(outcomes))
+
+    with patch("web.backend.services.workflow_runner.watch_job", side_effect=_worker_ran):
+        await run_workflow_in_container(job, backend)
+
+    assert backend.launch.call_count == 2
+    assert job_queue.get_job(job.id).state == JobState.COMPLETED
@@ -0,0 +1,273 @@
+"""Tests for the gRPC API servicer (called directly, without a network server)."""
+
+import json
+from datetime import datetime
+
+import pytest
+
+pytest.importorskip("grpc")
+
+import grpc
+
+from web.backend.grpc_api import hydra_pb2
+from web.backend.grpc_api import server as grpc_server
+from web.backend.models import JobState
+from web.backend.services import idempotency
+from web.backend.services.job_queue import Job
+
+
+class _Aborted(Exception):
+    pass
+
+
+class _Context:
+    """Stands in for grpc.aio.ServicerContext: abort() raises, as the real one does."""
+
+    def __init__(self, metadata=()):
+        self.code = None
+        self.details = None
+        self.metadata = metadata
+
+    def invocation_metadata(self):
+        return self.metadata
+
+    async def abort(self, code, details):
+        self.code, self.details = code, details
+        raise _Aborted(details)
+
+
+class _Queue:
+    """In-memory job_queue with the calls the servicer makes."""
+
+    def __init__(self):
+        self.jobs = {}
+
+    def create_job(self, **fields):
+        job = Job(id=f"job-{len(self.jobs) + 1}", **fields)
+        self.jobs[job.id] = job
+        return job
+
+    def get_job(self, job_id):
+        return self.jobs.get(job_id)
+
+    def update_job(self, job_id, **fields):
+        job = self.jobs[job_id]
+        for key, value in fields.items():
+            setattr(job, key, value)
+        return job
+
+    def find_by_idempotency_key(self, owner, key):
+        return next(
+            (j for j in self.jobs.values() if j.owner == owner and j.idempotency_key == key),
+            None,
+        )
+
+
+@pytest.fixture
+def queue(monkeypatch):
+    queue = _Queue()
+    started = []
+    monkeypatch.setattr(grpc_server, "job_queue", queue)
+    monkeypatch.setattr(grpc_server, "start_workflow_background", started.append)
+    queue.started = started
+    return queue
+
+
+@pytest.fixture
+def servicer():
+    return grpc_server.HydraServicer()
+
+
+@pytest.mark.asyncio
+async def test_create_workflow_validates_like_rest(queue, servicer):
+    context = _Context()
+    with pytest.raises(_Aborted):
+        await servicer.CreateWorkflow(
+            hydra_pb2.CreateWorkflowRequest(job_description="short", resume="x" * 20), context
+        )
+    assert context.code == grpc.StatusCode.INVALID_ARGUMENT
+    assert context.details.startswith("job_description:")
+
+    created = await servicer.CreateWorkflow(
+        hydra_pb2.CreateWorkflowRequest(
+            job_description="Platform engineer, AWS", resume="Jane Doe, SRE", company="Acme"
+        ),
+        _Context(),
+    )
+
+    job = queue.jobs[created.workflow_id]
+    assert created.status == "queued" and queue.started == [job]
+    assert job.company == "Acme" and job.role_title is None and job.max_audit_retries == 2
+
+
+@pytest.mark.asyncio
+async def test_a_repeated_idempotency_key_returns_the_first_workflow(
+    queue, servicer, monkeypatch
+):
+    monkeypatch.setattr(idempotency, "job_queue", queue)
+    keyed = (("idempotency-key", "retry-1"),)
+    request = hydra_pb2.CreateWorkflowRequest(
+        job_description="Platform engineer, AWS", resume="Jane Doe, SRE"
+    )
+
+    first = await servicer.CreateWorkflow(request, _Context(keyed))
+    again = await servicer.CreateWorkflow(request, _Context(keyed))
+    assert (first.status, again.status) == ("queued", "duplicate")
+    assert again.workflow_id == first.workflow_id and len(queue.started) == 1
+
+    context = _Context(keyed)
+    request.resume = "Someone else entirely"
+    with pytest.raises(_Aborted):
+        await servicer.CreateWorkflow(request, context)
+    assert context.code == grpc.StatusCode.INVALID_ARGUMENT
+
+
+@pytest.mark.asyncio
+async def test_get_state_maps_the_job(queue, servicer):
+    job = queue.create_job(job_description="JD", resume="R")
+    queue.update_job(
+        job.id,
+        state=JobState.GAP_ANALYSIS_REVIEW,
+        awaiting_user="greenlight",
+        intermediate_results={"gap_analysis": {"gaps": ["Kubernetes"]}},
+        agent_models={"gap_analyzer": "m"},
+    )
+
+    workflow = await servicer.GetState(hydra_pb2.GetStateRequest(workflow_id=job.id), _Context())
+
+    assert workflow.state == hydra_pb2.STATE_GAP_ANALYSIS_REVIEW
+    assert workflow.awaiting_input == hydra_pb2.AWAITING_INPUT_GREENLIGHT
+    assert json.loads(workflow.intermediate_results_json)["gap_analysis"]["gaps"] == ["Kubernetes"]
+    assert dict(workflow.agent_models) == {"gap_analyzer": "m"}
+    assert not workflow.HasField("final_documents") and workflow.completed_at == ""
+
+    context = _Context()
+    with pytest.raises(_Aborted):
+        await servicer.GetState(hydra_pb2.GetStateRequest(workflow_id="nope"), context)
+    assert context.code == grpc.StatusCode.NOT_FOUND
+
+
+@pytest.mark.asyncio
+async def test_greenlight_approve_decline_and_out_of_turn(queue, servicer):
+    job = queue.create_job(job_description="JD", resume="R")
+    context = _Context()
+    with pytest.raises(_Aborted):
+        await servicer.SubmitGreenlight(
+            hydra_pb2.SubmitGreenlightRequest(workflow_id=job.id, approve=True), context
+        )
+    assert context.code == grpc.StatusCode.FAILED_PRECONDITION
+
+    queue.update_job(job.id, state=JobState.GAP_ANALYSIS_REVIEW, awaiting_user="greenlight")
+    approved = await servicer.SubmitGreenlight(
+        hydra_pb2.SubmitGreenlightRequest(workflow_id=job.id, approve=True, notes="Lead with SRE"),
+        _Context(),
+    )
+    assert approved.status == "approved" and queue.started == [job]
+    assert job.gap_analysis_approved and job.greenlight_notes == "Lead with SRE"
+
+    queue.update_job(job.id, state=JobState.TAILORING)
+    again = await servicer.SubmitGreenlight(
+        hydra_pb2.SubmitGreenlightRequest(workflow_id=job.id, approve=True), _Context()
+    )
+    assert again.status == "noop"
+
+    declined_job = queue.create_job(job_description="JD", resume="R")
+    queue.update_job(declined_job.id, state=JobState.GAP_ANALYSIS_REVIEW)
+    declined = await servicer.SubmitGreenlight(
+        hydra_pb2.SubmitGreenlightRequest(workflow_id=declined_job.id, approve=False), _Context()
+    )
+    assert declined.status == "declined" and declined_job.state == JobState.FAILED
+    assert (await declined_job.get_event(timeout=1))["event"] == "complete"
+
+
+@pytest.mark.asyncio
+async def test_interview_answers_resume_the_workflow(queue, servicer):
+    job = queue.create_job(job_description="JD", resume="R")
+    queue.update_job(job.id, state=JobState.INTERROGATION_REVIEW)
+    answer = hydra_pb2.InterviewAnswer(question_id="q1", answer="Three years of EKS")
+
+    reply = await servicer.SubmitInterviewAnswers(
+        hydra_pb2.SubmitInterviewAnswersRequest(workflow_id=job.id, answers=[answer]), _Context()
+    )
+
+    assert reply.status == "submitted" and queue.started == [job]
+    assert job.interview_answers == [
+        {"question_id": "q1", "question": "", "answer": "Three years of EKS"}
+    ]
+
+
+@pytest.mark.asyncio
+async def test_stream_follows_the_sse_sequence(queue, servicer):
+    job = queue.create_job(job_description="JD", resume="R")
+    await job.emit_event("progress", {"state": "tailoring"})
+    await job.emit_event("complete", {"success": True})
+
+    request = hydra_pb2.StreamEventsRequest(workflow_id=job.id)
+    events = [event async for event in servicer.StreamEvents(request, _Context())]
+
+    assert [event.type for event in events] == ["connected", "progress", "complete"]
+    assert json.loads(events[1].data_json) == {"state": "tailoring"}
+
+    queue.update_job(job.id, state=JobState.COMPLETED, completed_at=datetime.now())
+    done = [event async for event in servicer.StreamEvents(request, _Context())]
+    assert [event.type for event in done] == ["connected", "complete"]
+
+
+@pytest.mark.asyncio
+async def test_pause_resume_and_cancel(queue, servicer, monkeypatch):
+    from web.backend.services import job_control, workflow_runner
+
+    monkeypatch.setattr(job_control, "job_queue", queue)
+    monkeypatch.setattr(job_control, "start_workflow_background", queue.started.append)
+    monkeypatch.setattr(workflow_runner, "job_queue", queue)
+    job = queue.create_job(job_description="JD", resume="R")
+    queue.update_job(job.id, state=JobState.INTERRUPTED)
+    request = {"workflow_id": job.id}
+
+    paused = await servicer.PauseWorkflow(hydra_pb2.PauseWorkflowRequest(**request), _Context())
+    assert paused.status == "paused" and job.state == JobState.PAUSED
+    state = await servicer.GetState(hydra_pb2.GetStateRequest(**request), _Context())
+    assert state.state == hydra_pb2.STATE_PAUSED
+
+    resumed = await servicer.ResumeWorkflow(hydra_pb2.ResumeWorkflowRequest(**request), _Context())
+    assert resumed.status == "resumed" and queue.started == [job]
+
+    cancel = hydra_pb2.CancelWorkflowRequest(**request)
+    cancelled = await servicer.CancelWorkflow(cancel, _Context())
+    assert cancelled.status == "cancelled" and job.state == JobState.CANCELLED
+
+    context = _Context()
+    with pytest.raises(_Aborted):
+        await servicer.ResumeWorkflow(hydra_pb2.ResumeWorkflowRequest(**request), context)
+    assert context.code == grpc.StatusCode.FAILED_PRECONDITION
+
+
+@pytest.mark.asyncio
+async def test_with_api_keys_calls_need_a_token_and_see_only_their_workflows(
+    queue, servicer, monkeypatch
+):
+    monkeypatch.setenv("HYDRA_API_KEYS", "alice:tok-a:10,bob:tok-b")
+    alice = (("authorization", "Bearer tok-a"),)
+    request = hydra_pb2.CreateWorkflowRequest(
+        job_description="Platform engineer, AWS", resume="Jane Doe, SRE"
+    )
+
+    context = _Context()
+    with pytest.raises(_Aborted):
+        await servicer.CreateWorkflow(request, context)
+    assert context.code == grpc.StatusCode.UNAUTHENTICATED
+
+    monkeypatch.setattr(grpc_server, "budget_refusal", lambda user: None)
+    created = await servicer.CreateWorkflow(request, _Context(alice))
+    assert queue.jobs[created.workflow_id].owner == "alice"
+
+    context = _Context((("authorization", "Bearer tok-b"),))
+    with pytest.raises(_Aborted):
+        await servicer.GetState(hydra_pb2.GetStateRequest(workflow_id=created.workflow_id), context)
+    assert context.code == grpc.StatusCode.NOT_FOUND
+
+    monkeypatch.setattr(grpc_server, "budget_refusal", lambda user: f"{user.name} is out")
+    context = _Context(alice)
+    with pytest.raises(_Aborted):
+        await servicer.CreateWorkflow(request, context)
+    assert context.code == grpc.StatusCode.RESOURCE_EXHAUSTED and context.details == "alice is out"
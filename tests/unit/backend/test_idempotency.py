@@ -0,0 +1,104 @@
+"""Idempotent job creation: a repeated request gets the earlier job, not a second run."""
+
+import uuid
+
+import pytest
+
+from web.backend.models import JobState
+from web.backend.services import idempotency
+from web.backend.services.idempotency import (
+    DUPLICATE_WINDOW_ENV,
+    IdempotencyError,
+    check_key,
+    create_once,
+    request_hash,
+)
+from web.backend.services.job_queue import Job, job_queue
+
+
+class _Queue:
+    """The lookups idempotency makes, over jobs kept in memory."""
+
+    def __init__(self):
+        self.jobs = []
+
+    def create(self, owner, key, digest):
+        job = Job(id=f"job-{len(self.jobs) + 1}", owner=owner)
+        job.idempotency_key, job.request_hash = key, digest
+        self.jobs.append(job)
+        return job
+
+    def find_by_idempotency_key(self, owner, key):
+        return next((j for j in self.jobs if j.owner == owner and j.idempotency_key == key), None)
+
+    def find_duplicate(self, owner, digest, since):
+        matches = [j for j in self.jobs if j.owner == owner and j.request_hash == digest]
+        return matches[-1] if matches else None
+
+
+@pytest.fixture
+def queue(monkeypatch):
+    queue = _Queue()
+    monkeypatch.setattr(idempotency, "job_queue", queue)
+    return queue
+
+
+def _create(queue, owner, key, digest):
+    return create_once(owner, key, digest, lambda: queue.create(owner, key, digest))
+
+
+def test_the_hash_covers_what_the_run_depends_on():
+    digest = request_hash("Platform engineer", "Jane Doe, SRE")
+    assert digest == request_hash("  Platform engineer\n", "Jane Doe, SRE ")
+    assert digest != request_hash("Platform engineer", "Jane Doe, SRE", model="gpt-4o")
+    assert digest != request_hash("Platform engineer", "Jane Doe, SRE", max_audit_retries=0)
+
+
+def test_keys_are_checked():
+    assert check_key(None) is None and check_key(" abc-123 ") == "abc-123"
+    for bad in ("", "   ", "k" * 256):
+        with pytest.raises(IdempotencyError):
+            check_key(bad)
+
+
+def test_the_same_key_returns_the_first_job(queue):
+    digest = request_hash("JD", "Resume")
+    first, created = _create(queue, "alice", "key-1", digest)
+    again, created_again = _create(queue, "alice", "key-1", digest)
+    assert created and not created_again and again is first
+
+    # Keys are per user, and a new key runs the same inputs again on purpose.
+    assert _create(queue, "bob", "key-1", digest)[1]
+    assert _create(queue, "alice", "key-2", digest)[1]
+
+    with pytest.raises(IdempotencyError, match="different request"):
+        _create(queue, "alice", "key-1", request_hash("Other JD", "Resume"))
+
+
+def test_without_a_key_the_same_inputs_return_the_recent_job(queue, monkeypatch):
+    monkeypatch.setenv(DUPLICATE_WINDOW_ENV, "600")
+    digest = request_hash("JD", "Resume")
+    first, _ = _create(queue, None, None, digest)
+    assert _create(queue, None, None, digest) == (first, False)
+    assert _create(queue, None, None, request_hash("JD", "Other resume"))[1]
+
+    monkeypatch.setenv(DUPLICATE_WINDOW_ENV, "0")
+    assert _create(queue, None, None, digest)[1]
+
+
+def test_duplicates_are_found_in_the_job_queue():
+    key = uuid.uuid4().hex
+    digest = request_hash(f"Test JD {key}", "Test resume")
+    job = job_queue.create_job(
+        job_description=f"Test JD {key}",
+        resume="Test resume",
+        idempotency_key=key,
+        request_hash=digest,
+    )
+    since = job.created_at.replace(microsecond=0)
+
+    assert job_queue.find_by_idempotency_key(None, key).id == job.id
+    assert job_queue.find_by_idempotency_key("alice", key) is None
+    assert job_queue.find_duplicate(None, digest, since).id == job.id
+    job_queue.update_job(job.id, state=JobState.FAILED)
+    assert job_queue.find_duplicate(None, digest, since) is None
@@ -0,0 +1,142 @@
+"""Pause, resume and cancel: live runs stop at the right place, persisted jobs move at once."""
+
+from unittest.mock import MagicMock, patch
+
+import pytest
+
+from runtime.crewai.hydra_workflow import RunStatus, WorkflowResult, WorkflowState
+from web.backend.models import JobState
+from web.backend.services.drain import drain
+from web.backend.services.job_control import (
+    JobControlError,
+    cancel_job,
+    pause_job,
+    resume_job,
+)
+from web.backend.services.job_queue import job_queue
+from web.backend.services.workflow_runner import (
+    CANCEL_REASON,
+    PAUSE_REASON,
+    _job_state,
+    run_workflow_async,
+)
+
+
+def _interrupted() -> WorkflowResult:
+    return WorkflowResult(
+        state=WorkflowState.TAILORING,
+        success=False,
+        status=RunStatus.INTERRUPTED,
+        error_message=f"Interrupted: {PAUSE_REASON} during tailoring",
+        intermediate_results={"gap_analysis": {"gaps": []}},
+    )
+
+
+@pytest.mark.asyncio
+async def test_pausing_a_live_run_stops_it_at_the_boundary():
+    job = job_queue.create_job(job_description="Test JD", resume="Test resume")
+    workflow = MagicMock()
+    drain.register(job.id, workflow)
+    try:
+        reply = await pause_job(job)
+    finally:
+        drain.unregister(job.id)
+
+    assert reply["status"] == "pausing"
+    workflow.stop_after_stage.assert_called_once_with(PAUSE_REASON)
+    workflow.cancel.assert_not_called()
+    assert _job_state(_interrupted(), job.id) == JobState.PAUSED
+    # The request is used up: a later drain of the same job is a plain interruption.
+    assert _job_state(_interrupted(), job.id) == JobState.INTERRUPTED
+
+
+@pytest.mark.asyncio
+async def test_a_cancelled_live_run_is_persisted_as_final():
+    job = job_queue.create_job(job_description="Test JD", resume="Test resume")
+    result = _interrupted()
+
+    def _execute(context):
+        assert drain.workflow_for(job.id) is workflow
+        return result
+
+    with (
+        patch("web.backend.services.workflow_runner.HydraWorkflow") as workflow_class,
+        patch("web.backend.services.workflow_runner.get_llm_client"),
+    ):
+        workflow = MagicMock()
+        workflow.get_current_state.return_value = WorkflowState.TAILORING
+        workflow.get_execution_log.return_value = []
+        workflow.get_intermediate_results.return_value = result.intermediate_results
+        workflow.agent_models = {}
+        workflow_class.return_value = workflow
+        drain.register(job.id, workflow)
+        assert (await cancel_job(job))["status"] == "cancelling"
+        workflow.execute.side_effect = _execute
+        await run_workflow_async(job)
+
+    workflow.cancel.assert_called_once_with(CANCEL_REASON)
+    events = []
+    while not job._event_queue.empty():
+        events.append((await job._event_queue.get())["event"])
+    assert "complete" in events
+    stored = job_queue.get_job(job.id)
+    assert stored.state == JobState.CANCELLED and stored.completed_at is not None
+    assert stored.intermediate_results == {"gap_analysis": {"gaps": []}}
+
+
+@pytest.mark.asyncio
+async def test_persisted_jobs_pause_resume_and_cancel_at_once():
+    job = job_queue.create_job(job_description="Test JD", resume="Test resume")
+    job = job_queue.update_job(job.id, state=JobState.INTERRUPTED, error_message="Interrupted")
+
+    assert (await pause_job(job))["status"] == "paused"
+    assert job_queue.get_job(job.id).state == JobState.PAUSED
+    assert (await pause_job(job))["status"] == "noop"
+
+    with patch("web.backend.services.job_control.start_workflow_background") as start:
+        assert (await resume_job(job))["status"] == "resumed"
+    start.assert_called_once()
+    stored = job_queue.get_job(job.id)
+    assert stored.state == JobState.INITIALIZED and stored.error_message is None
+
+    job_queue.update_job(job.id, state=JobState.PAUSED)
+    assert (await cancel_job(job))["status"] == "cancelled"
+    stored = job_queue.get_job(job.id)
+    assert stored.state == JobState.CANCELLED and not stored.success
+    assert (await job.get_event(timeout=1))["event"] == "complete"
+    assert (await cancel_job(job))["status"] == "noop"
+
+    for action in (pause_job, resume_job):
+        with pytest.raises(JobControlError, match="cancelled"):
+            await action(job)
+
+
+@pytest.mark.asyncio
+async def test_a_job_awaiting_input_is_not_resumed_by_resume():
+    job = job_queue.create_job(job_description="Test JD", resume="Test resume")
+    job = job_queue.update_job(
+        job.id, state=JobState.GAP_ANALYSIS_REVIEW, awaiting_user="greenlight"
+    )
+
+    assert (await pause_job(job))["status"] == "noop"
+    with pytest.raises(JobControlError, match="awaiting greenlight"):
+        await resume_job(job)
+
+
+def test_control_endpoints(test_client, mock_workflow_runner):
+    assert test_client.post("/api/v1/jobs/nope/pause").status_code == 404
+
+    job = job_queue.create_job(job_description="Test JD", resume="Test resume")
+    job_queue.update_job(job.id, state=JobState.INTERRUPTED)
+
+    paused = test_client.post(f"/api/v1/jobs/{job.id}/pause")
+    assert paused.status_code == 200 and paused.json()["status"] == "paused"
+    assert test_client.get(f"/api/v1/jobs/{job.id}").json()["state"] == "paused"
+
+    with patch("web.backend.services.job_control.start_workflow_background"):
+        resumed = test_client.post(f"/api/v1/jobs/{job.id}/resume")
+    assert resumed.json()["status"] == "resumed"
+
+    job_queue.update_job(job.id, state=JobState.COMPLETED)
+    refused = test_client.post(f"/api/v1/jobs/{job.id}/cancel")
+    assert refused.status_code == 400 and "completed" in refused.json()["detail"]
@@ -0,0 +1,124 @@
+"""Job leases: one server instance runs a job at a time, and a stalled one backs off."""
+
+import asyncio
+import time
+from unittest.mock import MagicMock
+
+import pytest
+
+from web.backend.models import JobState
+from web.backend.services import job_queue as job_queue_module
+from web.backend.services import workflow_runner
+from web.backend.services.drain import drain
+from web.backend.services.job_queue import Job, job_queue
+from web.backend.services.leases import LEASE_SECONDS_ENV, LeaseLostError, Leases
+
+
+def test_a_job_is_leased_to_one_instance_at_a_time():
+    job = job_queue.create_job(job_description="Test JD", resume="Test resume")
+    first, second = Leases("instance-a"), Leases("instance-b")
+
+    assert first.acquire(job.id) and not second.acquire(job.id)
+    assert second.holder(job.id) == "instance-a" and first.holder(job.id) is None
+
+    # A pause asked of the other instance reaches the holder with its next renewal.
+    assert second.request_stop(job.id, JobState.PAUSED.value)
+    assert first.renew(job.id) == "paused"
+
+    first.release(job.id)
+    assert second.acquire(job.id) and second.holder(job.id) is None
+    with pytest.raises(LeaseLostError):
+        first.renew(job.id)
+    second.release(job.id)
+
+
+def test_an_instance_that_lost_its_lease_cannot_overwrite_the_job(monkeypatch):
+    job = job_queue.create_job(job_description="Test JD", resume="Test resume")
+    stalled, successor = Leases("instance-a"), Leases("instance-b")
+    monkeypatch.setattr(job_queue_module, "leases", stalled)
+    monkeypatch.setenv(LEASE_SECONDS_ENV, "0.1")
+
+    assert stalled.acquire(job.id)
+    job_queue.update_job(job.id, state=JobState.TAILORING)
+    time.sleep(0.2)  # stalled past its lease
+    assert job.id in successor.orphaned()
+    assert successor.acquire(job.id)
+
+    with pytest.raises(LeaseLostError):
+        job_queue.update_job(job.id, state=JobState.FAILED)
+    assert job_queue.refresh_job(job.id).state == JobState.TAILORING
+    stalled.release(job.id)  # its lease is gone: the successor's stays
+    assert stalled.holder(job.id) == "instance-b"
+    successor.release(job.id)
+
+
+@pytest.mark.asyncio
+async def test_the_heartbeat_relays_stops_and_reports_a_lost_lease(monkeypatch):
+    monkeypatch.setenv(LEASE_SECONDS_ENV, "0.03")
+    renewals = iter([None, "paused", "paused", ConnectionError("db down"), LeaseLostError()])
+    lease = Leases("instance-a")
+
+    def _renew(job_id):
+        outcome = next(renewals)
+        if isinstance(outcome, Exception):
+            raise outcome
+        return outcome
+
+    monkeypatch.setattr(lease, "renew", _renew)
+    stops, lost = [], []
+
+    await asyncio.wait_for(lease.keep("job-1", stops.append, lambda: lost.append(1)), 2)
+
+    assert stops == ["paused"] and lost == [1]
+
+
+@pytest.fixture
+def leases(monkeypatch):
+    fake = MagicMock()
+    fake.acquire.return_value = True
+    fake.fence.return_value = None
+    monkeypatch.setattr(workflow_runner, "leases", fake)
+    monkeypatch.setattr(workflow_runner, "backend_from_env", lambda: None)
+    return fake
+
+
+@pytest.mark.asyncio
+async def test_a_run_whose_lease_is_lost_is_abandoned(leases, monkeypatch):
+    job = Job(id="job-1")
+    monkeypatch.setattr(job_queue, "refresh_job", lambda job_id: job)
+    workflow = MagicMock()
+    finished = asyncio.Event()
+
+    async def _run(job):
+        drain.register(job.id, workflow)
+        try:
+            await asyncio.sleep(5)
+        finally:
+            drain.unregister(job.id)
+            finished.set()
+
+    async def _keep(job_id, on_stop, on_lost):
+        await asyncio.sleep(0.05)
+        on_lost()
+
+    monkeypatch.setattr(workflow_runner, "run_workflow_async", _run)
+    leases.keep = _keep
+
+    assert await asyncio.wait_for(workflow_runner.run_job(job.id), 2)
+
+    assert finished.is_set()
+    workflow.cancel.assert_called_once_with(workflow_runner.LEASE_LOST_REASON)
+    leases.release.assert_called_once_with("job-1")
+
+    leases.acquire.return_value = False
+    leases.holder.return_value = "instance-b"
+    assert not await workflow_runner.run_job(job.id)
+
+
+def test_stopping_a_job_run_elsewhere_goes_through_the_row(leases):
+    leases.request_stop.return_value = True
+    assert workflow_runner.request_stop("job-1", JobState.CANCELLED)
+    leases.request_stop.assert_called_once_with("job-1", "cancelled")
+
+    leases.request_stop.return_value = False
+    assert not workflow_runner.request_stop("job-1", JobState.PAUSED)
@@ -4,9 +4,11 @@ from unittest.mock import patch
 
 import pytest
 
+from runtime.crewai.encryption import ENCRYPT_ENV, PASSPHRASE_ENV, seal_json, seal_text
 from runtime.crewai.hydra_workflow import WorkflowState
+from runtime.crewai.state_schema import STATE_VERSION
 from web.backend.models import JobState
-from web.backend.services.job_queue import Job, JobQueue
+from web.backend.services.job_queue import Job, JobQueue, _row_to_job
 from web.backend.services.workflow_runner import _map_workflow_state, run_workflow_async
 
 # --- JobQueue Tests ---
@@ -74,6 +76,45 @@ def test_job_queue_delete_job():
     assert queue.get_job(job.id) is None
     assert queue.delete_job("non-existent") is False
 
+def test_row_from_before_state_versioning_is_upgraded():
+    """A row without state_version is migrated from version 1 when loaded"""
+    row = {
+        "id": "legacy",
+        "state": "completed",
+        "success": True,
+        "intermediate_results": {
+            "executive_synthesis": {"decision": {"recommendation": "PASS", "fit_score": 0.9}}
+        },
+    }
+
+    job = _row_to_job(row)
+
+    decision = job.intermediate_results["executive_synthesis"]["decision"]
+    assert decision["recommendation"] == "STRONG_PROCEED"
+    assert job.state_version == STATE_VERSION
+
+def test_sealed_row_is_opened_when_loaded(monkeypatch):
+    """With encryption on, the row's content columns are stored sealed and load in the clear"""
+    monkeypatch.setenv(ENCRYPT_ENV, "1")
+    monkeypatch.setenv(PASSPHRASE_ENV, "correct horse battery staple")
+    row = {
+        "id": "sealed",
+        "state": "completed",
+        "success": True,
+        "resume": seal_text("# Jane Doe"),
+        "job_description": seal_text("Platform Engineer"),
+        "final_documents": seal_json({"resume": "# Jane Doe"}),
+        "intermediate_results": seal_json({"tailoring": {"tailored_resume": "# Jane Doe"}}),
+        "state_version": STATE_VERSION,
+    }
+    assert "Jane" not in str(row)
+
+    job = _row_to_job(row)
+
+    assert job.resume == "# Jane Doe" and job.job_description == "Platform Engineer"
+    assert job.final_documents == {"resume": "# Jane Doe"}
+    assert job.intermediate_results["tailoring"]["tailored_resume"] == "# Jane Doe"
+
 # --- WorkflowRunner Tests ---
 
 def test_map_workflow_state():
@@ -0,0 +1,173 @@
+"""Artifact and state storage: local disk, object stores, prefixes and lifecycle."""
+
+from unittest.mock import MagicMock, patch
+
+import pytest
+
+from runtime.crewai.encryption import ENCRYPT_ENV, MAGIC, PASSPHRASE_ENV
+from web.backend.services import storage
+from web.backend.services.job_queue import Job
+from web.backend.services.storage import (
+    LocalArtifactStore,
+    ObjectArtifactStore,
+    StorageConfigError,
+    apply_lifecycle_from_env,
+    artifact_store_from_env,
+    client_for,
+    state_store_from_env,
+)
+from web.backend.services.workflow_runner import _persist_hydra_results
+
+
+class FakeBucket:
+    """An in-memory bucket with the ObjectClient interface."""
+
+    scheme = "s3"
+
+    def __init__(self, bucket="hydra-runs"):
+        self.bucket = bucket
+        self.objects = {}
+        self.lifecycle = []
+
+    def put(self, key, data, content_type):
+        self.objects[key] = data
+
+    def get(self, key):
+        return self.objects.get(key)
+
+    def delete(self, key):
+        self.objects.pop(key, None)
+
+    def set_lifecycle(self, rule, prefix, expire_days, archive_days):
+        self.lifecycle.append((rule, prefix, expire_days, archive_days))
+
+
+@pytest.fixture
+def bucket(monkeypatch):
+    monkeypatch.setenv(storage.STORE_ENV, "s3://hydra-runs/prod/eu")
+    monkeypatch.delenv(storage.EXPIRE_DAYS_ENV, raising=False)
+    monkeypatch.delenv(storage.ARCHIVE_DAYS_ENV, raising=False)
+    fake = FakeBucket()
+    monkeypatch.setattr(storage, "client_for", lambda url: fake)
+    return fake
+
+
+def test_disk_is_the_default_and_keeps_its_layout(tmp_path, monkeypatch):
+    monkeypatch.delenv(storage.STORE_ENV, raising=False)
+    monkeypatch.setenv(storage.ARTIFACTS_DIR_ENV, str(tmp_path))
+
+    store = artifact_store_from_env()
+    path = store.write(
+        owner="Alice",
+        company="Acme Corp",
+        role_title="SRE",
+        run_id="7",
+        kind="resume",
+        content="# Jane",
+    )
+
+    assert isinstance(store, LocalArtifactStore) and state_store_from_env() is None
+    assert path == str(tmp_path / "alice" / "Acme_Corp" / "SRE" / "7" / "resume.md")
+    assert (tmp_path / "alice" / "Acme_Corp" / "SRE" / "7" / "resume.md").read_text() == "# Jane"
+
+
+def test_object_store_writes_documents_and_state_under_the_prefix(bucket):
+    store = artifact_store_from_env()
+    url = store.write(
+        owner="Alice",
+        company="Acme Corp",
+        role_title="SRE",
+        run_id="7",
+        kind="resume",
+        content="# Jane",
+    )
+    assert isinstance(store, ObjectArtifactStore)
+    assert url == "s3://hydra-runs/prod/eu/alice/Acme_Corp/SRE/7/resume.md"
+    assert bucket.objects["prod/eu/alice/Acme_Corp/SRE/7/resume.md"] == b"# Jane"
+
+    states = state_store_from_env()
+    states.save("job-1", None, {"state": "paused", "intermediate_results": {"gap_analysis": {}}})
+    assert "prod/eu/jobs/job-1/state.json" in bucket.objects
+    assert states.load("job-1", None)["state"] == "paused"
+    assert states.load("job-2", None) is None
+
+
+def test_documents_and_snapshots_are_sealed_with_encryption_on(bucket, monkeypatch):
+    monkeypatch.setenv(ENCRYPT_ENV, "1")
+    monkeypatch.setenv(PASSPHRASE_ENV, "correct horse battery staple")
+    artifact_store_from_env().write(
+        owner=None, company="Acme", role_title="SRE", run_id="7", kind="resume", content="# Jane"
+    )
+    states = state_store_from_env()
+    states.save("job-1", None, {"intermediate_results": {"tailoring": "# Jane"}})
+
+    for key in ("prod/eu/Acme/SRE/7/resume.md", "prod/eu/jobs/job-1/state.json"):
+        assert bucket.objects[key].startswith(MAGIC) and b"Jane" not in bucket.objects[key]
+    assert states.load("job-1", None)["intermediate_results"] == {"tailoring": "# Jane"}
+
+
+def test_finished_jobs_snapshot_their_state_and_store_their_documents(bucket):
+    job = Job(id="job-1", owner="bob", company="Acme", role_title="SRE")
+    job.hydra_job_id, job.hydra_run_id = "j", "run-9"
+    job.final_documents = {"resume": "# Bob"}
+    job.intermediate_results = {"tailoring": {"tailored_resume": "# Bob"}}
+
+    with patch("web.backend.services.workflow_runner.hydra_db") as db:
+        db.list_interviews.return_value = [{}]
+        _persist_hydra_results(job)
+
+    state = state_store_from_env().load("job-1", "bob")
+    assert state["intermediate_results"]["tailoring"]["tailored_resume"] == "# Bob"
+    call = db.create_stored_artifact.call_args.kwargs
+    assert call["owner"] == "bob" and call["kind"] == "resume"
+    assert isinstance(call["store"], ObjectArtifactStore)
+
+    # A bucket that is down costs the snapshot, not the job.
+    bucket.put = MagicMock(side_effect=OSError("unreachable"))
+    with patch("web.backend.services.workflow_runner.hydra_db"):
+        _persist_hydra_results(job)
+
+
+def test_stores_delete_only_their_own_documents(bucket, tmp_path):
+    local = LocalArtifactStore(tmp_path / "runs")
+    path = local.write(
+        owner=None, company="Acme", role_title="SRE", run_id="7", kind="resume", content="#"
+    )
+    outside = tmp_path / "notes.md"
+    outside.write_text("keep")
+    assert local.delete(path) and not (tmp_path / "runs" / "Acme").exists()
+    assert not local.delete(str(outside)) and outside.exists()
+
+    store = artifact_store_from_env()
+    url = store.write(
+        owner=None, company="Acme", role_title="SRE", run_id="7", kind="resume", content="#"
+    )
+    states = state_store_from_env()
+    states.save("job-1", None, {"state": "completed"})
+    assert store.delete(url) and states.delete("job-1", None) is None
+    assert bucket.objects == {}
+    assert not store.delete("s3://other-bucket/prod/eu/Acme/SRE/7/resume.md")
+    assert not store.delete("s3://hydra-runs/staging/Acme/SRE/7/resume.md")
+
+
+def test_lifecycle_rules_follow_the_prefix(bucket, monkeypatch):
+    assert apply_lifecycle_from_env() is False  # nothing configured
+
+    monkeypatch.setenv(storage.EXPIRE_DAYS_ENV, "365")
+    monkeypatch.setenv(storage.ARCHIVE_DAYS_ENV, "30")
+    assert apply_lifecycle_from_env() is True
+    assert bucket.lifecycle == [("hydra-prod-eu", "prod/eu/", 365, 30)]
+
+    monkeypatch.setenv(storage.ARCHIVE_DAYS_ENV, "400")
+    with pytest.raises(StorageConfigError, match="less than"):
+        apply_lifecycle_from_env()
+    monkeypatch.setenv(storage.EXPIRE_DAYS_ENV, "a year")
+    with pytest.raises(StorageConfigError, match="whole number of days"):
+        apply_lifecycle_from_env()
+
+
+def test_store_urls_are_checked():
+    with pytest.raises(StorageConfigError, match="must be 'file'"):
+        client_for("ftp://host/runs")
+    with pytest.raises(StorageConfigError, match="no bucket"):
+        client_for("s3:///runs")
@@ -0,0 +1,174 @@
+"""Work queue: requests queue jobs, a bounded pool of workers runs them."""
+
+import asyncio
+from unittest.mock import MagicMock
+
+import pytest
+
+from web.backend.models import JobState
+from web.backend.queue_worker import serve
+from web.backend.services import work_queue, workflow_runner
+from web.backend.services.drain import drain
+from web.backend.services.job_queue import Job, job_queue
+from web.backend.services.work_queue import (
+    LocalWorkQueue,
+    RedisWorkQueue,
+    WorkerPool,
+    WorkQueueError,
+    work_queue_from_env,
+    workers_from_env,
+)
+
+
+class FakeRedis:
+    """The redis.asyncio calls RedisWorkQueue makes, on an in-memory list."""
+
+    def __init__(self):
+        self.items = []
+
+    async def lpush(self, key, value):
+        self.items.insert(0, value.encode())
+
+    async def brpop(self, keys, timeout):
+        if not self.items:
+            await asyncio.sleep(0.01)  # the server's blocking wait, cut short
+            return None
+        return keys[0].encode(), self.items.pop()
+
+    async def aclose(self):
+        pass
+
+
+@pytest.fixture
+def env(monkeypatch):
+    monkeypatch.delenv(work_queue.WORK_QUEUE_ENV, raising=False)
+    monkeypatch.delenv(work_queue.WORKERS_ENV, raising=False)
+    return monkeypatch
+
+
+@pytest.mark.asyncio
+async def test_workers_run_queued_jobs_a_few_at_a_time():
+    queue, ran, running = LocalWorkQueue(), [], []
+
+    async def _run(job_id):
+        running.append(job_id)
+        assert len(running) <= 2
+        await asyncio.sleep(0.02)
+        running.remove(job_id)
+        ran.append(job_id)
+
+    pool = WorkerPool(queue, 2, _run)
+    pool.start()
+    for n in range(5):
+        await queue.put(f"job-{n}")
+    while len(ran) < 5:
+        await asyncio.sleep(0.01)
+    assert await pool.wait_idle(1) and pool.busy == 0
+    await pool.stop()
+
+    assert sorted(ran) == [f"job-{n}" for n in range(5)]
+
+
+@pytest.mark.asyncio
+async def test_a_redis_queue_is_first_in_first_out_and_a_draining_worker_leaves_it():
+    queue = RedisWorkQueue("redis://localhost:6379/0", client=FakeRedis())
+    await queue.put("job-1")
+    await queue.put("job-2")
+    assert await queue.get() == "job-1"
+
+    ran = []
+    pool = WorkerPool(queue, 1, ran.append)
+    drain.begin()
+    try:
+        pool.start()
+        await asyncio.sleep(0.05)
+    finally:
+        drain.reset()
+    await pool.stop()
+
+    assert ran == [] and await queue.get() == "job-2"  # put back for another worker
+
+
+def test_queue_and_workers_come_from_the_environment(env):
+    assert isinstance(work_queue_from_env(), LocalWorkQueue) and workers_from_env() == 4
+
+    env.setenv(work_queue.WORK_QUEUE_ENV, "amqp://rabbit/jobs")
+    with pytest.raises(WorkQueueError, match="must be 'local'"):
+        work_queue_from_env()
+    env.setenv(work_queue.WORKERS_ENV, "lots")
+    with pytest.raises(WorkQueueError, match="whole number"):
+        workers_from_env()
+    env.setenv(work_queue.WORKERS_ENV, "-1")
+    with pytest.raises(WorkQueueError, match="negative"):
+        workers_from_env()
+
+
+@pytest.mark.asyncio
+async def test_started_jobs_go_through_the_queue(env, monkeypatch):
+    ran = []
+
+    async def _run_job(job_id, detached=False):
+        ran.append((job_id, detached))
+        return True
+
+    monkeypatch.setattr(workflow_runner, "run_job", _run_job)
+    workflow_runner.start_workers(2)
+    try:
+        workflow_runner.start_workflow_background(Job(id="job-1"))
+        for _ in range(100):
+            if ran:
+                break
+            await asyncio.sleep(0.01)
+    finally:
+        await workflow_runner.stop_workers()
+    assert ran == [("job-1", False)]
+
+    # An API-only server needs a queue other processes can reach.
+    with pytest.raises(WorkQueueError, match="needs a shared"):
+        workflow_runner.start_workers(0)
+
+
+@pytest.mark.asyncio
+async def test_an_api_only_server_queues_and_follows_the_job(env, monkeypatch):
+    redis = FakeRedis()
+    monkeypatch.setattr(
+        workflow_runner, "work_queue_from_env", lambda: RedisWorkQueue("redis://r", client=redis)
+    )
+    followed = []
+
+    async def _watch(job, workers):
+        followed.append(job.id)
+        job.state = JobState.COMPLETED
+
+    monkeypatch.setattr(workflow_runner, "watch_job", _watch)
+    monkeypatch.setattr(job_queue, "get_job", lambda job_id: None)
+
+    workflow_runner.start_workers(0)
+    try:
+        workflow_runner.start_workflow_background(Job(id="job-1"))
+        for _ in range(100):
+            if followed:
+                break
+            await asyncio.sleep(0.01)
+    finally:
+        await workflow_runner.stop_workers()
+
+    assert redis.items == [b"job-1"] and followed == ["job-1"]
+
+
+@pytest.mark.asyncio
+async def test_queued_jobs_that_were_stopped_meanwhile_are_skipped(monkeypatch):
+    leases = MagicMock()
+    monkeypatch.setattr(workflow_runner, "leases", leases)
+    job = Job(id="job-1", state=JobState.CANCELLED)
+    monkeypatch.setattr(job_queue, "refresh_job", lambda job_id: job)
+
+    assert not await workflow_runner.run_job("job-1")
+    job.state, job.awaiting_user = JobState.GAP_ANALYSIS_REVIEW, "greenlight"
+    assert not await workflow_runner.run_job("job-1")
+    leases.acquire.assert_not_called()
+
+
+@pytest.mark.asyncio
+async def test_a_queue_worker_needs_a_shared_queue(env):
+    assert await serve(1) == 2
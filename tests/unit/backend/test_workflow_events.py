@@ -0,0 +1,82 @@
+"""Workflow events stream: every client sees a run's stages, partial output and pauses."""
+
+import asyncio
+from unittest.mock import patch
+
+import pytest
+
+from web.backend.models import JobState
+from web.backend.services.job_queue import Job
+
+
+@pytest.mark.asyncio
+async def test_every_subscriber_gets_every_event():
+    job = Job(id="job-1")
+    first, second = job.subscribe(), job.subscribe()
+
+    await job.emit_event("partial", {"stage": "tailoring", "text": "Led "})
+    job.unsubscribe(second)
+    await job.emit_event("complete", {"job_id": "job-1"})
+
+    assert [first.get_nowait()["event"] for _ in range(2)] == ["partial", "complete"]
+    assert second.get_nowait()["event"] == "partial" and second.empty()
+    # The job stream's own queue is left as it was.
+    assert (await job.get_event(timeout=0.1))["event"] == "partial"
+
+
+def _subscribed(job, events):
+    queue: asyncio.Queue = asyncio.Queue()
+    for event, data in events:
+        queue.put_nowait({"event": event, "data": data})
+    return lambda: queue
+
+
+def test_stream_relays_stages_partial_output_and_pauses(test_client):
+    job = Job(
+        id="job-1",
+        state=JobState.GAP_ANALYSIS_REVIEW,
+        awaiting_user="greenlight",
+        intermediate_results={"gap_analysis": {"fit": "strong"}},
+    )
+    job.subscribe = _subscribed(
+        job,
+        [
+            ("log", {"message": "Greenlight approved"}),
+            ("progress", {"state": "tailoring", "progress": 60}),
+            ("partial", {"stage": "tailoring", "text": "Led the platform"}),
+            ("stage_complete", {"stage": "tailoring", "result": {}}),
+            ("complete", {"job_id": "job-1", "success": True}),
+            ("progress", {"state": "completed"}),
+        ],
+    )
+
+    with patch("web.backend.services.job_queue.JobQueue.get_job", return_value=job):
+        response = test_client.get("/api/v1/workflows/job-1/events")
+
+    assert response.status_code == 200
+    events = [line[7:] for line in response.text.splitlines() if line.startswith("event: ")]
+    assert events == [
+        "connected",
+        "awaiting_user",
+        "progress",
+        "partial",
+        "stage_complete",
+        "complete",
+    ]
+    assert '"awaiting_user": "greenlight"' in response.text
+    assert '"text": "Led the platform"' in response.text
+
+
+def test_stream_of_a_finished_run_sends_its_result_and_ends(test_client):
+    job = Job(id="job-1", state=JobState.COMPLETED, success=True)
+
+    with patch("web.backend.services.job_queue.JobQueue.get_job", return_value=job):
+        response = test_client.get("/api/v1/workflows/job-1/events")
+
+    assert "event: connected" in response.text and "event: complete" in response.text
+    assert job._subscribers == []
+
+
+def test_stream_of_an_unknown_run_is_404(test_client):
+    with patch("web.backend.services.job_queue.JobQueue.get_job", return_value=None):
+        assert test_client.get("/api/v1/workflows/nope/events").status_code == 404
@@ -0,0 +1,167 @@
+"""
+Unit tests for application emails: drafting, sending on approval and follow-ups.
+"""
+
+import base64
+import json
+from datetime import date, datetime
+from email import message_from_bytes, policy
+from types import SimpleNamespace
+
+import pytest
+
+from runtime.crewai import cli
+from runtime.crewai.application_email import (
+    APPLICATION_FILE,
+    EMAIL_DRAFT_FILE,
+    GMAIL_SEND_URL,
+    SENT,
+    EmailError,
+    draft_email,
+    follow_ups,
+    gmail_sender,
+    load_application,
+    load_draft,
+    mark_followed_up,
+    save_draft,
+    send_application,
+    smtp_sender,
+)
+from runtime.crewai.artifacts import MANIFEST_FILE, RunInputs, write_run_artifacts
+from runtime.crewai.hydra_workflow import RunStatus
+
+pytestmark = pytest.mark.usefixtures("hydra_home")
+
+RESUME = """Jane Doe
+jane@example.com | +1 555 0100 | linkedin.com/in/jane
+
+## Experience
+### Platform Engineer, Acme (2019 - 2024)
+- Ran Kubernetes
+"""
+COVER_LETTER = "Dear Globex,\n\nI run platforms.\n"
+
+
+def _run(tmp_path, status=RunStatus.COMPLETED, run_id="run-1"):
+    result = SimpleNamespace(
+        status=status,
+        final_documents={"resume": RESUME, "cover_letter": COVER_LETTER},
+    )
+    inputs = RunInputs(company="Globex", role="SRE")
+    return write_run_artifacts(tmp_path / "out", result, run_id=run_id, inputs=inputs)
+
+
+def test_the_draft_attaches_the_resume_and_cover_letter(tmp_path):
+    run_dir = _run(tmp_path)
+
+    message, application = draft_email(run_dir, "jobs@globex.com")
+
+    assert message["From"] == "Jane Doe <jane@example.com>"
+    assert message["Subject"] == "Application: SRE – Jane Doe"
+    assert "for the SRE position at Globex" in message.get_body(("plain",)).get_content()
+    files = [part.get_filename() for part in message.iter_attachments()]
+    assert files == ["Jane Doe - resume.md", "Jane Doe - cover_letter.md"]
+    assert application.attachments == ("resume.md", "cover_letter.md")
+
+    (run_dir / "resume.pdf").write_bytes(b"%PDF-1.4")
+    message, _ = draft_email(run_dir, "jobs@globex.com", sender="me@example.org")
+    assert message["From"] == "Jane Doe <me@example.org>"
+    assert next(message.iter_attachments()).get_content_type() == "application/pdf"
+
+
+def test_a_draft_needs_an_address(tmp_path):
+    run_dir = _run(tmp_path)
+
+    with pytest.raises(EmailError, match="Not an email address"):
+        draft_email(run_dir, "globex")
+    (run_dir / "resume.md").write_text("Jane Doe\n")
+    with pytest.raises(EmailError, match="pass --from"):
+        draft_email(run_dir, "jobs@globex.com")
+
+
+def test_sending_records_a_follow_up(tmp_path):
+    run_dir = _run(tmp_path)
+    save_draft(run_dir, *draft_email(run_dir, "jobs@globex.com"))
+    sent = []
+
+    application = send_application(
+        run_dir, sent.append, "smtp", follow_up_days=5, now=datetime(2026, 10, 1, 9, 0)
+    )
+
+    assert [message["To"] for message in sent] == ["jobs@globex.com"]
+    assert (application.status, application.follow_up_on) == (SENT, "2026-10-06")
+    assert load_application(run_dir) == application
+    with pytest.raises(EmailError, match="Already sent"):
+        send_application(run_dir, sent.append, "smtp")
+
+    assert follow_ups(tmp_path / "out", today=date(2026, 10, 5)) == []
+    assert follow_ups(tmp_path / "out", today=date(2026, 10, 6)) == [(run_dir, application)]
+    mark_followed_up(run_dir)
+    assert follow_ups(tmp_path / "out", today=date(2026, 10, 9), include_upcoming=True) == []
+
+
+def test_gmail_sends_the_raw_message(tmp_path):
+    run_dir = _run(tmp_path)
+    save_draft(run_dir, *draft_email(run_dir, "jobs@globex.com"))
+    calls = []
+
+    send = gmail_sender({"GMAIL_ACCESS_TOKEN": "tok"}, post=lambda *call: calls.append(call))
+    send(load_draft(run_dir))
+
+    [(url, body, headers)] = calls
+    assert url == GMAIL_SEND_URL and headers["Authorization"] == "Bearer tok"
+    raw = base64.urlsafe_b64decode(json.loads(body)["raw"])
+    assert message_from_bytes(raw, policy=policy.default)["To"] == "jobs@globex.com"
+
+    with pytest.raises(EmailError, match="GMAIL_ACCESS_TOKEN not set"):
+        gmail_sender({})
+    with pytest.raises(EmailError, match="HYDRA_SMTP_HOST not set"):
+        smtp_sender({})
+
+
+def test_hydra_email_drafts_and_sends_on_approval(tmp_path, monkeypatch, capsys):
+    run_dir = _run(tmp_path)
+    sent = []
+    monkeypatch.setattr(cli, "transport_sender", lambda via: sent.append)
+    monkeypatch.setattr("builtins.input", lambda prompt: "n")
+
+    assert cli.main(["email", str(run_dir), "--to", "jobs@globex.com"]) == 0
+    assert "✉️  Application email to jobs@globex.com drafted" in capsys.readouterr().out
+    manifest = json.loads((run_dir / MANIFEST_FILE).read_text())
+    assert {EMAIL_DRAFT_FILE, APPLICATION_FILE} <= set(manifest["artifacts"])
+    assert manifest["application"]["status"] == "drafted"
+    assert "jobs@globex.com" not in json.dumps(manifest)
+
+    assert cli.main(["email", "run-1", "--out", str(tmp_path / "out"), "--send"]) == 1
+    assert sent == []
+
+    assert cli.main(["email", str(run_dir), "--send", "--yes", "--follow-up-days", "3"]) == 0
+    assert len(sent) == 1
+    manifest = json.loads((run_dir / MANIFEST_FILE).read_text())
+    assert manifest["application"]["status"] == SENT
+
+    assert cli.main(["followups", "--out", str(tmp_path / "out"), "--all"]) == 0
+    assert "Globex — SRE" in capsys.readouterr().out
+    assert cli.main(["followups", "--out", str(tmp_path / "out"), "--done", "run-1"]) == 0
+    assert load_application(run_dir).followed_up_at
+
+
+def test_a_run_the_audit_rejected_is_not_sent_without_force(tmp_path, monkeypatch):
+    run_dir = _run(tmp_path, status=RunStatus.COMPLETED_WITH_AUDIT_CONCERNS)
+    sent = []
+    monkeypatch.setattr(cli, "transport_sender", lambda via: sent.append)
+    cli.main(["email", str(run_dir), "--to", "jobs@globex.com"])
+
+    with pytest.raises(SystemExit):
+        cli.main(["email", str(run_dir), "--send", "--yes"])
+    assert cli.main(["email", str(run_dir), "--send", "--yes", "--force"]) == 0
+    assert len(sent) == 1
+
+
+def test_sending_needs_a_draft(tmp_path):
+    run_dir = _run(tmp_path)
+
+    with pytest.raises(SystemExit):
+        cli.main(["email", str(run_dir), "--send"])
+    with pytest.raises(SystemExit):
+        cli.main(["email", "missing", "--out", str(tmp_path / "out")])
@@ -0,0 +1,96 @@
+"""
+Unit tests for earlier applications to the same company: lookup, outcomes and the warning.
+"""
+
+import json
+from argparse import Namespace
+from datetime import date
+from unittest.mock import patch
+
+from runtime.crewai import cli
+from runtime.crewai.application_email import APPLICATION_FILE, SENT, Application
+from runtime.crewai.application_history import (
+    RECENT,
+    REJECTED,
+    REJECTED_ROLE,
+    normalize_company,
+    prior_applications,
+    record_outcome,
+    same_role,
+)
+from runtime.crewai.artifacts import MANIFEST_FILE, RESUME_FILE
+from runtime.crewai.interview import TRANSCRIPT_FILE
+
+TODAY = date(2026, 10, 17)
+
+
+def _run(out, run_id, company, role):
+    run_dir = out / run_id
+    run_dir.mkdir(parents=True)
+    inputs = {"company": company, "role": role}
+    manifest = {"run_id": run_id, "status": "completed", "inputs": inputs}
+    (run_dir / MANIFEST_FILE).write_text(json.dumps(manifest))
+    return run_dir
+
+
+def _history(out):
+    recent = _run(out, "acme-sre-20261010-090000-a1", "Acme, Inc.", "Site Reliability Engineer")
+    (recent / RESUME_FILE).write_text("# Jane")
+    (recent / TRANSCRIPT_FILE).write_text(json.dumps({"entries": []}))
+    application = Application(run_id=recent.name, to="jobs@acme.test", subject="SRE", status=SENT)
+    (recent / APPLICATION_FILE).write_text(json.dumps(application.to_dict()))
+    rejected = _run(out, "acme-pe-20250301-090000-bbbb2222", "ACME", "Senior Platform Engineer")
+    record_outcome(rejected, REJECTED)
+    _run(out, "acme-old-20250101-090000-cccc3333", "Acme", "Data Analyst")
+    _run(out, "globex-20261015-090000-dddd4444", "Globex", "Platform Engineer")
+    return recent, rejected
+
+
+def test_names_and_roles_match_loosely():
+    assert normalize_company("Acme, Inc.") == normalize_company("ACME") == "acme"
+    assert normalize_company("Co") == "co"
+    assert same_role("Senior Platform Engineer", "Platform Engineer, Senior")
+    assert not same_role("Platform Engineer", "Data Analyst")
+    assert not same_role(None, "Platform Engineer")
+
+
+def test_recent_runs_and_rejected_roles_are_found(tmp_path):
+    recent, rejected = _history(tmp_path)
+
+    priors = prior_applications(tmp_path, "Acme", "Platform Engineer, Senior", today=TODAY)
+
+    assert [(p.run_id, p.reasons) for p in priors] == [
+        (recent.name, [RECENT]),
+        (rejected.name, [REJECTED_ROLE]),
+    ]
+    assert priors[0].reusable == [RESUME_FILE, TRANSCRIPT_FILE]
+    assert priors[0].describe() == "Site Reliability Engineer, 2026-10-10: sent"
+    assert priors[1].describe() == "Senior Platform Engineer, 2025-03-01: rejected"
+    assert json.loads((rejected / MANIFEST_FILE).read_text())["outcome"]["status"] == REJECTED
+
+    only_rejections = prior_applications(tmp_path, "Acme", "Data Analyst", days=0, today=TODAY)
+    assert only_rejections == []
+    assert prior_applications(tmp_path, None, "Platform Engineer") == []
+
+
+def test_the_cli_warns_and_asks_before_applying_again(tmp_path, capsys):
+    recent, _ = _history(tmp_path)
+    args = Namespace(dedup_days=30, interactive=False)
+
+    with patch("runtime.crewai.application_history.date") as fake_date:
+        fake_date.today.return_value = TODAY
+        fake_date.min = date.min
+        priors = cli._warn_prior_applications(tmp_path, "Acme", "Platform Engineer", args)
+        out = capsys.readouterr().out
+        assert len(priors) == 2 and "You have applied to Acme before" in out
+        assert f"{recent.name} — Site Reliability Engineer, 2026-10-10: sent (within 30" in out
+        assert f"--reuse-interview {recent.name}" in out
+
+        args.interactive = True
+        with patch("builtins.input", return_value="n"):
+            assert cli._warn_prior_applications(tmp_path, "Acme", "SRE", args) is None
+        with patch("builtins.input", return_value="y"):
+            assert cli._warn_prior_applications(tmp_path, "Acme", "SRE", args)
+
+    assert cli.main(["outcome", recent.name, "offer", "--out", str(tmp_path)]) == 0
+    assert json.loads((recent / MANIFEST_FILE).read_text())["outcome"]["status"] == "offer"
@@ -1,10 +1,21 @@
 """Unit tests for run artifacts and the run manifest."""
 
 import json
+from datetime import datetime
 from types import SimpleNamespace
 
 from runtime.crewai import artifacts
-from runtime.crewai.artifacts import RunInputs, build_manifest, generate_run_id, write_run_artifacts
+from runtime.crewai.artifacts import (
+    ARTIFACT_INDEX_FILE,
+    RunInputs,
+    build_manifest,
+    generate_run_id,
+    job_labels,
+    record_artifacts,
+    slugify,
+    verify_artifact_index,
+    write_run_artifacts,
+)
 from runtime.crewai.hydra_workflow import RunStatus
 
 
@@ -36,6 +47,23 @@ def test_generate_run_id_is_sortable_and_unique():
     assert len(stamp) == len("20260101-120000")
 
 
+def test_run_id_leads_with_company_and_role_slugs():
+    now = datetime(2026, 1, 17, 10, 15, 0)
+    run_id = generate_run_id(now, company="Société Générale", role="Sr. Platform Engineer")
+
+    assert run_id.startswith("societe-generale-sr-platform-engineer-20260117-101500-")
+    assert generate_run_id(now, company="  ", role=None).startswith("20260117-101500-")
+    assert slugify("x" * 30 + " " + "y" * 30) == "x" * 30
+    assert slugify("!!!") == ""
+
+
+def test_job_labels_reads_the_job_description_header():
+    jd = "# Staff Data Engineer\n\nCompany: Acme Corp\n\nWe build rockets."
+    assert job_labels(jd) == ("Acme Corp", "Staff Data Engineer")
+    assert job_labels("**Position:** SRE\n# Heading") == (None, "SRE")
+    assert job_labels("We are hiring.\n" * 20 + "Company: Too Late") == (None, None)
+
+
 def test_build_manifest_summarizes_outcome_without_pii():
     inputs = RunInputs(job_description_chars=10, resume_chars=20, sources_chars=30, jd_path="jd.md")
     result = _result(final_documents={"resume": "SECRET_RESUME_BODY", "cover_letter": "SECRET_CL"})
@@ -71,9 +99,38 @@ def test_write_run_artifacts_creates_run_scoped_dir(tmp_path):
         artifacts.COVER_LETTER_FILE,
         artifacts.AUDIT_REPORT_FILE,
         artifacts.EXECUTION_LOG_FILE,
+        artifacts.REPORT_HTML_FILE,
     ]
 
 
+def test_artifact_index_hashes_every_file_in_the_run(tmp_path):
+    run_dir = write_run_artifacts(tmp_path, _result(), run_id="rid-1", include_intermediate=True)
+
+    index = json.loads((run_dir / ARTIFACT_INDEX_FILE).read_text())
+    kinds = {entry["path"]: entry["kind"] for entry in index["files"]}
+    assert index["run_id"] == "rid-1"
+    assert kinds[artifacts.RESUME_FILE] == "document"
+    assert kinds[f"{artifacts.INTERMEDIATE_DIR}/gap_analysis.yaml"] == "stage_output"
+    assert kinds[artifacts.EXECUTION_LOG_FILE] == "log"
+    assert kinds[artifacts.MANIFEST_FILE] == "manifest"
+    assert ARTIFACT_INDEX_FILE not in kinds
+    resume = next(e for e in index["files"] if e["path"] == artifacts.RESUME_FILE)
+    assert resume["bytes"] == 1 and len(resume["sha256"]) == 64
+    assert verify_artifact_index(run_dir) == {"missing": [], "changed": [], "added": []}
+
+    (run_dir / artifacts.RESUME_FILE).write_text("edited")
+    (run_dir / artifacts.COVER_LETTER_FILE).unlink()
+    (run_dir / "notes.md").write_text("n")
+    assert verify_artifact_index(run_dir) == {
+        "missing": [artifacts.COVER_LETTER_FILE],
+        "changed": [artifacts.RESUME_FILE],
+        "added": ["notes.md"],
+    }
+
+    record_artifacts(run_dir, ["notes.md"])
+    assert verify_artifact_index(run_dir)["added"] == []
+
+
 def test_write_run_artifacts_two_runs_do_not_clobber(tmp_path):
     write_run_artifacts(tmp_path, _result(final_documents={"resume": "first"}), run_id="r1")
     write_run_artifacts(tmp_path, _result(final_documents={"resume": "second"}), run_id="r2")
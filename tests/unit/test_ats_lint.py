@@ -0,0 +1,177 @@
+"""
+Unit tests for the ATS lint: hidden text, keyword stuffing, layout and section headers.
+"""
+
+import json
+from types import SimpleNamespace
+
+from runtime.crewai import cli
+from runtime.crewai.artifacts import build_manifest
+from runtime.crewai.ats_lint import (
+    FAILED,
+    HIDDEN_TEXT,
+    KEYWORD_STUFFING,
+    LAYOUT,
+    PASSED,
+    SECTION_HEADER,
+    lint_document,
+    lint_documents,
+)
+from runtime.crewai.hydra_workflow import RunStatus
+from runtime.crewai.pipeline_config import PipelineConfig
+from runtime.crewai.run_report import _audit_findings
+from runtime.crewai.skill_taxonomy import default_taxonomy
+from tests.conftest import patched_workflow
+
+CLEAN = """# Jane Doe
+jane@example.com | +1 415 555 0100 | linkedin.com/in/janedoe
+
+## Professional Summary
+Platform engineer who runs Kubernetes on AWS.
+
+## Experience
+**Senior Engineer, Acme** | Jan 2020 – Present
+- Led the move of 40 Python services to Kubernetes.
+
+## Education
+BSc Computer Science, 2016
+
+## Skills
+Python, Go, Kubernetes, AWS
+
+## Awards
+- Engineer of the year, 2022
+"""
+GAMED = """# Jane Doe
+jane@example.com
+
+## Where I've Made an Impact
+- Built Python tooling in Python, Python 3 and more Python
+<span style="color:#fff">Kubernetes Terraform AWS</span>
+<!-- keywords: Kafka Spark -->
+| Tool | Years |
+|------|-------|
+![headshot](me.png)
+"""
+
+
+def test_a_plain_resume_passes():
+    report = lint_documents({"resume": CLEAN, "cover_letter": "Dear team,\n\nI run AWS."})
+    assert report.status == PASSED and report.to_dict() == {"status": PASSED, "violations": []}
+
+
+def test_each_rule_is_reported_with_its_line():
+    violations = lint_document(GAMED)
+
+    found = [(violation.rule, violation.line) for violation in violations]
+    assert found == [
+        (SECTION_HEADER, 4),
+        (KEYWORD_STUFFING, 5),
+        (HIDDEN_TEXT, 6),
+        (HIDDEN_TEXT, 7),
+        (LAYOUT, 8),
+        (LAYOUT, 10),
+    ]
+    assert violations[1].detail == "'Python' 4 times"
+    assert violations[2].detail == "'color:#fff' hides text from readers"
+    assert violations[4].detail.startswith("table:")
+    assert violations[5].detail.startswith("image:")
+
+
+def test_stuffing_counts_a_skill_under_all_its_names_across_the_document():
+    bullets = "\n".join(f"- Shipped service {n} in Golang" for n in range(5))
+    more = "\n".join(f"- Rewrote job {n} in Go" for n in range(4))
+    letter = f"Dear team,\n\n{bullets}\n{more}\n"
+
+    violations = lint_document(letter, "cover_letter")
+
+    assert [(v.rule, v.line, v.detail) for v in violations] == [
+        (KEYWORD_STUFFING, 3, "'Go' 9 times in the document (at most 8)"),
+    ]
+    assert default_taxonomy().counts("Go, Golang and Google") == {"Go": 2}
+
+
+def test_hidden_text_tricks():
+    lines = [
+        '<font style="font-size: 0px">Kafka</font>',
+        '<div style="display:none">Spark</div>',
+        "Zero\u200bwidth",
+        "\\textcolor{white}{Airflow}",
+        "Font size 10px is fine, and so is white-collar",
+        '<td style="background-color: #fff; color: #333">Kubernetes</td>',
+    ]
+    violations = lint_document("\n".join(lines), "cover_letter")
+    assert [violation.line for violation in violations] == [1, 2, 3, 4]
+    assert {violation.rule for violation in violations} == {HIDDEN_TEXT}
+
+
+def _workflow(optimized_resume):
+    workflow = patched_workflow(auto_approve=True, pipeline_config=PipelineConfig())
+    workflow.gap_analyzer.execute.return_value = {"gaps": [], "confidence": 0.9}
+    workflow.interrogator_prepper.execute.return_value = {"questions": [], "confidence": 0.9}
+    workflow.differentiator.execute.return_value = {"differentiators": [], "confidence": 0.9}
+    workflow.tailoring_agent.execute.return_value = {
+        "tailored_resume": CLEAN,
+        "tailored_cover_letter": "",
+        "confidence": 0.9,
+    }
+    workflow.ats_optimizer.execute.return_value = {
+        "optimized_resume": optimized_resume,
+        "confidence": 0.9,
+    }
+    workflow.auditor_suite.execute.return_value = {"approval": {"approved": True}}
+    workflow.executive_synthesizer.execute.return_value = {"confidence": 0.9}
+    return workflow
+
+
+def test_a_violation_fails_an_approved_audit():
+    context = {
+        "job_description": "Platform Engineer",
+        "resume": CLEAN + GAMED,
+        "source_documents": "",
+    }
+
+    result = _workflow(GAMED).execute(context)
+
+    assert result.status is RunStatus.COMPLETED_WITH_AUDIT_CONCERNS
+    assert result.audit_failed is True
+    assert result.audit_report["final_status"] == "REJECTED"
+    assert result.audit_error == (
+        "6 ATS lint violation(s): hidden_text, keyword_stuffing, layout, section_header"
+    )
+    assert result.audit_report["ats_lint"]["status"] == FAILED
+    assert result.final_documents["resume"] == GAMED  # kept for review
+
+    clean = _workflow(CLEAN).execute(context)
+    assert clean.status is RunStatus.COMPLETED
+    assert clean.audit_report["ats_lint"] == {"status": PASSED, "violations": []}
+
+
+def test_run_json_counts_by_rule_and_the_cli_lists_them(tmp_path, capsys):
+    audit_report = {"final_status": "REJECTED", "ats_lint": lint_documents({"resume": GAMED})}
+    audit_report["ats_lint"] = audit_report["ats_lint"].to_dict()
+    result = SimpleNamespace(success=True, audit_report=audit_report)
+
+    manifest = build_manifest("run-1", result)
+
+    assert manifest["audit"]["ats_lint"] == {
+        "status": FAILED,
+        "violations": {"section_header": 1, "keyword_stuffing": 1, "hidden_text": 2, "layout": 2},
+    }
+    assert "Jane" not in json.dumps(manifest["audit"])
+    assert "ATS lint, hidden_text — an HTML comment is invisible but parsed (resume, line 7)" in (
+        _audit_findings(audit_report)
+    )
+
+    cli._report_ats_lint(audit_report)
+    out = capsys.readouterr().out
+    assert "🚫 6 ATS lint violation(s) — failing the audit:" in out
+    assert "[section_header] 'Where I've Made an Impact' is not a section" in out
+
+    gamed, clean = tmp_path / "gamed.md", tmp_path / "clean.md"
+    gamed.write_text(GAMED)
+    clean.write_text(CLEAN)
+    assert cli.main(["ats-check", str(clean)]) == 0
+    capsys.readouterr()
+    assert cli.main(["ats-check", str(gamed), "--json"]) == 1
+    assert json.loads(capsys.readouterr().out)["lint"]["status"] == FAILED
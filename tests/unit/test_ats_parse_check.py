@@ -0,0 +1,105 @@
+"""
+Unit tests for the simulated ATS parse of the final résumé.
+"""
+
+import json
+from types import SimpleNamespace
+
+from runtime.crewai.artifacts import write_run_artifacts
+from runtime.crewai.ats_parse_check import ATS_PARSE_FILE, parse_resume
+
+CLEAN = """# Jane Doe
+jane@example.com | +1 415 555 0100 | linkedin.com/in/janedoe
+
+## Experience
+**Senior Engineer, Acme** | Jan 2020 – Present
+- Led migration of 40 services.
+**Engineer, Beta** (2017 - 2019)
+
+## Education
+BSc Computer Science, 2016
+
+## Skills
+Languages: Python, Go | Rust
+"""
+
+HOSTILE = """# Jane Doe
+[Email me](mailto:jane@example.com) · [LinkedIn](https://linkedin.com/in/janedoe)
+
+## Where I've Made an Impact
+- Led migration of 40 services.
+
+## Toolbox
+| Tool   | Years |
+|--------|-------|
+| Python | 8     |
+📍 Berlin
+Summer 2019
+"""
+
+
+def test_conventional_resume_extracts_every_field():
+    report = parse_resume(CLEAN)
+
+    assert report.contact == {
+        "name": "Jane Doe",
+        "email": "jane@example.com",
+        "phone": "+1 415 555 0100",
+        "linkedin": "linkedin.com/in/janedoe",
+    }
+    assert report.sections == ["experience", "education", "skills"]
+    assert [e["role"] for e in report.experience_entries] == [
+        "Senior Engineer, Acme",
+        "Engineer, Beta",
+    ]
+    assert report.skills == ["Python", "Go", "Rust"]
+    assert report.missing_fields == [] and report.hazards == []
+    assert report.score == 100
+
+
+def test_layout_hazards_and_creative_headings_are_reported():
+    report = parse_resume(HOSTILE)
+
+    assert report.contact["email"] is None and report.contact["linkedin"] is None
+    assert report.unrecognised_headings == ["Where I've Made an Impact", "Toolbox"]
+    assert set(report.missing_fields) >= {
+        "email",
+        "linkedin",
+        "section:experience",
+        "section:skills",
+    }
+    kinds = [(h.kind, h.line) for h in report.hazards]
+    assert ("hidden_contact", 2) in kinds
+    assert kinds.count(("table", 8)) == 1 and not any(k == ("table", 9) for k in kinds)
+    assert ("icon", 11) in kinds and ("date_format", 12) in kinds
+    assert report.score < 30
+
+
+def test_manifest_gets_field_names_not_values(tmp_path):
+    result = SimpleNamespace(
+        status=None,
+        final_documents={"resume": CLEAN},
+        audit_report=None,
+        execution_log=[],
+        ats_parse=parse_resume(HOSTILE).to_dict(),
+    )
+
+    run_dir = write_run_artifacts(tmp_path, result, run_id="run-1")
+
+    full = json.loads((run_dir / ATS_PARSE_FILE).read_text())
+    assert full["contact"]["name"] == "Jane Doe"
+    manifest = json.loads((run_dir / "run.json").read_text())
+    assert "email" in manifest["ats_parse"]["missing_fields"]
+    assert "Jane" not in json.dumps(manifest)
+
+
+def test_cli_ats_check_exit_code(tmp_path, capsys):
+    from runtime.crewai import cli
+
+    clean, hostile = tmp_path / "clean.md", tmp_path / "hostile.md"
+    clean.write_text(CLEAN)
+    hostile.write_text(HOSTILE)
+
+    assert cli.main(["ats-check", str(clean)]) == 0
+    assert cli.main(["ats-check", str(hostile)]) == 1
+    assert "heading 'Toolbox' is not a section" in capsys.readouterr().out
@@ -0,0 +1,128 @@
+"""
+Unit tests for the audit log: greenlight decisions, provider calls and document access.
+"""
+
+import json
+from types import SimpleNamespace
+from unittest.mock import Mock, patch
+
+from runtime.crewai import cli
+from runtime.crewai.artifacts import MANIFEST_FILE, write_run_artifacts
+from runtime.crewai.audit_log import (
+    ARTIFACTS_ACCESSED,
+    AUDIT_LOG_FILE,
+    GREENLIGHT,
+    PROVIDER_CALL,
+    append_events,
+    audit_event,
+    load_events,
+    provider_calls,
+    record_access,
+)
+from runtime.crewai.hydra_workflow import RunStatus
+from runtime.crewai.pipeline_config import PipelineConfig
+from tests.conftest import patched_workflow
+
+CONTEXT = {"job_description": "Senior SRE\n\nKubernetes.", "resume": "# Jane Doe\nKubernetes"}
+
+
+def _workflow(**kwargs):
+    workflow = patched_workflow(pipeline_config=PipelineConfig(), **kwargs)
+    workflow._begin_run()
+    return workflow
+
+
+def _call(path="direct", **extra):
+    call = {"agent": "TailoringAgent", "model": "gpt-4o", "provider": "openai", "path": path}
+    return {**call, **extra}
+
+
+def test_provider_calls_name_the_data_sent_but_not_the_cached_ones():
+    sections = {"system_prompt": 900, "resume": 1200, "job_description": 400}
+    calls = [_call(), _call(path="cache"), _call(error="Timeout: slow")]
+
+    events = provider_calls("tailoring", calls, sections, redacted=True)
+
+    assert [event["action"] for event in events] == [PROVIDER_CALL, PROVIDER_CALL]
+    assert events[0]["provider"] == "openai" and events[0]["stage"] == "tailoring"
+    assert events[0]["data"] == ["job_description", "resume"]
+    assert events[0]["redacted"] is True
+    assert [event["failed"] for event in events] == [False, True]
+
+
+def test_the_log_is_appended_to(tmp_path):
+    path = tmp_path / "run" / AUDIT_LOG_FILE
+    assert load_events(path) == []
+    assert append_events(path, []) == 0 and not path.exists()
+
+    append_events(path, [audit_event(GREENLIGHT, actor="jane", approved=True)])
+    record_access(path.parent, "export", actor="jane", bundle="run.tar.gz")
+
+    first, second = load_events(path)
+    assert first["actor"] == "jane" and first["approved"] is True
+    assert second["action"] == ARTIFACTS_ACCESSED and second["how"] == "export"
+    assert all(json.loads(line) for line in path.read_text().splitlines())
+
+    # Access to a run whose log cannot be written is not an error.
+    blocker = tmp_path / "not-a-directory"
+    blocker.write_text("")
+    record_access(blocker / "run", "decrypt")
+
+
+def test_the_greenlight_decision_and_its_actor_are_logged():
+    workflow = _workflow(interactive=True)
+    workflow.actor = "jane"
+    workflow.user_interaction = Mock()
+    workflow.user_interaction.greenlight_gap_analysis.return_value = True
+    with patch.object(workflow, "_execute_with_fallback", return_value={"gaps": []}):
+        workflow._execute_gap_analysis(dict(CONTEXT))
+
+    (event,) = workflow.audit_events
+    assert event["action"] == GREENLIGHT
+    assert event["actor"] == "jane" and event["approved"] is True
+
+    # --yes lets the run through: that is logged as such.
+    workflow = _workflow(auto_approve=True)
+    with patch.object(workflow, "_execute_with_fallback", return_value={"gaps": []}):
+        workflow._execute_gap_analysis(dict(CONTEXT))
+    assert workflow.audit_events[0]["auto_approved"] is True
+
+
+def test_a_stage_logs_the_calls_it_made():
+    workflow = _workflow(auto_approve=True)
+    workflow.context_usage["tailoring"] = {"sections": {"resume": 10, "task_overhead": 5}}
+    agent = SimpleNamespace(prompt_transcript=[_call(), _call(path="cache")])
+
+    workflow._record_prompts(agent, "tailoring:gpt-4o")
+
+    (event,) = workflow.audit_events
+    assert event["stage"] == "tailoring:gpt-4o" and event["data"] == ["resume"]
+    assert event["redacted"] is False
+
+
+def test_a_run_writes_its_events_and_export_is_logged(tmp_path, monkeypatch):
+    result = SimpleNamespace(
+        success=True,
+        status=RunStatus.COMPLETED,
+        final_documents={"resume": "R", "cover_letter": "C"},
+        audit_report={"final_status": "APPROVED"},
+        executive_brief=None,
+        execution_log=[],
+        intermediate_results={},
+        agent_models={},
+        audit_failed=False,
+        audit_error=None,
+        error_message=None,
+        audit_events=[audit_event(GREENLIGHT, actor="jane", approved=True)],
+    )
+    run_dir = write_run_artifacts(tmp_path, result, run_id="acme-sre-20261017-090000-a1b2c3d4")
+    assert AUDIT_LOG_FILE in json.loads((run_dir / MANIFEST_FILE).read_text())["artifacts"]
+
+    monkeypatch.setattr(cli, "local_actor", lambda: "jane")
+    bundle = tmp_path / "run.tar.gz"
+    assert cli.main(["export", run_dir.name, "--out", str(tmp_path), "--bundle", str(bundle)]) == 0
+
+    events = load_events(run_dir / AUDIT_LOG_FILE)
+    assert [event["action"] for event in events] == [GREENLIGHT, ARTIFACTS_ACCESSED]
+    assert events[1]["how"] == "export" and events[1]["actor"] == "jane"
+    assert events[1]["redacted"] is False
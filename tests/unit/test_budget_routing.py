@@ -0,0 +1,136 @@
+"""
+Unit tests for budget-aware routing: cheap models for unimportant stages as money runs out.
+"""
+
+from unittest.mock import Mock, patch
+
+import pytest
+
+from runtime.crewai.budget_routing import (
+    DEFAULT_CHEAP_MODEL,
+    DOWNGRADE,
+    KEEP,
+    BudgetPolicy,
+    spent_usd,
+)
+from runtime.crewai.hydra_workflow import RunStatus
+from runtime.crewai.pipeline_config import PipelineConfig, PipelineConfigError
+from runtime.crewai.prompt_cache import CallUsage
+from tests.conftest import patched_workflow
+
+SONNET = "claude-sonnet-4-20250514"
+CONTEXT = {"job_description": "JD", "resume": "Resume", "source_documents": "Sources"}
+
+
+def test_stage_weights_set_when_each_stage_downgrades():
+    policy = BudgetPolicy.from_dict({"usd": 2, "weights": {"gap_analysis": 0.25}})
+
+    assert policy.threshold("research_agent") == 0.8
+    assert policy.threshold("gap_analysis") == 0.85
+    assert policy.threshold("tailoring") == 0.96
+    assert policy.threshold("auditor_suite") == 1.0
+    assert policy.to_dict()["weights"]["audit"] == 1.0
+    assert not BudgetPolicy().enabled and PipelineConfig().budget.usd is None
+
+
+def test_decisions_follow_the_spend():
+    policy = BudgetPolicy(usd=1.0)
+
+    assert policy.decide("research_agent", SONNET, 0.5).action == KEEP
+    downgraded = policy.decide("research_agent", SONNET, 0.8)
+    assert (downgraded.action, downgraded.to_model) == (DOWNGRADE, DEFAULT_CHEAP_MODEL)
+    assert "80% of $1 spent" in downgraded.reason
+    audit = policy.decide("auditor_suite", SONNET, 0.99)
+    assert audit.action == KEEP and "never downgraded" in audit.reason
+    cheap_already = policy.decide("research_agent", "gpt-4o-mini", 0.9)
+    assert cheap_already.action == KEEP and "not cheaper" in cheap_already.reason
+
+    per_stage = BudgetPolicy(usd=1.0, cheap_models={"tailoring": "openai:gpt-4o-mini"})
+    assert per_stage.decide("tailoring", SONNET, 0.97).to_model == "openai:gpt-4o-mini"
+
+
+@pytest.mark.parametrize(
+    "section, message",
+    [
+        ({"usd": 0}, "positive amount"),
+        ({"usd": 2, "downgrade_at": 1.5}, "from 0 to 1"),
+        ({"weights": {"polishing": 0.1}}, "unknown stage"),
+        ({"weights": {"audit": "high"}}, "from 0 to 1"),
+        ({"cheap_model": "llama"}, "Invalid model spec"),
+        ({"limit": 2}, "unknown budget setting"),
+    ],
+)
+def test_bad_budgets_are_config_errors(section, message):
+    with pytest.raises(PipelineConfigError, match=message):
+        PipelineConfig.from_dict({"budget": section})
+
+
+def test_spend_is_estimated_from_token_usage():
+    calls = [
+        CallUsage("Tailoring", f"anthropic/{SONNET}", prompt_tokens=100_000, completion_tokens=0),
+        CallUsage("Audit", "gpt-4o-mini", prompt_tokens=0, completion_tokens=1_000_000),
+        CallUsage("Local", "ollama/unpriced", prompt_tokens=5_000_000),
+    ]
+
+    assert spent_usd(calls) == pytest.approx(0.3 + 0.6)
+
+
+def _workflow(usd):
+    workflow = patched_workflow(
+        auto_approve=True, pipeline_config=PipelineConfig(budget=BudgetPolicy(usd=usd))
+    )
+    for agent in workflow._agents():
+        agent.llm = Mock(model=SONNET, temperature=0.5)
+
+    def expensive_gaps(context):
+        # $0.90 of a $1 budget: 300k prompt tokens at $3/M.
+        workflow.usage_ledger.record(CallUsage("Gap Analyzer", SONNET, prompt_tokens=300_000))
+        return {"gaps": ["Kafka"], "confidence": 0.9}
+
+    workflow.gap_analyzer.execute.side_effect = expensive_gaps
+    workflow.interrogator_prepper.execute.return_value = {"questions": [], "confidence": 0.9}
+    workflow.differentiator.execute.return_value = {"differentiators": [], "confidence": 0.9}
+    workflow.tailoring_agent.execute.return_value = {
+        "tailored_resume": "Resume",
+        "tailored_cover_letter": "Letter",
+        "confidence": 0.9,
+    }
+    workflow.ats_optimizer.execute.return_value = {"optimized_resume": "R", "confidence": 0.9}
+    workflow.auditor_suite.execute.return_value = {
+        "approval": {"approved": True},
+        "confidence": 0.9,
+    }
+    return workflow
+
+
+def test_the_workflow_downgrades_stages_as_the_budget_runs_out():
+    workflow = _workflow(usd=1.0)
+    cheap = Mock(model="together_ai/meta-llama/Llama-4-Maverick-17B-128E-Instruct-FP8")
+
+    with patch("runtime.crewai.hydra_workflow.get_llm_for_spec", return_value=cheap) as get:
+        result = workflow.execute(CONTEXT)
+
+    assert result.status is RunStatus.COMPLETED
+    budget = result.cost_budget
+    assert (budget["usd"], budget["spent_usd"]) == (1.0, pytest.approx(0.9))
+    # 90% spent after gap analysis: interrogation (86%), differentiation (90%) and
+    # ATS (88%) drop; tailoring (96%) and audit (never) keep the strong model.
+    assert budget["downgraded"] == ["interrogation", "differentiation", "ats_optimization"]
+    assert workflow.interrogator_prepper.llm is cheap
+    assert workflow.tailoring_agent.llm.model == SONNET
+    assert workflow.auditor_suite.llm.model == SONNET
+    decisions = {d["stage"]: d for d in budget["decisions"]}
+    assert decisions["gap_analysis"]["action"] == KEEP  # nothing spent yet
+    assert decisions["tailoring"]["reason"] == "90% spent, below 96%"
+    assert result.agent_models["interrogation"] == DEFAULT_CHEAP_MODEL
+    assert get.call_args.args[0] == DEFAULT_CHEAP_MODEL
+    assert any("Budget routing: interrogation downgraded" in line for line in result.execution_log)
+
+
+def test_no_budget_means_no_routing():
+    workflow = _workflow(usd=None)
+
+    result = workflow.execute(CONTEXT)
+
+    assert result.cost_budget is None
+    assert workflow.interrogator_prepper.llm.model == SONNET
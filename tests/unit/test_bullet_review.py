@@ -0,0 +1,121 @@
+"""
+Unit tests for the keyboard-driven bullet review.
+"""
+
+import io
+import json
+
+from runtime.crewai.bullet_review import (
+    ACCEPT,
+    ADDED,
+    EDIT,
+    PRE_REVIEW_RESUME_FILE,
+    PROVENANCE_FILE,
+    REJECT,
+    REMOVED,
+    REWRITTEN,
+    apply_decisions,
+    changed_bullets,
+    consolidate,
+    review_bullets,
+    run_review,
+)
+
+BASELINE = """# Jane Doe
+## Experience
+- Led migration of 40 services to Kubernetes.
+- Managed the on-call rotation.
+- Wrote the incident runbook.
+"""
+TAILORED = """# Jane Doe
+## Experience
+- Led migration of 40 services to Kubernetes, cutting costs 35%.
+- Wrote the incident runbook.
+- Mentored 5 engineers.
+"""
+
+
+def _keys(*keys):
+    pending = list(keys)
+    return lambda: pending.pop(0)
+
+
+def test_changes_are_paired_and_classified():
+    changes = changed_bullets(BASELINE, TAILORED)
+
+    assert [c.kind for c in changes] == [REWRITTEN, REMOVED, ADDED]
+    assert changes[0].old.endswith("Kubernetes.") and "35%" in changes[0].new
+    assert changes[1].old == "- Managed the on-call rotation."
+    assert changes[2].new == "- Mentored 5 engineers."
+
+
+def test_undecided_changes_keep_the_tailored_text():
+    assert apply_decisions(TAILORED, changed_bullets(BASELINE, TAILORED)) == TAILORED
+
+
+def test_reject_restores_the_baseline_and_edit_keeps_the_marker():
+    changes = review_bullets(
+        changed_bullets(BASELINE, TAILORED),
+        key_fn=_keys("r", "r", "e"),
+        input_fn=lambda prompt: "Mentored five engineers.",
+        out=io.StringIO(),
+    )
+
+    assert [c.decision for c in changes] == [REJECT, REJECT, EDIT]
+    assert apply_decisions(TAILORED, changes) == (
+        "# Jane Doe\n## Experience\n"
+        "- Led migration of 40 services to Kubernetes.\n"
+        "- Managed the on-call rotation.\n"
+        "- Wrote the incident runbook.\n"
+        "- Mentored five engineers.\n"
+    )
+
+
+def test_back_unknown_keys_and_accept_rest():
+    changes = review_bullets(
+        changed_bullets(BASELINE, TAILORED),
+        key_fn=_keys("r", "b", "x", "a", "A"),
+        out=io.StringIO(),
+    )
+
+    assert [c.decision for c in changes] == [ACCEPT, ACCEPT, ACCEPT]
+
+
+def test_quit_leaves_the_rest_undecided():
+    changes = review_bullets(
+        changed_bullets(BASELINE, TAILORED), key_fn=_keys("r", "q"), out=io.StringIO()
+    )
+
+    assert [c.decision for c in changes] == [REJECT, None, None]
+
+
+def test_consolidation_drops_duplicates_and_normalises_markers():
+    text = "- Shipped it.\n- Built the API.\n* Shipped it.\n\n\n* Led the team.\n1. Step\n"
+
+    assert consolidate(text) == "- Shipped it.\n- Built the API.\n\n- Led the team.\n1. Step\n"
+
+
+def test_run_review_writes_provenance_and_rechecks_claims(tmp_path):
+    (tmp_path / "resume.md").write_text(TAILORED)
+    (tmp_path / "run.json").write_text(json.dumps({"run_id": "run-1", "artifacts": []}))
+
+    verification = run_review(
+        tmp_path,
+        BASELINE,
+        evidence=["Mentored 5 engineers."],
+        key_fn=_keys("a", "r", "a"),
+        out=io.StringIO(),
+    )
+
+    assert (tmp_path / PRE_REVIEW_RESUME_FILE).read_text() == TAILORED
+    assert "Managed the on-call rotation." in (tmp_path / "resume.md").read_text()
+    provenance = json.loads((tmp_path / PROVENANCE_FILE).read_text())
+    assert provenance["decisions"] == {ACCEPT: 2, REJECT: 1, EDIT: 0}
+    assert provenance["bullets"][1]["final"] == "Managed the on-call rotation."
+    # The accepted "35%" is not in the evidence, so the post-review check flags it.
+    assert verification.blocking
+    assert [c.text for c in verification.unverified] == ["35%"]
+    manifest = json.loads((tmp_path / "run.json").read_text())
+    assert manifest["review"][REJECT] == 1
+    assert PROVENANCE_FILE in manifest["artifacts"]
+    assert "on-call" not in json.dumps(manifest)
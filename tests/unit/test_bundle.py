@@ -0,0 +1,142 @@
+"""
+Unit tests for run bundles (hydra export / hydra import).
+"""
+
+import io
+import json
+import os
+import tarfile
+
+import pytest
+
+from runtime.crewai.artifacts import (
+    ARTIFACT_INDEX_FILE,
+    INTERMEDIATE_DIR,
+    MANIFEST_FILE,
+    RESUME_FILE,
+    write_run_artifacts,
+    write_stage_output,
+)
+from runtime.crewai.bundle import BundleError, export_bundle, import_bundle
+from runtime.crewai.cli import main
+from runtime.crewai.encryption import ENCRYPT_ENV, PASSPHRASE_ENV, is_encrypted
+from runtime.crewai.run_control import LIVE_FILE, resume_arguments
+
+RESUME = "# Jane Doe\njane@example.com | +1 415 555 0100\n\n## Experience\n- Ran Kubernetes\n"
+
+
+def _run(out):
+    class Result:
+        final_documents = {"resume": RESUME, "cover_letter": "Write to jane@example.com"}
+
+    run_dir = write_run_artifacts(out, Result(), run_id="run-1")
+    write_stage_output(run_dir, "tailoring", {"tailored_resume": RESUME})
+    (run_dir / "resume.pdf").write_bytes(b"%PDF-1.7\n\xff\xfe\x00binary")
+    manifest = json.loads((run_dir / MANIFEST_FILE).read_text())
+    manifest.update(status="paused", inputs={"jd_path": "jd.md", "resume_path": "r.md"})
+    (run_dir / MANIFEST_FILE).write_text(json.dumps(manifest))
+    return run_dir
+
+
+def test_a_run_moves_between_machines_intact(tmp_path):
+    run_dir = _run(tmp_path / "here")
+    (run_dir / LIVE_FILE).write_text(json.dumps({"pid": 0}))  # a stale live.json
+
+    summary = export_bundle(run_dir, tmp_path / "run.tar.gz")
+    imported = import_bundle(tmp_path / "run.tar.gz", tmp_path / "there")
+
+    there = tmp_path / "there" / "run-1"
+    assert imported.path == there and imported.files == summary.files
+    assert imported.redacted is None
+    assert (there / RESUME_FILE).read_text() == RESUME
+    assert (there / "resume.pdf").read_bytes() == (run_dir / "resume.pdf").read_bytes()
+    assert (there / INTERMEDIATE_DIR / "tailoring.yaml").is_file()
+    assert not (there / LIVE_FILE).exists()
+    imported_as = json.loads((there / MANIFEST_FILE).read_text())["imported"]
+    assert imported_as["from"] == "run.tar.gz" and imported_as["redacted"] is False
+    assert "--resume" in resume_arguments(there)
+
+    with pytest.raises(BundleError, match="already exists"):
+        import_bundle(tmp_path / "run.tar.gz", tmp_path / "there")
+
+    (run_dir / LIVE_FILE).write_text(json.dumps({"pid": os.getpid()}))
+    with pytest.raises(BundleError, match="still executing"):
+        export_bundle(run_dir, tmp_path / "live.tar.gz")
+
+
+def test_redacted_bundles_drop_contact_details_and_files_that_are_not_text(
+    tmp_path, monkeypatch
+):
+    monkeypatch.setenv(ENCRYPT_ENV, "1")
+    monkeypatch.setenv(PASSPHRASE_ENV, "correct horse battery staple")
+    run_dir = _run(tmp_path / "here")
+    assert is_encrypted((run_dir / RESUME_FILE).read_bytes())
+
+    summary = export_bundle(run_dir, tmp_path / "coach.tar.gz", redact=True)
+    assert summary.redacted == {"emails": 1, "phones": 1, "addresses": 0}
+    assert summary.left_out == ["resume.pdf"]
+    monkeypatch.delenv(PASSPHRASE_ENV)
+    import_bundle(tmp_path / "coach.tar.gz", tmp_path / "coach")
+
+    there = tmp_path / "coach" / "run-1"
+    resume = (there / RESUME_FILE).read_text()
+    assert resume.startswith("# Jane Doe\n[EMAIL_1] | [PHONE_1]")
+    assert "[EMAIL_1]" in (there / "cover_letter.md").read_text()
+    assert "jane@example.com" not in (there / INTERMEDIATE_DIR / "tailoring.yaml").read_text()
+    assert not (there / "resume.pdf").exists()
+    manifest = json.loads((there / MANIFEST_FILE).read_text())
+    assert manifest["bundle"]["left_out"] == ["resume.pdf"] and manifest["imported"]["redacted"]
+    assert is_encrypted((run_dir / RESUME_FILE).read_bytes())  # the run itself is untouched
+
+    # Packed again without --redact-pii, the bundle says so, whatever it once recorded.
+    again = export_bundle(there, tmp_path / "again.tar.gz")
+    assert import_bundle(tmp_path / "again.tar.gz", tmp_path / "later").redacted is None
+    later = json.loads((tmp_path / "later" / "run-1" / MANIFEST_FILE).read_text())
+    assert later["imported"]["redacted"] is False and again.redacted is None
+
+
+def _tamper(bundle, name, data):
+    with tarfile.open(bundle) as archive:
+        members = [(m, archive.extractfile(m).read() if m.isfile() else None) for m in archive]
+    with tarfile.open(bundle, "w:gz") as archive:
+        for member, content in members:
+            if member.name == name:
+                member.size, content = len(data), data
+            archive.addfile(member, io.BytesIO(content) if content is not None else None)
+
+
+def test_damaged_or_unsafe_bundles_are_refused(tmp_path):
+    run_dir = _run(tmp_path / "here")
+    bundle = tmp_path / "run.tar.gz"
+    export_bundle(run_dir, bundle)
+
+    _tamper(bundle, f"run-1/{RESUME_FILE}", b"# Jane Doe, CTO\n")
+    with pytest.raises(BundleError, match=f"do not match {ARTIFACT_INDEX_FILE}: {RESUME_FILE}"):
+        import_bundle(bundle, tmp_path / "there")
+    assert not any((tmp_path / "there").iterdir())
+
+    with tarfile.open(tmp_path / "evil.tar.gz", "w:gz") as archive:
+        archive.add(run_dir / MANIFEST_FILE, arcname="run-1/../../etc/run.json")
+    with pytest.raises(BundleError, match="unsafe path"):
+        import_bundle(tmp_path / "evil.tar.gz", tmp_path / "there")
+
+    (tmp_path / "notes.tar.gz").write_text("not a tarball")
+    with pytest.raises(BundleError, match="not a readable bundle"):
+        import_bundle(tmp_path / "notes.tar.gz", tmp_path / "there")
+
+
+def test_export_and_import_subcommands(tmp_path, capsys):
+    _run(tmp_path / "here")
+    bundle = tmp_path / "run.tar.gz"
+
+    assert main(["export", "run-1", "--out", str(tmp_path / "here"), "--bundle", str(bundle)]) == 0
+    assert f"📦 Run run-1: 8 file(s) → {bundle}" in capsys.readouterr().out
+    assert main(["import", str(bundle), "--out", str(tmp_path / "there")]) == 0
+    assert "→ " + str(tmp_path / "there" / "run-1") in capsys.readouterr().out
+
+    with pytest.raises(SystemExit):
+        main(["import", str(bundle), "--out", str(tmp_path / "there")])
+    assert "already exists" in capsys.readouterr().err
+    with pytest.raises(SystemExit):
+        main(["export", "run-2", "--out", str(tmp_path / "here")])
+    assert "No run found: run-2" in capsys.readouterr().err
@@ -0,0 +1,201 @@
+"""
+Unit tests for cancelling a run mid-stage and resuming from its checkpoint.
+"""
+
+import json
+import os
+import signal
+import threading
+import time
+from types import SimpleNamespace
+from unittest.mock import Mock, patch
+
+import pytest
+
+from runtime.crewai import cli
+from runtime.crewai.agents.gap_analyzer import GapAnalyzerAgent
+from runtime.crewai.artifacts import MANIFEST_FILE
+from runtime.crewai.cancellation import CancelToken, RunCancelled
+from runtime.crewai.hydra_workflow import HydraWorkflow, RunStatus
+from runtime.crewai.state_schema import STATE_VERSION
+
+
+def _cancel_soon(token_or_workflow, reason="stop"):
+    timer = threading.Timer(0.2, token_or_workflow.cancel, args=(reason,))
+    timer.start()
+    return timer
+
+
+def test_a_cancelled_call_is_abandoned_not_awaited():
+    token = CancelToken()
+    release = threading.Event()
+    _cancel_soon(token)
+
+    started = time.monotonic()
+    with pytest.raises(RunCancelled) as excinfo:
+        token.run("tailoring", lambda: release.wait(10))
+    release.set()
+
+    assert time.monotonic() - started < 5
+    assert (excinfo.value.stage, excinfo.value.reason) == ("tailoring", "stop")
+    assert token.cancelled
+    # The first reason sticks; nothing runs once cancelled.
+    token.cancel("again")
+    with pytest.raises(RunCancelled, match="stop during gap_analysis"):
+        token.run("gap_analysis", lambda: "never")
+
+
+def test_cancellation_skips_the_agent_retries():
+    agent = GapAnalyzerAgent(Mock())
+    agent.cancel_token = CancelToken()
+    release = threading.Event()
+    calls = []
+
+    def _slow_model(task):
+        calls.append(task)
+        release.wait(10)
+        return "{}"
+
+    _cancel_soon(agent.cancel_token)
+    with patch.object(agent, "_invoke_llm", side_effect=_slow_model):
+        with pytest.raises(RunCancelled):
+            agent.execute({"job_description": "JD", "resume": "Jane Doe"})
+    release.set()
+
+    assert len(calls) == 1
+
+
+def test_a_stop_at_the_boundary_lets_the_call_in_flight_finish():
+    token = CancelToken()
+    timer = threading.Timer(0.2, token.stop_at_boundary, args=("draining",))
+    timer.start()
+
+    assert token.run("tailoring", lambda: time.sleep(0.5) or "answer") == "answer"
+    assert token.stopping and not token.cancelled
+    with pytest.raises(RunCancelled, match="draining during ats_optimization"):
+        token.check("ats_optimization")
+
+
+def _workflow():
+    with (
+        patch("runtime.crewai.hydra_workflow.GapAnalyzerAgent"),
+        patch("runtime.crewai.hydra_workflow.InterrogatorPrepperAgent"),
+        patch("runtime.crewai.hydra_workflow.DifferentiatorAgent"),
+        patch("runtime.crewai.hydra_workflow.TailoringAgent"),
+        patch("runtime.crewai.hydra_workflow.ATSOptimizerAgent"),
+        patch("runtime.crewai.hydra_workflow.AuditorSuiteAgent"),
+        patch("runtime.crewai.hydra_workflow.ExecutiveSynthesizerAgent"),
+    ):
+        workflow = HydraWorkflow(Mock(), use_per_agent_models=False, auto_approve=True)
+    workflow.gap_analyzer.execute.return_value = {"gaps": ["Kubernetes"]}
+    workflow.interrogator_prepper.execute.return_value = {"questions": []}
+    workflow.differentiator.execute.return_value = {"differentiators": ["AWS"]}
+    workflow.auditor_suite.execute.return_value = {"approved": True}
+    return workflow
+
+
+def test_a_cancelled_run_returns_its_completed_stages():
+    workflow = _workflow()
+
+    def _tailor_then_ctrl_c(context):
+        workflow.cancel("interrupted by Ctrl-C")
+        return {"tailored_resume": "Resume", "tailored_cover_letter": "Letter"}
+
+    workflow.tailoring_agent.execute.side_effect = _tailor_then_ctrl_c
+
+    result = workflow.execute(
+        {"job_description": "JD", "resume": "Jane Doe", "source_documents": "Jane Doe"}
+    )
+
+    assert result.status == RunStatus.INTERRUPTED and not result.success
+    assert "interrupted by Ctrl-C during ats_optimization" in result.error_message
+    assert set(result.intermediate_results) == {
+        "gap_analysis",
+        "interrogation",
+        "differentiation",
+        "tailoring",
+    }
+    workflow.ats_optimizer.execute.assert_not_called()
+
+    # The next run starts with a fresh token.
+    workflow.tailoring_agent.execute.side_effect = None
+    workflow.tailoring_agent.execute.return_value = {"tailored_resume": "Resume"}
+    workflow.ats_optimizer.execute.return_value = {}
+    rerun = workflow.execute(
+        {"job_description": "JD", "resume": "Jane Doe", "source_documents": "Jane Doe"}
+    )
+    assert rerun.status != RunStatus.INTERRUPTED
+
+
+def test_stop_after_stage_keeps_the_stage_in_flight():
+    workflow = _workflow()
+
+    def _slow_differentiation(context):
+        workflow.stop_after_stage("server draining for shutdown")
+        time.sleep(0.3)  # still running when the stop arrives
+        return {"differentiators": ["AWS"]}
+
+    workflow.differentiator.execute.side_effect = _slow_differentiation
+
+    result = workflow.execute(
+        {"job_description": "JD", "resume": "Jane Doe", "source_documents": "Jane Doe"}
+    )
+
+    assert result.status == RunStatus.INTERRUPTED
+    assert "server draining for shutdown during tailoring" in result.error_message
+    assert result.intermediate_results["differentiation"] == {"differentiators": ["AWS"]}
+    workflow.tailoring_agent.execute.assert_not_called()
+
+
+def test_ctrl_c_checkpoints_the_run_and_resume_run_continues_it(tmp_path, monkeypatch, capsys):
+    jd, resume, sources, out = (tmp_path / name for name in ("jd.md", "r.md", "src", "out"))
+    jd.write_text("JD")
+    resume.write_text("Jane Doe")
+    sources.mkdir()
+    (sources / "notes.md").write_text("Jane Doe")
+    contexts = []
+
+    class StubWorkflow:
+        def __init__(self, *args, **kwargs):
+            self.cancelled = None
+
+        def cancel(self, reason):
+            self.cancelled = reason
+
+        def execute(self, context):
+            contexts.append(context)
+            if "previous_results" not in context:
+                os.kill(os.getpid(), signal.SIGINT)  # the user presses Ctrl-C
+                time.sleep(0.1)
+            interrupted = self.cancelled is not None
+            return SimpleNamespace(
+                success=not interrupted,
+                status=RunStatus.INTERRUPTED if interrupted else RunStatus.COMPLETED,
+                final_documents=None if interrupted else {"resume": "R"},
+                audit_report=None if interrupted else {"final_status": "APPROVED"},
+                intermediate_results={"gap_analysis": {"gaps": ["Kubernetes"]}},
+                error_message=f"Interrupted: {self.cancelled} during tailoring",
+                execution_log=[],
+                agent_models={},
+            )
+
+    monkeypatch.setattr(cli, "get_llm_client", lambda *args, **kwargs: "stub-llm")
+    monkeypatch.setattr(cli, "HydraWorkflow", StubWorkflow)
+    monkeypatch.setenv("HYDRA_HOME", str(tmp_path / "home"))
+    argv = ["--jd", str(jd), "--resume", str(resume), "--sources", str(sources)]
+    argv += ["--out", str(out), "--no-cache"]
+
+    assert cli.main(argv) == 130
+    printed = capsys.readouterr().out
+    run_id = next(out.iterdir()).name
+    assert f"--resume-run {run_id}" in printed
+    manifest = json.loads((out / run_id / MANIFEST_FILE).read_text())
+    assert manifest["status"] == "interrupted" and manifest["state_version"] == STATE_VERSION
+    assert signal.getsignal(signal.SIGINT) is signal.default_int_handler
+
+    assert cli.main(argv + ["--resume-run", run_id]) == 0
+    assert contexts[-1]["previous_results"] == {"gap_analysis": {"gaps": ["Kubernetes"]}}
+    assert contexts[-1]["state_version"] == STATE_VERSION
+
+    with pytest.raises(SystemExit):
+        cli.main(argv + ["--resume-run", "no-such-run"])
@@ -0,0 +1,209 @@
+"""
+Unit tests for the chronology check: roles' dates against themselves and the baseline.
+"""
+
+import json
+from datetime import date
+from types import SimpleNamespace
+
+from runtime.crewai import cli
+from runtime.crewai.artifacts import build_manifest
+from runtime.crewai.chronology import (
+    CHANGED_DATES,
+    HIDDEN_GAP,
+    IMPOSSIBLE,
+    OVERLAP,
+    check_chronology,
+    gaps,
+    parse_roles,
+)
+from runtime.crewai.claim_verification import BLOCKED, OVERRIDDEN, PASSED
+from runtime.crewai.hydra_workflow import RunStatus
+from runtime.crewai.pipeline_config import PipelineConfig
+from runtime.crewai.run_report import _audit_findings
+from tests.conftest import patched_workflow
+
+TODAY = date(2026, 10, 1)
+BASELINE = """# Jane Doe
+jane@example.com
+
+## Experience
+### Senior Engineer | Acme
+Jun 2020 – Present
+- Led the move to Kubernetes
+
+### Engineer | Beta
+Mar 2017 - Jan 2020
+- Built the billing API
+
+## Education
+BSc Computer Science, 2012 - 2016
+"""
+# Acme now starts before Beta ended, Beta's year-only dates hide the gap after it, and
+# a contract the baseline does not have runs alongside Beta.
+TAILORED = """# Jane Doe
+jane@example.com
+
+## Experience
+### Senior Engineer | Acme
+Jan 2020 – Present
+- Led the move to Kubernetes
+
+**Engineer, Beta** | 2017 - 2020
+- Built the billing API
+
+### Contractor | Gamma
+2018 – 2019
+
+## Education
+BSc Computer Science, 2012 - 2016
+"""
+
+
+def test_roles_are_read_from_the_experience_sections():
+    roles = parse_roles(TAILORED, TODAY)
+
+    assert [(role.label, role.line, role.dates) for role in roles] == [
+        ("Senior Engineer | Acme", 6, "Jan 2020 – Present"),
+        ("Engineer, Beta", 9, "2017 - 2020"),
+        ("Contractor | Gamma", 13, "2018 – 2019"),
+    ]
+    assert roles[0].present and roles[0].end == 2026 * 12 + 9
+    assert roles[1].year_only and not roles[0].year_only
+    # Education is not employment.
+    assert "BSc" not in str([role.label for role in roles])
+    assert [role.dates for role in parse_roles("## Work History\n01/2019 to 2021-06\n")] == [
+        "01/2019 to 2021-06"
+    ]
+
+
+def test_the_baseline_gap_is_found():
+    assert gaps(parse_roles(BASELINE, TODAY)) == [(2020 * 12 + 1, 2020 * 12 + 4)]
+    report = check_chronology(BASELINE, BASELINE, today=TODAY)
+    assert report.status == PASSED and report.discrepancies == []
+    assert report.to_dict()["baseline_gaps"] == ["Feb 2020 – May 2020"]
+
+
+def test_a_word_that_starts_like_a_month_is_not_one():
+    resume = "## Experience\nHead of Marketing 2018 - 2020\nMayor's Office, September 2021 - now\n"
+
+    roles = parse_roles(resume, TODAY)
+
+    assert (roles[0].dates, roles[0].year_only) == ("2018 - 2020", True)
+    assert roles[1].start == 2021 * 12 + 8
+    report = check_chronology(resume, resume, today=TODAY)
+    assert report.status == PASSED and report.discrepancies == []
+
+
+def test_each_discrepancy_is_reported_with_its_line():
+    report = check_chronology(TAILORED, BASELINE, today=TODAY)
+
+    assert report.status == BLOCKED and report.roles == 3
+    assert [(item.kind, item.line) for item in report.discrepancies] == [
+        (CHANGED_DATES, 6),
+        (HIDDEN_GAP, 6),
+        (HIDDEN_GAP, 9),
+        (OVERLAP, 13),
+        (CHANGED_DATES, 13),
+    ]
+    assert report.discrepancies[2].detail == (
+        "'Engineer, Beta' (2017 - 2020) covers the baseline's gap Feb 2020 – May 2020"
+    )
+    # Year-only ranges overlap only in the months they are sure of.
+    assert report.discrepancies[3].detail == (
+        "'Contractor | Gamma' overlaps 'Engineer, Beta' by 2 months"
+    )
+    # Acme's start and Beta's year-only end share January 2020: a handover.
+    assert not any("'Senior Engineer | Acme'" in i.detail for i in report.discrepancies[3:])
+
+    overridden = check_chronology(TAILORED, BASELINE, override=True, today=TODAY)
+    assert overridden.status == OVERRIDDEN and not overridden.blocking
+
+
+def test_impossible_ranges_and_overlaps_without_a_baseline():
+    resume = (
+        "## Experience\n"
+        "### Lead | Acme\nMar 2021 - Jan 2020\n"
+        "### Engineer | Beta\nJan 2027 - Present\n"
+        "### Analyst | Gamma\nJan 2015 - Dec 2018\n"
+        "### Consultant | Delta\nJun 2018 - Jun 2019\n"
+    )
+
+    report = check_chronology(resume, "No dates here.", today=TODAY)
+
+    assert [(item.kind, item.line) for item in report.discrepancies] == [
+        (IMPOSSIBLE, 3),
+        (IMPOSSIBLE, 5),
+        (OVERLAP, 9),
+    ]
+    assert report.discrepancies[0].detail == "'Lead | Acme' ends before it starts"
+    assert report.to_dict()["baseline_gaps"] == []
+    # This year's year-only end runs to today, not to December.
+    assert check_chronology("## Experience\nX | 2019 - 2026\n", "", today=TODAY).status == PASSED
+
+
+def _workflow(final_resume):
+    workflow = patched_workflow(auto_approve=True, pipeline_config=PipelineConfig())
+    workflow.gap_analyzer.execute.return_value = {"gaps": [], "confidence": 0.9}
+    workflow.interrogator_prepper.execute.return_value = {"questions": [], "confidence": 0.9}
+    workflow.differentiator.execute.return_value = {"differentiators": [], "confidence": 0.9}
+    workflow.tailoring_agent.execute.return_value = {
+        "tailored_resume": BASELINE,
+        "tailored_cover_letter": "",
+        "confidence": 0.9,
+    }
+    workflow.ats_optimizer.execute.return_value = {
+        "optimized_resume": final_resume,
+        "confidence": 0.9,
+    }
+    workflow.auditor_suite.execute.return_value = {"approval": {"approved": True}}
+    workflow.executive_synthesizer.execute.return_value = {"confidence": 0.9}
+    return workflow
+
+
+def test_discrepancies_fail_an_approved_audit_unless_overridden():
+    context = {"job_description": "Platform Engineer", "resume": BASELINE, "source_documents": ""}
+
+    result = _workflow(TAILORED).execute(context)
+
+    assert result.status is RunStatus.COMPLETED_WITH_AUDIT_CONCERNS
+    assert result.audit_failed is True
+    assert result.audit_report["final_status"] == "REJECTED"
+    # The new contract is also an unverified claim; the dates are reported after it.
+    assert result.audit_error.endswith(
+        "; 5 date discrepancy(ies): changed_dates, hidden_gap, overlap"
+    )
+    assert result.audit_report["chronology"]["status"] == BLOCKED
+    assert result.final_documents["resume"] == TAILORED  # kept for review
+
+    overridden = _workflow(TAILORED).execute({**context, "claim_override": True})
+    assert overridden.audit_report["chronology"]["status"] == OVERRIDDEN
+    assert "date discrepancy" not in (overridden.audit_error or "")
+
+    clean = _workflow(BASELINE).execute(context)
+    assert clean.status is RunStatus.COMPLETED
+    assert clean.audit_report["chronology"]["status"] == PASSED
+
+
+def test_run_json_counts_by_kind_and_the_cli_lists_them(capsys):
+    chronology = check_chronology(TAILORED, BASELINE, today=TODAY).to_dict()
+    audit_report = {"final_status": "REJECTED", "chronology": chronology}
+
+    manifest = build_manifest("run-1", SimpleNamespace(success=True, audit_report=audit_report))
+
+    assert manifest["audit"]["chronology"] == {
+        "status": BLOCKED,
+        "roles": 3,
+        "discrepancies": {"changed_dates": 2, "hidden_gap": 2, "overlap": 1},
+    }
+    assert "Acme" not in json.dumps(manifest["audit"])
+    assert (
+        "Dates, overlap — 'Contractor | Gamma' overlaps 'Engineer, Beta' by 2 months "
+        "(resume, line 13)"
+    ) in _audit_findings(audit_report)
+
+    cli._report_chronology(audit_report)
+    out = capsys.readouterr().out
+    assert "📅 5 date discrepancy(ies) in the résumé — blocking completion:" in out
+    assert "   - [hidden_gap] 'Engineer, Beta' (2017 - 2020) covers" in out
+    assert "--allow-unverified-claims" in out
@@ -0,0 +1,171 @@
+"""
+Unit tests for the per-provider circuit breaker and how agents and failover use it.
+"""
+
+import urllib.error
+from types import SimpleNamespace
+from unittest.mock import Mock, patch
+
+import pytest
+
+from runtime.crewai.agents.gap_analyzer import GapAnalyzerAgent
+from runtime.crewai.base_agent import ValidationError
+from runtime.crewai.circuit_breaker import (
+    CLOSED,
+    HALF_OPEN,
+    OPEN,
+    CircuitBreaker,
+    CircuitOpen,
+    CircuitPolicy,
+    is_provider_failure,
+)
+from runtime.crewai.failover import HEALTH_URLS, HealthChecker
+from runtime.crewai.hydra_workflow import HydraWorkflow
+from runtime.crewai.pipeline_config import PipelineConfig, PipelineConfigError
+from runtime.crewai.timeouts import TimeoutExceeded
+
+MAVERICK = "meta-llama/Llama-4-Maverick-17B-128E-Instruct-FP8"
+POLICY = CircuitPolicy(failures=2, cooldown=30)
+
+
+class _ServerError(Exception):
+    status_code = 503
+
+
+class _RateLimited(Exception):
+    status_code = 429
+
+
+def test_failures_in_a_row_open_the_circuit_until_a_trial_call_succeeds():
+    now = [0.0]
+    breaker = CircuitBreaker(clock=lambda: now[0])
+
+    assert breaker.record_failure("together", POLICY) is False
+    breaker.record_success("openrouter")
+    assert breaker.record_failure("together", POLICY) is True
+    assert breaker.state("together") == OPEN and breaker.state("openrouter") == CLOSED
+    with pytest.raises(CircuitOpen, match="next trial in 30s") as opened:
+        breaker.allow("together", POLICY)
+    assert opened.value.provider == "together"
+
+    # After the cooldown one caller gets a trial; a failed trial reopens at once.
+    now[0] = 30
+    breaker.allow("together", POLICY)
+    assert breaker.state("together") == HALF_OPEN
+    with pytest.raises(CircuitOpen):
+        breaker.allow("together", POLICY)
+    assert breaker.record_failure("together", POLICY) is True
+    assert breaker.snapshot()["together"] == {
+        "state": OPEN,
+        "failures": 3,
+        "opens": 2,
+        "retry_in": 30.0,
+    }
+
+    now[0] = 60
+    breaker.allow("together", POLICY)
+    breaker.record_success("together")
+    assert breaker.state("together") == CLOSED
+    breaker.allow("together", POLICY)
+
+    # A trial that never reports back expires, so the circuit cannot stay stuck.
+    breaker.record_failure("together", POLICY)
+    breaker.record_failure("together", POLICY)
+    now[0] = 90
+    breaker.allow("together", POLICY)
+    now[0] = 120
+    breaker.allow("together", POLICY)
+
+    # failures: 0 turns the breaker off.
+    off = CircuitPolicy(failures=0)
+    assert breaker.record_failure("openai", off) is False
+    breaker.allow("together", off)
+
+
+def test_only_a_provider_failing_counts_and_the_policy_is_configured():
+    assert is_provider_failure(_ServerError())
+    assert is_provider_failure(TimeoutExceeded("gap_analysis", 180, "llm_call"))
+    assert is_provider_failure(urllib.error.URLError("connection refused"))
+    try:
+        try:
+            raise urllib.error.HTTPError("https://llm", 502, "Bad Gateway", {}, None)
+        except urllib.error.HTTPError as e:
+            raise RuntimeError("Gateway returned HTTP 502") from e
+    except RuntimeError as wrapped:
+        assert is_provider_failure(wrapped)
+    assert not is_provider_failure(_RateLimited())
+    assert not is_provider_failure(ValueError("bad JSON"))
+
+    config = PipelineConfig.from_dict({"failover": {"circuit": {"failures": 5}}})
+    assert config.failover.circuit == CircuitPolicy(failures=5, cooldown=60.0)
+    assert config.to_dict()["failover"]["circuit"] == {"failures": 5, "cooldown": 60.0}
+    with pytest.raises(PipelineConfigError, match="failover.circuit.cooldown"):
+        PipelineConfig.from_dict({"failover": {"circuit": {"cooldown": "soon"}}})
+
+
+def test_an_agent_stops_retrying_once_its_provider_circuit_opens(capsys):
+    breaker = CircuitBreaker()
+    agent = GapAnalyzerAgent(SimpleNamespace(model=f"together_ai/{MAVERICK}"))
+    agent.circuit = POLICY
+    context = {"job_description": "Platform engineer", "resume": "Jane Doe"}
+
+    with (
+        patch("runtime.crewai.base_agent.shared_breaker", return_value=breaker),
+        patch.object(agent, "_invoke_llm", side_effect=_ServerError("503")) as invoke,
+    ):
+        with pytest.raises(ValidationError):
+            agent.execute(context)
+        assert invoke.call_count == 2 and breaker.state("together") == OPEN
+        assert "Circuit opened for together" in capsys.readouterr().out
+
+        # The next stage fails at once, without a call or a retry.
+        with pytest.raises(CircuitOpen):
+            agent.execute(context)
+        assert invoke.call_count == 2
+
+
+def _workflow(breaker, health):
+    names = [
+        "GapAnalyzerAgent",
+        "InterrogatorPrepperAgent",
+        "DifferentiatorAgent",
+        "TailoringAgent",
+        "ATSOptimizerAgent",
+        "AuditorSuiteAgent",
+        "ExecutiveSynthesizerAgent",
+    ]
+    patches = [patch(f"runtime.crewai.hydra_workflow.{name}") for name in names]
+    for p in patches:
+        p.start()
+    try:
+        workflow = HydraWorkflow(Mock(), use_per_agent_models=False, auto_approve=True)
+    finally:
+        for p in patches:
+            p.stop()
+    workflow.breaker = breaker
+    workflow.health = health
+    workflow._begin_run()
+    return workflow
+
+
+def test_a_stage_on_an_open_circuit_fails_over_without_a_probe(monkeypatch):
+    monkeypatch.setenv("TOGETHER_API_KEY", "together-key")
+    monkeypatch.setenv("OPENROUTER_API_KEY", "openrouter-key")
+    breaker = CircuitBreaker()
+    breaker.record_failure("together", POLICY)
+    breaker.record_failure("together", POLICY)
+    fetch = Mock(return_value=200)
+    workflow = _workflow(breaker, HealthChecker(fetch))
+    agent = workflow.gap_analyzer
+    assert agent.circuit == workflow.failover.circuit
+    agent.llm = SimpleNamespace(model=f"together_ai/{MAVERICK}", temperature=0.3)
+    agent.execute.return_value = {"gaps": []}
+
+    workflow._execute_with_fallback(agent, {}, "gap_analysis")
+
+    assert agent.llm.model == "openrouter/meta-llama/llama-4-maverick"
+    assert workflow.stage_providers["gap_analysis"]["failed_over_from"] == "together"
+    # Only the target was checked; Together's open circuit needed no probe.
+    assert [call.args[0].full_url for call in fetch.call_args_list] == [
+        HEALTH_URLS["openrouter"]
+    ]
@@ -0,0 +1,150 @@
+"""
+Unit tests for the fabrication guard (claim verification against the evidence).
+"""
+
+from unittest.mock import Mock, patch
+
+import pytest
+
+from runtime.crewai.artifacts import build_manifest
+from runtime.crewai.claim_verification import (
+    BLOCKED,
+    OVERRIDDEN,
+    PASSED,
+    extract_claims,
+    verify_claims,
+)
+from runtime.crewai.hydra_workflow import HydraWorkflow, RunStatus, UserInteraction
+
+RESUME = """# Jane Doe
+## Experience
+- Led migration of 40 services to Kubernetes on AWS, cutting costs 35%.
+- Built GraphQL services in Go.
+## Skills
+Languages: Python, Go, Rust
+"""
+EVIDENCE = "Migrated 40 services to Kubernetes on AWS. GraphQL in Go. Python."
+
+
+def test_claims_carry_kind_line_and_section():
+    claims = {(c.kind, c.text): c for c in extract_claims(RESUME)}
+
+    assert claims[("metric", "35%")].location == "resume, line 3 (Experience)"
+    assert claims[("skill", "AWS")].line == 3
+    assert claims[("skill", "Rust")].section == "Skills"
+    assert ("skill", "Languages") not in claims
+
+
+def test_unsupported_metrics_and_skills_block():
+    report = verify_claims({"resume": RESUME}, [EVIDENCE])
+
+    assert report.status == BLOCKED and report.blocking
+    assert sorted(c.text for c in report.unverified) == ["35%", "Rust"]
+    listed = report.to_dict()["unverified_claims"]
+    assert {"kind": "skill", "text": "Rust"}.items() <= listed[-1].items()
+    assert listed[-1]["location"] == "resume, line 6 (Skills)"
+
+
+def test_override_keeps_the_report_but_does_not_block():
+    report = verify_claims({"resume": RESUME}, [EVIDENCE], override=True)
+
+    assert report.status == OVERRIDDEN and not report.blocking
+    assert len(report.unverified) == 2
+
+
+def test_interview_answers_count_as_evidence():
+    report = verify_claims({"resume": RESUME}, [EVIDENCE, "Cut costs by 35%; I write Rust."])
+
+    assert report.status == PASSED and report.unverified == []
+
+
+def test_the_cover_letter_may_cite_the_job_description_but_the_resume_may_not():
+    letter = (
+        "Dear Hiring Team at NVIDIA, I would like to help with your B2B SaaS roadmap for Q3 "
+        "and your team of 40 engineers."
+    )
+    posting = "NVIDIA is hiring: own the B2B SaaS roadmap with a team of 40 engineers."
+    resume = RESUME.replace("- Built", "- Shipped B2B SaaS features.\n- Built")
+    documents = {"resume": resume, "cover_letter": letter}
+
+    report = verify_claims(documents, [EVIDENCE, "Cut costs by 35%; Rust."], employer=[posting])
+
+    assert [(c.document, c.text) for c in report.unverified] == [
+        ("resume", "B2B"),
+        ("resume", "SaaS"),
+    ]
+    assert not {"2B", "3", "2"} & {c.text for c in extract_claims(letter) if c.kind == "metric"}
+
+
+@pytest.fixture
+def workflow():
+    with (
+        patch("runtime.crewai.hydra_workflow.GapAnalyzerAgent"),
+        patch("runtime.crewai.hydra_workflow.InterrogatorPrepperAgent"),
+        patch("runtime.crewai.hydra_workflow.DifferentiatorAgent"),
+        patch("runtime.crewai.hydra_workflow.TailoringAgent"),
+        patch("runtime.crewai.hydra_workflow.ATSOptimizerAgent"),
+        patch("runtime.crewai.hydra_workflow.AuditorSuiteAgent"),
+        patch("runtime.crewai.hydra_workflow.ExecutiveSynthesizerAgent"),
+    ):
+        return HydraWorkflow(Mock(), use_per_agent_models=False)
+
+
+def _run(workflow, **context):
+    workflow.gap_analyzer.execute.return_value = {"gaps": []}
+    workflow.interrogator_prepper.execute.return_value = {"questions": []}
+    workflow.differentiator.execute.return_value = {}
+    workflow.tailoring_agent.execute.return_value = {"tailored_resume": RESUME}
+    workflow.ats_optimizer.execute.return_value = {"optimized_resume": RESUME}
+    workflow.auditor_suite.execute.return_value = {"approval": {"approved": True}}
+    workflow.executive_synthesizer.execute.return_value = {}
+    return workflow.execute(
+        {
+            "job_description": "Platform engineer",
+            "resume": EVIDENCE,
+            "source_documents": "",
+            "gap_analysis_approved": True,
+            **context,
+        }
+    )
+
+
+def test_workflow_blocks_completion_on_unverified_claims(workflow):
+    result = _run(workflow)
+
+    assert result.status == RunStatus.COMPLETED_WITH_AUDIT_CONCERNS
+    assert result.audit_report["final_status"] == "REJECTED"
+    assert "2 claim(s) not supported" in result.audit_error
+    verification = result.audit_report["claim_verification"]
+    assert [c["text"] for c in verification["unverified_claims"]] == ["35%", "Rust"]
+    assert result.final_documents["resume"] == RESUME  # documents are preserved
+
+
+def test_context_override_completes_and_keeps_the_findings(workflow):
+    result = _run(workflow, claim_override=True)
+
+    assert result.status == RunStatus.COMPLETED
+    assert result.audit_report["claim_verification"]["status"] == OVERRIDDEN
+
+
+def test_interactive_yes_overrides(workflow):
+    workflow.interactive = True
+    with (
+        patch.object(UserInteraction, "ask_yes_no", return_value=True) as ask,
+        patch("builtins.print"),
+    ):
+        result = _run(workflow)
+
+    ask.assert_called_with("Keep these unverified claims anyway?")
+    assert result.status == RunStatus.COMPLETED
+
+
+def test_manifest_records_counts_only(workflow):
+    result = _run(workflow)
+
+    manifest = build_manifest("run-1", result)
+
+    counts = manifest["audit"]["claim_verification"]
+    assert counts["status"] == BLOCKED and counts["unverified_claims"] == 2
+    assert counts["checked_claims"] > 2
+    assert "Rust" not in str(manifest)
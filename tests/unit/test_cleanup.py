@@ -0,0 +1,138 @@
+"""
+Unit tests for run retention: which runs lose their documents, which go, and hydra gc.
+"""
+
+import json
+import os
+import time
+from datetime import date
+
+import pytest
+
+from runtime.crewai import cli
+from runtime.crewai.application_email import APPLICATION_FILE
+from runtime.crewai.artifacts import MANIFEST_FILE, RESUME_FILE
+from runtime.crewai.cleanup import (
+    ARTIFACT_DAYS_ENV,
+    STATE_DAYS_ENV,
+    CleanupPolicy,
+    CleanupReport,
+    clean_runs,
+    clean_stage_cache,
+    collect,
+    policy_from_env,
+)
+from runtime.crewai.prompt_transcript import PROMPT_TRANSCRIPT_FILE
+
+TODAY = date(2026, 10, 17)
+
+
+def _run(out, run_id):
+    run_dir = out / run_id
+    (run_dir / "intermediate").mkdir(parents=True)
+    manifest = {
+        "run_id": run_id,
+        "status": "completed",
+        "inputs": {"company": "Acme"},
+        "artifacts": [RESUME_FILE, PROMPT_TRANSCRIPT_FILE, APPLICATION_FILE],
+    }
+    (run_dir / MANIFEST_FILE).write_text(json.dumps(manifest))
+    (run_dir / RESUME_FILE).write_text("# Jane Doe")
+    (run_dir / PROMPT_TRANSCRIPT_FILE).write_text("{}")
+    (run_dir / APPLICATION_FILE).write_text("{}")
+    (run_dir / "intermediate" / "tailoring.yaml").write_text("tailored_resume: '# Jane'")
+    return run_dir
+
+
+def test_policies_are_checked(monkeypatch):
+    with pytest.raises(ValueError, match="at least 1"):
+        CleanupPolicy(artifact_days=0)
+    with pytest.raises(ValueError, match="before their documents"):
+        CleanupPolicy(artifact_days=90, state_days=30)
+    assert CleanupPolicy().empty
+
+    monkeypatch.setenv(ARTIFACT_DAYS_ENV, "90")
+    monkeypatch.setenv(STATE_DAYS_ENV, "365")
+    assert policy_from_env() == CleanupPolicy(90, 365)
+    assert policy_from_env(artifact_days=30) == CleanupPolicy(30, 365)
+    monkeypatch.setenv(STATE_DAYS_ENV, "a year")
+    with pytest.raises(ValueError, match="whole number of days"):
+        policy_from_env()
+
+
+def test_old_runs_lose_their_documents_then_go(tmp_path):
+    out = tmp_path / "output"
+    old = _run(out, "acme-sre-20260101-090000-a1b2c3d4")
+    ancient = _run(out, "acme-pe-20250101-090000-e5f6a7b8")
+    recent = _run(out, "acme-sre-20261010-090000-c9d0e1f2")
+    (out / "notes").mkdir()
+    policy = CleanupPolicy(artifact_days=90, state_days=365)
+
+    report = CleanupReport()
+    clean_runs(out, policy, report, today=TODAY)
+
+    assert report.purged == [old.name] and report.deleted == [ancient.name]
+    assert report.bytes_freed > 0
+    assert not ancient.exists() and (out / "notes").is_dir()
+    assert sorted(p.name for p in old.iterdir()) == [
+        APPLICATION_FILE,
+        "manifest.json",
+        "report.html",
+        MANIFEST_FILE,
+    ]
+    manifest = json.loads((old / MANIFEST_FILE).read_text())
+    assert manifest["cleanup"]["files_deleted"] == 3
+    assert manifest["artifacts"] == [APPLICATION_FILE]
+    assert (recent / RESUME_FILE).is_file()
+
+    # A purged run is not purged again.
+    again = CleanupReport()
+    clean_runs(out, policy, again, today=TODAY)
+    assert again.purged == [] and again.deleted == []
+
+
+def test_a_dry_run_deletes_nothing(tmp_path):
+    run_dir = _run(tmp_path, "acme-sre-20260101-090000-a1b2c3d4")
+    report = CleanupReport(dry_run=True)
+    clean_runs(tmp_path, CleanupPolicy(artifact_days=30), report, today=TODAY)
+    assert report.purged == [run_dir.name] and (run_dir / RESUME_FILE).is_file()
+
+
+def test_stale_stage_cache_entries_go(tmp_path):
+    stale, fresh = tmp_path / "ab" / "ab12.json", tmp_path / "cd" / "cd34.json"
+    for path in (stale, fresh):
+        path.parent.mkdir()
+        path.write_text("{}")
+    long_ago = time.time() - 100 * 86400
+    os.utime(stale, (long_ago, long_ago))
+
+    report = CleanupReport()
+    clean_stage_cache(CleanupPolicy(artifact_days=90), report, root=tmp_path)
+    assert report.cache_entries == 1 and not stale.exists() and fresh.exists()
+
+    clean_stage_cache(CleanupPolicy(state_days=90), report, root=tmp_path)
+    assert fresh.exists()
+
+
+def test_gc_command(tmp_path, monkeypatch, capsys):
+    monkeypatch.setenv("HYDRA_HOME", str(tmp_path / "home"))
+    monkeypatch.delenv(ARTIFACT_DAYS_ENV, raising=False)
+    monkeypatch.delenv(STATE_DAYS_ENV, raising=False)
+    out = tmp_path / "output"
+    run_dir = _run(out, "acme-sre-20200101-090000-a1b2c3d4")
+
+    with pytest.raises(SystemExit):
+        cli.main(["gc", "--out", str(out)])
+    assert "No retention period" in capsys.readouterr().err
+
+    assert cli.main(["gc", "--out", str(out), "--artifacts-days", "30", "--dry-run"]) == 0
+    assert "Would delete the documents of" in capsys.readouterr().out
+    assert (run_dir / RESUME_FILE).is_file()
+
+    assert cli.main(["gc", "--out", str(out), "--artifacts-days", "30"]) == 0
+    assert not (run_dir / RESUME_FILE).exists()
+    assert cli.main(["gc", "--out", str(out), "--artifacts-days", "30"]) == 0
+    assert "Nothing to delete." in capsys.readouterr().out
+
+    report = collect(out, CleanupPolicy(artifact_days=30, state_days=60))
+    assert report.deleted == [run_dir.name] and not run_dir.exists()
@@ -0,0 +1,167 @@
+"""
+Unit tests for the optional compensation stage and its negotiation brief.
+"""
+
+import json
+from unittest.mock import Mock, patch
+
+import pytest
+from crewai import LLM
+
+from runtime.crewai.agents.compensation import CompensationAgent
+from runtime.crewai.artifacts import write_run_artifacts
+from runtime.crewai.cli import main
+from runtime.crewai.compensation import (
+    NEGOTIATION_BRIEF_FILE,
+    CompensationTargets,
+    assess_target,
+    parse_amount,
+    render_brief,
+)
+from runtime.crewai.contracts import CompensationBrief
+
+RAW = {
+    "negotiation_brief": {
+        "currency": "usd",
+        "market_ranges": [
+            {"component": "Base", "low": "$210k", "high": "170,000", "citations": ["jd"]},
+            {"component": "equity", "low": 40000, "high": 80000, "citations": [2, 9]},
+            {"component": "bonus", "low": 0, "high": 20000, "note": "Typical for Series C"},
+            {"component": "sign-on", "low": "n/a", "high": None},  # no numbers: dropped
+        ],
+        "recommended_ask": {"base": "200k", "rationale": "Upper half of the posted range"},
+        "talking_points": ["Led the Kubernetes migration", {"text": "Cut deploy time 40%"}],
+        "questions": "Is there a vesting cliff?",
+    }
+}
+SOURCES = [
+    {"id": 1, "title": "Acme careers", "url": "https://a.example/1"},
+    {"id": 2, "title": "Acme equity report", "url": "https://a.example/2"},
+]
+
+
+def _brief():
+    return CompensationBrief.from_raw(RAW).checked({1, 2}).model_dump()
+
+
+def test_brief_is_normalized_and_labelled_by_evidence():
+    brief = _brief()
+
+    base, equity, bonus = brief["market_ranges"]
+    assert brief["currency"] == "USD"
+    assert (base["component"], base["low"], base["high"]) == ("base", 170000.0, 210000.0)
+    assert base["basis"] == "job description" and base["citations"] == []
+    assert equity["citations"] == [2] and equity["basis"] == "research"
+    assert bonus["basis"] == "model estimate" and bonus["currency"] == "USD"
+    assert brief["recommended_ask"] == {
+        "base": 200000.0,
+        "note": "Upper half of the posted range",
+    }
+    assert brief["talking_points"] == ["Led the Kubernetes migration", "Cut deploy time 40%"]
+    assert brief["questions"] == ["Is there a vesting cliff?"]
+
+
+def test_target_is_placed_against_the_best_backed_base_range():
+    brief = _brief()
+    estimate = {"component": "base", "low": 100000.0, "high": 120000.0, "currency": "USD"}
+    brief["market_ranges"].append({**estimate, "citations": [], "basis": "model estimate"})
+
+    assert assess_target(brief, CompensationTargets(185000)) == {
+        "position": "within",
+        "basis": "job description",
+    }
+    assert assess_target(brief, CompensationTargets(150000))["position"] == "below"
+    above = assess_target(brief, CompensationTargets(230000, minimum_base=215000))
+    assert above["position"] == "above" and above["walk_away_above_range"] is True
+    assert assess_target(brief, CompensationTargets(185000, currency="EUR"))["position"] == (
+        "unknown"
+    )
+    assert assess_target(brief, CompensationTargets()) is None
+
+
+def test_amounts_from_the_command_line():
+    assert parse_amount("185000") == parse_amount("185,000") == parse_amount("185k") == 185000
+    with pytest.raises(ValueError, match="Not an amount"):
+        parse_amount("lots")
+
+
+def test_rendered_brief_shows_numbers_basis_and_sources():
+    brief = {**_brief(), "sources": SOURCES}
+    brief["target_assessment"] = {"position": "within", "basis": "job description"}
+
+    text = render_brief(brief, CompensationTargets(185000, 160000))
+
+    assert "Your target base: USD 185,000; walk-away: USD 160,000" in text
+    assert "Your target is **within** the base range (job description)." in text
+    assert "- **base**: USD 170,000 – USD 210,000 (job description)" in text
+    assert "(research[2])" in text and "[2] Acme equity report https://a.example/2" in text
+    assert "## Questions to ask\n\n- Is there a vesting cliff?" in text
+
+
+def test_agent_checks_citations_and_never_sees_the_resume():
+    with patch("runtime.crewai.base_agent.Path.read_text", return_value="Compensation prompt"):
+        agent = CompensationAgent(LLM(model="gpt-4", api_key="test-key"))
+    agent.execute_with_retry = Mock(return_value=RAW)
+
+    result = agent.execute(
+        {
+            "job_description": "Platform engineer, $170,000 - $210,000",
+            "research": {"sources": SOURCES[:1]},
+            "compensation_targets": {"target_base": 185000, "currency": "USD"},
+            "resume": "secret résumé",
+        }
+    )
+
+    assert result["market_ranges"][1]["citations"] == []  # [2] was not a real source
+    assert result["target_assessment"]["position"] == "within"
+    assert result["targets"]["target_base"] == 185000
+    prompt = agent.execute_with_retry.call_args[0][0].description
+    assert "Target base salary: USD 185,000" in prompt
+    assert "secret résumé" not in prompt
+
+
+def test_brief_file_holds_the_numbers_and_the_manifest_only_counts(tmp_path):
+    brief = {**_brief(), "sources": SOURCES, "targets": {"target_base": 185000.0}}
+
+    class Result:
+        final_documents = {"resume": "# Jane"}
+        compensation_brief = brief
+
+    run_dir = write_run_artifacts(tmp_path, Result(), run_id="run-1")
+
+    assert "USD 185,000" in (run_dir / NEGOTIATION_BRIEF_FILE).read_text()
+    manifest = json.loads((run_dir / "run.json").read_text())
+    assert manifest["compensation"] == {
+        "market_ranges": 3,
+        "estimated_ranges": 1,
+        "talking_points": 2,
+        "questions": 1,
+    }
+    assert "185" not in json.dumps(manifest)
+
+
+@pytest.mark.parametrize(
+    "flags, message",
+    [
+        (["--target-salary", "185k"], "require --compensation"),
+        (["--compensation", "--target-salary", "lots"], "--target-salary: Not an amount"),
+        (
+            ["--compensation", "--target-salary", "150k", "--walk-away", "160k"],
+            "cannot be above --target-salary",
+        ),
+        (["--compensation", "--quick-apply"], "cannot be combined with --compensation"),
+    ],
+)
+def test_cli_rejects_inconsistent_compensation_flags(tmp_path, capsys, flags, message):
+    jd, resume = tmp_path / "jd.md", tmp_path / "resume.md"
+    jd.write_text("JD")
+    resume.write_text("Resume")
+    (tmp_path / "sources").mkdir()
+    (tmp_path / "sources" / "cv.md").write_text("Sources")
+
+    paths = ["--jd", str(jd), "--resume", str(resume), "--sources", str(tmp_path / "sources")]
+
+    with pytest.raises(SystemExit):
+        main([*paths, "--out", str(tmp_path / "out"), *flags])
+
+    assert message in capsys.readouterr().err
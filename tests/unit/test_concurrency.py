@@ -0,0 +1,187 @@
+"""
+Concurrency tests: several workflows in one process, and threads reading a run's
+progress while its stages write it.
+
+Python has no race detector; these tests shrink the interpreter's thread switch
+interval so threads interleave between almost every bytecode, which turns an unlocked
+read-while-write (e.g. "dictionary changed size during iteration") into a reliable
+failure instead of a rare one.
+"""
+
+import sys
+import threading
+import time
+from unittest.mock import Mock, patch
+
+import pytest
+
+from runtime.crewai.hydra_workflow import HydraWorkflow, RunStatus
+from runtime.crewai.quick_apply import LatencyBudget
+from runtime.crewai.tailoring_variants import VariantPreferences
+
+RUNS = 8
+# Job names without digits: claim verification would flag numbers in the résumé.
+JOBS = ["alpha", "bravo", "charlie", "delta", "echo", "foxtrot", "golf", "hotel"]
+
+
+@pytest.fixture(autouse=True)
+def tight_switching():
+    interval = sys.getswitchinterval()
+    sys.setswitchinterval(1e-6)
+    yield
+    sys.setswitchinterval(interval)
+
+
+def _echo(stage):
+    """An agent whose output names the job it was given, after yielding the GIL."""
+
+    def _execute(context):
+        time.sleep(0.001)
+        jd = context["job_description"]
+        if stage == "tailoring":
+            return {"tailored_resume": f"Resume for {jd}", "tailored_cover_letter": f"Dear {jd}"}
+        if stage == "gap_analysis":
+            return {"gaps": [f"gap-{i}" for i in range(50)], "jd": jd}
+        return {"stage": stage, "jd": jd}
+
+    return _execute
+
+
+def _workflow(latency_budget=None):
+    with (
+        patch("runtime.crewai.hydra_workflow.GapAnalyzerAgent"),
+        patch("runtime.crewai.hydra_workflow.InterrogatorPrepperAgent"),
+        patch("runtime.crewai.hydra_workflow.DifferentiatorAgent"),
+        patch("runtime.crewai.hydra_workflow.TailoringAgent"),
+        patch("runtime.crewai.hydra_workflow.ATSOptimizerAgent"),
+        patch("runtime.crewai.hydra_workflow.AuditorSuiteAgent"),
+        patch("runtime.crewai.hydra_workflow.ExecutiveSynthesizerAgent"),
+    ):
+        workflow = HydraWorkflow(
+            Mock(), use_per_agent_models=False, auto_approve=True, latency_budget=latency_budget
+        )
+    workflow.gap_analyzer.execute.side_effect = _echo("gap_analysis")
+    workflow.interrogator_prepper.execute.side_effect = lambda context: {"questions": []}
+    workflow.differentiator.execute.side_effect = _echo("differentiation")
+    workflow.tailoring_agent.execute.side_effect = _echo("tailoring")
+    workflow.ats_optimizer.execute.side_effect = lambda context: {}
+    workflow.auditor_suite.execute.return_value = {"approval": {"approved": True}}
+    workflow.executive_synthesizer.execute.side_effect = _echo("executive_synthesis")
+    return workflow
+
+
+def _context(jd):
+    return {"job_description": jd, "resume": "Jane Doe", "source_documents": "Jane Doe"}
+
+
+class _Poller(threading.Thread):
+    """Reads progress the way the web backend does, until stopped."""
+
+    def __init__(self, workflows):
+        super().__init__(daemon=True)
+        self.workflows = workflows
+        self.stop = threading.Event()
+        self.errors = []
+        self.reads = 0
+
+    def run(self):
+        while not self.stop.is_set():
+            for workflow in self.workflows:
+                try:
+                    workflow.get_current_state()
+                    workflow.get_execution_log()
+                    workflow.get_intermediate_results()
+                    self.reads += 1
+                except Exception as e:  # noqa: BLE001 - any error is the finding
+                    self.errors.append(e)
+
+
+def _run_all(workflows, contexts):
+    results = [None] * len(workflows)
+
+    def _run(index):
+        results[index] = workflows[index].execute(contexts[index])
+
+    poller = _Poller(workflows)
+    poller.start()
+    threads = [threading.Thread(target=_run, args=(i,)) for i in range(len(workflows))]
+    for thread in threads:
+        thread.start()
+    for thread in threads:
+        thread.join(timeout=30)
+    poller.stop.set()
+    poller.join(timeout=5)
+    return results, poller
+
+
+def test_concurrent_runs_each_own_their_state():
+    workflows = [_workflow() for _ in range(RUNS)]
+    contexts = [_context(f"job {JOBS[i]}") for i in range(RUNS)]
+
+    results, poller = _run_all(workflows, contexts)
+
+    assert poller.errors == [] and poller.reads > 0
+    for i, result in enumerate(results):
+        assert result.status == RunStatus.COMPLETED, result.error_message
+        assert result.final_documents["resume"] == f"Resume for job {JOBS[i]}"
+        stages = result.intermediate_results
+        assert {stages[stage]["jd"] for stage in ("gap_analysis", "differentiation")} == {
+            f"job {JOBS[i]}"
+        }
+        assert result.execution_log[0].endswith("Starting HydraWorkflow execution")
+        assert sum("Starting HydraWorkflow" in line for line in result.execution_log) == 1
+
+
+def test_parallel_stages_within_a_run_report_progress_safely():
+    workflows = [_workflow(LatencyBudget(300)) for _ in range(RUNS)]
+    contexts = [_context(f"quick {JOBS[i]}") for i in range(RUNS)]
+
+    results, poller = _run_all(workflows, contexts)
+
+    assert poller.errors == []
+    for i, result in enumerate(results):
+        assert result.status == RunStatus.COMPLETED, result.error_message
+        assert result.final_documents["resume"] == f"Resume for quick {JOBS[i]}"
+        # Gap analysis and differentiation ran on two threads; both were recorded.
+        assert {"gap_analysis", "differentiation"} <= set(result.intermediate_results)
+
+
+def test_one_workflow_runs_one_run_at_a_time_and_starts_each_fresh():
+    workflow = _workflow()
+    release = threading.Event()
+    entered = threading.Event()
+
+    def _blocking_gaps(context):
+        entered.set()
+        release.wait(timeout=10)
+        return {"gaps": []}
+
+    workflow.gap_analyzer.execute.side_effect = _blocking_gaps
+    first = threading.Thread(target=workflow.execute, args=(_context("first"),))
+    first.start()
+    assert entered.wait(timeout=10)
+
+    with pytest.raises(RuntimeError, match="already running"):
+        workflow.execute(_context("second"))
+
+    release.set()
+    first.join(timeout=10)
+    workflow.gap_analyzer.execute.side_effect = _echo("gap_analysis")
+    result = workflow.execute(_context("third"))
+
+    assert result.intermediate_results["gap_analysis"]["jd"] == "third"
+    assert sum("Starting HydraWorkflow" in line for line in result.execution_log) == 1
+
+
+def test_concurrent_preference_records_are_not_lost(tmp_path):
+    preferences = VariantPreferences(tmp_path / "preferences.json")
+    threads = [
+        threading.Thread(target=preferences.record, args=("a:m", ["a:m", "b:m"]))
+        for _ in range(20)
+    ]
+    for thread in threads:
+        thread.start()
+    for thread in threads:
+        thread.join(timeout=10)
+
+    assert preferences.load() == {"a:m": {"wins": 20, "runs": 20}, "b:m": {"wins": 0, "runs": 20}}
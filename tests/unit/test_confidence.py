@@ -0,0 +1,138 @@
+"""
+Unit tests for confidence gating: re-prompting and escalating unsure stages.
+"""
+
+from types import SimpleNamespace
+from unittest.mock import Mock
+
+import pytest
+from crewai import LLM
+
+from runtime.crewai.agents.differentiator import DifferentiatorAgent
+from runtime.crewai.artifacts import build_manifest
+from runtime.crewai.confidence import ConfidencePolicy, confidence_of, critique_prompt
+from runtime.crewai.html_report import render_report
+from runtime.crewai.pipeline_config import (
+    PipelineConfig,
+    PipelineConfigError,
+    load_pipeline_config,
+)
+from tests.conftest import patched_workflow
+
+
+def _workflow(interactive=False, **confidence):
+    config = PipelineConfig.from_dict({"confidence": confidence})
+    workflow = patched_workflow(auto_approve=True, pipeline_config=config)
+    workflow.interactive = interactive
+    workflow._begin_run()
+    return workflow
+
+
+def _answers(agent, *confidences):
+    """Make ``agent`` answer with each confidence in turn, noting the critique it got."""
+    critiques = []
+
+    def _execute(context):
+        critiques.append(agent.critique)
+        return {"gaps": [f"answer {len(critiques)}"], "confidence": confidences[len(critiques) - 1]}
+
+    agent.critique = None
+    agent.execute.side_effect = _execute
+    return critiques
+
+
+def test_policy_reads_per_stage_thresholds():
+    policy = ConfidencePolicy.from_dict({"threshold": 0.6, "stages": {"audit": 0.9}})
+
+    assert policy.for_stage("audit") == 0.9 and policy.for_stage("tailoring") == 0.6
+    assert policy.reprompts == 1 and policy.escalate is True
+    assert ConfidencePolicy.from_dict(policy.to_dict()) == policy
+    assert confidence_of({"confidence": 0.4}) == 0.4
+    assert confidence_of({"confidence": "high"}) is None
+
+
+@pytest.mark.parametrize(
+    "text, message",
+    [
+        ("confidence:\n  threshold: 1.5\n", "confidence.threshold"),
+        ("confidence:\n  stages: {audit: high}\n", "confidence.stages.audit"),
+        ("confidence:\n  reprompts: -1\n", "confidence.reprompts"),
+        ("confidence:\n  retry: true\n", "unknown confidence setting"),
+    ],
+)
+def test_bad_confidence_configs_are_rejected(tmp_path, text, message):
+    path = tmp_path / "pipeline.yaml"
+    path.write_text(text)
+    with pytest.raises(PipelineConfigError, match=message):
+        load_pipeline_config(path)
+
+
+def test_a_low_confidence_stage_is_re_prompted_with_a_critique():
+    workflow = _workflow(threshold=0.6)
+    agent = workflow.gap_analyzer
+    critiques = _answers(agent, 0.3, 0.8)
+
+    result = workflow._execute_with_fallback(agent, {"resume": "CV"}, "gap_analysis")
+
+    assert result == {"gaps": ["answer 2"], "confidence": 0.8}
+    assert critiques[0] is None
+    assert "confidence 0.30" in critiques[1] and '"answer 1"' in critiques[1]
+    assert agent.critique is None
+    summary = {"gap_analysis": {"confidence": 0.8, "threshold": 0.6, "reprompts": 1}}
+    assert workflow._confidence_summary() == summary
+
+    manifest = build_manifest("run-1", SimpleNamespace(stage_confidence=summary))
+    assert manifest["confidence"] == summary
+    assert "Confidence by stage" in render_report(manifest)
+
+
+def test_a_confident_stage_runs_once():
+    workflow = _workflow()
+    agent = workflow.differentiator
+    critiques = _answers(agent, 0.9)
+
+    workflow._execute_with_fallback(agent, {}, "differentiation")
+
+    assert critiques == [None]
+    assert workflow.stage_confidence["differentiation"]["reprompts"] == 0
+
+
+def test_a_stage_that_stays_unsure_keeps_its_best_answer_and_is_flagged():
+    workflow = _workflow(stages={"gap_analysis": 0.7}, reprompts=2)
+    agent = workflow.gap_analyzer
+    _answers(agent, 0.4, 0.5, 0.2)
+
+    result = workflow._execute_with_fallback(agent, {}, "gap_analysis")
+
+    assert result["gaps"] == ["answer 2"]
+    assert workflow.stage_confidence["gap_analysis"] == {
+        "confidence": 0.5,
+        "threshold": 0.7,
+        "reprompts": 2,
+        "below_threshold": True,
+        "escalated": True,
+    }
+    assert any("review it before sending" in line for line in workflow.execution_log)
+
+
+def test_an_interactive_run_asks_whether_to_continue():
+    workflow = _workflow(interactive=True, reprompts=0)
+    workflow.user_interaction = Mock()
+    workflow.user_interaction.ask_yes_no.return_value = False
+    _answers(workflow.gap_analyzer, 0.2)
+
+    with pytest.raises(Exception, match="User aborted"):
+        workflow._execute_with_fallback(workflow.gap_analyzer, {}, "gap_analysis")
+
+    question = workflow.user_interaction.ask_yes_no.call_args.args[0]
+    assert "gap_analysis" in question and "0.20 < 0.50" in question
+
+
+def test_the_critique_is_part_of_the_task():
+    agent = DifferentiatorAgent(LLM(model="gpt-4o-mini", api_key="test-key"))
+    agent.critique = critique_prompt({"differentiators": ["Go"], "confidence": 0.2}, 0.2, 0.5)
+
+    description = agent.create_task("Find differentiators.").description
+
+    assert "REVIEW OF YOUR PREVIOUS ANSWER" in description and '"Go"' in description
+    assert '"confidence": 0.2' not in description  # bookkeeping is not quoted
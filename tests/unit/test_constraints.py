@@ -0,0 +1,135 @@
+"""
+Unit tests for hard constraints: declaring them, checking postings and failing runs early.
+"""
+
+import json
+
+import pytest
+
+from runtime.crewai import cli
+from runtime.crewai.artifacts import MANIFEST_FILE, write_run_artifacts
+from runtime.crewai.constraints import (
+    CONSTRAINTS_FILE,
+    Constraints,
+    ConstraintsError,
+    check_constraints,
+    load_constraints,
+    posted_pay,
+)
+from runtime.crewai.hydra_workflow import RunStatus
+from runtime.crewai.pipeline_config import PipelineConfig
+from tests.conftest import patched_workflow
+
+pytestmark = pytest.mark.usefixtures("hydra_home")
+
+DECLARED = {
+    "work_authorization": ["Canada"],
+    "needs_sponsorship": True,
+    "remote_only": True,
+    "salary_floor": "150k",
+    "clearance": False,
+}
+JD = """Senior Engineer at Acme. On-site in Austin, 4 days a week.
+We are unable to sponsor visas. Candidates must be authorized to work in the United States.
+Active TS/SCI security clearance required. Salary: $120,000 - $140,000.
+"""
+
+
+def test_constraints_are_read_from_yaml_and_checked():
+    assert load_constraints() is None
+    constraints = Constraints.from_dict(DECLARED)
+    assert constraints.work_authorization == ["CA"] and constraints.salary_floor == 150000
+    assert constraints.declared() == [
+        "work_authorization",
+        "needs_sponsorship",
+        "remote_only",
+        "salary_floor",
+        "clearance",
+    ]
+    assert "Salary floor: 150,000 USD" in constraints.describe()
+    with pytest.raises(ConstraintsError, match="unknown constraint"):
+        Constraints.from_dict({"remote": True})
+    with pytest.raises(ConstraintsError, match="true or false"):
+        Constraints.from_dict({"remote_only": "yes"})
+
+    assert [v.describe() for v in check_constraints(constraints, JD)] == [
+        "needs sponsorship: you need sponsorship — “We are unable to sponsor visas”",
+        "clearance: the role needs a clearance — “Active TS/SCI security clearance required”",
+        "remote only: the role is on-site or hybrid — “On-site in Austin, 4 days a week”",
+        "work authorization: the role needs authorization to work in US — “Candidates must be "
+        "authorized to work in the United States”",
+        "salary floor: the posted pay tops out at 140,000 USD, below your floor of 150,000 — "
+        "“Salary: $120,000 - $140,000”",
+    ]
+    assert check_constraints(constraints, "Fully remote, $160k-$190k, hybrid optional.") == []
+    not_remote = "Remote-friendly team. This is not a remote role."
+    assert [v.constraint for v in check_constraints(constraints, not_remote)] == ["remote_only"]
+    company = "Join Acme, which is not fully remote. This role is remote, $160k-$190k."
+    assert check_constraints(constraints, company) == []
+
+
+def test_posted_pay_reads_ranges_but_not_rates():
+    pay = posted_pay("$150-180k base, or 140,000–170,000 EUR. Contractors: $95/hour.")
+    assert [(top, currency) for top, currency, _ in pay] == [(180000, "USD"), (170000, "EUR")]
+    assert [top for top, _, _ in posted_pay("Base salary of $175,000 per year.")] == [175000]
+
+
+def test_a_stipend_is_not_posted_pay():
+    perks = "We offer a $2,500 home office stipend and a $1,500 learning budget."
+    assert posted_pay(perks) == []
+    floor = Constraints.from_dict({"salary_floor": "150k"})
+    assert check_constraints(floor, perks) == []
+
+
+def _workflow(constraints):
+    workflow = patched_workflow(
+        auto_approve=True, pipeline_config=PipelineConfig(), constraints=constraints
+    )
+    workflow.gap_analyzer.execute.return_value = {
+        "gap_analysis": {
+            "requirements": [
+                {"text": "Active security clearance", "classification": "blocker"},
+            ]
+        }
+    }
+    return workflow
+
+
+def test_a_run_fails_before_any_model_call_or_before_the_interview(tmp_path):
+    context = {"job_description": JD, "resume": "Resume", "source_documents": "Sources"}
+    workflow = _workflow(Constraints.from_dict({"remote_only": True}))
+
+    result = workflow.execute(context)
+
+    assert result.status is RunStatus.FAILED
+    assert "Hard constraint not met in the job description: remote only" in result.error_message
+    workflow.gap_analyzer.execute.assert_not_called()
+    run_dir = write_run_artifacts(tmp_path, result, run_id="run-1")
+    manifest = json.loads((run_dir / MANIFEST_FILE).read_text())
+    assert manifest["constraints"] == {"declared": ["remote_only"], "violated": ["remote_only"]}
+
+    # The posting says nothing of a clearance; the analyzer's requirements do.
+    context["job_description"] = "Platform Engineer, fully remote."
+    workflow = _workflow(Constraints.from_dict({"clearance": False, "remote_only": True}))
+    result = workflow.execute(context)
+    assert "in the gap analysis: clearance" in result.error_message
+    workflow.interrogator_prepper.execute.assert_not_called()
+    workflow.tailoring_agent.execute.assert_not_called()
+
+
+def test_hydra_constraints_checks_a_posting(tmp_path, capsys):
+    jd = tmp_path / "jd.md"
+    jd.write_text(JD)
+    assert cli.main(["constraints"]) == 0
+    assert "No constraints declared" in capsys.readouterr().out
+
+    home = tmp_path / "home"
+    home.mkdir()
+    (home / CONSTRAINTS_FILE).write_text("remote_only: true\nsalary_floor: 100k\n")
+    assert cli.main(["constraints", "--jd", str(jd)]) == 1
+    out = capsys.readouterr().out
+    assert "Remote only: yes" in out and "🚧 remote only: the role is on-site" in out
+
+    jd.write_text("Remote, $150k-$170k")
+    assert cli.main(["constraints", "--jd", str(jd)]) == 0
+    assert "meets every constraint" in capsys.readouterr().out
@@ -0,0 +1,192 @@
+"""
+Unit tests for the contact header guarantee: the baseline's name and contacts restored.
+"""
+
+import json
+from types import SimpleNamespace
+
+from runtime.crewai import cli
+from runtime.crewai.artifacts import MANIFEST_FILE, write_run_artifacts
+from runtime.crewai.contact_header import (
+    ADDED,
+    ALTERED,
+    DROPPED,
+    EMAIL,
+    LINK,
+    NAME,
+    PHONE,
+    Change,
+    parse_header,
+    preserve_header,
+)
+from runtime.crewai.pipeline_config import PipelineConfig
+from tests.conftest import patched_workflow
+
+BASELINE = """# Jane Doe
+Platform Engineer
+jane.doe@example.com | +1 (415) 555-0100
+[LinkedIn](https://www.linkedin.com/in/janedoe) | github.com/janedoe
+
+## Experience
+### Engineer | Acme
+Jan 2019 - Present
+- Cut p99 latency from 800 ms to 120 ms
+"""
+MANGLED = """# Jane A. Doe
+Staff Platform Engineer · Kubernetes
+janedoe@example.com | jane@gmail.com | +1 (415) 555-0010
+[LinkedIn](https://www.linkedin.com/in/jane-doe)
+
+## Experience
+### Engineer | Acme
+Jan 2019 - Present
+- Cut p99 latency from 800 ms to 120 ms
+"""
+
+
+def test_the_header_is_parsed_above_the_first_section():
+    header = parse_header(BASELINE)
+
+    assert header.name == "Jane Doe"
+    assert header.email == ["jane.doe@example.com"]
+    assert header.phone == ["+1 (415) 555-0100"]
+    assert header.link == ["https://www.linkedin.com/in/janedoe", "github.com/janedoe"]
+    # Numbers in the body are not contacts.
+    assert header.count() == 5
+
+    plain = parse_header("Jane Doe\njane@example.com\n\nSummary line 415 555 0100")
+    assert (plain.name, plain.email, plain.phone) == ("Jane Doe", ["jane@example.com"], [])
+    assert parse_header("Migrated 40 services to Kubernetes.").name is None
+
+
+def test_a_headline_is_not_a_name_and_a_year_range_not_a_phone():
+    # A first line of capitalised words is a name only above a contact line.
+    assert parse_header("Senior Platform Engineer\nBuilt Kubernetes platforms.").name is None
+    assert parse_header("Jane Doe").count() == 0
+    assert parse_header("Jane Doe\n+1 415 555 0100").name == "Jane Doe"
+
+    header = parse_header("# Jane Doe\nAcme 2015-2024 | 2015 - 2024 | 415-555-0100")
+    assert header.phone == ["415-555-0100"]
+    # So a tailored header listing years has nothing to remove.
+    tailored = "# Jane Doe\nEngineer, 2015-2024\n"
+    assert preserve_header(tailored, "# Jane Doe\nEngineer, 2015-2024\n").resume == tailored
+
+
+def test_altered_added_and_dropped_fields_are_restored():
+    check = preserve_header(MANGLED, BASELINE)
+
+    assert check.resume.splitlines()[:4] == [
+        "# Jane Doe",
+        "Staff Platform Engineer · Kubernetes",  # a tailored headline stays
+        "jane.doe@example.com | +1 (415) 555-0100 | github.com/janedoe",
+        "[LinkedIn](https://www.linkedin.com/in/janedoe)",
+    ]
+    assert check.resume.split("## Experience")[1] == MANGLED.split("## Experience")[1]
+    assert check.changes == [
+        Change(NAME, ALTERED),
+        Change(EMAIL, ALTERED),
+        Change(EMAIL, ADDED),
+        Change(PHONE, ALTERED),
+        Change(LINK, ALTERED),
+        Change(LINK, DROPPED),
+    ]
+    assert check.to_dict()["checked"] == 5
+
+    # An added Markdown link goes with its text, not leaving "[Blog]()" behind.
+    blog = BASELINE.replace("github.com/janedoe", "github.com/janedoe | [Blog](https://jd.dev/x)")
+    added = preserve_header(blog, BASELINE)
+    assert added.resume == BASELINE and added.changes == [Change(LINK, ADDED)]
+
+    kept = preserve_header(BASELINE, BASELINE)
+    assert kept.resume == BASELINE and not kept.restored
+
+
+def test_a_header_dropped_entirely_is_rebuilt():
+    check = preserve_header("## Summary\nPlatform engineer.\n", BASELINE)
+
+    assert check.resume == (
+        "# Jane Doe\n"
+        "jane.doe@example.com | +1 (415) 555-0100 | https://www.linkedin.com/in/janedoe"
+        " | github.com/janedoe\n"
+        "\n"
+        "## Summary\nPlatform engineer.\n"
+    )
+    assert {change.change for change in check.changes} == {DROPPED}
+    # No header in the baseline: nothing to keep.
+    assert preserve_header("# Other\n", "Migrated 40 services.").resume == "# Other\n"
+    # Two résumés pasted together: whose header it is is not known.
+    pasted = preserve_header("# Jane Doe\n", BASELINE + BASELINE)
+    assert pasted.resume == "# Jane Doe\n" and pasted.checked == 0
+
+
+def test_top_level_section_headings_are_sections_not_more_resumes():
+    def sectioned(text):
+        return text.replace("## Experience\n### Engineer", "# Experience\n## Engineer")
+
+    baseline, mangled = sectioned(BASELINE), sectioned(MANGLED)
+    assert parse_header(baseline).count() == 5
+
+    check = preserve_header(mangled, baseline)
+
+    assert check.checked == 5
+    assert check.resume.splitlines()[2] == (
+        "jane.doe@example.com | +1 (415) 555-0100 | github.com/janedoe"
+    )
+    assert check.resume.split("# Experience")[1] == mangled.split("# Experience")[1]
+
+
+def _workflow():
+    workflow = patched_workflow(auto_approve=True, pipeline_config=PipelineConfig())
+    workflow.gap_analyzer.execute.return_value = {"gaps": [], "confidence": 0.9}
+    workflow.interrogator_prepper.execute.return_value = {"questions": [], "confidence": 0.9}
+    workflow.differentiator.execute.return_value = {"differentiators": [], "confidence": 0.9}
+    workflow.tailoring_agent.execute.return_value = {
+        "tailored_resume": MANGLED,
+        "tailored_cover_letter": "",
+        "confidence": 0.9,
+    }
+    workflow.ats_optimizer.execute.return_value = {"optimized_resume": MANGLED}
+    workflow.auditor_suite.execute.return_value = {"approval": {"approved": True}}
+    workflow.executive_synthesizer.execute.return_value = {"confidence": 0.9}
+    return workflow
+
+
+def test_the_audit_sees_and_the_run_keeps_the_restored_header():
+    workflow = _workflow()
+    context = {"job_description": "Platform Engineer", "resume": BASELINE, "source_documents": ""}
+
+    result = workflow.execute(context)
+
+    restored = preserve_header(MANGLED, BASELINE).resume
+    assert result.final_documents["resume"] == restored
+    audited = workflow.auditor_suite.execute.call_args_list[0].args[0]
+    assert "+1 (415) 555-0100" in str(audited) and "555-0010" not in str(audited)
+    assert result.contact_header["checked"] == 5
+    assert len(result.contact_header["changes"]) == 6
+
+
+def test_run_json_counts_the_fields_and_the_cli_names_them(tmp_path, capsys):
+    report = preserve_header(MANGLED, BASELINE).to_dict()
+    result = SimpleNamespace(final_documents={"resume": "# Jane"}, contact_header=report)
+
+    run_dir = write_run_artifacts(tmp_path, result, run_id="run-1")
+
+    manifest = json.loads((run_dir / MANIFEST_FILE).read_text())
+    assert manifest["contact_header"] == {
+        "checked": 5,
+        "restored": {
+            "name altered": 1,
+            "email altered": 1,
+            "email added": 1,
+            "phone altered": 1,
+            "link altered": 1,
+            "link dropped": 1,
+        },
+    }
+    assert "555" not in json.dumps(manifest["contact_header"])
+
+    cli._report_contact_header(report)
+    out = capsys.readouterr().out
+    assert "📇 Restored the résumé's name and contacts from your baseline (name altered," in out
+    cli._report_contact_header({"checked": 5, "changes": []})
+    assert capsys.readouterr().out == ""
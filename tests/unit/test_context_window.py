@@ -0,0 +1,130 @@
+"""
+Unit tests for context-window budgeting and deterministic prompt compaction.
+"""
+
+from unittest.mock import MagicMock
+
+from runtime.crewai.context_window import (
+    CHARS_PER_TOKEN,
+    MIN_KEEP_CHARS,
+    TASK_OVERHEAD_TOKENS,
+    compact_context,
+    context_tokens,
+    estimate_tokens,
+    measure_usage,
+    prompt_budget,
+)
+from runtime.crewai.hydra_workflow import HydraWorkflow
+from runtime.crewai.model_config import DEFAULT_CONTEXT_WINDOW, get_context_window
+
+
+def test_estimate_tokens_is_ceil_of_chars_over_four():
+    assert estimate_tokens("") == 0
+    assert estimate_tokens("abcd") == 1
+    assert estimate_tokens("abcde") == 2
+
+
+def test_context_window_lookup_tolerates_prefixes_and_unknowns():
+    assert get_context_window("claude-sonnet-4-20250514") == 200_000
+    assert get_context_window("openrouter/anthropic/claude-sonnet-4-20250514") == 200_000
+    assert get_context_window("mystery-model") == DEFAULT_CONTEXT_WINDOW
+    assert get_context_window(None) == DEFAULT_CONTEXT_WINDOW
+
+
+def test_prompt_budget_reserves_output_and_overhead():
+    assert prompt_budget(10_000, overhead_tokens=1000, output_reserve=2000) == 6_500
+    assert prompt_budget(100, overhead_tokens=1000) == 0
+
+
+def test_context_that_fits_is_returned_untouched():
+    context = {"resume": "short", "gap_analysis": {"gaps": []}}
+
+    compacted, report = compact_context(context, budget_tokens=10_000)
+
+    assert compacted is context
+    assert report is None
+
+
+def test_noise_fields_are_stripped_before_truncating():
+    gap = {"agent": "Gap Analyzer", "timestamp": "x" * 400, "confidence": 0.9, "gaps": ["k8s"]}
+    context = {"resume": "r", "gap_analysis": gap}
+    budget = context_tokens(context) - 50
+
+    compacted, report = compact_context(context, budget)
+
+    assert compacted["gap_analysis"] == {"gaps": ["k8s"]}
+    assert report.stripped_keys == ["gap_analysis"]
+    assert report.truncated_keys == []
+    assert not report.over_budget
+    # Input is not mutated.
+    assert "timestamp" in context["gap_analysis"]
+
+
+def test_oldest_outputs_are_truncated_first_and_protected_keys_kept():
+    resume = "R" * 4000
+    context = {
+        "resume": resume,
+        "gap_analysis": "G" * 8000,
+        "differentiation": "D" * 8000,
+    }
+    budget = (4000 + 8000 + 4000) // CHARS_PER_TOKEN
+
+    compacted, report = compact_context(context, budget)
+
+    assert compacted["resume"] == resume
+    assert report.truncated_keys == ["gap_analysis"]
+    assert compacted["differentiation"] == "D" * 8000
+    assert "truncated" in compacted["gap_analysis"]
+    assert not report.over_budget
+
+
+def test_over_budget_is_reported_when_protected_inputs_alone_do_not_fit():
+    context = {"resume": "R" * 40_000, "gap_analysis": "G" * 4000}
+
+    compacted, report = compact_context(context, budget_tokens=1000)
+
+    assert compacted["resume"] == "R" * 40_000
+    assert compacted["gap_analysis"].startswith("G" * MIN_KEEP_CHARS)
+    assert report.over_budget
+
+
+def test_workflow_compacts_context_for_small_model_windows(monkeypatch):
+    workflow = HydraWorkflow(MagicMock(), use_per_agent_models=False)
+    agent = MagicMock()
+    agent.llm.model = "tiny-model"
+    agent._build_backstory.return_value = "system prompt"
+    monkeypatch.setattr("runtime.crewai.hydra_workflow.get_context_window", lambda model: 6_000)
+    context = {"resume": "R" * 2000, "gap_analysis": "G" * 20_000}
+
+    fitted = workflow._fit_context(agent, context, "differentiation")
+
+    assert fitted["resume"] == context["resume"]
+    assert len(fitted["gap_analysis"]) < len(context["gap_analysis"])
+    assert any("Compacted context for differentiation" in line for line in workflow.execution_log)
+
+
+def test_measure_usage_breaks_down_sections():
+    usage = measure_usage({"resume": "R" * 400, "gap_analysis": "G" * 800}, 50, 1_000, "m")
+
+    assert usage.sections["system_prompt"] == 50
+    assert usage.sections["resume"] == 100
+    assert usage.sections["gap_analysis"] == 200
+    assert usage.used_tokens == 50 + TASK_OVERHEAD_TOKENS + 300
+    assert usage.to_dict()["fraction"] == round(usage.used_tokens / 1_000, 4)
+
+
+def test_workflow_records_peak_usage_per_stage(monkeypatch):
+    workflow = HydraWorkflow(MagicMock(), use_per_agent_models=False)
+    agent = MagicMock()
+    agent.llm.model = "tiny-model"
+    agent._build_backstory.return_value = "system prompt"
+    monkeypatch.setattr("runtime.crewai.hydra_workflow.get_context_window", lambda model: 6_000)
+
+    workflow._fit_context(agent, {"document": "D" * 4000}, "auditor_suite")
+    workflow._fit_context(agent, {"document": "D" * 400}, "auditor_suite")
+
+    usage = workflow.context_usage["auditor_suite"]
+    assert usage["model"] == "tiny-model"
+    assert usage["sections"]["document"] == 1000
+    assert usage["context_window"] == 6_000
+    assert not usage["compacted"]
@@ -11,6 +11,7 @@ from runtime.crewai.contracts import (
     AuditVerdict,
     ExecutiveDecision,
     GapAnalysis,
+    GapReview,
     TailoredDocuments,
     coerce_bool,
     coerce_text,
@@ -103,6 +104,38 @@ class TestGapAnalysis:
         assert skills == {"a", "b", "c"}  # direct_match excluded
 
 
+
+class TestGapReview:
+    def test_classified_requirements_nested_with_a_summary_score(self):
+        review = GapReview.from_raw(
+            {
+                "gap_analysis": {
+                    "summary": {"fit_score": "72"},
+                    "requirements": [
+                        {"requirement": "AWS", "classification": "direct_match"},
+                        {"text": "GCP", "classification": "adjacent_experience"},
+                        {"requirement": "K8s", "classification": "blocker"},
+                        {"requirement": "Go"},
+                    ],
+                }
+            }
+        )
+        assert review == GapReview(fit_score=72, matches=["AWS"], adjacent=["GCP"], gaps=["K8s"])
+
+    def test_flat_lists_win_over_requirements(self):
+        review = GapReview.from_raw(
+            {
+                "fit_score": 60,
+                "gaps": [{"skill": "Go"}, "Rust"],
+                "requirements": [{"requirement": "AWS", "classification": "direct_match"}],
+            }
+        )
+        assert review == GapReview(fit_score=60, gaps=["Go", "Rust"])
+
+    def test_garbage_is_empty(self):
+        assert GapReview.from_raw("nope") == GapReview()
+        assert GapReview.from_raw({"fit_score": "high"}).fit_score is None
+
 class TestRecommendation:
     @pytest.mark.parametrize(
         "score,expected",
@@ -0,0 +1,155 @@
+"""
+Unit tests for near-duplicate detection between concurrent applications' cover letters.
+"""
+
+import json
+import os
+from types import SimpleNamespace
+from unittest.mock import Mock, patch
+
+import pytest
+
+from runtime.crewai.artifacts import write_run_artifacts
+from runtime.crewai.cover_letter_overlap import (
+    find_overlaps,
+    paragraphs,
+    recent_cover_letters,
+)
+from runtime.crewai.hydra_workflow import MAX_DIFFERENTIATION_REWRITES, HydraWorkflow
+
+TEMPLATED = (
+    "Over the last eight years I have built and scaled distributed systems that serve "
+    "millions of users, and I would bring that same focus on reliability to your team."
+)
+FRESH = (
+    "Acme's move into warehouse robotics is exactly where my work on real-time fleet "
+    "telemetry applies: I cut incident response time for 300 robots at my last role."
+)
+
+
+def _letter(*body):
+    return "\n\n".join(["Dear Hiring Manager,", *body, "Best regards,\nJane"])
+
+
+def test_short_paragraphs_and_headings_are_not_compared():
+    letter = "# Cover letter\n\n" + _letter(TEMPLATED)
+
+    assert paragraphs(letter) == [TEMPLATED]
+
+
+def test_near_identical_paragraph_is_flagged_at_its_closest_match():
+    reworded = TEMPLATED.replace("eight", "seven").replace("your team", "Beta")
+    others = {"run-a": _letter(FRESH), "run-b": _letter(FRESH, reworded)}
+
+    overlaps = find_overlaps(_letter(TEMPLATED, FRESH), others)
+
+    assert [(o.paragraph, o.other_run, o.other_paragraph) for o in overlaps] == [
+        (1, "run-b", 2),
+        (2, "run-a", 1),
+    ]
+    assert overlaps[0].similarity >= 0.8 and overlaps[1].similarity == 1.0
+    assert find_overlaps(_letter(FRESH), {"run-a": _letter(TEMPLATED)}) == []
+
+
+def test_recent_letters_skip_old_runs_and_the_same_job_description(tmp_path):
+    for run, jd in (("new", "jd/beta.md"), ("same-jd", "jd/acme.md"), ("old", "jd/gamma.md")):
+        run_dir = tmp_path / run
+        run_dir.mkdir()
+        (run_dir / "cover_letter.md").write_text(f"letter {run}")
+        (run_dir / "run.json").write_text(json.dumps({"inputs": {"jd_path": jd}}))
+    old = tmp_path / "old" / "cover_letter.md"
+    os.utime(old, (old.stat().st_atime, old.stat().st_mtime - 40 * 86400))
+
+    letters = recent_cover_letters(tmp_path, exclude_jd_path="jd/acme.md", days=30)
+
+    assert letters == {"new": "letter new"}
+    assert recent_cover_letters(tmp_path, days=0) == {}
+
+
+@pytest.fixture
+def make_workflow():
+    def _make(others):
+        with (
+            patch("runtime.crewai.hydra_workflow.GapAnalyzerAgent"),
+            patch("runtime.crewai.hydra_workflow.InterrogatorPrepperAgent"),
+            patch("runtime.crewai.hydra_workflow.DifferentiatorAgent"),
+            patch("runtime.crewai.hydra_workflow.TailoringAgent"),
+            patch("runtime.crewai.hydra_workflow.ATSOptimizerAgent"),
+            patch("runtime.crewai.hydra_workflow.AuditorSuiteAgent"),
+            patch("runtime.crewai.hydra_workflow.ExecutiveSynthesizerAgent"),
+        ):
+            return HydraWorkflow(
+                Mock(), use_per_agent_models=False, other_cover_letters=others
+            )
+
+    return _make
+
+
+def _tailor(workflow, *outputs):
+    workflow.tailoring_agent.execute.side_effect = [
+        {"tailored_resume": "R", "cover_letter": letter} for letter in outputs
+    ]
+    return workflow._execute_tailoring({"job_description": "JD", "resume": "R"}, {}, {}, {})
+
+
+def test_templated_letter_is_sent_back_with_the_repeated_paragraphs(make_workflow):
+    workflow = make_workflow({"run-a": _letter(TEMPLATED)})
+
+    result = _tailor(workflow, _letter(TEMPLATED, FRESH), _letter(FRESH))
+
+    rewrite_context = workflow.tailoring_agent.execute.call_args[0][0]
+    assert rewrite_context["templated_paragraphs"] == [TEMPLATED]
+    assert result["cover_letter"] == _letter(FRESH)
+    assert workflow.cover_letter_overlap == {
+        "compared_letters": 1,
+        "initial_overlaps": 1,
+        "rewrites": 1,
+        "overlaps": [],
+    }
+
+
+def test_rewrites_are_bounded_and_worse_ones_are_discarded(make_workflow):
+    workflow = make_workflow({"run-a": _letter(TEMPLATED, FRESH)})
+    stubborn = [_letter(TEMPLATED, FRESH)] * MAX_DIFFERENTIATION_REWRITES
+
+    result = _tailor(workflow, _letter(TEMPLATED), *stubborn)
+
+    assert workflow.tailoring_agent.execute.call_count == MAX_DIFFERENTIATION_REWRITES + 1
+    assert result["cover_letter"] == _letter(TEMPLATED)
+    report = workflow.cover_letter_overlap
+    assert report["rewrites"] == MAX_DIFFERENTIATION_REWRITES
+    assert [o["other_run"] for o in report["overlaps"]] == ["run-a"]
+
+
+def test_no_other_letters_means_no_check(make_workflow):
+    workflow = make_workflow(None)
+
+    _tailor(workflow, _letter(TEMPLATED))
+
+    assert workflow.tailoring_agent.execute.call_count == 1
+    assert workflow.cover_letter_overlap is None
+
+
+def test_manifest_records_counts_not_paragraphs(tmp_path):
+    result = SimpleNamespace(
+        status=None,
+        final_documents={"cover_letter": _letter(TEMPLATED)},
+        audit_report=None,
+        execution_log=[],
+        cover_letter_overlap={
+            "compared_letters": 3,
+            "initial_overlaps": 2,
+            "rewrites": 1,
+            "overlaps": [{"paragraph": 1, "other_run": "run-a", "similarity": 0.9}],
+        },
+    )
+
+    run_dir = write_run_artifacts(tmp_path, result, run_id="run-1")
+
+    manifest = json.loads((run_dir / "run.json").read_text())
+    assert manifest["cover_letter_overlap"] == {
+        "compared_letters": 3,
+        "initial_overlaps": 2,
+        "rewrites": 1,
+        "remaining_overlaps": 1,
+    }
@@ -0,0 +1,191 @@
+"""
+Unit tests for regional CV formats: personal data, Europass XML and rendering.
+"""
+
+import json
+import xml.etree.ElementTree as ET
+from datetime import datetime
+from types import SimpleNamespace
+
+import pytest
+
+from runtime.crewai.artifacts import MANIFEST_FILE, RunInputs, write_run_artifacts
+from runtime.crewai.cli import main
+from runtime.crewai.cv_formats import (
+    CV_FORMATS,
+    EUROPASS_NAMESPACE,
+    EUROPASS_XML_FILE,
+    PERSONAL_FILE,
+    PersonalData,
+    PersonalDataError,
+    europass_xml,
+    load_personal,
+    personal_section,
+    with_personal_section,
+)
+from runtime.crewai.locale_policy import get_policy
+from runtime.crewai.localization import get_language
+from runtime.crewai.resume_themes import LATEX_FILE, THEMED_MARKDOWN_FILE
+from runtime.crewai.run_control import resume_arguments
+
+pytestmark = pytest.mark.usefixtures("hydra_home")
+
+RESUME = """# Jana Maria Novak
+
+**Platform Engineer**
+
+Berlin | jana@example.com | +49 30 1234567 | [GitHub](https://github.com/jana)
+
+## Experience
+
+### Acme — Staff Engineer
+*Mar 2021 – Present | Remote*
+
+- Led the Terraform migration
+
+### Site Reliability Engineer, Globex (2017–2021)
+
+- Ran 40 Kubernetes clusters
+
+## Education
+
+### TU Berlin — M.Sc. Computer Science
+*2015 – 2017*
+
+## Skills
+
+**Cloud:** AWS, GCP
+
+## Projects
+
+### kubectl-cost
+- Open-source cost reports
+"""
+PERSONAL = {
+    "date_of_birth": "1990-05-12",
+    "nationality": "German",
+    "marital_status": "married",
+}
+E = f"{{{EUROPASS_NAMESPACE}}}"
+
+
+def test_personal_data_is_read_and_shown_as_the_format_and_country_allow(tmp_path):
+    assert load_personal() is None
+    home = tmp_path / "home"
+    home.mkdir()
+    (home / "portrait.jpg").write_bytes(b"\xff\xd8jpeg")
+    (home / PERSONAL_FILE).write_text("photo: portrait.jpg\ndate_of_birth: 1990-05-12\n")
+    personal = load_personal()
+    assert personal.photo == home / "portrait.jpg"
+    assert personal.values == {"date_of_birth": "1990-05-12"}
+    with pytest.raises(PersonalDataError, match="unknown field"):
+        PersonalData.from_dict({"religion": "none"})
+
+    personal = PersonalData.from_dict({**PERSONAL, "photo": str(home / "portrait.jpg")})
+    section = personal_section(
+        CV_FORMATS["lebenslauf"], personal, RESUME, get_policy("DE"), get_language("de")
+    )
+    assert section.markdown == (
+        "## Persönliche Daten\n\n"
+        "Geburtsdatum: 12.05.1990\nStaatsangehörigkeit: German\nFamilienstand: married\n"
+    )
+    assert section.photo == home / "portrait.jpg" and section.warnings == []
+    themed = with_personal_section(RESUME, section.markdown)
+    assert themed.index("## Persönliche Daten") < themed.index("## Experience")
+
+    # A US-bound Lebenslauf keeps nationality only; a field on the résumé is not repeated.
+    section = personal_section(CV_FORMATS["lebenslauf"], personal, RESUME, get_policy("US"))
+    assert section.fields == ["nationality"] and section.photo is None
+    assert section.warnings == [
+        "date of birth left out: not expected on applications in US",
+        "marital status left out: not expected on applications in US",
+        "photo left out: not expected on applications in US",
+    ]
+    on_resume = RESUME.replace("## Experience", "Nationality: German\n\n## Experience")
+    section = personal_section(CV_FORMATS["europass"], personal, on_resume)
+    assert section.fields == ["date_of_birth"]
+    assert "Date of birth: 12 May 1990" in section.markdown
+    assert personal_section(CV_FORMATS["us"], personal, RESUME).markdown == ""
+
+
+def test_europass_xml_is_read_from_the_resume(tmp_path):
+    photo = tmp_path / "me.jpg"
+    photo.write_bytes(b"jpeg")
+    personal = PersonalData.from_dict(PERSONAL)
+
+    xml = europass_xml(
+        RESUME,
+        personal,
+        ("date_of_birth", "nationality"),
+        photo,
+        get_language("de"),
+        now=datetime(2026, 10, 17, 9, 30),
+    )
+
+    root = ET.fromstring(xml)
+    assert root.tag == f"{E}SkillsPassport" and root.get("locale") == "de"
+    assert root.findtext(f"{E}DocumentInfo/{E}CreationDate") == "2026-10-17T09:30:00"
+    learner = root.find(f"{E}LearnerInfo")
+    who = learner.find(f"{E}Identification")
+    assert who.findtext(f"{E}PersonName/{E}FirstName") == "Jana Maria"
+    assert who.findtext(f"{E}PersonName/{E}Surname") == "Novak"
+    assert who.findtext(f"{E}ContactInfo/{E}Email/{E}Contact") == "jana@example.com"
+    assert who.findtext(f".//{E}Telephone/{E}Contact") == "+49 30 1234567"
+    assert who.findtext(f".//{E}Website/{E}Contact") == "https://github.com/jana"
+    assert who.find(f"{E}Demographics/{E}Birthdate").attrib == {
+        "year": "1990",
+        "month": "--05",
+        "day": "---12",
+    }
+    assert who.findtext(f".//{E}Nationality/{E}Label") == "German"
+    assert who.findtext(f"{E}Photo/{E}Data") == "anBlZw=="
+
+    acme, globex = learner.findall(f"{E}WorkExperienceList/{E}WorkExperience")
+    assert acme.findtext(f"{E}Position/{E}Label") == "Staff Engineer"
+    assert acme.findtext(f"{E}Employer/{E}Name") == "Acme"
+    assert acme.find(f"{E}Period/{E}From").attrib == {"year": "2021", "month": "--03"}
+    assert acme.findtext(f"{E}Period/{E}Current") == "true"
+    assert acme.findtext(f"{E}Activities") == "- Led the Terraform migration"
+    assert globex.findtext(f"{E}Position/{E}Label") == "Site Reliability Engineer"
+    assert globex.find(f"{E}Period/{E}To").attrib == {"year": "2021"}
+    studies = learner.find(f"{E}EducationList/{E}Education")
+    assert studies.findtext(f"{E}Title") == "M.Sc. Computer Science"
+    assert studies.findtext(f"{E}Organisation/{E}Name") == "TU Berlin"
+    assert learner.findtext(f"{E}Skills/{E}Other/{E}Description") == "Cloud: AWS, GCP"
+    achievement = learner.find(f"{E}AchievementList/{E}Achievement")
+    assert achievement.findtext(f"{E}Title/{E}Label") == "Projects"
+    assert achievement.findtext(f"{E}Description") == "kubectl-cost\n- Open-source cost reports"
+
+
+def test_render_writes_the_format_and_the_manifest_names_fields_only(
+    tmp_path, monkeypatch, capsys
+):
+    monkeypatch.setattr("shutil.which", lambda name: None)
+    (tmp_path / "portrait.png").write_bytes(b"png")
+    personal = tmp_path / "personal.yaml"
+    personal.write_text("photo: portrait.png\ndate_of_birth: 1990-05-12\nnationality: German\n")
+    result = SimpleNamespace(final_documents={"resume": RESUME}, status=None)
+    inputs = RunInputs(jd_path="jd.md", resume_path="r.md", cv_format="europass")
+    run_dir = write_run_artifacts(tmp_path, result, run_id="run-1", inputs=inputs)
+
+    assert main(["render", str(run_dir), "--personal", str(personal)]) == 0
+
+    out = capsys.readouterr().out
+    assert "🪪 europass CV (europass theme)" in out
+    assert "Personal data shown: date_of_birth, nationality, photo" in out
+    manifest = json.loads((run_dir / MANIFEST_FILE).read_text())
+    assert manifest["cv_format"] == {
+        "name": "europass",
+        "personal": ["date_of_birth", "nationality"],
+        "photo": True,
+    }
+    assert {EUROPASS_XML_FILE, "photo.png"} <= set(manifest["artifacts"])
+    assert "1990" not in json.dumps(manifest)
+    assert r"\includegraphics[width=\linewidth]{photo.png}" in (run_dir / LATEX_FILE).read_text()
+    themed = (run_dir / THEMED_MARKDOWN_FILE).read_text()
+    assert themed.startswith("![Photo](photo.png)") and "Date of birth: 12 May 1990" in themed
+
+    manifest["status"] = "paused"
+    (run_dir / MANIFEST_FILE).write_text(json.dumps(manifest))
+    args = resume_arguments(run_dir)
+    assert args[args.index("--cv-format") + 1] == "europass"
@@ -0,0 +1,89 @@
+"""Unit tests for the live run dashboard (--tui)."""
+
+import io
+from unittest.mock import patch
+
+from rich.console import Console
+
+from runtime.crewai.dashboard import Dashboard, DashboardInteraction
+from runtime.crewai.hydra_workflow import UserInteraction, WorkflowState
+from runtime.crewai.prompt_cache import CallUsage, UsageLedger
+
+
+class _Workflow:
+    def __init__(self):
+        self.state = WorkflowState.INITIALIZED
+        self.usage_ledger = UsageLedger()
+        self.log = []
+        self.search_provider = None
+        self.compensation = False
+        self.user_interaction = UserInteraction()
+
+    def get_current_state(self):
+        return self.state
+
+    def get_execution_log(self):
+        return list(self.log)
+
+
+def _console():
+    return Console(file=io.StringIO(), width=120, force_terminal=False)
+
+
+def test_stages_tokens_and_log_are_tracked_as_the_run_moves():
+    workflow = _Workflow()
+    dashboard = Dashboard(workflow, "acme-20260117", console=_console())
+    assert "research" not in dashboard.stages and "compensation" not in dashboard.stages
+
+    workflow.state = WorkflowState.GAP_ANALYSIS
+    workflow.usage_ledger.record(CallUsage("Gap Analyzer", "unpriced/model", 1000, 200))
+    dashboard.observe()
+    workflow.state = WorkflowState.GAP_ANALYSIS_REVIEW  # the gate counts as its stage
+    dashboard.observe()
+    assert dashboard.stages["gap_analysis"].status == "running"
+
+    workflow.state = WorkflowState.TAILORING
+    workflow.usage_ledger.record(CallUsage("Tailoring", "unpriced/model", 3000, 900))
+    workflow.log.append("[2026-01-17T10:15:00] Executing Tailoring")
+    dashboard.observe()
+
+    gap, tailoring = dashboard.stages["gap_analysis"], dashboard.stages["tailoring"]
+    assert gap.status == "done" and (gap.calls, gap.prompt_tokens) == (1, 1000)
+    assert tailoring.status == "running" and tailoring.completion_tokens == 900
+    assert dashboard.stages["auditing"].status == "pending"
+
+    console = _console()
+    console.print(dashboard.render())
+    screen = console.file.getvalue()
+    assert "Hydra run acme-20260117" in screen
+    assert "2 model calls · 4000 in / 1100 out tokens" in screen
+    assert "Executing Tailoring" in screen and "2026-01-17T10:15:00" not in screen
+
+
+def test_the_greenlight_shows_the_gap_analysis_with_the_display_paused():
+    workflow = _Workflow()
+    console = _console()
+    with Dashboard(workflow, console=console) as dashboard:
+        assert isinstance(workflow.user_interaction, DashboardInteraction)
+        gap_result = {
+            "fit_score": 64,
+            "requirements": [
+                {"requirement": "Terraform", "classification": "direct_match"},
+                {"requirement": "Kubernetes", "classification": "gap"},
+            ],
+        }
+        with patch("builtins.input", return_value="y") as answer:
+            assert workflow.user_interaction.greenlight_gap_analysis(gap_result)
+        assert answer.call_count == 1 and dashboard.waiting_for is None
+
+    assert isinstance(workflow.user_interaction, UserInteraction)
+    screen = console.file.getvalue()
+    assert "Gap analysis · fit score 64" in screen
+    assert "Terraform" in screen and "Gaps (1)" in screen
+
+
+def test_without_the_dashboard_the_greenlight_still_lists_the_gaps(capsys):
+    with patch("builtins.input", return_value="n"):
+        assert not UserInteraction.greenlight_gap_analysis({"fit_score": 40, "gaps": ["Go"]})
+    printed = capsys.readouterr().out
+    assert "Fit score: 40" in printed and "Gaps: Go" in printed
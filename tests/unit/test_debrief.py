@@ -0,0 +1,115 @@
+"""
+Unit tests for interview debrief capture and reuse.
+"""
+
+import io
+import json
+from datetime import date
+
+from runtime.crewai.artifacts import MANIFEST_FILE
+from runtime.crewai.cli import main
+from runtime.crewai.debrief import (
+    DEBRIEF_FILE,
+    Debrief,
+    capture_debrief,
+    company_debriefs,
+    load_debriefs,
+    render_debriefs,
+    save_debrief,
+)
+
+
+def _answers(*lines):
+    replies = iter(lines)
+    return lambda prompt: next(replies)
+
+
+def _run(tmp_path, run_id, company=None):
+    run_dir = tmp_path / run_id
+    run_dir.mkdir()
+    manifest = {"run_id": run_id, "inputs": {"company": company}, "artifacts": []}
+    (run_dir / MANIFEST_FILE).write_text(json.dumps(manifest))
+    return run_dir
+
+
+def test_guided_questions_build_a_debrief():
+    out = io.StringIO()
+    debrief = capture_debrief(
+        "Acme",
+        _answers(
+            "",  # keep the recorded company
+            "technical",
+            "2026-10-2",  # not ISO: asked again
+            "2026-10-02",
+            "Design a rate limiter",
+            "Tell me about an outage",
+            "",
+            "7",  # out of range: asked again
+            "4",
+            "Clear system design",
+            "Numbers for the outage story",
+            "Onsite next week",
+            "2026-10-09",
+        ),
+        out=out,
+    )
+
+    assert debrief.company == "Acme" and debrief.round == "technical"
+    assert debrief.interview_date == "2026-10-02"
+    assert debrief.questions_asked == ["Design a rate limiter", "Tell me about an outage"]
+    assert debrief.self_rating == 4 and debrief.follow_up_by == "2026-10-09"
+    assert "YYYY-MM-DD" in out.getvalue() and "1-5" in out.getvalue()
+
+
+def test_skipped_answers_get_defaults():
+    debrief = capture_debrief(
+        None, _answers("", "Acme", "", "", "", "", "", "", "", ""), today=date(2026, 10, 1)
+    )
+
+    assert debrief.company == "Acme" and debrief.round == "interview"
+    assert debrief.interview_date == "2026-10-01"
+    assert debrief.self_rating is None and debrief.follow_up_by is None
+
+
+def test_debriefs_accumulate_and_the_manifest_keeps_counts_only(tmp_path):
+    run_dir = _run(tmp_path, "20261001-090000-aaaaaaaa", company="Acme")
+    save_debrief(run_dir, Debrief("Acme", "screen", "2026-10-02", ["Why Acme?"]))
+    save_debrief(run_dir, Debrief("Acme", "onsite", "2026-10-09", went_well="Design"))
+
+    assert [d.round for d in load_debriefs(run_dir)] == ["screen", "onsite"]
+    manifest = json.loads((run_dir / MANIFEST_FILE).read_text())
+    assert manifest["debriefs"] == {
+        "count": 2,
+        "rounds": ["screen", "onsite"],
+        "last_interview": "2026-10-09",
+    }
+    assert manifest["artifacts"] == [DEBRIEF_FILE]
+    assert "Why Acme" not in json.dumps(manifest)
+
+
+def test_company_debriefs_span_runs_and_ignore_other_companies(tmp_path):
+    save_debrief(_run(tmp_path, "run-b"), Debrief("ACME ", "onsite", "2026-10-09"))
+    save_debrief(_run(tmp_path, "run-a"), Debrief("Acme", "screen", "2026-10-02"))
+    save_debrief(_run(tmp_path, "run-c"), Debrief("Globex", "screen", "2026-10-03"))
+    (tmp_path / "run-d").mkdir()
+    (tmp_path / "run-d" / DEBRIEF_FILE).write_text("not json")
+
+    debriefs = company_debriefs(tmp_path, "acme")
+
+    assert [d.round for d in debriefs] == ["screen", "onsite"]
+    assert company_debriefs(tmp_path / "missing", "acme") == []
+    text = render_debriefs([d.to_dict() for d in debriefs])
+    assert text.index("2026-10-02, screen") < text.index("2026-10-09, onsite")
+
+
+def test_debrief_subcommand_saves_to_the_run(tmp_path, monkeypatch, capsys):
+    run_dir = _run(tmp_path, "20261001-090000-aaaaaaaa", company="Acme")
+    replies = iter(["", "screen", "2026-10-02", "", "", "", "", "", "2026-10-06"])
+    monkeypatch.setattr("builtins.input", lambda prompt: next(replies))
+
+    code = main(["debrief", "20261001-090000-aaaaaaaa", "--out", str(tmp_path)])
+
+    assert code == 0
+    saved = load_debriefs(run_dir)[0]
+    assert saved.company == "Acme" and saved.run_id == "20261001-090000-aaaaaaaa"
+    assert "Follow up with Acme by 2026-10-06" in capsys.readouterr().out
@@ -0,0 +1,98 @@
+"""
+Unit tests for deterministic runs: temperature 0, a fixed seed, and what the run records.
+"""
+
+import io
+import json
+from types import SimpleNamespace
+from unittest.mock import patch
+
+import pytest
+
+from runtime.crewai import cli
+from runtime.crewai.base_agent import BaseHydraAgent
+from runtime.crewai.determinism import accepts_seed, model_parameters, pin
+from runtime.crewai.gateway import GatewayConfig, GatewayLLM
+from runtime.crewai.run_control import MANIFEST_FILE, resume_arguments
+
+
+class _Reply(io.BytesIO):
+    def __enter__(self):
+        return self
+
+    def __exit__(self, *exc):
+        return False
+
+
+class _Agent(BaseHydraAgent):
+    role = "Tailoring Agent"
+    goal = "Write"
+    expected_output = "JSON"
+
+    def execute(self, context):  # pragma: no cover - not used in these tests
+        raise NotImplementedError
+
+
+def test_pinning_sets_temperature_zero_and_a_seed_where_the_provider_takes_one():
+    claude = SimpleNamespace(model="anthropic/claude-sonnet-4-20250514", temperature=0.7)
+    llama = SimpleNamespace(model="together_ai/meta-llama/Llama-3.3-70B", temperature=0.5)
+
+    pin(claude, 7)
+    pin(llama, 7)
+
+    assert not accepts_seed(claude) and accepts_seed(llama)
+    assert model_parameters(claude) == {"temperature": 0.0}
+    assert model_parameters(llama) == {"temperature": 0.0, "seed": 7}
+    assert model_parameters(SimpleNamespace(model="m", temperature=None, top_p=True)) == {}
+
+
+def test_a_seeded_agent_calls_at_temperature_zero_with_its_seed(monkeypatch):
+    from crewai import LLM
+
+    monkeypatch.setenv("HYDRA_DIRECT_LLM", "1")
+    agent = _Agent(LLM(model="openai/gpt-4o-mini", api_key="k", temperature=0.6))
+    agent.seed = 11
+    captured = {}
+
+    def fake_completion(**kwargs):
+        captured.update(kwargs)
+        payload = json.dumps({"agent": agent.role, "result": "ok"})
+        return {"choices": [{"message": {"content": payload}}]}
+
+    with patch("litellm.completion", side_effect=fake_completion):
+        agent.execute_with_retry(agent.create_task("Tailor."), max_retries=0)
+
+    assert captured["temperature"] == 0.0 and captured["seed"] == 11
+
+    # A gateway sends the seed in its request body too.
+    monkeypatch.setenv("TOKEN", "t")
+    config = GatewayConfig.from_dict({"url": "https://proxy/chat", "auth": {"token_env": "TOKEN"}})
+    gateway = GatewayLLM(config, "gpt-4o", temperature=0.6)
+    pin(gateway, 11)
+    reply = _Reply(json.dumps({"choices": [{"message": {"content": "ok"}}]}).encode())
+    with patch("runtime.crewai.gateway.urllib.request.urlopen", return_value=reply) as urlopen:
+        gateway.complete([])
+    body = json.loads(urlopen.call_args.args[0].data)
+    assert body["seed"] == 11 and body["temperature"] == 0.0
+
+
+def test_the_seed_is_recorded_and_carried_over_on_resume(tmp_path, monkeypatch, capsys):
+    monkeypatch.setenv("HYDRA_HOME", str(tmp_path / "home"))
+    run_dir = tmp_path / "run-1"
+    run_dir.mkdir()
+    inputs = {"jd_path": "jd.md", "resume_path": "r.md", "sources_path": "src", "seed": 7}
+    manifest = {"run_id": "run-1", "status": "interrupted", "inputs": inputs}
+    (run_dir / MANIFEST_FILE).write_text(json.dumps(manifest))
+
+    arguments = resume_arguments(run_dir)
+
+    assert arguments[arguments.index("--deterministic") :][:3] == ["--deterministic", "--seed", "7"]
+    for name in ("jd.md", "r.md"):
+        (tmp_path / name).write_text("text")
+    (tmp_path / "src").mkdir()
+    (tmp_path / "src" / "notes.md").write_text("text")
+    flags = ["--jd", "jd.md", "--resume", "r.md", "--sources", "src"]
+    flags = [str(tmp_path / flag) if i % 2 else flag for i, flag in enumerate(flags)]
+    with pytest.raises(SystemExit):
+        cli.main([*flags, "--no-plugins", "--seed", "7"])
+    assert "--seed requires --deterministic" in capsys.readouterr().err
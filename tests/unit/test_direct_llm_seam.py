@@ -7,6 +7,7 @@ NOT asserted here — that needs a live-model capture (scripts/capture_crewai_tr
 """
 
 import json
+from types import SimpleNamespace
 from unittest.mock import patch
 
 import pytest
@@ -88,3 +89,41 @@ def test_build_messages_shape(agent):
     messages = agent._build_messages(task)
     assert [m["role"] for m in messages] == ["system", "user"]
     assert messages[0]["content"].startswith("You are Gap Analyzer.")
+
+
+def test_direct_path_streams_output_to_a_listener(agent, monkeypatch):
+    monkeypatch.setenv("HYDRA_DIRECT_LLM", "1")
+    content = _canned_response(agent.role)["choices"][0]["message"]["content"]
+    pieces = [content[:10], content[10:], None]
+    chunks = [
+        SimpleNamespace(choices=[SimpleNamespace(delta=SimpleNamespace(content=piece))])
+        for piece in pieces
+    ] + [SimpleNamespace(choices=[])]  # the usage chunk
+    captured, seen = {}, []
+
+    def fake_completion(**kwargs):
+        captured.update(kwargs)
+        return iter(chunks)
+
+    agent.on_output = seen.append
+    with (
+        patch("litellm.completion", side_effect=fake_completion),
+        patch("litellm.stream_chunk_builder", return_value=_canned_response(agent.role)),
+    ):
+        out = agent.execute_with_retry(agent.create_task("desc"), max_retries=0)
+
+    assert out["result"] == "ok"
+    assert captured["stream"] is True and seen == pieces[:2]
+
+
+def test_crew_path_hands_the_whole_answer_to_a_listener(agent, monkeypatch):
+    monkeypatch.delenv("HYDRA_DIRECT_LLM", raising=False)
+    content = _canned_response(agent.role)["choices"][0]["message"]["content"]
+    seen = []
+    agent.on_output = seen.append
+
+    with patch("runtime.crewai.base_agent.Crew") as mock_crew:
+        mock_crew.return_value.kickoff.return_value = content
+        agent.execute_with_retry(agent.create_task("desc"), max_retries=0)
+
+    assert seen == [content]
@@ -0,0 +1,148 @@
+"""
+Unit tests for --dry-run: prompts are rendered and recorded, no model is called.
+"""
+
+import json
+
+import pytest
+
+from runtime.crewai.dry_run import (
+    ASSUMED_OUTPUT_TOKENS,
+    DryRunRecorder,
+    estimate_tokens,
+    write_dry_run_artifacts,
+)
+from runtime.crewai.hydra_workflow import HydraWorkflow, RunStatus
+from runtime.crewai.model_config import PROVIDER_ENV_KEYS, estimate_cost
+
+CONTEXT = {
+    "job_description": "Staff Platform Engineer. Kubernetes, Go, on-call leadership.",
+    "resume": "Jane Doe. Led the Kubernetes migration at Acme.",
+    "source_documents": "# notes.md\nMigrated 40 services to Kubernetes.",
+}
+
+
+@pytest.fixture
+def no_provider_keys(monkeypatch):
+    """A dry run must work with no API keys at all."""
+    for env_var in PROVIDER_ENV_KEYS.values():
+        monkeypatch.delenv(env_var, raising=False)
+
+
+def test_estimate_tokens_is_ceil_of_chars_over_four():
+    assert estimate_tokens("") == 0
+    assert estimate_tokens("abcd") == 1
+    assert estimate_tokens("abcde") == 2
+
+
+def test_estimate_cost_handles_provider_prefixes_and_unknown_models():
+    direct = estimate_cost("gpt-4o-mini", 1_000_000, 0)
+    prefixed = estimate_cost("openai/gpt-4o-mini", 1_000_000, 0)
+    assert direct == prefixed == pytest.approx(0.15)
+    assert estimate_cost("some/unknown-model", 1000, 1000) is None
+
+
+def test_recorder_records_prompt_and_returns_placeholder():
+    class FakeAgent:
+        role = "Gap Analyzer"
+        dry_run_recorder = None
+
+    recorder = DryRunRecorder()
+    agent = FakeAgent()
+    recorder.register(agent, "gap_analysis", "gpt-4o-mini")
+    assert agent.dry_run_recorder is recorder
+
+    output = recorder.record(
+        "Gap Analyzer",
+        [{"role": "system", "content": "sys" * 10}, {"role": "user", "content": "user"}],
+    )
+
+    assert output["dry_run"] is True
+    assert output["agent"] == "Gap Analyzer"
+    [record] = recorder.records
+    assert record.stage == "gap_analysis"
+    assert record.model == "gpt-4o-mini"
+    assert record.input_tokens == (
+        estimate_tokens("sys" * 10, "gpt-4o-mini") + estimate_tokens("user", "gpt-4o-mini")
+    )
+    assert record.output_tokens == ASSUMED_OUTPUT_TOKENS
+    assert record.cost_usd is not None
+
+
+def test_workflow_dry_run_renders_every_stage_without_llm(no_provider_keys):
+    workflow = HydraWorkflow(None, dry_run=True)
+
+    result = workflow.execute(CONTEXT)
+
+    assert result.status is not RunStatus.FAILED, result.error_message
+    stages = [r.stage for r in workflow.dry_run_recorder.records]
+    assert stages == [
+        "gap_analysis",
+        "interrogation",
+        "differentiation",
+        "tailoring",
+        "ats_optimization",
+        "auditor_suite",  # résumé
+        "auditor_suite",  # cover letter
+        "executive_synthesis",
+    ]
+    # The real inputs are rendered into the prompts.
+    gap = workflow.dry_run_recorder.records[0]
+    assert CONTEXT["resume"] in gap.user
+    assert "Gap Analyzer" in gap.system
+
+
+def test_write_dry_run_artifacts_keeps_summary_pii_free(tmp_path, no_provider_keys):
+    workflow = HydraWorkflow(None, dry_run=True)
+    workflow.execute(CONTEXT)
+
+    run_dir = write_dry_run_artifacts(tmp_path, workflow.dry_run_recorder, "run-1")
+
+    prompts = sorted(p.name for p in (run_dir / "prompts").iterdir())
+    assert prompts[0] == "01-gap_analysis.md"
+    assert len(prompts) == len(workflow.dry_run_recorder.records)
+    assert CONTEXT["resume"] in (run_dir / "prompts" / prompts[0]).read_text()
+
+    summary_text = (run_dir / "dry_run.json").read_text()
+    summary = json.loads(summary_text)
+    assert summary["mode"] == "dry_run"
+    assert summary["totals"]["calls"] == len(prompts)
+    assert CONTEXT["resume"] not in summary_text
+
+
+def test_cli_dry_run_never_builds_an_llm_client(tmp_path, monkeypatch, no_provider_keys, capsys):
+    from runtime.crewai import cli
+
+    jd_file = tmp_path / "jd.md"
+    resume_file = tmp_path / "resume.md"
+    sources_dir = tmp_path / "sources"
+    out_dir = tmp_path / "out"
+    jd_file.write_text(CONTEXT["job_description"])
+    resume_file.write_text(CONTEXT["resume"])
+    sources_dir.mkdir()
+    (sources_dir / "notes.md").write_text("Migrated 40 services.")
+
+    def _fail(*args, **kwargs):
+        raise AssertionError("dry run must not create an LLM client")
+
+    monkeypatch.setattr(cli, "get_llm_client", _fail)
+
+    exit_code = cli.main(
+        [
+            "--jd",
+            str(jd_file),
+            "--resume",
+            str(resume_file),
+            "--sources",
+            str(sources_dir),
+            "--out",
+            str(out_dir),
+            "--dry-run",
+        ]
+    )
+
+    assert exit_code == 0
+    assert "Dry run" in capsys.readouterr().out
+    [run_dir] = [p for p in out_dir.iterdir() if p.is_dir()]
+    assert (run_dir / "dry_run.json").exists()
+    assert not (run_dir / "resume.md").exists()
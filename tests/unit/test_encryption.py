@@ -0,0 +1,135 @@
+"""Unit tests for encryption at rest of run state and artifacts (--encrypt)."""
+
+import json
+from types import SimpleNamespace
+
+import pytest
+
+from runtime.crewai import cli
+from runtime.crewai.artifacts import (
+    MANIFEST_FILE,
+    RESUME_FILE,
+    load_checkpoint,
+    verify_artifact_index,
+    write_run_artifacts,
+)
+from runtime.crewai.encryption import (
+    ENCRYPT_ENV,
+    MAGIC,
+    PASSPHRASE_ENV,
+    SEALED_KEY,
+    TEXT_MAGIC,
+    EncryptionError,
+    StateCipher,
+    open_json,
+    open_text,
+    read_text,
+    seal_json,
+    seal_text,
+    write_text,
+)
+from runtime.crewai.hydra_workflow import RunStatus
+from runtime.crewai.run_control import resume_arguments
+from runtime.crewai.stage_cache import StageCache
+
+
+@pytest.fixture
+def encrypted(monkeypatch):
+    monkeypatch.setenv(ENCRYPT_ENV, "1")
+    monkeypatch.setenv(PASSPHRASE_ENV, "correct horse battery staple")
+
+
+def _paused_result():
+    return SimpleNamespace(
+        success=False,
+        status=RunStatus.PAUSED,
+        final_documents={"resume": "Jane Doe — SRE at Acme"},
+        audit_report=None,
+        executive_brief=None,
+        execution_log=["Executing Gap Analysis"],
+        intermediate_results={"gap_analysis": {"gaps": ["Kubernetes"]}},
+        agent_models={},
+        audit_failed=False,
+        audit_error=None,
+        error_message=None,
+    )
+
+
+def test_cipher_round_trips_and_rejects_a_wrong_key_or_altered_file():
+    cipher = StateCipher("passphrase one")
+    blob = cipher.encrypt("Jane Doe".encode())
+
+    assert blob.startswith(MAGIC) and b"Jane" not in blob
+    assert StateCipher("passphrase one").decrypt(blob) == b"Jane Doe"
+    with pytest.raises(EncryptionError, match="Wrong passphrase"):
+        StateCipher("passphrase two").decrypt(blob)
+    with pytest.raises(EncryptionError):
+        cipher.decrypt(blob[:-1] + bytes([blob[-1] ^ 1]))
+    assert cipher.encrypt(b"x") != cipher.encrypt(b"x")  # a fresh nonce per file
+
+
+def test_an_encrypted_run_keeps_its_content_sealed_and_loads_transparently(
+    tmp_path, encrypted, monkeypatch
+):
+    run_dir = write_run_artifacts(
+        tmp_path, _paused_result(), run_id="run-1", include_intermediate=True
+    )
+
+    assert (run_dir / RESUME_FILE).read_bytes().startswith(MAGIC)
+    assert (run_dir / "intermediate" / "gap_analysis.yaml").read_bytes().startswith(MAGIC)
+    manifest = json.loads((run_dir / MANIFEST_FILE).read_text())  # readable without the key
+    assert manifest["encrypted"] is True and "Jane" not in json.dumps(manifest)
+    assert verify_artifact_index(run_dir) == {"missing": [], "changed": [], "added": []}
+
+    assert read_text(run_dir / RESUME_FILE) == "Jane Doe — SRE at Acme"
+    assert load_checkpoint(run_dir)[0] == {"gap_analysis": {"gaps": ["Kubernetes"]}}
+
+    manifest["inputs"] = {"jd_path": "jd.md", "resume_path": "resume.md"}
+    (run_dir / MANIFEST_FILE).write_text(json.dumps(manifest))
+    assert "--encrypt" in resume_arguments(run_dir)
+
+    monkeypatch.delenv(PASSPHRASE_ENV)
+    monkeypatch.setattr("runtime.crewai.encryption.keychain_passphrase", lambda: None)
+    with pytest.raises(EncryptionError, match="No passphrase"):
+        load_checkpoint(run_dir)
+
+
+def test_plain_files_still_read_and_the_cache_is_sealed_too(tmp_path, encrypted, monkeypatch):
+    (tmp_path / "plain.md").write_text("plain")
+    assert read_text(tmp_path / "plain.md") == "plain"
+
+    cache = StageCache(tmp_path / "cache")
+    cache.put("ab" * 32, {"gaps": ["Go"]}, role="Gap Analyzer")
+    entry = next((tmp_path / "cache").rglob("*.json"))
+    assert entry.read_bytes().startswith(MAGIC)
+    assert cache.get("ab" * 32) == {"gaps": ["Go"]}
+
+    monkeypatch.setenv(PASSPHRASE_ENV, "someone else's")
+    assert cache.get("ab" * 32) is None  # undecryptable: a miss, not a crash
+
+
+def test_database_values_are_sealed_as_printable_text(encrypted, monkeypatch):
+    sealed = seal_text("Jane Doe, SRE")
+    assert sealed.startswith(TEXT_MAGIC) and sealed.isascii() and "Jane" not in sealed
+    assert open_text(sealed) == "Jane Doe, SRE"
+    assert seal_text("") == "" and seal_text(None) is None
+
+    results = {"tailoring": {"tailored_resume": "# Jane"}}
+    column = seal_json(results)
+    assert list(column) == [SEALED_KEY] and "Jane" not in json.dumps(column)
+    assert open_json(column) == results
+    # Values written before encryption was on pass through.
+    assert open_text("plain") == "plain" and open_json(results) == results
+
+    monkeypatch.delenv(ENCRYPT_ENV)
+    assert seal_text("Jane") == "Jane" and seal_json(results) is results
+
+
+def test_hydra_decrypt_prints_a_file_in_the_clear(tmp_path, encrypted, capsysbinary):
+    write_text(tmp_path / RESUME_FILE, "Jane Doe")
+
+    assert cli.main(["decrypt", str(tmp_path / RESUME_FILE)]) == 0
+    assert capsysbinary.readouterr().out == b"Jane Doe"
+
+    assert cli.main(["decrypt", str(tmp_path / RESUME_FILE), "--out", str(tmp_path / "r")]) == 0
+    assert (tmp_path / "r").read_text() == "Jane Doe"
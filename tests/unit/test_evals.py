@@ -0,0 +1,124 @@
+"""
+Unit tests for the eval suite (tests/evals/*) and its assertions.
+"""
+
+import shutil
+from pathlib import Path
+
+import pytest
+import yaml
+
+from runtime.crewai.cli import main
+from runtime.crewai.cv_formats import employers
+from runtime.crewai.evals import EvalError, discover, load_case, pass_rates, run_case
+
+EVALS_DIR = Path(__file__).resolve().parents[1] / "evals"
+CASES = discover([EVALS_DIR])
+
+
+def _copy(tmp_path, name="platform-engineer"):
+    case = tmp_path / name
+    shutil.copytree(EVALS_DIR / name, case)
+    return case
+
+
+def _edit(path, change):
+    data = yaml.safe_load(path.read_text())
+    change(data)
+    path.write_text(yaml.safe_dump(data, allow_unicode=True))
+
+
+@pytest.mark.parametrize("path", CASES, ids=[p.name for p in CASES])
+def test_eval_case(path):
+    result = run_case(load_case(path))
+    assert result.passed, "\n".join(f"{c.assertion}: {c.detail}" for c in result.checks)
+
+
+def test_the_assertions_catch_a_fabricated_employer_and_lost_keywords(tmp_path):
+    case = _copy(tmp_path)
+    invented = (
+        "# Jane Doe\n\n## Experience\n\n### Platform Engineer, Globex (2019 - 2024)\n"
+        "- Ran Kubernetes clusters on EKS\n\n### SRE, Hooli (2017 - 2019)\n- Ran Acme Cloud\n"
+    )
+
+    def _invent(responses):
+        responses["tailoring"]["tailored_resume"] = invented
+        responses["ats_optimization"]["optimized_resume"] = invented
+
+    _edit(case / "responses.yaml", _invent)
+    assert employers(invented) == ["Globex", "Hooli"]
+
+    result = run_case(load_case(case))
+
+    failed = {check.assertion: check.detail for check in result.checks if not check.passed}
+    assert failed["no_fabricated_employers"] == "not in the inputs: Hooli"
+    assert failed["must_contain"] == "missing: AWS, Terraform, on-call"
+    assert failed["must_not_contain"] == "present: Acme Cloud"
+    assert "valid_schema" not in failed
+
+
+def test_a_stage_output_without_its_documents_fails_the_schema(tmp_path):
+    case = _copy(tmp_path)
+    _edit(case / "expected.yaml", lambda expected: expected.pop("status"))
+    _edit(case / "responses.yaml", lambda r: r["gap_analysis"].update(requirements=[]))
+
+    result = run_case(load_case(case))
+
+    (schema,) = [check for check in result.checks if check.assertion == "valid_schema"]
+    assert not schema.passed and schema.detail == "gap_analysis has no classified requirements"
+
+
+def test_min_judge_score_fails_on_a_low_rubric_or_no_scores(tmp_path):
+    case = _copy(tmp_path)
+    _edit(case / "expected.yaml", lambda expected: expected.update(min_judge_score=9))
+
+    result = run_case(load_case(case))
+
+    (judged,) = [check for check in result.checks if check.assertion == "min_judge_score"]
+    assert judged.detail == "below 9: relevance 8, readability 8"
+    assert result.judge["scores"]["truthfulness"]["score"] == 10
+
+    _edit(case / "responses.yaml", lambda responses: responses.update(judge={"ok": True}))
+    result = run_case(load_case(case))
+    (judged,) = [check for check in result.checks if check.assertion == "min_judge_score"]
+    assert not judged.passed and judged.detail == "the judge gave no scores"
+
+
+def test_malformed_cases_are_rejected(tmp_path):
+    case = _copy(tmp_path)
+    (case / "expected.yaml").write_text("must_contian: [AWS]\n")
+    with pytest.raises(EvalError, match="unknown assertion\\(s\\) must_contian"):
+        load_case(case)
+    (case / "expected.yaml").write_text("must_contain: [AWS]\n")
+    (case / "responses.yaml").unlink()
+    with pytest.raises(EvalError, match="no responses.yaml; run it against a live model"):
+        run_case(load_case(case))
+
+
+def test_pass_rates_count_each_assertion_and_each_case(tmp_path):
+    broken = _copy(tmp_path)
+    _edit(broken / "expected.yaml", lambda expected: expected.update(must_contain=["COBOL"]))
+    results = [run_case(load_case(path)) for path in (*CASES, broken)]
+
+    rates = pass_rates(results)
+
+    assert rates["must_contain"] == (len(CASES), len(CASES) + 1)
+    assert rates["status"] == (len(CASES) + 1, len(CASES) + 1)
+    assert rates["cases"] == (len(CASES), len(CASES) + 1)
+
+
+def test_eval_subcommand_reports_pass_rates(tmp_path, capsys):
+    assert main(["eval", str(EVALS_DIR)]) == 0
+    out = capsys.readouterr().out
+    assert "✅ platform-engineer (completed)\n   judge: Relevance 8, Truthfulness 10" in out
+    assert f"cases                    {len(CASES)}/{len(CASES)}  100%" in out
+
+    broken = _copy(tmp_path)
+    _edit(broken / "expected.yaml", lambda expected: expected.update(must_contain=["COBOL"]))
+    assert main(["eval", str(broken)]) == 1
+    out = capsys.readouterr().out
+    assert "❌ platform-engineer (completed)\n   - must_contain: missing: COBOL" in out
+    assert main(["eval", str(broken), str(EVALS_DIR), "--min-pass-rate", "50"]) == 0
+    with pytest.raises(SystemExit):
+        main(["eval", "--model", "gpt-4o"])
+    assert "require --live" in capsys.readouterr().err
@@ -0,0 +1,183 @@
+"""
+Unit tests for prompt experiments over saved runs.
+"""
+
+import json
+from unittest.mock import Mock, patch
+
+import pytest
+import yaml
+
+from runtime.crewai import cli
+from runtime.crewai.experiments import (
+    BASELINE,
+    RESULTS_FILE,
+    Trial,
+    Variant,
+    ats_score,
+    find_runs,
+    judge_score,
+    load_case,
+    parse_variant,
+    render_report,
+    summarize,
+)
+from runtime.crewai.hydra_workflow import HydraWorkflow
+
+JD = "Senior Platform Engineer. Must have: Python, Terraform, Kubernetes."
+RESUME = """# Jane Doe
+jane@example.com | +1 555 0100 | Berlin
+
+## Experience
+### Platform Engineer, Acme (2019 - 2024)
+- Ran Kubernetes clusters for 40 services
+
+## Education
+BSc Computer Science, 2015
+
+## Skills
+Python, Kubernetes
+"""
+
+
+def _saved_run(out, run_id, stages=("gap_analysis", "differentiation")):
+    run_dir = out / run_id
+    (run_dir / "intermediate").mkdir(parents=True)
+    for stage in stages:
+        (run_dir / "intermediate" / f"{stage}.yaml").write_text(yaml.safe_dump({"stage": stage}))
+    (out / "jd.md").write_text(JD)
+    (out / "resume.md").write_text(RESUME)
+    (out / "sources").mkdir(exist_ok=True)
+    (out / "sources" / "notes.md").write_text("Ran Kubernetes at Acme")
+    inputs = {
+        "jd_path": str(out / "jd.md"),
+        "resume_path": str(out / "resume.md"),
+        "sources_path": str(out / "sources"),
+        "company": "Globex",
+    }
+    (run_dir / "run.json").write_text(json.dumps({"run_id": run_id, "inputs": inputs}))
+    return run_dir
+
+
+def test_variants_are_named_and_scored():
+    assert parse_variant("p2=./experiments/p2") == ("p2", "./experiments/p2")
+    assert parse_variant("./experiments/terse/") == ("terse", "./experiments/terse/")
+    with pytest.raises(ValueError, match="repository's own prompts"):
+        parse_variant("baseline=./p")
+
+    # Two of the JD's three skills, and a résumé an ATS parses well.
+    assert ats_score(RESUME, JD) == (
+        77.3,
+        {"parse_score": 88, "skill_coverage": 66.7, "missing_skills": ["Terraform"]},
+    )
+
+    assert judge_score({"final_status": "APPROVED"})[0] == 100.0
+    rejected = {"final_status": "REJECTED", "action_required": {"blocking": ["Invented employer"]}}
+    assert judge_score(rejected) == (40.0, {"approved": False, "blocking": ["Invented employer"]})
+
+
+def test_the_report_compares_variants_case_by_case():
+    variants = [Variant(BASELINE), Variant("terse", "/p/terse", {"a": "b"})]
+    trials = [
+        Trial("run-a", BASELINE, score=70.0),
+        Trial("run-a", "terse", score=85.0),
+        Trial("run-b", BASELINE, score=90.0),
+        Trial("run-b", "terse", error="the stage returned no résumé"),
+    ]
+
+    summary = summarize(trials, [BASELINE, "terse"])
+
+    assert summary[0] == {
+        "variant": BASELINE,
+        "cases": 2,
+        "mean": 80.0,
+        "min": 70.0,
+        "max": 90.0,
+        "wins": 1,
+        "failed": 0,
+    }
+    assert summary[1]["wins"] == 1 and summary[1]["failed"] == 1 and summary[1]["mean"] == 85.0
+    report = render_report("tailoring", "ats", variants, trials)
+    assert "| terse | /p/terse | 85.0 | 85.0 | 85.0 | 1 | 1 |" in report
+    assert "| run-b | 90.0 | failed |" in report
+    assert "- run-b / terse: the stage returned no résumé" in report
+
+
+def test_saved_runs_become_cases_with_their_upstream_outputs(tmp_path):
+    _saved_run(tmp_path, "run-a")
+    _saved_run(tmp_path, "run-b", stages=("research",))
+
+    assert [path.name for path in find_runs(tmp_path, "tailoring")] == ["run-a"]
+    case = load_case(tmp_path / "run-a", "tailoring", cli._read_sources)
+    assert case.context["job_description"] == JD
+    assert "Ran Kubernetes at Acme" in case.context["source_documents"]
+    assert set(case.context["previous_results"]) == {"gap_analysis", "differentiation"}
+    assert case.context["company"] == "Globex"
+    with pytest.raises(ValueError, match="run-b: no stored gap_analysis output"):
+        load_case(tmp_path / "run-b", "tailoring", cli._read_sources)
+
+
+def test_a_workflow_runs_one_stage_on_stored_outputs():
+    names = [
+        "GapAnalyzerAgent",
+        "InterrogatorPrepperAgent",
+        "DifferentiatorAgent",
+        "TailoringAgent",
+        "ATSOptimizerAgent",
+        "AuditorSuiteAgent",
+        "ExecutiveSynthesizerAgent",
+    ]
+    patches = [patch(f"runtime.crewai.hydra_workflow.{name}") for name in names]
+    for p in patches:
+        p.start()
+    try:
+        workflow = HydraWorkflow(Mock(), use_per_agent_models=False, auto_approve=True)
+    finally:
+        for p in patches:
+            p.stop()
+    workflow.tailoring_agent.execute.return_value = {"tailored_resume": RESUME}
+    context = {
+        "job_description": JD,
+        "resume": RESUME,
+        "source_documents": "notes",
+        "previous_results": {"differentiation": {"differentiators": ["40 services"]}},
+    }
+
+    output = workflow.execute_stage("tailoring", context)
+
+    assert output == {"tailored_resume": RESUME}
+    assert workflow.tailoring_agent.execute.call_args[0][0]["differentiators"] == ["40 services"]
+    for agent in (workflow.gap_analyzer, workflow.ats_optimizer, workflow.auditor_suite):
+        agent.execute.assert_not_called()
+    with pytest.raises(ValueError, match="audit cannot run on its own"):
+        workflow.execute_stage("audit", context)
+
+
+def test_the_experiment_command_scores_every_variant_on_every_run(tmp_path, monkeypatch):
+    _saved_run(tmp_path, "run-a")
+    prompts = tmp_path / "terse"
+    prompts.mkdir()
+    (prompts / "tailoring-agent.md").write_text("Be terse.")
+    bare = RESUME.replace("Kubernetes", "Docker")
+
+    class _Workflow:
+        def __init__(self, llm, prompt_overrides=None, **kwargs):
+            self.terse = bool(prompt_overrides)
+
+        def execute_stage(self, stage, context):
+            return {"tailored_resume": RESUME if self.terse else bare}
+
+    monkeypatch.setattr(cli, "HydraWorkflow", _Workflow)
+    monkeypatch.setattr(cli, "get_llm_client", lambda model=None: Mock())
+
+    code = cli.main(
+        ["experiment", "--stage", "tailoring", "--variant", f"terse={prompts}", "--out",
+         str(tmp_path)]
+    )  # fmt: skip
+
+    assert code == 0
+    (directory,) = (tmp_path / "experiments").iterdir()
+    results = json.loads((directory / RESULTS_FILE).read_text())
+    by_name = {row["variant"]: row for row in results["summary"]}
+    assert by_name["terse"]["wins"] == 1 and by_name[BASELINE]["wins"] == 0
+    assert (directory / "outputs" / "terse" / "run-a.md").read_text() == RESUME
@@ -0,0 +1,191 @@
+"""
+Unit tests for provider health checks and failover to an equivalent model.
+"""
+
+import urllib.error
+from types import SimpleNamespace
+from unittest.mock import patch
+
+import pytest
+
+from runtime.crewai import cli
+from runtime.crewai.artifacts import build_manifest
+from runtime.crewai.failover import (
+    HEALTH_URLS,
+    FailoverPolicy,
+    HealthChecker,
+    ProviderStatus,
+)
+from runtime.crewai.model_config import PROVIDER_ENV_KEYS
+from runtime.crewai.pipeline_config import (
+    PipelineConfig,
+    PipelineConfigError,
+    load_pipeline_config,
+)
+from tests.conftest import patched_workflow
+
+MAVERICK = "meta-llama/Llama-4-Maverick-17B-128E-Instruct-FP8"
+
+
+@pytest.fixture(autouse=True)
+def _keys(monkeypatch, tmp_path):
+    monkeypatch.setenv("HYDRA_HOME", str(tmp_path / "home"))
+    for env_var in PROVIDER_ENV_KEYS.values():
+        monkeypatch.delenv(env_var, raising=False)
+    monkeypatch.setenv("TOGETHER_API_KEY", "together-key")
+    monkeypatch.setenv("OPENROUTER_API_KEY", "openrouter-key")
+
+
+def _fetch(codes):
+    """A fetch answering each provider's health URL with its code (or raising it)."""
+    by_url = {HEALTH_URLS[provider]: code for provider, code in codes.items()}
+    requests = []
+
+    def fetch(request, timeout):
+        requests.append(request)
+        code = by_url[request.full_url]
+        if isinstance(code, Exception):
+            raise code
+        if code >= 400:
+            raise urllib.error.HTTPError(request.full_url, code, "error", {}, None)
+        return code
+
+    fetch.requests = requests
+    return fetch
+
+
+def test_policy_extends_the_defaults_and_finds_equivalents():
+    policy = FailoverPolicy.from_dict(
+        {"providers": {"chutes": "openrouter"}, "models": {"my/model": {"openrouter": "m/x"}}}
+    )
+
+    assert policy.providers["chutes"] == ("openrouter",)
+    assert policy.providers["together"] == ("openrouter",)  # default kept
+    assert policy.equivalent(f"together_ai/{MAVERICK}", "openrouter") == (
+        "meta-llama/llama-4-maverick"
+    )
+    assert policy.equivalent("openai/deepseek-ai/DeepSeek-V3", "openrouter") == (
+        "deepseek/deepseek-chat"
+    )
+    assert policy.candidates("chutes", "openai/my/model") == [("openrouter", "m/x")]
+    assert policy.candidates("together", "together_ai/unknown-model") == []
+
+
+@pytest.mark.parametrize(
+    "text, message",
+    [
+        ("failover:\n  providers: {together: [azure]}\n", "unknown provider"),
+        ("failover:\n  retries: 3\n", "unknown failover setting"),
+        ("failover:\n  enabled: sometimes\n", "failover.enabled"),
+        ("failover: [together]\n", "'failover' must be a mapping"),
+    ],
+)
+def test_bad_failover_configs_are_rejected(tmp_path, text, message):
+    path = tmp_path / "pipeline.yaml"
+    path.write_text(text)
+    with pytest.raises(PipelineConfigError, match=message):
+        load_pipeline_config(path)
+
+
+def test_health_checks_read_the_status_and_are_remembered():
+    now = [100.0]
+    fetch = _fetch(
+        {
+            "together": urllib.error.URLError("connection refused"),
+            "openrouter": 429,
+            "openai": 200,
+        }
+    )
+    health = HealthChecker(fetch, clock=lambda: now[0])
+
+    down = health.check("together")
+    assert not down.healthy and "unreachable" in down.detail
+    assert health.check("openrouter").healthy  # rate limited, but up
+    assert health.check("openai") == ProviderStatus("openai", False, "no API key", 0, 100.0)
+    assert fetch.requests[0].headers["Authorization"] == "Bearer together-key"
+
+    assert health.check("together") is down and len(fetch.requests) == 2  # remembered
+    now[0] += 61
+    assert health.known("together") is None
+    health.check("together")
+    assert len(fetch.requests) == 3
+
+
+def _workflow(health):
+    workflow = patched_workflow(auto_approve=True)
+    workflow.health = health
+    workflow._begin_run()
+    return workflow
+
+
+def _on_together(agent):
+    agent.llm = SimpleNamespace(model=f"together_ai/{MAVERICK}", temperature=0.3)
+
+
+def test_a_stage_on_a_provider_that_is_down_fails_over_to_the_equivalent_model():
+    health = HealthChecker(_fetch({"together": 503, "openrouter": 200}))
+    workflow = _workflow(health)
+    agent = workflow.gap_analyzer
+    _on_together(agent)
+    models = []
+
+    def _execute(context):
+        models.append(agent.llm.model)
+        if agent.llm.model.startswith("together_ai/"):
+            raise ConnectionError("502 Bad Gateway")
+        return {"gaps": []}
+
+    agent.execute.side_effect = _execute
+    result = workflow._execute_with_fallback(agent, {"resume": "CV"}, "gap_analysis")
+
+    assert result == {"gaps": []}
+    assert models == [f"together_ai/{MAVERICK}", "openrouter/meta-llama/llama-4-maverick"]
+    assert agent.llm.temperature == 0.3
+    assert workflow.agent_models["gap_analysis"] == "openrouter:meta-llama/llama-4-maverick"
+    served = {
+        "gap_analysis": {
+            "provider": "openrouter",
+            "model": "openrouter/meta-llama/llama-4-maverick",
+            "temperature": 0.3,
+            "failed_over_from": "together",
+        }
+    }
+    assert workflow._provider_summary() == served
+    assert build_manifest("run-1", SimpleNamespace(stage_providers=served))["providers"] == served
+
+    # The next stage on Together does not wait for its call to fail first.
+    differentiator = workflow.differentiator
+    _on_together(differentiator)
+    differentiator.execute.return_value = {"angles": []}
+    workflow._execute_with_fallback(differentiator, {}, "differentiation")
+    assert differentiator.execute.call_count == 1
+    assert workflow.stage_providers["differentiation"]["failed_over_from"] == "together"
+
+
+def test_a_failure_on_a_healthy_provider_uses_the_fallback_model():
+    workflow = _workflow(HealthChecker(_fetch({"together": 200, "openrouter": 200})))
+    agent = workflow.gap_analyzer
+    _on_together(agent)
+    agent.execute.side_effect = [ValueError("bad JSON"), {"gaps": []}]
+
+    workflow._execute_with_fallback(agent, {}, "gap_analysis")
+
+    assert agent.llm is workflow.fallback_llm
+    assert "failed_over_from" not in workflow.stage_providers["gap_analysis"]
+
+
+def test_failover_can_be_turned_off():
+    config = PipelineConfig.from_dict({"failover": {"enabled": False}})
+    assert config.failover.enabled is False
+    assert config.to_dict()["failover"]["enabled"] is False
+
+
+def test_hydra_providers_reports_each_configured_provider(capsys):
+    health = HealthChecker(_fetch({"together": 503, "openrouter": 200}))
+    with patch.object(cli, "shared_health", return_value=health):
+        assert cli.main(["providers"]) == 1
+
+    out = capsys.readouterr().out
+    assert "❌ together" in out and "server error (503)" in out
+    assert "✅ openrouter" in out and "fails over to: together" in out
+    assert "chutes" not in out  # no key, not checked
@@ -0,0 +1,149 @@
+"""
+Unit tests for the fit score: its parts, the go / no-go call and where it is shown.
+"""
+
+import json
+from datetime import date
+from types import SimpleNamespace
+from unittest.mock import patch
+
+from runtime.crewai.artifacts import MANIFEST_FILE, write_run_artifacts
+from runtime.crewai.contracts import GapReview
+from runtime.crewai.fit_score import (
+    GO,
+    GO_WITH_CAUTION,
+    NO_GO,
+    assess_fit,
+    level_of,
+    location_flags,
+    required_years,
+    resume_years,
+)
+from runtime.crewai.hydra_workflow import UserInteraction
+from runtime.crewai.review_server import _gap_review_html
+
+TODAY = date(2026, 10, 17)
+RESUME = """# Jane Doe
+
+## Experience
+
+### Senior Platform Engineer — Acme (2019–present)
+- Ran 40 Kubernetes clusters
+
+### Platform Engineer — Globex (2016–2019)
+- Built the CI pipeline
+"""
+JD = """Senior Platform Engineer
+
+We want 5+ years of platform work. Fully remote.
+"""
+
+
+def _requirement(type_, classification):
+    return {"text": "x", "type": type_, "classification": classification}
+
+
+def _gap(*requirements, fit_score=70):
+    return {
+        "gap_analysis": {
+            "summary": {"fit_score": fit_score},
+            "requirements": list(requirements),
+        }
+    }
+
+
+def test_titles_years_and_flags_are_read():
+    assert level_of("Staff Software Engineer") == 4
+    assert level_of("Sr. Data Analyst") == 3
+    assert level_of("Platform Engineer") is None
+    assert required_years("3-5 years of Go; 7+ yrs overall; since 2019") == 7
+    assert resume_years("2016 … 2019 – present", today=TODAY) == 10
+    assert resume_years("no dates") is None
+
+    flags = location_flags("Great team.\nWe do not offer visa sponsorship. On-site in Berlin.")
+    assert [label for label, _ in flags] == ["no visa sponsorship", "on-site or hybrid"]
+    assert flags[0][1] == "We do not offer visa sponsorship"
+
+
+def test_a_covered_senior_role_is_a_go():
+    gap = _gap(
+        _requirement("explicit_hard", "direct_match"),
+        _requirement("explicit_hard", "direct_match"),
+        _requirement("explicit_soft", "adjacent"),
+    )
+
+    fit = assess_fit(gap, JD, RESUME, today=TODAY)
+
+    assert (fit.must_have, fit.nice_to_have, fit.seniority) == (1.0, 0.5, 1.0)
+    assert fit.score == 92 and fit.recommendation == "STRONG_PROCEED" and fit.decision == GO
+    assert fit.rationale == [
+        "Must-haves: 2 of 2 covered (2 direct, 0 adjacent)",
+        "Nice-to-haves: 0.5 of 1 covered (0 direct, 1 adjacent)",
+        "Seniority: job asks senior, 5+ years; résumé shows senior, about 10 years",
+        "Analyzer's own estimate: 70",
+    ]
+
+
+def test_flags_call_for_caution_and_blockers_or_low_coverage_are_a_no_go():
+    covered = _gap(_requirement("explicit_hard", "direct_match"))
+    visa = JD + "We are unable to sponsor visas for this role.\n"
+
+    fit = assess_fit(covered, visa, RESUME, today=TODAY)
+    assert fit.score == 100 and fit.decision == GO_WITH_CAUTION
+    assert fit.flags == ["no visa sponsorship"]
+    assert "Flag: no visa sponsorship — “We are unable to sponsor visas for this role”" in (
+        fit.rationale
+    )
+
+    blocked = _gap(
+        _requirement("explicit_hard", "direct_match"),
+        _requirement("explicit_hard", "blocker"),
+    )
+    fit = assess_fit(blocked, JD, RESUME, today=TODAY)
+    assert fit.score == 49 and fit.decision == NO_GO and fit.blockers == 1
+
+    staff = "Staff Engineer\n\n10+ years required."
+    gaps = _gap(_requirement("explicit_hard", "gap"), _requirement("explicit_hard", "adjacent"))
+    fit = assess_fit(gaps, staff, RESUME, today=TODAY)
+    assert fit.seniority == 0.75 and fit.score == 40 and fit.recommendation == "PASS"
+
+    # No classified requirements: the model's own estimate stays the fit score.
+    assert assess_fit({"fit_score": 64, "gaps": []}, JD, RESUME).score is None
+    assert GapReview.from_raw({"fit_score": 64, "fit": {"score": None}}).fit_score == 64
+
+
+def test_the_greenlight_shows_the_call_and_asks_again_after_a_no_go(capsys):
+    gap = _gap(_requirement("explicit_hard", "gap"), fit_score=80)
+    gap["fit"] = assess_fit(gap, JD, RESUME, today=TODAY).to_dict()
+    review = GapReview.from_raw(gap)
+    assert review.fit_score == 29 and review.decision == NO_GO
+
+    with patch("builtins.input", return_value="n") as answer:
+        assert not UserInteraction.greenlight_gap_analysis(gap)
+    printed = capsys.readouterr().out
+    assert "Fit score: 29 — 🔴 NO-GO" in printed
+    assert "· Must-haves: 0 of 1 covered (0 direct, 0 adjacent)" in printed
+    assert "The fit is low (NO-GO). Proceed anyway?" in answer.call_args.args[0]
+
+    page = _gap_review_html(review)
+    assert "Gap analysis · fit score 29" in page and "Recommended: NO-GO" in page
+    assert "Analyzer&#x27;s own estimate: 80" in page
+
+
+def test_run_json_records_the_score_and_call(tmp_path):
+    gap = _gap(_requirement("explicit_hard", "direct_match"))
+    gap["fit"] = assess_fit(gap, JD, RESUME, today=TODAY).to_dict()
+    result = SimpleNamespace(
+        final_documents={"resume": "# Jane"}, intermediate_results={"gap_analysis": gap}
+    )
+
+    run_dir = write_run_artifacts(tmp_path, result, run_id="run-1")
+
+    manifest = json.loads((run_dir / MANIFEST_FILE).read_text())
+    assert manifest["fit"] == {
+        "score": 100,
+        "recommendation": "STRONG_PROCEED",
+        "decision": GO,
+        "flags": [],
+        "blockers": 0,
+    }
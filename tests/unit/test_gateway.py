@@ -0,0 +1,149 @@
+"""
+Unit tests for the org-internal LLM gateway client.
+"""
+
+import io
+import json
+import urllib.error
+from unittest.mock import Mock, patch
+
+import pytest
+
+from runtime.crewai.agents.gap_analyzer import GapAnalyzerAgent
+from runtime.crewai.gateway import (
+    GatewayConfig,
+    GatewayError,
+    GatewayLLM,
+    get_gateway_llm,
+)
+from runtime.crewai.llm_client import get_llm_client
+from runtime.crewai.model_config import get_llm_for_spec
+from runtime.crewai.prompt_cache import UsageLedger
+
+# A proxy with its own envelope: prompt under `input`, answer under `output.text`.
+CORP_CONFIG = """
+url: https://llm.corp.example/v1/generate
+model: corp-sonnet
+auth: {scheme: header, header: X-Api-Key, token_env: CORP_LLM_TOKEN}
+headers: {X-Team: hiring}
+request: {messages: input, temperature: null, extra: {stream: false}}
+response:
+  content: output.text
+  prompt_tokens: meta.tokens_in
+  completion_tokens: meta.tokens_out
+  error: fault
+"""
+
+
+class _Reply(io.BytesIO):
+    def __enter__(self):
+        return self
+
+    def __exit__(self, *exc):
+        return False
+
+
+def _serve(payload):
+    sent = {}
+
+    def _urlopen(request, timeout):
+        sent["url"] = request.full_url
+        sent["headers"] = {k.lower(): v for k, v in request.header_items()}
+        sent["body"] = json.loads(request.data)
+        sent["timeout"] = timeout
+        return _Reply(json.dumps(payload).encode())
+
+    return sent, patch("runtime.crewai.gateway.urllib.request.urlopen", side_effect=_urlopen)
+
+
+@pytest.fixture
+def corp_gateway(tmp_path, monkeypatch):
+    path = tmp_path / "gateway.yaml"
+    path.write_text(CORP_CONFIG)
+    monkeypatch.setenv("HYDRA_GATEWAY_CONFIG", str(path))
+    monkeypatch.setenv("CORP_LLM_TOKEN", "s3cret")
+    return path
+
+
+def test_custom_envelope_and_header_auth(corp_gateway):
+    llm = get_gateway_llm()
+    sent, urlopen = _serve({"output": {"text": "Hi"}, "meta": {"tokens_in": 7, "tokens_out": 2}})
+
+    with urlopen:
+        reply = llm.complete([{"role": "user", "content": "Hello"}])
+
+    assert sent["url"] == "https://llm.corp.example/v1/generate"
+    assert sent["headers"]["x-api-key"] == "s3cret" and sent["headers"]["x-team"] == "hiring"
+    assert "authorization" not in sent["headers"]
+    assert sent["body"] == {
+        "stream": False,
+        "model": "corp-sonnet",
+        "input": [{"role": "user", "content": "Hello"}],
+    }
+    assert reply["choices"][0]["message"]["content"] == "Hi"
+    assert reply["usage"] == {"prompt_tokens": 7, "completion_tokens": 2}
+
+
+def test_openai_shape_and_bearer_auth_by_default(monkeypatch):
+    monkeypatch.setenv("TOKEN", "t")
+    config = GatewayConfig.from_dict({"url": "https://proxy/chat", "auth": {"token_env": "TOKEN"}})
+    sent, urlopen = _serve({"choices": [{"message": {"content": "ok"}}]})
+
+    with urlopen:
+        reply = GatewayLLM(config, "gpt-4o", temperature=0.2).complete([])
+
+    assert sent["headers"]["authorization"] == "Bearer t"
+    assert sent["body"]["temperature"] == 0.2 and sent["body"]["model"] == "gpt-4o"
+    assert reply["usage"] == {"prompt_tokens": 0, "completion_tokens": 0}
+
+
+def test_errors_are_readable(corp_gateway, monkeypatch):
+    llm = get_gateway_llm("corp-opus")
+    _, urlopen = _serve({"fault": "quota exceeded"})
+    with urlopen, pytest.raises(GatewayError, match="no text at `output.text`: quota exceeded"):
+        llm.complete([])
+
+    denied = urllib.error.HTTPError("u", 403, "Forbidden", {}, io.BytesIO(b"bad key"))
+    with patch("runtime.crewai.gateway.urllib.request.urlopen", side_effect=denied):
+        with pytest.raises(GatewayError, match="HTTP 403: bad key"):
+            llm.complete([])
+
+    monkeypatch.delenv("CORP_LLM_TOKEN")
+    with pytest.raises(GatewayError, match="CORP_LLM_TOKEN not set"):
+        llm.complete([])
+    with pytest.raises(GatewayError, match="auth scheme"):
+        GatewayConfig.from_dict({"url": "u", "auth": {"scheme": "kerberos"}})
+    with pytest.raises(GatewayError, match="token_env"):
+        GatewayConfig.from_dict({"url": "u"})
+
+
+def test_gateway_is_a_provider_and_the_fallback_client(corp_gateway, monkeypatch):
+    for key in ("TOGETHER_API_KEY", "CHUTES_API_KEY", "OPENROUTER_API_KEY"):
+        monkeypatch.delenv(key, raising=False)
+
+    pinned = get_llm_for_spec("gateway:corp-opus", "tailoring_agent")
+    assert isinstance(pinned, GatewayLLM) and pinned.model == "corp-opus"
+    assert pinned.temperature == 0.6  # the tailoring agent's temperature
+    assert get_llm_client().model == "corp-sonnet"
+
+
+def test_agents_call_the_gateway_directly(corp_gateway):
+    agent = GapAnalyzerAgent(get_gateway_llm())
+    agent.usage_ledger = UsageLedger()
+    answer = json.dumps(
+        {
+            "agent": "Gap Analyzer",
+            "timestamp": "2026-10-17T00:00:00Z",
+            "confidence": 0.9,
+            "requirements": [],
+            "gaps": [],
+            "fit_score": 70,
+        }
+    )
+    sent, urlopen = _serve({"output": {"text": answer}, "meta": {"tokens_in": 900}})
+
+    with urlopen, patch("runtime.crewai.base_agent.Crew", Mock(side_effect=AssertionError)):
+        agent.execute({"job_description": "Platform engineer", "resume": "Jane Doe"})
+
+    assert sent["body"]["input"][0]["role"] == "system"
+    assert agent.usage_ledger.summary()["prompt_tokens"] == 900
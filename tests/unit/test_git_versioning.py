@@ -0,0 +1,115 @@
+"""Unit tests for committing runs to git stage by stage (--git)."""
+
+import subprocess
+from unittest.mock import Mock, patch
+
+import pytest
+
+from runtime.crewai.git_versioning import GitVersioning, GitVersioningError, stage_message
+from runtime.crewai.hydra_workflow import HydraWorkflow, RunStatus
+from runtime.crewai.prompt_cache import CallUsage
+from runtime.crewai.run_control import LIVE_FILE
+
+pytestmark = pytest.mark.skipif(
+    subprocess.run(["git", "--version"], capture_output=True).returncode != 0,
+    reason="git is not installed",
+)
+
+
+def _log(repo, *args):
+    return subprocess.run(
+        ["git", "log", "--format=%B%x00", *args], cwd=repo, capture_output=True, text=True
+    ).stdout.split("\0")
+
+
+def _workflow():
+    with (
+        patch("runtime.crewai.hydra_workflow.GapAnalyzerAgent"),
+        patch("runtime.crewai.hydra_workflow.InterrogatorPrepperAgent"),
+        patch("runtime.crewai.hydra_workflow.DifferentiatorAgent"),
+        patch("runtime.crewai.hydra_workflow.TailoringAgent"),
+        patch("runtime.crewai.hydra_workflow.ATSOptimizerAgent"),
+        patch("runtime.crewai.hydra_workflow.AuditorSuiteAgent"),
+        patch("runtime.crewai.hydra_workflow.ExecutiveSynthesizerAgent"),
+    ):
+        workflow = HydraWorkflow(Mock(), use_per_agent_models=False, auto_approve=True)
+
+    def _gap_analysis(context):
+        workflow.usage_ledger.record(CallUsage("Gap Analyzer", "deepseek/v3", 1200, 300, 200))
+        return {"gaps": ["Kubernetes"]}
+
+    def _tailor_then_pause(context):
+        workflow.stop_after_stage("paused")
+        return {"tailored_resume": "Resume", "tailored_cover_letter": "Letter"}
+
+    workflow.gap_analyzer.execute.side_effect = _gap_analysis
+    workflow.interrogator_prepper.execute.return_value = {"questions": []}
+    workflow.differentiator.execute.return_value = {"differentiators": ["AWS"]}
+    workflow.tailoring_agent.execute.side_effect = _tailor_then_pause
+    return workflow
+
+
+def test_each_stage_is_committed_with_its_models_and_tokens(tmp_path):
+    out = tmp_path / "output"
+    workflow = _workflow()
+    versioning = GitVersioning(out, "acme-20260117-101500-1a2b3c4d", workflow).open()
+    workflow.stage_listeners.append(versioning.commit_stage)
+    (out / versioning.run_id).mkdir()
+    (out / versioning.run_id / LIVE_FILE).write_text("{}")  # a run in flight
+
+    result = workflow.execute(
+        {"job_description": "JD", "resume": "Jane Doe", "source_documents": "Jane Doe"}
+    )
+    assert result.status == RunStatus.INTERRUPTED
+    (out / versioning.run_id / "resume.md").write_text("Resume")
+    versioning.commit_run("paused")
+
+    messages = _log(out)
+    assert messages[0].strip() == "[acme-20260117-101500-1a2b3c4d] run paused"
+    stages = [m.strip().splitlines()[0].split("] ")[1] for m in reversed(messages) if m.strip()]
+    assert stages[:4] == [
+        "gap_analysis: deepseek/v3",
+        "interrogation: no model calls (served from the stage cache)",
+        "differentiation: no model calls (served from the stage cache)",
+        "tailoring: no model calls (served from the stage cache)",
+    ]
+    assert "Prompt tokens: 1200 (200 read from cache)" in messages[-2]
+    assert len(versioning.commits) == 5
+    tracked = subprocess.run(
+        ["git", "ls-files"], cwd=out, capture_output=True, text=True
+    ).stdout.split()
+    assert f"{versioning.run_id}/intermediate/tailoring.yaml" in tracked
+    assert f"{versioning.run_id}/{LIVE_FILE}" not in tracked
+
+    # Nothing new: no empty commit.
+    assert versioning.commit_run("paused") is None
+
+
+def test_an_existing_repository_is_attached_not_reinitialized(tmp_path):
+    first = GitVersioning(tmp_path, "run-1").open()
+    first.commit_stage("gap_analysis", {"gaps": []})
+    second = GitVersioning(tmp_path, "run-2").open()
+    second.commit_stage("gap_analysis", {"gaps": ["Go"]})
+
+    assert len(_log(tmp_path)) - 1 == 2
+    assert _log(tmp_path, "--", "run-1")[0].startswith("[run-1] gap_analysis")
+
+
+def test_a_failing_listener_does_not_fail_the_run(tmp_path):
+    workflow = _workflow()
+    workflow.stage_listeners.append(Mock(side_effect=GitVersioningError("index.lock exists")))
+
+    result = workflow.execute(
+        {"job_description": "JD", "resume": "Jane Doe", "source_documents": "Jane Doe"}
+    )
+
+    assert set(result.intermediate_results) >= {"gap_analysis", "tailoring"}
+    assert any("index.lock exists" in line for line in result.execution_log)
+
+
+def test_stage_message_sums_every_call():
+    calls = [CallUsage("a", "m2", 10, 5), CallUsage("b", "m1", 20, 7)]
+    assert stage_message("r", "auditing", calls) == (
+        "[r] auditing: m1, m2",
+        "Model calls: 2\nPrompt tokens: 30\nCompletion tokens: 12",
+    )
@@ -0,0 +1,187 @@
+"""
+Unit tests for stage hooks: user commands and callbacks before and after a stage.
+"""
+
+import json
+import subprocess
+import sys
+
+import pytest
+
+from runtime.crewai.hooks import POST, PRE, HookError, HookPolicy, StageHook, run_hooks
+from runtime.crewai.hydra_workflow import RunStatus
+from runtime.crewai.pipeline_config import (
+    PipelineConfig,
+    PipelineConfigError,
+    load_pipeline_config,
+)
+from tests.conftest import patched_workflow
+
+CONTEXT = {"job_description": "JD", "resume": "Resume", "source_documents": "Sources"}
+
+
+def _script(tmp_path, name, body):
+    """A hook command: ``python <script>``, quoted for the shell."""
+    path = tmp_path / f"{name}.py"
+    path.write_text(f"import json, os, sys\npayload = json.load(sys.stdin)\n{body}\n")
+    return f'"{sys.executable}" "{path}"'
+
+
+def test_hooks_are_read_from_the_pipeline_config(tmp_path):
+    config = tmp_path / "pipeline.yaml"
+    config.write_text(
+        "hooks:\n"
+        "  tailoring:\n"
+        "    pre: ./check.sh\n"
+        "    post:\n"
+        "      - ./lint.sh\n"
+        "      - {run: ./upload.sh, timeout: 5, on_failure: warn}\n"
+        "  '*':\n"
+        "    post: ./log.sh\n"
+    )
+
+    hooks = load_pipeline_config(config).hooks
+
+    assert [h.command for h in hooks.for_stage("tailoring", PRE)] == ["./check.sh"]
+    post = hooks.for_stage("tailoring", POST)
+    assert [h.command for h in post] == ["./log.sh", "./lint.sh", "./upload.sh"]
+    assert (post[2].timeout, post[2].on_failure) == (5.0, "warn")
+    assert [h.command for h in hooks.for_stage("auditor_suite", POST)] == ["./log.sh"]
+    assert hooks.to_dict()["tailoring"]["pre"] == [
+        {"run": "./check.sh", "timeout": 60.0, "on_failure": "fail"}
+    ]
+    assert PipelineConfig().hooks.for_stage("tailoring", POST) == []
+
+
+@pytest.mark.parametrize(
+    "section, message",
+    [
+        ({"polishing": {"post": "x"}}, "unknown stage"),
+        ({"tailoring": {"during": "x"}}, "'pre' and/or 'post'"),
+        ({"tailoring": {"post": {"timeout": 5}}}, "mapping with 'run'"),
+        ({"tailoring": {"post": {"run": "x", "on_failure": "ignore"}}}, "on_failure"),
+        ({"tailoring": {"post": {"run": "x", "timeout": 0}}}, "positive"),
+    ],
+)
+def test_bad_hooks_are_config_errors(section, message):
+    with pytest.raises(PipelineConfigError, match=message):
+        PipelineConfig.from_dict({"hooks": section})
+
+
+def test_a_post_hook_sees_the_output_and_may_replace_it(tmp_path):
+    seen = tmp_path / "seen.json"
+    record = _script(tmp_path, "record", f"open({str(seen)!r}, 'w').write(json.dumps(payload))")
+    upper = _script(
+        tmp_path,
+        "upper",
+        'print(json.dumps({"output": {"resume": payload["output"]["resume"].upper(), '
+        '"stage": os.environ["HYDRA_STAGE"]}}))',
+    )
+    hooks = [StageHook("tailoring", POST, record), StageHook("tailoring", POST, upper)]
+
+    output, warnings = run_hooks(hooks, "tailoring", {"job": "SRE"}, {"resume": "jane"})
+
+    assert output == {"resume": "JANE", "stage": "tailoring"}
+    assert warnings == []
+    assert json.loads(seen.read_text()) == {
+        "hook": "post",
+        "stage": "tailoring",
+        "inputs": {"job": "SRE"},
+        "output": {"resume": "jane"},
+    }
+
+
+def test_a_failing_hook_fails_the_stage_unless_it_only_warns(tmp_path):
+    reject = _script(tmp_path, "reject", 'print("resume too long", file=sys.stderr)\nsys.exit(2)')
+    failing = StageHook("audit", PRE, reject)
+
+    with pytest.raises(HookError, match="pre-audit hook .* exited with 2: resume too long"):
+        run_hooks([failing], "auditor_suite", {})
+    warning = StageHook("audit", PRE, reject, on_failure="warn")
+    assert run_hooks([warning], "audit", {})[1] == [
+        f"pre-audit hook {reject} exited with 2: resume too long"
+    ]
+    garbage = StageHook("audit", POST, _script(tmp_path, "garbage", "print('done!')"))
+    with pytest.raises(HookError, match="something other than JSON"):
+        run_hooks([garbage], "audit", {}, {"approved": True})
+
+
+def test_slow_hooks_and_callbacks(tmp_path):
+    def slow(*args, **kwargs):
+        raise subprocess.TimeoutExpired(args[0], 1)
+
+    with pytest.raises(HookError, match="did not finish within 1s"):
+        run_hooks([StageHook("tailoring", PRE, "sleep 9", timeout=1)], "tailoring", {}, runner=slow)
+
+    def stamp(payload):
+        return {**payload["output"], "stamped": True}
+
+    def boom(payload):
+        raise ValueError("nope")
+
+    hooks = [StageHook("tailoring", POST, callback=stamp)]
+    assert run_hooks(hooks, "tailoring", {}, {"resume": "R"})[0] == {"resume": "R", "stamped": True}
+    with pytest.raises(HookError, match="post-tailoring hook boom failed: nope"):
+        run_hooks([StageHook("tailoring", POST, callback=boom)], "tailoring", {}, {})
+
+
+def _workflow(hooks):
+    workflow = patched_workflow(
+        auto_approve=True, pipeline_config=PipelineConfig(hooks=HookPolicy(tuple(hooks)))
+    )
+    workflow.gap_analyzer.execute.return_value = {"gaps": ["Kafka"], "confidence": 0.9}
+    workflow.interrogator_prepper.execute.return_value = {"questions": [], "confidence": 0.9}
+    workflow.differentiator.execute.return_value = {"differentiators": [], "confidence": 0.9}
+    workflow.tailoring_agent.execute.return_value = {
+        "tailored_resume": "Resume",
+        "tailored_cover_letter": "Letter",
+        "confidence": 0.9,
+    }
+    workflow.ats_optimizer.execute.return_value = {"optimized_resume": "R", "confidence": 0.9}
+    workflow.auditor_suite.execute.return_value = {
+        "approval": {"approved": True},
+        "confidence": 0.9,
+    }
+    return workflow
+
+
+def test_the_workflow_runs_hooks_around_stages():
+    calls = []
+
+    def note(payload):
+        calls.append((payload["hook"], payload["stage"]))
+
+    def reformat(payload):
+        return {**payload["output"], "tailored_resume": "Reformatted"}
+
+    def flaky(payload):
+        raise OSError("upload server down")
+
+    workflow = _workflow(
+        [
+            StageHook("gap_analysis", PRE, callback=note),
+            StageHook("tailoring", POST, callback=reformat),
+            StageHook("tailoring", POST, callback=flaky, on_failure="warn"),
+        ]
+    )
+
+    result = workflow.execute(CONTEXT)
+
+    assert result.status is RunStatus.COMPLETED
+    assert calls == [("pre", "gap_analysis")]
+    assert result.intermediate_results["tailoring"]["tailored_resume"] == "Reformatted"
+    [error] = result.errors
+    assert (error["stage"], error["kind"]) == ("tailoring", "hook")
+    assert "upload server down" in error["message"]
+
+
+def test_a_failing_pre_hook_stops_the_stage():
+    def veto(payload):
+        raise ValueError("JD is missing a salary range")
+
+    workflow = _workflow([StageHook("gap_analysis", PRE, callback=veto)])
+
+    result = workflow.execute(CONTEXT)
+
+    assert result.status is RunStatus.FAILED
+    workflow.gap_analyzer.execute.assert_not_called()
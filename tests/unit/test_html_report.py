@@ -0,0 +1,52 @@
+"""
+Unit tests for the HTML run report.
+"""
+
+from types import SimpleNamespace
+
+from runtime.crewai.artifacts import write_run_artifacts
+from runtime.crewai.html_report import REPORT_HTML_FILE, render_report
+
+USAGE = {
+    "tailoring": {
+        "model": "gpt-4o-mini",
+        "context_window": 10_000,
+        "used_tokens": 9_000,
+        "fraction": 0.9,
+        "compacted": True,
+        "sections": {"system_prompt": 1_000, "resume": 8_000},
+    }
+}
+
+
+def test_render_report_draws_a_bar_per_stage():
+    html = render_report({"run_id": "r1", "status": "completed", "context_usage": USAGE})
+
+    assert "<strong>tailoring</strong>" in html
+    assert 'class="critical">90%' in html
+    assert "width:80.00%" in html
+    assert "compacted" in html
+
+
+def test_render_report_escapes_manifest_values():
+    html = render_report({"run_id": "<script>", "warnings": ["a < b"]})
+
+    assert "<script>" not in html
+    assert "a &lt; b" in html
+    assert "No context-usage data" in html
+
+
+def test_run_artifacts_include_report_without_document_content(tmp_path):
+    result = SimpleNamespace(
+        status=None,
+        final_documents={"resume": "SECRET_RESUME"},
+        audit_report=None,
+        execution_log=[],
+        context_usage=USAGE,
+    )
+
+    run_dir = write_run_artifacts(tmp_path, result, run_id="run-1")
+
+    html = (run_dir / REPORT_HTML_FILE).read_text()
+    assert "tailoring" in html
+    assert "SECRET_RESUME" not in html
@@ -203,6 +203,149 @@ class TestHydraWorkflow:
         assert result.status == RunStatus.COMPLETED
         assert result.state == WorkflowState.COMPLETED
 
+    def test_research_feeds_gap_analysis_and_failure_is_non_fatal(
+        self, mock_llm, sample_context, mock_agent_results
+    ):
+        """Cited research reaches the gap analyzer; a research failure does not fail the run."""
+        with (
+            patch("runtime.crewai.hydra_workflow.ResearchAgent"),
+            patch("runtime.crewai.hydra_workflow.GapAnalyzerAgent"),
+            patch("runtime.crewai.hydra_workflow.InterrogatorPrepperAgent"),
+            patch("runtime.crewai.hydra_workflow.DifferentiatorAgent"),
+            patch("runtime.crewai.hydra_workflow.TailoringAgent"),
+            patch("runtime.crewai.hydra_workflow.ATSOptimizerAgent"),
+            patch("runtime.crewai.hydra_workflow.AuditorSuiteAgent"),
+            patch("runtime.crewai.hydra_workflow.ExecutiveSynthesizerAgent"),
+        ):
+            workflow = HydraWorkflow(
+                mock_llm, use_per_agent_models=False, auto_approve=True, search_provider=Mock()
+            )
+        workflow.gap_analyzer.execute.return_value = mock_agent_results["gap_analysis"]
+        workflow.interrogator_prepper.execute.return_value = mock_agent_results["interrogation"]
+        workflow.differentiator.execute.return_value = mock_agent_results["differentiation"]
+        workflow.tailoring_agent.execute.return_value = mock_agent_results["tailoring"]
+        workflow.ats_optimizer.execute.return_value = mock_agent_results["ats_optimization"]
+        workflow.auditor_suite.execute.return_value = mock_agent_results["audit_approved"]
+        research = {"company": "TechCorp", "sources": [{"id": 1, "url": "https://t"}]}
+        workflow.research_agent.execute.return_value = research
+
+        result = workflow.execute(sample_context)
+
+        assert result.status == RunStatus.COMPLETED
+        research_context = workflow.research_agent.execute.call_args[0][0]
+        assert "resume" not in research_context
+        assert workflow.gap_analyzer.execute.call_args[0][0]["research_data"] == research
+        assert result.intermediate_results["research"] == research
+
+        workflow.intermediate_results = {}
+        workflow.research_agent.execute.side_effect = RuntimeError("search down")
+        result = workflow.execute(sample_context)
+
+        assert result.status == RunStatus.COMPLETED
+        assert "research" not in result.intermediate_results
+        assert any("Research failed" in line for line in result.execution_log)
+
+    def test_gap_analysis_is_told_about_skill_synonyms(
+        self, workflow, sample_context, mock_agent_results
+    ):
+        """JD skills the résumé names differently reach the gap analyzer and the ATS check."""
+        workflow.auto_approve = True
+        workflow.gap_analyzer.execute.return_value = dict(mock_agent_results["gap_analysis"])
+        workflow.interrogator_prepper.execute.return_value = mock_agent_results["interrogation"]
+        workflow.differentiator.execute.return_value = mock_agent_results["differentiation"]
+        workflow.tailoring_agent.execute.return_value = mock_agent_results["tailoring"]
+        workflow.ats_optimizer.execute.return_value = mock_agent_results["ats_optimization"]
+        workflow.auditor_suite.execute.return_value = mock_agent_results["audit_approved"]
+        context = {
+            **sample_context,
+            "job_description": "Platform engineer: K8s, AWS, Terraform",
+            "resume": "Ran Kubernetes on AWS with Terraform",
+        }
+
+        result = workflow.execute(context)
+
+        sent = workflow.gap_analyzer.execute.call_args[0][0]
+        assert sent["skill_synonyms"] == [
+            {"skill": "Kubernetes", "jd_term": "K8s", "resume_term": "Kubernetes"}
+        ]
+        # The ATS check reads the final résumé, which here names none of them.
+        assert result.ats_parse["jd_skills"]["missing"] == [
+            "Amazon Web Services",
+            "Kubernetes",
+            "Terraform",
+        ]
+
+    def test_compensation_runs_only_when_asked_and_failure_is_non_fatal(
+        self, workflow, mock_llm, sample_context, mock_agent_results
+    ):
+        """The negotiation brief is opt-in, gets no résumé, and cannot fail the run."""
+        with (
+            patch("runtime.crewai.hydra_workflow.GapAnalyzerAgent"),
+            patch("runtime.crewai.hydra_workflow.InterrogatorPrepperAgent"),
+            patch("runtime.crewai.hydra_workflow.DifferentiatorAgent"),
+            patch("runtime.crewai.hydra_workflow.TailoringAgent"),
+            patch("runtime.crewai.hydra_workflow.ATSOptimizerAgent"),
+            patch("runtime.crewai.hydra_workflow.AuditorSuiteAgent"),
+            patch("runtime.crewai.hydra_workflow.ExecutiveSynthesizerAgent"),
+            patch("runtime.crewai.hydra_workflow.CompensationAgent"),
+        ):
+            opted_in = HydraWorkflow(
+                mock_llm, use_per_agent_models=False, auto_approve=True, compensation=True
+            )
+        for run in (workflow, opted_in):
+            run.gap_analyzer.execute.return_value = mock_agent_results["gap_analysis"]
+            run.interrogator_prepper.execute.return_value = mock_agent_results["interrogation"]
+            run.differentiator.execute.return_value = mock_agent_results["differentiation"]
+            run.tailoring_agent.execute.return_value = mock_agent_results["tailoring"]
+            run.ats_optimizer.execute.return_value = mock_agent_results["ats_optimization"]
+            run.auditor_suite.execute.return_value = mock_agent_results["audit_approved"]
+
+        result = workflow.execute(sample_context)
+        assert result.compensation_brief is None
+        assert "compensation" not in result.intermediate_results
+
+        brief = {"market_ranges": [], "talking_points": ["Led the AWS migration"]}
+        opted_in.compensation_agent.execute.return_value = brief
+        context = {**sample_context, "compensation_targets": {"target_base": 185000}}
+        result = opted_in.execute(context)
+
+        assert result.compensation_brief == brief
+        assert result.intermediate_results["compensation"] == brief
+        sent = opted_in.compensation_agent.execute.call_args[0][0]
+        assert sent["compensation_targets"] == {"target_base": 185000}
+        assert sent["differentiation"] == mock_agent_results["differentiation"]
+        assert "resume" not in sent and "source_documents" not in sent
+
+        opted_in.compensation_agent.execute.side_effect = RuntimeError("model down")
+        result = opted_in.execute(context)
+
+        assert result.status == RunStatus.COMPLETED
+        assert result.compensation_brief is None
+        assert any("Compensation analysis failed" in line for line in result.execution_log)
+
+    def test_greenlight_notes_reach_later_stages_on_resume(
+        self, workflow, sample_context, mock_agent_results
+    ):
+        """Resuming after the greenlight forwards the reviewer's notes downstream."""
+        workflow.interrogator_prepper.execute.return_value = mock_agent_results["interrogation"]
+        workflow.differentiator.execute.return_value = mock_agent_results["differentiation"]
+        workflow.tailoring_agent.execute.return_value = mock_agent_results["tailoring"]
+        workflow.ats_optimizer.execute.return_value = mock_agent_results["ats_optimization"]
+        workflow.auditor_suite.execute.return_value = mock_agent_results["audit_approved"]
+
+        context = {
+            **sample_context,
+            "previous_results": {"gap_analysis": dict(mock_agent_results["gap_analysis"])},
+            "greenlight_notes": "Lead with the platform migration",
+        }
+        result = workflow.execute(context)
+
+        assert result.status == RunStatus.COMPLETED
+        workflow.gap_analyzer.execute.assert_not_called()
+        diff_context = workflow.differentiator.execute.call_args[0][0]
+        notes = diff_context["gap_analysis"]["greenlight_notes"]
+        assert notes == "Lead with the platform migration"
+
     def test_execute_audit_rejected(self, workflow, sample_context, mock_agent_results):
         """A rejection is a valid verdict: documents are kept but flagged, with no retry."""
         workflow.gap_analyzer.execute.return_value = mock_agent_results["gap_analysis"]
@@ -0,0 +1,189 @@
+"""
+Unit tests for the impact rewriter: weak bullets, the agent, the stage and the interview.
+"""
+
+import json
+from unittest.mock import Mock, patch
+
+from crewai import LLM
+
+from runtime.crewai.agents.impact_rewriter import ImpactRewriterAgent
+from runtime.crewai.artifacts import MANIFEST_FILE, write_run_artifacts
+from runtime.crewai.cli import _report_impact
+from runtime.crewai.hydra_workflow import RunStatus
+from runtime.crewai.impact import (
+    NO_METRIC,
+    WEAK_OPENER,
+    interview_questions,
+    invented_numbers,
+    weak_bullets,
+)
+from runtime.crewai.pipeline_config import PipelineConfig
+from runtime.crewai.run_control import resume_arguments
+from runtime.crewai.workflow_templates import BUILTIN_TEMPLATES
+from tests.conftest import patched_workflow
+
+RESUME = """# Jane Doe
+
+## Experience
+
+### Platform Engineer | Acme
+- Responsible for the CI pipeline
+- Cut cloud spend 30% by rightsizing 200 instances
+- **Worked on** the billing API with 3 teams
+- Built the on-call handbook
+
+## Skills
+- Go, Kubernetes
+"""
+
+
+def test_weak_bullets_are_picked_by_software():
+    weak = weak_bullets(RESUME)
+
+    assert [(b["id"], b["reasons"]) for b in weak] == [
+        ("experience-1-1", [NO_METRIC, WEAK_OPENER]),
+        ("experience-1-3", [WEAK_OPENER]),
+        ("experience-1-4", [NO_METRIC]),
+    ]
+    assert weak[0]["entry"] == "Platform Engineer | Acme"
+    assert len(weak_bullets(RESUME, limit=1)) == 1
+    assert invented_numbers("Cut spend 30% across 12 teams", RESUME) == ["12"]
+
+
+def test_agent_drops_invented_numbers_but_keeps_the_questions():
+    with patch("runtime.crewai.base_agent.Path.read_text", return_value="Impact prompt"):
+        agent = ImpactRewriterAgent(LLM(model="gpt-4", api_key="test-key"))
+    agent.execute_with_retry = Mock(
+        return_value={
+            "rewrites": [
+                {
+                    "bullet": "[experience-1-1]",
+                    "rewrite": "Cut deploy time 80% by rebuilding the CI pipeline",
+                    "questions": ["How long did a deploy take before and after?"],
+                },
+                {
+                    "bullet": "experience-1-4",
+                    "rewrite": "Wrote the on-call handbook the rotation still uses",
+                    "questions": ["How many engineers?", "How many pages a week?", "More?"],
+                },
+                {"bullet": "experience-9-9", "rewrite": "Invented bullet"},
+            ]
+        }
+    )
+    weak = weak_bullets(RESUME)
+
+    result = agent.execute(
+        {"job_description": "Platform Engineer", "resume": RESUME, "bullets": weak}
+    )
+
+    first, second, third = result["bullets"]
+    assert first["rewrite"] == "" and first["questions"]
+    assert result["invented"] == [{"bullet": "experience-1-1", "numbers": ["80%"]}]
+    assert second["rewrite"] == "" and second["questions"] == []  # not in the answer
+    assert third["rewrite"].startswith("Wrote") and len(third["questions"]) == 2
+    assert [q["id"] for q in interview_questions(result)] == ["impact-1", "impact-2", "impact-3"]
+    assert 'about: "Built the on-call handbook"' in interview_questions(result)[1]["question"]
+    prompt = agent.execute_with_retry.call_args[0][0].description
+    assert "[experience-1-1] Responsible for the CI pipeline (no metric, weak opener)" in prompt
+
+
+def _workflow(template):
+    workflow = patched_workflow(
+        interactive=True, pipeline_config=PipelineConfig(), template=template
+    )
+    workflow.gap_analyzer.execute.return_value = {"gaps": [], "confidence": 0.9}
+    workflow.impact_rewriter.execute.side_effect = lambda context: {
+        "bullets": [
+            {**b, "rewrite": f"Better: {b['text']}", "questions": ["By how much?"]}
+            for b in context["bullets"]
+        ],
+        "invented": [],
+        "confidence": 0.9,
+    }
+    workflow.interrogator_prepper.execute.return_value = {
+        "questions": [{"id": "q1", "question": "Kubernetes?"}],
+        "confidence": 0.9,
+    }
+    workflow.differentiator.execute.return_value = {"differentiators": ["Go"], "confidence": 0.9}
+    workflow.tailoring_agent.execute.return_value = {
+        "tailored_resume": "Resume",
+        "tailored_cover_letter": "Letter",
+        "confidence": 0.9,
+    }
+    workflow.ats_optimizer.execute.return_value = {"optimized_resume": "R", "confidence": 0.9}
+    workflow.auditor_suite.execute.return_value = {"approval": {"approved": True}}
+    workflow.executive_synthesizer.execute.return_value = {"confidence": 0.9}
+    workflow.user_interaction = Mock()
+    workflow.user_interaction.greenlight_gap_analysis.return_value = True
+    workflow.user_interaction.conduct_interview.side_effect = lambda questions: [
+        {"question_id": q["id"], "question_text": q["question"], "answer": "About 40%"}
+        for q in questions
+        if q["id"] == "impact-1"
+    ]
+    return workflow
+
+
+def test_stage_questions_join_the_interview_and_rewrites_reach_tailoring():
+    workflow = _workflow(BUILTIN_TEMPLATES["thorough"].including("impact"))
+    context = {"job_description": "JD", "resume": RESUME, "source_documents": "Sources"}
+
+    result = workflow.execute(context)
+
+    assert result.status is RunStatus.COMPLETED
+    asked = workflow.user_interaction.conduct_interview.call_args.args[0]
+    assert [q["id"] for q in asked] == ["q1", "impact-1", "impact-2", "impact-3"]
+    tailoring = workflow.tailoring_agent.execute.call_args.args[0]
+    assert tailoring["impact_rewrites"][0] == {
+        "original": "Responsible for the CI pipeline",
+        "rewrite": "Better: Responsible for the CI pipeline",
+    }
+    assert tailoring["interview_notes"][0]["answer"] == "About 40%"
+    assert len(result.impact["bullets"]) == 3
+
+    # Not in the template: no stage, no questions.
+    workflow = _workflow(BUILTIN_TEMPLATES["thorough"])
+    result = workflow.execute(context)
+    workflow.impact_rewriter.execute.assert_not_called()
+    assert result.impact is None
+    assert "impact_rewrites" not in workflow.tailoring_agent.execute.call_args.args[0]
+
+
+def test_run_json_counts_and_the_cli_reports(tmp_path, capsys):
+    impact = {
+        "bullets": [
+            {"id": "experience-1-1", "text": "A", "rewrite": "B", "questions": ["How many?"]},
+            {"id": "experience-1-4", "text": "C", "rewrite": "", "questions": ["How fast?"]},
+        ],
+        "invented": [{"bullet": "experience-1-4", "numbers": ["80%"]}],
+    }
+
+    class Result:
+        final_documents = {"resume": "# Jane"}
+        intermediate_results = {
+            "interrogation": {"interview_notes": [{"question_id": "impact-2", "answer": "2x"}]}
+        }
+
+    Result.impact = impact
+    run_dir = write_run_artifacts(tmp_path, Result(), run_id="run-1")
+
+    manifest = json.loads((run_dir / MANIFEST_FILE).read_text())
+    assert manifest["impact"] == {
+        "weak_bullets": 2,
+        "rewritten": 1,
+        "questions": 2,
+        "answered": 1,
+        "dropped_for_invented_numbers": 1,
+    }
+    _report_impact(Result(), True)
+    out = capsys.readouterr().out
+    assert "Impact: 1 of 2 weak bullet(s) rewritten; 2 metric question(s), 1 answered" in out
+    assert "1 rewrite(s) dropped" in out
+
+    manifest.update(
+        status="paused",
+        inputs={"jd_path": "jd.md", "resume_path": "resume.md"},
+        template=BUILTIN_TEMPLATES["quick"].including("impact").to_dict(),
+    )
+    (run_dir / MANIFEST_FILE).write_text(json.dumps(manifest))
+    assert "--impact" in resume_arguments(run_dir)
@@ -0,0 +1,221 @@
+"""
+Unit tests for the interview: the session, the terminal, hydra serve, tailoring and
+reusing an earlier run's answers.
+"""
+
+import json
+import os
+import threading
+from unittest.mock import Mock, patch
+
+import pytest
+
+from runtime.crewai.artifacts import MANIFEST_FILE, RunInputs, write_run_artifacts
+from runtime.crewai.cancellation import CancelToken
+from runtime.crewai.hydra_workflow import RunStatus, UserInteraction
+from runtime.crewai.interview import (
+    TRANSCRIPT_FILE,
+    InterviewSession,
+    compile_experience,
+    describe,
+    load_session,
+    load_transcript,
+    normalize_questions,
+    render_experience,
+    reuse_answers,
+)
+from runtime.crewai.pipeline_config import PipelineConfig
+from runtime.crewai.review_server import RemoteGreenlight, ReviewServer
+from runtime.crewai.run_control import LIVE_FILE, resume_arguments
+from tests.conftest import patched_workflow
+
+QUESTIONS = [
+    {"question": "How big was the Kubernetes fleet?", "theme": "platform", "gap": "Kubernetes"},
+    "What did you own on call?",
+    {"id": "impact-1", "question": "How much faster were deploys?"},
+]
+TOKEN = "s3cret"
+
+
+def test_questions_are_numbered_and_the_session_answers_skips_and_goes_back():
+    questions = normalize_questions(QUESTIONS)
+    assert [q["id"] for q in questions] == ["q1", "q2", "impact-1"]
+    assert describe(questions[0]) == "Platform · Gap: Kubernetes"
+    grouped = normalize_questions({"leadership": ["Team size?"], "bad": "ignored"})
+    assert grouped == [{"theme": "leadership", "question": "Team size?", "id": "q1"}]
+
+    session = InterviewSession(questions)
+    session.answer("About 40 clusters")
+    session.skip()
+    session.back()
+    assert session.current["id"] == "q2" and session.progress == "2/3"
+    session.answer("The paging rota")
+    session.finish()
+
+    assert session.done and session.current is None
+    assert [n["question_id"] for n in session.notes()] == ["q1", "q2"]
+    restored = InterviewSession.from_dict(json.loads(json.dumps(session.to_dict())))
+    assert restored.notes() == session.notes() and restored.to_dict()["status"] == "done"
+
+
+def test_the_terminal_asks_one_question_at_a_time(capsys):
+    replies = iter(["40 clusters", "", "back", "The paging rota", "done"])
+    with patch("builtins.input", side_effect=lambda prompt="": next(replies)):
+        notes = UserInteraction.conduct_interview(QUESTIONS)
+
+    assert [(n["question_id"], n["answer"]) for n in notes] == [
+        ("q1", "40 clusters"),
+        ("q2", "The paging rota"),
+    ]
+    out = capsys.readouterr().out
+    assert "[1/3] How big was the Kubernetes fleet?" in out and "Gap: Kubernetes" in out
+    assert "Interview complete: 2 answered, 1 skipped." in out
+
+
+def test_answers_compile_into_experience():
+    notes = [
+        {"question_id": "q1", "question_text": "Fleet?", "answer": "Ran 40 clusters on AWS"},
+        {"question_id": "q2", "answer": "  "},
+    ]
+
+    experience = compile_experience(QUESTIONS, notes)
+
+    assert len(experience) == 1
+    entry = experience[0]
+    assert (entry["theme"], entry["gap"]) == ("platform", "Kubernetes")
+    assert entry["metrics"] == ["40"] and "AWS" in entry["tools"]
+    text = render_experience(experience)
+    assert "- Fleet? (platform · Kubernetes)" in text and "Metrics: 40" in text
+
+
+def _workflow():
+    workflow = patched_workflow(interactive=True, pipeline_config=PipelineConfig())
+    workflow.gap_analyzer.execute.return_value = {"gaps": ["Kubernetes"], "confidence": 0.9}
+    workflow.interrogator_prepper.execute.return_value = {
+        "questions": {"platform": ["How big was the fleet?"]},
+        "confidence": 0.9,
+    }
+    workflow.differentiator.execute.return_value = {"differentiators": ["Go"], "confidence": 0.9}
+    workflow.tailoring_agent.execute.return_value = {
+        "tailored_resume": "Resume",
+        "tailored_cover_letter": "Letter",
+        "confidence": 0.9,
+    }
+    workflow.ats_optimizer.execute.return_value = {"optimized_resume": "R", "confidence": 0.9}
+    workflow.auditor_suite.execute.return_value = {"approval": {"approved": True}}
+    workflow.executive_synthesizer.execute.return_value = {"confidence": 0.9}
+    workflow.user_interaction = Mock()
+    workflow.user_interaction.greenlight_gap_analysis.return_value = True
+    workflow.user_interaction.conduct_interview.side_effect = lambda questions: [
+        {"question_id": q["id"], "question_text": q["question"], "answer": "40 clusters"}
+        for q in questions
+    ]
+    return workflow
+
+
+def test_tailoring_gets_structured_experience_and_run_json_counts(tmp_path):
+    workflow = _workflow()
+    context = {"job_description": "JD", "resume": "Resume", "source_documents": "Sources"}
+
+    result = workflow.execute(context)
+
+    assert result.status is RunStatus.COMPLETED
+    asked = workflow.user_interaction.conduct_interview.call_args.args[0]
+    assert asked == [{"theme": "platform", "question": "How big was the fleet?", "id": "q1"}]
+    experience = workflow.tailoring_agent.execute.call_args.args[0]["interview_experience"]
+    assert experience[0]["theme"] == "platform" and experience[0]["metrics"] == ["40"]
+
+    run_dir = write_run_artifacts(tmp_path, result, run_id="run-1")
+    manifest = json.loads((run_dir / MANIFEST_FILE).read_text())
+    assert manifest["interview"] == {"questions": 1, "answered": 1, "reused": 0, "skipped": 0}
+    entries = load_transcript(run_dir)
+    assert entries == [
+        {
+            "question": "How big was the fleet?",
+            "theme": "platform",
+            "gap": "",
+            "answer": "40 clusters",
+            "from_run": "run-1",
+        }
+    ]
+
+
+def test_a_later_run_reuses_matching_answers_and_carries_the_rest(tmp_path):
+    entries = [
+        {
+            "question": "How big was your Kubernetes fleet?",
+            "answer": "40 clusters",
+            "from_run": "run-1",
+        },
+        {
+            "question": "Anything else?",
+            "gap": "SLOs",
+            "answer": "Wrote the SLO policy",
+            "from_run": "run-0",
+        },
+    ]
+    questions = normalize_questions(QUESTIONS)
+
+    reused, remaining = reuse_answers(questions, entries, "run-1")
+
+    assert [q["id"] for q in remaining] == ["q2", "impact-1"]
+    assert [(n["question_id"], n["answer"], n["reused_from"]) for n in reused] == [
+        ("q1", "40 clusters", "run-1"),
+        ("reused-1", "Wrote the SLO policy", "run-0"),
+    ]
+
+    workflow = _workflow()
+    context = {
+        "job_description": "JD",
+        "resume": "Resume",
+        "source_documents": "Sources",
+        "reused_interview": {"run_id": "run-1", "entries": entries},
+    }
+    result = workflow.execute(context)
+
+    # The prepper's only question matched; nothing is asked, both answers reach tailoring.
+    workflow.user_interaction.conduct_interview.assert_not_called()
+    experience = workflow.tailoring_agent.execute.call_args.args[0]["interview_experience"]
+    assert [e["answer"] for e in experience] == ["40 clusters", "Wrote the SLO policy"]
+    assert experience[1]["gap"] == "SLOs"
+    inputs = RunInputs(jd_path="jd.md", resume_path="r.md", reuse_interview="run-1")
+    run_dir = write_run_artifacts(tmp_path, result, run_id="run-2", inputs=inputs)
+    manifest = json.loads((run_dir / MANIFEST_FILE).read_text())
+    assert manifest["interview"] == {"questions": 1, "answered": 0, "reused": 2, "skipped": 0}
+    assert [e["from_run"] for e in load_transcript(run_dir)] == ["run-1", "run-0"]
+
+    manifest.update(status="paused")
+    (run_dir / MANIFEST_FILE).write_text(json.dumps(manifest))
+    assert resume_arguments(run_dir)[-6:-4] == ["--reuse-interview", "run-1"]
+    (run_dir / TRANSCRIPT_FILE).write_text(json.dumps({"entries": []}))
+    with pytest.raises(ValueError, match="no interview answers"):
+        load_transcript(run_dir)
+    with pytest.raises(ValueError, match="no interview transcript"):
+        load_transcript(tmp_path / "nope")
+
+
+def test_hydra_serve_asks_the_interview_one_question_per_page(tmp_path):
+    run_dir = tmp_path / "live-run"
+    workflow = type("W", (), {"cancel_token": CancelToken()})()
+    interaction = RemoteGreenlight(run_dir, workflow, poll_seconds=0.01)
+    notes = []
+    waiter = threading.Thread(target=lambda: notes.extend(interaction.conduct_interview(QUESTIONS)))
+    waiter.start()
+    while load_session(run_dir) is None:
+        waiter.join(0.01)
+    (run_dir / LIVE_FILE).write_text(json.dumps({"pid": os.getpid()}))
+    app = ReviewServer(tmp_path, TOKEN)
+    target = f"/runs/{run_dir.name}/interview"
+
+    page = app.handle("GET", f"/runs/{run_dir.name}", cookie_token=TOKEN)[2].decode()
+    assert "waiting for interview answers" in page and "question 1/3" in page
+    assert "How big was the Kubernetes fleet?" in page and 'http-equiv="refresh"' not in page
+    assert app.handle("POST", target, {"action": "maybe"}, TOKEN)[0] == 400
+    status, headers, _ = app.handle("POST", target, {"action": "answer", "answer": "40"}, TOKEN)
+    assert status == 303 and headers["Location"] == f"/runs/{run_dir.name}"
+    app.handle("POST", target, {"action": "skip"}, TOKEN)
+    app.handle("POST", target, {"action": "finish"}, TOKEN)
+    waiter.join(5)
+
+    assert [(n["question_id"], n["answer"]) for n in notes] == [("q1", "40")]
+    assert app.handle("POST", target, {"action": "skip"}, TOKEN)[0] == 409
@@ -0,0 +1,163 @@
+"""
+Unit tests for interview scheduling: .ics export, Google Calendar events and prep packs.
+"""
+
+import json
+from datetime import datetime, timezone
+from types import SimpleNamespace
+
+import pytest
+
+from runtime.crewai import cli
+from runtime.crewai.artifacts import MANIFEST_FILE, RunInputs, write_run_artifacts
+from runtime.crewai.debrief import Debrief, save_debrief
+from runtime.crewai.interview_calendar import (
+    GOOGLE_EVENTS_URL,
+    PREP_PACK_FILE,
+    CalendarError,
+    google_event,
+    load_interviews,
+    parse_start,
+    push_to_google,
+    render_ics,
+    schedule_interview,
+    write_prep_pack,
+)
+
+pytestmark = pytest.mark.usefixtures("hydra_home")
+
+RESULTS = {
+    "interrogation": {
+        "questions": [
+            {"question": "Tell me about a migration you led", "theme": "leadership"},
+            "How do you size a Kubernetes cluster?",
+        ]
+    },
+    "gap_analysis": {"gaps": ["Kafka"]},
+    "differentiation": {"differentiators": [{"title": "Migrated 40 services"}]},
+}
+
+
+def _run(tmp_path, run_id="run-1"):
+    result = SimpleNamespace(final_documents={"resume": "Jane Doe\n"}, intermediate_results=RESULTS)
+    return write_run_artifacts(
+        tmp_path / "out",
+        result,
+        run_id=run_id,
+        inputs=RunInputs(company="Globex", role="SRE"),
+        include_intermediate=True,
+    )
+
+
+def test_times_are_read_in_the_interview_time_zone():
+    start = parse_start("2026-10-20 14:00", "Europe/Berlin")
+
+    assert start.astimezone(timezone.utc) == datetime(2026, 10, 20, 12, 0, tzinfo=timezone.utc)
+    assert parse_start("2026-10-20T14:00-04:00").utcoffset().total_seconds() == -4 * 3600
+    with pytest.raises(CalendarError, match="Unknown time zone"):
+        parse_start("2026-10-20 14:00", "Mars/Olympus")
+    with pytest.raises(CalendarError, match="Not a date and time"):
+        parse_start("next tuesday")
+
+
+def test_the_ics_event_is_in_utc_with_reminders_and_the_prep_link(tmp_path):
+    start = parse_start("2026-10-20 14:00", "America/New_York")
+    interview = schedule_interview(
+        _run(tmp_path),
+        start,
+        "Onsite",
+        duration_minutes=90,
+        location="1 Main St, NYC",
+        reminders=[120],
+        tz_name="America/New_York",
+    )
+
+    ics = render_ics(interview, "file:///runs/run-1/interview_prep.md")
+
+    lines = ics.split("\r\n")
+    assert lines[0] == "BEGIN:VCALENDAR" and ics.endswith("END:VCALENDAR\r\n")
+    assert "DTSTART:20261020T180000Z" in lines and "DTEND:20261020T193000Z" in lines
+    assert "SUMMARY:Onsite interview — Globex (SRE)" in lines
+    assert "LOCATION:1 Main St\\, NYC" in lines
+    assert "TRIGGER:-PT120M" in lines
+    assert "URL:file:///runs/run-1/interview_prep.md" in lines
+    assert all(len(line.encode()) <= 75 for line in lines)
+    unfolded = ics.replace("\r\n ", "")
+    assert "2026-10-20 14:00 America/New_York (90 min)\\nPrep pack: file:///runs" in unfolded
+
+
+def test_google_events_carry_the_time_zone_and_reminders(tmp_path):
+    start = parse_start("2026-10-20 14:00", "Europe/Berlin")
+    interview = schedule_interview(_run(tmp_path), start, "Phone screen", tz_name="Europe/Berlin")
+    calls = []
+
+    def post(url, body, headers):
+        calls.append((url, json.loads(body), headers))
+        return '{"id": "evt42"}'
+
+    event_id = push_to_google(interview, "https://prep", {"GOOGLE_CALENDAR_TOKEN": "t"}, post)
+
+    assert event_id == "evt42"
+    [(url, event, headers)] = calls
+    assert url == GOOGLE_EVENTS_URL and headers["Authorization"] == "Bearer t"
+    assert event["start"] == {"dateTime": "2026-10-20T14:00:00+02:00", "timeZone": "Europe/Berlin"}
+    assert event["reminders"]["overrides"] == [
+        {"method": "popup", "minutes": 1440},
+        {"method": "popup", "minutes": 60},
+    ]
+    assert "timeZone" not in google_event(
+        schedule_interview(_run(tmp_path, "run-2"), parse_start("2026-10-20T14:00+02:00"), "X")
+    )["start"]
+    with pytest.raises(CalendarError, match="GOOGLE_CALENDAR_TOKEN not set"):
+        push_to_google(interview, env={})
+
+
+def test_the_prep_pack_gathers_questions_gaps_and_earlier_debriefs(tmp_path):
+    run_dir = _run(tmp_path)
+    earlier = _run(tmp_path, "run-0")
+    save_debrief(
+        earlier,
+        Debrief(
+            company="globex",
+            round="Phone",
+            interview_date="2026-03-01",
+            questions_asked=["Why Globex?"],
+        ),
+    )
+    interview = schedule_interview(run_dir, parse_start("2026-10-20 14:00", "UTC"), "Onsite")
+
+    pack = write_prep_pack(run_dir, interview).read_text()
+
+    assert pack.startswith("# Interview prep: Onsite interview — Globex (SRE)")
+    assert "- Tell me about a migration you led (leadership)" in pack
+    assert "- How do you size a Kubernetes cluster?" in pack
+    assert "## Gaps to have an answer for\n\n- Kafka" in pack
+    assert "## Lead with\n\n- Migrated 40 services" in pack
+    assert "- Why Globex? (Phone, 2026-03-01)" in pack
+
+
+def test_hydra_interview_records_the_interview(tmp_path, capsys):
+    run_dir = _run(tmp_path)
+    out = str(tmp_path / "out")
+
+    args = ["interview", "run-1", "--out", out, "--at", "2026-10-20 14:00", "--tz", "Asia/Tokyo"]
+    assert cli.main([*args, "--round", "Final", "--remind", "30"]) == 0
+
+    assert (run_dir / "interview-1.ics").read_text().count("BEGIN:VALARM") == 1
+    assert (run_dir / PREP_PACK_FILE).is_file()
+    [interview] = load_interviews(run_dir)
+    assert (interview.round, interview.timezone, interview.reminders) == (
+        "Final",
+        "Asia/Tokyo",
+        [30],
+    )
+    manifest = json.loads((run_dir / MANIFEST_FILE).read_text())
+    assert manifest["interviews"] == {"count": 1, "rounds": ["Final"]}
+    assert "interview-1.ics" in manifest["artifacts"]
+
+    assert cli.main(["interview", "run-1", "--out", out]) == 0
+    assert "1. 2026-10-20T14:00+09:00 Asia/Tokyo  Final interview — Globex (SRE)" in (
+        capsys.readouterr().out
+    )
+    with pytest.raises(SystemExit):
+        cli.main(["interview", "run-1", "--out", out, "--at", "soon"])
@@ -0,0 +1,215 @@
+"""
+Unit tests for --jd-url: reading job postings from Greenhouse, Lever, Workday and HTML.
+"""
+
+import html
+import json
+
+import pytest
+
+from runtime.crewai import cli
+from runtime.crewai.artifacts import job_labels
+from runtime.crewai.job_boards import (
+    JobBoardError,
+    board_source,
+    classify_heading,
+    fetch_posting,
+    html_blocks,
+    save_posting,
+)
+
+pytestmark = pytest.mark.usefixtures("hydra_home")
+
+DESCRIPTION = """
+<p>Acme builds the control plane for 3,000 factories.</p>
+<h3>What you'll do</h3>
+<ul><li><p>Run our Kubernetes fleet</p></li><li>Own Terraform modules</li></ul>
+<p><strong>Requirements</strong></p>
+<ul><li>5+ years with Go or Python</li><li>Production Kubernetes</li></ul>
+<p><b>Nice to have:</b></p>
+<p>• Kafka<br>• Rust</p>
+<h3>Benefits</h3>
+<p>Remote-first.</p>
+"""
+
+GREENHOUSE_JOB = {
+    "title": "Senior Platform Engineer",
+    "company_name": "Acme Robotics",
+    "location": {"name": "Berlin"},
+    "departments": [{"name": "Infrastructure"}],
+    "content": html.escape(DESCRIPTION),
+}
+LEVER_POSTING = {
+    "text": "Site Reliability Engineer",
+    "categories": {"team": "SRE", "location": "Remote, EU"},
+    "description": "<div>We keep trains on time.</div>",
+    "lists": [
+        {"text": "Responsibilities", "content": "<li>Own the on-call rotation</li>"},
+        {"text": "Qualifications", "content": "<li>Linux internals</li><li>Go</li>"},
+    ],
+    "additional": "<div>Visa sponsorship available.</div>",
+}
+WORKDAY_JOB = {
+    "jobPostingInfo": {
+        "title": "Data Engineer",
+        "location": "Austin, TX",
+        "jobDescription": "<p><b>Basic Qualifications:</b></p><ul><li>SQL</li></ul>"
+        "<p><b>Preferred Qualifications:</b></p><ul><li>dbt</li></ul>",
+    },
+    "hiringOrganization": {"name": "Globex"},
+}
+JSON_LD_PAGE = """<html><head><title>Careers | Initech</title>
+<script type="application/ld+json">{"@context": "https://schema.org", "@type": "JobPosting",
+ "title": "Backend Engineer", "hiringOrganization": {"@type": "Organization", "name": "Initech"},
+ "jobLocation": {"address": {"addressLocality": "Lisbon"}},
+ "description": "&lt;h2&gt;Requirements&lt;/h2&gt;&lt;ul&gt;&lt;li&gt;Java&lt;/li&gt;&lt;/ul&gt;"}
+</script></head><body><nav>Jobs | Blog</nav><h1>Backend Engineer</h1></body></html>"""
+
+
+def _serving(pages):
+    """A fetcher serving ``pages`` (text, or JSON-encoded data) by URL."""
+
+    def get(url):
+        body = pages[url]
+        return body if isinstance(body, str) else json.dumps(body)
+
+    return get
+
+
+@pytest.mark.parametrize(
+    "url, board, source",
+    [
+        (
+            "https://boards.greenhouse.io/acme/jobs/4012345?gh_src=x",
+            "greenhouse",
+            "https://boards-api.greenhouse.io/v1/boards/acme/jobs/4012345",
+        ),
+        (
+            "https://job-boards.greenhouse.io/acme/jobs/4012345",
+            "greenhouse",
+            "https://boards-api.greenhouse.io/v1/boards/acme/jobs/4012345",
+        ),
+        (
+            "https://jobs.lever.co/trainco/5f1e-9a/apply",
+            "lever",
+            "https://api.lever.co/v0/postings/trainco/5f1e-9a",
+        ),
+        (
+            "https://jobs.eu.lever.co/trainco/5f1e-9a",
+            "lever",
+            "https://api.eu.lever.co/v0/postings/trainco/5f1e-9a",
+        ),
+        (
+            "https://globex.wd5.myworkdayjobs.com/en-US/Careers/job/Austin-TX/Data-Engineer_R123",
+            "workday",
+            "https://globex.wd5.myworkdayjobs.com/wday/cxs/globex/Careers/job/Austin-TX/"
+            "Data-Engineer_R123",
+        ),
+        ("https://initech.com/careers/42", "html", "https://initech.com/careers/42"),
+    ],
+)
+def test_known_boards_are_read_through_their_api(url, board, source):
+    assert board_source(url) == (board, source)
+
+
+def test_headings_are_classified_into_requirement_kinds():
+    assert classify_heading("What You’ll Do") == "responsibilities"
+    assert classify_heading("Basic Qualifications:") == "must_haves"
+    assert classify_heading("Preferred qualifications") == "nice_to_haves"
+    assert classify_heading("Benefits") is None
+    assert html_blocks("<p>• Kafka<br>• Rust</p>") == [("item", "Kafka"), ("item", "Rust")]
+
+
+def test_a_greenhouse_posting_is_split_into_sections():
+    url = "https://boards.greenhouse.io/acme/jobs/4012345"
+    get = _serving({board_source(url)[1]: GREENHOUSE_JOB})
+
+    posting = fetch_posting(url, get)
+
+    assert (posting.title, posting.company, posting.team, posting.location) == (
+        "Senior Platform Engineer",
+        "Acme Robotics",
+        "Infrastructure",
+        "Berlin",
+    )
+    assert posting.responsibilities == ["Run our Kubernetes fleet", "Own Terraform modules"]
+    assert posting.must_haves == ["5+ years with Go or Python", "Production Kubernetes"]
+    assert posting.nice_to_haves == ["Kafka", "Rust"]
+    assert posting.about == [
+        "Acme builds the control plane for 3,000 factories.",
+        "**Benefits**",
+        "Remote-first.",
+    ]
+
+    markdown = posting.to_markdown()
+    assert "## Must-haves\n\n- 5+ years with Go or Python" in markdown
+    assert job_labels(markdown) == ("Acme Robotics", "Senior Platform Engineer")
+
+
+def test_a_lever_posting_reads_its_lists():
+    url = "https://jobs.lever.co/trainco/5f1e-9a"
+    posting = fetch_posting(url, _serving({board_source(url)[1]: LEVER_POSTING}))
+
+    assert (posting.company, posting.team) == ("Trainco", "SRE")
+    assert posting.responsibilities == ["Own the on-call rotation"]
+    assert posting.must_haves == ["Linux internals", "Go"]
+    assert posting.about == ["We keep trains on time.", "Visa sponsorship available."]
+
+
+def test_a_workday_posting_tells_basic_from_preferred_qualifications():
+    url = "https://globex.wd5.myworkdayjobs.com/Careers/job/Austin-TX/Data-Engineer_R123"
+    posting = fetch_posting(url, _serving({board_source(url)[1]: WORKDAY_JOB}))
+
+    assert (posting.company, posting.location) == ("Globex", "Austin, TX")
+    assert posting.must_haves == ["SQL"] and posting.nice_to_haves == ["dbt"]
+
+
+def test_other_pages_use_their_job_posting_data():
+    url = "https://initech.com/careers/42"
+    posting = fetch_posting(url, _serving({url: JSON_LD_PAGE}))
+
+    assert (posting.board, posting.title, posting.company, posting.location) == (
+        "html",
+        "Backend Engineer",
+        "Initech",
+        "Lisbon",
+    )
+    assert posting.must_haves == ["Java"]
+    assert "Jobs | Blog" not in posting.to_markdown()
+
+
+def test_a_page_without_a_posting_is_an_error():
+    with pytest.raises(JobBoardError, match="No job description"):
+        fetch_posting("https://initech.com/404", _serving({"https://initech.com/404": ""}))
+    with pytest.raises(JobBoardError, match="Not an http"):
+        board_source("file:///etc/passwd")
+
+
+def test_saved_postings_are_named_after_their_url(tmp_path):
+    url = "https://boards.greenhouse.io/acme/jobs/4012345"
+    posting = fetch_posting(url, _serving({board_source(url)[1]: GREENHOUSE_JOB}))
+
+    path = save_posting(posting, tmp_path)
+
+    assert path.name == "boards-greenhouse-io-acme-jobs-4012345.md"
+    assert path.read_text() == posting.to_markdown()
+
+
+def test_a_run_takes_its_job_description_from_the_url(tmp_path, monkeypatch, capsys):
+    url = "https://boards.greenhouse.io/acme/jobs/4012345"
+    get = _serving({board_source(url)[1]: GREENHOUSE_JOB})
+    monkeypatch.setattr(cli, "fetch_posting", lambda posting_url: fetch_posting(posting_url, get))
+    (tmp_path / "resume.md").write_text("Jane Doe\nPlatform engineer.")
+    (tmp_path / "sources").mkdir()
+    (tmp_path / "sources" / "notes.md").write_text("Ran Kubernetes.")
+    args = ["--resume", str(tmp_path / "resume.md"), "--out", str(tmp_path / "out")]
+
+    with pytest.raises(SystemExit):
+        cli.main(["--jd-url", url, *args])  # no --sources
+    code = cli.main(["--jd-url", url, "--sources", str(tmp_path / "sources"), *args, "--dry-run"])
+
+    assert code == 0
+    out = capsys.readouterr().out
+    assert "🌐 greenhouse posting: 2 responsibilities, 2 must-haves, 2 nice-to-haves" in out
+    saved = tmp_path / "home" / "job_postings" / "boards-greenhouse-io-acme-jobs-4012345.md"
+    assert saved.is_file()
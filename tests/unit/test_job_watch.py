@@ -0,0 +1,252 @@
+"""
+Unit tests for hydra watch: polling job feeds and queueing high-fit postings.
+"""
+
+import functools
+import json
+
+import pytest
+
+from runtime.crewai import cli
+from runtime.crewai.job_boards import JobBoardError
+from runtime.crewai.job_watch import (
+    APPROVED,
+    HN_ITEM_URL,
+    HN_SEARCH_URL,
+    PENDING,
+    REJECTED,
+    REMOTEOK_URL,
+    Feed,
+    JobQueue,
+    WatchError,
+    configured_feeds,
+    poll,
+)
+from runtime.crewai.skill_taxonomy import default_taxonomy
+
+pytestmark = pytest.mark.usefixtures("hydra_home")
+
+RESUME = "Jane Doe\nPlatform engineer: Kubernetes, Terraform, Python, Go."
+
+GREENHOUSE_BOARD = {
+    "jobs": [
+        {
+            "id": 11,
+            "title": "Platform Engineer",
+            "absolute_url": "https://boards.greenhouse.io/acme/jobs/11",
+            "location": {"name": "Berlin"},
+            "content": "&lt;h3&gt;Requirements&lt;/h3&gt;&lt;ul&gt;&lt;li&gt;Kubernetes and "
+            "Terraform&lt;/li&gt;&lt;li&gt;Python&lt;/li&gt;&lt;/ul&gt;",
+        },
+        {
+            "id": 12,
+            "title": "iOS Engineer",
+            "absolute_url": "https://boards.greenhouse.io/acme/jobs/12",
+            "content": "&lt;h3&gt;Requirements&lt;/h3&gt;&lt;ul&gt;&lt;li&gt;Swift, SwiftUI "
+            "and Objective-C&lt;/li&gt;&lt;/ul&gt;",
+        },
+    ]
+}
+LEVER_BOARD = [
+    {
+        "id": "5f1e",
+        "text": "SRE",
+        "hostedUrl": "https://jobs.lever.co/trainco/5f1e",
+        "categories": {"team": "Ops", "location": "Remote"},
+        "lists": [{"text": "Requirements", "content": "<li>Go</li>"}],
+    }
+]
+HN_THREAD = {"hits": [{"title": "Ask HN: Who is hiring? (October 2026)", "objectID": "900"}]}
+HN_COMMENTS = {
+    "children": [
+        {"id": 901, "text": "Initech | Backend Engineer | Lisbon | REMOTE<p>We use Python."},
+        {"id": 902, "text": None},
+    ]
+}
+REMOTEOK = [
+    {"legal": "API terms"},
+    {"id": "77", "position": "DevOps Engineer", "company": "Globex", "url": "https://r.ok/77"},
+]
+RSS = """<rss><channel>
+<item><title>Data Engineer</title><link>https://jobs.example/1</link><guid>j1</guid>
+<description>&lt;p&gt;SQL and dbt&lt;/p&gt;</description></item>
+</channel></rss>"""
+ATOM = """<feed xmlns="http://www.w3.org/2005/Atom">
+<entry><id>tag:jobs,1</id><title>ML Engineer</title><link href="https://jobs.example/2"/>
+<summary>PyTorch</summary></entry></feed>"""
+
+
+def _serving(pages):
+    """A fetcher serving ``pages`` (text, or JSON-encoded data) by URL."""
+
+    def get(url):
+        body = pages[url]
+        return body if isinstance(body, str) else json.dumps(body)
+
+    return get
+
+
+GREENHOUSE_URL = "https://boards-api.greenhouse.io/v1/boards/acme/jobs?content=true"
+BOARDS = _serving({GREENHOUSE_URL: GREENHOUSE_BOARD})
+
+
+def test_feed_specs_name_a_kind_and_a_target(tmp_path):
+    assert Feed.from_spec("greenhouse:acme") == Feed("greenhouse", "acme")
+    assert Feed.from_spec("rss:https://jobs.example/feed").target == "https://jobs.example/feed"
+    assert Feed.from_spec("hn").spec == "hn"
+    with pytest.raises(WatchError, match="Unknown feed"):
+        Feed.from_spec("indeed:x")
+    with pytest.raises(WatchError, match="needs a target"):
+        Feed.from_spec("lever")
+
+    config = tmp_path / "watch.yaml"
+    config.write_text("feeds: [hn, 'lever:trainco']\n")
+    assert [feed.spec for feed in configured_feeds([], config)] == ["hn", "lever:trainco"]
+    assert [feed.spec for feed in configured_feeds(["remoteok"], config)] == ["remoteok"]
+    assert configured_feeds([], tmp_path / "missing.yaml") == []
+
+
+def test_every_feed_kind_is_read_into_postings():
+    get = _serving(
+        {
+            GREENHOUSE_URL: GREENHOUSE_BOARD,
+            "https://api.lever.co/v0/postings/trainco?mode=json": LEVER_BOARD,
+            HN_SEARCH_URL: HN_THREAD,
+            HN_ITEM_URL.format(id="900"): HN_COMMENTS,
+            REMOTEOK_URL: REMOTEOK,
+            "https://jobs.example/rss": RSS,
+            "https://jobs.example/atom": ATOM,
+        }
+    )
+
+    greenhouse = Feed("greenhouse", "acme").fetch(get)
+    assert sorted(greenhouse) == ["greenhouse:11", "greenhouse:12"]
+    assert greenhouse["greenhouse:11"].must_haves == ["Kubernetes and Terraform", "Python"]
+    assert Feed("lever", "trainco").fetch(get)["lever:5f1e"].must_haves == ["Go"]
+    hn = Feed("hn").fetch(get)
+    assert list(hn) == ["hn:901"]
+    assert (hn["hn:901"].company, hn["hn:901"].title, hn["hn:901"].location) == (
+        "Initech",
+        "Backend Engineer",
+        "Lisbon",
+    )
+    remoteok = Feed("remoteok").fetch(get)
+    assert [posting.company for posting in remoteok.values()] == ["Globex"]
+    assert Feed("rss", "https://jobs.example/rss").fetch(get)["rss:j1"].title == "Data Engineer"
+    atom = Feed("rss", "https://jobs.example/atom").fetch(get)
+    assert atom["rss:tag:jobs,1"].url == "https://jobs.example/2"
+
+
+def test_poll_queues_postings_that_score_high_enough(tmp_path):
+    queue = JobQueue(tmp_path / "watch")
+    scored = []
+
+    def score(job_description, resume):
+        scored.append(job_description)
+        return 82.0
+
+    feed = Feed("greenhouse", "acme")
+    report = poll(feed, RESUME, score, queue, default_taxonomy(), get=BOARDS)
+
+    # The iOS posting shares no skills with the résumé, so no model call is spent on it.
+    assert (report.fetched, report.new, report.below_coverage, report.scored) == (2, 2, 1, 1)
+    assert len(scored) == 1
+    [job] = report.queued
+    assert (job.id, job.title, job.fit_score, job.status) == (
+        "greenhouse-11",
+        "Platform Engineer",
+        82.0,
+        PENDING,
+    )
+    assert "Kubernetes and Terraform" in open(job.jd_path).read()
+    assert queue.get("greenhouse-11") == job
+
+    again = poll(feed, RESUME, score, queue, default_taxonomy(), get=BOARDS)
+    assert (again.new, again.queued, len(scored)) == (0, [], 1)
+
+
+def test_low_scores_are_not_queued_and_failed_scores_are_retried(tmp_path):
+    queue = JobQueue(tmp_path / "watch")
+    feed = Feed("greenhouse", "acme")
+
+    def broken(job_description, resume):
+        raise RuntimeError("model timed out")
+
+    report = poll(feed, RESUME, broken, queue, default_taxonomy(), get=BOARDS)
+    assert report.scored == 0 and "model timed out" in report.error
+    assert "greenhouse:11" not in queue.seen()
+
+    report = poll(feed, RESUME, lambda jd, resume: 40.0, queue, default_taxonomy(), get=BOARDS)
+    assert (report.new, report.scored, report.queued) == (1, 1, [])
+    assert queue.jobs() == []
+
+
+def test_an_unreachable_feed_is_reported():
+    def down(url):
+        raise JobBoardError(f"Could not fetch {url}")
+
+    report = poll(Feed("hn"), RESUME, lambda jd, r: 90.0, JobQueue(), default_taxonomy(), get=down)
+
+    assert report.fetched == 0 and "Could not fetch" in report.error
+
+
+def _watch_once(tmp_path, monkeypatch, *extra):
+    monkeypatch.setattr(cli, "poll", functools.partial(poll, get=BOARDS))
+    monkeypatch.setattr(cli, "_watch_scorer", lambda parser, model: lambda jd, resume: 90.0)
+    (tmp_path / "resume.md").write_text(RESUME)
+    (tmp_path / "sources").mkdir()
+    args = ["--resume", str(tmp_path / "resume.md"), "--sources", str(tmp_path / "sources")]
+    return cli.main(["watch", "--feed", "greenhouse:acme", *args, "--once", *extra])
+
+
+def test_watch_once_polls_and_queues(tmp_path, monkeypatch, capsys):
+    assert _watch_once(tmp_path, monkeypatch) == 0
+
+    out = capsys.readouterr().out
+    assert "🔎 greenhouse:acme: 2 postings, 2 new, 1 below skill coverage, 1 scored" in out
+    assert "⭐ 90 Acme — Platform Engineer [greenhouse-11]" in out
+    [job] = JobQueue().jobs(PENDING)
+    assert job.resume_path == str((tmp_path / "resume.md").resolve())
+
+    assert cli.main(["watch", "queue"]) == 0
+    assert "greenhouse-11" in capsys.readouterr().out
+
+
+def test_approve_starts_a_run_and_reject_drops_the_job(tmp_path, monkeypatch):
+    _watch_once(tmp_path, monkeypatch)
+    runs = []
+    real_main = cli.main
+    monkeypatch.setattr(cli, "main", lambda argv: runs.append(argv) or 0)
+
+    approve = ["watch", "approve", "greenhouse-11", "--out", "runs/", "--variants", "2"]
+    assert real_main(approve) == 0
+
+    job = JobQueue().get("greenhouse-11")
+    assert (job.status, job.exit_code) == (APPROVED, 0)
+    [argv] = runs
+    assert argv[:2] == ["--jd", job.jd_path]
+    assert argv[argv.index("--resume") + 1] == job.resume_path
+    assert argv[-4:] == ["--out", "runs/", "--variants", "2"]
+
+    with pytest.raises(SystemExit):
+        real_main(["watch", "reject", "greenhouse-11"])  # already approved
+    with pytest.raises(SystemExit):
+        real_main(["watch", "approve", "nope"])
+
+
+def test_reject_marks_the_job(tmp_path, monkeypatch, capsys):
+    _watch_once(tmp_path, monkeypatch)
+
+    assert cli.main(["watch", "reject", "greenhouse-11"]) == 0
+
+    assert JobQueue().get("greenhouse-11").status == REJECTED
+    assert JobQueue().jobs(PENDING) == []
+
+
+def test_watch_needs_a_baseline_and_sources(tmp_path):
+    with pytest.raises(SystemExit):
+        cli.main(["watch", "--feed", "hn", "--once"])
+    with pytest.raises(SystemExit):
+        cli.main(["watch", "--feed", "hn", "--resume", "r.md", "--once"])
+    with pytest.raises(SystemExit):
+        cli.main(["watch", "--feed", "bogus", "--resume", "r.md", "--sources", ".", "--once"])
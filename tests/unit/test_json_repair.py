@@ -0,0 +1,134 @@
+"""
+Unit tests for JSON repair: safe fixes to model answers, JSON mode and the re-prompt.
+"""
+
+import sys
+from types import SimpleNamespace
+from unittest.mock import Mock
+
+import pytest
+
+from crewai import LLM, Task
+from runtime.crewai.base_agent import DIRECT_LLM_ENV, BaseHydraAgent, OutputParseError
+from runtime.crewai.gateway import GatewayLLM
+from runtime.crewai.json_repair import (
+    MAX_QUOTED_CHARS,
+    JSONRepairError,
+    json_mode_params,
+    parse_object,
+    repair_instruction,
+)
+
+
+class _Agent(BaseHydraAgent):
+    role = "Test Agent"
+    goal = "Test goal"
+    expected_output = "Test output"
+
+    def execute(self, context):
+        return {}
+
+
+@pytest.mark.parametrize(
+    "answer, expected",
+    [
+        ('Sure! Here it is:\n```json\n{"gaps": ["Go"]}\n```\nLet me know.', {"gaps": ["Go"]}),
+        ('```\n{"a": 1}\n```', {"a": 1}),
+        ('{"a": {"b": 1}} Note: I used {curly} braces.', {"a": {"b": 1}}),
+        ('{"gaps": ["Go", "Kafka",], "n": {"x": 1,},}', {"gaps": ["Go", "Kafka"], "n": {"x": 1}}),
+        ('{"text": "a, ]", "n": [1,\n]}', {"text": "a, ]", "n": [1]}),
+        ('{"summary": "line one\nline two"}', {"summary": "line one\nline two"}),
+        (
+            '{"quote": "the candidate\'s \\"best\\" work {really}"}',
+            {"quote": 'the candidate\'s "best" work {really}'},
+        ),
+    ],
+)
+def test_safe_repairs(answer, expected):
+    assert parse_object(answer) == expected
+
+
+@pytest.mark.parametrize(
+    "answer, message",
+    [
+        ('{"a": 1 "b": 2}', "Invalid JSON output"),
+        ('{"unclosed": "brace"', "Invalid JSON output"),
+        ("[1, 2]", "must be a JSON object"),
+        ("{'single': 'quotes'}", "Invalid JSON output"),
+    ],
+)
+def test_what_is_not_guessed(answer, message):
+    with pytest.raises(JSONRepairError, match=message):
+        parse_object(answer)
+
+
+def test_the_repair_instruction_quotes_the_answer_and_the_error():
+    text = repair_instruction("Expecting ',' delimiter", '{"a": 1 "b": 2}')
+    assert "Expecting ',' delimiter" in text and '{"a": 1 "b": 2}' in text
+
+    long_answer = "x" * (MAX_QUOTED_CHARS + 50)
+    assert "x" * MAX_QUOTED_CHARS + "…" in repair_instruction("e", long_answer)
+    assert "x" * (MAX_QUOTED_CHARS + 1) not in repair_instruction("e", long_answer)
+
+
+def test_json_mode_is_asked_for_where_the_provider_has_it(monkeypatch):
+    supported = {"gpt-4o": ["temperature", "response_format"], "old-model": ["temperature"]}
+    fake = SimpleNamespace(get_supported_openai_params=lambda model: supported[model])
+    monkeypatch.setitem(sys.modules, "litellm", fake)
+
+    assert json_mode_params("gpt-4o") == {"response_format": {"type": "json_object"}}
+    assert json_mode_params("old-model") == {}
+    assert json_mode_params("unknown") == {}  # lookup raised
+    assert json_mode_params(None) == {}
+
+
+def _reply(content):
+    return {"choices": [{"message": {"content": content}}]}
+
+
+def test_an_unparseable_answer_is_sent_back_with_its_parse_error():
+    llm = Mock(spec=GatewayLLM)
+    llm.model = "gw-model"
+    llm.complete.side_effect = [_reply('{"gaps": ["Go"] "confidence": 0.9}'), _reply('{"a": 1}')]
+    agent = _Agent(llm)
+    task = Task(description="Analyse the gaps.", expected_output="JSON", agent=Mock(), context=[])
+
+    result = agent.execute_with_retry(task, max_retries=1)
+
+    assert result["a"] == 1
+    first, second = (call.args[0][-1]["content"] for call in llm.complete.call_args_list)
+    assert "JSON REPAIR" not in first
+    assert "JSON REPAIR" in second and "Expecting ',' delimiter" in second
+    assert '{"gaps": ["Go"] "confidence": 0.9}' in second
+
+
+def test_other_failures_are_retried_with_the_same_task():
+    llm = Mock(spec=GatewayLLM)
+    llm.model = "gw-model"
+    llm.complete.side_effect = [RuntimeError("502"), _reply('{"a": 1}')]
+    agent = _Agent(llm)
+
+    task = Task(description="Go.", expected_output="JSON", agent=Mock(), context=[])
+    agent.execute_with_retry(task, max_retries=1)
+
+    first, second = (call.args[0][-1]["content"] for call in llm.complete.call_args_list)
+    assert first == second
+    with pytest.raises(OutputParseError):
+        agent.validate_output("no json here")
+
+
+def test_direct_calls_ask_for_json_mode(monkeypatch):
+    calls = []
+    fake = SimpleNamespace(
+        get_supported_openai_params=lambda model: ["response_format"],
+        completion=lambda **kwargs: calls.append(kwargs) or _reply('{"a": 1}'),
+    )
+    monkeypatch.setitem(sys.modules, "litellm", fake)
+    monkeypatch.setenv(DIRECT_LLM_ENV, "1")
+    task = Task(description="Go.", expected_output="JSON", agent=Mock(), context=[])
+
+    _Agent(LLM(model="gpt-4o", api_key="k")).execute_with_retry(task)
+    _Agent(LLM(model="gpt-4o", api_key="k"), use_json_mode=False).execute_with_retry(task)
+
+    assert calls[0]["response_format"] == {"type": "json_object"}
+    assert "response_format" not in calls[1]
@@ -0,0 +1,110 @@
+"""
+Unit tests for JSON Resume input and output.
+"""
+
+import json
+from unittest.mock import Mock, patch
+
+from runtime.crewai.agents.tailoring_agent import TailoringAgent
+from runtime.crewai.json_resume import (
+    JsonResumeError,
+    extract_tailored,
+    looks_like_json_resume,
+    parse_json_resume,
+    to_markdown,
+    validate,
+)
+
+DOCUMENT = {
+    "$schema": "https://raw.githubusercontent.com/jsonresume/resume-schema/v1.0.0/schema.json",
+    "basics": {
+        "name": "Jane Doe",
+        "label": "Platform Engineer",
+        "email": "jane@example.com",
+        "location": {"city": "Berlin", "countryCode": "DE"},
+        "profiles": [{"network": "GitHub", "url": "https://github.com/janedoe"}],
+    },
+    "work": [
+        {
+            "name": "Acme",
+            "position": "Staff Engineer",
+            "startDate": "2021-03",
+            "highlights": ["Cut deploy time 40%"],
+        },
+        {"name": "Initech", "position": "Engineer", "startDate": "2017", "endDate": "2021-02"},
+    ],
+    "skills": [{"name": "Cloud", "keywords": ["AWS", "Terraform"]}],
+    "education": [{"institution": "TU Berlin", "studyType": "BSc", "area": "CS"}],
+    "x-custom": {"kept": True},
+}
+
+
+def test_a_valid_document_parses():
+    assert validate(DOCUMENT) == []
+    assert parse_json_resume(json.dumps(DOCUMENT))["x-custom"] == {"kept": True}
+
+
+def test_schema_violations_are_reported_by_path():
+    document = {
+        "basics": {"name": 42},
+        "work": [{"name": "Acme", "startDate": "March 2021"}],
+        "skills": [{"keywords": "AWS"}],
+    }
+
+    errors = validate(document)
+
+    assert any(e.startswith("basics.name:") for e in errors)
+    assert any(e.startswith("work.0.startDate:") for e in errors)
+    assert any(e.startswith("skills.0.keywords:") for e in errors)
+    assert validate(["not", "an", "object"]) == ["document: must be a JSON object"]
+    try:
+        parse_json_resume(json.dumps(document))
+    except JsonResumeError as e:
+        assert e.errors == errors
+    else:
+        raise AssertionError("invalid document accepted")
+
+
+def test_json_resume_files_are_told_apart_from_prose():
+    assert looks_like_json_resume(json.dumps(DOCUMENT))
+    assert looks_like_json_resume('{"basics": {}}')
+    assert not looks_like_json_resume("# Jane Doe\n{not json}")
+    assert not looks_like_json_resume('{"requirements": []}')
+
+
+def test_document_renders_as_a_markdown_resume():
+    text = to_markdown(DOCUMENT)
+
+    assert text.startswith("# Jane Doe\n**Platform Engineer**\n\nBerlin | jane@example.com")
+    assert "https://github.com/janedoe" in text
+    assert "### Acme — Staff Engineer\n**2021-03 – Present**" in text
+    assert "- Cut deploy time 40%" in text
+    assert "**2017 – 2021-02**" in text
+    assert "**Cloud:** AWS, Terraform" in text
+    assert "BSc CS — TU Berlin" in text
+
+
+def test_tailored_document_is_read_from_the_tailoring_result():
+    assert extract_tailored({"tailored_json_resume": DOCUMENT}) == DOCUMENT
+    assert extract_tailored({"json_resume": json.dumps(DOCUMENT)}) == DOCUMENT
+    assert extract_tailored({"tailored_resume": "# Jane"}) is None
+
+
+def test_tailoring_is_asked_for_a_document_only_when_wanted():
+    agent = TailoringAgent(Mock())
+    context = {
+        "job_description": "Platform engineer",
+        "resume": "Jane Doe",
+        "interview_notes": "",
+        "differentiators": [],
+        "gap_analysis": {},
+    }
+
+    with patch.object(agent, "execute_with_retry", return_value={}):
+        with patch.object(agent, "create_task") as create_task:
+            agent.execute(context)
+            assert "tailored_json_resume" not in create_task.call_args.args[0]
+
+            agent.execute({**context, "json_resume": DOCUMENT})
+            prompt = create_task.call_args.args[0]
+            assert "tailored_json_resume" in prompt and '"x-custom"' in prompt
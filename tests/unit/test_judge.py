@@ -0,0 +1,119 @@
+"""
+Unit tests for the judge: rubric scores, the stage, and where the scores are shown.
+"""
+
+import json
+from pathlib import Path
+from types import SimpleNamespace
+
+import pytest
+
+from runtime.crewai.artifacts import MANIFEST_FILE, write_run_artifacts
+from runtime.crewai.cli import _report_judge
+from runtime.crewai.contracts import JudgeScores
+from runtime.crewai.hydra_workflow import HydraWorkflow, RunStatus
+from runtime.crewai.judge import lowest, manifest_summary, overall, report_lines
+from runtime.crewai.run_control import resume_arguments
+from runtime.crewai.run_report import build_run_report, render_markdown
+from runtime.crewai.scenarios import canned_model, load_scenario
+
+SCENARIO = Path(__file__).resolve().parents[1] / "scenarios" / "default.yaml"
+JUDGEMENT = {
+    "scores": {
+        "relevance": {"score": 8, "rationale": "AWS and Kubernetes lead."},
+        "truthfulness": {"score": 10, "rationale": "Every claim is in the sources."},
+        "readability": {"score": 6, "rationale": ""},
+    }
+}
+
+
+def test_scores_are_parsed_clamped_and_summarized():
+    raw = {
+        "judge": {
+            "scores": {
+                "Relevance": {"score": "8/10", "rationale": "Leads with AWS."},
+                "truthfulness": {"score": 12, "reason": "Backed."},
+                "ATS friendliness": 0.4,
+                "tone": {"score": 5},
+            }
+        }
+    }
+
+    scores = JudgeScores.from_raw(raw).scores
+
+    assert scores == {
+        "relevance": {"score": 8, "rationale": "Leads with AWS."},
+        "truthfulness": {"score": 10, "rationale": "Backed."},
+        "ats_friendliness": {"score": 1, "rationale": ""},
+    }
+    assert JudgeScores.from_raw({"verdict": "fine"}).scores == {}
+
+    assert overall(JUDGEMENT) == 8.0 and lowest(JUDGEMENT) == 6
+    assert overall(None) is None and lowest({"scores": {}}) is None
+    assert report_lines(JUDGEMENT) == [
+        "Relevance: 8/10 — AWS and Kubernetes lead.",
+        "Truthfulness: 10/10 — Every claim is in the sources.",
+        "Readability: 6/10",
+    ]
+    assert manifest_summary(JUDGEMENT) == {
+        "scores": {"relevance": 8, "truthfulness": 10, "readability": 6},
+        "overall": 8.0,
+    }
+
+
+def _run(judge_response):
+    scenario = load_scenario(SCENARIO)
+    workflow = HydraWorkflow(
+        SimpleNamespace(model="test/recorded"),
+        use_per_agent_models=False,
+        auto_approve=True,
+        judge=True,
+    )
+    with canned_model(workflow, {**scenario.responses, "judge": judge_response}) as model:
+        result = workflow.execute(dict(scenario.inputs))
+    return result, model
+
+
+def test_the_judge_scores_the_audited_resume_and_never_fails_the_run():
+    result, model = _run(JUDGEMENT)
+
+    assert result.status is RunStatus.COMPLETED
+    assert result.judge["scores"]["truthfulness"]["score"] == 10
+    assert model.stages[-1] == "judge" and "auditor_suite" in model.stages
+
+    # An answer with no scores is rejected; the run completes without them.
+    result, _ = _run({"verdict": "looks good"})
+    assert result.status is RunStatus.COMPLETED and result.judge is None
+    assert any("Judge failed" in line for line in result.execution_log)
+
+
+def test_scores_reach_the_manifest_report_and_console(tmp_path, capsys):
+    class Result:
+        final_documents = {"resume": "# Jane"}
+        judge = JUDGEMENT
+
+    run_dir = write_run_artifacts(tmp_path, Result(), run_id="run-1")
+
+    manifest = json.loads((run_dir / MANIFEST_FILE).read_text())
+    assert manifest["judge"] == manifest_summary(JUDGEMENT)
+    assert "AWS and Kubernetes lead" not in json.dumps(manifest)
+
+    report = build_run_report({"gap_analysis": {"gaps": []}}, {"final_status": "APPROVED"})
+    report.judge = JUDGEMENT
+    text = render_markdown(report)
+    assert "## Quality scores\n\nOverall: 8.0/10\n\n- Relevance: 8/10" in text
+
+    _report_judge(JUDGEMENT, True)
+    _report_judge(None, True)
+    _report_judge(None, False)
+    out = capsys.readouterr().out
+    assert "Judge: 8.0/10 (Relevance 8, Truthfulness 10, Readability 6)" in out
+    assert out.count("could not be judged") == 1
+
+
+@pytest.mark.parametrize("judge", [True, False])
+def test_resume_keeps_the_judge_flag(tmp_path, judge):
+    inputs = {"jd_path": "jd.md", "resume_path": "resume.md", "judge": judge}
+    (tmp_path / MANIFEST_FILE).write_text(json.dumps({"status": "paused", "inputs": inputs}))
+
+    assert ("--judge" in resume_arguments(tmp_path)) is judge
@@ -0,0 +1,130 @@
+"""
+Unit tests for the candidate knowledge base: gathering facts, merging, ranking and use.
+"""
+
+import json
+from types import SimpleNamespace
+from unittest.mock import Mock, patch
+
+import pytest
+from crewai import LLM
+
+from runtime.crewai import cli
+from runtime.crewai.agents.interrogator_prepper import InterrogatorPrepperAgent
+from runtime.crewai.agents.tailoring_agent import TailoringAgent
+from runtime.crewai.artifacts import MANIFEST_FILE, write_run_artifacts
+from runtime.crewai.knowledge_base import (
+    INTERVIEW,
+    SOURCES,
+    KnowledgeBase,
+    facts_from_interview,
+    facts_from_sources,
+    render_facts,
+)
+
+pytestmark = pytest.mark.usefixtures("hydra_home")
+
+SOURCES_TEXT = """# Billing platform
+
+- Migrated billing to PostgreSQL in March 2022, cutting p99 latency 40%
+- Good at things
+- Pairing with the data team on weekly reviews
+
+## Observability
+- Rolled out Prometheus alerting for 12 services
+"""
+EXPERIENCE = [
+    {
+        "question": "How big was the fleet?",
+        "theme": "platform",
+        "gap": "Kubernetes",
+        "answer": "Ran 40 Kubernetes clusters on AWS",
+    }
+]
+
+
+def test_facts_are_gathered_from_sources_and_interviews():
+    facts = facts_from_sources(SOURCES_TEXT, "run-1")
+
+    assert [f.project for f in facts] == ["Billing platform", "Observability"]
+    billing = facts[0]
+    assert billing.text == "Migrated billing to PostgreSQL in March 2022, cutting p99 latency 40%"
+    assert billing.origin == SOURCES and billing.dates == ["March 2022"]
+    assert "40%" in billing.metrics and "2022" not in billing.metrics
+    assert "PostgreSQL" in billing.tools
+
+    (answer,) = facts_from_interview(EXPERIENCE, "run-1")
+    assert answer.origin == INTERVIEW and answer.project == "Kubernetes"
+    assert answer.metrics == ["40"] and "AWS" in answer.tools
+
+
+def test_facts_merge_across_runs_and_rank_by_the_job():
+    knowledge = KnowledgeBase()
+    assert knowledge.add(facts_from_sources(SOURCES_TEXT, "run-1")) == 2
+    assert knowledge.add(facts_from_interview(EXPERIENCE, "run-1")) == 1
+    # The same statement, reworded only in case and punctuation, is merged.
+    again = facts_from_sources("- rolled out prometheus alerting for 12 services.", "run-2")
+    assert knowledge.add(again) == 0
+
+    facts = knowledge.facts()
+    assert len(facts) == 3
+    assert facts[1].runs == ["run-1", "run-2"]
+
+    jd = "SRE: Kubernetes on AWS, Prometheus alerting."
+    ranked = knowledge.relevant(jd)
+    assert [f.text for f in ranked] == [
+        "Ran 40 Kubernetes clusters on AWS",
+        "Rolled out Prometheus alerting for 12 services",
+    ]
+    assert knowledge.relevant("Chef de cuisine") == []
+    assert "- [Observability] Rolled out Prometheus alerting for 12 services (sources, 2 runs)" in (
+        render_facts(ranked)
+    )
+
+    assert knowledge.forget(facts[0].id) and not knowledge.forget(facts[0].id)
+    assert len(knowledge.facts()) == 2
+
+
+def test_known_facts_reach_the_interrogator_and_tailoring_prompts():
+    facts = [f.to_dict() for f in facts_from_interview(EXPERIENCE, "run-1")]
+    context = {
+        "job_description": "JD",
+        "resume": "Resume",
+        "gaps": ["Kubernetes"],
+        "gap_analysis": {},
+        "interview_notes": [],
+        "differentiators": [],
+        "knowledge_facts": facts,
+    }
+    with patch("runtime.crewai.base_agent.Path.read_text", return_value="Prompt"):
+        llm = LLM(model="gpt-4", api_key="test-key")
+        agents = [InterrogatorPrepperAgent(llm), TailoringAgent(llm)]
+    for agent in agents:
+        agent.execute_with_retry = Mock(return_value={"questions": [], "tailored_resume": "R"})
+        agent.execute(context)
+        prompt = agent.execute_with_retry.call_args[0][0].description
+        assert "- [Kubernetes] Ran 40 Kubernetes clusters on AWS (interview, 1 run)" in prompt
+
+
+def test_a_run_adds_its_facts_and_run_json_counts_them(tmp_path, capsys):
+    result = SimpleNamespace(
+        final_documents={"resume": "# Jane"},
+        intermediate_results={"interrogation": {"experience": EXPERIENCE}},
+    )
+    run_dir = write_run_artifacts(tmp_path, result, run_id="run-1")
+    knowledge = KnowledgeBase()
+
+    cli._grow_knowledge_base(knowledge, run_dir, result, SOURCES_TEXT, {"knowledge_facts": [{}]})
+
+    assert len(knowledge.facts()) == 3
+    manifest = json.loads((run_dir / MANIFEST_FILE).read_text())
+    assert manifest["knowledge_base"] == {"facts_used": 1, "facts_added": 3}
+    assert "Knowledge base: 3 new fact(s)" in capsys.readouterr().out
+
+    assert cli.main(["knowledge"]) == 0
+    assert "Ran 40 Kubernetes clusters on AWS" in capsys.readouterr().out
+    fact_id = knowledge.facts()[0].id
+    assert cli.main(["knowledge", "forget", fact_id]) == 0
+    assert len(knowledge.facts()) == 2
+    with pytest.raises(SystemExit):
+        cli.main(["knowledge", "forget", fact_id])
@@ -0,0 +1,177 @@
+"""
+Unit tests for the résumé's length and readability limits, and the trims after tailoring.
+"""
+
+import json
+from types import SimpleNamespace
+
+import pytest
+
+from runtime.crewai import cli
+from runtime.crewai.artifacts import MANIFEST_FILE, write_run_artifacts
+from runtime.crewai.length_limits import (
+    LengthPolicy,
+    check_length,
+    cut_bullets,
+    estimate_pages,
+    grade_level,
+    syllables,
+)
+from runtime.crewai.pipeline_config import PipelineConfig, PipelineConfigError
+from tests.conftest import patched_workflow
+
+
+def _resume(bullets_per_role=4, roles=2, bullet="- Led the move of a service to Kubernetes."):
+    lines = ["# Jane Doe", "jane@example.com", "", "## Summary", "Platform engineer.", ""]
+    lines.append("## Experience")
+    for role in range(1, roles + 1):
+        lines += [f"### Engineer {role} | Acme", "2019 - present"]
+        lines += [f"{bullet} ({role}.{n})" for n in range(1, bullets_per_role + 1)]
+    lines += ["", "## Skills", "Python, Kubernetes"]
+    return "\n".join(lines) + "\n"
+
+
+LONG = _resume(bullets_per_role=9, roles=4, bullet="- " + "Shipped the platform work. " * 7)
+SHORT = _resume()
+
+
+def test_policy_reads_the_length_section():
+    assert PipelineConfig().length == LengthPolicy(2, 6, None, 2)
+    config = PipelineConfig.from_dict(
+        {"length": {"max_pages": 1, "max_bullets_per_role": 0, "max_grade_level": 12}}
+    )
+    assert config.length.to_dict() == {
+        "max_pages": 1.0,
+        "max_bullets_per_role": None,
+        "max_grade_level": 12.0,
+        "trim_passes": 2,
+    }
+    off = LengthPolicy.from_dict({"max_pages": 0, "max_bullets_per_role": None})
+    assert off.enabled is False
+    for bad, match in (
+        ({"max_pages": "two"}, "length.max_pages must be a positive number"),
+        ({"max_bullets_per_role": 4.5}, "length.max_bullets_per_role must be a whole number"),
+        ({"trim_passes": 9}, "length.trim_passes must be between 0 and 5"),
+        ({"pages": 2}, "unknown length setting"),
+    ):
+        with pytest.raises(PipelineConfigError, match=match):
+            PipelineConfig.from_dict({"length": bad})
+
+
+def test_pages_bullets_and_grade_are_measured():
+    assert estimate_pages("") == 0
+    assert estimate_pages("x" * 95 * 50) == 1.0  # one line wrapped fifty times
+    assert estimate_pages(LONG) > 2
+
+    assert [syllables(word) for word in ("led", "cable", "infrastructure", "code")] == [1, 2, 4, 1]
+    assert grade_level([]) is None
+    assert grade_level(["The cat sat on the mat."]) < 0
+    plain, dense = grade_level(["I ran it."]), grade_level(
+        ["Orchestrated organization-wide infrastructure modernization initiatives."]
+    )
+    assert dense > 20 > plain
+
+    report = check_length(LONG, LengthPolicy(max_grade_level=3))
+    assert report.long_roles == [
+        {"role": "Engineer 1 | Acme", "bullets": 9},
+        {"role": "Engineer 2 | Acme", "bullets": 9},
+        {"role": "Engineer 3 | Acme", "bullets": 9},
+        {"role": "Engineer 4 | Acme", "bullets": 9},
+    ]
+    assert report.over_pages and report.over_grade
+    assert len(report.violations) == 6 and len(report.instructions()) == 3
+    assert check_length(SHORT, LengthPolicy()).violations == []
+
+
+def test_cutting_keeps_each_roles_first_bullets():
+    cut = cut_bullets(LONG, 6)
+
+    assert check_length(cut, LengthPolicy()).long_roles == []
+    assert "(1.6)" in cut and "(1.7)" not in cut and "(3.6)" in cut
+    assert cut.endswith("## Skills\nPython, Kubernetes\n")
+    assert cut_bullets(SHORT, 6) == SHORT
+
+    wrapped = (
+        "## Experience\n### Engineer | Acme\n- one\n- two that wraps\n  onto a second line\n"
+        "- three that wraps\n  onto a second line\n\n## Skills\nPython\n"
+    )
+    third = "- three that wraps\n  onto a second line\n"
+    assert cut_bullets(wrapped, 2) == wrapped.replace(third, "")
+
+
+def _workflow(length, first, *trims):
+    workflow = patched_workflow(auto_approve=True, pipeline_config=PipelineConfig(length=length))
+    workflow.tailoring_agent.execute.side_effect = [
+        {"tailored_resume": text, "tailored_cover_letter": "Letter", "confidence": 0.9}
+        for text in (first, *trims)
+    ]
+    return workflow
+
+
+def _tailor(workflow):
+    context = {"job_description": "JD", "resume": SHORT, "source_documents": ""}
+    result = workflow._execute_tailoring(context, {}, {}, {})
+    return result["tailored_resume"]
+
+
+def test_a_long_resume_is_trimmed_then_cut():
+    shorter = _resume(bullets_per_role=8)
+    workflow = _workflow(LengthPolicy(), LONG, shorter)
+
+    resume = _tailor(workflow)
+
+    trim_context = workflow.tailoring_agent.execute.call_args_list[1].args[0]
+    assert trim_context["length_limits"]["resume"] == LONG
+    assert trim_context["length_limits"]["instructions"][0].startswith("Cut the resume to 2 ")
+    assert resume == cut_bullets(shorter, 6)
+    assert workflow.intermediate_results["tailoring"]["tailored_resume"] == resume
+    enforcement = workflow.length_enforcement
+    assert enforcement["trims"] == 1 and enforcement["bullets_cut"] == 4
+    assert enforcement["before"]["pages"] > 2
+    assert enforcement["after"]["violations"] == []
+
+
+def test_a_trim_that_is_no_closer_is_not_kept():
+    policy = LengthPolicy(max_bullets_per_role=None, trim_passes=2)
+    workflow = _workflow(policy, LONG, LONG + LONG, "")
+
+    assert _tailor(workflow) == LONG
+    assert workflow.tailoring_agent.execute.call_count == 3
+    assert workflow.length_enforcement["trims"] == 2
+    assert workflow.length_enforcement["after"]["violations"] == ["about 2.5 pages (at most 2)"]
+
+    within = _workflow(LengthPolicy(), SHORT)
+    assert _tailor(within) == SHORT
+    assert within.tailoring_agent.execute.call_count == 1
+    assert within.length_enforcement["before"]["violations"] == []
+
+    off = _workflow(LengthPolicy(max_pages=None, max_bullets_per_role=None), LONG)
+    assert _tailor(off) == LONG and off.length_enforcement is None
+
+
+def test_run_json_and_the_cli_report_the_trims(tmp_path, capsys):
+    enforcement = {
+        "limits": LengthPolicy().to_dict(),
+        "trims": 1,
+        "bullets_cut": 12,
+        "before": check_length(LONG, LengthPolicy()).to_dict(),
+        "after": check_length(cut_bullets(LONG, 6), LengthPolicy(max_pages=1.5)).to_dict(),
+    }
+    result = SimpleNamespace(final_documents={"resume": "# Jane"}, length=enforcement)
+    run_dir = write_run_artifacts(tmp_path, result, run_id="run-1")
+    manifest = json.loads((run_dir / MANIFEST_FILE).read_text())
+
+    assert manifest["length"]["before"] == {
+        "pages": enforcement["before"]["pages"],
+        "grade_level": enforcement["before"]["grade_level"],
+        "long_roles": 4,
+        "violations": 5,
+    }
+    assert manifest["length"]["bullets_cut"] == 12
+    assert "Acme" not in str(manifest["length"])
+
+    cli._report_length(enforcement)
+    out = capsys.readouterr().out
+    assert "📏 Résumé over its length limits: about" in out
+    assert "(1 trim(s), 12 bullet(s) cut)" in out
+    assert "   - still about 1.8 pages (at most 1.5)" in out
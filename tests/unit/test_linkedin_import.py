@@ -0,0 +1,126 @@
+"""
+Unit tests for importing a LinkedIn data export as a baseline résumé.
+"""
+
+import json
+import zipfile
+
+import pytest
+
+from runtime.crewai.cli import main
+from runtime.crewai.linkedin_import import (
+    LinkedInImportError,
+    missing_sections,
+    parse_export,
+    render_markdown,
+)
+
+PROFILE = (
+    "First Name,Last Name,Maiden Name,Address,Birth Date,Headline,Summary,Industry,"
+    "Zip Code,Geo Location,Twitter Handles,Websites,Instant Messengers\n"
+    'Jane,Doe,,,,Platform Engineer,"Builds boring, reliable infrastructure.",'
+    "Software,,\"Berlin, Germany\",,,\n"
+)
+POSITIONS = (
+    "Company Name,Title,Description,Location,Started On,Finished On\n"
+    'Acme,Staff Engineer,"- Cut deploy time 40%\n- Led the Kubernetes migration",'
+    "Berlin,Mar 2021,\n"
+    "Initech,Engineer,,Remote,Jan 2017,Feb 2021\n"
+)
+EDUCATION = (
+    "School Name,Start Date,End Date,Notes,Degree Name,Activities\n"
+    "TU Berlin,2012,2016,,BSc Computer Science,\n"
+)
+SKILLS = "Name\nTerraform\nGo\n"
+EMAILS = (
+    "Email Address,Confirmed,Primary,Updated On\n"
+    "old@example.com,Yes,No,1/1/15\n"
+    "jane@example.com,Yes,Yes,1/1/20\n"
+)
+CERTIFICATIONS = (
+    "Notes:\n"
+    '"Certifications you added to your profile."\n'
+    "\n"
+    "Name,Url,Authority,Started On,Finished On,License Number\n"
+    "CKA,,CNCF,Jan 2022,,\n"
+)
+
+
+def _export(tmp_path, **files):
+    path = tmp_path / "Basic_LinkedInDataExport.zip"
+    with zipfile.ZipFile(path, "w") as archive:
+        for name, text in files.items():
+            archive.writestr(name, text)
+    return path
+
+
+def _full_export(tmp_path):
+    return _export(
+        tmp_path,
+        **{
+            "Profile.csv": PROFILE,
+            "Positions.csv": POSITIONS,
+            "Education.csv": EDUCATION,
+            "Skills.csv": SKILLS,
+            "Email Addresses.csv": EMAILS,
+            "Certifications.csv": CERTIFICATIONS,
+        },
+    )
+
+
+def test_export_is_parsed_into_a_profile(tmp_path):
+    profile = parse_export(_full_export(tmp_path))
+
+    assert profile.name == "Jane Doe"
+    assert profile.headline == "Platform Engineer"
+    assert profile.location == "Berlin, Germany"
+    assert profile.email == "jane@example.com"  # the primary one
+    assert [p.company for p in profile.positions] == ["Acme", "Initech"]
+    assert profile.positions[0].dates == "Mar 2021 – Present"
+    assert profile.skills == ["Terraform", "Go"]
+    assert profile.certifications == ["CKA — CNCF"]  # the "Notes:" preamble is skipped
+    assert missing_sections(profile) == []
+
+
+def test_markdown_follows_the_sample_resume_layout(tmp_path):
+    text = render_markdown(parse_export(_full_export(tmp_path)))
+
+    assert text.startswith("# Jane Doe\n**Platform Engineer**\n\nBerlin, Germany | jane@")
+    assert "### Acme — Staff Engineer\n**Mar 2021 – Present | Berlin**" in text
+    assert "- Cut deploy time 40%\n- Led the Kubernetes migration" in text
+    assert "## Skills\n\nTerraform, Go" in text
+    assert "BSc Computer Science — TU Berlin, 2012–2016" in text
+    assert text.index("## Experience") < text.index("## Skills") < text.index("## Education")
+
+
+def test_a_partial_export_leaves_sections_empty(tmp_path):
+    profile = parse_export(_export(tmp_path, **{"Profile.csv": PROFILE}))
+
+    assert profile.positions == [] and profile.email == ""
+    assert missing_sections(profile) == ["experience", "skills", "education"]
+    assert "## Experience" not in render_markdown(profile)
+
+
+def test_other_files_are_rejected(tmp_path):
+    not_zip = tmp_path / "resume.md"
+    not_zip.write_text("# Jane")
+    with pytest.raises(LinkedInImportError, match="not a LinkedIn export"):
+        parse_export(not_zip)
+    with pytest.raises(LinkedInImportError, match="no Profile.csv"):
+        parse_export(_export(tmp_path, **{"Skills.csv": SKILLS}))
+
+
+def test_import_linkedin_subcommand_writes_the_resume(tmp_path, capsys):
+    export = _full_export(tmp_path)
+    output = tmp_path / "resume.md"
+
+    assert main(["import-linkedin", str(export), "-o", str(output)]) == 0
+    assert output.read_text().startswith("# Jane Doe")
+    assert "2 position(s), 2 skill(s)" in capsys.readouterr().out
+
+    with pytest.raises(SystemExit):
+        main(["import-linkedin", str(export), "-o", str(output)])  # no --force
+
+    as_json = tmp_path / "resume.json"
+    main(["import-linkedin", str(export), "-o", str(as_json), "--format", "json"])
+    assert json.loads(as_json.read_text())["positions"][1]["company"] == "Initech"
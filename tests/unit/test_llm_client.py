@@ -5,13 +5,16 @@ Tests LLM client initialization, configuration, and error handling.
 """
 
 import os
+import threading
 from unittest.mock import Mock, patch
 
 import pytest
 
+from runtime.crewai.cancellation import CancelToken, RunCancelled
 from runtime.crewai.llm_client import (
     LLMClientError,
     LLMRetryHandler,
+    complete_batch,
     get_available_models,
     get_llm_client,
     validate_model_name,
@@ -144,3 +147,46 @@ class TestLLMRetryHandler:
         # Delays should be: 1.0, 2.0, 4.0
         assert handler.base_delay == 1.0
         assert handler.max_retries == 3
+
+
+class TestCompleteBatch:
+    """Test suite for complete_batch"""
+
+    def test_requests_run_together_and_fail_one_by_one(self):
+        """Requests run concurrently; an error stays with its request"""
+        together = threading.Barrier(3, timeout=5)
+
+        def answer(value):
+            together.wait()
+            return value
+
+        def fail():
+            together.wait()
+            raise ValueError("provider down")
+
+        results = complete_batch([lambda: answer("a"), fail, lambda: answer("c")])
+
+        assert [r.value for r in results] == ["a", None, "c"]
+        assert [r.ok for r in results] == [True, False, True]
+        assert str(results[1].error) == "provider down"
+        assert complete_batch([]) == []
+
+    def test_cancelled_run_skips_waiting_requests(self):
+        """Once the run is cancelled, requests not yet started never start"""
+        token = CancelToken()
+        started = []
+
+        def first():
+            started.append("first")
+            token.cancel("user cancelled")
+            return "done"
+
+        with pytest.raises(RunCancelled, match="user cancelled"):
+            complete_batch([first, lambda: started.append("second")], token, max_workers=1)
+        assert started == ["first"]
+
+        # A stop at the stage boundary lets the batch finish with its answers.
+        token = CancelToken()
+        token.stop_at_boundary()
+        assert complete_batch([lambda: "kept"], token)[0].value == "kept"
+
@@ -0,0 +1,85 @@
+"""
+Unit tests for export-time personal-data policy enforcement.
+"""
+
+import json
+from types import SimpleNamespace
+
+from runtime.crewai.artifacts import write_run_artifacts
+from runtime.crewai.locale_policy import apply_locale_policy, detect_fields, get_policy
+
+RESUME_WITH_PERSONAL_DATA = """# Jane Doe
+![Portrait](photo.jpg)
+- Date of birth: 1 May 1990
+- Marital status: married
+- Email: jane@example.com
+
+## Experience
+Born to build platforms; led the Kubernetes migration.
+"""
+
+
+def test_get_policy_is_case_insensitive_and_accepts_aliases():
+    assert get_policy("us").country == "US"
+    assert get_policy("UK").country == "GB"
+    assert get_policy("ZZ") is None
+
+
+def test_detect_fields_finds_labelled_fields_only():
+    found = detect_fields(RESUME_WITH_PERSONAL_DATA)
+
+    assert found["photo"] == [1]
+    assert found["date_of_birth"] == [2]
+    assert found["marital_status"] == [3]
+
+
+def test_forbidden_fields_are_removed_with_warnings():
+    report = apply_locale_policy({"resume": RESUME_WITH_PERSONAL_DATA}, get_policy("US"))
+
+    cleaned = report.documents["resume"]
+    assert "photo.jpg" not in cleaned
+    assert "Date of birth" not in cleaned
+    assert "Marital status" not in cleaned
+    # Unlabelled prose and other contact lines are untouched.
+    assert "Born to build platforms" in cleaned
+    assert "jane@example.com" in cleaned
+    assert sorted(report.removed) == [
+        "resume:date_of_birth",
+        "resume:marital_status",
+        "resume:photo",
+    ]
+    assert len(report.warnings) == 3
+
+
+def test_expected_fields_warn_but_are_never_invented():
+    resume = "# Jane Doe\n\n## Experience\nPlatform lead.\n"
+
+    report = apply_locale_policy({"resume": resume, "cover_letter": "Dear team"}, get_policy("JP"))
+
+    assert report.documents["resume"] == resume
+    assert report.removed == []
+    assert any("photo is customary in JP" in w for w in report.warnings)
+    assert any("date of birth is customary in JP" in w for w in report.warnings)
+    # Cover letters are not expected to carry a photo or birth date.
+    assert not any(w.startswith("cover_letter") for w in report.warnings)
+
+
+def test_optional_fields_are_left_alone():
+    report = apply_locale_policy({"resume": RESUME_WITH_PERSONAL_DATA}, get_policy("DE"))
+
+    assert report.documents["resume"] == RESUME_WITH_PERSONAL_DATA
+    assert report.warnings == []
+
+
+def test_policy_summary_lands_in_manifest(tmp_path):
+    report = apply_locale_policy({"resume": RESUME_WITH_PERSONAL_DATA}, get_policy("GB"))
+    result = SimpleNamespace(
+        status=None, final_documents=report.documents, audit_report=None, execution_log=[]
+    )
+
+    run_dir = write_run_artifacts(tmp_path, result, run_id="run-1", locale_policy=report)
+
+    manifest = json.loads((run_dir / "run.json").read_text())
+    assert manifest["locale_policy"]["country"] == "GB"
+    assert manifest["locale_policy"]["warnings"] == 3
+    assert "resume:photo" in manifest["locale_policy"]["removed"]
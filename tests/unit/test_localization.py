@@ -0,0 +1,119 @@
+"""
+Unit tests for output languages: date conventions, themed output and the prompts.
+"""
+
+import json
+from types import SimpleNamespace
+from unittest.mock import Mock, patch
+
+import pytest
+from crewai import LLM
+
+from runtime.crewai.agents.ats_optimizer import ATSOptimizerAgent
+from runtime.crewai.agents.tailoring_agent import TailoringAgent
+from runtime.crewai.artifacts import MANIFEST_FILE, RunInputs, write_run_artifacts
+from runtime.crewai.cli import main
+from runtime.crewai.localization import get_language, language_instruction, localize_dates
+from runtime.crewai.resume_themes import (
+    BUILTIN_THEMES_DIR,
+    LATEX_FILE,
+    THEMED_MARKDOWN_FILE,
+    load_theme,
+    parse_resume,
+    render,
+)
+from runtime.crewai.run_control import resume_arguments
+
+RESUME = """# Jana Novak
+
+Berlin | jana@example.com
+
+## Experience
+
+### Acme — Staff Engineer
+*Mar 2021 – Present | Remote*
+
+- Led the Terraform migration
+
+### Globex — Platform Engineer
+*2018-06 – 02/2021*
+
+- Built the CI pipeline
+"""
+
+
+def test_dates_are_written_the_language_way():
+    german, french = get_language("DE"), get_language("fr")
+    assert get_language("xx") is None
+
+    assert localize_dates("Mar 2021 – Present", german) == "03/2021 – heute"
+    assert localize_dates("September 2019 – current", french) == "sept. 2019 – aujourd'hui"
+    assert localize_dates("2018-06 – 2/2021", get_language("es")) == "jun. 2018 – feb. 2021"
+    # Years alone, ranges of years and other numbers are left as they are.
+    untouched = "2016–2019, 40% of 2020-2021"
+    assert localize_dates(untouched, german) == untouched
+    # "Present" and "current" are dates only where they end a range.
+    prose = "- Rebuilt the current platform; present at KubeCon 2022"
+    assert localize_dates(prose, german) == prose
+    assert localize_dates("2019 to present", german) == "2019 to heute"
+
+    assert language_instruction(None, "the resume") == ""
+    assert "Write dates as 03/2021 – heute." in language_instruction("de", "the resume")
+
+
+def test_themed_output_uses_the_language_for_dates_and_babel():
+    theme = load_theme("classic", [BUILTIN_THEMES_DIR])
+    resume = parse_resume(RESUME)
+
+    markdown = render(resume, theme, "markdown", get_language("de"))
+    latex = render(resume, theme, "latex", get_language("de"))
+    assert "*03/2021 – heute | Remote*" in markdown
+    assert "*06/2018 – 02/2021*" in markdown
+    assert r"\usepackage[ngerman]{babel}" in latex
+
+    # Without a language the dates stay as written.
+    assert "*Mar 2021 – Present | Remote*" in render(resume, theme, "markdown")
+    assert r"\usepackage[english]{babel}" in render(resume, theme, "latex")
+
+
+def test_the_agents_are_asked_for_the_language():
+    context = {
+        "job_description": "JD",
+        "resume": "Resume",
+        "tailored_resume": "Resume",
+        "gap_analysis": {},
+        "interview_notes": [],
+        "differentiators": [],
+        "output_language": "fr",
+    }
+    with patch("runtime.crewai.base_agent.Path.read_text", return_value="Prompt"):
+        llm = LLM(model="gpt-4", api_key="test-key")
+        agents = [TailoringAgent(llm), ATSOptimizerAgent(llm)]
+    for agent in agents:
+        agent.execute_with_retry = Mock(return_value={"tailored_resume": "R"})
+        agent.execute(context)
+        prompt = agent.execute_with_retry.call_args[0][0].description
+        assert "in French (fr)" in prompt and "sept." not in prompt
+
+
+def test_a_run_records_its_language_and_render_reuses_it(tmp_path, monkeypatch, capsys):
+    monkeypatch.setattr("shutil.which", lambda name: None)
+    result = SimpleNamespace(final_documents={"resume": RESUME}, status=None)
+    inputs = RunInputs(jd_path="jd.md", resume_path="r.md", lang="de")
+    run_dir = write_run_artifacts(tmp_path, result, run_id="run-1", inputs=inputs)
+    manifest = json.loads((run_dir / MANIFEST_FILE).read_text())
+    manifest["status"] = "paused"
+    (run_dir / MANIFEST_FILE).write_text(json.dumps(manifest))
+    args = resume_arguments(run_dir)
+    assert args[args.index("--lang") + 1] == "de"
+
+    assert main(["render", str(run_dir)]) == 0
+    assert "03/2021 – heute" in (run_dir / THEMED_MARKDOWN_FILE).read_text()
+    assert json.loads((run_dir / MANIFEST_FILE).read_text())["theme"]["lang"] == "de"
+    assert main(["render", str(run_dir), "--lang", "nl"]) == 0
+    assert r"\usepackage[dutch]{babel}" in (run_dir / LATEX_FILE).read_text()
+    capsys.readouterr()
+
+    with pytest.raises(SystemExit):
+        main(["render", str(run_dir), "--lang", "klingon"])
+    assert "unknown language 'klingon'" in capsys.readouterr().err
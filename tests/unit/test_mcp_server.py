@@ -0,0 +1,183 @@
+"""
+Unit tests for the MCP server that drives Hydra runs over JSON-RPC.
+"""
+
+import io
+import json
+
+import pytest
+
+from runtime.crewai.hydra_workflow import RunStatus, WorkflowResult, WorkflowState
+from runtime.crewai.mcp_server import (
+    INVALID_PARAMS,
+    METHOD_NOT_FOUND,
+    PARSE_ERROR,
+    HydraRuns,
+    McpServer,
+    serve,
+)
+
+GAPS = {"gaps": [{"requirement": "Kubernetes", "severity": "high"}]}
+QUESTIONS = {"questions": [{"id": "q1", "question": "Have you run Kubernetes?"}]}
+
+
+class _Workflow:
+    """Pauses for the greenlight, then for interview answers, then completes."""
+
+    contexts = []
+
+    def execute(self, context):
+        self.contexts.append(context)
+        if not context.get("gap_analysis_approved"):
+            return self._paused(WorkflowState.GAP_ANALYSIS_REVIEW, {"gap_analysis": GAPS})
+        if not context.get("interview_answers"):
+            return self._paused(WorkflowState.INTERROGATION_REVIEW, {"interrogation": QUESTIONS})
+        print("noise from an agent")  # must not reach the protocol stream
+        return WorkflowResult(
+            state=WorkflowState.COMPLETED,
+            success=True,
+            final_documents={"resume": "# Jane\nTailored", "cover_letter": "Dear Acme"},
+            audit_report={"final_status": "APPROVED"},
+            execution_log=["done"],
+        )
+
+    @staticmethod
+    def _paused(state, results):
+        return WorkflowResult(
+            state=state,
+            success=True,
+            status=RunStatus.PAUSED,
+            intermediate_results=results,
+            execution_log=[],
+        )
+
+
+@pytest.fixture
+def server(tmp_path):
+    _Workflow.contexts = []
+    return McpServer(HydraRuns(tmp_path, _Workflow))
+
+
+def _call(server, name, **arguments):
+    response = server.handle(
+        {
+            "jsonrpc": "2.0",
+            "id": 1,
+            "method": "tools/call",
+            "params": {"name": name, "arguments": arguments},
+        }
+    )
+    result = response["result"]
+    text = result["content"][0]["text"]
+    structured = not result["isError"] and text[:1] in "[{"
+    return (json.loads(text) if structured else text), result["isError"]
+
+
+def test_initialize_and_tool_listing(server):
+    init = server.handle(
+        {
+            "jsonrpc": "2.0",
+            "id": 0,
+            "method": "initialize",
+            "params": {"protocolVersion": "2024-11-05"},
+        }
+    )["result"]
+    assert init["protocolVersion"] == "2024-11-05"
+    assert set(init["capabilities"]) == {"tools", "resources"}
+
+    tools = server.handle({"jsonrpc": "2.0", "id": 1, "method": "tools/list"})["result"]["tools"]
+    names = {tool["name"] for tool in tools}
+    assert names == {
+        "start_run",
+        "get_run",
+        "list_runs",
+        "answer_greenlight",
+        "answer_interview",
+        "read_output",
+    }
+    start = next(tool for tool in tools if tool["name"] == "start_run")
+    assert start["inputSchema"]["required"] == ["job_description", "resume"]
+
+
+def test_conversation_from_start_to_outputs(server):
+    run, _ = _call(server, "start_run", job_description="SRE at Acme", resume="# Jane\nSRE")
+    run_id = run["run_id"]
+
+    paused, _ = _call(server, "get_run", run_id=run_id, wait_seconds=5)
+    assert paused["awaiting"] == "greenlight" and paused["gaps"] == GAPS["gaps"]
+
+    _call(server, "answer_greenlight", run_id=run_id, approve=True, notes="Lead with SRE")
+    paused, _ = _call(server, "get_run", run_id=run_id, wait_seconds=5)
+    assert paused["awaiting"] == "interview_answers"
+    assert paused["questions"] == QUESTIONS["questions"]
+
+    answers = [{"question_id": "q1", "answer": "Yes, 3 years"}]
+    _call(server, "answer_interview", run_id=run_id, answers=answers)
+    done, _ = _call(server, "get_run", run_id=run_id, wait_seconds=5)
+
+    assert done["status"] == "completed" and done["audit"]["passed"] is True
+    assert f"hydra://runs/{run_id}/cover_letter.md" in done["resources"]
+    last = _Workflow.contexts[-1]
+    assert last["greenlight_notes"] == "Lead with SRE" and last["interview_answers"] == answers
+    assert last["previous_results"]["gap_analysis"] == GAPS
+
+    letter, _ = _call(server, "read_output", run_id=run_id, file="cover_letter.md")
+    assert letter == "Dear Acme"
+    uri = f"hydra://runs/{run_id}/cover_letter.md"
+    read = server.handle(
+        {"jsonrpc": "2.0", "id": 2, "method": "resources/read", "params": {"uri": uri}}
+    )
+    assert read["result"]["contents"][0]["mimeType"] == "text/markdown"
+    listed = server.handle({"jsonrpc": "2.0", "id": 3, "method": "resources/list"})
+    assert {
+        "uri": f"hydra://runs/{run_id}/run.json",
+        "name": f"{run_id}/run.json",
+        "mimeType": "application/json",
+    } in listed["result"]["resources"]
+
+
+def test_declining_and_answering_out_of_turn(server):
+    run, _ = _call(server, "start_run", job_description="JD", resume="# Jane")
+    run_id = run["run_id"]
+    _call(server, "get_run", run_id=run_id, wait_seconds=5)
+
+    message, is_error = _call(server, "answer_interview", run_id=run_id, answers=[])
+    assert is_error and "not waiting for interview_answers" in message
+
+    declined, _ = _call(server, "answer_greenlight", run_id=run_id, approve=False)
+    assert declined["status"] == "declined"
+    assert _call(server, "list_runs")[0] == [
+        {"run_id": run_id, "status": "declined", "awaiting": None}
+    ]
+
+
+def test_bad_calls_and_paths(server):
+    message, is_error = _call(server, "get_run")
+    assert is_error and message == "missing required argument: run_id"
+    message, is_error = _call(server, "read_output", run_id="..", file="etc/passwd")
+    assert is_error and message.startswith("no such output")
+
+    unknown = server.handle(
+        {"jsonrpc": "2.0", "id": 4, "method": "tools/call", "params": {"name": "rm"}}
+    )
+    assert unknown["error"]["code"] == INVALID_PARAMS
+    missing = server.handle({"jsonrpc": "2.0", "id": 5, "method": "prompts/list"})
+    assert missing["error"]["code"] == METHOD_NOT_FOUND
+
+
+def test_stdio_transport_keeps_stdout_for_protocol(server, capsys):
+    lines = [
+        json.dumps({"jsonrpc": "2.0", "id": 1, "method": "ping"}),
+        json.dumps({"jsonrpc": "2.0", "method": "notifications/initialized"}),
+        "{not json",
+    ]
+    stdin = io.StringIO("\n".join(lines) + "\n")
+    stdout = io.StringIO()
+
+    serve(server, stdin, stdout)
+    print("after")  # stdout is restored once serving ends
+
+    responses = [json.loads(line) for line in stdout.getvalue().splitlines()]
+    assert responses[0] == {"jsonrpc": "2.0", "id": 1, "result": {}}
+    assert responses[1]["error"]["code"] == PARSE_ERROR and len(responses) == 2
+    assert capsys.readouterr().out == "after\n"
@@ -0,0 +1,125 @@
+"""
+Unit tests for automatic model routing from past runs' audit and eval records.
+"""
+
+import json
+from unittest.mock import Mock, patch
+
+from runtime.crewai.artifacts import MANIFEST_FILE
+from runtime.crewai.cli import main
+from runtime.crewai.hydra_workflow import HydraWorkflow
+from runtime.crewai.model_routing import (
+    ModelRouting,
+    ModelStats,
+    collect_stats,
+    configured_spec,
+    fallback_spec,
+    plan_routing,
+)
+
+SONNET = configured_spec("tailoring_agent")
+LLAMA = fallback_spec("tailoring_agent")
+GPT = "openai:gpt-4o"
+
+
+def _run(out_dir, index, models, passed, score=None):
+    run_dir = out_dir / f"20261001-1200{index:02d}-abcdef"
+    run_dir.mkdir(parents=True)
+    manifest = {"run_id": run_dir.name, "audit": {"passed": passed}, "models": models}
+    if score is not None:
+        manifest["eval"] = {"score": score}
+    (run_dir / MANIFEST_FILE).write_text(json.dumps(manifest))
+
+
+def _history(out_dir, runs):
+    """``runs``: (tailoring model as the manifest records it, audit passed) pairs."""
+    for index, (model, passed) in enumerate(runs):
+        _run(out_dir, index, {"tailoring_agent": model}, passed)
+
+
+def test_stats_map_recorded_models_to_specs_and_skip_unaudited_runs(tmp_path):
+    config_model = SONNET.partition(":")[2]
+    _run(tmp_path, 1, {"tailoring_agent": config_model, "gap_analyzer": "fallback"}, True)
+    _run(tmp_path, 2, {"tailoring_agent": GPT}, False, score=0.8)
+    _run(tmp_path, 3, {"tailoring_agent": GPT}, None)
+
+    stats = collect_stats(tmp_path)
+
+    assert set(stats) == {"tailoring_agent"}
+    assert stats["tailoring_agent"][SONNET].passed == 1
+    record = stats["tailoring_agent"][GPT]
+    assert (record.runs, record.passed, record.eval_scores) == (1, 0, [0.8])
+    assert record.score == 0.4
+
+
+def test_a_clearly_better_model_is_promoted_with_its_record_as_the_reason(tmp_path):
+    _history(tmp_path, [(SONNET, i < 3) for i in range(6)] + [(GPT, i < 5) for i in range(6)])
+    routing = ModelRouting(tmp_path / "routing.json")
+
+    changes = routing.update(collect_stats(tmp_path))
+
+    assert [(c.agent, c.old, c.new, c.kind) for c in changes] == [
+        ("tailoring_agent", SONNET, GPT, "promote")
+    ]
+    assert "audit passed 5/6" in changes[0].reason and "audit passed 3/6" in changes[0].reason
+    assert routing.overrides() == {"tailoring_agent": GPT}
+    assert routing.load()["history"][0]["new"] == GPT
+    # Re-running on the same record changes nothing.
+    assert routing.update(collect_stats(tmp_path)) == []
+
+
+def test_small_samples_and_narrow_margins_leave_routing_alone():
+    few = {"tailoring_agent": {SONNET: ModelStats(6, 3), GPT: ModelStats(4, 4)}}
+    close = {"tailoring_agent": {SONNET: ModelStats(10, 7), GPT: ModelStats(10, 7, [0.85])}}
+
+    assert plan_routing(few, {}) == []
+    assert plan_routing(close, {}) == []
+
+
+def test_a_failing_model_is_demoted(tmp_path):
+    stats = {"tailoring_agent": {SONNET: ModelStats(8, 2)}}
+    assert [(c.new, c.kind) for c in plan_routing(stats, {})] == [(LLAMA, "demote")]
+
+    # A routed model that stops passing goes back to the configured one.
+    stats = {"tailoring_agent": {GPT: ModelStats(8, 1)}}
+    routing = ModelRouting(tmp_path / "routing.json")
+    routing.path.write_text(json.dumps({"overrides": {"tailoring_agent": GPT}}))
+    changes = routing.update(stats)
+    assert [(c.new, c.kind) for c in changes] == [(SONNET, "demote")]
+    assert routing.overrides() == {}
+
+    # ...unless the step down has done even worse.
+    stats = {"tailoring_agent": {SONNET: ModelStats(8, 2), LLAMA: ModelStats(8, 1)}}
+    assert plan_routing(stats, {}) == []
+
+
+def test_workflow_uses_a_routed_model_and_falls_back_when_it_cannot():
+    routed_llm = Mock(model="gpt-4o")
+    with (
+        patch("runtime.crewai.hydra_workflow.get_llm_for_spec", return_value=routed_llm),
+        patch("runtime.crewai.hydra_workflow.get_llm_for_agent", return_value=Mock()),
+        patch("runtime.crewai.hydra_workflow.get_agent_model_info", return_value={"model": "m"}),
+    ):
+        workflow = HydraWorkflow(Mock(), model_routing={"tailoring_agent": GPT})
+    assert workflow.tailoring_agent.llm is routed_llm
+    assert workflow.agent_models["tailoring_agent"] == GPT
+    assert workflow.agent_models["gap_analyzer"] == "m"
+
+    with patch("runtime.crewai.hydra_workflow.get_llm_for_agent", return_value=Mock()):
+        workflow = HydraWorkflow(Mock(), model_routing={"tailoring_agent": "nope"})
+    assert workflow.agent_models["tailoring_agent"] != "nope"
+
+
+def test_routing_subcommand_reports_records_and_changes(tmp_path, monkeypatch, capsys):
+    monkeypatch.setenv("HYDRA_HOME", str(tmp_path / "home"))
+    _history(tmp_path, [(SONNET, i < 2) for i in range(6)] + [(GPT, True) for _ in range(6)])
+    ModelRouting().update(collect_stats(tmp_path))
+
+    assert main(["routing", "--out", str(tmp_path)]) == 0
+    report = capsys.readouterr().out
+    assert f"tailoring_agent: {GPT} (routed)" in report
+    assert "audit passed 2/6" in report
+    assert f"{SONNET} → {GPT} (promoted;" in report
+
+    assert main(["routing", "--reset"]) == 0
+    assert ModelRouting().overrides() == {}
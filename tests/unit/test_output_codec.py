@@ -0,0 +1,96 @@
+"""
+Unit tests for the output codec: YAML or JSON agent answers, kept as canonical JSON data.
+"""
+
+import json
+from datetime import date, datetime
+from unittest.mock import Mock
+
+import pytest
+import yaml
+
+from runtime.crewai.artifacts import load_checkpoint, write_stage_output
+from runtime.crewai.base_agent import BaseHydraAgent, OutputParseError
+from runtime.crewai.output_codec import canonical, decode_yaml, to_json, to_yaml
+
+
+class _Agent(BaseHydraAgent):
+    role = "Test Agent"
+    goal = "Test goal"
+    expected_output = "Test output"
+
+    def execute(self, context):
+        return {}
+
+
+@pytest.mark.parametrize(
+    "answer",
+    [
+        "Here you go:\n```yaml\ngaps:\n  - Kafka\nconfidence: 0.8\n```\nThanks!",
+        "```yml\ngaps: [Kafka]\nconfidence: 0.8\n```",
+        "gaps:\n  - Kafka\nconfidence: 0.8\n",
+    ],
+)
+def test_yaml_answers_are_decoded(answer):
+    assert decode_yaml(answer) == {"gaps": ["Kafka"], "confidence": 0.8}
+
+
+@pytest.mark.parametrize(
+    "answer",
+    [
+        "Sorry, I cannot help with that.",
+        "Note: this is prose with a colon.",
+        "Summary: the candidate fits.\nI hope this helps!",
+        "```yaml\n- just\n- a list\n```",
+        "```yaml\nkey: [unclosed\n```",
+        "",
+    ],
+)
+def test_what_is_not_yaml_output(answer):
+    assert decode_yaml(answer) is None
+
+
+def test_canonical_data_is_what_json_would_round_trip():
+    value = {
+        "since": date(2021, 3, 1),
+        "at": datetime(2026, 1, 2, 3, 4, 5),
+        2019: "year key",
+        True: "bool key",
+        "tags": ("a", "b"),
+        "nan": float("nan"),
+        "nested": [{"when": date(2020, 1, 1)}],
+    }
+
+    result = canonical(value)
+
+    assert result == {
+        "since": "2021-03-01",
+        "at": "2026-01-02T03:04:05",
+        "2019": "year key",
+        "true": "bool key",
+        "tags": ["a", "b"],
+        "nan": None,
+        "nested": [{"when": "2020-01-01"}],
+    }
+    assert json.loads(json.dumps(result)) == result
+    assert json.loads(to_json(value)) == result
+    assert yaml.safe_load(to_yaml(value)) == result
+
+
+def test_agents_accept_yaml_and_store_json_data():
+    agent = _Agent(Mock())
+
+    parsed = agent.validate_output("started: 2021-03-01\nroles:\n  - SRE\nconfidence: 0.9\n")
+
+    assert parsed["started"] == "2021-03-01"  # not a date object
+    assert (parsed["roles"], parsed["confidence"]) == (["SRE"], 0.9)
+    assert agent.validate_output('{"a": 1}')["a"] == 1
+    with pytest.raises(OutputParseError, match="Invalid JSON output"):
+        agent.validate_output('{"a": 1 "b": 2}')  # broken JSON is not read as YAML
+
+
+def test_stage_outputs_are_written_as_yaml_and_read_back(tmp_path):
+    path = write_stage_output(tmp_path, "gap_analysis", {"gaps": ("Kafka",), "résumé": "ok"})
+
+    assert "résumé: ok" in path.read_text()
+    assert load_checkpoint(tmp_path)[0]["gap_analysis"] == {"gaps": ["Kafka"], "résumé": "ok"}
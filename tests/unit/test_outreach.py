@@ -0,0 +1,288 @@
+"""
+Unit tests for the outreach stage: channel limits, the agent and the artifacts.
+"""
+
+import json
+from unittest.mock import MagicMock, Mock, patch
+
+import pytest
+import yaml
+from crewai import LLM
+
+from runtime.crewai.agents.outreach import OutreachAgent
+from runtime.crewai.artifacts import MANIFEST_FILE, write_run_artifacts
+from runtime.crewai.base_agent import ValidationError
+from runtime.crewai.cli import _outreach_pick, _report_outreach
+from runtime.crewai.contracts import OutreachMessages
+from runtime.crewai.hydra_workflow import HydraWorkflow
+from runtime.crewai.outreach import (
+    OPENER,
+    OUTREACH_FILE,
+    OUTREACH_PREFERENCES_FILE,
+    OUTREACH_VARIANTS_FILE,
+    SUBJECT_LINE,
+    check_options,
+    fit_to_limit,
+    rank_options,
+    record_choice,
+    render_outreach,
+)
+from runtime.crewai.run_control import resume_arguments
+from runtime.crewai.tailoring_variants import VariantPreferences
+from runtime.crewai.workflow_templates import BUILTIN_TEMPLATES
+
+LONG_NOTE = (
+    "Hi — I've applied for your Platform Engineer role. I led a 400-service Kubernetes "
+    "migration at Acme and cut deploy time by 40%. I'd value connecting. "
+    + "I also wrote the runbooks the on-call team still uses today. " * 3
+)
+RAW = {
+    "recipient": "Hiring Manager",
+    "variants": {
+        "connection_request": LONG_NOTE,
+        "inmail": {"subject": "Platform Engineer", "body": "Hi — I applied.\n\nThanks."},
+        "email": {"subject": "Application: Platform Engineer", "message": "Hello, I applied."},
+        "fax": {"message": "Not a channel"},
+    },
+    "differentiators_used": ["Led a 400-service Kubernetes migration"],
+    "research_used": [1, 7],
+}
+SUBJECTS = [
+    {"text": "Cut deploys 40% at Acme", "rationale": "Leads with a number", "angle": "Result"},
+    {"text": "Your Series C and platform", "why": "Shows research", "angle": "company"},
+    {"text": "Quick question on the platform team", "angle": "pun"},
+]
+OPTIONS = {
+    SUBJECT_LINE: check_options(SUBJECT_LINE, SUBJECTS),
+    OPENER: [
+        {"rank": 1, "text": "I led a migration.", "rationale": "Proof", "angle": "result"},
+        {"rank": 2, "text": "Congrats on the raise.", "rationale": "Warm", "angle": "company"},
+    ],
+}
+# The options as the outreach result holds them.
+OFFERED = {"subject_lines": OPTIONS[SUBJECT_LINE], "openers": OPTIONS[OPENER]}
+SOURCES = [{"id": 1, "title": "Acme raises Series C", "url": "https://a.example/1"}]
+
+
+def test_fit_to_limit_cuts_to_the_last_sentence_or_word():
+    assert fit_to_limit("  Short note.  ", 300) == "Short note."
+    cut = fit_to_limit(LONG_NOTE, 300)
+    assert len(cut) <= 300 and cut.endswith("still uses today.")
+    assert len(cut) < len(LONG_NOTE.strip())
+    words = fit_to_limit("one two three four five six seven", 20)
+    assert words == "one two three four…" and len(words) <= 20
+
+
+def test_contract_reads_variants_in_any_shape():
+    messages = OutreachMessages.from_raw(RAW)
+
+    assert messages.recipient == "hiring manager"
+    assert list(messages.variants) == ["connection_request", "inmail", "email"]
+    assert messages.variants["connection_request"]["subject"] == ""
+    assert messages.variants["inmail"]["message"] == "Hi — I applied.\n\nThanks."
+    # A single flat message is the email.
+    flat = OutreachMessages.from_raw({"subject": "Hello", "message": "I applied."})
+    assert flat.variants == {"email": {"subject": "Hello", "message": "I applied."}}
+
+
+def test_options_are_parsed_ranked_and_checked():
+    raw = {
+        **RAW,
+        "subject_lines": [
+            {"text": "Second", "rank": 2},
+            {"subject": "First", "rank": "1", "rationale": "Best"},
+            "Third",
+        ],
+        "openers": SUBJECTS,
+    }
+    messages = OutreachMessages.from_raw(raw)
+
+    assert [o["text"] for o in messages.subject_lines] == ["First", "Second", "Third"]
+    assert messages.subject_lines[0]["rationale"] == "Best"
+    assert messages.openers[1]["rationale"] == "Shows research"  # "why" is a rationale
+    checked = check_options(SUBJECT_LINE, SUBJECTS + SUBJECTS + [{"text": "x" * 200}])
+    assert [o["rank"] for o in checked] == [1, 2, 3]  # duplicates dropped, three kept
+    assert [o["angle"] for o in checked] == ["result", "company", "other"]
+
+
+def test_agent_retries_until_three_options_of_each_kind():
+    with patch("runtime.crewai.base_agent.Path.read_text", return_value="Outreach prompt"):
+        agent = OutreachAgent(LLM(model="gpt-4", api_key="test-key"))
+
+    with pytest.raises(ValidationError, match="offered 2 of 3 openers"):
+        agent._validate_schema({**RAW, "subject_lines": SUBJECTS, "openers": SUBJECTS[:2]})
+    agent._validate_schema({**RAW, "subject_lines": SUBJECTS, "openers": SUBJECTS})
+
+
+def test_picks_are_recorded_and_rank_the_angles_in_later_runs(tmp_path):
+    prefs = VariantPreferences(tmp_path / OUTREACH_PREFERENCES_FILE)
+    outreach = {"subject_lines": OPTIONS[SUBJECT_LINE]}
+
+    choice = record_choice(outreach, SUBJECT_LINE, 2, run_id="run-1", preferences=prefs)
+    record_choice(outreach, SUBJECT_LINE, 2, run_id="run-1", preferences=prefs)
+
+    assert choice == {"variant_kind": SUBJECT_LINE, "rank_chosen": 2, "run_id": "run-1"}
+    assert outreach["choices"] == [choice]  # a later pick of the same kind replaces it
+    assert prefs.load()["subject_line:company"] == {"wins": 2, "runs": 2}
+    ranked = rank_options(SUBJECT_LINE, OPTIONS[SUBJECT_LINE], prefs)
+    assert [(o["rank"], o["angle"]) for o in ranked][:2] == [(1, "company"), (2, "result")]
+    with pytest.raises(ValueError, match="No opener ranked 1"):
+        record_choice(outreach, OPENER, 1)
+
+
+def test_interactive_run_asks_for_a_pick_of_each_kind(tmp_path):
+    prefs = VariantPreferences(tmp_path / OUTREACH_PREFERENCES_FILE)
+    workflow = HydraWorkflow(
+        MagicMock(), use_per_agent_models=False, interactive=True, outreach_preferences=prefs
+    )
+    workflow.user_interaction = MagicMock()
+    workflow.user_interaction.pick_outreach.side_effect = [3, None]
+    result = dict(OFFERED)
+
+    workflow._pick_outreach_options(result)
+
+    kinds = [c.args[0] for c in workflow.user_interaction.pick_outreach.call_args_list]
+    assert kinds == [SUBJECT_LINE, OPENER]
+    assert result["choices"] == [{"variant_kind": SUBJECT_LINE, "rank_chosen": 3, "run_id": None}]
+    assert prefs.load()["subject_line:other"]["wins"] == 1
+
+
+def test_agent_holds_variants_to_channel_limits_and_checks_citations():
+    with patch("runtime.crewai.base_agent.Path.read_text", return_value="Outreach prompt"):
+        agent = OutreachAgent(LLM(model="gpt-4", api_key="test-key"))
+    agent.execute_with_retry = Mock(return_value=RAW)
+
+    result = agent.execute(
+        {
+            "job_description": "Platform Engineer at Acme",
+            "tailored_resume": "# Jane\n- Led a 400-service Kubernetes migration",
+            "recipient": "recruiter",
+            "research": {"sources": SOURCES},
+        }
+    )
+
+    connection = result["variants"]["connection_request"]
+    assert connection["trimmed"] and connection["chars"] <= connection["limit"] == 300
+    assert "subject" not in connection
+    assert result["variants"]["email"]["trimmed"] is False
+    assert result["recipient"] == "recruiter"
+    assert result["research_used"] == [1]  # 7 is not a real source
+    assert result["subject_lines"] == result["openers"] == []
+    prompt = agent.execute_with_retry.call_args[0][0].description
+    assert "outreach to the recruiter" in prompt
+    assert "connection_request: at most 300 characters" in prompt
+
+
+def test_messages_go_to_outreach_md_and_the_manifest_keeps_sizes(tmp_path):
+    outreach = {
+        "recipient": "hiring manager",
+        "variants": {
+            "connection_request": {"message": "Hi.", "chars": 3, "limit": 300, "trimmed": False},
+            "email": {
+                "subject": "Platform Engineer",
+                "message": "Hello, I applied.",
+                "chars": 17,
+                "limit": 1400,
+                "trimmed": True,
+            },
+        },
+        "differentiators_used": ["Kubernetes migration"],
+        "research_used": [1],
+        "sources": SOURCES,
+        "subject_lines": OPTIONS[SUBJECT_LINE],
+        "openers": OPTIONS[OPENER],
+        "choices": [{"variant_kind": OPENER, "rank_chosen": 2, "run_id": None}],
+    }
+
+    class Result:
+        final_documents = {"resume": "# Jane"}
+
+    Result.outreach = outreach
+    run_dir = write_run_artifacts(tmp_path, Result(), run_id="run-1")
+
+    text = (run_dir / OUTREACH_FILE).read_text()
+    assert text == render_outreach(outreach)
+    assert "## LinkedIn connection request" in text and "**Subject:** Platform Engineer" in text
+    assert "_17/1400 characters, trimmed to fit_" in text
+    assert "1. Acme raises Series C https://a.example/1" in text
+    manifest = json.loads((run_dir / MANIFEST_FILE).read_text())
+    assert manifest["outreach"]["variants"]["email"] == {"chars": 17, "trimmed": True}
+    assert "Hello, I applied." not in json.dumps(manifest)
+    assert OUTREACH_FILE in manifest["artifacts"]
+    variants = yaml.safe_load((run_dir / OUTREACH_VARIANTS_FILE).read_text())
+    assert variants["subject_lines"] == OPTIONS[SUBJECT_LINE]
+    assert variants["choices"] == [{"variant_kind": OPENER, "rank_chosen": 2, "run_id": "run-1"}]
+    assert manifest["outreach"]["options"] == {SUBJECT_LINE: 3, OPENER: 2}
+    assert manifest["outreach"]["chosen"] == {OPENER: 2}
+    assert "Congrats on the raise." not in json.dumps(manifest)
+    assert OUTREACH_VARIANTS_FILE in manifest["artifacts"]
+
+
+def test_outreach_pick_lists_and_records_the_options(tmp_path, monkeypatch, capsys):
+    monkeypatch.setenv("HYDRA_HOME", str(tmp_path / "home"))
+
+    class Result:
+        final_documents = {"resume": "# Jane"}
+        outreach = {"recipient": "hiring manager", "variants": {}, **OFFERED}
+
+    run_dir = write_run_artifacts(tmp_path / "output", Result(), run_id="run-1")
+
+    assert _outreach_pick(["run-1", "--out", str(tmp_path / "output")]) == 0
+    out = capsys.readouterr().out
+    assert "[2] Your Series C and platform" in out and "result: Leads with a number" in out
+    with pytest.raises(SystemExit):
+        _outreach_pick([str(run_dir), "--opener", "3"])
+    assert _outreach_pick([str(run_dir), "--subject-line", "1", "--opener", "2"]) == 0
+
+    variants = yaml.safe_load((run_dir / OUTREACH_VARIANTS_FILE).read_text())
+    assert [(c["variant_kind"], c["rank_chosen"], c["run_id"]) for c in variants["choices"]] == [
+        (SUBJECT_LINE, 1, "run-1"),
+        (OPENER, 2, "run-1"),
+    ]
+    manifest = json.loads((run_dir / MANIFEST_FILE).read_text())
+    assert manifest["outreach"]["chosen"] == {SUBJECT_LINE: 1, OPENER: 2}
+    prefs = VariantPreferences(tmp_path / "home" / OUTREACH_PREFERENCES_FILE).load()
+    assert prefs["opener:company"] == {"wins": 1, "runs": 1}
+
+
+def test_report_and_resume(tmp_path, capsys):
+    _report_outreach(None, True)
+    assert "could not be written" in capsys.readouterr().out
+    _report_outreach(
+        {
+            "recipient": "recruiter",
+            "variants": {
+                "connection_request": {
+                    "message": "Hi.",
+                    "chars": 3,
+                    "limit": 300,
+                    "trimmed": True,
+                }
+            },
+        },
+        True,
+    )
+    out = capsys.readouterr().out
+    assert f"Outreach to the recruiter → {OUTREACH_FILE}" in out
+    assert "LinkedIn connection request: 3/300 chars (trimmed to fit)" in out
+    _report_outreach({"recipient": "recruiter", "variants": {}, **OFFERED}, True)
+    out = capsys.readouterr().out
+    assert "top subject line: Cut deploys 40% at Acme" in out
+    assert "hydra outreach-pick" in out
+
+    (tmp_path / MANIFEST_FILE).write_text(
+        json.dumps(
+            {
+                "status": "paused",
+                "inputs": {
+                    "jd_path": "jd.md",
+                    "resume_path": "resume.md",
+                    "outreach_to": "recruiter",
+                },
+                "template": BUILTIN_TEMPLATES["quick"].including("outreach").to_dict(),
+            }
+        )
+    )
+    args = resume_arguments(tmp_path)
+    assert args[args.index("--template") + 1] == "quick"
+    assert "--outreach" in args and args[args.index("--outreach-to") + 1] == "recruiter"
@@ -0,0 +1,133 @@
+"""
+Unit tests for --redact-pii: contact details reach providers only as placeholders.
+"""
+
+import json
+from types import SimpleNamespace
+from unittest.mock import patch
+
+import pytest
+
+from runtime.crewai.artifacts import MANIFEST_FILE, build_manifest
+from runtime.crewai.base_agent import BaseHydraAgent
+from runtime.crewai.hydra_workflow import HydraWorkflow, RunStatus
+from runtime.crewai.model_config import PROVIDER_ENV_KEYS
+from runtime.crewai.pii_redaction import PiiRedactor
+from runtime.crewai.run_control import resume_arguments
+from runtime.crewai.stage_cache import StageCache
+from runtime.crewai.translation import LLMProvider
+
+HEADER = (
+    "Jane Doe\n"
+    "jane.doe+jobs@mail.example.com | +1 (415) 555-0134 | 415.555.0199\n"
+    "12 Main St, Springfield, IL 62704\n"
+)
+BODY = "Cut p99 latency 40% in 2019-2023; on-call for 1,200,000 users. Led 12 engineers."
+
+
+def test_contact_details_become_stable_placeholders_and_come_back():
+    redactor = PiiRedactor()
+    redacted = redactor.redact(HEADER + BODY)
+
+    assert redacted == (
+        "Jane Doe\n[EMAIL_1] | [PHONE_1] | [PHONE_2]\n[ADDRESS_1]\n" + BODY
+    )  # names, dates and figures are left alone
+    assert redactor.redact("Reach me at jane.doe+jobs@mail.example.com") == (
+        "Reach me at [EMAIL_1]"
+    )
+    assert redactor.redact("221B Baker Street, London and +44 20 7946 0958") == (
+        "[ADDRESS_2] and [PHONE_3]"
+    )
+    assert redactor.summary() == {"emails": 1, "phones": 3, "addresses": 2}
+
+    output = {"resume": "Jane Doe · [EMAIL_1] · [PHONE_1]", "notes": ["[ADDRESS_1]", 3]}
+    assert redactor.restore(output) == {
+        "resume": "Jane Doe · jane.doe+jobs@mail.example.com · +1 (415) 555-0134",
+        "notes": ["12 Main St, Springfield, IL 62704", 3],
+    }
+    assert redactor.restore("[EMAIL_9] stays") == "[EMAIL_9] stays"
+
+
+class _TailoringAgent(BaseHydraAgent):
+    role = "Tailoring Agent"
+    goal = "Tailor the résumé"
+    expected_output = "JSON documents"
+
+    def execute(self, context):  # pragma: no cover - not used in these tests
+        raise NotImplementedError
+
+
+def test_the_model_sees_placeholders_and_the_output_and_cache_hold_real_values(tmp_path):
+    from crewai import LLM
+
+    agent = _TailoringAgent(LLM(model="gpt-4o-mini", api_key="test-key"))
+    agent.redactor = PiiRedactor()
+    agent.stage_cache = StageCache(tmp_path)
+    sent = []
+
+    def _model(task):
+        sent.append(task.description)
+        return json.dumps({"agent": agent.role, "resume": "Jane Doe | [EMAIL_1] | [PHONE_1]"})
+
+    with patch.object(agent, "_invoke_llm", side_effect=_model):
+        output = agent.execute_with_retry(agent.create_task(f"Tailor this résumé:\n{HEADER}"))
+        again = agent.execute_with_retry(agent.create_task(f"Tailor this résumé:\n{HEADER}"))
+
+    assert len(sent) == 1 and "[EMAIL_1]" in sent[0]
+    assert "jane.doe" not in sent[0] and "555" not in sent[0] and "Main St" not in sent[0]
+    assert output["resume"] == "Jane Doe | jane.doe+jobs@mail.example.com | +1 (415) 555-0134"
+    assert again["resume"] == output["resume"]  # from the cache, restored
+
+
+def test_a_dry_run_records_what_would_be_sent(monkeypatch):
+    for env_var in PROVIDER_ENV_KEYS.values():
+        monkeypatch.delenv(env_var, raising=False)
+    workflow = HydraWorkflow(None, dry_run=True, redact_pii=True)
+
+    result = workflow.execute(
+        {"job_description": "SRE", "resume": HEADER + BODY, "source_documents": "notes"}
+    )
+
+    assert result.status is not RunStatus.FAILED, result.error_message
+    gap = workflow.dry_run_recorder.records[0]
+    assert "[EMAIL_1]" in gap.user and "jane.doe" not in gap.user
+    assert result.pii_redaction == {"emails": 1, "phones": 2, "addresses": 1}
+
+    manifest = build_manifest("run-1", result)
+    assert manifest["pii_redaction"] == result.pii_redaction
+    assert "jane.doe" not in json.dumps(manifest)
+
+
+def test_a_resumed_run_keeps_redacting(tmp_path):
+    (tmp_path / MANIFEST_FILE).write_text(
+        json.dumps(
+            {
+                "status": "paused",
+                "inputs": {"jd_path": "jd.md", "resume_path": "resume.md"},
+                "pii_redaction": {"emails": 1, "phones": 0, "addresses": 0},
+            }
+        )
+    )
+    assert "--redact-pii" in resume_arguments(tmp_path)
+
+
+def test_llm_translation_sends_placeholders_too():
+    redactor = PiiRedactor()
+    provider = LLMProvider(SimpleNamespace(model="gpt-4o-mini"), redactor)
+    sent = []
+
+    def _completion(**kwargs):
+        sent.append(kwargs["messages"][1]["content"])
+        reply = kwargs["messages"][1]["content"].replace("Reach me at", "Erreichbar unter")
+        return {"choices": [{"message": {"content": reply}}]}
+
+    with patch("litellm.completion", side_effect=_completion):
+        translated = provider.translate("Reach me at jane@example.com", "de")
+
+    assert sent == ["Reach me at [EMAIL_1]"]
+    assert translated == "Erreichbar unter jane@example.com"
+
+
+@pytest.mark.parametrize("text", ["Revenue grew from 2018 to 2021", "ISO 2025-12-08T12:00:00Z"])
+def test_ordinary_numbers_are_not_phone_numbers(text):
+    assert PiiRedactor().redact(text) == text
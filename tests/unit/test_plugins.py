@@ -0,0 +1,230 @@
+"""
+Unit tests for stage plugins: external programs that run as extra pipeline stages.
+"""
+
+import json
+import subprocess
+import sys
+
+import pytest
+
+from runtime.crewai import cli
+from runtime.crewai.hydra_workflow import RunStatus
+from runtime.crewai.plugins import (
+    PROTOCOL_VERSION,
+    PluginError,
+    StagePlugin,
+    discover_plugins,
+    plugin_request,
+    run_plugin,
+)
+from runtime.crewai.run_control import resume_arguments
+from tests.conftest import patched_workflow
+
+pytestmark = pytest.mark.usefixtures("hydra_home")
+
+# Answers with the stages it was sent and the role, or misbehaves as its "mode" file says.
+ECHO_PLUGIN = """
+import json, sys
+request = json.load(sys.stdin)
+mode = open("mode").read().strip()
+if mode == "crash":
+    print("boom", file=sys.stderr)
+    sys.exit(3)
+if mode == "garbage":
+    print("not json")
+elif mode == "error":
+    print(json.dumps({"error": "no salary data"}))
+else:
+    print(json.dumps({
+        "output": {"saw": sorted(request["results"]), "role": request["context"]["target_role"],
+                   "protocol": request["protocol"]},
+        "log": ["checked 1 posting"],
+    }))
+"""
+
+
+def _plugin(root, name="echo", after="gap_analysis", mode="ok", **manifest):
+    directory = root / name
+    directory.mkdir(parents=True)
+    (directory / "echo.py").write_text(ECHO_PLUGIN)
+    (directory / "mode").write_text(mode)
+    data = {"name": name, "command": [sys.executable, "echo.py"], "after": after, **manifest}
+    (directory / "plugin.yaml").write_text(json.dumps(data))  # JSON is YAML
+    return StagePlugin.from_manifest(directory / "plugin.yaml")
+
+
+def test_manifests_are_validated(tmp_path):
+    plugin = _plugin(tmp_path, description="Echoes", timeout=5, inputs=["gap_analysis"])
+
+    assert (plugin.name, plugin.after, plugin.timeout, plugin.inputs) == (
+        "echo",
+        "gap_analysis",
+        5.0,
+        ("gap_analysis",),
+    )
+    assert plugin.argv() == [sys.executable, "echo.py"]
+
+    bad = tmp_path / "bad" / "plugin.yaml"
+    bad.parent.mkdir()
+    for text, message in [
+        ("name: tailoring\ncommand: x\nafter: audit\n", "built-in stage"),
+        ("name: Bad-Name\ncommand: x\nafter: audit\n", "lowercase"),
+        ("command: x\nafter: compensation\n", "'after' must be one of"),
+        ("after: audit\n", "'command'"),
+        ("command: x\nafter: audit\ninputs: gap_analysis\n", "'inputs'"),
+    ]:
+        bad.write_text(text)
+        with pytest.raises(PluginError, match=message):
+            StagePlugin.from_manifest(bad)
+
+
+def test_a_program_in_the_plugin_directory_is_run_from_there(tmp_path):
+    directory = tmp_path / "sized"
+    directory.mkdir()
+    (directory / "run-me").write_text("#!/bin/sh\n")
+    (directory / "plugin.yaml").write_text("command: ./run-me --fast\nafter: tailoring\n")
+
+    plugin = StagePlugin.from_manifest(directory / "plugin.yaml")
+
+    assert plugin.name == "sized"
+    assert plugin.argv() == [str(directory.resolve() / "run-me"), "--fast"]
+
+
+def test_plugins_are_discovered_in_pipeline_order(tmp_path):
+    _plugin(tmp_path / "a", "late", after="audit")
+    _plugin(tmp_path / "a", "early", after="research")
+    _plugin(tmp_path / "b", "middle", after="tailoring")
+
+    plugins = discover_plugins([tmp_path / "a", tmp_path / "b", tmp_path / "missing"])
+
+    assert [p.name for p in plugins] == ["early", "middle", "late"]
+    _plugin(tmp_path / "c", "late", after="tailoring")
+    with pytest.raises(PluginError, match="Two plugins named 'late'"):
+        discover_plugins([tmp_path / "a", tmp_path / "c"])
+
+
+def test_the_request_holds_the_context_and_the_wanted_outputs(tmp_path):
+    plugin = _plugin(tmp_path, inputs=["gap_analysis"])
+    context = {"job_description": "JD", "resume": "R", "secret": "not sent"}
+
+    request = plugin_request(plugin, context, {"gap_analysis": {"gaps": []}, "research": {}})
+
+    assert request["protocol"] == PROTOCOL_VERSION
+    assert request["results"] == {"gap_analysis": {"gaps": []}}
+    assert request["context"]["job_description"] == "JD" and "secret" not in request["context"]
+
+
+def test_a_plugin_answers_with_its_output_and_log(tmp_path):
+    plugin = _plugin(tmp_path)
+
+    output, log = run_plugin(plugin, {"target_role": "SRE"}, {"gap_analysis": {}})
+
+    assert output == {"saw": ["gap_analysis"], "role": "SRE", "protocol": 1}
+    assert log == ["checked 1 posting"]
+
+
+@pytest.mark.parametrize(
+    "mode, message",
+    [
+        ("crash", "exited with 3: boom"),
+        ("garbage", "did not answer with a JSON object"),
+        ("error", "echo: no salary data"),
+    ],
+)
+def test_broken_plugins_are_errors(tmp_path, mode, message):
+    with pytest.raises(PluginError, match=message):
+        run_plugin(_plugin(tmp_path, mode=mode), {}, {})
+
+
+def test_slow_and_missing_plugins_are_errors(tmp_path):
+    plugin = _plugin(tmp_path, timeout=1)
+
+    def slow(*args, **kwargs):
+        raise subprocess.TimeoutExpired(args[0], 1)
+
+    with pytest.raises(PluginError, match="did not answer within 1s"):
+        run_plugin(plugin, {}, {}, runner=slow)
+    missing = StagePlugin("ghost", ("/nonexistent/ghost",), "audit", tmp_path)
+    with pytest.raises(PluginError, match="could not be started"):
+        run_plugin(missing, {}, {})
+
+
+def _workflow(plugins):
+    workflow = patched_workflow(auto_approve=True, plugins=plugins)
+    workflow.gap_analyzer.execute.return_value = {"gaps": ["Kafka"], "confidence": 0.9}
+    workflow.interrogator_prepper.execute.return_value = {"questions": [], "confidence": 0.9}
+    workflow.differentiator.execute.return_value = {"differentiators": [], "confidence": 0.9}
+    workflow.tailoring_agent.execute.return_value = {
+        "tailored_resume": "Resume",
+        "tailored_cover_letter": "Letter",
+        "confidence": 0.9,
+    }
+    workflow.ats_optimizer.execute.return_value = {"optimized_resume": "R", "confidence": 0.9}
+    workflow.auditor_suite.execute.return_value = {
+        "approval": {"approved": True},
+        "confidence": 0.9,
+    }
+    return workflow
+
+
+CONTEXT = {"job_description": "JD", "resume": "Resume", "source_documents": "Sources"}
+
+
+def test_the_workflow_runs_plugins_after_their_stage(tmp_path):
+    workflow = _workflow([_plugin(tmp_path)])
+
+    result = workflow.execute({**CONTEXT, "target_role": "SRE"})
+
+    assert result.status is RunStatus.COMPLETED
+    output = result.intermediate_results["echo"]
+    assert "gap_analysis" in output["saw"] and "tailoring" not in output["saw"]
+    assert output["role"] == "SRE"
+    assert any("[echo] checked 1 posting" in line for line in result.execution_log)
+
+    # Resuming with the plugin's output in hand does not run it again.
+    previous = {**result.intermediate_results, "echo": {"saw": "before"}}
+    again = workflow.execute({**CONTEXT, "previous_results": previous})
+    assert again.intermediate_results["echo"] == {"saw": "before"}
+
+
+def test_an_optional_plugin_failure_is_reported_and_a_required_one_fails_the_run(tmp_path):
+    optional = _workflow([_plugin(tmp_path / "a", mode="crash")])
+
+    result = optional.execute(CONTEXT)
+
+    assert result.status is RunStatus.COMPLETED
+    [error] = result.errors
+    assert (error["stage"], error["kind"]) == ("echo", "plugin")
+    assert "exited with 3" in error["message"]
+
+    required = _workflow([_plugin(tmp_path / "b", mode="crash", required=True)])
+    assert required.execute(CONTEXT).status is RunStatus.FAILED
+
+
+def test_hydra_plugins_lists_and_checks(tmp_path, capsys):
+    _plugin(tmp_path / "home" / "plugins", description="Echoes the request")
+    _plugin(tmp_path / "extra", "broken", after="audit", mode="garbage")
+
+    assert cli.main(["plugins", "--plugin-dir", str(tmp_path / "extra")]) == 0
+    out = capsys.readouterr().out
+    assert "echo" in out and "after gap_analysis" in out and "Echoes the request" in out
+    assert "broken" in out
+
+    assert cli.main(["plugins", "check", "echo"]) == 0
+    assert "✅ echo answered with output keys: protocol, role, saw" in capsys.readouterr().out
+    assert cli.main(["plugins", "--plugin-dir", str(tmp_path / "extra"), "check", "broken"]) == 1
+    with pytest.raises(SystemExit):
+        cli.main(["plugins", "check", "nope"])
+
+
+def test_a_resumed_run_loads_the_same_plugin_dirs(tmp_path):
+    run_dir = tmp_path / "out" / "run-1"
+    run_dir.mkdir(parents=True)
+    inputs = {"jd_path": "jd.md", "resume_path": "r.md", "plugin_dirs": ["/opt/plugins"]}
+    manifest = {"run_id": "run-1", "status": "paused", "inputs": inputs}
+    (run_dir / "run.json").write_text(json.dumps(manifest))
+
+    args = resume_arguments(run_dir)
+
+    assert args[args.index("--plugin-dir") + 1] == "/opt/plugins"
@@ -0,0 +1,135 @@
+"""
+Unit tests for résumé profiles: storing baselines, picking one, and recommending one.
+"""
+
+import json
+from types import SimpleNamespace
+
+import pytest
+
+from runtime.crewai import cli
+from runtime.crewai.agents.gap_analyzer import GapAnalyzerAgent
+from runtime.crewai.artifacts import RunInputs, build_manifest
+from runtime.crewai.contracts import GapReview
+from runtime.crewai.profiles import PROFILE_FILE, ProfileError, ProfileStore, rank_profiles
+from runtime.crewai.skill_taxonomy import default_taxonomy
+
+pytestmark = pytest.mark.usefixtures("hydra_home")
+
+JD = "Senior Platform Engineer. Must have Kubernetes, Terraform and Python."
+IC = "Platform engineer. Ran K8s clusters with Terraform; tooling in Python."
+MANAGER = "Engineering manager. Led 3 teams; hired 12 engineers; some Python."
+
+
+@pytest.fixture
+def store(tmp_path):
+    (tmp_path / "ic.md").write_text(IC)
+    (tmp_path / "manager.md").write_text(MANAGER)
+    store = ProfileStore()
+    store.add("ic", tmp_path / "ic.md", "Senior IC, platform")
+    store.add("management", tmp_path / "manager.md", "Engineering manager")
+    return store
+
+
+def test_profiles_are_stored_listed_and_removed(store, tmp_path):
+    assert [p.name for p in store.list()] == ["ic", "management"]
+    ic = store.get("ic")
+    assert ic.read() == IC and ic.description == "Senior IC, platform"
+    assert json.loads((ic.resume_path.parent / PROFILE_FILE).read_text())["resume"] == "resume.md"
+
+    (tmp_path / "ic2.md").write_text("Staff engineer")
+    with pytest.raises(ProfileError, match="exists"):
+        store.add("ic", tmp_path / "ic2.md")
+    assert store.add("ic", tmp_path / "ic2.md", replace=True).read() == "Staff engineer"
+
+    store.remove("management")
+    with pytest.raises(ProfileError, match=r"No profile 'management' \(profiles: ic\)"):
+        store.get("management")
+
+
+@pytest.mark.parametrize("name", ["auto", "IC", "../escape", ""])
+def test_bad_profile_names_are_rejected(store, tmp_path, name):
+    with pytest.raises(ProfileError, match="Bad profile name"):
+        store.add(name, tmp_path / "ic.md")
+
+
+def test_profiles_are_ranked_by_jd_skill_coverage(store):
+    fits = rank_profiles(JD, store.list(), default_taxonomy())
+
+    assert [fit.profile.name for fit in fits] == ["ic", "management"]
+    assert fits[0].score == 1.0 and fits[0].missing == []
+    assert fits[1].covered == ["Python"] and fits[1].missing == ["Kubernetes", "Terraform"]
+    assert fits[1].for_prompt() == {
+        "name": "management",
+        "description": "Engineering manager",
+        "jd_skills_covered": "1/3",
+        "missing": ["Kubernetes", "Terraform"],
+    }
+
+
+def test_the_gap_analyzer_is_asked_to_recommend_a_profile(store):
+    fits = rank_profiles(JD, store.list(), default_taxonomy())
+
+    prompt = GapAnalyzerAgent._describe_profiles([f.for_prompt() for f in fits], "management")
+
+    assert 'The résumé above is "management"' in prompt
+    assert "- ic: Senior IC, platform (JD skills covered 3/3; missing: none)" in prompt
+    assert '"profile_recommendation"' in prompt
+    assert GapAnalyzerAgent._describe_profiles(None, None) == ""
+
+
+def test_the_recommendation_reaches_the_review_and_the_manifest():
+    gap = {"gaps": ["Kafka"], "profile_recommendation": {"profile": "ic", "reason": "Hands-on"}}
+    review = GapReview.from_raw(gap)
+    assert (review.recommended_profile, review.profile_reason) == ("ic", "Hands-on")
+    assert GapReview.from_raw({"gaps": []}).recommended_profile is None
+
+    result = SimpleNamespace(intermediate_results={"gap_analysis": gap})
+    manifest = build_manifest("run-1", result, RunInputs(profile="management"))
+    assert manifest["inputs"]["profile"] == "management"
+    assert manifest["recommended_profile"] == "ic"
+
+
+def test_hydra_profiles_adds_lists_and_recommends(tmp_path, capsys):
+    (tmp_path / "ic.md").write_text(IC)
+    (tmp_path / "manager.md").write_text(MANAGER)
+    (tmp_path / "jd.md").write_text(JD)
+
+    assert cli.main(["profiles", "add", "ic", str(tmp_path / "ic.md")]) == 0
+    assert cli.main(["profiles", "add", "management", str(tmp_path / "manager.md")]) == 0
+    assert cli.main(["profiles"]) == 0
+    assert cli.main(["profiles", "recommend", "--jd", str(tmp_path / "jd.md")]) == 0
+
+    out = capsys.readouterr().out
+    assert "✅ Stored profile ic" in out
+    assert "1. ic               3/3 JD skills" in out
+    assert "missing: Kubernetes, Terraform" in out
+
+
+def test_a_run_takes_the_profile_covering_the_jd_best(store, tmp_path, capsys):
+    (tmp_path / "jd.md").write_text(JD)
+    (tmp_path / "sources").mkdir()
+    (tmp_path / "sources" / "notes.md").write_text("Migrated 40 services to Kubernetes.")
+
+    code = cli.main(
+        [
+            "--jd",
+            str(tmp_path / "jd.md"),
+            "--profile",
+            "auto",
+            "--sources",
+            str(tmp_path / "sources"),
+            "--out",
+            str(tmp_path / "out"),
+            "--dry-run",
+        ]
+    )
+
+    assert code == 0
+    assert "Using profile ic: covers 3 of 3 skills" in capsys.readouterr().out
+
+
+def test_a_run_needs_a_resume_or_a_profile(tmp_path):
+    (tmp_path / "jd.md").write_text(JD)
+    with pytest.raises(SystemExit):
+        cli.main(["--jd", str(tmp_path / "jd.md")])
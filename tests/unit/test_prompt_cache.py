@@ -0,0 +1,195 @@
+"""
+Unit tests for provider prompt caching support and the usage ledger.
+"""
+
+import json
+from unittest.mock import patch
+
+import pytest
+
+from runtime.crewai.base_agent import BaseHydraAgent
+from runtime.crewai.dry_run import DryRunRecorder
+from runtime.crewai.pii_redaction import PiiRedactor
+from runtime.crewai.prompt_cache import (
+    ANTHROPIC,
+    MIN_CACHEABLE_TOKENS,
+    OPENAI,
+    CallUsage,
+    UsageLedger,
+    apply_cache_control,
+    cache_provider,
+    cache_savings,
+    split_shared,
+    usage_from_litellm,
+)
+
+LONG_SYSTEM = "rules " * (MIN_CACHEABLE_TOKENS * 2)
+MESSAGES = [{"role": "system", "content": LONG_SYSTEM}, {"role": "user", "content": "task"}]
+RESUME = "Jana Novak, jana@example.com\n" + "- Led the Terraform migration\n" * 300
+
+
+class _Agent(BaseHydraAgent):
+    role = "Tailoring Agent"
+    goal = "Write"
+    expected_output = "JSON"
+
+    def execute(self, context):  # pragma: no cover - not used in these tests
+        raise NotImplementedError
+
+
+def test_cache_provider_detection():
+    assert cache_provider("anthropic/claude-sonnet-4-20250514") == ANTHROPIC
+    assert cache_provider("openrouter/anthropic/claude-sonnet-4-20250514") == ANTHROPIC
+    assert cache_provider("openai/gpt-4o-mini") == OPENAI
+    assert cache_provider("together_ai/meta-llama/Llama-4") is None
+    assert cache_provider(None) is None
+
+
+def test_anthropic_system_prefix_is_marked_and_input_untouched():
+    marked = apply_cache_control(MESSAGES, "anthropic/claude-sonnet-4-20250514")
+
+    block = marked[0]["content"][0]
+    assert block["cache_control"] == {"type": "ephemeral"}
+    assert block["text"] == LONG_SYSTEM
+    assert marked[1] == MESSAGES[1]
+    assert MESSAGES[0]["content"] == LONG_SYSTEM
+
+
+def test_short_prefixes_and_other_providers_are_left_plain():
+    short = [{"role": "system", "content": "short"}, MESSAGES[1]]
+
+    assert apply_cache_control(short, "anthropic/claude-sonnet-4-20250514") == short
+    assert apply_cache_control(MESSAGES, "openai/gpt-4o-mini") is MESSAGES
+
+
+def test_usage_extraction_handles_both_provider_shapes():
+    anthropic = usage_from_litellm(
+        "A",
+        "anthropic/claude-sonnet-4-20250514",
+        {"usage": {"prompt_tokens": 3000, "cache_read_input_tokens": 2000}},
+    )
+    openai = usage_from_litellm(
+        "B",
+        "openai/gpt-4o-mini",
+        {"usage": {"prompt_tokens": 3000, "prompt_tokens_details": {"cached_tokens": 1024}}},
+    )
+
+    assert anthropic.cache_read_tokens == 2000
+    assert openai.cache_read_tokens == 1024
+    assert usage_from_litellm("C", "m", {}) is None
+
+
+def test_cache_savings_nets_out_the_write_premium():
+    model = "claude-sonnet-4-20250514"  # $3.00 / 1M input tokens
+    read = cache_savings(CallUsage("A", model, cache_read_tokens=1_000_000))
+    write = cache_savings(CallUsage("A", model, cache_write_tokens=1_000_000))
+
+    assert read == pytest.approx(2.70)
+    assert write == pytest.approx(-0.75)
+    assert cache_savings(CallUsage("A", "mystery", cache_read_tokens=10)) is None
+
+
+def test_direct_call_sends_marked_prefix_and_records_usage(monkeypatch):
+    from crewai import LLM
+
+    monkeypatch.setenv("HYDRA_DIRECT_LLM", "1")
+    agent = _Agent(LLM(model="anthropic/claude-sonnet-4-20250514", api_key="k"))
+    agent.prompt = LONG_SYSTEM
+    agent.usage_ledger = UsageLedger()
+    captured = {}
+
+    def fake_completion(**kwargs):
+        captured.update(kwargs)
+        payload = json.dumps({"agent": agent.role, "result": "ok"})
+        return {
+            "choices": [{"message": {"content": payload}}],
+            "usage": {"prompt_tokens": 5000, "cache_read_input_tokens": 4000},
+        }
+
+    with patch("litellm.completion", side_effect=fake_completion):
+        agent.execute_with_retry(agent.create_task("Tailor."), max_retries=0)
+
+    assert captured["messages"][0]["content"][0]["cache_control"] == {"type": "ephemeral"}
+    summary = agent.usage_ledger.summary()
+    assert summary["calls"] == 1
+    assert summary["cache_read_tokens"] == 4000
+    assert summary["cache_savings_usd"] > 0
+
+
+def test_dry_run_projects_savings_for_repeated_prefixes():
+    recorder = DryRunRecorder()
+    recorder._agents["Auditor Suite"] = ("auditor_suite", "claude-sonnet-4-20250514")
+
+    recorder.record("Auditor Suite", MESSAGES)
+    recorder.record("Auditor Suite", MESSAGES)
+
+    first, second = recorder.records
+    assert first.cache_write_tokens > 0 and first.cache_read_tokens == 0
+    assert second.cache_read_tokens == first.cache_write_tokens
+    assert recorder.totals()["cache_savings_usd"] > 0
+
+
+def test_shared_texts_move_out_of_the_task_into_a_prefix():
+    task = f"Tailor this resume:\n{RESUME}\nfor the job."
+
+    prefix, description = split_shared(task, {"resume": RESUME, "source_documents": "short"})
+
+    assert RESUME in prefix and prefix.startswith("Reference material")
+    assert description == (
+        "Tailor this resume:\n[The candidate's baseline resume: given in full above]\nfor the job."
+    )
+    assert split_shared("No resume here.", {"resume": RESUME}) == ("", "No resume here.")
+
+
+def test_every_agent_sends_the_shared_prefix_first_and_redacted_when_asked(monkeypatch):
+    from crewai import LLM
+
+    monkeypatch.setenv("HYDRA_DIRECT_LLM", "1")
+    agent = _Agent(LLM(model="anthropic/claude-sonnet-4-20250514", api_key="k"))
+    agent.shared_context = {"resume": RESUME}
+    sent = []
+
+    def fake_completion(**kwargs):
+        sent.append(kwargs["messages"])
+        payload = json.dumps({"agent": agent.role, "result": "ok"})
+        return {"choices": [{"message": {"content": payload}}]}
+
+    with patch("litellm.completion", side_effect=fake_completion):
+        agent.execute_with_retry(agent.create_task(f"Tailor:\n{RESUME}"), max_retries=0)
+        agent.redactor = PiiRedactor()
+        agent.execute_with_retry(agent.create_task(f"Audit:\n{RESUME}"), max_retries=0)
+
+    plain, redacted = sent
+    shared = plain[0]["content"][0]
+    assert plain[0]["role"] == "system" and RESUME in shared["text"]
+    assert shared["cache_control"] == {"type": "ephemeral"}
+    assert RESUME not in plain[-1]["content"]
+    # With --redact-pii the redacted copy is shared; the address never leaves.
+    assert "jana@example.com" not in json.dumps(redacted)
+    assert "given in full above" in redacted[-1]["content"]
+
+
+def test_dry_run_reads_the_shared_prefix_across_agents():
+    recorder = DryRunRecorder()
+    model = "claude-sonnet-4-20250514"
+    recorder._agents["Auditor Suite"] = ("auditor_suite", model)
+    recorder._agents["Tailoring Agent"] = ("tailoring", model)
+    shared = {"role": "system", "content": RESUME}
+
+    recorder.record("Tailoring Agent", [shared, {"role": "system", "content": "Tailor."}])
+    recorder.record("Auditor Suite", [shared, {"role": "system", "content": "Audit."}])
+
+    first, second = recorder.records
+    assert first.cache_read_tokens == 0 and first.cache_write_tokens > 0
+    # The résumé is read from the cache; only the auditor's own prompt is new.
+    assert 0 < second.cache_read_tokens < first.cache_write_tokens
+    assert second.cache_write_tokens > 0
+
+
+def test_summary_counts_the_calls_that_hit_the_cache():
+    ledger = UsageLedger()
+    ledger.record(CallUsage("A", "openai/gpt-4o-mini", prompt_tokens=3000))
+    ledger.record(CallUsage("B", "openai/gpt-4o-mini", prompt_tokens=3000, cache_read_tokens=2048))
+
+    summary = ledger.summary()
+    assert summary["calls"] == 2 and summary["cache_hit_calls"] == 1
@@ -0,0 +1,183 @@
+"""
+Unit tests for prompt packs: the agents' prompts tuned per profession.
+"""
+
+import json
+from types import SimpleNamespace
+from unittest.mock import Mock
+
+import pytest
+
+from runtime.crewai import cli
+from runtime.crewai.artifacts import build_manifest
+from runtime.crewai.hydra_workflow import HydraWorkflow
+from runtime.crewai.prompt_packs import (
+    AUTO,
+    BUILTIN_PACKS_DIR,
+    PACK_PATH_ENV,
+    PromptPackError,
+    choose_pack,
+    load_pack,
+    load_packs,
+    pack_dirs,
+)
+from runtime.crewai.run_control import MANIFEST_FILE, resume_arguments
+
+SALES_JD = """Acme Corp
+
+Enterprise Account Executive
+
+Own a $1.2M quota selling to financial services. Build pipeline through outbound
+prospecting, run MEDDICC deals in Salesforce and grow ARR in your territory.
+"""
+PRODUCT_JD = """Acme Corp
+
+Senior Product Manager, Onboarding
+
+Own the roadmap for activation: run discovery and customer interviews, set OKRs,
+and prioritize experiments with design and engineering.
+"""
+DESIGN_JD = """Acme Corp
+
+Senior Product Designer
+
+Ship flows in Figma from wireframes to prototypes, run usability testing and grow
+our design system. A portfolio is required.
+"""
+ENGINEERING_JD = """Acme Corp
+
+Senior Site Reliability Engineer
+
+Run Kubernetes on AWS with Terraform, own on-call and CI/CD for our microservices.
+"""
+
+
+@pytest.fixture(autouse=True)
+def only_builtin_packs(tmp_path, monkeypatch):
+    """No pack from the developer's own $HYDRA_HOME or $HYDRA_PROMPT_PACK_PATH."""
+    monkeypatch.setenv("HYDRA_HOME", str(tmp_path / "hydra-home"))
+    monkeypatch.delenv(PACK_PATH_ENV, raising=False)
+
+
+def _pack(directory, name, titles=(), keywords=(), **prompts):
+    path = directory / name
+    path.mkdir(parents=True)
+    spec = {"description": f"{name} roles", "titles": list(titles), "keywords": list(keywords)}
+    (path / "pack.yaml").write_text(json.dumps(spec))
+    for agent, text in prompts.items():
+        (path / f"{agent.replace('_', '-')}.md").write_text(text)
+    return path
+
+
+@pytest.mark.parametrize(
+    "jd, expected",
+    [
+        (SALES_JD, "sales"),
+        (PRODUCT_JD, "product"),
+        (DESIGN_JD, "design"),
+        (ENGINEERING_JD, "engineering"),
+        ("Acme Corp\n\nOffice Coordinator\n\nKeep the office running.", "engineering"),
+    ],
+)
+def test_the_pack_is_detected_from_the_job_description(jd, expected):
+    choice = choose_pack(AUTO, jd)
+    assert choice.pack.name == expected
+    assert choice.detected is True
+
+
+def test_the_builtin_packs_tune_the_gap_analysis_and_tailoring():
+    packs = {pack.name: pack for pack in load_packs([BUILTIN_PACKS_DIR])}
+    assert sorted(packs) == ["design", "engineering", "product", "sales"]
+    assert packs["engineering"].prompts == {}
+    for name in ("design", "product", "sales"):
+        assert sorted(packs[name].prompts) == ["gap-analyzer", "tailoring-agent"]
+    assert "quota" in packs["sales"].prompts["gap-analyzer"].lower()
+
+
+def test_no_match_or_a_tie_falls_back_to_engineering(tmp_path):
+    _pack(tmp_path, "engineering", titles=["engineer"])
+    _pack(tmp_path, "sales", keywords=["quota"])
+    _pack(tmp_path, "support", keywords=["quota"])
+
+    tie = choose_pack(AUTO, "Acme\n\nRep\n\nCarry a quota.", dirs=[tmp_path])
+    assert tie.pack.name == "engineering"
+    assert tie.reason == "sales and support matched equally"
+    # A title phrase outweighs a keyword.
+    titled = choose_pack(AUTO, "Acme\n\nSales Engineer\n\nA quota.", dirs=[tmp_path])
+    assert titled.pack.name == "engineering"
+    nothing = choose_pack(AUTO, "Acme\n\nChef\n\nCook.", dirs=[tmp_path])
+    assert nothing.reason == "no pack's titles or keywords matched"
+
+
+def test_a_pack_asked_for_is_used_whatever_the_jd(tmp_path):
+    choice = choose_pack("sales", ENGINEERING_JD)
+    assert choice.pack.name == "sales" and choice.detected is False
+    assert choice.to_dict()["agents"] == ["gap-analyzer", "tailoring-agent"]
+
+    with pytest.raises(PromptPackError, match="Unknown prompt pack 'legal'"):
+        choose_pack("legal", SALES_JD)
+    _pack(tmp_path, "legal", tailor="Typo in the agent's name")
+    with pytest.raises(PromptPackError, match="no agent named tailor "):
+        load_pack("legal", [tmp_path])
+    (tmp_path / "broken").mkdir()
+    (tmp_path / "broken" / "pack.yaml").write_text("titles: lawyer")
+    with pytest.raises(PromptPackError, match="titles must be a list"):
+        load_pack("broken", [tmp_path])
+
+
+def test_your_own_packs_come_before_the_builtin_ones(tmp_path, monkeypatch):
+    home = tmp_path / "hydra-home" / "prompt_packs"
+    _pack(home, "sales", titles=["account executive"], tailoring_agent="House style.")
+    extra = tmp_path / "extra"
+    _pack(extra, "legal", titles=["counsel"], gap_analyzer="Bar admissions first.")
+    monkeypatch.setenv(PACK_PATH_ENV, str(extra))
+
+    assert pack_dirs()[:2] == [extra, home]
+    assert load_pack("sales", pack_dirs()).prompts == {"tailoring-agent": "House style."}
+    assert choose_pack(AUTO, "Acme\n\nSenior Counsel\n\nContracts.").pack.name == "legal"
+
+
+def test_each_run_gets_its_own_pack_on_top_of_the_agents_prompts():
+    workflow = HydraWorkflow(Mock(), use_per_agent_models=False, prompt_pack=AUTO)
+    own_gap = workflow.gap_analyzer.prompt
+    own_tailoring = workflow.tailoring_agent.prompt
+
+    workflow._choose_pack({"job_description": SALES_JD})
+    assert workflow.tailoring_agent.prompt.startswith(own_tailoring.rstrip())
+    assert "## Profession: Sales" in workflow.tailoring_agent.prompt
+    assert "## Profession: Sales" in workflow.gap_analyzer.prompt
+    summary = workflow._pack_summary()
+    assert summary["name"] == "sales" and summary["detected"] is True
+
+    # The next run starts from the agents' own prompts, not from the sales ones.
+    workflow._choose_pack({"job_description": ENGINEERING_JD})
+    assert workflow.gap_analyzer.prompt == own_gap
+    assert workflow.tailoring_agent.prompt == own_tailoring
+    assert workflow._pack_summary()["name"] == "engineering"
+
+    plain = HydraWorkflow(Mock(), use_per_agent_models=False)
+    plain._choose_pack({"job_description": SALES_JD})
+    assert plain._pack_summary() is None and plain.tailoring_agent.prompt == own_tailoring
+
+
+def test_the_pack_is_recorded_and_kept_on_resume(tmp_path):
+    pack = choose_pack("sales", ENGINEERING_JD).to_dict()
+    result = SimpleNamespace(success=True, prompt_pack=pack)
+    assert build_manifest("run-1", result)["prompt_pack"]["name"] == "sales"
+
+    inputs = {"jd_path": "jd.md", "resume_path": "resume.md", "prompt_pack": "sales"}
+    (tmp_path / MANIFEST_FILE).write_text(json.dumps({"status": "paused", "inputs": inputs}))
+    args = resume_arguments(tmp_path)
+    assert args[args.index("--prompt-pack") + 1] == "sales"
+
+
+def test_prompt_packs_lists_the_packs_and_the_one_a_jd_gets(tmp_path, capsys):
+    jd = tmp_path / "jd.md"
+    jd.write_text(SALES_JD)
+
+    assert cli.main(["prompt-packs", "--jd", str(jd)]) == 0
+
+    out = capsys.readouterr().out
+    assert "sales        Account executives" in out
+    assert "gap-analyzer, tailoring-agent" in out
+    assert f"🎒 {jd}: sales (matched account executive" in out
@@ -0,0 +1,144 @@
+"""
+Unit tests for the prompt transcript: every model call kept per stage, exported as Markdown.
+"""
+
+import json
+from types import SimpleNamespace
+from unittest.mock import Mock
+
+import pytest
+from crewai import Task
+
+from runtime.crewai import cli
+from runtime.crewai.artifacts import write_run_artifacts
+from runtime.crewai.base_agent import BaseHydraAgent
+from runtime.crewai.gateway import GatewayLLM
+from runtime.crewai.hydra_workflow import HydraWorkflow
+from runtime.crewai.prompt_transcript import (
+    PROMPT_TRANSCRIPT_FILE,
+    TRANSCRIPT_MARKDOWN_FILE,
+    call_entry,
+    load_transcript,
+    render_transcript,
+)
+
+
+class _Agent(BaseHydraAgent):
+    role = "Test Agent"
+    goal = "Test goal"
+    expected_output = "Test output"
+
+    def execute(self, context):
+        return {}
+
+
+def _reply(content):
+    return {"choices": [{"message": {"content": content}}]}
+
+
+def _task(description="Analyse the gaps."):
+    return Task(description=description, expected_output="JSON", agent=Mock(), context=[])
+
+
+def test_every_call_is_logged_with_what_rejected_it():
+    llm = Mock(spec=GatewayLLM)
+    llm.model = "gw-model"
+    llm.complete.side_effect = [
+        RuntimeError("502 Bad Gateway"),
+        _reply('{"gaps": ["Go"] "confidence": 0.9}'),
+        _reply('{"gaps": ["Go"]}'),
+    ]
+    agent = _Agent(llm)
+
+    agent.execute_with_retry(_task(), max_retries=2)
+
+    failed, unparsed, accepted = agent.prompt_transcript
+    assert (failed["response"], failed["error"]) == (None, "RuntimeError: 502 Bad Gateway")
+    assert unparsed["response"] == '{"gaps": ["Go"] "confidence": 0.9}'
+    assert "Invalid JSON output" in unparsed["error"]
+    assert "error" not in accepted and accepted["response"] == '{"gaps": ["Go"]}'
+    assert [call["attempt"] for call in agent.prompt_transcript] == [1, 2, 3]
+    assert {call["model"] for call in agent.prompt_transcript} == {"gw-model"}
+    assert {call["path"] for call in agent.prompt_transcript} == {"gateway"}
+    system, user = accepted["messages"]
+    assert system["role"] == "system" and "Test Agent" in system["content"]
+    assert "JSON REPAIR" in user["content"]  # the repaired prompt, as sent
+
+
+def test_the_workflow_collects_calls_per_stage():
+    workflow = HydraWorkflow(None, use_per_agent_models=False)
+    entry = call_entry("Gap Analyzer", "m", "direct", 1, [], "{}")
+    workflow.gap_analyzer.prompt_transcript = [entry]
+
+    workflow._record_prompts(workflow.gap_analyzer, "gap_analysis")
+
+    assert workflow.prompt_transcripts == {"gap_analysis": [entry]}
+    assert workflow.gap_analyzer.prompt_transcript == []
+
+
+def test_markdown_keeps_prompts_and_answers_verbatim():
+    messages = [
+        {"role": "system", "content": "You are the Tailoring agent."},
+        {"role": "user", "content": "Example:\n```json\n{}\n```"},
+    ]
+    transcripts = {
+        "gap_analysis": [call_entry("Gap Analyzer", "m", "cache", 1, [], '{"gaps": []}')],
+        "tailoring": [
+            call_entry("Tailoring", "gpt-4o", "direct", 1, messages, None, TimeoutError("60s")),
+            call_entry("Tailoring", "gpt-4o", "direct", 2, messages, "```json\n{}\n```"),
+        ],
+    }
+
+    markdown = render_transcript(transcripts, "run-1")
+
+    assert markdown.startswith("# Prompt transcript: run-1\n\n3 model call(s) across 2 stage(s).")
+    assert "## tailoring" in markdown and "stage cache hit" in markdown
+    assert "### Call 2: Tailoring · gpt-4o · via direct · attempt 2" in markdown
+    assert "**Rejected:** TimeoutError: 60s" in markdown and "_No response._" in markdown
+    assert "#### System prompt\n\n```\nYou are the Tailoring agent.\n```" in markdown
+    assert "````\nExample:\n```json\n{}\n```\n````" in markdown  # the fence outgrows ```
+    only = render_transcript(transcripts, "run-1", ["gap_analysis"])
+    assert "## gap_analysis" in only and "## tailoring" not in only
+
+
+def _run(tmp_path):
+    transcripts = {
+        "gap_analysis": [
+            call_entry("Gap Analyzer", "m", "direct", 1, [{"role": "user", "content": "Hi"}], "{}")
+        ]
+    }
+    result = SimpleNamespace(
+        status=None,
+        final_documents={},
+        audit_report=None,
+        execution_log=[],
+        prompt_transcripts=transcripts,
+    )
+    return write_run_artifacts(tmp_path / "output", result, run_id="run-1"), transcripts
+
+
+def test_runs_keep_the_transcript(tmp_path):
+    run_dir, transcripts = _run(tmp_path)
+
+    assert load_transcript(run_dir) == transcripts
+    manifest = json.loads((run_dir / "run.json").read_text())
+    assert PROMPT_TRANSCRIPT_FILE in manifest["artifacts"]
+    assert load_transcript(tmp_path) == {}
+
+
+def test_export_transcript_command(tmp_path, capsys):
+    run_dir, _ = _run(tmp_path)
+    out = str(tmp_path / "output")
+
+    assert cli.main(["export-transcript", "run-1", "--out", out]) == 0
+    assert "## gap_analysis" in (run_dir / TRANSCRIPT_MARKDOWN_FILE).read_text()
+    assert TRANSCRIPT_MARKDOWN_FILE in json.loads((run_dir / "run.json").read_text())["artifacts"]
+
+    capsys.readouterr()
+    assert cli.main(["export-transcript", str(run_dir), "-o", "-"]) == 0
+    assert capsys.readouterr().out.startswith("# Prompt transcript: run-1")
+
+    with pytest.raises(SystemExit):
+        cli.main(["export-transcript", "run-1", "--out", out, "--stage", "tailoring"])
+    with pytest.raises(SystemExit):
+        cli.main(["export-transcript", "run-2", "--out", out])
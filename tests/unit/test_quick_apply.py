@@ -0,0 +1,180 @@
+"""
+Unit tests for the quick-apply latency budget.
+"""
+
+import json
+import time
+from types import SimpleNamespace
+from unittest.mock import Mock, patch
+
+import pytest
+
+from runtime.crewai.artifacts import write_run_artifacts
+from runtime.crewai.hydra_workflow import HydraWorkflow, RunStatus
+from runtime.crewai.quick_apply import BudgetExceeded, LatencyBudget
+
+CONTEXT = {
+    "job_description": "Senior Platform Engineer role requiring AWS",
+    "resume": "Jane Doe, AWS engineer",
+    "source_documents": "Jane Doe, AWS engineer",
+    "gap_analysis_approved": True,
+}
+
+
+class _Clock:
+    def __init__(self):
+        self.now = 0.0
+
+    def __call__(self):
+        return self.now
+
+
+def _slow(seconds, result):
+    def _execute(context):
+        time.sleep(seconds)
+        return result
+
+    return _execute
+
+
+@pytest.fixture
+def make_workflow():
+    def _make(budget):
+        with (
+            patch("runtime.crewai.hydra_workflow.GapAnalyzerAgent"),
+            patch("runtime.crewai.hydra_workflow.InterrogatorPrepperAgent"),
+            patch("runtime.crewai.hydra_workflow.DifferentiatorAgent"),
+            patch("runtime.crewai.hydra_workflow.TailoringAgent"),
+            patch("runtime.crewai.hydra_workflow.ATSOptimizerAgent"),
+            patch("runtime.crewai.hydra_workflow.AuditorSuiteAgent"),
+            patch("runtime.crewai.hydra_workflow.ExecutiveSynthesizerAgent"),
+        ):
+            workflow = HydraWorkflow(
+                Mock(), use_per_agent_models=False, auto_approve=True, latency_budget=budget
+            )
+        workflow.gap_analyzer.execute.return_value = {"gaps": []}
+        workflow.differentiator.execute.return_value = {"differentiators": ["AWS"]}
+        workflow.tailoring_agent.execute.return_value = {
+            "tailored_resume": "Tailored resume",
+            "tailored_cover_letter": "Tailored letter",
+        }
+        workflow.ats_optimizer.execute.return_value = {"optimized_resume": "ATS resume"}
+        workflow.auditor_suite.execute.return_value = {"approval": {"approved": True}}
+        workflow.executive_synthesizer.execute.return_value = {"decision": {"fit_score": 80}}
+        return workflow
+
+    return _make
+
+
+def test_optional_stages_must_leave_time_for_required_ones():
+    clock = _Clock()
+    budget = LatencyBudget(100, clock=clock)
+
+    assert budget.fits("differentiation", ("tailoring",))  # 30 + 60 <= 100
+    assert not budget.fits("differentiation", ("gap_analysis", "tailoring", "auditing"))
+    clock.now = 80
+    assert budget.remaining() == 20 and not budget.fits("ats_optimization")
+
+
+def test_a_call_that_overruns_is_abandoned():
+    budget = LatencyBudget(0.05)
+
+    assert budget.run("gap_analysis", lambda: "done") == "done"
+    with pytest.raises(BudgetExceeded, match="during tailoring"):
+        budget.run("tailoring", lambda: time.sleep(1))
+    with pytest.raises(BudgetExceeded):
+        budget.run("auditing", lambda: "too late")
+
+    summary = budget.summary()
+    assert summary["exceeded"] == "tailoring"
+    assert set(summary["stage_seconds"]) == {"gap_analysis", "tailoring"}
+
+
+def test_errors_inside_the_budget_reach_the_caller():
+    def _fail():
+        raise ValueError("provider down")
+
+    with pytest.raises(ValueError, match="provider down"):
+        LatencyBudget(10).run("gap_analysis", _fail)
+
+
+def test_quick_apply_skips_the_interview_and_overlaps_differentiation(make_workflow):
+    workflow = make_workflow(LatencyBudget(300))
+
+    result = workflow.execute(CONTEXT)
+
+    assert result.status == RunStatus.COMPLETED
+    workflow.interrogator_prepper.execute.assert_not_called()
+    # Differentiation ran alongside gap analysis, so without its output.
+    assert workflow.differentiator.execute.call_args[0][0]["gap_analysis"] == {}
+    assert workflow.auditor_suite.execute.call_count == 2
+    assert result.executive_brief["decision"]["fit_score"] == 80
+    assert result.latency_budget["skipped"] == ["interrogation"]
+    assert result.latency_budget["exceeded"] is None
+
+
+def test_tight_budget_drops_optional_stages(make_workflow):
+    workflow = make_workflow(LatencyBudget(60, clock=_Clock()))
+
+    result = workflow.execute(CONTEXT)
+
+    assert result.status == RunStatus.COMPLETED
+    workflow.differentiator.execute.assert_not_called()
+    workflow.ats_optimizer.execute.assert_not_called()
+    assert result.final_documents["resume"] == "Tailored resume"
+    assert result.latency_budget["skipped"] == [
+        "differentiation",
+        "interrogation",
+        "ats_optimization",
+    ]
+
+
+def test_overrun_before_the_documents_exist_fails_the_run(make_workflow):
+    workflow = make_workflow(LatencyBudget(0.3))
+    workflow.tailoring_agent.execute.side_effect = _slow(1, {})
+
+    result = workflow.execute(CONTEXT)
+
+    assert result.status == RunStatus.FAILED
+    assert "exhausted during tailoring" in result.error_message
+    assert result.latency_budget["exceeded"] == "tailoring"
+
+
+def test_overrun_during_the_audit_returns_unaudited_documents_flagged(make_workflow):
+    workflow = make_workflow(LatencyBudget(0.3))
+    workflow.auditor_suite.execute.side_effect = _slow(1, {"approval": {"approved": True}})
+
+    result = workflow.execute(CONTEXT)
+
+    assert result.status == RunStatus.AUDIT_ERROR
+    assert result.final_documents["resume"] == "Tailored resume"  # no time for ATS
+    assert result.latency_budget["exceeded"] == "auditor_suite"
+
+
+def test_without_a_budget_nothing_is_skipped(make_workflow):
+    workflow = make_workflow(None)
+    workflow.interrogator_prepper.execute.return_value = {"questions": []}
+
+    result = workflow.execute({**CONTEXT, "interview_answers": [{"answer": "yes"}]})
+
+    assert result.status == RunStatus.COMPLETED and result.latency_budget is None
+    workflow.ats_optimizer.execute.assert_called_once()
+    assert workflow.differentiator.execute.call_args[0][0]["gap_analysis"] == {"gaps": []}
+
+
+def test_manifest_records_the_budget(tmp_path):
+    budget = LatencyBudget(300)
+    budget.skip("interrogation")
+    result = SimpleNamespace(
+        status=None,
+        final_documents={},
+        audit_report=None,
+        execution_log=[],
+        latency_budget=budget.summary(),
+    )
+
+    run_dir = write_run_artifacts(tmp_path, result, run_id="run-1")
+
+    manifest = json.loads((run_dir / "run.json").read_text())
+    assert manifest["latency_budget"]["budget_seconds"] == 300
+    assert manifest["latency_budget"]["skipped"] == ["interrogation"]
@@ -0,0 +1,116 @@
+"""
+Unit tests for per-provider rate limiting with fair queueing across runs.
+"""
+
+import json
+import threading
+import time
+from types import SimpleNamespace
+from unittest.mock import Mock, patch
+
+import pytest
+
+from runtime.crewai.agents.gap_analyzer import GapAnalyzerAgent
+from runtime.crewai.cancellation import CancelToken, RunCancelled
+from runtime.crewai.rate_limit import (
+    ProviderQueue,
+    RateLimit,
+    RateLimitConfigError,
+    RateLimiter,
+    parse_rate_limits,
+    provider_of,
+)
+
+
+def test_limits_parse_from_the_env_format():
+    assert parse_rate_limits("chutes=60/200000, openrouter=20,gateway=0/9000") == {
+        "chutes": RateLimit(60, 200000),
+        "openrouter": RateLimit(20, None),
+        "gateway": RateLimit(None, 9000),
+    }
+    assert parse_rate_limits("") == {}
+    for bad in ("chutes", "chutes=fast", "=60", "openai=-1"):
+        with pytest.raises(RateLimitConfigError, match="provider=RPM"):
+            parse_rate_limits(bad)
+
+
+def test_provider_is_read_from_the_llm_route():
+    assert provider_of(SimpleNamespace(model="together_ai/meta-llama/Llama-3")) == "together"
+    assert provider_of(SimpleNamespace(model="openrouter/anthropic/claude")) == "openrouter"
+    assert provider_of(SimpleNamespace(model="openai/gpt-4o")) == "openai"
+    chutes = SimpleNamespace(model="openai/deepseek-ai/DeepSeek-V3", base_url="https://chutes.ai")
+    assert provider_of(chutes) == "chutes"
+
+
+def test_requests_over_the_rpm_wait_for_the_window():
+    queue = ProviderQueue(RateLimit(rpm=2), window=0.3)
+    started = time.monotonic()
+    queue.acquire("run", 10)
+    queue.acquire("run", 10)
+    assert time.monotonic() - started < 0.1
+
+    queue.acquire("run", 10)
+    assert time.monotonic() - started >= 0.25
+
+
+def test_token_charges_are_settled_to_the_real_size():
+    queue = ProviderQueue(RateLimit(tpm=100), window=0.3)
+    grant = queue.acquire("run", 80)
+    grant.settle(30)
+
+    started = time.monotonic()
+    queue.acquire("run", 60)  # 30 + 60 fits
+    assert time.monotonic() - started < 0.1
+    queue.acquire("run", 50)  # 90 + 50 does not
+    assert time.monotonic() - started >= 0.25
+
+
+def test_runs_take_turns_instead_of_first_come_first_served():
+    queue = ProviderQueue(RateLimit(rpm=1), window=0.15)
+    order = []
+
+    def _call(owner):
+        queue.acquire(owner, 1)
+        order.append(owner)
+
+    threads = [threading.Thread(target=_call, args=(o,)) for o in ("big", "big", "big", "small")]
+    for thread in threads:
+        thread.start()
+        time.sleep(0.03)  # queue them in this order
+    for thread in threads:
+        thread.join(5)
+
+    # The big job's backlog does not hold the small job's one call behind all of it.
+    assert order == ["big", "big", "small", "big"]
+
+
+def test_a_cancelled_run_leaves_the_queue():
+    queue = ProviderQueue(RateLimit(rpm=1), window=10)
+    queue.acquire("other", 1)
+    token = CancelToken()
+    threading.Timer(0.2, token.cancel, args=("stop",)).start()
+
+    with pytest.raises(RunCancelled, match="stop during tailoring"):
+        queue.acquire("run", 1, token, "tailoring")
+    assert not queue._turns and not queue._waiting
+
+
+def test_agents_queue_their_calls_under_their_run():
+    limiter = Mock(limits={"openai": RateLimit(rpm=5)})
+    grant = limiter.acquire.return_value
+    agent = GapAnalyzerAgent(SimpleNamespace(model="openai/gpt-4o"))
+    agent.rate_limit_owner = "run-1"
+    answer = json.dumps({"requirements": [], "gaps": [], "fit_score": 70})
+
+    with (
+        patch("runtime.crewai.base_agent.shared_limiter", return_value=limiter),
+        patch.object(agent, "_invoke_llm", return_value=answer),
+    ):
+        agent.execute({"job_description": "Platform engineer", "resume": "Jane Doe"})
+
+    provider, owner, tokens, _, stage = limiter.acquire.call_args.args
+    assert (provider, owner, stage) == ("openai", "run-1", agent.role) and tokens > 0
+    assert grant.settle.call_args.args[0] > tokens
+
+    # No limits configured: nothing is queued.
+    assert RateLimiter({}).acquire("openai", "run-1", 10) is None
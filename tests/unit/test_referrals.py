@@ -0,0 +1,210 @@
+"""
+Unit tests for the referral finder: contacts, company matching, the agent and the stage.
+"""
+
+import json
+from unittest.mock import Mock, patch
+
+import pytest
+from crewai import LLM
+
+from runtime.crewai.agents.referrals import ReferralFinderAgent
+from runtime.crewai.artifacts import MANIFEST_FILE, write_run_artifacts
+from runtime.crewai.cli import _report_referrals, main
+from runtime.crewai.hydra_workflow import RunStatus
+from runtime.crewai.pipeline_config import PipelineConfig
+from runtime.crewai.referrals import (
+    REFERRALS_FILE,
+    Contact,
+    ContactsError,
+    load_contacts,
+    referral_paths,
+    same_company,
+)
+from runtime.crewai.run_control import resume_arguments
+from tests.conftest import patched_workflow
+
+CONTACTS_CSV = """Name,Company,Relationship,Title,Email
+Sam Lee,"Acme, Inc.",met at KubeCon,Staff Engineer,sam@example.com
+Ana Ruiz,Acme Robotics GmbH,former colleague,Engineering Manager,
+Kim Park,Globex,friend,,
+,Acme,friend,,
+"""
+CONTACTS = [
+    Contact("Sam Lee", "Acme, Inc.", "met at KubeCon", "Staff Engineer"),
+    Contact("Ana Ruiz", "Acme Robotics GmbH", "former colleague", "Engineering Manager"),
+    Contact("Kim Park", "Globex", "friend"),
+]
+
+
+def test_load_contacts_reads_known_columns_and_skips_incomplete_rows(tmp_path):
+    path = tmp_path / "contacts.csv"
+    path.write_text(CONTACTS_CSV, encoding="utf-8-sig")
+
+    assert load_contacts(path) == CONTACTS
+
+    path.write_text("Full Name,Employer\nSam,Acme\n")
+    with pytest.raises(ContactsError, match="needs name and company column"):
+        load_contacts(path)
+    with pytest.raises(ContactsError, match="No such contacts file"):
+        load_contacts(tmp_path / "missing.csv")
+
+
+def test_companies_match_without_case_punctuation_or_legal_suffixes():
+    assert same_company("Acme, Inc.", "ACME")
+    assert same_company("Acme Robotics GmbH", "Acme")
+    assert not same_company("Acme", "Acmesoft")
+    assert not same_company("Globex", "Acme")
+    assert not same_company("Inc.", "Inc")  # no name left to compare
+
+
+def test_referral_paths_rank_closest_ties_first():
+    paths = referral_paths(CONTACTS, "Acme")
+
+    assert [(p["id"], p["name"], p["path"]) for p in paths] == [
+        (1, "Ana Ruiz", "referral"),
+        (2, "Sam Lee", "advice"),
+    ]
+    assert referral_paths(CONTACTS, None) == []
+
+
+def test_agent_drafts_asks_only_for_the_given_contacts():
+    with patch("runtime.crewai.base_agent.Path.read_text", return_value="Referral prompt"):
+        agent = ReferralFinderAgent(LLM(model="gpt-4", api_key="test-key"))
+    agent.execute_with_retry = Mock(
+        return_value={
+            "asks": [
+                {"contact": 1, "path": "introduction", "ask": "Hi Ana — " + "x " * 800},
+                {"contact": 9, "path": "referral", "ask": "Hi stranger"},
+                {"contact": "[2]", "path": "beg", "ask": "Hi Sam, could we talk?"},
+            ]
+        }
+    )
+
+    result = agent.execute(
+        {
+            "job_description": "Platform Engineer at Acme",
+            "company": "Acme",
+            "contacts": referral_paths(CONTACTS, "Acme"),
+            "tailored_resume": "# Jane",
+        }
+    )
+
+    ana, sam = result["contacts"]
+    assert ana["path"] == "introduction" and len(ana["ask"]) <= 1200
+    assert sam["path"] == "advice"  # "beg" is not a path: the suggestion stands
+    assert len(result["contacts"]) == 2
+    prompt = agent.execute_with_retry.call_args[0][0].description
+    assert "[1] Ana Ruiz — Engineering Manager; relationship: former colleague" in prompt
+    assert "Kim Park" not in prompt
+
+
+def _workflow(contacts):
+    workflow = patched_workflow(
+        auto_approve=True, pipeline_config=PipelineConfig(), contacts=contacts
+    )
+    workflow.gap_analyzer.execute.return_value = {"gaps": [], "confidence": 0.9}
+    workflow.interrogator_prepper.execute.return_value = {"questions": [], "confidence": 0.9}
+    workflow.differentiator.execute.return_value = {"differentiators": ["Go"], "confidence": 0.9}
+    workflow.tailoring_agent.execute.return_value = {
+        "tailored_resume": "Resume",
+        "tailored_cover_letter": "Letter",
+        "confidence": 0.9,
+    }
+    workflow.ats_optimizer.execute.return_value = {"optimized_resume": "R", "confidence": 0.9}
+    workflow.auditor_suite.execute.return_value = {"approval": {"approved": True}}
+    workflow.executive_synthesizer.execute.return_value = {"confidence": 0.9}
+    workflow.referral_agent.execute.side_effect = lambda context: {
+        "contacts": [{**c, "ask": f"Hi {c['name']}"} for c in context["contacts"]],
+        "confidence": 0.9,
+    }
+    return workflow
+
+
+def test_stage_matches_contacts_and_only_sends_the_matches():
+    workflow = _workflow(CONTACTS)
+    context = {"job_description": "JD", "resume": "Resume", "source_documents": "Sources"}
+
+    result = workflow.execute({**context, "company": "Acme"})
+
+    assert result.status is RunStatus.COMPLETED
+    referrals = result.referrals
+    assert referrals["company"] == "Acme" and referrals["contacts_checked"] == 3
+    assert [c["name"] for c in referrals["contacts"]] == ["Ana Ruiz", "Sam Lee"]
+    sent = workflow.referral_agent.execute.call_args.args[0]
+    assert sent["tailored_resume"] == "R" and len(sent["contacts"]) == 2
+
+    # No one at the company: no model call, and an empty list to show for it.
+    workflow = _workflow(CONTACTS)
+    result = workflow.execute({**context, "company": "Initech"})
+    workflow.referral_agent.execute.assert_not_called()
+    assert result.referrals["contacts"] == []
+
+    # No company to match against: skipped.
+    workflow = _workflow(CONTACTS)
+    result = workflow.execute(context)
+    assert result.referrals is None
+    assert any("no target company" in line for line in result.execution_log)
+
+
+def test_referrals_md_names_contacts_and_run_json_only_counts(tmp_path, capsys):
+    referrals = {
+        "company": "Acme",
+        "contacts_checked": 3,
+        "contacts": [
+            {**referral_paths(CONTACTS, "Acme")[0], "ask": "Hi Ana, would you refer me?"},
+            {**referral_paths(CONTACTS, "Acme")[1], "ask": ""},
+        ],
+    }
+
+    class Result:
+        final_documents = {"resume": "# Jane"}
+
+    Result.referrals = referrals
+    run_dir = write_run_artifacts(tmp_path, Result(), run_id="run-1")
+
+    text = (run_dir / REFERRALS_FILE).read_text()
+    assert "## Ana Ruiz (Engineering Manager, former colleague)" in text
+    assert "**Path:** referral" in text and "Hi Ana, would you refer me?" in text
+    assert "_No ask was drafted._" in text
+    manifest = json.loads((run_dir / MANIFEST_FILE).read_text())
+    assert manifest["referrals"] == {
+        "contacts_checked": 3,
+        "matches": 2,
+        "paths": {"referral": 1, "introduction": 0, "advice": 1},
+        "drafted": 1,
+    }
+    assert "Ana" not in json.dumps(manifest)
+
+    _report_referrals(referrals, True)
+    out = capsys.readouterr().out
+    assert f"Referrals: 2 of 3 contact(s) at Acme → {REFERRALS_FILE}" in out
+    assert "Sam Lee: advice (no ask drafted)" in out
+
+
+def test_cli_checks_the_contacts_file_and_resume_keeps_it(tmp_path, capsys):
+    jd, resume = tmp_path / "jd.md", tmp_path / "resume.md"
+    jd.write_text("JD")
+    resume.write_text("Resume")
+    (tmp_path / "sources").mkdir()
+    (tmp_path / "sources" / "cv.md").write_text("Sources")
+    paths = ["--jd", str(jd), "--resume", str(resume), "--sources", str(tmp_path / "sources")]
+
+    with pytest.raises(SystemExit):
+        main([*paths, "--out", str(tmp_path / "out"), "--contacts", str(tmp_path / "no.csv")])
+    assert "--contacts: No such contacts file" in capsys.readouterr().err
+
+    (tmp_path / MANIFEST_FILE).write_text(
+        json.dumps(
+            {
+                "status": "paused",
+                "inputs": {
+                    "jd_path": "jd.md",
+                    "resume_path": "resume.md",
+                    "contacts_path": "/home/jane/contacts.csv",
+                },
+            }
+        )
+    )
+    args = resume_arguments(tmp_path)
+    assert args[args.index("--contacts") + 1] == "/home/jane/contacts.csv"
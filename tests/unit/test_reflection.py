@@ -0,0 +1,109 @@
+"""
+Unit tests for reflection: agents critiquing and revising their own first pass.
+"""
+
+from types import SimpleNamespace
+
+import pytest
+
+from runtime.crewai.artifacts import build_manifest
+from runtime.crewai.pipeline_config import (
+    PipelineConfig,
+    PipelineConfigError,
+    load_pipeline_config,
+)
+from runtime.crewai.reflection import ReflectionPolicy, reflect
+from tests.conftest import patched_workflow
+
+
+class _Drafter:
+    """An agent whose every pass is a new draft, and which notes what it was told."""
+
+    role = "Tailoring Agent"
+
+    def __init__(self, fail_on=None):
+        self.critique = None
+        self.critiques = []
+        self.fail_on = fail_on
+
+    def execute(self, context):
+        self.critiques.append(self.critique)
+        draft = len(self.critiques)
+        if draft == self.fail_on:
+            raise ValueError("bad JSON")
+        return {"resume": f"draft {draft} for {context['job']}", "confidence": 0.9}
+
+
+def test_each_iteration_revises_the_previous_answer():
+    agent = _Drafter()
+
+    result = reflect(agent, 2)({"job": "SRE"})
+
+    assert result["resume"] == "draft 3 for SRE"
+    assert agent.critiques[0] is None
+    assert "REFLECTION (1/2)" in agent.critiques[1] and "draft 1 for SRE" in agent.critiques[1]
+    assert "REFLECTION (2/2)" in agent.critiques[2] and "draft 2 for SRE" in agent.critiques[2]
+    assert '"confidence"' not in agent.critiques[1]
+    assert agent.critique is None
+
+
+def test_a_failed_revision_keeps_the_last_good_answer():
+    agent = _Drafter(fail_on=3)
+    agent.critique = "an outer critique"
+    logged = []
+
+    result = reflect(agent, 3, logged.append)({"job": "SRE"})
+
+    assert result["resume"] == "draft 2 for SRE"
+    assert len(agent.critiques) == 3
+    assert logged == ["Reflection 2/3 of Tailoring Agent failed: bad JSON"]
+    assert agent.critique == "an outer critique"  # restored
+
+
+def test_no_iterations_is_the_plain_agent():
+    agent = _Drafter()
+    assert reflect(agent, 0) == agent.execute
+
+
+def test_policy_is_per_stage_and_off_by_default():
+    policy = ReflectionPolicy.from_dict({"tailoring": 2, "audit": True, "research": False})
+
+    assert policy.for_stage("tailoring") == 2
+    assert policy.for_stage("auditor_suite") == 1  # the audit stage under its agent name
+    assert policy.for_stage("research_agent") == 0
+    assert ReflectionPolicy().for_stage("tailoring") == 0
+    assert PipelineConfig.from_dict({"reflection": policy.to_dict()}).reflection == policy
+
+
+@pytest.mark.parametrize(
+    "text, message",
+    [
+        ("reflection:\n  cover_letter: 1\n", "unknown stage"),
+        ("reflection:\n  tailoring: 9\n", "reflection.tailoring"),
+        ("reflection: [tailoring]\n", "'reflection' must be a mapping"),
+    ],
+)
+def test_bad_reflection_configs_are_rejected(tmp_path, text, message):
+    path = tmp_path / "pipeline.yaml"
+    path.write_text(text)
+    with pytest.raises(PipelineConfigError, match=message):
+        load_pipeline_config(path)
+
+
+def test_the_workflow_reflects_the_configured_stages():
+    config = PipelineConfig.from_dict({"reflection": {"tailoring": 1}})
+    workflow = patched_workflow(auto_approve=True, pipeline_config=config)
+    workflow._begin_run()
+    workflow._fit_context = lambda agent, context, stage: context
+    tailor = _Drafter()
+    differentiator = _Drafter()
+
+    assert workflow._run_agent(tailor, {"job": "SRE"}, "tailoring")["resume"] == (
+        "draft 2 for SRE"
+    )
+    workflow._run_agent(differentiator, {"job": "SRE"}, "differentiation")
+
+    assert len(differentiator.critiques) == 1
+    assert workflow._reflection_summary() == {"tailoring": 1}
+    manifest = build_manifest("run-1", SimpleNamespace(reflection={"tailoring": 1}))
+    assert manifest["reflection"] == {"tailoring": 1}
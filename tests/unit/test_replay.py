@@ -0,0 +1,138 @@
+"""
+Unit tests for replaying a run from one stage with other prompts.
+"""
+
+import json
+from types import SimpleNamespace
+from unittest.mock import Mock, patch
+
+import pytest
+
+from runtime.crewai import cli
+from runtime.crewai.hydra_workflow import HydraWorkflow, RunStatus
+from runtime.crewai.replay import load_prompt_overrides, upstream
+from runtime.crewai.run_control import MANIFEST_FILE, replay_arguments
+
+STORED = {
+    "research": {"company": "Acme"},
+    "gap_analysis": {"gaps": ["Terraform"]},
+    "interrogation": {"questions": [], "interview_notes": ["Led the AWS migration"]},
+    "differentiation": {"differentiators": ["Migrated 40 services"]},
+    "tailoring": {"tailored_resume": "Old résumé", "tailored_cover_letter": "Old letter"},
+    "ats_optimization": {"optimized_resume": "Old résumé"},
+    "salary_check": {"band": "ok"},
+    "tone_check": {"tone": "ok"},
+    "compensation": {"ask": 180000},
+}
+PLUGINS = [
+    SimpleNamespace(name="salary_check", after="gap_analysis"),
+    SimpleNamespace(name="tone_check", after="tailoring"),
+]
+
+
+def test_a_replay_reuses_only_the_stages_before_it_and_their_plugins():
+    assert list(upstream(STORED, "tailoring", PLUGINS)) == [
+        "research",
+        "gap_analysis",
+        "interrogation",
+        "differentiation",
+        "salary_check",
+    ]
+    assert list(upstream(STORED, "gap_analysis", PLUGINS)) == ["research"]
+    with pytest.raises(ValueError, match="cannot replay from 'compensation'"):
+        upstream(STORED, "compensation")
+
+
+def test_a_prompt_directory_replaces_the_agents_it_names(tmp_path):
+    root = tmp_path / "repo"
+    for agent in ("gap-analyzer", "tailoring-agent"):
+        (root / "agents" / agent).mkdir(parents=True)
+        (root / "agents" / agent / "prompt.md").write_text(f"{agent} v1")
+    prompts = tmp_path / "p2"
+    (prompts / "tailoring-agent").mkdir(parents=True)
+    (prompts / "tailoring-agent" / "prompt.md").write_text("Tailor v2")
+    (prompts / "gap-analyzer.md").write_text("Gaps v2")
+
+    assert load_prompt_overrides(prompts, root) == {
+        "agents/tailoring-agent/prompt.md": "Tailor v2",
+        "agents/gap-analyzer/prompt.md": "Gaps v2",
+    }
+    (prompts / "tailor.md").write_text("typo")
+    with pytest.raises(ValueError, match="no agent named tailor "):
+        load_prompt_overrides(prompts, root)
+    with pytest.raises(ValueError, match="not a directory"):
+        load_prompt_overrides(tmp_path / "missing", root)
+
+    workflow = HydraWorkflow(
+        Mock(),
+        use_per_agent_models=False,
+        prompt_overrides={"agents/tailoring-agent/prompt.md": "Tailor v2"},
+    )
+    assert workflow.tailoring_agent.prompt == "Tailor v2"
+    assert workflow.gap_analyzer.prompt.startswith("# GAP-ANALYZER")
+
+
+def _workflow():
+    names = [
+        "GapAnalyzerAgent",
+        "InterrogatorPrepperAgent",
+        "DifferentiatorAgent",
+        "TailoringAgent",
+        "ATSOptimizerAgent",
+        "AuditorSuiteAgent",
+        "ExecutiveSynthesizerAgent",
+    ]
+    patches = [patch(f"runtime.crewai.hydra_workflow.{name}") for name in names]
+    for p in patches:
+        p.start()
+    try:
+        return HydraWorkflow(Mock(), use_per_agent_models=False, auto_approve=True)
+    finally:
+        for p in patches:
+            p.stop()
+
+
+def test_a_replay_runs_the_stages_from_its_start_again():
+    workflow = _workflow()
+    workflow.tailoring_agent.execute.return_value = {
+        "tailored_resume": "New résumé",
+        "tailored_cover_letter": "New letter",
+        "confidence": 0.9,
+    }
+    workflow.ats_optimizer.execute.return_value = {"optimized_resume": "New résumé"}
+    workflow.auditor_suite.execute.return_value = {"approved": True, "final_status": "APPROVED"}
+    workflow.executive_synthesizer.execute.return_value = {"brief": "ok"}
+    context = {
+        "job_description": "Senior Platform Engineer",
+        "resume": "Jane Doe",
+        "source_documents": "Jane Doe, AWS",
+        "previous_results": upstream(STORED, "tailoring", PLUGINS),
+        "replay_from": "tailoring",
+    }
+
+    result = workflow.execute(context)
+
+    assert result.status in (RunStatus.COMPLETED, RunStatus.COMPLETED_WITH_AUDIT_CONCERNS)
+    for agent in (workflow.gap_analyzer, workflow.interrogator_prepper, workflow.differentiator):
+        agent.execute.assert_not_called()
+    tailoring_context = workflow.tailoring_agent.execute.call_args[0][0]
+    assert tailoring_context["differentiators"] == ["Migrated 40 services"]
+    assert tailoring_context["interview_notes"] == ["Led the AWS migration"]
+    assert workflow.intermediate_results["tailoring"]["tailored_resume"] == "New résumé"
+
+
+def test_replay_arguments_start_a_new_run_from_a_saved_one(tmp_path, capsys):
+    run_dir = tmp_path / "run-1"
+    run_dir.mkdir()
+    inputs = {"jd_path": "jd.md", "resume_path": "r.md", "prompt_dir": "/p1", "seed": 7}
+    manifest = {"run_id": "run-1", "status": "completed", "inputs": inputs}
+    (run_dir / MANIFEST_FILE).write_text(json.dumps(manifest))
+
+    assert replay_arguments(run_dir, "tailoring") == [
+        "--jd", "jd.md", "--resume", "r.md", "--deterministic", "--seed", "7",
+        "--prompt-dir", "/p1", "--out", str(tmp_path),
+        "--replay-run", "run-1", "--replay-from", "tailoring",
+    ]  # fmt: skip
+    with pytest.raises(SystemExit):
+        cli.main(["replay", "run-1", "--out", str(tmp_path), "--from", "compensation"])
+    assert "invalid choice: 'compensation'" in capsys.readouterr().err
@@ -0,0 +1,127 @@
+"""
+Unit tests for the baseline-vs-tailored résumé diff.
+"""
+
+import json
+from types import SimpleNamespace
+
+from runtime.crewai.artifacts import write_run_artifacts
+from runtime.crewai.resume_diff import (
+    RESUME_DIFF_FILE,
+    RESUME_DIFF_HTML_FILE,
+    html_diff,
+    inline_diff,
+    numbers_in,
+    side_by_side,
+    summarize,
+    unified_diff,
+)
+
+BASELINE = """# Jane Doe
+Led migration of 40 services to Kubernetes in 2021.
+Managed the on-call rotation for the platform team.
+"""
+TAILORED = """# Jane Doe
+Led migration of 40 services to Kubernetes in 2021, cutting costs 35%.
+Mentored 5 engineers.
+"""
+
+
+def test_unified_diff_marks_changes_and_colours_on_request():
+    plain = unified_diff(BASELINE, TAILORED)
+    coloured = unified_diff(BASELINE, TAILORED, color=True)
+
+    assert "-Managed the on-call rotation for the platform team." in plain
+    assert "+Mentored 5 engineers." in plain
+    assert "\033[" not in plain
+    assert "\033[32m+Mentored 5 engineers.\033[0m" in coloured
+
+
+def test_side_by_side_aligns_rows_and_marks_changed_ones():
+    rows = side_by_side(BASELINE, TAILORED, width=100).splitlines()
+
+    assert rows[0].startswith("# Jane Doe") and " | " not in rows[0]
+    assert " | " in rows[2]
+    assert rows[2].endswith("Mentored 5 engineers.")
+
+
+def test_html_diff_is_a_standalone_page():
+    html = html_diff(BASELINE, TAILORED)
+
+    assert html.lstrip().startswith("<!DOCTYPE html")
+    assert "Mentored" in html
+
+
+def test_numbers_in_catches_metrics_and_amounts():
+    assert numbers_in("saved $1.2M, 3x faster for 15,000 users (40%).") == {
+        "$1.2M",
+        "3x",
+        "15,000",
+        "40%",
+    }
+
+
+def test_summary_flags_new_numbers_and_dropped_lines():
+    summary = summarize(BASELINE, TAILORED)
+
+    assert summary.added_lines == 2 and summary.removed_lines == 2
+    assert summary.new_numbers == ["35%", "5"]
+    assert summary.dropped_lines == ["Managed the on-call rotation for the platform team."]
+
+
+def test_numbers_backed_by_sources_are_not_flagged():
+    summary = summarize(BASELINE, TAILORED, sources="Cut infra costs by 35%. Mentored 5 people.")
+
+    assert summary.new_numbers == []
+
+
+def test_run_artifacts_include_diff_files_and_content_free_summary(tmp_path):
+    result = SimpleNamespace(
+        status=None, final_documents={"resume": TAILORED}, audit_report=None, execution_log=[]
+    )
+
+    run_dir = write_run_artifacts(tmp_path, result, run_id="run-1", baseline_resume=BASELINE)
+
+    assert "+Mentored 5 engineers." in (run_dir / RESUME_DIFF_FILE).read_text()
+    assert (run_dir / RESUME_DIFF_HTML_FILE).exists()
+    manifest = json.loads((run_dir / "run.json").read_text())
+    assert manifest["resume_diff"] == {
+        "added_lines": 2,
+        "removed_lines": 2,
+        "new_numbers": 2,
+        "dropped_lines": 1,
+    }
+    assert RESUME_DIFF_FILE in manifest["artifacts"]
+
+
+def test_cli_diff_subcommand_uses_the_recorded_baseline(tmp_path, capsys):
+    from runtime.crewai import cli
+
+    baseline = tmp_path / "resume.md"
+    baseline.write_text(BASELINE)
+    run_dir = tmp_path / "out" / "run-1"
+    run_dir.mkdir(parents=True)
+    (run_dir / "resume.md").write_text(TAILORED)
+    (run_dir / "run.json").write_text(json.dumps({"inputs": {"resume_path": str(baseline)}}))
+    html_path = tmp_path / "diff.html"
+
+    code = cli.main(["diff", str(run_dir), "--html", str(html_path)])
+
+    assert code == 0
+    assert "+Mentored 5 engineers." in capsys.readouterr().out
+    assert html_path.exists()
+
+
+def test_inline_diff_rebuilds_the_whole_tailored_resume_from_the_stored_diff():
+    baseline = "\n".join(["Jane"] + [f"line {i}" for i in range(12)])
+    tailored = baseline.replace("line 2", "line two").replace("line 11", "line 11\nline 12")
+    rows = inline_diff(tailored, unified_diff(baseline, tailored))
+
+    assert [line for kind, line in rows if kind != "removed"] == tailored.splitlines()
+    assert [(kind, line) for kind, line in rows if kind != "same"] == [
+        ("removed", "line 2"),
+        ("added", "line two"),
+        ("added", "line 12"),
+    ]
+    assert rows[3] == ("removed", "line 2")  # where it was
+    assert inline_diff("same", "") == [("same", "same")]
@@ -0,0 +1,232 @@
+"""
+Unit tests for the structured résumé model, per-bullet patches and their review.
+"""
+
+import json
+from unittest.mock import Mock, patch
+
+import pytest
+from crewai import LLM
+
+from runtime.crewai.agents.tailoring_agent import TailoringAgent
+from runtime.crewai.artifacts import MANIFEST_FILE, RESUME_FILE, write_run_artifacts
+from runtime.crewai.resume_diff import RESUME_DIFF_FILE
+from runtime.crewai.resume_model import (
+    ACCEPTED,
+    PROPOSED,
+    REJECTED,
+    RESUME_PATCHES_FILE,
+    apply_patches,
+    decide,
+    load_patches,
+    parse_resume,
+    propose_patches,
+    save_patches,
+)
+from runtime.crewai.review_server import ReviewServer, decide_patches
+from runtime.crewai.run_control import resume_arguments
+
+RESUME = """# Jane Doe
+jane@example.com
+
+## Experience
+
+### Platform Engineer | Acme
+2021 – present
+- Ran the CI pipeline
+- Wrote runbooks
+- Organised the summer party
+
+### Engineer | Initech
+* Built the billing API
+
+## Skills
+Go, Kubernetes
+"""
+RAW = [
+    {
+        "op": "replace",
+        "target": "experience-1-1",
+        "text": "Cut deploys from 45 to 8 minutes",
+        "reason": "JD asks for CI/CD",
+    },
+    {"op": "insert", "target": "[experience-1-2]", "text": "Led the on-call rotation"},
+    {"op": "delete", "target": "experience-1-3", "reason": "Unrelated"},
+    {"op": "rewrite", "target": "experience-1-1", "text": "?"},
+    {"op": "replace", "target": "experience-9-1", "text": "?"},
+    {"op": "insert", "target": "experience-2-1", "text": ""},
+    {"op": "replace", "target": "experience-2-1", "text": "Built the billing API"},
+]
+PATCHED = RESUME.replace("- Ran the CI pipeline", "- Cut deploys from 45 to 8 minutes").replace(
+    "- Organised the summer party", "- Led the on-call rotation"
+)
+
+
+def test_parse_resume_round_trips_and_numbers_bullets():
+    document = parse_resume(RESUME)
+
+    assert document.render() == RESUME
+    assert [bullet.id for _, _, bullet in document.bullets()] == [
+        "experience-1-1",
+        "experience-1-2",
+        "experience-1-3",
+        "experience-2-1",
+    ]
+    section, entry, bullet = document.find("experience-2-1")
+    assert (section.title, entry.heading, bullet.text) == (
+        "Experience",
+        "Engineer | Initech",
+        "Built the billing API",
+    )
+    assert "[experience-1-2] Wrote runbooks" in document.outline()
+    assert "2021 – present" in document.outline()
+    assert parse_resume("## Skills\n## Skills\n- Go").find("skills2-0-1") is not None
+
+
+def test_propose_patches_checks_targets_and_applies_by_id():
+    document = parse_resume(RESUME)
+
+    patches, problems = propose_patches(document, RAW)
+
+    assert [(p.id, p.op, p.target) for p in patches] == [
+        ("p1", "replace", "experience-1-1"),
+        ("p2", "insert", "experience-1-2"),
+        ("p3", "delete", "experience-1-3"),
+    ]
+    assert patches[0].before == "Ran the CI pipeline"
+    assert patches[0].entry == "Platform Engineer | Acme"
+    assert patches[1].anchor == "Wrote runbooks"
+    assert patches[2].anchor == "Wrote runbooks"
+    assert problems == [
+        "experience-1-1: unknown op 'rewrite'",
+        "experience-9-1: no such bullet",
+        "experience-2-1: insert without text",
+    ]
+    assert apply_patches(document, patches).render() == PATCHED
+    patches[0].status = REJECTED
+    assert "- Ran the CI pipeline" in apply_patches(document, patches).render()
+    assert document.render() == RESUME  # the baseline is left alone
+
+
+def test_decide_undoes_and_redoes_patches_by_text():
+    patches, _ = propose_patches(parse_resume(RESUME), RAW)
+
+    text, conflicts = decide(PATCHED, patches, {"p1": REJECTED, "p2": REJECTED, "p3": REJECTED})
+    assert conflicts == [] and text == RESUME
+    assert {p.status for p in patches} == {REJECTED}
+
+    text, conflicts = decide(text, patches, {"p1": ACCEPTED, "p2": ACCEPTED, "p3": ACCEPTED})
+    assert conflicts == [] and text == PATCHED
+
+    # A later stage rewrote the replacement: rejecting it cannot be matched.
+    rewritten = PATCHED.replace("Cut deploys from 45 to 8 minutes", "Cut deploy time by 82%")
+    text, conflicts = decide(rewritten, patches, {"p1": REJECTED, "p3": ACCEPTED})
+    assert conflicts == ["p1"] and text == rewritten
+    assert patches[0].status == ACCEPTED
+
+
+def test_tailoring_agent_patch_mode_returns_the_patched_baseline():
+    with patch("runtime.crewai.base_agent.Path.read_text", return_value="Tailoring prompt"):
+        agent = TailoringAgent(LLM(model="gpt-4", api_key="test-key"))
+    agent.execute_with_retry = Mock(
+        return_value={"tailored_output": {"resume": {"content": ""}}, "resume_patches": RAW}
+    )
+    context = {
+        "job_description": "JD",
+        "resume": RESUME,
+        "source_documents": "Sources",
+        "gap_analysis": {},
+        "interview_notes": "",
+        "differentiators": [],
+        "resume_patches": True,
+    }
+
+    result = agent.execute(context)
+
+    assert result["tailored_resume"] == PATCHED
+    assert result["tailored_output"]["resume"] == PATCHED
+    assert [p["status"] for p in result["resume_patches"]] == [PROPOSED] * 3
+    assert len(result["patch_problems"]) == 3
+    prompt = agent.execute_with_retry.call_args[0][0].description
+    assert "[experience-1-3] Organised the summer party" in prompt
+
+    agent.execute_with_retry = Mock(return_value={"tailored_resume": "# Rewritten"})
+    with pytest.raises(Exception, match="no resume_patches"):
+        agent.execute(context)
+
+
+def _patched_run(tmp_path):
+    baseline = tmp_path / "resume.md"
+    baseline.write_text(RESUME)
+    patches, problems = propose_patches(parse_resume(RESUME), RAW)
+
+    class Result:
+        final_documents = {"resume": PATCHED}
+        intermediate_results = {
+            "tailoring": {
+                "resume_patches": [p.to_dict() for p in patches],
+                "patch_problems": problems,
+            }
+        }
+
+    run_dir = write_run_artifacts(tmp_path / "out", Result(), run_id="run-1")
+    manifest = json.loads((run_dir / MANIFEST_FILE).read_text())
+    manifest["status"] = "paused"
+    manifest["inputs"] = {"jd_path": "jd.md", "resume_path": str(baseline), "patches": True}
+    (run_dir / MANIFEST_FILE).write_text(json.dumps(manifest))
+    return run_dir
+
+
+def test_artifacts_keep_the_patches_and_count_them(tmp_path):
+    run_dir = _patched_run(tmp_path)
+
+    assert [p.id for p in load_patches(run_dir)] == ["p1", "p2", "p3"]
+    manifest = json.loads((run_dir / MANIFEST_FILE).read_text())
+    assert manifest["resume_patches"] == {
+        "proposed": 3,
+        "accepted": 0,
+        "rejected": 0,
+        "dropped": 3,
+    }
+    assert RESUME_PATCHES_FILE in manifest["artifacts"]
+    assert "--patches" in resume_arguments(run_dir)
+    assert load_patches(tmp_path) is None
+
+
+def test_decide_patches_edits_the_run_and_its_diff(tmp_path):
+    run_dir = _patched_run(tmp_path)
+
+    assert decide_patches(run_dir, {"p3": REJECTED}) == []
+
+    resume = (run_dir / RESUME_FILE).read_text()
+    assert "- Organised the summer party" in resume and "- Led the on-call rotation" in resume
+    diff = (run_dir / RESUME_DIFF_FILE).read_text()
+    assert "-- Organised the summer party" not in diff and "+- Led the on-call" in diff
+    manifest = json.loads((run_dir / MANIFEST_FILE).read_text())
+    assert manifest["resume_patches"]["rejected"] == 1
+    assert manifest["resume_patches"]["dropped"] == 3
+    with pytest.raises(ValueError, match="No such patch: p9"):
+        decide_patches(run_dir, {"p9": ACCEPTED})
+
+    save_patches(tmp_path, [])
+    (tmp_path / RESUME_FILE).write_text(RESUME)
+    assert decide_patches(tmp_path, {}) == []
+
+
+def test_review_pages_list_patches_and_take_decisions(tmp_path):
+    run_dir = _patched_run(tmp_path)
+    app = ReviewServer(run_dir.parent, "s3cret")
+
+    page = app.handle("GET", f"/runs/{run_dir.name}", cookie_token="s3cret")[2].decode()
+    assert "Résumé patches" in page and "3 proposed" in page
+    assert "<del>Ran the CI pipeline</del>" in page and "JD asks for CI/CD" in page
+
+    url = f"/runs/{run_dir.name}/patches"
+    status, headers, _ = app.handle("POST", url, {"patch": "p1", "decision": REJECTED}, "s3cret")
+    assert status == 303 and headers["Location"] == f"/runs/{run_dir.name}"
+    assert "- Ran the CI pipeline" in (run_dir / RESUME_FILE).read_text()
+    bad = app.handle("POST", url, {"patch": "p1", "decision": "maybe"}, "s3cret")
+    assert bad[0] == 400
+    (run_dir / RESUME_FILE).write_text(RESUME.replace("Wrote runbooks", "Wrote docs"))
+    conflict = app.handle("POST", url, {"patch": "p2", "decision": REJECTED}, "s3cret")
+    assert conflict[0] == 409 and "no longer matches" in conflict[2].decode()
@@ -0,0 +1,185 @@
+"""
+Unit tests for résumé themes (Markdown / LaTeX / PDF rendering).
+"""
+
+import json
+import subprocess
+from pathlib import Path
+
+import pytest
+
+from runtime.crewai.artifacts import write_run_artifacts
+from runtime.crewai.cli import main
+from runtime.crewai.resume_themes import (
+    BUILTIN_THEMES_DIR,
+    LATEX_FILE,
+    PDF_FILE,
+    THEMED_MARKDOWN_FILE,
+    ThemeError,
+    available_themes,
+    latex_inline,
+    load_theme,
+    parse_resume,
+    render,
+    write_theme,
+)
+
+RESUME = """# Jane Doe
+
+**Platform Engineer**
+
+Berlin | jane@example.com | [GitHub](https://github.com/jane_doe)
+
+---
+
+## Experience
+
+### Acme — Staff Engineer
+**2021 – Present | Remote**
+
+- Cut deploy time 40% & halved on-call pages
+- Led the *Terraform* migration
+
+Technologies: AWS, Terraform
+
+## Skills
+
+**Cloud:** AWS, GCP
+**Languages:** Go, Python
+"""
+
+
+def _builtin():
+    return [BUILTIN_THEMES_DIR]
+
+
+def test_resume_is_parsed_into_header_and_entries():
+    resume = parse_resume(RESUME)
+
+    assert resume.name == "Jane Doe"
+    assert resume.headline == "Platform Engineer"
+    github = "[GitHub](https://github.com/jane_doe)"
+    assert resume.contact == ["Berlin", "jane@example.com", github]
+    experience, skills = resume.sections
+    entry = experience.entries[0]
+    assert entry.heading == "Acme — Staff Engineer"
+    assert entry.details == "2021 – Present | Remote"
+    assert [kind for kind, _ in entry.content] == ["item", "item", "paragraph"]
+    lines = ["**Cloud:** AWS, GCP", "**Languages:** Go, Python"]
+    assert skills.entries[0].content == [("paragraph", lines)]
+
+    with pytest.raises(ThemeError, match="no '# Name' heading"):
+        parse_resume("Just some text")
+
+
+@pytest.mark.parametrize("name", ["classic", "modern", "compact"])
+def test_every_builtin_theme_renders_both_formats(name):
+    theme = load_theme(name, _builtin())
+    resume = parse_resume(RESUME)
+
+    markdown = render(resume, theme, "markdown")
+    latex = render(resume, theme, "latex")
+
+    assert "Jane Doe" in markdown and "Cut deploy time 40% & halved" in markdown
+    assert latex.startswith("\\documentclass") and latex.rstrip().endswith("\\end{document}")
+    assert "40\\% \\& halved" in latex
+    assert "\\emph{Terraform}" in latex
+    assert "$" not in latex.replace("\\$", "")
+    assert "\n\n\n" not in markdown
+
+
+def test_latex_inline_escapes_text_and_keeps_links():
+    assert latex_inline("C# & 100% of_it") == "C\\# \\& 100\\% of\\_it"
+    assert latex_inline("[GitHub](https://github.com/jane_doe)") == (
+        "\\href{https://github.com/jane_doe}{GitHub}"
+    )
+    assert latex_inline("**Go** and `k8s_ops`") == "\\textbf{Go} and \\texttt{k8s\\_ops}"
+
+
+def test_user_theme_overrides_and_extends_a_builtin(tmp_path):
+    (tmp_path / "classic").mkdir()
+    (tmp_path / "classic" / "theme.yaml").write_text(
+        "description: My classic\nextends: classic\nmarkdown:\n  item: '* $text'\n"
+    )
+    dirs = [tmp_path, BUILTIN_THEMES_DIR]
+
+    theme = load_theme("classic", dirs)
+
+    assert theme.description == "My classic"
+    assert available_themes(dirs)["classic"] == tmp_path / "classic"
+    markdown = render(parse_resume(RESUME), theme, "markdown")
+    assert "* Led the *Terraform* migration" in markdown
+    assert "## Experience" in markdown  # everything else from the built-in
+
+
+def test_broken_themes_are_reported(tmp_path):
+    for name, text in {
+        "loop": "extends: loop\n",
+        "partial": "markdown: {item: '- $text'}\n",
+        "typo": "extends: classic\nmarkdown: {item: '- $txt'}\n",
+    }.items():
+        (tmp_path / name).mkdir()
+        (tmp_path / name / "theme.yaml").write_text(text)
+    dirs = [tmp_path]
+
+    with pytest.raises(ThemeError, match="Unknown theme 'nope'"):
+        load_theme("nope", dirs)
+    with pytest.raises(ThemeError, match="extends unknown theme 'loop'"):
+        load_theme("loop", dirs)
+    with pytest.raises(ThemeError, match="no markdown template for: document"):
+        load_theme("partial", dirs)
+    typo = load_theme("typo", [tmp_path, BUILTIN_THEMES_DIR])
+    with pytest.raises(ThemeError, match=r"markdown.item has unknown placeholder \$txt"):
+        render(parse_resume(RESUME), typo, "markdown")
+
+
+def test_pdf_is_skipped_without_a_latex_engine(tmp_path, monkeypatch):
+    monkeypatch.setattr("shutil.which", lambda name: None)
+
+    output = write_theme(tmp_path, RESUME, load_theme("modern", _builtin()))
+
+    assert output.files == [THEMED_MARKDOWN_FILE, LATEX_FILE]
+    assert "no LaTeX engine" in output.pdf_error
+    assert (tmp_path / LATEX_FILE).read_text().startswith("\\documentclass")
+
+
+def test_pdf_is_built_with_the_first_engine_found(tmp_path, monkeypatch):
+    monkeypatch.setattr("shutil.which", lambda name: name if name == "pdflatex" else None)
+    commands = []
+
+    def fake_run(command, cwd, **kwargs):
+        commands.append(command[0])
+        (Path(cwd) / PDF_FILE).write_bytes(b"%PDF-1.5")
+        return subprocess.CompletedProcess(command, 0, "", "")
+
+    monkeypatch.setattr(subprocess, "run", fake_run)
+
+    output = write_theme(tmp_path, RESUME, load_theme("classic", _builtin()))
+
+    assert commands == ["pdflatex"]
+    assert output.pdf_error is None
+    assert (tmp_path / PDF_FILE).read_bytes() == b"%PDF-1.5"
+
+
+def test_render_subcommand_rerenders_a_run(tmp_path, monkeypatch, capsys):
+    monkeypatch.setattr("shutil.which", lambda name: None)
+
+    class Result:
+        final_documents = {"resume": RESUME}
+
+    run_dir = write_run_artifacts(tmp_path, Result(), run_id="run-1")
+
+    assert main(["render", str(run_dir), "--theme", "compact"]) == 0
+    assert "🎨 compact theme" in capsys.readouterr().out
+    manifest = json.loads((run_dir / "run.json").read_text())
+    assert manifest["theme"] == {"name": "compact", "pdf": False}
+    assert {THEMED_MARKDOWN_FILE, LATEX_FILE} <= set(manifest["artifacts"])
+
+    with pytest.raises(SystemExit):
+        main(["render", str(run_dir), "--theme", "nope"])
+
+
+def test_themes_subcommand_lists_the_gallery(capsys):
+    assert main(["themes"]) == 0
+    out = capsys.readouterr().out
+    assert "classic" in out and "modern" in out and "compact" in out
@@ -0,0 +1,131 @@
+"""
+Unit tests for stage-output retention policies.
+"""
+
+import json
+from types import SimpleNamespace
+from unittest.mock import Mock, patch
+
+import pytest
+
+from runtime.crewai.artifacts import MANIFEST_FILE, write_run_artifacts
+from runtime.crewai.cli import build_parser, main
+from runtime.crewai.hydra_workflow import HydraWorkflow, RunStatus
+from runtime.crewai.retention import RetentionPolicy, policy_from_env
+
+RESEARCH = {
+    "company": "Acme",
+    "recent_news": [{"summary": "Series C", "date": "2026-09", "citations": [2]}],
+    "funding": [],
+    "tech_stack": [],
+    "sources": [
+        {"id": 1, "title": "Jobs", "url": "https://a/jobs", "snippet": "page dump " * 50},
+        {"id": 2, "title": "News", "url": "https://a/news", "snippet": "Acme raises"},
+    ],
+    "searches": [{"round": 1, "query": "acme news", "result_ids": [1, 2]}],
+    "uncited_dropped": 0,
+}
+
+
+def test_research_summary_keeps_cited_sources_without_page_text():
+    summary = RetentionPolicy.parse("research").retain("research", RESEARCH)
+
+    assert summary["recent_news"] == RESEARCH["recent_news"]
+    assert summary["sources"] == [{"id": 2, "title": "News", "url": "https://a/news"}]
+    assert summary["retained"]["discarded"] == {"sources": 1, "snippets": 2}
+    assert len(RESEARCH["sources"]) == 2  # the original is untouched
+
+
+def test_policies_parse_and_protect_what_the_audit_reads(monkeypatch):
+    assert RetentionPolicy.parse(None).summarize == frozenset()
+    assert RetentionPolicy.parse("verbatim").retain("research", RESEARCH) is RESEARCH
+    assert RetentionPolicy.parse("lean").summarize == {"research", "executive_synthesis"}
+
+    with pytest.raises(ValueError, match="tailoring"):
+        RetentionPolicy.parse("research,tailoring")
+    with pytest.raises(ValueError, match="No summary"):
+        RetentionPolicy.parse("translation")
+
+    monkeypatch.setenv("HYDRA_RETENTION", "research")
+    assert policy_from_env().summarize == {"research"}
+
+
+def test_workflow_stores_summaries_but_later_stages_get_the_full_output(tmp_path):
+    with (
+        patch("runtime.crewai.hydra_workflow.ResearchAgent"),
+        patch("runtime.crewai.hydra_workflow.GapAnalyzerAgent"),
+        patch("runtime.crewai.hydra_workflow.InterrogatorPrepperAgent"),
+        patch("runtime.crewai.hydra_workflow.DifferentiatorAgent"),
+        patch("runtime.crewai.hydra_workflow.TailoringAgent"),
+        patch("runtime.crewai.hydra_workflow.ATSOptimizerAgent"),
+        patch("runtime.crewai.hydra_workflow.AuditorSuiteAgent"),
+        patch("runtime.crewai.hydra_workflow.ExecutiveSynthesizerAgent"),
+    ):
+        workflow = HydraWorkflow(
+            Mock(),
+            use_per_agent_models=False,
+            auto_approve=True,
+            search_provider=Mock(),
+            retention=RetentionPolicy.parse("lean"),
+        )
+    workflow.research_agent.execute.return_value = dict(RESEARCH)
+    workflow.gap_analyzer.execute.return_value = {"gaps": []}
+    workflow.interrogator_prepper.execute.return_value = {"questions": []}
+    workflow.differentiator.execute.return_value = {"differentiators": ["AWS"]}
+    workflow.tailoring_agent.execute.return_value = {
+        "tailored_resume": "Tailored resume",
+        "tailored_cover_letter": "Tailored letter",
+    }
+    workflow.ats_optimizer.execute.return_value = {}
+    workflow.auditor_suite.execute.return_value = {"approved": True, "issues": []}
+    workflow.executive_synthesizer.execute.return_value = {
+        "decision": {"fit_score": 80},
+        "talking_points": ["AWS migration"],
+    }
+
+    result = workflow.execute(
+        {
+            "job_description": "Platform engineer, AWS",
+            "resume": "Jane Doe",
+            "source_documents": "Jane Doe",
+            "gap_analysis_approved": True,
+        }
+    )
+
+    assert result.status == RunStatus.COMPLETED
+    research_data = workflow.gap_analyzer.execute.call_args[0][0]["research_data"]
+    assert research_data["sources"][0]["snippet"].startswith("page dump")
+    stored = result.intermediate_results
+    assert [source["id"] for source in stored["research"]["sources"]] == [2]
+    assert "talking_points" not in stored["executive_synthesis"]
+    assert result.executive_brief["talking_points"] == ["AWS migration"]
+    assert stored["tailoring"]["tailored_resume"] == "Tailored resume"
+
+    run_dir = write_run_artifacts(tmp_path, result, run_id="run")
+    manifest = json.loads((run_dir / MANIFEST_FILE).read_text())
+    assert manifest["retention"] == {
+        "summarize": ["executive_synthesis", "research"],
+        "discarded": {
+            "research": {"sources": 1, "snippets": 2},
+            "executive_synthesis": {"fields": 1},
+        },
+    }
+
+
+def test_cli_rejects_a_policy_that_drops_audit_inputs(tmp_path, capsys):
+    assert build_parser().parse_args(["--jd", "jd", "--resume", "r"]).retention == "verbatim"
+    jd, resume = tmp_path / "jd.md", tmp_path / "resume.md"
+    jd.write_text("Platform engineer")
+    resume.write_text("Jane Doe")
+
+    with pytest.raises(SystemExit):
+        main(["--jd", str(jd), "--resume", str(resume), "--retention", "ats_optimization"])
+
+    assert "--retention: Always kept verbatim" in capsys.readouterr().err
+
+
+def test_no_retention_entry_in_the_manifest_by_default(tmp_path):
+    result = SimpleNamespace(success=True, final_documents={"resume": "R"}, retention=None)
+    run_dir = write_run_artifacts(tmp_path, result, run_id="run")
+
+    assert "retention" not in json.loads((run_dir / MANIFEST_FILE).read_text())
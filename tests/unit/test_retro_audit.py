@@ -0,0 +1,161 @@
+"""
+Unit tests for the retroactive audit over past runs (``audit-all``).
+"""
+
+import json
+from datetime import date
+from unittest.mock import MagicMock
+
+import pytest
+
+from runtime.crewai.retro_audit import (
+    CLEAN,
+    ERROR,
+    FLAGGED,
+    REPORT_FILE,
+    SKIPPED,
+    audit_all,
+    blocking_issues,
+    parse_since,
+    run_date,
+)
+
+APPROVED = {"audit_report": {"approval": {"approved": True, "reason": "ok"}}}
+FABRICATION = {
+    "audit_report": {
+        "truth_audit": {
+            "issues": [
+                {"id": "TRUTH-001", "claim": "Cut costs 40%", "severity": "blocking"},
+                {"id": "TRUTH-002", "claim": "Led a team", "severity": "warning"},
+            ]
+        },
+        "action_required": {"blocking": ["TRUTH-001: metric not in sources"]},
+        "approval": {"approved": False, "reason": "Unsupported metric"},
+    }
+}
+
+
+def _read(path):
+    return path.read_text()
+
+
+def _make_run(out_dir, run_id, sources_dir, status="APPROVED", resume="# Résumé"):
+    run_dir = out_dir / run_id
+    run_dir.mkdir(parents=True)
+    (run_dir / "resume.md").write_text(resume)
+    manifest = {
+        "run_id": run_id,
+        "audit": {"final_status": status},
+        "inputs": {"sources_path": str(sources_dir), "jd_path": None},
+    }
+    (run_dir / "run.json").write_text(json.dumps(manifest))
+    return run_dir
+
+
+@pytest.fixture
+def sources_dir(tmp_path):
+    directory = tmp_path / "sources"
+    directory.mkdir()
+    (directory / "notes.md").write_text("Led the platform team.")
+    return directory
+
+
+def test_parse_since_accepts_year_month_and_day():
+    assert parse_since("2024") == date(2024, 1, 1)
+    assert parse_since("2024-03") == date(2024, 3, 1)
+    assert parse_since("2024-03-15") == date(2024, 3, 15)
+    with pytest.raises(ValueError):
+        parse_since("March 2024")
+
+
+def test_run_date_reads_the_run_id_prefix():
+    assert run_date("20240115-093000-abcd1234") == date(2024, 1, 15)
+    assert run_date("acme-sre-20240115-093000-abcd1234") == date(2024, 1, 15)
+    assert run_date("latest") is None
+
+
+def test_blocking_issues_reads_action_required_and_severities():
+    issues = blocking_issues(FABRICATION)
+
+    assert issues == ["TRUTH-001: metric not in sources", "TRUTH-001: Cut costs 40%"]
+    assert blocking_issues(APPROVED) == []
+    assert blocking_issues("not a dict") == []
+
+
+def test_audit_all_flags_runs_today_s_rules_reject(tmp_path, sources_dir):
+    out_dir = tmp_path / "output"
+    _make_run(out_dir, "20231201-100000-00000000", sources_dir)  # before --since
+    _make_run(out_dir, "20240110-100000-11111111", sources_dir, resume="clean")
+    _make_run(out_dir, "20240220-100000-22222222", sources_dir, resume="fabricated")
+    auditor = MagicMock()
+    auditor.execute.side_effect = lambda ctx: (
+        FABRICATION if ctx["document"] == "fabricated" else APPROVED
+    )
+
+    report = audit_all(out_dir, "2024-01", auditor, _read, lambda p: "sources")
+
+    assert [e.run_id for e in report.entries] == [
+        "20240110-100000-11111111",
+        "20240220-100000-22222222",
+    ]
+    assert [e.outcome for e in report.entries] == [CLEAN, FLAGGED]
+    assert report.entries[1].newly_flagged
+    written = json.loads((out_dir / REPORT_FILE).read_text())
+    assert written["counts"][FLAGGED] == 1
+    assert written["newly_flagged"] == ["20240220-100000-22222222"]
+
+
+def test_runs_without_sources_are_skipped_unless_overridden(tmp_path):
+    out_dir = tmp_path / "output"
+    _make_run(out_dir, "20240301-100000-33333333", tmp_path / "moved")
+    auditor = MagicMock()
+    auditor.execute.return_value = APPROVED
+
+    def missing(path):
+        raise FileNotFoundError(path)
+
+    skipped = audit_all(out_dir, "2024", auditor, _read, missing)
+    assert skipped.entries[0].outcome == SKIPPED
+    auditor.execute.assert_not_called()
+
+    audited = audit_all(out_dir, "2024", auditor, _read, missing, sources_override="s")
+    assert audited.entries[0].outcome == CLEAN
+    assert auditor.execute.call_args[0][0]["source_documents"] == "s"
+
+
+def test_an_auditor_error_does_not_abort_the_sweep(tmp_path, sources_dir):
+    out_dir = tmp_path / "output"
+    _make_run(out_dir, "20240401-100000-44444444", sources_dir, resume="boom")
+    _make_run(out_dir, "20240402-100000-55555555", sources_dir)
+    auditor = MagicMock()
+
+    def execute(ctx):
+        if ctx["document"] == "boom":
+            raise RuntimeError("rate limited")
+        return APPROVED
+
+    auditor.execute.side_effect = execute
+
+    report = audit_all(out_dir, "2024-04", auditor, _read, lambda p: "sources")
+
+    assert [e.outcome for e in report.entries] == [ERROR, CLEAN]
+    assert report.entries[0].reason == "rate limited"
+
+
+def test_cli_dispatches_audit_all_subcommand(tmp_path, monkeypatch, capsys, sources_dir):
+    from runtime.crewai import cli
+
+    out_dir = tmp_path / "output"
+    _make_run(out_dir, "20240501-100000-66666666", sources_dir, resume="fabricated")
+    auditor = MagicMock()
+    auditor.execute.return_value = FABRICATION
+    monkeypatch.setattr(cli, "get_llm_for_agent", lambda agent_type: MagicMock())
+    monkeypatch.setattr(cli, "AuditorSuiteAgent", lambda llm: auditor)
+
+    code = cli.main(["audit-all", "--since", "2024-05", "--out", str(out_dir)])
+
+    assert code == 1
+    out = capsys.readouterr().out
+    assert "20240501-100000-66666666" in out
+    assert "flagged (passed originally)" in out
+    assert "TRUTH-001" in out
@@ -0,0 +1,158 @@
+"""Unit tests for the phone review pages (hydra serve) and the remote greenlight."""
+
+import json
+import os
+import threading
+import urllib.error
+import urllib.request
+from http.server import ThreadingHTTPServer
+
+import pytest
+
+from runtime.crewai.artifacts import MANIFEST_FILE, RESUME_FILE
+from runtime.crewai.cancellation import CancelToken
+from runtime.crewai.resume_diff import RESUME_DIFF_FILE, unified_diff
+from runtime.crewai.review_server import (
+    APPROVED,
+    GREENLIGHT_FILE,
+    PENDING,
+    RemoteGreenlight,
+    ReviewServer,
+    _handler,
+    answer_greenlight,
+    greenlight_state,
+)
+from runtime.crewai.run_control import LIVE_FILE
+
+TOKEN = "s3cret"
+
+
+def _finished_run(out, run_id="acme-sre-20260117-101500-1a2b3c4d"):
+    run_dir = out / run_id
+    (run_dir / "intermediate").mkdir(parents=True)
+    (run_dir / MANIFEST_FILE).write_text(json.dumps({"run_id": run_id, "status": "completed"}))
+    (run_dir / "intermediate" / "gap_analysis.yaml").write_text(
+        "fit_score: 70\ngaps: [Kubernetes]\nmatches: [Terraform]\n"
+    )
+    baseline, tailored = "Jane\nOld bullet\nEnd", "Jane\nNew <b>bullet</b>\nEnd"
+    (run_dir / RESUME_FILE).write_text(tailored)
+    (run_dir / RESUME_DIFF_FILE).write_text(unified_diff(baseline, tailored))
+    (run_dir / "resume.pdf").write_bytes(b"%PDF-1.4")
+    return run_dir
+
+
+def _waiting_run(out, run_id="live-run"):
+    run_dir = out / run_id
+    run_dir.mkdir(parents=True)
+    (run_dir / LIVE_FILE).write_text(json.dumps({"pid": os.getpid()}))
+    greenlight = {"status": PENDING, "gap_review": {"fit_score": 55, "gaps": ["Go"]}}
+    (run_dir / GREENLIGHT_FILE).write_text(json.dumps(greenlight))
+    return run_dir
+
+
+def test_every_request_needs_the_token_which_a_cookie_then_carries(tmp_path):
+    app = ReviewServer(tmp_path, TOKEN)
+
+    assert app.handle("GET", "/")[0] == 401
+    assert app.handle("GET", "/?token=wrong")[0] == 401
+    status, headers, _ = app.handle("GET", f"/?token={TOKEN}")
+    assert status == 200 and f"hydra_token={TOKEN}" in headers["Set-Cookie"]
+    assert app.handle("GET", "/", cookie_token=TOKEN)[0] == 200
+
+
+def test_a_finished_run_shows_its_gaps_highlighted_resume_and_downloads(tmp_path):
+    run_dir = _finished_run(tmp_path)
+    app = ReviewServer(tmp_path, TOKEN)
+
+    index = app.handle("GET", "/", cookie_token=TOKEN)[2].decode()
+    assert run_dir.name in index and "completed" in index
+
+    page = app.handle("GET", f"/runs/{run_dir.name}", cookie_token=TOKEN)[2].decode()
+    assert "Gap analysis · fit score 70" in page and "Kubernetes" in page
+    assert "<del>Old bullet</del>" in page and "<ins>New &lt;b&gt;bullet&lt;/b&gt;</ins>" in page
+    assert "Approve" not in page and 'http-equiv="refresh"' not in page
+    assert page.index("resume.pdf") < page.index(f"files/{RESUME_FILE}")
+
+    status, headers, body = app.handle(
+        "GET", f"/runs/{run_dir.name}/files/resume.pdf", cookie_token=TOKEN
+    )
+    assert status == 200 and body == b"%PDF-1.4"
+    assert headers["Content-Disposition"] == 'attachment; filename="resume.pdf"'
+    markdown = app.handle("GET", f"/runs/{run_dir.name}/files/{RESUME_FILE}", cookie_token=TOKEN)
+    assert markdown[1]["Content-Type"] == "text/plain; charset=utf-8"
+
+    for target in (
+        f"/runs/{run_dir.name}/files/..%2F..%2Fsecret",
+        "/runs/..%2F/files/x",
+        "/runs/nope",
+    ):
+        assert app.handle("GET", target, cookie_token=TOKEN)[0] == 404
+
+
+def test_a_waiting_run_is_greenlit_once_from_the_page(tmp_path):
+    run_dir = _waiting_run(tmp_path)
+    app = ReviewServer(tmp_path, TOKEN)
+
+    page = app.handle("GET", f"/runs/{run_dir.name}", cookie_token=TOKEN)[2].decode()
+    assert "waiting for greenlight" in page and "fit score 55" in page
+    assert "value='approve'" in page
+
+    status, headers, _ = app.handle(
+        "POST", f"/runs/{run_dir.name}/greenlight", {"decision": "approve"}, TOKEN
+    )
+    assert status == 303 and headers["Location"] == f"/runs/{run_dir.name}"
+    assert greenlight_state(run_dir)["status"] == APPROVED
+    again = app.handle("POST", f"/runs/{run_dir.name}/greenlight", {"decision": "decline"}, TOKEN)
+    assert again[0] == 409
+    bad = app.handle("POST", f"/runs/{run_dir.name}/greenlight", {"decision": "maybe"}, TOKEN)
+    assert bad[0] == 400
+
+
+def test_remote_greenlight_waits_for_the_answer(tmp_path):
+    workflow = type("W", (), {"cancel_token": CancelToken()})()
+    interaction = RemoteGreenlight(tmp_path / "run", workflow, poll_seconds=0.01)
+    answers = []
+    waiter = threading.Thread(
+        target=lambda: answers.append(interaction.greenlight_gap_analysis({"gaps": ["Go"]}))
+    )
+    waiter.start()
+    while greenlight_state(tmp_path / "run") is None:
+        waiter.join(0.01)
+    assert greenlight_state(tmp_path / "run")["gap_review"]["gaps"] == ["Go"]
+
+    answer_greenlight(tmp_path / "run", approve=False)
+    waiter.join(5)
+    assert answers == [False]
+    with pytest.raises(ValueError, match="not waiting"):
+        answer_greenlight(tmp_path / "run", approve=True)
+
+    # A pause or cancel while waiting lets the run reach its stage boundary.
+    workflow.cancel_token.stop_at_boundary("paused")
+    assert interaction.greenlight_gap_analysis({"gaps": []}) is True
+    assert interaction.conduct_interview([{"question": "?"}]) == []
+    assert interaction.ask_yes_no("Keep these unverified claims anyway?") is False
+
+
+def test_the_pages_are_served_over_http(tmp_path):
+    run_dir = _waiting_run(tmp_path)
+    server = ThreadingHTTPServer(("127.0.0.1", 0), _handler(ReviewServer(tmp_path, TOKEN)))
+    threading.Thread(target=server.serve_forever, daemon=True).start()
+    base = f"http://127.0.0.1:{server.server_address[1]}"
+    try:
+        with urllib.request.urlopen(f"{base}/?token={TOKEN}") as response:
+            cookie = response.headers["Set-Cookie"].split(";")[0]
+            assert run_dir.name in response.read().decode()
+        request = urllib.request.Request(
+            f"{base}/runs/{run_dir.name}/greenlight",
+            data=b"decision=approve",
+            headers={"Cookie": cookie},
+            method="POST",
+        )
+        with urllib.request.urlopen(request) as response:  # follows the 303
+            assert response.status == 200
+        assert greenlight_state(run_dir)["status"] == APPROVED
+        with pytest.raises(urllib.error.HTTPError):
+            urllib.request.urlopen(f"{base}/")  # no token
+    finally:
+        server.shutdown()
+        server.server_close()
@@ -0,0 +1,181 @@
+"""
+Unit tests for pausing, resuming and cancelling runs (hydra pause/resume/cancel).
+"""
+
+import json
+import threading
+import time
+from functools import partial
+from types import SimpleNamespace
+from unittest.mock import Mock, patch
+
+import pytest
+
+from runtime.crewai import cli
+from runtime.crewai.artifacts import MANIFEST_FILE
+from runtime.crewai.hydra_workflow import RunStatus
+from runtime.crewai.run_control import (
+    CONTROL_FILE,
+    LIVE_FILE,
+    RunControl,
+    RunControlError,
+    control_run,
+    control_server,
+    is_live,
+    resume_arguments,
+    run_status,
+)
+
+
+def _saved_run(out, run_id="run-1", status="interrupted"):
+    run_dir = out / run_id
+    run_dir.mkdir(parents=True)
+    inputs = {"jd_path": "jd.md", "resume_path": "r.md", "sources_path": "src", "company": "Acme"}
+    manifest = {"run_id": run_id, "status": status, "inputs": inputs}
+    (run_dir / MANIFEST_FILE).write_text(json.dumps(manifest))
+    return run_dir
+
+
+def _wait_for(condition, seconds=5.0):
+    deadline = time.monotonic() + seconds
+    while not condition():
+        assert time.monotonic() < deadline, "timed out waiting"
+        time.sleep(0.01)
+
+
+def test_a_live_run_is_paused_then_cancelled_through_its_control_file(tmp_path):
+    run_dir = tmp_path / "run-1"
+    workflow = Mock()
+
+    with RunControl(run_dir, workflow, poll_seconds=0.01) as control:
+        assert is_live(run_dir)
+        assert control_run(run_dir, "pause").status == "pausing"
+        _wait_for(lambda: workflow.stop_after_stage.called)
+        assert control_run(run_dir, "cancel").status == "cancelling"
+        _wait_for(lambda: workflow.cancel.called)
+
+    assert control.status == "cancelled"
+    workflow.stop_after_stage.assert_called_once_with("paused by hydra pause")
+    assert not (run_dir / LIVE_FILE).exists() and not (run_dir / CONTROL_FILE).exists()
+    assert not is_live(run_dir)
+
+
+def test_a_run_whose_process_is_gone_is_not_live(tmp_path):
+    run_dir = _saved_run(tmp_path)
+    (run_dir / LIVE_FILE).write_text(json.dumps({"pid": 2**22 + 12345}))
+
+    assert not is_live(run_dir)
+    assert control_run(run_dir, "pause").status == "paused"
+
+
+def test_saved_runs_change_status_in_place(tmp_path):
+    run_dir = _saved_run(tmp_path)
+
+    assert control_run(run_dir, "pause").status == "paused"
+    assert control_run(run_dir, "pause").message == "is already paused"
+    assert resume_arguments(run_dir) == [
+        "--jd", "jd.md", "--resume", "r.md", "--sources", "src", "--company", "Acme",
+        "--out", str(tmp_path), "--resume-run", "run-1",
+    ]  # fmt: skip
+
+    assert control_run(run_dir, "cancel").status == "cancelled"
+    manifest = json.loads((run_dir / MANIFEST_FILE).read_text())
+    assert [(c["from"], c["to"]) for c in manifest["status_history"]] == [
+        ("interrupted", "paused"),
+        ("paused", "cancelled"),
+    ]
+    with pytest.raises(RunControlError, match="only a paused or interrupted run can resume"):
+        resume_arguments(run_dir)
+
+    finished = _saved_run(tmp_path, "run-2", status="completed")
+    with pytest.raises(RunControlError, match="run-2 is completed"):
+        control_run(finished, "pause")
+    with pytest.raises(RunControlError, match="No run"):
+        control_run(tmp_path / "nope", "cancel")
+
+
+def test_server_mode_posts_to_the_job_api(monkeypatch):
+    monkeypatch.setenv("HYDRA_API_TOKEN", "t0ken")
+    response = Mock()
+    response.read.return_value = b'{"job_id": "j1", "status": "pausing", "message": "ok"}'
+    response.__enter__ = Mock(return_value=response)
+    response.__exit__ = Mock(return_value=False)
+
+    with patch("urllib.request.urlopen", return_value=response) as urlopen:
+        reply = control_server("http://hydra:8000/", "j1", "pause")
+
+    request = urlopen.call_args.args[0]
+    assert request.full_url == "http://hydra:8000/api/v1/jobs/j1/pause"
+    assert request.get_method() == "POST"
+    assert request.get_header("Authorization") == "Bearer t0ken"
+    assert reply["status"] == "pausing"
+
+
+def test_hydra_pause_then_resume_end_to_end(tmp_path, monkeypatch, capsys):
+    jd, resume, sources, out = (tmp_path / name for name in ("jd.md", "r.md", "src", "out"))
+    jd.write_text("JD")
+    resume.write_text("Jane Doe")
+    sources.mkdir()
+    (sources / "notes.md").write_text("Jane Doe")
+    contexts = []
+
+    class StubWorkflow:
+        def __init__(self, *args, **kwargs):
+            self.stopped = threading.Event()
+
+        def stop_after_stage(self, reason):
+            self.stopped.set()
+
+        def execute(self, context):
+            contexts.append(context)
+            first = "previous_results" not in context
+            if first:
+                [run_dir] = [d for d in out.iterdir() if (d / LIVE_FILE).exists()]
+                assert cli.main(["pause", run_dir.name, "--out", str(out)]) == 0
+                assert self.stopped.wait(5)
+            return SimpleNamespace(
+                success=not first,
+                status=RunStatus.INTERRUPTED if first else RunStatus.COMPLETED,
+                final_documents=None if first else {"resume": "R"},
+                audit_report=None if first else {"final_status": "APPROVED"},
+                intermediate_results={"gap_analysis": {"gaps": []}},
+                error_message="Interrupted: paused by hydra pause during tailoring",
+                execution_log=[],
+                agent_models={},
+            )
+
+    monkeypatch.setattr(cli, "get_llm_client", lambda *args, **kwargs: "stub-llm")
+    monkeypatch.setattr(cli, "HydraWorkflow", StubWorkflow)
+    monkeypatch.setattr(cli, "RunControl", partial(RunControl, poll_seconds=0.01))
+    monkeypatch.setenv("HYDRA_HOME", str(tmp_path / "home"))
+    argv = ["--jd", str(jd), "--resume", str(resume), "--sources", str(sources)]
+
+    assert cli.main([*argv, "--out", str(out), "--no-cache"]) == 1
+    [run_id] = [d.name for d in out.iterdir()]
+    printed = capsys.readouterr().out
+    assert f"Run id: {run_id}" in printed and f"hydra resume {run_id}" in printed
+    assert run_status(out / run_id) == "paused"
+
+    assert cli.main(["resume", run_id, "--out", str(out), "--no-cache"]) == 0
+    assert contexts[-1]["previous_results"] == {"gap_analysis": {"gaps": []}}
+    manifest = json.loads((out / run_id / MANIFEST_FILE).read_text())
+    assert manifest["status"] == "resumed"
+    assert manifest["status_history"][-1]["resumed_as"] != run_id
+
+    with pytest.raises(SystemExit):
+        cli.main(["resume", run_id, "--out", str(out)])  # already resumed
+    assert "is resumed" in capsys.readouterr().err
+
+
+def test_a_cancelled_run_cannot_be_resumed_with_resume_run(tmp_path, capsys):
+    jd, resume, sources = tmp_path / "jd.md", tmp_path / "r.md", tmp_path / "src"
+    jd.write_text("JD")
+    resume.write_text("Jane Doe")
+    sources.mkdir()
+    (sources / "notes.md").write_text("Jane Doe")
+    _saved_run(tmp_path / "out", status="cancelled")
+    argv = ["--jd", str(jd), "--resume", str(resume), "--sources", str(sources)]
+
+    with pytest.raises(SystemExit):
+        cli.main([*argv, "--out", str(tmp_path / "out"), "--resume-run", "run-1"])
+    assert "was cancelled" in capsys.readouterr().err
@@ -0,0 +1,198 @@
+"""
+Unit tests for the run report: one readable summary of a run's stage outputs.
+"""
+
+import json
+import sys
+from types import SimpleNamespace
+
+import pytest
+
+from runtime.crewai import cli, resume_themes
+from runtime.crewai.artifacts import MANIFEST_FILE, RunInputs, write_run_artifacts
+from runtime.crewai.ats_parse_check import parse_resume
+from runtime.crewai.resume_themes import ThemeError, compile_pdf
+from runtime.crewai.run_report import (
+    RUN_REPORT_MARKDOWN_FILE,
+    RUN_REPORT_PDF_FILE,
+    build_run_report,
+    render_html,
+    render_latex,
+    render_markdown,
+    write_run_report,
+)
+
+pytestmark = pytest.mark.usefixtures("hydra_home")
+
+BASELINE = "Jane Doe\n\nWhere I've Made an Impact\n- Ran Kubernetes\n"
+FINAL = """Jane Doe
+jane@example.com | +1 555 0100 | linkedin.com/in/jane
+
+## Summary
+Platform engineer.
+
+## Experience
+### Platform Engineer, Acme (2019 - 2024)
+- Ran Kubernetes and Terraform for 40 services
+
+## Education
+BSc Computer Science
+
+## Skills
+Kubernetes, Terraform, Python
+"""
+JD = "Platform Engineer at Acme. Must have Kubernetes, Terraform and Python."
+
+RESULTS = {
+    "research": {
+        "company": "Acme",
+        "recent_news": [{"summary": "Opened a Berlin office", "citations": [1]}],
+        "funding": [{"summary": "Series C, $80M", "citations": [2]}],
+        "tech_stack": [],
+    },
+    "gap_analysis": {"matches": ["Kubernetes"], "adjacent_skills": ["Nomad"], "gaps": ["Kafka"]},
+    "differentiation": {
+        "differentiators": [
+            {"title": "Migrated 40 services", "evidence": "led the move off VMs"},
+            "On-call lead",
+        ]
+    },
+    "ats_optimization": {"ats_report": {"ats_score": 82}},
+    "executive_synthesis": {
+        "decision": {"fit_score": 78, "rationale": "Strong platform match & no Kafka."},
+        "action_items": {"immediate": ["Send by Friday"]},
+    },
+}
+AUDIT = {
+    "resume_audit": {
+        "approved": False,
+        "reason": "Unsupported metric",
+        "action_required": {"blocking": ["Remove the 99.99% uptime claim"]},
+    },
+    "final_status": "REJECTED",
+    "claim_verification": {
+        "unverified_claims": [
+            {"kind": "metric", "text": "99.99%", "location": "resume, line 9"}
+        ]
+    },
+}
+
+
+def _no_latex(monkeypatch):
+    monkeypatch.setattr(resume_themes.shutil, "which", lambda engine: None)
+
+
+def test_the_report_covers_every_section():
+    before = {"matched": ["Kubernetes"], "synonyms": [], "missing": ["Terraform", "Python"]}
+    after = {"matched": ["Kubernetes", "Terraform", "Python"], "synonyms": [], "missing": []}
+    report = build_run_report(
+        RESULTS,
+        AUDIT,
+        ats_before={"score": 40, "jd_skills": before},
+        ats_after={"score": 95, "missing_fields": [], "jd_skills": after},
+        role="Platform Engineer",
+    )
+
+    markdown = render_markdown(report)
+
+    assert markdown.startswith("# Run report: Platform Engineer at Acme")
+    assert [heading for heading, _, _ in report.sections()] == [
+        "Decision",
+        "Company snapshot",
+        "Gap summary",
+        "Differentiators",
+        "ATS score",
+        "Audit findings",
+        "Next steps",
+    ]
+    assert "PROCEED (fit 78/100)" in markdown
+    assert "- Funding: Series C, $80M" in markdown
+    assert "- Missing (1): Kafka" in markdown
+    assert "- Migrated 40 services — led the move off VMs" in markdown
+    assert "- Simulated ATS parse: 40 → 95/100" in markdown
+    assert "- JD skills found: 1/3 → 3/3" in markdown
+    assert "- ATS optimizer's own score: 82/100" in markdown
+    assert "- Resume, blocking — Remove the 99.99% uptime claim" in markdown
+    assert "- Unverified metric '99.99%' (resume, line 9)" in markdown
+    assert report.next_steps == [
+        "Send by Friday",
+        "Fix the audit findings above, or re-run, before sending anything.",
+        "Prepare an honest answer for the missing requirement: Kafka",
+    ]
+
+
+def test_a_run_without_optional_stages_leaves_their_sections_out():
+    report = build_run_report({"gap_analysis": {"gaps": []}}, {"final_status": "APPROVED"})
+
+    assert [heading for heading, _, _ in report.sections()] == ["Audit findings"]
+    assert report.title == "Run report"
+
+
+def test_html_and_latex_escape_the_content():
+    report = build_run_report(RESULTS, AUDIT)
+
+    assert "Strong platform match &amp; no Kafka." in render_html(report)
+    latex = render_latex(report)
+    assert r"Strong platform match \& no Kafka." in latex
+    assert r"Series C, \$80M" in latex
+
+
+def test_without_a_latex_engine_the_tex_is_still_written(tmp_path, monkeypatch):
+    _no_latex(monkeypatch)
+
+    output = write_run_report(tmp_path, build_run_report(RESULTS, AUDIT))
+
+    assert output.files == ["run_report.md", "run_report.html", "run_report.tex"]
+    assert "run_report.tex was written" in output.pdf_error
+    assert write_run_report(tmp_path, build_run_report(RESULTS), pdf=False).pdf_error is None
+
+
+def test_compile_pdf_builds_any_tex_file(tmp_path, monkeypatch):
+    fake_engine = (
+        "import pathlib, sys; "
+        "pathlib.Path(sys.argv[1]).with_suffix('.pdf').write_text('%PDF-1.4')"
+    )
+    engines = ((sys.executable, [sys.executable, "-c", fake_engine, resume_themes.LATEX_FILE]),)
+    monkeypatch.setattr(resume_themes, "PDF_ENGINES", engines)
+    (tmp_path / "run_report.tex").write_text("\\documentclass{article}")
+
+    assert compile_pdf(tmp_path / "run_report.tex") == tmp_path / RUN_REPORT_PDF_FILE
+    assert (tmp_path / RUN_REPORT_PDF_FILE).read_text() == "%PDF-1.4"
+
+    (tmp_path / "resume.tex").write_text("\\documentclass{article}")
+    assert compile_pdf(tmp_path / "resume.tex").name == "resume.pdf"
+
+    monkeypatch.setattr(resume_themes, "PDF_ENGINES", ())
+    with pytest.raises(ThemeError, match="no LaTeX engine"):
+        compile_pdf(tmp_path / "resume.tex")
+
+
+def test_hydra_report_writes_the_report_of_a_finished_run(tmp_path, monkeypatch, capsys):
+    _no_latex(monkeypatch)
+    (tmp_path / "resume.md").write_text(BASELINE)
+    (tmp_path / "jd.md").write_text(JD)
+    result = SimpleNamespace(
+        final_documents={"resume": FINAL},
+        intermediate_results=RESULTS,
+        audit_report={"final_status": "APPROVED"},
+        ats_parse=parse_resume(FINAL, JD).to_dict(),
+    )
+    inputs = RunInputs(resume_path=str(tmp_path / "resume.md"), jd_path=str(tmp_path / "jd.md"))
+    run_dir = write_run_artifacts(
+        tmp_path / "out", result, run_id="run-1", inputs=inputs, include_intermediate=True
+    )
+
+    assert cli.main(["report", str(run_dir), "--no-pdf"]) == 0
+
+    markdown = (run_dir / RUN_REPORT_MARKDOWN_FILE).read_text()
+    assert "# Run report: Acme" in markdown
+    assert "Simulated ATS parse: " in markdown and " → " in markdown
+    manifest = json.loads((run_dir / MANIFEST_FILE).read_text())
+    assert {"run_report.md", "run_report.html"} <= set(manifest["artifacts"])
+    assert manifest["run_report"] == {"pdf": False}
+    assert "📝 Run report →" in capsys.readouterr().out
+
+
+def test_hydra_report_needs_a_run_directory(tmp_path):
+    with pytest.raises(SystemExit):
+        cli.main(["report", str(tmp_path)])
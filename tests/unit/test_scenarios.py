@@ -0,0 +1,73 @@
+"""
+End-to-end scenarios for the shipped presets (tests/scenarios/*.yaml), and the runner.
+"""
+
+from pathlib import Path
+
+import pytest
+
+from runtime.crewai.cli import main
+from runtime.crewai.scenarios import ScenarioError, discover, load_scenario, run_scenario
+
+SCENARIOS_DIR = Path(__file__).resolve().parents[1] / "scenarios"
+SCENARIOS = discover([SCENARIOS_DIR])
+
+
+def _write(tmp_path, text):
+    path = tmp_path / "scenario.yaml"
+    path.write_text(text)
+    return path
+
+
+@pytest.mark.parametrize("path", SCENARIOS, ids=[p.stem for p in SCENARIOS])
+def test_scenario(path, tmp_path):
+    result = run_scenario(load_scenario(path), tmp_path)
+    assert result.passed, "\n".join(result.failures)
+
+
+def test_every_preset_has_a_scenario():
+    presets = {load_scenario(path).preset for path in SCENARIOS}
+    assert presets == {"default", "quick-apply"}
+
+
+def test_mismatches_are_all_reported(tmp_path):
+    scenario = load_scenario(SCENARIOS_DIR / "quick_apply.yaml")
+    scenario.expect = {
+        "status": "failed",
+        "stages": ["gap_analysis", "tailoring"],
+        "artifacts": {"resume.md": ["Kubernetes", "COBOL"], "brief.md": []},
+    }
+
+    failures = run_scenario(scenario, tmp_path).failures
+
+    assert failures[0] == "status: expected failed, got completed"
+    assert failures[1].startswith("stages: expected ['gap_analysis', 'tailoring'], got [")
+    assert failures[2:] == [
+        "artifacts: resume.md does not contain 'COBOL'",
+        "artifacts: brief.md was not written",
+    ]
+
+
+def test_a_stage_without_a_response_fails_the_run(tmp_path):
+    scenario = load_scenario(SCENARIOS_DIR / "default.yaml")
+    del scenario.responses["tailoring"]
+
+    result = run_scenario(scenario, tmp_path)
+
+    assert any("no response for stage 'tailoring'" in f for f in result.failures)
+
+
+def test_malformed_scenarios_are_rejected(tmp_path):
+    with pytest.raises(ScenarioError, match="'responses' must be a mapping"):
+        load_scenario(_write(tmp_path, "inputs: {}\nexpect: {}\n"))
+    with pytest.raises(ScenarioError, match="unknown preset 'federal'"):
+        load_scenario(_write(tmp_path, "preset: federal\ninputs: {}\nresponses: {}\nexpect: {}"))
+
+
+def test_scenario_subcommand_reports_each_file(tmp_path, capsys):
+    assert main(["scenario", str(SCENARIOS_DIR / "default.yaml"), "--out", str(tmp_path)]) == 0
+    assert "✅ default preset runs every stage" in capsys.readouterr().out
+
+    broken = _write(tmp_path, "inputs: {}\nresponses: {}\nexpect: {status: completed}\n")
+    assert main(["scenario", str(broken), "--out", str(tmp_path)]) == 1
+    assert "❌ scenario" in capsys.readouterr().out
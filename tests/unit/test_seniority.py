@@ -0,0 +1,224 @@
+"""
+Unit tests for seniority calibration: the levels, the framing, the agent and the stage.
+"""
+
+import json
+from unittest.mock import Mock, patch
+
+import pytest
+from crewai import LLM
+
+from runtime.crewai.agents.seniority_calibrator import SeniorityCalibratorAgent
+from runtime.crewai.artifacts import MANIFEST_FILE, write_run_artifacts
+from runtime.crewai.base_agent import ValidationError
+from runtime.crewai.cli import _report_seniority
+from runtime.crewai.hydra_workflow import RunStatus
+from runtime.crewai.pipeline_config import PipelineConfig
+from runtime.crewai.run_control import resume_arguments
+from runtime.crewai.seniority import MATCHED, OVER, UNDER, calibrate, role_level, team_sizes
+from runtime.crewai.workflow_templates import BUILTIN_TEMPLATES
+from tests.conftest import patched_workflow
+
+STAFF_JD = "Staff Platform Engineer\n\nOwn our platform across product teams."
+RESUME = """# Jane Doe
+
+## Experience
+
+### Platform Engineer | Acme
+2019 - present
+- Helped migrate 40 services to Kubernetes
+- Assisted with the on-call rotation
+- Cut cloud spend 30% by rightsizing instances
+
+### Junior Developer | Beta
+2016 - 2019
+- Worked on the billing API
+
+## Skills
+- Led, Owned, Kubernetes
+"""
+DIRECTOR_RESUME = """# Sam Roe
+
+## Experience
+
+### Director of Engineering | Acme
+2012 - present
+- Managed 45 engineers across 6 teams
+- Led the company-wide move to Kubernetes
+"""
+
+
+def test_a_staff_role_meets_a_resume_framed_as_help():
+    calibration = calibrate(STAFF_JD, RESUME)
+
+    assert calibration["role_level"] == "staff/lead"
+    assert calibration["resume_level"] == "mid-level"
+    assert calibration["verdict"] == UNDER
+    assert calibration["signals"] == {
+        "leading": 0,
+        "supporting": 3,
+        "team_sizes": [],
+        "scope": [],
+    }
+    assert calibration["issues"] == [
+        "the latest title reads mid-level; the role is staff/lead",
+        "3 of 4 bullets open with a supporting verb (helped, assisted) and fewer with a "
+        "leading one",
+        "no scope beyond the candidate's own team",
+    ]
+    assert len(calibration["instructions"]) == 3
+    # Skills are not experience: "Led" there is not a bullet about leading.
+    assert [b["id"] for b in calibration["bullets"]] == [
+        "experience-1-1",
+        "experience-1-2",
+        "experience-1-3",
+        "experience-2-1",
+    ]
+
+
+@pytest.mark.parametrize(
+    "jd, resume, verdict",
+    [
+        ("Software Engineer\n\n3+ years of Python.", DIRECTOR_RESUME, OVER),
+        ("Director of Engineering\n\nGrow the team.", DIRECTOR_RESUME, MATCHED),
+        ("Engineering Manager\n\nRun a platform team.", RESUME, UNDER),
+        ("Platform Engineer\n\nKubernetes.", RESUME, MATCHED),
+    ],
+)
+def test_the_verdict(jd, resume, verdict):
+    assert calibrate(jd, resume)["verdict"] == verdict
+
+
+def test_levels_and_team_sizes_are_read_from_the_text():
+    assert role_level("Backend Engineer\n\nYou have 8+ years of experience.") == (4, False)
+    assert role_level("Head of Data\n\nBuild the team.") == (6, True)
+    assert role_level("Platform Engineer") == (2, False)
+    assert team_sizes("Led a team of 6 and later 12 direct reports; 3 services") == [6, 12]
+
+    manager = calibrate("Engineering Manager\n\nRun a platform team.", RESUME)
+    assert "no team size stated, and the role manages people" in manager["issues"]
+
+
+def test_agent_keeps_notes_on_the_bullets_it_was_given():
+    with patch("runtime.crewai.base_agent.Path.read_text", return_value="Seniority prompt"):
+        agent = SeniorityCalibratorAgent(LLM(model="gpt-4", api_key="test-key"))
+    agent.execute_with_retry = Mock(
+        return_value={
+            "findings": [
+                {
+                    "bullet": "[experience-1-1]",
+                    "issue": "Opens with 'Helped' for a migration the sources say she led",
+                    "suggestion": "Lead with the migration she owned",
+                },
+                {"bullet": "experience-9-9", "suggestion": "Invented bullet"},
+                {"bullet": "experience-1-2", "issue": "No suggestion"},
+            ],
+            "instructions": "Open the summary with the platform she runs",
+        }
+    )
+    calibration = calibrate(STAFF_JD, RESUME)
+
+    result = agent.execute(
+        {"job_description": STAFF_JD, "resume": RESUME, "calibration": calibration}
+    )
+
+    assert [item["bullet"] for item in result["findings"]] == ["experience-1-1"]
+    assert result["instructions"] == ["Open the summary with the platform she runs"]
+    prompt = agent.execute_with_retry.call_args[0][0].description
+    assert "The role is staff/lead; the resume reads" in prompt
+    assert "[experience-1-2] Assisted with the on-call rotation" in prompt
+
+    agent.execute_with_retry = Mock(return_value={"findings": [], "instructions": []})
+    with pytest.raises(ValidationError, match="no findings or instructions"):
+        agent.execute({"job_description": STAFF_JD, "resume": RESUME, "calibration": calibration})
+
+
+def _workflow(template):
+    workflow = patched_workflow(
+        auto_approve=True, pipeline_config=PipelineConfig(), template=template
+    )
+    workflow.gap_analyzer.execute.return_value = {"gaps": [], "confidence": 0.9}
+    workflow.seniority_calibrator.execute.return_value = {
+        "findings": [
+            {"bullet": "experience-1-1", "issue": "Helped", "suggestion": "Say she led it"}
+        ],
+        "instructions": ["Open the summary with the platform she runs"],
+        "confidence": 0.9,
+    }
+    workflow.interrogator_prepper.execute.return_value = {"questions": [], "confidence": 0.9}
+    workflow.differentiator.execute.return_value = {"differentiators": ["Go"], "confidence": 0.9}
+    workflow.tailoring_agent.execute.return_value = {
+        "tailored_resume": "Resume",
+        "tailored_cover_letter": "Letter",
+        "confidence": 0.9,
+    }
+    workflow.ats_optimizer.execute.return_value = {"optimized_resume": "R", "confidence": 0.9}
+    workflow.auditor_suite.execute.return_value = {"approval": {"approved": True}}
+    workflow.executive_synthesizer.execute.return_value = {"confidence": 0.9}
+    return workflow
+
+
+def test_the_stage_adjusts_the_tailoring_instructions():
+    template = BUILTIN_TEMPLATES["thorough"].including("seniority")
+    context = {"job_description": STAFF_JD, "resume": RESUME, "source_documents": "Sources"}
+    workflow = _workflow(template)
+
+    result = workflow.execute(context)
+
+    assert result.status is RunStatus.COMPLETED
+    brief = workflow.tailoring_agent.execute.call_args.args[0]["seniority_calibration"]
+    assert brief["verdict"] == UNDER and brief["role_level"] == "staff/lead"
+    assert brief["instructions"][-1] == "Open the summary with the platform she runs"
+    assert len(brief["instructions"]) == 4
+    assert brief["findings"][0]["bullet"] == "experience-1-1"
+    assert result.seniority["verdict"] == UNDER
+
+    # The agent fails: software's instructions still reach tailoring.
+    workflow = _workflow(template)
+    workflow.seniority_calibrator.execute.side_effect = ValidationError("no findings")
+    workflow.execute(context)
+    brief = workflow.tailoring_agent.execute.call_args.args[0]["seniority_calibration"]
+    assert len(brief["instructions"]) == 3 and brief["findings"] == []
+
+    # A résumé at the role's level calls no model and changes nothing.
+    workflow = _workflow(template)
+    result = workflow.execute({**context, "job_description": "Platform Engineer\n\nGo."})
+    workflow.seniority_calibrator.execute.assert_not_called()
+    assert result.seniority["verdict"] == MATCHED
+    assert "seniority_calibration" not in workflow.tailoring_agent.execute.call_args.args[0]
+
+    # Not in the template: no stage.
+    workflow = _workflow(BUILTIN_TEMPLATES["thorough"])
+    result = workflow.execute(context)
+    assert result.seniority is None
+    assert "seniority_calibration" not in workflow.tailoring_agent.execute.call_args.args[0]
+
+
+def test_run_json_keeps_the_levels_and_the_cli_reports(tmp_path, capsys):
+    class Result:
+        final_documents = {"resume": "# Jane"}
+        intermediate_results = {}
+        seniority = {**calibrate(STAFF_JD, RESUME), "findings": [{"bullet": "experience-1-1"}]}
+
+    run_dir = write_run_artifacts(tmp_path, Result(), run_id="run-1")
+
+    manifest = json.loads((run_dir / MANIFEST_FILE).read_text())
+    assert manifest["seniority"] == {
+        "role_level": "staff/lead",
+        "resume_level": "mid-level",
+        "verdict": UNDER,
+        "issues": 3,
+        "findings": 1,
+    }
+    _report_seniority(Result(), True)
+    out = capsys.readouterr().out
+    assert "Seniority: under-leveled (role staff/lead, résumé mid-level)" in out
+    assert "- no scope beyond the candidate's own team" in out
+
+    manifest.update(
+        status="paused",
+        inputs={"jd_path": "jd.md", "resume_path": "resume.md"},
+        template=BUILTIN_TEMPLATES["quick"].including("seniority").to_dict(),
+    )
+    (run_dir / MANIFEST_FILE).write_text(json.dumps(manifest))
+    assert "--seniority" in resume_arguments(run_dir)
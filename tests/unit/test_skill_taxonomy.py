@@ -0,0 +1,154 @@
+"""
+Unit tests for skill normalization (alias dictionary + optional embeddings).
+"""
+
+import json
+
+import pytest
+
+from runtime.crewai import cli
+from runtime.crewai.artifacts import write_run_artifacts
+from runtime.crewai.ats_parse_check import parse_resume
+from runtime.crewai.skill_taxonomy import (
+    TAXONOMY_FILE_ENV,
+    SkillTaxonomy,
+    TaxonomyError,
+    default_taxonomy,
+    load_taxonomy,
+    skill_coverage,
+)
+
+JD = "We run Go services on K8s in GCP, deployed by CI/CD. PostgreSQL a plus. R&D budget."
+RESUME = """# Jane Doe
+
+## Skills
+
+Kubernetes, Golang, Google Cloud Platform, Postgres
+"""
+
+
+@pytest.fixture(autouse=True)
+def _no_user_taxonomy(tmp_path, monkeypatch):
+    monkeypatch.delenv(TAXONOMY_FILE_ENV, raising=False)
+    monkeypatch.setenv("HYDRA_HOME", str(tmp_path / "home"))
+
+
+def test_aliases_ignore_case_spacing_and_hyphens():
+    taxonomy = load_taxonomy()
+
+    assert taxonomy.canonical("k8s") == "Kubernetes"
+    assert taxonomy.canonical("NodeJS") == taxonomy.canonical("node.js") == "Node.js"
+    assert taxonomy.canonical("micro-services") == "Microservices"
+    assert taxonomy.canonical("Postgres.") == "PostgreSQL"
+    assert taxonomy.canonical("Underwater basket weaving") is None
+    assert taxonomy.normalize(" Underwater basket weaving ") == "Underwater basket weaving"
+    assert taxonomy.same("GCP", "Google Cloud")
+
+
+def test_free_text_respects_word_boundaries_and_case():
+    taxonomy = load_taxonomy()
+
+    found = taxonomy.mentions("Go and Node.js at Google; go to market; R&D; C# and C++")
+
+    assert found["Go"] == {"Go"}  # not "go to market", not inside "Google"
+    assert found["Node.js"] == {"Node.js"}  # the longer name, not "Node"
+    assert "R" not in found
+    assert {"C#", "C++"} <= set(found)
+    # A name inside a longer one counts once, for the longer one.
+    assert set(taxonomy.mentions("Elastic Kubernetes Service")) == {"Amazon EKS"}
+
+
+def test_coverage_separates_literal_matches_synonyms_and_missing():
+    coverage = skill_coverage(JD, RESUME, load_taxonomy())
+
+    assert coverage.matched == []
+    assert {s["jd_term"]: s["resume_term"] for s in coverage.synonyms} == {
+        "Go": "Golang",
+        "GCP": "Google Cloud Platform",
+        "K8s": "Kubernetes",
+        "PostgreSQL": "Postgres",
+    }
+    assert coverage.missing == ["Continuous Integration / Continuous Delivery"]
+    assert coverage.to_manifest() == {"matched": 0, "synonyms": 4, "missing": 1}
+
+
+def test_user_file_extends_and_overrides_the_builtin(tmp_path, monkeypatch):
+    user = tmp_path / "skills.yaml"
+    user.write_text("skills:\n  Kubernetes: [kubes]\n  Platform engineering: [K8s]\n")
+    monkeypatch.setenv(TAXONOMY_FILE_ENV, str(user))
+
+    taxonomy = load_taxonomy()
+
+    assert taxonomy.canonical("kubes") == "Kubernetes"
+    assert taxonomy.canonical("K8s") == "Platform engineering"  # the alias moved
+    assert taxonomy.canonical("kube") == "Kubernetes"  # built-in aliases kept
+
+
+def test_broken_files_are_reported_or_skipped(tmp_path, monkeypatch):
+    broken = tmp_path / "broken.yaml"
+    broken.write_text("skills:\n  Kubernetes: k8s\n")
+
+    with pytest.raises(TaxonomyError, match="aliases of 'Kubernetes' must be a list"):
+        load_taxonomy([broken])
+    with pytest.raises(TaxonomyError, match="Cannot read"):
+        load_taxonomy([tmp_path / "missing.yaml"])
+
+    monkeypatch.setenv(TAXONOMY_FILE_ENV, str(broken))
+    assert default_taxonomy().canonical("k8s") == "Kubernetes"  # built-in only
+
+
+def test_embeddings_place_unknown_listed_skills_and_failures_turn_them_off():
+    vectors = {"Kubernetes": [1.0, 0.0], "Docker": [0.0, 1.0], "k8s orchestration": [0.9, 0.1]}
+    calls = []
+
+    def embed(texts):
+        calls.append(list(texts))
+        return [vectors.get(text, [0.5, 0.5]) for text in texts]
+
+    taxonomy = SkillTaxonomy(embedder=embed, threshold=0.9)
+    taxonomy.add("Kubernetes", ["K8s"])
+    taxonomy.add("Docker")
+
+    assert taxonomy.canonical("k8s orchestration") == "Kubernetes"
+    assert taxonomy.canonical("K8s") == "Kubernetes" and len(calls) == 1  # alias: no call
+    assert taxonomy.canonical("Chess") is None  # below the threshold
+
+    def down(texts):
+        raise RuntimeError("no embedding model")
+
+    taxonomy = SkillTaxonomy(embedder=down)
+    taxonomy.add("Kubernetes")
+    assert taxonomy.canonical("container orchestration") is None
+    assert taxonomy.embedder is None
+
+
+def test_ats_parse_reports_jd_skills_and_the_manifest_counts_them(tmp_path):
+    report = parse_resume(RESUME, JD, load_taxonomy()).to_dict()
+
+    assert len(report["jd_skills"]["synonyms"]) == 4
+    assert "jd_skills" not in parse_resume(RESUME).to_dict()
+
+    class Result:
+        final_documents = {"resume": RESUME}
+        ats_parse = report
+
+    run_dir = write_run_artifacts(tmp_path, Result(), run_id="run-1")
+    manifest = json.loads((run_dir / "run.json").read_text())
+    assert manifest["ats_parse"]["jd_skills"] == {"matched": 0, "synonyms": 4, "missing": 1}
+
+
+def test_ats_check_with_a_jd_and_an_extra_taxonomy(tmp_path, capsys):
+    resume, jd, extra = tmp_path / "resume.md", tmp_path / "jd.md", tmp_path / "extra.yaml"
+    resume.write_text(RESUME)
+    jd.write_text(JD + " Experience with Hydra pipelines.")
+    extra.write_text("skills:\n  Hydra pipelines: [hydra]\n")
+
+    cli.main(["ats-check", str(resume), "--jd", str(jd), "--skill-taxonomy", str(extra)])
+
+    out = capsys.readouterr().out
+    assert "🔑 JD skills: 0 found as written, 4 under another name, 2 missing" in out
+    assert "JD says 'K8s', your résumé 'Kubernetes'" in out
+    assert "Hydra pipelines" in out
+
+    with pytest.raises(SystemExit):
+        cli.main(["ats-check", str(resume), "--skill-taxonomy", str(tmp_path / "nope.yaml")])
@@ -0,0 +1,104 @@
+"""
+Unit tests for the content-addressed stage cache.
+"""
+
+import json
+from unittest.mock import patch
+
+import pytest
+
+from runtime.crewai.base_agent import BaseHydraAgent
+from runtime.crewai.stage_cache import StageCache, cache_key, hydra_home
+
+
+class _GapAgent(BaseHydraAgent):
+    role = "Gap Analyzer"
+    goal = "Map requirements to experience"
+    expected_output = "JSON gap analysis"
+
+    def execute(self, context):  # pragma: no cover - not used in these tests
+        raise NotImplementedError
+
+
+@pytest.fixture
+def agent(tmp_path):
+    from crewai import LLM
+
+    agent = _GapAgent(LLM(model="gpt-4o-mini", api_key="test-key", temperature=0.3))
+    agent.stage_cache = StageCache(tmp_path / "cache")
+    return agent
+
+
+def _response(role):
+    return json.dumps({"agent": role, "timestamp": "t", "confidence": 0.9, "result": "ok"})
+
+
+MESSAGES = [{"role": "system", "content": "sys"}, {"role": "user", "content": "user"}]
+
+
+def test_cache_key_depends_on_messages_model_and_temperature():
+    base = cache_key(MESSAGES, "gpt-4o-mini", 0.3)
+
+    assert base == cache_key(list(MESSAGES), "gpt-4o-mini", 0.3)
+    assert base != cache_key(MESSAGES, "claude-sonnet-4-20250514", 0.3)
+    assert base != cache_key(MESSAGES, "gpt-4o-mini", 0.7)
+    other = [MESSAGES[0], {"role": "user", "content": "other"}]
+    assert base != cache_key(other, "gpt-4o-mini", 0.3)
+
+
+def test_put_get_round_trip_returns_fresh_copies(tmp_path):
+    cache = StageCache(tmp_path)
+    key = cache_key(MESSAGES, "m", 0.0)
+    assert cache.get(key) is None
+
+    cache.put(key, {"gaps": ["k8s"]}, "Gap Analyzer")
+    first = cache.get(key)
+    first["gaps"].append("mutated")
+
+    assert cache.get(key) == {"gaps": ["k8s"]}
+    assert cache.hits == ["Gap Analyzer", "Gap Analyzer"]
+    stored = next(tmp_path.rglob("*.json"))
+    assert stored.stat().st_mode & 0o077 == 0
+
+
+def test_corrupt_entry_is_a_miss(tmp_path):
+    cache = StageCache(tmp_path)
+    key = cache_key(MESSAGES, "m", 0.0)
+    cache.put(key, {"ok": True})
+    next(tmp_path.rglob("*.json")).write_text("{not json")
+
+    assert cache.get(key) is None
+
+
+def test_hydra_home_honors_env(monkeypatch, tmp_path):
+    monkeypatch.setenv("HYDRA_HOME", str(tmp_path))
+    assert hydra_home() == tmp_path
+
+
+def test_identical_call_is_served_from_cache(agent):
+    with patch.object(agent, "_invoke_llm", return_value=_response(agent.role)) as invoke:
+        task = agent.create_task("Analyze the gaps for this role.")
+        first = agent.execute_with_retry(task, max_retries=0)
+        second = agent.execute_with_retry(agent.create_task("Analyze the gaps for this role."))
+
+    assert invoke.call_count == 1
+    assert first == second
+    assert agent.stage_cache.hits == [agent.role]
+
+
+def test_changed_prompt_misses_the_cache(agent):
+    with patch.object(agent, "_invoke_llm", return_value=_response(agent.role)) as invoke:
+        agent.execute_with_retry(agent.create_task("Analyze the gaps for this role."))
+        agent.prompt = "A tweaked prompt."
+        agent.execute_with_retry(agent.create_task("Analyze the gaps for this role."))
+
+    assert invoke.call_count == 2
+    assert agent.stage_cache.hits == []
+
+
+def test_invalid_output_is_never_cached(agent):
+    with patch.object(agent, "_invoke_llm", return_value="not json"):
+        with pytest.raises(Exception):
+            agent.execute_with_retry(agent.create_task("Analyze."), max_retries=0)
+
+    assert not list(agent.stage_cache.root.rglob("*.json"))
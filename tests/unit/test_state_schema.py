@@ -0,0 +1,87 @@
+"""
+Unit tests for workflow state versioning and migrations.
+"""
+
+from unittest.mock import Mock, patch
+
+import pytest
+
+from runtime.crewai.hydra_workflow import HydraWorkflow, RunStatus
+from runtime.crewai.state_schema import (
+    MIGRATIONS,
+    STATE_VERSION,
+    StateVersionError,
+    migration,
+    upgrade_state,
+)
+
+V1_STATE = {
+    "gap_analysis": {"gaps": ["Kubernetes"]},
+    "executive_synthesis": {
+        # The model's own verdict, on a 0-1 scale the old code read as 0.85/100.
+        "decision": {"recommendation": "PASS", "fit_score": 0.85, "rationale": "Strong"},
+    },
+}
+
+
+def test_version_1_state_gets_the_python_owned_recommendation():
+    upgraded = upgrade_state(V1_STATE, None)
+
+    decision = upgraded["executive_synthesis"]["decision"]
+    assert decision["fit_score"] == 85.0 and decision["recommendation"] == "STRONG_PROCEED"
+    assert decision["rationale"] == "Strong"
+    assert upgraded["gap_analysis"] == V1_STATE["gap_analysis"]
+    assert V1_STATE["executive_synthesis"]["decision"]["recommendation"] == "PASS"
+
+
+def test_version_1_state_with_a_flat_score_gets_a_decision():
+    upgraded = upgrade_state({"executive_synthesis": {"fit_score": 55}}, 1)
+
+    assert upgraded["executive_synthesis"]["decision"]["recommendation"] == (
+        "PROCEED_WITH_CAUTION"
+    )
+
+
+def test_current_state_is_copied_unchanged_and_newer_states_are_refused():
+    state = {"tailoring": {"tailored_resume": "R"}}
+    upgraded = upgrade_state(state, STATE_VERSION)
+
+    assert upgraded == state and upgraded is not state
+    assert upgrade_state(None, None) == {}
+    with pytest.raises(StateVersionError, match="newer"):
+        upgrade_state(state, STATE_VERSION + 1)
+
+
+def test_every_version_has_a_migration_and_duplicates_are_rejected():
+    assert sorted(MIGRATIONS) == list(range(1, STATE_VERSION))
+    with pytest.raises(ValueError, match="Duplicate"):
+        migration(1)(lambda state: state)
+
+
+def test_workflow_resumes_from_an_upgraded_state():
+    with (
+        patch("runtime.crewai.hydra_workflow.GapAnalyzerAgent"),
+        patch("runtime.crewai.hydra_workflow.InterrogatorPrepperAgent"),
+        patch("runtime.crewai.hydra_workflow.DifferentiatorAgent"),
+        patch("runtime.crewai.hydra_workflow.TailoringAgent"),
+        patch("runtime.crewai.hydra_workflow.ATSOptimizerAgent"),
+        patch("runtime.crewai.hydra_workflow.AuditorSuiteAgent"),
+        patch("runtime.crewai.hydra_workflow.ExecutiveSynthesizerAgent"),
+    ):
+        workflow = HydraWorkflow(Mock(), use_per_agent_models=False)
+    context = {
+        "job_description": "Platform engineer",
+        "resume": "Jane Doe",
+        "source_documents": "Jane Doe",
+        "previous_results": V1_STATE,
+    }
+
+    paused = workflow.execute(context)  # resumes, then waits at the interview
+
+    assert paused.status == RunStatus.PAUSED
+    workflow.gap_analyzer.execute.assert_not_called()
+    decision = paused.intermediate_results["executive_synthesis"]["decision"]
+    assert decision["recommendation"] == "STRONG_PROCEED"
+
+    newer = workflow.execute({**context, "state_version": STATE_VERSION + 1})
+    assert newer.status == RunStatus.FAILED and "newer than this Hydra" in newer.error_message
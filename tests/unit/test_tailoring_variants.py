@@ -0,0 +1,149 @@
+"""
+Unit tests for parallel A/B tailoring and winner selection.
+"""
+
+import json
+from types import SimpleNamespace
+from unittest.mock import MagicMock, patch
+
+import pytest
+
+from runtime.crewai.artifacts import write_run_artifacts
+from runtime.crewai.hydra_workflow import HydraWorkflow
+from runtime.crewai.model_config import LLMClientError, parse_model_spec
+from runtime.crewai.tailoring_variants import (
+    MIN_WINS_FOR_DEFAULT,
+    TailoringCandidate,
+    VariantPreferences,
+    judge_by_audit,
+    pick_by_audit,
+    pick_interactively,
+    run_variants,
+)
+
+APPROVED = {"approval": {"approved": True}}
+REJECTED = {"approval": {"approved": False}, "action_required": {"blocking": ["TRUTH-001"]}}
+
+
+def _tailored(resume):
+    return {"tailored_resume": resume, "tailored_cover_letter": f"Cover for {resume}"}
+
+
+def test_parse_model_spec_requires_a_known_provider():
+    assert parse_model_spec("together:meta-llama/x") == ("together", "meta-llama/x")
+    with pytest.raises(LLMClientError):
+        parse_model_spec("claude-sonnet-4")
+    with pytest.raises(LLMClientError):
+        parse_model_spec("nowhere:model")
+
+
+def test_run_variants_keeps_order_and_isolates_failures():
+    def execute(spec):
+        if spec == "b":
+            raise RuntimeError("provider down")
+        return _tailored(spec)
+
+    candidates = run_variants(["a", "b", "c"], execute)
+
+    assert [c.spec for c in candidates] == ["a", "b", "c"]
+    assert candidates[1].error == "provider down"
+    assert candidates[2].documents.resume == "c"
+
+
+def test_audit_pick_prefers_approved_then_fewest_blocking():
+    candidates = [
+        TailoringCandidate("a", result=_tailored("a")),
+        TailoringCandidate("b", result=_tailored("b")),
+        TailoringCandidate("c", error="boom"),
+    ]
+
+    judge_by_audit(candidates, lambda resume: APPROVED if resume == "b" else REJECTED)
+
+    assert candidates[0].blocking == ["TRUTH-001"]
+    assert pick_by_audit(candidates).spec == "b"
+
+
+def test_audit_pick_ties_keep_preference_order():
+    candidates = [
+        TailoringCandidate("a", result=_tailored("a"), approved=True),
+        TailoringCandidate("b", result=_tailored("b"), approved=True),
+    ]
+
+    assert pick_by_audit(candidates).spec == "a"
+
+
+def test_interactive_pick_uses_the_answer_and_defaults_to_audit(capsys):
+    candidates = [
+        TailoringCandidate("a", result=_tailored("line one\nshared")),
+        TailoringCandidate("b", result=_tailored("line two\nshared"), approved=True),
+    ]
+
+    assert pick_interactively(candidates, input_fn=lambda _: "1").spec == "a"
+    assert pick_interactively(candidates, input_fn=lambda _: "").spec == "b"
+    assert "+line two" in capsys.readouterr().out
+
+
+def test_preferences_rank_and_suggest_a_default(tmp_path):
+    prefs = VariantPreferences(tmp_path / "prefs.json")
+    assert prefs.preferred() is None
+
+    for _ in range(MIN_WINS_FOR_DEFAULT):
+        prefs.record("b", ["a", "b"])
+
+    assert prefs.ranked(["a", "b"]) == ["b", "a"]
+    assert prefs.preferred() == "b"
+    assert prefs.load()["a"] == {"wins": 0, "runs": MIN_WINS_FOR_DEFAULT}
+
+
+def test_workflow_tailors_in_parallel_and_forwards_the_winner(tmp_path):
+    prefs = VariantPreferences(tmp_path / "prefs.json")
+
+    def fake_agent(llm):
+        agent = MagicMock()
+        agent.llm.model = llm
+        agent._build_backstory.return_value = "system"
+        agent.execute.return_value = _tailored(llm)
+        return agent
+
+    with (
+        patch("runtime.crewai.hydra_workflow.get_llm_for_spec", side_effect=lambda s, a: s),
+        patch("runtime.crewai.hydra_workflow.TailoringAgent", side_effect=fake_agent),
+    ):
+        workflow = HydraWorkflow(
+            MagicMock(),
+            use_per_agent_models=False,
+            tailoring_models=["together:a", "openai:b"],
+            variant_preferences=prefs,
+        )
+        workflow._audit_document = MagicMock(
+            side_effect=lambda ctx, resume, kind: APPROVED if resume == "openai:b" else REJECTED
+        )
+        result = workflow._execute_tailoring({"resume": "r"}, {}, {}, {})
+
+    assert result == _tailored("openai:b")
+    assert workflow.agent_models["tailoring_agent"] == "openai:b"
+    assert [c.winner for c in workflow.variant_candidates] == [False, True]
+    assert prefs.load()["openai:b"]["wins"] == 1
+
+
+def test_all_candidates_are_written_with_a_manifest_summary(tmp_path):
+    candidates = [
+        TailoringCandidate("together:meta-llama/x", result=_tailored("A"), approved=False),
+        TailoringCandidate("openai:gpt-4o-mini", result=_tailored("B"), approved=True),
+    ]
+    candidates[1].winner = True
+    result = SimpleNamespace(
+        status=None,
+        final_documents={"resume": "B"},
+        audit_report=None,
+        execution_log=[],
+        tailoring_variants=candidates,
+    )
+
+    run_dir = write_run_artifacts(tmp_path, result, run_id="run-1")
+
+    assert (run_dir / "variants" / "01-together-meta-llama-x.resume.md").read_text() == "A"
+    assert (run_dir / "variants" / "02-openai-gpt-4o-mini.cover_letter.md").exists()
+    manifest = json.loads((run_dir / "run.json").read_text())
+    assert [v["winner"] for v in manifest["tailoring_variants"]] == [False, True]
+    assert "A" not in json.dumps(manifest["tailoring_variants"])
@@ -0,0 +1,191 @@
+"""
+Unit tests for per-stage and per-LLM-call timeouts and the pipeline config.
+"""
+
+import json
+import threading
+import time
+from unittest.mock import Mock, patch
+
+import pytest
+
+from runtime.crewai import cli
+from runtime.crewai.agents.gap_analyzer import GapAnalyzerAgent
+from runtime.crewai.artifacts import write_run_artifacts
+from runtime.crewai.base_agent import ValidationError
+from runtime.crewai.hydra_workflow import RunStatus
+from runtime.crewai.pipeline_config import (
+    PIPELINE_CONFIG_ENV,
+    PipelineConfig,
+    PipelineConfigError,
+    default_pipeline_config,
+    load_pipeline_config,
+)
+from runtime.crewai.timeouts import (
+    DEFAULT_LLM_CALL_SECONDS,
+    DEFAULT_STAGE_SECONDS,
+    TimeoutExceeded,
+    TimeoutPolicy,
+    run_with_timeout,
+)
+from tests.conftest import patched_workflow
+
+
+@pytest.fixture(autouse=True)
+def _no_user_config(tmp_path, monkeypatch):
+    monkeypatch.delenv(PIPELINE_CONFIG_ENV, raising=False)
+    monkeypatch.setenv("HYDRA_HOME", str(tmp_path / "home"))
+
+
+def _workflow(timeouts):
+    return patched_workflow(auto_approve=True, pipeline_config=PipelineConfig(timeouts=timeouts))
+
+
+def test_defaults_and_overrides():
+    assert load_pipeline_config().timeouts == TimeoutPolicy()
+    policy = TimeoutPolicy.from_dict({"llm_call": 0, "stages": {"audit": 30, "research": None}})
+
+    assert policy.llm_call is None  # 0 turns it off
+    assert policy.stage == DEFAULT_STAGE_SECONDS
+    assert policy.for_stage("auditor_suite") == 30  # the workflow's name for the audit
+    assert policy.for_stage("research_agent") is None
+    assert policy.for_stage("tailoring") == 900  # built-in override kept
+    assert policy.for_stage("gap_analysis") == DEFAULT_STAGE_SECONDS
+    assert TimeoutPolicy().llm_call == DEFAULT_LLM_CALL_SECONDS
+
+
+@pytest.mark.parametrize(
+    "text, message",
+    [
+        ("timeouts:\n  stages: {auditing: 30}\n", "unknown stage"),
+        ("timeouts:\n  llm_call: soon\n", "timeouts.llm_call must be a number"),
+        ("timeouts:\n  stage: -1\n", "timeouts.stage must be a number"),
+        ("retries: 3\n", "unknown section"),
+        ("timeouts: [1]\n", "'timeouts' must be a mapping"),
+    ],
+)
+def test_bad_configs_are_rejected(tmp_path, monkeypatch, text, message):
+    path = tmp_path / "pipeline.yaml"
+    path.write_text(text)
+
+    with pytest.raises(PipelineConfigError, match=message):
+        load_pipeline_config(path)
+
+    monkeypatch.setenv(PIPELINE_CONFIG_ENV, str(path))
+    assert default_pipeline_config() == PipelineConfig()  # a run is not stopped by it
+
+
+def test_user_file_is_found_in_hydra_home(tmp_path, monkeypatch):
+    monkeypatch.setenv("HYDRA_HOME", str(tmp_path))
+    (tmp_path / "pipeline.yaml").write_text("timeouts:\n  stage: 120\n")
+
+    assert load_pipeline_config().timeouts.stage == 120
+
+
+def test_an_overrunning_call_is_abandoned():
+    release = threading.Event()
+
+    started = time.monotonic()
+    with pytest.raises(TimeoutExceeded, match="stage for tailoring timed out after 0.2s"):
+        run_with_timeout("tailoring", 0.2, lambda: release.wait(10))
+    release.set()
+
+    assert time.monotonic() - started < 5
+    assert run_with_timeout("tailoring", None, lambda: "done") == "done"
+    with pytest.raises(KeyError):
+        run_with_timeout("tailoring", 5, lambda: {}["missing"])
+
+
+def test_a_hung_llm_call_is_retried_and_recorded():
+    agent = GapAnalyzerAgent(Mock())
+    agent.call_timeout = 0.2
+    release = threading.Event()
+    answers = iter([lambda: release.wait(10), lambda: '{"requirements": [], "gaps": []}'])
+
+    with patch.object(agent, "_invoke_llm", side_effect=lambda task: next(answers)()):
+        result = agent.execute_with_retry(Mock(), max_retries=1)
+    release.set()
+
+    assert result["gaps"] == []
+    assert [e.scope for e in agent.timed_out] == ["llm_call"]
+
+    agent.timed_out = []
+    with patch.object(agent, "_invoke_llm", side_effect=lambda task: release.wait(10) or "x"):
+        release.clear()
+        with pytest.raises(ValidationError, match="timed out"):
+            agent.execute_with_retry(Mock(), max_retries=1)
+    release.set()
+    assert len(agent.timed_out) == 2
+
+
+def test_a_stage_timeout_falls_back_and_is_recorded_as_a_workflow_error():
+    workflow = _workflow(TimeoutPolicy(llm_call=None, stages={"gap_analysis": 0.2}))
+    release = threading.Event()
+    outputs = iter([lambda: release.wait(10), lambda: {"gaps": []}])
+    workflow.gap_analyzer.execute.side_effect = lambda context: next(outputs)()
+    workflow._begin_run()
+
+    result = workflow._execute_with_fallback(
+        workflow.gap_analyzer, {"job_description": "JD", "resume": "CV"}, "gap_analysis"
+    )
+    release.set()
+
+    assert result == {"gaps": []}
+    [error] = workflow._error_summary()
+    assert (error["stage"], error["kind"], error["scope"], error["seconds"]) == (
+        "gap_analysis",
+        "timeout",
+        "stage",
+        0.2,
+    )
+
+
+def test_a_stage_that_keeps_timing_out_fails_the_run_with_its_errors(tmp_path):
+    workflow = _workflow(TimeoutPolicy(stages={"gap_analysis": 0.1}))
+    release = threading.Event()
+    workflow.gap_analyzer.execute.side_effect = lambda context: release.wait(10)
+
+    result = workflow.execute({"job_description": "JD", "resume": "CV", "source_documents": "CV"})
+    release.set()
+
+    assert result.status == RunStatus.FAILED
+    assert "stage for gap_analysis timed out after 0.1s" in result.error_message
+    assert [error["scope"] for error in result.errors] == ["stage", "stage"]  # + fallback
+    assert all(agent.call_timeout == DEFAULT_LLM_CALL_SECONDS for agent in workflow._agents())
+
+    class Result:
+        final_documents = {"resume": "# Jane"}
+        errors = result.errors
+
+    run_dir = write_run_artifacts(tmp_path, Result(), run_id="run-1")
+    manifest = json.loads((run_dir / "run.json").read_text())
+    assert [error["stage"] for error in manifest["errors"]] == ["gap_analysis"] * 2
+
+
+def test_cli_checks_the_pipeline_config_up_front(tmp_path, capsys):
+    jd, resume, config = tmp_path / "jd.md", tmp_path / "resume.md", tmp_path / "p.yaml"
+    jd.write_text("JD")
+    resume.write_text("Resume")
+    config.write_text("timeouts:\n  stages: {polish: 5}\n")
+    (tmp_path / "sources").mkdir()
+    (tmp_path / "sources" / "cv.md").write_text("Sources")
+    paths = ["--jd", str(jd), "--resume", str(resume), "--sources", str(tmp_path / "sources")]
+
+    with pytest.raises(SystemExit):
+        cli.main([*paths, "--out", str(tmp_path / "out"), "--pipeline-config", str(config)])
+
+    assert "--pipeline-config" in capsys.readouterr().err
+
+
+def test_report_lists_timeouts(capsys):
+    cli._report_timeouts(
+        [
+            {"stage": "tailoring", "kind": "timeout", "scope": "llm_call", "seconds": 180.0},
+            {"stage": "audit", "kind": "timeout", "scope": "stage", "seconds": 600.0},
+        ]
+    )
+
+    out = capsys.readouterr().out
+    assert "2 timeout(s)" in out
+    assert "tailoring: LLM call after 180s" in out
+    assert "audit: whole stage after 600s" in out
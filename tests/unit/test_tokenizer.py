@@ -0,0 +1,114 @@
+"""
+Unit tests for per-model token counting: tiktoken for OpenAI models, estimates for the rest.
+"""
+
+import sys
+from types import SimpleNamespace
+from unittest.mock import Mock
+
+import pytest
+
+from runtime.crewai.context_window import compact_context, context_tokens
+from runtime.crewai.tokenizer import (
+    DEFAULT_TOKENIZER,
+    HeuristicTokenizer,
+    count_tokens,
+    is_openai_model,
+    tokenizer_for,
+    truncate_to_tokens,
+)
+from runtime.crewai.tokenizer.bpe import TiktokenTokenizer
+
+
+@pytest.fixture(autouse=True)
+def fresh_tokenizers():
+    tokenizer_for.cache_clear()
+    yield
+    tokenizer_for.cache_clear()
+
+
+class _Encoding:
+    """One token per word, like a (very) small BPE vocabulary."""
+
+    def encode(self, text, disallowed_special=()):
+        return text.split(" ")
+
+    def decode(self, tokens):
+        return " ".join(tokens)
+
+
+@pytest.fixture
+def fake_tiktoken(monkeypatch):
+    known = {"gpt-4o": "o200k_base", "gpt-4": "cl100k_base"}
+
+    def encoding_name_for_model(model):
+        return known[model]
+
+    module = SimpleNamespace(
+        encoding_name_for_model=encoding_name_for_model,
+        get_encoding=lambda name: _Encoding(),
+    )
+    monkeypatch.setitem(sys.modules, "tiktoken", module)
+    return module
+
+
+def test_without_a_model_tokens_are_four_characters():
+    assert tokenizer_for(None) is DEFAULT_TOKENIZER
+    assert tokenizer_for(Mock()) is DEFAULT_TOKENIZER  # a test double's .model
+    assert (count_tokens(""), count_tokens("abcd"), count_tokens("abcde")) == (0, 1, 2)
+    assert truncate_to_tokens("abcdefghij", 2) == "abcdefgh"
+    assert truncate_to_tokens("abc", 0) == ""
+
+
+def test_other_providers_are_estimated_per_family():
+    claude = tokenizer_for("openrouter/anthropic/claude-sonnet-4-20250514")
+
+    assert claude.name == "heuristic:3.5" and not claude.exact
+    assert (claude.count("a" * 7), claude.count("a" * 8)) == (2, 3)
+    assert claude.truncate("a" * 10, 2) == "a" * 7
+    assert tokenizer_for("together_ai/meta-llama/Llama-3.3-70B") is DEFAULT_TOKENIZER
+    with pytest.raises(ValueError):
+        HeuristicTokenizer(0)
+
+
+def test_openai_models_are_counted_with_their_vocabulary(fake_tiktoken):
+    gpt = tokenizer_for("openai/gpt-4o")
+
+    assert isinstance(gpt, TiktokenTokenizer) and gpt.exact
+    assert gpt.name == "tiktoken:o200k_base"
+    assert count_tokens("tailor the resume", "gpt-4o") == 3
+    assert truncate_to_tokens("tailor the resume", 2, "gpt-4o") == "tailor the"
+    assert truncate_to_tokens("short", 5, "gpt-4o") == "short"
+    # Newer than the installed tiktoken knows: picked by family.
+    assert tokenizer_for("gpt-5-mini").encoding_name == "o200k_base"
+    assert tokenizer_for("azure/gpt-3.5-turbo").encoding_name == "cl100k_base"
+    assert is_openai_model("o3-mini") and not is_openai_model("claude-3-opus")
+
+
+def test_openai_models_fall_back_to_the_estimate_without_tiktoken(monkeypatch):
+    monkeypatch.setitem(sys.modules, "tiktoken", None)  # import fails
+
+    assert tokenizer_for("gpt-4o-mini") is DEFAULT_TOKENIZER
+    assert count_tokens("abcde", "gpt-4o-mini") == 2
+
+
+def test_compaction_truncates_in_the_model_tokens(fake_tiktoken):
+    context = {"resume": "r", "gap_analysis": " ".join(["gap"] * 1000)}
+
+    compacted, report = compact_context(context, budget_tokens=600, model="gpt-4o")
+
+    assert report.truncated_keys == ["gap_analysis"]
+    assert report.tokens_before == 1001
+    assert not report.over_budget
+    assert report.tokens_after == context_tokens(compacted, "gpt-4o") <= 600
+    assert compacted["gap_analysis"].startswith("gap gap")
+
+
+def test_real_tiktoken_counts_gpt_4o():
+    tiktoken = pytest.importorskip("tiktoken")
+    try:
+        tiktoken.get_encoding("o200k_base")
+    except Exception:
+        pytest.skip("the o200k_base vocabulary cannot be downloaded here")
+
+    assert count_tokens("hello world", "gpt-4o") == 2
@@ -0,0 +1,175 @@
+"""
+Unit tests for agent tools and the bounded tool-calling loop.
+"""
+
+import json
+from types import SimpleNamespace
+from unittest.mock import patch
+
+import pytest
+from crewai import LLM
+
+from runtime.crewai.artifacts import write_run_artifacts
+from runtime.crewai.base_agent import BaseHydraAgent
+from runtime.crewai.tools import (
+    TOOL_TRANSCRIPT_FILE,
+    ATSScoreTool,
+    CalculatorTool,
+    FileReadTool,
+    ToolError,
+    WebSearchTool,
+    parse_tool_calls,
+    run_tool_call,
+)
+from runtime.crewai.web_search import SearchError, SearchResult
+
+
+class _ToolAgent(BaseHydraAgent):
+    role = "Tool Agent"
+    goal = "Use tools"
+    expected_output = "JSON"
+
+    def execute(self, context):
+        return self.execute_with_retry(self.create_task("Do the thing"))
+
+
+def _reply(**body):
+    return json.dumps({"agent": "Tool Agent", "confidence": 0.9, **body})
+
+
+@pytest.fixture
+def agent():
+    agent = _ToolAgent(LLM(model="gpt-4", api_key="test-key"))
+    agent.tools = [CalculatorTool()]
+    agent.max_tool_rounds = 2
+    return agent
+
+
+def test_calculator_is_exact_and_refuses_code():
+    calculator = CalculatorTool()
+
+    assert calculator.execute({"expression": "(180 - 120) / 120 * 100"}) == 50.0
+    for expression in ("__import__('os')", "2 ** 1000", "1 / 0"):
+        with pytest.raises(ToolError):
+            calculator.execute({"expression": expression})
+
+
+def test_file_reads_stay_inside_the_root(tmp_path):
+    (tmp_path / "notes.md").write_text("Led 40 services")
+    (tmp_path / "deep").mkdir()
+    tool = FileReadTool(tmp_path)
+
+    assert tool.execute({"path": "notes.md"}) == "Led 40 services"
+    assert tool.execute({"path": "."}) == ["deep/", "notes.md"]
+    with pytest.raises(ToolError, match="outside"):
+        tool.execute({"path": "../../etc/passwd"})
+
+
+def test_ats_score_and_search_tools():
+    scored = ATSScoreTool().execute({"resume": "# Jane\n## Hobbies\nChess"})
+    assert scored["score"] < 50 and "section:experience" in scored["missing_fields"]
+    assert "contact" not in scored
+
+    class _Provider:
+        def search(self, query, max_results=5):
+            if query == "down":
+                raise SearchError("quota exceeded")
+            return [SearchResult("T", "https://t")][:max_results]
+
+    search = WebSearchTool(_Provider())
+    assert search.execute({"query": "acme", "max_results": 50})[0]["url"] == "https://t"
+    with pytest.raises(ToolError, match="quota"):
+        search.execute({"query": "down"})
+
+
+def test_calls_are_validated_before_they_run():
+    tools = [CalculatorTool()]
+
+    assert parse_tool_calls({"tool_calls": {"name": "calculator"}}) == [
+        {"tool": "calculator", "arguments": {}}
+    ]
+    assert parse_tool_calls({"summary": "final"}) == []
+    missing = run_tool_call(tools, {"tool": "calculator", "arguments": {}}, 1)
+    assert missing["error"] == "missing required argument: expression"
+    wrong = run_tool_call(tools, {"tool": "calculator", "arguments": {"expression": 2}}, 1)
+    assert wrong["error"] == "argument expression must be string"
+    unknown = run_tool_call(tools, {"tool": "shell", "arguments": {}}, 1)
+    assert unknown["error"] == "unknown tool: shell"
+
+
+def test_tool_loop_feeds_results_back_and_records_the_transcript(agent):
+    replies = [
+        _reply(tool_calls=[{"tool": "calculator", "arguments": {"expression": "6 * 7"}}]),
+        _reply(answer=42),
+    ]
+    with patch.object(agent, "_invoke_llm", side_effect=replies) as invoke:
+        result = agent.execute({})
+
+    assert result["answer"] == 42 and "tool_calls" not in result
+    assert agent.tool_transcript == [
+        {"round": 1, "tool": "calculator", "arguments": {"expression": "6 * 7"}, "result": 42}
+    ]
+    second_prompt = invoke.call_args_list[1][0][0].description
+    assert '[1] calculator({"expression": "6 * 7"}) → 42' in second_prompt
+
+
+def test_tool_loop_is_bounded(agent):
+    again = _reply(tool_calls=[{"tool": "calculator", "arguments": {"expression": "1"}}])
+    with patch.object(agent, "_invoke_llm", return_value=again) as invoke:
+        agent.execute({})
+
+    assert invoke.call_count == agent.max_tool_rounds + 1
+    assert len(agent.tool_transcript) == agent.max_tool_rounds
+    assert "Tool budget used up" in invoke.call_args[0][0].description
+
+
+def test_agents_without_tools_make_a_single_call(agent):
+    agent.tools = []
+    with patch.object(agent, "_invoke_llm", return_value=_reply(answer=1)) as invoke:
+        agent.execute({})
+
+    assert invoke.call_count == 1
+    assert "TOOLS" not in invoke.call_args[0][0].description
+
+
+def test_transcript_artifact_and_manifest_counts(tmp_path):
+    transcripts = {
+        "ats_optimization": [
+            {"round": 1, "tool": "ats_score", "arguments": {"resume": "Jane"}, "result": {}},
+            {"round": 1, "tool": "calculator", "arguments": {}, "error": "missing"},
+        ]
+    }
+    result = SimpleNamespace(
+        status=None,
+        final_documents={},
+        audit_report=None,
+        execution_log=[],
+        tool_transcripts=transcripts,
+    )
+
+    run_dir = write_run_artifacts(tmp_path, result, run_id="run-1")
+
+    assert json.loads((run_dir / TOOL_TRANSCRIPT_FILE).read_text()) == transcripts
+    manifest = json.loads((run_dir / "run.json").read_text())
+    assert manifest["tool_calls"] == {
+        "ats_optimization": {"calls": 2, "errors": 1, "tools": ["ats_score", "calculator"]}
+    }
+    assert "Jane" not in json.dumps(manifest)
+
+
+def test_workflow_gives_tools_per_agent_and_collects_transcripts():
+    from runtime.crewai.hydra_workflow import HydraWorkflow
+
+    calculator = CalculatorTool()
+    workflow = HydraWorkflow(
+        None, use_per_agent_models=False, agent_tools={"ats_optimizer": [calculator]}
+    )
+    assert workflow.ats_optimizer.tools == [calculator] and workflow.gap_analyzer.tools == []
+
+    entry = {"round": 1, "tool": "calculator", "arguments": {}, "result": 1}
+    workflow.ats_optimizer.tool_transcript = [entry]
+    workflow._record_tool_calls(workflow.ats_optimizer, "ats_optimization")
+    assert workflow.tool_transcripts == {"ats_optimization": [entry]}
+
+    with pytest.raises(ValueError, match="No agent to give tools to: researcher"):
+        HydraWorkflow(None, use_per_agent_models=False, agent_tools={"researcher": []})
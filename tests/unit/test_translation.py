@@ -0,0 +1,124 @@
+"""
+Unit tests for the translation stage: glossary enforcement and artifact output.
+"""
+
+import json
+from types import SimpleNamespace
+
+import pytest
+
+from runtime.crewai.artifacts import translated_filename, write_run_artifacts
+from runtime.crewai.translation import (
+    DeepLProvider,
+    TranslationError,
+    TranslationProvider,
+    get_translation_provider,
+    load_glossary,
+    translate_documents,
+)
+
+
+class UpperProvider(TranslationProvider):
+    """Deterministic stand-in: "translates" by upper-casing, keeping placeholders."""
+
+    name = "fake"
+
+    def __init__(self):
+        self.seen = []
+
+    def translate(self, text, target_language):
+        self.seen.append(text)
+        return text.upper()
+
+
+class DroppingProvider(TranslationProvider):
+    """A provider that mangles placeholders, as a sloppy model might."""
+
+    name = "fake"
+
+    def translate(self, text, target_language):
+        return text.replace("[[G0]]", "G0")
+
+
+def test_glossary_terms_are_protected_and_applied_consistently():
+    provider = UpperProvider()
+    documents = {
+        "resume": "Led on-call rotation for Kubernetes.",
+        "cover_letter": "I enjoy On-Call work.",
+    }
+    glossary = {"on-call": "Rufbereitschaft"}
+
+    result = translate_documents(documents, provider, "DE", glossary)
+
+    # The provider never sees the source term, only the placeholder.
+    assert all("on-call" not in text.lower() for text in provider.seen)
+    assert "Rufbereitschaft" in result.documents["resume"]
+    assert "Rufbereitschaft" in result.documents["cover_letter"]
+    assert result.language == "de"
+    assert result.glossary_issues == []
+
+
+def test_lost_placeholder_is_reported():
+    result = translate_documents(
+        {"resume": "on-call lead"}, DroppingProvider(), "de", {"on-call": "Rufbereitschaft"}
+    )
+
+    assert result.glossary_issues == ["resume: Glossary term 'on-call' was lost in translation"]
+
+
+def test_empty_documents_are_skipped():
+    result = translate_documents({"resume": "text", "cover_letter": ""}, UpperProvider(), "fr")
+
+    assert list(result.documents) == ["resume"]
+
+
+def test_load_glossary_flat_and_per_language(tmp_path):
+    flat = tmp_path / "flat.yaml"
+    flat.write_text("on-call: Rufbereitschaft\n")
+    per_lang = tmp_path / "per_lang.yaml"
+    per_lang.write_text("de:\n  on-call: Rufbereitschaft\nfr:\n  on-call: astreinte\n")
+
+    assert load_glossary(flat, "de") == {"on-call": "Rufbereitschaft"}
+    assert load_glossary(per_lang, "fr") == {"on-call": "astreinte"}
+    assert load_glossary(per_lang, "it") == {}
+
+
+def test_deepl_requires_key_and_picks_free_endpoint(monkeypatch):
+    monkeypatch.delenv("DEEPL_API_KEY", raising=False)
+    with pytest.raises(TranslationError):
+        DeepLProvider()
+
+    assert DeepLProvider(api_key="abc:fx").url.startswith("https://api-free.deepl.com")
+    assert DeepLProvider(api_key="abc").url.startswith("https://api.deepl.com")
+
+
+def test_llm_provider_requires_an_llm():
+    with pytest.raises(TranslationError):
+        get_translation_provider("llm", llm=None)
+    with pytest.raises(TranslationError):
+        get_translation_provider("google")
+
+
+def test_translated_documents_are_written_and_manifested(tmp_path):
+    result = SimpleNamespace(
+        status=None,
+        final_documents={"resume": "Resume", "cover_letter": "Letter"},
+        audit_report=None,
+        execution_log=[],
+    )
+    translation = translate_documents(result.final_documents, UpperProvider(), "de")
+
+    run_dir = write_run_artifacts(tmp_path, result, run_id="run-1", translation=translation)
+
+    assert translated_filename("resume.md", "de") == "resume.de.md"
+    assert (run_dir / "resume.de.md").read_text() == "RESUME"
+    assert (run_dir / "cover_letter.de.md").read_text() == "LETTER"
+    manifest = json.loads((run_dir / "run.json").read_text())
+    assert manifest["translation"] == {
+        "language": "de",
+        "provider": "fake",
+        "glossary_terms": 0,
+        "glossary_issues": 0,
+    }
+    assert "resume.de.md" in manifest["artifacts"]
+    assert "RESUME" not in (run_dir / "run.json").read_text()
@@ -0,0 +1,138 @@
+"""
+Unit tests for WebAssembly stage plugins: manifests, capabilities and the host functions.
+"""
+
+import json
+import sys
+from unittest.mock import Mock
+
+import pytest
+
+from runtime.crewai.gateway import GatewayLLM
+from runtime.crewai.plugins import WASM, PluginError, StagePlugin, run_plugin
+from runtime.crewai.wasm_plugins import MAX_LLM_CALLS, WasmHost, check_imports
+
+RESPONSE = json.dumps({"output": {"polished": 3}, "log": ["from the answer"]})
+
+# Logs a line, then answers with RESPONSE, which sits at address 0.
+ANSWERING_MODULE = """
+(module
+  (import "hydra" "log" (func $log (param i32 i32)))
+  (memory (export "memory") 1)
+  (global $next (mut i32) (i32.const 4096))
+  (data (i32.const 0) "{response}")
+  (data (i32.const 2048) "hello")
+  (func (export "hydra_alloc") (param $size i32) (result i32)
+    (local $ptr i32)
+    (local.set $ptr (global.get $next))
+    (global.set $next (i32.add (global.get $next) (local.get $size)))
+    (local.get $ptr))
+  (func (export "hydra_run") (param i32 i32) (result i64)
+    (call $log (i32.const 2048) (i32.const 5))
+    (i64.const {length})))
+"""
+LOOPING_MODULE = """
+(module
+  (memory (export "memory") 1)
+  (func (export "hydra_alloc") (param i32) (result i32) (i32.const 1024))
+  (func (export "hydra_run") (param i32 i32) (result i64) (loop $spin (br $spin)) (i64.const 0)))
+"""
+
+
+def _wasm_plugin(tmp_path, source="(module)", **manifest):
+    directory = tmp_path / "polish"
+    directory.mkdir()
+    (directory / "polish.wat").write_text(source)
+    data = {"runtime": "wasm", "module": "polish.wat", "after": "tailoring", **manifest}
+    (directory / "plugin.yaml").write_text(json.dumps(data))
+    return StagePlugin.from_manifest(directory / "plugin.yaml")
+
+
+def test_wasm_manifests_name_a_module_and_known_capabilities(tmp_path):
+    plugin = _wasm_plugin(tmp_path, capabilities=["llm"], memory_mb=16)
+
+    assert (plugin.runtime, plugin.module, plugin.capabilities, plugin.memory_mb) == (
+        WASM,
+        "polish.wat",
+        ("llm",),
+        16,
+    )
+    assert plugin.describe() == "wasm polish.wat [llm]"
+
+    bad = tmp_path / "bad" / "plugin.yaml"
+    bad.parent.mkdir()
+    for text, message in [
+        ("runtime: wasm\nafter: audit\n", "needs 'module'"),
+        ("runtime: wasm\nmodule: a.wasm\nafter: audit\ncapabilities: [network]\n", "llm, sources"),
+        ("command: x\nafter: audit\ncapabilities: [llm]\n", "wasm plugins only"),
+        ("runtime: docker\ncommand: x\nafter: audit\n", "'runtime' must be one of"),
+    ]:
+        bad.write_text(text)
+        with pytest.raises(PluginError, match=message):
+            StagePlugin.from_manifest(bad)
+
+
+def test_modules_may_import_only_the_host_functions_they_were_granted(tmp_path):
+    plugin = _wasm_plugin(tmp_path, capabilities=["sources"])
+
+    check_imports(plugin, [("hydra", "log"), ("hydra", "read_source")])
+    with pytest.raises(PluginError, match="without the 'llm' capability"):
+        check_imports(plugin, [("hydra", "llm")])
+    with pytest.raises(PluginError, match="imports wasi_snapshot_preview1.fd_write"):
+        check_imports(plugin, [("wasi_snapshot_preview1", "fd_write")])
+
+
+def test_the_llm_host_function_goes_through_the_run_model():
+    llm = Mock(spec=GatewayLLM)
+    llm.complete.return_value = {"choices": [{"message": {"content": "Tighter bullet"}}]}
+    host = WasmHost(llm)
+    messages = [{"role": "user", "content": "Tighten: ran things", "extra": 1}]
+
+    assert host.call_llm({"messages": messages}) == {"content": "Tighter bullet"}
+    llm.complete.assert_called_once_with([{"role": "user", "content": "Tighten: ran things"}])
+    assert "expected" in host.call_llm({"messages": [{"role": "tool", "content": "x"}]})["error"]
+    assert WasmHost().call_llm({"messages": messages}) == {
+        "error": "no model is configured for plugins"
+    }
+    llm.complete.side_effect = RuntimeError("quota")
+    assert host.call_llm({"messages": messages}) == {"error": "model call failed: quota"}
+    assert MAX_LLM_CALLS > 0
+
+
+def test_sources_are_read_only_inside_the_sources_directory(tmp_path):
+    (tmp_path / "sources").mkdir()
+    (tmp_path / "sources" / "wins.md").write_text("Cut costs 30%")
+    (tmp_path / "secret.txt").write_text("no")
+    host = WasmHost(sources_dir=tmp_path / "sources")
+
+    assert host.read_source("wins.md") == {"content": "Cut costs 30%"}
+    assert host.read_source(".") == {"content": ["wins.md"]}
+    assert host.read_source("../secret.txt") == {"error": "path is outside the sources directory"}
+    assert WasmHost().read_source("wins.md") == {"error": "no sources directory"}
+
+
+def test_without_wasmtime_a_wasm_plugin_fails_with_how_to_install_it(tmp_path, monkeypatch):
+    monkeypatch.setitem(sys.modules, "wasmtime", None)
+
+    with pytest.raises(PluginError, match="pip install wasmtime"):
+        run_plugin(_wasm_plugin(tmp_path), {}, {})
+
+
+def test_a_wasm_module_answers_in_the_sandbox(tmp_path):
+    pytest.importorskip("wasmtime")
+    escaped = RESPONSE.replace('"', '\\"')
+    source = ANSWERING_MODULE.replace("{response}", escaped).replace(
+        "{length}", str(len(RESPONSE))
+    )
+
+    output, log = run_plugin(_wasm_plugin(tmp_path, source), {}, {"tailoring": {}})
+
+    assert output == {"polished": 3}
+    assert log == ["hello", "from the answer"]
+
+
+def test_a_wasm_module_is_stopped_at_its_timeout(tmp_path):
+    pytest.importorskip("wasmtime")
+
+    with pytest.raises(PluginError, match="did not answer within 0.2s"):
+        run_plugin(_wasm_plugin(tmp_path, LOOPING_MODULE, timeout=0.2), {}, {})
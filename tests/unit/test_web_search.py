@@ -0,0 +1,69 @@
+"""
+Unit tests for the pluggable web search providers.
+"""
+
+import io
+import json
+from unittest.mock import patch
+
+import pytest
+
+from runtime.crewai.web_search import (
+    BraveProvider,
+    SearchError,
+    SerpAPIProvider,
+    TavilyProvider,
+    get_search_provider,
+)
+
+
+def _respond(body):
+    return patch(
+        "urllib.request.urlopen", return_value=io.BytesIO(json.dumps(body).encode("utf-8"))
+    )
+
+
+def test_tavily_results_are_normalised():
+    body = {"results": [{"title": "T", "url": "https://t", "content": "c", "published_date": "d"}]}
+    with _respond(body) as urlopen:
+        results = TavilyProvider(api_key="k").search("acme", max_results=3)
+
+    request = urlopen.call_args[0][0]
+    assert request.get_header("Authorization") == "Bearer k"
+    assert json.loads(request.data) == {"query": "acme", "max_results": 3}
+    assert results[0].to_dict() == {
+        "title": "T",
+        "url": "https://t",
+        "snippet": "c",
+        "published": "d",
+    }
+
+
+def test_serpapi_and_brave_shapes():
+    serp = {"organic_results": [{"title": "S", "link": "https://s", "snippet": "x"}]}
+    brave = {"web": {"results": [{"title": "B", "url": "https://b", "description": "y"}]}}
+    with _respond(serp):
+        assert SerpAPIProvider(api_key="k").search("acme")[0].url == "https://s"
+    with _respond(brave) as urlopen:
+        assert BraveProvider(api_key="k").search("acme")[0].snippet == "y"
+    assert urlopen.call_args[0][0].get_header("X-subscription-token") == "k"
+
+
+def test_transport_failures_become_search_errors():
+    with patch("urllib.request.urlopen", side_effect=OSError("timed out")):
+        with pytest.raises(SearchError, match="brave search failed"):
+            BraveProvider(api_key="k").search("acme")
+
+
+def test_provider_selection(monkeypatch):
+    for key in ("TAVILY_API_KEY", "SERPAPI_API_KEY", "BRAVE_SEARCH_API_KEY"):
+        monkeypatch.delenv(key, raising=False)
+    with pytest.raises(SearchError, match="No search provider configured"):
+        get_search_provider()
+
+    monkeypatch.setenv("BRAVE_SEARCH_API_KEY", "k")
+    assert isinstance(get_search_provider(), BraveProvider)
+    with pytest.raises(SearchError, match="TAVILY_API_KEY not set"):
+        get_search_provider("tavily")
+    with pytest.raises(SearchError, match="Unknown search provider"):
+        get_search_provider("bing")
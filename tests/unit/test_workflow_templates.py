@@ -0,0 +1,172 @@
+"""
+Unit tests for workflow templates: which stages a run goes through.
+"""
+
+import json
+
+import pytest
+
+from runtime.crewai import cli
+from runtime.crewai.artifacts import MANIFEST_FILE
+from runtime.crewai.hydra_workflow import RunStatus
+from runtime.crewai.pipeline_config import PipelineConfig, PipelineConfigError
+from runtime.crewai.run_control import resume_arguments
+from runtime.crewai.workflow_templates import BUILTIN_TEMPLATES, TemplatePolicy
+from tests.conftest import patched_workflow
+
+CONTEXT = {"job_description": "JD", "resume": "Resume", "source_documents": "Sources"}
+
+
+def test_builtin_templates():
+    quick, thorough, referral = (BUILTIN_TEMPLATES[n] for n in ("quick", "thorough", "referral"))
+
+    assert quick.stages == ("research", "tailoring", "ats_optimization", "audit")
+    assert quick.runs("auditor_suite") and not quick.runs("gap_analysis")
+    assert len(thorough.stages) == 8 and not thorough.runs("outreach")
+    assert referral.stages == (*thorough.stages, "outreach")
+    assert quick.skipped() == [
+        "gap_analysis",
+        "seniority",
+        "impact",
+        "interrogation",
+        "differentiation",
+        "executive_synthesis",
+        "outreach",
+    ]
+
+
+def test_users_define_their_own_in_the_config():
+    config = PipelineConfig.from_dict(
+        {
+            "templates": {
+                "fast": ["ats_optimization", "tailoring"],
+                "networking": {
+                    "extends": "quick",
+                    "stages": ["outreach"],
+                    "description": "Tailor fast, then reach out",
+                },
+                "quick": ["tailoring"],
+            }
+        }
+    )
+    templates = config.templates
+
+    # Listed or not, tailoring and the audit run; stages run in pipeline order.
+    assert templates.get("fast").stages == ("tailoring", "ats_optimization", "audit")
+    networking = templates.get("networking")
+    assert networking.stages == ("research", "tailoring", "ats_optimization", "audit", "outreach")
+    assert networking.description == "Tailor fast, then reach out"
+    assert templates.get("quick").stages == ("tailoring", "audit")  # replaces the built-in
+    assert list(templates.available())[:3] == ["quick", "thorough", "referral"]
+    fast = config.to_dict()["templates"]["fast"]
+    assert fast["stages"] == ["tailoring", "ats_optimization", "audit"]
+    with pytest.raises(ValueError, match="available: quick, thorough, referral"):
+        TemplatePolicy().get("sloppy")
+
+
+@pytest.mark.parametrize(
+    "templates, message",
+    [
+        ({"fast": ["polishing"]}, "unknown stage"),
+        ({"fast": "tailoring"}, "list of stages or a mapping"),
+        ({"fast": {"extends": "speedy"}}, "extends unknown template"),
+        ({"fast": {"stage": ["tailoring"]}}, "unknown setting"),
+        ({"fast": {"stages": "tailoring"}}, "list of stage names"),
+    ],
+)
+def test_bad_templates_are_config_errors(templates, message):
+    with pytest.raises(PipelineConfigError, match=message):
+        PipelineConfig.from_dict({"templates": templates})
+
+
+def _workflow(template):
+    workflow = patched_workflow(
+        auto_approve=True, pipeline_config=PipelineConfig(), template=BUILTIN_TEMPLATES[template]
+    )
+    workflow.gap_analyzer.execute.return_value = {"gaps": [], "confidence": 0.9}
+    workflow.interrogator_prepper.execute.return_value = {"questions": [], "confidence": 0.9}
+    workflow.differentiator.execute.return_value = {"differentiators": ["Go"], "confidence": 0.9}
+    workflow.tailoring_agent.execute.return_value = {
+        "tailored_resume": "Resume",
+        "tailored_cover_letter": "Letter",
+        "confidence": 0.9,
+    }
+    workflow.ats_optimizer.execute.return_value = {"optimized_resume": "R", "confidence": 0.9}
+    workflow.auditor_suite.execute.return_value = {
+        "approval": {"approved": True},
+        "confidence": 0.9,
+    }
+    workflow.executive_synthesizer.execute.return_value = {"confidence": 0.9}
+    workflow.outreach_agent.execute.return_value = {
+        "recipient": "hiring manager",
+        "variants": {"email": {"subject": "Platform role", "message": "Hi, I applied."}},
+        "confidence": 0.9,
+    }
+    return workflow
+
+
+def test_quick_skips_everything_but_tailoring_ats_and_audit():
+    workflow = _workflow("quick")
+
+    result = workflow.execute(CONTEXT)
+
+    assert result.status is RunStatus.COMPLETED
+    for agent in (
+        workflow.gap_analyzer,
+        workflow.interrogator_prepper,
+        workflow.differentiator,
+        workflow.executive_synthesizer,
+        workflow.outreach_agent,
+    ):
+        agent.execute.assert_not_called()
+    workflow.ats_optimizer.execute.assert_called_once()
+    assert workflow.auditor_suite.execute.called
+    assert result.executive_brief is None and result.outreach is None
+    assert result.template["name"] == "quick"
+    assert any("Template quick: skipping gap_analysis" in line for line in result.execution_log)
+
+
+def test_referral_ends_with_outreach_messages():
+    workflow = _workflow("referral")
+
+    result = workflow.execute(CONTEXT)
+
+    assert result.outreach["variants"]["email"]["message"] == "Hi, I applied."
+    outreach_context = workflow.outreach_agent.execute.call_args.args[0]
+    assert outreach_context["tailored_resume"] == "R"  # the audited, ATS-optimised résumé
+    assert outreach_context["differentiation"]["differentiators"] == ["Go"]
+    log = result.execution_log
+    assert any("Outreach: 1 message(s) to the hiring manager" in line for line in log)
+    assert result.intermediate_results["outreach"] == result.outreach
+
+
+def test_a_failed_outreach_does_not_fail_the_run():
+    workflow = _workflow("referral")
+    workflow.outreach_agent.execute.side_effect = RuntimeError("provider down")
+
+    result = workflow.execute(CONTEXT)
+
+    assert result.status is RunStatus.COMPLETED and result.outreach is None
+    assert any("Outreach failed" in line for line in result.execution_log)
+
+
+def test_templates_command_and_resume(tmp_path, capsys):
+    config = tmp_path / "pipeline.yaml"
+    config.write_text("templates:\n  fast: [tailoring]\n")
+
+    assert cli.main(["templates", "--pipeline-config", str(config)]) == 0
+    out = capsys.readouterr().out
+    assert "thorough" in out and "(default)" in out
+    assert "fast" in out and "[pipeline config]" in out and "tailoring → audit" in out
+
+    (tmp_path / MANIFEST_FILE).write_text(
+        json.dumps(
+            {
+                "status": "paused",
+                "inputs": {"jd_path": "jd.md", "resume_path": "resume.md"},
+                "template": BUILTIN_TEMPLATES["referral"].to_dict(),
+            }
+        )
+    )
+    args = resume_arguments(tmp_path)
+    assert args[args.index("--template") + 1] == "referral"
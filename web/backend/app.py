@@ -1,6 +1,8 @@
 """Litestar application for Hydra web API."""
 
+import asyncio
 import logging
+import os
 from pathlib import Path
 
 # Load environment variables from .env file BEFORE any other imports
@@ -26,11 +28,23 @@ from litestar.logging import LoggingConfig
 from litestar.middleware.base import MiddlewareProtocol
 from litestar.types import ASGIApp, Receive, Scope, Send
 
+from web.backend.auth import ApiKeyMiddleware, AuthConfigError, configured_keys
 from web.backend.db import apply_migrations
 from web.backend.observability.sentry import setup_sentry
+from web.backend.routes.admin import AdminController
 from web.backend.routes.health import HealthController
-from web.backend.routes.jobs import JobsController
+from web.backend.routes.jobs import JobsController, LegacyJobsController
+from web.backend.routes.workflows import WorkflowsController
+from web.backend.services.cleanup import start_cleanup
+from web.backend.services.drain import drain
+from web.backend.services.storage import STORE_ENV, apply_lifecycle_from_env
+from web.backend.services.workflow_runner import (
+    resume_interrupted_jobs,
+    start_workers,
+    stop_workers,
+)
 from web.backend.telemetry import get_tracer, init_telemetry, shutdown_telemetry
+from web.backend.versioning import ApiVersionMiddleware
 
 # Configure logging
 logging_config = LoggingConfig(
@@ -92,19 +106,74 @@ class TelemetryMiddleware(MiddlewareProtocol):
                 raise
 
 
+# The gRPC server, when HYDRA_GRPC_PORT is set (see web/backend/grpc_api).
+_grpc_server = None
+# The scheduled cleanup, when a retention period is set (see services/cleanup.py).
+_cleanup_task = None
+
+
 async def on_startup() -> None:
-    """Initialize telemetry, Sentry, and database on application startup."""
+    """Initialize telemetry, Sentry, database and the optional gRPC API on startup.
+
+    Also sets the artifact bucket's lifecycle rule (see services/storage.py), installs
+    the SIGTERM drain (see services/drain.py), opens the work queue and starts this
+    server's workers (services/work_queue.py; a malformed HYDRA_WORK_QUEUE stops the
+    startup) and resumes the jobs the previous server checkpointed while draining,
+    or that a dead instance left leased (services/leases.py). Then starts the
+    scheduled cleanup of expired runs (services/cleanup.py).
+    """
+    global _grpc_server, _cleanup_task
     init_telemetry()
     setup_sentry()
+    # A malformed HYDRA_API_KEYS fails closed: every API request errors until fixed.
+    try:
+        users = len(configured_keys())
+        if users:
+            logging.info("Multi-user mode: %d API users", users)
+    except AuthConfigError as exc:
+        logging.error("API keys not loaded: %s", exc)
     # Apply database migrations
     try:
         apply_migrations()
     except Exception as exc:
         logging.error("Database migrations failed: %s", exc)
 
+    try:
+        if apply_lifecycle_from_env():
+            logging.info("Artifact lifecycle rule set on %s", os.environ[STORE_ENV])
+    except Exception as exc:
+        logging.error("Artifact lifecycle rule not set: %s", exc)
+
+    drain.reset()
+    drain.install_signal_handler(asyncio.get_running_loop())
+    pool = start_workers()
+    logging.info("Work queue %s, %d worker(s)", type(pool.queue).__name__, pool.size)
+    try:
+        resume_interrupted_jobs()
+    except Exception as exc:
+        logging.error("Resuming interrupted jobs failed: %s", exc)
+    _cleanup_task = start_cleanup()
+
+    grpc_port = os.environ.get("HYDRA_GRPC_PORT")
+    if grpc_port:
+        try:
+            # Imported here: grpcio is only needed when the gRPC API is enabled.
+            from web.backend.grpc_api.server import start_grpc_server
+
+            _grpc_server = await start_grpc_server(int(grpc_port))
+        except Exception as exc:
+            logging.error("gRPC API not started: %s", exc)
+
 
 async def on_shutdown() -> None:
-    """Shutdown telemetry on application shutdown."""
+    """Drain in-flight runs, stop the workers and the gRPC API, and shut down telemetry."""
+    # Already done when SIGTERM started the shutdown; this covers Ctrl-C and reloads.
+    await drain.drain()
+    await stop_workers()
+    if _cleanup_task is not None:
+        _cleanup_task.cancel()
+    if _grpc_server is not None:
+        await _grpc_server.stop(grace=5)
     shutdown_telemetry()
 
 # Configure CORS for local development
@@ -113,14 +182,22 @@ cors_config = CORSConfig(
     allow_methods=["GET", "POST", "PUT", "DELETE", "OPTIONS"],
     allow_headers=["*"],
     allow_credentials=True,
+    # Let browser clients read the versioning headers (see versioning.py).
+    expose_headers=["API-Version", "Deprecation", "Sunset", "Link"],
 )
 
 # Create Litestar app
 app = Litestar(
-    route_handlers=[HealthController, JobsController],
+    route_handlers=[
+        HealthController,
+        JobsController,
+        LegacyJobsController,
+        WorkflowsController,
+        AdminController,
+    ],
     cors_config=cors_config,
     logging_config=logging_config,
-    middleware=[TelemetryMiddleware],
+    middleware=[TelemetryMiddleware, ApiVersionMiddleware, ApiKeyMiddleware],
     on_startup=[on_startup],
     on_shutdown=[on_shutdown],
     debug=True,
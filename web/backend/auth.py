@@ -0,0 +1,214 @@
+"""API tokens for a shared server: who is calling, what they see, what they may spend.
+
+Off by default: with no tokens configured the API stays open, as it always was for a
+single user on localhost. Setting ``HYDRA_API_KEYS`` turns on multi-user mode:
+
+    HYDRA_API_KEYS="alice:3f9c...e1:25,bob:77ab...90"
+
+Each entry is ``user:token`` with an optional monthly budget in USD. Then:
+
+- Every ``/api`` request needs ``Authorization: Bearer <token>`` (or ``X-API-Key``);
+  anything else is a 401. ``/health`` stays open, and CORS preflights pass through.
+  gRPC calls send the same header as metadata (UNAUTHENTICATED without it).
+- A job belongs to the user who created it. Another user's job — or one created
+  before auth was switched on — is a 404, exactly like a job that does not exist.
+- Each user's artifacts are written under their own prefix of HYDRA_ARTIFACTS_DIR, or
+  of the bucket prefix with object storage (services/storage.py).
+- A user whose runs this calendar month (UTC) have cost their budget cannot start
+  or resume a run (402; RESOURCE_EXHAUSTED over gRPC). ``HYDRA_USER_BUDGET_USD``
+  is the budget for users without one of their own. The check happens before a run
+  starts: a run in flight is never stopped half-way for its spend.
+
+``HYDRA_ADMIN_USERS`` (comma-separated names from ``HYDRA_API_KEYS``) lists the
+users who may call the admin API (routes/admin.py), which sees every user's jobs;
+anyone else gets 403 there. With auth off the admin API is open like the rest.
+
+Tokens are compared by SHA-256 digest in constant time, so a timing difference does
+not leak how much of a guessed token was right.
+"""
+
+from __future__ import annotations
+
+import hashlib
+import hmac
+import json
+import os
+import re
+from dataclasses import dataclass
+from datetime import datetime, timezone
+from typing import Iterable, Optional
+
+from litestar.middleware.base import MiddlewareProtocol
+from litestar.types import ASGIApp, Receive, Scope, Send
+
+API_KEYS_ENV = "HYDRA_API_KEYS"
+USER_BUDGET_ENV = "HYDRA_USER_BUDGET_USD"
+ADMIN_USERS_ENV = "HYDRA_ADMIN_USERS"
+
+_USER_NAME_RE = re.compile(r"^[A-Za-z0-9][A-Za-z0-9_.-]*$")
+
+
+class AuthConfigError(ValueError):
+    """HYDRA_API_KEYS is malformed."""
+
+
+@dataclass(frozen=True)
+class ApiUser:
+    """A caller identified by their API token."""
+
+    name: str
+    budget_usd: Optional[float] = None
+
+
+def storage_prefix(owner: str) -> str:
+    """The directory (or key prefix) the artifacts of ``owner``'s jobs go in."""
+    return owner.lower()
+
+
+def _digest(token: str) -> bytes:
+    return hashlib.sha256(token.encode("utf-8")).digest()
+
+
+def parse_api_keys(
+    raw: str, default_budget: Optional[float] = None
+) -> list[tuple[bytes, ApiUser]]:
+    """``(token digest, user)`` for each ``user:token[:budget]`` entry of ``raw``."""
+    keys: list[tuple[bytes, ApiUser]] = []
+    names: set[str] = set()
+    for entry in filter(None, (part.strip() for part in raw.split(","))):
+        name, _, rest = entry.partition(":")
+        token, _, budget = rest.partition(":")
+        name, token, budget = name.strip(), token.strip(), budget.strip()
+        if not _USER_NAME_RE.match(name) or not token:
+            raise AuthConfigError(f"{API_KEYS_ENV}: expected user:token[:budget], got {name!r}")
+        if name.lower() in names:
+            raise AuthConfigError(f"{API_KEYS_ENV}: user {name!r} is listed twice")
+        try:
+            budget_usd = float(budget) if budget else default_budget
+        except ValueError as e:
+            raise AuthConfigError(f"{API_KEYS_ENV}: bad budget for {name!r}: {budget!r}") from e
+        names.add(name.lower())
+        keys.append((_digest(token), ApiUser(name, budget_usd)))
+    return keys
+
+
+def configured_keys() -> list[tuple[bytes, ApiUser]]:
+    """The API keys from the environment; [] when auth is off."""
+    default = os.environ.get(USER_BUDGET_ENV, "").strip()
+    try:
+        default_budget = float(default) if default else None
+    except ValueError as e:
+        raise AuthConfigError(f"{USER_BUDGET_ENV}: not a number: {default!r}") from e
+    return parse_api_keys(os.environ.get(API_KEYS_ENV, ""), default_budget)
+
+
+def auth_enabled() -> bool:
+    return bool(os.environ.get(API_KEYS_ENV, "").strip())
+
+
+def authenticate(
+    token: Optional[str], keys: Iterable[tuple[bytes, ApiUser]]
+) -> Optional[ApiUser]:
+    """The user ``token`` belongs to, or None."""
+    if not token:
+        return None
+    digest = _digest(token)
+    user = None
+    for known, candidate in keys:
+        # No early exit: every key is compared, whichever one matches.
+        if hmac.compare_digest(digest, known):
+            user = candidate
+    return user
+
+
+def token_from_headers(headers: Iterable[tuple[str, str]]) -> Optional[str]:
+    """The token in an ``Authorization: Bearer`` or ``X-API-Key`` header."""
+    for name, value in headers:
+        name = name.lower()
+        if name == "authorization":
+            scheme, _, token = value.partition(" ")
+            if scheme.lower() == "bearer" and token.strip():
+                return token.strip()
+        elif name == "x-api-key" and value.strip():
+            return value.strip()
+    return None
+
+
+def can_access(user: Optional[ApiUser], owner: Optional[str]) -> bool:
+    """Whether ``user`` may see a job owned by ``owner`` (always, with auth off)."""
+    return user is None or owner == user.name
+
+
+def is_admin(user: Optional[ApiUser]) -> bool:
+    """Whether ``user`` may use the admin API (anyone, with auth off)."""
+    if user is None:
+        return True
+    admins = {name.strip().lower() for name in os.environ.get(ADMIN_USERS_ENV, "").split(",")}
+    return user.name.lower() in admins
+
+
+def month_start(now: Optional[datetime] = None) -> datetime:
+    """The start of the current budget period: this calendar month, UTC."""
+    now = (now or datetime.now(timezone.utc)).astimezone(timezone.utc)
+    return now.replace(day=1, hour=0, minute=0, second=0, microsecond=0)
+
+
+def over_budget(user: Optional[ApiUser], spent_usd: float) -> Optional[str]:
+    """Why ``user`` may not start a run, having spent ``spent_usd`` this month; else None."""
+    if user is None or user.budget_usd is None or spent_usd < user.budget_usd:
+        return None
+    return (
+        f"Monthly budget of ${user.budget_usd:.2f} reached "
+        f"(${spent_usd:.2f} spent since {month_start():%Y-%m-%d})"
+    )
+
+
+def _is_protected(path: str) -> bool:
+    return path == "/api" or path.startswith("/api/")
+
+
+class ApiKeyMiddleware(MiddlewareProtocol):
+    """ASGI middleware resolving the API token to ``scope["user"]``; 401 without one.
+
+    ``scope["user"]`` is the ApiUser, or None when auth is off.
+    """
+
+    def __init__(self, app: ASGIApp) -> None:
+        self.app = app
+
+    async def __call__(self, scope: Scope, receive: Receive, send: Send) -> None:
+        if scope["type"] != "http":
+            await self.app(scope, receive, send)
+            return
+        scope["user"] = None
+        if (
+            not auth_enabled()
+            or not _is_protected(scope.get("path", "/"))
+            or scope.get("method") == "OPTIONS"
+        ):
+            await self.app(scope, receive, send)
+            return
+
+        headers = [(k.decode("latin-1"), v.decode("latin-1")) for k, v in scope["headers"]]
+        user = authenticate(token_from_headers(headers), configured_keys())
+        if user is None:
+            await _unauthorized(send)
+            return
+        scope["user"] = user
+        await self.app(scope, receive, send)
+
+
+async def _unauthorized(send: Send) -> None:
+    body = json.dumps({"status_code": 401, "detail": "Missing or invalid API token"}).encode()
+    await send(
+        {
+            "type": "http.response.start",
+            "status": 401,
+            "headers": [
+                (b"content-type", b"application/json"),
+                (b"content-length", str(len(body)).encode()),
+                (b"www-authenticate", b'Bearer realm="hydra"'),
+            ],
+        }
+    )
+    await send({"type": "http.response.body", "body": body})
@@ -0,0 +1,4 @@
+-- Human-in-the-loop gates persist which input a paused job is waiting for, so the
+-- second half of a run can resume after a server restart.
+ALTER TABLE job_queue ADD COLUMN IF NOT EXISTS awaiting_user TEXT;
+ALTER TABLE job_queue ADD COLUMN IF NOT EXISTS greenlight_notes TEXT;
@@ -0,0 +1,3 @@
+-- Schema version of intermediate_results (runtime/crewai/state_schema.py). Rows from
+-- before versioning stay NULL and are upgraded from version 1 when loaded.
+ALTER TABLE job_queue ADD COLUMN IF NOT EXISTS state_version INTEGER;
@@ -0,0 +1,6 @@
+-- Multi-user server mode (web/backend/auth.py): the API user a job belongs to, and
+-- what its model calls have cost so far, for per-user monthly budgets. Jobs created
+-- with auth off stay NULL-owned.
+ALTER TABLE job_queue ADD COLUMN IF NOT EXISTS owner TEXT;
+ALTER TABLE job_queue ADD COLUMN IF NOT EXISTS cost_usd DOUBLE PRECISION NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS job_queue_owner_created_at ON job_queue (owner, created_at);
@@ -0,0 +1,8 @@
+-- Leases for multi-instance deployments (web/backend/services/leases.py): the server
+-- instance running a job's workflow, until when, and a version bumped on every new
+-- lease so writes from an instance that lost it are refused. stop_requested carries
+-- a pause or cancel to the instance holding the lease.
+ALTER TABLE job_queue ADD COLUMN IF NOT EXISTS lease_owner TEXT;
+ALTER TABLE job_queue ADD COLUMN IF NOT EXISTS lease_expires_at TIMESTAMPTZ;
+ALTER TABLE job_queue ADD COLUMN IF NOT EXISTS lease_version INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE job_queue ADD COLUMN IF NOT EXISTS stop_requested TEXT;
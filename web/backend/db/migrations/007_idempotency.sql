@@ -0,0 +1,8 @@
+-- Idempotent job creation (web/backend/services/idempotency.py): the caller's
+-- Idempotency-Key, unique per owner, and a hash of what the job runs on, to find a
+-- recent identical request.
+ALTER TABLE job_queue ADD COLUMN IF NOT EXISTS idempotency_key TEXT;
+ALTER TABLE job_queue ADD COLUMN IF NOT EXISTS request_hash TEXT;
+CREATE UNIQUE INDEX IF NOT EXISTS job_queue_owner_idempotency_key
+    ON job_queue (COALESCE(owner, ''), idempotency_key) WHERE idempotency_key IS NOT NULL;
+CREATE INDEX IF NOT EXISTS job_queue_request_hash ON job_queue (request_hash, created_at);
@@ -0,0 +1,4 @@
+-- Admin API (web/backend/routes/admin.py): when a finished job's documents were
+-- purged under the retention policy, and an index for finding the jobs to purge.
+ALTER TABLE job_queue ADD COLUMN IF NOT EXISTS artifacts_purged_at TIMESTAMPTZ;
+CREATE INDEX IF NOT EXISTS job_queue_state_completed_at ON job_queue (state, completed_at);
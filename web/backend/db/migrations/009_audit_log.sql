@@ -0,0 +1,24 @@
+-- Audit log (web/backend/services/audit_log.py): decisions, provider calls and document
+-- access, per job. Rows can only be added: a trigger refuses UPDATE and DELETE, and
+-- job_id is not a foreign key, so the log outlives the jobs the retention policy deletes.
+CREATE TABLE IF NOT EXISTS audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    job_id TEXT,
+    actor TEXT,
+    action TEXT NOT NULL,
+    details JSONB NOT NULL DEFAULT '{}'::jsonb
+);
+CREATE INDEX IF NOT EXISTS audit_log_job_id ON audit_log (job_id, id);
+CREATE INDEX IF NOT EXISTS audit_log_at ON audit_log (at);
+
+CREATE OR REPLACE FUNCTION audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_log_append_only ON audit_log;
+CREATE TRIGGER audit_log_append_only
+    BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION audit_log_append_only();
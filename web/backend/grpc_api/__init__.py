@@ -0,0 +1 @@
+"""gRPC API (optional): hydra_pb2*.py are generated by proto/generate.sh."""
@@ -0,0 +1,351 @@
+"""gRPC API for services that embed Hydra (see proto/hydra/v1/hydra.proto).
+
+Serves the same jobs as the REST API (routes/jobs.py) from inside the backend's event
+loop: workflows start on the same background runner and emit the same events, so a
+workflow created over gRPC shows up in the web UI and vice versa. Started by
+``app.on_startup`` when HYDRA_GRPC_PORT is set.
+
+With API keys configured (web/backend/auth.py) every call carries the REST API's
+``authorization: Bearer <token>`` as metadata, and sees only its own workflows.
+"""
+
+from __future__ import annotations
+
+import json
+import logging
+from datetime import datetime
+from typing import Any, AsyncIterator, Optional
+
+import grpc
+from pydantic import ValidationError
+
+from runtime.crewai.audit_log import GREENLIGHT, INTERVIEW_ANSWERS
+from web.backend.auth import (
+    ApiUser,
+    auth_enabled,
+    authenticate,
+    can_access,
+    configured_keys,
+    token_from_headers,
+)
+from web.backend.grpc_api import hydra_pb2, hydra_pb2_grpc
+from web.backend.models import AwaitingInput, CreateJobRequest, JobState
+from web.backend.routes.jobs import _is_after_state, budget_refusal
+from web.backend.services.audit_log import (
+    CANCELLED,
+    JOB_CREATED,
+    PAUSED,
+    RESUMED,
+    audit_log,
+)
+from web.backend.services.drain import drain
+from web.backend.services.idempotency import (
+    IDEMPOTENCY_HEADER,
+    IdempotencyError,
+    check_key,
+    create_once,
+    find_existing,
+    request_hash,
+)
+from web.backend.services.job_control import (
+    JobControlError,
+    cancel_job,
+    pause_job,
+    resume_job,
+)
+from web.backend.services.job_queue import Job, job_queue
+from web.backend.services.workflow_runner import start_workflow_background
+
+GRPC_PORT_ENV = "HYDRA_GRPC_PORT"
+
+logger = logging.getLogger(__name__)
+
+_STATES = {state: hydra_pb2.State.Value(f"STATE_{state.name}") for state in JobState}
+_AWAITING = {
+    AwaitingInput.GREENLIGHT.value: hydra_pb2.AWAITING_INPUT_GREENLIGHT,
+    AwaitingInput.INTERVIEW_ANSWERS.value: hydra_pb2.AWAITING_INPUT_INTERVIEW_ANSWERS,
+}
+
+
+def _timestamp(value: Optional[datetime]) -> str:
+    return value.isoformat() if value else ""
+
+
+def _json(value: Any) -> str:
+    return json.dumps(value, default=str) if value else ""
+
+
+def workflow_message(job: Job) -> hydra_pb2.Workflow:
+    """A job as the gRPC ``Workflow`` message (the REST JobResponse, field for field)."""
+    documents = job.final_documents or {}
+    return hydra_pb2.Workflow(
+        workflow_id=job.id,
+        state=_STATES.get(job.state, hydra_pb2.STATE_UNSPECIFIED),
+        success=job.success,
+        progress_percent=job.get_progress_percent(),
+        awaiting_input=_AWAITING.get(job.awaiting_user, hydra_pb2.AWAITING_INPUT_UNSPECIFIED),
+        created_at=_timestamp(job.created_at),
+        started_at=_timestamp(job.started_at),
+        completed_at=_timestamp(job.completed_at),
+        final_documents=(
+            hydra_pb2.Documents(
+                resume=documents.get("resume", ""),
+                cover_letter=documents.get("cover_letter", ""),
+            )
+            if job.final_documents
+            else None
+        ),
+        audit_status=(job.audit_report or {}).get("final_status") or "",
+        audit_failed=job.audit_failed,
+        audit_error=job.audit_error or "",
+        error_message=job.error_message or "",
+        agent_models=job.agent_models or {},
+        intermediate_results_json=_json(job.intermediate_results),
+        executive_brief_json=_json(job.executive_brief),
+    )
+
+
+def _event(event_type: str, data: dict[str, Any]) -> hydra_pb2.Event:
+    return hydra_pb2.Event(type=event_type, data_json=json.dumps(data, default=str))
+
+
+class HydraServicer(hydra_pb2_grpc.HydraServicer):
+    """The REST job endpoints, over gRPC."""
+
+    async def _caller(self, context: grpc.aio.ServicerContext) -> Optional[ApiUser]:
+        """The API user the call's token belongs to (None with auth off); else UNAUTHENTICATED."""
+        if not auth_enabled():
+            return None
+        metadata = [(key, value) for key, value in context.invocation_metadata() or ()]
+        user = authenticate(token_from_headers(metadata), configured_keys())
+        if user is None:
+            await context.abort(grpc.StatusCode.UNAUTHENTICATED, "Missing or invalid API token")
+        return user
+
+    async def _job(self, workflow_id: str, context: grpc.aio.ServicerContext) -> Job:
+        user = await self._caller(context)
+        job = job_queue.get_job(workflow_id) if workflow_id else None
+        if not job or not can_access(user, job.owner):
+            await context.abort(grpc.StatusCode.NOT_FOUND, "Workflow not found")
+        return job
+
+    async def _actor(self, context: grpc.aio.ServicerContext) -> Optional[str]:
+        """The caller's name, for the audit log (services/audit_log.py)."""
+        user = await self._caller(context)
+        return user.name if user else None
+
+    async def _within_budget(self, context: grpc.aio.ServicerContext) -> None:
+        """RESOURCE_EXHAUSTED once the caller's monthly budget is spent (the REST API's 402)."""
+        reason = budget_refusal(await self._caller(context))
+        if reason:
+            await context.abort(grpc.StatusCode.RESOURCE_EXHAUSTED, reason)
+
+    async def _accepting_runs(self, context: grpc.aio.ServicerContext) -> None:
+        """UNAVAILABLE while the server drains for a restart (the REST API's 503)."""
+        if drain.draining:
+            await context.abort(grpc.StatusCode.UNAVAILABLE, "Server is restarting; retry shortly")
+
+    async def _paused_at(
+        self, job: Job, state: JobState, label: str, context: grpc.aio.ServicerContext
+    ) -> Optional[hydra_pb2.SubmitResponse]:
+        """None if ``job`` is paused at ``state``; a no-op reply if it has moved on."""
+        if job.state == state:
+            return None
+        if _is_after_state(job.state, state):
+            return hydra_pb2.SubmitResponse(
+                workflow_id=job.id, status="noop", message=f"Workflow already advanced past {label}"
+            )
+        await context.abort(
+            grpc.StatusCode.FAILED_PRECONDITION,
+            f"Workflow is not awaiting {label} (current: {job.state.value})",
+        )
+
+    async def CreateWorkflow(self, request, context):
+        await self._accepting_runs(context)
+        user = await self._caller(context)
+        owner = user.name if user else None
+        metadata = dict(context.invocation_metadata() or ())
+        try:
+            key = check_key(metadata.get(IDEMPOTENCY_HEADER.lower()))
+        except IdempotencyError as e:
+            await context.abort(grpc.StatusCode.INVALID_ARGUMENT, str(e))
+        try:
+            # The REST request model, so both APIs validate input the same way.
+            data = CreateJobRequest(
+                job_description=request.job_description,
+                resume=request.resume,
+                source_documents=request.source_documents,
+                company=request.company or None,
+                role_title=request.role_title or None,
+                source=request.source or None,
+                url=request.url or None,
+                model=request.model or None,
+                max_audit_retries=(
+                    request.max_audit_retries if request.HasField("max_audit_retries") else 2
+                ),
+            )
+        except ValidationError as e:
+            error = e.errors()[0]
+            field = ".".join(str(part) for part in error["loc"])
+            await context.abort(grpc.StatusCode.INVALID_ARGUMENT, f"{field}: {error['msg']}")
+
+        digest = request_hash(
+            data.job_description,
+            data.resume,
+            data.source_documents,
+            data.model,
+            data.max_audit_retries,
+        )
+        # A repeat of an earlier request answers with its workflow (services/idempotency.py).
+        try:
+            job, created = find_existing(owner, key, digest), False
+        except IdempotencyError as e:
+            await context.abort(grpc.StatusCode.INVALID_ARGUMENT, str(e))
+        if job is None:
+            await self._within_budget(context)
+            job, created = create_once(
+                owner,
+                key,
+                digest,
+                lambda: job_queue.create_job(
+                    **data.model_dump(), owner=owner, idempotency_key=key, request_hash=digest
+                ),
+            )
+        if created:
+            audit_log.record(JOB_CREATED, job.id, owner, via="grpc")
+            start_workflow_background(job)
+        return hydra_pb2.CreateWorkflowResponse(
+            workflow_id=job.id,
+            status="queued" if created else "duplicate",
+            created_at=_timestamp(job.created_at),
+        )
+
+    async def GetState(self, request, context):
+        job = await self._job(request.workflow_id, context)
+        audit_log.record_access(job, await self._actor(context), via="grpc")
+        return workflow_message(job)
+
+    async def SubmitGreenlight(self, request, context):
+        await self._accepting_runs(context)
+        job = await self._job(request.workflow_id, context)
+        noop = await self._paused_at(job, JobState.GAP_ANALYSIS_REVIEW, "the greenlight", context)
+        if noop is not None:
+            return noop
+        notes = request.notes or None
+
+        if not request.approve:
+            job = job_queue.update_job(
+                job.id,
+                state=JobState.FAILED,
+                success=False,
+                awaiting_user=None,
+                greenlight_notes=notes,
+                completed_at=datetime.now(),
+                error_message="Declined at greenlight",
+            )
+            actor = await self._actor(context)
+            audit_log.record(GREENLIGHT, job.id, actor, approved=False, via="grpc")
+            await job.emit_event("complete", job.get_complete_event_payload())
+            return hydra_pb2.SubmitResponse(
+                workflow_id=job.id,
+                status="declined",
+                message="Greenlight declined, workflow stopped",
+            )
+
+        await self._within_budget(context)
+        job = job_queue.update_job(
+            job.id, gap_analysis_approved=True, greenlight_notes=notes, awaiting_user=None
+        )
+        actor = await self._actor(context)
+        audit_log.record(GREENLIGHT, job.id, actor, approved=True, via="grpc")
+        start_workflow_background(job)
+        return hydra_pb2.SubmitResponse(
+            workflow_id=job.id, status="approved", message="Greenlight approved, workflow resumed"
+        )
+
+    async def SubmitInterviewAnswers(self, request, context):
+        await self._accepting_runs(context)
+        job = await self._job(request.workflow_id, context)
+        noop = await self._paused_at(
+            job, JobState.INTERROGATION_REVIEW, "interview answers", context
+        )
+        if noop is not None:
+            return noop
+
+        await self._within_budget(context)
+        answers = [
+            {"question_id": a.question_id, "question": a.question, "answer": a.answer}
+            for a in request.answers
+        ]
+        job = job_queue.update_job(job.id, interview_answers=answers, awaiting_user=None)
+        actor = await self._actor(context)
+        audit_log.record(INTERVIEW_ANSWERS, job.id, actor, answered=len(answers), via="grpc")
+        start_workflow_background(job)
+        return hydra_pb2.SubmitResponse(
+            workflow_id=job.id,
+            status="submitted",
+            message="Interview answers submitted, workflow resumed",
+        )
+
+    async def _control(self, action, request, context, event: str) -> hydra_pb2.SubmitResponse:
+        """A job_control action, logged as ``event``; FAILED_PRECONDITION when the state
+        does not allow it."""
+        job = await self._job(request.workflow_id, context)
+        try:
+            reply = await action(job)
+        except JobControlError as e:
+            await context.abort(grpc.StatusCode.FAILED_PRECONDITION, str(e))
+        audit_log.record(event, job.id, await self._actor(context), via="grpc")
+        return hydra_pb2.SubmitResponse(
+            workflow_id=reply["job_id"], status=reply["status"], message=reply["message"]
+        )
+
+    async def PauseWorkflow(self, request, context):
+        return await self._control(pause_job, request, context, PAUSED)
+
+    async def ResumeWorkflow(self, request, context):
+        await self._accepting_runs(context)
+        await self._within_budget(context)
+        return await self._control(resume_job, request, context, RESUMED)
+
+    async def CancelWorkflow(self, request, context):
+        return await self._control(cancel_job, request, context, CANCELLED)
+
+    async def StreamEvents(self, request, context) -> AsyncIterator[hydra_pb2.Event]:
+        job = await self._job(request.workflow_id, context)
+        # Same sequence as the SSE stream (routes/jobs.py stream_job).
+        yield _event(
+            "connected",
+            {
+                "job_id": job.id,
+                "state": job.state.value,
+                "progress": job.get_progress_percent(),
+                "intermediate_results": job.intermediate_results,
+                "agent_models": job.agent_models,
+            },
+        )
+        actor = await self._actor(context)
+        if job.state in (JobState.COMPLETED, JobState.FAILED, JobState.CANCELLED):
+            audit_log.record_access(job, actor, via="grpc")
+            yield _event("complete", job.get_complete_event_payload())
+            return
+
+        while True:
+            event = await job.get_event(timeout=30.0)
+            if event is None:
+                continue  # gRPC keeps the connection alive itself
+            if event["event"] == "complete":
+                audit_log.record_access(job, actor, via="grpc")
+            yield _event(event["event"], event["data"])
+            if event["event"] in ("complete", "error"):
+                break
+
+
+async def start_grpc_server(port: int) -> grpc.aio.Server:
+    """Serve the Hydra gRPC API on ``port`` in the running event loop."""
+    server = grpc.aio.server()
+    hydra_pb2_grpc.add_HydraServicer_to_server(HydraServicer(), server)
+    server.add_insecure_port(f"[::]:{port}")
+    await server.start()
+    logger.info("Hydra gRPC API listening on :%s", port)
+    return server
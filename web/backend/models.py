@@ -21,6 +21,16 @@ class JobState(str, Enum):
     EXECUTIVE_SYNTHESIS = "executive_synthesis"
     COMPLETED = "completed"
     FAILED = "failed"
+    INTERRUPTED = "interrupted"  # Stopped by a server drain; resumed on the next start
+    PAUSED = "paused"  # Paused on request; resumed only on request
+    CANCELLED = "cancelled"  # Cancelled on request
+
+
+class AwaitingInput(str, Enum):
+    """Human input a paused job is waiting for (persisted as job_queue.awaiting_user)."""
+
+    GREENLIGHT = "greenlight"  # go/no-go after gap analysis
+    INTERVIEW_ANSWERS = "interview_answers"
 
 
 class AuditStatus(str, Enum):
@@ -60,6 +70,15 @@ class ApproveGapAnalysisRequest(BaseModel):
     approved: bool = True
 
 
+class GreenlightRequest(BaseModel):
+    """Go/no-go decision on the gap analysis; approving resumes the workflow."""
+
+    approve: bool
+    notes: Optional[str] = Field(
+        default=None, max_length=4000, description="Guidance passed to later stages"
+    )
+
+
 class SubmitInterviewAnswersRequest(BaseModel):
     """Request to submit interview answers and resume workflow."""
 
@@ -107,6 +126,9 @@ class JobResponse(BaseModel):
     agent_models: Optional[dict[str, str]] = Field(
         default=None, description="Models used by each agent"
     )
+    awaiting_user: Optional[AwaitingInput] = Field(
+        default=None, description="Input a paused job is waiting for"
+    )
 
 
 class SSEEvent(BaseModel):
@@ -114,3 +136,54 @@ class SSEEvent(BaseModel):
 
     event: str
     data: dict[str, Any]
+
+
+class RunSummary(BaseModel):
+    """One run in the admin API's list of every user's runs."""
+
+    job_id: str
+    owner: Optional[str] = None
+    company: Optional[str] = None
+    role_title: Optional[str] = None
+    state: JobState
+    awaiting_user: Optional[AwaitingInput] = None
+    created_at: datetime
+    started_at: Optional[datetime] = None
+    completed_at: Optional[datetime] = None
+    cost_usd: float = 0.0
+    error_message: Optional[str] = None
+    artifacts_purged_at: Optional[datetime] = Field(
+        default=None, description="When the run's documents were deleted by a purge"
+    )
+
+
+class RunListResponse(BaseModel):
+    """A page of runs, newest first."""
+
+    runs: list[RunSummary]
+    limit: int
+    offset: int
+
+
+class ForceFailRequest(BaseModel):
+    """Request to end a stuck run as failed."""
+
+    reason: Optional[str] = Field(default=None, description="Why, for the job's error message")
+
+
+class RequeueRequest(BaseModel):
+    """Request to run a failed job again."""
+
+    from_stage: Optional[str] = Field(
+        default=None,
+        description="Stage to run again from (default: the one that failed)",
+    )
+
+
+class PurgeRequest(BaseModel):
+    """Request to delete the documents of old runs."""
+
+    older_than_days: Optional[int] = Field(
+        default=None, ge=1, description="Retention period (default: HYDRA_ARTIFACT_EXPIRE_DAYS)"
+    )
+    dry_run: bool = Field(default=False, description="Only list the runs that would be purged")
@@ -0,0 +1,83 @@
+"""Queue worker entrypoint: run the jobs API servers put on a shared work queue.
+
+    python -m web.backend.queue_worker [--workers N]
+
+Takes job ids off ``HYDRA_WORK_QUEUE`` (Redis or NATS, see services/work_queue.py)
+and runs up to ``--workers`` (default ``HYDRA_WORKERS``, 4) workflows at a time,
+writing their progress to the job rows for the API servers to relay. Start as many
+as the load needs, on any machine that reaches Postgres and the queue.
+
+SIGTERM or Ctrl-C drains like the API server does (see services/drain.py): no new
+jobs are taken, runs in flight stop at their next stage boundary, and each is
+queued again for another worker to resume from its checkpoint.
+
+Exit status: 0 after a drain, 2 when the queue is not configured for workers.
+"""
+
+import argparse
+import asyncio
+import logging
+import signal
+import sys
+from pathlib import Path
+
+from dotenv import load_dotenv
+
+load_dotenv(Path(__file__).parent.parent.parent / ".env")
+
+from web.backend.services.drain import CANCEL_GRACE_SECONDS, drain  # noqa: E402
+from web.backend.services.work_queue import (  # noqa: E402
+    WORK_QUEUE_ENV,
+    WorkQueueError,
+    workers_from_env,
+)
+from web.backend.services.workflow_runner import start_workers, stop_workers  # noqa: E402
+
+logger = logging.getLogger(__name__)
+
+
+async def serve(workers: int) -> int:
+    """Run ``workers`` queue workers until SIGTERM or SIGINT, then drain."""
+    try:
+        pool = start_workers(workers, detached=True)
+    except WorkQueueError as e:
+        logger.error(str(e))
+        return 2
+    if not pool.queue.shared:
+        logger.error(f"{WORK_QUEUE_ENV} must name a Redis or NATS queue for queue workers")
+        await stop_workers()
+        return 2
+
+    stopping = asyncio.Event()
+    loop = asyncio.get_running_loop()
+    for signum in (signal.SIGTERM, signal.SIGINT):
+        loop.add_signal_handler(signum, stopping.set)
+    logger.info(f"{workers} queue worker(s) on {type(pool.queue).__name__}")
+    await stopping.wait()
+
+    logger.info(f"Draining {pool.busy} run(s) in flight")
+    remaining = await drain.drain()
+    if remaining:
+        logger.error(f"Stopping with runs still in flight: {', '.join(remaining)}")
+    # Drained runs return once persisted; let their workers queue them again.
+    await pool.wait_idle(CANCEL_GRACE_SECONDS)
+    await stop_workers()
+    return 0
+
+
+def main(argv=None) -> int:
+    parser = argparse.ArgumentParser(description="Run Hydra jobs from the shared work queue")
+    parser.add_argument("--workers", type=int, help="Workflows to run at once")
+    args = parser.parse_args(argv)
+    logging.basicConfig(level=logging.INFO, format="%(asctime)s - %(levelname)s - %(message)s")
+    try:
+        workers = workers_from_env() if args.workers is None else args.workers
+    except WorkQueueError as e:
+        parser.error(str(e))
+    if workers < 1:
+        parser.error("a queue worker needs at least one worker")
+    return asyncio.run(serve(workers))
+
+
+if __name__ == "__main__":
+    sys.exit(main())
@@ -0,0 +1,178 @@
+"""Admin API: every user's runs, for the operators of a shared server.
+
+- ``GET /api/v1/admin/runs``: runs of all users, newest first, filtered by
+  ``status`` (a job state), ``user`` and ``since`` / ``until`` (creation time);
+- ``POST /api/v1/admin/runs/{id}/fail``: end a stuck run as failed;
+- ``POST /api/v1/admin/runs/{id}/requeue``: run a failed job again from its
+  checkpoint, or from ``from_stage``;
+- ``POST /api/v1/admin/purge``: delete the documents of runs that ended more than
+  ``older_than_days`` (default ``HYDRA_ARTIFACT_EXPIRE_DAYS``) ago;
+- ``GET /api/v1/admin/audit``: the audit log as JSONL, filtered by ``job_id``,
+  ``user`` and ``since`` / ``until`` (services/audit_log.py).
+
+Only the users in ``HYDRA_ADMIN_USERS`` may call it (web/backend/auth.py). What the
+actions do is in services/admin.py.
+"""
+
+import asyncio
+from datetime import datetime
+from typing import Optional
+
+from litestar import Controller, Request, get, post
+from litestar.exceptions import HTTPException
+from litestar.params import Parameter
+from litestar.response import Response
+from litestar.status_codes import (
+    HTTP_200_OK,
+    HTTP_400_BAD_REQUEST,
+    HTTP_403_FORBIDDEN,
+    HTTP_404_NOT_FOUND,
+)
+
+from web.backend.auth import is_admin
+from web.backend.models import (
+    ForceFailRequest,
+    JobState,
+    PurgeRequest,
+    RequeueRequest,
+    RunListResponse,
+    RunSummary,
+)
+from web.backend.routes.jobs import _actor, _caller, _reject_while_draining
+from web.backend.services import admin
+from web.backend.services.audit_log import (
+    FORCE_FAILED,
+    JSONL_MEDIA_TYPE,
+    PURGED,
+    REQUEUED,
+    audit_log,
+    to_jsonl,
+)
+from web.backend.services.job_control import JobControlError
+from web.backend.services.job_queue import Job, job_queue
+from web.backend.services.storage import StorageConfigError
+from web.backend.versioning import API_PREFIX
+
+MAX_RUNS_PER_PAGE = 500
+
+
+def _require_admin(request: Request) -> None:
+    """403 unless the caller is an administrator."""
+    if not is_admin(_caller(request)):
+        raise HTTPException(status_code=HTTP_403_FORBIDDEN, detail="Admin access required")
+
+
+def _get_run_or_404(job_id: str) -> Job:
+    job = job_queue.get_job(job_id)
+    if not job:
+        raise HTTPException(status_code=HTTP_404_NOT_FOUND, detail="Job not found")
+    return job
+
+
+def _summary(job: Job) -> RunSummary:
+    return RunSummary(
+        job_id=job.id,
+        owner=job.owner,
+        company=job.company,
+        role_title=job.role_title,
+        state=job.state,
+        awaiting_user=job.awaiting_user,
+        created_at=job.created_at,
+        started_at=job.started_at,
+        completed_at=job.completed_at,
+        cost_usd=job.cost_usd,
+        error_message=job.error_message,
+        artifacts_purged_at=job.artifacts_purged_at,
+    )
+
+
+class AdminController(Controller):
+    """Controller for run management by administrators."""
+
+    path = f"{API_PREFIX}/admin"
+
+    @get("/runs", status_code=HTTP_200_OK)
+    async def list_runs(
+        self,
+        request: Request,
+        status: Optional[str] = None,
+        user: Optional[str] = None,
+        since: Optional[datetime] = None,
+        until: Optional[datetime] = None,
+        limit: int = Parameter(default=50, ge=1, le=MAX_RUNS_PER_PAGE),
+        offset: int = Parameter(default=0, ge=0),
+    ) -> RunListResponse:
+        """List the runs of every user, newest first."""
+        _require_admin(request)
+        try:
+            state = JobState(status) if status else None
+        except ValueError as e:
+            raise HTTPException(
+                status_code=HTTP_400_BAD_REQUEST, detail=f"Unknown status: {status}"
+            ) from e
+        jobs = job_queue.search_jobs(
+            state=state, owner=user, since=since, until=until, limit=limit, offset=offset
+        )
+        return RunListResponse(runs=[_summary(job) for job in jobs], limit=limit, offset=offset)
+
+    @post("/runs/{job_id:str}/fail", status_code=HTTP_200_OK)
+    async def force_fail(
+        self, request: Request, job_id: str, data: Optional[ForceFailRequest] = None
+    ) -> dict:
+        """End a stuck run as failed; it is stopped and can no longer write to the job."""
+        _require_admin(request)
+        job = _get_run_or_404(job_id)
+        try:
+            job = await admin.force_fail(job, data.reason if data else None)
+        except JobControlError as e:
+            raise HTTPException(status_code=HTTP_400_BAD_REQUEST, detail=str(e)) from e
+        audit_log.record(FORCE_FAILED, job.id, _actor(request), reason=job.error_message)
+        return {"job_id": job.id, "status": "failed", "message": job.error_message}
+
+    @post("/runs/{job_id:str}/requeue", status_code=HTTP_200_OK)
+    async def requeue(
+        self, request: Request, job_id: str, data: Optional[RequeueRequest] = None
+    ) -> dict:
+        """Run a failed job again from its checkpoint, or from ``from_stage``."""
+        _require_admin(request)
+        _reject_while_draining()
+        job = _get_run_or_404(job_id)
+        from_stage = data.from_stage if data else None
+        try:
+            job = admin.requeue(job, from_stage)
+        except JobControlError as e:
+            raise HTTPException(status_code=HTTP_400_BAD_REQUEST, detail=str(e)) from e
+        audit_log.record(REQUEUED, job.id, _actor(request), from_stage=from_stage)
+        where = f"from {from_stage}" if from_stage else "from its last completed stage"
+        return {"job_id": job.id, "status": "requeued", "message": f"Job requeued {where}"}
+
+    @post("/purge", status_code=HTTP_200_OK)
+    async def purge(self, request: Request, data: Optional[PurgeRequest] = None) -> dict:
+        """Delete the documents of runs that ended before the retention period."""
+        _require_admin(request)
+        data = data or PurgeRequest()
+        try:
+            days = admin.retention_days(data.older_than_days)
+            result = await asyncio.to_thread(admin.purge_artifacts, days, data.dry_run)
+        except StorageConfigError as e:
+            raise HTTPException(status_code=HTTP_400_BAD_REQUEST, detail=str(e)) from e
+        if not data.dry_run:
+            for job_id in result["job_ids"]:
+                audit_log.record(PURGED, job_id, _actor(request), older_than_days=days)
+        return result
+
+    @get("/audit", status_code=HTTP_200_OK)
+    async def export_audit_log(
+        self,
+        request: Request,
+        job_id: Optional[str] = None,
+        user: Optional[str] = None,
+        since: Optional[datetime] = None,
+        until: Optional[datetime] = None,
+    ) -> Response:
+        """The audit log, oldest first, as JSONL."""
+        _require_admin(request)
+        events = await asyncio.to_thread(
+            audit_log.search, job_id=job_id, actor=user, since=since, until=until
+        )
+        return Response(content=to_jsonl(events), media_type=JSONL_MEDIA_TYPE)
@@ -1,7 +1,10 @@
 """Health check endpoint."""
 
-from litestar import Controller, get
-from litestar.status_codes import HTTP_200_OK
+from litestar import Controller, Response, get
+from litestar.status_codes import HTTP_200_OK, HTTP_503_SERVICE_UNAVAILABLE
+
+from runtime.crewai.circuit_breaker import CLOSED, shared_breaker
+from web.backend.services.drain import drain
 
 
 class HealthController(Controller):
@@ -10,10 +13,32 @@ class HealthController(Controller):
     path = "/health"
 
     @get("/", status_code=HTTP_200_OK)
-    async def health_check(self) -> dict:
-        """Return health status."""
-        return {
+    async def health_check(self) -> Response[dict]:
+        """Return health status; 503 while draining, so load balancers stop routing here.
+
+        Providers whose circuit is open or half open are listed under ``circuits``: the
+        service still answers, but stages on them are failing over.
+        """
+        if drain.draining:
+            return Response(
+                {
+                    "status": "draining",
+                    "service": "hydra-api",
+                    "version": "1.0.0",
+                    "in_flight": len(drain.live_jobs()),
+                },
+                status_code=HTTP_503_SERVICE_UNAVAILABLE,
+            )
+        body = {
             "status": "healthy",
             "service": "hydra-api",
             "version": "1.0.0",
         }
+        circuits = {
+            provider: circuit
+            for provider, circuit in shared_breaker().snapshot().items()
+            if circuit["state"] != CLOSED
+        }
+        if circuits:
+            body["circuits"] = circuits
+        return Response(body, status_code=HTTP_200_OK)
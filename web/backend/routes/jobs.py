@@ -1,13 +1,24 @@
 """Job management endpoints with SSE streaming."""
 
 import json
-from typing import AsyncGenerator
+from datetime import datetime
+from typing import AsyncGenerator, Optional
 
-from litestar import Controller, get, post
+from litestar import Controller, Request, get, post
 from litestar.exceptions import HTTPException
-from litestar.response import Stream
-from litestar.status_codes import HTTP_200_OK, HTTP_202_ACCEPTED, HTTP_404_NOT_FOUND
+from litestar.response import Response, Stream
+from litestar.status_codes import (
+    HTTP_200_OK,
+    HTTP_202_ACCEPTED,
+    HTTP_400_BAD_REQUEST,
+    HTTP_402_PAYMENT_REQUIRED,
+    HTTP_404_NOT_FOUND,
+    HTTP_422_UNPROCESSABLE_ENTITY,
+    HTTP_503_SERVICE_UNAVAILABLE,
+)
 
+from runtime.crewai.audit_log import GREENLIGHT, INTERVIEW_ANSWERS
+from web.backend.auth import ApiUser, can_access, month_start, over_budget
 from web.backend.models import (
     ApproveGapAnalysisRequest,
     AuditReport,
@@ -15,12 +26,37 @@ from web.backend.models import (
     CreateJobRequest,
     CreateJobResponse,
     FinalDocuments,
+    GreenlightRequest,
     JobResponse,
     JobState,
     SubmitInterviewAnswersRequest,
 )
-from web.backend.services.job_queue import job_queue
+from web.backend.services.audit_log import (
+    CANCELLED,
+    JOB_CREATED,
+    JSONL_MEDIA_TYPE,
+    PAUSED,
+    RESUMED,
+    audit_log,
+    to_jsonl,
+)
+from web.backend.services.drain import drain
+from web.backend.services.idempotency import (
+    IDEMPOTENCY_HEADER,
+    IdempotencyError,
+    check_key,
+    create_once,
+    request_hash,
+)
+from web.backend.services.job_control import (
+    JobControlError,
+    cancel_job,
+    pause_job,
+    resume_job,
+)
+from web.backend.services.job_queue import Job, job_queue
 from web.backend.services.workflow_runner import start_workflow_background
+from web.backend.versioning import API_PREFIX, LEGACY_PREFIX
 
 _STATE_ORDER: dict[JobState, int] = {
     JobState.INITIALIZED: 0,
@@ -42,30 +78,115 @@ def _is_after_state(current: JobState, target: JobState) -> bool:
     return _STATE_ORDER.get(current, -1) > _STATE_ORDER.get(target, -1)
 
 
+def _reject_while_draining() -> None:
+    """503 for anything that would start a run while the server drains for a restart."""
+    if drain.draining:
+        raise HTTPException(
+            status_code=HTTP_503_SERVICE_UNAVAILABLE,
+            detail="Server is restarting; retry shortly",
+            headers={"Retry-After": "30"},
+        )
+
+
+def _caller(request: Request) -> Optional[ApiUser]:
+    """The authenticated API user (web/backend/auth.py); None when auth is off."""
+    return request.scope.get("user")
+
+
+def _actor(request: Request) -> Optional[str]:
+    """The caller's name, for the audit log (services/audit_log.py)."""
+    user = _caller(request)
+    return user.name if user else None
+
+
+def _get_job_or_404(job_id: str, request: Request) -> Job:
+    """The job, if the caller may see it: another user's job is as missing as no job."""
+    job = job_queue.get_job(job_id)
+    if not job or not can_access(_caller(request), job.owner):
+        raise HTTPException(status_code=HTTP_404_NOT_FOUND, detail="Job not found")
+    return job
+
+
+def budget_refusal(user: Optional[ApiUser]) -> Optional[str]:
+    """Why ``user`` may not start or resume a run this month, or None if they may."""
+    if user is None or user.budget_usd is None:
+        return None
+    return over_budget(user, job_queue.spent_since(user.name, month_start()))
+
+
+def _reject_over_budget(request: Request) -> None:
+    """402 for anything that would start a run once the caller's budget is spent."""
+    reason = budget_refusal(_caller(request))
+    if reason:
+        raise HTTPException(status_code=HTTP_402_PAYMENT_REQUIRED, detail=reason)
+
+
+async def _control(action, job: Job, request: Request, event: str) -> dict:
+    """Run a job_control action, logged as ``event``; 400 when the job's state does not
+    allow it."""
+    try:
+        reply = await action(job)
+    except JobControlError as e:
+        raise HTTPException(status_code=HTTP_400_BAD_REQUEST, detail=str(e)) from e
+    audit_log.record(event, job.id, _actor(request), via="rest")
+    return reply
+
+
 class JobsController(Controller):
     """Controller for job management endpoints."""
 
-    path = "/api/jobs"
+    path = f"{API_PREFIX}/jobs"
 
     @post("/", status_code=HTTP_202_ACCEPTED)
-    async def create_job(self, data: CreateJobRequest) -> CreateJobResponse:
+    async def create_job(self, request: Request, data: CreateJobRequest) -> CreateJobResponse:
         """
         Create a new job and start processing in background.
 
-        Returns job_id immediately while workflow runs asynchronously.
+        Returns job_id immediately while workflow runs asynchronously. A repeat of an
+        earlier request (same Idempotency-Key, or same inputs shortly after) returns
+        that request's job with status "duplicate" (see services/idempotency.py).
         """
-        job = job_queue.create_job(
-            job_description=data.job_description,
-            resume=data.resume,
-            source_documents=data.source_documents,
-            company=data.company,
-            role_title=data.role_title,
-            source=data.source,
-            url=data.url,
-            model=data.model,
-            max_audit_retries=data.max_audit_retries,
+        _reject_while_draining()
+        user = _caller(request)
+        owner = user.name if user else None
+        try:
+            key = check_key(request.headers.get(IDEMPOTENCY_HEADER))
+        except IdempotencyError as e:
+            raise HTTPException(status_code=HTTP_400_BAD_REQUEST, detail=str(e)) from e
+        digest = request_hash(
+            data.job_description,
+            data.resume,
+            data.source_documents,
+            data.model,
+            data.max_audit_retries,
         )
 
+        def _create() -> Job:
+            _reject_over_budget(request)
+            return job_queue.create_job(
+                job_description=data.job_description,
+                resume=data.resume,
+                source_documents=data.source_documents,
+                company=data.company,
+                role_title=data.role_title,
+                source=data.source,
+                url=data.url,
+                model=data.model,
+                max_audit_retries=data.max_audit_retries,
+                owner=owner,
+                idempotency_key=key,
+                request_hash=digest,
+            )
+
+        try:
+            job, created = create_once(owner, key, digest, _create)
+        except IdempotencyError as e:
+            raise HTTPException(status_code=HTTP_422_UNPROCESSABLE_ENTITY, detail=str(e)) from e
+        if not created:
+            # A repeat of an earlier request: its job, not a second run.
+            return CreateJobResponse(job_id=job.id, status="duplicate", created_at=job.created_at)
+
+        audit_log.record(JOB_CREATED, job.id, owner, via="rest")
         # Start workflow in background
         start_workflow_background(job)
 
@@ -76,11 +197,12 @@ class JobsController(Controller):
         )
 
     @post("/{job_id:str}/approve_gap_analysis", status_code=HTTP_200_OK)
-    async def approve_gap_analysis(self, job_id: str, data: ApproveGapAnalysisRequest) -> dict:
+    async def approve_gap_analysis(
+        self, request: Request, job_id: str, data: ApproveGapAnalysisRequest
+    ) -> dict:
         """Approve gap analysis and resume workflow."""
-        job = job_queue.get_job(job_id)
-        if not job:
-            raise HTTPException(status_code=HTTP_404_NOT_FOUND, detail="Job not found")
+        _reject_while_draining()
+        job = _get_job_or_404(job_id, request)
 
         if job.state != JobState.GAP_ANALYSIS_REVIEW:
             # Idempotency: if user clicks twice or UI is stale, treat "already advanced" as a no-op.
@@ -95,8 +217,10 @@ class JobsController(Controller):
                 detail=f"Job is not in GAP_ANALYSIS_REVIEW state (current: {job.state})",
             )
 
+        _reject_over_budget(request)
         # Update job and get the updated object (crucial for workflow to see the approval)
-        job = job_queue.update_job(job_id, gap_analysis_approved=data.approved)
+        job = job_queue.update_job(job_id, gap_analysis_approved=data.approved, awaiting_user=None)
+        audit_log.record(GREENLIGHT, job_id, _actor(request), approved=data.approved, via="rest")
 
         # Resume workflow with updated job
         start_workflow_background(job)
@@ -107,14 +231,71 @@ class JobsController(Controller):
             "message": "Gap analysis approved, workflow resumed",
         }
 
+    @post("/{job_id:str}/greenlight", status_code=HTTP_200_OK)
+    async def greenlight(self, request: Request, job_id: str, data: GreenlightRequest) -> dict:
+        """Record the go/no-go decision on the gap analysis.
+
+        The paused job is persisted (state GAP_ANALYSIS_REVIEW, awaiting_user
+        "greenlight") with its intermediate results, so this works the same whether
+        or not the server restarted since the pause. Approving resumes the workflow
+        with ``notes`` forwarded to later stages; declining ends the job.
+        """
+        _reject_while_draining()
+        job = _get_job_or_404(job_id, request)
+
+        if job.state != JobState.GAP_ANALYSIS_REVIEW:
+            if _is_after_state(job.state, JobState.GAP_ANALYSIS_REVIEW):
+                return {
+                    "job_id": job_id,
+                    "status": "noop",
+                    "message": "Job already advanced past the greenlight",
+                }
+            raise HTTPException(
+                status_code=400,
+                detail=f"Job is not awaiting a greenlight (current: {job.state})",
+            )
+
+        if not data.approve:
+            job = job_queue.update_job(
+                job_id,
+                state=JobState.FAILED,
+                success=False,
+                awaiting_user=None,
+                greenlight_notes=data.notes,
+                completed_at=datetime.now(),
+                error_message="Declined at greenlight",
+            )
+            audit_log.record(GREENLIGHT, job_id, _actor(request), approved=False, via="rest")
+            await job.emit_event("complete", job.get_complete_event_payload())
+            return {
+                "job_id": job_id,
+                "status": "declined",
+                "message": "Greenlight declined, workflow stopped",
+            }
+
+        _reject_over_budget(request)
+        job = job_queue.update_job(
+            job_id,
+            gap_analysis_approved=True,
+            greenlight_notes=data.notes,
+            awaiting_user=None,
+        )
+        audit_log.record(GREENLIGHT, job_id, _actor(request), approved=True, via="rest")
+        start_workflow_background(job)
+
+        return {
+            "job_id": job_id,
+            "status": "approved",
+            "message": "Greenlight approved, workflow resumed",
+        }
+
     @post("/{job_id:str}/submit_interview_answers", status_code=HTTP_200_OK)
     async def submit_interview_answers(
-        self, job_id: str, data: SubmitInterviewAnswersRequest
+        self, request: Request, job_id: str, data: SubmitInterviewAnswersRequest
     ) -> dict:
         """Submit interview answers and resume workflow."""
-        job = job_queue.get_job(job_id)
-        if not job:
-            raise HTTPException(status_code=HTTP_404_NOT_FOUND, detail="Job not found")
+        _reject_while_draining()
+        job = _get_job_or_404(job_id, request)
 
         if job.state != JobState.INTERROGATION_REVIEW:
             if _is_after_state(job.state, JobState.INTERROGATION_REVIEW):
@@ -128,8 +309,12 @@ class JobsController(Controller):
                 detail=f"Job is not in INTERROGATION_REVIEW state (current: {job.state})",
             )
 
+        _reject_over_budget(request)
         # Update job and get the updated object (crucial for workflow to see the answers)
-        job = job_queue.update_job(job_id, interview_answers=data.answers)
+        job = job_queue.update_job(job_id, interview_answers=data.answers, awaiting_user=None)
+        audit_log.record(
+            INTERVIEW_ANSWERS, job_id, _actor(request), answered=len(data.answers), via="rest"
+        )
 
         # Resume workflow with updated job
         start_workflow_background(job)
@@ -140,13 +325,29 @@ class JobsController(Controller):
             "message": "Interview answers submitted, workflow resumed",
         }
 
+    @post("/{job_id:str}/pause", status_code=HTTP_200_OK)
+    async def pause(self, request: Request, job_id: str) -> dict:
+        """Pause the job at its next stage boundary; ``resume`` picks it up there."""
+        return await _control(pause_job, _get_job_or_404(job_id, request), request, PAUSED)
+
+    @post("/{job_id:str}/resume", status_code=HTTP_200_OK)
+    async def resume(self, request: Request, job_id: str) -> dict:
+        """Resume a paused or interrupted job from its last completed stage."""
+        _reject_while_draining()
+        job = _get_job_or_404(job_id, request)
+        _reject_over_budget(request)
+        return await _control(resume_job, job, request, RESUMED)
+
+    @post("/{job_id:str}/cancel", status_code=HTTP_200_OK)
+    async def cancel(self, request: Request, job_id: str) -> dict:
+        """Cancel the job; its completed stages stay on the job, but it will not run again."""
+        return await _control(cancel_job, _get_job_or_404(job_id, request), request, CANCELLED)
+
     @get("/{job_id:str}", status_code=HTTP_200_OK)
-    def get_job(self, job_id: str) -> JobResponse:
+    def get_job(self, request: Request, job_id: str) -> JobResponse:
         """Get job status and results."""
-        job = job_queue.get_job(job_id)
-
-        if not job:
-            raise HTTPException(status_code=HTTP_404_NOT_FOUND, detail="Job not found")
+        job = _get_job_or_404(job_id, request)
+        audit_log.record_access(job, _actor(request), via="rest")
 
         # Build response
         final_docs = None
@@ -191,10 +392,20 @@ class JobsController(Controller):
             audit_failed=job.audit_failed,
             audit_error=job.audit_error,
             agent_models=job.agent_models,
+            awaiting_user=job.awaiting_user,
+        )
+
+    @get("/{job_id:str}/audit", status_code=HTTP_200_OK)
+    def get_audit_log(self, request: Request, job_id: str) -> Response:
+        """The job's audit log, oldest first, as JSONL (see services/audit_log.py)."""
+        job = _get_job_or_404(job_id, request)
+        return Response(
+            content=to_jsonl(audit_log.search(job_id=job.id)),
+            media_type=JSONL_MEDIA_TYPE,
         )
 
     @get("/{job_id:str}/stream")
-    async def stream_job(self, job_id: str) -> Stream:
+    async def stream_job(self, request: Request, job_id: str) -> Stream:
         """
         Stream job progress via Server-Sent Events.
 
@@ -206,10 +417,8 @@ class JobsController(Controller):
         - complete: Job finished (success or failure)
         - error: Error occurred
         """
-        job = job_queue.get_job(job_id)
-
-        if not job:
-            raise HTTPException(status_code=HTTP_404_NOT_FOUND, detail="Job not found")
+        job = _get_job_or_404(job_id, request)
+        actor = _actor(request)
 
         async def event_generator() -> AsyncGenerator[bytes, None]:
             """Generate SSE events."""
@@ -226,7 +435,8 @@ class JobsController(Controller):
             )
 
             # If already complete, send final state and close
-            if job.state in (JobState.COMPLETED, JobState.FAILED):
+            if job.state in (JobState.COMPLETED, JobState.FAILED, JobState.CANCELLED):
+                audit_log.record_access(job, actor, via="rest")
                 yield _format_sse_event("complete", job.get_complete_event_payload())
                 return
 
@@ -239,6 +449,8 @@ class JobsController(Controller):
                     yield b": keepalive\n\n"
                     continue
 
+                if event["event"] == "complete":
+                    audit_log.record_access(job, actor, via="rest")
                 yield _format_sse_event(event["event"], event["data"])
 
                 # Stop streaming on completion
@@ -260,3 +472,9 @@ def _format_sse_event(event_type: str, data: dict) -> bytes:
     """Format data as SSE event."""
     json_data = json.dumps(data, default=str)
     return f"event: {event_type}\ndata: {json_data}\n\n".encode("utf-8")
+
+
+class LegacyJobsController(JobsController):
+    """The v1 job endpoints on their original, unversioned (deprecated) path."""
+
+    path = f"{LEGACY_PREFIX}/jobs"
@@ -0,0 +1,102 @@
+"""Workflow events: one run's progress as it happens, for the web UI and other clients.
+
+``GET /api/v1/workflows/{id}/events`` (the id is the job's) is a Server-Sent Events
+stream of what a client needs to show a run live without polling:
+
+- connected: the run as it stands when the stream opens;
+- started: a run of the workflow began (again, after a pause or a resume);
+- progress: the run moved on to another stage;
+- partial: a piece of a model's answer as it arrives (``stage``, ``text``). Token
+  by token where the call streams (``HYDRA_DIRECT_LLM``), else each answer whole.
+  A retried call streams again, and with ``--redact-pii`` contact details are the
+  placeholders the model saw;
+- stage_complete: a stage's validated output;
+- awaiting_user: the run paused for input (``awaiting_user``: greenlight or
+  interview), sent on connect too if it already waits; the stream stays open;
+- complete / error: the run finished; the stream ends.
+
+Unlike the job stream (``/jobs/{id}/stream``), every client gets every event, so
+the web UI and a third-party client can follow the same run. Events are relayed by
+the server instance that runs or watches the job; partial output is only there for
+workflows run in that server's process.
+"""
+
+import asyncio
+from typing import AsyncGenerator
+
+from litestar import Controller, Request, get
+from litestar.response import Stream
+
+from web.backend.models import JobState
+from web.backend.routes.jobs import _format_sse_event, _get_job_or_404
+from web.backend.versioning import API_PREFIX
+
+KEEPALIVE_SECONDS = 30.0
+
+# What the job stream carries that this one passes on (logs stay on the job stream).
+RELAYED = {
+    "started",
+    "progress",
+    "partial",
+    "stage_complete",
+    "awaiting_user",
+    "complete",
+    "error",
+}
+FINAL = ("complete", "error")
+_DONE = (JobState.COMPLETED, JobState.FAILED, JobState.CANCELLED)
+
+
+class WorkflowsController(Controller):
+    path = f"{API_PREFIX}/workflows"
+
+    @get("/{workflow_id:str}/events")
+    async def stream_events(self, request: Request, workflow_id: str) -> Stream:
+        """Stream a workflow run's stage transitions, partial model output and
+        awaiting_user signals via Server-Sent Events."""
+        job = _get_job_or_404(workflow_id, request)
+        # Subscribed before the snapshot, so nothing falls between the two.
+        events = job.subscribe()
+
+        async def event_generator() -> AsyncGenerator[bytes, None]:
+            try:
+                yield _format_sse_event(
+                    "connected",
+                    {
+                        "job_id": job.id,
+                        "state": job.state.value,
+                        "progress": job.get_progress_percent(),
+                        "awaiting_user": job.awaiting_user,
+                        "intermediate_results": job.intermediate_results,
+                        "agent_models": job.agent_models,
+                    },
+                )
+                if job.state in _DONE:
+                    yield _format_sse_event("complete", job.get_complete_event_payload())
+                    return
+                if job.awaiting_user is not None:
+                    yield _format_sse_event("awaiting_user", job.get_awaiting_event_payload())
+
+                while True:
+                    try:
+                        event = await asyncio.wait_for(events.get(), KEEPALIVE_SECONDS)
+                    except asyncio.TimeoutError:
+                        yield b": keepalive\n\n"
+                        continue
+                    if event["event"] not in RELAYED:
+                        continue
+                    yield _format_sse_event(event["event"], event["data"])
+                    if event["event"] in FINAL:
+                        return
+            finally:
+                job.unsubscribe(events)
+
+        return Stream(
+            event_generator(),
+            media_type="text/event-stream",
+            headers={
+                "Cache-Control": "no-cache",
+                "Connection": "keep-alive",
+                "X-Accel-Buffering": "no",  # Disable nginx buffering
+            },
+        )
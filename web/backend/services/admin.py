@@ -0,0 +1,125 @@
+"""Run management for server operators: the admin API's actions (routes/admin.py).
+
+- force-fail: end a run that is stuck — a workflow hung on a provider, a job whose
+  instance died with the lease held, a container that never reports — as ``failed``.
+  The run is asked to stop wherever it is (like a cancel), and its lease is revoked
+  (services/leases.py), so a run that carries on regardless can no longer write to
+  the job.
+- requeue: run a ``failed`` job again from its checkpoint: the stages it completed
+  are kept and the one that failed runs again, or from an earlier stage whose
+  output and everything after it are dropped first. It counts against no budget.
+- purge: delete the documents of runs that ended before the retention period — the
+  files or objects in the artifact store, their records, the interview, the state
+  snapshot, and the documents, stage outputs, résumé and sources in the job row.
+  ``HYDRA_ARTIFACT_EXPIRE_DAYS``, the bucket lifecycle setting (services/storage.py),
+  is the default period, so disk storage and the database follow the same rule as
+  the bucket. The job row itself, its job description and its outcome are kept
+  (services/cleanup.py deletes those later, and purges on a schedule).
+"""
+
+import asyncio
+import logging
+from datetime import datetime, timedelta
+from typing import Any, Optional
+
+from runtime.crewai.replay import REPLAY_STAGES, upstream
+from web.backend.models import JobState
+from web.backend.services.hydra_db import hydra_db
+from web.backend.services.job_control import FINISHED, JobControlError
+from web.backend.services.job_queue import Job, job_queue
+from web.backend.services.leases import leases
+from web.backend.services.storage import (
+    EXPIRE_DAYS_ENV,
+    StorageConfigError,
+    artifact_store_from_env,
+    days_from_env,
+    state_store_from_env,
+)
+from web.backend.services.workflow_runner import request_stop, start_workflow_background
+
+logger = logging.getLogger(__name__)
+
+FORCE_FAIL_REASON = "Failed by an administrator"
+
+
+async def force_fail(job: Job, reason: Optional[str] = None) -> Job:
+    """End ``job`` as failed, whatever its run is doing (see the module docstring)."""
+    if job.state in FINISHED:
+        raise JobControlError(f"Cannot fail a {job.state.value} job")
+    await asyncio.to_thread(request_stop, job.id, JobState.CANCELLED)
+    await asyncio.to_thread(leases.revoke, job.id)
+    message = f"{FORCE_FAIL_REASON}: {reason}" if reason else FORCE_FAIL_REASON
+    job = job_queue.override_job(
+        job.id,
+        state=JobState.FAILED,
+        success=False,
+        awaiting_user=None,
+        completed_at=datetime.now(),
+        error_message=message,
+    )
+    logger.warning(f"Job {job.id} force-failed: {message}")
+    await job.emit_event("complete", job.get_complete_event_payload())
+    return job
+
+
+def requeue(job: Job, from_stage: Optional[str] = None) -> Job:
+    """Run failed ``job`` again from its checkpoint, or from ``from_stage``."""
+    if job.state != JobState.FAILED:
+        raise JobControlError(f"Only a failed job can be requeued (current: {job.state.value})")
+    fields: dict[str, Any] = {}
+    if from_stage is not None:
+        try:
+            fields["intermediate_results"] = upstream(job.intermediate_results, from_stage)
+        except ValueError as e:
+            raise JobControlError(str(e)) from e
+        # Gate answers given for outputs that are now re-run no longer apply.
+        stage = REPLAY_STAGES.index(from_stage)
+        if stage <= REPLAY_STAGES.index("gap_analysis"):
+            fields.update(gap_analysis_approved=False, greenlight_notes=None)
+        if stage <= REPLAY_STAGES.index("interrogation"):
+            fields["interview_answers"] = []
+    job = job_queue.override_job(
+        job.id,
+        state=JobState.INITIALIZED,
+        success=False,
+        completed_at=None,
+        error_message=None,
+        awaiting_user=None,
+        **fields,
+    )
+    start_workflow_background(job)
+    return job
+
+
+def retention_days(older_than_days: Optional[int] = None) -> int:
+    """The retention period to purge by: ``older_than_days``, else the configured one."""
+    days = older_than_days if older_than_days is not None else days_from_env(EXPIRE_DAYS_ENV)
+    if not days:
+        raise StorageConfigError(
+            f"No retention period: pass older_than_days or set {EXPIRE_DAYS_ENV}"
+        )
+    return days
+
+
+def purge_artifacts(days: int, dry_run: bool = False) -> dict[str, Any]:
+    """Delete the documents of jobs that ended more than ``days`` ago."""
+    jobs = job_queue.finished_before(datetime.now() - timedelta(days=days))
+    purged = {"days": days, "dry_run": dry_run, "job_ids": [job.id for job in jobs]}
+    if dry_run:
+        return {**purged, "artifacts": None}
+
+    store, state_store = artifact_store_from_env(), state_store_from_env()
+    artifacts = 0
+    for job in jobs:
+        if job.hydra_run_id:
+            for record in hydra_db.list_artifacts(job.hydra_run_id):
+                location = (record.get("metadata") or {}).get("path")
+                if location and not store.delete(location):
+                    logger.warning(f"Left {location} of job {job.id}: not in the current store")
+            artifacts += hydra_db.delete_artifacts(job.hydra_run_id)
+            hydra_db.delete_interviews(job.hydra_run_id)
+        if state_store is not None:
+            state_store.delete(job.id, job.owner)
+        job_queue.purge_documents(job.id)
+    logger.info(f"Purged the documents of {len(jobs)} job(s) older than {days} days")
+    return {**purged, "artifacts": artifacts}
@@ -0,0 +1,147 @@
+"""The server's audit log: who decided what about a job, who read its documents, and
+which model provider was sent which of its data.
+
+The events are those of a local run's ``audit_log.jsonl`` (runtime/crewai/audit_log.py),
+kept in the ``audit_log`` table instead, with the job they belong to:
+
+- the workflow's own — ``provider_call`` for each model call — recorded when a run
+  returns, with the job's owner as actor;
+- the API's — ``greenlight`` (``approved``), ``interview_answers`` (``answered``),
+  ``job_created``, ``paused``, ``resumed`` and ``cancelled`` by the calling API user,
+  ``artifacts_accessed`` when a finished job's documents are returned (REST
+  ``GET /jobs/{id}`` and its stream's ``complete`` event, gRPC ``GetState`` and
+  ``StreamEvents``), and the admin API's ``force_failed``, ``requeued`` and
+  ``purged``. Each says ``via`` which API (``rest`` or ``grpc``);
+- the scheduled cleanup's ``purged`` and ``deleted`` (``via`` ``retention``).
+
+The table can only be appended to — a trigger refuses UPDATE and DELETE — and rows
+hold no job-description or résumé text, so they stay when the retention policy
+purges and deletes jobs (services/cleanup.py). ``GET /api/v1/admin/audit`` exports it
+as JSONL, and a job's owner reads its own at ``GET /api/v1/jobs/{id}/audit``. A
+failure to write is logged, not raised: the request it describes goes ahead.
+"""
+
+import json
+import logging
+from datetime import datetime
+from typing import Any, Iterable, Mapping, Optional
+
+from psycopg.types.json import Json
+
+from runtime.crewai.audit_log import ARTIFACTS_ACCESSED
+from web.backend.db import get_conn
+from web.backend.services.job_queue import Job
+
+logger = logging.getLogger(__name__)
+
+JOB_CREATED = "job_created"
+PAUSED = "paused"
+RESUMED = "resumed"
+CANCELLED = "cancelled"
+FORCE_FAILED = "force_failed"
+REQUEUED = "requeued"
+PURGED = "purged"
+DELETED = "deleted"
+
+JSONL_MEDIA_TYPE = "application/x-ndjson"
+
+# Event keys that are columns rather than details.
+_COLUMNS = ("at", "job_id", "actor", "action")
+
+
+class AuditLog:
+    """Appends to and reads the ``audit_log`` table."""
+
+    def record_events(
+        self,
+        job_id: Optional[str],
+        events: Iterable[Mapping[str, Any]],
+        actor: Optional[str] = None,
+    ) -> int:
+        """Append ``events`` (runtime/crewai/audit_log.py's form) for ``job_id``; ``actor``
+        stands in for events that name none. How many were written."""
+        rows = [
+            (
+                event.get("at"),
+                job_id,
+                event.get("actor") or actor,
+                event["action"],
+                Json({k: v for k, v in event.items() if k not in _COLUMNS}),
+            )
+            for event in events
+        ]
+        if not rows:
+            return 0
+        try:
+            with get_conn() as conn:
+                with conn.cursor() as cursor:
+                    cursor.executemany(
+                        "INSERT INTO audit_log (at, job_id, actor, action, details)"
+                        " VALUES (COALESCE(%s::timestamptz, NOW()), %s, %s, %s, %s)",
+                        rows,
+                    )
+                conn.commit()
+        except Exception as exc:
+            logger.error(f"Audit log: {len(rows)} event(s) for job {job_id} not recorded: {exc}")
+            return 0
+        return len(rows)
+
+    def record(
+        self, action: str, job_id: Optional[str], actor: Optional[str] = None, **details: Any
+    ) -> bool:
+        """Append one event, now; whether it was written."""
+        return bool(self.record_events(job_id, [{"action": action, **details}], actor=actor))
+
+    def record_access(self, job: Job, actor: Optional[str], via: str) -> None:
+        """Log that ``job``'s documents were returned to ``actor``, if it has any."""
+        if job.final_documents:
+            self.record(ARTIFACTS_ACCESSED, job.id, actor, via=via)
+
+    def search(
+        self,
+        job_id: Optional[str] = None,
+        actor: Optional[str] = None,
+        since: Optional[datetime] = None,
+        until: Optional[datetime] = None,
+        limit: Optional[int] = None,
+    ) -> list[dict[str, Any]]:
+        """Events, oldest first, filtered by job, actor and time, as JSONL lines hold them."""
+        clauses, params = [], []
+        for clause, value in (
+            ("job_id = %s", job_id),
+            ("actor = %s", actor),
+            ("at >= %s", since),
+            ("at < %s", until),
+        ):
+            if value is not None:
+                clauses.append(clause)
+                params.append(value)
+        where = f" WHERE {' AND '.join(clauses)}" if clauses else ""
+        bound = " LIMIT %s" if limit is not None else ""
+        with get_conn() as conn:
+            rows = conn.execute(
+                f"SELECT * FROM audit_log{where} ORDER BY id{bound}",
+                (*params, *([limit] if limit is not None else [])),
+            ).fetchall()
+        return [_event(row) for row in rows]
+
+
+def _event(row: Mapping[str, Any]) -> dict[str, Any]:
+    at = row["at"]
+    return {
+        "at": at.isoformat() if isinstance(at, datetime) else at,
+        "job_id": row["job_id"],
+        "actor": row["actor"],
+        "action": row["action"],
+        **(row["details"] or {}),
+    }
+
+
+def to_jsonl(events: Iterable[Mapping[str, Any]]) -> str:
+    """Events as JSONL, one object per line."""
+    return "".join(
+        json.dumps(dict(event), ensure_ascii=False, default=str) + "\n" for event in events
+    )
+
+
+audit_log = AuditLog()
@@ -0,0 +1,101 @@
+"""Scheduled cleanup: the server applies its retention policy on its own.
+
+Résumés, interview answers and transcripts are personal data that should not live
+forever. With a retention period set, the server runs a cleanup pass at startup and
+then every ``HYDRA_GC_INTERVAL_SECONDS`` (default a day; 0 turns it off):
+
+- after ``HYDRA_ARTIFACT_EXPIRE_DAYS``, a finished run's documents are purged, as by
+  ``POST /api/v1/admin/purge`` (services/admin.py): the run stays listed with its
+  job description and outcome;
+- after ``HYDRA_STATE_EXPIRE_DAYS``, the job goes altogether: its row, its Hydra
+  job, run, interview and artifact records, and its state snapshot.
+
+Both are recorded in the audit log (``purged``, ``deleted``; services/audit_log.py),
+which itself is kept. These are the periods ``hydra gc`` applies to local runs
+(runtime/crewai/cleanup.py).
+Every server instance runs the pass; it only touches finished jobs and repeating it
+changes nothing, so instances need not take turns.
+"""
+
+import asyncio
+import logging
+import os
+from datetime import datetime, timedelta
+from typing import Any, Optional
+
+from runtime.crewai.cleanup import CleanupPolicy, policy_from_env
+from web.backend.services import admin
+from web.backend.services.audit_log import DELETED, PURGED, audit_log
+from web.backend.services.hydra_db import hydra_db
+from web.backend.services.job_queue import job_queue
+from web.backend.services.storage import state_store_from_env
+
+logger = logging.getLogger(__name__)
+
+GC_INTERVAL_ENV = "HYDRA_GC_INTERVAL_SECONDS"
+DEFAULT_GC_INTERVAL = 86400.0
+
+
+def gc_interval_from_env() -> float:
+    """Seconds between cleanup passes (0: none)."""
+    try:
+        seconds = float(os.environ.get(GC_INTERVAL_ENV, DEFAULT_GC_INTERVAL))
+    except ValueError:
+        return DEFAULT_GC_INTERVAL
+    return max(seconds, 0.0)
+
+
+def delete_expired_jobs(days: int) -> list[str]:
+    """Delete the jobs that ended more than ``days`` ago; their ids."""
+    jobs = job_queue.finished_before(datetime.now() - timedelta(days=days), purged=True)
+    state_store = state_store_from_env()
+    for job in jobs:
+        if job.hydra_job_id:
+            hydra_db.delete_job(job.hydra_job_id)
+        if state_store is not None:
+            state_store.delete(job.id, job.owner)
+        job_queue.delete_job(job.id)
+        audit_log.record(DELETED, job.id, via="retention", older_than_days=days)
+    if jobs:
+        logger.info(f"Deleted {len(jobs)} job(s) older than {days} days")
+    return [job.id for job in jobs]
+
+
+def collect(policy: CleanupPolicy) -> dict[str, Any]:
+    """One cleanup pass: purge, then delete, what ``policy`` no longer keeps."""
+    result: dict[str, Any] = {"purged": [], "deleted": []}
+    # A job past its state period loses its documents first, wherever they are stored.
+    days = policy.artifact_days or policy.state_days
+    if days:
+        result["purged"] = admin.purge_artifacts(days)["job_ids"]
+        for job_id in result["purged"]:
+            audit_log.record(PURGED, job_id, via="retention", older_than_days=days)
+    if policy.state_days:
+        result["deleted"] = delete_expired_jobs(policy.state_days)
+    return result
+
+
+async def _cleanup_loop(policy: CleanupPolicy, interval: float) -> None:
+    while True:
+        try:
+            await asyncio.to_thread(collect, policy)
+        except Exception as exc:
+            logger.error(f"Cleanup pass failed: {exc}")
+        await asyncio.sleep(interval)
+
+
+def start_cleanup() -> Optional[asyncio.Task]:
+    """Start the scheduled cleanup, if a retention period is set; its task, else None."""
+    try:
+        policy = policy_from_env()
+    except ValueError as exc:
+        logger.error(f"Retention policy not applied: {exc}")
+        return None
+    interval = gc_interval_from_env()
+    if policy.empty or not interval:
+        return None
+    logger.info(
+        f"Cleanup every {interval:.0f}s: documents after {policy.artifact_days} days, "
+        f"jobs after {policy.state_days} days"
+    )
+    return asyncio.create_task(_cleanup_loop(policy, interval))
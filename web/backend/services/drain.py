@@ -0,0 +1,160 @@
+"""Drain mode: let a deploy replace the server without losing in-flight workflows.
+
+A container orchestrator stops a pod with SIGTERM, waits a grace period, then kills
+it. On SIGTERM the server:
+
+1. stops accepting runs — creating a job, greenlighting or answering the interview
+   get 503, and ``/health`` reports ``draining`` so the load balancer moves on;
+2. asks every workflow in flight to stop at its next stage boundary: the stage
+   under way finishes and is kept (``HydraWorkflow.stop_after_stage``);
+3. waits for those runs to return INTERRUPTED and be persisted with their completed
+   stages (job state ``interrupted``) — up to ``HYDRA_DRAIN_TIMEOUT`` seconds, after
+   which the stage still running is abandoned rather than waited for;
+4. hands SIGTERM back to the ASGI server, which shuts down as usual.
+
+The next server to start resumes every ``interrupted`` job from its checkpoint
+(``resume_interrupted_jobs``). A second SIGTERM skips the wait.
+"""
+
+import asyncio
+import logging
+import os
+import signal
+import time
+from threading import Lock
+from typing import Any, Callable, Optional
+
+logger = logging.getLogger(__name__)
+
+DRAIN_TIMEOUT_ENV = "HYDRA_DRAIN_TIMEOUT"
+DEFAULT_DRAIN_TIMEOUT = 60.0
+# After the timeout, how long abandoned runs get to return and be persisted.
+CANCEL_GRACE_SECONDS = 5.0
+POLL_SECONDS = 0.2
+
+DRAIN_REASON = "server draining for shutdown"
+
+
+def drain_timeout_from_env() -> float:
+    """Seconds to wait for in-flight stages (``HYDRA_DRAIN_TIMEOUT``, default 60)."""
+    try:
+        return float(os.environ.get(DRAIN_TIMEOUT_ENV, DEFAULT_DRAIN_TIMEOUT))
+    except ValueError:
+        return DEFAULT_DRAIN_TIMEOUT
+
+
+class Drain:
+    """Tracks the workflows in flight and whether the server is draining."""
+
+    def __init__(self) -> None:
+        self._lock = Lock()
+        self._live: dict[str, Any] = {}  # job id -> HydraWorkflow
+        self._draining = False
+
+    @property
+    def draining(self) -> bool:
+        return self._draining
+
+    def live_jobs(self) -> list[str]:
+        with self._lock:
+            return list(self._live)
+
+    def workflow_for(self, job_id: str) -> Optional[Any]:
+        """The workflow running ``job_id`` in this process, or None."""
+        with self._lock:
+            return self._live.get(job_id)
+
+    def register(self, job_id: str, workflow: Any) -> None:
+        """Track a workflow about to run until ``unregister``."""
+        with self._lock:
+            self._live[job_id] = workflow
+
+    def unregister(self, job_id: str) -> None:
+        with self._lock:
+            self._live.pop(job_id, None)
+
+    def reset(self) -> None:
+        """Accept runs again: an app started anew in the same process (tests, reloads)."""
+        self._draining = False
+
+    def begin(self) -> None:
+        """Refuse new runs and ask every live workflow to stop at its next stage."""
+        self._draining = True
+        for workflow in self._workflows():
+            workflow.stop_after_stage(DRAIN_REASON)
+
+    async def drain(self, timeout: Optional[float] = None) -> list[str]:
+        """Drain and wait for live runs to finish; the job ids still running after it."""
+        self.begin()
+        timeout = drain_timeout_from_env() if timeout is None else timeout
+        if await self._wait(timeout):
+            return []
+        logger.warning(
+            "Drain timed out after %.0fs; abandoning the stages in flight for %s",
+            timeout,
+            ", ".join(self.live_jobs()),
+        )
+        for workflow in self._workflows():
+            workflow.cancel(DRAIN_REASON)
+        await self._wait(CANCEL_GRACE_SECONDS)
+        return self.live_jobs()
+
+    async def _wait(self, timeout: float) -> bool:
+        deadline = time.monotonic() + timeout
+        while self.live_jobs():
+            if time.monotonic() >= deadline:
+                return False
+            # Re-sent every poll: a run registered just before it began gets a fresh
+            # cancel token when ``execute`` starts, and has to be told again.
+            for workflow in self._workflows():
+                workflow.stop_after_stage(DRAIN_REASON)
+            await asyncio.sleep(POLL_SECONDS)
+        return True
+
+    def _workflows(self) -> list[Any]:
+        with self._lock:
+            return list(self._live.values())
+
+    def install_signal_handler(self, loop: asyncio.AbstractEventLoop) -> None:
+        """Drain on SIGTERM, then pass the signal on to the previous handler.
+
+        The previous handler is the ASGI server's own (uvicorn's graceful shutdown),
+        so the server exits only once the in-flight runs are checkpointed.
+        """
+        try:
+            previous = signal.getsignal(signal.SIGTERM)
+        except ValueError:  # not the main thread
+            return
+
+        def _forward(signum: int, frame: Any) -> None:
+            if callable(previous):
+                previous(signum, frame)
+            else:
+                # No Python-level handler to defer to: take the default action.
+                signal.signal(signum, signal.SIG_DFL)
+                os.kill(os.getpid(), signum)
+
+        def _on_sigterm(signum: int, frame: Any) -> None:
+            if self._draining:
+                _forward(signum, frame)  # a second SIGTERM: stop waiting
+                return
+            logger.info("SIGTERM: draining %d in-flight run(s)", len(self.live_jobs()))
+            self.begin()
+            loop.call_soon_threadsafe(
+                lambda: loop.create_task(self._drain_then(_forward, signum, frame))
+            )
+
+        try:
+            signal.signal(signal.SIGTERM, _on_sigterm)
+        except ValueError:
+            return
+
+    async def _drain_then(self, forward: Callable[[int, Any], None], signum: int, frame: Any):
+        remaining = await self.drain()
+        if remaining:
+            logger.error("Shutting down with runs still in flight: %s", ", ".join(remaining))
+        forward(signum, frame)
+
+
+# Global drain state, shared by the runner, the routes and the app lifecycle.
+drain = Drain()
@@ -0,0 +1,315 @@
+"""Execution backends: where a job's workflow actually runs.
+
+``inprocess`` (the default) runs workflows on the API server's thread pool. At
+scale, teams want every run isolated with its own CPU and memory limits, so
+``HYDRA_EXECUTION_BACKEND`` can move each workflow into its own container:
+
+- ``docker`` — ``docker run`` of ``HYDRA_WORKER_IMAGE`` per job;
+- ``kubernetes`` — a ``batch/v1`` Job per job, applied with ``kubectl``.
+
+The container runs ``python -m web.backend.worker <job_id>``: it loads the job from
+Postgres, runs the workflow, and writes its progress and result back to the same
+row. Postgres is the only coordination point — the API server never talks to the
+container. It watches the row instead (``watch_job``) and turns the changes into
+the usual SSE events, and it asks the backend whether the container is still
+alive, so a worker that dies without writing a result fails the job rather than
+leaving it running forever.
+
+Resource limits come from ``HYDRA_WORKER_CPUS`` (default 1), ``HYDRA_WORKER_MEMORY``
+(Kubernetes quantity, default 2Gi) and ``HYDRA_WORKER_TIMEOUT`` seconds (default
+3600). Containers are named ``hydra-job-<job id>``. Docker workers get the database
+URL and provider keys passed through from the server's environment; Kubernetes
+workers read theirs from the Secret named by ``HYDRA_K8S_ENV_SECRET``.
+
+Paused runs end their container: approving the greenlight or sending interview
+answers starts a new one, which resumes from the stored intermediate results. A
+worker stopped with SIGTERM (a node drain, a cancelled Job) checkpoints at the next
+stage boundary like the API server does (see services/drain.py).
+"""
+
+import asyncio
+import json
+import logging
+import os
+import re
+import subprocess
+import time
+from datetime import datetime
+from typing import Any, Optional
+
+from runtime.crewai.model_config import PROVIDER_ENV_KEYS
+from runtime.crewai.rate_limit import RATE_LIMITS_ENV
+from runtime.crewai.retention import RETENTION_ENV
+from web.backend.models import JobState
+from web.backend.services.job_queue import Job, job_queue
+from web.backend.services.storage import (
+    ARTIFACTS_DIR_ENV,
+    OBJECT_STORE_CREDENTIAL_ENV,
+    S3_ENDPOINT_ENV,
+    STORE_ENV,
+)
+
+logger = logging.getLogger(__name__)
+
+EXECUTION_BACKEND_ENV = "HYDRA_EXECUTION_BACKEND"
+INPROCESS = "inprocess"
+DOCKER = "docker"
+KUBERNETES = "kubernetes"
+BACKENDS = (INPROCESS, DOCKER, KUBERNETES)
+
+WORKER_IMAGE_ENV = "HYDRA_WORKER_IMAGE"
+WORKER_CPUS_ENV = "HYDRA_WORKER_CPUS"
+WORKER_MEMORY_ENV = "HYDRA_WORKER_MEMORY"
+WORKER_TIMEOUT_ENV = "HYDRA_WORKER_TIMEOUT"
+K8S_NAMESPACE_ENV = "HYDRA_K8S_NAMESPACE"
+K8S_ENV_SECRET_ENV = "HYDRA_K8S_ENV_SECRET"
+
+DEFAULT_IMAGE = "composable-me:latest"
+DEFAULT_CPUS = "1"
+DEFAULT_MEMORY = "2Gi"
+DEFAULT_TIMEOUT = 3600
+WATCH_SECONDS = 1.0
+WORKER_COMMAND = ["python", "-m", "web.backend.worker"]
+
+# Passed through to docker workers (names only: docker reads the values itself).
+WORKER_ENV = [
+    "HYDRA_DATABASE_URL",
+    ARTIFACTS_DIR_ENV,
+    STORE_ENV,
+    S3_ENDPOINT_ENV,
+    *OBJECT_STORE_CREDENTIAL_ENV,
+    RATE_LIMITS_ENV,
+    RETENTION_ENV,
+    *PROVIDER_ENV_KEYS.values(),
+    "TOGETHER_MODEL",
+    "CHUTES_MODEL",
+    "OPENROUTER_MODEL",
+]
+
+# Job states after which a container has nothing left to do.
+_SETTLED = (JobState.COMPLETED, JobState.FAILED, JobState.INTERRUPTED)
+
+
+class ExecutionBackendError(RuntimeError):
+    """Raised when a worker container cannot be started or the backend is misconfigured."""
+
+    pass
+
+
+def worker_name(job_id: str) -> str:
+    """Container / Kubernetes Job name for a job (DNS-1123: lowercase, <= 63 chars)."""
+    return f"hydra-job-{re.sub(r'[^a-z0-9-]', '-', job_id.lower())}"[:63].rstrip("-")
+
+
+def _docker_memory(quantity: str) -> str:
+    """Kubernetes memory quantity (2Gi, 512Mi) in docker's notation (2g, 512m)."""
+    match = re.fullmatch(r"(\d+)([KMG])i?", quantity.strip())
+    return f"{match.group(1)}{match.group(2).lower()}" if match else quantity
+
+
+def _run(command: list[str], stdin: Optional[str] = None) -> subprocess.CompletedProcess:
+    try:
+        return subprocess.run(command, input=stdin, capture_output=True, text=True, timeout=30)
+    except (OSError, subprocess.SubprocessError) as e:
+        raise ExecutionBackendError(f"{command[0]} failed: {e}") from e
+
+
+class ContainerBackend:
+    """A backend that runs each job's workflow in a container of its own."""
+
+    name = ""
+
+    def __init__(
+        self,
+        image: str = DEFAULT_IMAGE,
+        cpus: str = DEFAULT_CPUS,
+        memory: str = DEFAULT_MEMORY,
+        timeout: int = DEFAULT_TIMEOUT,
+    ):
+        self.image = image
+        self.cpus = cpus
+        self.memory = memory
+        self.timeout = timeout
+
+    def launch(self, job_id: str) -> None:
+        raise NotImplementedError
+
+    def running(self, job_id: str) -> bool:
+        """Whether the job's worker is still starting or running."""
+        raise NotImplementedError
+
+    def stop(self, job_id: str) -> None:
+        raise NotImplementedError
+
+
+class DockerBackend(ContainerBackend):
+    """``docker run`` per job, with CPU and memory limits."""
+
+    name = DOCKER
+
+    def command(self, job_id: str) -> list[str]:
+        command = ["docker", "run", "--detach", "--rm", "--name", worker_name(job_id)]
+        command += ["--cpus", self.cpus, "--memory", _docker_memory(self.memory)]
+        command += ["--stop-timeout", "60", "--label", f"hydra.job={job_id}"]
+        for name in WORKER_ENV:
+            if name in os.environ:
+                command += ["--env", name]  # value read from this process's env
+        return command + [self.image, *WORKER_COMMAND, job_id]
+
+    def launch(self, job_id: str) -> None:
+        _run(["docker", "rm", "--force", worker_name(job_id)])  # a previous run's, if any
+        result = _run(self.command(job_id))
+        if result.returncode != 0:
+            raise ExecutionBackendError(f"docker run failed: {result.stderr.strip()}")
+
+    def running(self, job_id: str) -> bool:
+        # Started with --rm: a worker that exited is gone.
+        result = _run(["docker", "inspect", "--format", "{{.State.Status}}", worker_name(job_id)])
+        return result.returncode == 0 and result.stdout.strip() in ("created", "running")
+
+    def stop(self, job_id: str) -> None:
+        _run(["docker", "stop", worker_name(job_id)])
+
+
+class KubernetesBackend(ContainerBackend):
+    """A ``batch/v1`` Job per job, with resource requests, limits and a deadline."""
+
+    name = KUBERNETES
+
+    def __init__(self, namespace: str = "default", env_secret: str = "hydra-env", **kwargs):
+        super().__init__(**kwargs)
+        self.namespace = namespace
+        self.env_secret = env_secret
+
+    def manifest(self, job_id: str) -> dict[str, Any]:
+        resources = {"cpu": self.cpus, "memory": self.memory}
+        return {
+            "apiVersion": "batch/v1",
+            "kind": "Job",
+            "metadata": {
+                "name": worker_name(job_id),
+                "namespace": self.namespace,
+                "labels": {"app": "hydra-worker", "hydra.job": worker_name(job_id)},
+            },
+            "spec": {
+                "backoffLimit": 0,  # a failed run is reported, not silently retried
+                "activeDeadlineSeconds": self.timeout,
+                "ttlSecondsAfterFinished": 3600,
+                "template": {
+                    "metadata": {"labels": {"app": "hydra-worker"}},
+                    "spec": {
+                        "restartPolicy": "Never",
+                        "terminationGracePeriodSeconds": 90,
+                        "containers": [
+                            {
+                                "name": "worker",
+                                "image": self.image,
+                                "args": [*WORKER_COMMAND, job_id],
+                                "envFrom": [{"secretRef": {"name": self.env_secret}}],
+                                "resources": {"requests": resources, "limits": resources},
+                            }
+                        ],
+                    },
+                },
+            },
+        }
+
+    def launch(self, job_id: str) -> None:
+        # A Job runs once: the one from before a pause must go for the resumed run.
+        self.stop(job_id)
+        result = _run(["kubectl", "apply", "-f", "-"], stdin=json.dumps(self.manifest(job_id)))
+        if result.returncode != 0:
+            raise ExecutionBackendError(f"kubectl apply failed: {result.stderr.strip()}")
+
+    def running(self, job_id: str) -> bool:
+        status = "jsonpath={.status.succeeded}/{.status.failed}"
+        command = ["kubectl", "get", "job", worker_name(job_id), "--namespace", self.namespace]
+        result = _run(command + ["--output", status])
+        if result.returncode != 0:
+            return False  # deleted, or expired by ttlSecondsAfterFinished
+        # Counts are omitted until a pod finishes.
+        return not any(count not in ("", "0") for count in result.stdout.strip().split("/"))
+
+    def stop(self, job_id: str) -> None:
+        command = ["kubectl", "delete", "job", worker_name(job_id), "--namespace", self.namespace]
+        _run(command + ["--ignore-not-found", "--wait"])
+
+
+def backend_from_env() -> Optional[ContainerBackend]:
+    """The configured container backend, or None to run workflows in process."""
+    name = os.environ.get(EXECUTION_BACKEND_ENV, INPROCESS).strip().lower() or INPROCESS
+    if name not in BACKENDS:
+        raise ExecutionBackendError(
+            f"Unknown {EXECUTION_BACKEND_ENV} '{name}' (one of {', '.join(BACKENDS)})"
+        )
+    if name == INPROCESS:
+        return None
+    options = {
+        "image": os.environ.get(WORKER_IMAGE_ENV, DEFAULT_IMAGE),
+        "cpus": os.environ.get(WORKER_CPUS_ENV, DEFAULT_CPUS),
+        "memory": os.environ.get(WORKER_MEMORY_ENV, DEFAULT_MEMORY),
+        "timeout": int(os.environ.get(WORKER_TIMEOUT_ENV, DEFAULT_TIMEOUT)),
+    }
+    if name == DOCKER:
+        return DockerBackend(**options)
+    return KubernetesBackend(
+        namespace=os.environ.get(K8S_NAMESPACE_ENV, "default"),
+        env_secret=os.environ.get(K8S_ENV_SECRET_ENV, "hydra-env"),
+        **options,
+    )
+
+
+async def watch_job(job: Job, backend: ContainerBackend) -> None:
+    """Relay a container-run job's progress from the store to its SSE listeners.
+
+    Ends when the job completes, fails, pauses for input or is interrupted — or
+    when its worker is gone without having said so, which fails the job.
+    """
+    last_state = job.state
+    last_log_length = len(job.execution_log)
+    emitted = set(job.intermediate_results)
+    deadline = time.monotonic() + backend.timeout + 60
+
+    while True:
+        await asyncio.sleep(WATCH_SECONDS)
+        alive = await asyncio.to_thread(backend.running, job.id)
+        job = job_queue.refresh_job(job.id) or job
+
+        if job.state != last_state:
+            last_state = job.state
+            await job.emit_event(
+                "progress",
+                {
+                    "state": job.state.value,
+                    "progress": job.get_progress_percent(),
+                    "agent_models": job.agent_models,
+                },
+            )
+        for entry in job.execution_log[last_log_length:]:
+            await job.emit_event("log", {"message": entry})
+        last_log_length = len(job.execution_log)
+        for stage, stage_result in job.intermediate_results.items():
+            if stage not in emitted:
+                emitted.add(stage)
+                await job.emit_event("stage_complete", {"stage": stage, "result": stage_result})
+
+        if job.state in _SETTLED or job.awaiting_user is not None:
+            break
+        if not alive or time.monotonic() > deadline:
+            reason = "exited" if not alive else "timed out"
+            logger.error(f"Worker for job {job.id} {reason} without a result")
+            if alive:
+                await asyncio.to_thread(backend.stop, job.id)
+            job = job_queue.update_job(
+                job.id,
+                state=JobState.FAILED,
+                success=False,
+                completed_at=datetime.now(),
+                error_message=f"Worker {worker_name(job.id)} {reason} without a result",
+            )
+            break
+
+    if job.state in (JobState.COMPLETED, JobState.FAILED):
+        await job.emit_event("complete", job.get_complete_event_payload())
+    elif job.awaiting_user is not None:
+        await job.emit_event("awaiting_user", job.get_awaiting_event_payload())
@@ -1,20 +1,43 @@
-"""Postgres-backed persistence for Hydra core artifacts."""
+"""Postgres-backed persistence for Hydra core artifacts.
+
+Job descriptions, interviews and artifact contents are sealed like the job queue's
+columns when ``HYDRA_ENCRYPT_STATE`` is on (see services/job_queue.py), and opened
+again when read.
+"""
 
 from __future__ import annotations
 
 from dataclasses import dataclass
-from pathlib import Path
 from typing import Any, Optional
 
 from psycopg.types.json import Json
 
+from runtime.crewai.encryption import open_json, open_text, seal_json, seal_text
 from web.backend.db import get_conn
+from web.backend.services.storage import ArtifactStore
+
+
+# The columns holding the candidate's content, sealed at rest.
+_SEALED_TEXT = ("jd_text", "content")
+_SEALED_JSON = ("questions", "answers", "structured_notes")
+
+
+def _opened(row: Any) -> dict[str, Any]:
+    """``row`` as a dict, with its sealed columns opened."""
+    opened = dict(row)
+    for column in _SEALED_TEXT:
+        if column in opened:
+            opened[column] = open_text(opened[column])
+    for column in _SEALED_JSON:
+        if column in opened:
+            opened[column] = open_json(opened[column])
+    return opened
 
 
 @dataclass(frozen=True)
 class ArtifactWriteResult:
     db_row: dict[str, Any]
-    file_path: Optional[Path]
+    location: str  # a path, or an s3:// / gs:// URL (see services/storage.py)
 
 
 class HydraDB:
@@ -71,10 +94,10 @@ class HydraDB:
                 VALUES (%s, %s)
                 RETURNING *
                 """,
-                (job_id, jd_text),
+                (job_id, seal_text(jd_text)),
             ).fetchone()
             conn.commit()
-            return dict(row)
+            return _opened(row)
 
     def list_job_descriptions(self, job_id: str) -> list[dict[str, Any]]:
         with get_conn() as conn:
@@ -82,7 +105,7 @@ class HydraDB:
                 "SELECT * FROM job_descriptions WHERE job_id = %s ORDER BY created_at",
                 (job_id,),
             ).fetchall()
-            return [dict(row) for row in rows]
+            return [_opened(row) for row in rows]
 
     def create_run(
         self,
@@ -162,13 +185,13 @@ class HydraDB:
                 """,
                 (
                     run_id,
-                    Json(questions),
-                    Json(answers),
-                    Json(structured_notes),
+                    Json(seal_json(questions)),
+                    Json(seal_json(answers)),
+                    Json(seal_json(structured_notes)),
                 ),
             ).fetchone()
             conn.commit()
-            return dict(row)
+            return _opened(row)
 
     def list_interviews(self, run_id: str) -> list[dict[str, Any]]:
         with get_conn() as conn:
@@ -176,7 +199,7 @@ class HydraDB:
                 "SELECT * FROM interviews WHERE run_id = %s ORDER BY created_at",
                 (run_id,),
             ).fetchall()
-            return [dict(row) for row in rows]
+            return [_opened(row) for row in rows]
 
     def create_artifact(
         self,
@@ -196,12 +219,12 @@ class HydraDB:
                 (
                     run_id,
                     kind,
-                    content,
+                    seal_text(content),
                     Json(metadata) if metadata is not None else None,
                 ),
             ).fetchone()
             conn.commit()
-            return dict(row)
+            return _opened(row)
 
     def list_artifacts(self, run_id: str) -> list[dict[str, Any]]:
         with get_conn() as conn:
@@ -209,33 +232,34 @@ class HydraDB:
                 "SELECT * FROM artifacts WHERE run_id = %s ORDER BY created_at",
                 (run_id,),
             ).fetchall()
-            return [dict(row) for row in rows]
+            return [_opened(row) for row in rows]
 
-    def write_artifact_to_disk(
-        self,
-        *,
-        base_dir: Path,
-        company: str,
-        role_title: str,
-        run_id: str,
-        kind: str,
-        content: str,
-    ) -> Path:
-        """Write artifact content to disk and return the file path."""
-        safe_company = company.strip().replace(" ", "_")
-        safe_role = role_title.strip().replace(" ", "_")
-        output_dir = base_dir / safe_company / safe_role / run_id
-        output_dir.mkdir(parents=True, exist_ok=True)
+    def delete_artifacts(self, run_id: str) -> int:
+        """Delete the run's artifact records; how many there were."""
+        with get_conn() as conn:
+            cursor = conn.execute("DELETE FROM artifacts WHERE run_id = %s", (run_id,))
+            conn.commit()
+            return cursor.rowcount
+
+    def delete_interviews(self, run_id: str) -> int:
+        """Delete the run's interview questions and answers; how many there were."""
+        with get_conn() as conn:
+            cursor = conn.execute("DELETE FROM interviews WHERE run_id = %s", (run_id,))
+            conn.commit()
+            return cursor.rowcount
 
-        filename = f"{kind}.md"
-        path = output_dir / filename
-        path.write_text(content)
-        return path
+    def delete_job(self, job_id: str) -> bool:
+        """Delete a job with its description, runs, interviews and artifact records."""
+        with get_conn() as conn:
+            cursor = conn.execute("DELETE FROM jobs WHERE id = %s", (job_id,))
+            conn.commit()
+            return cursor.rowcount > 0
 
-    def create_artifact_with_disk_write(
+    def create_stored_artifact(
         self,
         *,
-        base_dir: Path,
+        store: ArtifactStore,
+        owner: Optional[str],
         company: str,
         role_title: str,
         run_id: str,
@@ -243,9 +267,9 @@ class HydraDB:
         content: str,
         metadata: Optional[dict[str, Any]] = None,
     ) -> ArtifactWriteResult:
-        """Write artifact to disk and persist a DB record with the path in metadata."""
-        file_path = self.write_artifact_to_disk(
-            base_dir=base_dir,
+        """Write artifact to the store and persist a DB record with its location in metadata."""
+        location = store.write(
+            owner=owner,
             company=company,
             role_title=role_title,
             run_id=run_id,
@@ -253,7 +277,7 @@ class HydraDB:
             content=content,
         )
         stored_metadata = dict(metadata or {})
-        stored_metadata.setdefault("path", str(file_path))
+        stored_metadata.setdefault("path", location)
 
         row = self.create_artifact(
             run_id=run_id,
@@ -261,7 +285,7 @@ class HydraDB:
             content=content,
             metadata=stored_metadata,
         )
-        return ArtifactWriteResult(db_row=row, file_path=file_path)
+        return ArtifactWriteResult(db_row=row, location=location)
 
 
 hydra_db = HydraDB()
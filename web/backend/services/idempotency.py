@@ -0,0 +1,115 @@
+"""Idempotent job creation: a request sent twice starts one run, not two.
+
+A run costs real money in model calls, and a double-clicked button, a client retrying
+after a timeout or a resubmitted form would otherwise start it again. Creating a job
+first looks for the one an earlier request already created, and answers with it
+(status ``duplicate``) instead of starting another run:
+
+- ``Idempotency-Key`` header (gRPC: ``idempotency-key`` metadata): a key the client
+  picks per request, e.g. a UUID. The same key again returns the job it created, for
+  as long as the job is kept. Keys are the caller's own (per API user), and reusing
+  one for a different request is refused (422) rather than answered with the wrong
+  job. A request with a key is deduplicated by its key only, so a client sending
+  keys can start the same run twice on purpose by using a new key;
+- otherwise, a hash of what the run works on — job description, résumé, sources,
+  model and audit retries. The same inputs again from the same user within
+  ``HYDRA_DUPLICATE_WINDOW_SECONDS`` (default 600; 0 turns this off) return that
+  job, unless it failed or was cancelled.
+
+A server checks and creates under one lock, so two identical requests reaching the
+same instance at once still start one run. Across instances the unique index on
+(owner, key) settles a race on a key; two keyless requests racing on two instances
+can both start.
+"""
+
+import hashlib
+import json
+import os
+from datetime import datetime, timedelta
+from threading import Lock
+from typing import Callable, Optional
+
+from psycopg.errors import UniqueViolation
+
+from web.backend.services.job_queue import Job, job_queue
+
+IDEMPOTENCY_HEADER = "Idempotency-Key"
+DUPLICATE_WINDOW_ENV = "HYDRA_DUPLICATE_WINDOW_SECONDS"
+DEFAULT_DUPLICATE_WINDOW = 600.0
+MAX_KEY_LENGTH = 255
+
+_create_lock = Lock()
+
+
+class IdempotencyError(ValueError):
+    """The Idempotency-Key is malformed, or was used for a different request."""
+
+
+def request_hash(
+    job_description: str,
+    resume: str,
+    source_documents: str = "",
+    model: Optional[str] = None,
+    max_audit_retries: int = 2,
+) -> str:
+    """A digest of the inputs a run depends on (surrounding whitespace ignored)."""
+    inputs = [
+        job_description.strip(),
+        resume.strip(),
+        source_documents.strip(),
+        model,
+        max_audit_retries,
+    ]
+    return hashlib.sha256(json.dumps(inputs).encode("utf-8")).hexdigest()
+
+
+def duplicate_window_from_env() -> float:
+    """Seconds an identical request returns the earlier job (0: never)."""
+    try:
+        seconds = float(os.environ.get(DUPLICATE_WINDOW_ENV, DEFAULT_DUPLICATE_WINDOW))
+    except ValueError:
+        return DEFAULT_DUPLICATE_WINDOW
+    return max(seconds, 0.0)
+
+
+def check_key(key: Optional[str]) -> Optional[str]:
+    """The Idempotency-Key as sent, or None without one; IdempotencyError if malformed."""
+    if key is None:
+        return None
+    key = key.strip()
+    if not key or len(key) > MAX_KEY_LENGTH:
+        raise IdempotencyError(f"{IDEMPOTENCY_HEADER} must be 1 to {MAX_KEY_LENGTH} characters")
+    return key
+
+
+def find_existing(owner: Optional[str], key: Optional[str], digest: str) -> Optional[Job]:
+    """The job an earlier request with this key, or these inputs, created; else None."""
+    if key is not None:
+        job = job_queue.find_by_idempotency_key(owner, key)
+        if job is not None and job.request_hash != digest:
+            raise IdempotencyError(
+                f"{IDEMPOTENCY_HEADER} {key!r} was already used for a different request"
+            )
+        return job
+    window = duplicate_window_from_env()
+    if not window:
+        return None
+    return job_queue.find_duplicate(owner, digest, datetime.now() - timedelta(seconds=window))
+
+
+def create_once(
+    owner: Optional[str], key: Optional[str], digest: str, create: Callable[[], Job]
+) -> tuple[Job, bool]:
+    """The earlier request's job and False, or the one ``create`` makes and True."""
+    with _create_lock:
+        existing = find_existing(owner, key, digest)
+        if existing is not None:
+            return existing, False
+        try:
+            return create(), True
+        except UniqueViolation:
+            # Another instance created the job for this key in the meantime.
+            existing = find_existing(owner, key, digest)
+            if existing is None:
+                raise
+            return existing, False
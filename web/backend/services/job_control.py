@@ -0,0 +1,78 @@
+"""Pause, resume and cancel a job's workflow, for the REST and gRPC APIs.
+
+- pause: a running job stops at its next stage boundary — the stage under way
+  finishes and is kept — and is persisted as ``paused``. A job with no live run
+  (``interrupted`` by a drain, or left mid-stage by a crashed server) becomes
+  ``paused`` at once, so no restart resumes it behind the user's back.
+- resume: a ``paused`` or ``interrupted`` job restarts from its checkpoint; the
+  stages it completed are not run again.
+- cancel: a running job stops now, abandoning the stage under way (a job in a
+  worker container stops at its next boundary); a job with no live run ends at
+  once. ``cancelled`` is final.
+
+Each returns the reply both APIs send: ``{"job_id", "status", "message"}``, status
+"pausing"/"cancelling" while a live run winds down, "paused", "resumed" or
+"cancelled" when done, "noop" if the job already was where it was asked to go.
+"""
+
+import asyncio
+
+from web.backend.models import JobState
+from web.backend.services.job_queue import Job, job_queue
+from web.backend.services.workflow_runner import (
+    request_stop,
+    settle_stopped,
+    start_workflow_background,
+)
+
+# States a job does not leave: nothing to pause, resume or cancel.
+FINISHED = (JobState.COMPLETED, JobState.FAILED, JobState.CANCELLED)
+RESUMABLE = (JobState.PAUSED, JobState.INTERRUPTED)
+
+
+class JobControlError(ValueError):
+    """The job is in a state the action does not apply to."""
+
+
+def _reply(job: Job, status: str, message: str) -> dict:
+    return {"job_id": job.id, "status": status, "message": message}
+
+
+def _check_not_finished(job: Job, action: str) -> None:
+    if job.state in FINISHED:
+        raise JobControlError(f"Cannot {action} a {job.state.value} job")
+
+
+async def pause_job(job: Job) -> dict:
+    """Pause ``job`` at its next stage boundary (see the module docstring)."""
+    _check_not_finished(job, "pause")
+    if job.state == JobState.PAUSED:
+        return _reply(job, "noop", "Job is already paused")
+    if job.awaiting_user is not None:
+        return _reply(job, "noop", f"Job is already paused awaiting {job.awaiting_user}")
+    if await asyncio.to_thread(request_stop, job.id, JobState.PAUSED):
+        return _reply(job, "pausing", "Job will pause when its current stage finishes")
+    await settle_stopped(job, JobState.PAUSED)
+    return _reply(job, "paused", "Job paused")
+
+
+async def resume_job(job: Job) -> dict:
+    """Restart a paused or interrupted ``job`` from its checkpoint."""
+    if job.state not in RESUMABLE:
+        if job.awaiting_user is not None:
+            raise JobControlError(f"Job is awaiting {job.awaiting_user}, not paused")
+        raise JobControlError(f"Job is not paused (current: {job.state.value})")
+    job = job_queue.update_job(job.id, state=JobState.INITIALIZED, error_message=None)
+    start_workflow_background(job)
+    return _reply(job, "resumed", "Job resumed from its last completed stage")
+
+
+async def cancel_job(job: Job) -> dict:
+    """Cancel ``job``: a live run is stopped, any other ends at once."""
+    if job.state == JobState.CANCELLED:
+        return _reply(job, "noop", "Job is already cancelled")
+    _check_not_finished(job, "cancel")
+    if await asyncio.to_thread(request_stop, job.id, JobState.CANCELLED):
+        return _reply(job, "cancelling", "Job is being cancelled")
+    await settle_stopped(job, JobState.CANCELLED)
+    return _reply(job, "cancelled", "Job cancelled")
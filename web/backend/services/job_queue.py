@@ -2,6 +2,12 @@
 
 Jobs are persisted to Postgres and survive server restarts.
 SSE event queues remain in-memory (ephemeral by design).
+
+With ``HYDRA_ENCRYPT_STATE`` on, the columns holding the candidate's content — the
+job description, résumé, sources, interview answers, greenlight notes, log and
+every result — are written sealed with the ``HYDRA_STATE_PASSPHRASE`` key (see
+runtime.crewai.encryption); the rest (state, owner, timestamps, cost) stays queryable.
+Rows written in the clear still load.
 """
 
 import asyncio
@@ -14,8 +20,11 @@ from typing import Any, Optional
 
 from psycopg.types.json import Json
 
+from runtime.crewai.encryption import open_json, open_text, seal_json, seal_text
+from runtime.crewai.state_schema import STATE_VERSION, upgrade_state
 from web.backend.db import get_conn
 from web.backend.models import JobState
+from web.backend.services.leases import LeaseLostError, leases
 
 # NOTE: schema migrations run at application startup (see web/backend/app.py
 # `on_startup`). Importing this module must not open a database connection —
@@ -27,6 +36,8 @@ class Job:
     """Represents a job in the queue."""
 
     id: str
+    # The API user the job belongs to (web/backend/auth.py); None with auth off.
+    owner: Optional[str] = None
     company: Optional[str] = None
     role_title: Optional[str] = None
     source: Optional[str] = None
@@ -45,24 +56,38 @@ class Job:
     source_documents: str = ""
     model: Optional[str] = None
     max_audit_retries: int = 2
+    # The creating request's Idempotency-Key and content hash (services/idempotency.py).
+    idempotency_key: Optional[str] = None
+    request_hash: Optional[str] = None
 
     # Results
     final_documents: Optional[dict[str, str]] = None
     audit_report: Optional[dict[str, Any]] = None
     executive_brief: Optional[dict[str, Any]] = None
     intermediate_results: dict[str, Any] = field(default_factory=dict)
+    # Schema version of intermediate_results; loaded rows are upgraded to the current one.
+    state_version: int = STATE_VERSION
     execution_log: list[str] = field(default_factory=list)
     error_message: Optional[str] = None
     audit_failed: bool = False
     audit_error: Optional[str] = None
     agent_models: dict[str, str] = field(default_factory=dict)
+    # Estimated cost of the job's model calls across all its runs, for user budgets.
+    cost_usd: float = 0.0
+    # When its documents were deleted under the retention policy (services/admin.py).
+    artifacts_purged_at: Optional[datetime] = None
 
     # User inputs for resume
     gap_analysis_approved: bool = False
     interview_answers: list[dict[str, Any]] = field(default_factory=list)
+    greenlight_notes: Optional[str] = None
+    # Which human input a paused job is waiting for (AwaitingInput value), else None.
+    awaiting_user: Optional[str] = None
 
     # For SSE updates (in-memory only, not persisted)
     _event_queue: asyncio.Queue = field(default_factory=asyncio.Queue, repr=False)
+    # Each workflow events stream's own copy of every event (see subscribe).
+    _subscribers: list[asyncio.Queue] = field(default_factory=list, repr=False)
 
     def get_progress_percent(self) -> int:
         """Calculate progress percentage based on current state."""
@@ -79,12 +104,30 @@ class Job:
             JobState.EXECUTIVE_SYNTHESIS: 95,
             JobState.COMPLETED: 100,
             JobState.FAILED: 100,
+            JobState.CANCELLED: 100,
         }
         return stage_progress.get(self.state, 0)
 
     async def emit_event(self, event_type: str, data: dict[str, Any]) -> None:
         """Emit an SSE event to listeners."""
-        await self._event_queue.put({"event": event_type, "data": data})
+        event = {"event": event_type, "data": data}
+        await self._event_queue.put(event)
+        for queue in list(self._subscribers):
+            queue.put_nowait(event)
+
+    def subscribe(self) -> asyncio.Queue:
+        """A queue of the events emitted from now on, for one listener of its own.
+
+        The job stream takes events off the shared queue, so two streams would split
+        them; a subscriber sees them all. ``unsubscribe`` it when done.
+        """
+        queue: asyncio.Queue = asyncio.Queue()
+        self._subscribers.append(queue)
+        return queue
+
+    def unsubscribe(self, queue: asyncio.Queue) -> None:
+        if queue in self._subscribers:
+            self._subscribers.remove(queue)
 
     async def get_event(self, timeout: float = 30.0) -> Optional[dict[str, Any]]:
         """Get next event from queue with timeout."""
@@ -93,6 +136,16 @@ class Job:
         except asyncio.TimeoutError:
             return None
 
+    def get_awaiting_event_payload(self) -> dict[str, Any]:
+        """The awaiting_user event payload: which input a paused run waits for."""
+        return {
+            "job_id": self.id,
+            "state": self.state.value,
+            "progress": self.get_progress_percent(),
+            "awaiting_user": self.awaiting_user,
+            "intermediate_results": self.intermediate_results,
+        }
+
     def get_complete_event_payload(self) -> dict[str, Any]:
         """Generate the complete event payload (DRY helper for SSE).
 
@@ -129,16 +182,22 @@ def _coerce_json(value: Any, default: Any) -> Any:
         return default
     if isinstance(value, str):
         try:
-            return json.loads(value)
+            value = json.loads(value)
         except json.JSONDecodeError:
             return default
-    return value
+    return open_json(value)
+
+
+def _sealed(value: Any) -> Optional[Json]:
+    """A JSON column's value, sealed when encryption is on; None stays NULL."""
+    return Json(seal_json(value)) if value is not None else None
 
 
 def _row_to_job(row: dict[str, Any]) -> Job:
     """Convert database row to Job."""
     return Job(
         id=row["id"],
+        owner=row.get("owner"),
         company=row.get("company"),
         role_title=row.get("role_title"),
         source=row.get("source"),
@@ -150,22 +209,32 @@ def _row_to_job(row: dict[str, Any]) -> Job:
         created_at=_deserialize_datetime(row.get("created_at")) or datetime.now(),
         started_at=_deserialize_datetime(row.get("started_at")),
         completed_at=_deserialize_datetime(row.get("completed_at")),
-        job_description=row.get("job_description") or "",
-        resume=row.get("resume") or "",
-        source_documents=row.get("source_documents") or "",
+        job_description=open_text(row.get("job_description")) or "",
+        resume=open_text(row.get("resume")) or "",
+        source_documents=open_text(row.get("source_documents")) or "",
         model=row.get("model"),
         max_audit_retries=row.get("max_audit_retries") or 2,
+        idempotency_key=row.get("idempotency_key"),
+        request_hash=row.get("request_hash"),
         final_documents=_coerce_json(row.get("final_documents"), None),
         audit_report=_coerce_json(row.get("audit_report"), None),
         executive_brief=_coerce_json(row.get("executive_brief"), None),
-        intermediate_results=_coerce_json(row.get("intermediate_results"), {}),
+        # Rows saved before state versioning have no state_version (version 1).
+        intermediate_results=upgrade_state(
+            _coerce_json(row.get("intermediate_results"), {}), row.get("state_version")
+        ),
+        state_version=STATE_VERSION,
         execution_log=_coerce_json(row.get("execution_log"), []),
         error_message=row.get("error_message"),
         audit_failed=bool(row.get("audit_failed")),
         audit_error=row.get("audit_error"),
         agent_models=_coerce_json(row.get("agent_models"), {}),
+        cost_usd=float(row.get("cost_usd") or 0.0),
+        artifacts_purged_at=_deserialize_datetime(row.get("artifacts_purged_at")),
         gap_analysis_approved=bool(row.get("gap_analysis_approved")),
         interview_answers=_coerce_json(row.get("interview_answers"), []),
+        greenlight_notes=open_text(row.get("greenlight_notes")),
+        awaiting_user=row.get("awaiting_user"),
     )
 
 
@@ -188,13 +257,17 @@ class JobQueue:
         url: Optional[str] = None,
         model: Optional[str] = None,
         max_audit_retries: int = 2,
+        owner: Optional[str] = None,
+        idempotency_key: Optional[str] = None,
+        request_hash: Optional[str] = None,
     ) -> Job:
-        """Create and store a new job."""
+        """Create and store a new job, owned by ``owner`` in multi-user mode."""
         job_id = str(uuid.uuid4())
         safe_company = company or "Unknown Company"
         safe_role = role_title or "Unknown Role"
         job = Job(
             id=job_id,
+            owner=owner,
             company=safe_company,
             role_title=safe_role,
             source=source,
@@ -204,6 +277,8 @@ class JobQueue:
             source_documents=source_documents,
             model=model,
             max_audit_retries=max_audit_retries,
+            idempotency_key=idempotency_key,
+            request_hash=request_hash,
         )
 
         with self._lock:
@@ -216,7 +291,8 @@ class JobQueue:
                         job_description, resume, source_documents, model, max_audit_retries,
                         final_documents, audit_report, executive_brief, intermediate_results,
                         execution_log, error_message, audit_failed, audit_error, agent_models,
-                        gap_analysis_approved, interview_answers
+                        gap_analysis_approved, interview_answers, greenlight_notes, awaiting_user,
+                        state_version, owner, cost_usd, idempotency_key, request_hash
                     )
                     VALUES (
                         %s, %s, %s, %s, %s, %s, %s,
@@ -224,7 +300,8 @@ class JobQueue:
                         %s, %s, %s, %s, %s,
                         %s, %s, %s, %s,
                         %s, %s, %s, %s, %s,
-                        %s, %s
+                        %s, %s, %s, %s,
+                        %s, %s, %s, %s, %s
                     )
                     """,
                     (
@@ -240,22 +317,29 @@ class JobQueue:
                         job.created_at,
                         job.started_at,
                         job.completed_at,
-                        job.job_description,
-                        job.resume,
-                        job.source_documents,
+                        seal_text(job.job_description),
+                        seal_text(job.resume),
+                        seal_text(job.source_documents),
                         job.model,
                         job.max_audit_retries,
-                        Json(job.final_documents) if job.final_documents is not None else None,
-                        Json(job.audit_report) if job.audit_report is not None else None,
-                        Json(job.executive_brief) if job.executive_brief is not None else None,
-                        Json(job.intermediate_results),
-                        Json(job.execution_log),
+                        _sealed(job.final_documents),
+                        _sealed(job.audit_report),
+                        _sealed(job.executive_brief),
+                        _sealed(job.intermediate_results),
+                        _sealed(job.execution_log),
                         job.error_message,
                         job.audit_failed,
                         job.audit_error,
                         Json(job.agent_models),
                         job.gap_analysis_approved,
-                        Json(job.interview_answers),
+                        _sealed(job.interview_answers),
+                        seal_text(job.greenlight_notes),
+                        job.awaiting_user,
+                        job.state_version,
+                        job.owner,
+                        job.cost_usd,
+                        job.idempotency_key,
+                        job.request_hash,
                     ),
                 )
                 conn.commit()
@@ -282,8 +366,37 @@ class JobQueue:
                 return job
             return None
 
+    def refresh_job(self, job_id: str) -> Optional[Job]:
+        """Re-read a job another process updated, in place: its SSE queue is kept."""
+        with get_conn() as conn:
+            row = conn.execute("SELECT * FROM job_queue WHERE id = %s", (job_id,)).fetchone()
+        if not row:
+            return None
+        fresh = _row_to_job(row)
+        with self._lock:
+            job = self._active_jobs.setdefault(job_id, fresh)
+            if job is not fresh:
+                for name in fresh.__dataclass_fields__:
+                    if name not in ("_event_queue", "_subscribers"):
+                        setattr(job, name, getattr(fresh, name))
+        return job
+
     def update_job(self, job_id: str, **kwargs) -> Optional[Job]:
-        """Update job fields."""
+        """Update job fields.
+
+        While this instance holds the job's lease (services/leases.py) the write only
+        applies if it still does: LeaseLostError if another instance took the job over.
+        """
+        return self._update(job_id, kwargs, leases.fence(job_id))
+
+    def override_job(self, job_id: str, **kwargs) -> Optional[Job]:
+        """Update job fields whoever holds the job's lease: an administrator's write,
+        after revoking the lease from the run (see services/admin.py)."""
+        return self._update(job_id, kwargs, None)
+
+    def _update(
+        self, job_id: str, fields: dict[str, Any], fence: Optional[tuple[str, int]]
+    ) -> Optional[Job]:
         with self._lock:
             job = self._active_jobs.get(job_id)
             if not job:
@@ -300,13 +413,13 @@ class JobQueue:
                 return None
 
             # Update in-memory object
-            for key, value in kwargs.items():
+            for key, value in fields.items():
                 if hasattr(job, key):
                     setattr(job, key, value)
 
             # Persist to database
             with get_conn() as conn:
-                conn.execute(
+                cursor = conn.execute(
                     """
                     UPDATE job_queue SET
                         company = %s,
@@ -329,9 +442,14 @@ class JobQueue:
                         audit_error = %s,
                         agent_models = %s,
                         gap_analysis_approved = %s,
-                        interview_answers = %s
+                        interview_answers = %s,
+                        greenlight_notes = %s,
+                        awaiting_user = %s,
+                        state_version = %s,
+                        cost_usd = %s
                     WHERE id = %s
-                    """,
+                    """
+                    + (" AND lease_owner = %s AND lease_version = %s" if fence else ""),
                     (
                         job.company,
                         job.role_title,
@@ -343,32 +461,167 @@ class JobQueue:
                         job.success,
                         job.started_at,
                         job.completed_at,
-                        Json(job.final_documents) if job.final_documents is not None else None,
-                        Json(job.audit_report) if job.audit_report is not None else None,
-                        Json(job.executive_brief) if job.executive_brief is not None else None,
-                        Json(job.intermediate_results),
-                        Json(job.execution_log),
+                        _sealed(job.final_documents),
+                        _sealed(job.audit_report),
+                        _sealed(job.executive_brief),
+                        _sealed(job.intermediate_results),
+                        _sealed(job.execution_log),
                         job.error_message,
                         job.audit_failed,
                         job.audit_error,
                         Json(job.agent_models),
                         job.gap_analysis_approved,
-                        Json(job.interview_answers),
+                        _sealed(job.interview_answers),
+                        seal_text(job.greenlight_notes),
+                        job.awaiting_user,
+                        job.state_version,
+                        job.cost_usd,
                         job_id,
+                        *(fence or ()),
                     ),
                 )
                 conn.commit()
+            lost = fence is not None and cursor.rowcount == 0
+        if lost:
+            # The row is the holder's: put the cached job back to it.
+            self.refresh_job(job_id)
+            raise LeaseLostError(f"job {job_id} was taken over by another instance")
+        return job
 
-            return job
+    def list_jobs(
+        self, limit: int = 10, offset: int = 0, owner: Optional[str] = None
+    ) -> list[Job]:
+        """List jobs with pagination; only ``owner``'s when given."""
+        with get_conn() as conn:
+            if owner is None:
+                rows = conn.execute(
+                    "SELECT * FROM job_queue ORDER BY created_at DESC LIMIT %s OFFSET %s",
+                    (limit, offset),
+                ).fetchall()
+            else:
+                rows = conn.execute(
+                    "SELECT * FROM job_queue WHERE owner = %s"
+                    " ORDER BY created_at DESC LIMIT %s OFFSET %s",
+                    (owner, limit, offset),
+                ).fetchall()
+            return [_row_to_job(row) for row in rows]
 
-    def list_jobs(self, limit: int = 10, offset: int = 0) -> list[Job]:
-        """List jobs with pagination."""
+    def spent_since(self, owner: str, since: datetime) -> float:
+        """What ``owner``'s jobs created since ``since`` have cost, in USD."""
+        with get_conn() as conn:
+            row = conn.execute(
+                "SELECT COALESCE(SUM(cost_usd), 0) AS spent FROM job_queue"
+                " WHERE owner = %s AND created_at >= %s",
+                (owner, since),
+            ).fetchone()
+        return float(row["spent"])
+
+    def find_by_idempotency_key(self, owner: Optional[str], key: str) -> Optional[Job]:
+        """``owner``'s job created with Idempotency-Key ``key``, if any."""
+        with get_conn() as conn:
+            row = conn.execute(
+                "SELECT id FROM job_queue"
+                " WHERE idempotency_key = %s AND owner IS NOT DISTINCT FROM %s",
+                (key, owner),
+            ).fetchone()
+        return self.get_job(row["id"]) if row else None
+
+    def find_duplicate(
+        self, owner: Optional[str], request_hash: str, since: datetime
+    ) -> Optional[Job]:
+        """``owner``'s newest job since ``since`` on the same inputs, unless it failed or
+        was cancelled."""
+        with get_conn() as conn:
+            row = conn.execute(
+                "SELECT id FROM job_queue"
+                " WHERE request_hash = %s AND owner IS NOT DISTINCT FROM %s"
+                " AND created_at >= %s AND state NOT IN (%s, %s)"
+                " ORDER BY created_at DESC LIMIT 1",
+                (
+                    request_hash,
+                    owner,
+                    since,
+                    JobState.FAILED.value,
+                    JobState.CANCELLED.value,
+                ),
+            ).fetchone()
+        return self.get_job(row["id"]) if row else None
+
+    def search_jobs(
+        self,
+        state: Optional[JobState] = None,
+        owner: Optional[str] = None,
+        since: Optional[datetime] = None,
+        until: Optional[datetime] = None,
+        limit: int = 50,
+        offset: int = 0,
+    ) -> list[Job]:
+        """Every user's jobs, newest first, filtered by state, owner and creation time."""
+        clauses, params = [], []
+        for clause, value in (
+            ("state = %s", state.value if state else None),
+            ("owner = %s", owner),
+            ("created_at >= %s", since),
+            ("created_at < %s", until),
+        ):
+            if value is not None:
+                clauses.append(clause)
+                params.append(value)
+        where = f" WHERE {' AND '.join(clauses)}" if clauses else ""
         with get_conn() as conn:
             rows = conn.execute(
-                "SELECT * FROM job_queue ORDER BY created_at DESC LIMIT %s OFFSET %s",
-                (limit, offset),
+                f"SELECT * FROM job_queue{where} ORDER BY created_at DESC LIMIT %s OFFSET %s",
+                (*params, limit, offset),
             ).fetchall()
-            return [_row_to_job(row) for row in rows]
+        return [_row_to_job(row) for row in rows]
+
+    def finished_before(self, cutoff: datetime, purged: bool = False) -> list[Job]:
+        """Jobs that ended before ``cutoff`` and still have their documents (with
+        ``purged``, those whose documents are gone too), oldest first."""
+        unpurged = "" if purged else " AND artifacts_purged_at IS NULL"
+        with get_conn() as conn:
+            rows = conn.execute(
+                "SELECT id FROM job_queue WHERE state IN (%s, %s, %s)"
+                f" AND completed_at < %s{unpurged} ORDER BY completed_at",
+                (
+                    JobState.COMPLETED.value,
+                    JobState.FAILED.value,
+                    JobState.CANCELLED.value,
+                    cutoff,
+                ),
+            ).fetchall()
+        return [job for job in (self.get_job(row["id"]) for row in rows) if job]
+
+    def purge_documents(self, job_id: str) -> Optional[Job]:
+        """Drop the documents, stage outputs and personal inputs kept in the job row (the
+        résumé, sources and interview answers), and record when."""
+        purged_at = datetime.now()
+        with get_conn() as conn:
+            conn.execute(
+                "UPDATE job_queue SET final_documents = NULL, audit_report = NULL,"
+                " executive_brief = NULL, intermediate_results = %s, resume = '',"
+                " source_documents = '', interview_answers = %s, greenlight_notes = NULL,"
+                " artifacts_purged_at = %s WHERE id = %s",
+                (Json({}), Json([]), purged_at, job_id),
+            )
+            conn.commit()
+        job = self.get_job(job_id)
+        if job is not None:
+            job.final_documents = job.audit_report = job.executive_brief = None
+            job.intermediate_results, job.interview_answers = {}, []
+            job.resume = job.source_documents = ""
+            job.greenlight_notes = None
+            job.artifacts_purged_at = purged_at
+        return job
+
+    def list_jobs_in_state(self, state: JobState) -> list[Job]:
+        """All jobs in ``state``, oldest first (cached objects where loaded)."""
+        with get_conn() as conn:
+            rows = conn.execute(
+                "SELECT id FROM job_queue WHERE state = %s ORDER BY created_at",
+                (state.value,),
+            ).fetchall()
+        return [job for job in (self.get_job(row["id"]) for row in rows) if job]
 
     def delete_job(self, job_id: str) -> bool:
         """Delete a job by ID."""
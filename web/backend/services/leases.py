@@ -0,0 +1,242 @@
+"""Job leases: run ``hydra serve`` as several instances behind a load balancer.
+
+Every instance shares the Postgres job queue, so any of them can be asked to start,
+resume or greenlight a job, and each resumes the ``interrupted`` jobs it finds at
+startup. A lease makes sure only one of them runs a job's workflow at a time:
+
+- before starting a run an instance takes the job's lease — ``lease_owner`` set to
+  its id (``HYDRA_INSTANCE_ID``, else host, pid and a random suffix) and
+  ``lease_expires_at`` to ``HYDRA_LEASE_SECONDS`` (default 60) from now. The lease is
+  taken in one UPDATE that only matches a job with no lease, an expired one or one
+  of its own, so of two instances racing for a job exactly one wins; the other
+  leaves the run alone;
+- while the run goes on the holder renews the lease every third of that time, and
+  releases it when the run returns. An instance that dies stops renewing; once the
+  lease has expired the job is orphaned, and the next instance to start resumes it
+  along with the ``interrupted`` ones (``resume_interrupted_jobs``);
+- each new lease bumps ``lease_version``. The holder's writes to the job row match
+  on its owner and version (optimistic locking), so an instance that stalled past
+  its lease — a long GC pause, a lost database connection — cannot overwrite the
+  progress of the instance that took over: its write fails with LeaseLostError, its
+  heartbeat finds the lease gone, and its run is abandoned;
+- pausing or cancelling a job whose run is on another instance writes the request
+  to the row (``stop_requested``); the holder picks it up with its next renewal and
+  stops the run as if it had been asked directly.
+
+Times are the database's (``NOW()``), so instances need not agree on the clock. A
+single server holds and renews its leases all the same; nothing needs configuring.
+"""
+
+import asyncio
+import logging
+import os
+import socket
+import uuid
+from threading import Lock
+from typing import Callable, Optional
+
+from web.backend.db import get_conn
+
+logger = logging.getLogger(__name__)
+
+INSTANCE_ID_ENV = "HYDRA_INSTANCE_ID"
+LEASE_SECONDS_ENV = "HYDRA_LEASE_SECONDS"
+DEFAULT_LEASE_SECONDS = 60.0
+# Renewals per lease period: a lease survives two renewals that fail in a row.
+RENEWALS_PER_LEASE = 3
+# The version a revoked lease is fenced with: no row has it, so every write fails.
+REVOKED = -1
+
+
+class LeaseLostError(RuntimeError):
+    """Another instance took over the job; this one must not write to it."""
+
+
+def lease_seconds_from_env() -> float:
+    """How long a lease lasts without renewal (``HYDRA_LEASE_SECONDS``, default 60)."""
+    try:
+        seconds = float(os.environ.get(LEASE_SECONDS_ENV, DEFAULT_LEASE_SECONDS))
+    except ValueError:
+        return DEFAULT_LEASE_SECONDS
+    return seconds if seconds > 0 else DEFAULT_LEASE_SECONDS
+
+
+def default_instance_id() -> str:
+    """``HYDRA_INSTANCE_ID``, else one unique to this process."""
+    return os.environ.get(INSTANCE_ID_ENV) or (
+        f"{socket.gethostname()}-{os.getpid()}-{uuid.uuid4().hex[:6]}"
+    )
+
+
+class Leases:
+    """The job leases this instance holds, and the queries that take and keep them."""
+
+    def __init__(self, instance_id: Optional[str] = None) -> None:
+        self.instance_id = instance_id or default_instance_id()
+        self._lock = Lock()
+        self._held: dict[str, int] = {}  # job id -> lease_version
+
+    def held(self) -> list[str]:
+        with self._lock:
+            return list(self._held)
+
+    def fence(self, job_id: str) -> Optional[tuple[str, int]]:
+        """(owner, version) a write to ``job_id`` must match, or None if not leased here."""
+        with self._lock:
+            version = self._held.get(job_id)
+        return None if version is None else (self.instance_id, version)
+
+    def acquire(self, job_id: str) -> bool:
+        """Take the lease on ``job_id``; False if a live lease of another instance has it."""
+        with get_conn() as conn:
+            row = conn.execute(
+                """
+                UPDATE job_queue SET
+                    lease_owner = %s,
+                    lease_expires_at = NOW() + make_interval(secs => %s),
+                    lease_version = lease_version + 1,
+                    stop_requested = NULL
+                WHERE id = %s AND (
+                    lease_owner IS NULL OR lease_owner = %s OR lease_expires_at < NOW()
+                )
+                RETURNING lease_version
+                """,
+                (self.instance_id, lease_seconds_from_env(), job_id, self.instance_id),
+            ).fetchone()
+            conn.commit()
+        if row is None:
+            return False
+        with self._lock:
+            self._held[job_id] = row["lease_version"]
+        return True
+
+    def renew(self, job_id: str) -> Optional[str]:
+        """Extend the lease on ``job_id``; the pause or cancel requested for it, if any.
+
+        LeaseLostError if the lease is no longer this instance's.
+        """
+        fence = self.fence(job_id)
+        if fence is None:
+            raise LeaseLostError(f"job {job_id} is not leased by {self.instance_id}")
+        with get_conn() as conn:
+            row = conn.execute(
+                """
+                UPDATE job_queue SET lease_expires_at = NOW() + make_interval(secs => %s)
+                WHERE id = %s AND lease_owner = %s AND lease_version = %s
+                RETURNING stop_requested
+                """,
+                (lease_seconds_from_env(), job_id, *fence),
+            ).fetchone()
+            conn.commit()
+        if row is None:
+            raise LeaseLostError(f"job {job_id} was taken over by another instance")
+        return row["stop_requested"]
+
+    def release(self, job_id: str) -> None:
+        """Give up the lease on ``job_id`` (a no-op if it was lost meanwhile)."""
+        with self._lock:
+            version = self._held.pop(job_id, None)
+        if version is None:
+            return
+        with get_conn() as conn:
+            conn.execute(
+                """
+                UPDATE job_queue SET
+                    lease_owner = NULL, lease_expires_at = NULL, stop_requested = NULL
+                WHERE id = %s AND lease_owner = %s AND lease_version = %s
+                """,
+                (job_id, self.instance_id, version),
+            )
+            conn.commit()
+
+    def revoke(self, job_id: str) -> None:
+        """Take the lease on ``job_id`` from whichever instance holds it, this one
+        included: its writes to the job are refused from now on, and its heartbeat
+        abandons the run."""
+        with self._lock:
+            if job_id in self._held:
+                self._held[job_id] = REVOKED
+        with get_conn() as conn:
+            conn.execute(
+                "UPDATE job_queue SET lease_owner = NULL, lease_expires_at = NULL,"
+                " lease_version = lease_version + 1, stop_requested = NULL WHERE id = %s",
+                (job_id,),
+            )
+            conn.commit()
+
+    def holder(self, job_id: str) -> Optional[str]:
+        """The other instance holding a live lease on ``job_id``, or None."""
+        with get_conn() as conn:
+            row = conn.execute(
+                "SELECT lease_owner FROM job_queue"
+                " WHERE id = %s AND lease_owner <> %s AND lease_expires_at >= NOW()",
+                (job_id, self.instance_id),
+            ).fetchone()
+        return row["lease_owner"] if row else None
+
+    def in_flight(self, job_id: str) -> bool:
+        """Whether ``job_id`` waits for an instance to take it or one holds a live lease."""
+        with get_conn() as conn:
+            row = conn.execute(
+                "SELECT lease_owner IS NULL OR lease_expires_at >= NOW() AS in_flight"
+                " FROM job_queue WHERE id = %s",
+                (job_id,),
+            ).fetchone()
+        return bool(row and row["in_flight"])
+
+    def orphaned(self) -> list[str]:
+        """Jobs whose lease expired unreleased: their instance died mid-run. At startup
+        that includes the leases a previous process with this ``HYDRA_INSTANCE_ID`` left."""
+        with self._lock:
+            held = set(self._held)
+        with get_conn() as conn:
+            rows = conn.execute(
+                "SELECT id FROM job_queue WHERE lease_owner IS NOT NULL"
+                " AND (lease_expires_at < NOW() OR lease_owner = %s) ORDER BY created_at",
+                (self.instance_id,),
+            ).fetchall()
+        return [row["id"] for row in rows if row["id"] not in held]
+
+    def request_stop(self, job_id: str, state: str) -> bool:
+        """Ask the instance running ``job_id`` to stop it (``state``: paused or
+        cancelled); False if no other instance holds a live lease on it."""
+        with get_conn() as conn:
+            cursor = conn.execute(
+                "UPDATE job_queue SET stop_requested = %s"
+                " WHERE id = %s AND lease_owner <> %s AND lease_expires_at >= NOW()",
+                (state, job_id, self.instance_id),
+            )
+            conn.commit()
+        return cursor.rowcount > 0
+
+    async def keep(
+        self,
+        job_id: str,
+        on_stop: Callable[[str], None],
+        on_lost: Callable[[], None],
+    ) -> None:
+        """Renew the lease on ``job_id`` until cancelled, passing each newly requested
+        stop to ``on_stop`` (in a thread); call ``on_lost`` and return if it is lost.
+
+        A renewal that fails for another reason (the database unreachable) is retried
+        at the next interval: the lease lasts RENEWALS_PER_LEASE intervals.
+        """
+        delivered = None
+        while True:
+            await asyncio.sleep(lease_seconds_from_env() / RENEWALS_PER_LEASE)
+            try:
+                requested = await asyncio.to_thread(self.renew, job_id)
+            except LeaseLostError as e:
+                logger.warning(f"Lease on job {job_id} lost: {e}")
+                on_lost()
+                return
+            except Exception as e:
+                logger.warning(f"Could not renew the lease on job {job_id}: {e}")
+                continue
+            if requested and requested != delivered:
+                delivered = requested
+                await asyncio.to_thread(on_stop, requested)
+
+
+# This instance's leases, shared by the job queue and the runner.
+leases = Leases()
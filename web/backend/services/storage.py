@@ -0,0 +1,364 @@
+"""Where the server keeps runs: local disk, or an S3/GCS/MinIO bucket.
+
+Two stores, picked by ``HYDRA_ARTIFACT_STORE``:
+
+- the artifact store holds each run's documents (résumé, cover letter, audit
+  report), one object per document;
+- the state store keeps a snapshot of each job's workflow state — its stage outputs,
+  state version and log — next to them, written at every progress checkpoint and
+  when the run ends. Postgres stays the job queue; the snapshot makes a run in the
+  bucket complete on its own, so it outlives the job row and can be read without
+  the database.
+
+``HYDRA_ARTIFACT_STORE`` is one of:
+
+- unset or ``file`` — documents on disk under ``HYDRA_ARTIFACTS_DIR`` (the default,
+  and how it always worked); no state snapshots, the job row is the state;
+- ``s3://bucket/prefix`` — Amazon S3, or any S3-compatible store (MinIO, R2, Ceph)
+  with ``HYDRA_S3_ENDPOINT_URL``. Credentials come from the usual AWS environment
+  variables, profile or instance role. Needs ``pip install boto3``;
+- ``gs://bucket/prefix`` — Google Cloud Storage, with application default
+  credentials. Needs ``pip install google-cloud-storage``.
+
+Keys are ``<prefix>/<user>/<company>/<role>/<run id>/<kind>.md`` for documents and
+``<prefix>/<user>/jobs/<job id>/state.json`` for state; ``<user>`` is the API user
+(see auth.py) and is left out with auth off. Several deployments can share a bucket
+under different prefixes.
+
+Lifecycle: with ``HYDRA_ARTIFACT_EXPIRE_DAYS`` the server sets a bucket rule at
+startup that deletes objects under its prefix that many days after they were
+written, and with ``HYDRA_ARTIFACT_ARCHIVE_DAYS`` one that moves them to a colder
+storage class first (S3 ``GLACIER_IR``, GCS ``COLDLINE``). The rules are named after
+the prefix and replace only the server's own, so the bucket's other rules stay.
+Disk storage has no lifecycle; see ``HYDRA_RETENTION`` for stage outputs.
+
+With ``HYDRA_ENCRYPT_STATE`` on, documents and state snapshots are written encrypted
+with the ``HYDRA_STATE_PASSPHRASE`` key (see runtime.crewai.encryption), as run
+directories are; snapshots written in the clear still load.
+"""
+
+import json
+import os
+from datetime import datetime
+from pathlib import Path
+from typing import Any, Optional, Protocol, Union
+from urllib.parse import urlparse
+
+from runtime.crewai.encryption import is_encrypted, open_bytes, seal_bytes, write_text
+from web.backend.auth import storage_prefix
+
+STORE_ENV = "HYDRA_ARTIFACT_STORE"
+ARTIFACTS_DIR_ENV = "HYDRA_ARTIFACTS_DIR"
+S3_ENDPOINT_ENV = "HYDRA_S3_ENDPOINT_URL"
+EXPIRE_DAYS_ENV = "HYDRA_ARTIFACT_EXPIRE_DAYS"
+ARCHIVE_DAYS_ENV = "HYDRA_ARTIFACT_ARCHIVE_DAYS"
+# Passed through to docker workers, which write their run's artifacts themselves.
+OBJECT_STORE_CREDENTIAL_ENV = (
+    "AWS_ACCESS_KEY_ID",
+    "AWS_SECRET_ACCESS_KEY",
+    "AWS_SESSION_TOKEN",
+    "AWS_REGION",
+    "AWS_DEFAULT_REGION",
+)
+
+STATE_FILE = "state.json"
+S3_ARCHIVE_CLASS = "GLACIER_IR"
+GCS_ARCHIVE_CLASS = "COLDLINE"
+_DEFAULT_ARTIFACTS_DIR = Path(__file__).parent.parent.parent / "out"
+
+
+class StorageConfigError(ValueError):
+    """HYDRA_ARTIFACT_STORE or a lifecycle setting is malformed, or its client is missing."""
+
+
+class ObjectClient(Protocol):
+    """The few bucket operations the stores need, one implementation per provider."""
+
+    bucket: str
+    scheme: str
+
+    def put(self, key: str, data: bytes, content_type: str) -> None: ...
+
+    def get(self, key: str) -> Optional[bytes]: ...
+
+    def delete(self, key: str) -> None: ...
+
+    def set_lifecycle(
+        self, rule: str, prefix: str, expire_days: Optional[int], archive_days: Optional[int]
+    ) -> None: ...
+
+
+class S3Client:
+    """S3 and S3-compatible stores (MinIO with an endpoint URL) through boto3."""
+
+    scheme = "s3"
+
+    def __init__(self, bucket: str, endpoint_url: Optional[str] = None):
+        try:
+            import boto3  # only needed with an s3:// store
+        except ImportError as err:
+            raise StorageConfigError("s3:// artifact storage needs `pip install boto3`") from err
+        self.bucket = bucket
+        self._s3 = boto3.client("s3", endpoint_url=endpoint_url)
+
+    def put(self, key: str, data: bytes, content_type: str) -> None:
+        self._s3.put_object(Bucket=self.bucket, Key=key, Body=data, ContentType=content_type)
+
+    def get(self, key: str) -> Optional[bytes]:
+        try:
+            return self._s3.get_object(Bucket=self.bucket, Key=key)["Body"].read()
+        except self._s3.exceptions.NoSuchKey:
+            return None
+
+    def delete(self, key: str) -> None:
+        self._s3.delete_object(Bucket=self.bucket, Key=key)  # a missing key is no error
+
+    def set_lifecycle(
+        self, rule: str, prefix: str, expire_days: Optional[int], archive_days: Optional[int]
+    ) -> None:
+        try:
+            current = self._s3.get_bucket_lifecycle_configuration(Bucket=self.bucket)["Rules"]
+        except self._s3.exceptions.ClientError:
+            current = []  # no lifecycle configuration yet
+        ours: dict[str, Any] = {"ID": rule, "Filter": {"Prefix": prefix}, "Status": "Enabled"}
+        if expire_days:
+            ours["Expiration"] = {"Days": expire_days}
+        if archive_days:
+            ours["Transitions"] = [{"Days": archive_days, "StorageClass": S3_ARCHIVE_CLASS}]
+        rules = [r for r in current if r.get("ID") != rule] + [ours]
+        self._s3.put_bucket_lifecycle_configuration(
+            Bucket=self.bucket, LifecycleConfiguration={"Rules": rules}
+        )
+
+
+class GCSClient:
+    """Google Cloud Storage through google-cloud-storage."""
+
+    scheme = "gs"
+
+    def __init__(self, bucket: str):
+        try:
+            from google.cloud import storage  # only needed with a gs:// store
+        except ImportError as err:
+            raise StorageConfigError(
+                "gs:// artifact storage needs `pip install google-cloud-storage`"
+            ) from err
+        self.bucket = bucket
+        self._bucket = storage.Client().bucket(bucket)
+
+    def put(self, key: str, data: bytes, content_type: str) -> None:
+        self._bucket.blob(key).upload_from_string(data, content_type=content_type)
+
+    def get(self, key: str) -> Optional[bytes]:
+        blob = self._bucket.blob(key)
+        return blob.download_as_bytes() if blob.exists() else None
+
+    def delete(self, key: str) -> None:
+        blob = self._bucket.blob(key)
+        if blob.exists():
+            blob.delete()
+
+    def set_lifecycle(
+        self, rule: str, prefix: str, expire_days: Optional[int], archive_days: Optional[int]
+    ) -> None:
+        # GCS rules carry no name: ours are the ones matching exactly our prefix.
+        self._bucket.reload()
+        rules = [
+            r
+            for r in self._bucket.lifecycle_rules
+            if r.get("condition", {}).get("matchesPrefix") != [prefix]
+        ]
+        if archive_days:
+            rules.append(
+                {
+                    "action": {"type": "SetStorageClass", "storageClass": GCS_ARCHIVE_CLASS},
+                    "condition": {"age": archive_days, "matchesPrefix": [prefix]},
+                }
+            )
+        if expire_days:
+            rules.append(
+                {
+                    "action": {"type": "Delete"},
+                    "condition": {"age": expire_days, "matchesPrefix": [prefix]},
+                }
+            )
+        self._bucket.lifecycle_rules = rules
+        self._bucket.patch()
+
+
+def _content_type(data: bytes, plain: str) -> str:
+    return "application/octet-stream" if is_encrypted(data) else plain
+
+
+def _safe(part: str) -> str:
+    return part.strip().replace(" ", "_").replace("/", "_")
+
+
+def _run_parts(company: str, role_title: str, run_id: str) -> list[str]:
+    return [_safe(company), _safe(role_title), run_id]
+
+
+class LocalArtifactStore:
+    """Documents on disk under ``base_dir``: ``<user>/<company>/<role>/<run id>/<kind>.md``."""
+
+    def __init__(self, base_dir: Path):
+        self.base_dir = Path(base_dir)
+
+    def write(
+        self,
+        *,
+        owner: Optional[str],
+        company: str,
+        role_title: str,
+        run_id: str,
+        kind: str,
+        content: str,
+    ) -> str:
+        """Write one document; where it went (a path)."""
+        base = self.base_dir / storage_prefix(owner) if owner else self.base_dir
+        output_dir = base.joinpath(*_run_parts(company, role_title, run_id))
+        output_dir.mkdir(parents=True, exist_ok=True)
+        path = output_dir / f"{kind}.md"
+        write_text(path, content)
+        return str(path)
+
+    def delete(self, location: str) -> bool:
+        """Delete a document ``write`` returned the path of, and the run directory once
+        empty; False for a path outside ``base_dir`` (left alone)."""
+        path = Path(location).resolve()
+        base = self.base_dir.resolve()
+        if base not in path.parents:
+            return False
+        path.unlink(missing_ok=True)
+        for parent in path.parents:
+            if parent == base or any(parent.iterdir()):
+                break
+            parent.rmdir()
+        return True
+
+
+class ObjectArtifactStore:
+    """Documents as objects under ``prefix`` in the bucket of ``client``."""
+
+    def __init__(self, client: ObjectClient, prefix: str = ""):
+        self.client = client
+        self.prefix = prefix.strip("/")
+
+    def key(self, owner: Optional[str], *parts: str) -> str:
+        user = [storage_prefix(owner)] if owner else []
+        return "/".join([p for p in (self.prefix,) if p] + user + list(parts))
+
+    def url(self, key: str) -> str:
+        return f"{self.client.scheme}://{self.client.bucket}/{key}"
+
+    def write(
+        self,
+        *,
+        owner: Optional[str],
+        company: str,
+        role_title: str,
+        run_id: str,
+        kind: str,
+        content: str,
+    ) -> str:
+        """Write one document; where it went (an ``s3://`` or ``gs://`` URL)."""
+        key = self.key(owner, *_run_parts(company, role_title, run_id), f"{kind}.md")
+        data = seal_bytes(content.encode("utf-8"))
+        self.client.put(key, data, _content_type(data, "text/markdown; charset=utf-8"))
+        return self.url(key)
+
+    def delete(self, location: str) -> bool:
+        """Delete a document ``write`` returned the URL of; False for one in another
+        bucket or under another prefix (left alone)."""
+        parsed = urlparse(location)
+        key = parsed.path.lstrip("/")
+        if parsed.scheme != self.client.scheme or parsed.netloc != self.client.bucket:
+            return False
+        if self.prefix and not key.startswith(f"{self.prefix}/"):
+            return False
+        self.client.delete(key)
+        return True
+
+    def apply_lifecycle(self, expire_days: Optional[int], archive_days: Optional[int]) -> None:
+        """Set (or replace) the bucket rule for this store's prefix."""
+        rule = f"hydra-{self.prefix or 'root'}".replace("/", "-")
+        prefix = f"{self.prefix}/" if self.prefix else ""
+        self.client.set_lifecycle(rule, prefix, expire_days, archive_days)
+
+
+ArtifactStore = Union[LocalArtifactStore, ObjectArtifactStore]
+
+
+class StateStore:
+    """Snapshots of job state, as ``<prefix>/<user>/jobs/<job id>/state.json``."""
+
+    def __init__(self, artifacts: ObjectArtifactStore):
+        self.artifacts = artifacts
+
+    def save(self, job_id: str, owner: Optional[str], state: dict[str, Any]) -> str:
+        """Write the snapshot of ``job_id``; its URL."""
+        key = self.artifacts.key(owner, "jobs", job_id, STATE_FILE)
+        snapshot = {"job_id": job_id, "saved_at": datetime.now().isoformat(), **state}
+        data = seal_bytes(json.dumps(snapshot, indent=2, default=str).encode("utf-8"))
+        self.artifacts.client.put(key, data, _content_type(data, "application/json"))
+        return self.artifacts.url(key)
+
+    def load(self, job_id: str, owner: Optional[str]) -> Optional[dict[str, Any]]:
+        """The last snapshot of ``job_id``, or None if it has none."""
+        data = self.artifacts.client.get(self.artifacts.key(owner, "jobs", job_id, STATE_FILE))
+        return json.loads(open_bytes(data)) if data is not None else None
+
+    def delete(self, job_id: str, owner: Optional[str]) -> None:
+        """Delete the snapshot of ``job_id`` (none is no error)."""
+        self.artifacts.client.delete(self.artifacts.key(owner, "jobs", job_id, STATE_FILE))
+
+
+def days_from_env(name: str) -> Optional[int]:
+    """The whole number of days set in environment variable ``name``; None if unset."""
+    raw = os.environ.get(name, "").strip()
+    if not raw:
+        return None
+    if not raw.isdigit() or int(raw) == 0:
+        raise StorageConfigError(f"{name} must be a whole number of days, got {raw!r}")
+    return int(raw)
+
+
+def client_for(url: str) -> ObjectClient:
+    """The bucket client for an ``s3://`` or ``gs://`` store URL."""
+    parsed = urlparse(url)
+    if not parsed.netloc:
+        raise StorageConfigError(f"{STORE_ENV}: no bucket in {url!r}")
+    if parsed.scheme == "s3":
+        return S3Client(parsed.netloc, os.environ.get(S3_ENDPOINT_ENV) or None)
+    if parsed.scheme == "gs":
+        return GCSClient(parsed.netloc)
+    raise StorageConfigError(
+        f"{STORE_ENV} must be 'file', s3://bucket/prefix or gs://bucket/prefix, got {url!r}"
+    )
+
+
+def artifact_store_from_env(client: Optional[ObjectClient] = None) -> ArtifactStore:
+    """The configured artifact store: local disk, or a bucket (``client`` overrides
+    the one the URL would create, for tests)."""
+    url = os.environ.get(STORE_ENV, "").strip()
+    if not url or url == "file":
+        return LocalArtifactStore(Path(os.environ.get(ARTIFACTS_DIR_ENV, _DEFAULT_ARTIFACTS_DIR)))
+    return ObjectArtifactStore(client or client_for(url), urlparse(url).path)
+
+
+def state_store_from_env(client: Optional[ObjectClient] = None) -> Optional[StateStore]:
+    """The configured state store; None with disk storage, where the job row is the state."""
+    artifacts = artifact_store_from_env(client)
+    return StateStore(artifacts) if isinstance(artifacts, ObjectArtifactStore) else None
+
+
+def apply_lifecycle_from_env(client: Optional[ObjectClient] = None) -> bool:
+    """Set the bucket's lifecycle rule when one is configured; whether one was set."""
+    expire_days, archive_days = days_from_env(EXPIRE_DAYS_ENV), days_from_env(ARCHIVE_DAYS_ENV)
+    if expire_days and archive_days and archive_days >= expire_days:
+        raise StorageConfigError(f"{ARCHIVE_DAYS_ENV} must be less than {EXPIRE_DAYS_ENV}")
+    store = artifact_store_from_env(client)
+    if not isinstance(store, ObjectArtifactStore) or not (expire_days or archive_days):
+        return False
+    store.apply_lifecycle(expire_days, archive_days)
+    return True
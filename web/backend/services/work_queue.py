@@ -0,0 +1,266 @@
+"""Work queue: the API front end queues jobs, workers pull and run them.
+
+A request that starts a job — creating it, approving the greenlight, answering the
+interview, resuming — only puts the job id on the queue and returns. Workers take
+ids off the queue and run the workflows (``run_job`` in workflow_runner), at most
+``HYDRA_WORKERS`` at a time per process (default 4), so a burst of requests
+neither ties up the request handlers nor starts more model calls than the process
+can carry.
+
+``HYDRA_WORK_QUEUE`` picks the queue:
+
+- unset or ``local`` — an in-process channel (the default): the API server runs its
+  own workers, one process doing both as before;
+- ``redis://host:6379/0`` — a Redis list. Needs ``pip install redis``;
+- ``nats://host:4222`` — a NATS JetStream work-queue stream. Needs
+  ``pip install nats-py``.
+
+With a shared queue the two sides scale apart: API servers with ``HYDRA_WORKERS=0``
+only queue jobs and relay their progress from the job row, and any number of
+``python -m web.backend.queue_worker`` processes run them. A worker takes the job's
+lease before running it (see leases.py), so a job delivered twice — queued again by
+a resume, or by a server restarting — still runs once, and a job paused or
+cancelled while it waited is skipped. Delivery is at most once: a worker that dies
+in the moment between taking a job and leasing it loses the job, which stays
+``initialized`` until cancelled.
+"""
+
+import asyncio
+import logging
+import os
+from typing import Any, Awaitable, Callable, Optional, Protocol
+from urllib.parse import urlparse
+
+from web.backend.services.drain import POLL_SECONDS, drain
+from web.backend.services.execution import DEFAULT_TIMEOUT, WORKER_TIMEOUT_ENV
+from web.backend.services.leases import leases
+
+logger = logging.getLogger(__name__)
+
+WORK_QUEUE_ENV = "HYDRA_WORK_QUEUE"
+WORKERS_ENV = "HYDRA_WORKERS"
+DEFAULT_WORKERS = 4
+
+LOCAL = "local"
+QUEUE_NAME = "hydra-jobs"  # the Redis list
+NATS_STREAM = "HYDRA_JOBS"
+NATS_SUBJECT = "hydra.jobs"
+NATS_CONSUMER = "hydra-workers"
+# How long one blocking read waits before trying again (a worker stopping notices).
+BLOCK_SECONDS = 5
+
+
+class WorkQueueError(ValueError):
+    """``HYDRA_WORK_QUEUE`` or ``HYDRA_WORKERS`` is malformed, or its client missing."""
+
+
+class WorkQueue(Protocol):
+    """Job ids in, job ids out, oldest first."""
+
+    shared: bool  # reachable from other processes
+
+    async def put(self, job_id: str) -> None: ...
+
+    async def get(self) -> str: ...
+
+    async def close(self) -> None: ...
+
+
+class LocalWorkQueue:
+    """An in-process channel: the server's own workers run what it queues."""
+
+    shared = False
+
+    def __init__(self) -> None:
+        self._queue: asyncio.Queue[str] = asyncio.Queue()
+
+    async def put(self, job_id: str) -> None:
+        self._queue.put_nowait(job_id)
+
+    async def get(self) -> str:
+        return await self._queue.get()
+
+    async def close(self) -> None:
+        pass
+
+
+class RedisWorkQueue:
+    """A Redis list: LPUSH to queue, BRPOP to take."""
+
+    shared = True
+
+    def __init__(self, url: str, client: Any = None, key: str = QUEUE_NAME) -> None:
+        if client is None:
+            try:
+                import redis.asyncio as redis
+            except ImportError as err:
+                raise WorkQueueError(f"{url} needs the redis client: pip install redis") from err
+            client = redis.from_url(url)
+        self._client = client
+        self.key = key
+
+    async def put(self, job_id: str) -> None:
+        await self._client.lpush(self.key, job_id)
+
+    async def get(self) -> str:
+        while True:
+            item = await self._client.brpop([self.key], timeout=BLOCK_SECONDS)
+            if item:
+                value = item[1]
+                return value.decode() if isinstance(value, bytes) else value
+
+    async def close(self) -> None:
+        await self._client.aclose()
+
+
+class NatsWorkQueue:
+    """A JetStream stream with work-queue retention: each id goes to one worker."""
+
+    shared = True
+
+    def __init__(self, url: str) -> None:
+        try:
+            import nats  # noqa: F401
+        except ImportError as err:
+            raise WorkQueueError(f"{url} needs the NATS client: pip install nats-py") from err
+        self.url = url
+        self._connection = None
+        self._jetstream = None
+        self._subscription = None
+
+    async def _stream(self):
+        if self._jetstream is None:
+            import nats
+            from nats.js.api import RetentionPolicy
+
+            self._connection = await nats.connect(self.url)
+            jetstream = self._connection.jetstream()
+            await jetstream.add_stream(
+                name=NATS_STREAM, subjects=[NATS_SUBJECT], retention=RetentionPolicy.WORK_QUEUE
+            )
+            self._jetstream = jetstream
+        return self._jetstream
+
+    async def put(self, job_id: str) -> None:
+        await (await self._stream()).publish(NATS_SUBJECT, job_id.encode())
+
+    async def get(self) -> str:
+        from nats.errors import TimeoutError as NatsTimeout
+
+        if self._subscription is None:
+            jetstream = await self._stream()
+            self._subscription = await jetstream.pull_subscribe(
+                NATS_SUBJECT, durable=NATS_CONSUMER
+            )
+        while True:
+            try:
+                messages = await self._subscription.fetch(1, timeout=BLOCK_SECONDS)
+            except NatsTimeout:
+                continue
+            # Acknowledged at once: the job's lease, not redelivery, guards the run.
+            await messages[0].ack()
+            return messages[0].data.decode()
+
+    async def close(self) -> None:
+        if self._connection is not None:
+            await self._connection.close()
+
+
+def work_queue_from_env() -> WorkQueue:
+    """The queue named by ``HYDRA_WORK_QUEUE``; call it on the running event loop."""
+    url = os.environ.get(WORK_QUEUE_ENV, "").strip()
+    if url in ("", LOCAL):
+        return LocalWorkQueue()
+    scheme = urlparse(url).scheme
+    if scheme in ("redis", "rediss"):
+        return RedisWorkQueue(url)
+    if scheme == "nats":
+        return NatsWorkQueue(url)
+    raise WorkQueueError(
+        f"{WORK_QUEUE_ENV} must be 'local', redis://… or nats://…, not {url!r}"
+    )
+
+
+def workers_from_env() -> int:
+    """Workflows this process runs at once (``HYDRA_WORKERS``, default 4; 0: none)."""
+    raw = os.environ.get(WORKERS_ENV, "").strip()
+    if not raw:
+        return DEFAULT_WORKERS
+    try:
+        workers = int(raw)
+    except ValueError as err:
+        raise WorkQueueError(f"{WORKERS_ENV} must be a whole number, not {raw!r}") from err
+    if workers < 0:
+        raise WorkQueueError(f"{WORKERS_ENV} cannot be negative")
+    return workers
+
+
+class WorkerPool:
+    """``size`` workers, each taking a job id off ``queue`` and awaiting ``run`` on it."""
+
+    def __init__(
+        self, queue: WorkQueue, size: int, run: Callable[[str], Awaitable[Any]]
+    ) -> None:
+        self.queue = queue
+        self.size = size
+        self._run = run
+        self._tasks: list[asyncio.Task] = []
+        self._busy = 0
+
+    @property
+    def busy(self) -> int:
+        """Workers running a job right now."""
+        return self._busy
+
+    def start(self) -> None:
+        self._tasks = [asyncio.create_task(self._work()) for _ in range(self.size)]
+
+    async def _work(self) -> None:
+        while True:
+            job_id = await self.queue.get()
+            if drain.draining and self.queue.shared:
+                # Left for a worker that is not shutting down.
+                await self.queue.put(job_id)
+                return
+            self._busy += 1
+            try:
+                await self._run(job_id)
+            except Exception as e:
+                logger.error(f"Worker failed on job {job_id}: {e}")
+            finally:
+                self._busy -= 1
+
+    async def wait_idle(self, timeout: float) -> bool:
+        """Wait up to ``timeout`` seconds for the runs in flight to return; True if so."""
+        deadline = asyncio.get_running_loop().time() + timeout
+        while self._busy:
+            if asyncio.get_running_loop().time() >= deadline:
+                return False
+            await asyncio.sleep(POLL_SECONDS)
+        return True
+
+    async def stop(self) -> None:
+        """Stop the workers; a run still in flight is abandoned (drain first)."""
+        for task in self._tasks:
+            task.cancel()
+        await asyncio.gather(*self._tasks, return_exceptions=True)
+        self._tasks = []
+        await self.queue.close()
+
+
+class QueueWorkers:
+    """What ``execution.watch_job`` asks of a backend, for a job a queue worker in
+    another process runs: it is alive while queued or while its lease is live."""
+
+    name = "queue"
+
+    def __init__(self, timeout: Optional[int] = None) -> None:
+        if timeout is None:
+            timeout = int(os.environ.get(WORKER_TIMEOUT_ENV, DEFAULT_TIMEOUT))
+        self.timeout = timeout
+
+    def running(self, job_id: str) -> bool:
+        return leases.in_flight(job_id)
+
+    def stop(self, job_id: str) -> None:
+        leases.request_stop(job_id, "cancelled")
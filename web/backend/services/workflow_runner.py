@@ -3,29 +3,59 @@
 import asyncio
 import json
 import logging
-import os
+import threading
 from concurrent.futures import ThreadPoolExecutor
 from datetime import datetime
-from pathlib import Path
+from typing import Optional
 
 # Import from parent project
-from runtime.crewai.hydra_workflow import HydraWorkflow, WorkflowState
+from runtime.crewai.hydra_workflow import HydraWorkflow, RunStatus, WorkflowState
 from runtime.crewai.llm_client import get_llm_client
-from web.backend.models import JobState
+from runtime.crewai.model_config import estimate_cost
+from runtime.crewai.model_routing import ModelRouting
+from runtime.crewai.pii_redaction import redaction_enabled
+from runtime.crewai.prompt_packs import AUTO as AUTO_PACK
+from runtime.crewai.retention import policy_from_env
+from web.backend.models import AwaitingInput, JobState
 from web.backend.observability.sentry import capture_error
 from web.backend.observability.sse_errors import build_error_payload_from_exception
+from web.backend.services.audit_log import audit_log
+from web.backend.services.drain import drain
+from web.backend.services.execution import (
+    ContainerBackend,
+    ExecutionBackendError,
+    backend_from_env,
+    watch_job,
+)
 from web.backend.services.hydra_db import hydra_db
 from web.backend.services.job_queue import Job, job_queue
+from web.backend.services.leases import LeaseLostError, leases
+from web.backend.services.storage import artifact_store_from_env, state_store_from_env
+from web.backend.services.work_queue import (
+    WORK_QUEUE_ENV,
+    WORKERS_ENV,
+    QueueWorkers,
+    WorkerPool,
+    WorkQueueError,
+    work_queue_from_env,
+    workers_from_env,
+)
 
 logger = logging.getLogger(__name__)
 
 # Thread pool for running blocking workflow operations
 _executor = ThreadPoolExecutor(max_workers=4)
+# How often a run in another process (a worker container or queue worker) writes its
+# progress to the job row, for the server relaying it.
+PROGRESS_SECONDS = 2.0
 
 
-def _artifacts_base_dir() -> Path:
-    project_root = Path(__file__).parent.parent.parent
-    return Path(os.environ.get("HYDRA_ARTIFACTS_DIR", str(project_root / "out")))
+def _run_cost(workflow: HydraWorkflow) -> float:
+    """Estimated USD cost of the model calls in one ``execute`` (unpriced models count 0)."""
+    return sum(
+        estimate_cost(call.model, call.prompt_tokens, call.completion_tokens) or 0.0
+        for call in list(workflow.usage_ledger.calls)
+    )
 
 
 def _ensure_hydra_records(job: Job) -> None:
@@ -66,7 +96,34 @@ def _ensure_hydra_records(job: Job) -> None:
     )
 
 
+def _save_state(job: Job) -> None:
+    """Snapshot the job's workflow state to the object store, when one is configured.
+
+    The job row stays the source of truth, so a failed snapshot is logged, not raised.
+    """
+    try:
+        store = state_store_from_env()
+        if store is None:
+            return
+        store.save(
+            job.id,
+            job.owner,
+            {
+                "state": job.state.value,
+                "state_version": job.state_version,
+                "awaiting_user": job.awaiting_user,
+                "error_message": job.error_message,
+                "agent_models": job.agent_models,
+                "intermediate_results": job.intermediate_results,
+                "execution_log": job.execution_log,
+            },
+        )
+    except Exception as e:
+        logger.warning(f"Job {job.id} state snapshot not saved: {e}")
+
+
 def _persist_hydra_results(job: Job) -> None:
+    _save_state(job)
     if not job.hydra_job_id or not job.hydra_run_id:
         return
 
@@ -97,15 +154,16 @@ def _persist_hydra_results(job: Job) -> None:
             structured_notes=structured_notes,
         )
 
-    base_dir = _artifacts_base_dir()
+    store = artifact_store_from_env()
     company = job.company or "Unknown Company"
     role_title = job.role_title or "Unknown Role"
 
     if job.final_documents:
         resume = job.final_documents.get("resume")
         if resume:
-            hydra_db.create_artifact_with_disk_write(
-                base_dir=base_dir,
+            hydra_db.create_stored_artifact(
+                store=store,
+                owner=job.owner,
                 company=company,
                 role_title=role_title,
                 run_id=job.hydra_run_id,
@@ -114,8 +172,9 @@ def _persist_hydra_results(job: Job) -> None:
             )
         cover_letter = job.final_documents.get("cover_letter")
         if cover_letter:
-            hydra_db.create_artifact_with_disk_write(
-                base_dir=base_dir,
+            hydra_db.create_stored_artifact(
+                store=store,
+                owner=job.owner,
                 company=company,
                 role_title=role_title,
                 run_id=job.hydra_run_id,
@@ -125,8 +184,9 @@ def _persist_hydra_results(job: Job) -> None:
 
     if job.audit_report:
         audit_content = json.dumps(job.audit_report, indent=2, sort_keys=True)
-        hydra_db.create_artifact_with_disk_write(
-            base_dir=base_dir,
+        hydra_db.create_stored_artifact(
+            store=store,
+            owner=job.owner,
             company=company,
             role_title=role_title,
             run_id=job.hydra_run_id,
@@ -147,6 +207,10 @@ def _map_workflow_state(state: WorkflowState) -> JobState:
         WorkflowState.TAILORING: JobState.TAILORING,
         WorkflowState.ATS_OPTIMIZATION: JobState.ATS_OPTIMIZATION,
         WorkflowState.AUDITING: JobState.AUDITING,
+        WorkflowState.CLAIM_VERIFICATION: JobState.AUDITING,  # part of the audit phase
+        WorkflowState.ATS_LINT: JobState.AUDITING,
+        WorkflowState.CHRONOLOGY_CHECK: JobState.AUDITING,
+        WorkflowState.ATS_PARSE_CHECK: JobState.AUDITING,
         WorkflowState.EXECUTIVE_SYNTHESIS: JobState.EXECUTIVE_SYNTHESIS,
         WorkflowState.COMPLETED: JobState.COMPLETED,
         WorkflowState.FAILED: JobState.FAILED,
@@ -154,11 +218,93 @@ def _map_workflow_state(state: WorkflowState) -> JobState:
     return mapping.get(state, JobState.INITIALIZED)
 
 
-def _run_workflow_sync(job: Job) -> None:
+# Why a live run was asked to stop, by job id: JobState.PAUSED or JobState.CANCELLED.
+# A run that stops without one was drained, and stays ``interrupted``.
+_stop_requests: dict[str, JobState] = {}
+_stop_lock = threading.Lock()
+
+PAUSE_REASON = "paused on request"
+CANCEL_REASON = "cancelled on request"
+LEASE_LOST_REASON = "taken over by another server instance"
+
+
+def request_stop(job_id: str, state: JobState) -> bool:
+    """Ask the job's live run to stop; False if it has none.
+
+    ``JobState.PAUSED`` stops it at the next stage boundary. ``JobState.CANCELLED``
+    abandons the stage under way in process; a worker container is stopped at the
+    next boundary either way (SIGTERM), and this server settles the job once the
+    worker has checkpointed it. A run another server instance holds the lease on is
+    asked through the job row, and that instance stops it (see services/leases.py).
+    """
+    workflow = drain.workflow_for(job_id)
+    if workflow is None and leases.fence(job_id) is None:
+        if leases.request_stop(job_id, state.value):
+            return True
+    backend = backend_from_env() if workflow is None else None
+    if workflow is None and (backend is None or not backend.running(job_id)):
+        return False
+    with _stop_lock:
+        _stop_requests[job_id] = state
+    if workflow is None:
+        backend.stop(job_id)
+    elif state == JobState.CANCELLED:
+        workflow.cancel(CANCEL_REASON)
+    else:
+        workflow.stop_after_stage(PAUSE_REASON)
+    return True
+
+
+def _take_stop_request(job_id: str) -> Optional[JobState]:
+    with _stop_lock:
+        return _stop_requests.pop(job_id, None)
+
+
+def _job_state(result, job_id: Optional[str] = None) -> JobState:
+    """API state for a finished ``workflow.execute``.
+
+    A stopped run is ``paused`` or ``cancelled`` if ``request_stop`` asked for it,
+    else ``interrupted`` (by a drain). Any request left over is cleared.
+    """
+    requested = _take_stop_request(job_id) if job_id else None
+    if getattr(result, "status", None) == RunStatus.INTERRUPTED:
+        return requested or JobState.INTERRUPTED
+    return _map_workflow_state(result.state)
+
+
+def _awaiting_for(state: JobState) -> Optional[str]:
+    """Human input a job in ``state`` is waiting for, or None if it is not paused."""
+    if state == JobState.GAP_ANALYSIS_REVIEW:
+        return AwaitingInput.GREENLIGHT.value
+    if state == JobState.INTERROGATION_REVIEW:
+        return AwaitingInput.INTERVIEW_ANSWERS.value
+    return None
+
+
+def _persist_progress(job: Job, workflow: HydraWorkflow) -> None:
+    """Write a running workflow's state, log and finished stages to the job row."""
+    job.state = _map_workflow_state(workflow.get_current_state())
+    job.execution_log = workflow.get_execution_log()
+    job.intermediate_results = {**job.intermediate_results, **workflow.get_intermediate_results()}
+    job.agent_models = dict(workflow.agent_models)
+    job_queue.update_job(job.id)
+    _save_state(job)
+
+
+def _record_audit_events(job: Job, result) -> None:
+    """Add the run's provider calls to the audit log, on behalf of the job's owner."""
+    events = getattr(result, "audit_events", None)
+    if events:
+        audit_log.record_events(job.id, events, actor=job.owner)
+
+
+def _run_workflow_sync(job: Job, progress_every: Optional[float] = None) -> None:
     """
     Run HydraWorkflow synchronously (called in thread pool).
 
-    This is the blocking function that actually executes the workflow.
+    This is the blocking function that actually executes the workflow. With
+    ``progress_every`` the job row is also updated that often while the run is in
+    flight, for a worker in another process whose server watches the store.
     """
     try:
         job.started_at = datetime.now()
@@ -168,7 +314,14 @@ def _run_workflow_sync(job: Job) -> None:
         llm = get_llm_client(model=job.model)
 
         # Create workflow
-        workflow = HydraWorkflow(llm, max_audit_retries=job.max_audit_retries)
+        workflow = HydraWorkflow(
+            llm,
+            max_audit_retries=job.max_audit_retries,
+            retention=policy_from_env(),
+            model_routing=ModelRouting().overrides(),
+            redact_pii=redaction_enabled(),
+            prompt_pack=AUTO_PACK,
+        )
 
         # Build context
         context = {
@@ -176,15 +329,38 @@ def _run_workflow_sync(job: Job) -> None:
             "resume": job.resume,
             "source_documents": job.source_documents,
             "previous_results": job.intermediate_results,
+            "state_version": job.state_version,
             "gap_analysis_approved": job.gap_analysis_approved,
+            "greenlight_notes": job.greenlight_notes,
             "interview_answers": job.interview_answers,
         }
 
         # Execute workflow
-        result = workflow.execute(context)
+        stop_reporting = threading.Event()
+        if progress_every:
+
+            def _report() -> None:
+                while not stop_reporting.wait(progress_every):
+                    try:
+                        _persist_progress(job, workflow)
+                    except Exception as e:
+                        logger.warning(f"Job {job.id} progress not saved: {e}")
+
+            reporter = threading.Thread(target=_report, daemon=True)
+            reporter.start()
+        drain.register(job.id, workflow)
+        try:
+            result = workflow.execute(context)
+        finally:
+            drain.unregister(job.id)
+            job.cost_usd += _run_cost(workflow)
+            stop_reporting.set()
+            if progress_every:
+                reporter.join()
+        _record_audit_events(job, result)
 
         # Update job with results
-        job.state = _map_workflow_state(result.state)
+        job.state = _job_state(result, job.id)
         job.success = result.success
         job.final_documents = result.final_documents
         job.audit_report = result.audit_report
@@ -197,8 +373,16 @@ def _run_workflow_sync(job: Job) -> None:
         job.audit_failed = getattr(result, "audit_failed", False)
         job.audit_error = getattr(result, "audit_error", None)
         job.agent_models = result.agent_models or {}
+        job.awaiting_user = _awaiting_for(job.state)
 
-        if job.state not in (JobState.GAP_ANALYSIS_REVIEW, JobState.INTERROGATION_REVIEW):
+        if job.state in (JobState.INTERRUPTED, JobState.PAUSED):
+            # Checkpointed for resuming: not complete, no outcome yet.
+            job_queue.update_job(job.id)
+            _save_state(job)
+            logger.info(f"Job {job.id} {job.state.value}; resumable")
+            return
+
+        if job.awaiting_user is None:
             job.completed_at = datetime.now()
 
         _ensure_hydra_records(job)
@@ -210,6 +394,7 @@ def _run_workflow_sync(job: Job) -> None:
     except Exception as e:
         logger.error(f"Job {job.id} failed with exception: {e}")
         job.state = JobState.FAILED
+        job.awaiting_user = None
         job.success = False
         job.completed_at = datetime.now()
         job.error_message = str(e)
@@ -264,6 +449,14 @@ async def run_workflow_async(job: Job) -> None:
 
     This runs the sync workflow in a thread pool while polling for state changes.
     """
+    if drain.draining:
+        # Scheduled (e.g. by a greenlight) after the drain began: leave it for the
+        # next server, exactly as if it had been interrupted before its first stage.
+        job.state = JobState.INTERRUPTED
+        job.awaiting_user = None
+        job_queue.update_job(job.id)
+        return
+
     job.started_at = datetime.now()
     job.state = JobState.INITIALIZED
     job_queue.update_job(job.id, started_at=job.started_at, state=job.state)
@@ -282,10 +475,25 @@ async def run_workflow_async(job: Job) -> None:
         llm = get_llm_client(model=job.model)
         
         # Create workflow
-        workflow = HydraWorkflow(llm, max_audit_retries=job.max_audit_retries)
+        workflow = HydraWorkflow(
+            llm,
+            max_audit_retries=job.max_audit_retries,
+            retention=policy_from_env(),
+            model_routing=ModelRouting().overrides(),
+            redact_pii=redaction_enabled(),
+            prompt_pack=AUTO_PACK,
+        )
         
-        # Store agent_models immediately so it's available
-        job.agent_models = workflow.agent_models
+        # Model output as it arrives, from the workflow thread to the event loop.
+        workflow.output_listeners.append(
+            lambda stage, text: asyncio.run_coroutine_threadsafe(
+                job.emit_event("partial", {"stage": stage, "text": text}), loop
+            )
+        )
+
+        # Store agent_models immediately so it's available. Always a copy: the workflow
+        # thread updates its own dict when a stage falls back to another model.
+        job.agent_models = dict(workflow.agent_models)
         _ensure_hydra_records(job)
 
         # Build context (include previous results for resuming)
@@ -294,14 +502,21 @@ async def run_workflow_async(job: Job) -> None:
             "resume": job.resume,
             "source_documents": job.source_documents,
             "previous_results": job.intermediate_results,
+            "state_version": job.state_version,
             "gap_analysis_approved": job.gap_analysis_approved,
+            "greenlight_notes": job.greenlight_notes,
             "interview_answers": job.interview_answers,
         }
 
         # Create a future for the workflow execution
         def run_workflow():
-            return workflow.execute(context)
+            try:
+                return workflow.execute(context)
+            finally:
+                drain.unregister(job.id)
+                job.cost_usd += _run_cost(workflow)
 
+        drain.register(job.id, workflow)
         future = loop.run_in_executor(_executor, run_workflow)
 
         # Poll for state changes while workflow runs
@@ -319,6 +534,7 @@ async def run_workflow_async(job: Job) -> None:
             if current_state != last_state:
                 last_state = current_state
                 job.state = current_state
+                job.agent_models = dict(workflow.agent_models)
                 await job.emit_event("progress", {
                     "state": current_state.value,
                     "progress": job.get_progress_percent(),
@@ -347,9 +563,10 @@ async def run_workflow_async(job: Job) -> None:
 
         # Get the result from the future
         result = future.result()
+        _record_audit_events(job, result)
 
         # Update job with results
-        job.state = _map_workflow_state(result.state)
+        job.state = _job_state(result, job.id)
         job.success = result.success
         job.final_documents = result.final_documents
         job.audit_report = result.audit_report
@@ -373,6 +590,8 @@ async def run_workflow_async(job: Job) -> None:
         job.audit_failed = getattr(result, "audit_failed", False)
         job.audit_error = getattr(result, "audit_error", None)
         job.agent_models = result.agent_models or {}
+        # Persisted with the job so the second half can resume after a restart.
+        job.awaiting_user = _awaiting_for(job.state)
 
         logger.info(f"Job {job.id} completed: success={job.success}, state={job.state}")
 
@@ -385,8 +604,13 @@ async def run_workflow_async(job: Job) -> None:
         })
 
         # Pause states are not terminal: keep SSE stream alive and do not mark completed.
-        if job.state in (JobState.GAP_ANALYSIS_REVIEW, JobState.INTERROGATION_REVIEW):
+        # Neither is a drained run, which the next server resumes from its checkpoint,
+        # nor one paused on request.
+        if job.awaiting_user is not None or job.state in (JobState.INTERRUPTED, JobState.PAUSED):
             job_queue.update_job(job.id)
+            _save_state(job)
+            if job.awaiting_user is not None:
+                await job.emit_event("awaiting_user", job.get_awaiting_event_payload())
             return
 
         # Terminal-ish: mark completion and emit completion event.
@@ -395,9 +619,14 @@ async def run_workflow_async(job: Job) -> None:
         job_queue.update_job(job.id)
         await job.emit_event("complete", job.get_complete_event_payload())
 
+    except LeaseLostError as e:
+        # Another instance owns the job now; its writes are the ones that count.
+        logger.warning(f"Job {job.id} abandoned: {e}")
+
     except Exception as e:
         logger.error(f"Async workflow failed for job {job.id}: {e}")
         job.state = JobState.FAILED
+        job.awaiting_user = None
         job.error_message = str(e)
         job.completed_at = datetime.now()
 
@@ -421,10 +650,245 @@ async def run_workflow_async(job: Job) -> None:
         await job.emit_event("error", error_payload)
 
 
+# A worker container stopped mid-run (node drain, eviction) is relaunched from its
+# checkpoint this many times before the job is left interrupted.
+MAX_WORKER_RELAUNCHES = 3
+
+
+async def run_workflow_in_container(job: Job, backend: ContainerBackend) -> None:
+    """
+    Run a job's workflow in a worker container and relay its progress.
+
+    The worker writes to the job row (see services/execution.py); this only
+    launches it and watches the row.
+    """
+    for attempt in range(MAX_WORKER_RELAUNCHES + 1):
+        if drain.draining:
+            job.state = JobState.INTERRUPTED
+            job.awaiting_user = None
+            job_queue.update_job(job.id)
+            return
+
+        job.started_at = datetime.now()
+        job.state = JobState.INITIALIZED
+        job.awaiting_user = None
+        job.error_message = None
+        job_queue.update_job(job.id)
+        await job.emit_event("started", {
+            "job_id": job.id,
+            "state": job.state.value,
+            "agent_models": job.agent_models,
+        })
+
+        try:
+            await asyncio.to_thread(backend.launch, job.id)
+        except ExecutionBackendError as e:
+            logger.error(f"Could not start a worker for job {job.id}: {e}")
+            job.state = JobState.FAILED
+            job.awaiting_user = None
+            job.success = False
+            job.error_message = str(e)
+            job.completed_at = datetime.now()
+            job_queue.update_job(job.id)
+            error_payload = build_error_payload_from_exception(job_id=job.id, error=e)
+            await job.emit_event("error", error_payload)
+            return
+
+        logger.info(f"Job {job.id} running in {backend.name} worker (attempt {attempt + 1})")
+        await watch_job(job, backend)
+        job = job_queue.get_job(job.id) or job
+        if job.state != JobState.INTERRUPTED:
+            _take_stop_request(job.id)
+            return
+        requested = _take_stop_request(job.id)
+        if requested is not None:
+            # Stopped by request_stop rather than a node drain: settle, do not relaunch.
+            await settle_stopped(job, requested)
+            return
+        # The worker checkpointed at a stage boundary: resume it in a fresh container.
+
+
+async def settle_stopped(job: Job, state: JobState) -> Job:
+    """Record a job with no live run as PAUSED (resumable) or CANCELLED (final)."""
+    if state == JobState.PAUSED:
+        return job_queue.update_job(job.id, state=JobState.PAUSED, awaiting_user=None)
+    job = job_queue.update_job(
+        job.id,
+        state=JobState.CANCELLED,
+        success=False,
+        awaiting_user=None,
+        completed_at=datetime.now(),
+        error_message="Cancelled on request",
+    )
+    await job.emit_event("complete", job.get_complete_event_payload())
+    return job
+
+
+async def _run_leased(job: Job, run) -> None:
+    """Await ``run`` while renewing the job's lease; release it when the run returns.
+
+    Should the lease be lost, the run is abandoned: an in-process workflow is
+    cancelled; a worker container is left to the instance that took over, whose
+    launch replaces it.
+    """
+    task = asyncio.ensure_future(run)
+
+    def _lost() -> None:
+        workflow = drain.workflow_for(job.id)
+        if workflow is not None:
+            workflow.cancel(LEASE_LOST_REASON)
+        task.cancel()
+
+    def _stop(state: str) -> None:
+        request_stop(job.id, JobState(state))
+
+    heartbeat = asyncio.create_task(leases.keep(job.id, _stop, _lost))
+    try:
+        await task
+    except LeaseLostError as e:
+        logger.warning(f"Job {job.id} abandoned: {e}")
+    except asyncio.CancelledError:
+        if not heartbeat.done():
+            raise
+        logger.warning(f"Job {job.id} abandoned: {LEASE_LOST_REASON}")
+    finally:
+        heartbeat.cancel()
+        await asyncio.to_thread(leases.release, job.id)
+
+
+# States a job has no run in flight or due in: finished, or paused on request. An
+# expired lease on such a job was merely never released, and a queued one is skipped.
+_AT_REST = (JobState.COMPLETED, JobState.FAILED, JobState.CANCELLED, JobState.PAUSED)
+
+
+async def run_job(job_id: str, detached: bool = False) -> bool:
+    """Run a job's workflow under its lease; False if it was not run.
+
+    A job is skipped if it is at rest or waiting for the user (a pause or cancel can
+    overtake a queued job), or if another instance holds its lease (a job delivered
+    twice). The run is in a worker container when ``HYDRA_EXECUTION_BACKEND`` names
+    one, else in process; ``detached`` is for a queue worker, which has no SSE
+    listeners and writes its progress to the job row for the API server instead.
+    """
+    job = await asyncio.to_thread(job_queue.refresh_job, job_id)
+    if job is None or job.state in _AT_REST or job.awaiting_user is not None:
+        logger.info(f"Job {job_id} has no run due; skipped")
+        return False
+    if not await asyncio.to_thread(leases.acquire, job_id):
+        logger.info(f"Job {job_id} is running on {leases.holder(job_id)}; not started here")
+        return False
+    backend = backend_from_env()
+    if backend is not None:
+        run = run_workflow_in_container(job, backend)
+    elif detached:
+        run = asyncio.to_thread(_run_workflow_sync, job, PROGRESS_SECONDS)
+    else:
+        run = run_workflow_async(job)
+    await _run_leased(job, run)
+    return True
+
+
+# This process's work queue and workers, from ``start_workers`` (the app at startup,
+# or a queue worker process) until ``stop_workers``.
+_pool: Optional[WorkerPool] = None
+
+
+def start_workers(size: Optional[int] = None, detached: bool = False) -> WorkerPool:
+    """Open the work queue (services/work_queue.py) and start ``size`` workers on it
+    (``HYDRA_WORKERS`` by default); WorkQueueError if either is misconfigured."""
+    global _pool
+    size = workers_from_env() if size is None else size
+
+    async def _work(job_id: str) -> None:
+        await run_job(job_id, detached)
+        job = job_queue.get_job(job_id)
+        if drain.draining and pool.queue.shared and job and job.state == JobState.INTERRUPTED:
+            # Checkpointed for this worker's shutdown: another worker resumes it now.
+            job_queue.update_job(job_id, state=JobState.INITIALIZED, error_message=None)
+            await pool.queue.put(job_id)
+
+    queue = work_queue_from_env()
+    if not size and not queue.shared:
+        raise WorkQueueError(
+            f"{WORKERS_ENV}=0 needs a shared {WORK_QUEUE_ENV}: nothing would run the jobs"
+        )
+    pool = _pool = WorkerPool(queue, size, _work)
+    pool.start()
+    return pool
+
+
+async def stop_workers() -> None:
+    """Stop this process's workers and close the work queue."""
+    global _pool
+    if _pool is not None:
+        pool, _pool = _pool, None
+        await pool.stop()
+
+
+async def _follow_queued(job: Job) -> None:
+    """Relay the progress of a job a queue worker elsewhere runs, from the job row."""
+    workers = QueueWorkers()
+    for _ in range(MAX_WORKER_RELAUNCHES + 1):
+        await watch_job(job, workers)
+        job = job_queue.get_job(job.id) or job
+        if job.state != JobState.INTERRUPTED or drain.draining:
+            return
+        # Drained with its worker and queued again: follow the next one.
+
+
+async def _queue_job(job: Job, pool: WorkerPool) -> None:
+    try:
+        await pool.queue.put(job.id)
+    except Exception as e:
+        logger.error(f"Could not queue job {job.id}: {e}")
+        job = job_queue.update_job(
+            job.id,
+            state=JobState.FAILED,
+            success=False,
+            awaiting_user=None,
+            completed_at=datetime.now(),
+            error_message=f"Could not queue the job: {e}",
+        )
+        await job.emit_event("error", build_error_payload_from_exception(job_id=job.id, error=e))
+        return
+    if not pool.size:
+        await _follow_queued(job)
+
+
 def start_workflow_background(job: Job) -> None:
     """
     Start workflow execution in background.
 
-    This schedules the async workflow to run without blocking.
+    The job goes on the work queue and a worker runs it (see ``run_job``), here or
+    in a queue worker process. Without workers started (a tool or test calling
+    this outside the app) the job runs in process at once, as if dequeued.
+    """
+    if _pool is None:
+        asyncio.create_task(run_job(job.id))
+    else:
+        asyncio.create_task(_queue_job(job, _pool))
+
+
+def resume_interrupted_jobs() -> list[str]:
+    """Restart every job a drained server checkpointed; their ids.
+
+    Each resumes from its persisted intermediate results, so the stages completed
+    before the drain are not run again. So does every job orphaned by a server
+    instance that died mid-run (its lease expired); a job another instance is
+    already resuming is left to it.
     """
-    asyncio.create_task(run_workflow_async(job))
+    resumed = []
+    orphaned = [
+        job
+        for job in map(job_queue.get_job, leases.orphaned())
+        if job and job.state not in _AT_REST and job.awaiting_user is None
+    ]
+    for job in job_queue.list_jobs_in_state(JobState.INTERRUPTED) + orphaned:
+        if job.id in resumed or leases.holder(job.id):
+            continue
+        job = job_queue.update_job(job.id, state=JobState.INITIALIZED, error_message=None)
+        start_workflow_background(job)
+        resumed.append(job.id)
+    if resumed:
+        logger.info(f"Resuming {len(resumed)} job(s) interrupted by a drain or orphaned")
+    return resumed
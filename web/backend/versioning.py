@@ -0,0 +1,94 @@
+"""REST API versioning: ``/api/v1`` is the API, the unversioned ``/api`` paths an alias.
+
+Every endpoint is served under ``/api/v1/...``. The original unversioned paths
+(``/api/jobs``, ...) still work — the browser extension, older web UI builds and
+third-party integrations call them — but they are the same v1 handlers, mounted a
+second time, and are deprecated:
+
+- Every ``/api`` response carries ``API-Version: v1``.
+- Responses on an unversioned path also carry ``Deprecation`` (RFC 9745), ``Sunset``
+  (RFC 8594) and a ``Link`` to the versioned path with ``rel="successor-version"``.
+  The first legacy call to each resource is logged, to find the clients still on it.
+
+A breaking change goes in a new version (``/api/v2``) served next to v1, never into
+v1 itself. ``/health`` is infrastructure, not API, and stays unversioned. The gRPC
+API is versioned by its package (``hydra.v1``) in the same way.
+"""
+
+import logging
+import re
+from datetime import datetime, timezone
+from email.utils import format_datetime
+
+from litestar.middleware.base import MiddlewareProtocol
+from litestar.types import ASGIApp, Receive, Scope, Send
+
+logger = logging.getLogger(__name__)
+
+API_VERSION = "v1"
+API_PREFIX = f"/api/{API_VERSION}"
+LEGACY_PREFIX = "/api"
+
+# When the unversioned paths were deprecated, and when they may be removed.
+LEGACY_DEPRECATED_AT = datetime(2026, 10, 17, tzinfo=timezone.utc)
+LEGACY_SUNSET_AT = datetime(2027, 4, 30, tzinfo=timezone.utc)
+
+# /api/v1, /api/v2/jobs, ...; not /api/videos or /api/vendors.
+_VERSIONED_RE = re.compile(r"^/api/v\d+(?:/|$)")
+
+
+def is_legacy_path(path: str) -> bool:
+    """Whether ``path`` is an unversioned API path (``/api/jobs``, not ``/api/v1/jobs``)."""
+    return path.startswith(f"{LEGACY_PREFIX}/") and not _VERSIONED_RE.match(path)
+
+
+def successor_path(path: str) -> str:
+    """The versioned path serving the same endpoint as the legacy ``path``."""
+    return API_PREFIX + path[len(LEGACY_PREFIX):]
+
+
+def version_headers(path: str) -> list[tuple[bytes, bytes]]:
+    """The versioning headers for a response on ``path``; [] outside the API."""
+    if path != LEGACY_PREFIX and not path.startswith(f"{LEGACY_PREFIX}/"):
+        return []
+    headers = [(b"api-version", API_VERSION.encode())]
+    if is_legacy_path(path):
+        deprecated = int(LEGACY_DEPRECATED_AT.timestamp())
+        headers += [
+            (b"deprecation", f"@{deprecated}".encode()),
+            (b"sunset", format_datetime(LEGACY_SUNSET_AT, usegmt=True).encode()),
+            (b"link", f'<{successor_path(path)}>; rel="successor-version"'.encode()),
+        ]
+    return headers
+
+
+class ApiVersionMiddleware(MiddlewareProtocol):
+    """ASGI middleware adding the versioning headers to API responses."""
+
+    def __init__(self, app: ASGIApp) -> None:
+        self.app = app
+        self._legacy_seen: set[str] = set()
+
+    async def __call__(self, scope: Scope, receive: Receive, send: Send) -> None:
+        path = scope.get("path", "/") if scope["type"] == "http" else ""
+        headers = version_headers(path)
+        if not headers:
+            await self.app(scope, receive, send)
+            return
+
+        resource = "/".join(path.split("/")[:3])  # /api/jobs/<id>/stream -> /api/jobs
+        if is_legacy_path(path) and resource not in self._legacy_seen:
+            self._legacy_seen.add(resource)
+            logger.info(
+                "Deprecated API path %s %s called; use %s",
+                scope.get("method", ""),
+                path,
+                successor_path(path),
+            )
+
+        async def send_wrapper(message):
+            if message["type"] == "http.response.start":
+                message["headers"] = [*message.get("headers", []), *headers]
+            await send(message)
+
+        await self.app(scope, receive, send_wrapper)
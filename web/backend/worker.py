@@ -0,0 +1,61 @@
+"""Worker entrypoint: run one job's workflow, in a container of its own.
+
+    python -m web.backend.worker <job_id>
+
+Started by a container execution backend (services/execution.py), never by hand.
+The job is read from and written back to Postgres — the API server watching the
+row relays its progress to clients. SIGTERM checkpoints the run at the next stage
+boundary (job state ``interrupted``) so the server can resume it in a new worker.
+
+Exit status: 0 when the run ended (completed, paused for input or interrupted),
+1 when it failed, 2 when the job does not exist.
+"""
+
+import argparse
+import logging
+import signal
+import sys
+import threading
+from pathlib import Path
+
+from dotenv import load_dotenv
+
+load_dotenv(Path(__file__).parent.parent.parent / ".env")
+
+from web.backend.models import JobState  # noqa: E402
+from web.backend.services.drain import POLL_SECONDS, drain  # noqa: E402
+from web.backend.services.job_queue import job_queue  # noqa: E402
+from web.backend.services.workflow_runner import (  # noqa: E402
+    PROGRESS_SECONDS,
+    _run_workflow_sync,
+)
+
+logger = logging.getLogger(__name__)
+
+
+def main(argv=None) -> int:
+    parser = argparse.ArgumentParser(description="Run one Hydra job's workflow")
+    parser.add_argument("job_id")
+    args = parser.parse_args(argv)
+    logging.basicConfig(level=logging.INFO, format="%(asctime)s - %(levelname)s - %(message)s")
+
+    job = job_queue.get_job(args.job_id)
+    if job is None:
+        logger.error(f"Job {args.job_id} not found")
+        return 2
+
+    signal.signal(signal.SIGTERM, lambda signum, frame: drain.begin())
+    run = threading.Thread(target=_run_workflow_sync, args=(job, PROGRESS_SECONDS))
+    run.start()
+    while run.is_alive():
+        run.join(POLL_SECONDS)
+        if drain.draining:
+            # Re-signal: a workflow registered after SIGTERM must stop too.
+            drain.begin()
+
+    logger.info(f"Job {job.id} worker done: state={job.state.value}")
+    return 1 if job.state == JobState.FAILED else 0
+
+
+if __name__ == "__main__":
+    sys.exit(main())
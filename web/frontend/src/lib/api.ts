@@ -10,7 +10,7 @@ const BACKEND_URL = import.meta.env.BACKEND_URL || 'http://localhost:8000';
  * Create a new job.
  */
 export async function createJob(request: CreateJobRequest): Promise<CreateJobResponse> {
-  const response = await fetch(`${BACKEND_URL}/api/jobs`, {
+  const response = await fetch(`${BACKEND_URL}/api/v1/jobs`, {
     method: 'POST',
     headers: {
       'Content-Type': 'application/json',
@@ -30,7 +30,7 @@ export async function createJob(request: CreateJobRequest): Promise<CreateJobRes
  * Get job status and results.
  */
 export async function getJob(jobId: string): Promise<Job> {
-  const response = await fetch(`${BACKEND_URL}/api/jobs/${jobId}`);
+  const response = await fetch(`${BACKEND_URL}/api/v1/jobs/${jobId}`);
 
   if (!response.ok) {
     if (response.status === 404) {
@@ -46,5 +46,5 @@ export async function getJob(jobId: string): Promise<Job> {
  * Create an EventSource for streaming job progress.
  */
 export function createJobStream(jobId: string): EventSource {
-  return new EventSource(`${BACKEND_URL}/api/jobs/${jobId}/stream`);
+  return new EventSource(`${BACKEND_URL}/api/v1/jobs/${jobId}/stream`);
 }
@@ -24,7 +24,7 @@ export type HitlActionResponse = {
  * @returns Response with job_id, status, and message
  */
 export async function approveGapAnalysis(jobId: string, approved: boolean): Promise<HitlActionResponse> {
-  const response = await fetch(`${BACKEND_URL}/api/jobs/${jobId}/approve_gap_analysis`, {
+  const response = await fetch(`${BACKEND_URL}/api/v1/jobs/${jobId}/approve_gap_analysis`, {
     method: 'POST',
     headers: {
       'Content-Type': 'application/json',
@@ -40,6 +40,36 @@ export async function approveGapAnalysis(jobId: string, approved: boolean): Prom
   return response.json();
 }
 
+/**
+ * Go/no-go on the gap analysis. Approving resumes the workflow with the notes
+ * forwarded to later stages; declining stops the job. Works after a server restart.
+ *
+ * @param jobId - The job ID
+ * @param approve - Whether to proceed with this application
+ * @param notes - Optional guidance for the remaining stages
+ * @returns Response with job_id, status, and message
+ */
+export async function greenlight(
+  jobId: string,
+  approve: boolean,
+  notes?: string
+): Promise<HitlActionResponse> {
+  const response = await fetch(`${BACKEND_URL}/api/v1/jobs/${jobId}/greenlight`, {
+    method: 'POST',
+    headers: {
+      'Content-Type': 'application/json',
+    },
+    body: JSON.stringify({ approve, notes }),
+  });
+
+  if (!response.ok) {
+    const error = await response.json().catch(() => ({ detail: 'Unknown error' }));
+    throw new Error(error.detail || `HTTP ${response.status}`);
+  }
+
+  return response.json();
+}
+
 /**
  * Submit interview answers and resume workflow.
  *
@@ -51,7 +81,7 @@ export async function submitInterviewAnswers(
   jobId: string,
   answers: InterviewAnswer[]
 ): Promise<HitlActionResponse> {
-  const response = await fetch(`${BACKEND_URL}/api/jobs/${jobId}/submit_interview_answers`, {
+  const response = await fetch(`${BACKEND_URL}/api/v1/jobs/${jobId}/submit_interview_answers`, {
     method: 'POST',
     headers: {
       'Content-Type': 'application/json',
@@ -103,6 +103,16 @@ export interface GapAnalysisResult {
   matches?: string[];
   adjacent_skills?: string[];
   blockers?: string[];
+  fit?: FitAssessment;
+}
+
+// The fit score computed from the gap analysis, and its go / no-go call
+export interface FitAssessment {
+  score?: number | null;
+  recommendation?: string | null;
+  decision?: 'GO' | 'GO WITH CAUTION' | 'NO-GO' | null;
+  rationale?: string[];
+  flags?: string[];
 }
 
 // Interrogation/Interview types for HITL
@@ -147,8 +157,12 @@ export interface Job {
   audit_failed: boolean;
   audit_error?: string;
   agent_models?: Record<string, string>;
+  awaiting_user?: AwaitingInput | null;
 }
 
+// Human input a paused job is waiting for (mirrors AwaitingInput in web/backend/models.py)
+export type AwaitingInput = 'greenlight' | 'interview_answers';
+
 // SSE event types
 export interface SSEProgressEvent {
   state: JobState;
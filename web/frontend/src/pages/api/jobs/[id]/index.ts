@@ -18,7 +18,7 @@ export const GET: APIRoute = async ({ params }) => {
   }
 
   try {
-    const response = await fetch(`${BACKEND_URL}/api/jobs/${id}`);
+    const response = await fetch(`${BACKEND_URL}/api/v1/jobs/${id}`);
     const data = await response.json();
 
     return new Response(JSON.stringify(data), {
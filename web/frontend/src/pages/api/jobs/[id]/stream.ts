@@ -19,7 +19,7 @@ export const GET: APIRoute = async ({ params }) => {
 
   try {
     // Fetch SSE stream from backend
-    const response = await fetch(`${BACKEND_URL}/api/jobs/${id}/stream`, {
+    const response = await fetch(`${BACKEND_URL}/api/v1/jobs/${id}/stream`, {
       headers: {
         'Accept': 'text/event-stream',
       },
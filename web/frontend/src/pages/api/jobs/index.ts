@@ -27,7 +27,7 @@ export const POST: APIRoute = async ({ request }) => {
     }
 
     // Proxy to backend
-    const response = await fetch(`${BACKEND_URL}/api/jobs`, {
+    const response = await fetch(`${BACKEND_URL}/api/v1/jobs`, {
       method: 'POST',
       headers: {
         'Content-Type': 'application/json',
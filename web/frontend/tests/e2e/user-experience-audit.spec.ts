@@ -313,7 +313,7 @@ Platform engineer with 7 years of experience building scalable cloud infrastruct
 
         // Get final job state from API
         try {
-            const jobResponse = await page.request.get(`http://localhost:8000/api/jobs/${jobId}`);
+            const jobResponse = await page.request.get(`http://localhost:8000/api/v1/jobs/${jobId}`);
             const jobData = await jobResponse.json();
             log(`Final job state: ${jobData.state}`);
             log(`Job has results: ${!!jobData.results}`);